@@ -0,0 +1,167 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/hyperledger/firefly-signer/pkg/abi"
+	"github.com/kaleido-io/paladin/cli/internal/output"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldclient"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/query"
+	"github.com/spf13/cobra"
+)
+
+var contractABIColumns = []output.Column{
+	{Header: "ADDRESS", Path: "address"},
+	{Header: "CODE HASH", Path: "codeHash"},
+	{Header: "SOURCE", Path: "source"},
+	{Header: "ABI HASH", Path: "abiHash"},
+	{Header: "CREATED", Path: "created"},
+}
+
+var decodedTxColumns = []output.Column{
+	{Header: "HASH", Path: "transaction.hash"},
+	{Header: "BLOCK", Path: "transaction.blockNumber"},
+	{Header: "TO", Path: "transaction.to"},
+	{Header: "FUNCTION", Path: "call.signature"},
+	{Header: "CALL ERROR", Path: "callError"},
+}
+
+func newABICommand(opts *globalOptions) *cobra.Command {
+	abiCmd := &cobra.Command{
+		Use:   "abi",
+		Short: "Register contract ABIs, and decode indexed transactions with them",
+	}
+
+	var registerOpts struct {
+		address  string
+		codeHash string
+	}
+	registerCmd := &cobra.Command{
+		Use:   "register <abi-file>",
+		Short: "Register an ABI against a contract address, or against every contract deployed with the same code",
+		Long: "The file can contain either a JSON ABI array, or a compiler build artifact with an 'abi' field. " +
+			"Use '-' to read from stdin.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			input := &pldapi.ContractABIInput{}
+			var err error
+			if registerOpts.address != "" {
+				if input.Address, err = pldtypes.ParseEthAddress(registerOpts.address); err != nil {
+					return fmt.Errorf("invalid contract address '%s': %s", registerOpts.address, err)
+				}
+			}
+			if registerOpts.codeHash != "" {
+				codeHash, err := pldtypes.ParseBytes32(registerOpts.codeHash)
+				if err != nil {
+					return fmt.Errorf("invalid code hash '%s': %s", registerOpts.codeHash, err)
+				}
+				input.CodeHash = &codeHash
+			}
+			if input.ABI, err = readABIFile(cmd.InOrStdin(), args[0]); err != nil {
+				return err
+			}
+			ctx := cmd.Context()
+			c, err := opts.client(ctx)
+			if err != nil {
+				return err
+			}
+			registered, err := c.PTX().RegisterContractABI(ctx, input)
+			if err != nil {
+				return err
+			}
+			return opts.print(cmd, registered, contractABIColumns...)
+		},
+	}
+	registerCmd.Flags().StringVar(&registerOpts.address, "address", "", "Address of the contract")
+	registerCmd.Flags().StringVar(&registerOpts.codeHash, "code-hash", "", "Keccak256 hash of the deployed runtime code of the contract")
+	registerCmd.MarkFlagsOneRequired("address", "code-hash")
+	registerCmd.MarkFlagsMutuallyExclusive("address", "code-hash")
+
+	getCmd := &cobra.Command{
+		Use:   "get <address>",
+		Short: "Get the ABI the node resolves for a contract address",
+		Args:  cobra.ExactArgs(1),
+		RunE: opts.runWithClient(func(ctx context.Context, c pldclient.PaladinClient, args []string) (any, error) {
+			addr, err := pldtypes.ParseEthAddress(args[0])
+			if err != nil {
+				return nil, fmt.Errorf("invalid contract address '%s': %s", args[0], err)
+			}
+			return c.PTX().GetContractABI(ctx, *addr)
+		}, contractABIColumns...),
+	}
+
+	var limit int
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the most recent contract ABI registrations",
+		Args:  cobra.NoArgs,
+		RunE: opts.runWithClient(func(ctx context.Context, c pldclient.PaladinClient, args []string) (any, error) {
+			return c.PTX().QueryContractABIs(ctx, query.NewQueryBuilder().Sort("-created").Limit(limit).Query())
+		}, contractABIColumns...),
+	}
+	listCmd.Flags().IntVar(&limit, "limit", 25, "Maximum number of registrations to return")
+
+	var dataFormat string
+	decodeCmd := &cobra.Command{
+		Use:   "decode <transaction-hash>",
+		Short: "Decode the function call and events of an indexed base ledger transaction",
+		Args:  cobra.ExactArgs(1),
+		RunE: opts.runWithClient(func(ctx context.Context, c pldclient.PaladinClient, args []string) (any, error) {
+			txHash, err := pldtypes.ParseBytes32(args[0])
+			if err != nil {
+				return nil, fmt.Errorf("invalid transaction hash '%s': %s", args[0], err)
+			}
+			return c.PTX().DecodeIndexedTransaction(ctx, txHash, pldtypes.JSONFormatOptions(dataFormat))
+		}, decodedTxColumns...),
+	}
+	decodeCmd.Flags().StringVar(&dataFormat, "format", "", "JSON formatting options for the decoded data, such as 'mode=array&number=hex'")
+
+	abiCmd.AddCommand(registerCmd, getCmd, listCmd, decodeCmd)
+	return abiCmd
+}
+
+func readABIFile(stdin io.Reader, file string) (abi.ABI, error) {
+	var b []byte
+	var err error
+	if file == "-" {
+		b, err = io.ReadAll(stdin)
+	} else {
+		b, err = os.ReadFile(file)
+	}
+	if err != nil {
+		return nil, err
+	}
+	var a abi.ABI
+	if err := json.Unmarshal(b, &a); err == nil {
+		return a, nil
+	}
+	var artifact struct {
+		ABI abi.ABI `json:"abi"`
+	}
+	if err := json.Unmarshal(b, &artifact); err != nil || len(artifact.ABI) == 0 {
+		return nil, fmt.Errorf("file '%s' does not contain an ABI array, or a build artifact with an 'abi' field", file)
+	}
+	return artifact.ABI, nil
+}
@@ -0,0 +1,97 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContractABIs(t *testing.T) {
+	dir := t.TempDir()
+	configFile := filepath.Join(dir, "cli.yaml")
+	abiFile := filepath.Join(dir, "Store.json")
+	require.NoError(t, os.WriteFile(abiFile, []byte(`{
+		"contractName": "Store",
+		"abi": [{"type":"function","name":"set","inputs":[{"name":"value","type":"uint256"}]}]
+	}`), 0644))
+	contract := pldtypes.RandAddress()
+	txHash := pldtypes.RandBytes32()
+	url := newFakeNode(t, map[string]rpcHandler{
+		"ptx_registerContractABI": func(params []json.RawMessage) (any, string) {
+			var input pldapi.ContractABIInput
+			require.NoError(t, json.Unmarshal(params[0], &input))
+			assert.Equal(t, contract, input.Address)
+			require.Len(t, input.ABI, 1)
+			return &pldapi.ContractABI{Address: input.Address, Source: pldapi.ContractABISourceAddress}, ""
+		},
+		"ptx_getContractABI": func(params []json.RawMessage) (any, string) {
+			assert.JSONEq(t, `"`+contract.String()+`"`, string(params[0]))
+			return &pldapi.ContractABI{Address: contract, Source: pldapi.ContractABISourceCodeHash}, ""
+		},
+		"ptx_queryContractABIs": func(params []json.RawMessage) (any, string) {
+			assert.JSONEq(t, `{"limit":25,"sort":["-created"]}`, string(params[0]))
+			return []*pldapi.ContractABI{}, ""
+		},
+		"ptx_decodeIndexedTransaction": func(params []json.RawMessage) (any, string) {
+			assert.JSONEq(t, `"`+txHash.String()+`"`, string(params[0]))
+			assert.JSONEq(t, `"mode=array"`, string(params[1]))
+			return &pldapi.DecodedTransaction{
+				Transaction: &pldapi.IndexedTransaction{Hash: txHash, To: contract},
+				Call:        &pldapi.ABIDecodedData{Signature: "set(uint256)"},
+			}, ""
+		},
+	})
+
+	out, err := runCLI(t, configFile, "--url", url, "abi", "register", abiFile, "--address", contract.String())
+	require.NoError(t, err)
+	assert.Contains(t, out, contract.String())
+
+	out, err = runCLI(t, configFile, "--url", url, "abi", "get", contract.String())
+	require.NoError(t, err)
+	assert.Contains(t, out, "codeHash")
+
+	_, err = runCLI(t, configFile, "--url", url, "abi", "list")
+	require.NoError(t, err)
+
+	out, err = runCLI(t, configFile, "--url", url, "abi", "decode", txHash.String(), "--format", "mode=array")
+	require.NoError(t, err)
+	assert.Contains(t, out, "set(uint256)")
+
+	_, err = runCLI(t, configFile, "--url", url, "abi", "register", abiFile)
+	assert.Regexp(t, "address|code-hash", err)
+	_, err = runCLI(t, configFile, "--url", url, "abi", "register", abiFile, "--address", "bad")
+	assert.Regexp(t, "invalid contract address", err)
+	_, err = runCLI(t, configFile, "--url", url, "abi", "register", abiFile, "--code-hash", "bad")
+	assert.Regexp(t, "invalid code hash", err)
+	_, err = runCLI(t, configFile, "--url", url, "abi", "register", configFile+".missing", "--address", contract.String())
+	assert.Error(t, err)
+	require.NoError(t, os.WriteFile(abiFile, []byte(`{}`), 0644))
+	_, err = runCLI(t, configFile, "--url", url, "abi", "register", abiFile, "--address", contract.String())
+	assert.Regexp(t, "does not contain an ABI", err)
+	_, err = runCLI(t, configFile, "--url", url, "abi", "get", "bad")
+	assert.Regexp(t, "invalid contract address", err)
+	_, err = runCLI(t, configFile, "--url", url, "abi", "decode", "bad")
+	assert.Regexp(t, "invalid transaction hash", err)
+}
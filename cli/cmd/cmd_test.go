@@ -0,0 +1,241 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/rpcclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type rpcHandler func(params []json.RawMessage) (any, string)
+
+func newFakeNode(t *testing.T, handlers map[string]rpcHandler) string {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req rpcclient.RPCRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		params := make([]json.RawMessage, len(req.Params))
+		for i, p := range req.Params {
+			params[i] = json.RawMessage(p)
+		}
+		h, ok := handlers[req.Method]
+		require.True(t, ok, "unexpected method %s", req.Method)
+		result, errMsg := h(params)
+		res := map[string]any{"jsonrpc": "2.0", "id": req.ID}
+		if errMsg != "" {
+			res["error"] = map[string]any{"code": -32000, "message": errMsg}
+		} else {
+			res["result"] = result
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(res)
+	}))
+	t.Cleanup(server.Close)
+	return server.URL
+}
+
+func runCLI(t *testing.T, configFile string, args ...string) (string, error) {
+	cmd := NewRootCommand()
+	out := new(bytes.Buffer)
+	cmd.SetOut(out)
+	cmd.SetErr(new(bytes.Buffer))
+	cmd.SetArgs(append([]string{"--config", configFile}, args...))
+	err := cmd.Execute()
+	return out.String(), err
+}
+
+func TestProfiles(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "sub", "cli.yaml")
+
+	out, err := runCLI(t, configFile, "profile", "list")
+	require.NoError(t, err)
+	assert.Equal(t, "NAME  URL  CURRENT\n", out)
+
+	_, err = runCLI(t, configFile, "profile", "set", "node1", "--url", "http://node1:31548", "--username", "u1", "--password", "p1")
+	require.NoError(t, err)
+	_, err = runCLI(t, configFile, "profile", "set", "node2", "--url", "https://node2:31548", "--ca-file", "ca.pem", "--request-timeout", "5s")
+	require.NoError(t, err)
+
+	out, err = runCLI(t, configFile, "-o", "json", "profile", "list")
+	require.NoError(t, err)
+	assert.JSONEq(t, `[
+		{"name":"node1","url":"http://node1:31548","current":true},
+		{"name":"node2","url":"https://node2:31548","current":false}
+	]`, out)
+
+	_, err = runCLI(t, configFile, "profile", "use", "node2")
+	require.NoError(t, err)
+	_, err = runCLI(t, configFile, "profile", "set", "node1", "--use")
+	require.NoError(t, err)
+	_, err = runCLI(t, configFile, "profile", "delete", "node2")
+	require.NoError(t, err)
+
+	out, err = runCLI(t, configFile, "profile", "list")
+	require.NoError(t, err)
+	assert.Regexp(t, `node1\s+http://node1:31548\s+true`, out)
+	assert.NotContains(t, out, "node2")
+
+	info, err := os.Stat(configFile)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	_, err = runCLI(t, configFile, "profile", "use", "node2")
+	assert.Regexp(t, "profile 'node2' not found", err)
+	_, err = runCLI(t, configFile, "profile", "delete", "node2")
+	assert.Regexp(t, "profile 'node2' not found", err)
+	_, err = runCLI(t, configFile, "profile", "set", "node3")
+	assert.Regexp(t, "requires a URL", err)
+
+	_, err = runCLI(t, configFile, "profile", "delete", "node1")
+	require.NoError(t, err)
+	_, err = runCLI(t, configFile, "domain", "list")
+	assert.Regexp(t, "no node URL", err)
+	_, err = runCLI(t, configFile, "--profile", "missing", "domain", "list")
+	assert.Regexp(t, "profile 'missing' not found", err)
+}
+
+func TestBadConfigFile(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "cli.yaml")
+	require.NoError(t, os.WriteFile(configFile, []byte("profiles: [wrong]"), 0600))
+	for _, args := range [][]string{
+		{"profile", "list"},
+		{"profile", "set", "a", "--url", "http://a"},
+		{"profile", "use", "a"},
+		{"profile", "delete", "a"},
+		{"domain", "list"},
+	} {
+		_, err := runCLI(t, configFile, args...)
+		assert.Regexp(t, "invalid configuration file", err)
+	}
+}
+
+func TestDomainsAndPeers(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "cli.yaml")
+	registry := pldtypes.RandAddress()
+	contract := pldtypes.RandAddress()
+	url := newFakeNode(t, map[string]rpcHandler{
+		"domain_listDomains": func(params []json.RawMessage) (any, string) {
+			return []string{"noto"}, ""
+		},
+		"domain_getDomain": func(params []json.RawMessage) (any, string) {
+			if string(params[0]) != `"noto"` {
+				return nil, "not found"
+			}
+			return &pldapi.Domain{Name: "noto", RegistryAddress: registry}, ""
+		},
+		"domain_querySmartContracts": func(params []json.RawMessage) (any, string) {
+			assert.Contains(t, string(params[0]), registry.String())
+			return []*pldapi.DomainSmartContract{{DomainName: "noto", DomainAddress: registry, Address: *contract}}, ""
+		},
+		"transport_peers": func(params []json.RawMessage) (any, string) {
+			return []*pldapi.PeerInfo{{Name: "node2", Stats: pldapi.PeerStats{SentMsgs: 5}}}, ""
+		},
+		"transport_peerInfo": func(params []json.RawMessage) (any, string) {
+			return &pldapi.PeerInfo{Name: "node2", OutboundTransport: "grpc"}, ""
+		},
+	})
+
+	out, err := runCLI(t, configFile, "--url", url, "--debug", "domain", "list")
+	require.NoError(t, err)
+	assert.Regexp(t, `noto\s+`+registry.String(), out)
+
+	out, err = runCLI(t, configFile, "--url", url, "-o", "yaml", "domain", "get", "noto")
+	require.NoError(t, err)
+	assert.Regexp(t, "^name: noto\npaused: false\nregistryAddress: \"?"+registry.String(), out)
+
+	_, err = runCLI(t, configFile, "--url", url, "domain", "get", "pente")
+	assert.Regexp(t, "not found", err)
+
+	out, err = runCLI(t, configFile, "--url", url, "domain", "contracts", "noto")
+	require.NoError(t, err)
+	assert.Regexp(t, contract.String()+`\s+noto`, out)
+
+	_, err = runCLI(t, configFile, "--url", url, "domain", "contracts", "pente")
+	assert.Regexp(t, "not found", err)
+
+	out, err = runCLI(t, configFile, "--url", url, "peer", "list")
+	require.NoError(t, err)
+	lines := strings.Split(out, "\n")
+	assert.Regexp(t, `^NAME\s+ACTIVATED\s+SENT MSGS`, lines[0])
+	assert.Regexp(t, `^node2\s+5\s+0`, lines[1])
+
+	out, err = runCLI(t, configFile, "--url", url, "peer", "get", "node2")
+	require.NoError(t, err)
+	assert.Contains(t, out, "grpc")
+
+	_, err = runCLI(t, configFile, "--url", url, "-o", "xml", "peer", "get", "node2")
+	assert.Regexp(t, "invalid output format 'xml'", err)
+}
+
+func TestStates(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "cli.yaml")
+	schemaID := pldtypes.RandBytes32()
+	contract := pldtypes.RandAddress()
+	confirmTxHash := pldtypes.RandBytes32()
+	confirmBlock := int64(1234)
+	url := newFakeNode(t, map[string]rpcHandler{
+		"pstate_listSchemas": func(params []json.RawMessage) (any, string) {
+			return []*pldapi.Schema{{ID: schemaID, Signature: "type=Coin(uint256 amount)"}}, ""
+		},
+		"pstate_queryStates": func(params []json.RawMessage) (any, string) {
+			assert.JSONEq(t, `{"limit":100}`, string(params[2]))
+			assert.JSONEq(t, `"available"`, string(params[3]))
+			return []*pldapi.State{{
+				StateBase: pldapi.StateBase{ID: pldtypes.HexBytes("s1"), Data: pldtypes.RawJSON(`{"amount":"10"}`)},
+				Confirmed: &pldapi.StateConfirmRecord{TransactionHash: &confirmTxHash, BlockNumber: &confirmBlock},
+			}}, ""
+		},
+		"pstate_queryContractStates": func(params []json.RawMessage) (any, string) {
+			assert.JSONEq(t, `"`+contract.String()+`"`, string(params[1]))
+			assert.JSONEq(t, `{"limit":1,"sort":["created"]}`, string(params[3]))
+			assert.JSONEq(t, `"spent"`, string(params[4]))
+			return []*pldapi.State{}, ""
+		},
+	})
+
+	out, err := runCLI(t, configFile, "--url", url, "state", "schemas", "noto")
+	require.NoError(t, err)
+	assert.Contains(t, out, "type=Coin(uint256 amount)")
+
+	out, err = runCLI(t, configFile, "--url", url, "state", "query", "noto", schemaID.String())
+	require.NoError(t, err)
+	assert.Contains(t, out, `{"amount":"10"}`)
+	assert.Contains(t, out, confirmTxHash.String())
+	assert.Contains(t, out, "1234")
+
+	_, err = runCLI(t, configFile, "--url", url, "state", "query", "noto", schemaID.String(),
+		"--contract", contract.String(), "--status", "spent", "--query", `{"limit":1,"sort":["created"]}`)
+	require.NoError(t, err)
+
+	_, err = runCLI(t, configFile, "--url", url, "state", "query", "noto", "bad")
+	assert.Regexp(t, "invalid schema ID", err)
+	_, err = runCLI(t, configFile, "--url", url, "state", "query", "noto", schemaID.String(), "--contract", "bad")
+	assert.Regexp(t, "invalid contract address", err)
+	_, err = runCLI(t, configFile, "--url", url, "state", "query", "noto", schemaID.String(), "--query", "{!!!")
+	assert.Regexp(t, "invalid query", err)
+}
@@ -0,0 +1,92 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+
+	"github.com/kaleido-io/paladin/cli/internal/output"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldclient"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/query"
+	"github.com/spf13/cobra"
+)
+
+var domainColumns = []output.Column{
+	{Header: "NAME", Path: "name"},
+	{Header: "REGISTRY ADDRESS", Path: "registryAddress"},
+}
+
+func newDomainCommand(opts *globalOptions) *cobra.Command {
+	domainCmd := &cobra.Command{
+		Use:   "domain",
+		Short: "Inspect the domains configured on the node",
+	}
+
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the domains configured on the node",
+		Args:  cobra.NoArgs,
+		RunE: opts.runWithClient(func(ctx context.Context, c pldclient.PaladinClient, args []string) (any, error) {
+			names, err := c.Domain().ListDomains(ctx)
+			if err != nil {
+				return nil, err
+			}
+			domains := make([]any, len(names))
+			for i, name := range names {
+				if domains[i], err = c.Domain().GetDomain(ctx, name); err != nil {
+					return nil, err
+				}
+			}
+			return domains, nil
+		}, domainColumns...),
+	}
+
+	getCmd := &cobra.Command{
+		Use:   "get <name>",
+		Short: "Get a domain by name",
+		Args:  cobra.ExactArgs(1),
+		RunE: opts.runWithClient(func(ctx context.Context, c pldclient.PaladinClient, args []string) (any, error) {
+			return c.Domain().GetDomain(ctx, args[0])
+		}, domainColumns...),
+	}
+
+	var limit int
+	contractsCmd := &cobra.Command{
+		Use:   "contracts [domain]",
+		Short: "List the private smart contracts indexed by the node, optionally for a single domain",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: opts.runWithClient(func(ctx context.Context, c pldclient.PaladinClient, args []string) (any, error) {
+			qb := query.NewQueryBuilder().Limit(limit)
+			if len(args) == 1 {
+				// Smart contracts are indexed against the registry address of the domain
+				domain, err := c.Domain().GetDomain(ctx, args[0])
+				if err != nil {
+					return nil, err
+				}
+				qb = qb.Equal("domainAddress", domain.RegistryAddress)
+			}
+			return c.Domain().QuerySmartContracts(ctx, qb.Query())
+		},
+			output.Column{Header: "ADDRESS", Path: "address"},
+			output.Column{Header: "DOMAIN", Path: "domainName"},
+		),
+	}
+	contractsCmd.Flags().IntVar(&limit, "limit", 100, "Maximum number of smart contracts to return")
+
+	domainCmd.AddCommand(listCmd, getCmd, contractsCmd)
+	return domainCmd
+}
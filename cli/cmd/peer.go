@@ -0,0 +1,63 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+
+	"github.com/kaleido-io/paladin/cli/internal/output"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldclient"
+	"github.com/spf13/cobra"
+)
+
+var peerColumns = []output.Column{
+	{Header: "NAME", Path: "name"},
+	{Header: "ACTIVATED", Path: "activated"},
+	{Header: "SENT MSGS", Path: "stats.sentMsgs"},
+	{Header: "RECEIVED MSGS", Path: "stats.receivedMsgs"},
+	{Header: "RELIABLE HWM", Path: "stats.reliableHighestSent"},
+	{Header: "RELIABLE ACKED", Path: "stats.reliableAckBase"},
+	{Header: "TRANSPORT", Path: "outboundTransport"},
+}
+
+func newPeerCommand(opts *globalOptions) *cobra.Command {
+	peerCmd := &cobra.Command{
+		Use:   "peer",
+		Short: "Inspect the peers the node is communicating with",
+	}
+
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the active peers of the node",
+		Args:  cobra.NoArgs,
+		RunE: opts.runWithClient(func(ctx context.Context, c pldclient.PaladinClient, args []string) (any, error) {
+			return c.Transport().Peers(ctx)
+		}, peerColumns...),
+	}
+
+	getCmd := &cobra.Command{
+		Use:   "get <node>",
+		Short: "Get the details of a peer by node name",
+		Args:  cobra.ExactArgs(1),
+		RunE: opts.runWithClient(func(ctx context.Context, c pldclient.PaladinClient, args []string) (any, error) {
+			return c.Transport().PeerInfo(ctx, args[0])
+		}, peerColumns...),
+	}
+
+	peerCmd.AddCommand(listCmd, getCmd)
+	return peerCmd
+}
@@ -0,0 +1,159 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/kaleido-io/paladin/cli/internal/output"
+	"github.com/kaleido-io/paladin/cli/internal/profiles"
+	"github.com/spf13/cobra"
+)
+
+type profileListEntry struct {
+	Name    string `json:"name"`
+	URL     string `json:"url"`
+	Current bool   `json:"current"`
+}
+
+func newProfileCommand(opts *globalOptions) *cobra.Command {
+	profileCmd := &cobra.Command{
+		Use:   "profile",
+		Short: "Manage the profiles for connecting to Paladin nodes",
+	}
+
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the configured profiles",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			conf, err := opts.loadConfig()
+			if err != nil {
+				return err
+			}
+			entries := []*profileListEntry{}
+			for _, name := range conf.Names() {
+				entries = append(entries, &profileListEntry{
+					Name:    name,
+					URL:     conf.Profiles[name].URL,
+					Current: name == conf.CurrentProfile,
+				})
+			}
+			return opts.print(cmd, entries,
+				output.Column{Header: "NAME", Path: "name"},
+				output.Column{Header: "URL", Path: "url"},
+				output.Column{Header: "CURRENT", Path: "current"},
+			)
+		},
+	}
+
+	var setOpts struct {
+		url            string
+		username       string
+		password       string
+		requestTimeout string
+		caFile         string
+		use            bool
+	}
+	setCmd := &cobra.Command{
+		Use:   "set <name>",
+		Short: "Create or update a profile",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			conf, err := opts.loadConfig()
+			if err != nil {
+				return err
+			}
+			name := args[0]
+			profile := conf.Profiles[name]
+			if profile == nil {
+				profile = &profiles.Profile{}
+				conf.Profiles[name] = profile
+			}
+			flags := cmd.Flags()
+			if flags.Changed("url") {
+				profile.URL = setOpts.url
+			}
+			if flags.Changed("username") {
+				profile.Auth.Username = setOpts.username
+			}
+			if flags.Changed("password") {
+				profile.Auth.Password = setOpts.password
+			}
+			if flags.Changed("request-timeout") {
+				profile.RequestTimeout = &setOpts.requestTimeout
+			}
+			if flags.Changed("ca-file") {
+				profile.TLS.Enabled = true
+				profile.TLS.CAFile = setOpts.caFile
+			}
+			if profile.URL == "" {
+				return fmt.Errorf("profile '%s' requires a URL", name)
+			}
+			if setOpts.use || conf.CurrentProfile == "" {
+				conf.CurrentProfile = name
+			}
+			return conf.Save(opts.configFile)
+		},
+	}
+	setCmd.Flags().StringVar(&setOpts.url, "url", "", "JSON/RPC URL of the node")
+	setCmd.Flags().StringVar(&setOpts.username, "username", "", "Username for basic auth")
+	setCmd.Flags().StringVar(&setOpts.password, "password", "", "Password for basic auth")
+	setCmd.Flags().StringVar(&setOpts.requestTimeout, "request-timeout", "", "Request timeout, such as 30s")
+	setCmd.Flags().StringVar(&setOpts.caFile, "ca-file", "", "CA certificate file for verifying a TLS endpoint")
+	setCmd.Flags().BoolVar(&setOpts.use, "use", false, "Make this the current profile")
+
+	useCmd := &cobra.Command{
+		Use:   "use <name>",
+		Short: "Set the current profile",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			conf, err := opts.loadConfig()
+			if err != nil {
+				return err
+			}
+			if _, err := conf.Get(args[0]); err != nil {
+				return err
+			}
+			conf.CurrentProfile = args[0]
+			return conf.Save(opts.configFile)
+		},
+	}
+
+	deleteCmd := &cobra.Command{
+		Use:   "delete <name>",
+		Short: "Delete a profile",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			conf, err := opts.loadConfig()
+			if err != nil {
+				return err
+			}
+			if _, err := conf.Get(args[0]); err != nil {
+				return err
+			}
+			delete(conf.Profiles, args[0])
+			if conf.CurrentProfile == args[0] {
+				conf.CurrentProfile = ""
+			}
+			return conf.Save(opts.configFile)
+		},
+	}
+
+	profileCmd.AddCommand(listCmd, setCmd, useCmd, deleteCmd)
+	return profileCmd
+}
@@ -0,0 +1,119 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/kaleido-io/paladin/cli/internal/output"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldclient"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/query"
+	"github.com/spf13/cobra"
+)
+
+var receiptColumns = []output.Column{
+	{Header: "ID", Path: "id"},
+	{Header: "SEQUENCE", Path: "sequence"},
+	{Header: "SUCCESS", Path: "success"},
+	{Header: "DOMAIN", Path: "domain"},
+	{Header: "HASH", Path: "transactionHash"},
+	{Header: "BLOCK", Path: "blockNumber"},
+	{Header: "FAILURE", Path: "failureMessage"},
+}
+
+func newReceiptCommand(opts *globalOptions) *cobra.Command {
+	receiptCmd := &cobra.Command{
+		Use:   "receipt",
+		Short: "Query and export transaction receipts",
+	}
+
+	var limit int
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the most recent transaction receipts",
+		Args:  cobra.NoArgs,
+		RunE: opts.runWithClient(func(ctx context.Context, c pldclient.PaladinClient, args []string) (any, error) {
+			return c.PTX().QueryTransactionReceipts(ctx, query.NewQueryBuilder().Sort("-sequence").Limit(limit).Query())
+		}, receiptColumns...),
+	}
+	listCmd.Flags().IntVar(&limit, "limit", 25, "Maximum number of receipts to return")
+
+	var exportOpts struct {
+		file     string
+		after    uint64
+		pageSize int
+	}
+	exportCmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export transaction receipts as JSON lines, in the order they were written",
+		Long: "Pages through all receipts with a sequence greater than --after, writing one JSON object per line. " +
+			"The sequence of the last receipt written can be passed as --after to resume an export.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			c, err := opts.client(ctx)
+			if err != nil {
+				return err
+			}
+			w := cmd.OutOrStdout()
+			if exportOpts.file != "" {
+				f, err := os.Create(exportOpts.file)
+				if err != nil {
+					return err
+				}
+				defer f.Close()
+				w = f
+			}
+			count, err := exportReceipts(ctx, c, w, exportOpts.after, exportOpts.pageSize)
+			if exportOpts.file != "" {
+				fmt.Fprintf(cmd.ErrOrStderr(), "Exported %d receipts to %s\n", count, exportOpts.file)
+			}
+			return err
+		},
+	}
+	exportCmd.Flags().StringVarP(&exportOpts.file, "file", "f", "", "File to write to, instead of stdout")
+	exportCmd.Flags().Uint64Var(&exportOpts.after, "after", 0, "Only export receipts with a sequence greater than this")
+	exportCmd.Flags().IntVar(&exportOpts.pageSize, "page-size", 100, "Number of receipts to request from the node at a time")
+
+	receiptCmd.AddCommand(listCmd, exportCmd)
+	return receiptCmd
+}
+
+func exportReceipts(ctx context.Context, c pldclient.PaladinClient, w io.Writer, after uint64, pageSize int) (count int, err error) {
+	enc := json.NewEncoder(w)
+	for {
+		jq := query.NewQueryBuilder().GreaterThan("sequence", after).Sort("sequence").Limit(pageSize).Query()
+		page, err := c.PTX().QueryTransactionReceipts(ctx, jq)
+		if err != nil {
+			return count, err
+		}
+		for _, r := range page {
+			if err := enc.Encode(r); err != nil {
+				return count, err
+			}
+			after = r.Sequence
+			count++
+		}
+		if len(page) < pageSize {
+			return count, nil
+		}
+	}
+}
@@ -0,0 +1,137 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/kaleido-io/paladin/cli/internal/output"
+	"github.com/kaleido-io/paladin/cli/internal/profiles"
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldclient"
+	"github.com/spf13/cobra"
+)
+
+// globalOptions are the persistent flags shared by all commands
+type globalOptions struct {
+	configFile string
+	profile    string
+	url        string
+	output     string
+	debug      bool
+}
+
+func NewRootCommand() *cobra.Command {
+	opts := &globalOptions{}
+	rootCmd := &cobra.Command{
+		Use:   "paladin",
+		Short: "Administrative command-line tool for Paladin nodes",
+		Long: "Paladin CLI wraps the JSON/RPC API of a Paladin node, to submit and manage transactions, " +
+			"query states, and inspect the domains and peers of the node. Connection details for multiple " +
+			"nodes can be stored as named profiles.",
+		SilenceUsage: true,
+		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+			// The RPC client logs every request, which is only useful when diagnosing problems
+			if opts.debug {
+				log.SetLevel("debug")
+			} else {
+				log.SetLevel("error")
+			}
+		},
+	}
+	flags := rootCmd.PersistentFlags()
+	flags.StringVar(&opts.configFile, "config", profiles.DefaultPath(), "CLI configuration file containing the profiles")
+	flags.StringVarP(&opts.profile, "profile", "p", "", "Profile to use, instead of the current profile")
+	flags.StringVar(&opts.url, "url", "", "JSON/RPC URL of the node, overriding the URL in the profile")
+	flags.StringVarP(&opts.output, "output", "o", output.FormatTable, "Output format: table, json or yaml")
+	flags.BoolVar(&opts.debug, "debug", false, "Log the JSON/RPC requests made to the node")
+
+	rootCmd.AddCommand(
+		newProfileCommand(opts),
+		newDomainCommand(opts),
+		newPeerCommand(opts),
+		newStateCommand(opts),
+		newTxCommand(opts),
+		newReceiptCommand(opts),
+		newABICommand(opts),
+	)
+	return rootCmd
+}
+
+func Execute() int {
+	if err := NewRootCommand().Execute(); err != nil {
+		return 1
+	}
+	return 0
+}
+
+func (o *globalOptions) loadConfig() (*profiles.Config, error) {
+	return profiles.Load(o.configFile)
+}
+
+// client connects to the node selected by the --url and --profile flags, or the current profile
+func (o *globalOptions) client(ctx context.Context) (pldclient.PaladinClient, error) {
+	conf, err := o.loadConfig()
+	if err != nil {
+		return nil, err
+	}
+	profile, err := conf.Get(o.profile)
+	if err != nil {
+		return nil, err
+	}
+	httpConf := pldconf.HTTPClientConfig{}
+	if profile != nil {
+		httpConf = profile.HTTPClientConfig
+	}
+	if o.url != "" {
+		httpConf.URL = o.url
+	}
+	if httpConf.URL == "" {
+		return nil, errors.New("no node URL - use --url, or configure a profile with 'paladin profile set'")
+	}
+	return pldclient.New().HTTP(ctx, &httpConf)
+}
+
+func (o *globalOptions) print(cmd *cobra.Command, data any, columns ...output.Column) error {
+	return output.Print(cmd.OutOrStdout(), o.output, data, columns)
+}
+
+// runWithClient is a helper for the RunE of commands that call the node
+func (o *globalOptions) runWithClient(fn func(ctx context.Context, c pldclient.PaladinClient, args []string) (any, error), columns ...output.Column) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		c, err := o.client(ctx)
+		if err != nil {
+			return err
+		}
+		result, err := fn(ctx, c, args)
+		if err != nil {
+			return err
+		}
+		return o.print(cmd, result, columns...)
+	}
+}
+
+func requireOK(success bool, action string) error {
+	if !success {
+		return fmt.Errorf("%s was not successful", action)
+	}
+	return nil
+}
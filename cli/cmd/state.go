@@ -0,0 +1,101 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/kaleido-io/paladin/cli/internal/output"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldclient"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/query"
+	"github.com/spf13/cobra"
+)
+
+func newStateCommand(opts *globalOptions) *cobra.Command {
+	stateCmd := &cobra.Command{
+		Use:   "state",
+		Short: "Query the private states stored by the node",
+	}
+
+	schemasCmd := &cobra.Command{
+		Use:   "schemas <domain>",
+		Short: "List the state schemas of a domain",
+		Args:  cobra.ExactArgs(1),
+		RunE: opts.runWithClient(func(ctx context.Context, c pldclient.PaladinClient, args []string) (any, error) {
+			return c.StateStore().ListSchemas(ctx, args[0])
+		},
+			output.Column{Header: "ID", Path: "id"},
+			output.Column{Header: "TYPE", Path: "type"},
+			output.Column{Header: "SIGNATURE", Path: "signature"},
+		),
+	}
+
+	var queryOpts struct {
+		contract string
+		status   string
+		query    string
+		limit    int
+	}
+	queryCmd := &cobra.Command{
+		Use:   "query <domain> <schema>",
+		Short: "Query the states of a schema, optionally restricted to a single contract",
+		Args:  cobra.ExactArgs(2),
+		RunE: opts.runWithClient(func(ctx context.Context, c pldclient.PaladinClient, args []string) (any, error) {
+			schemaID, err := pldtypes.ParseBytes32(args[1])
+			if err != nil {
+				return nil, fmt.Errorf("invalid schema ID '%s': %s", args[1], err)
+			}
+			jq := query.NewQueryBuilder().Limit(queryOpts.limit).Query()
+			if queryOpts.query != "" {
+				jq = &query.QueryJSON{}
+				if err := json.Unmarshal([]byte(queryOpts.query), jq); err != nil {
+					return nil, fmt.Errorf("invalid query: %s", err)
+				}
+			}
+			status := pldapi.StateStatusQualifier(queryOpts.status)
+			var states []*pldapi.State
+			if queryOpts.contract != "" {
+				contractAddr, err := pldtypes.ParseEthAddress(queryOpts.contract)
+				if err != nil {
+					return nil, fmt.Errorf("invalid contract address '%s': %s", queryOpts.contract, err)
+				}
+				err = c.CallRPC(ctx, &states, "pstate_queryContractStates", args[0], contractAddr, schemaID, jq, status)
+				return states, err
+			}
+			err = c.CallRPC(ctx, &states, "pstate_queryStates", args[0], schemaID, jq, status)
+			return states, err
+		},
+			output.Column{Header: "ID", Path: "id"},
+			output.Column{Header: "CONTRACT", Path: "contractAddress"},
+			output.Column{Header: "CREATED", Path: "created"},
+			output.Column{Header: "CONFIRMED TX HASH", Path: "confirmed.transactionHash"},
+			output.Column{Header: "BLOCK", Path: "confirmed.blockNumber"},
+			output.Column{Header: "DATA", Path: "data"},
+		),
+	}
+	queryCmd.Flags().StringVar(&queryOpts.contract, "contract", "", "Only return states for this contract address")
+	queryCmd.Flags().StringVar(&queryOpts.status, "status", string(pldapi.StateStatusAvailable), "State status: available, confirmed, unconfirmed, spent or all")
+	queryCmd.Flags().StringVar(&queryOpts.query, "query", "", "JSON query, overriding --limit")
+	queryCmd.Flags().IntVar(&queryOpts.limit, "limit", 100, "Maximum number of states to return")
+
+	stateCmd.AddCommand(schemasCmd, queryCmd)
+	return stateCmd
+}
@@ -0,0 +1,310 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kaleido-io/paladin/cli/internal/output"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldclient"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/query"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+)
+
+var txColumns = []output.Column{
+	{Header: "ID", Path: "id"},
+	{Header: "TYPE", Path: "type"},
+	{Header: "DOMAIN", Path: "domain"},
+	{Header: "FROM", Path: "from"},
+	{Header: "TO", Path: "to"},
+	{Header: "FUNCTION", Path: "function"},
+	{Header: "CREATED", Path: "created"},
+}
+
+var publicTxColumns = []output.Column{
+	{Header: "LOCAL ID", Path: "localId"},
+	{Header: "FROM", Path: "from"},
+	{Header: "NONCE", Path: "nonce"},
+	{Header: "TRANSACTION", Path: "transaction"},
+	{Header: "HASH", Path: "transactionHash"},
+	{Header: "SUCCESS", Path: "success"},
+	{Header: "CREATED", Path: "created"},
+}
+
+func newTxCommand(opts *globalOptions) *cobra.Command {
+	txCmd := &cobra.Command{
+		Use:   "tx",
+		Short: "Submit and manage transactions",
+	}
+	txCmd.AddCommand(
+		newTxSubmitCommand(opts),
+		newTxGetCommand(opts),
+		newTxListCommand(opts),
+		newPublicTxCommand(opts),
+	)
+	return txCmd
+}
+
+func newTxSubmitCommand(opts *globalOptions) *cobra.Command {
+	var file string
+	var wait time.Duration
+	submitCmd := &cobra.Command{
+		Use:   "submit",
+		Short: "Submit a public or private transaction, from a JSON or YAML file",
+		Long: "Submit a transaction from a file containing the same JSON (or equivalent YAML) as the " +
+			"ptx_sendTransaction JSON/RPC method. Use '-' to read from stdin.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			tx, err := readTransactionInput(cmd.InOrStdin(), file)
+			if err != nil {
+				return err
+			}
+			ctx := cmd.Context()
+			c, err := opts.client(ctx)
+			if err != nil {
+				return err
+			}
+			txID, err := c.PTX().SendTransaction(ctx, tx)
+			if err != nil {
+				return err
+			}
+			if wait <= 0 {
+				return opts.print(cmd, map[string]any{"id": txID}, output.Column{Header: "ID", Path: "id"})
+			}
+			receipt, err := waitForReceipt(ctx, c, *txID, wait)
+			if err != nil {
+				return err
+			}
+			return opts.print(cmd, receipt, receiptColumns...)
+		},
+	}
+	submitCmd.Flags().StringVarP(&file, "file", "f", "", "File containing the transaction (required)")
+	submitCmd.Flags().DurationVar(&wait, "wait", 0, "Wait up to this duration for the receipt, such as 30s")
+	_ = submitCmd.MarkFlagRequired("file")
+	return submitCmd
+}
+
+func readTransactionInput(stdin io.Reader, file string) (*pldapi.TransactionInput, error) {
+	var b []byte
+	var err error
+	if file == "-" {
+		b, err = io.ReadAll(stdin)
+	} else {
+		b, err = os.ReadFile(file)
+	}
+	if err != nil {
+		return nil, err
+	}
+	var tx pldapi.TransactionInput
+	if err := yaml.Unmarshal(b, &tx); err != nil {
+		return nil, fmt.Errorf("invalid transaction: %s", err)
+	}
+	return &tx, nil
+}
+
+func waitForReceipt(ctx context.Context, c pldclient.PaladinClient, txID uuid.UUID, timeout time.Duration) (*pldapi.TransactionReceipt, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	ticker := time.NewTicker(pldclient.DefaultReceiptPollingInterval)
+	defer ticker.Stop()
+	for {
+		receipt, err := c.PTX().GetTransactionReceipt(ctx, txID)
+		if err != nil {
+			return nil, err
+		}
+		if receipt != nil {
+			return receipt, nil
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return nil, fmt.Errorf("timed out waiting for receipt for transaction %s", txID)
+		}
+	}
+}
+
+func newTxGetCommand(opts *globalOptions) *cobra.Command {
+	return &cobra.Command{
+		Use:   "get <id>",
+		Short: "Get a transaction by ID, including its receipt and public transactions",
+		Args:  cobra.ExactArgs(1),
+		RunE: opts.runWithClient(func(ctx context.Context, c pldclient.PaladinClient, args []string) (any, error) {
+			txID, err := uuid.Parse(args[0])
+			if err != nil {
+				return nil, fmt.Errorf("invalid transaction ID '%s': %s", args[0], err)
+			}
+			return c.PTX().GetTransactionFull(ctx, txID)
+		}, txColumns...),
+	}
+}
+
+func newTxListCommand(opts *globalOptions) *cobra.Command {
+	var limit int
+	var pending bool
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the most recent transactions",
+		Args:  cobra.NoArgs,
+		RunE: opts.runWithClient(func(ctx context.Context, c pldclient.PaladinClient, args []string) (any, error) {
+			jq := query.NewQueryBuilder().Sort("-created").Limit(limit).Query()
+			if pending {
+				var txs []*pldapi.Transaction
+				err := c.CallRPC(ctx, &txs, "ptx_queryPendingTransactions", jq, false)
+				return txs, err
+			}
+			return c.PTX().QueryTransactions(ctx, jq)
+		}, txColumns...),
+	}
+	listCmd.Flags().IntVar(&limit, "limit", 25, "Maximum number of transactions to return")
+	listCmd.Flags().BoolVar(&pending, "pending", false, "Only return transactions that do not have a receipt yet")
+	return listCmd
+}
+
+func newPublicTxCommand(opts *globalOptions) *cobra.Command {
+	publicCmd := &cobra.Command{
+		Use:   "public",
+		Short: "Manage the public transactions submitted to the base ledger",
+	}
+
+	var listOpts struct {
+		from    string
+		pending bool
+		limit   int
+	}
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the most recent public transactions",
+		Args:  cobra.NoArgs,
+		RunE: opts.runWithClient(func(ctx context.Context, c pldclient.PaladinClient, args []string) (any, error) {
+			qb := query.NewQueryBuilder().Sort("-localId").Limit(listOpts.limit)
+			if listOpts.from != "" {
+				from, err := pldtypes.ParseEthAddress(listOpts.from)
+				if err != nil {
+					return nil, fmt.Errorf("invalid from address '%s': %s", listOpts.from, err)
+				}
+				qb = qb.Equal("from", from)
+			}
+			if listOpts.pending {
+				return c.PTX().QueryPendingPublicTransactions(ctx, qb.Query())
+			}
+			return c.PTX().QueryPublicTransactions(ctx, qb.Query())
+		}, publicTxColumns...),
+	}
+	listCmd.Flags().StringVar(&listOpts.from, "from", "", "Only return transactions from this signing address")
+	listCmd.Flags().BoolVar(&listOpts.pending, "pending", false, "Only return transactions that are not yet confirmed")
+	listCmd.Flags().IntVar(&listOpts.limit, "limit", 25, "Maximum number of transactions to return")
+
+	getCmd := &cobra.Command{
+		Use:   "get <from> <nonce>",
+		Short: "Get a public transaction by signing address and nonce",
+		Args:  cobra.ExactArgs(2),
+		RunE: opts.runWithClient(func(ctx context.Context, c pldclient.PaladinClient, args []string) (any, error) {
+			from, nonce, err := parseFromAndNonce(args)
+			if err != nil {
+				return nil, err
+			}
+			return c.PTX().GetPublicTransactionByNonce(ctx, *from, nonce)
+		}, publicTxColumns...),
+	}
+
+	suspendCmd := &cobra.Command{
+		Use:   "suspend <from> <nonce>",
+		Short: "Stop submitting a pending public transaction to the base ledger, until it is resumed",
+		Args:  cobra.ExactArgs(2),
+		RunE: opts.runWithClient(func(ctx context.Context, c pldclient.PaladinClient, args []string) (any, error) {
+			from, nonce, err := parseFromAndNonce(args)
+			if err != nil {
+				return nil, err
+			}
+			success, err := c.PTX().SuspendPublicTransaction(ctx, *from, nonce)
+			if err == nil {
+				err = requireOK(success, "suspend")
+			}
+			return map[string]any{"from": from, "nonce": nonce, "suspended": true}, err
+		},
+			output.Column{Header: "FROM", Path: "from"},
+			output.Column{Header: "NONCE", Path: "nonce"},
+			output.Column{Header: "SUSPENDED", Path: "suspended"},
+		),
+	}
+
+	resumeCmd := &cobra.Command{
+		Use:   "resume <from> <nonce>",
+		Short: "Resume submitting a suspended public transaction to the base ledger",
+		Args:  cobra.ExactArgs(2),
+		RunE: opts.runWithClient(func(ctx context.Context, c pldclient.PaladinClient, args []string) (any, error) {
+			from, nonce, err := parseFromAndNonce(args)
+			if err != nil {
+				return nil, err
+			}
+			success, err := c.PTX().ResumePublicTransaction(ctx, *from, nonce)
+			if err == nil {
+				err = requireOK(success, "resume")
+			}
+			return map[string]any{"from": from, "nonce": nonce, "suspended": false}, err
+		},
+			output.Column{Header: "FROM", Path: "from"},
+			output.Column{Header: "NONCE", Path: "nonce"},
+			output.Column{Header: "SUSPENDED", Path: "suspended"},
+		),
+	}
+
+	cancelCmd := &cobra.Command{
+		Use:   "cancel <from> <nonce>",
+		Short: "Replace a pending public transaction with a no-op, so it fails as expired unless it is mined first",
+		Args:  cobra.ExactArgs(2),
+		RunE: opts.runWithClient(func(ctx context.Context, c pldclient.PaladinClient, args []string) (any, error) {
+			from, nonce, err := parseFromAndNonce(args)
+			if err != nil {
+				return nil, err
+			}
+			success, err := c.PTX().CancelPublicTransaction(ctx, *from, nonce)
+			if err == nil {
+				err = requireOK(success, "cancel")
+			}
+			return map[string]any{"from": from, "nonce": nonce, "cancelling": true}, err
+		},
+			output.Column{Header: "FROM", Path: "from"},
+			output.Column{Header: "NONCE", Path: "nonce"},
+			output.Column{Header: "CANCELLING", Path: "cancelling"},
+		),
+	}
+
+	publicCmd.AddCommand(listCmd, getCmd, suspendCmd, resumeCmd, cancelCmd)
+	return publicCmd
+}
+
+func parseFromAndNonce(args []string) (*pldtypes.EthAddress, pldtypes.HexUint64, error) {
+	from, err := pldtypes.ParseEthAddress(args[0])
+	if err != nil {
+		return nil, 0, fmt.Errorf("invalid from address '%s': %s", args[0], err)
+	}
+	nonce, err := strconv.ParseUint(args[1], 0, 64)
+	if err != nil {
+		return nil, 0, fmt.Errorf("invalid nonce '%s': %s", args[1], err)
+	}
+	return from, pldtypes.HexUint64(nonce), nil
+}
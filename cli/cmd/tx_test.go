@@ -0,0 +1,299 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/query"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTxSubmitAndGet(t *testing.T) {
+	dir := t.TempDir()
+	configFile := filepath.Join(dir, "cli.yaml")
+	txFile := filepath.Join(dir, "tx.yaml")
+	require.NoError(t, os.WriteFile(txFile, []byte(`
+type: public
+from: owner
+to: "0x05d936207f04d81a85881b72a0d17854ee8be45a"
+function: set
+abi:
+- type: function
+  name: set
+  inputs: [{name: value, type: uint256}]
+data:
+  value: 42
+`), 0644))
+
+	txID := uuid.New()
+	receiptCalls := 0
+	url := newFakeNode(t, map[string]rpcHandler{
+		"ptx_sendTransaction": func(params []json.RawMessage) (any, string) {
+			var tx pldapi.TransactionInput
+			require.NoError(t, json.Unmarshal(params[0], &tx))
+			if tx.From == "bad" {
+				return nil, "rejected"
+			}
+			assert.Equal(t, "owner", tx.From)
+			assert.Equal(t, "set", tx.Function)
+			assert.JSONEq(t, `{"value":42}`, tx.Data.String())
+			return txID, ""
+		},
+		"ptx_getTransactionReceipt": func(params []json.RawMessage) (any, string) {
+			receiptCalls++
+			if receiptCalls == 1 {
+				return nil, ""
+			}
+			return &pldapi.TransactionReceipt{ID: txID, TransactionReceiptData: pldapi.TransactionReceiptData{Success: true, Sequence: 7}}, ""
+		},
+		"ptx_getTransactionFull": func(params []json.RawMessage) (any, string) {
+			return &pldapi.TransactionFull{Transaction: &pldapi.Transaction{ID: &txID, TransactionBase: pldapi.TransactionBase{From: "owner"}}}, ""
+		},
+	})
+
+	out, err := runCLI(t, configFile, "--url", url, "tx", "submit", "-f", txFile)
+	require.NoError(t, err)
+	assert.Equal(t, "ID\n"+txID.String()+"\n", out)
+
+	out, err = runCLI(t, configFile, "--url", url, "-o", "json", "tx", "submit", "-f", txFile, "--wait", "5s")
+	require.NoError(t, err)
+	assert.Equal(t, 2, receiptCalls)
+	var receipt pldapi.TransactionReceipt
+	require.NoError(t, json.Unmarshal([]byte(out), &receipt))
+	assert.True(t, receipt.Success)
+
+	out, err = runCLI(t, configFile, "--url", url, "tx", "get", txID.String())
+	require.NoError(t, err)
+	assert.Regexp(t, txID.String()+`\s+owner`, out)
+
+	_, err = runCLI(t, configFile, "--url", url, "tx", "get", "bad")
+	assert.Regexp(t, "invalid transaction ID", err)
+
+	require.NoError(t, os.WriteFile(txFile, []byte(`{from: bad}`), 0644))
+	_, err = runCLI(t, configFile, "--url", url, "tx", "submit", "-f", txFile)
+	assert.Regexp(t, "rejected", err)
+
+	require.NoError(t, os.WriteFile(txFile, []byte(`[wrong]`), 0644))
+	_, err = runCLI(t, configFile, "--url", url, "tx", "submit", "-f", txFile)
+	assert.Regexp(t, "invalid transaction", err)
+
+	_, err = runCLI(t, configFile, "--url", url, "tx", "submit", "-f", filepath.Join(dir, "missing.yaml"))
+	assert.Error(t, err)
+
+	_, err = runCLI(t, configFile, "--url", url, "tx", "submit")
+	assert.Regexp(t, "required flag", err)
+}
+
+func TestTxSubmitStdinWaitTimeout(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "cli.yaml")
+	url := newFakeNode(t, map[string]rpcHandler{
+		"ptx_sendTransaction": func(params []json.RawMessage) (any, string) {
+			return uuid.New(), ""
+		},
+		"ptx_getTransactionReceipt": func(params []json.RawMessage) (any, string) {
+			return nil, ""
+		},
+	})
+
+	cmd := NewRootCommand()
+	cmd.SetIn(strings.NewReader(`{"type":"public","from":"owner"}`))
+	cmd.SetOut(new(strings.Builder))
+	cmd.SetErr(new(strings.Builder))
+	cmd.SetArgs([]string{"--config", configFile, "--url", url, "tx", "submit", "-f", "-", "--wait", "10ms"})
+	assert.Regexp(t, "timed out waiting for receipt", cmd.Execute())
+}
+
+func TestTxList(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "cli.yaml")
+	txID := uuid.New()
+	url := newFakeNode(t, map[string]rpcHandler{
+		"ptx_queryTransactions": func(params []json.RawMessage) (any, string) {
+			assert.JSONEq(t, `{"limit":25,"sort":["-created"]}`, string(params[0]))
+			return []*pldapi.Transaction{{ID: &txID}}, ""
+		},
+		"ptx_queryPendingTransactions": func(params []json.RawMessage) (any, string) {
+			assert.JSONEq(t, `false`, string(params[1]))
+			return []*pldapi.Transaction{}, ""
+		},
+	})
+
+	out, err := runCLI(t, configFile, "--url", url, "tx", "list")
+	require.NoError(t, err)
+	assert.Contains(t, out, txID.String())
+
+	out, err = runCLI(t, configFile, "--url", url, "tx", "list", "--pending", "--limit", "1")
+	require.NoError(t, err)
+	assert.Equal(t, 1, strings.Count(out, "\n"))
+}
+
+func TestPublicTx(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "cli.yaml")
+	from := pldtypes.RandAddress()
+	nonce := pldtypes.HexUint64(10)
+	ptx := &pldapi.PublicTxWithBinding{PublicTx: &pldapi.PublicTx{From: *from, Nonce: &nonce}}
+	suspended := false
+	url := newFakeNode(t, map[string]rpcHandler{
+		"ptx_queryPublicTransactions": func(params []json.RawMessage) (any, string) {
+			var jq query.QueryJSON
+			require.NoError(t, json.Unmarshal(params[0], &jq))
+			require.Len(t, jq.Eq, 1)
+			return []*pldapi.PublicTxWithBinding{ptx}, ""
+		},
+		"ptx_queryPendingPublicTransactions": func(params []json.RawMessage) (any, string) {
+			return []*pldapi.PublicTxWithBinding{}, ""
+		},
+		"ptx_getPublicTransactionByNonce": func(params []json.RawMessage) (any, string) {
+			assert.JSONEq(t, `"0xa"`, string(params[1]))
+			return ptx, ""
+		},
+		"ptx_suspendPublicTransaction": func(params []json.RawMessage) (any, string) {
+			suspended = true
+			return true, ""
+		},
+		"ptx_resumePublicTransaction": func(params []json.RawMessage) (any, string) {
+			if !suspended {
+				return false, ""
+			}
+			suspended = false
+			return true, ""
+		},
+		"ptx_cancelPublicTransaction": func(params []json.RawMessage) (any, string) {
+			if string(params[1]) != `"0xa"` {
+				return nil, "PD011990: Public transaction not found"
+			}
+			return true, ""
+		},
+	})
+
+	out, err := runCLI(t, configFile, "--url", url, "tx", "public", "list", "--from", from.String())
+	require.NoError(t, err)
+	assert.Regexp(t, from.String()+`\s+0xa`, out)
+
+	_, err = runCLI(t, configFile, "--url", url, "tx", "public", "list", "--pending")
+	require.NoError(t, err)
+
+	_, err = runCLI(t, configFile, "--url", url, "tx", "public", "list", "--from", "bad")
+	assert.Regexp(t, "invalid from address", err)
+
+	out, err = runCLI(t, configFile, "--url", url, "tx", "public", "get", from.String(), "0xa")
+	require.NoError(t, err)
+	assert.Regexp(t, from.String()+`\s+0xa`, out)
+
+	out, err = runCLI(t, configFile, "--url", url, "tx", "public", "suspend", from.String(), "10")
+	require.NoError(t, err)
+	assert.Regexp(t, from.String()+`\s+0xa\s+true`, out)
+	assert.True(t, suspended)
+
+	out, err = runCLI(t, configFile, "--url", url, "-o", "json", "tx", "public", "resume", from.String(), "10")
+	require.NoError(t, err)
+	assert.JSONEq(t, fmt.Sprintf(`{"from":"%s","nonce":"0xa","suspended":false}`, from), out)
+
+	_, err = runCLI(t, configFile, "--url", url, "tx", "public", "resume", from.String(), "10")
+	assert.Regexp(t, "resume was not successful", err)
+
+	out, err = runCLI(t, configFile, "--url", url, "tx", "public", "cancel", from.String(), "10")
+	require.NoError(t, err)
+	assert.Regexp(t, from.String()+`\s+0xa\s+true`, out)
+
+	_, err = runCLI(t, configFile, "--url", url, "tx", "public", "cancel", from.String(), "11")
+	assert.Regexp(t, "PD011990", err)
+
+	_, err = runCLI(t, configFile, "--url", url, "tx", "public", "cancel", "bad", "10")
+	assert.Regexp(t, "invalid from address", err)
+
+	_, err = runCLI(t, configFile, "--url", url, "tx", "public", "suspend", "bad", "10")
+	assert.Regexp(t, "invalid from address", err)
+	_, err = runCLI(t, configFile, "--url", url, "tx", "public", "resume", from.String(), "bad")
+	assert.Regexp(t, "invalid nonce", err)
+	_, err = runCLI(t, configFile, "--url", url, "tx", "public", "get", from.String(), "bad")
+	assert.Regexp(t, "invalid nonce", err)
+}
+
+func TestReceipts(t *testing.T) {
+	dir := t.TempDir()
+	configFile := filepath.Join(dir, "cli.yaml")
+
+	// 5 receipts, exported in pages of 2
+	var receipts []*pldapi.TransactionReceipt
+	for i := 1; i <= 5; i++ {
+		receipts = append(receipts, &pldapi.TransactionReceipt{ID: uuid.New(), TransactionReceiptData: pldapi.TransactionReceiptData{Sequence: uint64(i * 10)}})
+	}
+	pages := 0
+	url := newFakeNode(t, map[string]rpcHandler{
+		"ptx_queryTransactionReceipts": func(params []json.RawMessage) (any, string) {
+			var jq query.QueryJSON
+			require.NoError(t, json.Unmarshal(params[0], &jq))
+			if jq.Sort[0] == "-sequence" {
+				return receipts[:1], ""
+			}
+			pages++
+			var after int64
+			require.NoError(t, json.Unmarshal(jq.GT[0].Value, &after))
+			if after == 999 {
+				return nil, "pop"
+			}
+			page := []*pldapi.TransactionReceipt{}
+			for _, r := range receipts {
+				if r.Sequence > uint64(after) && len(page) < *jq.Limit {
+					page = append(page, r)
+				}
+			}
+			return page, ""
+		},
+	})
+
+	out, err := runCLI(t, configFile, "--url", url, "receipt", "list")
+	require.NoError(t, err)
+	assert.Regexp(t, receipts[0].ID.String()+`\s+10`, out)
+
+	out, err = runCLI(t, configFile, "--url", url, "receipt", "export", "--page-size", "2")
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	require.Len(t, lines, 5)
+	assert.Equal(t, 3, pages)
+	for i, line := range lines {
+		var r pldapi.TransactionReceipt
+		require.NoError(t, json.Unmarshal([]byte(line), &r))
+		assert.Equal(t, receipts[i].ID, r.ID)
+	}
+
+	exportFile := filepath.Join(dir, "receipts.jsonl")
+	_, err = runCLI(t, configFile, "--url", url, "receipt", "export", "--after", "30", "-f", exportFile)
+	require.NoError(t, err)
+	b, err := os.ReadFile(exportFile)
+	require.NoError(t, err)
+	assert.Equal(t, 2, strings.Count(string(b), "\n"))
+
+	_, err = runCLI(t, configFile, "--url", url, "receipt", "export", "--after", "999")
+	assert.Regexp(t, "pop", err)
+
+	_, err = runCLI(t, configFile, "--url", url, "receipt", "export", "-f", filepath.Join(dir, "missing", "file"))
+	assert.Error(t, err)
+
+	_, err = runCLI(t, configFile, "receipt", "export")
+	assert.Regexp(t, "no node URL", err)
+}
@@ -0,0 +1,135 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	FormatTable = "table"
+	FormatJSON  = "json"
+	FormatYAML  = "yaml"
+)
+
+// Column is a column in table output. Path is a dot separated path to a field in the
+// JSON representation of each row, or empty to print the whole row.
+type Column struct {
+	Header string
+	Path   string
+}
+
+// Print writes the data in the requested format. Table output is only produced for
+// the columns supplied - json and yaml always include all of the data.
+func Print(w io.Writer, format string, data any, columns []Column) error {
+	switch format {
+	case FormatJSON:
+		b, err := json.MarshalIndent(data, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(b))
+		return err
+	case FormatYAML:
+		b, err := yaml.Marshal(data)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(b)
+		return err
+	case FormatTable:
+		return printTable(w, data, columns)
+	default:
+		return fmt.Errorf("invalid output format '%s' (must be %s, %s or %s)", format, FormatTable, FormatJSON, FormatYAML)
+	}
+}
+
+func printTable(w io.Writer, data any, columns []Column) error {
+	// Work on the generic JSON representation, so the same column paths are used as in the json output
+	b, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	var generic any
+	if err := json.Unmarshal(b, &generic); err != nil {
+		return err
+	}
+	rows, isList := generic.([]any)
+	if !isList {
+		if generic == nil {
+			return nil
+		}
+		rows = []any{generic}
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	headers := make([]string, len(columns))
+	for i, c := range columns {
+		headers[i] = c.Header
+	}
+	fmt.Fprintln(tw, strings.Join(headers, "\t"))
+	for _, row := range rows {
+		cells := make([]string, len(columns))
+		for i, c := range columns {
+			cells[i] = cellValue(lookup(row, c.Path))
+		}
+		fmt.Fprintln(tw, strings.Join(cells, "\t"))
+	}
+	return tw.Flush()
+}
+
+func lookup(v any, path string) any {
+	if path == "" {
+		return v
+	}
+	for _, segment := range strings.Split(path, ".") {
+		switch typed := v.(type) {
+		case map[string]any:
+			v = typed[segment]
+		case []any:
+			i, err := strconv.Atoi(segment)
+			if err != nil || i < 0 || i >= len(typed) {
+				return nil
+			}
+			v = typed[i]
+		default:
+			return nil
+		}
+	}
+	return v
+}
+
+func cellValue(v any) string {
+	switch typed := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return typed
+	case map[string]any, []any:
+		b, _ := json.Marshal(typed)
+		return string(b)
+	default:
+		return fmt.Sprintf("%v", typed)
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package output
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrintTable(t *testing.T) {
+	data := []map[string]any{
+		{"name": "a", "nested": map[string]any{"list": []any{1, "x"}}},
+		{"name": "bb", "nested": map[string]any{"list": []any{}}, "flag": true},
+	}
+	columns := []Column{
+		{Header: "NAME", Path: "name"},
+		{Header: "FIRST", Path: "nested.list.0"},
+		{Header: "SECOND", Path: "nested.list.1"},
+		{Header: "BAD", Path: "nested.list.x"},
+		{Header: "MISSING", Path: "name.sub"},
+		{Header: "FLAG", Path: "flag"},
+		{Header: "NESTED", Path: "nested"},
+	}
+	buf := new(bytes.Buffer)
+	require.NoError(t, Print(buf, FormatTable, data, columns))
+	assert.Equal(t,
+		"NAME  FIRST  SECOND  BAD  MISSING  FLAG  NESTED\n"+
+			"a     1      x                           {\"list\":[1,\"x\"]}\n"+
+			"bb                                 true  {\"list\":[]}\n",
+		buf.String())
+
+	buf.Reset()
+	require.NoError(t, Print(buf, FormatTable, []string{"x", "y"}, []Column{{Header: "VALUE"}}))
+	assert.Equal(t, "VALUE\nx\ny\n", buf.String())
+
+	buf.Reset()
+	require.NoError(t, Print(buf, FormatTable, nil, []Column{{Header: "VALUE"}}))
+	assert.Empty(t, buf.String())
+}
+
+func TestPrintJSONAndYAML(t *testing.T) {
+	buf := new(bytes.Buffer)
+	require.NoError(t, Print(buf, FormatJSON, map[string]any{"a": 1}, nil))
+	assert.Equal(t, "{\n  \"a\": 1\n}\n", buf.String())
+
+	buf.Reset()
+	require.NoError(t, Print(buf, FormatYAML, map[string]any{"a": 1}, nil))
+	assert.Equal(t, "a: 1\n", buf.String())
+}
+
+func TestPrintErrors(t *testing.T) {
+	assert.Regexp(t, "invalid output format 'xml'", Print(new(bytes.Buffer), "xml", nil, nil))
+
+	unmarshallable := map[string]any{"fn": func() {}}
+	assert.Error(t, Print(new(bytes.Buffer), FormatJSON, unmarshallable, nil))
+	assert.Error(t, Print(new(bytes.Buffer), FormatYAML, unmarshallable, nil))
+	assert.Error(t, Print(new(bytes.Buffer), FormatTable, unmarshallable, nil))
+}
@@ -0,0 +1,112 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package profiles
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"sigs.k8s.io/yaml"
+)
+
+// ConfigEnvVar can be set to override the default location of the CLI configuration file
+const ConfigEnvVar = "PALADIN_CLI_CONFIG"
+
+// Profile holds the connection details for a Paladin node
+type Profile struct {
+	pldconf.HTTPClientConfig `json:",inline"`
+}
+
+// Config is the CLI configuration file, containing a named profile for each node
+type Config struct {
+	CurrentProfile string              `json:"currentProfile,omitempty"`
+	Profiles       map[string]*Profile `json:"profiles,omitempty"`
+}
+
+// DefaultPath returns the configuration file used when one is not specified on the command line
+func DefaultPath() string {
+	if path := os.Getenv(ConfigEnvVar); path != "" {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return filepath.Join(".paladin", "cli.yaml")
+	}
+	return filepath.Join(home, ".paladin", "cli.yaml")
+}
+
+// Load reads the configuration file. A file that does not exist yet is treated as empty.
+func Load(path string) (*Config, error) {
+	conf := &Config{}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			conf.Profiles = map[string]*Profile{}
+			return conf, nil
+		}
+		return nil, err
+	}
+	if err := yaml.Unmarshal(b, conf); err != nil {
+		return nil, fmt.Errorf("invalid configuration file '%s': %s", path, err)
+	}
+	if conf.Profiles == nil {
+		conf.Profiles = map[string]*Profile{}
+	}
+	return conf, nil
+}
+
+// Save writes the configuration file, creating the directory if required. The file
+// can contain credentials, so is only readable by the current user.
+func (c *Config) Save(path string) error {
+	b, err := yaml.Marshal(c)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	return os.WriteFile(path, b, 0600)
+}
+
+// Names returns the profile names in sorted order
+func (c *Config) Names() []string {
+	names := make([]string, 0, len(c.Profiles))
+	for name := range c.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Get returns the named profile, or the current profile if name is empty.
+// Nil is returned with no error if no name is supplied and there is no current profile.
+func (c *Config) Get(name string) (*Profile, error) {
+	if name == "" {
+		name = c.CurrentProfile
+		if name == "" {
+			return nil, nil
+		}
+	}
+	p, ok := c.Profiles[name]
+	if !ok {
+		return nil, fmt.Errorf("profile '%s' not found", name)
+	}
+	return p, nil
+}
@@ -0,0 +1,92 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package profiles
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadSave(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dir", "cli.yaml")
+
+	conf, err := Load(path)
+	require.NoError(t, err)
+	assert.Empty(t, conf.Names())
+	p, err := conf.Get("")
+	require.NoError(t, err)
+	assert.Nil(t, p)
+
+	conf.Profiles["b"] = &Profile{}
+	conf.Profiles["b"].URL = "http://b"
+	conf.Profiles["a"] = &Profile{}
+	conf.Profiles["a"].URL = "http://a"
+	conf.Profiles["a"].Auth.Username = "user"
+	conf.CurrentProfile = "b"
+	require.NoError(t, conf.Save(path))
+
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(b), "url: http://a")
+
+	conf, err = Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, conf.Names())
+	p, err = conf.Get("")
+	require.NoError(t, err)
+	assert.Equal(t, "http://b", p.URL)
+	p, err = conf.Get("a")
+	require.NoError(t, err)
+	assert.Equal(t, "user", p.Auth.Username)
+	_, err = conf.Get("c")
+	assert.Regexp(t, "profile 'c' not found", err)
+}
+
+func TestLoadErrors(t *testing.T) {
+	dir := t.TempDir()
+	_, err := Load(dir)
+	assert.Error(t, err)
+
+	path := filepath.Join(dir, "cli.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("{}"), 0600))
+	conf, err := Load(path)
+	require.NoError(t, err)
+	assert.NotNil(t, conf.Profiles)
+
+	require.NoError(t, os.WriteFile(path, []byte("currentProfile: [a]"), 0600))
+	_, err = Load(path)
+	assert.Regexp(t, "invalid configuration file", err)
+
+	// Cannot create a directory where there is a file
+	assert.Error(t, conf.Save(filepath.Join(path, "cli.yaml")))
+}
+
+func TestDefaultPath(t *testing.T) {
+	t.Setenv(ConfigEnvVar, "/tmp/custom.yaml")
+	assert.Equal(t, "/tmp/custom.yaml", DefaultPath())
+
+	t.Setenv(ConfigEnvVar, "")
+	t.Setenv("HOME", "/home/test")
+	assert.Equal(t, "/home/test/.paladin/cli.yaml", DefaultPath())
+
+	t.Setenv("HOME", "")
+	assert.Equal(t, ".paladin/cli.yaml", DefaultPath())
+}
@@ -49,6 +49,10 @@ func InitConfig(conf *pldconf.LogConfig) {
 
 	level := confutil.StringNotEmpty(conf.Level, *pldconf.LogDefaults.Level)
 	SetLevel(level)
+	resetSubsystemLevels(logrus.GetLevel())
+	for subsystem, subsystemLevel := range conf.Subsystems {
+		SetSubsystemLevel(subsystem, subsystemLevel)
+	}
 
 	output := confutil.StringNotEmpty(conf.Output, *pldconf.LogDefaults.Output)
 	switch output {
@@ -64,11 +68,11 @@ func InitConfig(conf *pldconf.LogConfig) {
 			MaxAge:     int(math.Ceil(float64(maxAgeDuration) / float64(time.Hour) / 24)), /* round up in days */
 			Compress:   confutil.Bool(conf.File.Compress, *pldconf.LogDefaults.File.Compress),
 		}
-		logrus.SetOutput(lumberjack)
+		setOutput(lumberjack)
 	case "stderr":
-		logrus.SetOutput(os.Stderr)
+		setOutput(os.Stderr)
 	case "stdout":
-		logrus.SetOutput(os.Stdout)
+		setOutput(os.Stdout)
 		fallthrough
 	default:
 	}
@@ -128,7 +132,11 @@ func loggerFromContext(ctx context.Context) *logrus.Entry {
 }
 
 func GetLevel() string {
-	switch logrus.GetLevel() {
+	return levelString(logrus.GetLevel())
+}
+
+func levelString(l logrus.Level) string {
+	switch l {
 	case logrus.ErrorLevel:
 		return "error"
 	case logrus.WarnLevel:
@@ -143,7 +151,23 @@ func GetLevel() string {
 }
 
 func SetLevel(level string) {
-	var l logrus.Level
+	l := parseLevel(level)
+	logrus.SetLevel(l)
+	propagateRootLevel(l)
+}
+
+// IsValidLevel checks the level is one of the explicitly supported strings
+// (SetLevel falls back to info for anything else)
+func IsValidLevel(level string) bool {
+	switch strings.ToLower(level) {
+	case "error", "warn", "warning", "info", "debug", "trace":
+		return true
+	default:
+		return false
+	}
+}
+
+func parseLevel(level string) (l logrus.Level) {
 	switch strings.ToLower(level) {
 	case "error":
 		l = logrus.ErrorLevel
@@ -156,7 +180,7 @@ func SetLevel(level string) {
 	default:
 		l = logrus.InfoLevel
 	}
-	logrus.SetLevel(l)
+	return l
 }
 
 type Formatting struct {
@@ -183,6 +207,7 @@ func (utc *utcFormat) Format(e *logrus.Entry) ([]byte, error) {
 
 func setFormatting(format *Formatting) {
 	var formatter logrus.Formatter
+	reportCaller := false
 	switch format.Format {
 	case "json":
 		formatter = &logrus.JSONFormatter{
@@ -203,7 +228,7 @@ func setFormatting(format *Formatting) {
 			DisableSorting:  false,
 			FullTimestamp:   true,
 		}
-		logrus.SetReportCaller(true)
+		reportCaller = true
 	case "simple":
 		fallthrough
 	default:
@@ -219,5 +244,5 @@ func setFormatting(format *Formatting) {
 	if format.UTC {
 		formatter = &utcFormat{f: formatter}
 	}
-	logrus.SetFormatter(formatter)
+	setFormatter(formatter, reportCaller)
 }
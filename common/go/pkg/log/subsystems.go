@@ -0,0 +1,184 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"context"
+	"io"
+	"os"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Standard correlation fields, so that JSON logs can be filtered consistently across components
+const (
+	FieldSubsystem = "subsystem"
+	FieldTxID      = "txID"
+	FieldDomain    = "domain"
+	FieldSigner    = "signer"
+)
+
+// Each subsystem gets its own logrus logger, so it can have an independent level.
+// The output and formatting are always shared with the root logger.
+type subsystemLogger struct {
+	logger   *logrus.Logger
+	override *logrus.Level // nil means follow the root level
+}
+
+type Levels struct {
+	Level      string            `json:"level"`
+	Subsystems map[string]string `json:"subsystems"`
+}
+
+var (
+	subsystemsLock sync.Mutex
+	subsystems     = map[string]*subsystemLogger{}
+
+	currentOutput    atomic.Pointer[io.Writer]
+	currentFormatter atomic.Pointer[logrus.Formatter]
+)
+
+type sharedOutput struct{}
+
+func (sharedOutput) Write(b []byte) (int, error) {
+	if w := currentOutput.Load(); w != nil {
+		return (*w).Write(b)
+	}
+	return os.Stderr.Write(b)
+}
+
+type sharedFormatter struct{}
+
+func (sharedFormatter) Format(e *logrus.Entry) ([]byte, error) {
+	if f := currentFormatter.Load(); f != nil {
+		return (*f).Format(e)
+	}
+	return (&logrus.TextFormatter{}).Format(e)
+}
+
+func setOutput(w io.Writer) {
+	currentOutput.Store(&w)
+	logrus.SetOutput(w)
+}
+
+func setFormatter(f logrus.Formatter, reportCaller bool) {
+	currentFormatter.Store(&f)
+	logrus.SetFormatter(f)
+	logrus.SetReportCaller(reportCaller)
+	subsystemsLock.Lock()
+	defer subsystemsLock.Unlock()
+	for _, s := range subsystems {
+		s.logger.SetReportCaller(reportCaller)
+	}
+}
+
+func getSubsystemLogger(name string) *subsystemLogger {
+	subsystemsLock.Lock()
+	defer subsystemsLock.Unlock()
+	s := subsystems[name]
+	if s == nil {
+		s = &subsystemLogger{
+			logger: &logrus.Logger{
+				Out:          sharedOutput{},
+				Formatter:    sharedFormatter{},
+				Hooks:        make(logrus.LevelHooks),
+				Level:        logrus.GetLevel(),
+				ExitFunc:     os.Exit,
+				ReportCaller: logrus.StandardLogger().ReportCaller,
+			},
+		}
+		subsystems[name] = s
+	}
+	return s
+}
+
+// WithSubsystem returns a context with a logger for the named subsystem, retaining any
+// fields already on the logger in the context. All logs written via this context are
+// subject to the level of the subsystem, rather than the root level.
+func WithSubsystem(ctx context.Context, name string) context.Context {
+	EnsureInit()
+	existing := loggerFromContext(ctx)
+	entry := logrus.NewEntry(getSubsystemLogger(name).logger).
+		WithFields(existing.Data).
+		WithField(FieldSubsystem, name)
+	return WithLogger(ctx, entry)
+}
+
+// SetSubsystemLevel sets an independent level for a subsystem. An empty level
+// reverts the subsystem to following the root level.
+func SetSubsystemLevel(name, level string) {
+	s := getSubsystemLogger(name)
+	subsystemsLock.Lock()
+	defer subsystemsLock.Unlock()
+	if level == "" {
+		s.override = nil
+		s.logger.SetLevel(logrus.GetLevel())
+	} else {
+		l := parseLevel(level)
+		s.override = &l
+		s.logger.SetLevel(l)
+	}
+}
+
+// Called whenever the root level changes, to update all subsystems without an override
+func propagateRootLevel(l logrus.Level) {
+	subsystemsLock.Lock()
+	defer subsystemsLock.Unlock()
+	for _, s := range subsystems {
+		if s.override == nil {
+			s.logger.SetLevel(l)
+		}
+	}
+}
+
+func resetSubsystemLevels(l logrus.Level) {
+	subsystemsLock.Lock()
+	defer subsystemsLock.Unlock()
+	for _, s := range subsystems {
+		s.override = nil
+		s.logger.SetLevel(l)
+	}
+}
+
+// GetLevels returns the root level, and the level of every subsystem that has been used or configured
+func GetLevels() *Levels {
+	levels := &Levels{
+		Level:      GetLevel(),
+		Subsystems: map[string]string{},
+	}
+	subsystemsLock.Lock()
+	defer subsystemsLock.Unlock()
+	for name, s := range subsystems {
+		levels.Subsystems[name] = levelString(s.logger.GetLevel())
+	}
+	return levels
+}
+
+// SubsystemNames returns the sorted list of subsystems that have been used or configured
+func SubsystemNames() []string {
+	subsystemsLock.Lock()
+	defer subsystemsLock.Unlock()
+	names := make([]string, 0, len(subsystems))
+	for name := range subsystems {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
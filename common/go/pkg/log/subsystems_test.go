@@ -0,0 +1,116 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubsystemLevels(t *testing.T) {
+	defer func() { InitConfig(&pldconf.LogConfig{}) /* reinstate defaults for other tests */ }()
+	InitConfig(&pldconf.LogConfig{
+		Level: confutil.P("info"),
+		Subsystems: map[string]string{
+			"sub1": "debug",
+		},
+	})
+
+	ctx := WithLogField(context.Background(), "myfield", "myvalue")
+	ctx1 := WithSubsystem(ctx, "sub1")
+	ctx2 := WithSubsystem(ctx, "sub2")
+	assert.Equal(t, "myvalue", L(ctx1).Data["myfield"])
+	assert.Equal(t, "sub1", L(ctx1).Data[FieldSubsystem])
+	assert.True(t, L(ctx1).Logger.IsLevelEnabled(logrus.DebugLevel))
+	assert.False(t, L(ctx2).Logger.IsLevelEnabled(logrus.DebugLevel))
+
+	// sub2 follows the root, sub1 does not
+	SetLevel("trace")
+	assert.True(t, L(ctx2).Logger.IsLevelEnabled(logrus.TraceLevel))
+	assert.False(t, L(ctx1).Logger.IsLevelEnabled(logrus.TraceLevel))
+	assert.Equal(t, &Levels{
+		Level: "trace",
+		Subsystems: map[string]string{
+			"sub1": "debug",
+			"sub2": "trace",
+		},
+	}, GetLevels())
+	assert.Equal(t, []string{"sub1", "sub2"}, SubsystemNames())
+
+	// revert sub1 to following the root
+	SetSubsystemLevel("sub1", "")
+	assert.True(t, L(ctx1).Logger.IsLevelEnabled(logrus.TraceLevel))
+	SetSubsystemLevel("sub2", "error")
+	assert.False(t, L(ctx2).Logger.IsLevelEnabled(logrus.WarnLevel))
+
+	// re-init resets the overrides
+	InitConfig(&pldconf.LogConfig{})
+	assert.Equal(t, "info", GetLevels().Subsystems["sub2"])
+}
+
+func TestSubsystemSharedOutputJSON(t *testing.T) {
+	defer func() { InitConfig(&pldconf.LogConfig{}) /* reinstate defaults for other tests */ }()
+	InitConfig(&pldconf.LogConfig{
+		Format: confutil.P("json"),
+	})
+	buff := new(bytes.Buffer)
+	setOutput(buff)
+
+	ctx := WithSubsystem(WithLogField(context.Background(), FieldTxID, "tx1"), "sub3")
+	L(ctx).Infof("hello")
+
+	var logLine map[string]any
+	require.NoError(t, json.Unmarshal(buff.Bytes(), &logLine))
+	assert.Equal(t, "hello", logLine["message"])
+	assert.Equal(t, "sub3", logLine[FieldSubsystem])
+	assert.Equal(t, "tx1", logLine[FieldTxID])
+}
+
+func TestSubsystemReportCaller(t *testing.T) {
+	defer func() { InitConfig(&pldconf.LogConfig{}) /* reinstate defaults for other tests */ }()
+	ctx := WithSubsystem(context.Background(), "sub4")
+	InitConfig(&pldconf.LogConfig{
+		Format: confutil.P("detailed"),
+	})
+	assert.True(t, L(ctx).Logger.ReportCaller)
+}
+
+func TestSubsystemDefaultOutputAndFormatter(t *testing.T) {
+	currentOutput.Store(nil)
+	currentFormatter.Store(nil)
+	defer func() { InitConfig(&pldconf.LogConfig{}) /* reinstate defaults for other tests */ }()
+	_, err := sharedOutput{}.Write([]byte{})
+	require.NoError(t, err)
+	b, err := sharedFormatter{}.Format(logrus.NewEntry(logrus.New()))
+	require.NoError(t, err)
+	assert.True(t, strings.Contains(string(b), "level="))
+}
+
+func TestIsValidLevel(t *testing.T) {
+	assert.True(t, IsValidLevel("DEBUG"))
+	assert.True(t, IsValidLevel("warning"))
+	assert.False(t, IsValidLevel("verbose"))
+}
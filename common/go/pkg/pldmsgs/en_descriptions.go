@@ -28,28 +28,65 @@ var pdm = func(key, translation string) i18n.MessageKey {
 
 // pldapi/blockindex.go
 var (
-	IndexedBlockNumber                 = pdm("IndexedBlock.number", "The block number")
-	IndexedBlockHash                   = pdm("IndexedBlock.hash", "The unique hash of the block")
-	IndexedBlockTimestamp              = pdm("IndexedBlock.timestamp", "The block timestamp")
-	IndexedTransactionHash             = pdm("IndexedTransaction.hash", "The unique hash of the transaction")
-	IndexedTransactionBlockNumber      = pdm("IndexedTransaction.blockNumber", "The block number containing this transaction")
-	IndexedTransactionTransactionIndex = pdm("IndexedTransaction.transactionIndex", "The index of the transaction within the block")
-	IndexedTransactionFrom             = pdm("IndexedTransaction.from", "The sender's Ethereum address")
-	IndexedTransactionTo               = pdm("IndexedTransaction.to", "The recipient's Ethereum address (optional)")
-	IndexedTransactionNonce            = pdm("IndexedTransaction.nonce", "The transaction nonce")
-	IndexedTransactionContractAddress  = pdm("IndexedTransaction.contractAddress", "The contract address created by this transaction (optional)")
-	IndexedTransactionResult           = pdm("IndexedTransaction.result", "The result of the transaction (optional)")
-	IndexedTransactionBlock            = pdm("IndexedTransaction.block", "The block containing this event")
-	IndexedEventBlockNumber            = pdm("IndexedEvent.blockNumber", "The block number containing this event")
-	IndexedEventTransactionIndex       = pdm("IndexedEvent.transactionIndex", "The index of the transaction within the block")
-	IndexedEventLogIndex               = pdm("IndexedEvent.logIndex", "The log index of the event")
-	IndexedEventTransactionHash        = pdm("IndexedEvent.transactionHash", "The hash of the transaction that triggered this event")
-	IndexedEventSignature              = pdm("IndexedEvent.signature", "The event signature")
-	IndexedEventTransaction            = pdm("IndexedEvent.transaction", "The transaction that triggered this event (optional)")
-	IndexedEventBlock                  = pdm("IndexedEvent.block", "The block containing this event")
-	EventWithDataSoliditySignature     = pdm("EventWithData.soliditySignature", "A Solidity style description of the event and parameters, including parameter names and whether they are indexed")
-	EventWithDataAddress               = pdm("EventWithData.address", "The address of the smart contract that emitted this event")
-	EventWithDataData                  = pdm("EventWithData.data", "JSON formatted data from the event")
+	IndexedBlockNumber                       = pdm("IndexedBlock.number", "The block number")
+	IndexedBlockHash                         = pdm("IndexedBlock.hash", "The unique hash of the block")
+	IndexedBlockTimestamp                    = pdm("IndexedBlock.timestamp", "The block timestamp")
+	IndexedTransactionHash                   = pdm("IndexedTransaction.hash", "The unique hash of the transaction")
+	IndexedTransactionBlockNumber            = pdm("IndexedTransaction.blockNumber", "The block number containing this transaction")
+	IndexedTransactionTransactionIndex       = pdm("IndexedTransaction.transactionIndex", "The index of the transaction within the block")
+	IndexedTransactionFrom                   = pdm("IndexedTransaction.from", "The sender's Ethereum address")
+	IndexedTransactionTo                     = pdm("IndexedTransaction.to", "The recipient's Ethereum address (optional)")
+	IndexedTransactionNonce                  = pdm("IndexedTransaction.nonce", "The transaction nonce")
+	IndexedTransactionContractAddress        = pdm("IndexedTransaction.contractAddress", "The contract address created by this transaction (optional)")
+	IndexedTransactionResult                 = pdm("IndexedTransaction.result", "The result of the transaction (optional)")
+	IndexedTransactionBlock                  = pdm("IndexedTransaction.block", "The block containing this event")
+	IndexedEventBlockNumber                  = pdm("IndexedEvent.blockNumber", "The block number containing this event")
+	IndexedEventTransactionIndex             = pdm("IndexedEvent.transactionIndex", "The index of the transaction within the block")
+	IndexedEventLogIndex                     = pdm("IndexedEvent.logIndex", "The log index of the event")
+	IndexedEventTransactionHash              = pdm("IndexedEvent.transactionHash", "The hash of the transaction that triggered this event")
+	IndexedEventSignature                    = pdm("IndexedEvent.signature", "The event signature")
+	IndexedEventTransaction                  = pdm("IndexedEvent.transaction", "The transaction that triggered this event (optional)")
+	IndexedEventBlock                        = pdm("IndexedEvent.block", "The block containing this event")
+	EventWithDataSoliditySignature           = pdm("EventWithData.soliditySignature", "A Solidity style description of the event and parameters, including parameter names and whether they are indexed")
+	EventWithDataAddress                     = pdm("EventWithData.address", "The address of the smart contract that emitted this event")
+	EventWithDataData                        = pdm("EventWithData.data", "JSON formatted data from the event")
+	DecodedEventTopics                       = pdm("DecodedEvent.topics", "The raw topics of the log, the first of which is the event signature")
+	DecodedEventRawData                      = pdm("DecodedEvent.rawData", "The raw non-indexed data of the log")
+	IndexerBackfillRequestFromBlock          = pdm("IndexerBackfillRequest.fromBlock", "The first block to index (inclusive)")
+	IndexerBackfillRequestToBlock            = pdm("IndexerBackfillRequest.toBlock", "The last block to index (inclusive). Must be behind the block the indexer is following the head of the chain from")
+	IndexerBackfillRequestConcurrency        = pdm("IndexerBackfillRequest.concurrency", "The number of parallel workers, overriding the configured default (optional)")
+	IndexerBackfillRequestBatchSize          = pdm("IndexerBackfillRequest.batchSize", "The number of blocks each worker indexes in a single database transaction, overriding the configured default (optional)")
+	IndexerBackfillStatusFromBlock           = pdm("IndexerBackfillStatus.fromBlock", "The first block of the backfill range")
+	IndexerBackfillStatusToBlock             = pdm("IndexerBackfillStatus.toBlock", "The last block of the backfill range")
+	IndexerBackfillStatusConcurrency         = pdm("IndexerBackfillStatus.concurrency", "The number of parallel workers")
+	IndexerBackfillStatusBatchSize           = pdm("IndexerBackfillStatus.batchSize", "The number of blocks each worker indexes in a single database transaction")
+	IndexerBackfillStatusBlocksIndexed       = pdm("IndexerBackfillStatus.blocksIndexed", "The number of blocks in the range that have been indexed so far")
+	IndexerBackfillStatusRunning             = pdm("IndexerBackfillStatus.running", "True while the backfill workers are running")
+	IndexerBackfillStatusStarted             = pdm("IndexerBackfillStatus.started", "The time the backfill started")
+	IndexerBackfillStatusCompleted           = pdm("IndexerBackfillStatus.completed", "The time the backfill completed, failed, or was stopped")
+	IndexerBackfillStatusError               = pdm("IndexerBackfillStatus.error", "The error that caused the backfill to stop, if it did not complete successfully")
+	ChainTimeBlockNumber                     = pdm("ChainTime.blockNumber", "The number of the highest block received from the chain")
+	ChainTimeBlockTimestamp                  = pdm("ChainTime.blockTimestamp", "The timestamp the block producer set in the highest block")
+	ChainTimeObservedAt                      = pdm("ChainTime.observedAt", "The time on the local clock when the highest block was received")
+	ChainTimeEstimatedTime                   = pdm("ChainTime.estimatedTime", "The estimated current time on the chain - the block timestamp, plus the time elapsed on the local clock since it was received")
+	ChainTimeDriftMS                         = pdm("ChainTime.driftMs", "How far the local clock was ahead of the block timestamp when the block was received, in milliseconds. Negative if the block timestamp was ahead")
+	ChainTimeDriftExceeded                   = pdm("ChainTime.driftExceeded", "True if the drift is beyond the configured warning threshold")
+	HeaderVerificationStatusEnabled          = pdm("HeaderVerificationStatus.enabled", "True if headers of blocks fetched from the node are being verified")
+	HeaderVerificationStatusConsensus        = pdm("HeaderVerificationStatus.consensus", "The consensus algorithm the headers are verified against - none, qbft or ibft2")
+	HeaderVerificationStatusBlocksVerified   = pdm("HeaderVerificationStatus.blocksVerified", "The number of block headers verified since the node started")
+	HeaderVerificationStatusHighestBlock     = pdm("HeaderVerificationStatus.highestBlockVerified", "The highest block number that has been verified")
+	HeaderVerificationStatusDiscrepancies    = pdm("HeaderVerificationStatus.discrepancies", "The number of block headers that failed verification since the node started")
+	HeaderVerificationStatusLastDiscrepancy  = pdm("HeaderVerificationStatus.lastDiscrepancy", "The most recent block header that failed verification")
+	HeaderDiscrepancyBlockNumber             = pdm("HeaderDiscrepancy.blockNumber", "The number of the block that failed verification")
+	HeaderDiscrepancyBlockHash               = pdm("HeaderDiscrepancy.blockHash", "The hash the node reported for the block")
+	HeaderDiscrepancyDetected                = pdm("HeaderDiscrepancy.detected", "The time the discrepancy was detected")
+	HeaderDiscrepancyError                   = pdm("HeaderDiscrepancy.error", "Why the block failed verification")
+	IndexerEventReplayRequestStreamType      = pdm("IndexerEventReplayRequest.streamType", "The type of the event stream - defaults to internal")
+	IndexerEventReplayRequestStreamName      = pdm("IndexerEventReplayRequest.streamName", "The name of the event stream to deliver the events to")
+	IndexerEventReplayRequestTransactionHash = pdm("IndexerEventReplayRequest.transactionHash", "The hash of the base ledger transaction to re-deliver the indexed events of")
+	IndexerEventReplayResultStreamName       = pdm("IndexerEventReplayResult.streamName", "The name of the event stream the events were delivered to")
+	IndexerEventReplayResultTransactionHash  = pdm("IndexerEventReplayResult.transactionHash", "The hash of the base ledger transaction")
+	IndexerEventReplayResultEvents           = pdm("IndexerEventReplayResult.events", "The number of events matching the event stream that were delivered")
 )
 
 // pldapi/keymgr.go
@@ -65,18 +102,30 @@ var (
 	KeyVerifierVerifier                = pdm("KeyVerifier.verifier", "The verifier value")
 	KeyVerifierType                    = pdm("KeyVerifier.type", "The type of verifier")
 	KeyVerifierAlgorithm               = pdm("KeyVerifier.algorithm", "The algorithm used by the verifier")
+	VerifierLookupLookup               = pdm("VerifierLookup.lookup", "The identity locator to resolve, in the format identity@node. The local node is used if the node is omitted")
+	VerifierLookupAlgorithm            = pdm("VerifierLookup.algorithm", "The signing algorithm of the key to resolve")
+	VerifierLookupVerifierType         = pdm("VerifierLookup.verifierType", "The type of verifier to return for the key")
+	ResolvedVerifierVerifier           = pdm("ResolvedVerifier.verifier", "The resolved verifier value")
 	KeyPathSegmentName                 = pdm("KeyPathSegment.name", "The name of the path segment")
 	KeyPathSegmentIndex                = pdm("KeyPathSegment.index", "The index of the path segment")
+	EIP712TypedDataTypes               = pdm("EIP712TypedData.types", "The type definitions, including the EIP712Domain type. Each type is a list of members with a name and a Solidity type")
+	EIP712TypedDataPrimaryType         = pdm("EIP712TypedData.primaryType", "The name of the type of the message being signed")
+	EIP712TypedDataDomain              = pdm("EIP712TypedData.domain", "The values of the EIP712Domain type, which scope the signature to an application, chain and contract")
+	EIP712TypedDataMessage             = pdm("EIP712TypedData.message", "The values of the message being signed, which must match the primary type")
 )
 
 // pldapi/public_tx.go
 var (
 	PublicTxOptionsGas                     = pdm("PublicTxOptions.gas", "The gas limit for the transaction (optional)")
 	PublicTxOptionsValue                   = pdm("PublicTxOptions.value", "The value transferred in the transaction (optional)")
+	PublicTxOptionsBlobs                   = pdm("PublicTxOptions.blobs", "Blob data that makes this an EIP-4844 blob transaction. Each entry is a full 131072 byte blob, or up to 126976 bytes of data that is packed into a blob 31 bytes per field element (optional)")
+	PublicTxOptionsExpiry                  = pdm("PublicTxOptions.expiry", "If the transaction is not mined by this time, it is cancelled by submitting a zero value transfer to self with the same nonce. Cannot be combined with blobs (optional)")
+	PublicTxOptionsMetadata                = pdm("PublicTxOptions.metadata", "A JSON object stored with the public transaction, such as an order ID from the submitting system. Public transactions can be queried by values within it using jsonEq (optional)")
 	PublicCallOptionsBlock                 = pdm("PublicCallOptions.block", "The block number or 'latest' when calling a public smart contract (optional)")
 	PublicTxGasPricingMaxPriorityFeePerGas = pdm("PublicTxGasPricing.maxPriorityFeePerGas", "The maximum priority fee per gas (optional)")
 	PublicTxGasPricingMaxFeePerGas         = pdm("PublicTxGasPricing.maxFeePerGas", "The maximum fee per gas (optional)")
 	PublicTxGasPricingGasPrice             = pdm("PublicTxGasPricing.gasPrice", "The gas price (optional)")
+	PublicTxGasPricingMaxFeePerBlobGas     = pdm("PublicTxGasPricing.maxFeePerBlobGas", "The maximum fee per blob gas, for blob transactions (optional)")
 	PublicTxInputFrom                      = pdm("PublicTxInput.from", "The resolved signing account")
 	PublicTxInputTo                        = pdm("PublicTxInput.to", "The target contract address (optional)")
 	PublicTxInputData                      = pdm("PublicTxInput.data", "The pre-encoded calldata (optional)")
@@ -84,6 +133,8 @@ var (
 	PublicTxSubmissionNonce                = pdm("PublicTxSubmission.nonce", "The transaction nonce")
 	PublicTxSubmissionDataTime             = pdm("PublicTxSubmissionData.time", "The submission time")
 	PublicTxSubmissionDataTransactionHash  = pdm("PublicTxSubmissionData.transactionHash", "The transaction hash")
+	PublicTxSubmissionDataCancel           = pdm("PublicTxSubmissionData.cancel", "True if this submission was the no-op replacement transaction used to cancel an expired transaction")
+	PublicTxRawSubmissionRawTransaction    = pdm("PublicTxRawSubmission.rawTransaction", "The signed transaction exactly as it was submitted, RLP encoded ready for eth_sendRawTransaction. Empty if the transaction was signed by the blockchain node, or submitted before raw transactions were stored")
 	PublicTxLocalID                        = pdm("PublicTx.localId", "A locally generated numeric ID for the public transaction. Unique within the node")
 	PublicTxTo                             = pdm("PublicTx.to", "The target contract address (optional)")
 	PublicTxData                           = pdm("PublicTx.data", "The pre-encoded calldata (optional)")
@@ -96,14 +147,112 @@ var (
 	PublicTxRevertData                     = pdm("PublicTx.revertData", "The revert data (optional)")
 	PublicTxSubmissions                    = pdm("PublicTx.submissions", "The submission data (optional)")
 	PublicTxActivity                       = pdm("PublicTx.activity", "The transaction activity records (optional)")
+	PublicTxBlobHashes                     = pdm("PublicTx.blobHashes", "The versioned hashes of the blobs, for EIP-4844 blob transactions")
+	PublicTxExpired                        = pdm("PublicTx.expired", "True if the transaction passed its expiry before being mined, and was cancelled (optional)")
+	PublicTxStageTimeStage                 = pdm("PublicTxStageTime.stage", "The stage the public transaction reached")
+	PublicTxStageTimeTime                  = pdm("PublicTxStageTime.time", "The time the stage was first reached")
+	PublicTxStageTimeSincePrevMS           = pdm("PublicTxStageTime.sincePrevMs", "Milliseconds since the previous recorded stage")
+	PublicTxStageTimeSinceStartMS          = pdm("PublicTxStageTime.sinceStartMs", "Milliseconds since the first recorded stage")
+	PublicTxTimelineLocalID                = pdm("PublicTxTimeline.localId", "The local ID of the public transaction")
+	PublicTxTimelineStages                 = pdm("PublicTxTimeline.stages", "The stages recorded for the public transaction, in order. Only retained in memory for recent transactions")
+	PublicTxTimelineBinding                = pdm("PublicTxTimeline.binding", "The Paladin transaction the public transaction is bound to (optional)")
+	PublicTxQueryPendingLongerThan         = pdm("PublicTxQuery.pendingLongerThan", "Only return transactions that have been pending for longer than this duration, such as '5m' (optional)")
+	PublicTxQueryAfter                     = pdm("PublicTxQuery.after", "The 'next' cursor returned with the previous page, to query the page that follows it (optional)")
+	PublicTxPageItems                      = pdm("PublicTxPage.items", "The public transactions on this page")
+	PublicTxPageNext                       = pdm("PublicTxPage.next", "A cursor to pass as 'after' to query the next page. Omitted on the last page")
+	PublicTxLatencyPercentilesCount        = pdm("PublicTxLatencyPercentiles.count", "The number of samples in the current window")
+	PublicTxLatencyPercentilesP50MS        = pdm("PublicTxLatencyPercentiles.p50Ms", "50th percentile latency in milliseconds")
+	PublicTxLatencyPercentilesP90MS        = pdm("PublicTxLatencyPercentiles.p90Ms", "90th percentile latency in milliseconds")
+	PublicTxLatencyPercentilesP99MS        = pdm("PublicTxLatencyPercentiles.p99Ms", "99th percentile latency in milliseconds")
+	PublicTxLatencyPercentilesMaxMS        = pdm("PublicTxLatencyPercentiles.maxMs", "Maximum latency in milliseconds")
+	PublicTxLatencyStatsStage              = pdm("PublicTxLatencyStats.stage", "The stage these statistics are for")
+	PublicTxLatencyStatsSincePrev          = pdm("PublicTxLatencyStats.sincePrev", "Latency from the previous stage to this stage")
+	PublicTxLatencyStatsSinceStart         = pdm("PublicTxLatencyStats.sinceStart", "Latency from the received stage to this stage")
+	PublicTxDryRunFrom                     = pdm("PublicTxDryRun.from", "The sender's Ethereum address")
+	PublicTxDryRunTo                       = pdm("PublicTxDryRun.to", "The target contract address (optional)")
+	PublicTxDryRunData                     = pdm("PublicTxDryRun.data", "The pre-encoded calldata (optional)")
+	PublicTxDryRunNonce                    = pdm("PublicTxDryRun.nonce", "The provisional nonce. This is not reserved, so might be assigned to the next transaction submitted by the node for the same sender")
+	PublicTxDryRunTransactionHash          = pdm("PublicTxDryRun.transactionHash", "The hash of the signed transaction")
+	PublicTxDryRunRawTransaction           = pdm("PublicTxDryRun.rawTransaction", "The signed transaction, RLP encoded ready for submission with eth_sendRawTransaction")
+	PublicTxDryRunBlobHashes               = pdm("PublicTxDryRun.blobHashes", "The versioned hashes of the blobs, for EIP-4844 blob transactions")
+	PublicTxSimulationTraced               = pdm("PublicTxSimulation.traced", "True if the node supported debug_traceCall, so the call tree and logs are available. Otherwise only the result of eth_call is returned")
+	PublicTxSimulationReverted             = pdm("PublicTxSimulation.reverted", "True if the transaction would revert")
+	PublicTxSimulationRevertData           = pdm("PublicTxSimulation.revertData", "The raw revert data returned by the transaction, if it reverted")
+	PublicTxSimulationRevertReason         = pdm("PublicTxSimulation.revertReason", "The revert data decoded against the error definitions in the ABIs known to the node, where possible")
+	PublicTxSimulationOutput               = pdm("PublicTxSimulation.output", "The data returned by the transaction, if it succeeded")
+	PublicTxSimulationGasUsed              = pdm("PublicTxSimulation.gasUsed", "The gas used by the transaction, when traced")
+	PublicTxSimulationCall                 = pdm("PublicTxSimulation.call", "The top-level call of the transaction, containing the tree of sub-calls")
+	PublicTxSimulationLogs                 = pdm("PublicTxSimulation.logs", "The events that would be emitted by the transaction, in order. Events from sub-calls that revert are excluded")
+	PublicTxSimulationStorageChanges       = pdm("PublicTxSimulation.storageChanges", "The contract storage slots that would be modified by the transaction, if supported by the node")
+	PublicTxSimulatedCallType              = pdm("PublicTxSimulatedCall.type", "The type of call - such as CALL, STATICCALL, DELEGATECALL or CREATE")
+	PublicTxSimulatedCallFrom              = pdm("PublicTxSimulatedCall.from", "The address making the call")
+	PublicTxSimulatedCallTo                = pdm("PublicTxSimulatedCall.to", "The address being called, or the address of the created contract")
+	PublicTxSimulatedCallValue             = pdm("PublicTxSimulatedCall.value", "The value transferred with the call")
+	PublicTxSimulatedCallGas               = pdm("PublicTxSimulatedCall.gas", "The gas available to the call")
+	PublicTxSimulatedCallGasUsed           = pdm("PublicTxSimulatedCall.gasUsed", "The gas used by the call, including its sub-calls")
+	PublicTxSimulatedCallInput             = pdm("PublicTxSimulatedCall.input", "The calldata of the call")
+	PublicTxSimulatedCallOutput            = pdm("PublicTxSimulatedCall.output", "The data returned by the call, which is the revert data if the call failed")
+	PublicTxSimulatedCallError             = pdm("PublicTxSimulatedCall.error", "The error reported by the EVM if the call failed")
+	PublicTxSimulatedCallRevertReason      = pdm("PublicTxSimulatedCall.revertReason", "The decoded revert reason, if the call reverted")
+	PublicTxSimulatedCallCalls             = pdm("PublicTxSimulatedCall.calls", "The sub-calls made by this call, in order")
+	PublicTxSimulatedLogAddress            = pdm("PublicTxSimulatedLog.address", "The address of the contract that emitted the event")
+	PublicTxSimulatedLogTopics             = pdm("PublicTxSimulatedLog.topics", "The indexed topics of the event, starting with the event signature hash")
+	PublicTxSimulatedLogData               = pdm("PublicTxSimulatedLog.data", "The non-indexed data of the event")
+	PublicTxStorageChangeAddress           = pdm("PublicTxStorageChange.address", "The address of the contract whose storage is modified")
+	PublicTxStorageChangeSlot              = pdm("PublicTxStorageChange.slot", "The storage slot")
+	PublicTxStorageChangeBefore            = pdm("PublicTxStorageChange.before", "The value of the slot before the transaction")
+	PublicTxStorageChangeAfter             = pdm("PublicTxStorageChange.after", "The value of the slot after the transaction")
+	PublicTxBatchImpactTransactions        = pdm("PublicTxBatchImpact.transactions", "The predicted nonce and cost of each transaction, in the order they were supplied")
+	PublicTxBatchImpactSigners             = pdm("PublicTxBatchImpact.signers", "The predicted impact on each signing address, in the order each first appears in the batch")
+	PublicTxImpactFrom                     = pdm("PublicTxImpact.from", "The sender's Ethereum address")
+	PublicTxImpactTo                       = pdm("PublicTxImpact.to", "The target contract address (optional)")
+	PublicTxImpactNonce                    = pdm("PublicTxImpact.nonce", "The provisional nonce. This is not reserved, so might be assigned to another transaction submitted before the batch")
+	PublicTxImpactGasCost                  = pdm("PublicTxImpact.gasCost", "The most the transaction could cost in gas, calculated from the gas limit and the maximum fee per gas (or gas price)")
+	PublicTxSignerImpactSigner             = pdm("PublicTxSignerImpact.signer", "The signing address")
+	PublicTxSignerImpactTransactions       = pdm("PublicTxSignerImpact.transactions", "The number of transactions in the batch from this signer")
+	PublicTxSignerImpactFirstNonce         = pdm("PublicTxSignerImpact.firstNonce", "The provisional nonce of the first transaction in the batch from this signer")
+	PublicTxSignerImpactNextNonce          = pdm("PublicTxSignerImpact.nextNonce", "The nonce that would be assigned to the next transaction from this signer after the batch")
+	PublicTxSignerImpactGasCost            = pdm("PublicTxSignerImpact.gasCost", "The most the transactions in the batch from this signer could cost in gas")
+	PublicTxSignerImpactValue              = pdm("PublicTxSignerImpact.value", "The total value transferred by the transactions in the batch from this signer")
+	PublicTxSignerImpactBalance            = pdm("PublicTxSignerImpact.balance", "The current balance of the signer")
+	PublicTxSignerImpactReserved           = pdm("PublicTxSignerImpact.reserved", "The funds already committed to the signer's transactions in flight")
+	PublicTxSignerImpactShortfall          = pdm("PublicTxSignerImpact.shortfall", "How far the balance, after reservations, falls short of the gas cost and value of the batch. Omitted if the batch can be funded")
+	PublicTxSignerImpactAutoFuel           = pdm("PublicTxSignerImpact.autoFuel", "True if auto-fueling is configured and would top up the signer to fund the batch")
+	PublicTxSignerImpactAutoFuelAmount     = pdm("PublicTxSignerImpact.autoFuelAmount", "The amount auto-fueling would top up the signer by, based on the configured thresholds")
 	PublicTxBindingTransaction             = pdm("PublicTxBinding.transaction", "The transaction ID")
 	PublicTxBindingTransactionType         = pdm("PublicTxBinding.transactionType", "The transaction type")
 )
 
+// pldapi/domain.go
+var (
+	DomainName                 = pdm("Domain.name", "The name of the domain")
+	DomainRegistryAddress      = pdm("Domain.registryAddress", "The address of the registry contract that smart contracts of this domain are deployed through")
+	DomainPaused               = pdm("Domain.paused", "True if the domain is paused, so new transactions are rejected and events are held until it is resumed")
+	SmartContractDomainName    = pdm("SmartContract.domainName", "The name of the domain the smart contract belongs to")
+	SmartContractDomainAddress = pdm("SmartContract.domainAddress", "The registry address of the domain the smart contract belongs to")
+	SmartContractAddress       = pdm("SmartContract.address", "The address of the smart contract")
+)
+
 // pldapi/stored_abi.go
 var (
 	StoredABIHash = pdm("StoredABI.hash", "The unique hash of the ABI")
 	StoredABIAPI  = pdm("StoredABI.abi", "The Application Binary Interface (ABI) definition")
+
+	ContractABIInputAddress       = pdm("ContractABIInput.address", "The address of a single deployed contract to register the ABI against. Exactly one of address or codeHash must be set")
+	ContractABIInputCodeHash      = pdm("ContractABIInput.codeHash", "The keccak256 hash of the deployed runtime bytecode, to register the ABI against every contract deployed with the same code")
+	ContractABIInputABI           = pdm("ContractABIInput.abi", "The Application Binary Interface (ABI) definition of the contract")
+	ContractABIAddress            = pdm("ContractABI.address", "The address of the contract the ABI is registered against, or was resolved for")
+	ContractABICodeHash           = pdm("ContractABI.codeHash", "The hash of the deployed runtime bytecode the ABI is registered against")
+	ContractABISource             = pdm("ContractABI.source", "How the ABI was found for the contract - address, codeHash, or domain for a private smart contract")
+	ContractABIDomain             = pdm("ContractABI.domain", "The domain the ABI was harvested from, for private smart contracts")
+	ContractABIABIHash            = pdm("ContractABI.abiHash", "The hash of the stored ABI, which can be retrieved with ptx_getStoredABI")
+	ContractABIABI                = pdm("ContractABI.abi", "The Application Binary Interface (ABI) definition")
+	ContractABICreated            = pdm("ContractABI.created", "The time the ABI was registered against the contract")
+	DecodedTransactionTransaction = pdm("DecodedTransaction.transaction", "The indexed base ledger transaction")
+	DecodedTransactionContractABI = pdm("DecodedTransaction.contractABI", "The registered contract ABI used to decode the transaction, if one was found for the target contract")
+	DecodedTransactionCall        = pdm("DecodedTransaction.call", "The decoded function call, if the calldata matched a known ABI")
+	DecodedTransactionCallError   = pdm("DecodedTransaction.callError", "Why the calldata could not be decoded, if it did not match any known ABI")
+	DecodedTransactionEvents      = pdm("DecodedTransaction.events", "The events emitted by the transaction. The data is populated for events that matched a known ABI")
 )
 
 // pldclient/transaction.go
@@ -111,6 +260,7 @@ var (
 	TransactionID                                           = pdm("Transaction.id", "Server-generated UUID for this transaction (query only)")
 	TransactionCreated                                      = pdm("Transaction.created", "Server-generated creation timestamp for this transaction (query only)")
 	TransactionSubmitMode                                   = pdm("Transaction.submitMode", "Whether the submission of the transaction to the base ledger is to be performed automatically by the node or coordinated externally (query only)")
+	TransactionTenant                                       = pdm("Transaction.tenant", "The tenant that submitted the transaction, when tenant authentication is configured on the RPC server (query only)")
 	TransactionIdempotencyKey                               = pdm("Transaction.idempotencyKey", "Externally supplied unique identifier for this transaction. 409 Conflict will be returned on attempt to re-submit")
 	TransactionType                                         = pdm("Transaction.type", "Type of transaction (public or private)")
 	TransactionDomain                                       = pdm("Transaction.domain", "Name of a domain - only required on input for private deploy transactions")
@@ -122,8 +272,12 @@ var (
 	TransactionInputDependsOn                               = pdm("TransactionInput.dependsOn", "Transactions that must be mined on the blockchain successfully before this transaction submits")
 	TransactionInputABI                                     = pdm("TransactionInput.abi", "Application Binary Interface (ABI) definition - required if abiReference not supplied")
 	TransactionInputBytecode                                = pdm("TransactionInput.bytecode", "Bytecode prepended to encoded data inputs for deploy transactions")
+	TransactionScheduleInputNotBefore                       = pdm("TransactionScheduleInput.notBefore", "The transaction is persisted, but is not submitted for processing until this time (optional)")
+	TransactionScheduleInputNotBeforeBlock                  = pdm("TransactionScheduleInput.notBeforeBlock", "The transaction is persisted, but is not submitted for processing until the confirmed block height reaches this block (optional)")
+	TransactionScheduleReleased                             = pdm("TransactionSchedule.released", "The time the scheduled transaction was submitted for processing - not set while the transaction is still waiting")
 	TransactionCallDataFormat                               = pdm("TransactionCall.dataFormat", "How call data should be serialized into JSON once decoded using the ABI function definition")
 	TransactionFullDependsOn                                = pdm("TransactionFull.dependsOn", "Transactions registered as dependencies when the transaction was created")
+	TransactionFullScheduled                                = pdm("TransactionFull.scheduled", "The schedule the transaction was submitted with, and when it was released for processing - only set for scheduled transactions")
 	TransactionFullReceipt                                  = pdm("TransactionFull.receipt", "Transaction receipt data - available if the transaction has reached a final state")
 	TransactionFullPublic                                   = pdm("TransactionFull.public", "List of public transactions associated with this transaction")
 	TransactionFullHistory                                  = pdm("TransactionFull.history", "List of values that have previously been provided for this transaction")
@@ -167,6 +321,12 @@ var (
 	TransactionReceiptFiltersDomain                         = pdm("TransactionReceiptFilters.domain", "Only deliver receipts for an individual domain (only valid with type=private)")
 	TransactionReceiptOptionsDomainReceipts                 = pdm("TransactionReceiptOptions.domainReceipts", "When true, a full domain receipt will be generated for each event with complete state data")
 	TransactionReceiptOptionsIncompleteStateReceiptBehavior = pdm("TransactionReceiptOptions.incompleteStateReceiptBehavior", "When set to 'block_contract', if a transaction with incomplete state data is detected then delivery of all receipts on that individual smart contract address will pause until the missing state arrives. Receipts for other contract addresses continue to be delivered")
+	ExternalTransactionWatchID                              = pdm("ExternalTransactionWatch.id", "The ID of the watch, which is also the transaction ID of the receipt written when the transaction is mined. Generated if not supplied")
+	ExternalTransactionWatchCreated                         = pdm("ExternalTransactionWatch.created", "Time the watch was created")
+	ExternalTransactionWatchTransactionHash                 = pdm("ExternalTransactionWatch.transactionHash", "The hash of the transaction to watch. Set this, or from and nonce")
+	ExternalTransactionWatchFrom                            = pdm("ExternalTransactionWatch.from", "The sender of the transaction to watch, which must be set along with the nonce. Matches any transaction mined with this nonce, including a replacement")
+	ExternalTransactionWatchNonce                           = pdm("ExternalTransactionWatch.nonce", "The nonce of the transaction to watch, which must be set along with from")
+	ExternalTransactionWatchCompleted                       = pdm("ExternalTransactionWatch.completed", "Time the transaction was found on chain, and the receipt written (optional)")
 	BlockchainEventListenerName                             = pdm("BlockchainEventListener.name", "Unique name for the blockchain event listener")
 	BlockchainEventListenerCreated                          = pdm("BlockchainEventListener.created", "Time the listener was created")
 	BlockchainEventListenerStarted                          = pdm("BlockchainEventListener.started", "If the listener is started - can be set to false to disable delivery server-side")
@@ -175,11 +335,17 @@ var (
 	BlockchainEventListenerOptionsBatchSize                 = pdm("BlockchainEventListenerOptions.batchSize", "The maximum number of events to deliver in each batch")
 	BlockchainEventListenerOptionsBatchTimeout              = pdm("BlockchainEventListenerOptions.batchTimeout", "The maximum time to wait for a batch to fill before delivering")
 	BlockchainEventListenerOptionsFromBlock                 = pdm("BlockchainEventListenerOptions.fromBlock", "The block number from which to start listenening for events, or 'latest' to start from the latest block")
+	BlockchainEventListenerOptionsConfirmations             = pdm("BlockchainEventListenerOptions.confirmations", "The number of blocks that must be mined on top of the block containing an event before it is delivered. Defaults to 0 for the lowest latency")
 	BlockchainEventListenerSourceABI                        = pdm("BlockchainEventListenerSource.abi", "The ABI containing events to listen for")
 	BlockchainEventListenerSourceAddress                    = pdm("BlockchainEventListenerSource.address", "The address to listen for events from")
 	BlockchainEventListenerStatusCatchup                    = pdm("BlockchainEventListenerStatus.catchup", "Whether the event listener is catching up to the latest block")
 	BlockcgainEventListenerStatusCheckpoint                 = pdm("BlockchainEventListenerStatus.checkpoint", "The checkpoint for the event listener")
 	BlockchainEventListenerCheckpointBlockNumber            = pdm("BlockchainEventListenerCheckpoint.blockNumber", "The last block fully processed by the event listener")
+	DomainEventSubscriptionName                             = pdm("DomainEventSubscription.name", "Name of the durable blockchain event listener that stores the checkpoint for this subscription. Subscribing again with the same name resumes from the checkpoint")
+	DomainEventSubscriptionDomain                           = pdm("DomainEventSubscription.domain", "The domain whose events should be delivered")
+	DomainEventSubscriptionContracts                        = pdm("DomainEventSubscription.contracts", "Addresses of the smart contracts of the domain to deliver events for")
+	DomainEventSubscriptionEvents                           = pdm("DomainEventSubscription.events", "Names of the domain events to deliver. Defaults to all events declared by the domain")
+	DomainEventSubscriptionOptions                          = pdm("DomainEventSubscription.options", "Options for the underlying event listener, applied when it is first created")
 )
 
 // query/query_json.go
@@ -198,11 +364,13 @@ var (
 	OpField                     = pdm("Op.field", "Field to apply the operation to")
 	OpSingleValValue            = pdm("OpSingleVal.value", "Value to compare against")
 	OpMultiValValues            = pdm("OpMultiVal.values", "Values to compare against")
+	OpJSONPathPath              = pdm("OpJSONPath.path", "Dot separated path to a value within a JSON field, such as 'owner.keys.0'")
 	StatementsOr                = pdm("Statements.or", "List of alternative statements")
 	OpsEqual                    = pdm("Ops.equal", "Equal to")
 	OpsEq                       = pdm("Ops.eq", "Equal to (short name)")
 	OpsNEq                      = pdm("Ops.neq", "Not equal to")
 	OpsLike                     = pdm("Ops.like", "Like")
+	OpsContains                 = pdm("Ops.contains", "Contains the value as a substring")
 	OpsLessThan                 = pdm("Ops.lessThan", "Less than")
 	OpsLT                       = pdm("Ops.lt", "Less than (short name)")
 	OpsLessThanOrEqual          = pdm("Ops.lessThanOrEqual", "Less than or equal to")
@@ -214,6 +382,7 @@ var (
 	OpsIn                       = pdm("Ops.in", "In")
 	OpsNIn                      = pdm("Ops.nin", "Not in")
 	OpsNull                     = pdm("Ops.null", "Null")
+	OpsJSONEq                   = pdm("Ops.jsonEq", "Equal to the value at a path within a JSON field")
 )
 
 // pldclient/states.go
@@ -230,6 +399,8 @@ var (
 	StateLocks                   = pdm("State.locks", "When querying states within a domain context running ahead of the blockchain assembling transactions for submission, this provides detail on locks applied to the state")
 	StateNullifier               = pdm("State.nullifier", "Only set if nullifiers are being used in the domain, and a nullifier has been generated that is available for spending this state")
 	StateConfirmTransaction      = pdm("StateConfirm.transaction", "The ID of the Paladin transaction where this state was confirmed")
+	StateConfirmTransactionHash  = pdm("StateConfirm.transactionHash", "The hash of the base ledger transaction that confirmed this state, if reported by the domain")
+	StateConfirmBlockNumber      = pdm("StateConfirm.blockNumber", "The block number of the base ledger transaction that confirmed this state, if reported by the domain")
 	StateSpendTransaction        = pdm("StateSpend.transaction", "The ID of the Paladin transaction where this state was spent")
 	StateLockTransaction         = pdm("StateLock.transaction", "The ID of the Paladin transaction being assembled that is responsible for this lock")
 	StateLockType                = pdm("StateLock.type", "Whether this lock is for create, read or spend")
@@ -252,6 +423,192 @@ var (
 	UnavailableStatesInfo        = pdm("UnavailableStates.info", "The IDs of info states referenced in this transaction, for which the private data is unavailable")
 )
 
+// pldapi/states.go snapshots
+var (
+	StateSnapshotDomain              = pdm("StateSnapshot.domain", "The domain the states were exported from")
+	StateSnapshotContractAddress     = pdm("StateSnapshot.contractAddress", "If set, the snapshot only contains the states of this smart contract")
+	StateSnapshotCreated             = pdm("StateSnapshot.created", "The time the snapshot was exported")
+	StateSnapshotSchemas             = pdm("StateSnapshot.schemas", "The schemas of the states in the snapshot. The ID of each schema is verified against its definition on import")
+	StateSnapshotStates              = pdm("StateSnapshot.states", "The states, in the order they were created. The ID of each state is verified against the hash of its data on import")
+	StateSnapshotHash                = pdm("StateSnapshot.hash", "A hash over the schema IDs, and the IDs and confirm/spend/nullifier records of every state, verified on import to detect a modified or truncated snapshot")
+	StateSnapshotEntryConfirmed      = pdm("StateSnapshotEntry.confirmed", "The ID of the transaction that confirmed this state, if it has been confirmed")
+	StateSnapshotEntrySpent          = pdm("StateSnapshotEntry.spent", "The ID of the transaction that spent this state (or its nullifier), if it has been spent")
+	StateSnapshotEntryNullifier      = pdm("StateSnapshotEntry.nullifier", "The nullifier for this state, for domains that spend states using nullifiers")
+	StateSnapshotImportResultDomain  = pdm("StateSnapshotImportResult.domain", "The domain the states were imported into")
+	StateSnapshotImportResultHash    = pdm("StateSnapshotImportResult.hash", "The verified hash of the imported snapshot")
+	StateSnapshotImportResultSchemas = pdm("StateSnapshotImportResult.schemas", "The number of schemas in the snapshot")
+	StateSnapshotImportResultStates  = pdm("StateSnapshotImportResult.states", "The number of states in the snapshot. States that already existed on this node are left unchanged")
+	StateAnchorID                    = pdm("StateAnchor.id", "The ID of the anchor")
+	StateAnchorCreated               = pdm("StateAnchor.created", "The time the anchor was created")
+	StateAnchorDomain                = pdm("StateAnchor.domain", "The domain of the anchored states")
+	StateAnchorRoot                  = pdm("StateAnchor.root", "The merkle root over the IDs of the anchored states")
+	StateAnchorLeafCount             = pdm("StateAnchor.leafCount", "The number of states in the anchor")
+	StateAnchorContractAddress       = pdm("StateAnchor.contractAddress", "The address of the StateAnchorRegistry contract the root was written to")
+	StateAnchorTransaction           = pdm("StateAnchor.transaction", "The ID of the public transaction that writes the root to the base ledger")
+	StateAnchorProofAnchor           = pdm("StateAnchorProof.anchor", "The anchor that includes the state")
+	StateAnchorProofState            = pdm("StateAnchorProof.state", "The ID of the state")
+	StateAnchorProofLeafIndex        = pdm("StateAnchorProof.leafIndex", "The index of the state in the anchor, where the states are sorted by ID")
+	StateAnchorProofLeaf             = pdm("StateAnchorProof.leaf", "The leaf of the merkle tree for the state, which is the keccak256 hash of the state ID")
+	StateAnchorProofProof            = pdm("StateAnchorProof.proof", "The sibling hashes from the leaf to the root, where each pair is hashed in sorted order")
+)
+
+// pldapi/states.go query planning
+var (
+	StateLabelStatsLabel            = pdm("StateLabelStats.label", "The name of the labelled field in the schema")
+	StateLabelStatsType             = pdm("StateLabelStats.type", "The type of the label index used for the field - 'string' or 'int64'")
+	StateLabelStatsDistinctValues   = pdm("StateLabelStats.distinctValues", "The number of distinct values of the field across all states of the schema")
+	StateLabelStatsStates           = pdm("StateLabelStats.states", "The number of states of the schema with a value for the field")
+	StateQueryExplanationSQL        = pdm("StateQueryExplanation.sql", "The SQL that would be executed for the query, with the values inlined")
+	StateQueryExplanationIndexed    = pdm("StateQueryExplanation.indexed", "False if the query has no predicates that can use a label index, so will scan every state of the schema")
+	StateQueryExplanationLabelStats = pdm("StateQueryExplanation.labelStats", "Statistics for the labels used in the query, in the order the planner applies them - most selective first")
+	StateQueryExplanationStatsTime  = pdm("StateQueryExplanation.statsTime", "The time the label statistics were calculated")
+)
+
+// pldapi/backup.go
+var (
+	QuiesceStatusQuiesced          = pdm("QuiesceStatus.quiesced", "True if no public transaction orchestrators are running, so no nonces are being allocated or transactions submitted")
+	QuiesceStatusConfirmedBlock    = pdm("QuiesceStatus.confirmedBlock", "The highest block confirmed by the block indexer, if any blocks have been indexed")
+	DrainStatusDraining            = pdm("DrainStatus.draining", "True if the node is draining, and rejecting new transactions submitted over the API")
+	DrainStatusSafeToStop          = pdm("DrainStatus.safeToStop", "True once all in-flight work has completed or parked, public transaction submission has stopped, and all event streams have checkpointed")
+	DrainStatusStarted             = pdm("DrainStatus.started", "The time the drain was started")
+	DrainStatusPrivateTransactions = pdm("DrainStatus.privateTransactions", "The number of private transactions in sequencers on this node that have not yet completed, parked or been delegated to another node")
+	DrainStatusPublicTransactions  = pdm("DrainStatus.publicTransactions", "The number of public transactions in flight in the public transaction manager, waiting to be submitted or confirmed")
+	DrainStatusPendingEvents       = pdm("DrainStatus.pendingEvents", "The number of blockchain events passed to event streams that have not yet been delivered and checkpointed")
+	DrainStatusConfirmedBlock      = pdm("DrainStatus.confirmedBlock", "The highest block confirmed by the block indexer, if any blocks have been indexed")
+	SignerNonceSigner              = pdm("SignerNonce.signer", "The signing address")
+	SignerNonceNextNonce           = pdm("SignerNonce.nextNonce", "The next nonce that would be allocated to a transaction from this signer")
+	BackupDatabaseInfoType         = pdm("BackupDatabaseInfo.type", "The type of database - 'postgres', 'cockroachdb' or 'sqlite'")
+	BackupDatabaseInfoPosition     = pdm("BackupDatabaseInfo.position", "The position in the transaction log when the manifest was written, for point-in-time recovery - the write-ahead log position for PostgreSQL, or the cluster logical timestamp for CockroachDB")
+	BackupKeyInfoWallets           = pdm("BackupKeyInfo.wallets", "The names of the configured wallets, which hold the key material that is not part of the database backup")
+	BackupKeyInfoKeyMappings       = pdm("BackupKeyInfo.keyMappings", "The number of key identifiers that have been mapped to keys in a wallet")
+	BackupKeyInfoKeyVerifiers      = pdm("BackupKeyInfo.keyVerifiers", "The number of verifiers that have been resolved for mapped keys")
+	BackupManifestID               = pdm("BackupManifest.id", "The ID of the manifest, which is also stored in the database being backed up")
+	BackupManifestCreated          = pdm("BackupManifest.created", "The time the manifest was created")
+	BackupManifestNode             = pdm("BackupManifest.node", "The name of the node")
+	BackupManifestConfigHash       = pdm("BackupManifest.configHash", "A keccak256 hash of the node configuration, to detect configuration drift on restore")
+	BackupManifestConfirmedBlock   = pdm("BackupManifest.confirmedBlock", "The highest block confirmed by the block indexer when the manifest was created")
+	BackupManifestDatabase         = pdm("BackupManifest.database", "Coordinates of the database the backup must be taken from")
+	BackupManifestKeys             = pdm("BackupManifest.keys", "Metadata about the keys used by the node")
+	BackupManifestSigners          = pdm("BackupManifest.signers", "The next nonce of every signer, which is checked against the blockchain when a node starts")
+)
+
+// pldapi/config_change.go
+var (
+	ConfigChangeID         = pdm("ConfigChange.id", "The ID of the change")
+	ConfigChangeCreated    = pdm("ConfigChange.created", "The time the change was applied")
+	ConfigChangeSection    = pdm("ConfigChange.section", "The section of the config that changed, such as 'log' or 'publicTxManager.gasPrice'")
+	ConfigChangePrevious   = pdm("ConfigChange.previous", "The settings in the section before the change")
+	ConfigChangeValue      = pdm("ConfigChange.value", "The settings in the section after the change")
+	ConfigChangeConfigHash = pdm("ConfigChange.configHash", "A keccak256 hash of the whole config file that was loaded, which can be compared with a backup manifest")
+)
+
+// pldapi/data_purge.go
+var (
+	DataPurgeRequestInputRequestedBy  = pdm("DataPurgeRequestInput.requestedBy", "The identity of the person requesting the purge, which must be different to the person who approves it")
+	DataPurgeRequestInputReason       = pdm("DataPurgeRequestInput.reason", "The reason for the purge, such as a reference to the erasure request being honored")
+	DataPurgeRequestInputDomain       = pdm("DataPurgeRequestInput.domain", "The domain of the states to purge - required if any states are listed")
+	DataPurgeRequestInputStates       = pdm("DataPurgeRequestInput.states", "The IDs of the states to purge the data of")
+	DataPurgeRequestInputTransactions = pdm("DataPurgeRequestInput.transactions", "The IDs of the transactions to purge the input data of")
+	DataPurgeRequestID                = pdm("DataPurgeRequest.id", "The ID of the purge request")
+	DataPurgeRequestCreated           = pdm("DataPurgeRequest.created", "The time the purge was requested")
+	DataPurgeRequestRequestedBy       = pdm("DataPurgeRequest.requestedBy", "The identity of the person that requested the purge")
+	DataPurgeRequestReason            = pdm("DataPurgeRequest.reason", "The reason given for the purge")
+	DataPurgeRequestDomain            = pdm("DataPurgeRequest.domain", "The domain of the states to purge")
+	DataPurgeRequestStates            = pdm("DataPurgeRequest.states", "The IDs of the states to purge the data of")
+	DataPurgeRequestTransactions      = pdm("DataPurgeRequest.transactions", "The IDs of the transactions to purge the input data of")
+	DataPurgeRequestStatus            = pdm("DataPurgeRequest.status", "Whether the purge is pending approval, or has been executed")
+	DataPurgeRequestApprovedBy        = pdm("DataPurgeRequest.approvedBy", "The identity of the person that approved the purge")
+	DataPurgeRequestExecuted          = pdm("DataPurgeRequest.executed", "The time the purge was approved and executed")
+	DataPurgeLogEntryID               = pdm("DataPurgeLogEntry.id", "The ID of the log entry")
+	DataPurgeLogEntryPurgeRequest     = pdm("DataPurgeLogEntry.purgeRequest", "The ID of the purge request that was executed")
+	DataPurgeLogEntryCreated          = pdm("DataPurgeLogEntry.created", "The time the data was purged")
+	DataPurgeLogEntryTargetType       = pdm("DataPurgeLogEntry.targetType", "Whether a state or a transaction was purged")
+	DataPurgeLogEntryTargetID         = pdm("DataPurgeLogEntry.targetId", "The ID of the state or transaction that was purged")
+	DataPurgeLogEntryDomain           = pdm("DataPurgeLogEntry.domain", "The domain of the purged state")
+	DataPurgeLogEntryDataHash         = pdm("DataPurgeLogEntry.dataHash", "A keccak256 hash of the data that was purged, which is omitted if there was no data")
+)
+
+// pldapi/swap.go
+var (
+	SwapInputName           = pdm("SwapInput.name", "An optional name for the swap")
+	SwapInputFrom           = pdm("SwapInput.from", "The local signing identity that creates, executes and cancels the settlement contract on the base ledger")
+	SwapInputAtomFactory    = pdm("SwapInput.atomFactory", "The address of the AtomFactory used to create the settlement contract")
+	SwapInputLegs           = pdm("SwapInput.legs", "Two or more private transactions to prepare and settle atomically, which can be in different domains")
+	SwapID                  = pdm("Swap.id", "The ID of the swap")
+	SwapName                = pdm("Swap.name", "The name of the swap, if one was supplied")
+	SwapCreated             = pdm("Swap.created", "Time the swap was created")
+	SwapUpdated             = pdm("Swap.updated", "Time the status of the swap last changed")
+	SwapFrom                = pdm("Swap.from", "The local signing identity that creates, executes and cancels the settlement contract")
+	SwapAtomFactory         = pdm("Swap.atomFactory", "The address of the AtomFactory used to create the settlement contract")
+	SwapStatus              = pdm("Swap.status", "The stage of the swap in its prepare/approve/execute lifecycle, or whether it was rolled back or failed")
+	SwapLegs                = pdm("Swap.legs", "The private transactions submitted for preparation, one for each leg of the swap")
+	SwapOperations          = pdm("Swap.operations", "The base ledger calls derived from the prepared transaction of each leg, which the settlement contract executes in a single transaction")
+	SwapAtom                = pdm("Swap.atom", "The address of the settlement contract, once it has been created. The parties authorize this address in each domain before execution")
+	SwapApproveTransaction  = pdm("Swap.approveTransaction", "The ID of the public transaction that created the settlement contract")
+	SwapExecuteTransaction  = pdm("Swap.executeTransaction", "The ID of the most recent public transaction that executed the settlement contract")
+	SwapRollbackTransaction = pdm("Swap.rollbackTransaction", "The ID of the public transaction that cancelled the settlement contract")
+	SwapFailureMessage      = pdm("Swap.failureMessage", "Why the swap failed, or why its most recent approve/execute/rollback transaction reverted")
+	SwapLegTransactionID    = pdm("SwapLeg.transactionId", "The ID of the private transaction, which is also the ID of the prepared transaction it results in")
+	SwapLegDomain           = pdm("SwapLeg.domain", "The domain of the private transaction")
+	SwapLegTo               = pdm("SwapLeg.to", "The private smart contract the transaction invokes")
+	SwapOperationContract   = pdm("SwapOperation.contractAddress", "The base ledger contract to call")
+	SwapOperationCallData   = pdm("SwapOperation.callData", "The ABI encoded call data, including the function selector")
+)
+
+// pldapi/transaction_fees.go
+var (
+	TransactionFeeTransactionHash         = pdm("TransactionFee.transactionHash", "The hash of the base ledger transaction the fee was paid for")
+	TransactionFeeTransaction             = pdm("TransactionFee.transaction", "The ID of the Paladin transaction the base ledger transaction was submitted for")
+	TransactionFeeTime                    = pdm("TransactionFee.time", "The timestamp of the block the transaction was mined in")
+	TransactionFeeBlockNumber             = pdm("TransactionFee.blockNumber", "The block the transaction was mined in")
+	TransactionFeeSigner                  = pdm("TransactionFee.signer", "The address that signed, and paid for, the base ledger transaction")
+	TransactionFeeIdentity                = pdm("TransactionFee.identity", "The identity that submitted the Paladin transaction")
+	TransactionFeeTenant                  = pdm("TransactionFee.tenant", "The first segment of the identity, with any node qualifier removed")
+	TransactionFeeDomain                  = pdm("TransactionFee.domain", "The domain of the Paladin transaction, for private transactions")
+	TransactionFeeGasUsed                 = pdm("TransactionFee.gasUsed", "The gas used by the transaction, from the receipt")
+	TransactionFeeEffectiveGasPrice       = pdm("TransactionFee.effectiveGasPrice", "The price in wei paid per unit of gas, from the receipt")
+	TransactionFeeFee                     = pdm("TransactionFee.fee", "The fee in wei - the gas used multiplied by the effective gas price")
+	TransactionFeeReportInputGroupBy      = pdm("TransactionFeeReportInput.groupBy", "How to group the fees - 'day' (UTC, by block time), 'signer', 'identity', 'tenant' or 'domain'. Defaults to 'day'")
+	TransactionFeeReportInputSince        = pdm("TransactionFeeReportInput.since", "Only include transactions mined at or after this time")
+	TransactionFeeReportInputUntil        = pdm("TransactionFeeReportInput.until", "Only include transactions mined before this time")
+	TransactionFeeReportInputSigner       = pdm("TransactionFeeReportInput.signer", "Only include transactions signed by this address")
+	TransactionFeeReportInputTenant       = pdm("TransactionFeeReportInput.tenant", "Only include transactions submitted by identities of this tenant")
+	TransactionFeeReportInputDomain       = pdm("TransactionFeeReportInput.domain", "Only include transactions of this domain")
+	TransactionFeeReportGroupBy           = pdm("TransactionFeeReport.groupBy", "How the fees are grouped")
+	TransactionFeeReportSince             = pdm("TransactionFeeReport.since", "The start of the time range of the report, if one was requested")
+	TransactionFeeReportUntil             = pdm("TransactionFeeReport.until", "The end of the time range of the report, if one was requested")
+	TransactionFeeReportEntries           = pdm("TransactionFeeReport.entries", "The totals for each group, sorted by key")
+	TransactionFeeReportTotal             = pdm("TransactionFeeReport.total", "The totals across all groups")
+	TransactionFeeReportEntryKey          = pdm("TransactionFeeReportEntry.key", "The day (YYYY-MM-DD), signer, identity, tenant or domain of the group")
+	TransactionFeeReportEntryTransactions = pdm("TransactionFeeReportEntry.transactions", "The number of transactions in the group")
+	TransactionFeeReportEntryGasUsed      = pdm("TransactionFeeReportEntry.gasUsed", "The total gas used by the transactions in the group")
+	TransactionFeeReportEntryFee          = pdm("TransactionFeeReportEntry.fee", "The total fee in wei paid for the transactions in the group")
+)
+
+// pldapi/evidence.go
+var (
+	TransactionEndorsementTransactionID   = pdm("TransactionEndorsement.transactionId", "The ID of the transaction the attestation was gathered for")
+	TransactionEndorsementCreated         = pdm("TransactionEndorsement.created", "The time the transaction was dispatched with this attestation")
+	TransactionEndorsementName            = pdm("TransactionEndorsement.name", "The name of the attestation in the attestation plan of the domain")
+	TransactionEndorsementAttestationType = pdm("TransactionEndorsement.attestationType", "The type of attestation - 'SIGN', 'ENDORSE' or 'GENERATE_PROOF'")
+	TransactionEndorsementLookup          = pdm("TransactionEndorsement.lookup", "The identity locator of the party that provided the attestation")
+	TransactionEndorsementVerifier        = pdm("TransactionEndorsement.verifier", "The resolved verifier of the party that provided the attestation")
+	TransactionEndorsementPayloadType     = pdm("TransactionEndorsement.payloadType", "The type of the payload, if the domain requested a particular payload type")
+	TransactionEndorsementPayload         = pdm("TransactionEndorsement.payload", "The attestation payload, such as a signature, as returned by the party")
+	TransactionEndorsementConstraints     = pdm("TransactionEndorsement.constraints", "Constraints the party placed on the submission of the transaction")
+	TransactionEvidenceNode               = pdm("TransactionEvidence.node", "The name of the node that exported the evidence")
+	TransactionEvidenceTransactionID      = pdm("TransactionEvidence.transactionId", "The ID of the transaction")
+	TransactionEvidenceExported           = pdm("TransactionEvidence.exported", "The time the evidence was exported")
+	TransactionEvidenceReceipt            = pdm("TransactionEvidence.receipt", "The receipt of the transaction, including the states and domain receipt when available")
+	TransactionEvidenceEvents             = pdm("TransactionEvidence.events", "The events emitted by the base ledger transaction that finalized the transaction")
+	TransactionEvidenceEndorsements       = pdm("TransactionEvidence.endorsements", "The attestations gathered before dispatch. Only available on the node that coordinated the transaction")
+	SignedTransactionEvidenceFormat       = pdm("SignedTransactionEvidence.format", "The format of the envelope. Currently always 'paladin.evidence.v1'")
+	SignedTransactionEvidencePayload      = pdm("SignedTransactionEvidence.payload", "The exact bytes of the JSON serialized evidence that were signed")
+	SignedTransactionEvidencePayloadHash  = pdm("SignedTransactionEvidence.payloadHash", "The keccak256 hash of the payload")
+	SignedTransactionEvidenceSigner       = pdm("SignedTransactionEvidence.signer", "The Ethereum address of the node key that signed the evidence")
+	SignedTransactionEvidenceSignature    = pdm("SignedTransactionEvidence.signature", "A 65 byte compact secp256k1 R,S,V signature of the payload hash, with a V value of 27 or 28")
+)
+
 // pldclient/registry.go
 var (
 	RegistryEntryRegistry                 = pdm("RegistryEntry.registry", "The registry that maintains this record")
@@ -267,6 +624,25 @@ var (
 	OnChainLocationTransactionIndex       = pdm("OnChainLocation.transactionIndex", "The transaction index within the block")
 	OnChainLocationLogIndex               = pdm("OnChainLocation.logIndex", "The log index within the transaction of the event")
 	ActiveFlagActive                      = pdm("ActiveFlag.active", "When querying with an activeFilter of 'any' or 'inactive', this boolean shows if the entry/property is active or not")
+	RegistryCredentialID                  = pdm("RegistryCredential.id", "The ID of the credential, assigned by the issuer and unique within the registry")
+	RegistryCredentialRegistry            = pdm("RegistryCredential.registry", "The local registry the credential is attached in. Not covered by the proof")
+	RegistryCredentialEntryID             = pdm("RegistryCredential.entryId", "The ID of the registry entry that is the subject of the credential")
+	RegistryCredentialType                = pdm("RegistryCredential.type", "The type of attestation made by the credential, such as 'KYCPassed'")
+	RegistryCredentialIssuer              = pdm("RegistryCredential.issuer", "The Ethereum address of the secp256k1 key of the issuer")
+	RegistryCredentialIssuanceDate        = pdm("RegistryCredential.issuanceDate", "The time the issuer issued the credential")
+	RegistryCredentialExpirationDate      = pdm("RegistryCredential.expirationDate", "The time after which the credential is no longer valid (optional)")
+	RegistryCredentialClaims              = pdm("RegistryCredential.claims", "Name + value pairs of claims the issuer makes about the subject")
+	RegistryCredentialProof               = pdm("RegistryCredential.proof", "A 65 byte compact secp256k1 R,S,V signature by the issuer, over the keccak256 hash of the JSON serialization of the id, entryId, type, issuer, issuanceDate, expirationDate and claims")
+	RegistryCredentialAttached            = pdm("RegistryCredential.attached", "The time the credential was attached on this node. Not covered by the proof")
+	RegistryCredentialRevoked             = pdm("RegistryCredential.revoked", "The time the credential was revoked on this node, if it has been revoked. Not covered by the proof")
+	RegistryCredentialCheckRegistry       = pdm("RegistryCredentialCheck.registry", "The registry containing the entry to check")
+	RegistryCredentialCheckEntryID        = pdm("RegistryCredentialCheck.entryId", "The ID of the entry to check - supply this or entryPath")
+	RegistryCredentialCheckEntryPath      = pdm("RegistryCredentialCheck.entryPath", "The names of each entry from a root entry down to the entry to check - supply this or entryId")
+	RegistryCredentialCheckType           = pdm("RegistryCredentialCheck.type", "The type of credential the entry must hold")
+	RegistryCredentialCheckIssuers        = pdm("RegistryCredentialCheck.issuers", "If set, only credentials from one of these issuers are considered. The trusted issuers configured for the registry always apply")
+	RegistryCredentialCheckResultValid    = pdm("RegistryCredentialCheckResult.valid", "True if the entry holds an un-revoked and un-expired credential of the type, from a trusted issuer")
+	RegistryCredentialCheckResultReason   = pdm("RegistryCredentialCheckResult.reason", "When valid is false, a description of why no credential matched")
+	RegistryCredentialCheckResultCred     = pdm("RegistryCredentialCheckResult.credential", "When valid is true, the credential that matched")
 )
 
 // pldclient/transport.go
@@ -286,6 +662,78 @@ var (
 	PeerStatsLastReceive         = pdm("PeerStats.lastReceive", "Timestamp of the last receive from this peer")
 	PeerStatsReliableHighestSent = pdm("PeerStats.reliableHighestSent", "Outbound reliable messages are assigned a sequence. This is the highest sequence sent to the peer since activation")
 	PeerStatsReliableAckBase     = pdm("PeerStats.reliableAckBase", "Outbound reliable messages are assigned a sequence. This is the lowest sequence that has not received an acknowledgement from the peer")
+	PeerStatsRejectedMsgs        = pdm("PeerStats.rejectedMsgs", "Count of inbound messages from this peer rejected since activation, due to exceeding limits or the peer being banned")
+	PeerStatsRateLimitedMsgs     = pdm("PeerStats.rateLimitedMsgs", "Count of inbound messages from this peer rejected since activation for exceeding the per-peer message or byte rate limits")
+	PeerStatsOversizedMsgs       = pdm("PeerStats.oversizedMsgs", "Count of inbound messages from this peer rejected since activation for exceeding the maximum inbound message size")
+
+	ScheduledJobID          = pdm("ScheduledJob.id", "The ID of the job")
+	ScheduledJobName        = pdm("ScheduledJob.name", "The unique name of a recurring job. Not set for one-shot jobs")
+	ScheduledJobCreated     = pdm("ScheduledJob.created", "The time the job was created")
+	ScheduledJobJobType     = pdm("ScheduledJob.jobType", "The type of the job, which determines the component handler that runs it")
+	ScheduledJobSchedule    = pdm("ScheduledJob.schedule", "The schedule of a recurring job, as a five field cron expression or '@every <duration>'. Not set for one-shot jobs")
+	ScheduledJobPayload     = pdm("ScheduledJob.payload", "JSON data passed to the handler when the job runs")
+	ScheduledJobNextRun     = pdm("ScheduledJob.nextRun", "The time the job is next due to run")
+	ScheduledJobAttempt     = pdm("ScheduledJob.attempt", "The number of consecutive failed attempts to run the job")
+	ScheduledJobLeaseOwner  = pdm("ScheduledJob.leaseOwner", "The scheduler instance that currently holds the lease to run the job")
+	ScheduledJobLeaseExpiry = pdm("ScheduledJob.leaseExpiry", "The time the current lease expires, after which another scheduler instance can run the job")
+	ScheduledJobLastRun     = pdm("ScheduledJob.lastRun", "The time the job last ran")
+	ScheduledJobLastError   = pdm("ScheduledJob.lastError", "The error from the last run of the job, if it failed")
+	ScheduledJobCompleted   = pdm("ScheduledJob.completed", "The time a one-shot job completed, or was abandoned after reaching the maximum attempts")
+
+	BaseContractName             = pdm("BaseContract.name", "The name of the base ledger contract, such as IdentityRegistry or NotoFactory")
+	BaseContractCreated          = pdm("BaseContract.created", "The time the contract was first recorded by this node")
+	BaseContractUpdated          = pdm("BaseContract.updated", "The time the record was last updated, such as when the contract was rebound or redeployed")
+	BaseContractVersion          = pdm("BaseContract.version", "The version of the contract this node is using. For embedded builds this is the hash of the contract bytecode")
+	BaseContractOrigin           = pdm("BaseContract.origin", "Whether the contract was bound from configuration, or deployed by this node from its embedded build")
+	BaseContractAddress          = pdm("BaseContract.address", "The address of the contract on the base ledger. Not set while a deployment is pending")
+	BaseContractTransaction      = pdm("BaseContract.transaction", "The ID of the transaction that deployed the contract, for contracts deployed by this node")
+	BaseContractFailure          = pdm("BaseContract.failure", "The reason the deployment failed, if the deployment transaction reverted")
+	BaseContractEmbeddedVersion  = pdm("BaseContract.embeddedVersion", "The version of the build embedded in this node's release. Not set if this node has no embedded build for the contract")
+	BaseContractUpgradeAvailable = pdm("BaseContract.upgradeAvailable", "True if the embedded build differs from the version of the contract this node is using")
+
+	BaseContractMismatchNode         = pdm("BaseContractMismatch.node", "The peer node that reported a different version or address for the contract")
+	BaseContractMismatchName         = pdm("BaseContractMismatch.name", "The name of the base ledger contract")
+	BaseContractMismatchLocalVersion = pdm("BaseContractMismatch.localVersion", "The version of the contract recorded by this node")
+	BaseContractMismatchPeerVersion  = pdm("BaseContractMismatch.peerVersion", "The version of the contract reported by the peer node")
+	BaseContractMismatchLocalAddress = pdm("BaseContractMismatch.localAddress", "The address of the contract recorded by this node")
+	BaseContractMismatchPeerAddress  = pdm("BaseContractMismatch.peerAddress", "The address of the contract reported by the peer node")
+	BaseContractMismatchDetected     = pdm("BaseContractMismatch.detected", "The time the mismatch was last reported")
+
+	WebhookName    = pdm("Webhook.name", "The unique name of the webhook")
+	WebhookURL     = pdm("Webhook.url", "The HTTP endpoint that events are POSTed to")
+	WebhookSigned  = pdm("Webhook.signed", "True if each payload is signed with an HMAC-SHA256 signature in the X-Paladin-Webhook-Signature header")
+	WebhookFilters = pdm("Webhook.filters", "The filters that select which events are delivered to the webhook")
+
+	WebhookFiltersEventTypes = pdm("WebhookFilters.eventTypes", "The event types delivered to the webhook. All event types are delivered if empty")
+	WebhookFiltersDomain     = pdm("WebhookFilters.domain", "Only deliver events for private transactions in this domain")
+	WebhookFiltersSigner     = pdm("WebhookFilters.signer", "Only deliver events for transactions submitted by this signing identity")
+
+	WebhookEventID        = pdm("WebhookEvent.id", "The ID of the delivery. The same ID is sent on every attempt, so the endpoint can discard duplicates")
+	WebhookEventWebhook   = pdm("WebhookEvent.webhook", "The name of the webhook the event is delivered to")
+	WebhookEventEventType = pdm("WebhookEvent.eventType", "The type of the event")
+	WebhookEventCreated   = pdm("WebhookEvent.created", "The time the event was recorded for delivery")
+	WebhookEventReceipt   = pdm("WebhookEvent.receipt", "The receipt of the transaction that triggered the event")
+
+	WebhookDeliveryID             = pdm("WebhookDelivery.id", "The ID of the delivery, which is also the ID of the event sent to the endpoint")
+	WebhookDeliveryWebhook        = pdm("WebhookDelivery.webhook", "The name of the webhook")
+	WebhookDeliveryCreated        = pdm("WebhookDelivery.created", "The time the delivery was recorded")
+	WebhookDeliveryEventType      = pdm("WebhookDelivery.eventType", "The type of the event")
+	WebhookDeliveryTransaction    = pdm("WebhookDelivery.transaction", "The ID of the transaction that triggered the event")
+	WebhookDeliverySequence       = pdm("WebhookDelivery.sequence", "The sequence of the transaction receipt that triggered the event")
+	WebhookDeliveryStatus         = pdm("WebhookDelivery.status", "Whether the delivery is pending, was delivered, or failed once the attempts were exhausted")
+	WebhookDeliveryAttempts       = pdm("WebhookDelivery.attempts", "The number of attempts made since the delivery was recorded or last manually retried")
+	WebhookDeliveryLastAttempt    = pdm("WebhookDelivery.lastAttempt", "The time of the last attempt")
+	WebhookDeliveryLastStatusCode = pdm("WebhookDelivery.lastStatusCode", "The HTTP status code returned by the endpoint on the last attempt. Not set if no response was received")
+	WebhookDeliveryLastError      = pdm("WebhookDelivery.lastError", "The error from the last failed attempt")
+	WebhookDeliveryDelivered      = pdm("WebhookDelivery.delivered", "The time the endpoint accepted the event")
+	WebhookDeliveryPayload        = pdm("WebhookDelivery.payload", "The JSON body POSTed to the endpoint")
+
+	PeerBanNode       = pdm("PeerBan.node", "The name of the banned peer node")
+	PeerBanBanned     = pdm("PeerBan.banned", "The time the ban was applied")
+	PeerBanExpires    = pdm("PeerBan.expires", "The time the ban expires, after which messages from the peer are accepted again")
+	PeerBanViolations = pdm("PeerBan.violations", "The number of inbound limit violations within the ban window that triggered the ban")
+	PeerBanReason     = pdm("PeerBan.reason", "The most recent violation that triggered the ban")
+	PeerBanRejected   = pdm("PeerBan.rejected", "Count of inbound messages from the peer rejected while the ban has been in place")
 
 	ReliableMessageSequence    = pdm("ReliableMessage.sequence", "Sequence number for the position of this message in the local database")
 	ReliableMessageID          = pdm("ReliableMessage.id", "UUID for this message. A separate message, with a separate ID, is allocated for each participant that will receive the message")
@@ -315,6 +763,21 @@ var (
 	PrivacyGroupEVMTXPublicTxOptions = pdm("PrivacyGroupEVMTX.publicTxOptions", "The public transaction submission options to use in the resulting transaction submission")
 
 	PrivacyGroupInputTransactionOptions = pdm("PrivacyGroupInput.transactionOptions", "Options that will be propagated to the final private transaction that is submitted after the domain has validated the input properties and generated the base private transaction")
+	PrivacyGroupInputTemplate           = pdm("PrivacyGroupInput.template", "The name of a privacy group template to apply, which supplies the domain, default properties, configuration and member roles for the group (optional)")
+	PrivacyGroupInputRoles              = pdm("PrivacyGroupInput.roles", "Members assigned to each role declared by the template. Role members are added to the member list, and recorded in the 'role.<name>' group properties")
+
+	PrivacyGroupTemplateName               = pdm("PrivacyGroupTemplate.name", "Unique name for the template")
+	PrivacyGroupTemplateSource             = pdm("PrivacyGroupTemplate.source", "Whether the template was defined in the node configuration, or registered over the API")
+	PrivacyGroupTemplateCreated            = pdm("PrivacyGroupTemplate.created", "Time the template was registered - not set for templates defined in configuration")
+	PrivacyGroupTemplateDomain             = pdm("PrivacyGroupTemplate.domain", "The domain of privacy groups created from the template")
+	PrivacyGroupTemplateProperties         = pdm("PrivacyGroupTemplate.properties", "Default application specific properties, which can be overridden when creating the group")
+	PrivacyGroupTemplateRequiredProperties = pdm("PrivacyGroupTemplate.requiredProperties", "Names of properties that must have a value after the defaults have been applied")
+	PrivacyGroupTemplateConfiguration      = pdm("PrivacyGroupTemplate.configuration", "Default domain specific configuration, which can be overridden when creating the group")
+	PrivacyGroupTemplateRoles              = pdm("PrivacyGroupTemplate.roles", "The member roles that groups created from the template can assign")
+
+	PrivacyGroupTemplateRoleName       = pdm("PrivacyGroupTemplateRole.name", "Name of the role")
+	PrivacyGroupTemplateRoleMinMembers = pdm("PrivacyGroupTemplateRole.minMembers", "The minimum number of members that must be assigned to the role")
+	PrivacyGroupTemplateRoleMaxMembers = pdm("PrivacyGroupTemplateRole.maxMembers", "The maximum number of members that can be assigned to the role (optional - unlimited if not set)")
 
 	PrivacyGroupID                 = pdm("PrivacyGroup.id", "The ID of the group, which is the hash-derived ID of the genesis state (assured to be unique within the domain)")
 	PrivacyGroupDomain             = pdm("PrivacyGroup.domain", "The domain of the privacy group")
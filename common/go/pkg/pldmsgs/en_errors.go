@@ -51,9 +51,17 @@ var (
 	MsgTypesTypeInferenceNotSupportedForX    = pde("PD020021", "ABI type inference not supported for '%s' property of type %T")
 	MsgTypesNumberTypeInferenceRequiresInt   = pde("PD020022", "ABI type inference only support integer JSON numbers. Property '%s' has non-integer value '%s'")
 	MsgTypesCannotInferTypeOfEmptyArray      = pde("PD020023", "ABI type inference cannot determine type of empty array '%s'")
+	MsgTypesInvalidDecimal                   = pde("PD020024", "Invalid decimal: %s", 400)
+	MsgTypesDecimalPrecisionLoss             = pde("PD020025", "Decimal %s cannot be represented with %d decimal places without losing precision", 400)
+	MsgTypesDecimalScaleInvalid              = pde("PD020026", "Decimal scale %d must be between 0 and %d", 400)
+	MsgTypesEvidenceFormatUnsupported        = pde("PD020027", "Unsupported transaction evidence format '%s'")
+	MsgTypesEvidenceHashMismatch             = pde("PD020028", "Transaction evidence payload does not match the payload hash %s")
+	MsgTypesEvidenceSignerMismatch           = pde("PD020029", "Transaction evidence was signed by %s rather than the signer %s")
+	MsgTypesCredentialIssuerMismatch         = pde("PD020030", "Credential '%s' was signed by %s rather than the issuer %s")
 
 	// Inflight PD0201XX
 	MsgInflightRequestCancelled = pde("PD020100", "Request cancelled after %s")
+	MsgInflightOverloaded       = pde("PD020101", "Too many requests in-flight (max=%d)", 429)
 
 	// PldClient module PD0202XX
 	MsgPaladinClientInvalidInput      = pde("PD020200", "Unable to convert to ABI function input (%s)")
@@ -104,15 +112,27 @@ var (
 	MsgHTTPServerMissingPort        = pde("PD020601", "HTTP server port must be specified for '%s'")
 	MsgHTTPServerNoWSUpgradeSupport = pde("PD020602", "HTTP server does not support WebSocket upgrade (%T)")
 	MsgUIServerFailed               = pde("PD020603", "HTTP server failed to load index file", 500)
+	MsgHTTPServerSNIWithoutTLS      = pde("PD020604", "HTTP server '%s' has SNI configuration, but TLS is not enabled")
+	MsgHTTPServerSNINoServerNames   = pde("PD020605", "HTTP server '%s' SNI entry %d has no server names")
 
 	// JSON/RPC PD0207XX
-	MsgJSONRPCInvalidRequest      = pde("PD020700", "Invalid JSON/RPC request data")
-	MsgJSONRPCMissingRequestID    = pde("PD020701", "Invalid JSON/RPC request. Must set request ID")
-	MsgJSONRPCUnsupportedMethod   = pde("PD020702", "method not supported %s")
-	MsgJSONRPCIncorrectParamCount = pde("PD020703", "method %s requires %d params (supplied=%d)")
-	MsgJSONRPCInvalidParam        = pde("PD020704", "method %s parameter %d invalid: %s")
-	MsgJSONRPCResultSerialization = pde("PD020705", "method %s result serialization failed: %s")
-	MsgJSONRPCAysncNonWSConn      = pde("PD020706", "method %s only available on WebSocket connections")
+	MsgJSONRPCInvalidRequest       = pde("PD020700", "Invalid JSON/RPC request data")
+	MsgJSONRPCMissingRequestID     = pde("PD020701", "Invalid JSON/RPC request. Must set request ID")
+	MsgJSONRPCUnsupportedMethod    = pde("PD020702", "method not supported %s")
+	MsgJSONRPCIncorrectParamCount  = pde("PD020703", "method %s requires %d params (supplied=%d)")
+	MsgJSONRPCInvalidParam         = pde("PD020704", "method %s parameter %d invalid: %s")
+	MsgJSONRPCResultSerialization  = pde("PD020705", "method %s result serialization failed: %s")
+	MsgJSONRPCAysncNonWSConn       = pde("PD020706", "method %s only available on WebSocket connections")
+	MsgJSONRPCAdminAuthRequired    = pde("PD020707", "method %s requires admin authorization")
+	MsgJSONRPCAdminTokenFile       = pde("PD020708", "Failed to read admin token file '%s'")
+	MsgJSONRPCAdminTokenEmpty      = pde("PD020709", "Admin token file '%s' is empty")
+	MsgJSONRPCTenantAuthRequired   = pde("PD020710", "method %s requires a tenant or admin token")
+	MsgJSONRPCTenantInvalid        = pde("PD020711", "Tenant %d is invalid: name and token file are required, and the name and token must be unique")
+	MsgJSONRPCTenantDomainDenied   = pde("PD020712", "Tenant '%s' is not permitted to use domain '%s'", 403)
+	MsgJSONRPCTenantTokenFile      = pde("PD020713", "Failed to read token file '%s' for tenant '%s'")
+	MsgJSONRPCAdminInvalid         = pde("PD020714", "Admin %d is invalid: name and token file are required, and the name and token must be unique")
+	MsgJSONRPCTenantIdentityDenied = pde("PD020715", "Tenant '%s' is not permitted to use identity '%s'", 403)
+	MsgJSONRPCTenantContractDenied = pde("PD020716", "Tenant '%s' is not permitted to access contract '%s'", 403)
 
 	// Signing module PD0208XX
 	MsgSigningModuleBadPathError                = pde("PD020800", "Path '%s' does not exist, or it is not a directory")
@@ -122,6 +142,14 @@ var (
 	MsgSigningModuleFSError                     = pde("PD020804", "Filesystem error")
 	MsgSigningModuleKeyHandleClash              = pde("PD020805", "Invalid key handle (clash)")
 	MsgSigningModuleKeyNotExist                 = pde("PD020806", "Key '%s' does not exist")
+	MsgSigningModuleMasterPasswordMissing       = pde("PD020828", "Master password for the keystore could not be loaded")
+	MsgSigningModuleKDFInvalid                  = pde("PD020829", "Invalid key derivation function '%s'")
+	MsgSigningModuleManifestInvalid             = pde("PD020830", "Keystore manifest '%s' could not be verified. The master password is incorrect, or the manifest has been tampered with")
+	MsgSigningModuleKeyFileDecrypt              = pde("PD020831", "Key file '%s' could not be decrypted with the master password")
+	MsgSigningModuleKeyFileRollback             = pde("PD020832", "Key file '%s' is at generation %d, but the keystore is at generation %d. The file might have been rolled back")
+	MsgSigningModuleUnencryptedKeyFiles         = pde("PD020833", "Keystore '%s' contains key files that are not encrypted with the master password. Run the master password rotation to migrate them")
+	MsgSigningModuleKeyFileFormat               = pde("PD020834", "Key file '%s' is not in the expected format")
+	MsgSigningModuleNoMasterPassword            = pde("PD020835", "Keystore is not configured with a master password")
 	MsgSigningUnsupportedKeyStoreType           = pde("PD020807", "Unsupported key store type: '%s'")
 	MsgSigningHierarchicalRequiresLoading       = pde("PD020808", "Signing module has been configured to disallow in-memory key material. Hierarchical Deterministic (HD) wallet function implemented in the signing module requires in-memory key material")
 	MsgSigningKeyStoreNoInStoreSingingSupport   = pde("PD020809", "They configured key store '%s' does not support signing within the keystore itself (keys must be loaded into memory in the module to sign)")
@@ -143,6 +171,10 @@ var (
 	MsgSigningEmptyPayload                      = pde("PD020825", "No payload supplied for signing")
 	MsgSigningInvalidDomainAlgorithmNoPrefix    = pde("PD020826", "Invalid domain algorithm (no 'domain:' prefix): %s")
 	MsgSigningNoDomainRegisteredWithModule      = pde("PD020827", "Domain '%s' has not been registered in this signing module")
+	MsgSigningInvalidTypedData                  = pde("PD020836", "Invalid EIP-712 typed data payload")
+	MsgSigningSessionFailed                     = pde("PD020837", "Signing session %s failed: %s")
+	MsgSigningSessionTimeout                    = pde("PD020838", "Signing session %s was still in progress after %s. It will be resumed if the request is retried")
+	MsgSigningSessionInvalidStatus              = pde("PD020839", "Signing session %s returned invalid status '%s'")
 
 	// Reference markdown PD0209XX
 	MsgReferenceMarkdownMissing = pde("PD020900", "Reference markdown file missing: '%s'")
@@ -151,4 +183,17 @@ var (
 	// SolUtils module PD0210XX
 	MsgSolBuildParseFailed = pde("PD021000", "Invalid link hash at position %d in bytecode. Fully qualified lib name: %s. Placeholder: %s. Lib name hash prefix: %s")
 	MsgSolBuildMissingLink = pde("PD021001", "The solidity build is unlinked and requires an address for '%s'")
+
+	// KeyedQueue PD0211XX
+	MsgKeyedQueueDraining      = pde("PD021100", "Queue is draining and cannot start processing for key '%v'")
+	MsgKeyedQueueWaitCancelled = pde("PD021101", "Context cancelled after %s waiting for capacity to process key '%v'")
+
+	// Binding generator PD0212XX
+	MsgBindgenPackageRequired   = pde("PD021200", "A Go package name is required to generate bindings")
+	MsgBindgenDomainRequired    = pde("PD021201", "The domain descriptor must include the name of the domain")
+	MsgBindgenUnsupportedType   = pde("PD021202", "ABI type '%s' of '%s' is not supported in generated bindings")
+	MsgBindgenStateNotTuple     = pde("PD021203", "State '%s' in the domain descriptor must be a tuple")
+	MsgBindgenUnnamedParameter  = pde("PD021204", "Parameter %d of '%s' must be named to generate a binding")
+	MsgBindgenInvalidABI        = pde("PD021205", "The file does not contain an ABI array, or a build artifact with an 'abi' field")
+	MsgBindgenInvalidDescriptor = pde("PD021206", "Invalid domain descriptor")
 )
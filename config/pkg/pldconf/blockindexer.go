@@ -23,14 +23,43 @@ import (
 )
 
 type BlockIndexerConfig struct {
-	FromBlock             json.RawMessage    `json:"fromBlock,omitempty"` // TODO: this should be a pldtypes.RawJSON but that's not possible right now because of a ciruclar dependency
-	CommitBatchSize       *int               `json:"commitBatchSize"`
-	CommitBatchTimeout    *string            `json:"commitBatchTimeout"`
-	RequiredConfirmations *int               `json:"requiredConfirmations"`
-	ChainHeadCacheLen     *int               `json:"chainHeadCacheLen"`
-	BlockPollingInterval  *string            `json:"blockPollingInterval"`
-	EventStreams          EventStreamsConfig `json:"eventStreams"`
-	Retry                 RetryConfig        `json:"retry"`
+	FromBlock             json.RawMessage          `json:"fromBlock,omitempty"` // TODO: this should be a pldtypes.RawJSON but that's not possible right now because of a ciruclar dependency
+	CommitBatchSize       *int                     `json:"commitBatchSize"`
+	CommitBatchTimeout    *string                  `json:"commitBatchTimeout"`
+	RequiredConfirmations *int                     `json:"requiredConfirmations"`
+	ChainHeadCacheLen     *int                     `json:"chainHeadCacheLen"`
+	BlockPollingInterval  *string                  `json:"blockPollingInterval"`
+	ChainTimeDriftWarning *string                  `json:"chainTimeDriftWarning"` // warn when block timestamps differ from the local clock by more than this as they arrive
+	EventStreams          EventStreamsConfig       `json:"eventStreams"`
+	Backfill              BackfillConfig           `json:"backfill"`
+	HeaderVerification    HeaderVerificationConfig `json:"headerVerification"`
+	Retry                 RetryConfig              `json:"retry"`
+}
+
+// When enabled, the hash of every block fetched from the node is recalculated from its header, rather than
+// trusting the node, and any block that does not link to the verified chain through its parent hash is flagged.
+// For the BFT consensus algorithms, which have immediate finality, the committed seals are also checked against
+// the configured validators, and a block that replaces a verified block is flagged. Discrepancies are logged and
+// reported by bidx_getHeaderVerificationStatus - they do not stop indexing.
+type HeaderVerificationConfig struct {
+	Enabled    *bool    `json:"enabled"`
+	Consensus  *string  `json:"consensus"`  // "none", or "qbft" / "ibft2" to verify committed seals and finality
+	Validators []string `json:"validators"` // the validators seals must come from - required for "qbft" / "ibft2", and must be updated when the validator set changes
+}
+
+var HeaderVerificationDefaults = &HeaderVerificationConfig{
+	Enabled:   confutil.P(false),
+	Consensus: confutil.P("none"),
+}
+
+type BackfillConfig struct {
+	Concurrency *int `json:"concurrency"` // default number of parallel workers for a backfill
+	BatchSize   *int `json:"batchSize"`   // default number of blocks each worker indexes in a single DB transaction
+}
+
+var BlockIndexerBackfillDefaults = &BackfillConfig{
+	Concurrency: confutil.P(4),
+	BatchSize:   confutil.P(50),
 }
 
 type EventStreamsConfig struct {
@@ -50,4 +79,5 @@ var BlockIndexerDefaults = &BlockIndexerConfig{
 	RequiredConfirmations: confutil.P(0),
 	ChainHeadCacheLen:     confutil.P(50),
 	BlockPollingInterval:  confutil.P("10s"),
+	ChainTimeDriftWarning: confutil.P("1m"),
 }
@@ -35,4 +35,11 @@ type PaladinConfig struct {
 	PublicTxManager        PublicTxManagerConfig  `json:"publicTxManager"`
 	IdentityResolver       IdentityResolverConfig `json:"identityResolver"`
 	GroupManager           GroupManagerConfig     `json:"groupManager"`
+	JobScheduler           JobSchedulerConfig     `json:"jobScheduler"`
+	ContractManager        ContractManagerConfig  `json:"contractManager"`
+	Webhooks               WebhooksConfig         `json:"webhooks"`
+	Backup                 BackupConfig           `json:"backup"`
+	Drain                  DrainConfig            `json:"drain"`
+	ConfigReload           ConfigReloadConfig     `json:"configReload"`
+	Health                 HealthConfig           `json:"health"`
 }
@@ -0,0 +1,27 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package pldconf
+
+type ContractManagerConfig struct {
+	From      string                         `json:"from"`      // the signing identity used to deploy base ledger contracts from their embedded builds
+	Contracts map[string]*BaseContractConfig `json:"contracts"` // keyed by contract name, such as IdentityRegistry or NotoFactory
+}
+
+type BaseContractConfig struct {
+	Address    *string `json:"address"`    // bind to a contract that is already deployed at this address
+	Version    *string `json:"version"`    // the version recorded for a bound contract - defaults to the version of the embedded build
+	AutoDeploy *bool   `json:"autoDeploy"` // deploy the contract from its embedded build on startup, if no deployment has been recorded
+}
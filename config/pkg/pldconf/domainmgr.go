@@ -26,7 +26,8 @@ type DomainManagerConfig struct {
 }
 
 type DomainManagerManagerConfig struct {
-	ContractCache CacheConfig `json:"contractCache"`
+	ContractCache    CacheConfig    `json:"contractCache"`
+	PrivateTxWaiters InflightConfig `json:"privateTxWaiters"`
 }
 
 type DomainConfig struct {
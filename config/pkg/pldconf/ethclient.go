@@ -20,11 +20,39 @@ import (
 )
 
 type EthClientConfig struct {
-	WS                WSClientConfig   `json:"ws"`
-	HTTP              HTTPClientConfig `json:"http"`
-	EstimateGasFactor *float64         `json:"gasEstimateFactor"`
+	WS                 WSClientConfig           `json:"ws"`
+	HTTP               HTTPClientConfig         `json:"http"`
+	EstimateGasFactor  *float64                 `json:"gasEstimateFactor"`
+	GasEstimatePadding GasEstimatePaddingConfig `json:"gasEstimatePadding"`
+	ChainProfile       ChainProfileConfig       `json:"chainProfile"`
+}
+
+// How transactions are encoded and signed for the chain. The defaults suit any modern EVM chain, and are
+// only changed for legacy or private chains (such as Quorum) that do not accept EIP-1559 transactions.
+type ChainProfileConfig struct {
+	TransactionType *string `json:"transactionType"` // one of eip1559, legacy_eip155 or legacy_original (pre-EIP-155, with no chain ID in the signature)
+	ChainID         *int64  `json:"chainId"`         // the chain ID to sign with, used in place of querying eth_chainId (for chains that do not support it, or report a different ID)
+	VBase           *int64  `json:"vBase"`           // for legacy_original, a custom base added to the recovery ID in place of 27 (such as 37 for Quorum private transactions)
+}
+
+// Padding added to a gas estimate to get the gas limit for a transaction. When percent is not set the
+// gas estimate factor is used, so the minimum can be used on its own to top up small estimates.
+type GasEstimatePaddingConfig struct {
+	Percent   *float64                               `json:"percent"`   // the percentage of the estimate to add
+	Minimum   *uint64                                `json:"minimum"`   // the minimum amount of gas to add, whatever the percentage
+	Contracts map[string]*GasEstimatePaddingOverride `json:"contracts"` // overrides keyed by the to address of the transaction
+}
+
+// Overrides for an individual contract, where estimation is known to diverge from execution. Fields that
+// are not set are inherited from the top level policy.
+type GasEstimatePaddingOverride struct {
+	Percent *float64 `json:"percent"`
+	Minimum *uint64  `json:"minimum"`
 }
 
 var EthClientDefaults = &EthClientConfig{
 	EstimateGasFactor: confutil.P(2.0),
+	ChainProfile: ChainProfileConfig{
+		TransactionType: confutil.P("eip1559"),
+	},
 }
@@ -19,6 +19,29 @@ import "github.com/kaleido-io/paladin/config/pkg/confutil"
 type GroupManagerConfig struct {
 	Cache            CacheConfig      `json:"cache"`
 	MessageListeners MessageListeners `json:"messageListeners"`
+	Templates        GroupTemplates   `json:"templates"`
+}
+
+type GroupTemplates struct {
+	Definitions          []*GroupTemplateConfig `json:"definitions"`          // templates available on this node, which cannot be modified via the API
+	Allowed              []string               `json:"allowed"`              // if set, only templates with these names can be used to create groups
+	Required             *bool                  `json:"required"`             // if true, every group must be created from a template
+	AllowAPIRegistration *bool                  `json:"allowAPIRegistration"` // if false, templates can only be defined in configuration
+}
+
+type GroupTemplateConfig struct {
+	Name               string                     `json:"name"`
+	Domain             string                     `json:"domain"`
+	Properties         map[string]string          `json:"properties"`
+	RequiredProperties []string                   `json:"requiredProperties"`
+	Configuration      map[string]string          `json:"configuration"`
+	Roles              []*GroupTemplateRoleConfig `json:"roles"`
+}
+
+type GroupTemplateRoleConfig struct {
+	Name       string `json:"name"`
+	MinMembers int    `json:"minMembers"`
+	MaxMembers *int   `json:"maxMembers"`
 }
 
 type MessageListeners struct {
@@ -34,4 +57,8 @@ var GroupManagerDefaults = &GroupManagerConfig{
 		Retry:        GenericRetryDefaults.RetryConfig,
 		ReadPageSize: confutil.P(100),
 	},
+	Templates: GroupTemplates{
+		Required:             confutil.P(false),
+		AllowAPIRegistration: confutil.P(true),
+	},
 }
@@ -21,19 +21,27 @@ import (
 )
 
 type HTTPServerConfig struct {
-	TLS                   TLSConfig  `json:"tls"`
-	CORS                  CORSConfig `json:"cors"`
-	Address               *string    `json:"address"`
-	Port                  *int       `json:"port"`
-	DefaultRequestTimeout *string    `json:"defaultRequestTimeout"`
-	MaxRequestTimeout     *string    `json:"maxRequestTimeout"`
-	ReadTimeout           *string    `json:"readTimeout"`
-	WriteTimeout          *string    `json:"writeTimeout"`
-	ShutdownTimeout       *string    `json:"shutdownTimeout"`
+	TLS                   TLSConfig             `json:"tls"`
+	SNI                   []HTTPServerSNIConfig `json:"sni,omitempty"`      // TLS configuration selected by the server name the client requests, falling back to "tls" when none match
+	CertReloadInterval    *string               `json:"certReloadInterval"` // minimum interval between checks for changes to certificate and key files, on new connections. Zero to disable
+	CORS                  CORSConfig            `json:"cors"`
+	Address               *string               `json:"address"`
+	Port                  *int                  `json:"port"`
+	DefaultRequestTimeout *string               `json:"defaultRequestTimeout"`
+	MaxRequestTimeout     *string               `json:"maxRequestTimeout"`
+	ReadTimeout           *string               `json:"readTimeout"`
+	WriteTimeout          *string               `json:"writeTimeout"`
+	ShutdownTimeout       *string               `json:"shutdownTimeout"`
+}
+
+type HTTPServerSNIConfig struct {
+	ServerNames []string  `json:"serverNames"` // exact host names, or a wildcard for a single label such as "*.example.com"
+	TLS         TLSConfig `json:"tls"`         // the "enabled" flag is implied
 }
 
 var HTTPDefaults = &HTTPServerConfig{
 	Address:               confutil.P("127.0.0.1"),
+	CertReloadInterval:    confutil.P("30s"),
 	DefaultRequestTimeout: confutil.P("2m"),
 	MaxRequestTimeout:     confutil.P("10m"),
 	ShutdownTimeout:       confutil.P("10s"),
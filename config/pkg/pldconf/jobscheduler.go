@@ -0,0 +1,37 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package pldconf
+
+import "github.com/kaleido-io/paladin/config/pkg/confutil"
+
+type JobSchedulerConfig struct {
+	PollInterval  *string            `json:"pollInterval"`  // how often the DB is checked for jobs that are due
+	LeaseDuration *string            `json:"leaseDuration"` // how long a job is held by one replica before another can take it over
+	Workers       *int               `json:"workers"`       // the maximum number of jobs run concurrently by this node
+	BatchSize     *int               `json:"batchSize"`     // the maximum number of due jobs read on each poll
+	Retry         RetryConfigWithMax `json:"retry"`         // backoff between failed attempts, and the attempts before a one-shot job is abandoned
+}
+
+var JobSchedulerDefaults = &JobSchedulerConfig{
+	PollInterval:  confutil.P("1s"),
+	LeaseDuration: confutil.P("5m"),
+	Workers:       confutil.P(5),
+	BatchSize:     confutil.P(50),
+	Retry: RetryConfigWithMax{
+		RetryConfig: GenericRetryDefaults.RetryConfig,
+		MaxAttempts: confutil.P(10),
+	},
+}
@@ -0,0 +1,29 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pldconf
+
+import "github.com/kaleido-io/paladin/config/pkg/confutil"
+
+type KeyedQueueConfig struct {
+	MaxActive   *int    `json:"maxActive"`   // the number of keys that can be processed concurrently - zero for no limit
+	SwapTimeout *string `json:"swapTimeout"` // when all slots are full, keys processed for longer than this are swapped out for those waiting
+}
+
+var KeyedQueueDefaults = &KeyedQueueConfig{
+	MaxActive:   confutil.P(0),
+	SwapTimeout: confutil.P("10m"),
+}
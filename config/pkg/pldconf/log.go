@@ -21,6 +21,8 @@ import "github.com/kaleido-io/paladin/config/pkg/confutil"
 type LogConfig struct {
 	// the logging level
 	Level *string `json:"level"`
+	// independent logging levels for individual subsystems, such as 'publictxmgr' or 'blockindexer'
+	Subsystems map[string]string `json:"subsystems"`
 	// the format ('simple', 'json')
 	Format *string `json:"format"`
 	// the output location ('stdout','stderr','file')
@@ -19,7 +19,29 @@ import (
 )
 
 type PluginManagerConfig struct {
-	GRPC GRPCConfig `json:"grpc"`
+	GRPC         GRPCConfig              `json:"grpc"`
+	RestartRetry RetryConfig             `json:"restartRetry"` // backoff between attempts to restart a plugin that has exited
+	HealthCheck  PluginHealthCheckConfig `json:"healthCheck"`
+}
+
+var PluginManagerDefaults = &PluginManagerConfig{
+	GRPC:         *DefaultGRPCConfig,
+	RestartRetry: GenericRetryDefaults.RetryConfig,
+	HealthCheck:  *PluginHealthCheckDefaults,
+}
+
+// Each connected plugin is pinged periodically, and a plugin that does not respond within the timeout
+// is disconnected and restarted by the loader
+type PluginHealthCheckConfig struct {
+	Enabled  *bool   `json:"enabled"`
+	Interval *string `json:"interval"`
+	Timeout  *string `json:"timeout"`
+}
+
+var PluginHealthCheckDefaults = &PluginHealthCheckConfig{
+	Enabled:  confutil.P(true),
+	Interval: confutil.P("30s"),
+	Timeout:  confutil.P("30s"),
 }
 
 type GRPCConfig struct {
@@ -31,7 +53,8 @@ var DefaultGRPCConfig = &GRPCConfig{
 }
 
 type PluginConfig struct {
-	Type    string  `json:"type"`
-	Library string  `json:"library"`
-	Class   *string `json:"class,omitempty"`
+	Type      string   `json:"type"`
+	Library   string   `json:"library"`
+	Class     *string  `json:"class,omitempty"`
+	Classpath []string `json:"classpath,omitempty"` // additional JAR files or directories for the class loader of a jar plugin
 }
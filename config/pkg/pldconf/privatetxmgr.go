@@ -17,11 +17,21 @@ package pldconf
 import "github.com/kaleido-io/paladin/config/pkg/confutil"
 
 type PrivateTxManagerConfig struct {
-	Writer                         FlushWriterConfig               `json:"writer"`
-	Sequencer                      PrivateTxManagerSequencerConfig `json:"sequencer"`
-	StateDistributer               DistributerConfig               `json:"stateDistributer"`
-	PreparedTransactionDistributer DistributerConfig               `json:"preparedTransactionDistributer"`
-	RequestTimeout                 *string                         `json:"requestTimeout"`
+	Writer                         FlushWriterConfig                 `json:"writer"`
+	Sequencer                      PrivateTxManagerSequencerConfig   `json:"sequencer"`
+	SequencerPool                  KeyedQueueConfig                  `json:"sequencerPool"` // bounds the number of contracts sequenced concurrently
+	StateDistributer               DistributerConfig                 `json:"stateDistributer"`
+	PreparedTransactionDistributer DistributerConfig                 `json:"preparedTransactionDistributer"`
+	RequestTimeout                 *string                           `json:"requestTimeout"`
+	OrderedContracts               []PrivateTxManagerOrderedContract `json:"orderedContracts"`
+}
+
+// A contract where every transaction is coordinated by a single elected ordering node, rather than
+// by the node selected by the domain's coordinator selection policy. Every node that submits
+// transactions to the contract must be configured with the same ordering nodes.
+type PrivateTxManagerOrderedContract struct {
+	ContractAddress string   `json:"contractAddress"`
+	OrderingNodes   []string `json:"orderingNodes"`
 }
 
 type DistributerConfig struct {
@@ -45,17 +55,34 @@ var PrivateTxManagerDefaults = &PrivateTxManagerConfig{
 		MaxPendingEvents:                    confutil.P(500),
 		RoundRobinCoordinatorBlockRangeSize: confutil.P(100),
 		AssembleRequestTimeout:              confutil.P("1s"),
+		CoordinatorFailoverTimeout:          confutil.P("5m"),
+		CoordinatorHeartbeatInterval:        confutil.P("30s"),
+		EndorsementBatchMaxSize:             confutil.P(1), // batching is off by default, as nodes that do not support it will drop the batches
+		EndorsementBatchTimeout:             confutil.P("10ms"),
+		ReassembleRetry: RetryConfigWithMax{
+			RetryConfig: RetryConfig{
+				InitialDelay: confutil.P("100ms"),
+				MaxDelay:     confutil.P("5s"),
+				Factor:       confutil.P(2.0),
+			},
+			MaxAttempts: confutil.P(5),
+		},
 	},
 	RequestTimeout: confutil.P("1s"),
 }
 
 type PrivateTxManagerSequencerConfig struct {
-	MaxConcurrentProcess                *int    `json:"maxConcurrentProcess,omitempty"`
-	MaxInflightTransactions             *int    `json:"maxInflightTransactions,omitempty"`
-	MaxPendingEvents                    *int    `json:"maxPendingEvents,omitempty"`
-	EvaluationInterval                  *string `json:"evalInterval,omitempty"`
-	PersistenceRetryTimeout             *string `json:"persistenceRetryTimeout,omitempty"`
-	StaleTimeout                        *string `json:"staleTimeout,omitempty"`
-	RoundRobinCoordinatorBlockRangeSize *int    `json:"roundRobinCoordinatorBlockRangeSize,omitempty"`
-	AssembleRequestTimeout              *string `json:"assembleRequestTimeout,omitempty"`
+	MaxConcurrentProcess                *int               `json:"maxConcurrentProcess,omitempty"`
+	MaxInflightTransactions             *int               `json:"maxInflightTransactions,omitempty"`
+	MaxPendingEvents                    *int               `json:"maxPendingEvents,omitempty"`
+	EvaluationInterval                  *string            `json:"evalInterval,omitempty"`
+	PersistenceRetryTimeout             *string            `json:"persistenceRetryTimeout,omitempty"`
+	StaleTimeout                        *string            `json:"staleTimeout,omitempty"`
+	RoundRobinCoordinatorBlockRangeSize *int               `json:"roundRobinCoordinatorBlockRangeSize,omitempty"`
+	AssembleRequestTimeout              *string            `json:"assembleRequestTimeout,omitempty"`
+	CoordinatorFailoverTimeout          *string            `json:"coordinatorFailoverTimeout,omitempty"`
+	CoordinatorHeartbeatInterval        *string            `json:"coordinatorHeartbeatInterval,omitempty"`
+	EndorsementBatchMaxSize             *int               `json:"endorsementBatchMaxSize,omitempty"`
+	EndorsementBatchTimeout             *string            `json:"endorsementBatchTimeout,omitempty"`
+	ReassembleRetry                     RetryConfigWithMax `json:"reassembleRetry"`
 }
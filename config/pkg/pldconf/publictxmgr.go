@@ -25,6 +25,9 @@ type PublicTxManagerConfig struct {
 	GasPrice       GasPriceConfig                    `json:"gasPrice"`
 	BalanceManager BalanceManagerConfig              `json:"balanceManager"`
 	GasLimit       GasLimitConfig                    `json:"gasLimit"`
+	Submission     PublicTxSubmissionConfig          `json:"submission"`
+	Validation     PublicTxValidationConfig          `json:"validation"`
+	Compression    PublicTxCompressionConfig         `json:"compression"`
 }
 
 var PublicTxManagerDefaults = &PublicTxManagerConfig{
@@ -53,14 +56,40 @@ var PublicTxManagerDefaults = &PublicTxManagerConfig{
 			},
 			RecordsPerTransaction: confutil.P(25),
 		},
+		LatencyTracing: PublicTxManagerLatencyTracingConfig{
+			Enabled: confutil.P(true),
+			CacheConfig: CacheConfig{
+				Capacity: confutil.P(1000),
+			},
+			SampleWindow: confutil.P(1000),
+		},
+		EventNotifier: PublicTxManagerEventNotifierConfig{
+			BatchSize:    confutil.P(50),
+			PollInterval: confutil.P("5s"),
+		},
+		NodeRestartDetection: PublicTxManagerNodeRestartDetectionConfig{
+			Enabled:   confutil.P(true),
+			Interval:  confutil.P("30s"),
+			ProbeSize: confutil.P(5),
+		},
+		Recovery: PublicTxManagerRecoveryConfig{
+			Enabled:    confutil.P(true),
+			ReportOnly: confutil.P(false),
+		},
 	},
 	Orchestrator: PublicTxManagerOrchestratorConfig{
 		MaxInFlight:          confutil.P(500),
+		MaxOverflow:          confutil.P(1000),
 		Interval:             confutil.P("5s"),
 		ResubmitInterval:     confutil.P("5m"),
 		StaleTimeout:         confutil.P("5m"),
 		StageRetryTime:       confutil.P("10s"),
 		PersistenceRetryTime: confutil.P("5s"),
+		StageTimeouts: StageTimeoutsConfig{
+			Sign:    confutil.P("1m"),
+			Submit:  confutil.P("1m"),
+			Confirm: confutil.P("1m"),
+		},
 		SubmissionRetry: RetryConfigWithMax{
 			RetryConfig: RetryConfig{
 				InitialDelay: confutil.P("250ms"),
@@ -74,6 +103,10 @@ var PublicTxManagerDefaults = &PublicTxManagerConfig{
 		IncreaseMax:        nil,
 		IncreasePercentage: confutil.P(0),
 		FixedGasPrice:      nil,
+		Blob: BlobGasPriceConfig{
+			FeeMultiplier:      confutil.P(2.0),
+			IncreasePercentage: confutil.P(100),
+		},
 		Cache: CacheConfig{
 			Capacity: confutil.P(100),
 			// TODO: Enable a KB based cache with TTL in Paladin
@@ -103,19 +136,71 @@ var PublicTxManagerDefaults = &PublicTxManagerConfig{
 	},
 	GasLimit: GasLimitConfig{
 		GasEstimateFactor: confutil.P(1.5),
+		TraceReverts:      confutil.P(false),
+	},
+	Submission: PublicTxSubmissionConfig{
+		DefaultBackend: confutil.P(SubmissionBackendNode),
 	},
+	Compression: PublicTxCompressionConfig{
+		Codec:     confutil.P("gzip"),
+		Threshold: confutil.P("16Kb"),
+	},
+	Validation: PublicTxValidationConfig{
+		MaxGas:          confutil.P(int64(30000000)),
+		MaxCalldataSize: confutil.P("128Kb"),
+	},
+}
+
+// The built-in backend, which submits to the blockchain node configured for the Paladin node
+const SubmissionBackendNode = "node"
+
+type SubmissionBackendType string
+
+const (
+	SubmissionBackendTypeNode  SubmissionBackendType = "node"  // eth_sendRawTransaction to the configured blockchain node
+	SubmissionBackendTypeRelay SubmissionBackendType = "relay" // a private relay endpoint, such as a Flashbots Protect RPC
+	SubmissionBackendTypeFile  SubmissionBackendType = "file"  // written to a directory, to be broadcast by a separate (air-gapped) process
+)
+
+type PublicTxSubmissionConfig struct {
+	DefaultBackend *string                             `json:"defaultBackend"` // used for any signer not listed in signers
+	Backends       map[string]*SubmissionBackendConfig `json:"backends"`
+	Signers        map[string]string                   `json:"signers"` // signing address -> backend name
+}
+
+type SubmissionBackendConfig struct {
+	Type  SubmissionBackendType        `json:"type"`
+	Relay SubmissionRelayBackendConfig `json:"relay"`
+	File  SubmissionFileBackendConfig  `json:"file"`
+}
+
+type SubmissionRelayBackendConfig struct {
+	HTTPClientConfig `json:",inline"`
+	Method           *string `json:"method"`
+}
+
+var SubmissionRelayBackendDefaults = &SubmissionRelayBackendConfig{
+	Method: confutil.P("eth_sendPrivateTransaction"),
+}
+
+type SubmissionFileBackendConfig struct {
+	Directory string `json:"directory"`
 }
 
 type PublicTxManagerManagerConfig struct {
-	MaxInFlightOrchestrators *int                                 `json:"maxInFlightOrchestrators"`
-	Interval                 *string                              `json:"interval"`
-	OrchestratorIdleTimeout  *string                              `json:"orchestratorIdleTimeout"`  // idle orchestrators exit after this time
-	OrchestratorStaleTimeout *string                              `json:"orchestratorStaleTimeout"` // stale orchestrators exit after this time - TODO: Define stale
-	OrchestratorSwapTimeout  *string                              `json:"orchestratorSwapTimeout"`  // orchestrators are cycled out after this time, when all slots are full
-	NonceCacheTimeout        *string                              `json:"nonceCacheTimeout"`
-	ActivityRecords          PublicTxManagerActivityRecordsConfig `json:"activityRecords"`
-	SubmissionWriter         FlushWriterConfig                    `json:"submissionWriter"`
-	Retry                    RetryConfig                          `json:"retry"`
+	MaxInFlightOrchestrators *int                                      `json:"maxInFlightOrchestrators"`
+	Interval                 *string                                   `json:"interval"`
+	OrchestratorIdleTimeout  *string                                   `json:"orchestratorIdleTimeout"`  // idle orchestrators exit after this time
+	OrchestratorStaleTimeout *string                                   `json:"orchestratorStaleTimeout"` // stale orchestrators exit after this time - TODO: Define stale
+	OrchestratorSwapTimeout  *string                                   `json:"orchestratorSwapTimeout"`  // orchestrators are cycled out after this time, when all slots are full
+	NonceCacheTimeout        *string                                   `json:"nonceCacheTimeout"`
+	ActivityRecords          PublicTxManagerActivityRecordsConfig      `json:"activityRecords"`
+	LatencyTracing           PublicTxManagerLatencyTracingConfig       `json:"latencyTracing"`
+	EventNotifier            PublicTxManagerEventNotifierConfig        `json:"eventNotifier"`
+	NodeRestartDetection     PublicTxManagerNodeRestartDetectionConfig `json:"nodeRestartDetection"`
+	Recovery                 PublicTxManagerRecoveryConfig             `json:"recovery"`
+	SubmissionWriter         FlushWriterConfig                         `json:"submissionWriter"`
+	Retry                    RetryConfig                               `json:"retry"`
 }
 
 type PublicTxManagerActivityRecordsConfig struct {
@@ -123,6 +208,30 @@ type PublicTxManagerActivityRecordsConfig struct {
 	RecordsPerTransaction *int `json:"entriesPerTransaction"`
 }
 
+type PublicTxManagerLatencyTracingConfig struct {
+	Enabled      *bool `json:"enabled"`
+	CacheConfig        // the number of recent transaction timelines retained in memory
+	SampleWindow *int  `json:"sampleWindow"` // the number of recent samples per stage used to calculate percentiles
+}
+
+type PublicTxManagerEventNotifierConfig struct {
+	BatchSize    *int    `json:"batchSize"`    // the maximum number of events delivered to a subscriber at a time
+	PollInterval *string `json:"pollInterval"` // subscribers are notified of new events in-process, so this is a fallback
+}
+
+// Nodes that restart (common on dev networks) or flush their pool lose our pending transactions
+// silently. When detected, all in-flight transactions are re-broadcast without waiting for the resubmit interval.
+type PublicTxManagerNodeRestartDetectionConfig struct {
+	Enabled   *bool   `json:"enabled"`
+	Interval  *string `json:"interval"`  // how often the node is probed
+	ProbeSize *int    `json:"probeSize"` // the maximum number of submitted transactions checked against the node's pool on each probe
+}
+
+type PublicTxManagerRecoveryConfig struct {
+	Enabled    *bool `json:"enabled"`
+	ReportOnly *bool `json:"reportOnly"` // reconcile with the chain and report the summary, but leave every transaction to be re-submitted as it would be without recovery
+}
+
 type ProactiveAutoFuelingCalcMethod string
 
 const (
@@ -137,25 +246,101 @@ type BalanceManagerConfig struct {
 }
 
 type AutoFuelingConfig struct {
-	Source                           *string `json:"source"` // key resolution string
-	SourceAddressMinBalance          *string `json:"sourceAddressMinBalance"`
-	ProactiveFuelingTransactionTotal *int    `json:"proactiveFuelingTransactionTotal"`
-	ProactiveCostEstimationMethod    *string `json:"proactiveCostEstimationMethod"`
-	MinDestBalance                   *string `json:"minDestBalance"`
-	MaxDestBalance                   *string `json:"maxDestBalance"`
-	MinThreshold                     *string `json:"minThreshold"`
+	Source                           *string                    `json:"source"` // key resolution string
+	SourceAddressMinBalance          *string                    `json:"sourceAddressMinBalance"`
+	SourceDailySpendingCap           *string                    `json:"sourceDailySpendingCap"`
+	Sources                          []*AutoFuelingSourceConfig `json:"sources"` // additional sources, used in priority order when a preferred source cannot fuel
+	ProactiveFuelingTransactionTotal *int                       `json:"proactiveFuelingTransactionTotal"`
+	ProactiveCostEstimationMethod    *string                    `json:"proactiveCostEstimationMethod"`
+	MinDestBalance                   *string                    `json:"minDestBalance"`
+	MaxDestBalance                   *string                    `json:"maxDestBalance"`
+	MinThreshold                     *string                    `json:"minThreshold"`
+}
+
+// AutoFuelingSourceConfig is a fueling source that is failed over to when the sources before it are depleted,
+// have reached their daily spending cap, or have a stale orchestrator
+type AutoFuelingSourceConfig struct {
+	Source           string  `json:"source"`           // key resolution string
+	Priority         int     `json:"priority"`         // lower values are used first - the single source configured with "source" has priority 0
+	MinBalance       *string `json:"minBalance"`       // the source is not used when its balance is below this
+	DailySpendingCap *string `json:"dailySpendingCap"` // the total value the source will transfer per UTC day
 }
 
 type GasPriceConfig struct {
-	IncreaseMax        *string            `json:"increaseMax"`
-	IncreasePercentage *int               `json:"increasePercentage"`
-	FixedGasPrice      any                `json:"fixedGasPrice"` // number or object
-	GasOracleAPI       GasOracleAPIConfig `json:"gasOracleAPI"`
-	Cache              CacheConfig        `json:"cache"`
+	IncreaseMax        *string                   `json:"increaseMax"`
+	IncreasePercentage *int                      `json:"increasePercentage"`
+	FixedGasPrice      any                       `json:"fixedGasPrice"` // number or object
+	GasOracleAPI       GasOracleAPIConfig        `json:"gasOracleAPI"`
+	Cache              CacheConfig               `json:"cache"`
+	Schedules          []*GasPriceScheduleConfig `json:"schedules"` // the first schedule that is active is applied to the gas price from the node
+	Blob               BlobGasPriceConfig        `json:"blob"`
+}
+
+// BlobGasPriceConfig controls the max fee per blob gas of EIP-4844 blob transactions, which is escalated separately to the execution gas price
+type BlobGasPriceConfig struct {
+	FeeMultiplier      *float64 `json:"feeMultiplier"`      // applied to the blob base fee from the node, to allow for it rising before the transaction is mined
+	IncreasePercentage *int     `json:"increasePercentage"` // nodes typically require a 100% increase to replace a blob transaction
+	IncreaseMax        *string  `json:"increaseMax"`
+}
+
+// GasPriceScheduleConfig adjusts pricing during a window of the day, optionally only when the network is congested
+type GasPriceScheduleConfig struct {
+	Name               string   `json:"name"`
+	Days               []string `json:"days"`               // e.g. "mon" or "monday" - every day if empty
+	StartTime          string   `json:"startTime"`          // HH:MM (inclusive) - the whole day if start and end are both empty
+	EndTime            string   `json:"endTime"`            // HH:MM (exclusive) - can be before the start time, to span midnight
+	TimeZone           string   `json:"timeZone"`           // IANA time zone name, defaults to UTC
+	MinNodeGasPrice    *string  `json:"minNodeGasPrice"`    // only active when the gas price from the node is at or above this value
+	FixedGasPrice      any      `json:"fixedGasPrice"`      // number or object, used instead of the gas price from the node
+	Multiplier         *float64 `json:"multiplier"`         // applied to the gas price from the node
+	MaxGasPrice        *string  `json:"maxGasPrice"`        // caps the gas price (or max fee per gas) while active
+	IncreasePercentage *int     `json:"increasePercentage"` // overrides the gas price increase percentage for resubmissions while active
+	IncreaseMax        *string  `json:"increaseMax"`        // overrides the max gas price for resubmissions while active
 }
 
 type GasLimitConfig struct {
-	GasEstimateFactor *float64 `json:"gasEstimateFactor"`
+	GasEstimateFactor *float64                 `json:"gasEstimateFactor"`
+	Padding           GasEstimatePaddingConfig `json:"padding"`
+	TraceReverts      *bool                    `json:"traceReverts"` // trace the call with debug_traceCall when gas estimation reverts, to log where in the call tree it failed
+}
+
+// Compression of the calldata of public transactions at rest in the public transaction store. Large calldata
+// (such as privacy group transitions) is compressed when the transaction is written, and transparently
+// decompressed when it is read back. Existing uncompressed transactions are unaffected.
+type PublicTxCompressionConfig struct {
+	Codec     *string `json:"codec"`     // one of "none", "gzip" or "deflate"
+	Threshold *string `json:"threshold"` // calldata smaller than this is stored uncompressed
+}
+
+// Checks applied to each new transaction before a nonce is assigned, so that a transaction the chain
+// would never accept fails back to the caller rather than holding up the nonces after it
+type PublicTxValidationConfig struct {
+	MaxGas          *int64                       `json:"maxGas"`          // the largest gas limit a transaction can request - typically the block gas limit of the chain
+	MaxValue        *string                      `json:"maxValue"`        // the largest value in wei a transaction can transfer - no limit if not set
+	MaxCalldataSize *string                      `json:"maxCalldataSize"` // nodes reject transactions larger than 128Kb from their pool by default
+	Signers         PublicTxSignerPolicyConfig   `json:"signers"`
+	PolicyContract  PublicTxPolicyContractConfig `json:"policyContract"`
+}
+
+// When configured, every public transaction is checked against an on-chain policy contract before it is
+// accepted, by calling the view function:
+//
+//	function checkTransaction(bytes32 digest, address from, address to, uint256 value, bytes calldata data) external view returns (bool)
+//
+// The digest is keccak256(abi.encode(chainId, from, to, value, keccak256(data))), so the contract can either
+// look up transactions approved in advance, or apply its own rules to the fields. A deploy passes the zero
+// address as "to". A false result rejects the transaction, as does a failure to call the contract.
+type PublicTxPolicyContractConfig struct {
+	Address *string `json:"address"` // the check is disabled if not set
+}
+
+// Each entry is either an 0x address, or a pattern for the identity path of the key in the key manager.
+// In a pattern each "." separated segment is matched with shell glob rules ("*", "?" and "[...]"),
+// and a final "**" segment matches one or more further segments - so "tenant1.**" matches every key
+// under "tenant1".
+type PublicTxSignerPolicyConfig struct {
+	Allow []string `json:"allow"` // if set, only signers matching an entry are accepted
+	Deny  []string `json:"deny"`  // signers matching an entry are rejected, even if they match an allow entry
 }
 
 type GasOracleAPIConfig struct {
@@ -163,14 +348,25 @@ type GasOracleAPIConfig struct {
 	Template string `json:"template"`
 }
 
+// StageTimeoutsConfig sets how long each stage of an in-flight transaction can run without producing
+// a result, before it is abandoned and run again
+type StageTimeoutsConfig struct {
+	Sign        *string `json:"sign"`
+	Submit      *string `json:"submit"`
+	ReceiptWait *string `json:"receiptWait"` // time to wait for a receipt before re-pricing and re-submitting - defaults to resubmitInterval
+	Confirm     *string `json:"confirm"`     // time to persist the status change once a receipt is received
+}
+
 type PublicTxManagerOrchestratorConfig struct {
-	MaxInFlight               *int               `json:"maxInFlight"`
-	Interval                  *string            `json:"interval"`
-	ResubmitInterval          *string            `json:"resubmitInterval"`
-	StaleTimeout              *string            `json:"staleTimeout"`
-	StageRetryTime            *string            `json:"stageRetryTime"`
-	PersistenceRetryTime      *string            `json:"persistenceRetryTime"`
-	UnavailableBalanceHandler *string            `json:"unavailableBalanceHandler"`
-	SubmissionRetry           RetryConfigWithMax `json:"submissionRetry"`
-	TimeLineLoggingMaxEntries int                `json:"timelineMaxEntries"`
+	MaxInFlight               *int                `json:"maxInFlight"`
+	MaxOverflow               *int                `json:"maxOverflow"` // depth of the queue of transactions waiting in the DB for an in-flight slot, beyond which a warning is logged (zero disables counting the queue)
+	Interval                  *string             `json:"interval"`
+	ResubmitInterval          *string             `json:"resubmitInterval"`
+	StaleTimeout              *string             `json:"staleTimeout"`
+	StageRetryTime            *string             `json:"stageRetryTime"`
+	PersistenceRetryTime      *string             `json:"persistenceRetryTime"`
+	StageTimeouts             StageTimeoutsConfig `json:"stageTimeouts"`
+	UnavailableBalanceHandler *string             `json:"unavailableBalanceHandler"`
+	SubmissionRetry           RetryConfigWithMax  `json:"submissionRetry"`
+	TimeLineLoggingMaxEntries int                 `json:"timelineMaxEntries"`
 }
@@ -24,22 +24,28 @@ type RegistryManagerConfig struct {
 }
 
 type RegistryManagerManagerConfig struct {
-	RegistryCache CacheConfig `json:"registryCache"`
+	RegistryCache   CacheConfig `json:"registryCache"`
+	CredentialCache CacheConfig `json:"credentialCache"`
 }
 
 var RegistryCacheDefaults = &CacheConfig{
 	Capacity: confutil.P(100),
 }
 
+var RegistryCredentialCacheDefaults = &CacheConfig{
+	Capacity: confutil.P(1000),
+}
+
 type RegistryInitConfig struct {
 	Retry RetryConfig `json:"retry"`
 }
 
 type RegistryConfig struct {
-	Init       RegistryInitConfig       `json:"init"`
-	Transports RegistryTransportsConfig `json:"transports"`
-	Plugin     PluginConfig             `json:"plugin"`
-	Config     map[string]any           `json:"config"`
+	Init        RegistryInitConfig        `json:"init"`
+	Transports  RegistryTransportsConfig  `json:"transports"`
+	Credentials RegistryCredentialsConfig `json:"credentials"`
+	Plugin      PluginConfig              `json:"plugin"`
+	Config      map[string]any            `json:"config"`
 }
 
 type RegistryTransportsConfig struct {
@@ -82,6 +88,13 @@ type RegistryTransportsConfig struct {
 	TransportMap map[string]string
 }
 
+type RegistryCredentialsConfig struct {
+	// If set, only credentials signed by one of these issuer addresses can be
+	// attached to entries in this registry. Otherwise any issuer is accepted
+	// on attach, and trust decisions are left to the caller of each check.
+	TrustedIssuers []string `json:"trustedIssuers"`
+}
+
 var RegistryTransportsDefaults = &RegistryTransportsConfig{
 	Enabled:        confutil.P(true),
 	PropertyRegexp: "^transport.(.*)$",
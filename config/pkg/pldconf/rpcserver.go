@@ -42,6 +42,43 @@ type RPCServerConfigWS struct {
 }
 
 type RPCServerConfig struct {
-	HTTP RPCServerConfigHTTP `json:"http,omitempty"`
-	WS   RPCServerConfigWS   `json:"ws,omitempty"`
+	HTTP       RPCServerConfigHTTP       `json:"http,omitempty"`
+	WS         RPCServerConfigWS         `json:"ws,omitempty"`
+	AdminAuth  RPCServerAdminAuthConfig  `json:"adminAuth,omitempty"`
+	TenantAuth RPCServerTenantAuthConfig `json:"tenantAuth,omitempty"`
+}
+
+// When enabled, methods in the admin groups are only available to callers that supply the
+// admin token as a bearer token in the Authorization header (on the WebSocket upgrade for WS)
+type RPCServerAdminAuthConfig struct {
+	Enabled   bool                    `json:"enabled"`
+	TokenFile string                  `json:"tokenFile"` // file containing the shared admin token, read on startup - optional if admins are named
+	Admins    []*RPCServerAdminConfig `json:"admins"`    // named admins, each with their own token, so their actions can be attributed to them
+	Groups    []string                `json:"groups"`    // RPC method groups (the prefix before the "_") restricted to admin callers
+}
+
+type RPCServerAdminConfig struct {
+	Name      string `json:"name"`
+	TokenFile string `json:"tokenFile"` // file containing the token of this admin, read on startup
+}
+
+var RPCServerAdminAuthDefaults = RPCServerAdminAuthConfig{
+	Groups: []string{"admin", "debug"},
+}
+
+// Each tenant supplies its own token as a bearer token, in the same way as the admin token. Callers that
+// supply a tenant token only see the transactions submitted by that tenant, and the states of the contracts
+// it deployed, and are limited to its domains and identities.
+type RPCServerTenantAuthConfig struct {
+	Required bool                     `json:"required"` // reject callers that supply neither a tenant token nor the admin token
+	Tenants  []*RPCServerTenantConfig `json:"tenants"`
+}
+
+type RPCServerTenantConfig struct {
+	Name                   string   `json:"name"`
+	TokenFile              string   `json:"tokenFile"`              // file containing the tenant token, read on startup
+	Domains                []string `json:"domains"`                // the domains the tenant can use - all domains if empty
+	Identities             []string `json:"identities"`             // key identifier prefixes the tenant can submit from, resolve and sign with - all identities if empty
+	Contracts              []string `json:"contracts"`              // private contracts the tenant can read states of, in addition to those it deployed
+	MaxPendingTransactions *int     `json:"maxPendingTransactions"` // limit on the tenant's transactions without a receipt - unlimited if unset
 }
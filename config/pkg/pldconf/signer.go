@@ -40,6 +40,17 @@ type KeyStoreConfig struct {
 	KeyStoreSigning   bool                     `json:"keyStoreSigning"` // if HD Wallet or ZKP based signing is required, in-memory keys are required (so this needs to be false)
 	FileSystem        FileSystemKeyStoreConfig `json:"filesystem"`
 	Static            StaticKeyStoreConfig     `json:"static"`
+	SigningSession    SigningSessionConfig     `json:"signingSession"` // for key stores that sign asynchronously in multi-round sessions, such as MPC/TSS providers
+}
+
+type SigningSessionConfig struct {
+	PollInterval *string `json:"pollInterval"` // how often to check an in-progress session for completion
+	Timeout      *string `json:"timeout"`      // how long to wait for a session before returning an error - the session is resumed when the request is retried. Keep this within the public transaction manager's sign stage timeout, which also bounds the wait
+}
+
+var SigningSessionDefaults = &SigningSessionConfig{
+	PollInterval: confutil.P("250ms"),
+	Timeout:      confutil.P("50s"), // within the default sign stage timeout of the public transaction manager
 }
 
 type KeyDerivationType string
@@ -103,6 +114,40 @@ type FileSystemKeyStoreConfig struct {
 	Cache    CacheConfig `json:"cache"`
 	FileMode *string     `json:"fileMode"`
 	DirMode  *string     `json:"dirMode"`
+	// When a master password is configured, each key is encrypted with a key derived from the master password,
+	// rather than being stored in keystorev3 format alongside a randomly generated password file.
+	MasterPassword FileSystemMasterPasswordConfig `json:"masterPassword"`
+	KDF            FileSystemKDFConfig            `json:"kdf"` // parameters used for files written from now on - each file records its own
+}
+
+type FileSystemMasterPasswordConfig struct {
+	File string `json:"file,omitempty"` // file containing the password (trailing whitespace is trimmed)
+	Env  string `json:"env,omitempty"`  // environment variable containing the password
+}
+
+type FileSystemKDFType string
+
+const (
+	FileSystemKDFTypeScrypt   FileSystemKDFType = "scrypt"
+	FileSystemKDFTypeArgon2id FileSystemKDFType = "argon2id"
+)
+
+type FileSystemKDFConfig struct {
+	Type     FileSystemKDFType `json:"type"`
+	Scrypt   ScryptKDFConfig   `json:"scrypt"`
+	Argon2id Argon2idKDFConfig `json:"argon2id"`
+}
+
+type ScryptKDFConfig struct {
+	N *int `json:"n"`
+	R *int `json:"r"`
+	P *int `json:"p"`
+}
+
+type Argon2idKDFConfig struct {
+	Time      *int `json:"time"`
+	MemoryKiB *int `json:"memoryKiB"`
+	Threads   *int `json:"threads"`
 }
 
 var FileSystemDefaults = &FileSystemKeyStoreConfig{
@@ -112,4 +157,17 @@ var FileSystemDefaults = &FileSystemKeyStoreConfig{
 	Cache: CacheConfig{
 		Capacity: confutil.P(100),
 	},
+	KDF: FileSystemKDFConfig{
+		Type: FileSystemKDFTypeScrypt,
+		Scrypt: ScryptKDFConfig{
+			N: confutil.P(1 << 18), // matches the keystorev3 standard
+			R: confutil.P(8),
+			P: confutil.P(1),
+		},
+		Argon2id: Argon2idKDFConfig{
+			Time:      confutil.P(3),
+			MemoryKiB: confutil.P(64 * 1024),
+			Threads:   confutil.P(4),
+		},
+	},
 }
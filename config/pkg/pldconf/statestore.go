@@ -21,7 +21,37 @@ import (
 )
 
 type StateStoreConfig struct {
-	SchemaCache CacheConfig `json:"schemaCache"`
+	SchemaCache CacheConfig          `json:"schemaCache"`
+	LabelStats  LabelStatsConfig     `json:"labelStats"`
+	Anchoring   StateAnchoringConfig `json:"anchoring"`
+}
+
+// The anchoring service periodically builds a merkle tree over the IDs of newly confirmed states
+// in each domain, and writes the root to a StateAnchorRegistry contract on the base ledger
+type StateAnchoringConfig struct {
+	Enabled         *bool   `json:"enabled"`
+	Interval        *string `json:"interval"`        // how often to check for new confirmed states to anchor
+	ContractAddress string  `json:"contractAddress"` // the address of the StateAnchorRegistry contract
+	From            string  `json:"from"`            // the signing identity used to submit the anchoring transactions
+	BatchMaxSize    *int    `json:"batchMaxSize"`    // the maximum number of states in a single anchor
+}
+
+var StateAnchoringDefaults = &StateAnchoringConfig{
+	Enabled:      confutil.P(false),
+	Interval:     confutil.P("1m"),
+	BatchMaxSize: confutil.P(1000),
+}
+
+type LabelStatsConfig struct {
+	MaxAge *string     `json:"maxAge"` // how long the label statistics of a schema are used for query planning, before being recalculated
+	Cache  CacheConfig `json:"cache"`
+}
+
+var LabelStatsDefaults = &LabelStatsConfig{
+	MaxAge: confutil.P("1m"),
+	Cache: CacheConfig{
+		Capacity: confutil.P(100),
+	},
 }
 
 var StateWriterConfigDefaults = FlushWriterConfig{
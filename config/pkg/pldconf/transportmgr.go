@@ -17,15 +17,42 @@ package pldconf
 import "github.com/kaleido-io/paladin/config/pkg/confutil"
 
 type TransportManagerConfig struct {
-	NodeName              string                      `json:"nodeName"`
-	SendQueueLen          *int                        `json:"sendQueueLen"`
-	PeerInactivityTimeout *string                     `json:"peerInactivityTimeout"`
-	PeerReaperInterval    *string                     `json:"peerReaperInterval"`
-	SendRetry             RetryConfigWithMax          `json:"sendRetry"`
-	ReliableScanRetry     RetryConfig                 `json:"reliableScanRetry"`
-	ReliableMessageResend *string                     `json:"reliableMessageResend"`
-	ReliableMessageWriter FlushWriterConfig           `json:"reliableMessageWriter"`
-	Transports            map[string]*TransportConfig `json:"transports"`
+	NodeName              string                       `json:"nodeName"`
+	SendQueueLen          *int                         `json:"sendQueueLen"`
+	PeerInactivityTimeout *string                      `json:"peerInactivityTimeout"`
+	PeerReaperInterval    *string                      `json:"peerReaperInterval"`
+	SendRetry             RetryConfigWithMax           `json:"sendRetry"`
+	ReliableScanRetry     RetryConfig                  `json:"reliableScanRetry"`
+	ReliableMessageResend *string                      `json:"reliableMessageResend"`
+	ReliableMessageWriter FlushWriterConfig            `json:"reliableMessageWriter"`
+	Compression           TransportCompressionConfig   `json:"compression"`
+	Chunking              TransportChunkingConfig      `json:"chunking"`
+	InboundLimits         TransportInboundLimitsConfig `json:"inboundLimits"`
+	Transports            map[string]*TransportConfig  `json:"transports"`
+}
+
+type TransportCompressionConfig struct {
+	Codec     *string `json:"codec"`     // one of "none", "gzip" or "deflate"
+	Threshold *string `json:"threshold"` // payloads smaller than this are sent uncompressed
+}
+
+type TransportChunkingConfig struct {
+	ChunkSize         *string `json:"chunkSize"`         // encoded payloads larger than this are split into chunks
+	MaxMessageSize    *string `json:"maxMessageSize"`    // upper bound on a reassembled (and decompressed) payload
+	ReassemblyTimeout *string `json:"reassemblyTimeout"` // partially received messages are discarded after this time
+}
+
+// Limits applied to the messages received from each peer. Peers that repeatedly exceed the limits are banned
+// for a period, during which all their messages are rejected.
+type TransportInboundLimitsConfig struct {
+	MessageRate    *float64 `json:"messageRate"`    // messages per second accepted from each peer - zero disables
+	MessageBurst   *int     `json:"messageBurst"`   // messages accepted from a peer in a burst above the rate
+	ByteRate       *string  `json:"byteRate"`       // payload bytes per second accepted from each peer - zero disables
+	ByteBurst      *string  `json:"byteBurst"`      // payload bytes accepted from a peer in a burst above the rate (at least maxMessageSize)
+	MaxMessageSize *string  `json:"maxMessageSize"` // the largest payload accepted in a single message (or chunk) from the transport
+	BanThreshold   *int     `json:"banThreshold"`   // violations within the ban window that cause a ban - zero disables banning
+	BanWindow      *string  `json:"banWindow"`
+	BanDuration    *string  `json:"banDuration"`
 }
 
 type TransportInitConfig struct {
@@ -52,6 +79,26 @@ var TransportManagerDefaults = &TransportManagerConfig{
 		BatchTimeout: confutil.P("250ms"),
 		BatchMaxSize: confutil.P(50),
 	},
+	Compression: TransportCompressionConfig{
+		Codec:     confutil.P("gzip"),
+		Threshold: confutil.P("64Kb"),
+	},
+	// The chunk size is kept well below the 4MB default gRPC message limit
+	Chunking: TransportChunkingConfig{
+		ChunkSize:         confutil.P("1Mb"),
+		MaxMessageSize:    confutil.P("256Mb"),
+		ReassemblyTimeout: confutil.P("1m"),
+	},
+	InboundLimits: TransportInboundLimitsConfig{
+		MessageRate:    confutil.P(1000.0),
+		MessageBurst:   confutil.P(2000),
+		ByteRate:       confutil.P("64Mb"),
+		ByteBurst:      confutil.P("128Mb"),
+		MaxMessageSize: confutil.P("4Mb"),
+		BanThreshold:   confutil.P(100),
+		BanWindow:      confutil.P("1m"),
+		BanDuration:    confutil.P("5m"),
+	},
 }
 
 type TransportConfig struct {
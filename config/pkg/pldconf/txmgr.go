@@ -20,9 +20,11 @@ import (
 )
 
 type TxManagerConfig struct {
-	ABI              ABIConfig          `json:"abi"`
-	Transactions     TransactionsConfig `json:"transactions"`
-	ReceiptListeners ReceiptListeners   `json:"receiptListeners"`
+	ABI              ABIConfig            `json:"abi"`
+	Transactions     TransactionsConfig   `json:"transactions"`
+	ReceiptListeners ReceiptListeners     `json:"receiptListeners"`
+	Evidence         EvidenceConfig       `json:"evidence"`
+	SigningPools     []*SigningPoolConfig `json:"signingPools"`
 }
 
 type ABIConfig struct {
@@ -30,7 +32,13 @@ type ABIConfig struct {
 }
 
 type TransactionsConfig struct {
-	Cache CacheConfig `json:"cache"`
+	Cache     CacheConfig                 `json:"cache"`
+	Scheduler TransactionsSchedulerConfig `json:"scheduler"`
+}
+
+type TransactionsSchedulerConfig struct {
+	Interval  *string `json:"interval"`  // how often to check for scheduled transactions that are ready to be submitted
+	BatchSize *int    `json:"batchSize"` // the maximum number of scheduled transactions to release on each check
 }
 
 type ReceiptListeners struct {
@@ -39,6 +47,18 @@ type ReceiptListeners struct {
 	StateGapCheckInterval *string     `json:"stateGapCheckInterval"`
 }
 
+type EvidenceConfig struct {
+	SigningKey *string `json:"signingKey"` // identifier of the local key that signs exported transaction evidence
+}
+
+// A signing pool spreads the public transactions submitted from an identity across a set of signing
+// addresses derived beneath it, each with its own nonce stream, so that throughput is not limited by
+// the nonce serialization of a single address.
+type SigningPoolConfig struct {
+	Identity string `json:"identity"` // the identity transactions are submitted "from"
+	Size     int    `json:"size"`     // the number of signing addresses, resolved as the keys "<identity>.0" to "<identity>.<size-1>"
+}
+
 var TxManagerDefaults = &TxManagerConfig{
 	ABI: ABIConfig{
 		Cache: CacheConfig{
@@ -49,10 +69,17 @@ var TxManagerDefaults = &TxManagerConfig{
 		Cache: CacheConfig{
 			Capacity: confutil.P(100),
 		},
+		Scheduler: TransactionsSchedulerConfig{
+			Interval:  confutil.P("1s"),
+			BatchSize: confutil.P(50),
+		},
 	},
 	ReceiptListeners: ReceiptListeners{
 		Retry:                 GenericRetryDefaults.RetryConfig,
 		ReadPageSize:          confutil.P(100),
 		StateGapCheckInterval: confutil.P("1s"),
 	},
+	Evidence: EvidenceConfig{
+		SigningKey: confutil.P("node.evidence"),
+	},
 }
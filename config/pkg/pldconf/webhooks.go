@@ -0,0 +1,48 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package pldconf
+
+import "github.com/kaleido-io/paladin/config/pkg/confutil"
+
+type WebhooksConfig struct {
+	Webhooks []*WebhookConfig `json:"webhooks"` // HTTP endpoints that are sent receipt events as transactions complete
+}
+
+type WebhookConfig struct {
+	Name             string               `json:"name"`       // unique name, used to identify deliveries and the receipt listener that feeds the webhook
+	SecretFile       *string              `json:"secretFile"` // a file containing the key used to HMAC sign each payload - payloads are unsigned if not set
+	Filters          WebhookFiltersConfig `json:"filters"`
+	Retry            RetryConfigWithMax   `json:"retry"` // backoff between failed deliveries, and the attempts before a delivery is marked failed
+	HTTPClientConfig `json:",inline"`
+}
+
+type WebhookFiltersConfig struct {
+	EventTypes []string `json:"eventTypes"` // transaction_success and/or transaction_failed - all events if empty
+	Domain     *string  `json:"domain"`     // only private transactions for this domain
+	Signer     *string  `json:"signer"`     // only transactions submitted by this signing identity
+}
+
+var WebhookDefaults = &WebhookConfig{
+	Retry: RetryConfigWithMax{
+		RetryConfig: RetryConfig{
+			InitialDelay: confutil.P("1s"),
+			MaxDelay:     confutil.P("5m"),
+			Factor:       confutil.P(2.0),
+		},
+		MaxAttempts: confutil.P(10),
+	},
+	HTTPClientConfig: *DefaultHTTPConfig,
+}
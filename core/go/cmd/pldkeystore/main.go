@@ -0,0 +1,81 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+// pldkeystore performs offline administration of a filesystem keystore. The node using the keystore must be stopped.
+//
+//	pldkeystore rotate-password -path ./keystore [-password-file current.pwd] -new-password-file new.pwd [-kdf scrypt|argon2id]
+//
+// Rotation re-encrypts every key under the new master password, and moves the keystore to a new generation so
+// key files from before the rotation are rejected. If the keystore does not yet have a master password, the
+// existing key files are migrated and their password files are deleted.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/toolkit/pkg/signer/keystores"
+)
+
+func main() {
+	os.Exit(run(context.Background(), os.Args[1:]))
+}
+
+func run(ctx context.Context, args []string) int {
+	if len(args) == 0 || args[0] != "rotate-password" {
+		fmt.Fprintln(os.Stderr, "usage: pldkeystore rotate-password -path DIR [-password-file FILE | -password-env VAR] -new-password-file FILE [-kdf scrypt|argon2id]")
+		return 2
+	}
+	flags := flag.NewFlagSet("rotate-password", flag.ContinueOnError)
+	path := flags.String("path", "keystore", "Path of the filesystem keystore")
+	passwordFile := flags.String("password-file", "", "File containing the current master password (omit when migrating a keystore without one)")
+	passwordEnv := flags.String("password-env", "", "Environment variable containing the current master password")
+	newPasswordFile := flags.String("new-password-file", "", "File containing the new master password")
+	kdf := flags.String("kdf", string(pldconf.FileSystemKDFTypeScrypt), "Key derivation function for the re-encrypted files (scrypt or argon2id)")
+	if err := flags.Parse(args[1:]); err != nil {
+		return 2
+	}
+	if *newPasswordFile == "" {
+		fmt.Fprintln(os.Stderr, "-new-password-file is required")
+		return 2
+	}
+
+	newPassword, err := os.ReadFile(*newPasswordFile)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	conf := &pldconf.FileSystemKeyStoreConfig{
+		Path: path,
+		MasterPassword: pldconf.FileSystemMasterPasswordConfig{
+			File: *passwordFile,
+			Env:  *passwordEnv,
+		},
+		KDF: pldconf.FileSystemKDFConfig{
+			Type: pldconf.FileSystemKDFType(*kdf),
+		},
+	}
+	err = keystores.RotateFilesystemMasterPassword(ctx, conf, []byte(strings.TrimRight(string(newPassword), " \t\r\n")))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	fmt.Printf("Rotated master password of keystore %s\n", *path)
+	return 0
+}
@@ -0,0 +1,66 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+// tbscenario runs one or more YAML scenario files against a running testbed
+//
+//	tbscenario -url http://127.0.0.1:8545 scenario1.yaml [scenario2.yaml ...]
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/pkg/testbed/scenario"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/rpcclient"
+)
+
+func main() {
+	os.Exit(run(context.Background(), os.Args[1:]))
+}
+
+func run(ctx context.Context, args []string) int {
+	flags := flag.NewFlagSet("tbscenario", flag.ContinueOnError)
+	url := flags.String("url", "http://127.0.0.1:8545", "JSON/RPC URL of the testbed")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if flags.NArg() == 0 {
+		fmt.Fprintln(os.Stderr, "usage: tbscenario [-url URL] scenario.yaml [...]")
+		return 2
+	}
+
+	rpc, err := rpcclient.NewHTTPClient(ctx, &pldconf.HTTPClientConfig{URL: *url})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	failed := 0
+	for _, file := range flags.Args() {
+		if err := scenario.RunFile(ctx, rpc, file); err != nil {
+			fmt.Fprintf(os.Stderr, "FAIL %s: %s\n", file, err)
+			failed++
+		} else {
+			fmt.Printf("PASS %s\n", file)
+		}
+	}
+	if failed > 0 {
+		return 1
+	}
+	return 0
+}
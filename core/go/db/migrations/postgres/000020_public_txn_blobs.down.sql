@@ -0,0 +1,5 @@
+BEGIN;
+
+DROP TABLE public_txn_blobs;
+
+COMMIT;
@@ -0,0 +1,15 @@
+BEGIN;
+
+-- The blob sidecar of EIP-4844 (type 3) transactions, which must be kept to resubmit the transaction
+CREATE TABLE public_txn_blobs (
+  "pub_txn_id"                BIGINT          NOT NULL,
+  "idx"                       INT             NOT NULL,
+  "versioned_hash"            TEXT            NOT NULL,
+  "commitment"                TEXT            NOT NULL,
+  "proof"                     TEXT            NOT NULL,
+  "blob"                      TEXT            NOT NULL,
+  PRIMARY KEY ("pub_txn_id", "idx"),
+  FOREIGN KEY ("pub_txn_id") REFERENCES public_txns ("pub_txn_id") ON DELETE CASCADE
+);
+
+COMMIT;
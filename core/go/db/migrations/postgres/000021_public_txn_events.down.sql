@@ -0,0 +1,6 @@
+BEGIN;
+
+DROP TABLE public_txn_event_cursors;
+DROP TABLE public_txn_events;
+
+COMMIT;
@@ -0,0 +1,22 @@
+BEGIN;
+
+-- Status transitions of public transactions, recorded for in-process subscribers with persisted cursors
+CREATE TABLE public_txn_events (
+  "sequence"                  BIGINT          GENERATED ALWAYS AS IDENTITY,
+  "pub_txn_id"                BIGINT          NOT NULL,
+  "event_type"                TEXT            NOT NULL,
+  "tx_hash"                   TEXT,
+  "created"                   BIGINT          NOT NULL,
+  PRIMARY KEY ("sequence"),
+  FOREIGN KEY ("pub_txn_id") REFERENCES public_txns ("pub_txn_id") ON DELETE CASCADE
+);
+CREATE INDEX public_txn_events_pub_txn_id ON public_txn_events("pub_txn_id");
+
+CREATE TABLE public_txn_event_cursors (
+  "subscriber"                TEXT            NOT NULL,
+  "sequence"                  BIGINT          NOT NULL,
+  "time"                      BIGINT          NOT NULL,
+  PRIMARY KEY ("subscriber")
+);
+
+COMMIT;
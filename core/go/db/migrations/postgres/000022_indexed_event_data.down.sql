@@ -0,0 +1,5 @@
+BEGIN;
+
+DROP TABLE indexed_event_data;
+
+COMMIT;
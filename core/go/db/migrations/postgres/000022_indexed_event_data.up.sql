@@ -0,0 +1,20 @@
+BEGIN;
+
+CREATE TABLE indexed_event_data (
+    "block_number"       BIGINT  NOT NULL,
+    "transaction_index"  INT     NOT NULL,
+    "log_index"          INT     NOT NULL,
+    "transaction_hash"   TEXT    NOT NULL,
+    "signature"          TEXT    NOT NULL,
+    "address"            CHAR(40) NOT NULL,
+    "topics"             TEXT    NOT NULL,
+    "raw_data"           TEXT    NOT NULL,
+    "solidity_signature" TEXT    NOT NULL,
+    "data"               TEXT    NOT NULL,
+    PRIMARY KEY ("block_number", "transaction_index", "log_index"),
+    FOREIGN KEY ("block_number", "transaction_index", "log_index") REFERENCES indexed_events ("block_number", "transaction_index", "log_index") ON DELETE CASCADE
+);
+CREATE INDEX indexed_event_data_signature ON indexed_event_data("signature");
+CREATE INDEX indexed_event_data_address ON indexed_event_data("address");
+
+COMMIT;
@@ -0,0 +1,10 @@
+BEGIN;
+
+DROP INDEX state_int64_labels_by_contract;
+DROP INDEX state_labels_by_contract;
+DROP INDEX states_by_contract;
+
+ALTER TABLE state_int64_labels DROP COLUMN "contract_address";
+ALTER TABLE state_labels DROP COLUMN "contract_address";
+
+COMMIT;
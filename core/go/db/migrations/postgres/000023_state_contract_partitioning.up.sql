@@ -0,0 +1,17 @@
+BEGIN;
+
+-- Labels carry the contract address of their state, so label joins for a single contract
+-- only visit the labels of that contract
+ALTER TABLE state_labels ADD COLUMN "contract_address" TEXT;
+ALTER TABLE state_int64_labels ADD COLUMN "contract_address" TEXT;
+
+UPDATE state_labels SET "contract_address" = s."contract_address"
+  FROM states s WHERE s."domain_name" = state_labels."domain_name" AND s."id" = state_labels."state";
+UPDATE state_int64_labels SET "contract_address" = s."contract_address"
+  FROM states s WHERE s."domain_name" = state_int64_labels."domain_name" AND s."id" = state_int64_labels."state";
+
+CREATE INDEX states_by_contract ON states("domain_name", "contract_address", "schema", "created");
+CREATE INDEX state_labels_by_contract ON state_labels("domain_name", "contract_address", "label", "value");
+CREATE INDEX state_int64_labels_by_contract ON state_int64_labels("domain_name", "contract_address", "label", "value");
+
+COMMIT;
@@ -0,0 +1,21 @@
+BEGIN;
+
+-- The attestations gathered for a private transaction by its coordinator, recorded at dispatch
+-- so they can be exported as evidence after the transaction completes
+CREATE TABLE transaction_endorsements (
+    "transaction"       UUID       NOT NULL,
+    "idx"               INT        NOT NULL,
+    "created"           BIGINT     NOT NULL,
+    "name"              TEXT       NOT NULL,
+    "attestation_type"  TEXT       NOT NULL,
+    "lookup"            TEXT       NOT NULL,
+    "verifier"          TEXT       NOT NULL,
+    "algorithm"         TEXT       NOT NULL,
+    "verifier_type"     TEXT       NOT NULL,
+    "payload_type"      TEXT       ,
+    "payload"           TEXT       ,
+    "constraints"       TEXT       ,
+    PRIMARY KEY ("transaction", "idx")
+);
+
+COMMIT;
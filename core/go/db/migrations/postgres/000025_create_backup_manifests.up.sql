@@ -0,0 +1,14 @@
+BEGIN;
+
+-- Written while the node is quiesced, immediately before a database backup is taken,
+-- so that the latest manifest in a restored database describes the backup it came from
+CREATE TABLE backup_manifests (
+    "id"                UUID       NOT NULL,
+    "created"           BIGINT     NOT NULL,
+    "manifest"          TEXT       NOT NULL,
+    PRIMARY KEY ("id")
+);
+
+CREATE INDEX backup_manifests_created ON backup_manifests("created");
+
+COMMIT;
@@ -0,0 +1,16 @@
+BEGIN;
+
+-- An audit record of every config change applied at runtime by reloading the config file
+CREATE TABLE config_changes (
+    "id"                UUID       NOT NULL,
+    "created"           BIGINT     NOT NULL,
+    "section"           TEXT       NOT NULL,
+    "previous"          TEXT       NOT NULL,
+    "value"             TEXT       NOT NULL,
+    "config_hash"       TEXT       NOT NULL,
+    PRIMARY KEY ("id")
+);
+
+CREATE INDEX config_changes_created ON config_changes("created");
+
+COMMIT;
@@ -0,0 +1,7 @@
+BEGIN;
+
+ALTER TABLE public_completions DROP COLUMN "expired";
+ALTER TABLE public_submissions DROP COLUMN "cancel";
+ALTER TABLE public_txns DROP COLUMN "expiry";
+
+COMMIT;
@@ -0,0 +1,8 @@
+BEGIN;
+
+-- Transactions not mined by their expiry are cancelled, by replacing the nonce with a no-op transaction
+ALTER TABLE public_txns ADD "expiry" BIGINT;
+ALTER TABLE public_submissions ADD "cancel" BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE public_completions ADD "expired" BOOLEAN NOT NULL DEFAULT FALSE;
+
+COMMIT;
@@ -0,0 +1,17 @@
+BEGIN;
+
+-- Watches for transactions submitted outside of Paladin, by hash or by sender+nonce.
+-- The id is used as the transaction ID of the receipt written when the transaction is mined.
+CREATE TABLE external_tx_watches (
+  "id"          UUID            NOT NULL,
+  "created"     BIGINT          NOT NULL,
+  "tx_hash"     VARCHAR,
+  "from"        VARCHAR,
+  "nonce"       BIGINT,
+  "completed"   BIGINT,
+  PRIMARY KEY ("id")
+);
+CREATE INDEX external_tx_watches_tx_hash ON external_tx_watches ("tx_hash");
+CREATE INDEX external_tx_watches_from_nonce ON external_tx_watches ("from", "nonce");
+
+COMMIT;
@@ -0,0 +1,4 @@
+BEGIN;
+DROP TABLE IF EXISTS state_anchor_leaves;
+DROP TABLE IF EXISTS state_anchors;
+COMMIT;
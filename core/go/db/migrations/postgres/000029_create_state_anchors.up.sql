@@ -0,0 +1,27 @@
+BEGIN;
+
+-- Merkle roots of the IDs of confirmed states, anchored to the base ledger by the state store.
+-- Each confirmed state is a leaf of exactly one anchor, at a fixed index in the sorted leaf set.
+CREATE TABLE state_anchors (
+  "id"               UUID    NOT NULL,
+  "created"          BIGINT  NOT NULL,
+  "domain_name"      TEXT    NOT NULL,
+  "root"             TEXT    NOT NULL,
+  "leaf_count"       INT     NOT NULL,
+  "contract_address" TEXT    NOT NULL,
+  "transaction"      UUID    NOT NULL,
+  PRIMARY KEY ("id")
+);
+CREATE INDEX state_anchors_domain_name ON state_anchors ("domain_name", "created");
+
+CREATE TABLE state_anchor_leaves (
+  "domain_name"      TEXT    NOT NULL,
+  "state"            TEXT    NOT NULL,
+  "anchor"           UUID    NOT NULL,
+  "leaf_index"       INT     NOT NULL,
+  PRIMARY KEY ("domain_name", "state"),
+  FOREIGN KEY ("anchor") REFERENCES state_anchors ("id") ON DELETE CASCADE
+);
+CREATE INDEX state_anchor_leaves_anchor ON state_anchor_leaves ("anchor", "leaf_index");
+
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+
+-- The signed transaction of each submission, so it can be re-broadcast through another channel
+ALTER TABLE public_submissions ADD "raw_tx" TEXT;
+
+COMMIT;
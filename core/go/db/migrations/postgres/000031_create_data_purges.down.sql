@@ -0,0 +1,5 @@
+BEGIN;
+DROP TABLE IF EXISTS purge_log;
+DROP FUNCTION IF EXISTS purge_log_append_only;
+DROP TABLE IF EXISTS purge_requests;
+COMMIT;
@@ -0,0 +1,47 @@
+BEGIN;
+
+-- Requests to purge the private data of states and transactions, which must be approved by
+-- a second person before they are executed
+CREATE TABLE purge_requests (
+    "id"                UUID       NOT NULL,
+    "created"           BIGINT     NOT NULL,
+    "requested_by"      TEXT       NOT NULL,
+    "reason"            TEXT       NOT NULL,
+    "domain"            TEXT,
+    "states"            TEXT       NOT NULL,
+    "transactions"      TEXT       NOT NULL,
+    "status"            TEXT       NOT NULL,
+    "approved_by"       TEXT,
+    "executed"          BIGINT,
+    PRIMARY KEY ("id")
+);
+
+CREATE INDEX purge_requests_created ON purge_requests("created");
+
+-- An append-only record of each state and transaction that has been purged, with the hash of
+-- the data that was removed
+CREATE TABLE purge_log (
+    "id"                UUID       NOT NULL,
+    "purge_request"     UUID       NOT NULL,
+    "created"           BIGINT     NOT NULL,
+    "target_type"       TEXT       NOT NULL,
+    "target_id"         TEXT       NOT NULL,
+    "domain"            TEXT,
+    "data_hash"         TEXT,
+    PRIMARY KEY ("id"),
+    FOREIGN KEY ("purge_request") REFERENCES purge_requests ("id")
+);
+
+CREATE INDEX purge_log_created ON purge_log("created");
+CREATE INDEX purge_log_target ON purge_log("target_id");
+
+CREATE FUNCTION purge_log_append_only() RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'purge_log is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER purge_log_append_only BEFORE UPDATE OR DELETE ON purge_log
+    FOR EACH ROW EXECUTE FUNCTION purge_log_append_only();
+
+COMMIT;
@@ -0,0 +1,10 @@
+BEGIN;
+
+-- Rows locked with SELECT FOR UPDATE to provide named locks on databases without advisory locks,
+-- such as CockroachDB. PostgreSQL uses pg_advisory_xact_lock instead.
+CREATE TABLE named_locks (
+    "id"                BIGINT     NOT NULL,
+    PRIMARY KEY ("id")
+);
+
+COMMIT;
@@ -0,0 +1,23 @@
+BEGIN;
+
+-- The fee paid for each base ledger transaction this node submitted, attributed to the identity
+-- that submitted the Paladin transaction, for internal chargeback
+CREATE TABLE transaction_fees (
+    "tx_hash"             TEXT    NOT NULL,
+    "transaction"         UUID    NOT NULL,
+    "time"                BIGINT  NOT NULL,
+    "block_number"        BIGINT  NOT NULL,
+    "signer"              TEXT    NOT NULL,
+    "identity"            TEXT    NOT NULL,
+    "tenant"              TEXT    NOT NULL,
+    "domain"              TEXT,
+    "gas_used"            BIGINT  NOT NULL,
+    "effective_gas_price" TEXT    NOT NULL,
+    "fee"                 TEXT    NOT NULL,
+    PRIMARY KEY ("tx_hash")
+);
+
+CREATE INDEX transaction_fees_time ON transaction_fees ("time");
+CREATE INDEX transaction_fees_transaction ON transaction_fees ("transaction");
+
+COMMIT;
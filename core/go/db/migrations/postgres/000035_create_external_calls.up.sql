@@ -0,0 +1,17 @@
+BEGIN;
+
+-- External calls are declared by domains when preparing a private transaction, and submitted as
+-- public transactions once it is confirmed. The dedup key ensures each is only ever submitted once.
+CREATE TABLE external_calls (
+  "dedup_key"    VARCHAR         NOT NULL,
+  "transaction"  UUID            NOT NULL,
+  "idx"          INT             NOT NULL,
+  "created"      BIGINT          NOT NULL,
+  "call"         VARCHAR         NOT NULL,
+  "submitted"    BIGINT,
+  "public_tx"    UUID,
+  PRIMARY KEY ("dedup_key")
+);
+CREATE INDEX external_calls_transaction ON external_calls ("transaction");
+
+COMMIT;
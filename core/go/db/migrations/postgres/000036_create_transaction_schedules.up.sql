@@ -0,0 +1,17 @@
+BEGIN;
+
+-- Transactions submitted with a notBefore time or block are held here until they are released
+-- into the private or public transaction manager.
+CREATE TABLE transaction_schedules (
+  "transaction"       UUID            NOT NULL,
+  "scheduled"         BIGINT          NOT NULL,
+  "not_before"        BIGINT,
+  "not_before_block"  BIGINT,
+  "public_tx"         VARCHAR,
+  "released"          BIGINT,
+  PRIMARY KEY ("transaction"),
+  FOREIGN KEY ("transaction") REFERENCES transactions ("id") ON DELETE CASCADE
+);
+CREATE INDEX transaction_schedules_released ON transaction_schedules ("released");
+
+COMMIT;
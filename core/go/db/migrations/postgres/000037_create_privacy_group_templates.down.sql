@@ -0,0 +1,3 @@
+BEGIN;
+DROP TABLE IF EXISTS privacy_group_templates;
+COMMIT;
@@ -0,0 +1,12 @@
+BEGIN;
+
+-- Privacy group templates registered via the API. Templates defined in configuration are not stored here.
+CREATE TABLE privacy_group_templates (
+  "name"          VARCHAR         NOT NULL,
+  "created"       BIGINT          NOT NULL,
+  "domain"        VARCHAR         NOT NULL,
+  "definition"    VARCHAR         NOT NULL,
+  PRIMARY KEY ("name")
+);
+
+COMMIT;
@@ -0,0 +1,20 @@
+BEGIN;
+
+-- Signed credentials attached to registry entries on this node
+CREATE TABLE reg_credentials (
+    "registry"           VARCHAR NOT NULL,
+    "id"                 VARCHAR NOT NULL,
+    "entry_id"           VARCHAR NOT NULL,
+    "type"               VARCHAR NOT NULL,
+    "issuer"             VARCHAR NOT NULL,
+    "expires"            BIGINT,
+    "attached"           BIGINT  NOT NULL,
+    "revoked"            BIGINT,
+    "credential"         VARCHAR NOT NULL,
+    PRIMARY KEY ("registry", "id"),
+    FOREIGN KEY ("registry", "entry_id") REFERENCES reg_entries ("registry", "id") ON DELETE CASCADE
+);
+
+CREATE INDEX reg_credentials_entry ON reg_credentials("registry", "entry_id");
+
+COMMIT;
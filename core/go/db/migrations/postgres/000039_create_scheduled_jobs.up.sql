@@ -0,0 +1,24 @@
+BEGIN;
+
+-- Jobs run by the job scheduler. Recurring jobs have a unique name and a schedule, one-shot jobs have neither.
+-- A job is leased by one node at a time, so replicas sharing the database do not run the same job concurrently.
+CREATE TABLE scheduled_jobs (
+  "id"            UUID            NOT NULL,
+  "name"          VARCHAR,
+  "created"       BIGINT          NOT NULL,
+  "job_type"      VARCHAR         NOT NULL,
+  "schedule"      VARCHAR,
+  "payload"       VARCHAR,
+  "next_run"      BIGINT          NOT NULL,
+  "attempt"       INT             NOT NULL,
+  "lease_owner"   VARCHAR,
+  "lease_expiry"  BIGINT,
+  "last_run"      BIGINT,
+  "last_error"    VARCHAR,
+  "completed"     BIGINT,
+  PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX scheduled_jobs_name ON scheduled_jobs("name");
+CREATE INDEX scheduled_jobs_next_run ON scheduled_jobs("next_run");
+
+COMMIT;
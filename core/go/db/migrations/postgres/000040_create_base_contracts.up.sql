@@ -0,0 +1,17 @@
+BEGIN;
+
+-- The base ledger contracts (registries, domain factories) this node has deployed or is bound to,
+-- with the version of each so that upgrades and differences between nodes can be detected.
+CREATE TABLE base_contracts (
+  "name"          VARCHAR         NOT NULL,
+  "created"       BIGINT          NOT NULL,
+  "updated"       BIGINT          NOT NULL,
+  "version"       VARCHAR         NOT NULL,
+  "origin"        VARCHAR         NOT NULL,
+  "address"       VARCHAR,
+  "transaction"   UUID,
+  "failure"       VARCHAR,
+  PRIMARY KEY ("name")
+);
+
+COMMIT;
@@ -0,0 +1,7 @@
+BEGIN;
+
+DROP INDEX transactions_by_tenant;
+
+ALTER TABLE transactions DROP COLUMN "tenant";
+
+COMMIT;
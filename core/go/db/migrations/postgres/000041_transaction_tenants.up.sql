@@ -0,0 +1,9 @@
+BEGIN;
+
+-- The tenant (authenticated RPC caller) that submitted each transaction, so that queries
+-- and quotas can be scoped to the submitting tenant. Null for un-scoped callers.
+ALTER TABLE transactions ADD COLUMN "tenant" TEXT;
+
+CREATE INDEX transactions_by_tenant ON transactions("tenant", "created");
+
+COMMIT;
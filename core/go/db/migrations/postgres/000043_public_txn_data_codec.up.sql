@@ -0,0 +1,7 @@
+BEGIN;
+
+-- The codec large calldata was compressed with at rest. Null for calldata stored uncompressed,
+-- including all public transactions written before compression was introduced.
+ALTER TABLE public_txns ADD COLUMN "data_codec" TEXT;
+
+COMMIT;
@@ -0,0 +1,7 @@
+BEGIN;
+
+-- Arbitrary JSON object supplied by the submitter, so upstream systems can correlate public
+-- transactions with their own identifiers using jsonEq queries.
+ALTER TABLE public_txns ADD COLUMN "metadata" TEXT;
+
+COMMIT;
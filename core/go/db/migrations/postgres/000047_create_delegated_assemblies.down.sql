@@ -0,0 +1,5 @@
+BEGIN;
+
+DROP TABLE delegated_assemblies;
+
+COMMIT;
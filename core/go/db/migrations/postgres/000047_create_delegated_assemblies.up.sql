@@ -0,0 +1,15 @@
+BEGIN;
+
+-- The assembly a sending node produced for a transaction it delegated to a remote coordinator.
+-- Kept until the transaction completes, so that after a failover (or a restart of the sending node)
+-- a new coordinator is given exactly the same assembly as the one it took over from.
+CREATE TABLE delegated_assemblies (
+  "transaction"               UUID            NOT NULL,
+  "contract_address"          VARCHAR         NOT NULL,
+  "coordinator"               VARCHAR         NOT NULL,
+  "post_assembly"             VARCHAR         NOT NULL,
+  "created"                   BIGINT          NOT NULL,
+  PRIMARY KEY ("transaction")
+);
+
+COMMIT;
@@ -0,0 +1,10 @@
+CREATE TABLE public_txn_blobs (
+  "pub_txn_id"                INTEGER         NOT NULL,
+  "idx"                       INT             NOT NULL,
+  "versioned_hash"            VARCHAR         NOT NULL,
+  "commitment"                VARCHAR         NOT NULL,
+  "proof"                     VARCHAR         NOT NULL,
+  "blob"                      VARCHAR         NOT NULL,
+  PRIMARY KEY ("pub_txn_id", "idx"),
+  FOREIGN KEY ("pub_txn_id") REFERENCES public_txns ("pub_txn_id") ON DELETE CASCADE
+);
@@ -0,0 +1,2 @@
+DROP TABLE public_txn_event_cursors;
+DROP TABLE public_txn_events;
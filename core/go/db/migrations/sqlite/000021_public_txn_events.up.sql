@@ -0,0 +1,16 @@
+CREATE TABLE public_txn_events (
+  "sequence"                  INTEGER         PRIMARY KEY AUTOINCREMENT,
+  "pub_txn_id"                INTEGER         NOT NULL,
+  "event_type"                VARCHAR         NOT NULL,
+  "tx_hash"                   VARCHAR,
+  "created"                   BIGINT          NOT NULL,
+  FOREIGN KEY ("pub_txn_id") REFERENCES public_txns ("pub_txn_id") ON DELETE CASCADE
+);
+CREATE INDEX public_txn_events_pub_txn_id ON public_txn_events("pub_txn_id");
+
+CREATE TABLE public_txn_event_cursors (
+  "subscriber"                VARCHAR         NOT NULL,
+  "sequence"                  BIGINT          NOT NULL,
+  "time"                      BIGINT          NOT NULL,
+  PRIMARY KEY ("subscriber")
+);
@@ -0,0 +1 @@
+DROP TABLE indexed_event_data;
@@ -0,0 +1,16 @@
+CREATE TABLE indexed_event_data (
+    "block_number"       BIGINT  NOT NULL,
+    "transaction_index"  INT     NOT NULL,
+    "log_index"          INT     NOT NULL,
+    "transaction_hash"   VARCHAR NOT NULL,
+    "signature"          VARCHAR NOT NULL,
+    "address"            VARCHAR NOT NULL,
+    "topics"             VARCHAR NOT NULL,
+    "raw_data"           VARCHAR NOT NULL,
+    "solidity_signature" VARCHAR NOT NULL,
+    "data"               VARCHAR NOT NULL,
+    PRIMARY KEY ("block_number", "transaction_index", "log_index"),
+    FOREIGN KEY ("block_number", "transaction_index", "log_index") REFERENCES indexed_events ("block_number", "transaction_index", "log_index") ON DELETE CASCADE
+);
+CREATE INDEX indexed_event_data_signature ON indexed_event_data("signature");
+CREATE INDEX indexed_event_data_address ON indexed_event_data("address");
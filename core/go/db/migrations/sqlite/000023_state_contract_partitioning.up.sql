@@ -0,0 +1,13 @@
+ALTER TABLE state_labels ADD COLUMN "contract_address" VARCHAR;
+ALTER TABLE state_int64_labels ADD COLUMN "contract_address" VARCHAR;
+
+UPDATE state_labels SET "contract_address" = (
+  SELECT s."contract_address" FROM states s WHERE s."domain_name" = state_labels."domain_name" AND s."id" = state_labels."state"
+);
+UPDATE state_int64_labels SET "contract_address" = (
+  SELECT s."contract_address" FROM states s WHERE s."domain_name" = state_int64_labels."domain_name" AND s."id" = state_int64_labels."state"
+);
+
+CREATE INDEX states_by_contract ON states("domain_name", "contract_address", "schema", "created");
+CREATE INDEX state_labels_by_contract ON state_labels("domain_name", "contract_address", "label", "value");
+CREATE INDEX state_int64_labels_by_contract ON state_int64_labels("domain_name", "contract_address", "label", "value");
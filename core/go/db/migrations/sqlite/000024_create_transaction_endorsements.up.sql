@@ -0,0 +1,15 @@
+CREATE TABLE transaction_endorsements (
+    "transaction"       UUID       NOT NULL,
+    "idx"               INT        NOT NULL,
+    "created"           BIGINT     NOT NULL,
+    "name"              VARCHAR    NOT NULL,
+    "attestation_type"  VARCHAR    NOT NULL,
+    "lookup"            VARCHAR    NOT NULL,
+    "verifier"          VARCHAR    NOT NULL,
+    "algorithm"         VARCHAR    NOT NULL,
+    "verifier_type"     VARCHAR    NOT NULL,
+    "payload_type"      VARCHAR    ,
+    "payload"           VARCHAR    ,
+    "constraints"       VARCHAR    ,
+    PRIMARY KEY ("transaction", "idx")
+);
@@ -0,0 +1,8 @@
+CREATE TABLE backup_manifests (
+    "id"                UUID       NOT NULL,
+    "created"           BIGINT     NOT NULL,
+    "manifest"          VARCHAR    NOT NULL,
+    PRIMARY KEY ("id")
+);
+
+CREATE INDEX backup_manifests_created ON backup_manifests("created");
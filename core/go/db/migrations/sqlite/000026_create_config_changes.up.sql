@@ -0,0 +1,11 @@
+CREATE TABLE config_changes (
+    "id"                UUID       NOT NULL,
+    "created"           BIGINT     NOT NULL,
+    "section"           VARCHAR    NOT NULL,
+    "previous"          VARCHAR    NOT NULL,
+    "value"             VARCHAR    NOT NULL,
+    "config_hash"       VARCHAR    NOT NULL,
+    PRIMARY KEY ("id")
+);
+
+CREATE INDEX config_changes_created ON config_changes("created");
@@ -0,0 +1,3 @@
+ALTER TABLE public_txns ADD "expiry" BIGINT;
+ALTER TABLE public_submissions ADD "cancel" BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE public_completions ADD "expired" BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS state_anchor_leaves;
+DROP TABLE IF EXISTS state_anchors;
@@ -0,0 +1 @@
+ALTER TABLE public_submissions DROP COLUMN "raw_tx";
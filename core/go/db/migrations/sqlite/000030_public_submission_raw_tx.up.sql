@@ -0,0 +1 @@
+ALTER TABLE public_submissions ADD "raw_tx" TEXT;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS purge_log;
+DROP TABLE IF EXISTS purge_requests;
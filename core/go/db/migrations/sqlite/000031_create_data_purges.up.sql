@@ -0,0 +1,40 @@
+CREATE TABLE purge_requests (
+    "id"                UUID       NOT NULL,
+    "created"           BIGINT     NOT NULL,
+    "requested_by"      VARCHAR    NOT NULL,
+    "reason"            VARCHAR    NOT NULL,
+    "domain"            VARCHAR,
+    "states"            VARCHAR    NOT NULL,
+    "transactions"      VARCHAR    NOT NULL,
+    "status"            VARCHAR    NOT NULL,
+    "approved_by"       VARCHAR,
+    "executed"          BIGINT,
+    PRIMARY KEY ("id")
+);
+
+CREATE INDEX purge_requests_created ON purge_requests("created");
+
+CREATE TABLE purge_log (
+    "id"                UUID       NOT NULL,
+    "purge_request"     UUID       NOT NULL,
+    "created"           BIGINT     NOT NULL,
+    "target_type"       VARCHAR    NOT NULL,
+    "target_id"         VARCHAR    NOT NULL,
+    "domain"            VARCHAR,
+    "data_hash"         VARCHAR,
+    PRIMARY KEY ("id"),
+    FOREIGN KEY ("purge_request") REFERENCES purge_requests ("id")
+);
+
+CREATE INDEX purge_log_created ON purge_log("created");
+CREATE INDEX purge_log_target ON purge_log("target_id");
+
+CREATE TRIGGER purge_log_no_update BEFORE UPDATE ON purge_log
+BEGIN
+    SELECT RAISE(ABORT, 'purge_log is append-only');
+END;
+
+CREATE TRIGGER purge_log_no_delete BEFORE DELETE ON purge_log
+BEGIN
+    SELECT RAISE(ABORT, 'purge_log is append-only');
+END;
@@ -0,0 +1,4 @@
+CREATE TABLE named_locks (
+    "id"                BIGINT     NOT NULL,
+    PRIMARY KEY ("id")
+);
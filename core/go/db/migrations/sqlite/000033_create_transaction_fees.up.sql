@@ -0,0 +1,17 @@
+CREATE TABLE transaction_fees (
+    "tx_hash"             VARCHAR  NOT NULL,
+    "transaction"         UUID     NOT NULL,
+    "time"                BIGINT   NOT NULL,
+    "block_number"        BIGINT   NOT NULL,
+    "signer"              VARCHAR  NOT NULL,
+    "identity"            VARCHAR  NOT NULL,
+    "tenant"              VARCHAR  NOT NULL,
+    "domain"              VARCHAR,
+    "gas_used"            BIGINT   NOT NULL,
+    "effective_gas_price" VARCHAR  NOT NULL,
+    "fee"                 VARCHAR  NOT NULL,
+    PRIMARY KEY ("tx_hash")
+);
+
+CREATE INDEX transaction_fees_time ON transaction_fees ("time");
+CREATE INDEX transaction_fees_transaction ON transaction_fees ("transaction");
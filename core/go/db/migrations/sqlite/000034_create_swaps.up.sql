@@ -0,0 +1,21 @@
+-- Swaps coordinate prepared transactions from one or more domains, which settle atomically on
+-- the base ledger through an Atom contract. The legs and derived operations are stored as JSON.
+CREATE TABLE swaps (
+  "id"           UUID            NOT NULL,
+  "name"         VARCHAR,
+  "created"      BIGINT          NOT NULL,
+  "updated"      BIGINT          NOT NULL,
+  "from"         VARCHAR         NOT NULL,
+  "atom_factory" VARCHAR         NOT NULL,
+  "status"       VARCHAR         NOT NULL,
+  "legs"         VARCHAR         NOT NULL,
+  "operations"   VARCHAR,
+  "atom"         VARCHAR,
+  "approve_tx"   UUID,
+  "execute_tx"   UUID,
+  "rollback_tx"  UUID,
+  "failure"      VARCHAR,
+  PRIMARY KEY ("id")
+);
+CREATE INDEX swaps_created ON swaps ("created");
+CREATE INDEX swaps_status ON swaps ("status");
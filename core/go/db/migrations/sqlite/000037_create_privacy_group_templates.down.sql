@@ -0,0 +1 @@
+DROP TABLE IF EXISTS privacy_group_templates;
@@ -0,0 +1,16 @@
+-- Signed credentials attached to registry entries on this node
+CREATE TABLE reg_credentials (
+    "registry"           TEXT    NOT NULL,
+    "id"                 TEXT    NOT NULL,
+    "entry_id"           TEXT    NOT NULL,
+    "type"               TEXT    NOT NULL,
+    "issuer"             TEXT    NOT NULL,
+    "expires"            BIGINT,
+    "attached"           BIGINT  NOT NULL,
+    "revoked"            BIGINT,
+    "credential"         TEXT    NOT NULL,
+    PRIMARY KEY ("registry", "id"),
+    FOREIGN KEY ("registry", "entry_id") REFERENCES reg_entries ("registry", "id") ON DELETE CASCADE
+);
+
+CREATE INDEX reg_credentials_entry ON reg_credentials("registry", "entry_id");
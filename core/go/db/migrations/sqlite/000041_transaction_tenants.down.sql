@@ -0,0 +1,3 @@
+DROP INDEX transactions_by_tenant;
+
+ALTER TABLE transactions DROP COLUMN "tenant";
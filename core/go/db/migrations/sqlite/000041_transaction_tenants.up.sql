@@ -0,0 +1,3 @@
+ALTER TABLE transactions ADD COLUMN "tenant" VARCHAR;
+
+CREATE INDEX transactions_by_tenant ON transactions("tenant", "created");
@@ -0,0 +1,20 @@
+-- Deliveries of receipt events to the webhooks configured on this node. Each delivery is attempted by a
+-- job on the job scheduler, and retried with backoff until it is delivered or the attempts are exhausted.
+CREATE TABLE webhook_deliveries (
+  "id"                UUID            NOT NULL,
+  "webhook"           VARCHAR         NOT NULL,
+  "created"           BIGINT          NOT NULL,
+  "event_type"        VARCHAR         NOT NULL,
+  "transaction"       UUID            NOT NULL,
+  "sequence"          BIGINT          NOT NULL,
+  "payload"           VARCHAR         NOT NULL,
+  "status"            VARCHAR         NOT NULL,
+  "attempts"          INT             NOT NULL,
+  "last_attempt"      BIGINT,
+  "last_status_code"  INT,
+  "last_error"        VARCHAR,
+  "delivered"         BIGINT,
+  PRIMARY KEY ("id")
+);
+CREATE INDEX webhook_deliveries_webhook ON webhook_deliveries("webhook", "created");
+CREATE INDEX webhook_deliveries_transaction ON webhook_deliveries("transaction");
@@ -0,0 +1,3 @@
+-- The codec large calldata was compressed with at rest. Null for calldata stored uncompressed,
+-- including all public transactions written before compression was introduced.
+ALTER TABLE public_txns ADD COLUMN "data_codec" VARCHAR;
@@ -0,0 +1,3 @@
+-- Arbitrary JSON object supplied by the submitter, so upstream systems can correlate public
+-- transactions with their own identifiers using jsonEq queries.
+ALTER TABLE public_txns ADD COLUMN "metadata" VARCHAR;
@@ -0,0 +1,12 @@
+-- Registrations of an ABI against a deployed contract, either by the address of a single
+-- contract, or by the keccak256 hash of the runtime code shared by every deployment of it.
+CREATE TABLE contract_abis (
+  "address"                   VARCHAR,
+  "code_hash"                 VARCHAR,
+  "abi_hash"                  VARCHAR         NOT NULL,
+  "created"                   BIGINT          NOT NULL,
+  FOREIGN KEY ("abi_hash") REFERENCES abis ("hash") ON DELETE CASCADE
+);
+CREATE UNIQUE INDEX contract_abis_address ON contract_abis("address");
+CREATE UNIQUE INDEX contract_abis_code_hash ON contract_abis("code_hash");
+CREATE INDEX contract_abis_created ON contract_abis("created");
@@ -0,0 +1,3 @@
+DROP INDEX state_confirm_records_tx_hash;
+ALTER TABLE state_confirm_records DROP COLUMN "block_number";
+ALTER TABLE state_confirm_records DROP COLUMN "tx_hash";
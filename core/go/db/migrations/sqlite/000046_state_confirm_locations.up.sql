@@ -0,0 +1,5 @@
+-- The base ledger transaction and block that confirmed each state, where the domain reported
+-- the on-chain location of the confirmation. Existing records are left without a location.
+ALTER TABLE state_confirm_records ADD COLUMN "tx_hash" TEXT;
+ALTER TABLE state_confirm_records ADD COLUMN "block_number" BIGINT;
+CREATE INDEX state_confirm_records_tx_hash ON state_confirm_records("tx_hash");
@@ -0,0 +1 @@
+DROP TABLE delegated_assemblies;
@@ -0,0 +1,188 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package componentmgr
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/internal/msgs"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/toolkit/pkg/rpcserver"
+)
+
+func (cm *componentManager) initAdminRPC() {
+	cm.adminRPCModule = rpcserver.NewRPCModule("admin").
+		Add("admin_getLogLevels", cm.rpcGetLogLevels()).
+		Add("admin_setLogLevel", cm.rpcSetLogLevel()).
+		Add("admin_setSubsystemLogLevel", cm.rpcSetSubsystemLogLevel()).
+		Add("admin_quiesce", cm.rpcQuiesce()).
+		Add("admin_resume", cm.rpcResume()).
+		Add("admin_getQuiesceStatus", cm.rpcGetQuiesceStatus()).
+		Add("admin_drain", cm.rpcDrain()).
+		Add("admin_cancelDrain", cm.rpcCancelDrain()).
+		Add("admin_getDrainStatus", cm.rpcGetDrainStatus()).
+		Add("admin_createBackupManifest", cm.rpcCreateBackupManifest()).
+		Add("admin_listConfigChanges", cm.rpcListConfigChanges()).
+		Add("admin_requestPurge", cm.rpcRequestPurge()).
+		Add("admin_approvePurge", cm.rpcApprovePurge()).
+		Add("admin_listPurgeRequests", cm.rpcListPurgeRequests()).
+		Add("admin_listPurgeLog", cm.rpcListPurgeLog()).
+		Add("admin_getGasPriceSchedules", cm.rpcGetGasPriceSchedules()).
+		Add("admin_setGasPriceSchedules", cm.rpcSetGasPriceSchedules())
+}
+
+func (cm *componentManager) rpcGetLogLevels() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod0(func(ctx context.Context) (*log.Levels, error) {
+		return log.GetLevels(), nil
+	})
+}
+
+func (cm *componentManager) rpcSetLogLevel() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod1(func(ctx context.Context,
+		level string,
+	) (*log.Levels, error) {
+		if !log.IsValidLevel(level) {
+			return nil, i18n.NewError(ctx, msgs.MsgComponentInvalidLogLevel, level)
+		}
+		log.L(ctx).Infof("Setting log level to %s", level)
+		log.SetLevel(level)
+		return log.GetLevels(), nil
+	})
+}
+
+func (cm *componentManager) rpcSetSubsystemLogLevel() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod2(func(ctx context.Context,
+		subsystem string,
+		level string, // empty string reverts the subsystem to the root level
+	) (*log.Levels, error) {
+		if level != "" && !log.IsValidLevel(level) {
+			return nil, i18n.NewError(ctx, msgs.MsgComponentInvalidLogLevel, level)
+		}
+		log.L(ctx).Infof("Setting log level for subsystem %s to '%s'", subsystem, level)
+		log.SetSubsystemLevel(subsystem, level)
+		return log.GetLevels(), nil
+	})
+}
+
+func (cm *componentManager) rpcQuiesce() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod0(func(ctx context.Context) (*pldapi.QuiesceStatus, error) {
+		return cm.quiesce(ctx)
+	})
+}
+
+func (cm *componentManager) rpcResume() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod0(func(ctx context.Context) (*pldapi.QuiesceStatus, error) {
+		return cm.resume(ctx), nil
+	})
+}
+
+func (cm *componentManager) rpcGetQuiesceStatus() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod0(func(ctx context.Context) (*pldapi.QuiesceStatus, error) {
+		return cm.getQuiesceStatus(ctx), nil
+	})
+}
+
+func (cm *componentManager) rpcDrain() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod0(func(ctx context.Context) (*pldapi.DrainStatus, error) {
+		return cm.drain(ctx), nil
+	})
+}
+
+func (cm *componentManager) rpcCancelDrain() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod0(func(ctx context.Context) (*pldapi.DrainStatus, error) {
+		return cm.cancelDrain(ctx), nil
+	})
+}
+
+func (cm *componentManager) rpcGetDrainStatus() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod0(func(ctx context.Context) (*pldapi.DrainStatus, error) {
+		return cm.getDrainStatus(ctx), nil
+	})
+}
+
+func (cm *componentManager) rpcCreateBackupManifest() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod0(func(ctx context.Context) (*pldapi.BackupManifest, error) {
+		return cm.createBackupManifest(ctx)
+	})
+}
+
+func (cm *componentManager) rpcListConfigChanges() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod1(func(ctx context.Context,
+		limit int,
+	) ([]*pldapi.ConfigChange, error) {
+		if limit <= 0 {
+			limit = 100
+		}
+		return cm.listConfigChanges(ctx, limit)
+	})
+}
+
+func (cm *componentManager) rpcRequestPurge() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod1(func(ctx context.Context,
+		input pldapi.DataPurgeRequestInput,
+	) (*pldapi.DataPurgeRequest, error) {
+		return cm.requestPurge(ctx, &input)
+	})
+}
+
+func (cm *componentManager) rpcApprovePurge() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod2(func(ctx context.Context,
+		id uuid.UUID,
+		approvedBy string,
+	) (*pldapi.DataPurgeRequest, error) {
+		return cm.approvePurge(ctx, id, approvedBy)
+	})
+}
+
+func (cm *componentManager) rpcListPurgeRequests() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod1(func(ctx context.Context,
+		limit int,
+	) ([]*pldapi.DataPurgeRequest, error) {
+		if limit <= 0 {
+			limit = 100
+		}
+		return cm.listPurgeRequests(ctx, limit)
+	})
+}
+
+func (cm *componentManager) rpcListPurgeLog() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod1(func(ctx context.Context,
+		limit int,
+	) ([]*pldapi.DataPurgeLogEntry, error) {
+		if limit <= 0 {
+			limit = 100
+		}
+		return cm.listPurgeLog(ctx, limit)
+	})
+}
+
+func (cm *componentManager) rpcGetGasPriceSchedules() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod0(func(ctx context.Context) ([]*pldconf.GasPriceScheduleConfig, error) {
+		return cm.publicTxManager.GetGasPriceSchedules(ctx), nil
+	})
+}
+
+func (cm *componentManager) rpcSetGasPriceSchedules() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod1(func(ctx context.Context,
+		schedules []*pldconf.GasPriceScheduleConfig,
+	) (bool, error) {
+		return true, cm.publicTxManager.SetGasPriceSchedules(ctx, schedules)
+	})
+}
@@ -0,0 +1,125 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package componentmgr
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/mocks/componentmocks"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/rpcclient"
+	"github.com/kaleido-io/paladin/toolkit/pkg/rpcserver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func callAdminRPC(t *testing.T, handler rpcserver.RPCHandler, params ...any) (*log.Levels, *rpcclient.RPCError) {
+	jsonParams := make([]pldtypes.RawJSON, len(params))
+	for i, p := range params {
+		jsonParams[i] = pldtypes.JSONString(p)
+	}
+	res := handler.Handle(context.Background(), &rpcclient.RPCRequest{
+		JSONRpc: "2.0",
+		ID:      pldtypes.RawJSON(`1`),
+		Params:  jsonParams,
+	})
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	var levels log.Levels
+	require.NoError(t, json.Unmarshal(res.Result, &levels))
+	return &levels, nil
+}
+
+func TestAdminRPCLogLevels(t *testing.T) {
+	defer log.InitConfig(&pldconf.LogConfig{})
+
+	cm := NewComponentManager(context.Background(), tempSocketFile(t), uuid.New(), &pldconf.PaladinConfig{}).(*componentManager)
+	cm.initAdminRPC()
+	assert.Equal(t, []string{
+		"admin_approvePurge", "admin_cancelDrain", "admin_createBackupManifest", "admin_drain", "admin_getDrainStatus",
+		"admin_getGasPriceSchedules", "admin_getLogLevels", "admin_getQuiesceStatus", "admin_listConfigChanges", "admin_listPurgeLog",
+		"admin_listPurgeRequests", "admin_quiesce", "admin_requestPurge", "admin_resume", "admin_setGasPriceSchedules",
+		"admin_setLogLevel", "admin_setSubsystemLogLevel",
+	}, cm.adminRPCModule.MethodNames())
+
+	levels, rpcErr := callAdminRPC(t, cm.rpcSetLogLevel(), "debug")
+	require.Nil(t, rpcErr)
+	assert.Equal(t, "debug", levels.Level)
+
+	levels, rpcErr = callAdminRPC(t, cm.rpcSetSubsystemLogLevel(), "admintest", "trace")
+	require.Nil(t, rpcErr)
+	assert.Equal(t, "trace", levels.Subsystems["admintest"])
+
+	levels, rpcErr = callAdminRPC(t, cm.rpcSetSubsystemLogLevel(), "admintest", "")
+	require.Nil(t, rpcErr)
+	assert.Equal(t, "debug", levels.Subsystems["admintest"])
+
+	levels, rpcErr = callAdminRPC(t, cm.rpcGetLogLevels())
+	require.Nil(t, rpcErr)
+	assert.Equal(t, "debug", levels.Level)
+
+	_, rpcErr = callAdminRPC(t, cm.rpcSetLogLevel(), "verbose")
+	assert.Regexp(t, "PD010036", rpcErr.Message)
+
+	_, rpcErr = callAdminRPC(t, cm.rpcSetSubsystemLogLevel(), "admintest", "verbose")
+	assert.Regexp(t, "PD010036", rpcErr.Message)
+}
+
+func TestAdminRPCGasPriceSchedules(t *testing.T) {
+	schedules := []*pldconf.GasPriceScheduleConfig{
+		{Name: "settlement", StartTime: "16:00", EndTime: "17:00", Multiplier: confutil.P(2.0)},
+	}
+	publicTxManager := componentmocks.NewPublicTxManager(t)
+	publicTxManager.On("GetGasPriceSchedules", mock.Anything).Return(schedules).Once()
+	publicTxManager.On("SetGasPriceSchedules", mock.Anything, schedules).Return(nil).Once()
+	publicTxManager.On("SetGasPriceSchedules", mock.Anything, []*pldconf.GasPriceScheduleConfig{}).Return(fmt.Errorf("pop")).Once()
+
+	cm := NewComponentManager(context.Background(), tempSocketFile(t), uuid.New(), &pldconf.PaladinConfig{}).(*componentManager)
+	cm.publicTxManager = publicTxManager
+
+	res := cm.rpcGetGasPriceSchedules().Handle(context.Background(), &rpcclient.RPCRequest{
+		JSONRpc: "2.0",
+		ID:      pldtypes.RawJSON(`1`),
+	})
+	require.Nil(t, res.Error)
+	var retrieved []*pldconf.GasPriceScheduleConfig
+	require.NoError(t, json.Unmarshal(res.Result, &retrieved))
+	assert.Equal(t, schedules, retrieved)
+
+	res = cm.rpcSetGasPriceSchedules().Handle(context.Background(), &rpcclient.RPCRequest{
+		JSONRpc: "2.0",
+		ID:      pldtypes.RawJSON(`1`),
+		Params:  []pldtypes.RawJSON{pldtypes.JSONString(schedules)},
+	})
+	require.Nil(t, res.Error)
+	assert.JSONEq(t, `true`, res.Result.String())
+
+	res = cm.rpcSetGasPriceSchedules().Handle(context.Background(), &rpcclient.RPCRequest{
+		JSONRpc: "2.0",
+		ID:      pldtypes.RawJSON(`1`),
+		Params:  []pldtypes.RawJSON{pldtypes.JSONString([]*pldconf.GasPriceScheduleConfig{})},
+	})
+	assert.Regexp(t, "pop", res.Error.Message)
+}
@@ -0,0 +1,201 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package componentmgr
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/google/uuid"
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/internal/msgs"
+	"github.com/kaleido-io/paladin/core/pkg/persistence"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+)
+
+// The manifest is written into the database itself while the node is quiesced, so the most
+// recent manifest in a restored database describes the backup that database was restored from.
+type dbBackupManifest struct {
+	ID       uuid.UUID          `gorm:"column:id"`
+	Created  pldtypes.Timestamp `gorm:"column:created"`
+	Manifest pldtypes.RawJSON   `gorm:"column:manifest"`
+}
+
+func (dbBackupManifest) TableName() string {
+	return "backup_manifests"
+}
+
+func (cm *componentManager) configHash() pldtypes.Bytes32 {
+	return pldtypes.Bytes32Keccak(pldtypes.JSONString(cm.conf))
+}
+
+func (cm *componentManager) quiesce(ctx context.Context) (*pldapi.QuiesceStatus, error) {
+	timeout := confutil.DurationMin(cm.conf.Backup.QuiesceTimeout, 0, *pldconf.BackupConfigDefaults.QuiesceTimeout)
+	quiesceCtx, cancelCtx := context.WithTimeout(ctx, timeout)
+	defer cancelCtx()
+	// If we time out the engine stays quiesced, so the caller can retry or resume
+	if err := cm.publicTxManager.Quiesce(quiesceCtx); err != nil {
+		return nil, err
+	}
+	return cm.getQuiesceStatus(ctx), nil
+}
+
+func (cm *componentManager) resume(ctx context.Context) *pldapi.QuiesceStatus {
+	cm.publicTxManager.Unquiesce(ctx)
+	return cm.getQuiesceStatus(ctx)
+}
+
+func (cm *componentManager) getQuiesceStatus(ctx context.Context) *pldapi.QuiesceStatus {
+	status := &pldapi.QuiesceStatus{
+		Quiesced: cm.publicTxManager.IsQuiesced(),
+	}
+	if confirmed, err := cm.blockIndexer.GetConfirmedBlockHeight(ctx); err == nil {
+		status.ConfirmedBlock = &confirmed
+	}
+	return status
+}
+
+func (cm *componentManager) createBackupManifest(ctx context.Context) (*pldapi.BackupManifest, error) {
+	if !cm.publicTxManager.IsQuiesced() {
+		return nil, i18n.NewError(ctx, msgs.MsgComponentBackupNotQuiesced)
+	}
+
+	manifest := &pldapi.BackupManifest{
+		ID:         uuid.New(),
+		Created:    pldtypes.TimestampNow(),
+		Node:       cm.transportManager.LocalNodeName(),
+		ConfigHash: cm.configHash(),
+	}
+	confirmed, err := cm.blockIndexer.GetConfirmedBlockHeight(ctx)
+	if err != nil {
+		return nil, err
+	}
+	manifest.ConfirmedBlock = confirmed
+
+	err = cm.persistence.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) (err error) {
+		manifest.Database, err = getBackupDatabaseInfo(ctx, dbTX)
+		if err == nil {
+			manifest.Keys, err = cm.getBackupKeyInfo(ctx, dbTX)
+		}
+		if err == nil {
+			manifest.Signers, err = cm.publicTxManager.GetSignerNonces(ctx, dbTX)
+		}
+		if err == nil {
+			err = dbTX.DB().WithContext(ctx).Create(&dbBackupManifest{
+				ID:       manifest.ID,
+				Created:  manifest.Created,
+				Manifest: pldtypes.JSONString(manifest),
+			}).Error
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	log.L(ctx).Infof("Created backup manifest %s at block %d with %d signers", manifest.ID, manifest.ConfirmedBlock, len(manifest.Signers))
+	return manifest, nil
+}
+
+func getBackupDatabaseInfo(ctx context.Context, dbTX persistence.DBTX) (*pldapi.BackupDatabaseInfo, error) {
+	db := dbTX.DB().WithContext(ctx)
+	dialect := persistence.GetDialect(db)
+	info := &pldapi.BackupDatabaseInfo{
+		Type: dialect.Name(),
+	}
+	if positionQuery := dialect.LogPositionQuery(); positionQuery != "" {
+		// Point-in-time recovery must target a position at or after this one
+		if err := db.Raw(positionQuery).Scan(&info.Position).Error; err != nil {
+			return nil, err
+		}
+	}
+	return info, nil
+}
+
+func (cm *componentManager) getBackupKeyInfo(ctx context.Context, dbTX persistence.DBTX) (*pldapi.BackupKeyInfo, error) {
+	info := &pldapi.BackupKeyInfo{
+		Wallets: make([]string, len(cm.conf.Wallets)),
+	}
+	for i, w := range cm.conf.Wallets {
+		info.Wallets[i] = w.Name
+	}
+	err := dbTX.DB().WithContext(ctx).Table("key_mappings").Count(&info.KeyMappings).Error
+	if err == nil {
+		err = dbTX.DB().WithContext(ctx).Table("key_verifiers").Count(&info.KeyVerifiers).Error
+	}
+	if err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+func (cm *componentManager) getLatestBackupManifest(ctx context.Context, dbTX persistence.DBTX) (*pldapi.BackupManifest, error) {
+	var dbManifests []*dbBackupManifest
+	err := dbTX.DB().WithContext(ctx).
+		Order(`"created" DESC`).
+		Limit(1).
+		Find(&dbManifests).
+		Error
+	if err != nil || len(dbManifests) == 0 {
+		return nil, err
+	}
+	var manifest *pldapi.BackupManifest
+	err = json.Unmarshal(dbManifests[0].Manifest, &manifest)
+	return manifest, err
+}
+
+// validateRestore runs on every startup, as we cannot tell a restart from a restore. If the database
+// is behind the chain for any signer in the latest manifest, then transactions were submitted after
+// the backup was taken and starting would re-use their nonces.
+func (cm *componentManager) validateRestore() error {
+	ctx := cm.bgCtx
+	if !confutil.Bool(cm.conf.Backup.ValidateRestore, *pldconf.BackupConfigDefaults.ValidateRestore) {
+		return nil
+	}
+
+	manifest, err := cm.getLatestBackupManifest(ctx, cm.persistence.NOTX())
+	if err != nil || manifest == nil {
+		return err
+	}
+	log.L(ctx).Infof("Validating database against backup manifest %s created %s", manifest.ID, manifest.Created)
+	if manifest.ConfigHash != cm.configHash() {
+		log.L(ctx).Warnf("Configuration has changed since backup manifest %s was created", manifest.ID)
+	}
+
+	dbNonces, err := cm.publicTxManager.GetSignerNonces(ctx, cm.persistence.NOTX())
+	if err != nil {
+		return i18n.WrapError(ctx, err, msgs.MsgComponentRestoreValidationError, manifest.ID)
+	}
+	nextNonces := make(map[pldtypes.EthAddress]pldtypes.HexUint64, len(dbNonces))
+	for _, sn := range dbNonces {
+		nextNonces[sn.Signer] = sn.NextNonce
+	}
+
+	ethClient := cm.ethClientFactory.HTTPClient()
+	for _, sn := range manifest.Signers {
+		chainNonce, err := ethClient.GetTransactionCount(ctx, sn.Signer)
+		if err != nil {
+			return i18n.WrapError(ctx, err, msgs.MsgComponentRestoreValidationError, manifest.ID)
+		}
+		if *chainNonce > nextNonces[sn.Signer] {
+			return i18n.NewError(ctx, msgs.MsgComponentRestoreBehindChain, sn.Signer, manifest.ID, nextNonces[sn.Signer], *chainNonce)
+		}
+	}
+	return nil
+}
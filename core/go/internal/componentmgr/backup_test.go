@@ -0,0 +1,225 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package componentmgr
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/mocks/componentmocks"
+	"github.com/kaleido-io/paladin/core/mocks/ethclientmocks"
+	"github.com/kaleido-io/paladin/core/pkg/persistence"
+	"github.com/kaleido-io/paladin/core/pkg/persistence/mockpersistence"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/rpcclient"
+	"github.com/kaleido-io/paladin/toolkit/pkg/rpcserver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type backupTestMocks struct {
+	publicTxManager  *componentmocks.PublicTxManager
+	blockIndexer     *componentmocks.BlockIndexer
+	transportManager *componentmocks.TransportManager
+	ethClientFactory *ethclientmocks.EthClientFactory
+	ethClient        *ethclientmocks.EthClient
+}
+
+func newTestBackupComponentManager(t *testing.T, p persistence.Persistence, conf *pldconf.PaladinConfig) (context.Context, *componentManager, *backupTestMocks) {
+	ctx := context.Background()
+	m := &backupTestMocks{
+		publicTxManager:  componentmocks.NewPublicTxManager(t),
+		blockIndexer:     componentmocks.NewBlockIndexer(t),
+		transportManager: componentmocks.NewTransportManager(t),
+		ethClientFactory: ethclientmocks.NewEthClientFactory(t),
+		ethClient:        ethclientmocks.NewEthClient(t),
+	}
+	m.ethClientFactory.On("HTTPClient").Return(m.ethClient).Maybe()
+	m.transportManager.On("LocalNodeName").Return("node1").Maybe()
+
+	cm := NewComponentManager(ctx, tempSocketFile(t), uuid.New(), conf).(*componentManager)
+	cm.persistence = p
+	cm.publicTxManager = m.publicTxManager
+	cm.blockIndexer = m.blockIndexer
+	cm.transportManager = m.transportManager
+	cm.ethClientFactory = m.ethClientFactory
+	return ctx, cm, m
+}
+
+func newTestBackupRealDB(t *testing.T) persistence.Persistence {
+	p, cleanup, err := persistence.NewUnitTestPersistence(context.Background(), "componentmgr")
+	require.NoError(t, err)
+	t.Cleanup(cleanup)
+	return p
+}
+
+func callBackupRPC[T any](t *testing.T, handler rpcserver.RPCHandler) (*T, *rpcclient.RPCError) {
+	res := handler.Handle(context.Background(), &rpcclient.RPCRequest{
+		JSONRpc: "2.0",
+		ID:      pldtypes.RawJSON(`1`),
+	})
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	var result T
+	require.NoError(t, json.Unmarshal(res.Result, &result))
+	return &result, nil
+}
+
+func TestBackupQuiesceManifestResume(t *testing.T) {
+	conf := &pldconf.PaladinConfig{}
+	conf.Wallets = []*pldconf.WalletConfig{{Name: "wallet1"}}
+	ctx, cm, m := newTestBackupComponentManager(t, newTestBackupRealDB(t), conf)
+
+	signer := *pldtypes.RandAddress()
+	quiesced := false
+	m.publicTxManager.On("Quiesce", mock.Anything).Return(nil).Run(func(args mock.Arguments) { quiesced = true })
+	m.publicTxManager.On("Unquiesce", mock.Anything).Return().Run(func(args mock.Arguments) { quiesced = false })
+	m.publicTxManager.On("IsQuiesced").Return(func() bool { return quiesced })
+	m.publicTxManager.On("GetSignerNonces", mock.Anything, mock.Anything).Return([]*pldapi.SignerNonce{
+		{Signer: signer, NextNonce: 10},
+	}, nil)
+	m.blockIndexer.On("GetConfirmedBlockHeight", mock.Anything).Return(pldtypes.HexUint64(12345), nil)
+
+	// cannot create a manifest until quiesced
+	cm.initAdminRPC()
+	_, rpcErr := callBackupRPC[pldapi.BackupManifest](t, cm.rpcCreateBackupManifest())
+	assert.Regexp(t, "PD010037", rpcErr.Message)
+
+	status, rpcErr := callBackupRPC[pldapi.QuiesceStatus](t, cm.rpcQuiesce())
+	require.Nil(t, rpcErr)
+	assert.True(t, status.Quiesced)
+	assert.Equal(t, pldtypes.HexUint64(12345), *status.ConfirmedBlock)
+
+	manifest, rpcErr := callBackupRPC[pldapi.BackupManifest](t, cm.rpcCreateBackupManifest())
+	require.Nil(t, rpcErr)
+	assert.Equal(t, "node1", manifest.Node)
+	assert.Equal(t, cm.configHash(), manifest.ConfigHash)
+	assert.Equal(t, pldtypes.HexUint64(12345), manifest.ConfirmedBlock)
+	assert.Equal(t, "sqlite", manifest.Database.Type)
+	assert.Equal(t, []string{"wallet1"}, manifest.Keys.Wallets)
+	assert.Equal(t, []*pldapi.SignerNonce{{Signer: signer, NextNonce: 10}}, manifest.Signers)
+
+	stored, err := cm.getLatestBackupManifest(ctx, cm.persistence.NOTX())
+	require.NoError(t, err)
+	assert.Equal(t, manifest.ID, stored.ID)
+
+	status, rpcErr = callBackupRPC[pldapi.QuiesceStatus](t, cm.rpcResume())
+	require.Nil(t, rpcErr)
+	assert.False(t, status.Quiesced)
+
+	status, rpcErr = callBackupRPC[pldapi.QuiesceStatus](t, cm.rpcGetQuiesceStatus())
+	require.Nil(t, rpcErr)
+	assert.False(t, status.Quiesced)
+
+	// the chain has caught up with the backup, and no further - so a restart is fine
+	m.ethClient.On("GetTransactionCount", mock.Anything, signer).Return(confutil.P(pldtypes.HexUint64(10)), nil).Once()
+	require.NoError(t, cm.validateRestore())
+
+	// the chain has moved on since the backup, but the DB is behind
+	m.ethClient.On("GetTransactionCount", mock.Anything, signer).Return(confutil.P(pldtypes.HexUint64(11)), nil).Once()
+	err = cm.validateRestore()
+	assert.Regexp(t, "PD010038.*"+manifest.ID.String(), err)
+
+	// unless disabled
+	cm.conf.Backup.ValidateRestore = confutil.P(false)
+	require.NoError(t, cm.validateRestore())
+}
+
+func TestBackupQuiesceFail(t *testing.T) {
+	_, cm, m := newTestBackupComponentManager(t, nil, &pldconf.PaladinConfig{})
+	m.publicTxManager.On("Quiesce", mock.Anything).Return(fmt.Errorf("pop"))
+
+	_, rpcErr := callBackupRPC[pldapi.QuiesceStatus](t, cm.rpcQuiesce())
+	assert.Regexp(t, "pop", rpcErr.Message)
+}
+
+func TestGetQuiesceStatusNoBlocks(t *testing.T) {
+	ctx, cm, m := newTestBackupComponentManager(t, nil, &pldconf.PaladinConfig{})
+	m.publicTxManager.On("IsQuiesced").Return(true)
+	m.blockIndexer.On("GetConfirmedBlockHeight", mock.Anything).Return(pldtypes.HexUint64(0), fmt.Errorf("no blocks"))
+
+	status := cm.getQuiesceStatus(ctx)
+	assert.True(t, status.Quiesced)
+	assert.Nil(t, status.ConfirmedBlock)
+
+	_, err := cm.createBackupManifest(ctx)
+	assert.Regexp(t, "no blocks", err)
+}
+
+func TestCreateBackupManifestDBFail(t *testing.T) {
+	mp, err := mockpersistence.NewSQLMockProvider()
+	require.NoError(t, err)
+	ctx, cm, m := newTestBackupComponentManager(t, mp.P, &pldconf.PaladinConfig{})
+	m.publicTxManager.On("IsQuiesced").Return(true)
+	m.blockIndexer.On("GetConfirmedBlockHeight", mock.Anything).Return(pldtypes.HexUint64(12345), nil)
+
+	mp.Mock.ExpectBegin()
+	mp.Mock.ExpectQuery("pg_current_wal_lsn").WillReturnRows(mp.Mock.NewRows([]string{"pg_current_wal_lsn"}).AddRow("0/16B3748"))
+	mp.Mock.ExpectQuery("SELECT.*key_mappings").WillReturnError(fmt.Errorf("pop"))
+	mp.Mock.ExpectRollback()
+
+	_, err = cm.createBackupManifest(ctx)
+	assert.Regexp(t, "pop", err)
+}
+
+func TestGetBackupDatabaseInfoPostgres(t *testing.T) {
+	mp, err := mockpersistence.NewSQLMockProvider()
+	require.NoError(t, err)
+	mp.Mock.ExpectQuery("pg_current_wal_lsn").WillReturnRows(mp.Mock.NewRows([]string{"pg_current_wal_lsn"}).AddRow("0/16B3748"))
+
+	info, err := getBackupDatabaseInfo(context.Background(), mp.P.NOTX())
+	require.NoError(t, err)
+	assert.Equal(t, "postgres", info.Type)
+	assert.Equal(t, "0/16B3748", info.Position)
+
+	mp.Mock.ExpectQuery("pg_current_wal_lsn").WillReturnError(fmt.Errorf("pop"))
+	_, err = getBackupDatabaseInfo(context.Background(), mp.P.NOTX())
+	assert.Regexp(t, "pop", err)
+}
+
+func TestValidateRestoreFailures(t *testing.T) {
+	mp, err := mockpersistence.NewSQLMockProvider()
+	require.NoError(t, err)
+	_, cm, m := newTestBackupComponentManager(t, mp.P, &pldconf.PaladinConfig{})
+
+	manifestID := uuid.New()
+	signer := *pldtypes.RandAddress()
+	manifestRows := func() {
+		mp.Mock.ExpectQuery("SELECT.*backup_manifests").WillReturnRows(mp.Mock.NewRows([]string{"id", "manifest"}).AddRow(
+			manifestID, pldtypes.JSONString(&pldapi.BackupManifest{ID: manifestID, Signers: []*pldapi.SignerNonce{{Signer: signer, NextNonce: 5}}}).String(),
+		))
+	}
+
+	mp.Mock.ExpectQuery("SELECT.*backup_manifests").WillReturnError(fmt.Errorf("pop"))
+	assert.Regexp(t, "pop", cm.validateRestore())
+
+	manifestRows()
+	m.publicTxManager.On("GetSignerNonces", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("pop")).Once()
+	assert.Regexp(t, "PD010039.*pop", cm.validateRestore())
+
+	manifestRows()
+	m.publicTxManager.On("GetSignerNonces", mock.Anything, mock.Anything).Return([]*pldapi.SignerNonce{}, nil)
+	m.ethClient.On("GetTransactionCount", mock.Anything, signer).Return(nil, fmt.Errorf("pop"))
+	assert.Regexp(t, "PD010039.*pop", cm.validateRestore())
+}
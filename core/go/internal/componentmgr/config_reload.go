@@ -0,0 +1,223 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package componentmgr
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/internal/msgs"
+	"github.com/kaleido-io/paladin/core/pkg/persistence"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+)
+
+type dbConfigChange struct {
+	ID         uuid.UUID          `gorm:"column:id"`
+	Created    pldtypes.Timestamp `gorm:"column:created"`
+	Section    string             `gorm:"column:section"`
+	Previous   pldtypes.RawJSON   `gorm:"column:previous"`
+	Value      pldtypes.RawJSON   `gorm:"column:value"`
+	ConfigHash pldtypes.Bytes32   `gorm:"column:config_hash"`
+}
+
+func (dbConfigChange) TableName() string {
+	return "config_changes"
+}
+
+// A section of the config that can be changed without a restart. Everything outside of these
+// sections is only read at startup, so changes to it are reported but not applied.
+type reloadableSection struct {
+	name  string
+	get   func(conf *pldconf.PaladinConfig) any
+	clear func(conf *pldconf.PaladinConfig)
+}
+
+var reloadableSections = []*reloadableSection{
+	{
+		name: "log",
+		get: func(conf *pldconf.PaladinConfig) any {
+			return map[string]any{"level": conf.Log.Level, "subsystems": conf.Log.Subsystems}
+		},
+		clear: func(conf *pldconf.PaladinConfig) {
+			conf.Log.Level, conf.Log.Subsystems = nil, nil
+		},
+	},
+	{
+		name: "publicTxManager.gasPrice",
+		get: func(conf *pldconf.PaladinConfig) any {
+			gp := &conf.PublicTxManager.GasPrice
+			return map[string]any{"increasePercentage": gp.IncreasePercentage, "increaseMax": gp.IncreaseMax, "schedules": gp.Schedules}
+		},
+		clear: func(conf *pldconf.PaladinConfig) {
+			gp := &conf.PublicTxManager.GasPrice
+			gp.IncreasePercentage, gp.IncreaseMax, gp.Schedules = nil, nil, nil
+		},
+	},
+	{
+		name: "publicTxManager.balanceManager.autoFueling",
+		get: func(conf *pldconf.PaladinConfig) any {
+			af := &conf.PublicTxManager.BalanceManager.AutoFueling
+			return map[string]any{"minDestBalance": af.MinDestBalance, "maxDestBalance": af.MaxDestBalance, "minThreshold": af.MinThreshold}
+		},
+		clear: func(conf *pldconf.PaladinConfig) {
+			af := &conf.PublicTxManager.BalanceManager.AutoFueling
+			af.MinDestBalance, af.MaxDestBalance, af.MinThreshold = nil, nil, nil
+		},
+	},
+	{
+		// the watcher settings themselves are only read at startup, but changing them does not need a warning
+		name: "configReload",
+		get:  func(conf *pldconf.PaladinConfig) any { return nil },
+		clear: func(conf *pldconf.PaladinConfig) {
+			conf.ConfigReload = pldconf.ConfigReloadConfig{}
+		},
+	},
+}
+
+func withoutReloadableSections(conf *pldconf.PaladinConfig) pldtypes.RawJSON {
+	c := *conf
+	for _, s := range reloadableSections {
+		s.clear(&c)
+	}
+	return pldtypes.JSONString(&c)
+}
+
+// ReloadConfig applies the reloadable sections of an updated config, and records an audit entry for
+// each section that changed. The changes are applied within the DB transaction that writes the
+// audit entries, so a change that fails to apply is not recorded.
+func (cm *componentManager) ReloadConfig(newConf *pldconf.PaladinConfig) ([]*pldapi.ConfigChange, error) {
+	ctx := cm.bgCtx
+	cm.reloadMux.Lock()
+	defer cm.reloadMux.Unlock()
+
+	if withoutReloadableSections(cm.currentConf).String() != withoutReloadableSections(newConf).String() {
+		log.L(ctx).Warnf("The config file contains changes that cannot be applied without a restart")
+	}
+
+	configHash := pldtypes.Bytes32Keccak(pldtypes.JSONString(newConf))
+	var changes []*pldapi.ConfigChange
+	for _, s := range reloadableSections {
+		previous, value := pldtypes.JSONString(s.get(cm.currentConf)), pldtypes.JSONString(s.get(newConf))
+		if previous.String() != value.String() {
+			changes = append(changes, &pldapi.ConfigChange{
+				ID:         uuid.New(),
+				Created:    pldtypes.TimestampNow(),
+				Section:    s.name,
+				Previous:   previous,
+				Value:      value,
+				ConfigHash: configHash,
+			})
+		}
+	}
+	if len(changes) == 0 {
+		return changes, nil
+	}
+
+	// Check the log levels before we change anything, as setting them cannot fail
+	levels := []string{confutil.StringNotEmpty(newConf.Log.Level, *pldconf.LogDefaults.Level)}
+	for _, level := range newConf.Log.Subsystems {
+		levels = append(levels, level)
+	}
+	for _, level := range levels {
+		if !log.IsValidLevel(level) {
+			return nil, i18n.NewError(ctx, msgs.MsgComponentInvalidLogLevel, level)
+		}
+	}
+
+	err := cm.persistence.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
+		dbChanges := make([]*dbConfigChange, len(changes))
+		for i, c := range changes {
+			dbChanges[i] = &dbConfigChange{
+				ID:         c.ID,
+				Created:    c.Created,
+				Section:    c.Section,
+				Previous:   c.Previous,
+				Value:      c.Value,
+				ConfigHash: c.ConfigHash,
+			}
+		}
+		if err := dbTX.DB().WithContext(ctx).Create(dbChanges).Error; err != nil {
+			return err
+		}
+		return cm.applyConfigChanges(ctx, changes, newConf)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, c := range changes {
+		log.L(ctx).Infof("Config change %s applied to section %s: %s -> %s", c.ID, c.Section, c.Previous, c.Value)
+	}
+	cm.currentConf = newConf
+	return changes, nil
+}
+
+func (cm *componentManager) applyConfigChanges(ctx context.Context, changes []*pldapi.ConfigChange, newConf *pldconf.PaladinConfig) error {
+	changed := make(map[string]bool, len(changes))
+	for _, c := range changes {
+		changed[c.Section] = true
+	}
+
+	// The public transaction manager validates both of its sections before it applies either
+	if changed["publicTxManager.gasPrice"] || changed["publicTxManager.balanceManager.autoFueling"] {
+		if err := cm.publicTxManager.ReloadConfig(ctx, &newConf.PublicTxManager); err != nil {
+			return i18n.WrapError(ctx, err, msgs.MsgComponentConfigReloadFailed)
+		}
+	}
+
+	if changed["log"] {
+		log.SetLevel(confutil.StringNotEmpty(newConf.Log.Level, *pldconf.LogDefaults.Level))
+		// Subsystems removed from the config revert to the root level
+		for subsystem := range cm.currentConf.Log.Subsystems {
+			if _, ok := newConf.Log.Subsystems[subsystem]; !ok {
+				log.SetSubsystemLevel(subsystem, "")
+			}
+		}
+		for subsystem, level := range newConf.Log.Subsystems {
+			log.SetSubsystemLevel(subsystem, level)
+		}
+	}
+	return nil
+}
+
+func (cm *componentManager) listConfigChanges(ctx context.Context, limit int) ([]*pldapi.ConfigChange, error) {
+	var dbChanges []*dbConfigChange
+	err := cm.persistence.NOTX().DB().WithContext(ctx).
+		Order(`"created" DESC`).
+		Limit(limit).
+		Find(&dbChanges).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	changes := make([]*pldapi.ConfigChange, len(dbChanges))
+	for i, c := range dbChanges {
+		changes[i] = &pldapi.ConfigChange{
+			ID:         c.ID,
+			Created:    c.Created,
+			Section:    c.Section,
+			Previous:   c.Previous,
+			Value:      c.Value,
+			ConfigHash: c.ConfigHash,
+		}
+	}
+	return changes, nil
+}
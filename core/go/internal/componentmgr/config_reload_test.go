@@ -0,0 +1,130 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package componentmgr
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/pkg/persistence/mockpersistence"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/rpcclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func restoreLogLevels(t *testing.T) {
+	level := log.GetLevel()
+	t.Cleanup(func() {
+		log.SetLevel(level)
+		for _, subsystem := range log.SubsystemNames() {
+			log.SetSubsystemLevel(subsystem, "")
+		}
+	})
+}
+
+func TestReloadConfig(t *testing.T) {
+	restoreLogLevels(t)
+	conf := &pldconf.PaladinConfig{}
+	conf.Log.Subsystems = map[string]string{"blockindexer": "debug"}
+	conf.PublicTxManager.GasPrice.IncreasePercentage = confutil.P(10)
+	_, cm, m := newTestBackupComponentManager(t, newTestBackupRealDB(t), conf)
+
+	// an identical config applies nothing
+	changes, err := cm.ReloadConfig(&pldconf.PaladinConfig{
+		Log: pldconf.LogConfig{Subsystems: map[string]string{"blockindexer": "debug"}},
+		PublicTxManager: pldconf.PublicTxManagerConfig{
+			GasPrice: pldconf.GasPriceConfig{IncreasePercentage: confutil.P(10)},
+		},
+	})
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+
+	newConf := &pldconf.PaladinConfig{
+		Log: pldconf.LogConfig{Level: confutil.P("trace"), Subsystems: map[string]string{"publictxmgr": "error"}},
+		PublicTxManager: pldconf.PublicTxManagerConfig{
+			GasPrice: pldconf.GasPriceConfig{IncreasePercentage: confutil.P(20)},
+		},
+		// changes outside of the reloadable sections are not applied
+		DB: pldconf.DBConfig{Type: "postgres"},
+	}
+	m.publicTxManager.On("ReloadConfig", mock.Anything, &newConf.PublicTxManager).Return(nil).Once()
+	changes, err = cm.ReloadConfig(newConf)
+	require.NoError(t, err)
+	require.Len(t, changes, 2)
+	assert.Equal(t, "log", changes[0].Section)
+	assert.JSONEq(t, `{"level":null,"subsystems":{"blockindexer":"debug"}}`, changes[0].Previous.String())
+	assert.JSONEq(t, `{"level":"trace","subsystems":{"publictxmgr":"error"}}`, changes[0].Value.String())
+	assert.Equal(t, "publicTxManager.gasPrice", changes[1].Section)
+	assert.JSONEq(t, `{"increasePercentage":20,"increaseMax":null,"schedules":null}`, changes[1].Value.String())
+	assert.Equal(t, pldtypes.Bytes32Keccak(pldtypes.JSONString(newConf)), changes[0].ConfigHash)
+
+	levels := log.GetLevels()
+	assert.Equal(t, "trace", levels.Level)
+	assert.Equal(t, "error", levels.Subsystems["publictxmgr"])
+	assert.Equal(t, "trace", levels.Subsystems["blockindexer"]) // reverted to the root level
+
+	cm.initAdminRPC()
+	res := cm.rpcListConfigChanges().Handle(context.Background(), &rpcclient.RPCRequest{
+		JSONRpc: "2.0",
+		ID:      pldtypes.RawJSON(`1`),
+		Params:  []pldtypes.RawJSON{pldtypes.RawJSON(`0`)},
+	})
+	require.Nil(t, res.Error)
+	var stored []*pldapi.ConfigChange
+	require.NoError(t, json.Unmarshal(res.Result, &stored))
+	require.Len(t, stored, 2)
+	assert.ElementsMatch(t, []string{changes[0].ID.String(), changes[1].ID.String()}, []string{stored[0].ID.String(), stored[1].ID.String()})
+	assert.Equal(t, changes[0].ConfigHash, stored[0].ConfigHash)
+
+	// a failure to apply is not recorded, and leaves the current config in place
+	failConf := &pldconf.PaladinConfig{}
+	failConf.PublicTxManager.BalanceManager.AutoFueling.MinDestBalance = confutil.P("100")
+	m.publicTxManager.On("ReloadConfig", mock.Anything, &failConf.PublicTxManager).Return(fmt.Errorf("pop")).Once()
+	_, err = cm.ReloadConfig(failConf)
+	assert.Regexp(t, "PD010040.*pop", err)
+	assert.Same(t, newConf, cm.currentConf)
+
+	stored, err = cm.listConfigChanges(context.Background(), 10)
+	require.NoError(t, err)
+	assert.Len(t, stored, 2)
+}
+
+func TestReloadConfigInvalidLogLevel(t *testing.T) {
+	_, cm, _ := newTestBackupComponentManager(t, nil, &pldconf.PaladinConfig{})
+
+	_, err := cm.ReloadConfig(&pldconf.PaladinConfig{
+		Log: pldconf.LogConfig{Subsystems: map[string]string{"publictxmgr": "wrong"}},
+	})
+	assert.Regexp(t, "PD010036.*wrong", err)
+}
+
+func TestListConfigChangesFail(t *testing.T) {
+	mp, err := mockpersistence.NewSQLMockProvider()
+	require.NoError(t, err)
+	_, cm, _ := newTestBackupComponentManager(t, mp.P, &pldconf.PaladinConfig{})
+
+	mp.Mock.ExpectQuery("SELECT.*config_changes").WillReturnError(fmt.Errorf("pop"))
+	_, err = cm.listConfigChanges(context.Background(), 10)
+	assert.Regexp(t, "pop", err)
+}
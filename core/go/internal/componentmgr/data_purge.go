@@ -0,0 +1,444 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package componentmgr
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/core/internal/msgs"
+	"github.com/kaleido-io/paladin/core/pkg/persistence"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/toolkit/pkg/rpcserver"
+)
+
+type dbPurgeRequest struct {
+	ID           uuid.UUID                             `gorm:"column:id"`
+	Created      pldtypes.Timestamp                    `gorm:"column:created"`
+	RequestedBy  string                                `gorm:"column:requested_by"`
+	Reason       string                                `gorm:"column:reason"`
+	Domain       *string                               `gorm:"column:domain"`
+	States       pldtypes.RawJSON                      `gorm:"column:states"`
+	Transactions pldtypes.RawJSON                      `gorm:"column:transactions"`
+	Status       pldtypes.Enum[pldapi.DataPurgeStatus] `gorm:"column:status"`
+	ApprovedBy   *string                               `gorm:"column:approved_by"`
+	Executed     *pldtypes.Timestamp                   `gorm:"column:executed"`
+}
+
+func (dbPurgeRequest) TableName() string {
+	return "purge_requests"
+}
+
+type dbPurgeLogEntry struct {
+	ID           uuid.UUID                                 `gorm:"column:id"`
+	PurgeRequest uuid.UUID                                 `gorm:"column:purge_request"`
+	Created      pldtypes.Timestamp                        `gorm:"column:created"`
+	TargetType   pldtypes.Enum[pldapi.DataPurgeTargetType] `gorm:"column:target_type"`
+	TargetID     string                                    `gorm:"column:target_id"`
+	Domain       *string                                   `gorm:"column:domain"`
+	DataHash     *pldtypes.Bytes32                         `gorm:"column:data_hash"`
+}
+
+func (dbPurgeLogEntry) TableName() string {
+	return "purge_log"
+}
+
+// the payload of a state or transaction that is removed by a purge
+type dbPurgeTarget struct {
+	ID   string           `gorm:"column:id"`
+	Data pldtypes.RawJSON `gorm:"column:data"`
+}
+
+func (r *dbPurgeRequest) toAPI() *pldapi.DataPurgeRequest {
+	pr := &pldapi.DataPurgeRequest{
+		ID:           r.ID,
+		Created:      r.Created,
+		RequestedBy:  r.RequestedBy,
+		Reason:       r.Reason,
+		States:       []pldtypes.HexBytes{},
+		Transactions: []uuid.UUID{},
+		Status:       r.Status,
+		ApprovedBy:   r.ApprovedBy,
+		Executed:     r.Executed,
+	}
+	if r.Domain != nil {
+		pr.Domain = *r.Domain
+	}
+	// these were serialized by us, so cannot fail to parse
+	_ = json.Unmarshal(r.States, &pr.States)
+	_ = json.Unmarshal(r.Transactions, &pr.Transactions)
+	return pr
+}
+
+func (e *dbPurgeLogEntry) toAPI() *pldapi.DataPurgeLogEntry {
+	le := &pldapi.DataPurgeLogEntry{
+		ID:           e.ID,
+		PurgeRequest: e.PurgeRequest,
+		Created:      e.Created,
+		TargetType:   e.TargetType,
+		TargetID:     e.TargetID,
+		DataHash:     e.DataHash,
+	}
+	if e.Domain != nil {
+		le.Domain = *e.Domain
+	}
+	return le
+}
+
+// requestPurge records a request to purge the private data of a set of states and transactions, which
+// has no effect until it is approved by someone other than the requester.
+func (cm *componentManager) requestPurge(ctx context.Context, input *pldapi.DataPurgeRequestInput) (*pldapi.DataPurgeRequest, error) {
+	requestedBy, err := cm.purgeAdmin(ctx, input.RequestedBy, "requestedBy")
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(input.Reason) == "" {
+		return nil, i18n.NewError(ctx, msgs.MsgComponentPurgeMissingField, "reason")
+	}
+	if len(input.States) == 0 && len(input.Transactions) == 0 {
+		return nil, i18n.NewError(ctx, msgs.MsgComponentPurgeNoTargets)
+	}
+	if len(input.States) > 0 && input.Domain == "" {
+		return nil, i18n.NewError(ctx, msgs.MsgComponentPurgeMissingField, "domain")
+	}
+
+	states, transactions := input.States, input.Transactions
+	if states == nil {
+		states = []pldtypes.HexBytes{}
+	}
+	if transactions == nil {
+		transactions = []uuid.UUID{}
+	}
+	dbReq := &dbPurgeRequest{
+		ID:           uuid.New(),
+		Created:      pldtypes.TimestampNow(),
+		RequestedBy:  requestedBy,
+		Reason:       input.Reason,
+		States:       pldtypes.JSONString(states),
+		Transactions: pldtypes.JSONString(transactions),
+		Status:       pldapi.DataPurgeStatusPending.Enum(),
+	}
+	if input.Domain != "" {
+		dbReq.Domain = &input.Domain
+	}
+	// Checked again on approval, but there is no point recording a request that cannot be approved
+	if err := cm.checkPurgeable(ctx, cm.persistence.NOTX(), input.Domain, states, transactions); err != nil {
+		return nil, err
+	}
+	if err := cm.persistence.NOTX().DB().WithContext(ctx).Create(dbReq).Error; err != nil {
+		return nil, err
+	}
+	log.L(ctx).Infof("Purge request %s for %d states and %d transactions requested by '%s'", dbReq.ID, len(states), len(transactions), dbReq.RequestedBy)
+	return dbReq.toAPI(), nil
+}
+
+// approvePurge executes a pending purge request. The data of each state and transaction is removed,
+// along with the labels indexed from the state data, leaving the IDs and other metadata in place.
+// An entry is written to the append-only purge log for each of them, with the hash of the data removed.
+// Only spent states, and transactions that have a receipt, can be purged - so nothing the node is still
+// processing loses its data.
+func (cm *componentManager) approvePurge(ctx context.Context, id uuid.UUID, approvedBy string) (*pldapi.DataPurgeRequest, error) {
+	approvedBy, err := cm.purgeAdmin(ctx, approvedBy, "approvedBy")
+	if err != nil {
+		return nil, err
+	}
+
+	var pr *pldapi.DataPurgeRequest
+	err = cm.persistence.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
+		var dbReqs []*dbPurgeRequest
+		err := dbTX.DB().WithContext(ctx).
+			Where("id = ?", id).
+			Limit(1).
+			Find(&dbReqs).
+			Error
+		if err != nil {
+			return err
+		}
+		if len(dbReqs) == 0 {
+			return i18n.NewError(ctx, msgs.MsgComponentPurgeRequestNotFound, id)
+		}
+		dbReq := dbReqs[0]
+		if dbReq.Status != pldapi.DataPurgeStatusPending.Enum() {
+			return i18n.NewError(ctx, msgs.MsgComponentPurgeNotPending, id, dbReq.Status)
+		}
+		if strings.EqualFold(approvedBy, strings.TrimSpace(dbReq.RequestedBy)) {
+			return i18n.NewError(ctx, msgs.MsgComponentPurgeSelfApproval, id, dbReq.RequestedBy)
+		}
+		apiReq := dbReq.toAPI()
+		if err := cm.checkPurgeable(ctx, dbTX, apiReq.Domain, apiReq.States, apiReq.Transactions); err != nil {
+			return err
+		}
+
+		executed := pldtypes.TimestampNow()
+		dbReq.Status = pldapi.DataPurgeStatusExecuted.Enum()
+		dbReq.ApprovedBy = &approvedBy
+		dbReq.Executed = &executed
+		pr = dbReq.toAPI()
+
+		// The status check is repeated in the update, so only one of two concurrent approvals can win
+		res := dbTX.DB().WithContext(ctx).
+			Model(&dbPurgeRequest{}).
+			Where("id = ?", id).
+			Where("status = ?", pldapi.DataPurgeStatusPending.Enum()).
+			Updates(map[string]any{
+				"status":      dbReq.Status,
+				"approved_by": approvedBy,
+				"executed":    executed,
+			})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected != 1 {
+			return i18n.NewError(ctx, msgs.MsgComponentPurgeNotPending, id, pldapi.DataPurgeStatusExecuted)
+		}
+
+		var entries []*dbPurgeLogEntry
+		if len(pr.States) > 0 {
+			stateEntries, err := cm.purgeStates(ctx, dbTX, pr, executed)
+			if err != nil {
+				return err
+			}
+			entries = append(entries, stateEntries...)
+		}
+		if len(pr.Transactions) > 0 {
+			txEntries, err := cm.purgeTransactions(ctx, dbTX, pr, executed)
+			if err != nil {
+				return err
+			}
+			entries = append(entries, txEntries...)
+		}
+		return dbTX.DB().WithContext(ctx).Create(entries).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(pr.States) > 0 {
+		cm.stateManager.EvictCachedStates(pr.Domain, pr.States...)
+	}
+	if len(pr.Transactions) > 0 {
+		cm.txManager.EvictCachedTransactions(pr.Transactions...)
+	}
+	log.L(ctx).Infof("Purge request %s requested by '%s' approved by '%s' and executed", pr.ID, pr.RequestedBy, approvedBy)
+	return pr, nil
+}
+
+// purgeAdmin returns the name to record for the admin requesting or approving a purge.
+//
+// When the RPC server has named admins, this is the admin that authenticated the request with their own
+// token, so the requester and approver are distinct authenticated identities. Without named admins there
+// is only the shared admin token, so the name is recorded as supplied, and the requirement for a different
+// approver is an honour-system control between the holders of that token.
+func (cm *componentManager) purgeAdmin(ctx context.Context, supplied, field string) (string, error) {
+	supplied = strings.TrimSpace(supplied)
+	adminAuth := &cm.conf.RPCServer.AdminAuth
+	if !adminAuth.Enabled || len(adminAuth.Admins) == 0 {
+		if supplied == "" {
+			return "", i18n.NewError(ctx, msgs.MsgComponentPurgeMissingField, field)
+		}
+		return supplied, nil
+	}
+	authenticated := rpcserver.AdminIdentity(ctx)
+	if authenticated == "" {
+		return "", i18n.NewError(ctx, msgs.MsgComponentPurgeAdminRequired)
+	}
+	if supplied != "" && supplied != authenticated {
+		return "", i18n.NewError(ctx, msgs.MsgComponentPurgeAdminMismatch, field, supplied, authenticated)
+	}
+	return authenticated, nil
+}
+
+// checkPurgeable returns an error if any of the states are unspent, or any of the transactions are still
+// pending, as the node still needs their data. States that are not stored locally have nothing to purge.
+func (cm *componentManager) checkPurgeable(ctx context.Context, dbTX persistence.DBTX, domain string, states []pldtypes.HexBytes, transactions []uuid.UUID) error {
+	if len(states) > 0 {
+		// For states with a nullifier, it is the nullifier that is spent
+		var unspent []string
+		err := dbTX.DB().WithContext(ctx).
+			Table("states").
+			Where("domain_name = ?", domain).
+			Where("id IN (?)", states).
+			Where(`NOT EXISTS (SELECT 1 FROM state_spend_records sp WHERE sp.domain_name = states.domain_name AND sp."state" = states.id)`).
+			Where(`NOT EXISTS (SELECT 1 FROM state_nullifiers n JOIN state_spend_records sp ON sp.domain_name = n.domain_name AND sp."state" = n.id WHERE n.domain_name = states.domain_name AND n."state" = states.id)`).
+			Pluck("id", &unspent).
+			Error
+		if err != nil {
+			return err
+		}
+		if len(unspent) > 0 {
+			return i18n.NewError(ctx, msgs.MsgComponentPurgeStatesUnspent, unspent)
+		}
+	}
+	if len(transactions) > 0 {
+		var pending []string
+		err := dbTX.DB().WithContext(ctx).
+			Table("transactions").
+			Where("id IN (?)", transactions).
+			Where(`NOT EXISTS (SELECT 1 FROM transaction_receipts r WHERE r."transaction" = transactions.id)`).
+			Pluck("id", &pending).
+			Error
+		if err != nil {
+			return err
+		}
+		if len(pending) > 0 {
+			return i18n.NewError(ctx, msgs.MsgComponentPurgeTransactionsPending, pending)
+		}
+	}
+	return nil
+}
+
+func (cm *componentManager) purgeStates(ctx context.Context, dbTX persistence.DBTX, pr *pldapi.DataPurgeRequest, executed pldtypes.Timestamp) ([]*dbPurgeLogEntry, error) {
+	var found []*dbPurgeTarget
+	err := dbTX.DB().WithContext(ctx).
+		Table("states").
+		Select("id", "data").
+		Where("domain_name = ?", pr.Domain).
+		Where("id IN (?)", pr.States).
+		Find(&found).
+		Error
+	if err == nil {
+		err = dbTX.DB().WithContext(ctx).
+			Table("states").
+			Where("domain_name = ?", pr.Domain).
+			Where("id IN (?)", pr.States).
+			Update("data", nil).
+			Error
+	}
+	// The labels are indexed from the data, so are purged along with it
+	for _, labelTable := range []string{"state_labels", "state_int64_labels"} {
+		if err == nil {
+			err = dbTX.DB().WithContext(ctx).
+				Table(labelTable).
+				Where("domain_name = ?", pr.Domain).
+				Where("state IN (?)", pr.States).
+				Delete(nil).
+				Error
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	dataHashes := make(map[string]*pldtypes.Bytes32, len(found))
+	for _, s := range found {
+		dataHashes[s.ID] = purgedDataHash(s.Data)
+	}
+	entries := make([]*dbPurgeLogEntry, len(pr.States))
+	for i, stateID := range pr.States {
+		entries[i] = &dbPurgeLogEntry{
+			ID:           uuid.New(),
+			PurgeRequest: pr.ID,
+			Created:      executed,
+			TargetType:   pldapi.DataPurgeTargetState.Enum(),
+			TargetID:     stateID.HexString0xPrefix(),
+			Domain:       &pr.Domain,
+			DataHash:     dataHashes[stateID.HexString()],
+		}
+	}
+	return entries, nil
+}
+
+func (cm *componentManager) purgeTransactions(ctx context.Context, dbTX persistence.DBTX, pr *pldapi.DataPurgeRequest, executed pldtypes.Timestamp) ([]*dbPurgeLogEntry, error) {
+	var found []*dbPurgeTarget
+	err := dbTX.DB().WithContext(ctx).
+		Table("transactions").
+		Select("id", "data").
+		Where("id IN (?)", pr.Transactions).
+		Find(&found).
+		Error
+	if err == nil {
+		err = dbTX.DB().WithContext(ctx).
+			Table("transactions").
+			Where("id IN (?)", pr.Transactions).
+			Update("data", nil).
+			Error
+	}
+	if err == nil {
+		// The data of earlier versions of each transaction is kept in its history
+		err = dbTX.DB().WithContext(ctx).
+			Table("transaction_history").
+			Where("tx_id IN (?)", pr.Transactions).
+			Update("data", nil).
+			Error
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	dataHashes := make(map[string]*pldtypes.Bytes32, len(found))
+	for _, tx := range found {
+		dataHashes[tx.ID] = purgedDataHash(tx.Data)
+	}
+	entries := make([]*dbPurgeLogEntry, len(pr.Transactions))
+	for i, txID := range pr.Transactions {
+		entries[i] = &dbPurgeLogEntry{
+			ID:           uuid.New(),
+			PurgeRequest: pr.ID,
+			Created:      executed,
+			TargetType:   pldapi.DataPurgeTargetTransaction.Enum(),
+			TargetID:     txID.String(),
+			DataHash:     dataHashes[txID.String()],
+		}
+	}
+	return entries, nil
+}
+
+func purgedDataHash(data pldtypes.RawJSON) *pldtypes.Bytes32 {
+	if data.IsNil() {
+		return nil
+	}
+	hash := pldtypes.Bytes32Keccak(data)
+	return &hash
+}
+
+func (cm *componentManager) listPurgeRequests(ctx context.Context, limit int) ([]*pldapi.DataPurgeRequest, error) {
+	var dbReqs []*dbPurgeRequest
+	err := cm.persistence.NOTX().DB().WithContext(ctx).
+		Order(`"created" DESC`).
+		Limit(limit).
+		Find(&dbReqs).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	requests := make([]*pldapi.DataPurgeRequest, len(dbReqs))
+	for i, r := range dbReqs {
+		requests[i] = r.toAPI()
+	}
+	return requests, nil
+}
+
+func (cm *componentManager) listPurgeLog(ctx context.Context, limit int) ([]*pldapi.DataPurgeLogEntry, error) {
+	var dbEntries []*dbPurgeLogEntry
+	err := cm.persistence.NOTX().DB().WithContext(ctx).
+		Order(`"created" DESC`).
+		Limit(limit).
+		Find(&dbEntries).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]*pldapi.DataPurgeLogEntry, len(dbEntries))
+	for i, e := range dbEntries {
+		entries[i] = e.toAPI()
+	}
+	return entries, nil
+}
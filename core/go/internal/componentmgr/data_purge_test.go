@@ -0,0 +1,391 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package componentmgr
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/mocks/componentmocks"
+	"github.com/kaleido-io/paladin/core/pkg/persistence"
+	"github.com/kaleido-io/paladin/core/pkg/persistence/mockpersistence"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/rpcclient"
+	"github.com/kaleido-io/paladin/toolkit/pkg/rpcserver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func insertPurgeTestData(t *testing.T, p persistence.Persistence, stateID pldtypes.HexBytes, stateData string, txID uuid.UUID, txData string) {
+	db := p.NOTX().DB()
+	abiHash := pldtypes.RandBytes32()
+	for _, stmt := range []struct {
+		sql  string
+		args []any
+	}{
+		{`INSERT INTO schemas ("id", "created", "domain_name", "type", "signature", "definition", "labels") VALUES (?, 0, 'domain1', 'abi', 'sig', '{}', '[]')`, []any{"schema1"}},
+		{`INSERT INTO states ("id", "created", "domain_name", "schema", "data") VALUES (?, 0, 'domain1', 'schema1', ?)`, []any{stateID, stateData}},
+		{`INSERT INTO state_labels ("domain_name", "state", "label", "value") VALUES ('domain1', ?, 'owner', 'me')`, []any{stateID}},
+		{`INSERT INTO state_int64_labels ("domain_name", "state", "label", "value") VALUES ('domain1', ?, 'amount', 10)`, []any{stateID}},
+		{`INSERT INTO abis ("hash", "abi", "created") VALUES (?, '[]', 0)`, []any{abiHash}},
+		{`INSERT INTO transactions ("id", "created", "type", "submit_mode", "abi_ref", "from", "data") VALUES (?, 0, 'private', 'auto', ?, 'me', ?)`, []any{txID, abiHash, txData}},
+		{`INSERT INTO transaction_history ("id", "tx_id", "created", "type", "abi_ref", "from", "data") VALUES (?, ?, 0, 'private', ?, 'me', ?)`, []any{uuid.New(), txID, abiHash, txData}},
+	} {
+		require.NoError(t, db.Exec(stmt.sql, stmt.args...).Error)
+	}
+}
+
+// only spent states, and transactions with a receipt, can be purged
+func finalizePurgeTestData(t *testing.T, p persistence.Persistence, spentID pldtypes.HexBytes, txID uuid.UUID) {
+	db := p.NOTX().DB()
+	require.NoError(t, db.Exec(`INSERT INTO state_spend_records ("domain_name", "state", "transaction") VALUES ('domain1', ?, ?)`, spentID, txID).Error)
+	require.NoError(t, db.Exec(`INSERT INTO transaction_receipts ("transaction", "domain", "indexed", "success") VALUES (?, 'domain1', 0, true)`, txID).Error)
+}
+
+func TestDataPurge(t *testing.T) {
+	p := newTestBackupRealDB(t)
+	ctx, cm, _ := newTestBackupComponentManager(t, p, &pldconf.PaladinConfig{})
+	mtx := componentmocks.NewTXManager(t)
+	cm.txManager = mtx
+	mss := componentmocks.NewStateManager(t)
+	cm.stateManager = mss
+
+	stateID := pldtypes.HexBytes(pldtypes.RandBytes(32))
+	txID := uuid.New()
+	insertPurgeTestData(t, p, stateID, `{"owner":"me"}`, txID, `{"to":"you"}`)
+	finalizePurgeTestData(t, p, stateID, txID)
+
+	pr, err := cm.requestPurge(ctx, &pldapi.DataPurgeRequestInput{
+		RequestedBy:  "alice",
+		Reason:       "erasure request 42",
+		Domain:       "domain1",
+		States:       []pldtypes.HexBytes{stateID},
+		Transactions: []uuid.UUID{txID},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, pldapi.DataPurgeStatusPending.Enum(), pr.Status)
+
+	// nothing is purged until approved, and the requester cannot approve it
+	_, err = cm.approvePurge(ctx, pr.ID, "ALICE")
+	assert.Regexp(t, "PD010049", err)
+	var stateData *string
+	require.NoError(t, p.NOTX().DB().Raw(`SELECT "data" FROM states WHERE "id" = ?`, stateID).Row().Scan(&stateData))
+	assert.JSONEq(t, `{"owner":"me"}`, *stateData)
+
+	mss.On("EvictCachedStates", "domain1", stateID).Return().Once()
+	mtx.On("EvictCachedTransactions", txID).Return().Once()
+	cm.initAdminRPC()
+	res := cm.rpcApprovePurge().Handle(ctx, &rpcclient.RPCRequest{
+		JSONRpc: "2.0",
+		ID:      pldtypes.RawJSON(`1`),
+		Params:  []pldtypes.RawJSON{pldtypes.JSONString(pr.ID), pldtypes.JSONString("bob")},
+	})
+	require.Nil(t, res.Error)
+	var approved pldapi.DataPurgeRequest
+	require.NoError(t, json.Unmarshal(res.Result, &approved))
+	assert.Equal(t, pldapi.DataPurgeStatusExecuted.Enum(), approved.Status)
+	assert.Equal(t, "bob", *approved.ApprovedBy)
+	assert.NotNil(t, approved.Executed)
+
+	// the data and labels are gone, but the state and transaction remain
+	require.NoError(t, p.NOTX().DB().Raw(`SELECT "data" FROM states WHERE "id" = ?`, stateID).Row().Scan(&stateData))
+	assert.Nil(t, stateData)
+	var count int64
+	require.NoError(t, p.NOTX().DB().Table("state_labels").Where("state = ?", stateID).Count(&count).Error)
+	assert.Zero(t, count)
+	require.NoError(t, p.NOTX().DB().Table("state_int64_labels").Where("state = ?", stateID).Count(&count).Error)
+	assert.Zero(t, count)
+	var txData *string
+	require.NoError(t, p.NOTX().DB().Raw(`SELECT "data" FROM transactions WHERE "id" = ?`, txID).Row().Scan(&txData))
+	assert.Nil(t, txData)
+	require.NoError(t, p.NOTX().DB().Raw(`SELECT "data" FROM transaction_history WHERE "tx_id" = ?`, txID).Row().Scan(&txData))
+	assert.Nil(t, txData)
+	require.NoError(t, p.NOTX().DB().Table("transactions").Where("id = ?", txID).Count(&count).Error)
+	assert.Equal(t, int64(1), count)
+
+	// it cannot be approved twice
+	_, err = cm.approvePurge(ctx, pr.ID, "carol")
+	assert.Regexp(t, "PD010048", err)
+
+	requests, err := cm.listPurgeRequests(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, requests, 1)
+	assert.Equal(t, []pldtypes.HexBytes{stateID}, requests[0].States)
+	assert.Equal(t, []uuid.UUID{txID}, requests[0].Transactions)
+	assert.Equal(t, "domain1", requests[0].Domain)
+
+	entries, err := cm.listPurgeLog(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	byType := map[pldapi.DataPurgeTargetType]*pldapi.DataPurgeLogEntry{}
+	for _, e := range entries {
+		assert.Equal(t, pr.ID, e.PurgeRequest)
+		byType[e.TargetType.V()] = e
+	}
+	assert.Equal(t, stateID.HexString0xPrefix(), byType[pldapi.DataPurgeTargetState].TargetID)
+	assert.Equal(t, "domain1", byType[pldapi.DataPurgeTargetState].Domain)
+	assert.Equal(t, pldtypes.Bytes32Keccak([]byte(`{"owner":"me"}`)), *byType[pldapi.DataPurgeTargetState].DataHash)
+	assert.Equal(t, txID.String(), byType[pldapi.DataPurgeTargetTransaction].TargetID)
+	assert.Equal(t, pldtypes.Bytes32Keccak([]byte(`{"to":"you"}`)), *byType[pldapi.DataPurgeTargetTransaction].DataHash)
+
+	// the log cannot be changed
+	err = p.NOTX().DB().Exec(`UPDATE purge_log SET "data_hash" = NULL`).Error
+	assert.Regexp(t, "append-only", err)
+	err = p.NOTX().DB().Exec(`DELETE FROM purge_log`).Error
+	assert.Regexp(t, "append-only", err)
+}
+
+func TestDataPurgeOnlyTransactionsNotFound(t *testing.T) {
+	p := newTestBackupRealDB(t)
+	ctx, cm, _ := newTestBackupComponentManager(t, p, &pldconf.PaladinConfig{})
+	mtx := componentmocks.NewTXManager(t)
+	cm.txManager = mtx
+
+	// a transaction we do not have is still logged, with no hash
+	txID := uuid.New()
+	pr, err := cm.requestPurge(ctx, &pldapi.DataPurgeRequestInput{
+		RequestedBy:  "alice",
+		Reason:       "erasure request 43",
+		Transactions: []uuid.UUID{txID},
+	})
+	require.NoError(t, err)
+	assert.Empty(t, pr.States)
+
+	mtx.On("EvictCachedTransactions", txID).Return().Once()
+	_, err = cm.approvePurge(ctx, pr.ID, "bob")
+	require.NoError(t, err)
+
+	entries, err := cm.listPurgeLog(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Nil(t, entries[0].DataHash)
+}
+
+func TestDataPurgeOnlyFinalized(t *testing.T) {
+	p := newTestBackupRealDB(t)
+	ctx, cm, _ := newTestBackupComponentManager(t, p, &pldconf.PaladinConfig{})
+
+	stateID := pldtypes.HexBytes(pldtypes.RandBytes(32))
+	txID := uuid.New()
+	insertPurgeTestData(t, p, stateID, `{"owner":"me"}`, txID, `{"to":"you"}`)
+
+	_, err := cm.requestPurge(ctx, &pldapi.DataPurgeRequestInput{
+		RequestedBy: "alice",
+		Reason:      "erasure request 44",
+		Domain:      "domain1",
+		States:      []pldtypes.HexBytes{stateID},
+	})
+	assert.Regexp(t, "PD010058.*"+stateID.HexString(), err)
+
+	_, err = cm.requestPurge(ctx, &pldapi.DataPurgeRequestInput{
+		RequestedBy:  "alice",
+		Reason:       "erasure request 44",
+		Transactions: []uuid.UUID{txID},
+	})
+	assert.Regexp(t, "PD010059.*"+txID.String(), err)
+
+	// for a state with a nullifier, it is the nullifier that is spent
+	nullifierID := pldtypes.HexBytes(pldtypes.RandBytes(32))
+	require.NoError(t, p.NOTX().DB().Exec(`INSERT INTO state_nullifiers ("domain_name", "id", "state") VALUES ('domain1', ?, ?)`, nullifierID, stateID).Error)
+	finalizePurgeTestData(t, p, nullifierID, txID)
+	pr, err := cm.requestPurge(ctx, &pldapi.DataPurgeRequestInput{
+		RequestedBy:  "alice",
+		Reason:       "erasure request 44",
+		Domain:       "domain1",
+		States:       []pldtypes.HexBytes{stateID},
+		Transactions: []uuid.UUID{txID},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, pldapi.DataPurgeStatusPending.Enum(), pr.Status)
+}
+
+func TestDataPurgeNamedAdmins(t *testing.T) {
+	p := newTestBackupRealDB(t)
+	conf := &pldconf.PaladinConfig{}
+	conf.RPCServer.AdminAuth = pldconf.RPCServerAdminAuthConfig{
+		Enabled: true,
+		Admins: []*pldconf.RPCServerAdminConfig{
+			{Name: "alice", TokenFile: "alice.token"},
+			{Name: "bob", TokenFile: "bob.token"},
+		},
+	}
+	ctx, cm, _ := newTestBackupComponentManager(t, p, conf)
+	mtx := componentmocks.NewTXManager(t)
+	cm.txManager = mtx
+	aliceCtx := rpcserver.WithAdminIdentity(ctx, "alice")
+	bobCtx := rpcserver.WithAdminIdentity(ctx, "bob")
+
+	txID := uuid.New()
+	input := &pldapi.DataPurgeRequestInput{
+		Reason:       "erasure request 45",
+		Transactions: []uuid.UUID{txID},
+	}
+
+	// the shared admin token does not identify who is making the request
+	_, err := cm.requestPurge(ctx, input)
+	assert.Regexp(t, "PD010056", err)
+
+	// names are taken from the authenticated admin, and cannot be supplied as anyone else
+	input.RequestedBy = "bob"
+	_, err = cm.requestPurge(aliceCtx, input)
+	assert.Regexp(t, "PD010057.*requestedBy.*bob.*alice", err)
+	input.RequestedBy = ""
+	pr, err := cm.requestPurge(aliceCtx, input)
+	require.NoError(t, err)
+	assert.Equal(t, "alice", pr.RequestedBy)
+
+	_, err = cm.approvePurge(aliceCtx, pr.ID, "")
+	assert.Regexp(t, "PD010049", err)
+	_, err = cm.approvePurge(bobCtx, pr.ID, "carol")
+	assert.Regexp(t, "PD010057.*approvedBy.*carol.*bob", err)
+
+	mtx.On("EvictCachedTransactions", txID).Return().Once()
+	approved, err := cm.approvePurge(bobCtx, pr.ID, "")
+	require.NoError(t, err)
+	assert.Equal(t, "bob", *approved.ApprovedBy)
+}
+
+func TestDataPurgeRequestValidation(t *testing.T) {
+	_, cm, _ := newTestBackupComponentManager(t, nil, &pldconf.PaladinConfig{})
+	ctx := context.Background()
+
+	_, err := cm.requestPurge(ctx, &pldapi.DataPurgeRequestInput{Reason: "r"})
+	assert.Regexp(t, "PD010045.*requestedBy", err)
+	_, err = cm.requestPurge(ctx, &pldapi.DataPurgeRequestInput{RequestedBy: "alice"})
+	assert.Regexp(t, "PD010045.*reason", err)
+	_, err = cm.requestPurge(ctx, &pldapi.DataPurgeRequestInput{RequestedBy: "alice", Reason: "r"})
+	assert.Regexp(t, "PD010046", err)
+	_, err = cm.requestPurge(ctx, &pldapi.DataPurgeRequestInput{RequestedBy: "alice", Reason: "r", States: []pldtypes.HexBytes{pldtypes.RandBytes(32)}})
+	assert.Regexp(t, "PD010045.*domain", err)
+	_, err = cm.approvePurge(ctx, uuid.New(), " ")
+	assert.Regexp(t, "PD010045.*approvedBy", err)
+}
+
+func TestDataPurgeDBErrors(t *testing.T) {
+	mp, err := mockpersistence.NewSQLMockProvider()
+	require.NoError(t, err)
+	ctx, cm, _ := newTestBackupComponentManager(t, mp.P, &pldconf.PaladinConfig{})
+
+	mp.Mock.ExpectQuery("SELECT.*transactions").WillReturnRows(mp.Mock.NewRows([]string{"id"}))
+	mp.Mock.ExpectExec("INSERT.*purge_requests").WillReturnError(fmt.Errorf("pop"))
+	_, err = cm.requestPurge(ctx, &pldapi.DataPurgeRequestInput{RequestedBy: "alice", Reason: "r", Transactions: []uuid.UUID{uuid.New()}})
+	assert.Regexp(t, "pop", err)
+
+	mp.Mock.ExpectQuery("SELECT.*transactions").WillReturnError(fmt.Errorf("pop"))
+	_, err = cm.requestPurge(ctx, &pldapi.DataPurgeRequestInput{RequestedBy: "alice", Reason: "r", Transactions: []uuid.UUID{uuid.New()}})
+	assert.Regexp(t, "pop", err)
+
+	mp.Mock.ExpectQuery("SELECT.*states").WillReturnError(fmt.Errorf("pop"))
+	_, err = cm.requestPurge(ctx, &pldapi.DataPurgeRequestInput{RequestedBy: "alice", Reason: "r", Domain: "domain1", States: []pldtypes.HexBytes{pldtypes.RandBytes(32)}})
+	assert.Regexp(t, "pop", err)
+
+	mp.Mock.ExpectBegin()
+	mp.Mock.ExpectQuery("SELECT.*purge_requests").WillReturnRows(mp.Mock.NewRows([]string{}))
+	mp.Mock.ExpectRollback()
+	_, err = cm.approvePurge(ctx, uuid.New(), "bob")
+	assert.Regexp(t, "PD010047", err)
+
+	mp.Mock.ExpectBegin()
+	mp.Mock.ExpectQuery("SELECT.*purge_requests").WillReturnError(fmt.Errorf("pop"))
+	mp.Mock.ExpectRollback()
+	_, err = cm.approvePurge(ctx, uuid.New(), "bob")
+	assert.Regexp(t, "pop", err)
+
+	pendingRows := func() *sqlmock.Rows {
+		return mp.Mock.NewRows([]string{"id", "requested_by", "domain", "states", "transactions", "status"}).
+			AddRow(uuid.New(), "alice", "domain1", `["0x1234"]`, `["`+uuid.NewString()+`"]`, "pending")
+	}
+
+	expectPurgeable := func() {
+		mp.Mock.ExpectQuery("SELECT.*states").WillReturnRows(mp.Mock.NewRows([]string{"id"}))
+		mp.Mock.ExpectQuery("SELECT.*transactions").WillReturnRows(mp.Mock.NewRows([]string{"id"}))
+	}
+
+	mp.Mock.ExpectBegin()
+	mp.Mock.ExpectQuery("SELECT.*purge_requests").WillReturnRows(pendingRows())
+	mp.Mock.ExpectQuery("SELECT.*states").WillReturnError(fmt.Errorf("pop"))
+	mp.Mock.ExpectRollback()
+	_, err = cm.approvePurge(ctx, uuid.New(), "bob")
+	assert.Regexp(t, "pop", err)
+
+	mp.Mock.ExpectBegin()
+	mp.Mock.ExpectQuery("SELECT.*purge_requests").WillReturnRows(pendingRows())
+	expectPurgeable()
+	mp.Mock.ExpectExec("UPDATE.*purge_requests").WillReturnError(fmt.Errorf("pop"))
+	mp.Mock.ExpectRollback()
+	_, err = cm.approvePurge(ctx, uuid.New(), "bob")
+	assert.Regexp(t, "pop", err)
+
+	// approved by someone else in the meantime
+	mp.Mock.ExpectBegin()
+	mp.Mock.ExpectQuery("SELECT.*purge_requests").WillReturnRows(pendingRows())
+	expectPurgeable()
+	mp.Mock.ExpectExec("UPDATE.*purge_requests").WillReturnResult(sqlmock.NewResult(0, 0))
+	mp.Mock.ExpectRollback()
+	_, err = cm.approvePurge(ctx, uuid.New(), "bob")
+	assert.Regexp(t, "PD010048", err)
+
+	mp.Mock.ExpectBegin()
+	mp.Mock.ExpectQuery("SELECT.*purge_requests").WillReturnRows(pendingRows())
+	expectPurgeable()
+	mp.Mock.ExpectExec("UPDATE.*purge_requests").WillReturnResult(sqlmock.NewResult(0, 1))
+	mp.Mock.ExpectQuery("SELECT.*states").WillReturnError(fmt.Errorf("pop"))
+	mp.Mock.ExpectRollback()
+	_, err = cm.approvePurge(ctx, uuid.New(), "bob")
+	assert.Regexp(t, "pop", err)
+
+	mp.Mock.ExpectBegin()
+	mp.Mock.ExpectQuery("SELECT.*purge_requests").WillReturnRows(pendingRows())
+	expectPurgeable()
+	mp.Mock.ExpectExec("UPDATE.*purge_requests").WillReturnResult(sqlmock.NewResult(0, 1))
+	mp.Mock.ExpectQuery("SELECT.*states").WillReturnRows(mp.Mock.NewRows([]string{}))
+	mp.Mock.ExpectExec("UPDATE.*states").WillReturnResult(sqlmock.NewResult(0, 0))
+	mp.Mock.ExpectExec("DELETE.*state_labels").WillReturnResult(sqlmock.NewResult(0, 0))
+	mp.Mock.ExpectExec("DELETE.*state_int64_labels").WillReturnResult(sqlmock.NewResult(0, 0))
+	mp.Mock.ExpectQuery("SELECT.*transactions").WillReturnError(fmt.Errorf("pop"))
+	mp.Mock.ExpectRollback()
+	_, err = cm.approvePurge(ctx, uuid.New(), "bob")
+	assert.Regexp(t, "pop", err)
+
+	mp.Mock.ExpectBegin()
+	mp.Mock.ExpectQuery("SELECT.*purge_requests").WillReturnRows(pendingRows())
+	expectPurgeable()
+	mp.Mock.ExpectExec("UPDATE.*purge_requests").WillReturnResult(sqlmock.NewResult(0, 1))
+	mp.Mock.ExpectQuery("SELECT.*states").WillReturnRows(mp.Mock.NewRows([]string{}))
+	mp.Mock.ExpectExec("UPDATE.*states").WillReturnResult(sqlmock.NewResult(0, 0))
+	mp.Mock.ExpectExec("DELETE.*state_labels").WillReturnResult(sqlmock.NewResult(0, 0))
+	mp.Mock.ExpectExec("DELETE.*state_int64_labels").WillReturnResult(sqlmock.NewResult(0, 0))
+	mp.Mock.ExpectQuery("SELECT.*transactions").WillReturnRows(mp.Mock.NewRows([]string{}))
+	mp.Mock.ExpectExec("UPDATE.*transactions").WillReturnResult(sqlmock.NewResult(0, 0))
+	mp.Mock.ExpectExec("UPDATE.*transaction_history").WillReturnError(fmt.Errorf("pop"))
+	mp.Mock.ExpectRollback()
+	_, err = cm.approvePurge(ctx, uuid.New(), "bob")
+	assert.Regexp(t, "pop", err)
+
+	mp.Mock.ExpectQuery("SELECT.*purge_requests").WillReturnError(fmt.Errorf("pop"))
+	_, err = cm.listPurgeRequests(ctx, 10)
+	assert.Regexp(t, "pop", err)
+
+	mp.Mock.ExpectQuery("SELECT.*purge_log").WillReturnError(fmt.Errorf("pop"))
+	_, err = cm.listPurgeLog(ctx, 10)
+	assert.Regexp(t, "pop", err)
+}
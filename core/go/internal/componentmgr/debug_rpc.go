@@ -0,0 +1,81 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package componentmgr
+
+import (
+	"context"
+
+	"github.com/kaleido-io/paladin/core/internal/components"
+	"github.com/kaleido-io/paladin/core/pkg/blockindexer"
+	"github.com/kaleido-io/paladin/toolkit/pkg/cache"
+	"github.com/kaleido-io/paladin/toolkit/pkg/inflight"
+	"github.com/kaleido-io/paladin/toolkit/pkg/rpcserver"
+)
+
+// The debug functions return a point-in-time view of the in-memory state of the engines,
+// and are combined with the debug functions registered by the transaction manager
+func (cm *componentManager) initDebugRPC() {
+	cm.debugRPCModule = rpcserver.NewRPCModule("debug").
+		Add("debug_getSequencers", cm.rpcGetSequencers()).
+		Add("debug_getPublicTxOrchestrators", cm.rpcGetPublicTxOrchestrators()).
+		Add("debug_getPublicTxRecovery", cm.rpcGetPublicTxRecovery()).
+		Add("debug_getPublicTxMetrics", cm.rpcGetPublicTxMetrics()).
+		Add("debug_getEventStreams", cm.rpcGetEventStreams()).
+		Add("debug_getCacheStats", cm.rpcGetCacheStats()).
+		Add("debug_getInflightStats", cm.rpcGetInflightStats())
+}
+
+func (cm *componentManager) rpcGetSequencers() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod0(func(ctx context.Context) ([]*components.SequencerSnapshot, error) {
+		return cm.privateTxManager.GetSequencerSnapshots(ctx), nil
+	})
+}
+
+func (cm *componentManager) rpcGetPublicTxOrchestrators() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod0(func(ctx context.Context) ([]*components.PublicTxOrchestratorSnapshot, error) {
+		return cm.publicTxManager.GetOrchestratorSnapshots(ctx), nil
+	})
+}
+
+func (cm *componentManager) rpcGetPublicTxRecovery() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod0(func(ctx context.Context) (*components.PublicTxRecoverySummary, error) {
+		return cm.publicTxManager.GetRecoverySummary(ctx), nil
+	})
+}
+
+func (cm *componentManager) rpcGetPublicTxMetrics() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod0(func(ctx context.Context) (*components.PublicTxEngineMetrics, error) {
+		return cm.publicTxManager.GetEngineMetrics(ctx), nil
+	})
+}
+
+func (cm *componentManager) rpcGetEventStreams() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod0(func(ctx context.Context) ([]*blockindexer.EventStreamSnapshot, error) {
+		return cm.blockIndexer.GetEventStreamSnapshots(ctx), nil
+	})
+}
+
+func (cm *componentManager) rpcGetCacheStats() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod0(func(ctx context.Context) ([]*cache.Stats, error) {
+		return cache.AllStats(), nil
+	})
+}
+
+func (cm *componentManager) rpcGetInflightStats() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod0(func(ctx context.Context) ([]*inflight.InflightStats, error) {
+		return inflight.AllStats(), nil
+	})
+}
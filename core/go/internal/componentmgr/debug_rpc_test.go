@@ -0,0 +1,103 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package componentmgr
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/internal/components"
+	"github.com/kaleido-io/paladin/core/mocks/componentmocks"
+	"github.com/kaleido-io/paladin/core/pkg/blockindexer"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/toolkit/pkg/cache"
+	"github.com/kaleido-io/paladin/toolkit/pkg/inflight"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDebugRPC(t *testing.T) {
+	cm := NewComponentManager(context.Background(), tempSocketFile(t), uuid.New(), &pldconf.PaladinConfig{}).(*componentManager)
+	mpvm := componentmocks.NewPrivateTxManager(t)
+	mpbm := componentmocks.NewPublicTxManager(t)
+	mbi := componentmocks.NewBlockIndexer(t)
+	cm.privateTxManager = mpvm
+	cm.publicTxManager = mpbm
+	cm.blockIndexer = mbi
+
+	cm.initDebugRPC()
+	assert.Equal(t, []string{
+		"debug_getCacheStats", "debug_getEventStreams", "debug_getInflightStats", "debug_getPublicTxMetrics", "debug_getPublicTxOrchestrators", "debug_getPublicTxRecovery", "debug_getSequencers",
+	}, cm.debugRPCModule.MethodNames())
+
+	contractAddr := pldtypes.RandAddress()
+	mpvm.On("GetSequencerSnapshots", mock.Anything).Return([]*components.SequencerSnapshot{
+		{ContractAddress: contractAddr.String(), Domain: "domain1"},
+	})
+	sequencers, rpcErr := callBackupRPC[[]*components.SequencerSnapshot](t, cm.rpcGetSequencers())
+	require.Nil(t, rpcErr)
+	assert.Equal(t, "domain1", (*sequencers)[0].Domain)
+
+	signer := *pldtypes.RandAddress()
+	mpbm.On("GetOrchestratorSnapshots", mock.Anything).Return([]*components.PublicTxOrchestratorSnapshot{
+		{Signer: signer, State: "running", InFlight: []*components.PublicTxInFlightSnapshot{{Nonce: 10, Stage: "submit"}}},
+	})
+	orchestrators, rpcErr := callBackupRPC[[]*components.PublicTxOrchestratorSnapshot](t, cm.rpcGetPublicTxOrchestrators())
+	require.Nil(t, rpcErr)
+	assert.Equal(t, signer, (*orchestrators)[0].Signer)
+	assert.Equal(t, "submit", (*orchestrators)[0].InFlight[0].Stage)
+
+	mpbm.On("GetRecoverySummary", mock.Anything).Return(&components.PublicTxRecoverySummary{
+		Signers: []*components.PublicTxSignerRecovery{{Signer: signer, Pending: 3, InPool: 2}},
+	})
+	recovery, rpcErr := callBackupRPC[*components.PublicTxRecoverySummary](t, cm.rpcGetPublicTxRecovery())
+	require.Nil(t, rpcErr)
+	assert.Equal(t, 2, (*recovery).Signers[0].InPool)
+
+	mpbm.On("GetEngineMetrics", mock.Anything).Return(&components.PublicTxEngineMetrics{
+		StageTimeouts: map[string]int64{"sign": 2},
+	})
+	metrics, rpcErr := callBackupRPC[*components.PublicTxEngineMetrics](t, cm.rpcGetPublicTxMetrics())
+	require.Nil(t, rpcErr)
+	assert.Equal(t, int64(2), (*metrics).StageTimeouts["sign"])
+
+	mbi.On("GetEventStreamSnapshots", mock.Anything).Return([]*blockindexer.EventStreamSnapshot{
+		{ID: uuid.New(), Name: "stream1", CheckpointBlock: 12345},
+	})
+	streams, rpcErr := callBackupRPC[[]*blockindexer.EventStreamSnapshot](t, cm.rpcGetEventStreams())
+	require.Nil(t, rpcErr)
+	assert.Equal(t, int64(12345), (*streams)[0].CheckpointBlock)
+
+	c := cache.NewNamedCache[string, string]("componentmgr.test", &pldconf.CacheConfig{}, &pldconf.CacheConfig{Capacity: confutil.P(10)})
+	c.Set("key1", "val1")
+	_, _ = c.Get("key1")
+	stats, rpcErr := callBackupRPC[[]*cache.Stats](t, cm.rpcGetCacheStats())
+	require.Nil(t, rpcErr)
+	assert.Contains(t, *stats, &cache.Stats{Name: "componentmgr.test", Capacity: 10, Size: 1, Hits: 1})
+
+	ifm := inflight.NewNamedBoundedInflightManager[uuid.UUID, string]("componentmgr.test", &pldconf.InflightConfig{
+		MaxInflight: confutil.P(10),
+	}, uuid.Parse)
+	req := ifm.AddInflight(context.Background(), uuid.New())
+	defer req.Cancel()
+	inflightStats, rpcErr := callBackupRPC[[]*inflight.InflightStats](t, cm.rpcGetInflightStats())
+	require.Nil(t, rpcErr)
+	assert.Contains(t, *inflightStats, &inflight.InflightStats{Name: "componentmgr.test", Current: 1, Peak: 1, Capacity: 10})
+}
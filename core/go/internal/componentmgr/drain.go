@@ -0,0 +1,132 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package componentmgr
+
+import (
+	"context"
+	"time"
+
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+)
+
+// Parked private transactions are waiting on the domain, and delegated ones are coordinated by another
+// node, so neither holds up a drain. Both are picked up again from the database after a restart.
+func privateTxSettled(status string) bool {
+	return status == "parked" || status == "delegated"
+}
+
+// drain stops new transactions being accepted, then waits for the work in flight to finish. Once no
+// private or public transactions are in flight the public TX manager is quiesced, so nothing new is
+// submitted to the chain, and then we wait for the event streams to deliver and checkpoint everything
+// they have been passed. If the deadline passes first the node stays draining, and the status returned
+// shows what is still outstanding - the caller can call drain again to keep waiting, or cancel it.
+func (cm *componentManager) drain(ctx context.Context) *pldapi.DrainStatus {
+	timeout := confutil.DurationMin(cm.conf.Drain.Timeout, 0, *pldconf.DrainConfigDefaults.Timeout)
+	pollInterval := confutil.DurationMin(cm.conf.Drain.PollInterval, time.Millisecond, *pldconf.DrainConfigDefaults.PollInterval)
+	drainCtx, cancelCtx := context.WithTimeout(ctx, timeout)
+	defer cancelCtx()
+
+	cm.drainMux.Lock()
+	if cm.drainStarted == nil {
+		log.L(ctx).Infof("Draining node")
+		cm.drainStarted = confutil.P(pldtypes.TimestampNow())
+		cm.txManager.SetDraining(ctx, true)
+	}
+	cm.drainMux.Unlock()
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		status := cm.getDrainStatus(ctx)
+		if status.SafeToStop || !status.Draining /* cancelled */ {
+			return status
+		}
+		if status.PrivateTransactions == 0 && status.PublicTransactions == 0 && !cm.publicTxManager.IsQuiesced() {
+			cm.quiesceForDrain(drainCtx)
+			continue
+		}
+		select {
+		case <-ticker.C:
+		case <-drainCtx.Done():
+			log.L(ctx).Warnf("Timed out draining node: privateTransactions=%d publicTransactions=%d pendingEvents=%d",
+				status.PrivateTransactions, status.PublicTransactions, status.PendingEvents)
+			return cm.getDrainStatus(ctx)
+		}
+	}
+}
+
+func (cm *componentManager) quiesceForDrain(ctx context.Context) {
+	cm.drainMux.Lock()
+	cm.drainQuiesced = true
+	cm.drainMux.Unlock()
+	// The engine stays quiesced even if this times out, and the timeout will end the drain
+	if err := cm.publicTxManager.Quiesce(ctx); err != nil {
+		log.L(ctx).Warnf("Drain failed to quiesce public transaction manager: %s", err)
+	}
+}
+
+// cancelDrain returns the node to normal operation. The public TX manager is only resumed if the
+// drain quiesced it, so an admin quiesce for a backup is not undone.
+func (cm *componentManager) cancelDrain(ctx context.Context) *pldapi.DrainStatus {
+	cm.drainMux.Lock()
+	if cm.drainStarted != nil {
+		log.L(ctx).Infof("Cancelling drain")
+		cm.txManager.SetDraining(ctx, false)
+		if cm.drainQuiesced {
+			cm.publicTxManager.Unquiesce(ctx)
+		}
+		cm.drainStarted = nil
+		cm.drainQuiesced = false
+	}
+	cm.drainMux.Unlock()
+	return cm.getDrainStatus(ctx)
+}
+
+func (cm *componentManager) getDrainStatus(ctx context.Context) *pldapi.DrainStatus {
+	cm.drainMux.Lock()
+	status := &pldapi.DrainStatus{
+		Draining: cm.drainStarted != nil,
+		Started:  cm.drainStarted,
+	}
+	cm.drainMux.Unlock()
+
+	for _, s := range cm.privateTxManager.GetSequencerSnapshots(ctx) {
+		for _, tx := range s.InFlight {
+			if !privateTxSettled(tx.Status) {
+				status.PrivateTransactions++
+			}
+		}
+	}
+	for _, o := range cm.publicTxManager.GetOrchestratorSnapshots(ctx) {
+		status.PublicTransactions += len(o.InFlight)
+	}
+	for _, es := range cm.blockIndexer.GetEventStreamSnapshots(ctx) {
+		status.PendingEvents += es.Pending
+	}
+	if confirmed, err := cm.blockIndexer.GetConfirmedBlockHeight(ctx); err == nil {
+		status.ConfirmedBlock = &confirmed
+	}
+	status.SafeToStop = status.Draining &&
+		status.PrivateTransactions == 0 &&
+		status.PublicTransactions == 0 &&
+		status.PendingEvents == 0 &&
+		cm.publicTxManager.IsQuiesced()
+	return status
+}
@@ -0,0 +1,175 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package componentmgr
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/internal/components"
+	"github.com/kaleido-io/paladin/core/mocks/componentmocks"
+	"github.com/kaleido-io/paladin/core/pkg/blockindexer"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type drainTestMocks struct {
+	*backupTestMocks
+	txManager        *componentmocks.TXManager
+	privateTxManager *componentmocks.PrivateTxManager
+	quiesced         atomic.Bool
+}
+
+func newTestDrainComponentManager(t *testing.T, timeout string) (context.Context, *componentManager, *drainTestMocks) {
+	conf := &pldconf.PaladinConfig{
+		Drain: pldconf.DrainConfig{
+			Timeout:      confutil.P(timeout),
+			PollInterval: confutil.P("1ms"),
+		},
+	}
+	ctx, cm, bm := newTestBackupComponentManager(t, nil, conf)
+	m := &drainTestMocks{
+		backupTestMocks:  bm,
+		txManager:        componentmocks.NewTXManager(t),
+		privateTxManager: componentmocks.NewPrivateTxManager(t),
+	}
+	cm.txManager = m.txManager
+	cm.privateTxManager = m.privateTxManager
+	m.publicTxManager.On("IsQuiesced").Return(func() bool { return m.quiesced.Load() }).Maybe()
+	m.blockIndexer.On("GetConfirmedBlockHeight", mock.Anything).Return(pldtypes.HexUint64(12345), nil).Maybe()
+	return ctx, cm, m
+}
+
+func sequencerSnapshot(statuses ...string) []*components.SequencerSnapshot {
+	s := &components.SequencerSnapshot{}
+	for _, status := range statuses {
+		s.InFlight = append(s.InFlight, components.PrivateTxStatus{Status: status})
+	}
+	return []*components.SequencerSnapshot{s}
+}
+
+func TestDrainSafeToStopAndCancel(t *testing.T) {
+	ctx, cm, m := newTestDrainComponentManager(t, "10s")
+
+	// Each source of work drains over a few polls
+	var privatePolls, publicPolls, eventPolls atomic.Int32
+	m.privateTxManager.On("GetSequencerSnapshots", mock.Anything).Return(func(context.Context) []*components.SequencerSnapshot {
+		if privatePolls.Add(1) < 3 {
+			return sequencerSnapshot("assembled", "parked", "delegated")
+		}
+		return sequencerSnapshot("parked")
+	})
+	m.publicTxManager.On("GetOrchestratorSnapshots", mock.Anything).Return(func(context.Context) []*components.PublicTxOrchestratorSnapshot {
+		if publicPolls.Add(1) < 5 {
+			return []*components.PublicTxOrchestratorSnapshot{{InFlight: []*components.PublicTxInFlightSnapshot{{}, {}}}}
+		}
+		return []*components.PublicTxOrchestratorSnapshot{}
+	})
+	m.blockIndexer.On("GetEventStreamSnapshots", mock.Anything).Return(func(context.Context) []*blockindexer.EventStreamSnapshot {
+		if eventPolls.Add(1) < 8 {
+			return []*blockindexer.EventStreamSnapshot{{Pending: 3}, {Pending: 0}}
+		}
+		return []*blockindexer.EventStreamSnapshot{{Pending: 0}}
+	})
+	m.txManager.On("SetDraining", mock.Anything, true).Return().Once()
+	m.publicTxManager.On("Quiesce", mock.Anything).Return(nil).Run(func(args mock.Arguments) { m.quiesced.Store(true) }).Once()
+
+	status := cm.getDrainStatus(ctx)
+	assert.False(t, status.Draining)
+	assert.False(t, status.SafeToStop)
+	assert.Equal(t, 1, status.PrivateTransactions)
+	assert.Equal(t, 2, status.PublicTransactions)
+	assert.Equal(t, int64(3), status.PendingEvents)
+
+	cm.initAdminRPC()
+	status, rpcErr := callBackupRPC[pldapi.DrainStatus](t, cm.rpcDrain())
+	require.Nil(t, rpcErr)
+	assert.True(t, status.Draining)
+	assert.True(t, status.SafeToStop)
+	assert.NotNil(t, status.Started)
+	assert.Zero(t, status.PrivateTransactions)
+	assert.Zero(t, status.PublicTransactions)
+	assert.Zero(t, status.PendingEvents)
+	assert.Equal(t, pldtypes.HexUint64(12345), *status.ConfirmedBlock)
+
+	// Draining again is a no-op
+	status2, rpcErr := callBackupRPC[pldapi.DrainStatus](t, cm.rpcDrain())
+	require.Nil(t, rpcErr)
+	assert.True(t, status2.SafeToStop)
+	assert.Equal(t, status.Started, status2.Started)
+
+	status, rpcErr = callBackupRPC[pldapi.DrainStatus](t, cm.rpcGetDrainStatus())
+	require.Nil(t, rpcErr)
+	assert.True(t, status.SafeToStop)
+
+	// Cancelling resumes the public TX manager, as the drain quiesced it
+	m.txManager.On("SetDraining", mock.Anything, false).Return().Once()
+	m.publicTxManager.On("Unquiesce", mock.Anything).Return().Run(func(args mock.Arguments) { m.quiesced.Store(false) }).Once()
+	status, rpcErr = callBackupRPC[pldapi.DrainStatus](t, cm.rpcCancelDrain())
+	require.Nil(t, rpcErr)
+	assert.False(t, status.Draining)
+	assert.False(t, status.SafeToStop)
+	assert.Nil(t, status.Started)
+
+	// Cancelling again is a no-op
+	status = cm.cancelDrain(ctx)
+	assert.False(t, status.Draining)
+}
+
+func TestDrainTimeoutInFlight(t *testing.T) {
+	ctx, cm, m := newTestDrainComponentManager(t, "10ms")
+
+	m.privateTxManager.On("GetSequencerSnapshots", mock.Anything).Return(sequencerSnapshot("endorsed"))
+	m.publicTxManager.On("GetOrchestratorSnapshots", mock.Anything).Return([]*components.PublicTxOrchestratorSnapshot{})
+	m.blockIndexer.On("GetEventStreamSnapshots", mock.Anything).Return([]*blockindexer.EventStreamSnapshot{})
+	m.txManager.On("SetDraining", mock.Anything, true).Return().Once()
+
+	status := cm.drain(ctx)
+	assert.True(t, status.Draining)
+	assert.False(t, status.SafeToStop)
+	assert.Equal(t, 1, status.PrivateTransactions)
+
+	// The public TX manager was not quiesced by the drain, so is not resumed on cancel
+	m.txManager.On("SetDraining", mock.Anything, false).Return().Once()
+	status = cm.cancelDrain(ctx)
+	assert.False(t, status.Draining)
+}
+
+func TestDrainQuiesceTimeout(t *testing.T) {
+	ctx, cm, m := newTestDrainComponentManager(t, "10ms")
+
+	m.privateTxManager.On("GetSequencerSnapshots", mock.Anything).Return([]*components.SequencerSnapshot{})
+	m.publicTxManager.On("GetOrchestratorSnapshots", mock.Anything).Return([]*components.PublicTxOrchestratorSnapshot{})
+	m.blockIndexer.On("GetEventStreamSnapshots", mock.Anything).Return([]*blockindexer.EventStreamSnapshot{})
+	m.txManager.On("SetDraining", mock.Anything, true).Return()
+	m.publicTxManager.On("Quiesce", mock.Anything).Return(fmt.Errorf("pop")).Run(func(args mock.Arguments) {
+		// The engine stays quiesced, but some orchestrators did not stop in time
+		m.quiesced.Store(true)
+		<-args[0].(context.Context).Done()
+	}).Once()
+
+	// Nothing is in flight, so the orchestrators left will stop without submitting anything
+	status := cm.drain(ctx)
+	assert.True(t, status.Draining)
+	assert.True(t, status.SafeToStop)
+}
@@ -0,0 +1,241 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package componentmgr
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/internal/msgs"
+)
+
+const (
+	HealthStatusUp   = "UP"
+	HealthStatusDown = "DOWN"
+)
+
+type HealthCheckResult struct {
+	Name     string `json:"name"`
+	Status   string `json:"status"`
+	Duration string `json:"duration"`
+	Error    string `json:"error,omitempty"`
+	Details  any    `json:"details,omitempty"`
+}
+
+type HealthResponse struct {
+	Status string               `json:"status"`
+	Checks []*HealthCheckResult `json:"checks,omitempty"`
+}
+
+type healthCheck struct {
+	name  string
+	check func(ctx context.Context) (details any, err error)
+}
+
+type DatabaseHealth struct {
+	OpenConnections int `json:"openConnections"`
+	InUse           int `json:"inUse"`
+	Idle            int `json:"idle"`
+}
+
+type BlockchainHealth struct {
+	ChainID   int64  `json:"chainId"`
+	PeerCount uint64 `json:"peerCount"`
+}
+
+type BlockIndexerHealth struct {
+	ChainHead    uint64 `json:"chainHead"`
+	IndexedBlock uint64 `json:"indexedBlock"`
+	Lag          uint64 `json:"lag"`
+	MaxLag       uint64 `json:"maxLag"`
+}
+
+// The HTTP server for JSON/RPC also serves the probe endpoints used by Kubernetes:
+// - /livez only confirms the process is serving requests, so a slow dependency never causes a restart
+// - /readyz checks each of the dependencies, and returns 503 if any of them are down
+func (cm *componentManager) registerHealthEndpoints() {
+	cm.rpcServer.HandleHTTP("/livez", cm.livezHandler)
+	cm.rpcServer.HandleHTTP("/readyz", cm.readyzHandler)
+}
+
+func (cm *componentManager) livezHandler(res http.ResponseWriter, req *http.Request) {
+	writeHealthResponse(req.Context(), res, &HealthResponse{Status: HealthStatusUp})
+}
+
+func (cm *componentManager) readyzHandler(res http.ResponseWriter, req *http.Request) {
+	writeHealthResponse(req.Context(), res, cm.checkReadiness(req.Context()))
+}
+
+func writeHealthResponse(ctx context.Context, res http.ResponseWriter, hr *HealthResponse) {
+	status := http.StatusOK
+	if hr.Status != HealthStatusUp {
+		status = http.StatusServiceUnavailable
+	}
+	res.Header().Set("Content-Type", "application/json; charset=utf-8")
+	res.WriteHeader(status)
+	if err := json.NewEncoder(res).Encode(hr); err != nil {
+		log.L(ctx).Warnf("Failed to write health response: %s", err)
+	}
+}
+
+func (cm *componentManager) healthChecks() []*healthCheck {
+	return []*healthCheck{
+		{name: "database", check: cm.checkDatabase},
+		{name: "blockchain", check: cm.checkBlockchain},
+		{name: "block_indexer", check: cm.checkBlockIndexer},
+		{name: "plugins", check: cm.checkPlugins},
+		{name: "key_stores", check: cm.checkKeyStores},
+	}
+}
+
+// All checks run in parallel, and any check that has not completed within the timeout is reported down
+func (cm *componentManager) checkReadiness(ctx context.Context) *HealthResponse {
+	timeout := confutil.DurationMin(cm.conf.Health.Timeout, 0, *pldconf.HealthConfigDefaults.Timeout)
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	checks := cm.healthChecks()
+	resultChls := make([]chan *HealthCheckResult, len(checks))
+	for i, hc := range checks {
+		resultChls[i] = make(chan *HealthCheckResult, 1)
+		go func(hc *healthCheck, resultChl chan<- *HealthCheckResult) {
+			resultChl <- hc.run(ctx)
+		}(hc, resultChls[i])
+	}
+
+	hr := &HealthResponse{Status: HealthStatusUp, Checks: make([]*HealthCheckResult, len(checks))}
+	for i, hc := range checks {
+		select {
+		case hr.Checks[i] = <-resultChls[i]:
+		case <-ctx.Done():
+			select {
+			case hr.Checks[i] = <-resultChls[i]: // completed just as we timed out
+			default:
+				hr.Checks[i] = &HealthCheckResult{
+					Name:     hc.name,
+					Status:   HealthStatusDown,
+					Duration: timeout.String(),
+					Error:    i18n.NewError(ctx, msgs.MsgComponentHealthCheckTimeout, timeout).Error(),
+				}
+			}
+		}
+		if hr.Checks[i].Status != HealthStatusUp {
+			log.L(ctx).Warnf("Health check %s is %s: %s", hc.name, hr.Checks[i].Status, hr.Checks[i].Error)
+			hr.Status = HealthStatusDown
+		}
+	}
+	return hr
+}
+
+func (hc *healthCheck) run(ctx context.Context) *HealthCheckResult {
+	startTime := time.Now()
+	details, err := hc.check(ctx)
+	result := &HealthCheckResult{
+		Name:     hc.name,
+		Status:   HealthStatusUp,
+		Duration: time.Since(startTime).String(),
+		Details:  details,
+	}
+	if err != nil {
+		result.Status = HealthStatusDown
+		result.Error = err.Error()
+	}
+	return result
+}
+
+func (cm *componentManager) checkDatabase(ctx context.Context) (any, error) {
+	sqlDB, err := cm.persistence.DB().DB()
+	if err == nil {
+		err = sqlDB.PingContext(ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+	stats := sqlDB.Stats()
+	return &DatabaseHealth{
+		OpenConnections: stats.OpenConnections,
+		InUse:           stats.InUse,
+		Idle:            stats.Idle,
+	}, nil
+}
+
+func (cm *componentManager) checkBlockchain(ctx context.Context) (any, error) {
+	peerCount, err := cm.ethClientFactory.HTTPClient().PeerCount(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &BlockchainHealth{
+		ChainID:   cm.ethClientFactory.ChainID(),
+		PeerCount: peerCount,
+	}, nil
+}
+
+func (cm *componentManager) checkBlockIndexer(ctx context.Context) (any, error) {
+	chainHead, err := cm.blockIndexer.GetBlockListenerHeight(ctx)
+	if err != nil {
+		return nil, err
+	}
+	indexed, err := cm.blockIndexer.GetConfirmedBlockHeight(ctx)
+	if err != nil {
+		return nil, err
+	}
+	bih := &BlockIndexerHealth{
+		ChainHead:    chainHead,
+		IndexedBlock: indexed.Uint64(),
+		MaxLag:       uint64(confutil.IntMin(cm.conf.Health.MaxBlockIndexerLag, 0, *pldconf.HealthConfigDefaults.MaxBlockIndexerLag)),
+	}
+	if chainHead > bih.IndexedBlock {
+		bih.Lag = chainHead - bih.IndexedBlock
+	}
+	if bih.Lag > bih.MaxLag {
+		return bih, i18n.NewError(ctx, msgs.MsgComponentHealthBlockIndexerLag, bih.Lag, bih.MaxLag)
+	}
+	return bih, nil
+}
+
+func (cm *componentManager) checkPlugins(ctx context.Context) (any, error) {
+	statuses := cm.pluginManager.GetPluginStatuses()
+	notReady := []string{}
+	for _, ps := range statuses {
+		if !ps.Initialized {
+			notReady = append(notReady, ps.Name)
+		}
+	}
+	if len(notReady) > 0 {
+		return statuses, i18n.NewError(ctx, msgs.MsgComponentHealthPluginsNotReady, notReady)
+	}
+	return statuses, nil
+}
+
+func (cm *componentManager) checkKeyStores(ctx context.Context) (any, error) {
+	statuses := cm.keyManager.CheckWallets(ctx)
+	notReady := []string{}
+	for _, ws := range statuses {
+		if ws.Error != "" {
+			notReady = append(notReady, ws.Name)
+		}
+	}
+	if len(notReady) > 0 {
+		return statuses, i18n.NewError(ctx, msgs.MsgComponentHealthWalletsNotReady, notReady)
+	}
+	return statuses, nil
+}
@@ -0,0 +1,196 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package componentmgr
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/internal/components"
+	"github.com/kaleido-io/paladin/core/mocks/componentmocks"
+	"github.com/kaleido-io/paladin/core/mocks/ethclientmocks"
+	"github.com/kaleido-io/paladin/core/pkg/persistence/mockpersistence"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type healthTestMocks struct {
+	db               *mockpersistence.SQLMockProvider
+	ethClientFactory *ethclientmocks.EthClientFactory
+	ethClient        *ethclientmocks.EthClient
+	blockIndexer     *componentmocks.BlockIndexer
+	pluginManager    *componentmocks.PluginManager
+	keyManager       *componentmocks.KeyManager
+}
+
+func newTestHealthComponentManager(t *testing.T, conf *pldconf.PaladinConfig) (*componentManager, *healthTestMocks) {
+	db, err := mockpersistence.NewSQLMockProvider()
+	require.NoError(t, err)
+	m := &healthTestMocks{
+		db:               db,
+		ethClientFactory: ethclientmocks.NewEthClientFactory(t),
+		ethClient:        ethclientmocks.NewEthClient(t),
+		blockIndexer:     componentmocks.NewBlockIndexer(t),
+		pluginManager:    componentmocks.NewPluginManager(t),
+		keyManager:       componentmocks.NewKeyManager(t),
+	}
+	m.ethClientFactory.On("HTTPClient").Return(m.ethClient).Maybe()
+
+	cm := NewComponentManager(context.Background(), tempSocketFile(t), uuid.New(), conf).(*componentManager)
+	cm.persistence = db.P
+	cm.ethClientFactory = m.ethClientFactory
+	cm.blockIndexer = m.blockIndexer
+	cm.pluginManager = m.pluginManager
+	cm.keyManager = m.keyManager
+	return cm, m
+}
+
+func callHealthHandler(t *testing.T, handler http.HandlerFunc) (int, *HealthResponse) {
+	res := httptest.NewRecorder()
+	handler(res, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, "application/json; charset=utf-8", res.Header().Get("Content-Type"))
+	var hr HealthResponse
+	err := json.Unmarshal(res.Body.Bytes(), &hr)
+	require.NoError(t, err)
+	return res.Code, &hr
+}
+
+func healthChecksByName(hr *HealthResponse) map[string]*HealthCheckResult {
+	checks := make(map[string]*HealthCheckResult)
+	for _, c := range hr.Checks {
+		checks[c.Name] = c
+	}
+	return checks
+}
+
+func TestLivez(t *testing.T) {
+	cm, _ := newTestHealthComponentManager(t, &pldconf.PaladinConfig{})
+
+	status, hr := callHealthHandler(t, cm.livezHandler)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, HealthStatusUp, hr.Status)
+	assert.Empty(t, hr.Checks)
+}
+
+func TestReadyzAllUp(t *testing.T) {
+	cm, m := newTestHealthComponentManager(t, &pldconf.PaladinConfig{})
+
+	m.ethClient.On("PeerCount", mock.Anything).Return(uint64(3), nil)
+	m.ethClientFactory.On("ChainID").Return(int64(1337))
+	m.blockIndexer.On("GetBlockListenerHeight", mock.Anything).Return(uint64(1100), nil)
+	m.blockIndexer.On("GetConfirmedBlockHeight", mock.Anything).Return(pldtypes.HexUint64(1000), nil)
+	m.pluginManager.On("GetPluginStatuses").Return([]*components.PluginStatus{
+		{Name: "domain1", Type: "DOMAIN", Registered: true, Initialized: true},
+	})
+	m.keyManager.On("CheckWallets", mock.Anything).Return([]*components.WalletStatus{
+		{Name: "wallet1"},
+	})
+
+	status, hr := callHealthHandler(t, cm.readyzHandler)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, HealthStatusUp, hr.Status)
+	require.Len(t, hr.Checks, 5)
+	checks := healthChecksByName(hr)
+	for _, name := range []string{"database", "blockchain", "block_indexer", "plugins", "key_stores"} {
+		require.Contains(t, checks, name)
+		assert.Equal(t, HealthStatusUp, checks[name].Status, name)
+		assert.NotEmpty(t, checks[name].Duration, name)
+	}
+	assert.Equal(t, map[string]any{"chainId": float64(1337), "peerCount": float64(3)}, checks["blockchain"].Details)
+	assert.Equal(t, map[string]any{
+		"chainHead": float64(1100), "indexedBlock": float64(1000), "lag": float64(100), "maxLag": float64(100),
+	}, checks["block_indexer"].Details)
+}
+
+func TestReadyzAllDown(t *testing.T) {
+	cm, m := newTestHealthComponentManager(t, &pldconf.PaladinConfig{
+		Health: pldconf.HealthConfig{MaxBlockIndexerLag: confutil.P(10)},
+	})
+
+	m.db.Mock.ExpectClose()
+	err := m.db.DB.Close()
+	require.NoError(t, err)
+	m.ethClient.On("PeerCount", mock.Anything).Return(uint64(0), fmt.Errorf("pop"))
+	m.blockIndexer.On("GetBlockListenerHeight", mock.Anything).Return(uint64(1100), nil)
+	m.blockIndexer.On("GetConfirmedBlockHeight", mock.Anything).Return(pldtypes.HexUint64(1000), nil)
+	m.pluginManager.On("GetPluginStatuses").Return([]*components.PluginStatus{
+		{Name: "domain1", Type: "DOMAIN", Registered: true, Initialized: true},
+		{Name: "transport1", Type: "TRANSPORT"},
+	})
+	m.keyManager.On("CheckWallets", mock.Anything).Return([]*components.WalletStatus{
+		{Name: "wallet1", Listable: true, Error: "pop"},
+	})
+
+	status, hr := callHealthHandler(t, cm.readyzHandler)
+	assert.Equal(t, http.StatusServiceUnavailable, status)
+	assert.Equal(t, HealthStatusDown, hr.Status)
+	checks := healthChecksByName(hr)
+	for _, c := range checks {
+		assert.Equal(t, HealthStatusDown, c.Status, c.Name)
+	}
+	assert.Regexp(t, "closed", checks["database"].Error)
+	assert.Regexp(t, "pop", checks["blockchain"].Error)
+	assert.Regexp(t, "PD010042.*100.*10", checks["block_indexer"].Error)
+	assert.Regexp(t, "PD010043.*transport1", checks["plugins"].Error)
+	assert.Regexp(t, "PD010044.*wallet1", checks["key_stores"].Error)
+	assert.Len(t, checks["plugins"].Details, 2)
+}
+
+func TestReadyzBlockIndexerErrors(t *testing.T) {
+	cm, m := newTestHealthComponentManager(t, &pldconf.PaladinConfig{})
+
+	m.blockIndexer.On("GetBlockListenerHeight", mock.Anything).Return(uint64(0), fmt.Errorf("pop")).Once()
+	_, err := cm.checkBlockIndexer(context.Background())
+	assert.Regexp(t, "pop", err)
+
+	m.blockIndexer.On("GetBlockListenerHeight", mock.Anything).Return(uint64(100), nil)
+	m.blockIndexer.On("GetConfirmedBlockHeight", mock.Anything).Return(pldtypes.HexUint64(0), fmt.Errorf("pop"))
+	_, err = cm.checkBlockIndexer(context.Background())
+	assert.Regexp(t, "pop", err)
+}
+
+func TestReadyzTimeout(t *testing.T) {
+	cm, m := newTestHealthComponentManager(t, &pldconf.PaladinConfig{
+		Health: pldconf.HealthConfig{Timeout: confutil.P("10ms")},
+	})
+
+	blocked := make(chan struct{})
+	defer close(blocked)
+	m.ethClient.On("PeerCount", mock.Anything).Run(func(args mock.Arguments) {
+		<-blocked
+	}).Return(uint64(0), fmt.Errorf("pop")).Maybe()
+	m.blockIndexer.On("GetBlockListenerHeight", mock.Anything).Return(uint64(1000), nil)
+	m.blockIndexer.On("GetConfirmedBlockHeight", mock.Anything).Return(pldtypes.HexUint64(1000), nil)
+	m.pluginManager.On("GetPluginStatuses").Return([]*components.PluginStatus{})
+	m.keyManager.On("CheckWallets", mock.Anything).Return([]*components.WalletStatus{})
+
+	status, hr := callHealthHandler(t, cm.readyzHandler)
+	assert.Equal(t, http.StatusServiceUnavailable, status)
+	checks := healthChecksByName(hr)
+	assert.Equal(t, HealthStatusDown, checks["blockchain"].Status)
+	assert.Regexp(t, "PD010041.*10ms", checks["blockchain"].Error)
+	assert.Equal(t, HealthStatusUp, checks["database"].Status)
+	assert.Equal(t, HealthStatusUp, checks["block_indexer"].Status)
+}
@@ -18,15 +18,18 @@ package componentmgr
 import (
 	"context"
 	"net/http"
+	"sync"
 
 	"github.com/google/uuid"
 	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
 	"github.com/kaleido-io/paladin/config/pkg/confutil"
 	"github.com/kaleido-io/paladin/config/pkg/pldconf"
 	"github.com/kaleido-io/paladin/core/internal/components"
+	"github.com/kaleido-io/paladin/core/internal/contractmgr"
 	"github.com/kaleido-io/paladin/core/internal/domainmgr"
 	"github.com/kaleido-io/paladin/core/internal/groupmgr"
 	"github.com/kaleido-io/paladin/core/internal/identityresolver"
+	"github.com/kaleido-io/paladin/core/internal/jobscheduler"
 	"github.com/kaleido-io/paladin/core/internal/keymanager"
 	"github.com/kaleido-io/paladin/core/internal/msgs"
 	"github.com/kaleido-io/paladin/core/internal/plugins"
@@ -36,11 +39,14 @@ import (
 	"github.com/kaleido-io/paladin/core/internal/statemgr"
 	"github.com/kaleido-io/paladin/core/internal/transportmgr"
 	"github.com/kaleido-io/paladin/core/internal/txmgr"
+	"github.com/kaleido-io/paladin/core/internal/webhooks"
 	"github.com/kaleido-io/paladin/core/pkg/blockindexer"
 
 	"github.com/kaleido-io/paladin/common/go/pkg/log"
 	"github.com/kaleido-io/paladin/core/pkg/ethclient"
 	"github.com/kaleido-io/paladin/core/pkg/persistence"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/retry"
 	"github.com/kaleido-io/paladin/toolkit/pkg/httpserver"
 	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
@@ -53,6 +59,7 @@ type ComponentManager interface {
 	StartManagers() error
 	CompleteStart() error
 	Stop()
+	ReloadConfig(conf *pldconf.PaladinConfig) ([]*pldapi.ConfigChange, error)
 }
 
 type componentManager struct {
@@ -61,6 +68,13 @@ type componentManager struct {
 	bgCtx        context.Context
 	// config
 	conf *pldconf.PaladinConfig
+	// the last config applied by a reload, which starts as the config we were started with
+	currentConf *pldconf.PaladinConfig
+	reloadMux   sync.Mutex
+	// drain state, where drainQuiesced records if the drain (rather than an admin) quiesced the public TX manager
+	drainMux      sync.Mutex
+	drainStarted  *pldtypes.Timestamp
+	drainQuiesced bool
 	// debug server
 	debugServer httpserver.Server
 	// pre-init
@@ -69,6 +83,8 @@ type componentManager struct {
 	persistence      persistence.Persistence
 	blockIndexer     blockindexer.BlockIndexer
 	rpcServer        rpcserver.RPCServer
+	adminRPCModule   *rpcserver.RPCModule
+	debugRPCModule   *rpcserver.RPCModule
 
 	// managers
 	stateManager     components.StateManager
@@ -81,6 +97,9 @@ type componentManager struct {
 	txManager        components.TXManager
 	identityResolver components.IdentityResolver
 	groupManager     components.GroupManager
+	jobScheduler     components.JobScheduler
+	contractManager  components.ContractManager
+	webhooksManager  components.WebhooksManager
 	// managers that are not a core part of the engine, but allow Paladin to operate in an extended mode - the testbed is an example.
 	// these cannot be queried by other components (no AdditionalManagers() function on AllComponents)
 	additionalManagers []components.AdditionalManager
@@ -114,6 +133,7 @@ func NewComponentManager(bgCtx context.Context, grpcTarget string, instanceUUID
 		instanceUUID:          instanceUUID,
 		bgCtx:                 bgCtx,
 		conf:                  conf,
+		currentConf:           conf,
 		additionalManagers:    additionalManagers,
 		initResults:           make(map[string]*components.ManagerInitResult),
 		started:               make(map[string]stoppable),
@@ -168,6 +188,11 @@ func (cm *componentManager) Init() (err error) {
 		cm.initResults["key_manager"], err = cm.keyManager.PreInit(cm)
 		err = cm.wrapIfErr(err, msgs.MsgComponentKeyManagerInitError)
 	}
+	if err == nil {
+		cm.jobScheduler = jobscheduler.NewJobScheduler(cm.bgCtx, &cm.conf.JobScheduler)
+		cm.initResults["job_scheduler"], err = cm.jobScheduler.PreInit(cm)
+		err = cm.wrapIfErr(err, msgs.MsgComponentJobSchedulerInitError)
+	}
 	if err == nil {
 		cm.stateManager = statemgr.NewStateManager(cm.bgCtx, &cm.conf.StateStore, cm.persistence)
 		cm.initResults["state_manager"], err = cm.stateManager.PreInit(cm)
@@ -221,6 +246,18 @@ func (cm *componentManager) Init() (err error) {
 		err = cm.wrapIfErr(err, msgs.MsgComponentGroupManagerInitError)
 	}
 
+	if err == nil {
+		cm.contractManager = contractmgr.NewContractManager(cm.bgCtx, &cm.conf.ContractManager)
+		cm.initResults["contract_manager"], err = cm.contractManager.PreInit(cm)
+		err = cm.wrapIfErr(err, msgs.MsgComponentContractManagerInitError)
+	}
+
+	if err == nil {
+		cm.webhooksManager = webhooks.NewWebhooksManager(cm.bgCtx, &cm.conf.Webhooks)
+		cm.initResults["webhooks_manager"], err = cm.webhooksManager.PreInit(cm)
+		err = cm.wrapIfErr(err, msgs.MsgComponentWebhooksInitError)
+	}
+
 	if err == nil {
 		cm.identityResolver = identityresolver.NewIdentityResolver(cm.bgCtx, &cm.conf.IdentityResolver)
 		cm.initResults["identity_resolver"], err = cm.identityResolver.PreInit(cm)
@@ -241,6 +278,11 @@ func (cm *componentManager) Init() (err error) {
 		err = cm.wrapIfErr(err, msgs.MsgComponentKeyManagerInitError)
 	}
 
+	if err == nil {
+		err = cm.jobScheduler.PostInit(cm)
+		err = cm.wrapIfErr(err, msgs.MsgComponentJobSchedulerInitError)
+	}
+
 	if err == nil {
 		err = cm.stateManager.PostInit(cm)
 		err = cm.wrapIfErr(err, msgs.MsgComponentStateManagerInitError)
@@ -286,6 +328,16 @@ func (cm *componentManager) Init() (err error) {
 		err = cm.wrapIfErr(err, msgs.MsgComponentGroupManagerInitError)
 	}
 
+	if err == nil {
+		err = cm.contractManager.PostInit(cm)
+		err = cm.wrapIfErr(err, msgs.MsgComponentContractManagerInitError)
+	}
+
+	if err == nil {
+		err = cm.webhooksManager.PostInit(cm)
+		err = cm.wrapIfErr(err, msgs.MsgComponentWebhooksInitError)
+	}
+
 	if err == nil {
 		err = cm.identityResolver.PostInit(cm)
 		err = cm.wrapIfErr(err, msgs.MsgComponentIdentityResolverInitError)
@@ -334,6 +386,11 @@ func (cm *componentManager) StartManagers() (err error) {
 	err = cm.startEthClient()
 	err = cm.addIfStarted("eth_client", cm.ethClientFactory, err, msgs.MsgComponentEthClientStartError)
 
+	// check we have not been restored from a backup that is behind the chain, before anything can allocate nonces
+	if err == nil {
+		err = cm.validateRestore()
+	}
+
 	// start the managers
 	if err == nil {
 		err = cm.keyManager.Start()
@@ -385,6 +442,24 @@ func (cm *componentManager) StartManagers() (err error) {
 		err = cm.addIfStarted("group_manager", cm.groupManager, err, msgs.MsgComponentGroupManagerStartError)
 	}
 
+	// base contracts are deployed through the TX manager
+	if err == nil {
+		err = cm.contractManager.Start()
+		err = cm.addIfStarted("contract_manager", cm.contractManager, err, msgs.MsgComponentContractManagerStartError)
+	}
+
+	// webhooks attach to receipt listeners on the TX manager, and deliver through the job scheduler
+	if err == nil {
+		err = cm.webhooksManager.Start()
+		err = cm.addIfStarted("webhooks_manager", cm.webhooksManager, err, msgs.MsgComponentWebhooksStartError)
+	}
+
+	// jobs are only run once all the managers that handle them have started
+	if err == nil {
+		err = cm.jobScheduler.Start()
+		err = cm.addIfStarted("job_scheduler", cm.jobScheduler, err, msgs.MsgComponentJobSchedulerStartError)
+	}
+
 	for _, am := range cm.additionalManagers {
 		if err == nil {
 			err = am.Start()
@@ -408,6 +483,7 @@ func (cm *componentManager) CompleteStart() error {
 	// start the RPC server last
 	if err == nil {
 		cm.registerRPCModules()
+		cm.registerHealthEndpoints()
 		err = cm.rpcServer.Start()
 		err = cm.addIfStarted("rpc_server", cm.rpcServer, err, msgs.MsgComponentRPCServerStartError)
 	}
@@ -471,6 +547,11 @@ func (cm *componentManager) registerRPCModules() {
 			cm.rpcServer.Register(rpcMod)
 		}
 	}
+	// Admin functions that span all components
+	cm.initAdminRPC()
+	cm.rpcServer.Register(cm.adminRPCModule)
+	cm.initDebugRPC()
+	cm.rpcServer.Register(cm.debugRPCModule)
 	// We handle block indexer separately (doesn't fit the internal ManagerLifecycle model
 	// as it's currently a standalone re-usable component)
 	cm.rpcServer.Register(cm.BlockIndexer().RPCModule())
@@ -549,6 +630,18 @@ func (cm *componentManager) GroupManager() components.GroupManager {
 	return cm.groupManager
 }
 
+func (cm *componentManager) JobScheduler() components.JobScheduler {
+	return cm.jobScheduler
+}
+
+func (cm *componentManager) ContractManager() components.ContractManager {
+	return cm.contractManager
+}
+
+func (cm *componentManager) WebhooksManager() components.WebhooksManager {
+	return cm.webhooksManager
+}
+
 func (cm *componentManager) IdentityResolver() components.IdentityResolver {
 	return cm.identityResolver
 }
@@ -128,6 +128,9 @@ func TestInitOK(t *testing.T) {
 	assert.NotNil(t, cm.PublicTxManager())
 	assert.NotNil(t, cm.TxManager())
 	assert.NotNil(t, cm.GroupManager())
+	assert.NotNil(t, cm.JobScheduler())
+	assert.NotNil(t, cm.ContractManager())
+	assert.NotNil(t, cm.WebhooksManager())
 	assert.NotNil(t, cm.IdentityResolver())
 
 	// Check we can send a request for a javadump - even just after init (not start)
@@ -211,13 +214,30 @@ func TestStartOK(t *testing.T) {
 	mockStateManager.On("Start").Return(nil)
 	mockStateManager.On("Stop").Return()
 
+	mockJobScheduler := componentmocks.NewJobScheduler(t)
+	mockJobScheduler.On("Start").Return(nil)
+	mockJobScheduler.On("Stop").Return()
+
+	mockContractManager := componentmocks.NewContractManager(t)
+	mockContractManager.On("Start").Return(nil)
+	mockContractManager.On("Stop").Return()
+
+	mockWebhooksManager := componentmocks.NewWebhooksManager(t)
+	mockWebhooksManager.On("Start").Return(nil)
+	mockWebhooksManager.On("Stop").Return()
+
 	mockRPCServer := componentmocks.NewRPCServer(t)
 	mockRPCServer.On("Start").Return(nil)
 	mockRPCServer.On("Register", mock.AnythingOfType("*rpcserver.RPCModule")).Return()
+	mockRPCServer.On("HandleHTTP", mock.Anything, mock.Anything).Return()
 	mockRPCServer.On("Stop").Return()
 	mockRPCServer.On("HTTPAddr").Return(&net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 8545})
 	mockRPCServer.On("WSAddr").Return(&net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 8546})
 
+	mockPersistence, err := mockpersistence.NewSQLMockProvider()
+	require.NoError(t, err)
+	mockPersistence.Mock.ExpectQuery("SELECT.*backup_manifests").WillReturnRows(mockPersistence.Mock.NewRows([]string{}))
+
 	mockExtraManager := componentmocks.NewAdditionalManager(t)
 	mockExtraManager.On("Start").Return(nil)
 	mockExtraManager.On("Name").Return("unittest_manager")
@@ -232,6 +252,7 @@ func TestStartOK(t *testing.T) {
 			},
 		},
 	}
+	cm.persistence = mockPersistence.P
 	cm.blockIndexer = mockBlockIndexer
 	cm.pluginManager = mockPluginManager
 	cm.keyManager = mockKeyManager
@@ -244,9 +265,12 @@ func TestStartOK(t *testing.T) {
 	cm.privateTxManager = mockPrivateTxManager
 	cm.txManager = mockTxManager
 	cm.groupManager = mockGroupManager
+	cm.jobScheduler = mockJobScheduler
+	cm.contractManager = mockContractManager
+	cm.webhooksManager = mockWebhooksManager
 	cm.additionalManagers = append(cm.additionalManagers, mockExtraManager)
 
-	err := cm.StartManagers()
+	err = cm.StartManagers()
 	require.NoError(t, err)
 	err = cm.CompleteStart()
 	require.NoError(t, err)
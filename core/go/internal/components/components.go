@@ -52,6 +52,9 @@ type Managers interface {
 	StateManager() StateManager
 	IdentityResolver() IdentityResolver
 	GroupManager() GroupManager
+	JobScheduler() JobScheduler
+	ContractManager() ContractManager
+	WebhooksManager() WebhooksManager
 }
 
 // All managers conform to a standard lifecycle
@@ -0,0 +1,40 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package components
+
+import (
+	"context"
+
+	"github.com/kaleido-io/paladin/core/pkg/persistence"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+)
+
+// The contract manager records the base ledger contracts (registries, domain factories) this node
+// is bound to or has deployed, and the version of each.
+type ContractManager interface {
+	ManagerLifecycle
+	TransportClient
+
+	ListBaseContracts(ctx context.Context, dbTX persistence.DBTX) ([]*pldapi.BaseContract, error)
+	GetBaseContract(ctx context.Context, dbTX persistence.DBTX, name string) (*pldapi.BaseContract, error) // nil if not recorded
+	// Deploys the contract from the build embedded in this node. The record is returned with the
+	// deployment transaction, and the address is filled in once the receipt is available.
+	DeployBaseContract(ctx context.Context, name string) (*pldapi.BaseContract, error)
+	// Sends the versions recorded by this node to a peer, which compares them with its own and replies
+	// with its versions. Both nodes log a warning and record any mismatch.
+	CheckPeerVersions(ctx context.Context, node string) error
+	ListVersionMismatches(ctx context.Context) []*pldapi.BaseContractMismatch
+}
@@ -57,6 +57,9 @@ type GroupManager interface {
 	GetGroupByID(ctx context.Context, dbTX persistence.DBTX, domainName string, groupID pldtypes.HexBytes) (*pldapi.PrivacyGroup, error)
 	QueryGroups(ctx context.Context, dbTX persistence.DBTX, jq *query.QueryJSON) ([]*pldapi.PrivacyGroup, error)
 
+	RegisterTemplate(ctx context.Context, dbTX persistence.DBTX, t *pldapi.PrivacyGroupTemplate) (*pldapi.PrivacyGroupTemplate, error)
+	GetTemplate(ctx context.Context, dbTX persistence.DBTX, name string) (*pldapi.PrivacyGroupTemplate, error)
+
 	SendMessage(ctx context.Context, dbTX persistence.DBTX, msg *pldapi.PrivacyGroupMessageInput) (*uuid.UUID, error)
 	ReceiveMessages(ctx context.Context, dbTX persistence.DBTX, msgs []*pldapi.PrivacyGroupMessage) (results map[uuid.UUID]error, err error)
 	QueryMessages(ctx context.Context, dbTX persistence.DBTX, jq *query.QueryJSON) ([]*pldapi.PrivacyGroupMessage, error)
@@ -15,7 +15,11 @@
 
 package components
 
-import "context"
+import (
+	"context"
+
+	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
+)
 
 // IdentityResolver is the interface for resolving verifiers for a given alorithm from a lookup identity
 // It can integrate with a local key manager or can communicate with an other IdentityResolver on a remote node
@@ -24,5 +28,6 @@ type IdentityResolver interface {
 	ManagerLifecycle
 	TransportClient
 	ResolveVerifier(ctx context.Context, lookup string, algorithm string, verifierType string) (string, error)
+	ResolveVerifiers(ctx context.Context, requests []*prototk.ResolveVerifierRequest) ([]*prototk.ResolvedVerifier, error)
 	ResolveVerifierAsync(ctx context.Context, lookup string, algorithm string, verifierType string, resolved func(ctx context.Context, verifier string), failed func(ctx context.Context, err error))
 }
@@ -0,0 +1,51 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package components
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/kaleido-io/paladin/core/pkg/persistence"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/query"
+)
+
+// A handler is invoked with the job while the scheduler holds a lease on it, so no other node
+// sharing the database will run the same job concurrently. Returning an error schedules a retry.
+type JobHandler func(ctx context.Context, job *pldapi.ScheduledJob) error
+
+type ScheduleJobRequest struct {
+	JobType string
+	Payload pldtypes.RawJSON
+	RunAt   *pldtypes.Timestamp // if unset the job is due immediately
+}
+
+type JobScheduler interface {
+	ManagerLifecycle
+
+	// Handlers must be registered in PostInit, before the scheduler is started. Only jobs with
+	// a handler registered on this node are leased by it.
+	RegisterHandler(jobType string, handler JobHandler)
+	// Creates the named recurring job, or updates the type and schedule if it already exists.
+	// The schedule is a five field cron expression (minute, hour, day of month, month, day of week)
+	// in UTC, or "@every <duration>".
+	EnsureRecurringJob(ctx context.Context, dbTX persistence.DBTX, name, jobType, schedule string) error
+	// Schedules a one-shot job, which runs once it has been committed and is due
+	ScheduleJob(ctx context.Context, dbTX persistence.DBTX, req *ScheduleJobRequest) (uuid.UUID, error)
+	QueryJobs(ctx context.Context, dbTX persistence.DBTX, jq *query.QueryJSON) ([]*pldapi.ScheduledJob, error)
+}
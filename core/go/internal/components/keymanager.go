@@ -18,6 +18,7 @@ package components
 import (
 	"context"
 
+	"github.com/hyperledger/firefly-signer/pkg/eip712"
 	"github.com/kaleido-io/paladin/core/pkg/persistence"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
@@ -57,4 +58,16 @@ type KeyManager interface {
 	ReverseKeyLookup(ctx context.Context, dbTX persistence.DBTX, algorithm, verifierType, verifier string) (mapping *pldapi.KeyMappingAndVerifier, err error)
 
 	Sign(ctx context.Context, mapping *pldapi.KeyMappingAndVerifier, payloadType string, payload []byte) ([]byte, error)
+
+	// Signs EIP-712 typed data with a secp256k1 key, returning the compact 65 byte R,S,V signature
+	SignTypedData(ctx context.Context, mapping *pldapi.KeyMappingAndVerifier, typedData *eip712.TypedData) ([]byte, error)
+
+	// Probes the key store behind each wallet, for health reporting
+	CheckWallets(ctx context.Context) []*WalletStatus
+}
+
+type WalletStatus struct {
+	Name     string `json:"name"`
+	Listable bool   `json:"listable"` // only key stores that support listing can be actively probed
+	Error    string `json:"error,omitempty"`
 }
@@ -29,4 +29,13 @@ type PluginManager interface {
 	WaitForInit(ctx context.Context) error
 	ReloadPluginList() error
 	SendSystemCommandToLoader(cmd prototk.PluginLoad_SysCommand)
+	GetPluginStatuses() []*PluginStatus
+}
+
+type PluginStatus struct {
+	Name        string    `json:"name"`
+	Type        string    `json:"type"`
+	ID          uuid.UUID `json:"id"`
+	Registered  bool      `json:"registered"`
+	Initialized bool      `json:"initialized"`
 }
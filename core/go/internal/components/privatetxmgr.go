@@ -52,6 +52,14 @@ type PrivateTxStatus struct {
 	FailureMessage string                       `json:"failureMessage,omitempty"`
 }
 
+// SequencerSnapshot is an in-memory view of a sequencer, for debugging
+type SequencerSnapshot struct {
+	ContractAddress string             `json:"contractAddress"`
+	Domain          string             `json:"domain"`
+	Initiated       pldtypes.Timestamp `json:"initiated"`
+	InFlight        []PrivateTxStatus  `json:"inFlight"`
+}
+
 type StateDistributionSet struct {
 	LocalNode  string
 	SenderNode string
@@ -83,6 +91,7 @@ type PrivateTxManager interface {
 	//Synchronous functions to submit a new private transaction
 	HandleNewTx(ctx context.Context, dbTX persistence.DBTX, tx *ValidatedTransaction) error
 	GetTxStatus(ctx context.Context, domainAddress string, txID uuid.UUID) (status PrivateTxStatus, err error)
+	GetSequencerSnapshots(ctx context.Context) []*SequencerSnapshot
 
 	// Synchronous function to call an existing deployed smart contract
 	CallPrivateSmartContract(ctx context.Context, call *ResolvedTransaction) (*abi.ComponentValue, error)
@@ -95,6 +104,10 @@ type PrivateTxManager interface {
 
 	PrivateTransactionConfirmed(ctx context.Context, receipt *TxCompletion)
 
+	// Called in the DB transaction that records the confirmation of private transactions, to submit
+	// any external calls the domain declared when preparing them (only the dispatching node has these)
+	SubmitExternalCalls(ctx context.Context, dbTX persistence.DBTX, txIDs []uuid.UUID) error
+
 	BuildStateDistributions(ctx context.Context, tx *PrivateTransaction) (*StateDistributionSet, error)
 	BuildNullifier(ctx context.Context, kr KeyResolver, s *StateDistributionWithData) (*NullifierUpsert, error)
 	BuildNullifiers(ctx context.Context, distributions []*StateDistributionWithData) (nullifiers []*NullifierUpsert, err error)
@@ -19,6 +19,7 @@ import (
 	"context"
 
 	"github.com/google/uuid"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
 	"github.com/kaleido-io/paladin/core/internal/filters"
 	"github.com/kaleido-io/paladin/core/pkg/blockindexer"
 	"github.com/kaleido-io/paladin/core/pkg/persistence"
@@ -30,17 +31,24 @@ import (
 var PublicTxFilterFields filters.FieldSet = filters.FieldMap{
 	"localId":         filters.Int64Field(`"public_txns"."pub_txn_id"`),
 	"from":            filters.HexBytesField(`"from"`),
+	"to":              filters.HexBytesField(`"to"`),
 	"nonce":           filters.Int64Field("nonce"),
-	"created":         filters.Int64Field("created"),
-	"completedAt":     filters.Int64Field(`"Completed"."created"`),
-	"transactionHash": filters.Int64Field(`"Completed"."tx_hash"`),
+	"created":         filters.TimestampField(`"public_txns"."created"`),
+	"completedAt":     filters.TimestampField(`"Completed"."created"`),
+	"transactionHash": filters.Bytes32Field(`"Completed"."tx_hash"`),
 	"success":         filters.BooleanField(`"Completed"."success"`),
 	"revertData":      filters.HexBytesField(`"Completed"."revert_data"`),
+	"expired":         filters.BooleanField(`"Completed"."expired"`),
+	"expiry":          filters.TimestampField(`"public_txns"."expiry"`),
+	"metadata":        filters.JSONField(`"public_txns"."metadata"`),
+	// derived from the completion, using the values of pldapi.PublicTxStatus
+	"status": filters.StringField(`(CASE WHEN "Completed"."tx_hash" IS NULL THEN 'pending' WHEN "Completed"."expired" THEN 'expired' WHEN "Completed"."success" THEN 'success' ELSE 'failed' END)`),
 }
 
 type PublicTxSubmission struct {
 	Bindings             []*PaladinTXReference
-	pldapi.PublicTxInput // the request to create the transaction
+	pldapi.PublicTxInput                     // the request to create the transaction
+	ChainID              *pldtypes.HexUint64 // if set by the submitter, must match the chain the node is connected to
 }
 
 type PaladinTXReference struct {
@@ -51,6 +59,42 @@ type PaladinTXReference struct {
 type PublicTxMatch struct {
 	PaladinTXReference
 	*blockindexer.IndexedTransactionNotify
+	PublicTxnID uint64 // the local ID of the public transaction that matched
+	Expired     bool   // the no-op replacement of an expired transaction was mined, rather than the transaction itself
+}
+
+type PublicTxEventType string
+
+const (
+	PublicTxEventCreated   PublicTxEventType = "created"
+	PublicTxEventSubmitted PublicTxEventType = "submitted" // recorded for each submission with a new transaction hash
+	PublicTxEventSucceeded PublicTxEventType = "succeeded"
+	PublicTxEventFailed    PublicTxEventType = "failed"
+	PublicTxEventExpired   PublicTxEventType = "expired" // the no-op replacement was mined, after the expiry of the transaction
+)
+
+// PublicTxEvent is a status transition of a public transaction
+type PublicTxEvent struct {
+	Sequence        uint64
+	PublicTxnID     uint64
+	Type            PublicTxEventType
+	From            pldtypes.EthAddress
+	Nonce           *uint64 // nil until the nonce is assigned
+	TransactionHash *pldtypes.Bytes32
+	Bindings        []*PaladinTXReference // empty for transactions submitted directly to the public TX manager, such as auto-fueling
+	Created         pldtypes.Timestamp
+}
+
+// PublicTxEventFilter restricts the events delivered to a subscriber - all fields are optional
+type PublicTxEventFilter struct {
+	Signers             []pldtypes.EthAddress
+	TransactionIDPrefix string // matched against the IDs of the bound Paladin transactions
+	Types               []PublicTxEventType
+}
+
+type PublicTxEventReceiver interface {
+	// Returning an error causes the batch to be redelivered, so processing must be idempotent
+	DeliverPublicTxEvents(ctx context.Context, events []*PublicTxEvent) error
 }
 
 type PublicTxManager interface {
@@ -59,12 +103,29 @@ type PublicTxManager interface {
 	// Synchronous functions that are executed on the callers thread
 	QueryPublicTxForTransactions(ctx context.Context, dbTX persistence.DBTX, boundToTxns []uuid.UUID, jq *query.QueryJSON) (map[uuid.UUID][]*pldapi.PublicTx, error)
 	QueryPublicTxWithBindings(ctx context.Context, dbTX persistence.DBTX, jq *query.QueryJSON) ([]*pldapi.PublicTxWithBinding, error)
+	QueryPublicTxPage(ctx context.Context, dbTX persistence.DBTX, ptq *pldapi.PublicTxQuery) (*pldapi.PublicTxPage, error)
 	GetPublicTransactionForHash(ctx context.Context, dbTX persistence.DBTX, hash pldtypes.Bytes32) (*pldapi.PublicTxWithBinding, error)
+	GetRawSubmission(ctx context.Context, dbTX persistence.DBTX, hash pldtypes.Bytes32) (*pldapi.PublicTxRawSubmission, error)
+
+	// Latency tracing of recent public transactions, which is only held in memory
+	GetTransactionTimelines(ctx context.Context, dbTX persistence.DBTX, txID uuid.UUID) ([]*pldapi.PublicTxTimeline, error)
+	GetLatencyStats(ctx context.Context) []*pldapi.PublicTxLatencyStats
+
+	// Gas price schedules can be replaced at runtime, until the next restart
+	GetGasPriceSchedules(ctx context.Context) []*pldconf.GasPriceScheduleConfig
+	SetGasPriceSchedules(ctx context.Context, schedules []*pldconf.GasPriceScheduleConfig) error
+	ReloadConfig(ctx context.Context, conf *pldconf.PublicTxManagerConfig) error
 
 	// Perform (potentially expensive) transaction level validation, such as gas estimation. Call before starting a DB transaction
 	ValidateTransaction(ctx context.Context, dbTX persistence.DBTX, transaction *PublicTxSubmission) error
 	// Write a set of validated transactions to the public TX mgr database, notifying the relevant orchestrator(s) to wake, assign nonces, and start the submission process
 	WriteNewTransactions(ctx context.Context, dbTX persistence.DBTX, transactions []*PublicTxSubmission) ([]*pldapi.PublicTx, error)
+	// Run a transaction through validation, nonce assignment, gas pricing and signing - returning the signed transaction without persisting or submitting it
+	DryRunTransaction(ctx context.Context, dbTX persistence.DBTX, transaction *PublicTxSubmission) (*pldapi.PublicTxDryRun, error)
+	// Execute a transaction against the latest block without signing or submitting it, returning the call trace where the node supports it
+	SimulateTransaction(ctx context.Context, dbTX persistence.DBTX, transaction *PublicTxSubmission) (*pldapi.PublicTxSimulation, error)
+	// Predict the nonces, gas cost and auto-fueling a batch of validated transactions would lead to, without persisting or reserving anything
+	EstimateBatchImpact(ctx context.Context, dbTX persistence.DBTX, transactions []*PublicTxSubmission) (*pldapi.PublicTxBatchImpact, error)
 	// Convenience function that does ValidateTransaction+WriteNewTransactions for a single Tx
 	SingleTransactionSubmit(ctx context.Context, transaction *PublicTxSubmission) (*pldapi.PublicTx, error)
 
@@ -72,4 +133,106 @@ type PublicTxManager interface {
 	NotifyConfirmPersisted(ctx context.Context, confirms []*PublicTxMatch)
 
 	UpdateTransaction(ctx context.Context, id uuid.UUID, pubTXID uint64, from *pldtypes.EthAddress, tx *pldapi.TransactionInput, publicTxData []byte, txmgrDBUpdate func(dbTX persistence.DBTX) error) error
+
+	// Subscribe to status transitions of public transactions, with at-least-once delivery from a cursor persisted against the name.
+	// Events are only recorded when there are subscribers, so this must be called before the public TX manager is started.
+	AddEventSubscriber(ctx context.Context, name string, filter *PublicTxEventFilter, r PublicTxEventReceiver) error
+
+	// Administrative actions to stop, and restart, the submission of a pending transaction
+	SuspendTransaction(ctx context.Context, from pldtypes.EthAddress, nonce uint64) error
+	ResumeTransaction(ctx context.Context, from pldtypes.EthAddress, nonce uint64) error
+	// Replace a pending transaction with a no-op, so it fails with an expired receipt unless the original is mined first
+	CancelTransaction(ctx context.Context, from pldtypes.EthAddress, nonce uint64) error
+
+	// Drain all in-flight orchestrators and stop new ones starting, so that nonce allocation is frozen for a backup
+	Quiesce(ctx context.Context) error
+	Unquiesce(ctx context.Context)
+	IsQuiesced() bool
+	// The next nonce for every signer that has had a nonce allocated, which is only stable while quiesced
+	GetSignerNonces(ctx context.Context, dbTX persistence.DBTX) ([]*pldapi.SignerNonce, error)
+
+	// In-memory view of the orchestrators and their in-flight transactions, for debugging
+	GetOrchestratorSnapshots(ctx context.Context) []*PublicTxOrchestratorSnapshot
+	// The outcome of the recovery phase run when the engine started - nil if recovery is disabled, or has not started
+	GetRecoverySummary(ctx context.Context) *PublicTxRecoverySummary
+	// Counters kept in memory by the engine since it started, for debugging
+	GetEngineMetrics(ctx context.Context) *PublicTxEngineMetrics
+}
+
+type PublicTxOrchestratorSnapshot struct {
+	Signer         pldtypes.EthAddress         `json:"signer"`
+	State          string                      `json:"state"`
+	StateEntryTime pldtypes.Timestamp          `json:"stateEntryTime"`
+	Started        pldtypes.Timestamp          `json:"started"`
+	InFlight       []*PublicTxInFlightSnapshot `json:"inFlight"`
+	Overflow       int                         `json:"overflow"` // transactions waiting in the DB for an in-flight slot
+}
+
+type PublicTxInFlightSnapshot struct {
+	LocalID         uint64              `json:"localId"`
+	Nonce           uint64              `json:"nonce"`
+	Stage           string              `json:"stage"`
+	InFlightStatus  string              `json:"inFlightStatus"`
+	TransactionHash *pldtypes.Bytes32   `json:"transactionHash,omitempty"`
+	LastSubmit      *pldtypes.Timestamp `json:"lastSubmit,omitempty"`
+}
+
+type PublicTxEngineMetrics struct {
+	StageTimeouts map[string]int64                         `json:"stageTimeouts"` // the number of times each in-flight stage has timed out
+	StageLatency  map[string]*PublicTxStageHistogram       `json:"stageLatency"`  // the latency of every transaction reaching each stage (when latency tracing is enabled)
+	Fueling       map[string]*PublicTxFuelingMetrics       `json:"fueling"`       // keyed by the address of each auto-fueling source
+	OverflowQueue map[string]*PublicTxOverflowQueueMetrics `json:"overflowQueue"` // keyed by the address of each signer with transactions waiting for an in-flight slot
+}
+
+type PublicTxOverflowQueueMetrics struct {
+	Queued int `json:"queued"` // transactions waiting in the DB for an in-flight slot, as of the last poll of the orchestrator
+	Free   int `json:"free"`   // how far the queue is below the configured maxOverflow
+}
+
+type PublicTxFuelingMetrics struct {
+	Transactions int64                `json:"transactions"` // fueling transactions submitted from the source
+	Spent        *pldtypes.HexUint256 `json:"spent"`        // total value of those transactions
+	Failovers    map[string]int64     `json:"failovers"`    // the number of times the source was skipped, by reason
+}
+
+type PublicTxStageHistogram struct {
+	SincePrev  *PublicTxLatencyHistogram `json:"sincePrev"`
+	SinceStart *PublicTxLatencyHistogram `json:"sinceStart"`
+}
+
+// Cumulative buckets, so each bucket counts every sample less than or equal to its bound,
+// and the total count includes the samples above the largest bound
+type PublicTxLatencyHistogram struct {
+	Count      int64                    `json:"count"`
+	SumSeconds float64                  `json:"sumSeconds"`
+	Buckets    []*PublicTxLatencyBucket `json:"buckets"`
+}
+
+type PublicTxLatencyBucket struct {
+	LESeconds float64 `json:"le"`
+	Count     int64   `json:"count"`
+}
+
+// On start the engine reconciles the transactions persisted as pending with the chain, before
+// any orchestrators are started, so that work lost or completed during a crash is accounted for.
+type PublicTxRecoverySummary struct {
+	Started    pldtypes.Timestamp        `json:"started"`
+	Completed  *pldtypes.Timestamp       `json:"completed,omitempty"` // nil while recovery is running
+	ReportOnly bool                      `json:"reportOnly"`
+	Signers    []*PublicTxSignerRecovery `json:"signers"`
+}
+
+type PublicTxSignerRecovery struct {
+	Signer         pldtypes.EthAddress `json:"signer"`
+	ConfirmedNonce *uint64             `json:"confirmedNonce,omitempty"` // nil if the chain could not be queried
+	ChainError     string              `json:"chainError,omitempty"`
+	Pending        int                 `json:"pending"`       // incomplete and not suspended
+	Suspended      int                 `json:"suspended"`     // left alone until resumed
+	Unassigned     int                 `json:"unassigned"`    // waiting for a nonce
+	Unsubmitted    int                 `json:"unsubmitted"`   // nonce assigned, but no submission was recorded
+	Mined          int                 `json:"mined"`         // submitted with a nonce the chain has passed, so tracked until the block indexer confirms it
+	InPool         int                 `json:"inPool"`        // known to the blockchain node, so tracked without re-submitting
+	Resubmit       int                 `json:"resubmit"`      // submitted, but not known to the node (or could not be checked) so will be re-submitted
+	NonceConsumed  int                 `json:"nonceConsumed"` // no submission recorded, but the chain has passed the nonce
+	NonceGaps      int                 `json:"nonceGaps"`     // nonces between the confirmed nonce and the highest pending nonce held by no pending transaction
 }
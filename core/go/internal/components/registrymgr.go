@@ -49,10 +49,14 @@ type RegistryManager interface {
 	RegistryRegistered(name string, id uuid.UUID, toRegistry RegistryManagerToRegistry) (fromRegistry plugintk.RegistryCallbacks, err error)
 	GetNodeTransports(ctx context.Context, node string) ([]*RegistryNodeTransportEntry, error)
 	GetRegistry(ctx context.Context, name string) (Registry, error)
+	CheckCredential(ctx context.Context, dbTX persistence.DBTX, check *pldapi.RegistryCredentialCheck) (*pldapi.RegistryCredentialCheckResult, error)
 }
 
 type Registry interface {
 	QueryEntries(ctx context.Context, dbTX persistence.DBTX, fActive pldapi.ActiveFilter, jq *query.QueryJSON) ([]*pldapi.RegistryEntry, error)
 	QueryEntriesWithProps(ctx context.Context, dbTX persistence.DBTX, fActive pldapi.ActiveFilter, jq *query.QueryJSON) ([]*pldapi.RegistryEntryWithProperties, error)
 	GetEntryProperties(ctx context.Context, dbTX persistence.DBTX, fActive pldapi.ActiveFilter, entityIDs ...pldtypes.HexBytes) ([]*pldapi.RegistryProperty, error)
+	AttachCredential(ctx context.Context, dbTX persistence.DBTX, cred *pldapi.RegistryCredential) (*pldapi.RegistryCredential, error)
+	GetCredentials(ctx context.Context, dbTX persistence.DBTX, entryID pldtypes.HexBytes, includeRevoked bool) ([]*pldapi.RegistryCredential, error)
+	RevokeCredential(ctx context.Context, dbTX persistence.DBTX, id string) error
 }
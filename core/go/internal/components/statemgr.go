@@ -25,6 +25,7 @@ import (
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/query"
+	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
 	"gorm.io/gorm"
 )
 
@@ -34,8 +35,9 @@ type StateManager interface {
 	// Get a list of all active domain contexts
 	ListDomainContexts() []DomainContextInfo
 
-	// Create a new domain context - caller is responsible for closing it
-	NewDomainContext(ctx context.Context, domain Domain, contractAddress pldtypes.EthAddress) DomainContext
+	// Create a new domain context - caller is responsible for closing it.
+	// The isolation overrides the default configured by the domain, unless it is empty
+	NewDomainContext(ctx context.Context, domain Domain, contractAddress pldtypes.EthAddress, isolation DomainContextIsolation) DomainContext
 
 	// Get a previously created domain context
 	GetDomainContext(ctx context.Context, id uuid.UUID) DomainContext
@@ -67,6 +69,21 @@ type StateManager interface {
 
 	// Get all states created, read or spent by a confirmed transaction
 	GetTransactionStates(ctx context.Context, dbTX persistence.DBTX, txID uuid.UUID) (*pldapi.TransactionStates, error)
+
+	// Returns the SQL that would be executed for a query, and the label statistics used to plan it
+	ExplainStatesQuery(ctx context.Context, dbTX persistence.DBTX, domainName string, contractAddress *pldtypes.EthAddress, schemaID pldtypes.Bytes32, query *query.QueryJSON, status pldapi.StateStatusQualifier) (*pldapi.StateQueryExplanation, error)
+
+	// Export all the states of a domain (optionally for a single contract), with their schemas and confirm/spend records
+	ExportStateSnapshot(ctx context.Context, dbTX persistence.DBTX, domainName string, contractAddress *pldtypes.EthAddress) (*pldapi.StateSnapshot, error)
+
+	// Import a snapshot exported from another node, verifying the hashes of everything in it
+	ImportStateSnapshot(ctx context.Context, dbTX persistence.DBTX, snapshot *pldapi.StateSnapshot) (*pldapi.StateSnapshotImportResult, error)
+
+	// Returns the merkle proof that a state is included in an anchor written to the base ledger, or nil if it has not been anchored yet
+	GetStateAnchorProof(ctx context.Context, dbTX persistence.DBTX, domainName string, stateID pldtypes.HexBytes) (*pldapi.StateAnchorProof, error)
+
+	// Called after the data of states is changed in the DB outside of the state manager
+	EvictCachedStates(domainName string, stateIDs ...pldtypes.HexBytes)
 }
 
 type StateQueryOptions struct {
@@ -76,9 +93,32 @@ type StateQueryOptions struct {
 }
 
 type DomainContextInfo struct {
-	ID              uuid.UUID           `json:"id"`
-	DomainName      string              `json:"domain"`
-	ContractAddress pldtypes.EthAddress `json:"contractAddress"`
+	ID              uuid.UUID              `json:"id"`
+	DomainName      string                 `json:"domain"`
+	ContractAddress pldtypes.EthAddress    `json:"contractAddress"`
+	Isolation       DomainContextIsolation `json:"isolation"`
+}
+
+// The isolation of a domain context determines which of the states that are not yet confirmed
+// on-chain are available for selection by its queries
+type DomainContextIsolation string
+
+const (
+	DomainContextIsolationStrict            DomainContextIsolation = "strict"             // only confirmed states
+	DomainContextIsolationSpeculative       DomainContextIsolation = "speculative"        // plus the states being created in this context
+	DomainContextIsolationSharedSpeculative DomainContextIsolation = "shared_speculative" // plus the states being created in any other context for the same contract
+)
+
+// DomainStateIsolation returns the isolation the domain has asked for, in the contexts that assemble its transactions
+func DomainStateIsolation(conf *prototk.DomainConfig) DomainContextIsolation {
+	switch conf.GetStateIsolation() {
+	case prototk.DomainConfig_STATE_ISOLATION_STRICT:
+		return DomainContextIsolationStrict
+	case prototk.DomainConfig_STATE_ISOLATION_SHARED_SPECULATIVE:
+		return DomainContextIsolationSharedSpeculative
+	default:
+		return DomainContextIsolationSpeculative
+	}
 }
 
 // The DSI is the state interface that is exposed outside of the statestore package, for the
@@ -168,6 +208,9 @@ type DomainContext interface {
 	// safely under the mutex of the domain context.
 	StateLocksByTransaction() map[uuid.UUID][]pldapi.StateLock
 
+	// Whether any state locks are held in this context, which is safe to call on a critical path
+	HasStateLocks() bool
+
 	// Reset restores the world to the current state of the database, clearing any errors
 	// from failed flush, all un-flushed writes, and all in-memory state locks.
 	// It does not wait for an in-progress flush to complete
@@ -100,6 +100,15 @@ type PrivateTransaction struct {
 	PreparedPublicTransaction  *pldapi.TransactionInput `json:"-"`
 	PreparedPrivateTransaction *pldapi.TransactionInput `json:"-"`
 	PreparedMetadata           pldtypes.RawJSON         `json:"-"`
+	PreparedExternalCalls      []*ExternalCall          `json:"-"`
+}
+
+// ExternalCall is a public transaction a domain asks to be submitted once the private transaction
+// that prepared it is confirmed. The dedup key is persisted, so at most one call is ever submitted
+// for each key, however many times the transaction is prepared or its confirmation is processed.
+type ExternalCall struct {
+	DedupKey    string
+	Transaction *pldapi.TransactionInput
 }
 
 // PrivateContractDeploy is a simpler transaction type that constructs new private smart contract instances
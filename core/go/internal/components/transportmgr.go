@@ -23,6 +23,7 @@ import (
 	"github.com/kaleido-io/paladin/config/pkg/pldconf"
 	"github.com/kaleido-io/paladin/core/pkg/persistence"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/query"
 	"github.com/kaleido-io/paladin/toolkit/pkg/plugintk"
 	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
@@ -45,6 +46,12 @@ type ReceivedMessage struct {
 	Payload       []byte
 }
 
+// A StateRequest asks a remote node to re-send states it previously distributed to this node
+type StateRequest struct {
+	Domain   string              `json:"domain"`
+	StateIDs []pldtypes.HexBytes `json:"stateIds"`
+}
+
 type TransportManagerToTransport interface {
 	plugintk.TransportAPI
 	Initialized()
@@ -110,6 +117,11 @@ type TransportManager interface {
 	// including over node restart, until an ack is returned from the remote node.
 	SendReliable(ctx context.Context, dbTX persistence.DBTX, msg ...*pldapi.ReliableMessage) (err error)
 
+	// Reliably sends a request to a remote node, to re-send states that it previously distributed to this node
+	// but that are missing locally. The remote node re-queues a state distribution for each state it holds a
+	// record of sending to this node, and nacks the request listing any states it did not send to us.
+	RequestStates(ctx context.Context, dbTX persistence.DBTX, node string, req *StateRequest) (*pldapi.ReliableMessage, error)
+
 	QueryReliableMessages(ctx context.Context, dbTX persistence.DBTX, jq *query.QueryJSON) ([]*pldapi.ReliableMessage, error)
 	QueryReliableMessageAcks(ctx context.Context, dbTX persistence.DBTX, jq *query.QueryJSON) ([]*pldapi.ReliableMessageAck, error)
 }
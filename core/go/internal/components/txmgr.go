@@ -98,18 +98,22 @@ type TXManager interface {
 	SendTransactions(ctx context.Context, dbTX persistence.DBTX, txs ...*pldapi.TransactionInput) (txIDs []uuid.UUID, err error)
 	ResolveTransactionInputs(ctx context.Context, dbTX persistence.DBTX, tx *pldapi.TransactionInput) (*ResolvedFunction, *abi.ComponentValue, pldtypes.RawJSON, error)
 	PrepareTransactions(ctx context.Context, dbTX persistence.DBTX, txs ...*pldapi.TransactionInput) (txIDs []uuid.UUID, err error)
+	SetDraining(ctx context.Context, draining bool) // rejects new transactions submitted over the API while draining
 	GetTransactionByID(ctx context.Context, id uuid.UUID) (*pldapi.Transaction, error)
 	GetResolvedTransactionByID(ctx context.Context, id uuid.UUID) (*ResolvedTransaction, error) // cache optimized
 	GetTransactionByIDFull(ctx context.Context, id uuid.UUID) (result *pldapi.TransactionFull, err error)
 	GetTransactionDependencies(ctx context.Context, id uuid.UUID) (*pldapi.TransactionDependencies, error)
 	GetPublicTransactionByNonce(ctx context.Context, from pldtypes.EthAddress, nonce pldtypes.HexUint64) (*pldapi.PublicTxWithBinding, error)
 	GetPublicTransactionByHash(ctx context.Context, hash pldtypes.Bytes32) (*pldapi.PublicTxWithBinding, error)
+	GetPublicTransactionRawSubmission(ctx context.Context, hash pldtypes.Bytes32) (*pldapi.PublicTxRawSubmission, error)
 	QueryTransactions(ctx context.Context, jq *query.QueryJSON, dbTX persistence.DBTX, pending bool) ([]*pldapi.Transaction, error)
 	QueryTransactionsResolved(ctx context.Context, jq *query.QueryJSON, dbTX persistence.DBTX, pending bool) ([]*ResolvedTransaction, error)
 	QueryTransactionsFull(ctx context.Context, jq *query.QueryJSON, dbTX persistence.DBTX, pending bool) (results []*pldapi.TransactionFull, err error)
 	QueryTransactionsFullTx(ctx context.Context, jq *query.QueryJSON, dbTX persistence.DBTX, pending bool) ([]*pldapi.TransactionFull, error)
 	QueryTransactionReceipts(ctx context.Context, jq *query.QueryJSON) ([]*pldapi.TransactionReceipt, error)
 	GetTransactionReceiptByID(ctx context.Context, id uuid.UUID) (*pldapi.TransactionReceipt, error)
+	GetTransactionEndorsements(ctx context.Context, dbTX persistence.DBTX, id uuid.UUID) ([]*pldapi.TransactionEndorsement, error)
+	ExportTransactionEvidence(ctx context.Context, id uuid.UUID) (*pldapi.SignedTransactionEvidence, error)
 	GetPreparedTransactionByID(ctx context.Context, dbTX persistence.DBTX, id uuid.UUID) (*pldapi.PreparedTransaction, error)
 	GetPreparedTransactionWithRefsByID(ctx context.Context, dbTX persistence.DBTX, id uuid.UUID) (*PreparedTransactionWithRefs, error)
 	QueryPreparedTransactions(ctx context.Context, dbTX persistence.DBTX, jq *query.QueryJSON) ([]*pldapi.PreparedTransaction, error)
@@ -123,6 +127,18 @@ type TXManager interface {
 	StopReceiptListener(ctx context.Context, name string) error
 	DeleteReceiptListener(ctx context.Context, name string) error
 	AddReceiptReceiver(ctx context.Context, name string, r ReceiptReceiver) (ReceiverCloser, error)
+	WatchExternalTransaction(ctx context.Context, watch *pldapi.ExternalTransactionWatch) (*pldapi.ExternalTransactionWatch, error)
+	GetExternalTransactionWatch(ctx context.Context, id uuid.UUID) (*pldapi.ExternalTransactionWatch, error)
+	QueryExternalTransactionWatches(ctx context.Context, dbTX persistence.DBTX, jq *query.QueryJSON) ([]*pldapi.ExternalTransactionWatch, error)
+	DeleteExternalTransactionWatch(ctx context.Context, id uuid.UUID) error
+	QueryTransactionFees(ctx context.Context, dbTX persistence.DBTX, jq *query.QueryJSON) ([]*pldapi.TransactionFee, error)
+	GetTransactionFeeReport(ctx context.Context, dbTX persistence.DBTX, input *pldapi.TransactionFeeReportInput) (*pldapi.TransactionFeeReport, error)
+	PrepareSwap(ctx context.Context, input *pldapi.SwapInput) (*pldapi.Swap, error)
+	GetSwap(ctx context.Context, id uuid.UUID) (*pldapi.Swap, error)
+	QuerySwaps(ctx context.Context, dbTX persistence.DBTX, jq *query.QueryJSON) ([]*pldapi.Swap, error)
+	ApproveSwap(ctx context.Context, id uuid.UUID) (*pldapi.Swap, error)
+	ExecuteSwap(ctx context.Context, id uuid.UUID) (*pldapi.Swap, error)
+	RollbackSwap(ctx context.Context, id uuid.UUID) (*pldapi.Swap, error)
 
 	// These functions for use of other components
 
@@ -131,4 +147,6 @@ type TXManager interface {
 	PrepareInternalPrivateTransaction(ctx context.Context, dbTX persistence.DBTX, tx *pldapi.TransactionInput, submitMode pldapi.SubmitMode) (*ValidatedTransaction, error)
 	UpsertInternalPrivateTxsFinalizeIDs(ctx context.Context, dbTX persistence.DBTX, txis []*ValidatedTransaction) error
 	WritePreparedTransactions(ctx context.Context, dbTX persistence.DBTX, prepared []*PreparedTransactionWithRefs) error
+	WriteTransactionEndorsements(ctx context.Context, dbTX persistence.DBTX, endorsements []*pldapi.TransactionEndorsement) error
+	EvictCachedTransactions(txIDs ...uuid.UUID) // called after the data of transactions is changed in the DB outside of the TX manager
 }
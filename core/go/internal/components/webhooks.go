@@ -0,0 +1,38 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package components
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/kaleido-io/paladin/core/pkg/persistence"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/query"
+)
+
+// The webhooks manager POSTs an event to each configured HTTP endpoint as transactions complete, so
+// external systems can be notified without holding a websocket subscription open. Each delivery is
+// recorded, and retried with backoff until the endpoint accepts it or the attempts are exhausted.
+type WebhooksManager interface {
+	ManagerLifecycle
+
+	ListWebhooks(ctx context.Context) []*pldapi.Webhook
+	QueryDeliveries(ctx context.Context, dbTX persistence.DBTX, jq *query.QueryJSON) ([]*pldapi.WebhookDelivery, error)
+	GetDelivery(ctx context.Context, dbTX persistence.DBTX, id uuid.UUID) (*pldapi.WebhookDelivery, error) // nil if not found
+	// Resets a failed or delivered delivery to pending, and attempts it again with a fresh set of retries
+	RetryDelivery(ctx context.Context, id uuid.UUID) (*pldapi.WebhookDelivery, error)
+}
@@ -0,0 +1,68 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package contractmgr
+
+import (
+	"context"
+	"embed"
+	"io/fs"
+	"path"
+	"strings"
+
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/solutils"
+)
+
+// The builds of the base ledger contracts shipped with this release, copied in by the build
+// from the compiled solidity. Each file is named after the contract.
+//
+//go:embed abis/*.json
+var embeddedBuilds embed.FS
+
+type baseContractBuild struct {
+	build   *solutils.SolidityBuild
+	version string
+}
+
+// The version of an embedded build is the hash of its bytecode, so it changes with any change to the
+// contract or the compiler, and nodes running the same release agree on it.
+func buildVersion(bytecode pldtypes.HexBytes) string {
+	return pldtypes.Bytes32Keccak(bytecode).String()
+}
+
+func loadEmbeddedBuilds(ctx context.Context, buildFS fs.FS) (map[string]*baseContractBuild, error) {
+	files, err := fs.Glob(buildFS, "abis/*.json")
+	if err != nil {
+		return nil, err
+	}
+	builds := make(map[string]*baseContractBuild, len(files))
+	for _, file := range files {
+		buildJSON, err := fs.ReadFile(buildFS, file)
+		var build *solutils.SolidityBuild
+		if err == nil {
+			build, err = solutils.LoadBuild(ctx, buildJSON)
+		}
+		if err != nil {
+			return nil, err
+		}
+		name := strings.TrimSuffix(path.Base(file), ".json")
+		builds[name] = &baseContractBuild{
+			build:   build,
+			version: buildVersion(build.Bytecode),
+		}
+	}
+	return builds, nil
+}
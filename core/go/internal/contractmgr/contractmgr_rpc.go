@@ -0,0 +1,67 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package contractmgr
+
+import (
+	"context"
+
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/toolkit/pkg/rpcserver"
+)
+
+func (cm *contractManager) RPCModule() *rpcserver.RPCModule {
+	return cm.rpcModule
+}
+
+func (cm *contractManager) initRPC() {
+	cm.rpcModule = rpcserver.NewRPCModule("contracts").
+		Add("contracts_listBaseContracts", cm.rpcListBaseContracts()).
+		Add("contracts_getBaseContract", cm.rpcGetBaseContract()).
+		Add("contracts_deployBaseContract", cm.rpcDeployBaseContract()).
+		Add("contracts_checkPeerVersions", cm.rpcCheckPeerVersions()).
+		Add("contracts_listVersionMismatches", cm.rpcListVersionMismatches())
+}
+
+func (cm *contractManager) rpcListBaseContracts() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod0(func(ctx context.Context) ([]*pldapi.BaseContract, error) {
+		return cm.ListBaseContracts(ctx, cm.p.NOTX())
+	})
+}
+
+func (cm *contractManager) rpcGetBaseContract() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod1(func(ctx context.Context, name string) (*pldapi.BaseContract, error) {
+		return cm.GetBaseContract(ctx, cm.p.NOTX(), name)
+	})
+}
+
+func (cm *contractManager) rpcDeployBaseContract() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod1(func(ctx context.Context, name string) (*pldapi.BaseContract, error) {
+		return cm.DeployBaseContract(ctx, name)
+	})
+}
+
+func (cm *contractManager) rpcCheckPeerVersions() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod1(func(ctx context.Context, node string) (bool, error) {
+		err := cm.CheckPeerVersions(ctx, node)
+		return err == nil, err
+	})
+}
+
+func (cm *contractManager) rpcListVersionMismatches() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod0(func(ctx context.Context) ([]*pldapi.BaseContractMismatch, error) {
+		return cm.ListVersionMismatches(ctx), nil
+	})
+}
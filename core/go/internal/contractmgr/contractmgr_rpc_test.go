@@ -0,0 +1,85 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package contractmgr
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/google/uuid"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldclient"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/rpcclient"
+	"github.com/kaleido-io/paladin/toolkit/pkg/rpcserver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRPCServer(t *testing.T, cm *contractManager) rpcclient.Client {
+	s, err := rpcserver.NewRPCServer(context.Background(), &pldconf.RPCServerConfig{
+		HTTP: pldconf.RPCServerConfigHTTP{
+			HTTPServerConfig: pldconf.HTTPServerConfig{Address: confutil.P("127.0.0.1"), Port: confutil.P(0)},
+		},
+		WS: pldconf.RPCServerConfigWS{Disabled: true},
+	})
+	require.NoError(t, err)
+	err = s.Start()
+	require.NoError(t, err)
+	t.Cleanup(s.Stop)
+
+	s.Register(cm.RPCModule())
+
+	return rpcclient.WrapRestyClient(resty.New().SetBaseURL(fmt.Sprintf("http://%s", s.HTTPAddr())))
+}
+
+func TestRPCBaseContracts(t *testing.T) {
+	ctx := context.Background()
+	cm, mc := newTestContractManager(t, &pldconf.ContractManagerConfig{From: "deployer"})
+	client := pldclient.Wrap(newTestRPCServer(t, cm)).Contracts()
+
+	txID := uuid.New()
+	mc.txManager.On("SendTransactions", mock.Anything, mock.Anything, mock.Anything).Return([]uuid.UUID{txID}, nil)
+	mc.txManager.On("GetTransactionReceiptByID", mock.Anything, txID).Return(nil, nil)
+
+	bc, err := client.DeployBaseContract(ctx, "Contract1")
+	require.NoError(t, err)
+	assert.Equal(t, txID, *bc.Transaction)
+
+	bc, err = client.GetBaseContract(ctx, "Contract1")
+	require.NoError(t, err)
+	assert.Equal(t, "Contract1", bc.Name)
+
+	contracts, err := client.ListBaseContracts(ctx)
+	require.NoError(t, err)
+	require.Len(t, contracts, 1)
+
+	mc.transportManager.On("LocalNodeName").Return("node1")
+	mc.transportManager.On("Send", mock.Anything, mock.Anything).Return(nil)
+	requested, err := client.CheckPeerVersions(ctx, "node2")
+	require.NoError(t, err)
+	assert.True(t, requested)
+
+	_, err = client.CheckPeerVersions(ctx, "node1")
+	assert.Regexp(t, "PD012807", err)
+
+	mismatches, err := client.ListVersionMismatches(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, mismatches)
+}
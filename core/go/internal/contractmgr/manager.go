@@ -0,0 +1,349 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package contractmgr
+
+import (
+	"context"
+	"io/fs"
+	"sort"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/internal/components"
+	"github.com/kaleido-io/paladin/core/internal/msgs"
+	"github.com/kaleido-io/paladin/core/pkg/persistence"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/toolkit/pkg/rpcserver"
+	"gorm.io/gorm/clause"
+)
+
+type persistedBaseContract struct {
+	Name        string                                   `gorm:"column:name;primaryKey"`
+	Created     pldtypes.Timestamp                       `gorm:"column:created"`
+	Updated     pldtypes.Timestamp                       `gorm:"column:updated"`
+	Version     string                                   `gorm:"column:version"`
+	Origin      pldtypes.Enum[pldapi.BaseContractOrigin] `gorm:"column:origin"`
+	Address     *pldtypes.EthAddress                     `gorm:"column:address"`
+	Transaction *uuid.UUID                               `gorm:"column:transaction"`
+	Failure     *string                                  `gorm:"column:failure"`
+}
+
+func (persistedBaseContract) TableName() string {
+	return "base_contracts"
+}
+
+type contractManager struct {
+	bgCtx context.Context
+
+	conf             *pldconf.ContractManagerConfig
+	p                persistence.Persistence
+	txManager        components.TXManager
+	transportManager components.TransportManager
+	rpcModule        *rpcserver.RPCModule
+
+	buildFS fs.FS
+	builds  map[string]*baseContractBuild
+
+	mismatchLock sync.Mutex
+	mismatches   map[string]map[string]*pldapi.BaseContractMismatch // by node, then by contract name
+}
+
+func NewContractManager(bgCtx context.Context, conf *pldconf.ContractManagerConfig) components.ContractManager {
+	return &contractManager{
+		bgCtx:      log.WithLogField(bgCtx, "role", "contract-manager"),
+		conf:       conf,
+		buildFS:    embeddedBuilds,
+		mismatches: make(map[string]map[string]*pldapi.BaseContractMismatch),
+	}
+}
+
+func (cm *contractManager) PreInit(c components.PreInitComponents) (_ *components.ManagerInitResult, err error) {
+	cm.p = c.Persistence()
+	if cm.builds, err = loadEmbeddedBuilds(cm.bgCtx, cm.buildFS); err != nil {
+		return nil, err
+	}
+	cm.initRPC()
+	return &components.ManagerInitResult{
+		RPCModules: []*rpcserver.RPCModule{cm.rpcModule},
+	}, nil
+}
+
+func (cm *contractManager) PostInit(c components.AllComponents) error {
+	cm.txManager = c.TxManager()
+	cm.transportManager = c.TransportManager()
+	return nil
+}
+
+// On startup the configured bindings are recorded, any contracts configured for automatic deployment
+// that have not yet been deployed are deployed, and a warning is logged for each contract where the
+// build embedded in this release differs from the version in use.
+func (cm *contractManager) Start() error {
+	ctx := cm.bgCtx
+	err := cm.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
+		for _, name := range cm.configuredNames() {
+			if err := cm.bindConfigured(ctx, dbTX, name, cm.conf.Contracts[name]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	for _, name := range cm.configuredNames() {
+		if err != nil {
+			break
+		}
+		bc := cm.conf.Contracts[name]
+		if bc.Address == nil && confutil.Bool(bc.AutoDeploy, false) {
+			err = cm.autoDeploy(ctx, name)
+		}
+	}
+	if err != nil {
+		return err
+	}
+	contracts, err := cm.ListBaseContracts(ctx, cm.p.NOTX())
+	if err != nil {
+		return err
+	}
+	for _, c := range contracts {
+		if c.UpgradeAvailable {
+			log.L(ctx).Warnf("Base contract %s is at version %s, but this release embeds version %s", c.Name, c.Version, c.EmbeddedVersion)
+		}
+	}
+	return nil
+}
+
+func (cm *contractManager) Stop() {}
+
+func (cm *contractManager) configuredNames() []string {
+	names := make([]string, 0, len(cm.conf.Contracts))
+	for name, bc := range cm.conf.Contracts {
+		if bc != nil {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (cm *contractManager) bindConfigured(ctx context.Context, dbTX persistence.DBTX, name string, bc *pldconf.BaseContractConfig) error {
+	if bc.Address == nil {
+		return nil
+	}
+	addr, err := pldtypes.ParseEthAddress(*bc.Address)
+	if err != nil {
+		return i18n.WrapError(ctx, err, msgs.MsgContractMgrInvalidAddress, *bc.Address, name)
+	}
+	var version string
+	switch {
+	case bc.Version != nil:
+		version = *bc.Version
+	case cm.builds[name] != nil:
+		version = cm.builds[name].version
+	default:
+		return i18n.NewError(ctx, msgs.MsgContractMgrVersionRequired, name)
+	}
+
+	existing, err := cm.getRecord(ctx, dbTX, name)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		if existing.Address != nil && *existing.Address == *addr && existing.Version == version {
+			return nil
+		}
+		log.L(ctx).Warnf("Rebinding base contract %s from %s (version %s) to %s (version %s)", name, existing.Address, existing.Version, addr, version)
+	}
+	now := pldtypes.TimestampNow()
+	return cm.upsertRecord(ctx, dbTX, &persistedBaseContract{
+		Name:    name,
+		Created: now,
+		Updated: now,
+		Version: version,
+		Origin:  pldapi.BaseContractOriginBound.Enum(),
+		Address: addr,
+	})
+}
+
+// Replaces everything but the creation time of any existing record
+func (cm *contractManager) upsertRecord(ctx context.Context, dbTX persistence.DBTX, r *persistedBaseContract) error {
+	return dbTX.DB().
+		WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "name"}},
+			DoUpdates: clause.AssignmentColumns([]string{"updated", "version", "origin", "address", "transaction", "failure"}),
+		}).
+		Create(r).
+		Error
+}
+
+func (cm *contractManager) autoDeploy(ctx context.Context, name string) error {
+	existing, err := cm.getRecord(ctx, cm.p.NOTX(), name)
+	if err != nil || (existing != nil && existing.Failure == nil) {
+		return err
+	}
+	_, err = cm.DeployBaseContract(ctx, name)
+	return err
+}
+
+func (cm *contractManager) getRecord(ctx context.Context, dbTX persistence.DBTX, name string) (*persistedBaseContract, error) {
+	var records []*persistedBaseContract
+	err := dbTX.DB().
+		WithContext(ctx).
+		Where("name = ?", name).
+		Limit(1).
+		Find(&records).
+		Error
+	if err != nil || len(records) == 0 {
+		return nil, err
+	}
+	return records[0], nil
+}
+
+func (cm *contractManager) DeployBaseContract(ctx context.Context, name string) (*pldapi.BaseContract, error) {
+	build := cm.builds[name]
+	if build == nil {
+		return nil, i18n.NewError(ctx, msgs.MsgContractMgrNoEmbeddedBuild, name)
+	}
+	if cm.conf.From == "" {
+		return nil, i18n.NewError(ctx, msgs.MsgContractMgrNoFrom)
+	}
+
+	var record *persistedBaseContract
+	err := cm.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
+		existing, err := cm.getRecord(ctx, dbTX, name)
+		if err != nil {
+			return err
+		}
+		// A failed deployment can be retried, but anything else must be removed from the
+		// configuration and the database before being replaced
+		if existing != nil && existing.Failure == nil {
+			return i18n.NewError(ctx, msgs.MsgContractMgrAlreadyDeployed, name, existing.Origin, existing.Version)
+		}
+		txIDs, err := cm.txManager.SendTransactions(ctx, dbTX, &pldapi.TransactionInput{
+			TransactionBase: pldapi.TransactionBase{
+				IdempotencyKey: "base_contract_" + uuid.NewString(),
+				Type:           pldapi.TransactionTypePublic.Enum(),
+				From:           cm.conf.From,
+				Data:           pldtypes.RawJSON(`{}`),
+			},
+			ABI:      build.build.ABI,
+			Bytecode: build.build.Bytecode,
+		})
+		if err != nil {
+			return err
+		}
+		now := pldtypes.TimestampNow()
+		created := now
+		if existing != nil {
+			created = existing.Created
+		}
+		record = &persistedBaseContract{
+			Name:        name,
+			Created:     created,
+			Updated:     now,
+			Version:     build.version,
+			Origin:      pldapi.BaseContractOriginDeployed.Enum(),
+			Transaction: &txIDs[0],
+		}
+		return cm.upsertRecord(ctx, dbTX, record)
+	})
+	if err != nil {
+		return nil, err
+	}
+	log.L(ctx).Infof("Deploying base contract %s version %s (tx=%s)", name, record.Version, record.Transaction)
+	return cm.mapToAPI(record), nil
+}
+
+func (cm *contractManager) ListBaseContracts(ctx context.Context, dbTX persistence.DBTX) ([]*pldapi.BaseContract, error) {
+	var records []*persistedBaseContract
+	err := dbTX.DB().
+		WithContext(ctx).
+		Order("name").
+		Find(&records).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	contracts := make([]*pldapi.BaseContract, len(records))
+	for i, r := range records {
+		if err := cm.checkDeployment(ctx, dbTX, r); err != nil {
+			return nil, err
+		}
+		contracts[i] = cm.mapToAPI(r)
+	}
+	return contracts, nil
+}
+
+func (cm *contractManager) GetBaseContract(ctx context.Context, dbTX persistence.DBTX, name string) (*pldapi.BaseContract, error) {
+	r, err := cm.getRecord(ctx, dbTX, name)
+	if err != nil || r == nil {
+		return nil, err
+	}
+	if err := cm.checkDeployment(ctx, dbTX, r); err != nil {
+		return nil, err
+	}
+	return cm.mapToAPI(r), nil
+}
+
+// The outcome of a pending deployment is filled in from the receipt the first time the record is
+// read after the receipt is available
+func (cm *contractManager) checkDeployment(ctx context.Context, dbTX persistence.DBTX, r *persistedBaseContract) error {
+	if r.Address != nil || r.Transaction == nil || r.Failure != nil {
+		return nil
+	}
+	receipt, err := cm.txManager.GetTransactionReceiptByID(ctx, *r.Transaction)
+	if err != nil || receipt == nil {
+		return err
+	}
+	updates := map[string]any{"updated": pldtypes.TimestampNow()}
+	if receipt.Success {
+		r.Address = receipt.ContractAddress
+		updates["address"] = r.Address
+		log.L(ctx).Infof("Base contract %s version %s deployed at %s", r.Name, r.Version, r.Address)
+	} else {
+		r.Failure = &receipt.FailureMessage
+		updates["failure"] = r.Failure
+		log.L(ctx).Errorf("Deployment of base contract %s failed: %s", r.Name, receipt.FailureMessage)
+	}
+	return dbTX.DB().
+		WithContext(ctx).
+		Model(&persistedBaseContract{}).
+		Where("name = ?", r.Name).
+		Updates(updates).
+		Error
+}
+
+func (cm *contractManager) mapToAPI(r *persistedBaseContract) *pldapi.BaseContract {
+	bc := &pldapi.BaseContract{
+		Name:        r.Name,
+		Created:     r.Created,
+		Updated:     r.Updated,
+		Version:     r.Version,
+		Origin:      r.Origin,
+		Address:     r.Address,
+		Transaction: r.Transaction,
+		Failure:     r.Failure,
+	}
+	if build := cm.builds[r.Name]; build != nil {
+		bc.EmbeddedVersion = build.version
+		bc.UpgradeAvailable = build.version != r.Version
+	}
+	return bc
+}
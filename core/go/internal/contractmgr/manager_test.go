@@ -0,0 +1,295 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package contractmgr
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"testing/fstest"
+
+	"github.com/google/uuid"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/mocks/componentmocks"
+	"github.com/kaleido-io/paladin/core/pkg/persistence"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type mockComponents struct {
+	txManager        *componentmocks.TXManager
+	transportManager *componentmocks.TransportManager
+}
+
+func testBuildFS() fstest.MapFS {
+	return fstest.MapFS{
+		"abis/Contract1.json": &fstest.MapFile{Data: []byte(`{"abi":[],"bytecode":"0x1111"}`)},
+		"abis/Contract2.json": &fstest.MapFile{Data: []byte(`{"abi":[],"bytecode":"0x2222"}`)},
+	}
+}
+
+func newTestContractManager(t *testing.T, conf *pldconf.ContractManagerConfig) (*contractManager, *mockComponents) {
+	p, pDone, err := persistence.NewUnitTestPersistence(context.Background(), "contractmgr")
+	require.NoError(t, err)
+	t.Cleanup(pDone)
+
+	mc := &mockComponents{
+		txManager:        componentmocks.NewTXManager(t),
+		transportManager: componentmocks.NewTransportManager(t),
+	}
+	pic := componentmocks.NewPreInitComponents(t)
+	pic.On("Persistence").Return(p)
+	ac := componentmocks.NewAllComponents(t)
+	ac.On("TxManager").Return(mc.txManager)
+	ac.On("TransportManager").Return(mc.transportManager)
+
+	cm := NewContractManager(context.Background(), conf).(*contractManager)
+	cm.buildFS = testBuildFS()
+	ir, err := cm.PreInit(pic)
+	require.NoError(t, err)
+	assert.NotEmpty(t, ir.RPCModules)
+	err = cm.PostInit(ac)
+	require.NoError(t, err)
+	t.Cleanup(cm.Stop)
+	return cm, mc
+}
+
+func TestLoadEmbeddedBuilds(t *testing.T) {
+	builds, err := loadEmbeddedBuilds(context.Background(), embeddedBuilds)
+	require.NoError(t, err)
+	assert.NotEmpty(t, builds)
+	for name, b := range builds {
+		assert.Equal(t, buildVersion(b.build.Bytecode), b.version, name)
+	}
+}
+
+func TestLoadEmbeddedBuildsBadJSON(t *testing.T) {
+	_, err := loadEmbeddedBuilds(context.Background(), fstest.MapFS{
+		"abis/Bad.json": &fstest.MapFile{Data: []byte(`{!`)},
+	})
+	assert.Error(t, err)
+}
+
+func TestPreInitBadBuilds(t *testing.T) {
+	cm := NewContractManager(context.Background(), &pldconf.ContractManagerConfig{}).(*contractManager)
+	cm.buildFS = fstest.MapFS{
+		"abis/Bad.json": &fstest.MapFile{Data: []byte(`{!`)},
+	}
+	pic := componentmocks.NewPreInitComponents(t)
+	pic.On("Persistence").Return(nil)
+	_, err := cm.PreInit(pic)
+	assert.Error(t, err)
+}
+
+func TestStartBindConfigured(t *testing.T) {
+	ctx := context.Background()
+	addr1 := pldtypes.RandAddress()
+	addr2 := pldtypes.RandAddress()
+	cm, _ := newTestContractManager(t, &pldconf.ContractManagerConfig{
+		Contracts: map[string]*pldconf.BaseContractConfig{
+			"Contract1": {Address: confutil.P(addr1.String())},
+			"Other":     {Address: confutil.P(addr2.String()), Version: confutil.P("v1")},
+			"Unset":     nil,
+		},
+	})
+
+	err := cm.Start()
+	require.NoError(t, err)
+
+	contracts, err := cm.ListBaseContracts(ctx, cm.p.NOTX())
+	require.NoError(t, err)
+	require.Len(t, contracts, 2)
+	assert.Equal(t, "Contract1", contracts[0].Name)
+	assert.Equal(t, addr1, contracts[0].Address)
+	assert.Equal(t, pldapi.BaseContractOriginBound, contracts[0].Origin.V())
+	assert.Equal(t, buildVersion(pldtypes.MustParseHexBytes("0x1111")), contracts[0].Version)
+	assert.Equal(t, contracts[0].Version, contracts[0].EmbeddedVersion)
+	assert.False(t, contracts[0].UpgradeAvailable)
+	assert.Equal(t, "Other", contracts[1].Name)
+	assert.Equal(t, "v1", contracts[1].Version)
+	assert.Empty(t, contracts[1].EmbeddedVersion)
+
+	// Restarting with the same config is a no-op
+	err = cm.Start()
+	require.NoError(t, err)
+
+	// Rebinding to a new address and an older version shows an upgrade is available
+	addr3 := pldtypes.RandAddress()
+	cm.conf.Contracts["Contract1"] = &pldconf.BaseContractConfig{Address: confutil.P(addr3.String()), Version: confutil.P("v0")}
+	err = cm.Start()
+	require.NoError(t, err)
+
+	bc, err := cm.GetBaseContract(ctx, cm.p.NOTX(), "Contract1")
+	require.NoError(t, err)
+	assert.Equal(t, addr3, bc.Address)
+	assert.Equal(t, "v0", bc.Version)
+	assert.True(t, bc.UpgradeAvailable)
+	assert.Equal(t, contracts[0].Created, bc.Created)
+
+	bc, err = cm.GetBaseContract(ctx, cm.p.NOTX(), "Unknown")
+	require.NoError(t, err)
+	assert.Nil(t, bc)
+}
+
+func TestStartBindInvalidAddress(t *testing.T) {
+	cm, _ := newTestContractManager(t, &pldconf.ContractManagerConfig{
+		Contracts: map[string]*pldconf.BaseContractConfig{
+			"Contract1": {Address: confutil.P("wrong")},
+		},
+	})
+	err := cm.Start()
+	assert.Regexp(t, "PD012803.*wrong.*Contract1", err)
+}
+
+func TestStartBindVersionRequired(t *testing.T) {
+	cm, _ := newTestContractManager(t, &pldconf.ContractManagerConfig{
+		Contracts: map[string]*pldconf.BaseContractConfig{
+			"Other": {Address: confutil.P(pldtypes.RandAddress().String())},
+		},
+	})
+	err := cm.Start()
+	assert.Regexp(t, "PD012804.*Other", err)
+}
+
+func TestStartAutoDeploy(t *testing.T) {
+	ctx := context.Background()
+	cm, mc := newTestContractManager(t, &pldconf.ContractManagerConfig{
+		From: "deployer",
+		Contracts: map[string]*pldconf.BaseContractConfig{
+			"Contract1": {AutoDeploy: confutil.P(true)},
+			"Contract2": {},
+		},
+	})
+
+	txID := uuid.New()
+	mc.txManager.On("SendTransactions", mock.Anything, mock.Anything, mock.MatchedBy(func(tx *pldapi.TransactionInput) bool {
+		return tx.From == "deployer" &&
+			tx.Type.V() == pldapi.TransactionTypePublic &&
+			tx.Bytecode.String() == "0x1111"
+	})).Return([]uuid.UUID{txID}, nil).Once()
+	mc.txManager.On("GetTransactionReceiptByID", mock.Anything, txID).Return(nil, nil).Once()
+
+	err := cm.Start()
+	require.NoError(t, err)
+
+	contractAddr := pldtypes.RandAddress()
+	mc.txManager.On("GetTransactionReceiptByID", mock.Anything, txID).Return(&pldapi.TransactionReceipt{
+		ID: txID,
+		TransactionReceiptData: pldapi.TransactionReceiptData{
+			Success:         true,
+			ContractAddress: contractAddr,
+		},
+	}, nil).Once()
+
+	bc, err := cm.GetBaseContract(ctx, cm.p.NOTX(), "Contract1")
+	require.NoError(t, err)
+	assert.Equal(t, pldapi.BaseContractOriginDeployed, bc.Origin.V())
+	assert.Equal(t, txID, *bc.Transaction)
+	assert.Equal(t, contractAddr, bc.Address)
+
+	// The address is now recorded, so the receipt is not checked again - and no deploy happens on restart
+	err = cm.Start()
+	require.NoError(t, err)
+}
+
+func TestDeployFailedReceiptRetry(t *testing.T) {
+	ctx := context.Background()
+	cm, mc := newTestContractManager(t, &pldconf.ContractManagerConfig{From: "deployer"})
+
+	txID1 := uuid.New()
+	mc.txManager.On("SendTransactions", mock.Anything, mock.Anything, mock.Anything).Return([]uuid.UUID{txID1}, nil).Once()
+	bc, err := cm.DeployBaseContract(ctx, "Contract2")
+	require.NoError(t, err)
+	assert.Equal(t, txID1, *bc.Transaction)
+	assert.Nil(t, bc.Address)
+
+	_, err = cm.DeployBaseContract(ctx, "Contract2")
+	assert.Regexp(t, "PD012802.*Contract2", err)
+
+	mc.txManager.On("GetTransactionReceiptByID", mock.Anything, txID1).Return(&pldapi.TransactionReceipt{
+		ID: txID1,
+		TransactionReceiptData: pldapi.TransactionReceiptData{
+			FailureMessage: "pop",
+		},
+	}, nil).Once()
+	contracts, err := cm.ListBaseContracts(ctx, cm.p.NOTX())
+	require.NoError(t, err)
+	require.Len(t, contracts, 1)
+	assert.Equal(t, "pop", *contracts[0].Failure)
+
+	// A failed deployment can be retried
+	txID2 := uuid.New()
+	mc.txManager.On("SendTransactions", mock.Anything, mock.Anything, mock.Anything).Return([]uuid.UUID{txID2}, nil).Once()
+	bc, err = cm.DeployBaseContract(ctx, "Contract2")
+	require.NoError(t, err)
+	assert.Equal(t, txID2, *bc.Transaction)
+	assert.Nil(t, bc.Failure)
+	assert.Equal(t, contracts[0].Created, bc.Created)
+}
+
+func TestDeployErrors(t *testing.T) {
+	ctx := context.Background()
+	cm, mc := newTestContractManager(t, &pldconf.ContractManagerConfig{})
+
+	_, err := cm.DeployBaseContract(ctx, "Unknown")
+	assert.Regexp(t, "PD012800.*Unknown", err)
+
+	_, err = cm.DeployBaseContract(ctx, "Contract1")
+	assert.Regexp(t, "PD012801", err)
+
+	cm.conf.From = "deployer"
+	mc.txManager.On("SendTransactions", mock.Anything, mock.Anything, mock.Anything).Return(nil, fmt.Errorf("pop"))
+	_, err = cm.DeployBaseContract(ctx, "Contract1")
+	assert.Regexp(t, "pop", err)
+
+	contracts, err := cm.ListBaseContracts(ctx, cm.p.NOTX())
+	require.NoError(t, err)
+	assert.Empty(t, contracts)
+}
+
+func TestAutoDeployFail(t *testing.T) {
+	cm, mc := newTestContractManager(t, &pldconf.ContractManagerConfig{
+		From: "deployer",
+		Contracts: map[string]*pldconf.BaseContractConfig{
+			"Contract1": {AutoDeploy: confutil.P(true)},
+		},
+	})
+	mc.txManager.On("SendTransactions", mock.Anything, mock.Anything, mock.Anything).Return(nil, fmt.Errorf("pop"))
+	err := cm.Start()
+	assert.Regexp(t, "pop", err)
+}
+
+func TestCheckDeploymentReceiptError(t *testing.T) {
+	ctx := context.Background()
+	cm, mc := newTestContractManager(t, &pldconf.ContractManagerConfig{From: "deployer"})
+
+	txID := uuid.New()
+	mc.txManager.On("SendTransactions", mock.Anything, mock.Anything, mock.Anything).Return([]uuid.UUID{txID}, nil).Once()
+	_, err := cm.DeployBaseContract(ctx, "Contract1")
+	require.NoError(t, err)
+
+	mc.txManager.On("GetTransactionReceiptByID", mock.Anything, txID).Return(nil, fmt.Errorf("pop"))
+	_, err = cm.ListBaseContracts(ctx, cm.p.NOTX())
+	assert.Regexp(t, "pop", err)
+	_, err = cm.GetBaseContract(ctx, cm.p.NOTX(), "Contract1")
+	assert.Regexp(t, "pop", err)
+	err = cm.Start()
+	assert.Regexp(t, "pop", err)
+}
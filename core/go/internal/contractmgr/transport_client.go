@@ -0,0 +1,167 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package contractmgr
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/core/internal/components"
+	"github.com/kaleido-io/paladin/core/internal/msgs"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
+)
+
+const (
+	MessageTypeVersionsRequest  = "BaseContractVersionsRequest"
+	MessageTypeVersionsResponse = "BaseContractVersionsResponse"
+)
+
+type baseContractVersion struct {
+	Name    string               `json:"name"`
+	Version string               `json:"version"`
+	Address *pldtypes.EthAddress `json:"address,omitempty"`
+}
+
+func (cm *contractManager) HandlePaladinMsg(ctx context.Context, message *components.ReceivedMessage) {
+	switch message.MessageType {
+	case MessageTypeVersionsRequest:
+		go cm.handleVersions(ctx, message, true)
+	case MessageTypeVersionsResponse:
+		go cm.handleVersions(ctx, message, false)
+	default:
+		log.L(ctx).Errorf("Unknown message type: %s", message.MessageType)
+	}
+}
+
+func (cm *contractManager) CheckPeerVersions(ctx context.Context, node string) error {
+	if node == cm.transportManager.LocalNodeName() {
+		return i18n.NewError(ctx, msgs.MsgContractMgrPeerCheckLocalNode)
+	}
+	return cm.sendVersions(ctx, node, MessageTypeVersionsRequest, nil)
+}
+
+func (cm *contractManager) localVersions(ctx context.Context) ([]*baseContractVersion, error) {
+	contracts, err := cm.ListBaseContracts(ctx, cm.p.NOTX())
+	if err != nil {
+		return nil, err
+	}
+	versions := make([]*baseContractVersion, len(contracts))
+	for i, c := range contracts {
+		versions[i] = &baseContractVersion{Name: c.Name, Version: c.Version, Address: c.Address}
+	}
+	return versions, nil
+}
+
+func (cm *contractManager) sendVersions(ctx context.Context, node, messageType string, correlationID *uuid.UUID) error {
+	versions, err := cm.localVersions(ctx)
+	if err != nil {
+		return err
+	}
+	return cm.transportManager.Send(ctx, &components.FireAndForgetMessageSend{
+		Node:          node,
+		Component:     prototk.PaladinMsg_CONTRACT_MANAGER,
+		CorrelationID: correlationID,
+		MessageType:   messageType,
+		Payload:       pldtypes.JSONString(versions),
+	})
+}
+
+// Both the request and the response carry the versions of the sending node, so the versions are
+// compared on both sides - and the receiver of a request replies with its own versions.
+func (cm *contractManager) handleVersions(ctx context.Context, message *components.ReceivedMessage, reply bool) {
+	var peerVersions []*baseContractVersion
+	if err := json.Unmarshal(message.Payload, &peerVersions); err != nil {
+		log.L(ctx).Errorf("%s", i18n.WrapError(ctx, err, msgs.MsgContractMgrInvalidPeerMessage, message.FromNode))
+		return
+	}
+	localVersions, err := cm.localVersions(ctx)
+	if err != nil {
+		log.L(ctx).Errorf("Failed to read base contract versions to compare with node %s: %s", message.FromNode, err)
+		return
+	}
+	cm.compareVersions(ctx, message.FromNode, localVersions, peerVersions)
+	if reply {
+		if err := cm.sendVersions(ctx, message.FromNode, MessageTypeVersionsResponse, &message.MessageID); err != nil {
+			log.L(ctx).Errorf("Failed to reply to node %s with base contract versions: %s", message.FromNode, err)
+		}
+	}
+}
+
+// Only contracts recorded on both nodes are compared. The addresses are compared as well as
+// the versions, as nodes bound to different deployments of the same contract do not interoperate.
+func (cm *contractManager) compareVersions(ctx context.Context, node string, localVersions, peerVersions []*baseContractVersion) {
+	local := make(map[string]*baseContractVersion, len(localVersions))
+	for _, lv := range localVersions {
+		local[lv.Name] = lv
+	}
+
+	cm.mismatchLock.Lock()
+	defer cm.mismatchLock.Unlock()
+	nodeMismatches := cm.mismatches[node]
+	if nodeMismatches == nil {
+		nodeMismatches = make(map[string]*pldapi.BaseContractMismatch)
+		cm.mismatches[node] = nodeMismatches
+	}
+	for _, pv := range peerVersions {
+		lv := local[pv.Name]
+		if lv == nil {
+			continue
+		}
+		addressMismatch := lv.Address != nil && pv.Address != nil && *lv.Address != *pv.Address
+		if lv.Version == pv.Version && !addressMismatch {
+			delete(nodeMismatches, pv.Name)
+			continue
+		}
+		log.L(ctx).Warnf("Base contract %s differs on node %s: local version=%s address=%s, peer version=%s address=%s",
+			pv.Name, node, lv.Version, lv.Address, pv.Version, pv.Address)
+		nodeMismatches[pv.Name] = &pldapi.BaseContractMismatch{
+			Node:         node,
+			Name:         pv.Name,
+			LocalVersion: lv.Version,
+			PeerVersion:  pv.Version,
+			LocalAddress: lv.Address,
+			PeerAddress:  pv.Address,
+			Detected:     pldtypes.TimestampNow(),
+		}
+	}
+	if len(nodeMismatches) == 0 {
+		delete(cm.mismatches, node)
+	}
+}
+
+func (cm *contractManager) ListVersionMismatches(ctx context.Context) []*pldapi.BaseContractMismatch {
+	cm.mismatchLock.Lock()
+	defer cm.mismatchLock.Unlock()
+	mismatches := []*pldapi.BaseContractMismatch{}
+	for _, nodeMismatches := range cm.mismatches {
+		for _, m := range nodeMismatches {
+			mismatches = append(mismatches, m)
+		}
+	}
+	sort.Slice(mismatches, func(i, j int) bool {
+		if mismatches[i].Node != mismatches[j].Node {
+			return mismatches[i].Node < mismatches[j].Node
+		}
+		return mismatches[i].Name < mismatches[j].Name
+	})
+	return mismatches
+}
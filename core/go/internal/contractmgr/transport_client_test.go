@@ -0,0 +1,203 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package contractmgr
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/internal/components"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newBoundContractManager(t *testing.T, addr *pldtypes.EthAddress) (*contractManager, *mockComponents) {
+	cm, mc := newTestContractManager(t, &pldconf.ContractManagerConfig{
+		Contracts: map[string]*pldconf.BaseContractConfig{
+			"Contract1": {Address: confutil.P(addr.String())},
+		},
+	})
+	err := cm.Start()
+	require.NoError(t, err)
+	return cm, mc
+}
+
+func TestCheckPeerVersions(t *testing.T) {
+	ctx := context.Background()
+	addr := pldtypes.RandAddress()
+	cm, mc := newBoundContractManager(t, addr)
+
+	mc.transportManager.On("LocalNodeName").Return("node1")
+	mc.transportManager.On("Send", mock.Anything, mock.MatchedBy(func(send *components.FireAndForgetMessageSend) bool {
+		var versions []*baseContractVersion
+		err := json.Unmarshal(send.Payload, &versions)
+		return err == nil &&
+			send.Node == "node2" &&
+			send.Component == prototk.PaladinMsg_CONTRACT_MANAGER &&
+			send.MessageType == MessageTypeVersionsRequest &&
+			send.CorrelationID == nil &&
+			len(versions) == 1 && versions[0].Name == "Contract1" && *versions[0].Address == *addr
+	})).Return(nil)
+
+	err := cm.CheckPeerVersions(ctx, "node2")
+	require.NoError(t, err)
+
+	err = cm.CheckPeerVersions(ctx, "node1")
+	assert.Regexp(t, "PD012807", err)
+}
+
+func TestHandleVersionsRequestMismatch(t *testing.T) {
+	ctx := context.Background()
+	addr := pldtypes.RandAddress()
+	cm, mc := newBoundContractManager(t, addr)
+	local, err := cm.GetBaseContract(ctx, cm.p.NOTX(), "Contract1")
+	require.NoError(t, err)
+
+	requestID := uuid.New()
+	replied := make(chan struct{})
+	mc.transportManager.On("Send", mock.Anything, mock.MatchedBy(func(send *components.FireAndForgetMessageSend) bool {
+		return send.Node == "node2" &&
+			send.MessageType == MessageTypeVersionsResponse &&
+			*send.CorrelationID == requestID
+	})).Return(nil).Run(func(args mock.Arguments) { close(replied) })
+
+	otherAddr := pldtypes.RandAddress()
+	cm.handleVersions(ctx, &components.ReceivedMessage{
+		FromNode:    "node2",
+		MessageID:   requestID,
+		MessageType: MessageTypeVersionsRequest,
+		Payload: pldtypes.JSONString([]*baseContractVersion{
+			{Name: "Contract1", Version: local.Version, Address: otherAddr},
+			{Name: "Unknown", Version: "v1"},
+		}),
+	}, true)
+	<-replied
+
+	mismatches := cm.ListVersionMismatches(ctx)
+	require.Len(t, mismatches, 1)
+	assert.Equal(t, "node2", mismatches[0].Node)
+	assert.Equal(t, "Contract1", mismatches[0].Name)
+	assert.Equal(t, addr, mismatches[0].LocalAddress)
+	assert.Equal(t, otherAddr, mismatches[0].PeerAddress)
+
+	// A matching response clears the mismatch
+	cm.handleVersions(ctx, &components.ReceivedMessage{
+		FromNode:    "node2",
+		MessageType: MessageTypeVersionsResponse,
+		Payload: pldtypes.JSONString([]*baseContractVersion{
+			{Name: "Contract1", Version: local.Version, Address: addr},
+		}),
+	}, false)
+	assert.Empty(t, cm.ListVersionMismatches(ctx))
+}
+
+func TestListVersionMismatchesSorted(t *testing.T) {
+	ctx := context.Background()
+	cm, _ := newTestContractManager(t, &pldconf.ContractManagerConfig{})
+
+	local := []*baseContractVersion{{Name: "a", Version: "v1"}, {Name: "b", Version: "v1"}}
+	peer := []*baseContractVersion{{Name: "a", Version: "v2"}, {Name: "b", Version: "v2"}}
+	cm.compareVersions(ctx, "node3", local, peer)
+	cm.compareVersions(ctx, "node2", local, peer)
+
+	mismatches := cm.ListVersionMismatches(ctx)
+	require.Len(t, mismatches, 4)
+	assert.Equal(t, []string{"node2/a", "node2/b", "node3/a", "node3/b"}, []string{
+		mismatches[0].Node + "/" + mismatches[0].Name,
+		mismatches[1].Node + "/" + mismatches[1].Name,
+		mismatches[2].Node + "/" + mismatches[2].Name,
+		mismatches[3].Node + "/" + mismatches[3].Name,
+	})
+	assert.Equal(t, "v2", mismatches[0].PeerVersion)
+}
+
+func TestHandleVersionsBadPayload(t *testing.T) {
+	ctx := context.Background()
+	cm, _ := newTestContractManager(t, &pldconf.ContractManagerConfig{})
+
+	// No reply is sent
+	cm.handleVersions(ctx, &components.ReceivedMessage{
+		FromNode:    "node2",
+		MessageType: MessageTypeVersionsRequest,
+		Payload:     []byte(`{!`),
+	}, true)
+	assert.Empty(t, cm.ListVersionMismatches(ctx))
+}
+
+func TestHandleVersionsReplyFail(t *testing.T) {
+	ctx := context.Background()
+	cm, mc := newTestContractManager(t, &pldconf.ContractManagerConfig{})
+
+	mc.transportManager.On("Send", mock.Anything, mock.Anything).Return(fmt.Errorf("pop"))
+	cm.handleVersions(ctx, &components.ReceivedMessage{
+		FromNode:    "node2",
+		MessageType: MessageTypeVersionsRequest,
+		Payload:     []byte(`[]`),
+	}, true)
+}
+
+func TestHandleVersionsLocalReadFail(t *testing.T) {
+	ctx := context.Background()
+	cm, mc := newTestContractManager(t, &pldconf.ContractManagerConfig{From: "deployer"})
+
+	txID := uuid.New()
+	mc.txManager.On("SendTransactions", mock.Anything, mock.Anything, mock.Anything).Return([]uuid.UUID{txID}, nil)
+	_, err := cm.DeployBaseContract(ctx, "Contract1")
+	require.NoError(t, err)
+	mc.txManager.On("GetTransactionReceiptByID", mock.Anything, txID).Return(nil, fmt.Errorf("pop"))
+
+	cm.handleVersions(ctx, &components.ReceivedMessage{
+		FromNode:    "node2",
+		MessageType: MessageTypeVersionsRequest,
+		Payload:     []byte(`[]`),
+	}, true)
+
+	mc.transportManager.On("LocalNodeName").Return("node1")
+	err = cm.CheckPeerVersions(ctx, "node2")
+	assert.Regexp(t, "pop", err)
+}
+
+func TestHandlePaladinMsgDispatch(t *testing.T) {
+	ctx := context.Background()
+	cm, mc := newTestContractManager(t, &pldconf.ContractManagerConfig{})
+
+	replied := make(chan struct{})
+	mc.transportManager.On("Send", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) { close(replied) })
+	cm.HandlePaladinMsg(ctx, &components.ReceivedMessage{
+		FromNode:    "node2",
+		MessageType: MessageTypeVersionsRequest,
+		Payload:     []byte(`[]`),
+	})
+	<-replied
+
+	cm.HandlePaladinMsg(ctx, &components.ReceivedMessage{
+		FromNode:    "node2",
+		MessageType: MessageTypeVersionsResponse,
+		Payload:     []byte(`[]`),
+	})
+	cm.HandlePaladinMsg(ctx, &components.ReceivedMessage{
+		FromNode:    "node2",
+		MessageType: "unknown",
+	})
+}
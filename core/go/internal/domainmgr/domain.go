@@ -60,6 +60,8 @@ type domain struct {
 
 	stateLock          sync.Mutex
 	initialized        atomic.Bool
+	pauseLock          sync.Mutex
+	paused             atomic.Bool
 	initRetry          *retry.Retry
 	config             *prototk.DomainConfig
 	schemasBySignature map[string]components.Schema
@@ -103,7 +105,7 @@ func (dm *domainManager) newDomain(name string, conf *pldconf.DomainConfig, toDo
 		d.defaultGasLimit = pldtypes.HexUint64(*conf.DefaultGasLimit)
 	}
 	log.L(dm.bgCtx).Debugf("Domain %s configured. Config: %s", name, pldtypes.JSONString(conf.Config))
-	d.ctx, d.cancelCtx = context.WithCancel(log.WithLogField(dm.bgCtx, "domain", d.name))
+	d.ctx, d.cancelCtx = context.WithCancel(log.WithLogField(dm.bgCtx, log.FieldDomain, d.name))
 	return d
 }
 
@@ -179,6 +181,8 @@ func (d *domain) processDomainConfig(dbTX persistence.DBTX, confRes *prototk.Con
 	if err != nil {
 		return nil, err
 	}
+	// Pausing stops the event stream, which stays stopped over a restart - so the domain stays paused too
+	d.paused.Store(d.eventStream != nil && d.eventStream.Started != nil && !*d.eventStream.Started)
 
 	return &prototk.InitDomainRequest{
 		AbiStateSchemas: schemasProto,
@@ -277,6 +281,59 @@ func (d *domain) Initialized() bool {
 	return d.initialized.Load()
 }
 
+func (d *domain) checkNotPaused(ctx context.Context) error {
+	if d.paused.Load() {
+		return i18n.NewError(ctx, msgs.MsgDomainPaused, d.name)
+	}
+	return nil
+}
+
+// Pausing rejects new private transactions, and stops the event stream of the domain so that events
+// are held in the block indexer until it is resumed. Transactions already in flight carry on.
+func (d *domain) pause(ctx context.Context) error {
+	if err := d.checkInit(ctx); err != nil {
+		return err
+	}
+	d.pauseLock.Lock()
+	defer d.pauseLock.Unlock()
+
+	d.paused.Store(true)
+	if d.eventStream != nil {
+		if err := d.dm.blockIndexer.StopEventStream(ctx, d.eventStream.ID); err != nil {
+			d.paused.Store(false)
+			return err
+		}
+	}
+	log.L(ctx).Infof("Domain %s paused", d.name)
+	return nil
+}
+
+func (d *domain) resume(ctx context.Context) error {
+	if err := d.checkInit(ctx); err != nil {
+		return err
+	}
+	d.pauseLock.Lock()
+	defer d.pauseLock.Unlock()
+
+	// Start the event stream first, so events are processed before new transactions are accepted
+	if d.eventStream != nil {
+		if err := d.dm.blockIndexer.StartEventStream(ctx, d.eventStream.ID); err != nil {
+			return err
+		}
+	}
+	d.paused.Store(false)
+	log.L(ctx).Infof("Domain %s resumed", d.name)
+	return nil
+}
+
+func (d *domain) apiDomain() *pldapi.Domain {
+	return &pldapi.Domain{
+		Name:            d.name,
+		RegistryAddress: d.registryAddress,
+		Paused:          d.paused.Load(),
+	}
+}
+
 func (d *domain) Name() string {
 	return d.name
 }
@@ -289,10 +346,14 @@ func (d *domain) Configuration() *prototk.DomainConfig {
 	return d.config
 }
 
+// States that have had their data purged are not passed to the domain, as if they were not found
 func toProtoStates(states []*pldapi.State) []*prototk.StoredState {
-	pbStates := make([]*prototk.StoredState, len(states))
-	for i, s := range states {
-		pbStates[i] = &prototk.StoredState{
+	pbStates := make([]*prototk.StoredState, 0, len(states))
+	for _, s := range states {
+		if s.Data.IsNil() {
+			continue
+		}
+		pbState := &prototk.StoredState{
 			Id:        s.ID.String(),
 			SchemaId:  s.Schema.String(),
 			CreatedAt: s.Created.UnixNano(),
@@ -300,11 +361,12 @@ func toProtoStates(states []*pldapi.State) []*prototk.StoredState {
 			Locks:     []*prototk.StateLock{},
 		}
 		for _, l := range s.Locks {
-			pbStates[i].Locks = append(pbStates[i].Locks, &prototk.StateLock{
+			pbState.Locks = append(pbState.Locks, &prototk.StateLock{
 				Type:        mapStateLockType(l.Type.V()),
 				Transaction: l.Transaction.String(),
 			})
 		}
+		pbStates = append(pbStates, pbState)
 	}
 	return pbStates
 }
@@ -571,6 +633,9 @@ func (d *domain) RecoverSigner(ctx context.Context, recoverRequest *prototk.Reco
 }
 
 func (d *domain) InitDeploy(ctx context.Context, tx *components.PrivateContractDeploy) error {
+	if err := d.checkNotPaused(ctx); err != nil {
+		return err
+	}
 	if tx.Inputs == nil {
 		return i18n.NewError(ctx, msgs.MsgDomainTXIncompleteInitDeploy)
 	}
@@ -765,7 +830,13 @@ func (d *domain) BuildDomainReceipt(ctx context.Context, dbTX persistence.DBTX,
 		// We know nothing about this transaction yet
 		return nil, i18n.NewError(ctx, msgs.MsgDomainDomainReceiptNotAvailable, txID)
 	}
-	empty := len(txStates.Spent) == 0 && len(txStates.Read) == 0 && len(txStates.Confirmed) == 0 && len(txStates.Info) == 0
+	// States that have had their data purged are left out, which means the domain does not have everything
+	inputStates, inputsPurged := withoutPurgedStates(txStates.Spent)
+	readStates, readsPurged := withoutPurgedStates(txStates.Read)
+	outputStates, outputsPurged := withoutPurgedStates(txStates.Confirmed)
+	infoStates, infoPurged := withoutPurgedStates(txStates.Info)
+	purged := inputsPurged || readsPurged || outputsPurged || infoPurged
+	empty := len(inputStates) == 0 && len(readStates) == 0 && len(outputStates) == 0 && len(infoStates) == 0
 	if empty {
 		// We have none of the private data for the transaction at all
 		return nil, i18n.NewError(ctx, msgs.MsgDomainDomainReceiptNoStatesAvailable, txID)
@@ -774,11 +845,11 @@ func (d *domain) BuildDomainReceipt(ctx context.Context, dbTX persistence.DBTX,
 	// As long as we have some knowledge, we call to the domain and see what it builds with what we have available
 	res, err := d.api.BuildReceipt(ctx, &prototk.BuildReceiptRequest{
 		TransactionId: pldtypes.Bytes32UUIDFirst16(txID).String(),
-		Complete:      txStates.Unavailable == nil, // important for the domain to know if we have everything (it may fail with partial knowledge)
-		InputStates:   d.toEndorsableListBase(txStates.Spent),
-		ReadStates:    d.toEndorsableListBase(txStates.Read),
-		OutputStates:  d.toEndorsableListBase(txStates.Confirmed),
-		InfoStates:    d.toEndorsableListBase(txStates.Info),
+		Complete:      txStates.Unavailable == nil && !purged, // important for the domain to know if we have everything (it may fail with partial knowledge)
+		InputStates:   d.toEndorsableListBase(inputStates),
+		ReadStates:    d.toEndorsableListBase(readStates),
+		OutputStates:  d.toEndorsableListBase(outputStates),
+		InfoStates:    d.toEndorsableListBase(infoStates),
 	})
 	if err != nil {
 		return nil, err
@@ -786,6 +857,18 @@ func (d *domain) BuildDomainReceipt(ctx context.Context, dbTX persistence.DBTX,
 	return pldtypes.RawJSON(res.ReceiptJson), nil
 }
 
+func withoutPurgedStates(states []*pldapi.StateBase) (_ []*pldapi.StateBase, purged bool) {
+	withData := make([]*pldapi.StateBase, 0, len(states))
+	for _, s := range states {
+		if s.Data.IsNil() {
+			purged = true
+		} else {
+			withData = append(withData, s)
+		}
+	}
+	return withData, purged
+}
+
 func (d *domain) SendTransaction(ctx context.Context, req *prototk.SendTransactionRequest) (*prototk.SendTransactionResponse, error) {
 	c, err := d.checkInFlight(ctx, req.StateQueryContext, true /* need write */)
 	if err != nil {
@@ -843,6 +926,43 @@ func (d *domain) GetStatesByID(ctx context.Context, req *prototk.GetStatesByIDRe
 	}, err
 }
 
+func (d *domain) CheckCredential(ctx context.Context, req *prototk.CheckCredentialRequest) (*prototk.CheckCredentialResponse, error) {
+	check := &pldapi.RegistryCredentialCheck{
+		Registry:  req.Registry,
+		EntryPath: req.EntryPath,
+		Type:      req.CredentialType,
+	}
+	if req.EntryId != "" {
+		entryID, err := pldtypes.ParseHexBytes(ctx, req.EntryId)
+		if err != nil {
+			return nil, err
+		}
+		check.EntryID = entryID
+	}
+	for _, issuer := range req.TrustedIssuers {
+		addr, err := pldtypes.ParseEthAddress(issuer)
+		if err != nil {
+			return nil, err
+		}
+		check.Issuers = append(check.Issuers, addr)
+	}
+
+	result, err := d.dm.registryMgr.CheckCredential(ctx, d.dm.persistence.NOTX(), check)
+	if err != nil {
+		return nil, err
+	}
+	log.L(ctx).Debugf("Domain %s checked credential type '%s' in registry '%s': valid=%t", d.name, req.CredentialType, req.Registry, result.Valid)
+	res := &prototk.CheckCredentialResponse{
+		Valid:  result.Valid,
+		Reason: result.Reason,
+	}
+	if result.Credential != nil {
+		credentialJSON := pldtypes.JSONString(result.Credential).String()
+		res.CredentialJson = &credentialJSON
+	}
+	return res, nil
+}
+
 func (d *domain) ConfigurePrivacyGroup(ctx context.Context, inputConfiguration map[string]string) (configuration map[string]string, err error) {
 	res, err := d.api.ConfigurePrivacyGroup(ctx, &prototk.ConfigurePrivacyGroupRequest{
 		InputConfiguration: inputConfiguration,
@@ -29,9 +29,11 @@ import (
 	"github.com/kaleido-io/paladin/config/pkg/pldconf"
 	"github.com/kaleido-io/paladin/core/internal/components"
 	"github.com/kaleido-io/paladin/core/mocks/componentmocks"
+	"github.com/kaleido-io/paladin/core/pkg/blockindexer"
 
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/rpcclient"
 	"github.com/kaleido-io/paladin/toolkit/pkg/algorithms"
 	"github.com/kaleido-io/paladin/toolkit/pkg/plugintk"
 	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
@@ -221,7 +223,7 @@ func newTestDomain(t *testing.T, realDB bool, domainConfig *prototk.DomainConfig
 	var mdc *componentmocks.DomainContext
 	addr := *pldtypes.RandAddress()
 	if realDB {
-		dCtx := dm.stateStore.NewDomainContext(ctx, tp.d, addr)
+		dCtx := dm.stateStore.NewDomainContext(ctx, tp.d, addr, components.DomainContextIsolationSpeculative)
 		c = tp.d.newInFlightDomainRequest(dm.persistence.NOTX(), dCtx, true /* readonly unless modified by test */)
 	} else {
 		mdc = componentmocks.NewDomainContext(t)
@@ -1036,6 +1038,69 @@ func TestRecoverSignerFailCases(t *testing.T) {
 	assert.Regexp(t, "PD011638", err)
 }
 
+func TestCheckCredential(t *testing.T) {
+	entryID := pldtypes.HexBytes(pldtypes.RandBytes(32))
+	issuer := pldtypes.RandAddress()
+	td, done := newTestDomain(t, false, goodDomainConf(), mockSchemas(), func(mc *mockComponents) {
+		mc.registryMgr.On("CheckCredential", mock.Anything, mock.Anything, mock.MatchedBy(func(check *pldapi.RegistryCredentialCheck) bool {
+			return check.Registry == "registry1" &&
+				check.EntryID.Equals(entryID) &&
+				check.Type == "KYCPassed" &&
+				len(check.Issuers) == 1 && check.Issuers[0].Equals(issuer)
+		})).Return(&pldapi.RegistryCredentialCheckResult{
+			Valid:      true,
+			Credential: &pldapi.RegistryCredential{ID: "cred1"},
+		}, nil).Once()
+		mc.registryMgr.On("CheckCredential", mock.Anything, mock.Anything, mock.MatchedBy(func(check *pldapi.RegistryCredentialCheck) bool {
+			return check.Type == "Accredited"
+		})).Return(&pldapi.RegistryCredentialCheckResult{
+			Reason: "not held",
+		}, nil).Once()
+		mc.registryMgr.On("CheckCredential", mock.Anything, mock.Anything, mock.Anything).Return(nil, fmt.Errorf("pop")).Once()
+	})
+	defer done()
+
+	res, err := td.d.CheckCredential(td.ctx, &prototk.CheckCredentialRequest{
+		Registry:       "registry1",
+		EntryId:        entryID.String(),
+		CredentialType: "KYCPassed",
+		TrustedIssuers: []string{issuer.String()},
+	})
+	require.NoError(t, err)
+	assert.True(t, res.Valid)
+	var cred *pldapi.RegistryCredential
+	err = json.Unmarshal([]byte(*res.CredentialJson), &cred)
+	require.NoError(t, err)
+	assert.Equal(t, "cred1", cred.ID)
+
+	res, err = td.d.CheckCredential(td.ctx, &prototk.CheckCredentialRequest{
+		Registry:       "registry1",
+		EntryPath:      []string{"node1"},
+		CredentialType: "Accredited",
+	})
+	require.NoError(t, err)
+	assert.False(t, res.Valid)
+	assert.Equal(t, "not held", res.Reason)
+	assert.Nil(t, res.CredentialJson)
+
+	_, err = td.d.CheckCredential(td.ctx, &prototk.CheckCredentialRequest{
+		Registry:       "registry1",
+		EntryPath:      []string{"node1"},
+		CredentialType: "KYCPassed",
+	})
+	assert.Regexp(t, "pop", err)
+
+	_, err = td.d.CheckCredential(td.ctx, &prototk.CheckCredentialRequest{
+		EntryId: "not hex",
+	})
+	assert.Regexp(t, "PD020007", err)
+
+	_, err = td.d.CheckCredential(td.ctx, &prototk.CheckCredentialRequest{
+		TrustedIssuers: []string{"wrong"},
+	})
+	assert.Error(t, err)
+}
+
 func TestSendTransactionFailCases(t *testing.T) {
 	td, done := newTestDomain(t, false, goodDomainConf(), mockSchemas())
 	defer done()
@@ -1210,10 +1275,10 @@ func TestGetDomainReceiptAllAvailable(t *testing.T) {
 	td, done := newTestDomain(t, false, goodDomainConf(), mockSchemas(), func(mc *mockComponents) {
 		mc.stateStore.On("GetTransactionStates", mock.Anything, mock.Anything, txID).
 			Return(&pldapi.TransactionStates{
-				Spent:     []*pldapi.StateBase{{ID: stateID1}},
-				Read:      []*pldapi.StateBase{{ID: stateID2}},
-				Confirmed: []*pldapi.StateBase{{ID: stateID3}},
-				Info:      []*pldapi.StateBase{{ID: stateID4}},
+				Spent:     []*pldapi.StateBase{{ID: stateID1, Data: pldtypes.RawJSON(`{}`)}},
+				Read:      []*pldapi.StateBase{{ID: stateID2, Data: pldtypes.RawJSON(`{}`)}},
+				Confirmed: []*pldapi.StateBase{{ID: stateID3, Data: pldtypes.RawJSON(`{}`)}},
+				Info:      []*pldapi.StateBase{{ID: stateID4, Data: pldtypes.RawJSON(`{}`)}},
 			}, nil)
 	})
 	defer done()
@@ -1250,7 +1315,7 @@ func TestGetDomainReceiptIncomplete(t *testing.T) {
 	td, done := newTestDomain(t, false, goodDomainConf(), mockSchemas(), func(mc *mockComponents) {
 		mc.stateStore.On("GetTransactionStates", mock.Anything, mock.Anything, txID).
 			Return(&pldapi.TransactionStates{
-				Spent: []*pldapi.StateBase{{ID: stateID1}},
+				Spent: []*pldapi.StateBase{{ID: stateID1, Data: pldtypes.RawJSON(`{}`)}},
 				Unavailable: &pldapi.UnavailableStates{
 					Confirmed: []pldtypes.HexBytes{stateID2},
 				},
@@ -1274,6 +1339,56 @@ func TestGetDomainReceiptIncomplete(t *testing.T) {
 
 }
 
+func TestGetDomainReceiptPurgedStates(t *testing.T) {
+	txID := uuid.New()
+
+	stateID1 := pldtypes.HexBytes(pldtypes.RandBytes(32))
+	stateID2 := pldtypes.HexBytes(pldtypes.RandBytes(32))
+
+	td, done := newTestDomain(t, false, goodDomainConf(), mockSchemas(), func(mc *mockComponents) {
+		mc.stateStore.On("GetTransactionStates", mock.Anything, mock.Anything, txID).
+			Return(&pldapi.TransactionStates{
+				Spent:     []*pldapi.StateBase{{ID: stateID1}},
+				Confirmed: []*pldapi.StateBase{{ID: stateID2, Data: pldtypes.RawJSON(`{}`)}},
+			}, nil)
+	})
+	defer done()
+	assert.Nil(t, td.d.initError.Load())
+
+	td.tp.Functions.BuildReceipt = func(ctx context.Context, req *prototk.BuildReceiptRequest) (*prototk.BuildReceiptResponse, error) {
+		require.False(t, req.Complete)
+		require.Empty(t, req.InputStates)
+		require.Len(t, req.OutputStates, 1)
+		require.Equal(t, stateID2.String(), req.OutputStates[0].Id)
+
+		return &prototk.BuildReceiptResponse{
+			ReceiptJson: `{"some":"receipt"}`,
+		}, nil
+	}
+
+	resData, err := td.d.GetDomainReceipt(td.ctx, td.c.dbTX, txID)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"some":"receipt"}`, resData.Pretty())
+
+}
+
+func TestGetDomainReceiptAllPurged(t *testing.T) {
+	txID := uuid.New()
+
+	td, done := newTestDomain(t, false, goodDomainConf(), mockSchemas(), func(mc *mockComponents) {
+		mc.stateStore.On("GetTransactionStates", mock.Anything, mock.Anything, txID).
+			Return(&pldapi.TransactionStates{
+				Spent: []*pldapi.StateBase{{ID: pldtypes.RandBytes(32)}},
+			}, nil)
+	})
+	defer done()
+	assert.Nil(t, td.d.initError.Load())
+
+	_, err := td.d.GetDomainReceipt(td.ctx, td.c.dbTX, txID)
+	assert.Regexp(t, "PD011658", err)
+
+}
+
 func TestGetDomainReceiptFail(t *testing.T) {
 	txID := uuid.New()
 
@@ -1282,7 +1397,7 @@ func TestGetDomainReceiptFail(t *testing.T) {
 	td, done := newTestDomain(t, false, goodDomainConf(), mockSchemas(), func(mc *mockComponents) {
 		mc.stateStore.On("GetTransactionStates", mock.Anything, mock.Anything, txID).
 			Return(&pldapi.TransactionStates{
-				Spent: []*pldapi.StateBase{{ID: stateID1}},
+				Spent: []*pldapi.StateBase{{ID: stateID1, Data: pldtypes.RawJSON(`{}`)}},
 			}, nil)
 	})
 	defer done()
@@ -1484,3 +1599,167 @@ func TestDomainInitPrivacyGroupBadResFromAddr(t *testing.T) {
 	assert.Regexp(t, "bad address", err)
 
 }
+
+func mockDomainEventStream(streamID uuid.UUID, started bool) func(mc *mockComponents) {
+	return func(mc *mockComponents) {
+		mc.blockIndexer.On("AddEventStream", mock.Anything, mock.Anything, mock.Anything).Return(&blockindexer.EventStream{
+			ID:      streamID,
+			Started: &started,
+		}, nil)
+	}
+}
+
+func TestDomainPauseResume(t *testing.T) {
+	streamID := uuid.New()
+	domainConf := goodDomainConf()
+	domainConf.AbiEventsJson = fakeCoinEventsABI
+	td, done := newTestDomain(t, true, domainConf, mockUpsertABIOk, mockDomainEventStream(streamID, true))
+	defer done()
+	assert.False(t, td.d.apiDomain().Paused)
+
+	td.dm.blockIndexer.(*componentmocks.BlockIndexer).On("StopEventStream", mock.Anything, streamID).Return(nil).Once()
+	res := td.dm.rpcPauseDomain().Handle(td.ctx, &rpcclient.RPCRequest{
+		JSONRpc: "2.0",
+		ID:      pldtypes.RawJSON(`1`),
+		Params:  []pldtypes.RawJSON{pldtypes.JSONString("test1")},
+	})
+	require.Nil(t, res.Error)
+	assert.JSONEq(t, fmt.Sprintf(`{"name":"test1","registryAddress":"%s","paused":true}`, td.d.registryAddress), res.Result.String())
+
+	err := td.d.InitDeploy(td.ctx, &components.PrivateContractDeploy{})
+	assert.Regexp(t, "PD011668.*test1", err)
+	err = (&domainContract{d: td.d}).InitTransaction(td.ctx, &components.PrivateTransaction{}, &components.ResolvedTransaction{})
+	assert.Regexp(t, "PD011668.*test1", err)
+
+	td.dm.blockIndexer.(*componentmocks.BlockIndexer).On("StartEventStream", mock.Anything, streamID).Return(nil).Once()
+	res = td.dm.rpcResumeDomain().Handle(td.ctx, &rpcclient.RPCRequest{
+		JSONRpc: "2.0",
+		ID:      pldtypes.RawJSON(`1`),
+		Params:  []pldtypes.RawJSON{pldtypes.JSONString("test1")},
+	})
+	require.Nil(t, res.Error)
+	assert.False(t, td.d.apiDomain().Paused)
+
+	err = td.d.InitDeploy(td.ctx, &components.PrivateContractDeploy{})
+	assert.Regexp(t, "PD011620", err) // past the pause check
+}
+
+func TestDomainPausedOverRestart(t *testing.T) {
+	domainConf := goodDomainConf()
+	domainConf.AbiEventsJson = fakeCoinEventsABI
+	td, done := newTestDomain(t, true, domainConf, mockUpsertABIOk, mockDomainEventStream(uuid.New(), false))
+	defer done()
+
+	assert.True(t, td.d.Initialized())
+	assert.True(t, td.d.apiDomain().Paused)
+}
+
+func TestDomainPauseResumeErrors(t *testing.T) {
+	streamID := uuid.New()
+	domainConf := goodDomainConf()
+	domainConf.AbiEventsJson = fakeCoinEventsABI
+	td, done := newTestDomain(t, true, domainConf, mockUpsertABIOk, mockDomainEventStream(streamID, true), func(mc *mockComponents) {
+		mc.blockIndexer.On("StopEventStream", mock.Anything, streamID).Return(fmt.Errorf("pop"))
+		mc.blockIndexer.On("StartEventStream", mock.Anything, streamID).Return(fmt.Errorf("pop"))
+	})
+	defer done()
+
+	// a failure to stop the stream leaves the domain running
+	err := td.d.pause(td.ctx)
+	assert.Regexp(t, "pop", err)
+	assert.False(t, td.d.apiDomain().Paused)
+
+	td.d.paused.Store(true)
+	err = td.d.resume(td.ctx)
+	assert.Regexp(t, "pop", err)
+	assert.True(t, td.d.apiDomain().Paused)
+
+	res := td.dm.rpcPauseDomain().Handle(td.ctx, &rpcclient.RPCRequest{
+		JSONRpc: "2.0",
+		ID:      pldtypes.RawJSON(`1`),
+		Params:  []pldtypes.RawJSON{pldtypes.JSONString("unknown")},
+	})
+	assert.Regexp(t, "PD011600", res.Error.Message)
+	res = td.dm.rpcResumeDomain().Handle(td.ctx, &rpcclient.RPCRequest{
+		JSONRpc: "2.0",
+		ID:      pldtypes.RawJSON(`1`),
+		Params:  []pldtypes.RawJSON{pldtypes.JSONString("unknown")},
+	})
+	assert.Regexp(t, "PD011600", res.Error.Message)
+
+	td.d.initialized.Store(false)
+	assert.Regexp(t, "PD011601", td.d.pause(td.ctx))
+	assert.Regexp(t, "PD011601", td.d.resume(td.ctx))
+}
+
+func TestDomainRPCPassthrough(t *testing.T) {
+	domainConf := goodDomainConf()
+	domainConf.RpcMethods = []string{"status", "nothing", "badResult", "fails"}
+	td, done := newTestDomain(t, false, domainConf, mockSchemas())
+	defer done()
+
+	td.tp.Functions.HandleRPC = func(ctx context.Context, req *prototk.HandleRPCRequest) (*prototk.HandleRPCResponse, error) {
+		switch req.Method {
+		case "test1_status":
+			assert.JSONEq(t, `["a",1]`, req.ParamsJson)
+			return &prototk.HandleRPCResponse{ResultJson: `{"ok":true}`}, nil
+		case "test1_nothing":
+			assert.JSONEq(t, `[]`, req.ParamsJson)
+			return &prototk.HandleRPCResponse{}, nil
+		case "test1_badResult":
+			return &prototk.HandleRPCResponse{ResultJson: `{!!! wrong`}, nil
+		default:
+			return nil, fmt.Errorf("pop")
+		}
+	}
+
+	rpcModule := td.dm.buildDomainRPCModule("test1")
+	require.NotNil(t, rpcModule)
+	handler := td.dm.rpcDomainPassthrough("test1")
+	call := func(method string, params ...pldtypes.RawJSON) *rpcclient.RPCResponse {
+		return handler.Handle(td.ctx, &rpcclient.RPCRequest{
+			JSONRpc: "2.0",
+			ID:      pldtypes.RawJSON(`1`),
+			Method:  method,
+			Params:  params,
+		})
+	}
+
+	res := call("test1_status", pldtypes.JSONString("a"), pldtypes.RawJSON(`1`))
+	require.Nil(t, res.Error)
+	assert.JSONEq(t, `{"ok":true}`, res.Result.String())
+
+	res = call("test1_nothing")
+	require.Nil(t, res.Error)
+	assert.Equal(t, `null`, res.Result.String())
+
+	res = call("test1_badResult")
+	assert.Regexp(t, "PD011670", res.Error.Message)
+
+	res = call("test1_fails")
+	assert.Regexp(t, "pop", res.Error.Message)
+
+	res = call("test1_unknown")
+	assert.Regexp(t, "PD011669.*test1_unknown", res.Error.Message)
+
+	res = td.dm.rpcDomainPassthrough("unknown").Handle(td.ctx, &rpcclient.RPCRequest{Method: "unknown_status"})
+	assert.Regexp(t, "PD011600", res.Error.Message)
+
+	td.d.initialized.Store(false)
+	res = call("test1_status")
+	assert.Regexp(t, "PD011601", res.Error.Message)
+}
+
+func TestDomainRPCModuleInvalidName(t *testing.T) {
+	_, dm, _, done := newTestDomainManager(t, false, &pldconf.DomainManagerConfig{
+		Domains: map[string]*pldconf.DomainConfig{
+			"domain_1": {
+				RegistryAddress: pldtypes.RandHex(20),
+			},
+		},
+	})
+	defer done()
+
+	assert.Nil(t, dm.buildDomainRPCModule("domain_1"))
+	assert.NotNil(t, dm.buildDomainRPCModule("domain1"))
+}
@@ -254,6 +254,21 @@ func (d *domain) handleEventBatch(ctx context.Context, dbTX persistence.DBTX, ba
 		if err != nil {
 			return err
 		}
+
+		// Any external calls declared when the transactions were prepared are submitted in the same
+		// DB transaction, so they are submitted exactly once with the confirmation
+		var confirmedTxIDs []uuid.UUID
+		for _, txc := range txCompletions {
+			if txc.PSC != nil {
+				confirmedTxIDs = append(confirmedTxIDs, txc.TransactionID)
+			}
+		}
+		if len(confirmedTxIDs) > 0 {
+			err = d.dm.privateTxManager.SubmitExternalCalls(ctx, dbTX, confirmedTxIDs)
+			if err != nil {
+				return err
+			}
+		}
 	}
 
 	dbTX.AddPostCommit(func(txCtx context.Context) {
@@ -275,7 +290,7 @@ func (d *domain) handleEventBatchForContract(ctx context.Context, dbTX persisten
 
 	// We have a domain context for queries, but we never flush it to DB - as the only updates
 	// we allow in this function are those performed within our dbTX.
-	c := d.newInFlightDomainRequest(dbTX, d.dm.stateStore.NewDomainContext(ctx, d, addr), false /* write enabled */)
+	c := d.newInFlightDomainRequest(dbTX, d.dm.stateStore.NewDomainContext(ctx, d, addr, components.DomainContextIsolationSpeculative), false /* write enabled */)
 	defer c.close()
 
 	batch.StateQueryContext = c.id
@@ -304,13 +319,18 @@ func (d *domain) handleEventBatchForContract(ctx context.Context, dbTX persisten
 		stateReads[i] = &pldapi.StateReadRecord{DomainName: d.name, State: stateID, Transaction: txUUID}
 	}
 
+	txLocations, err := d.completedTransactionLocations(ctx, res.TransactionsComplete)
+	if err != nil {
+		return nil, err
+	}
+
 	stateConfirms := make([]*pldapi.StateConfirmRecord, len(res.ConfirmedStates))
 	for i, state := range res.ConfirmedStates {
 		txUUID, stateID, err := d.prepareIndexRecord(ctx, state.TransactionId, state.Id)
 		if err != nil {
 			return nil, err
 		}
-		stateConfirms[i] = &pldapi.StateConfirmRecord{DomainName: d.name, State: stateID, Transaction: txUUID}
+		stateConfirms[i] = newStateConfirmRecord(d.name, stateID, txUUID, txLocations)
 	}
 
 	stateInfoRecords := make([]*pldapi.StateInfoRecord, len(res.InfoStates))
@@ -347,7 +367,7 @@ func (d *domain) handleEventBatchForContract(ctx context.Context, dbTX persisten
 		})
 
 		// These have implicit confirmations
-		stateConfirms = append(stateConfirms, &pldapi.StateConfirmRecord{DomainName: d.name, State: id, Transaction: *txUUID})
+		stateConfirms = append(stateConfirms, newStateConfirmRecord(d.name, id, *txUUID, txLocations))
 	}
 
 	// Write any new states first
@@ -368,6 +388,39 @@ func (d *domain) handleEventBatchForContract(ctx context.Context, dbTX persisten
 	return res, err
 }
 
+// completedTransactionLocations maps the transactions the domain reported complete in a batch, to the
+// base ledger transaction hash and block of the event that completed them
+func (d *domain) completedTransactionLocations(ctx context.Context, completions []*prototk.CompletedTransaction) (map[uuid.UUID]*pldtypes.OnChainLocation, error) {
+	txLocations := make(map[uuid.UUID]*pldtypes.OnChainLocation, len(completions))
+	for _, completion := range completions {
+		if completion.Location == nil {
+			continue
+		}
+		txID, err := d.recoverTransactionID(ctx, completion.TransactionId)
+		if err != nil {
+			return nil, err
+		}
+		txHash, err := pldtypes.ParseBytes32Ctx(ctx, completion.Location.TransactionHash)
+		if err != nil {
+			return nil, err
+		}
+		txLocations[*txID] = &pldtypes.OnChainLocation{
+			TransactionHash: txHash,
+			BlockNumber:     completion.Location.BlockNumber,
+		}
+	}
+	return txLocations, nil
+}
+
+func newStateConfirmRecord(domainName string, stateID pldtypes.HexBytes, txID uuid.UUID, txLocations map[uuid.UUID]*pldtypes.OnChainLocation) *pldapi.StateConfirmRecord {
+	confirm := &pldapi.StateConfirmRecord{DomainName: domainName, State: stateID, Transaction: txID}
+	if location := txLocations[txID]; location != nil {
+		confirm.TransactionHash = &location.TransactionHash
+		confirm.BlockNumber = &location.BlockNumber
+	}
+	return confirm
+}
+
 func (d *domain) prepareIndexRecord(ctx context.Context, txIDStr, stateIDStr string) (uuid.UUID, pldtypes.HexBytes, error) {
 	txUUID, err := d.recoverTransactionID(ctx, txIDStr)
 	if err != nil {
@@ -242,8 +242,10 @@ func TestHandleEventBatch(t *testing.T) {
 		}, []*pldapi.StateReadRecord{
 			{DomainName: "test1", State: pldtypes.MustParseHexBytes(stateRead), Transaction: txID}, // the ReadStates StateUpdate
 		}, []*pldapi.StateConfirmRecord{
-			{DomainName: "test1", State: pldtypes.MustParseHexBytes(stateConfirmed), Transaction: txID}, // the ConfirmedStates StateUpdate
-			{DomainName: "test1", State: pldtypes.MustParseHexBytes(fakeHash1), Transaction: txID},      // the implicit confirm from the NewConfirmedState
+			// the ConfirmedStates StateUpdate, with the location of the event that completed the transaction
+			{DomainName: "test1", State: pldtypes.MustParseHexBytes(stateConfirmed), Transaction: txID, TransactionHash: &event2.TransactionHash, BlockNumber: &event2.BlockNumber},
+			// the implicit confirm from the NewConfirmedState
+			{DomainName: "test1", State: pldtypes.MustParseHexBytes(fakeHash1), Transaction: txID, TransactionHash: &event2.TransactionHash, BlockNumber: &event2.BlockNumber},
 		}, []*pldapi.StateInfoRecord{
 			{DomainName: "test1", State: pldtypes.MustParseHexBytes(stateInfo), Transaction: txID}, // the InfoStates StateUpdate
 		}).Return(nil, nil)
@@ -271,6 +273,7 @@ func TestHandleEventBatch(t *testing.T) {
 			return true
 		})).Return(nil)
 
+		mc.privateTxManager.On("SubmitExternalCalls", mock.Anything, mock.Anything, []uuid.UUID{txID}).Return(nil)
 		mc.privateTxManager.On("PrivateTransactionConfirmed", mock.Anything, mock.Anything).Return()
 
 		mc.txManager.On("SendTransactions", mock.Anything, mock.Anything, mock.Anything).Return([]uuid.UUID{txID}, nil)
@@ -367,6 +370,60 @@ func TestHandleEventBatch(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestHandleEventBatchSubmitExternalCallsFail(t *testing.T) {
+	batchID := uuid.New()
+	txID := uuid.New()
+	contract1 := pldtypes.RandAddress()
+
+	td, done := newTestDomain(t, false, goodDomainConf(), mockSchemas(), func(mc *mockComponents) {
+		mc.stateStore.On("WriteStateFinalizations", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, nil).Maybe()
+		mc.txManager.On("FinalizeTransactions", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+		mc.privateTxManager.On("SubmitExternalCalls", mock.Anything, mock.Anything, []uuid.UUID{txID}).Return(fmt.Errorf("pop"))
+	})
+	defer done()
+	d := td.d
+
+	mp, err := mockpersistence.NewSQLMockProvider()
+	require.NoError(t, err)
+	mp.Mock.ExpectBegin()
+	mp.Mock.ExpectQuery("SELECT.*private_smart_contracts").WillReturnRows(sqlmock.NewRows(
+		[]string{"address", "domain_address"},
+	).AddRow(contract1, d.registryAddress))
+	mp.Mock.ExpectRollback()
+
+	td.tp.Functions.HandleEventBatch = func(ctx context.Context, req *prototk.HandleEventBatchRequest) (*prototk.HandleEventBatchResponse, error) {
+		return &prototk.HandleEventBatchResponse{
+			TransactionsComplete: []*prototk.CompletedTransaction{
+				{
+					TransactionId: pldtypes.Bytes32UUIDFirst16(txID).String(),
+					Location:      req.Events[0].Location,
+				},
+			},
+		}, nil
+	}
+	td.tp.Functions.InitContract = func(ctx context.Context, icr *prototk.InitContractRequest) (*prototk.InitContractResponse, error) {
+		return &prototk.InitContractResponse{Valid: true, ContractConfig: &prototk.ContractConfig{}}, nil
+	}
+
+	err = mp.P.Transaction(td.ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
+		return d.handleEventBatch(ctx, dbTX, &blockindexer.EventDeliveryBatch{
+			BatchID: batchID,
+			Events: []*pldapi.EventWithData{
+				{
+					Address: *contract1,
+					IndexedEvent: &pldapi.IndexedEvent{
+						BlockNumber:     1000,
+						TransactionHash: pldtypes.RandBytes32(),
+						Signature:       pldtypes.RandBytes32(),
+					},
+					Data: pldtypes.RawJSON(`{}`),
+				},
+			},
+		})
+	})
+	assert.Regexp(t, "pop", err)
+}
+
 func TestHandleEventBatchFinalizeFail(t *testing.T) {
 	batchID := uuid.New()
 
@@ -1016,6 +1073,52 @@ func TestHandleEventBatchBadTransactionID(t *testing.T) {
 	assert.ErrorContains(t, err, "PD020008")
 }
 
+func TestHandleEventBatchBadLocationTransactionHash(t *testing.T) {
+	batchID := uuid.New()
+	contract1 := pldtypes.RandAddress()
+
+	td, done := newTestDomain(t, false, goodDomainConf(), mockSchemas())
+	defer done()
+
+	mp, err := mockpersistence.NewSQLMockProvider()
+	require.NoError(t, err)
+
+	mp.Mock.ExpectBegin()
+	mp.Mock.ExpectQuery("SELECT.*private_smart_contracts").WillReturnRows(sqlmock.NewRows(
+		[]string{"address", "domain_address"},
+	).AddRow(contract1, td.d.registryAddress))
+
+	td.tp.Functions.HandleEventBatch = func(ctx context.Context, req *prototk.HandleEventBatchRequest) (*prototk.HandleEventBatchResponse, error) {
+		return &prototk.HandleEventBatchResponse{
+			TransactionsComplete: []*prototk.CompletedTransaction{
+				{
+					TransactionId: pldtypes.RandHex(32),
+					Location: &prototk.OnChainEventLocation{
+						TransactionHash: "badnotgood",
+					},
+				},
+			},
+		}, nil
+	}
+	td.tp.Functions.InitContract = func(ctx context.Context, icr *prototk.InitContractRequest) (*prototk.InitContractResponse, error) {
+		return &prototk.InitContractResponse{Valid: true, ContractConfig: &prototk.ContractConfig{}}, nil
+	}
+
+	err = mp.P.Transaction(context.Background(), func(ctx context.Context, dbTX persistence.DBTX) error {
+		return td.d.handleEventBatch(td.ctx, dbTX, &blockindexer.EventDeliveryBatch{
+			BatchID: batchID,
+			Events: []*pldapi.EventWithData{
+				{
+					Address:      *td.d.registryAddress,
+					IndexedEvent: &pldapi.IndexedEvent{},
+					Data:         pldtypes.RawJSON(`{"result": "success"}`),
+				},
+			},
+		})
+	})
+	assert.ErrorContains(t, err, "PD020007")
+}
+
 func TestHandleEventBatchMarkConfirmedFail(t *testing.T) {
 	batchID := uuid.New()
 	txID := uuid.New()
@@ -61,6 +61,7 @@ var smartContractFilters = filters.FieldMap{
 }
 
 func NewDomainManager(bgCtx context.Context, conf *pldconf.DomainManagerConfig) components.DomainManager {
+	bgCtx = log.WithSubsystem(bgCtx, "domainmgr")
 	allDomains := []string{}
 	for name := range conf.Domains {
 		allDomains = append(allDomains, name)
@@ -71,8 +72,8 @@ func NewDomainManager(bgCtx context.Context, conf *pldconf.DomainManagerConfig)
 		conf:             conf,
 		domainsByName:    make(map[string]*domain),
 		domainsByAddress: make(map[pldtypes.EthAddress]*domain),
-		privateTxWaiter:  inflight.NewInflightManager[uuid.UUID, *components.ReceiptInput](uuid.Parse),
-		contractCache:    cache.NewCache[pldtypes.EthAddress, *domainContract](&conf.DomainManager.ContractCache, pldconf.ContractCacheDefaults),
+		privateTxWaiter:  inflight.NewNamedBoundedInflightManager[uuid.UUID, *components.ReceiptInput]("domainmgr.privateTxWaiters", &conf.DomainManager.PrivateTxWaiters, uuid.Parse),
+		contractCache:    cache.NewNamedCache[pldtypes.EthAddress, *domainContract]("domainmgr.contracts", &conf.DomainManager.ContractCache, pldconf.ContractCacheDefaults),
 	}
 }
 
@@ -86,6 +87,7 @@ type domainManager struct {
 	privateTxManager components.PrivateTxManager
 	txManager        components.TXManager
 	transportMgr     components.TransportManager
+	registryMgr      components.RegistryManager
 	blockIndexer     blockindexer.BlockIndexer
 	keyManager       components.KeyManager
 	ethClientFactory ethclient.EthClientFactory
@@ -108,8 +110,14 @@ type event_PaladinRegisterSmartContract_V0 struct {
 
 func (dm *domainManager) PreInit(c components.PreInitComponents) (*components.ManagerInitResult, error) {
 	dm.buildRPCModule()
+	rpcModules := []*rpcserver.RPCModule{dm.rpcModule}
+	for name := range dm.conf.Domains {
+		if domainRPCModule := dm.buildDomainRPCModule(name); domainRPCModule != nil {
+			rpcModules = append(rpcModules, domainRPCModule)
+		}
+	}
 	return &components.ManagerInitResult{
-		RPCModules: []*rpcserver.RPCModule{dm.rpcModule},
+		RPCModules: rpcModules,
 	}, nil
 }
 
@@ -122,6 +130,7 @@ func (dm *domainManager) PostInit(c components.AllComponents) error {
 	dm.blockIndexer = c.BlockIndexer()
 	dm.keyManager = c.KeyManager()
 	dm.transportMgr = c.TransportManager()
+	dm.registryMgr = c.RegistryManager()
 
 	// Register ourselves as a signing on the key manager
 	dm.domainSigner = &domainSigner{dm: dm}
@@ -230,7 +239,10 @@ func (dm *domainManager) getDomainByName(ctx context.Context, name string) (*dom
 func (dm *domainManager) ExecDeployAndWait(ctx context.Context, txID uuid.UUID, call func() error) (dc components.DomainSmartContract, err error) {
 	// Waits for the event that confirms a smart contract has been deployed (or a context timeout)
 	// using the transaction ID of the deploy transaction
-	req := dm.privateTxWaiter.AddInflight(ctx, txID)
+	req, err := dm.privateTxWaiter.TryAddInflight(ctx, txID)
+	if err != nil {
+		return nil, err
+	}
 	defer req.Cancel()
 	log.L(ctx).Infof("Added waiter %s for private deployment TransactionID %s", req.ID(), txID)
 
@@ -263,11 +275,14 @@ func (dm *domainManager) waitForDeploy(ctx context.Context, req *inflight.Inflig
 func (dm *domainManager) ExecAndWaitTransaction(ctx context.Context, txID uuid.UUID, call func() error) error {
 	// Waits for the event that confirms a transaction has been processed (or a context timeout)
 	// using the ID of the transaction
-	req := dm.privateTxWaiter.AddInflight(ctx, txID)
+	req, err := dm.privateTxWaiter.TryAddInflight(ctx, txID)
+	if err != nil {
+		return err
+	}
 	defer req.Cancel()
 	log.L(ctx).Infof("Added waiter %s for private TransactionID %s", req.ID(), txID)
 
-	err := call()
+	err = call()
 	if err == nil {
 		_, err = req.Wait()
 	}
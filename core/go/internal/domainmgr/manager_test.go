@@ -23,6 +23,7 @@ import (
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/google/uuid"
 	"github.com/hyperledger/firefly-signer/pkg/abi"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
 	"github.com/kaleido-io/paladin/config/pkg/pldconf"
 	"github.com/kaleido-io/paladin/core/internal/components"
 	"github.com/kaleido-io/paladin/core/internal/statemgr"
@@ -49,6 +50,7 @@ type mockComponents struct {
 	txManager        *componentmocks.TXManager
 	privateTxManager *componentmocks.PrivateTxManager
 	transportMgr     *componentmocks.TransportManager
+	registryMgr      *componentmocks.RegistryManager
 }
 
 func newTestDomainManager(t *testing.T, realDB bool, conf *pldconf.DomainManagerConfig, extraSetup ...func(mc *mockComponents)) (context.Context, *domainManager, *mockComponents, func()) {
@@ -64,6 +66,7 @@ func newTestDomainManager(t *testing.T, realDB bool, conf *pldconf.DomainManager
 		txManager:        componentmocks.NewTXManager(t),
 		privateTxManager: componentmocks.NewPrivateTxManager(t),
 		transportMgr:     componentmocks.NewTransportManager(t),
+		registryMgr:      componentmocks.NewRegistryManager(t),
 	}
 
 	// Blockchain stuff is always mocked
@@ -77,6 +80,7 @@ func newTestDomainManager(t *testing.T, realDB bool, conf *pldconf.DomainManager
 	componentMocks.On("TxManager").Return(mc.txManager)
 	componentMocks.On("PrivateTxManager").Return(mc.privateTxManager)
 	componentMocks.On("TransportManager").Return(mc.transportMgr)
+	componentMocks.On("RegistryManager").Return(mc.registryMgr)
 	mc.transportMgr.On("LocalNodeName").Return("node1").Maybe()
 
 	var p persistence.Persistence
@@ -184,6 +188,7 @@ func TestDomainMissingRegistryAddress(t *testing.T) {
 		txManager:        componentmocks.NewTXManager(t),
 		privateTxManager: componentmocks.NewPrivateTxManager(t),
 		transportMgr:     componentmocks.NewTransportManager(t),
+		registryMgr:      componentmocks.NewRegistryManager(t),
 	}
 	componentMocks := componentmocks.NewAllComponents(t)
 	componentMocks.On("EthClientFactory").Return(mc.ethClientFactory)
@@ -196,6 +201,7 @@ func TestDomainMissingRegistryAddress(t *testing.T) {
 	componentMocks.On("TxManager").Return(mc.txManager)
 	componentMocks.On("PrivateTxManager").Return(mc.privateTxManager)
 	componentMocks.On("TransportManager").Return(mc.transportMgr)
+	componentMocks.On("RegistryManager").Return(mc.registryMgr)
 
 	mp, err := mockpersistence.NewSQLMockProvider()
 	require.NoError(t, err)
@@ -366,3 +372,28 @@ func TestWaitForTransactionTimeout(t *testing.T) {
 	err := dm.ExecAndWaitTransaction(cancelled, uuid.New(), func() error { return nil })
 	assert.Regexp(t, "PD020100", err)
 }
+
+func TestWaitersOverloaded(t *testing.T) {
+	ctx, dm, _, done := newTestDomainManager(t, false, &pldconf.DomainManagerConfig{
+		Domains: map[string]*pldconf.DomainConfig{
+			"domain1": {
+				RegistryAddress: pldtypes.RandHex(20),
+			},
+		},
+		DomainManager: pldconf.DomainManagerManagerConfig{
+			PrivateTxWaiters: pldconf.InflightConfig{
+				MaxInflight: confutil.P(1),
+			},
+		},
+	})
+	defer done()
+
+	req := dm.privateTxWaiter.AddInflight(ctx, uuid.New())
+	defer req.Cancel()
+
+	_, err := dm.ExecDeployAndWait(ctx, uuid.New(), func() error { return nil })
+	assert.Regexp(t, "PD020101", err)
+
+	err = dm.ExecAndWaitTransaction(ctx, uuid.New(), func() error { return nil })
+	assert.Regexp(t, "PD020101", err)
+}
@@ -136,6 +136,9 @@ func (dc *domainContract) ContractConfig() *prototk.ContractConfig {
 }
 
 func (dc *domainContract) InitTransaction(ctx context.Context, tx *components.PrivateTransaction, localTx *components.ResolvedTransaction) error {
+	if err := dc.d.checkNotPaused(ctx); err != nil {
+		return err
+	}
 
 	txSpec, err := dc.buildTransactionSpecification(ctx, localTx, tx.Intent)
 	if err != nil {
@@ -571,7 +574,46 @@ func (dc *domainContract) PrepareTransaction(dCtx components.DomainContext, read
 	if res.Metadata != nil {
 		tx.PreparedMetadata = pldtypes.RawJSON(*res.Metadata)
 	}
-	return nil
+	tx.PreparedExternalCalls, err = dc.mapExternalCalls(dCtx.Ctx(), tx, res.ExternalCalls)
+	return err
+}
+
+func (dc *domainContract) mapExternalCalls(ctx context.Context, tx *components.PrivateTransaction, externalCalls []*prototk.ExternalCall) ([]*components.ExternalCall, error) {
+	if len(externalCalls) == 0 {
+		return nil, nil
+	}
+	calls := make([]*components.ExternalCall, len(externalCalls))
+	for i, ec := range externalCalls {
+		if ec.DedupKey == "" {
+			return nil, i18n.NewError(ctx, msgs.MsgDomainExternalCallNoDedupKey, i, dc.d.name)
+		}
+		var functionABI abi.Entry
+		if err := json.Unmarshal(([]byte)(ec.FunctionAbiJson), &functionABI); err != nil {
+			return nil, i18n.WrapError(ctx, err, msgs.MsgDomainPrivateAbiJsonInvalid)
+		}
+		contractAddress, err := pldtypes.ParseEthAddress(ec.ContractAddress)
+		if err != nil {
+			return nil, err
+		}
+		signer := tx.Signer
+		if ec.RequiredSigner != nil && len(*ec.RequiredSigner) > 0 {
+			signer = *ec.RequiredSigner
+		}
+		calls[i] = &components.ExternalCall{
+			DedupKey: ec.DedupKey,
+			Transaction: &pldapi.TransactionInput{
+				TransactionBase: pldapi.TransactionBase{
+					Type:     pldapi.TransactionTypePublic.Enum(),
+					Function: functionABI.String(),
+					From:     signer,
+					To:       contractAddress,
+					Data:     pldtypes.RawJSON(ec.ParamsJson),
+				},
+				ABI: abi.ABI{&functionABI},
+			},
+		}
+	}
+	return calls, nil
 }
 
 func (dc *domainContract) InitCall(ctx context.Context, callTx *components.ResolvedTransaction) ([]*prototk.ResolveVerifierRequest, error) {
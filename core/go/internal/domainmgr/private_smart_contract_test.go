@@ -1184,6 +1184,83 @@ func TestPrepareTransactionUnknownContract(t *testing.T) {
 	require.Regexp(t, "PD011609", err)
 }
 
+func TestPrepareTransactionExternalCalls(t *testing.T) {
+	td, done := newTestDomain(t, false, goodDomainConf(), mockSchemas(), mockBlockHeight)
+	defer done()
+
+	psc, tx := doDomainInitAssembleTransactionOK(t, td)
+	tx.Signer = "signer1"
+
+	bridgeAddr := pldtypes.RandAddress()
+	td.tp.Functions.PrepareTransaction = func(ctx context.Context, ptr *prototk.PrepareTransactionRequest) (*prototk.PrepareTransactionResponse, error) {
+		return &prototk.PrepareTransactionResponse{
+			Transaction: &prototk.PreparedTransaction{
+				FunctionAbiJson: fakeDownstreamPrivateABI,
+				ParamsJson:      `{"thing": "something else"}`,
+			},
+			ExternalCalls: []*prototk.ExternalCall{
+				{
+					DedupKey:        "notify1",
+					FunctionAbiJson: fakeDownstreamPrivateABI,
+					ParamsJson:      `{"thing": "notify"}`,
+					ContractAddress: bridgeAddr.String(),
+				},
+				{
+					DedupKey:        "notify2",
+					FunctionAbiJson: fakeDownstreamPrivateABI,
+					ParamsJson:      `{"thing": "notify again"}`,
+					ContractAddress: bridgeAddr.String(),
+					RequiredSigner:  confutil.P("bridge.signer"),
+				},
+			},
+		}, nil
+	}
+
+	err := psc.PrepareTransaction(td.mdc, td.c.dbTX, tx)
+	require.NoError(t, err)
+	require.Len(t, tx.PreparedExternalCalls, 2)
+	assert.Equal(t, "notify1", tx.PreparedExternalCalls[0].DedupKey)
+	assert.Equal(t, pldapi.TransactionBase{
+		Type:     pldapi.TransactionTypePublic.Enum(),
+		Function: "doTheNextThing(string)",
+		From:     "signer1",
+		To:       bridgeAddr,
+		Data:     pldtypes.RawJSON(`{"thing": "notify"}`),
+	}, tx.PreparedExternalCalls[0].Transaction.TransactionBase)
+	assert.Equal(t, "bridge.signer", tx.PreparedExternalCalls[1].Transaction.From)
+}
+
+func TestPrepareTransactionExternalCallsBad(t *testing.T) {
+	td, done := newTestDomain(t, false, goodDomainConf(), mockSchemas(), mockBlockHeight)
+	defer done()
+
+	psc, tx := doDomainInitAssembleTransactionOK(t, td)
+	tx.Signer = "signer1"
+
+	var externalCall *prototk.ExternalCall
+	td.tp.Functions.PrepareTransaction = func(ctx context.Context, ptr *prototk.PrepareTransactionRequest) (*prototk.PrepareTransactionResponse, error) {
+		return &prototk.PrepareTransactionResponse{
+			Transaction: &prototk.PreparedTransaction{
+				FunctionAbiJson: fakeDownstreamPrivateABI,
+				ParamsJson:      `{"thing": "something else"}`,
+			},
+			ExternalCalls: []*prototk.ExternalCall{externalCall},
+		}, nil
+	}
+
+	externalCall = &prototk.ExternalCall{}
+	err := psc.PrepareTransaction(td.mdc, td.c.dbTX, tx)
+	assert.Regexp(t, "PD011671", err)
+
+	externalCall = &prototk.ExternalCall{DedupKey: "notify1", FunctionAbiJson: "!!!wrong"}
+	err = psc.PrepareTransaction(td.mdc, td.c.dbTX, tx)
+	assert.Regexp(t, "PD011607", err)
+
+	externalCall = &prototk.ExternalCall{DedupKey: "notify1", FunctionAbiJson: fakeDownstreamPrivateABI, ContractAddress: "wrong"}
+	err = psc.PrepareTransaction(td.mdc, td.c.dbTX, tx)
+	assert.Regexp(t, "bad address", err)
+}
+
 func TestLoadStatesBadSchema(t *testing.T) {
 	td, done := newTestDomain(t, false, goodDomainConf(), mockSchemas(), mockBlockHeight)
 	defer done()
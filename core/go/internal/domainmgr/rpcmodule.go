@@ -17,12 +17,20 @@ package domainmgr
 
 import (
 	"context"
+	"encoding/json"
+	"slices"
+	"strings"
 
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
 	"github.com/kaleido-io/paladin/core/internal/components"
+	"github.com/kaleido-io/paladin/core/internal/msgs"
 	"github.com/kaleido-io/paladin/core/pkg/persistence"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/query"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/rpcclient"
+	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
 	"github.com/kaleido-io/paladin/toolkit/pkg/rpcserver"
 )
 
@@ -32,7 +40,64 @@ func (dm *domainManager) buildRPCModule() {
 		Add("domain_getDomain", dm.rpcGetDomain()).
 		Add("domain_getDomainByAddress", dm.rpcGetDomainByAddress()).
 		Add("domain_querySmartContracts", dm.rpcQuerySmartContracts()).
-		Add("domain_getSmartContractByAddress", dm.rpcGetSmartContractByAddress())
+		Add("domain_getSmartContractByAddress", dm.rpcGetSmartContractByAddress()).
+		Add("domain_pauseDomain", dm.rpcPauseDomain()).
+		Add("domain_resumeDomain", dm.rpcResumeDomain())
+}
+
+// Each configured domain gets its own RPC group, named after the domain, and any method in that
+// group is passed through to the domain plugin if the domain declared it in its config.
+func (dm *domainManager) buildDomainRPCModule(name string) *rpcserver.RPCModule {
+	if strings.Contains(name, "_") {
+		log.L(dm.bgCtx).Warnf("Domain %s cannot expose custom RPC methods as its name contains '_'", name)
+		return nil
+	}
+	return rpcserver.NewRPCModule(name).AddFallback(dm.rpcDomainPassthrough(name))
+}
+
+func (dm *domainManager) rpcDomainPassthrough(name string) rpcserver.RPCHandler {
+	return rpcserver.HandlerFunc(func(ctx context.Context, req *rpcclient.RPCRequest) *rpcclient.RPCResponse {
+		result, err := dm.handleDomainRPC(ctx, name, req)
+		if err != nil {
+			return rpcclient.NewRPCErrorResponse(err, req.ID, rpcclient.RPCCodeInvalidRequest)
+		}
+		return &rpcclient.RPCResponse{
+			JSONRpc: "2.0",
+			ID:      req.ID,
+			Result:  result,
+		}
+	})
+}
+
+func (dm *domainManager) handleDomainRPC(ctx context.Context, name string, req *rpcclient.RPCRequest) (pldtypes.RawJSON, error) {
+	d, err := dm.getDomainByName(ctx, name)
+	if err == nil {
+		err = d.checkInit(ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !slices.Contains(d.config.RpcMethods, strings.TrimPrefix(req.Method, name+"_")) {
+		return nil, i18n.NewError(ctx, msgs.MsgDomainRPCMethodNotSupported, name, req.Method)
+	}
+	params := req.Params
+	if params == nil {
+		params = []pldtypes.RawJSON{}
+	}
+	res, err := d.api.HandleRPC(ctx, &prototk.HandleRPCRequest{
+		Method:     req.Method,
+		ParamsJson: pldtypes.JSONString(params).String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if res.ResultJson == "" {
+		return pldtypes.RawJSON(`null`), nil
+	}
+	if !json.Valid([]byte(res.ResultJson)) {
+		return nil, i18n.NewError(ctx, msgs.MsgDomainRPCInvalidResult, name, req.Method)
+	}
+	return pldtypes.RawJSON(res.ResultJson), nil
 }
 
 func (dm *domainManager) rpcQueryTransactions() rpcserver.RPCHandler {
@@ -51,10 +116,7 @@ func (dm *domainManager) rpcGetDomain() rpcserver.RPCHandler {
 		if err != nil {
 			return nil, err
 		}
-		return &pldapi.Domain{
-			Name:            domain.name,
-			RegistryAddress: domain.registryAddress,
-		}, nil
+		return domain.apiDomain(), nil
 	})
 }
 
@@ -64,10 +126,33 @@ func (dm *domainManager) rpcGetDomainByAddress() rpcserver.RPCHandler {
 		if err != nil {
 			return nil, err
 		}
-		return &pldapi.Domain{
-			Name:            domain.name,
-			RegistryAddress: domain.registryAddress,
-		}, nil
+		return domain.apiDomain(), nil
+	})
+}
+
+func (dm *domainManager) rpcPauseDomain() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod1(func(ctx context.Context, name string) (*pldapi.Domain, error) {
+		domain, err := dm.getDomainByName(ctx, name)
+		if err == nil {
+			err = domain.pause(ctx)
+		}
+		if err != nil {
+			return nil, err
+		}
+		return domain.apiDomain(), nil
+	})
+}
+
+func (dm *domainManager) rpcResumeDomain() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod1(func(ctx context.Context, name string) (*pldapi.Domain, error) {
+		domain, err := dm.getDomainByName(ctx, name)
+		if err == nil {
+			err = domain.resume(ctx)
+		}
+		if err != nil {
+			return nil, err
+		}
+		return domain.apiDomain(), nil
 	})
 }
 
@@ -0,0 +1,68 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filters
+
+import (
+	"bytes"
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"regexp"
+	"strings"
+
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/core/internal/msgs"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+)
+
+// A JSONField is a TEXT column holding a JSON document. Values supplied for
+// the field are compared as compact JSON strings, and the jsonEq operation can
+// be used to compare a value at a path inside the document.
+type JSONField string
+
+var jsonPathSegmentRegexp = regexp.MustCompile(`^[a-zA-Z0-9_\-]+$`)
+
+func (jf JSONField) SQLColumn() string {
+	return (string)(jf)
+}
+
+func (jf JSONField) SupportsLIKE() bool {
+	return false
+}
+
+func (jf JSONField) SQLValue(ctx context.Context, jsonValue pldtypes.RawJSON) (driver.Value, error) {
+	if jsonValue.IsNil() {
+		return nil, nil
+	}
+	buff := new(bytes.Buffer)
+	if err := json.Compact(buff, jsonValue); err != nil {
+		return nil, err
+	}
+	return buff.String(), nil
+}
+
+// Paths are a dot separated set of object keys, or array indexes, such as "owner.keys.0".
+// Only alphanumerics, dash and underscore are supported in each segment.
+func parseJSONPath(ctx context.Context, path string) ([]string, error) {
+	segments := strings.Split(path, ".")
+	for _, s := range segments {
+		if !jsonPathSegmentRegexp.MatchString(s) {
+			return nil, i18n.NewError(ctx, msgs.MsgFiltersJSONPathInvalid, path)
+		}
+	}
+	return segments, nil
+}
@@ -39,6 +39,8 @@ type Traverser[T any] interface {
 	BuildOr(ot ...T) Traverser[T]
 	IsEqual(e *query.OpSingleVal, fieldName string, field FieldResolver, testValue driver.Value) Traverser[T]
 	IsLike(e *query.OpSingleVal, fieldName string, field FieldResolver, testValue driver.Value) Traverser[T]
+	IsContains(e *query.OpSingleVal, fieldName string, field FieldResolver, testValue string) Traverser[T]
+	IsJSONPathEqual(e *query.OpJSONPath, fieldName string, field JSONField, path []string, testValue pldtypes.RawJSON) Traverser[T]
 	IsNull(e *query.Op, fieldName string, field FieldResolver) Traverser[T]
 	IsLessThan(e *query.OpSingleVal, fieldName string, field FieldResolver, testValue driver.Value) Traverser[T]
 	IsLessThanOrEqual(e *query.OpSingleVal, fieldName string, field FieldResolver, testValue driver.Value) Traverser[T]
@@ -190,6 +192,20 @@ func (qt *queryTraverser[T]) addSimpleFilters(t Traverser[T], jf *query.Statemen
 		}
 		t = t.IsLike(e, e.Field, field, testValue)
 	}
+	for _, e := range jf.Contains {
+		field, testValue, err := resolveFieldAndValue(qt.ctx, qt.fieldSet, e.Field, e.Value)
+		if err != nil {
+			return t.WithError(err)
+		}
+		if !field.SupportsLIKE() {
+			return t.WithError(i18n.NewError(qt.ctx, msgs.MsgFiltersFieldTypeDoesNotSupportLike, field))
+		}
+		testString, ok := testValue.(string)
+		if !ok {
+			return t.WithError(i18n.NewError(qt.ctx, msgs.MsgFiltersValueInvalidForString, e.Value))
+		}
+		t = t.IsContains(e, e.Field, field, testString)
+	}
 	for _, e := range jf.Null {
 		field, err := resolveField(qt.ctx, qt.fieldSet, e.Field)
 		if err != nil {
@@ -200,6 +216,33 @@ func (qt *queryTraverser[T]) addSimpleFilters(t Traverser[T], jf *query.Statemen
 	return t
 }
 
+func (qt *queryTraverser[T]) addJSONPathEqual(t Traverser[T], e *query.OpJSONPath) Traverser[T] {
+	field, err := resolveField(qt.ctx, qt.fieldSet, e.Field)
+	if err != nil {
+		return t.WithError(err)
+	}
+	jsonField, ok := field.(JSONField)
+	if !ok {
+		return t.WithError(i18n.NewError(qt.ctx, msgs.MsgFiltersFieldTypeNotJSON, field))
+	}
+	if e.CaseInsensitive {
+		return t.WithError(i18n.NewError(qt.ctx, msgs.MsgFiltersJSONQueryOpUnsupportedMod, "jsonEq", justCaseInsensitive))
+	}
+	path, err := parseJSONPath(qt.ctx, e.Path)
+	if err != nil {
+		return t.WithError(err)
+	}
+	testValue, err := resolveValue(qt.ctx, e.Field, jsonField, e.Value)
+	if err != nil {
+		return t.WithError(err)
+	}
+	if testValue == nil {
+		// Use the null operation on the whole field to check for null
+		return t.WithError(i18n.NewError(qt.ctx, msgs.MsgFiltersValueMissing, e.Field))
+	}
+	return t.IsJSONPathEqual(e, e.Field, jsonField, path, pldtypes.RawJSON(testValue.(string)))
+}
+
 func joinShortNames(long, short, negated []*query.OpSingleVal) []*query.OpSingleVal {
 	res := make([]*query.OpSingleVal, len(long)+len(short)+len(negated))
 	copy(res, long)
@@ -279,6 +322,12 @@ func (qt *queryTraverser[T]) BuildAndFilter(t Traverser[T], jf *query.Statements
 		}
 		t = t.IsIn(e, e.Field, field, testValues)
 	}
+	for _, e := range jf.JSONEq {
+		t = qt.addJSONPathEqual(t, e)
+		if t.Error() != nil {
+			return t
+		}
+	}
 	if len(jf.Or) > 0 {
 		var ors []T
 		for _, child := range jf.Or {
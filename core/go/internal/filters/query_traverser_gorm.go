@@ -21,6 +21,8 @@ import (
 	"database/sql/driver"
 	"fmt"
 
+	"github.com/kaleido-io/paladin/core/pkg/persistence"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/query"
 	"gorm.io/gorm"
 )
@@ -121,6 +123,21 @@ func (t *gormTraverser) IsLike(e *query.OpSingleVal, fieldName string, field Fie
 	return t
 }
 
+func (t *gormTraverser) IsContains(e *query.OpSingleVal, fieldName string, field FieldResolver, testValue string) Traverser[*gormTraverser] {
+	clause, arg := persistence.GetDialect(t.rootDB).Contains(field.SQLColumn(), e.Not, e.CaseInsensitive, testValue)
+	t.db = t.db.Where(clause, arg)
+	return t
+}
+
+func (t *gormTraverser) IsJSONPathEqual(e *query.OpJSONPath, fieldName string, field JSONField, path []string, testValue pldtypes.RawJSON) Traverser[*gormTraverser] {
+	clause, pathArg := persistence.GetDialect(t.rootDB).JSONPathEqual(field.SQLColumn(), path)
+	if e.Not {
+		clause = "NOT (" + clause + ")"
+	}
+	t.db = t.db.Where(clause, pathArg, testValue.String())
+	return t
+}
+
 func (t *gormTraverser) IsNull(e *query.Op, fieldName string, field FieldResolver) Traverser[*gormTraverser] {
 	if e.Not {
 		t.db = t.db.Where(fmt.Sprintf("%s IS NOT NULL", field.SQLColumn()))
@@ -21,6 +21,7 @@ import (
 	"encoding/json"
 	"testing"
 
+	"github.com/kaleido-io/paladin/core/pkg/persistence"
 	"github.com/kaleido-io/paladin/core/pkg/persistence/mockpersistence"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/query"
 	"github.com/stretchr/testify/assert"
@@ -628,3 +629,77 @@ func TestBuildQueryJSONContainsShortNames(t *testing.T) {
 	})
 	assert.Equal(t, "SELECT count(*) FROM \"test\" WHERE sequence <= 12345 AND sequence > 12345", generatedSQL)
 }
+
+func TestBuildQueryJSONContainsAndJSONPathPostgres(t *testing.T) {
+
+	var qf query.QueryJSON
+	err := json.Unmarshal([]byte(`{
+		"contains": [
+			{"field": "name", "value": "50%_off"},
+			{"field": "name", "value": "sale", "caseInsensitive": true, "not": true}
+		],
+		"jsonEq": [
+			{"field": "props", "path": "owner.name", "value": "bob"},
+			{"field": "props", "path": "count", "value": 5, "not": true}
+		]
+	}`), &qf)
+	require.NoError(t, err)
+
+	p, err := mockpersistence.NewSQLMockProvider()
+	require.NoError(t, err)
+	generatedSQL := p.P.DB().ToSQL(func(tx *gorm.DB) *gorm.DB {
+		var count int64
+		db := BuildGORM(context.Background(), &qf, tx.Table("test"), FieldMap{
+			"name":  StringField("name"),
+			"props": JSONField("props"),
+		}).Count(&count)
+		require.NoError(t, db.Error)
+		return db
+	})
+	assert.Equal(t, `SELECT count(*) FROM "test" WHERE name LIKE '%50\%\_off%' AND name NOT ILIKE '%sale%' AND `+
+		`(props)::jsonb #> '{owner,name}'::text[] = '"bob"'::jsonb AND NOT ((props)::jsonb #> '{count}'::text[] = '5'::jsonb)`, generatedSQL)
+}
+
+func TestBuildQueryJSONContainsAndJSONPathSQLite(t *testing.T) {
+	ctx := context.Background()
+	p, done, err := persistence.NewUnitTestPersistence(ctx, "filters")
+	require.NoError(t, err)
+	defer done()
+
+	if p.DB().Dialector.Name() != persistence.DialectSQLite {
+		t.Skip("SQLite specific test")
+	}
+
+	err = p.DB().Exec(`CREATE TABLE "filter_test" ("name" TEXT, "props" TEXT)`).Error
+	require.NoError(t, err)
+	err = p.DB().Exec(`INSERT INTO "filter_test" ("name", "props") VALUES
+		('Big SALE today', '{"owner":{"name":"bob"},"count":5,"tags":["a","b"]}'),
+		('50%_off',        '{"owner":{"name":"sally"},"count":10,"tags":["c"]}')`).Error
+	require.NoError(t, err)
+
+	fields := FieldMap{
+		"name":  StringField("name"),
+		"props": JSONField("props"),
+	}
+	runQuery := func(jq string) []string {
+		var qf query.QueryJSON
+		err := json.Unmarshal([]byte(jq), &qf)
+		require.NoError(t, err)
+		var names []string
+		err = BuildGORM(ctx, &qf, p.DB().Table("filter_test"), fields).Order("name").Pluck("name", &names).Error
+		require.NoError(t, err)
+		return names
+	}
+
+	assert.Equal(t, []string{"Big SALE today"}, runQuery(`{"contains": [{"field": "name", "value": "SALE"}]}`))
+	assert.Empty(t, runQuery(`{"contains": [{"field": "name", "value": "sale"}]}`))
+	assert.Equal(t, []string{"Big SALE today"}, runQuery(`{"contains": [{"field": "name", "value": "sale", "caseInsensitive": true}]}`))
+	assert.Equal(t, []string{"50%_off"}, runQuery(`{"contains": [{"field": "name", "value": "%_"}]}`))
+	assert.Equal(t, []string{"50%_off"}, runQuery(`{"contains": [{"field": "name", "value": "sale", "caseInsensitive": true, "not": true}]}`))
+
+	assert.Equal(t, []string{"50%_off"}, runQuery(`{"jsonEq": [{"field": "props", "path": "owner.name", "value": "sally"}]}`))
+	assert.Equal(t, []string{"Big SALE today"}, runQuery(`{"jsonEq": [{"field": "props", "path": "count", "value": 5}]}`))
+	assert.Equal(t, []string{"Big SALE today"}, runQuery(`{"jsonEq": [{"field": "props", "path": "tags.1", "value": "b"}]}`))
+	assert.Equal(t, []string{"50%_off"}, runQuery(`{"jsonEq": [{"field": "props", "path": "tags", "value": ["c"]}]}`))
+	assert.Equal(t, []string{"50%_off"}, runQuery(`{"jsonEq": [{"field": "props", "path": "count", "value": 5, "not": true}]}`))
+}
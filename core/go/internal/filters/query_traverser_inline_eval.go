@@ -19,7 +19,10 @@ package filters
 import (
 	"context"
 	"database/sql/driver"
+	"encoding/json"
+	"reflect"
 	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
@@ -211,6 +214,67 @@ func (t *inlineEval) IsLike(e *query.OpSingleVal, fieldName string, field FieldR
 	)
 }
 
+func (t *inlineEval) IsContains(e *query.OpSingleVal, fieldName string, field FieldResolver, testValue string) Traverser[*inlineEval] {
+	return t.doCompare(&e.Op, fieldName, field, testValue,
+		func(caseInsensitive bool, s1, s2 string) bool {
+			if caseInsensitive {
+				return strings.Contains(strings.ToLower(s1), strings.ToLower(s2))
+			}
+			return strings.Contains(s1, s2)
+		},
+		t.int64LikeNotSupported,
+	)
+}
+
+func (t *inlineEval) IsJSONPathEqual(e *query.OpJSONPath, fieldName string, field JSONField, path []string, testValue pldtypes.RawJSON) Traverser[*inlineEval] {
+	actualValue, err := t.valueSet.GetValue(t.ctx, fieldName, field)
+	if err != nil {
+		return t.withError(err)
+	}
+	valMatches := false
+	if actualValue != nil {
+		strValue, ok := actualValue.(string)
+		if !ok {
+			return t.withError(i18n.NewError(t.ctx, msgs.MsgFiltersUnexpectedResolvedValueType, actualValue, testValue))
+		}
+		var doc, expected any
+		if err := json.Unmarshal([]byte(strValue), &doc); err != nil {
+			return t.withError(err)
+		}
+		if err := json.Unmarshal(testValue, &expected); err != nil {
+			return t.withError(err)
+		}
+		found, exists := jsonPathLookup(doc, path)
+		valMatches = exists && reflect.DeepEqual(found, expected)
+	}
+	if e.Not {
+		valMatches = !valMatches
+	}
+	t.matches = t.matches && valMatches
+	return t
+}
+
+func jsonPathLookup(v any, path []string) (any, bool) {
+	for _, segment := range path {
+		switch vt := v.(type) {
+		case map[string]any:
+			var exists bool
+			if v, exists = vt[segment]; !exists {
+				return nil, false
+			}
+		case []any:
+			idx, err := strconv.Atoi(segment)
+			if err != nil || idx >= len(vt) {
+				return nil, false
+			}
+			v = vt[idx]
+		default:
+			return nil, false
+		}
+	}
+	return v, true
+}
+
 func (t *inlineEval) int64LikeNotSupported(s1, s2 int64) bool {
 	_ = t.WithError(i18n.NewError(t.ctx, msgs.MsgFiltersLikeNotSupportedForIntValue))
 	return false
@@ -36,6 +36,7 @@ var allTypesFieldMap = FieldMap{
 	"boolField":    Int64BoolField("bool_field"),
 	"int256Field":  Int256Field("int256_field"),
 	"uint256Field": Uint256Field("uint256_field"),
+	"jsonField":    JSONField("json_field"),
 }
 
 type badTypeResolver string
@@ -836,3 +837,110 @@ func TestEvalQueryOrRollup(t *testing.T) {
 	assert.Regexp(t, "pop", res.Error())
 
 }
+
+func TestEvalQueryMatchContains(t *testing.T) {
+
+	var qf *query.QueryJSON
+	err := json.Unmarshal([]byte(`{"contains": [{"field": "int64Field", "value": "111"}]}`), &qf)
+	require.NoError(t, err)
+	_, err = EvalQuery(context.Background(), qf, allTypesFieldMap, ResolvingValueSet{})
+	assert.Regexp(t, "PD010716", err)
+
+	err = json.Unmarshal([]byte(`{"contains": [{"field": "stringField", "value": "lo_w%"}]}`), &qf)
+	require.NoError(t, err)
+	match, err := EvalQuery(context.Background(), qf, allTypesFieldMap, ResolvingValueSet{
+		"stringField": pldtypes.RawJSON(`"hello_w%rld"`),
+	})
+	require.NoError(t, err)
+	assert.True(t, match)
+	match, err = EvalQuery(context.Background(), qf, allTypesFieldMap, ResolvingValueSet{
+		"stringField": pldtypes.RawJSON(`"hello world"`),
+	})
+	require.NoError(t, err)
+	assert.False(t, match)
+
+	err = json.Unmarshal([]byte(`{"contains": [{"field": "stringField", "value": "WORLD", "caseInsensitive": true, "not": true}]}`), &qf)
+	require.NoError(t, err)
+	match, err = EvalQuery(context.Background(), qf, allTypesFieldMap, ResolvingValueSet{
+		"stringField": pldtypes.RawJSON(`"Hello World"`),
+	})
+	require.NoError(t, err)
+	assert.False(t, match)
+	match, err = EvalQuery(context.Background(), qf, allTypesFieldMap, ResolvingValueSet{
+		"stringField": pldtypes.RawJSON(`"Hello"`),
+	})
+	require.NoError(t, err)
+	assert.True(t, match)
+}
+
+func TestEvalQueryMatchJSONPath(t *testing.T) {
+
+	doc := ResolvingValueSet{
+		"jsonField": pldtypes.RawJSON(`{"name":"secret things","owner":{"keys":["a","b"],"count":5}}`),
+	}
+
+	for _, tc := range []struct {
+		query   string
+		matches bool
+	}{
+		{`{"jsonEq": [{"field": "jsonField", "path": "name", "value": "secret things"}]}`, true},
+		{`{"jsonEq": [{"field": "jsonField", "path": "name", "value": "other"}]}`, false},
+		{`{"jsonEq": [{"field": "jsonField", "path": "name", "value": "other", "not": true}]}`, true},
+		{`{"jsonEq": [{"field": "jsonField", "path": "owner.count", "value": 5}]}`, true},
+		{`{"jsonEq": [{"field": "jsonField", "path": "owner.count", "value": "5"}]}`, false},
+		{`{"jsonEq": [{"field": "jsonField", "path": "owner.keys.1", "value": "b"}]}`, true},
+		{`{"jsonEq": [{"field": "jsonField", "path": "owner.keys.2", "value": "b"}]}`, false},
+		{`{"jsonEq": [{"field": "jsonField", "path": "owner.keys", "value": ["a","b"]}]}`, true},
+		{`{"jsonEq": [{"field": "jsonField", "path": "name.sub", "value": "x"}]}`, false},
+		{`{"jsonEq": [{"field": "jsonField", "path": "missing", "value": "x"}]}`, false},
+	} {
+		var qf *query.QueryJSON
+		err := json.Unmarshal([]byte(tc.query), &qf)
+		require.NoError(t, err)
+		match, err := EvalQuery(context.Background(), qf, allTypesFieldMap, doc)
+		require.NoError(t, err)
+		assert.Equal(t, tc.matches, match, tc.query)
+	}
+
+	// Null value never matches
+	var qf *query.QueryJSON
+	err := json.Unmarshal([]byte(`{"jsonEq": [{"field": "jsonField", "path": "name", "value": "x"}]}`), &qf)
+	require.NoError(t, err)
+	match, err := EvalQuery(context.Background(), qf, allTypesFieldMap, ResolvingValueSet{})
+	require.NoError(t, err)
+	assert.False(t, match)
+}
+
+func TestEvalQueryJSONPathErrors(t *testing.T) {
+
+	for _, tc := range []struct {
+		query string
+		err   string
+	}{
+		{`{"jsonEq": [{"field": "stringField", "path": "a", "value": "x"}]}`, "PD010722"},
+		{`{"jsonEq": [{"field": "jsonField", "path": "a..b", "value": "x"}]}`, "PD010723"},
+		{`{"jsonEq": [{"field": "jsonField", "path": "a'", "value": "x"}]}`, "PD010723"},
+		{`{"jsonEq": [{"field": "jsonField", "path": "a", "value": "x", "caseInsensitive": true}]}`, "PD010702"},
+		{`{"jsonEq": [{"field": "jsonField", "path": "a"}]}`, "PD010708"},
+		{`{"jsonEq": [{"field": "jsonField", "path": "a", "value": null}]}`, "PD010708"},
+		{`{"jsonEq": [{"field": "unknown", "path": "a", "value": "x"}]}`, "PD010700"},
+	} {
+		var qf *query.QueryJSON
+		err := json.Unmarshal([]byte(tc.query), &qf)
+		require.NoError(t, err)
+		_, err = EvalQuery(context.Background(), qf, allTypesFieldMap, ResolvingValueSet{})
+		assert.Regexp(t, tc.err, err, tc.query)
+	}
+
+	var qf *query.QueryJSON
+	err := json.Unmarshal([]byte(`{"jsonEq": [{"field": "jsonField", "path": "a", "value": "x"}]}`), &qf)
+	require.NoError(t, err)
+	_, err = EvalQuery(context.Background(), qf, allTypesFieldMap, PassthroughValueSet{
+		"jsonField": int64(1),
+	})
+	assert.Regexp(t, "PD010713", err)
+	_, err = EvalQuery(context.Background(), qf, allTypesFieldMap, PassthroughValueSet{
+		"jsonField": "!{ not json",
+	})
+	assert.Error(t, err)
+}
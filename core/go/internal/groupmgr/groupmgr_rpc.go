@@ -38,6 +38,10 @@ func (gm *groupManager) initRPC() {
 		Add("pgroup_getGroupByAddress", gm.rpcGetGroupByAddress()).
 		Add("pgroup_queryGroups", gm.rpcQueryGroups()).
 		Add("pgroup_queryGroupsWithMember", gm.rpcQueryGroupsWithMember()).
+		Add("pgroup_registerTemplate", gm.rpcRegisterTemplate()).
+		Add("pgroup_getTemplate", gm.rpcGetTemplate()).
+		Add("pgroup_listTemplates", gm.rpcListTemplates()).
+		Add("pgroup_deleteTemplate", gm.rpcDeleteTemplate()).
 		Add("pgroup_sendTransaction", gm.rpcSendTransaction()).
 		Add("pgroup_call", gm.rpcCall()).
 		Add("pgroup_createMessageListener", gm.rpcCreateMessageListener()).
@@ -86,6 +90,37 @@ func (gm *groupManager) rpcQueryGroupsWithMember() rpcserver.RPCHandler {
 	})
 }
 
+func (gm *groupManager) rpcRegisterTemplate() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod1(func(ctx context.Context, template pldapi.PrivacyGroupTemplate) (registered *pldapi.PrivacyGroupTemplate, err error) {
+		err = gm.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
+			registered, err = gm.RegisterTemplate(ctx, dbTX, &template)
+			return err
+		})
+		return registered, err
+	})
+}
+
+func (gm *groupManager) rpcGetTemplate() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod1(func(ctx context.Context, name string) (*pldapi.PrivacyGroupTemplate, error) {
+		return gm.GetTemplate(ctx, gm.p.NOTX(), name)
+	})
+}
+
+func (gm *groupManager) rpcListTemplates() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod0(func(ctx context.Context) ([]*pldapi.PrivacyGroupTemplate, error) {
+		return gm.ListTemplates(ctx, gm.p.NOTX())
+	})
+}
+
+func (gm *groupManager) rpcDeleteTemplate() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod1(func(ctx context.Context, name string) (bool, error) {
+		err := gm.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
+			return gm.DeleteTemplate(ctx, dbTX, name)
+		})
+		return err == nil, err
+	})
+}
+
 func (gm *groupManager) rpcSendTransaction() rpcserver.RPCHandler {
 	return rpcserver.RPCMethod1(func(ctx context.Context, tx *pldapi.PrivacyGroupEVMTXInput) (txID *uuid.UUID, err error) {
 		err = gm.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
@@ -230,6 +230,15 @@ func TestPrivacyGroupRPCLifecycleRealDB(t *testing.T) {
 	require.Equal(t, groupID, groups[0].ID)
 	require.Equal(t, []string{"me@node1", "you@node2"}, groups[0].Members)
 
+	// Search for it by a property
+	groups, err = pgroupRPC.QueryGroups(ctx, query.NewQueryBuilder().JSONEqual("properties", "name", "secret things").Limit(1).Query())
+	require.NoError(t, err)
+	require.Len(t, groups, 1)
+	require.Equal(t, groupID, groups[0].ID)
+	groups, err = pgroupRPC.QueryGroups(ctx, query.NewQueryBuilder().JSONEqual("properties", "name", "other things").Limit(1).Query())
+	require.NoError(t, err)
+	require.Empty(t, groups)
+
 	// Send a transaction to it
 	tx1ID, err := pgroupRPC.SendTransaction(ctx, &pldapi.PrivacyGroupEVMTXInput{
 		Domain:         "domain1",
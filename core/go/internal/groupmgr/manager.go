@@ -47,6 +47,7 @@ var groupDBOnlyFilters = filters.FieldMap{
 	"contractAddress": filters.HexBytesField(`"Receipt"."contract_address"`),
 	"genesisSalt":     filters.HexBytesField("genesis_salt"),
 	"genesisSchema":   filters.HexBytesField("genesis_schema"),
+	"properties":      filters.JSONField(`"privacy_groups"."properties"`),
 }
 
 type groupManager struct {
@@ -70,6 +71,11 @@ type groupManager struct {
 	messageListenersLoadPageSize int
 	messageListenerLock          sync.Mutex
 	messageListeners             map[string]*messageListener
+
+	configTemplates          map[string]*pldapi.PrivacyGroupTemplate
+	templatesAllowed         map[string]bool
+	templatesRequired        bool
+	templatesAPIRegistration bool
 }
 
 type referencedReceipt struct {
@@ -112,7 +118,7 @@ func (pgm persistedGroupMember) TableName() string {
 func NewGroupManager(bgCtx context.Context, conf *pldconf.GroupManagerConfig) components.GroupManager {
 	gm := &groupManager{
 		conf:             conf,
-		deployedPGCache:  cache.NewCache[string, *pldapi.PrivacyGroup](&conf.Cache, &pldconf.GroupManagerDefaults.Cache),
+		deployedPGCache:  cache.NewNamedCache[string, *pldapi.PrivacyGroup]("groupmgr.privacyGroups", &conf.Cache, &pldconf.GroupManagerDefaults.Cache),
 		messageListeners: make(map[string]*messageListener),
 	}
 	gm.messagesInit()
@@ -135,6 +141,9 @@ func (gm *groupManager) PostInit(c components.AllComponents) error {
 	gm.p = c.Persistence()
 	gm.transportManager = c.TransportManager()
 	gm.registryManager = c.RegistryManager()
+	if err := gm.templatesInit(gm.bgCtx); err != nil {
+		return err
+	}
 	return gm.loadMessageListeners()
 }
 
@@ -236,6 +245,10 @@ func (gm *groupManager) validateGroupGenesisSet(ctx context.Context, domainName
 }
 
 func (gm *groupManager) CreateGroup(ctx context.Context, dbTX persistence.DBTX, spec *pldapi.PrivacyGroupInput) (group *pldapi.PrivacyGroup, err error) {
+	if err := gm.applyTemplate(ctx, dbTX, spec); err != nil {
+		return nil, err
+	}
+
 	pgGenesis := &pldapi.PrivacyGroupGenesisState{
 		GenesisSalt: pldtypes.RandBytes32(),
 		Name:        spec.Name,
@@ -436,7 +449,7 @@ func (gm *groupManager) GetGroupByAddress(ctx context.Context, dbTX persistence.
 	return groups[0], nil
 }
 
-// This function queries the groups only using what's in the DB, without allowing properties of the group to be used to do the query
+// This function queries the groups only using what's in the DB - properties of the group can be queried with jsonEq
 func (gm *groupManager) queryGroupsCommon(ctx context.Context, dbTX persistence.DBTX, jq *query.QueryJSON, finalizers ...func(db *gorm.DB) *gorm.DB) ([]*pldapi.PrivacyGroup, error) {
 	qw := &filters.QueryWrapper[persistedGroup, pldapi.PrivacyGroup]{
 		P:           gm.p,
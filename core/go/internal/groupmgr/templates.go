@@ -0,0 +1,315 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package groupmgr
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/internal/msgs"
+	"github.com/kaleido-io/paladin/core/pkg/persistence"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+)
+
+// Templates registered via the API are persisted. Templates defined in config are held
+// in memory only, and take precedence (a template of the same name cannot be registered).
+type persistedGroupTemplate struct {
+	Name       string             `gorm:"column:name;primaryKey"`
+	Created    pldtypes.Timestamp `gorm:"column:created"`
+	Domain     string             `gorm:"column:domain"`
+	Definition pldtypes.RawJSON   `gorm:"column:definition"`
+}
+
+func (persistedGroupTemplate) TableName() string {
+	return "privacy_group_templates"
+}
+
+func mapTemplateConfig(tc *pldconf.GroupTemplateConfig) *pldapi.PrivacyGroupTemplate {
+	t := &pldapi.PrivacyGroupTemplate{
+		Name:               tc.Name,
+		Source:             pldapi.PrivacyGroupTemplateSourceConfig.Enum(),
+		Domain:             tc.Domain,
+		Properties:         tc.Properties,
+		RequiredProperties: tc.RequiredProperties,
+		Configuration:      tc.Configuration,
+	}
+	for _, r := range tc.Roles {
+		t.Roles = append(t.Roles, &pldapi.PrivacyGroupTemplateRole{
+			Name:       r.Name,
+			MinMembers: r.MinMembers,
+			MaxMembers: r.MaxMembers,
+		})
+	}
+	return t
+}
+
+func (gm *groupManager) templatesInit(ctx context.Context) error {
+	conf := &gm.conf.Templates
+	defaults := &pldconf.GroupManagerDefaults.Templates
+	gm.templatesRequired = confutil.Bool(conf.Required, *defaults.Required)
+	gm.templatesAPIRegistration = confutil.Bool(conf.AllowAPIRegistration, *defaults.AllowAPIRegistration)
+	if len(conf.Allowed) > 0 {
+		gm.templatesAllowed = make(map[string]bool, len(conf.Allowed))
+		for _, name := range conf.Allowed {
+			gm.templatesAllowed[name] = true
+		}
+	}
+	gm.configTemplates = make(map[string]*pldapi.PrivacyGroupTemplate, len(conf.Definitions))
+	for _, tc := range conf.Definitions {
+		t := mapTemplateConfig(tc)
+		if err := gm.validateTemplate(ctx, t); err != nil {
+			return err
+		}
+		if gm.configTemplates[t.Name] != nil {
+			return i18n.NewError(ctx, msgs.MsgPGroupsTemplateExists, t.Name)
+		}
+		gm.configTemplates[t.Name] = t
+	}
+	return nil
+}
+
+func (gm *groupManager) validateTemplate(ctx context.Context, t *pldapi.PrivacyGroupTemplate) error {
+	if err := pldtypes.ValidateSafeCharsStartEndAlphaNum(ctx, t.Name, pldtypes.DefaultNameMaxLen, "name"); err != nil {
+		return err
+	}
+	if t.Domain == "" {
+		return i18n.NewError(ctx, msgs.MsgPGroupsNoDomain)
+	}
+	for k := range t.Properties {
+		if isReservedTemplateProperty(k) {
+			return i18n.NewError(ctx, msgs.MsgPGroupsTemplateReservedProperty, k)
+		}
+	}
+	roleNames := make(map[string]bool, len(t.Roles))
+	for _, r := range t.Roles {
+		if err := pldtypes.ValidateSafeCharsStartEndAlphaNum(ctx, r.Name, pldtypes.DefaultNameMaxLen, "name"); err != nil {
+			return err
+		}
+		if roleNames[r.Name] || r.MinMembers < 0 || (r.MaxMembers != nil && (*r.MaxMembers < 1 || *r.MaxMembers < r.MinMembers)) {
+			return i18n.NewError(ctx, msgs.MsgPGroupsTemplateRoleInvalid, r.Name, t.Name)
+		}
+		roleNames[r.Name] = true
+	}
+	return nil
+}
+
+func isReservedTemplateProperty(k string) bool {
+	return k == pldapi.PrivacyGroupTemplateProperty || strings.HasPrefix(k, pldapi.PrivacyGroupRolePropertyPrefix)
+}
+
+func (gm *groupManager) RegisterTemplate(ctx context.Context, dbTX persistence.DBTX, t *pldapi.PrivacyGroupTemplate) (*pldapi.PrivacyGroupTemplate, error) {
+	if !gm.templatesAPIRegistration {
+		return nil, i18n.NewError(ctx, msgs.MsgPGroupsTemplateRegistrationDisabled)
+	}
+	if gm.configTemplates[t.Name] != nil {
+		return nil, i18n.NewError(ctx, msgs.MsgPGroupsTemplateConfigured, t.Name)
+	}
+	if err := gm.validateTemplate(ctx, t); err != nil {
+		return nil, err
+	}
+	existing, err := gm.GetTemplate(ctx, dbTX, t.Name)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, i18n.NewError(ctx, msgs.MsgPGroupsTemplateExists, t.Name)
+	}
+
+	t.Source = pldapi.PrivacyGroupTemplateSourceAPI.Enum()
+	t.Created = pldtypes.TimestampNow()
+	log.L(ctx).Infof("Registering privacy group template '%s' for domain '%s'", t.Name, t.Domain)
+	err = dbTX.DB().
+		WithContext(ctx).
+		Create(&persistedGroupTemplate{
+			Name:       t.Name,
+			Created:    t.Created,
+			Domain:     t.Domain,
+			Definition: pldtypes.JSONString(t),
+		}).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+func (gm *groupManager) GetTemplate(ctx context.Context, dbTX persistence.DBTX, name string) (*pldapi.PrivacyGroupTemplate, error) {
+	if t := gm.configTemplates[name]; t != nil {
+		return t, nil
+	}
+	var pts []*persistedGroupTemplate
+	err := dbTX.DB().
+		WithContext(ctx).
+		Where("name = ?", name).
+		Limit(1).
+		Find(&pts).
+		Error
+	if err != nil || len(pts) == 0 {
+		return nil, err
+	}
+	return mapPersistedTemplate(pts[0])
+}
+
+func mapPersistedTemplate(pt *persistedGroupTemplate) (*pldapi.PrivacyGroupTemplate, error) {
+	var t pldapi.PrivacyGroupTemplate
+	if err := json.Unmarshal(pt.Definition, &t); err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+func (gm *groupManager) ListTemplates(ctx context.Context, dbTX persistence.DBTX) ([]*pldapi.PrivacyGroupTemplate, error) {
+	var pts []*persistedGroupTemplate
+	err := dbTX.DB().
+		WithContext(ctx).
+		Find(&pts).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	templates := make([]*pldapi.PrivacyGroupTemplate, 0, len(gm.configTemplates)+len(pts))
+	for _, t := range gm.configTemplates {
+		templates = append(templates, t)
+	}
+	for _, pt := range pts {
+		t, err := mapPersistedTemplate(pt)
+		if err != nil {
+			return nil, err
+		}
+		templates = append(templates, t)
+	}
+	sort.Slice(templates, func(i, j int) bool { return templates[i].Name < templates[j].Name })
+	return templates, nil
+}
+
+func (gm *groupManager) DeleteTemplate(ctx context.Context, dbTX persistence.DBTX, name string) error {
+	if gm.configTemplates[name] != nil {
+		return i18n.NewError(ctx, msgs.MsgPGroupsTemplateConfigured, name)
+	}
+	res := dbTX.DB().
+		WithContext(ctx).
+		Where("name = ?", name).
+		Delete(&persistedGroupTemplate{})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return i18n.NewError(ctx, msgs.MsgPGroupsTemplateNotFound, name)
+	}
+	return nil
+}
+
+// Updates the input to create a group, with the domain, properties, configuration and members from the
+// template (if one is specified), and enforces the template policy of this node.
+func (gm *groupManager) applyTemplate(ctx context.Context, dbTX persistence.DBTX, spec *pldapi.PrivacyGroupInput) error {
+	if spec.Template == "" {
+		if gm.templatesRequired {
+			return i18n.NewError(ctx, msgs.MsgPGroupsTemplateRequired)
+		}
+		if len(spec.Roles) > 0 {
+			return i18n.NewError(ctx, msgs.MsgPGroupsRolesWithoutTemplate)
+		}
+		return nil
+	}
+
+	if gm.templatesAllowed != nil && !gm.templatesAllowed[spec.Template] {
+		return i18n.NewError(ctx, msgs.MsgPGroupsTemplateNotAllowed, spec.Template)
+	}
+	t, err := gm.GetTemplate(ctx, dbTX, spec.Template)
+	if err != nil {
+		return err
+	}
+	if t == nil {
+		return i18n.NewError(ctx, msgs.MsgPGroupsTemplateNotFound, spec.Template)
+	}
+
+	if spec.Domain == "" {
+		spec.Domain = t.Domain
+	} else if spec.Domain != t.Domain {
+		return i18n.NewError(ctx, msgs.MsgPGroupsTemplateDomainMismatch, t.Name, t.Domain, spec.Domain)
+	}
+
+	// Supplied properties and configuration override the defaults in the template
+	properties := make(map[string]string, len(t.Properties)+len(spec.Properties)+len(t.Roles)+1)
+	for k, v := range t.Properties {
+		properties[k] = v
+	}
+	for k, v := range spec.Properties {
+		if isReservedTemplateProperty(k) {
+			return i18n.NewError(ctx, msgs.MsgPGroupsTemplateReservedProperty, k)
+		}
+		properties[k] = v
+	}
+	for _, k := range t.RequiredProperties {
+		if properties[k] == "" {
+			return i18n.NewError(ctx, msgs.MsgPGroupsTemplatePropertyRequired, k, t.Name)
+		}
+	}
+	configuration := make(map[string]string, len(t.Configuration)+len(spec.Configuration))
+	for k, v := range t.Configuration {
+		configuration[k] = v
+	}
+	for k, v := range spec.Configuration {
+		configuration[k] = v
+	}
+
+	// Role members are added to the group (if not already listed), and the assignments recorded in the properties
+	roles := make(map[string]*pldapi.PrivacyGroupTemplateRole, len(t.Roles))
+	for _, r := range t.Roles {
+		roles[r.Name] = r
+	}
+	for roleName := range spec.Roles {
+		if roles[roleName] == nil {
+			return i18n.NewError(ctx, msgs.MsgPGroupsTemplateRoleUnknown, roleName, t.Name)
+		}
+	}
+	members := append([]string{}, spec.Members...)
+	isMember := make(map[string]bool, len(members))
+	for _, m := range members {
+		isMember[m] = true
+	}
+	for _, r := range t.Roles {
+		roleMembers := spec.Roles[r.Name]
+		if len(roleMembers) < r.MinMembers {
+			return i18n.NewError(ctx, msgs.MsgPGroupsTemplateRoleMinMembers, r.Name, t.Name, r.MinMembers)
+		}
+		if r.MaxMembers != nil && len(roleMembers) > *r.MaxMembers {
+			return i18n.NewError(ctx, msgs.MsgPGroupsTemplateRoleMaxMembers, r.Name, t.Name, *r.MaxMembers)
+		}
+		if len(roleMembers) > 0 {
+			properties[pldapi.PrivacyGroupRolePropertyPrefix+r.Name] = strings.Join(roleMembers, ",")
+		}
+		for _, m := range roleMembers {
+			if !isMember[m] {
+				members = append(members, m)
+				isMember[m] = true
+			}
+		}
+	}
+	properties[pldapi.PrivacyGroupTemplateProperty] = t.Name
+
+	spec.Members = members
+	spec.Properties = properties
+	spec.Configuration = configuration
+	return nil
+}
@@ -0,0 +1,287 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package groupmgr
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/pkg/persistence"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func testTemplateConfig() *pldconf.GroupManagerConfig {
+	return &pldconf.GroupManagerConfig{
+		Templates: pldconf.GroupTemplates{
+			Definitions: []*pldconf.GroupTemplateConfig{{
+				Name:               "settlement",
+				Domain:             "domain1",
+				Properties:         map[string]string{"jurisdiction": "uk", "window": "daily"},
+				RequiredProperties: []string{"window", "counterparty"},
+				Configuration:      map[string]string{"evmVersion": "shanghai"},
+				Roles: []*pldconf.GroupTemplateRoleConfig{
+					{Name: "issuer", MinMembers: 1, MaxMembers: confutil.P(1)},
+					{Name: "observer"},
+				},
+			}},
+		},
+	}
+}
+
+func TestGroupTemplatesRPCRealDB(t *testing.T) {
+	ctx, gm, _, done := newTestGroupManager(t, true, testTemplateConfig())
+	defer done()
+
+	client := newTestRPCServer(t, ctx, gm)
+
+	var registered *pldapi.PrivacyGroupTemplate
+	rpcErr := client.CallRPC(ctx, &registered, "pgroup_registerTemplate", &pldapi.PrivacyGroupTemplate{
+		Name:   "bilateral",
+		Domain: "domain1",
+		Roles:  []*pldapi.PrivacyGroupTemplateRole{{Name: "party", MinMembers: 2, MaxMembers: confutil.P(2)}},
+	})
+	require.NoError(t, rpcErr)
+	assert.Equal(t, pldapi.PrivacyGroupTemplateSourceAPI, registered.Source.V())
+	assert.NotZero(t, registered.Created)
+
+	var template *pldapi.PrivacyGroupTemplate
+	rpcErr = client.CallRPC(ctx, &template, "pgroup_getTemplate", "bilateral")
+	require.NoError(t, rpcErr)
+	assert.Equal(t, registered, template)
+
+	rpcErr = client.CallRPC(ctx, &template, "pgroup_getTemplate", "settlement")
+	require.NoError(t, rpcErr)
+	assert.Equal(t, pldapi.PrivacyGroupTemplateSourceConfig, template.Source.V())
+	assert.Equal(t, []string{"window", "counterparty"}, template.RequiredProperties)
+
+	template = nil
+	rpcErr = client.CallRPC(ctx, &template, "pgroup_getTemplate", "unknown")
+	require.NoError(t, rpcErr)
+	assert.Nil(t, template)
+
+	var templates []*pldapi.PrivacyGroupTemplate
+	rpcErr = client.CallRPC(ctx, &templates, "pgroup_listTemplates")
+	require.NoError(t, rpcErr)
+	require.Len(t, templates, 2)
+	assert.Equal(t, "bilateral", templates[0].Name)
+	assert.Equal(t, "settlement", templates[1].Name)
+
+	rpcErr = client.CallRPC(ctx, &registered, "pgroup_registerTemplate", &pldapi.PrivacyGroupTemplate{Name: "bilateral", Domain: "domain1"})
+	assert.Regexp(t, "PD012528", rpcErr)
+
+	rpcErr = client.CallRPC(ctx, &registered, "pgroup_registerTemplate", &pldapi.PrivacyGroupTemplate{Name: "settlement", Domain: "domain1"})
+	assert.Regexp(t, "PD012529", rpcErr)
+
+	var success bool
+	rpcErr = client.CallRPC(ctx, &success, "pgroup_deleteTemplate", "bilateral")
+	require.NoError(t, rpcErr)
+	assert.True(t, success)
+
+	rpcErr = client.CallRPC(ctx, &success, "pgroup_deleteTemplate", "bilateral")
+	assert.Regexp(t, "PD012524", rpcErr)
+
+	rpcErr = client.CallRPC(ctx, &success, "pgroup_deleteTemplate", "settlement")
+	assert.Regexp(t, "PD012529", rpcErr)
+}
+
+func TestCreateGroupFromTemplateRealDB(t *testing.T) {
+	ctx, gm, _, done := newTestGroupManager(t, true, testTemplateConfig(), func(mc *mockComponents, conf *pldconf.GroupManagerConfig) {
+		cpg := mc.domain.On("ConfigurePrivacyGroup", mock.Anything, mock.Anything)
+		cpg.Run(func(args mock.Arguments) {
+			inputConf := args[1].(map[string]string)
+			assert.Equal(t, map[string]string{"evmVersion": "shanghai"}, inputConf)
+			cpg.Return(inputConf, nil)
+		})
+		ipg := mc.domain.On("InitPrivacyGroup", mock.Anything, mock.Anything, mock.Anything)
+		ipg.Run(func(args mock.Arguments) {
+			genesis := args[2].(*pldapi.PrivacyGroupGenesisState)
+			assert.Equal(t, []string{"me@node1", "issuer@node1", "auditor@node1"}, genesis.Members)
+			assert.Equal(t, map[string]string{
+				"template":      "settlement",
+				"jurisdiction":  "uk",
+				"window":        "weekly",
+				"counterparty":  "bank1",
+				"role.issuer":   "issuer@node1",
+				"role.observer": "me@node1,auditor@node1",
+			}, genesis.Properties.Map())
+			ipg.Return(&pldapi.TransactionInput{
+				TransactionBase: pldapi.TransactionBase{Type: pldapi.TransactionTypePrivate.Enum()},
+			}, nil)
+		})
+		mc.txManager.On("SendTransactions", mock.Anything, mock.Anything, mock.Anything).Return([]uuid.UUID{uuid.New()}, nil)
+	})
+	defer done()
+
+	var group *pldapi.PrivacyGroup
+	err := gm.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) (err error) {
+		group, err = gm.CreateGroup(ctx, dbTX, &pldapi.PrivacyGroupInput{
+			Template:   "settlement",
+			Members:    []string{"me@node1"},
+			Properties: map[string]string{"window": "weekly", "counterparty": "bank1"},
+			Roles: map[string][]string{
+				"issuer":   {"issuer@node1"},
+				"observer": {"me@node1", "auditor@node1"},
+			},
+		})
+		return err
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "domain1", group.Domain)
+	assert.Equal(t, "settlement", group.Properties["template"])
+}
+
+func TestApplyTemplateErrors(t *testing.T) {
+	conf := testTemplateConfig()
+	conf.Templates.Allowed = []string{"settlement", "unknown"}
+	ctx, gm, _, done := newTestGroupManager(t, true, conf)
+	defer done()
+
+	validSpec := func() *pldapi.PrivacyGroupInput {
+		return &pldapi.PrivacyGroupInput{
+			Template:   "settlement",
+			Properties: map[string]string{"counterparty": "bank1"},
+			Roles:      map[string][]string{"issuer": {"issuer@node1"}},
+		}
+	}
+	require.NoError(t, gm.applyTemplate(ctx, gm.p.NOTX(), validSpec()))
+
+	for _, tc := range []struct {
+		name   string
+		modify func(spec *pldapi.PrivacyGroupInput)
+		err    string
+	}{
+		{name: "roles without template", modify: func(spec *pldapi.PrivacyGroupInput) { spec.Template = "" }, err: "PD012536"},
+		{name: "not allowed", modify: func(spec *pldapi.PrivacyGroupInput) { spec.Template = "other" }, err: "PD012526"},
+		{name: "not found", modify: func(spec *pldapi.PrivacyGroupInput) { spec.Template = "unknown" }, err: "PD012524"},
+		{name: "domain mismatch", modify: func(spec *pldapi.PrivacyGroupInput) { spec.Domain = "domain2" }, err: "PD012530"},
+		{name: "reserved property", modify: func(spec *pldapi.PrivacyGroupInput) { spec.Properties["role.issuer"] = "me@node1" }, err: "PD012537"},
+		{name: "required property", modify: func(spec *pldapi.PrivacyGroupInput) { spec.Properties = nil }, err: "PD012531.*counterparty"},
+		{name: "unknown role", modify: func(spec *pldapi.PrivacyGroupInput) { spec.Roles["admin"] = []string{"me@node1"} }, err: "PD012532"},
+		{name: "min members", modify: func(spec *pldapi.PrivacyGroupInput) { delete(spec.Roles, "issuer") }, err: "PD012533"},
+		{name: "max members", modify: func(spec *pldapi.PrivacyGroupInput) { spec.Roles["issuer"] = []string{"a@node1", "b@node1"} }, err: "PD012534"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			spec := validSpec()
+			tc.modify(spec)
+			err := gm.applyTemplate(ctx, gm.p.NOTX(), spec)
+			assert.Regexp(t, tc.err, err)
+		})
+	}
+}
+
+func TestTemplateRequired(t *testing.T) {
+	conf := testTemplateConfig()
+	conf.Templates.Required = confutil.P(true)
+	ctx, gm, _, done := newTestGroupManager(t, false, conf, mockEmptyMessageListeners, mockBeginRollback)
+	defer done()
+
+	err := gm.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
+		_, err := gm.CreateGroup(ctx, dbTX, &pldapi.PrivacyGroupInput{
+			Domain:  "domain1",
+			Members: []string{"me@node1"},
+		})
+		return err
+	})
+	assert.Regexp(t, "PD012525", err)
+}
+
+func TestTemplateAPIRegistrationDisabled(t *testing.T) {
+	conf := testTemplateConfig()
+	conf.Templates.AllowAPIRegistration = confutil.P(false)
+	ctx, gm, _, done := newTestGroupManager(t, false, conf, mockEmptyMessageListeners)
+	defer done()
+
+	_, err := gm.RegisterTemplate(ctx, gm.p.NOTX(), &pldapi.PrivacyGroupTemplate{Name: "t1", Domain: "domain1"})
+	assert.Regexp(t, "PD012527", err)
+}
+
+func TestTemplateConfigInvalid(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		template *pldconf.GroupTemplateConfig
+		err      string
+	}{
+		{name: "bad name", template: &pldconf.GroupTemplateConfig{Name: "-wrong", Domain: "domain1"}, err: "PD020005"},
+		{name: "no domain", template: &pldconf.GroupTemplateConfig{Name: "t1"}, err: "PD012505"},
+		{name: "reserved property", template: &pldconf.GroupTemplateConfig{Name: "t1", Domain: "domain1", Properties: map[string]string{"template": "t2"}}, err: "PD012537"},
+		{name: "bad role name", template: &pldconf.GroupTemplateConfig{Name: "t1", Domain: "domain1", Roles: []*pldconf.GroupTemplateRoleConfig{{Name: "-wrong"}}}, err: "PD020005"},
+		{name: "duplicate role", template: &pldconf.GroupTemplateConfig{Name: "t1", Domain: "domain1", Roles: []*pldconf.GroupTemplateRoleConfig{{Name: "r1"}, {Name: "r1"}}}, err: "PD012535"},
+		{name: "negative min", template: &pldconf.GroupTemplateConfig{Name: "t1", Domain: "domain1", Roles: []*pldconf.GroupTemplateRoleConfig{{Name: "r1", MinMembers: -1}}}, err: "PD012535"},
+		{name: "max below min", template: &pldconf.GroupTemplateConfig{Name: "t1", Domain: "domain1", Roles: []*pldconf.GroupTemplateRoleConfig{{Name: "r1", MinMembers: 2, MaxMembers: confutil.P(1)}}}, err: "PD012535"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			gm := NewGroupManager(context.Background(), &pldconf.GroupManagerConfig{
+				Templates: pldconf.GroupTemplates{Definitions: []*pldconf.GroupTemplateConfig{tc.template}},
+			}).(*groupManager)
+			err := gm.templatesInit(context.Background())
+			assert.Regexp(t, tc.err, err)
+		})
+	}
+
+	gm := NewGroupManager(context.Background(), &pldconf.GroupManagerConfig{
+		Templates: pldconf.GroupTemplates{Definitions: []*pldconf.GroupTemplateConfig{
+			{Name: "t1", Domain: "domain1"},
+			{Name: "t1", Domain: "domain2"},
+		}},
+	}).(*groupManager)
+	err := gm.templatesInit(context.Background())
+	assert.Regexp(t, "PD012528", err)
+}
+
+func TestTemplatesDBErrors(t *testing.T) {
+	ctx, gm, mc, done := newTestGroupManager(t, false, &pldconf.GroupManagerConfig{}, mockEmptyMessageListeners)
+	defer done()
+
+	mc.db.Mock.ExpectQuery("SELECT.*privacy_group_templates").WillReturnError(fmt.Errorf("pop"))
+	_, err := gm.RegisterTemplate(ctx, gm.p.NOTX(), &pldapi.PrivacyGroupTemplate{Name: "t1", Domain: "domain1"})
+	assert.Regexp(t, "pop", err)
+
+	_, err = gm.RegisterTemplate(ctx, gm.p.NOTX(), &pldapi.PrivacyGroupTemplate{Name: "-wrong", Domain: "domain1"})
+	assert.Regexp(t, "PD020005", err)
+
+	mc.db.Mock.ExpectQuery("SELECT.*privacy_group_templates").WillReturnRows(sqlmock.NewRows([]string{}))
+	mc.db.Mock.ExpectExec("INSERT.*privacy_group_templates").WillReturnError(fmt.Errorf("pop"))
+	_, err = gm.RegisterTemplate(ctx, gm.p.NOTX(), &pldapi.PrivacyGroupTemplate{Name: "t1", Domain: "domain1"})
+	assert.Regexp(t, "pop", err)
+
+	mc.db.Mock.ExpectQuery("SELECT.*privacy_group_templates").WillReturnRows(sqlmock.NewRows([]string{"name", "definition"}).AddRow("t1", "!!!wrong"))
+	_, err = gm.GetTemplate(ctx, gm.p.NOTX(), "t1")
+	assert.Error(t, err)
+
+	mc.db.Mock.ExpectQuery("SELECT.*privacy_group_templates").WillReturnError(fmt.Errorf("pop"))
+	_, err = gm.ListTemplates(ctx, gm.p.NOTX())
+	assert.Regexp(t, "pop", err)
+
+	mc.db.Mock.ExpectQuery("SELECT.*privacy_group_templates").WillReturnRows(sqlmock.NewRows([]string{"name", "definition"}).AddRow("t1", "!!!wrong"))
+	_, err = gm.ListTemplates(ctx, gm.p.NOTX())
+	assert.Error(t, err)
+
+	mc.db.Mock.ExpectExec("DELETE.*privacy_group_templates").WillReturnError(fmt.Errorf("pop"))
+	err = gm.DeleteTemplate(ctx, gm.p.NOTX(), "t1")
+	assert.Regexp(t, "pop", err)
+
+	mc.db.Mock.ExpectQuery("SELECT.*privacy_group_templates").WillReturnError(fmt.Errorf("pop"))
+	err = gm.applyTemplate(ctx, gm.p.NOTX(), &pldapi.PrivacyGroupInput{Template: "t1"})
+	assert.Regexp(t, "pop", err)
+}
@@ -42,6 +42,7 @@ type identityResolver struct {
 	transportManager      components.TransportManager
 	inflightRequests      map[string]*inflightRequest
 	inflightRequestsMutex *sync.Mutex
+	pendingLookups        map[string][]*inflightRequest // callers waiting on a lookup that is already in progress, by cache key
 	verifierCache         cache.Cache[string, string]
 }
 
@@ -55,7 +56,8 @@ func NewIdentityResolver(ctx context.Context, conf *pldconf.IdentityResolverConf
 		bgCtx:                 ctx,
 		inflightRequests:      make(map[string]*inflightRequest),
 		inflightRequestsMutex: &sync.Mutex{},
-		verifierCache:         cache.NewCache[string, string](&conf.VerifierCache, &pldconf.IdentityResolverDefaults.VerifierCache),
+		pendingLookups:        make(map[string][]*inflightRequest),
+		verifierCache:         cache.NewNamedCache[string, string]("identityresolver.verifiers", &conf.VerifierCache, &pldconf.IdentityResolverDefaults.VerifierCache),
 	}
 }
 
@@ -101,6 +103,54 @@ func (ir *identityResolver) ResolveVerifier(ctx context.Context, lookup string,
 	}
 }
 
+// Resolves a set of verifiers in parallel, for example all the parties referenced by a transaction.
+// Local and remote lookups all proceed at the same time, rather than one after another, and lookups
+// are shared with any other requests for the same verifier that are already in progress.
+// If any lookup fails, the error for the first failing request in the list is returned.
+func (ir *identityResolver) ResolveVerifiers(ctx context.Context, requests []*prototk.ResolveVerifierRequest) ([]*prototk.ResolvedVerifier, error) {
+	type lookupResult struct {
+		index    int
+		verifier string
+		err      error
+	}
+	results := make(chan *lookupResult, len(requests))
+	for i, r := range requests {
+		ir.ResolveVerifierAsync(ctx, r.Lookup, r.Algorithm, r.VerifierType, func(ctx context.Context, verifier string) {
+			results <- &lookupResult{index: i, verifier: verifier}
+		}, func(ctx context.Context, err error) {
+			results <- &lookupResult{index: i, err: err}
+		})
+	}
+
+	verifiers := make([]*prototk.ResolvedVerifier, len(requests))
+	var failure *lookupResult
+	for range requests {
+		select {
+		case result := <-results:
+			if result.err != nil {
+				if failure == nil || result.index < failure.index {
+					failure = result
+				}
+				continue
+			}
+			r := requests[result.index]
+			verifiers[result.index] = &prototk.ResolvedVerifier{
+				Lookup:       r.Lookup,
+				Algorithm:    r.Algorithm,
+				VerifierType: r.VerifierType,
+				Verifier:     result.verifier,
+			}
+		case <-ctx.Done():
+			return nil, i18n.NewError(ctx, msgs.MsgContextCanceled)
+		}
+	}
+	if failure != nil {
+		r := requests[failure.index]
+		return nil, i18n.WrapError(ctx, failure.err, msgs.MsgKeyResolutionFailed, r.Lookup, r.Algorithm, r.VerifierType)
+	}
+	return verifiers, nil
+}
+
 func (ir *identityResolver) ResolveVerifierAsync(ctx context.Context, lookup string, algorithm string, verifierType string, resolved func(ctx context.Context, verifier string), failed func(ctx context.Context, err error)) {
 	// if the verifier lookup is a local key, we can resolve it here
 	// if it is a remote key, we need to delegate to the remote node
@@ -112,26 +162,34 @@ func (ir *identityResolver) ResolveVerifierAsync(ctx context.Context, lookup str
 		return
 	}
 
-	// Ensure we log and cache if we resolve
 	cacheKey := cacheKey(identifier, node, algorithm, verifierType)
-	cachedVerifier, _ := ir.verifierCache.Get(cacheKey)
-	isCached := cachedVerifier != ""
 	isLocal := node == ir.nodeName
-	cacheAndResolve := func(ctx context.Context, verifier string) {
-		if !isCached {
-			ir.verifierCache.Set(cacheKey, verifier)
-		}
-		log.L(ctx).Debugf("ResolvedVerifier(lookup='%s',identifier='%s',node='%s',isLocal=%t,algorithm='%s',verifierType='%s',cached=%t): %s",
-			lookup, identifier, node, isLocal, algorithm, verifierType, isCached, verifier,
+	if cachedVerifier, _ := ir.verifierCache.Get(cacheKey); cachedVerifier != "" {
+		log.L(ctx).Debugf("ResolvedVerifier(lookup='%s',identifier='%s',node='%s',isLocal=%t,algorithm='%s',verifierType='%s',cached=true): %s",
+			lookup, identifier, node, isLocal, algorithm, verifierType, cachedVerifier,
 		)
-		resolved(ctx, verifier)
+		resolved(ctx, cachedVerifier)
+		return
 	}
 
-	if isCached {
-		cacheAndResolve(ctx, cachedVerifier)
+	// When many transactions reference the same party, we only look up the verifier once
+	if !ir.addPendingLookup(cacheKey, &inflightRequest{resolved: resolved, failed: failed}) {
+		log.L(ctx).Debugf("Joining in-progress lookup of verifier %s (algorithm=%s, verifierType=%s)", lookup, algorithm, verifierType)
 		return
 	}
 
+	// Ensure we log and cache if we resolve, and notify everyone waiting on the lookup
+	cacheAndResolve := func(ctx context.Context, verifier string) {
+		ir.verifierCache.Set(cacheKey, verifier)
+		log.L(ctx).Debugf("ResolvedVerifier(lookup='%s',identifier='%s',node='%s',isLocal=%t,algorithm='%s',verifierType='%s',cached=false): %s",
+			lookup, identifier, node, isLocal, algorithm, verifierType, verifier,
+		)
+		ir.completePendingLookup(ctx, cacheKey, verifier, nil)
+	}
+	failed = func(ctx context.Context, err error) {
+		ir.completePendingLookup(ctx, cacheKey, "", err)
+	}
+
 	if isLocal {
 		// this is an asynchronous call because the key manager may need to call out to a remote signer in order to
 		// resolve the key (e.g. if this is the first time this key has been referenced)
@@ -191,6 +249,31 @@ func (ir *identityResolver) ResolveVerifierAsync(ctx context.Context, lookup str
 	}
 }
 
+// Returns true if this is the first caller for the lookup, and hence the caller that needs to perform it
+func (ir *identityResolver) addPendingLookup(cacheKey string, request *inflightRequest) bool {
+	ir.inflightRequestsMutex.Lock()
+	defer ir.inflightRequestsMutex.Unlock()
+	waiters, inProgress := ir.pendingLookups[cacheKey]
+	ir.pendingLookups[cacheKey] = append(waiters, request)
+	return !inProgress
+}
+
+func (ir *identityResolver) completePendingLookup(ctx context.Context, cacheKey string, verifier string, err error) {
+	ir.inflightRequestsMutex.Lock()
+	waiters := ir.pendingLookups[cacheKey]
+	delete(ir.pendingLookups, cacheKey)
+	ir.inflightRequestsMutex.Unlock()
+
+	// make sure we don't hold the lock while calling the callbacks
+	for _, waiter := range waiters {
+		if err != nil {
+			waiter.failed(ctx, err)
+		} else {
+			waiter.resolved(ctx, verifier)
+		}
+	}
+}
+
 func (ir *identityResolver) addInflightRequest(requestID string, request *inflightRequest) {
 	ir.inflightRequestsMutex.Lock()
 	defer ir.inflightRequestsMutex.Unlock()
@@ -2,6 +2,8 @@ package identityresolver
 
 import (
 	"context"
+	"fmt"
+	"sync"
 	"testing"
 	"time"
 
@@ -10,9 +12,11 @@ import (
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
 	"github.com/kaleido-io/paladin/toolkit/pkg/algorithms"
 	"github.com/kaleido-io/paladin/toolkit/pkg/cache"
+	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
 	"github.com/kaleido-io/paladin/toolkit/pkg/verifiers"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 func TestResolveVerifier(t *testing.T) {
@@ -23,9 +27,11 @@ func TestResolveVerifier(t *testing.T) {
 	capacity := 100
 	config := &pldconf.CacheConfig{Capacity: &capacity}
 	r = &identityResolver{
-		nodeName:      "testnode",
-		verifierCache: cache.NewCache[string, string](config, config),
-		keyManager:    componentmocks.NewKeyManager(t),
+		nodeName:              "testnode",
+		inflightRequestsMutex: &sync.Mutex{},
+		pendingLookups:        make(map[string][]*inflightRequest),
+		verifierCache:         cache.NewCache[string, string](config, config),
+		keyManager:            componentmocks.NewKeyManager(t),
 	}
 	waitChan := make(chan time.Time)
 	resolvedKey := &pldapi.KeyMappingAndVerifier{
@@ -61,6 +67,104 @@ func TestResolveVerifierAsync(t *testing.T) {
 	r.ResolveVerifierAsync(context.Background(), "something$bad", "bad algorithm", "bad type", resolved, errhandler)
 }
 
+func newTestIdentityResolver(t *testing.T) (*identityResolver, *componentmocks.KeyManager) {
+	capacity := 100
+	ir := NewIdentityResolver(context.Background(), &pldconf.IdentityResolverConfig{
+		VerifierCache: pldconf.CacheConfig{Capacity: &capacity},
+	}).(*identityResolver)
+	ir.nodeName = "testnode"
+	km := componentmocks.NewKeyManager(t)
+	ir.keyManager = km
+	return ir, km
+}
+
+func TestResolveVerifiersCoalesceLookups(t *testing.T) {
+	ir, km := newTestIdentityResolver(t)
+
+	// hold the lookups until both requests for alice are waiting on the same one
+	waitChan := make(chan time.Time)
+	km.On("ResolveKeyNewDatabaseTX", mock.Anything, "alice", algorithms.ECDSA_SECP256K1, verifiers.ETH_ADDRESS).
+		WaitUntil(waitChan).
+		Return(&pldapi.KeyMappingAndVerifier{Verifier: &pldapi.KeyVerifier{Verifier: "0xaaaa"}}, nil).Once()
+	km.On("ResolveKeyNewDatabaseTX", mock.Anything, "bob", algorithms.ECDSA_SECP256K1, verifiers.ETH_ADDRESS).
+		WaitUntil(waitChan).
+		Return(&pldapi.KeyMappingAndVerifier{Verifier: &pldapi.KeyVerifier{Verifier: "0xbbbb"}}, nil).Once()
+	go func() {
+		for {
+			ir.inflightRequestsMutex.Lock()
+			waiting := len(ir.pendingLookups["alice@testnode|"+algorithms.ECDSA_SECP256K1+"|"+verifiers.ETH_ADDRESS])
+			ir.inflightRequestsMutex.Unlock()
+			if waiting == 2 {
+				close(waitChan)
+				return
+			}
+			time.Sleep(1 * time.Millisecond)
+		}
+	}()
+
+	resolved, err := ir.ResolveVerifiers(context.Background(), []*prototk.ResolveVerifierRequest{
+		{Lookup: "alice@testnode", Algorithm: algorithms.ECDSA_SECP256K1, VerifierType: verifiers.ETH_ADDRESS},
+		{Lookup: "bob", Algorithm: algorithms.ECDSA_SECP256K1, VerifierType: verifiers.ETH_ADDRESS},
+		{Lookup: "alice", Algorithm: algorithms.ECDSA_SECP256K1, VerifierType: verifiers.ETH_ADDRESS},
+	})
+	require.NoError(t, err)
+	require.Len(t, resolved, 3)
+	assert.Equal(t, "alice@testnode", resolved[0].Lookup)
+	assert.Equal(t, "0xaaaa", resolved[0].Verifier)
+	assert.Equal(t, "bob", resolved[1].Lookup)
+	assert.Equal(t, "0xbbbb", resolved[1].Verifier)
+	assert.Equal(t, "alice", resolved[2].Lookup)
+	assert.Equal(t, "0xaaaa", resolved[2].Verifier)
+	assert.Empty(t, ir.pendingLookups)
+
+	// a second call is served from the cache
+	resolved, err = ir.ResolveVerifiers(context.Background(), []*prototk.ResolveVerifierRequest{
+		{Lookup: "bob@testnode", Algorithm: algorithms.ECDSA_SECP256K1, VerifierType: verifiers.ETH_ADDRESS},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "0xbbbb", resolved[0].Verifier)
+}
+
+func TestResolveVerifiersFail(t *testing.T) {
+	ir, km := newTestIdentityResolver(t)
+
+	km.On("ResolveKeyNewDatabaseTX", mock.Anything, "alice", algorithms.ECDSA_SECP256K1, verifiers.ETH_ADDRESS).
+		Return(nil, fmt.Errorf("pop"))
+	km.On("ResolveKeyNewDatabaseTX", mock.Anything, "bob", algorithms.ECDSA_SECP256K1, verifiers.ETH_ADDRESS).
+		Return(&pldapi.KeyMappingAndVerifier{Verifier: &pldapi.KeyVerifier{Verifier: "0xbbbb"}}, nil).Maybe()
+
+	_, err := ir.ResolveVerifiers(context.Background(), []*prototk.ResolveVerifierRequest{
+		{Lookup: "bob", Algorithm: algorithms.ECDSA_SECP256K1, VerifierType: verifiers.ETH_ADDRESS},
+		{Lookup: "something$bad", Algorithm: algorithms.ECDSA_SECP256K1, VerifierType: verifiers.ETH_ADDRESS},
+		{Lookup: "alice", Algorithm: algorithms.ECDSA_SECP256K1, VerifierType: verifiers.ETH_ADDRESS},
+	})
+	assert.Regexp(t, "PD011806.*something\\$bad.*PD020006", err)
+	assert.Empty(t, ir.pendingLookups)
+
+	// failures are not cached
+	_, err = ir.ResolveVerifiers(context.Background(), []*prototk.ResolveVerifierRequest{
+		{Lookup: "alice", Algorithm: algorithms.ECDSA_SECP256K1, VerifierType: verifiers.ETH_ADDRESS},
+	})
+	assert.Regexp(t, "PD011806.*alice.*pop", err)
+}
+
+func TestResolveVerifiersContextCanceled(t *testing.T) {
+	ir, km := newTestIdentityResolver(t)
+
+	waitChan := make(chan time.Time)
+	defer close(waitChan)
+	km.On("ResolveKeyNewDatabaseTX", mock.Anything, "alice", algorithms.ECDSA_SECP256K1, verifiers.ETH_ADDRESS).
+		WaitUntil(waitChan).
+		Return(&pldapi.KeyMappingAndVerifier{Verifier: &pldapi.KeyVerifier{Verifier: "0xaaaa"}}, nil).Maybe()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := ir.ResolveVerifiers(ctx, []*prototk.ResolveVerifierRequest{
+		{Lookup: "alice", Algorithm: algorithms.ECDSA_SECP256K1, VerifierType: verifiers.ETH_ADDRESS},
+	})
+	assert.Regexp(t, "PD010301", err)
+}
+
 func TestNewIdentityResolver(t *testing.T) {
 	capacity := 100
 	ctx := context.Background()
@@ -0,0 +1,39 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package jobscheduler
+
+import (
+	"context"
+
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/query"
+	"github.com/kaleido-io/paladin/toolkit/pkg/rpcserver"
+)
+
+func (js *jobScheduler) RPCModule() *rpcserver.RPCModule {
+	return js.rpcModule
+}
+
+func (js *jobScheduler) initRPC() {
+	js.rpcModule = rpcserver.NewRPCModule("jobs").
+		Add("jobs_queryJobs", js.rpcQueryJobs())
+}
+
+func (js *jobScheduler) rpcQueryJobs() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod1(func(ctx context.Context, jq query.QueryJSON) ([]*pldapi.ScheduledJob, error) {
+		return js.QueryJobs(ctx, js.p.NOTX(), &jq)
+	})
+}
@@ -0,0 +1,71 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package jobscheduler
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/internal/components"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldclient"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/query"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/rpcclient"
+	"github.com/kaleido-io/paladin/toolkit/pkg/rpcserver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRPCServer(t *testing.T, ctx context.Context, js *jobScheduler) rpcclient.Client {
+	s, err := rpcserver.NewRPCServer(ctx, &pldconf.RPCServerConfig{
+		HTTP: pldconf.RPCServerConfigHTTP{
+			HTTPServerConfig: pldconf.HTTPServerConfig{Address: confutil.P("127.0.0.1"), Port: confutil.P(0)},
+		},
+		WS: pldconf.RPCServerConfigWS{Disabled: true},
+	})
+	require.NoError(t, err)
+	err = s.Start()
+	require.NoError(t, err)
+	t.Cleanup(s.Stop)
+
+	s.Register(js.RPCModule())
+
+	return rpcclient.WrapRestyClient(resty.New().SetBaseURL(fmt.Sprintf("http://%s", s.HTTPAddr())))
+}
+
+func TestRPCQueryJobs(t *testing.T) {
+	ctx := context.Background()
+	js := newTestJobScheduler(t, newTestPersistence(t), testConf())
+	client := pldclient.Wrap(newTestRPCServer(t, ctx, js)).Jobs()
+
+	err := js.EnsureRecurringJob(ctx, js.p.NOTX(), "recurring1", "job1", "@hourly")
+	require.NoError(t, err)
+	jobID := scheduleJob(t, js, &components.ScheduleJobRequest{JobType: "job2"})
+
+	jobs, err := client.QueryJobs(ctx, query.NewQueryBuilder().Limit(10).Query())
+	require.NoError(t, err)
+	require.Len(t, jobs, 2)
+	assert.Equal(t, jobID, jobs[0].ID)
+	assert.Equal(t, "recurring1", *jobs[1].Name)
+
+	jobs, err = client.QueryJobs(ctx, query.NewQueryBuilder().Equal("jobType", "job1").Limit(10).Query())
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+	assert.Equal(t, "@hourly", *jobs[0].Schedule)
+}
@@ -0,0 +1,438 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package jobscheduler
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/internal/components"
+	"github.com/kaleido-io/paladin/core/internal/filters"
+	"github.com/kaleido-io/paladin/core/internal/msgs"
+	"github.com/kaleido-io/paladin/core/pkg/persistence"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/query"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/retry"
+	"github.com/kaleido-io/paladin/toolkit/pkg/rpcserver"
+	"gorm.io/gorm/clause"
+)
+
+var jobFilters = filters.FieldMap{
+	"id":          filters.UUIDField("id"),
+	"name":        filters.StringField("name"),
+	"created":     filters.TimestampField("created"),
+	"jobType":     filters.StringField("job_type"),
+	"schedule":    filters.StringField("schedule"),
+	"nextRun":     filters.TimestampField("next_run"),
+	"attempt":     filters.Int64Field("attempt"),
+	"leaseOwner":  filters.StringField("lease_owner"),
+	"leaseExpiry": filters.TimestampField("lease_expiry"),
+	"lastRun":     filters.TimestampField("last_run"),
+	"lastError":   filters.StringField("last_error"),
+	"completed":   filters.TimestampField("completed"),
+}
+
+// Jobs are stored in the database, rather than being driven by in-memory tickers, so that schedules
+// survive restarts. Each run of a job is protected by a lease, so that when multiple replicas share
+// a database each job runs on only one of them at a time, and a job leased by a replica that fails
+// is picked up by another once the lease expires.
+type persistedJob struct {
+	ID          uuid.UUID           `gorm:"column:id;primaryKey"`
+	Name        *string             `gorm:"column:name"`
+	Created     pldtypes.Timestamp  `gorm:"column:created"`
+	JobType     string              `gorm:"column:job_type"`
+	Schedule    *string             `gorm:"column:schedule"`
+	Payload     pldtypes.RawJSON    `gorm:"column:payload"`
+	NextRun     pldtypes.Timestamp  `gorm:"column:next_run"`
+	Attempt     int                 `gorm:"column:attempt"`
+	LeaseOwner  *string             `gorm:"column:lease_owner"`
+	LeaseExpiry *pldtypes.Timestamp `gorm:"column:lease_expiry"`
+	LastRun     *pldtypes.Timestamp `gorm:"column:last_run"`
+	LastError   *string             `gorm:"column:last_error"`
+	Completed   *pldtypes.Timestamp `gorm:"column:completed"`
+}
+
+func (persistedJob) TableName() string {
+	return "scheduled_jobs"
+}
+
+func (pj *persistedJob) mapToAPI() *pldapi.ScheduledJob {
+	return &pldapi.ScheduledJob{
+		ID:          pj.ID,
+		Name:        pj.Name,
+		Created:     pj.Created,
+		JobType:     pj.JobType,
+		Schedule:    pj.Schedule,
+		Payload:     pj.Payload,
+		NextRun:     pj.NextRun,
+		Attempt:     pj.Attempt,
+		LeaseOwner:  pj.LeaseOwner,
+		LeaseExpiry: pj.LeaseExpiry,
+		LastRun:     pj.LastRun,
+		LastError:   pj.LastError,
+		Completed:   pj.Completed,
+	}
+}
+
+type jobScheduler struct {
+	bgCtx     context.Context
+	cancelCtx context.CancelFunc
+
+	conf      *pldconf.JobSchedulerConfig
+	p         persistence.Persistence
+	rpcModule *rpcserver.RPCModule
+
+	owner         string
+	pollInterval  time.Duration
+	leaseDuration time.Duration
+	batchSize     int
+	retry         *retry.Retry
+	maxAttempts   int
+
+	handlers     map[string]components.JobHandler
+	slots        chan struct{}
+	inflightLock sync.Mutex
+	inflight     map[uuid.UUID]bool
+	workers      sync.WaitGroup
+	poke         chan struct{}
+	loopDone     chan struct{}
+}
+
+func NewJobScheduler(bgCtx context.Context, conf *pldconf.JobSchedulerConfig) components.JobScheduler {
+	js := &jobScheduler{
+		conf:          conf,
+		owner:         uuid.NewString(),
+		pollInterval:  confutil.DurationMin(conf.PollInterval, 10*time.Millisecond, *pldconf.JobSchedulerDefaults.PollInterval),
+		leaseDuration: confutil.DurationMin(conf.LeaseDuration, time.Second, *pldconf.JobSchedulerDefaults.LeaseDuration),
+		batchSize:     confutil.IntMin(conf.BatchSize, 1, *pldconf.JobSchedulerDefaults.BatchSize),
+		retry:         retry.NewRetryLimited(&conf.Retry, &pldconf.JobSchedulerDefaults.Retry),
+		handlers:      make(map[string]components.JobHandler),
+		slots:         make(chan struct{}, confutil.IntMin(conf.Workers, 1, *pldconf.JobSchedulerDefaults.Workers)),
+		inflight:      make(map[uuid.UUID]bool),
+		poke:          make(chan struct{}, 1),
+	}
+	js.maxAttempts = js.retry.MaxAttempts()
+	js.bgCtx, js.cancelCtx = context.WithCancel(log.WithLogField(bgCtx, "role", "job-scheduler"))
+	return js
+}
+
+func (js *jobScheduler) PreInit(c components.PreInitComponents) (*components.ManagerInitResult, error) {
+	js.p = c.Persistence()
+	js.initRPC()
+	return &components.ManagerInitResult{
+		RPCModules: []*rpcserver.RPCModule{js.rpcModule},
+	}, nil
+}
+
+func (js *jobScheduler) PostInit(c components.AllComponents) error {
+	return nil
+}
+
+func (js *jobScheduler) Start() error {
+	js.loopDone = make(chan struct{})
+	go js.run()
+	return nil
+}
+
+func (js *jobScheduler) Stop() {
+	js.cancelCtx()
+	if js.loopDone != nil {
+		<-js.loopDone
+	}
+}
+
+func (js *jobScheduler) RegisterHandler(jobType string, handler components.JobHandler) {
+	js.handlers[jobType] = handler
+}
+
+func (js *jobScheduler) EnsureRecurringJob(ctx context.Context, dbTX persistence.DBTX, name, jobType, scheduleSpec string) error {
+	if jobType == "" {
+		return i18n.NewError(ctx, msgs.MsgJobSchedulerMissingJobType)
+	}
+	nextRun, err := js.nextScheduledRun(ctx, scheduleSpec, time.Now())
+	if err != nil {
+		return err
+	}
+
+	// Another replica might be creating the same job concurrently, so we insert if missing and then
+	// only update it if the definition has changed - leaving the next run time of an existing job alone
+	err = dbTX.DB().
+		WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&persistedJob{
+			ID:       uuid.New(),
+			Name:     &name,
+			Created:  pldtypes.TimestampNow(),
+			JobType:  jobType,
+			Schedule: &scheduleSpec,
+			NextRun:  pldtypes.Timestamp(nextRun.UnixNano()),
+		}).
+		Error
+	if err == nil {
+		err = dbTX.DB().
+			WithContext(ctx).
+			Model(&persistedJob{}).
+			Where("name = ?", name).
+			Where("job_type <> ? OR schedule <> ? OR completed IS NOT NULL", jobType, scheduleSpec).
+			Updates(map[string]any{
+				"job_type":   jobType,
+				"schedule":   scheduleSpec,
+				"next_run":   pldtypes.Timestamp(nextRun.UnixNano()),
+				"attempt":    0,
+				"last_error": nil,
+				"completed":  nil,
+			}).
+			Error
+	}
+	return err
+}
+
+func (js *jobScheduler) nextScheduledRun(ctx context.Context, scheduleSpec string, after time.Time) (time.Time, error) {
+	s, err := parseSchedule(ctx, scheduleSpec)
+	if err != nil {
+		return time.Time{}, err
+	}
+	nextRun, ok := s.next(after)
+	if !ok {
+		return time.Time{}, i18n.NewError(ctx, msgs.MsgJobSchedulerNoNextRun, scheduleSpec)
+	}
+	return nextRun, nil
+}
+
+func (js *jobScheduler) ScheduleJob(ctx context.Context, dbTX persistence.DBTX, req *components.ScheduleJobRequest) (uuid.UUID, error) {
+	if req.JobType == "" {
+		return uuid.Nil, i18n.NewError(ctx, msgs.MsgJobSchedulerMissingJobType)
+	}
+	now := pldtypes.TimestampNow()
+	job := &persistedJob{
+		ID:      uuid.New(),
+		Created: now,
+		JobType: req.JobType,
+		Payload: req.Payload,
+		NextRun: now,
+	}
+	if req.RunAt != nil {
+		job.NextRun = *req.RunAt
+	}
+	err := dbTX.DB().WithContext(ctx).Create(job).Error
+	if err != nil {
+		return uuid.Nil, err
+	}
+	dbTX.AddPostCommit(func(ctx context.Context) {
+		js.pokeLoop()
+	})
+	return job.ID, nil
+}
+
+func (js *jobScheduler) QueryJobs(ctx context.Context, dbTX persistence.DBTX, jq *query.QueryJSON) ([]*pldapi.ScheduledJob, error) {
+	qw := &filters.QueryWrapper[persistedJob, pldapi.ScheduledJob]{
+		P:           js.p,
+		DefaultSort: "-created",
+		Filters:     jobFilters,
+		Query:       jq,
+		MapResult: func(pj *persistedJob) (*pldapi.ScheduledJob, error) {
+			return pj.mapToAPI(), nil
+		},
+	}
+	return qw.Run(ctx, dbTX)
+}
+
+func (js *jobScheduler) pokeLoop() {
+	select {
+	case js.poke <- struct{}{}:
+	default:
+	}
+}
+
+func (js *jobScheduler) run() {
+	defer close(js.loopDone)
+
+	ticker := time.NewTicker(js.pollInterval)
+	defer ticker.Stop()
+	for {
+		if err := js.pollJobs(js.bgCtx); err != nil {
+			log.L(js.bgCtx).Errorf("Failed to poll for due jobs: %s", err)
+		}
+		select {
+		case <-ticker.C:
+		case <-js.poke:
+		case <-js.bgCtx.Done():
+			log.L(js.bgCtx).Debugf("Job scheduler stopping")
+			js.workers.Wait()
+			return
+		}
+	}
+}
+
+// Leases due jobs that we have handlers for, up to the number of free workers, and starts them
+func (js *jobScheduler) pollJobs(ctx context.Context) error {
+	if len(js.handlers) == 0 {
+		return nil
+	}
+	jobTypes := make([]string, 0, len(js.handlers))
+	for jobType := range js.handlers {
+		jobTypes = append(jobTypes, jobType)
+	}
+
+	now := pldtypes.TimestampNow()
+	var due []*persistedJob
+	err := js.p.DB().
+		WithContext(ctx).
+		Where("completed IS NULL").
+		Where("next_run <= ?", now).
+		Where("lease_expiry IS NULL OR lease_expiry < ?", now).
+		Where("job_type IN (?)", jobTypes).
+		Order("next_run").
+		Limit(js.batchSize).
+		Find(&due).
+		Error
+	if err != nil {
+		return err
+	}
+
+	for _, job := range due {
+		if js.isInflight(job.ID) {
+			continue
+		}
+		select {
+		case js.slots <- struct{}{}:
+		default:
+			log.L(ctx).Debugf("All job workers busy")
+			return nil
+		}
+		acquired, err := js.acquireLease(ctx, job)
+		if err != nil || !acquired {
+			<-js.slots
+			if err != nil {
+				return err
+			}
+			continue
+		}
+		js.inflightLock.Lock()
+		js.inflight[job.ID] = true
+		js.inflightLock.Unlock()
+		js.workers.Add(1)
+		go js.runJob(job)
+	}
+	return nil
+}
+
+func (js *jobScheduler) isInflight(id uuid.UUID) bool {
+	js.inflightLock.Lock()
+	defer js.inflightLock.Unlock()
+	return js.inflight[id]
+}
+
+// The lease is only granted if the job has not been run, or leased, by another replica since we read it
+func (js *jobScheduler) acquireLease(ctx context.Context, job *persistedJob) (bool, error) {
+	now := pldtypes.TimestampNow()
+	leaseExpiry := pldtypes.Timestamp(now.Time().Add(js.leaseDuration).UnixNano())
+	result := js.p.DB().
+		WithContext(ctx).
+		Model(&persistedJob{}).
+		Where("id = ?", job.ID).
+		Where("completed IS NULL").
+		Where("next_run = ?", job.NextRun).
+		Where("lease_expiry IS NULL OR lease_expiry < ?", now).
+		Updates(map[string]any{
+			"lease_owner":  js.owner,
+			"lease_expiry": leaseExpiry,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	if result.RowsAffected != 1 {
+		log.L(ctx).Debugf("Job %s (%s) leased by another scheduler", job.ID, job.JobType)
+		return false, nil
+	}
+	job.LeaseOwner = &js.owner
+	job.LeaseExpiry = &leaseExpiry
+	return true, nil
+}
+
+func (js *jobScheduler) runJob(job *persistedJob) {
+	defer func() {
+		js.inflightLock.Lock()
+		delete(js.inflight, job.ID)
+		js.inflightLock.Unlock()
+		<-js.slots
+		js.workers.Done()
+	}()
+
+	ctx := log.WithLogField(js.bgCtx, "job", job.ID.String())
+	log.L(ctx).Debugf("Running job %s (type=%s attempt=%d)", job.ID, job.JobType, job.Attempt+1)
+	jobErr := js.handlers[job.JobType](ctx, job.mapToAPI())
+	if jobErr != nil {
+		log.L(ctx).Errorf("Job %s (type=%s attempt=%d) failed: %s", job.ID, job.JobType, job.Attempt+1, jobErr)
+	}
+	if err := js.completeRun(ctx, job, jobErr); err != nil {
+		// The lease will expire, and the job will be run again
+		log.L(ctx).Errorf("Failed to record result of job %s: %s", job.ID, err)
+	}
+}
+
+// Releases the lease, and calculates when the job next needs to run (if ever). Failed runs are retried
+// with backoff. After the maximum attempts a one-shot job is abandoned, and a recurring job waits for
+// its next scheduled time.
+func (js *jobScheduler) completeRun(ctx context.Context, job *persistedJob, jobErr error) error {
+	now := time.Now()
+	updates := map[string]any{
+		"lease_owner":  nil,
+		"lease_expiry": nil,
+		"last_run":     pldtypes.Timestamp(now.UnixNano()),
+		"attempt":      0,
+		"last_error":   nil,
+	}
+	attempt := job.Attempt + 1
+	retryable := jobErr != nil && (js.maxAttempts <= 0 || attempt < js.maxAttempts)
+	if jobErr != nil {
+		updates["attempt"] = attempt
+		updates["last_error"] = jobErr.Error()
+	}
+	switch {
+	case retryable:
+		updates["next_run"] = pldtypes.Timestamp(now.Add(js.retry.Delay(attempt)).UnixNano())
+	case job.Schedule != nil:
+		updates["attempt"] = 0
+		nextRun, err := js.nextScheduledRun(ctx, *job.Schedule, now)
+		if err == nil {
+			updates["next_run"] = pldtypes.Timestamp(nextRun.UnixNano())
+		} else {
+			log.L(ctx).Errorf("Recurring job %s will not run again: %s", job.ID, err)
+			updates["completed"] = pldtypes.Timestamp(now.UnixNano())
+		}
+	default:
+		updates["completed"] = pldtypes.Timestamp(now.UnixNano())
+	}
+
+	result := js.p.DB().
+		WithContext(ctx).
+		Model(&persistedJob{}).
+		Where("id = ?", job.ID).
+		Where("lease_owner = ?", js.owner).
+		Updates(updates)
+	if result.Error == nil && result.RowsAffected != 1 {
+		log.L(ctx).Warnf("Lease on job %s was lost before it completed", job.ID)
+	}
+	return result.Error
+}
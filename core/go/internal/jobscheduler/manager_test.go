@@ -0,0 +1,413 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package jobscheduler
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/internal/components"
+	"github.com/kaleido-io/paladin/core/mocks/componentmocks"
+	"github.com/kaleido-io/paladin/core/pkg/persistence"
+	"github.com/kaleido-io/paladin/core/pkg/persistence/mockpersistence"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/query"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testConf() *pldconf.JobSchedulerConfig {
+	return &pldconf.JobSchedulerConfig{
+		PollInterval: confutil.P("10ms"),
+		Retry: pldconf.RetryConfigWithMax{
+			RetryConfig: pldconf.RetryConfig{
+				InitialDelay: confutil.P("1ms"),
+				MaxDelay:     confutil.P("1ms"),
+			},
+			MaxAttempts: confutil.P(3),
+		},
+	}
+}
+
+func newTestJobScheduler(t *testing.T, p persistence.Persistence, conf *pldconf.JobSchedulerConfig) *jobScheduler {
+	pic := componentmocks.NewPreInitComponents(t)
+	pic.On("Persistence").Return(p)
+
+	js := NewJobScheduler(context.Background(), conf)
+	ir, err := js.PreInit(pic)
+	require.NoError(t, err)
+	assert.NotEmpty(t, ir.RPCModules)
+	err = js.PostInit(componentmocks.NewAllComponents(t))
+	require.NoError(t, err)
+	t.Cleanup(js.Stop)
+	return js.(*jobScheduler)
+}
+
+func newTestPersistence(t *testing.T) persistence.Persistence {
+	p, pDone, err := persistence.NewUnitTestPersistence(context.Background(), "jobscheduler")
+	require.NoError(t, err)
+	t.Cleanup(pDone)
+	return p
+}
+
+func getJob(t *testing.T, js *jobScheduler, id uuid.UUID) *pldapi.ScheduledJob {
+	jobs, err := js.QueryJobs(context.Background(), js.p.NOTX(), query.NewQueryBuilder().Equal("id", id).Limit(1).Query())
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+	return jobs[0]
+}
+
+func scheduleJob(t *testing.T, js *jobScheduler, req *components.ScheduleJobRequest) (jobID uuid.UUID) {
+	err := js.p.Transaction(context.Background(), func(ctx context.Context, dbTX persistence.DBTX) (err error) {
+		jobID, err = js.ScheduleJob(ctx, dbTX, req)
+		return err
+	})
+	require.NoError(t, err)
+	return jobID
+}
+
+func TestNewJobSchedulerDefaults(t *testing.T) {
+	js := NewJobScheduler(context.Background(), &pldconf.JobSchedulerConfig{}).(*jobScheduler)
+	assert.Equal(t, 1*time.Second, js.pollInterval)
+	assert.Equal(t, 5*time.Minute, js.leaseDuration)
+	assert.Equal(t, 50, js.batchSize)
+	assert.Equal(t, 5, cap(js.slots))
+	assert.Equal(t, 10, js.maxAttempts)
+	// stop is safe when never started
+	js.Stop()
+}
+
+func TestOneShotJobRetriedUntilSuccess(t *testing.T) {
+	js := newTestJobScheduler(t, newTestPersistence(t), testConf())
+
+	var attempts atomic.Int32
+	done := make(chan *pldapi.ScheduledJob, 1)
+	js.RegisterHandler("job1", func(ctx context.Context, job *pldapi.ScheduledJob) error {
+		if attempts.Add(1) < 3 {
+			return fmt.Errorf("pop")
+		}
+		done <- job
+		return nil
+	})
+	require.NoError(t, js.Start())
+
+	jobID := scheduleJob(t, js, &components.ScheduleJobRequest{
+		JobType: "job1",
+		Payload: pldtypes.RawJSON(`{"some":"data"}`),
+	})
+
+	job := <-done
+	assert.Equal(t, jobID, job.ID)
+	assert.JSONEq(t, `{"some":"data"}`, job.Payload.String())
+	assert.Equal(t, 2, job.Attempt)
+	assert.Equal(t, "pop", *job.LastError)
+
+	assert.Eventually(t, func() bool {
+		return getJob(t, js, jobID).Completed != nil
+	}, 5*time.Second, 10*time.Millisecond)
+	job = getJob(t, js, jobID)
+	assert.Zero(t, job.Attempt)
+	assert.Nil(t, job.LastError)
+	assert.Nil(t, job.LeaseOwner)
+	assert.Nil(t, job.LeaseExpiry)
+	assert.NotNil(t, job.LastRun)
+}
+
+func TestOneShotJobAbandoned(t *testing.T) {
+	js := newTestJobScheduler(t, newTestPersistence(t), testConf())
+
+	var attempts atomic.Int32
+	js.RegisterHandler("job1", func(ctx context.Context, job *pldapi.ScheduledJob) error {
+		attempts.Add(1)
+		return fmt.Errorf("pop")
+	})
+	require.NoError(t, js.Start())
+
+	jobID := scheduleJob(t, js, &components.ScheduleJobRequest{JobType: "job1"})
+	assert.Eventually(t, func() bool {
+		return getJob(t, js, jobID).Completed != nil
+	}, 5*time.Second, 10*time.Millisecond)
+	job := getJob(t, js, jobID)
+	assert.Equal(t, 3, job.Attempt)
+	assert.Equal(t, "pop", *job.LastError)
+	assert.Equal(t, int32(3), attempts.Load())
+}
+
+func TestDelayedJob(t *testing.T) {
+	js := newTestJobScheduler(t, newTestPersistence(t), testConf())
+
+	ran := make(chan time.Time, 1)
+	js.RegisterHandler("job1", func(ctx context.Context, job *pldapi.ScheduledJob) error {
+		ran <- time.Now()
+		return nil
+	})
+	require.NoError(t, js.Start())
+
+	runAt := time.Now().Add(100 * time.Millisecond)
+	scheduleJob(t, js, &components.ScheduleJobRequest{
+		JobType: "job1",
+		RunAt:   confutil.P(pldtypes.Timestamp(runAt.UnixNano())),
+	})
+	assert.False(t, (<-ran).Before(runAt))
+}
+
+func TestRecurringJob(t *testing.T) {
+	js := newTestJobScheduler(t, newTestPersistence(t), testConf())
+	ctx := context.Background()
+
+	var runs atomic.Int32
+	js.RegisterHandler("job1", func(ctx context.Context, job *pldapi.ScheduledJob) error {
+		if runs.Add(1) == 1 {
+			return fmt.Errorf("pop")
+		}
+		return nil
+	})
+	require.NoError(t, js.Start())
+
+	err := js.EnsureRecurringJob(ctx, js.p.NOTX(), "recurring1", "job1", "@every 20ms")
+	require.NoError(t, err)
+
+	assert.Eventually(t, func() bool { return runs.Load() >= 3 }, 5*time.Second, 10*time.Millisecond)
+
+	jobs, err := js.QueryJobs(ctx, js.p.NOTX(), query.NewQueryBuilder().Equal("name", "recurring1").Limit(10).Query())
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+	assert.Nil(t, jobs[0].Completed)
+	assert.Equal(t, "@every 20ms", *jobs[0].Schedule)
+}
+
+func TestEnsureRecurringJobUpdates(t *testing.T) {
+	js := newTestJobScheduler(t, newTestPersistence(t), testConf())
+	ctx := context.Background()
+
+	err := js.EnsureRecurringJob(ctx, js.p.NOTX(), "recurring1", "job1", "0 0 1 1 *")
+	require.NoError(t, err)
+	job1 := getJobByName(t, js, "recurring1")
+
+	// The next run is not reset when the job is unchanged (such as on a restart)
+	err = js.EnsureRecurringJob(ctx, js.p.NOTX(), "recurring1", "job1", "0 0 1 1 *")
+	require.NoError(t, err)
+	job2 := getJobByName(t, js, "recurring1")
+	assert.Equal(t, job1.ID, job2.ID)
+	assert.Equal(t, job1.NextRun, job2.NextRun)
+
+	err = js.EnsureRecurringJob(ctx, js.p.NOTX(), "recurring1", "job1", "@every 1s")
+	require.NoError(t, err)
+	job3 := getJobByName(t, js, "recurring1")
+	assert.Equal(t, job1.ID, job3.ID)
+	assert.Less(t, job3.NextRun, job1.NextRun)
+	assert.Equal(t, "@every 1s", *job3.Schedule)
+}
+
+func getJobByName(t *testing.T, js *jobScheduler, name string) *pldapi.ScheduledJob {
+	jobs, err := js.QueryJobs(context.Background(), js.p.NOTX(), query.NewQueryBuilder().Equal("name", name).Limit(1).Query())
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+	return jobs[0]
+}
+
+func TestRecurringJobFailsMaxAttempts(t *testing.T) {
+	js := newTestJobScheduler(t, newTestPersistence(t), testConf())
+	ctx := context.Background()
+
+	err := js.EnsureRecurringJob(ctx, js.p.NOTX(), "recurring1", "job1", "@every 1h")
+	require.NoError(t, err)
+	job := getJobByName(t, js, "recurring1")
+
+	// Past the maximum attempts we give up retrying, and wait for the next scheduled run
+	pj := &persistedJob{ID: job.ID, JobType: job.JobType, Schedule: job.Schedule, NextRun: job.NextRun, Attempt: 2}
+	acquired, err := js.acquireLease(ctx, pj)
+	require.NoError(t, err)
+	require.True(t, acquired)
+	err = js.completeRun(ctx, pj, fmt.Errorf("pop"))
+	require.NoError(t, err)
+
+	job = getJobByName(t, js, "recurring1")
+	assert.Zero(t, job.Attempt)
+	assert.Equal(t, "pop", *job.LastError)
+	assert.Nil(t, job.Completed)
+	assert.Greater(t, job.NextRun, pldtypes.Timestamp(time.Now().Add(59*time.Minute).UnixNano()))
+
+	// An unparsable schedule completes the job
+	pj.Schedule = confutil.P("wrong")
+	pj.NextRun = job.NextRun
+	acquired, err = js.acquireLease(ctx, pj)
+	require.NoError(t, err)
+	require.True(t, acquired)
+	err = js.completeRun(ctx, pj, nil)
+	require.NoError(t, err)
+	assert.NotNil(t, getJobByName(t, js, "recurring1").Completed)
+}
+
+func TestLeaseOnlyGrantedOnce(t *testing.T) {
+	p := newTestPersistence(t)
+	conf := testConf()
+	js1 := newTestJobScheduler(t, p, conf)
+	js2 := newTestJobScheduler(t, p, conf)
+	ctx := context.Background()
+
+	jobID := scheduleJob(t, js1, &components.ScheduleJobRequest{JobType: "job1"})
+	job := getJob(t, js1, jobID)
+	pj1 := &persistedJob{ID: jobID, JobType: "job1", NextRun: job.NextRun}
+	pj2 := &persistedJob{ID: jobID, JobType: "job1", NextRun: job.NextRun}
+
+	acquired, err := js1.acquireLease(ctx, pj1)
+	require.NoError(t, err)
+	assert.True(t, acquired)
+	acquired, err = js2.acquireLease(ctx, pj2)
+	require.NoError(t, err)
+	assert.False(t, acquired)
+	assert.Equal(t, js1.owner, *getJob(t, js1, jobID).LeaseOwner)
+
+	// Only the lease owner can complete the run
+	err = js2.completeRun(ctx, pj2, nil)
+	require.NoError(t, err)
+	assert.Nil(t, getJob(t, js1, jobID).Completed)
+	err = js1.completeRun(ctx, pj1, nil)
+	require.NoError(t, err)
+	assert.NotNil(t, getJob(t, js1, jobID).Completed)
+}
+
+func TestExpiredLeaseTakenOver(t *testing.T) {
+	p := newTestPersistence(t)
+	conf := testConf()
+	conf.LeaseDuration = confutil.P("1s")
+	js1 := newTestJobScheduler(t, p, conf)
+	js2 := newTestJobScheduler(t, p, conf)
+	ctx := context.Background()
+
+	jobID := scheduleJob(t, js1, &components.ScheduleJobRequest{JobType: "job1"})
+	job := getJob(t, js1, jobID)
+	acquired, err := js1.acquireLease(ctx, &persistedJob{ID: jobID, NextRun: job.NextRun})
+	require.NoError(t, err)
+	require.True(t, acquired)
+
+	// Simulate the first scheduler failing, and its lease expiring
+	err = p.DB().Model(&persistedJob{}).Where("id = ?", jobID).Update("lease_expiry", pldtypes.Timestamp(time.Now().Add(-time.Second).UnixNano())).Error
+	require.NoError(t, err)
+
+	ran := make(chan struct{})
+	js2.RegisterHandler("job1", func(ctx context.Context, job *pldapi.ScheduledJob) error {
+		close(ran)
+		return nil
+	})
+	require.NoError(t, js2.Start())
+	<-ran
+}
+
+func TestWorkersBusy(t *testing.T) {
+	conf := testConf()
+	conf.Workers = confutil.P(1)
+	js := newTestJobScheduler(t, newTestPersistence(t), conf)
+	ctx := context.Background()
+
+	started := make(chan uuid.UUID, 2)
+	release := make(chan struct{})
+	js.RegisterHandler("job1", func(ctx context.Context, job *pldapi.ScheduledJob) error {
+		started <- job.ID
+		<-release
+		return nil
+	})
+	job1 := scheduleJob(t, js, &components.ScheduleJobRequest{JobType: "job1"})
+	job2 := scheduleJob(t, js, &components.ScheduleJobRequest{JobType: "job1"})
+	// A job type with no handler on this node is left for others
+	job3 := scheduleJob(t, js, &components.ScheduleJobRequest{JobType: "job3"})
+
+	require.NoError(t, js.pollJobs(ctx))
+	first := <-started
+	// The running job is not leased again, and the other job waits for a worker
+	require.NoError(t, js.pollJobs(ctx))
+	assert.Len(t, started, 0)
+
+	close(release)
+	require.NoError(t, js.Start())
+	second := <-started
+	assert.ElementsMatch(t, []uuid.UUID{job1, job2}, []uuid.UUID{first, second})
+	assert.Nil(t, getJob(t, js, job3).LeaseOwner)
+}
+
+func TestScheduleJobErrors(t *testing.T) {
+	js := newTestJobScheduler(t, newTestPersistence(t), testConf())
+	ctx := context.Background()
+
+	_, err := js.ScheduleJob(ctx, js.p.NOTX(), &components.ScheduleJobRequest{})
+	assert.Regexp(t, "PD012702", err)
+
+	err = js.EnsureRecurringJob(ctx, js.p.NOTX(), "recurring1", "", "@hourly")
+	assert.Regexp(t, "PD012702", err)
+
+	err = js.EnsureRecurringJob(ctx, js.p.NOTX(), "recurring1", "job1", "wrong")
+	assert.Regexp(t, "PD012700", err)
+
+	err = js.EnsureRecurringJob(ctx, js.p.NOTX(), "recurring1", "job1", "0 0 30 2 *")
+	assert.Regexp(t, "PD012703", err)
+}
+
+func newMockDBJobScheduler(t *testing.T) (*jobScheduler, sqlmock.Sqlmock) {
+	mdb, err := mockpersistence.NewSQLMockProvider()
+	require.NoError(t, err)
+	js := newTestJobScheduler(t, mdb.P, testConf())
+	js.RegisterHandler("job1", func(ctx context.Context, job *pldapi.ScheduledJob) error { return nil })
+	return js, mdb.Mock
+}
+
+func TestDBErrors(t *testing.T) {
+	ctx := context.Background()
+
+	js, mdb := newMockDBJobScheduler(t)
+	mdb.ExpectQuery("SELECT.*scheduled_jobs").WillReturnError(fmt.Errorf("pop"))
+	assert.Regexp(t, "pop", js.pollJobs(ctx))
+
+	js, mdb = newMockDBJobScheduler(t)
+	mdb.ExpectQuery("SELECT.*scheduled_jobs").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uuid.New()))
+	mdb.ExpectExec("UPDATE.*scheduled_jobs").WillReturnError(fmt.Errorf("pop"))
+	assert.Regexp(t, "pop", js.pollJobs(ctx))
+	assert.Len(t, js.slots, 0)
+
+	js, mdb = newMockDBJobScheduler(t)
+	mdb.ExpectExec("INSERT.*scheduled_jobs").WillReturnError(fmt.Errorf("pop"))
+	err := js.EnsureRecurringJob(ctx, js.p.NOTX(), "recurring1", "job1", "@hourly")
+	assert.Regexp(t, "pop", err)
+
+	js, mdb = newMockDBJobScheduler(t)
+	mdb.ExpectExec("INSERT.*scheduled_jobs").WillReturnError(fmt.Errorf("pop"))
+	_, err = js.ScheduleJob(ctx, js.p.NOTX(), &components.ScheduleJobRequest{JobType: "job1"})
+	assert.Regexp(t, "pop", err)
+
+	// Failing to record the result leaves the job to be run again when the lease expires
+	js, mdb = newMockDBJobScheduler(t)
+	mdb.ExpectExec("UPDATE.*scheduled_jobs").WillReturnError(fmt.Errorf("pop"))
+	js.slots <- struct{}{}
+	js.workers.Add(1)
+	js.runJob(&persistedJob{ID: uuid.New(), JobType: "job1"})
+	assert.Len(t, js.slots, 0)
+}
+
+func TestPollNoHandlers(t *testing.T) {
+	mdb, err := mockpersistence.NewSQLMockProvider()
+	require.NoError(t, err)
+	js := newTestJobScheduler(t, mdb.P, testConf())
+	require.NoError(t, js.pollJobs(context.Background()))
+	require.NoError(t, mdb.Mock.ExpectationsWereMet())
+}
@@ -0,0 +1,78 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package jobscheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mustTime(t *testing.T, s string) time.Time {
+	tm, err := time.Parse(time.RFC3339, s)
+	require.NoError(t, err)
+	return tm
+}
+
+func TestScheduleNext(t *testing.T) {
+	ctx := context.Background()
+	for _, tc := range []struct {
+		spec  string
+		after string
+		next  string
+	}{
+		{spec: "@every 90s", after: "2025-03-07T10:07:30Z", next: "2025-03-07T10:09:00Z"},
+		{spec: "*/15 * * * *", after: "2025-03-07T10:07:30Z", next: "2025-03-07T10:15:00Z"},
+		{spec: "*/15 * * * *", after: "2025-03-07T10:45:00Z", next: "2025-03-07T11:00:00Z"},
+		{spec: "5/20 * * * *", after: "2025-03-07T10:46:00Z", next: "2025-03-07T11:05:00Z"},
+		{spec: "0 9 * * 1-5", after: "2025-03-07T10:00:00Z", next: "2025-03-10T09:00:00Z"}, // Friday to Monday
+		{spec: "30 2,14 * * *", after: "2025-03-07T10:00:00Z", next: "2025-03-07T14:30:00Z"},
+		{spec: "0 0 29 2 *", after: "2025-03-07T10:00:00Z", next: "2028-02-29T00:00:00Z"},
+		{spec: "0 0 15 * 0", after: "2025-03-07T10:00:00Z", next: "2025-03-09T00:00:00Z"}, // Sunday before the 15th
+		{spec: "0 0 * * 7", after: "2025-03-07T10:00:00Z", next: "2025-03-09T00:00:00Z"},
+		{spec: "0-10/5 12 1 1-6/2 *", after: "2025-03-01T12:05:00Z", next: "2025-03-01T12:10:00Z"},
+		{spec: "@daily", after: "2025-12-31T10:00:00Z", next: "2026-01-01T00:00:00Z"},
+		{spec: "@hourly", after: "2025-03-07T10:59:59Z", next: "2025-03-07T11:00:00Z"},
+	} {
+		s, err := parseSchedule(ctx, tc.spec)
+		require.NoError(t, err, tc.spec)
+		next, ok := s.next(mustTime(t, tc.after))
+		require.True(t, ok, tc.spec)
+		assert.Equal(t, mustTime(t, tc.next), next.UTC(), tc.spec)
+	}
+}
+
+func TestScheduleNoNextRun(t *testing.T) {
+	s, err := parseSchedule(context.Background(), "0 0 31 2 *")
+	require.NoError(t, err)
+	_, ok := s.next(time.Now())
+	assert.False(t, ok)
+}
+
+func TestScheduleInvalid(t *testing.T) {
+	ctx := context.Background()
+	for _, spec := range []string{"", "@every", "@every nope", "@every -1s", "@fortnightly", "* * * *", "* * * * * *"} {
+		_, err := parseSchedule(ctx, spec)
+		assert.Regexp(t, "PD012700", err, spec)
+	}
+	for _, spec := range []string{"60 * * * *", "* 24 * * *", "* * 0 * *", "* * * 13 *", "* * * * 8", "*/0 * * * *", "*/x * * * *", "5-1 * * * *", "a * * * *", "1-b * * * *", "1,,2 * * * *"} {
+		_, err := parseSchedule(ctx, spec)
+		assert.Regexp(t, "PD012701", err, spec)
+	}
+}
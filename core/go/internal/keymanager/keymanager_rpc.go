@@ -19,10 +19,13 @@ package keymanager
 import (
 	"context"
 
+	"github.com/hyperledger/firefly-signer/pkg/eip712"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/query"
+	"github.com/kaleido-io/paladin/toolkit/pkg/algorithms"
 	"github.com/kaleido-io/paladin/toolkit/pkg/rpcserver"
+	"github.com/kaleido-io/paladin/toolkit/pkg/verifiers"
 )
 
 func (km *keyManager) RPCModule() *rpcserver.RPCModule {
@@ -35,7 +38,8 @@ func (km *keyManager) initRPC() {
 		Add("keymgr_resolveKey", km.rpcResolveKey()).
 		Add("keymgr_resolveEthAddress", km.rpcResolveEthAddress()).
 		Add("keymgr_reverseKeyLookup", km.rpcReverseKeyLookup()).
-		Add("keymgr_queryKeys", km.rpcQueryKeys())
+		Add("keymgr_queryKeys", km.rpcQueryKeys()).
+		Add("keymgr_signTypedData", km.rpcSignTypedData())
 
 }
 
@@ -52,6 +56,9 @@ func (km *keyManager) rpcResolveKey() rpcserver.RPCHandler {
 		algorithm string,
 		verifierType string,
 	) (*pldapi.KeyMappingAndVerifier, error) {
+		if err := rpcserver.CheckTenantIdentity(ctx, identifier); err != nil {
+			return nil, err
+		}
 		return km.ResolveKeyNewDatabaseTX(ctx, identifier, algorithm, verifierType)
 	})
 }
@@ -60,6 +67,9 @@ func (km *keyManager) rpcResolveEthAddress() rpcserver.RPCHandler {
 	return rpcserver.RPCMethod1(func(ctx context.Context,
 		identifier string,
 	) (*pldtypes.EthAddress, error) {
+		if err := rpcserver.CheckTenantIdentity(ctx, identifier); err != nil {
+			return nil, err
+		}
 		return km.ResolveEthAddressNewDatabaseTX(ctx, identifier)
 	})
 }
@@ -70,7 +80,14 @@ func (km *keyManager) rpcReverseKeyLookup() rpcserver.RPCHandler {
 		verifierType string,
 		verifier string,
 	) (*pldapi.KeyMappingAndVerifier, error) {
-		return km.ReverseKeyLookup(ctx, km.p.NOTX(), algorithm, verifierType, verifier)
+		mapping, err := km.ReverseKeyLookup(ctx, km.p.NOTX(), algorithm, verifierType, verifier)
+		if err == nil {
+			err = rpcserver.CheckTenantIdentity(ctx, mapping.Identifier)
+		}
+		if err != nil {
+			return nil, err
+		}
+		return mapping, nil
 	})
 }
 
@@ -81,3 +98,19 @@ func (km *keyManager) rpcQueryKeys() rpcserver.RPCHandler {
 		return km.QueryKeys(ctx, km.p.DB(), &jq)
 	})
 }
+
+func (km *keyManager) rpcSignTypedData() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod2(func(ctx context.Context,
+		keyIdentifier string,
+		typedData pldapi.EIP712TypedData,
+	) (pldtypes.HexBytes, error) {
+		if err := rpcserver.CheckTenantIdentity(ctx, keyIdentifier); err != nil {
+			return nil, err
+		}
+		mapping, err := km.ResolveKeyNewDatabaseTX(ctx, keyIdentifier, algorithms.ECDSA_SECP256K1, verifiers.ETH_ADDRESS)
+		if err != nil {
+			return nil, err
+		}
+		return km.SignTypedData(ctx, mapping, (*eip712.TypedData)(&typedData))
+	})
+}
@@ -18,9 +18,13 @@ package keymanager
 import (
 	"context"
 	"fmt"
+	"os"
+	"path"
 	"testing"
 
 	"github.com/go-resty/resty/v2"
+	"github.com/hyperledger/firefly-signer/pkg/eip712"
+	"github.com/hyperledger/firefly-signer/pkg/secp256k1"
 	"github.com/kaleido-io/paladin/config/pkg/confutil"
 	"github.com/kaleido-io/paladin/config/pkg/pldconf"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
@@ -139,15 +143,107 @@ func TestRPCLocalDetails(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, resolvedKey, reverseLookedUp)
 
+	typedData := &pldapi.EIP712TypedData{
+		Types: eip712.TypeSet{
+			eip712.EIP712Domain: eip712.Type{{Name: "name", Type: "string"}},
+			"Permit":            eip712.Type{{Name: "spender", Type: "address"}, {Name: "value", Type: "uint256"}},
+		},
+		PrimaryType: "Permit",
+		Domain:      map[string]interface{}{"name": "Token"},
+		Message:     map[string]interface{}{"spender": ethAddress.String(), "value": "1000"},
+	}
+	var signature pldtypes.HexBytes
+	err = rpc.CallRPC(ctx, &signature, "keymgr_signTypedData", "my.key.1", typedData)
+	require.NoError(t, err)
+	hash, encErr := eip712.EncodeTypedDataV4(ctx, (*eip712.TypedData)(typedData))
+	require.NoError(t, encErr)
+	sig, decErr := secp256k1.DecodeCompactRSV(ctx, signature)
+	require.NoError(t, decErr)
+	signer, recoverErr := sig.RecoverDirect(hash, 0)
+	require.NoError(t, recoverErr)
+	assert.Equal(t, ethAddress.String(), pldtypes.EthAddress(*signer).String())
+
+	err = rpc.CallRPC(ctx, &signature, "keymgr_signTypedData", "", typedData)
+	assert.Regexp(t, "PD010500", err)
+
+}
+
+func TestRPCTenantIdentities(t *testing.T) {
+	ctx, km, _, done := newTestDBKeyManagerWithWallets(t, hdWalletConfig("hdwallet1", ""))
+	defer done()
+
+	tokenFile := path.Join(t.TempDir(), "token")
+	err := os.WriteFile(tokenFile, []byte("app1token"), 0600)
+	require.NoError(t, err)
+	rpc, tenantRPC, rpcDone := newTestRPCServerConf(t, ctx, km, pldconf.RPCServerTenantAuthConfig{
+		Tenants: []*pldconf.RPCServerTenantConfig{
+			{Name: "app1", TokenFile: tokenFile, Identities: []string{"app1."}},
+		},
+	}, "app1token")
+	defer rpcDone()
+
+	// Un-scoped callers can resolve any key
+	var app1Key, app2Key *pldapi.KeyMappingAndVerifier
+	err = rpc.CallRPC(ctx, &app1Key, "keymgr_resolveKey", "app1.key1", algorithms.ECDSA_SECP256K1, verifiers.ETH_ADDRESS)
+	require.NoError(t, err)
+	err = rpc.CallRPC(ctx, &app2Key, "keymgr_resolveKey", "app2.key1", algorithms.ECDSA_SECP256K1, verifiers.ETH_ADDRESS)
+	require.NoError(t, err)
+
+	// The tenant can only use its own keys
+	var resolvedKey *pldapi.KeyMappingAndVerifier
+	err = tenantRPC.CallRPC(ctx, &resolvedKey, "keymgr_resolveKey", "app1.key1", algorithms.ECDSA_SECP256K1, verifiers.ETH_ADDRESS)
+	require.NoError(t, err)
+	assert.Equal(t, app1Key, resolvedKey)
+	err = tenantRPC.CallRPC(ctx, &resolvedKey, "keymgr_resolveKey", "app2.key1", algorithms.ECDSA_SECP256K1, verifiers.ETH_ADDRESS)
+	assert.Regexp(t, "PD020715.*app1.*app2.key1", err)
+
+	var ethAddress *pldtypes.EthAddress
+	err = tenantRPC.CallRPC(ctx, &ethAddress, "keymgr_resolveEthAddress", "app2.key1")
+	assert.Regexp(t, "PD020715", err)
+
+	err = tenantRPC.CallRPC(ctx, &resolvedKey, "keymgr_reverseKeyLookup", algorithms.ECDSA_SECP256K1, verifiers.ETH_ADDRESS, app1Key.Verifier.Verifier)
+	require.NoError(t, err)
+	assert.Equal(t, app1Key, resolvedKey)
+	err = tenantRPC.CallRPC(ctx, &resolvedKey, "keymgr_reverseKeyLookup", algorithms.ECDSA_SECP256K1, verifiers.ETH_ADDRESS, app2Key.Verifier.Verifier)
+	assert.Regexp(t, "PD020715", err)
+
+	var queryEntries []*pldapi.KeyQueryEntry
+	err = rpc.CallRPC(ctx, &queryEntries, "keymgr_queryKeys", query.NewQueryBuilder().Equal("isKey", true).Limit(10).Query())
+	require.NoError(t, err)
+	assert.Len(t, queryEntries, 2)
+	err = tenantRPC.CallRPC(ctx, &queryEntries, "keymgr_queryKeys", query.NewQueryBuilder().Equal("isKey", true).Limit(10).Query())
+	require.NoError(t, err)
+	require.Len(t, queryEntries, 1)
+	assert.Equal(t, "app1.key1", queryEntries[0].Path)
+	err = tenantRPC.CallRPC(ctx, &queryEntries, "keymgr_queryKeys", query.NewQueryBuilder().Equal("parent", "app2").Limit(10).Query())
+	require.NoError(t, err)
+	assert.Empty(t, queryEntries)
+
+	typedData := &pldapi.EIP712TypedData{
+		Types:       eip712.TypeSet{eip712.EIP712Domain: eip712.Type{{Name: "name", Type: "string"}}},
+		PrimaryType: eip712.EIP712Domain,
+		Domain:      map[string]interface{}{"name": "Token"},
+	}
+	var signature pldtypes.HexBytes
+	err = tenantRPC.CallRPC(ctx, &signature, "keymgr_signTypedData", "app1.key1", typedData)
+	require.NoError(t, err)
+	err = tenantRPC.CallRPC(ctx, &signature, "keymgr_signTypedData", "app2.key1", typedData)
+	assert.Regexp(t, "PD020715", err)
 }
 
 func newTestRPCServer(t *testing.T, ctx context.Context, km *keyManager) (rpcclient.Client, func()) {
+	c, _, done := newTestRPCServerConf(t, ctx, km, pldconf.RPCServerTenantAuthConfig{}, "")
+	return c, done
+}
+
+func newTestRPCServerConf(t *testing.T, ctx context.Context, km *keyManager, tenantAuth pldconf.RPCServerTenantAuthConfig, token string) (rpcclient.Client, rpcclient.Client, func()) {
 
 	s, err := rpcserver.NewRPCServer(ctx, &pldconf.RPCServerConfig{
 		HTTP: pldconf.RPCServerConfigHTTP{
 			HTTPServerConfig: pldconf.HTTPServerConfig{Address: confutil.P("127.0.0.1"), Port: confutil.P(0)},
 		},
-		WS: pldconf.RPCServerConfigWS{Disabled: true},
+		WS:         pldconf.RPCServerConfigWS{Disabled: true},
+		TenantAuth: tenantAuth,
 	})
 	require.NoError(t, err)
 	err = s.Start()
@@ -156,7 +252,8 @@ func newTestRPCServer(t *testing.T, ctx context.Context, km *keyManager) (rpccli
 	s.Register(km.RPCModule())
 
 	c := rpcclient.WrapRestyClient(resty.New().SetBaseURL(fmt.Sprintf("http://%s", s.HTTPAddr())))
+	tokenClient := rpcclient.WrapRestyClient(resty.New().SetBaseURL(fmt.Sprintf("http://%s", s.HTTPAddr())).SetAuthToken(token))
 
-	return c, s.Stop
+	return c, tokenClient, s.Stop
 
 }
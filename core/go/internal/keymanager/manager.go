@@ -17,8 +17,11 @@ package keymanager
 
 import (
 	"context"
+	"encoding/json"
+	"strings"
 	"sync"
 
+	"github.com/hyperledger/firefly-signer/pkg/eip712"
 	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
 	"github.com/kaleido-io/paladin/config/pkg/pldconf"
 	"github.com/kaleido-io/paladin/core/internal/components"
@@ -35,6 +38,7 @@ import (
 	"github.com/kaleido-io/paladin/toolkit/pkg/cache"
 	"github.com/kaleido-io/paladin/toolkit/pkg/rpcserver"
 	"github.com/kaleido-io/paladin/toolkit/pkg/signerapi"
+	"github.com/kaleido-io/paladin/toolkit/pkg/signpayloads"
 	"github.com/kaleido-io/paladin/toolkit/pkg/verifiers"
 )
 
@@ -59,9 +63,9 @@ func NewKeyManager(bgCtx context.Context, conf *pldconf.KeyManagerConfig) compon
 	return &keyManager{
 		bgCtx:                   bgCtx,
 		conf:                    conf,
-		identifierCache:         cache.NewCache[string, *pldapi.KeyMappingWithPath](&conf.IdentifierCache, &pldconf.KeyManagerDefaults.IdentifierCache),
-		verifierByIdentityCache: cache.NewCache[string, *pldapi.KeyVerifier](&conf.VerifierCache, &pldconf.KeyManagerDefaults.VerifierCache),
-		verifierReverseCache:    cache.NewCache[string, *pldapi.KeyMappingAndVerifier](&conf.VerifierCache, &pldconf.KeyManagerDefaults.VerifierCache),
+		identifierCache:         cache.NewNamedCache[string, *pldapi.KeyMappingWithPath]("keymanager.identifiers", &conf.IdentifierCache, &pldconf.KeyManagerDefaults.IdentifierCache),
+		verifierByIdentityCache: cache.NewNamedCache[string, *pldapi.KeyVerifier]("keymanager.verifiersByIdentity", &conf.VerifierCache, &pldconf.KeyManagerDefaults.VerifierCache),
+		verifierReverseCache:    cache.NewNamedCache[string, *pldapi.KeyMappingAndVerifier]("keymanager.verifiersReverse", &conf.VerifierCache, &pldconf.KeyManagerDefaults.VerifierCache),
 		walletsByName:           make(map[string]*wallet),
 	}
 }
@@ -106,6 +110,17 @@ func (km *keyManager) Sign(ctx context.Context, mapping *pldapi.KeyMappingAndVer
 	return w.sign(ctx, mapping, payloadType, payload)
 }
 
+func (km *keyManager) SignTypedData(ctx context.Context, mapping *pldapi.KeyMappingAndVerifier, typedData *eip712.TypedData) ([]byte, error) {
+	if mapping.Verifier.Algorithm != algorithms.ECDSA_SECP256K1 {
+		return nil, i18n.NewError(ctx, msgs.MsgKeyManagerTypedDataAlgorithm, mapping.Verifier.Algorithm)
+	}
+	payload, err := json.Marshal(typedData)
+	if err != nil {
+		return nil, err
+	}
+	return km.Sign(ctx, mapping, signpayloads.EIP712_TO_RSV, payload)
+}
+
 func (km *keyManager) lockAllocationOrGetOwner(kr *keyResolver) *keyResolver {
 	km.allocLock.Lock()
 	defer km.allocLock.Unlock()
@@ -256,6 +271,7 @@ func (km *keyManager) QueryKeys(ctx context.Context, dbTX *gorm.DB, jq *query.Qu
 	q.Joins("LEFT OUTER JOIN key_mappings ON key_paths.path = key_mappings.identifier")
 	q.Joins(`LEFT OUTER JOIN (SELECT parent AS "p" from key_paths AS p) AS k ON key_paths.path = k.p`)
 	q.Where("key_paths.path != ''")
+	q = tenantKeyScope(ctx, dbTX, q)
 
 	err = q.Find(&keyList).Error
 	if err != nil {
@@ -290,3 +306,22 @@ func (km *keyManager) QueryKeys(ctx context.Context, dbTX *gorm.DB, jq *query.Qu
 
 	return keyList, nil
 }
+
+// Restricts a key query to the identities of the tenant of the calling RPC request (if any)
+func tenantKeyScope(ctx context.Context, db, q *gorm.DB) *gorm.DB {
+	tenant := rpcserver.TenantFromContext(ctx)
+	if tenant == nil || len(tenant.Identities) == 0 {
+		return q
+	}
+	var matchAny *gorm.DB
+	for i, prefix := range tenant.Identities {
+		if i == 0 {
+			matchAny = db.Where(`key_paths.path LIKE ? ESCAPE '\'`, likeEscaper.Replace(prefix)+"%")
+		} else {
+			matchAny = matchAny.Or(`key_paths.path LIKE ? ESCAPE '\'`, likeEscaper.Replace(prefix)+"%")
+		}
+	}
+	return q.Where(matchAny)
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
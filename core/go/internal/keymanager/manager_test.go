@@ -23,6 +23,7 @@ import (
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/google/uuid"
+	"github.com/hyperledger/firefly-signer/pkg/eip712"
 	"github.com/hyperledger/firefly-signer/pkg/secp256k1"
 	"github.com/kaleido-io/paladin/config/pkg/pldconf"
 	"github.com/kaleido-io/paladin/core/internal/components"
@@ -401,6 +402,18 @@ func TestSignUnknownWallet(t *testing.T) {
 
 }
 
+func TestSignTypedDataWrongAlgorithm(t *testing.T) {
+
+	ctx, km, _, done := newTestDBKeyManagerWithWallets(t)
+	defer done()
+
+	_, err := km.SignTypedData(ctx, &pldapi.KeyMappingAndVerifier{Verifier: &pldapi.KeyVerifier{
+		Algorithm: "test:blue",
+	}}, &eip712.TypedData{})
+	assert.Regexp(t, "PD010515", err)
+
+}
+
 type testSigner struct {
 	getMinimumKeyLen func(ctx context.Context, algorithm string) (int, error)
 	getVerifier      func(ctx context.Context, algorithm string, verifierType string, privateKey []byte) (string, error)
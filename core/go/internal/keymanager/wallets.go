@@ -17,12 +17,15 @@ package keymanager
 
 import (
 	"context"
+	"errors"
 	"regexp"
 
 	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
 	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/common/go/pkg/pldmsgs"
 	"github.com/kaleido-io/paladin/config/pkg/confutil"
 	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/internal/components"
 	"github.com/kaleido-io/paladin/core/internal/msgs"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
@@ -95,6 +98,30 @@ func (km *keyManager) getWalletList() []*pldapi.WalletInfo {
 	return walletNames
 }
 
+func (km *keyManager) CheckWallets(ctx context.Context) []*components.WalletStatus {
+	statuses := make([]*components.WalletStatus, len(km.walletsOrdered))
+	for i, w := range km.walletsOrdered {
+		statuses[i] = w.checkKeyStore(ctx)
+	}
+	return statuses
+}
+
+// Listing a single key is the cheapest round trip we can make to the key store, so we use it
+// as a probe. Key stores without listing support are assumed to be reachable.
+func (w *wallet) checkKeyStore(ctx context.Context) *components.WalletStatus {
+	status := &components.WalletStatus{Name: w.name, Listable: true}
+	_, err := w.signingModule.List(ctx, &signerapi.ListKeysRequest{Limit: 1})
+	if err != nil {
+		var pdErr i18n.PDError
+		if errors.As(err, &pdErr) && pdErr.MessageKey() == pldmsgs.MsgSigningKeyListingNotSupported {
+			status.Listable = false
+		} else {
+			status.Error = err.Error()
+		}
+	}
+	return status
+}
+
 func (w *wallet) resolveKeyAndVerifier(ctx context.Context, mapping *pldapi.KeyMappingWithPath, algorithm, verifierType string) (*pldapi.KeyMappingAndVerifier, error) {
 
 	req := &signerapi.ResolveKeyRequest{
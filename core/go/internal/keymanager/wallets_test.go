@@ -129,3 +129,36 @@ func TestSignError(t *testing.T) {
 	}, "any", []byte("payload"))
 	assert.Regexp(t, "pop", err)
 }
+
+func TestCheckWallets(t *testing.T) {
+
+	ctx, km, _, done := newTestKeyManager(t, false, &pldconf.KeyManagerConfig{
+		Wallets: []*pldconf.WalletConfig{
+			hdWalletConfig("hdwallet1", ""),
+			hdWalletConfig("hdwallet2", ""),
+			hdWalletConfig("hdwallet3", ""),
+		},
+	})
+	defer done()
+
+	w2, err := km.getWalletByName(ctx, "hdwallet2")
+	require.NoError(t, err)
+	ms2 := signermocks.NewSigningModule(t)
+	ms2.On("List", mock.Anything, mock.Anything).Return(&signerapi.ListKeysResponse{}, nil)
+	w2.signingModule = ms2
+
+	w3, err := km.getWalletByName(ctx, "hdwallet3")
+	require.NoError(t, err)
+	ms3 := signermocks.NewSigningModule(t)
+	ms3.On("List", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("pop"))
+	w3.signingModule = ms3
+
+	statuses := km.CheckWallets(ctx)
+	require.Len(t, statuses, 3)
+	assert.Equal(t, "hdwallet1", statuses[0].Name)
+	assert.False(t, statuses[0].Listable)
+	assert.Empty(t, statuses[0].Error)
+	assert.True(t, statuses[1].Listable)
+	assert.Empty(t, statuses[1].Error)
+	assert.Equal(t, "pop", statuses[2].Error)
+}
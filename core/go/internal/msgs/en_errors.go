@@ -18,6 +18,7 @@ package msgs
 
 import (
 	"fmt"
+	"net/http"
 	"strings"
 	"sync"
 
@@ -76,6 +77,30 @@ var (
 	MsgComponentDebugServerStartError      = pde("PD010033", "Error starting debug server")
 	MsgComponentGroupManagerInitError      = pde("PD010034", "Error initializing privacy group manager")
 	MsgComponentGroupManagerStartError     = pde("PD010035", "Error starting group manager ")
+	MsgComponentInvalidLogLevel            = pde("PD010036", "Invalid log level '%s'", 400)
+	MsgComponentBackupNotQuiesced          = pde("PD010037", "The node must be quiesced with admin_quiesce before a backup manifest is created", 400)
+	MsgComponentRestoreBehindChain         = pde("PD010038", "The database is behind the blockchain for signer %s since backup %s was taken: next nonce in the database is %d, but %d transactions are on chain. Set backup.validateRestore=false to start regardless")
+	MsgComponentRestoreValidationError     = pde("PD010039", "Error validating the database against backup %s")
+	MsgComponentConfigReloadFailed         = pde("PD010040", "Failed to apply the reloaded config")
+	MsgComponentHealthCheckTimeout         = pde("PD010041", "Health check did not complete within %s")
+	MsgComponentHealthBlockIndexerLag      = pde("PD010042", "Block indexer is %d blocks behind the chain head (max=%d)")
+	MsgComponentHealthPluginsNotReady      = pde("PD010043", "Plugins not initialized: %v")
+	MsgComponentHealthWalletsNotReady      = pde("PD010044", "Key stores not accessible for wallets: %v")
+	MsgComponentPurgeMissingField          = pde("PD010045", "Purge request must include '%s'", 400)
+	MsgComponentPurgeNoTargets             = pde("PD010046", "Purge request must include at least one state or transaction", 400)
+	MsgComponentPurgeRequestNotFound       = pde("PD010047", "Purge request '%s' not found", 404)
+	MsgComponentPurgeNotPending            = pde("PD010048", "Purge request '%s' cannot be approved as it is %s", 409)
+	MsgComponentPurgeSelfApproval          = pde("PD010049", "Purge request '%s' must be approved by someone other than the requester '%s'", 403)
+	MsgComponentJobSchedulerInitError      = pde("PD010050", "Error initializing job scheduler")
+	MsgComponentJobSchedulerStartError     = pde("PD010051", "Error starting job scheduler")
+	MsgComponentContractManagerInitError   = pde("PD010052", "Error initializing contract manager")
+	MsgComponentContractManagerStartError  = pde("PD010053", "Error starting contract manager")
+	MsgComponentWebhooksInitError          = pde("PD010054", "Error initializing webhooks manager")
+	MsgComponentWebhooksStartError         = pde("PD010055", "Error starting webhooks manager")
+	MsgComponentPurgeAdminRequired         = pde("PD010056", "Purge requests must be made and approved by a named admin using their own token", 403)
+	MsgComponentPurgeAdminMismatch         = pde("PD010057", "'%s' was supplied as '%s', but the request was authenticated as admin '%s'", 403)
+	MsgComponentPurgeStatesUnspent         = pde("PD010058", "Only spent states can be purged - states not spent: %v", 409)
+	MsgComponentPurgeTransactionsPending   = pde("PD010059", "Only transactions that have a receipt can be purged - transactions pending: %v", 409)
 
 	// States PD0101XX
 	MsgStateInvalidLength             = pde("PD010101", "Invalid hash len expected=%d actual=%d")
@@ -109,6 +134,14 @@ var (
 	MsgStateFlushInProgress           = pde("PD010131", "A flush is already in progress for this domain context")
 	MsgDomainContextImportInvalidJSON = pde("PD010132", "Attempted to import state locks but the JSON could not be parsed")
 	MsgDomainContextImportBadStates   = pde("PD010133", "Attempted to import state failed")
+	MsgStateSnapshotHashMismatch      = pde("PD010134", "The snapshot hash '%s' does not match the calculated hash '%s'")
+	MsgStateSnapshotSchemaMismatch    = pde("PD010135", "The ID of schema '%s' in the snapshot does not match the hash of its definition '%s'")
+	MsgStateSnapshotWrongDomain       = pde("PD010136", "Entry '%s' in the snapshot is for domain '%s' rather than the snapshot domain '%s'")
+	MsgStateSnapshotWrongContract     = pde("PD010137", "State '%s' in the snapshot is not for contract '%s'")
+	MsgStateSnapshotSchemaMissing     = pde("PD010138", "State '%s' in the snapshot refers to schema '%s' that is not in the snapshot")
+	MsgStateExplainDomainContext      = pde("PD010139", "Query explanation is only supported for the available, confirmed, unconfirmed, spent and all status qualifiers: %s")
+	MsgStateAnchoringInvalidConfig    = pde("PD010140", "State anchoring requires a valid contractAddress and from identity")
+	MsgStateAnchorRootMismatch        = pde("PD010141", "Recalculated root %s of state anchor %s does not match the stored root %s")
 
 	// Persistence PD0102XX
 	MsgPersistenceInvalidType          = pde("PD010200", "Invalid persistence type: %s")
@@ -151,6 +184,7 @@ var (
 	MsgKeyManagerIdentifierPathNotFound     = pde("PD010512", "Identifier path segment '%s' not found in database")
 	MsgKeyManagerExistingIdentifierNotFound = pde("PD010513", "Identifier '%s' not found in database")
 	MsgKeyManagerMissingDatabaseTxn         = pde("PD010514", "Missing database transaction context")
+	MsgKeyManagerTypedDataAlgorithm         = pde("PD010515", "EIP-712 typed data can only be signed with ecdsa:secp256k1 keys (algorithm='%s')")
 
 	// Comms bus PD0106XX
 	MsgDestinationNotFound     = pde("PD010600", "Destination not found: %s")
@@ -180,6 +214,8 @@ var (
 	MsgFiltersValueInvalidHexBytes32      = pde("PD010719", "Failed to parse value as 32 byte hex string (parsedBytes=%d)")
 	MsgFiltersValueInvalidUUID            = pde("PD010720", "Failed to parse value as UUID: %v")
 	MsgFiltersQueryLimitRequired          = pde("PD010721", "limit is required on all queries")
+	MsgFiltersFieldTypeNotJSON            = pde("PD010722", "Field does not support JSON path comparison (%T)")
+	MsgFiltersJSONPathInvalid             = pde("PD010723", "Invalid JSON path '%s'")
 
 	// Plugin controller PD0112XX
 	MsgPluginLoaderUUIDError   = pde("PD011200", "Plugin loader UUID incorrect")
@@ -190,6 +226,7 @@ var (
 	MsgPluginBadResponseBody   = pde("PD011205", "%s %s returned invalid response body %T")
 	MsgPluginError             = pde("PD011206", "%s %s returned error: %s")
 	MsgPluginLoadFailed        = pde("PD011207", "Plugin load failed: %s")
+	MsgPluginClasspathNotJar   = pde("PD011208", "Plugin '%s' has a classpath configured, but is of type '%s' rather than 'jar'")
 
 	// BlockIndexer PD0113XX
 	MsgBlockIndexerInvalidFromBlock         = pde("PD011300", "Invalid from block '%s' (must be 'latest' or number)")
@@ -203,6 +240,23 @@ var (
 	MsgBlockIndexerConfirmedBlockNotFound   = pde("PD011310", "Block %s (%d) not found on retrieval after detection and requested number of confirmations")
 	MsgBlockIndexerLimitRequired            = pde("PD011311", "limit is required on all queries")
 	MsgBlockIndexerEventStreamNotFound      = pde("PD011312", "Event stream not found: %s")
+	MsgBlockIndexerBackfillRunning          = pde("PD011313", "A backfill is already running from block %d to block %d")
+	MsgBlockIndexerBackfillInvalidRange     = pde("PD011314", "Invalid backfill range from block %d to block %d")
+	MsgBlockIndexerBackfillNoHead           = pde("PD011315", "Backfill cannot start until the block indexer has established the block it is indexing from")
+	MsgBlockIndexerBackfillAheadOfHead      = pde("PD011316", "Backfill must end before block %d, which the block indexer is indexing from")
+	MsgBlockIndexerBackfillNotRunning       = pde("PD011317", "No backfill is running")
+	MsgBlockIndexerBackfillBlockNotFound    = pde("PD011318", "Block %d not found during backfill")
+	MsgBlockIndexerReplayNoHandler          = pde("PD011319", "Event stream %s is not active on this node, so events cannot be replayed to it")
+	MsgBlockIndexerReplayTxNotIndexed       = pde("PD011320", "Transaction %s has not been indexed")
+	MsgBlockIndexerNoChainTime              = pde("PD011321", "No blocks have been received from the chain yet to establish the chain time")
+	MsgBlockIndexerHeaderConsensusInvalid   = pde("PD011322", "Invalid header verification consensus '%s' (must be 'none', 'qbft' or 'ibft2')")
+	MsgBlockIndexerHeaderValidatorInvalid   = pde("PD011323", "Invalid header verification validator address '%s'")
+	MsgBlockIndexerHeaderInvalid            = pde("PD011324", "Invalid header for block %d")
+	MsgBlockIndexerHeaderHashMismatch       = pde("PD011325", "Block %d has hash %s reported by the node, but hash %s calculated from its header")
+	MsgBlockIndexerHeaderParentMismatch     = pde("PD011326", "Block %d has parent hash %s, but block %d was verified with hash %s")
+	MsgBlockIndexerHeaderFinalityViolated   = pde("PD011327", "Block %d has hash %s, but block %d was already verified with hash %s")
+	MsgBlockIndexerHeaderNoValidators       = pde("PD011328", "Validators must be configured to verify the committed seals of %s blocks")
+	MsgBlockIndexerHeaderInsufficientSeals  = pde("PD011329", "Block %d has committed seals from %d of %d validators, which is below the quorum of %d")
 
 	// EthClient module PD0115XX
 	MsgEthClientInvalidInput            = pde("PD011500", "Unable to convert to ABI function input (func=%s)")
@@ -223,6 +277,10 @@ var (
 	MsgEthClientReturnValueNotDecoded   = pde("PD011515", "Error return value for custom error: %s")
 	MsgEthClientReturnValueNotAvailable = pde("PD011516", "Error return value unavailable")
 	MsgEthClientNoConnection            = pde("PD011517", "No JSON/RPC connection is available to this client")
+	MsgEthClientInvalidPaddingContract  = pde("PD011518", "Invalid contract address '%s' in gas estimate padding overrides")
+	MsgEthClientInvalidTraceResult      = pde("PD011519", "Invalid account address '%s' in trace result from node")
+	MsgEthClientInvalidChainIDOverride  = pde("PD011520", "Invalid chain ID %d in chain profile")
+	MsgEthClientInvalidVBase            = pde("PD011521", "Invalid V base %d in chain profile for transaction type '%s' - a custom V base can only be used with legacy_original")
 
 	// DomainManager module PD0116XX
 	MsgDomainNotFound                         = pde("PD011600", "Domain %q not found")
@@ -288,6 +346,10 @@ var (
 	MsgDomainInvalidPGroupGenesisABI          = pde("PD011664", "Domain generated an invalid privacy group genesis ABI parameter schema")
 	MsgDomainInvalidPGroupTxTypeNotPrivate    = pde("PD011665", "Resulting wrapped function call for privacy group must be a private transaction (type=%s)")
 	MsgDomainInvalidPGroupTxCannotRedirect    = pde("PD011666", "Resulting wrapped function call must target the same smart contract (contract=%s,addr=%s)")
+	MsgDomainPaused                           = pde("PD011668", "Domain '%s' is paused and is not accepting new transactions")
+	MsgDomainRPCMethodNotSupported            = pde("PD011669", "Domain '%s' does not support RPC method '%s'")
+	MsgDomainRPCInvalidResult                 = pde("PD011670", "Domain '%s' returned invalid JSON for RPC method '%s'")
+	MsgDomainExternalCallNoDedupKey           = pde("PD011671", "External call %d returned by domain '%s' does not have a dedup key")
 
 	// Entrypoint PD0117XX
 	MsgEntrypointUnknownRunMode = pde("PD011700", "Unknown run mode '%s'")
@@ -332,6 +394,11 @@ var (
 	MsgPrivateTxMgrFunctionNotProvided           = pde("PD011836", "Function abi not provided in transaction input")
 	MsgPrivateTxMgrAssembleRequestInvalid        = pde("PD011837", "Assemble request is invalid for transaction %s")
 	MsgPrivateTxMgrAssembleTxnNotFound           = pde("PD011838", "Transaction %s not found in local node")
+	MsgPrivateTxManagerCoordinatorFailover       = pde("PD011839", "Coordinator node %s did not respond within %s. Failing over to a new coordinator")
+	MsgPrivateTxManagerReassembleExhausted       = pde("PD011840", "Transaction was re-assembled %d times but could not be endorsed. Last rejection: %s")
+	MsgPrivateTxManagerInvalidOrderedContract    = pde("PD011841", "Invalid ordered contract configuration for contract '%s'")
+	MsgPrivateTxManagerOrderingNodeNotEndorser   = pde("PD011842", "Ordering node %s cannot coordinate transaction %s as it is not in the endorsement set %v")
+	MsgPrivateTxManagerCoordinatorRevoked        = pde("PD011843", "Node %s is no longer the coordinator for transaction %s")
 
 	// Public Transaction Manager PD0119XX
 	MsgInsufficientBalance             = pde("PD011900", "Balance %s of fueling source address %s is below the required amount %s")
@@ -366,6 +433,58 @@ var (
 	MsgUpdateGasPriceLower             = pde("PD011938", "Gas price cannot be lowered for transaction (current=%s requested=%s)")
 	MsgUpdateMaxFeePerGasLower         = pde("PD011939", "Max fee per gas cannot be lowered for transaction (current=%s requested=%s)")
 	MsgUpdateNoFixedPricing            = pde("PD011940", "Cannot unset gas price for transaction with fixed gas pricing")
+	MsgGasPriceScheduleInvalidTime     = pde("PD011941", "Invalid time '%s' in gas price schedule '%s' - must be HH:MM")
+	MsgGasPriceScheduleInvalidDay      = pde("PD011942", "Invalid day '%s' in gas price schedule '%s'")
+	MsgGasPriceScheduleInvalidTimeZone = pde("PD011943", "Invalid time zone '%s' in gas price schedule '%s'")
+	MsgGasPriceScheduleInvalidPrice    = pde("PD011944", "Invalid gas price '%s' in gas price schedule '%s'")
+	MsgGasPriceScheduleInvalidMult     = pde("PD011945", "Invalid multiplier %f in gas price schedule '%s' - must be greater than zero")
+	MsgBlobTxTooManyBlobs              = pde("PD011946", "Transaction has %d blobs - the maximum is %d")
+	MsgBlobTxBlobTooLarge              = pde("PD011947", "Blob %d is %d bytes - the maximum is %d bytes, or exactly %d bytes for a complete blob")
+	MsgBlobTxInvalidBlob               = pde("PD011948", "Blob %d is invalid")
+	MsgBlobTxMissingTo                 = pde("PD011949", "Blob transactions cannot deploy contracts - the 'to' address is required")
+	MsgBlobTxInvalidFieldElement       = pde("PD011950", "Blob %d contains an invalid field element at index %d - each 32 byte element must be less than the BLS12-381 modulus")
+	MsgBlobTxMissingBlobFee            = pde("PD011951", "Blob transactions with fixed gas pricing must also set maxFeePerBlobGas")
+	MsgBlobTxMissingSidecar            = pde("PD011952", "Blob sidecar for transaction %d is incomplete - expected %d blobs, found %d")
+	MsgFuelingSourceDailyCapReached    = pde("PD011953", "Fueling source address %s has transferred %s today, and cannot transfer %s without exceeding its daily spending cap %s")
+	MsgFuelingSourceStale              = pde("PD011954", "Fueling source address %s is not used as its orchestrator is stale")
+	MsgFuelingSourceDuplicate          = pde("PD011955", "Auto-fueling source '%s' resolves to address %s, which is already configured as a source")
+	MsgPublicTxEventSubscriberStarted  = pde("PD011956", "Event subscriber '%s' cannot be added after the public transaction manager has started")
+	MsgPublicTxEventSubscriberExists   = pde("PD011957", "Event subscriber '%s' already exists")
+	MsgPublicTxEventInvalidTxIDPrefix  = pde("PD011958", "Invalid transaction ID prefix '%s' for event subscriber '%s'")
+	MsgPublicTxQuiesceTimeout          = pde("PD011959", "Timed out waiting for %d in-flight orchestrators to stop")
+	MsgPublicTxPageInvalidSort         = pde("PD011960", "Pages of public transactions can only be sorted by a single 'localId' or 'created' field: %v")
+	MsgPublicTxPageInvalidCursor       = pde("PD011961", "Invalid page cursor '%s'")
+	MsgPublicTxPageInvalidDuration     = pde("PD011962", "Invalid pendingLongerThan duration '%s'")
+	MsgSubmissionBackendInvalidType    = pde("PD011963", "Invalid type '%s' for submission backend '%s'")
+	MsgSubmissionBackendNotFound       = pde("PD011964", "Submission backend '%s' is not configured")
+	MsgSubmissionBackendInvalidSigner  = pde("PD011965", "Invalid signing address '%s' in the submission backend config")
+	MsgSubmissionBackendMissingDir     = pde("PD011966", "A directory must be configured for file submission backend '%s'")
+	MsgSubmissionBackendRelayFailed    = pde("PD011967", "Relay submission backend '%s' failed to submit the transaction with %s")
+	MsgSubmissionBackendFileFailed     = pde("PD011968", "File submission backend '%s' failed to write the transaction")
+	MsgPublicTxExpiryBlobs             = pde("PD011969", "An expiry cannot be set on blob transactions")
+	MsgPublicTxExpiryPassed            = pde("PD011970", "Expiry %s has already passed")
+	MsgPublicTxExpired                 = pde("PD011971", "Transaction expired before it was mined, and was cancelled by the no-op replacement transaction %s")
+	MsgPublicTxInjectedFault           = pde("PD011972", "Injected fault: %s")
+	MsgPublicTxFromZeroAddress         = pde("PD011973", "The from address cannot be the zero address", http.StatusBadRequest)
+	MsgPublicTxToZeroAddress           = pde("PD011974", "The to address cannot be the zero address - omit it to deploy a contract", http.StatusBadRequest)
+	MsgPublicTxGasBelowIntrinsic       = pde("PD011975", "Gas limit %d is below the minimum of %d for any transaction", http.StatusBadRequest)
+	MsgPublicTxGasAboveMax             = pde("PD011976", "Gas limit %d exceeds the maximum of %d", http.StatusBadRequest)
+	MsgPublicTxValueAboveMax           = pde("PD011977", "Value %s exceeds the maximum of %s", http.StatusBadRequest)
+	MsgPublicTxCalldataTooLarge        = pde("PD011978", "Calldata is %d bytes - the maximum is %d bytes", http.StatusBadRequest)
+	MsgPublicTxChainIDMismatch         = pde("PD011979", "Transaction is for chain %d, but the node is connected to chain %d", http.StatusBadRequest)
+	MsgPublicTxInvalidMaxValue         = pde("PD011980", "Invalid maximum value '%s' in the validation config")
+	MsgPublicTxRecovered               = pde("PD011981", "PubTx[RECOVERY] from=%s nonce=%d outcome=%s")
+	MsgPublicTxSignerNotPermitted      = pde("PD011982", "Signing address %s (key '%s') is not permitted to submit public transactions", http.StatusForbidden)
+	MsgPublicTxInvalidSignerPattern    = pde("PD011983", "Invalid signer pattern '%s' in the validation config")
+	MsgPublicTxCalldataCodecInvalid    = pde("PD011984", "Invalid calldata compression codec '%s'")
+	MsgPublicTxCalldataDecodeFailed    = pde("PD011985", "Failed to decode calldata of public transaction %d stored with codec '%s'")
+	MsgPublicTxMetadataInvalid         = pde("PD011986", "Public transaction metadata must be a JSON object")
+	MsgPublicTxPolicyContractInvalid   = pde("PD011987", "Invalid policy contract address '%s'")
+	MsgPublicTxPolicyDenied            = pde("PD011988", "Transaction from %s with digest %s was denied by policy contract %s", http.StatusForbidden)
+	MsgPublicTxPolicyCheckFailed       = pde("PD011989", "Failed to check transaction from %s against policy contract %s")
+	MsgPublicTxNonceNotFound           = pde("PD011990", "Public transaction not found from %s with nonce %d", http.StatusNotFound)
+	MsgPublicTxCancelCompleted         = pde("PD011991", "Public transaction from %s with nonce %d cannot be cancelled as it is already complete", http.StatusConflict)
+	MsgPublicTxOrchestratorQuiesced    = pde("PD011992", "Orchestrator for signing address %s not started as the public transaction manager is quiesced")
 
 	// TransportManager module PD0120XX
 	MsgTransportInvalidMessage                 = pde("PD012000", "Invalid message")
@@ -390,18 +509,37 @@ var (
 	MsgTransportStateSchemaNotAvailableLocally = pde("PD012020", "State schema not available locally: domain=%s,id=%s")
 	MsgTransportMessageNotAvailableLocally     = pde("PD012021", "Message not available locally: id=%s")
 	MsgTransportPrivacyGroupStateStorageFailed = pde("PD012022", "Storage of privacy group state failed: id=%s")
+	MsgTransportStateRequestEmpty              = pde("PD012023", "State request must include a domain and at least one state ID")
+	MsgTransportRequestedStatesNotDistributed  = pde("PD012024", "Requested states were not previously distributed to node '%s' in domain '%s': %s")
+	MsgTransportCompressionCodecInvalid        = pde("PD012025", "Invalid transport compression codec '%s'")
+	MsgTransportChunkInvalid                   = pde("PD012026", "Invalid chunk %d of %d (totalSize=%d) for message %s")
+	MsgTransportMessageTooLarge                = pde("PD012027", "Message %s exceeds the maximum message size of %d bytes")
+	MsgTransportPayloadDecodeFailed            = pde("PD012028", "Failed to decode %s payload of message %s")
+	MsgTransportPeerBanned                     = pde("PD012029", "Messages from node '%s' are rejected until %s due to repeated limit violations: %s")
+	MsgTransportInboundMessageTooLarge         = pde("PD012030", "Message %s from node '%s' of %d bytes exceeds the maximum inbound message size of %d bytes")
+	MsgTransportPeerRateLimited                = pde("PD012031", "Inbound rate limit exceeded for node '%s'")
 
 	// RegistryManager module PD0121XX
-	MsgRegistryNodeEntiresNotFound     = pde("PD012100", "No entries found for node '%s'")
-	MsgRegistryNotFound                = pde("PD012101", "Registry %q not found")
-	MsgRegistryInvalidEventSource      = pde("PD012102", "Events source %d is invalid")
-	MsgRegistryInvalidEntryID          = pde("PD012103", "Invalid entry ID '%s'")
-	MsgRegistryInvalidEntryName        = pde("PD012104", "Invalid entry name '%s'")
-	MsgRegistryInvalidPropertyName     = pde("PD012105", "Invalid property name '%s'")
-	MsgRegistryInvalidParentID         = pde("PD012106", "Invalid parent ID '%s'")
-	MsgRegistryQueryLimitRequired      = pde("PD012107", "Limit is required on all queries")
-	MsgRegistryTransportPropertyRegexp = pde("PD012108", "transports.propertyRegexp for registry '%s' is invalid")
-	MsgRegistryDollarPrefixReserved    = pde("PD012109", "Name '%s' is invalid. Dollar ('$') prefix is allowed only for reserved properties, and then is required (pluginReserved=%t)")
+	MsgRegistryNodeEntiresNotFound       = pde("PD012100", "No entries found for node '%s'")
+	MsgRegistryNotFound                  = pde("PD012101", "Registry %q not found")
+	MsgRegistryInvalidEventSource        = pde("PD012102", "Events source %d is invalid")
+	MsgRegistryInvalidEntryID            = pde("PD012103", "Invalid entry ID '%s'")
+	MsgRegistryInvalidEntryName          = pde("PD012104", "Invalid entry name '%s'")
+	MsgRegistryInvalidPropertyName       = pde("PD012105", "Invalid property name '%s'")
+	MsgRegistryInvalidParentID           = pde("PD012106", "Invalid parent ID '%s'")
+	MsgRegistryQueryLimitRequired        = pde("PD012107", "Limit is required on all queries")
+	MsgRegistryTransportPropertyRegexp   = pde("PD012108", "transports.propertyRegexp for registry '%s' is invalid")
+	MsgRegistryDollarPrefixReserved      = pde("PD012109", "Name '%s' is invalid. Dollar ('$') prefix is allowed only for reserved properties, and then is required (pluginReserved=%t)")
+	MsgRegistryCredentialMissingFields   = pde("PD012110", "Credential must have an id, entryId, type and issuer")
+	MsgRegistryCredentialIssuerUntrusted = pde("PD012111", "Credential issuer %s is not trusted by registry '%s'")
+	MsgRegistryCredentialExpired         = pde("PD012112", "Credential '%s' expired at %s")
+	MsgRegistryCredentialExists          = pde("PD012113", "Credential '%s' is already attached in registry '%s'")
+	MsgRegistryCredentialNotFound        = pde("PD012114", "Credential '%s' not found in registry '%s'")
+	MsgRegistryEntryNotFound             = pde("PD012115", "Entry '%s' not found in registry '%s'")
+	MsgRegistryTrustedIssuerInvalid      = pde("PD012116", "credentials.trustedIssuers for registry '%s' contains an invalid address '%s'")
+	MsgRegistryCredentialCheckInvalid    = pde("PD012117", "Credential check must specify a registry, a credential type, and exactly one of entryId or entryPath")
+	MsgRegistryCredentialProofInvalid    = pde("PD012118", "Proof of credential '%s' is invalid")
+	MsgRegistryCredentialNotHeld         = pde("PD012119", "No valid credential of type '%s' is held by entry '%s' in registry '%s'")
 
 	// TxMgr module PD0122XX
 	MsgTxMgrInvalidABI                            = pde("PD012201", "ABI is invalid")
@@ -453,6 +591,35 @@ var (
 	MsgTxMgrBlockchainEventListenerInvalidTimeout = pde("PD012250", "Error parsing batch timeout '%s': %s")
 	MsgTxMgrBlockchainEventListenerNoSources      = pde("PD012251", "Blockchain event listener '%s' has no sources configured")
 	MsgTxMgrBlockchainEventListenerNoABIs         = pde("PD012252", "Blockchain event listener '%s' has a source with no ABI configured")
+	MsgTxMgrDryRunPublicOnly                      = pde("PD012253", "Dry run and simulation only support public transactions")
+	MsgTxMgrEvidenceNoReceipt                     = pde("PD012254", "Transaction %s does not have a receipt, so evidence cannot be exported yet")
+	MsgTxMgrEvidenceSigningFailed                 = pde("PD012255", "Failed to sign evidence for transaction %s with key '%s'")
+	MsgTxMgrDomainEventSubscriptionInvalid        = pde("PD012257", "Invalid domain event subscription")
+	MsgTxMgrDomainEventsNotDeclared               = pde("PD012258", "Domain '%s' does not declare any events")
+	MsgTxMgrDomainEventUnknown                    = pde("PD012259", "Event '%s' is not declared by domain '%s'")
+	MsgTxMgrDomainEventNoContracts                = pde("PD012260", "At least one contract address is required to subscribe to events of domain '%s'")
+	MsgTxMgrDomainEventContractMismatch           = pde("PD012261", "Smart contract %s belongs to domain '%s' not '%s'")
+	MsgTxMgrDomainEventListenerMismatch           = pde("PD012262", "Blockchain event listener '%s' already exists with different sources to this domain event subscription")
+	MsgTxMgrExternalTxWatchInvalid                = pde("PD012263", "An external transaction watch must specify either a transactionHash, or both a from address and a nonce")
+	MsgTxMgrExternalTxWatchNotFound               = pde("PD012264", "External transaction watch %s not found")
+	MsgTxMgrReplayNoTransactionHash               = pde("PD012265", "Transaction %s does not have a confirmed base ledger transaction to replay events from")
+	MsgTxMgrSwapLegsRequired                      = pde("PD012266", "A swap requires at least two legs")
+	MsgTxMgrSwapFieldRequired                     = pde("PD012267", "Swap field '%s' is required")
+	MsgTxMgrSwapNotFound                          = pde("PD012268", "Swap %s not found")
+	MsgTxMgrSwapInvalidStatus                     = pde("PD012269", "Swap %s cannot be %s in status '%s'")
+	MsgTxMgrSwapLegFailed                         = pde("PD012270", "Preparation of swap leg %s failed: %s")
+	MsgTxMgrSwapLegNotPublic                      = pde("PD012271", "Swap leg %s prepared a %s transaction, rather than a base ledger call that can be settled")
+	MsgTxMgrSwapAtomNotFound                      = pde("PD012272", "No AtomDeployed event was found in settlement contract creation transaction %s")
+	MsgTxMgrScheduledReleaseFailed                = pde("PD012273", "Scheduled transaction could not be submitted: %s")
+	MsgTxMgrDraining                              = pde("PD012274", "The node is draining and is not accepting new transactions", 503)
+	MsgTxMgrTenantQuotaExceeded                   = pde("PD012275", "Tenant %s has %d pending transactions, and submitting %d more would exceed its limit of %d", 429)
+	MsgTxMgrSigningPoolInvalid                    = pde("PD012276", "Signing pool '%s' must have an identity and a size of at least 1")
+	MsgTxMgrSigningPoolDuplicate                  = pde("PD012277", "Signing pool '%s' is configured more than once")
+	MsgTxMgrContractABIKeyRequired                = pde("PD012278", "Exactly one of address or codeHash must be supplied to register a contract ABI")
+	MsgTxMgrContractABIEmpty                      = pde("PD012279", "The ABI to register against a contract must contain at least one entry")
+	MsgTxMgrDecodeTxNotIndexed                    = pde("PD012280", "Transaction %s has not been indexed")
+	MsgTxMgrDecodeTxNotFound                      = pde("PD012281", "Transaction %s is indexed, but was not returned by the blockchain node")
+	MsgTxMgrDryRunRollback                        = pde("PD012282", "Dry run complete - rolling back database transaction")
 
 	// FlushWriter module PD0123XX
 	MsgFlushWriterQuiescing      = pde("PD012300", "Writer shutting down")
@@ -487,4 +654,56 @@ var (
 	MsgPGroupsJSONRPCSubscriptionNack       = pde("PD012521", "JSON/RPC subscription '%s' returned nack for message batch")
 	MsgPGroupsGenesisSaltUnset              = pde("PD012522", "Genesis salt must be set")
 	MsgPGroupsReceivedGenesisInvalid        = pde("PD012523", "Received genesis state is invalid")
+	MsgPGroupsTemplateNotFound              = pde("PD012524", "Privacy group template '%s' not found")
+	MsgPGroupsTemplateRequired              = pde("PD012525", "A template is required to create privacy groups on this node")
+	MsgPGroupsTemplateNotAllowed            = pde("PD012526", "Privacy group template '%s' is not allowed on this node")
+	MsgPGroupsTemplateRegistrationDisabled  = pde("PD012527", "Registration of privacy group templates via the API is disabled on this node")
+	MsgPGroupsTemplateExists                = pde("PD012528", "Privacy group template '%s' already exists")
+	MsgPGroupsTemplateConfigured            = pde("PD012529", "Privacy group template '%s' is defined in configuration, and cannot be modified via the API")
+	MsgPGroupsTemplateDomainMismatch        = pde("PD012530", "Privacy group template '%s' is for domain '%s' not '%s'")
+	MsgPGroupsTemplatePropertyRequired      = pde("PD012531", "Property '%s' is required by privacy group template '%s'")
+	MsgPGroupsTemplateRoleUnknown           = pde("PD012532", "Role '%s' is not defined in privacy group template '%s'")
+	MsgPGroupsTemplateRoleMinMembers        = pde("PD012533", "Role '%s' in privacy group template '%s' requires at least %d members")
+	MsgPGroupsTemplateRoleMaxMembers        = pde("PD012534", "Role '%s' in privacy group template '%s' allows at most %d members")
+	MsgPGroupsTemplateRoleInvalid           = pde("PD012535", "Role '%s' in privacy group template '%s' is invalid")
+	MsgPGroupsRolesWithoutTemplate          = pde("PD012536", "Roles can only be assigned when creating a privacy group from a template")
+	MsgPGroupsTemplateReservedProperty      = pde("PD012537", "Property '%s' is reserved for groups created from a template")
+
+	// Testbed scenario runner PD0126XX
+	MsgTestbedScenarioReadFailed      = pde("PD012600", "Failed to read scenario file '%s'")
+	MsgTestbedScenarioParseFailed     = pde("PD012601", "Failed to parse scenario")
+	MsgTestbedScenarioInvalidStep     = pde("PD012602", "Scenario step %d must specify exactly one of deploy, invoke, call or rpc")
+	MsgTestbedScenarioABINotFound     = pde("PD012603", "ABI '%s' is not defined in the scenario")
+	MsgTestbedScenarioABIInvalid      = pde("PD012604", "ABI '%s' is invalid")
+	MsgTestbedScenarioVarNotFound     = pde("PD012605", "Variable '%s' is not defined")
+	MsgTestbedScenarioStepFailed      = pde("PD012606", "Scenario step %d (%s) failed")
+	MsgTestbedScenarioAssertionFailed = pde("PD012607", "Scenario step %d (%s) assertion failed at '%s': expected %s, got %s")
+	MsgTestbedScenarioExpectedError   = pde("PD012608", "Scenario step %d (%s) expected an error containing '%s' but succeeded")
+	MsgTestbedScenarioUnexpectedError = pde("PD012609", "Scenario step %d (%s) expected an error containing '%s' but got: %s")
+	MsgTestbedScenarioInvalidPath     = pde("PD012610", "Path '%s' cannot be resolved in the result")
+
+	// Job scheduler PD0127XX
+	MsgJobSchedulerInvalidSchedule  = pde("PD012700", "Invalid schedule '%s': expected five cron fields (minute hour day-of-month month day-of-week) or '@every <duration>'")
+	MsgJobSchedulerInvalidCronField = pde("PD012701", "Invalid %s field '%s' in schedule '%s'")
+	MsgJobSchedulerMissingJobType   = pde("PD012702", "Job type must be specified")
+	MsgJobSchedulerNoNextRun        = pde("PD012703", "Schedule '%s' has no future run time")
+
+	// Contract manager PD0128XX
+	MsgContractMgrNoEmbeddedBuild    = pde("PD012800", "No build is embedded in this node for base contract '%s'", 404)
+	MsgContractMgrNoFrom             = pde("PD012801", "A 'from' signing identity must be configured to deploy base contracts")
+	MsgContractMgrAlreadyDeployed    = pde("PD012802", "Base contract '%s' is already recorded (origin=%s version=%s)", 409)
+	MsgContractMgrInvalidAddress     = pde("PD012803", "Invalid address '%s' configured for base contract '%s'")
+	MsgContractMgrVersionRequired    = pde("PD012804", "A version must be configured for base contract '%s' as no build is embedded in this node")
+	MsgContractMgrInvalidPeerMessage = pde("PD012806", "Invalid base contract versions message from node '%s'")
+	MsgContractMgrPeerCheckLocalNode = pde("PD012807", "Cannot check base contract versions against the local node", 400)
+
+	// Webhooks PD0129XX
+	MsgWebhooksDuplicateName    = pde("PD012900", "Duplicate webhook name '%s'")
+	MsgWebhooksInvalidEventType = pde("PD012901", "Invalid event type '%s' for webhook '%s'")
+	MsgWebhooksSecretFileError  = pde("PD012902", "Failed to read the secret file for webhook '%s'")
+	MsgWebhooksSecretFileEmpty  = pde("PD012903", "The secret file for webhook '%s' is empty")
+	MsgWebhooksDeliveryNotFound = pde("PD012904", "Webhook delivery '%s' not found", 404)
+	MsgWebhooksNotConfigured    = pde("PD012905", "Webhook '%s' is not configured on this node", 404)
+	MsgWebhooksDeliveryPending  = pde("PD012906", "Webhook delivery '%s' is already pending", 409)
+	MsgWebhooksUnexpectedStatus = pde("PD012907", "Webhook endpoint responded with HTTP status %d")
 )
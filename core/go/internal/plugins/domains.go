@@ -125,6 +125,15 @@ func (br *domainBridge) RequestReply(ctx context.Context, reqMsg plugintk.Plugin
 				}
 			},
 		)
+	case *prototk.DomainMessage_CheckCredential:
+		return callManagerImpl(ctx, req.CheckCredential,
+			br.manager.CheckCredential,
+			func(resMsg *prototk.DomainMessage, res *prototk.CheckCredentialResponse) {
+				resMsg.ResponseToDomain = &prototk.DomainMessage_CheckCredentialRes{
+					CheckCredentialRes: res,
+				}
+			},
+		)
 	default:
 		return nil, i18n.NewError(ctx, msgs.MsgPluginBadRequestBody, req)
 	}
@@ -414,3 +423,18 @@ func (br *domainBridge) WrapPrivacyGroupEVMTX(ctx context.Context, req *prototk.
 	)
 	return
 }
+
+func (br *domainBridge) HandleRPC(ctx context.Context, req *prototk.HandleRPCRequest) (res *prototk.HandleRPCResponse, err error) {
+	err = br.toPlugin.RequestReply(ctx,
+		func(dm plugintk.PluginMessage[prototk.DomainMessage]) {
+			dm.Message().RequestToDomain = &prototk.DomainMessage_HandleRpc{HandleRpc: req}
+		},
+		func(dm plugintk.PluginMessage[prototk.DomainMessage]) bool {
+			if r, ok := dm.Message().ResponseFromDomain.(*prototk.DomainMessage_HandleRpcRes); ok {
+				res = r.HandleRpcRes
+			}
+			return res != nil
+		},
+	)
+	return
+}
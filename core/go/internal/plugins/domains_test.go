@@ -47,6 +47,7 @@ type testDomainManager struct {
 	sendTransaction     func(context.Context, *prototk.SendTransactionRequest) (*prototk.SendTransactionResponse, error)
 	localNodeName       func(context.Context, *prototk.LocalNodeNameRequest) (*prototk.LocalNodeNameResponse, error)
 	getStates           func(context.Context, *prototk.GetStatesByIDRequest) (*prototk.GetStatesByIDResponse, error)
+	checkCredential     func(context.Context, *prototk.CheckCredentialRequest) (*prototk.CheckCredentialResponse, error)
 }
 
 func (tp *testDomainManager) FindAvailableStates(ctx context.Context, req *prototk.FindAvailableStatesRequest) (*prototk.FindAvailableStatesResponse, error) {
@@ -77,6 +78,10 @@ func (tp *testDomainManager) GetStatesByID(ctx context.Context, req *prototk.Get
 	return tp.getStates(ctx, req)
 }
 
+func (tp *testDomainManager) CheckCredential(ctx context.Context, req *prototk.CheckCredentialRequest) (*prototk.CheckCredentialResponse, error) {
+	return tp.checkCredential(ctx, req)
+}
+
 func domainConnectFactory(ctx context.Context, client prototk.PluginControllerClient) (grpc.BidiStreamingClient[prototk.DomainMessage, prototk.DomainMessage], error) {
 	return client.ConnectDomain(context.Background())
 }
@@ -91,11 +96,14 @@ func domainHeaderAccessor(msg *prototk.DomainMessage) *prototk.Header {
 func (tp *testDomainManager) mock(t *testing.T) *componentmocks.DomainManager {
 	mdm := componentmocks.NewDomainManager(t)
 	pluginMap := make(map[string]*pldconf.PluginConfig)
-	for name := range tp.domains {
+	for name, td := range tp.domains {
 		pluginMap[name] = &pldconf.PluginConfig{
 			Type:    string(pldtypes.LibraryTypeCShared),
 			Library: "/tmp/not/applicable",
 		}
+		if mp, ok := td.(*mockPlugin[prototk.DomainMessage]); ok && mp.conf != nil {
+			pluginMap[name] = mp.conf
+		}
 	}
 	mdm.On("ConfiguredDomains").Return(pluginMap).Maybe()
 	mdr := mdm.On("DomainRegistered", mock.Anything, mock.Anything).Maybe()
@@ -266,6 +274,10 @@ func TestDomainRequestsOK(t *testing.T) {
 				},
 			}, nil
 		},
+		HandleRPC: func(ctx context.Context, hrr *prototk.HandleRPCRequest) (*prototk.HandleRPCResponse, error) {
+			assert.Equal(t, "domain1_custom", hrr.Method)
+			return &prototk.HandleRPCResponse{ResultJson: `{"some":"result"}`}, nil
+		},
 	}
 
 	tdm := &testDomainManager{
@@ -332,6 +344,13 @@ func TestDomainRequestsOK(t *testing.T) {
 		}, nil
 	}
 
+	tdm.checkCredential = func(ctx context.Context, ccr *prototk.CheckCredentialRequest) (*prototk.CheckCredentialResponse, error) {
+		assert.Equal(t, "KYCPassed", ccr.CredentialType)
+		return &prototk.CheckCredentialResponse{
+			Valid: true,
+		}, nil
+	}
+
 	ctx, pc, done := newTestDomainPluginManager(t, &testManagers{
 		testDomainManager: tdm,
 	})
@@ -471,6 +490,13 @@ func TestDomainRequestsOK(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, `{"wrapped":"params"}`, wpgtr.Transaction.ParamsJson)
 
+	hrr, err := domainAPI.HandleRPC(ctx, &prototk.HandleRPCRequest{
+		Method:     "domain1_custom",
+		ParamsJson: `[]`,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, `{"some":"result"}`, hrr.ResultJson)
+
 	callbacks := <-waitForCallbacks
 
 	fas, err := callbacks.FindAvailableStates(ctx, &prototk.FindAvailableStatesRequest{
@@ -514,6 +540,12 @@ func TestDomainRequestsOK(t *testing.T) {
 	})
 	require.NoError(t, err)
 	assert.Len(t, gsr.States, 1)
+
+	ccr, err := callbacks.CheckCredential(ctx, &prototk.CheckCredentialRequest{
+		CredentialType: "KYCPassed",
+	})
+	require.NoError(t, err)
+	assert.True(t, ccr.Valid)
 }
 
 func TestDomainRegisterFail(t *testing.T) {
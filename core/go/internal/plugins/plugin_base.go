@@ -60,6 +60,7 @@ type plugin[M any] struct {
 	initializing bool
 	registered   bool
 	initialized  bool
+	restartCount int
 }
 
 type pluginHandler[M any] struct {
@@ -85,6 +86,7 @@ type pluginHandler[M any] struct {
 	// Plugin gets bound late after the stream is started
 	pluginInfo      atomic.Pointer[pluginInfo]
 	pluginToManager pluginToManager[M]
+	plugin          atomic.Pointer[plugin[M]]
 }
 
 type pluginInfo struct {
@@ -96,6 +98,7 @@ type pluginInfo struct {
 func (p *plugin[CB]) notifyInitialized() {
 	p.pc.mux.Lock()
 	p.initialized = true
+	p.restartCount = 0
 	p.pc.mux.Unlock()
 	log.L(p.pc.bgCtx).Infof("Plugin load %s [%s] (type=%s) completed", p.def.Plugin, p.id, p.def.Plugin.PluginType)
 	p.pc.tapLoadingProgressed()
@@ -133,22 +136,37 @@ func newPluginHandler[M any](pm *pluginManager,
 }
 
 // Plugins connect over this channel, and must announce themselves with their ID to complete the load
-func (ph *pluginHandler[M]) serve() error {
+func (ph *pluginHandler[M]) serve() (err error) {
 	defer close(ph.serverDone)
 
 	go ph.sender()
 
+	// Returning ends the stream, which we do if the receiver ends, or if we are closed because the
+	// plugin failed a health check
+	handlerDone := ph.ctx.Done()
+	receiverDone := make(chan error, 1)
+	go func() {
+		receiverDone <- ph.receiver()
+	}()
+	select {
+	case err = <-receiverDone:
+	case <-handlerDone:
+		err = i18n.NewError(ph.stream.Context(), msgs.MsgContextCanceled)
+	}
+
+	// If we got to the point we've initialized, then we're unregistered & uninitialized when we return
+	if plugin := ph.plugin.Load(); plugin != nil {
+		plugin.notifyStopped()
+	}
+	ph.close()
+	return err
+}
+
+// We are the receiving routine for the gRPC stream (we do NOT send)
+func (ph *pluginHandler[M]) receiver() error {
 	serverCtx := ph.stream.Context()
 	var plugin *plugin[M]
 	var pi *pluginInfo
-	defer func() {
-		// If we got to the point we've initialized, then we're unregistered & uninitialized when we return
-		if plugin != nil {
-			plugin.notifyStopped()
-		}
-		ph.close()
-	}()
-	// We are the receiving routine for the gRPC stream (we do NOT send)
 	for {
 		iMsg, err := ph.stream.Recv()
 		if err != nil {
@@ -190,6 +208,10 @@ func (ph *pluginHandler[M]) serve() error {
 				// Close the connection to this plugin
 				return err
 			}
+			ph.plugin.Store(plugin)
+			if ph.pc.pingInterval > 0 {
+				go ph.pinger(ph.ctx, plugin)
+			}
 		case prototk.Header_RESPONSE_FROM_PLUGIN,
 			prototk.Header_ERROR_RESPONSE:
 			// If this is an in-flight request, then pass it back to the handler over the request channel
@@ -241,6 +263,51 @@ func (ph *pluginHandler[M]) close() {
 	<-ph.senderDone
 }
 
+// pinger checks the plugin is still processing requests, and if it stops responding we close the
+// stream and ask the loader to load it again (which stops the unresponsive instance first)
+func (ph *pluginHandler[M]) pinger(ctx context.Context, plugin *plugin[M]) {
+	ticker := time.NewTicker(ph.pc.pingInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+		if err := ph.ping(ctx); err != nil {
+			if ctx.Err() == nil {
+				log.L(ctx).Errorf("Plugin did not respond to ping within %s: %s", ph.pc.pingTimeout, err)
+				ph.cancelCtx()
+				<-ph.serverDone // so the plugin is marked as stopped before we ask for it to be loaded again
+				scheduleRestart(ctx, ph.pc, ph.pluginMap, plugin.def.Plugin)
+			}
+			return
+		}
+	}
+}
+
+// The ping is a request with no body, which the plugin answers with an empty response
+// (or an error from older plugins) without calling into the plugin implementation
+func (ph *pluginHandler[M]) ping(ctx context.Context) error {
+	reqID := uuid.New()
+	req := ph.wrapper.Wrap(new(M))
+	header := req.Header()
+	header.PluginId = ph.pluginInfo.Load().instanceID
+	header.MessageId = reqID.String()
+	header.MessageType = prototk.Header_REQUEST_TO_PLUGIN
+
+	pingCtx, cancelPing := context.WithTimeout(ctx, ph.pc.pingTimeout)
+	defer cancelPing()
+	inflight := ph.inflight.AddInflight(pingCtx, reqID)
+	defer inflight.Cancel()
+	ph.send(req)
+	_, err := inflight.Wait()
+	if err == nil {
+		log.L(ctx).Debugf("[%s] <== PING [%s]", reqID, inflight.Age())
+	}
+	return err
+}
+
 // Go routine started for each request
 func (ph *pluginHandler[M]) handleRequestFromPlugin(ctx context.Context, pi *pluginInfo, req plugintk.PluginMessage[M]) {
 	// Call the manager
@@ -18,6 +18,7 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -31,6 +32,7 @@ import (
 	"github.com/kaleido-io/paladin/config/pkg/confutil"
 	"github.com/kaleido-io/paladin/config/pkg/pldconf"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/retry"
 	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
 	"google.golang.org/grpc"
 )
@@ -54,6 +56,9 @@ type pluginManager struct {
 	network         string
 	address         string
 	shutdownTimeout time.Duration
+	restartRetry    *retry.Retry
+	pingInterval    time.Duration // zero if health checks are disabled
+	pingTimeout     time.Duration
 
 	domainManager components.DomainManager
 	domainPlugins map[uuid.UUID]*plugin[prototk.DomainMessage]
@@ -82,6 +87,8 @@ func NewPluginManager(bgCtx context.Context,
 		grpcTarget:      grpcTarget,
 		loaderID:        loaderID,
 		shutdownTimeout: confutil.DurationMin(conf.GRPC.ShutdownTimeout, 0, *pldconf.DefaultGRPCConfig.ShutdownTimeout),
+		restartRetry:    retry.NewRetryIndefinite(&conf.RestartRetry, &pldconf.PluginManagerDefaults.RestartRetry),
+		pingTimeout:     confutil.DurationMin(conf.HealthCheck.Timeout, 1*time.Millisecond, *pldconf.PluginHealthCheckDefaults.Timeout),
 
 		domainPlugins:    make(map[uuid.UUID]*plugin[prototk.DomainMessage]),
 		transportPlugins: make(map[uuid.UUID]*plugin[prototk.TransportMessage]),
@@ -92,6 +99,9 @@ func NewPluginManager(bgCtx context.Context,
 		notifySystemCommand:  make(chan prototk.PluginLoad_SysCommand, 1),
 		loadingProgressed:    make(chan *prototk.PluginLoadFailed, 1),
 	}
+	if confutil.Bool(conf.HealthCheck.Enabled, *pldconf.PluginHealthCheckDefaults.Enabled) {
+		pc.pingInterval = confutil.DurationMin(conf.HealthCheck.Interval, 1*time.Millisecond, *pldconf.PluginHealthCheckDefaults.Interval)
+	}
 	return pc
 }
 
@@ -207,6 +217,19 @@ func (pm *pluginManager) LoaderID() uuid.UUID {
 	return pm.loaderID
 }
 
+func (pm *pluginManager) GetPluginStatuses() []*components.PluginStatus {
+	statuses := pluginStatuses(pm, pm.domainPlugins)
+	statuses = append(statuses, pluginStatuses(pm, pm.transportPlugins)...)
+	statuses = append(statuses, pluginStatuses(pm, pm.registryPlugins)...)
+	sort.Slice(statuses, func(i, j int) bool {
+		if statuses[i].Type != statuses[j].Type {
+			return statuses[i].Type < statuses[j].Type
+		}
+		return statuses[i].Name < statuses[j].Name
+	})
+	return statuses
+}
+
 func (pm *pluginManager) ReloadPluginList() (err error) {
 	for name, dp := range pm.domainManager.ConfiguredDomains() {
 		if err == nil {
@@ -282,15 +305,50 @@ func (pm *pluginManager) SendSystemCommandToLoader(cmd prototk.PluginLoad_SysCom
 	}
 }
 
+// The loader reports a failure both when a plugin fails to load, and when a loaded plugin exits
+// unexpectedly. In either case we ask the loader to load it again after a backoff delay.
 func (pm *pluginManager) LoadFailed(ctx context.Context, req *prototk.PluginLoadFailed) (*prototk.EmptyResponse, error) {
 	log.L(ctx).Errorf("Plugin load %s (type=%s) failed: %s", req.Plugin.Name, req.Plugin.PluginType, req.ErrorMessage)
 	select {
 	case pm.loadingProgressed <- req:
 	default:
 	}
+	switch req.Plugin.PluginType {
+	case prototk.PluginInfo_DOMAIN:
+		scheduleRestart(ctx, pm, pm.domainPlugins, req.Plugin)
+	case prototk.PluginInfo_TRANSPORT:
+		scheduleRestart(ctx, pm, pm.transportPlugins, req.Plugin)
+	case prototk.PluginInfo_REGISTRY:
+		scheduleRestart(ctx, pm, pm.registryPlugins, req.Plugin)
+	}
 	return &prototk.EmptyResponse{}, nil
 }
 
+func scheduleRestart[CB any](ctx context.Context, pm *pluginManager, pluginMap map[uuid.UUID]*plugin[CB], info *prototk.PluginInfo) {
+	p, err := getPluginByIDString(pm, pluginMap, info.Id, info.PluginType)
+	if err != nil {
+		log.L(ctx).Warnf("Cannot restart plugin: %s", err)
+		return
+	}
+	pm.mux.Lock()
+	p.restartCount++
+	attempt := p.restartCount
+	pm.mux.Unlock()
+	delay := pm.restartRetry.Delay(attempt)
+	log.L(ctx).Infof("Plugin %s (type=%s) will be reloaded in %s (attempt=%d)", p.name, info.PluginType, delay, attempt)
+	time.AfterFunc(delay, func() {
+		pm.mux.Lock()
+		if !p.initialized {
+			p.initializing = false
+		}
+		pm.mux.Unlock()
+		select {
+		case pm.notifyPluginsUpdated <- true:
+		default:
+		}
+	})
+}
+
 func initPlugin[CB any](ctx context.Context, pm *pluginManager, pluginMap map[uuid.UUID]*plugin[CB], name string, pType prototk.PluginInfo_PluginType, conf *pldconf.PluginConfig) (err error) {
 	pm.mux.Lock()
 	defer pm.mux.Unlock()
@@ -306,8 +364,12 @@ func initPlugin[CB any](ctx context.Context, pm *pluginManager, pluginMap map[uu
 		},
 		LibLocation: conf.Library,
 		Class:       conf.Class,
+		Classpath:   conf.Classpath,
 	}
 	pluginType, err := pldtypes.LibraryType(conf.Type).Enum().Validate()
+	if err == nil && len(conf.Classpath) > 0 && pluginType != pldtypes.LibraryTypeJar {
+		err = i18n.NewError(ctx, msgs.MsgPluginClasspathNotJar, name, pluginType)
+	}
 	if err == nil {
 		plugin.def.LibType, err = MapLibraryTypeToProto(pluginType.Enum())
 		pluginMap[plugin.id] = plugin
@@ -346,6 +408,22 @@ func unloadedPlugins[CB any](pm *pluginManager, pluginMap map[uuid.UUID]*plugin[
 	return unloaded, notInitializing
 }
 
+func pluginStatuses[CB any](pm *pluginManager, pluginMap map[uuid.UUID]*plugin[CB]) []*components.PluginStatus {
+	pm.mux.Lock()
+	defer pm.mux.Unlock()
+	statuses := make([]*components.PluginStatus, 0, len(pluginMap))
+	for _, plugin := range pluginMap {
+		statuses = append(statuses, &components.PluginStatus{
+			Name:        plugin.name,
+			Type:        plugin.def.Plugin.PluginType.String(),
+			ID:          plugin.id,
+			Registered:  plugin.registered,
+			Initialized: plugin.initialized,
+		})
+	}
+	return statuses
+}
+
 func getPluginByIDString[CB any](pm *pluginManager, pluginMap map[uuid.UUID]*plugin[CB], idStr string, pbType prototk.PluginInfo_PluginType) (p *plugin[CB], err error) {
 	pm.mux.Lock()
 	defer pm.mux.Unlock()
@@ -23,6 +23,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/kaleido-io/paladin/config/pkg/confutil"
 	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/internal/components"
 	"github.com/kaleido-io/paladin/core/mocks/componentmocks"
 
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
@@ -211,6 +212,33 @@ func TestNotifyPluginUpdateNotStarted(t *testing.T) {
 	pc.SendSystemCommandToLoader(prototk.PluginLoad_THREAD_DUMP)
 }
 
+func TestGetPluginStatuses(t *testing.T) {
+	tdm := &testDomainManager{domains: map[string]plugintk.Plugin{
+		"domain2": &mockPlugin[prototk.DomainMessage]{t: t},
+		"domain1": &mockPlugin[prototk.DomainMessage]{t: t},
+	}}
+	pc := NewPluginManager(context.Background(), tempUDS(t), uuid.New(), &pldconf.PluginManagerConfig{})
+	err := pc.PostInit((&testManagers{testDomainManager: tdm}).componentMocks(t))
+	require.NoError(t, err)
+
+	pm := pc.(*pluginManager)
+	for _, p := range pm.domainPlugins {
+		if p.name == "domain2" {
+			p.registered = true
+			p.initialized = true
+		}
+	}
+
+	statuses := pc.GetPluginStatuses()
+	require.Len(t, statuses, 2)
+	assert.Equal(t, "domain1", statuses[0].Name)
+	assert.Equal(t, prototk.PluginInfo_DOMAIN.String(), statuses[0].Type)
+	assert.False(t, statuses[0].Initialized)
+	assert.Equal(t, "domain2", statuses[1].Name)
+	assert.True(t, statuses[1].Registered)
+	assert.True(t, statuses[1].Initialized)
+}
+
 func TestLoaderErrors(t *testing.T) {
 	ctx := context.Background()
 	tdm := &testDomainManager{
@@ -233,6 +261,9 @@ func TestLoaderErrors(t *testing.T) {
 			GRPC: pldconf.GRPCConfig{
 				ShutdownTimeout: confutil.P("1ms"),
 			},
+			RestartRetry: pldconf.RetryConfig{
+				InitialDelay: confutil.P("1h"), // restart is tested separately
+			},
 		})
 	err := pc.PostInit((&testManagers{testDomainManager: tdm}).componentMocks(t))
 	require.NoError(t, err)
@@ -319,3 +350,179 @@ func TestLoaderErrors(t *testing.T) {
 		_, _ = pc.(*pluginManager).LoadFailed(context.Background(), &prototk.PluginLoadFailed{Plugin: &prototk.PluginInfo{}})
 	}
 }
+
+func TestLoaderRestartsFailedPlugins(t *testing.T) {
+	ctx := context.Background()
+	newPlugin := func(libType pldtypes.LibraryType) *mockPlugin[prototk.DomainMessage] {
+		return &mockPlugin[prototk.DomainMessage]{
+			t:              t,
+			connectFactory: domainConnectFactory,
+			headerAccessor: domainHeaderAccessor,
+			conf: &pldconf.PluginConfig{
+				Type:      string(libType),
+				Library:   "some/where.jar",
+				Class:     confutil.P("com.example.Plugin"),
+				Classpath: []string{"deps/a.jar", "deps/classes"},
+			},
+		}
+	}
+	tm := &testManagers{
+		testDomainManager: &testDomainManager{
+			domains: map[string]plugintk.Plugin{"domain1": newPlugin(pldtypes.LibraryTypeJar)},
+		},
+		testTransportManager: &testTransportManager{
+			transports: map[string]plugintk.Plugin{"transport1": &mockPlugin[prototk.TransportMessage]{t: t, conf: &pldconf.PluginConfig{
+				Type: string(pldtypes.LibraryTypeCShared), Library: "some/where.so",
+			}}},
+		},
+		testRegistryManager: &testRegistryManager{
+			registries: map[string]plugintk.Plugin{"registry1": &mockPlugin[prototk.RegistryMessage]{t: t, conf: &pldconf.PluginConfig{
+				Type: string(pldtypes.LibraryTypeCShared), Library: "some/where.so",
+			}}},
+		},
+	}
+	pc := NewPluginManager(ctx,
+		"tcp:127.0.0.1:0",
+		uuid.New(),
+		&pldconf.PluginManagerConfig{
+			GRPC: pldconf.GRPCConfig{
+				ShutdownTimeout: confutil.P("1ms"),
+			},
+			RestartRetry: pldconf.RetryConfig{
+				InitialDelay: confutil.P("1ms"),
+			},
+		})
+	err := pc.PostInit(tm.componentMocks(t))
+	require.NoError(t, err)
+
+	err = pc.Start()
+	require.NoError(t, err)
+	defer pc.Stop()
+
+	conn, err := grpc.NewClient(pc.GRPCTargetURL(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer conn.Close()
+
+	client := prototk.NewPluginControllerClient(conn)
+	loaderStream, err := client.InitLoader(ctx, &prototk.PluginLoaderInit{
+		Id: pc.LoaderID().String(),
+	})
+	require.NoError(t, err)
+
+	loads := map[prototk.PluginInfo_PluginType]*prototk.PluginLoad{}
+	for len(loads) < 3 {
+		loadReq, err := loaderStream.Recv()
+		require.NoError(t, err)
+		loads[loadReq.Plugin.PluginType] = loadReq
+	}
+	domainLoad := loads[prototk.PluginInfo_DOMAIN]
+	assert.Equal(t, prototk.PluginLoad_JAR, domainLoad.LibType)
+	assert.Equal(t, []string{"deps/a.jar", "deps/classes"}, domainLoad.Classpath)
+
+	// Each plugin that fails (to load, or later on) is sent to the loader again with the same ID
+	for _, pluginType := range []prototk.PluginInfo_PluginType{
+		prototk.PluginInfo_DOMAIN,
+		prototk.PluginInfo_TRANSPORT,
+		prototk.PluginInfo_REGISTRY,
+	} {
+		_, err = client.LoadFailed(ctx, &prototk.PluginLoadFailed{
+			Plugin:       loads[pluginType].Plugin,
+			ErrorMessage: "pop",
+		})
+		require.NoError(t, err)
+		reloadReq, err := loaderStream.Recv()
+		require.NoError(t, err)
+		assert.Equal(t, loads[pluginType].Plugin.Id, reloadReq.Plugin.Id)
+	}
+
+	// Unknown plugins are ignored
+	_, err = client.LoadFailed(ctx, &prototk.PluginLoadFailed{
+		Plugin: &prototk.PluginInfo{Id: uuid.NewString()},
+	})
+	require.NoError(t, err)
+}
+
+func TestUnresponsivePluginRestarted(t *testing.T) {
+	ctx := context.Background()
+	tdm := &testDomainManager{
+		domains: map[string]plugintk.Plugin{
+			"domain1": &mockPlugin[prototk.DomainMessage]{t: t, conf: &pldconf.PluginConfig{
+				Type: string(pldtypes.LibraryTypeJar), Library: "some/where.jar",
+			}},
+		},
+	}
+	tdm.domainRegistered = func(name string, toDomain components.DomainManagerToDomain) (plugintk.DomainCallbacks, error) {
+		return tdm, nil
+	}
+	pc := NewPluginManager(ctx,
+		"tcp:127.0.0.1:0",
+		uuid.New(),
+		&pldconf.PluginManagerConfig{
+			GRPC: pldconf.GRPCConfig{
+				ShutdownTimeout: confutil.P("1ms"),
+			},
+			RestartRetry: pldconf.RetryConfig{
+				InitialDelay: confutil.P("1ms"),
+			},
+			HealthCheck: pldconf.PluginHealthCheckConfig{
+				Interval: confutil.P("10ms"),
+				Timeout:  confutil.P("10ms"),
+			},
+		})
+	err := pc.PostInit((&testManagers{testDomainManager: tdm}).componentMocks(t))
+	require.NoError(t, err)
+
+	err = pc.Start()
+	require.NoError(t, err)
+	defer pc.Stop()
+
+	conn, err := grpc.NewClient(pc.GRPCTargetURL(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer conn.Close()
+
+	client := prototk.NewPluginControllerClient(conn)
+	loaderStream, err := client.InitLoader(ctx, &prototk.PluginLoaderInit{
+		Id: pc.LoaderID().String(),
+	})
+	require.NoError(t, err)
+	loadReq, err := loaderStream.Recv()
+	require.NoError(t, err)
+
+	domainStream, err := client.ConnectDomain(ctx)
+	require.NoError(t, err)
+	err = domainStream.Send(&prototk.DomainMessage{
+		Header: &prototk.Header{
+			MessageType: prototk.Header_REGISTER,
+			PluginId:    loadReq.Plugin.Id,
+			MessageId:   uuid.NewString(),
+		},
+	})
+	require.NoError(t, err)
+
+	// We get pinged, but never answer
+	ping, err := domainStream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, prototk.Header_REQUEST_TO_PLUGIN, ping.Header.MessageType)
+	assert.Nil(t, ping.RequestToDomain)
+
+	// So the stream is closed, and the plugin is sent to the loader again
+	for err == nil {
+		_, err = domainStream.Recv()
+	}
+	reloadReq, err := loaderStream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, loadReq.Plugin.Id, reloadReq.Plugin.Id)
+}
+
+func TestInitPluginClasspathNotJar(t *testing.T) {
+	tdm := &testDomainManager{domains: map[string]plugintk.Plugin{
+		"domain1": &mockPlugin[prototk.DomainMessage]{t: t, conf: &pldconf.PluginConfig{
+			Type:      string(pldtypes.LibraryTypeCShared),
+			Library:   "some/where.so",
+			Classpath: []string{"deps/a.jar"},
+		}},
+	}}
+	pc := NewPluginManager(context.Background(), tempUDS(t), uuid.New(), &pldconf.PluginManagerConfig{})
+	err := pc.PostInit((&testManagers{testDomainManager: tdm}).componentMocks(t))
+	assert.Regexp(t, "PD011208.*domain1", err)
+}
@@ -17,6 +17,7 @@ package privatetxnmgr
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	"github.com/google/uuid"
@@ -26,21 +27,125 @@ import (
 	"github.com/kaleido-io/paladin/core/internal/msgs"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
 	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
+	"gorm.io/gorm/clause"
 )
 
+// the last assembly we produced for a transaction we delegated, and the coordinator we gave it to
+type remoteAssembly struct {
+	coordinator  string
+	postAssembly *components.TransactionPostAssembly
+}
+
+type delegatedAssembly struct {
+	Transaction     uuid.UUID           `gorm:"column:transaction;primaryKey"`
+	ContractAddress pldtypes.EthAddress `gorm:"column:contract_address"`
+	Coordinator     string              `gorm:"column:coordinator"`
+	PostAssembly    pldtypes.RawJSON    `gorm:"column:post_assembly"`
+	Created         pldtypes.Timestamp  `gorm:"column:created"`
+}
+
+func (delegatedAssembly) TableName() string {
+	return "delegated_assemblies"
+}
+
+// The assemblies are persisted, so that the fencing of a failed over coordinator survives a restart of this node
+func (s *Sequencer) getRemoteAssembly(ctx context.Context, transactionID uuid.UUID) (*remoteAssembly, error) {
+	s.remoteAssembliesLock.Lock()
+	ra := s.remoteAssemblies[transactionID]
+	s.remoteAssembliesLock.Unlock()
+	if ra != nil {
+		return ra, nil
+	}
+
+	var records []*delegatedAssembly
+	err := s.components.Persistence().NOTX().DB().
+		WithContext(ctx).
+		Where(`"transaction" = ?`, transactionID).
+		Limit(1).
+		Find(&records).
+		Error
+	if err != nil || len(records) == 0 {
+		return nil, err
+	}
+	ra = &remoteAssembly{coordinator: records[0].Coordinator}
+	if err := json.Unmarshal(records[0].PostAssembly, &ra.postAssembly); err != nil {
+		return nil, err
+	}
+	s.remoteAssembliesLock.Lock()
+	s.remoteAssemblies[transactionID] = ra
+	s.remoteAssembliesLock.Unlock()
+	return ra, nil
+}
+
+func (s *Sequencer) storeRemoteAssembly(ctx context.Context, transactionID uuid.UUID, ra *remoteAssembly) error {
+	err := s.components.Persistence().NOTX().DB().
+		WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "transaction"}},
+			DoUpdates: clause.AssignmentColumns([]string{"coordinator", "post_assembly"}),
+		}).
+		Create(&delegatedAssembly{
+			Transaction:     transactionID,
+			ContractAddress: s.contractAddress,
+			Coordinator:     ra.coordinator,
+			PostAssembly:    pldtypes.JSONString(ra.postAssembly),
+			Created:         pldtypes.TimestampNow(),
+		}).
+		Error
+	if err != nil {
+		return err
+	}
+	s.remoteAssembliesLock.Lock()
+	s.remoteAssemblies[transactionID] = ra
+	s.remoteAssembliesLock.Unlock()
+	return nil
+}
+
+func (s *Sequencer) deleteRemoteAssembly(ctx context.Context, transactionID uuid.UUID) {
+	s.remoteAssembliesLock.Lock()
+	delete(s.remoteAssemblies, transactionID)
+	s.remoteAssembliesLock.Unlock()
+	err := s.components.Persistence().NOTX().DB().
+		WithContext(ctx).
+		Where(`"transaction" = ?`, transactionID).
+		Delete(&delegatedAssembly{}).
+		Error
+	if err != nil {
+		log.L(ctx).Errorf("Failed to delete delegated assembly for transaction %s: %s", transactionID, err)
+	}
+}
+
 // assemble a transaction that we are not coordinating, using the provided state locks
 // all errors are assumed to be transient and the request should be retried
 // if the domain as deemed the request as invalid then it will communicate the `revert` directive via the AssembleTransactionResponse_REVERT result without any error
-func (s *Sequencer) assembleForRemoteCoordinator(ctx context.Context, transactionID uuid.UUID, preAssembly *components.TransactionPreAssembly, stateLocksJSON []byte, blockHeight int64) (*components.TransactionPostAssembly, error) {
+func (s *Sequencer) assembleForRemoteCoordinator(ctx context.Context, transactionID uuid.UUID, coordinator string, preAssembly *components.TransactionPreAssembly, stateLocksJSON []byte, blockHeight int64) (*components.TransactionPostAssembly, error) {
 
-	log.L(ctx).Debugf("assembleForRemoteCoordinator: Assembling transaction %s ", transactionID)
+	log.L(ctx).Debugf("assembleForRemoteCoordinator: Assembling transaction %s for %s", transactionID, coordinator)
+
+	// If the transaction has moved to a new coordinator (for example because we failed over from the one we assembled for
+	// previously), the old coordinator might still dispatch what we gave it. So we give the new coordinator exactly the same
+	// assembly, and the base ledger rejects whichever arrives second. The assembly is then recorded against the new coordinator,
+	// so a re-assembly it requests later, because an endorser rejected the assembly, gets a fresh assembly.
+	previous, err := s.getRemoteAssembly(ctx, transactionID)
+	if err != nil {
+		log.L(ctx).Errorf("assembleForRemoteCoordinator: Error loading previous assembly: %s", err)
+		return nil, err
+	}
+	if previous != nil && previous.coordinator != coordinator {
+		log.L(ctx).Infof("assembleForRemoteCoordinator: Re-using assembly of transaction %s from coordinator %s", transactionID, previous.coordinator)
+		if err := s.storeRemoteAssembly(ctx, transactionID, &remoteAssembly{coordinator: coordinator, postAssembly: previous.postAssembly}); err != nil {
+			log.L(ctx).Errorf("assembleForRemoteCoordinator: Error recording assembly for new coordinator: %s", err)
+			return nil, err
+		}
+		return previous.postAssembly, nil
+	}
 
 	log.L(ctx).Debugf("assembleForRemoteCoordinator: resetting domain context with state locks from the coordinator which assumes a block height of %d compared with local blockHeight of %d", blockHeight, s.environment.GetBlockHeight())
 	//If our block height is behind the coordinator, there are some states that would otherwise be available to us but we wont see
 	// if our block height is ahead of the coordinator, there is a small chance that we we assemble a transaction that the coordinator will not be able to
 	// endorse yet but it is better to wait around on the endorsement flow than to wait around on the assemble flow which is single threaded per domain
 
-	err := s.delegateDomainContext.ImportSnapshot(stateLocksJSON)
+	err = s.delegateDomainContext.ImportSnapshot(stateLocksJSON)
 	if err != nil {
 		log.L(ctx).Errorf("assembleForRemoteCoordinator: Error importing state locks: %s", err)
 		return nil, err
@@ -53,6 +158,12 @@ func (s *Sequencer) assembleForRemoteCoordinator(ctx context.Context, transactio
 		return nil, err
 	}
 
+	if postAssembly.AssemblyResult == prototk.AssembleTransactionResponse_OK {
+		if err := s.storeRemoteAssembly(ctx, transactionID, &remoteAssembly{coordinator: coordinator, postAssembly: postAssembly}); err != nil {
+			log.L(ctx).Errorf("assembleForRemoteCoordinator: Error storing assembly: %s", err)
+			return nil, err
+		}
+	}
 	return postAssembly, nil
 }
 
@@ -20,6 +20,8 @@ import (
 	"fmt"
 	"hash/fnv"
 	"slices"
+	"sync"
+	"time"
 
 	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
 	"github.com/kaleido-io/paladin/common/go/pkg/log"
@@ -46,6 +48,9 @@ import (
 // 1+1 - core option set for Noto
 // 2+1 - core option set for Pente
 // 3+2 - core option set for Zeto
+//
+// Any contract can instead be configured on each node with a set of ordering nodes, in which case a single elected
+// node orders (coordinates) every transaction - see orderingServiceSelectorPolicy
 
 type CoordinatorSelectionMode int
 
@@ -57,7 +62,15 @@ const (
 // Override only intended for unit tests currently
 var EndorsementCoordinatorSelectionMode CoordinatorSelectionMode = HashedSelection
 
-func NewCoordinatorSelector(ctx context.Context, nodeName string, contractConfig *prototk.ContractConfig, sequencerConfig pldconf.PrivateTxManagerSequencerConfig) (ptmgrtypes.CoordinatorSelector, error) {
+func NewCoordinatorSelector(ctx context.Context, nodeName string, contractConfig *prototk.ContractConfig, sequencerConfig pldconf.PrivateTxManagerSequencerConfig, orderingNodes []string) (ptmgrtypes.CoordinatorSelector, error) {
+	if len(orderingNodes) > 0 {
+		sortedNodes := slices.Clone(orderingNodes)
+		slices.Sort(sortedNodes)
+		return &orderingServiceSelectorPolicy{
+			coordinatorFailover: newCoordinatorFailover(nodeName, confutil.DurationMin(sequencerConfig.CoordinatorFailoverTimeout, 1*time.Millisecond, *pldconf.PrivateTxManagerDefaults.Sequencer.CoordinatorFailoverTimeout)),
+			orderingNodes:       slices.Compact(sortedNodes),
+		}, nil
+	}
 	if contractConfig.GetCoordinatorSelection() == prototk.ContractConfig_COORDINATOR_SENDER {
 		return &staticCoordinatorSelectorPolicy{
 			nodeName: nodeName,
@@ -78,27 +91,108 @@ func NewCoordinatorSelector(ctx context.Context, nodeName string, contractConfig
 		}, nil
 	}
 	if contractConfig.GetCoordinatorSelection() == prototk.ContractConfig_COORDINATOR_ENDORSER {
+		failover := newCoordinatorFailover(nodeName, confutil.DurationMin(sequencerConfig.CoordinatorFailoverTimeout, 1*time.Millisecond, *pldconf.PrivateTxManagerDefaults.Sequencer.CoordinatorFailoverTimeout))
 		if EndorsementCoordinatorSelectionMode == BlockHeightRoundRobin {
 			return &roundRobinCoordinatorSelectorPolicy{
-				localNode: nodeName,
-				rangeSize: confutil.Int(sequencerConfig.RoundRobinCoordinatorBlockRangeSize, *pldconf.PrivateTxManagerDefaults.Sequencer.RoundRobinCoordinatorBlockRangeSize),
+				coordinatorFailover: failover,
+				localNode:           nodeName,
+				rangeSize:           confutil.Int(sequencerConfig.RoundRobinCoordinatorBlockRangeSize, *pldconf.PrivateTxManagerDefaults.Sequencer.RoundRobinCoordinatorBlockRangeSize),
 			}, nil
 		}
 		// TODO: More work is required to perform leader election of an endorser, so right now a simple hash algorithm is used.
 		return &endorsementSetHashSelection{
-			localNode: nodeName,
+			coordinatorFailover: failover,
+			localNode:           nodeName,
 		}, nil
 	}
 	return nil, i18n.NewError(ctx, msgs.MsgDomainInvalidCoordinatorSelection, contractConfig.GetCoordinatorSelection())
 }
 
+// When the coordinator of a transaction stops responding, the nodes that delegated to it mark it as unavailable
+// and fail over to the next node in the (sorted) candidate list. The delegation request carries the list of unavailable
+// nodes, so the node taking over makes the same choice rather than delegating back to the failed coordinator.
+// Nodes become candidates again once the failover timeout has passed since they were marked unavailable.
+type coordinatorFailover struct {
+	localNode       string
+	failoverTimeout time.Duration
+	clock           ptmgrtypes.Clock
+	lock            sync.Mutex
+	unavailable     map[string]time.Time // node name to the time it was marked unavailable
+}
+
+func newCoordinatorFailover(localNode string, failoverTimeout time.Duration) *coordinatorFailover {
+	return &coordinatorFailover{
+		localNode:       localNode,
+		failoverTimeout: failoverTimeout,
+		clock:           ptmgrtypes.RealClock(),
+		unavailable:     make(map[string]time.Time),
+	}
+}
+
+func (cf *coordinatorFailover) CoordinatorUnavailable(ctx context.Context, node string) bool {
+	if node == "" || node == cf.localNode {
+		// we never fail over from ourselves
+		return false
+	}
+	cf.lock.Lock()
+	defer cf.lock.Unlock()
+	if _, alreadyUnavailable := cf.unavailable[node]; !alreadyUnavailable {
+		log.L(ctx).Warnf("Coordinator node %s is unavailable. Failing over to the next candidate for %s", node, cf.failoverTimeout)
+	}
+	cf.unavailable[node] = cf.clock.Now()
+	return true
+}
+
+func (cf *coordinatorFailover) UnavailableCoordinators(ctx context.Context) []string {
+	cf.lock.Lock()
+	defer cf.lock.Unlock()
+	nodes := make([]string, 0, len(cf.unavailable))
+	for node := range cf.unavailable {
+		if cf.isUnavailable(ctx, node) {
+			nodes = append(nodes, node)
+		}
+	}
+	slices.Sort(nodes)
+	return nodes
+}
+
+// must be called with the lock held
+func (cf *coordinatorFailover) isUnavailable(ctx context.Context, node string) bool {
+	since, unavailable := cf.unavailable[node]
+	if unavailable && cf.clock.Now().After(since.Add(cf.failoverTimeout)) {
+		log.L(ctx).Infof("Coordinator node %s failover period has passed. Node is a candidate coordinator again", node)
+		delete(cf.unavailable, node)
+		return false
+	}
+	return unavailable
+}
+
+// Starting from the preferred index, returns the first candidate node that is available.
+// If every candidate is unavailable then we stick with the preferred node.
+func (cf *coordinatorFailover) selectAvailable(ctx context.Context, candidateNodes []string, preferredIndex int) string {
+	cf.lock.Lock()
+	defer cf.lock.Unlock()
+	for i := 0; i < len(candidateNodes); i++ {
+		node := candidateNodes[(preferredIndex+i)%len(candidateNodes)]
+		if !cf.isUnavailable(ctx, node) {
+			if i > 0 {
+				log.L(ctx).Debugf("SelectCoordinatorNode: failed over from %s to %s", candidateNodes[preferredIndex], node)
+			}
+			return node
+		}
+	}
+	return candidateNodes[preferredIndex]
+}
+
 type staticCoordinatorSelectorPolicy struct {
 	nodeName string
 }
 
 type endorsementSetHashSelection struct {
-	localNode  string
-	chosenNode string
+	*coordinatorFailover
+	localNode      string
+	candidateNodes []string
+	preferredIndex int
 }
 
 func (s *staticCoordinatorSelectorPolicy) SelectCoordinatorNode(ctx context.Context, _ *components.PrivateTransaction, environment ptmgrtypes.SequencerEnvironment) (int64, string, error) {
@@ -106,9 +200,19 @@ func (s *staticCoordinatorSelectorPolicy) SelectCoordinatorNode(ctx context.Cont
 	return environment.GetBlockHeight(), s.nodeName, nil
 }
 
+func (s *staticCoordinatorSelectorPolicy) CoordinatorUnavailable(ctx context.Context, node string) bool {
+	// There is no other node that can take over from a static coordinator (or from the sender)
+	log.L(ctx).Warnf("Coordinator node %s is not responding, but cannot fail over from a fixed coordinator", node)
+	return false
+}
+
+func (s *staticCoordinatorSelectorPolicy) UnavailableCoordinators(ctx context.Context) []string {
+	return nil
+}
+
 func (s *endorsementSetHashSelection) SelectCoordinatorNode(ctx context.Context, transaction *components.PrivateTransaction, environment ptmgrtypes.SequencerEnvironment) (int64, string, error) {
 	blockHeight := environment.GetBlockHeight()
-	if s.chosenNode == "" {
+	if len(s.candidateNodes) == 0 {
 		if transaction.PostAssembly == nil {
 			//if we don't know the candidate nodes, and the transaction hasn't been assembled yet, then we can't select a coordinator so just assume we are the coordinator
 			// until we get the transaction assembled and then re-evaluate
@@ -147,14 +251,16 @@ func (s *endorsementSetHashSelection) SelectCoordinatorNode(ctx context.Context,
 			h.Write([]byte(identity))
 		}
 		// Use that as an index into the chosen node set
-		s.chosenNode = candidateNodes[int(h.Sum32())%len(candidateNodes)]
+		s.candidateNodes = candidateNodes
+		s.preferredIndex = int(h.Sum32()) % len(candidateNodes)
 	}
 
-	return blockHeight, s.chosenNode, nil
+	return blockHeight, s.selectAvailable(ctx, s.candidateNodes, s.preferredIndex), nil
 
 }
 
 type roundRobinCoordinatorSelectorPolicy struct {
+	*coordinatorFailover
 	localNode      string
 	candidateNodes []string
 	rangeSize      int
@@ -170,27 +276,11 @@ func (s *roundRobinCoordinatorSelectorPolicy) SelectCoordinatorNode(ctx context.
 			log.L(ctx).Debug("SelectCoordinatorNode: No candidate nodes, assuming local node is the coordinator")
 			return blockHeight, s.localNode, nil
 		} else {
-			//use a map to dedupe as we go
-			candidateNodesMap := make(map[string]struct{})
-			for _, attestationPlan := range transaction.PostAssembly.AttestationPlan {
-				if attestationPlan.AttestationType == prototk.AttestationType_ENDORSE {
-					for _, party := range attestationPlan.Parties {
-						node, err := pldtypes.PrivateIdentityLocator(party).Node(ctx, true)
-						if err != nil {
-							log.L(ctx).Errorf("SelectCoordinatorNode: Error resolving node for party %s: %s", party, err)
-							return -1, "", i18n.NewError(ctx, msgs.MsgPrivateTxManagerInternalError, err)
-						}
-						if node == "" {
-							node = s.localNode
-						}
-						candidateNodesMap[node] = struct{}{}
-					}
-				}
-			}
-			for candidateNode := range candidateNodesMap {
-				s.candidateNodes = append(s.candidateNodes, candidateNode)
+			candidateNodes, err := endorserNodes(ctx, s.localNode, transaction.PostAssembly.AttestationPlan)
+			if err != nil {
+				return -1, "", err
 			}
-			slices.Sort(s.candidateNodes)
+			s.candidateNodes = candidateNodes
 		}
 	}
 
@@ -203,9 +293,77 @@ func (s *roundRobinCoordinatorSelectorPolicy) SelectCoordinatorNode(ctx context.
 	rangeIndex := blockHeight / int64(s.rangeSize)
 
 	coordinatorIndex := int(rangeIndex) % len(s.candidateNodes)
-	coordinatorNode := s.candidateNodes[coordinatorIndex]
+	coordinatorNode := s.selectAvailable(ctx, s.candidateNodes, coordinatorIndex)
 	log.L(ctx).Debugf("SelectCoordinatorNode: selected coordinator node %s using round robin algorithm for blockHeight: %d and rangeSize %d ", coordinatorNode, blockHeight, s.rangeSize)
 
 	return blockHeight, coordinatorNode, nil
 
 }
+
+// The sorted, de-duplicated set of nodes of the endorsing parties in an attestation plan. Parties without a node are
+// on the local node.
+func endorserNodes(ctx context.Context, localNode string, attestationPlan []*prototk.AttestationRequest) ([]string, error) {
+	//use a map to dedupe as we go
+	candidateNodesMap := make(map[string]struct{})
+	for _, attestationPlan := range attestationPlan {
+		if attestationPlan.AttestationType == prototk.AttestationType_ENDORSE {
+			for _, party := range attestationPlan.Parties {
+				node, err := pldtypes.PrivateIdentityLocator(party).Node(ctx, true)
+				if err != nil {
+					log.L(ctx).Errorf("SelectCoordinatorNode: Error resolving node for party %s: %s", party, err)
+					return nil, i18n.NewError(ctx, msgs.MsgPrivateTxManagerInternalError, err)
+				}
+				if node == "" {
+					node = localNode
+				}
+				candidateNodesMap[node] = struct{}{}
+			}
+		}
+	}
+	candidateNodes := make([]string, 0, len(candidateNodesMap))
+	for candidateNode := range candidateNodesMap {
+		candidateNodes = append(candidateNodes, candidateNode)
+	}
+	slices.Sort(candidateNodes)
+	return candidateNodes, nil
+}
+
+// For contracts with high contention, such as a cash token that is used by every trade, having coordinators on
+// different nodes assemble conflicting transactions in parallel leads to a lot of rejected endorsements and
+// re-assembly. Instead, one elected node orders every transaction for the contract. It assembles them one at a time
+// against a single view of the state locks, so conflicting transactions are sequenced rather than retried.
+//
+// This works with any coordinator selection mode of the domain, as long as the ordering node can gather the
+// endorsements and submit. So once a transaction is assembled, the ordering node must be one of its endorsers (for a
+// privacy group, one of the members, and for a notary, the notary's node). Otherwise the transaction is reverted, as
+// re-assembly cannot fix the configuration. Transactions with no endorsers, such as those with sender selection, can be
+// ordered by any node.
+//
+// The elected node is the first node, in sorted order, of the configured ordering nodes that this node has not marked
+// unavailable. With no failures, every node elects the same ordering node without any extra messages. Each node only
+// has its own view of which ordering nodes are unavailable though, so while a failover is in progress nodes can elect
+// different ordering nodes. The node taking over adopts the unavailable nodes listed in each delegation request, and the
+// other nodes fail over in the same way once the failed ordering node stops responding to them. Until they converge,
+// conflicting transactions from nodes that disagree are resolved by re-assembly, as for endorser selection.
+//
+// Unlike endorser selection, the ordering node is known before assembly, so transactions are delegated straight away
+// and the ordering node sends assemble requests back to the sender's node.
+type orderingServiceSelectorPolicy struct {
+	*coordinatorFailover
+	orderingNodes []string
+}
+
+func (s *orderingServiceSelectorPolicy) SelectCoordinatorNode(ctx context.Context, transaction *components.PrivateTransaction, environment ptmgrtypes.SequencerEnvironment) (int64, string, error) {
+	orderingNode := s.selectAvailable(ctx, s.orderingNodes, 0)
+	if transaction.PostAssembly != nil {
+		endorsers, err := endorserNodes(ctx, s.localNode, transaction.PostAssembly.AttestationPlan)
+		if err != nil {
+			return -1, "", err
+		}
+		if len(endorsers) > 0 && !slices.Contains(endorsers, orderingNode) {
+			return -1, "", i18n.NewError(ctx, msgs.MsgPrivateTxManagerOrderingNodeNotEndorser, orderingNode, transaction.ID, endorsers)
+		}
+	}
+	log.L(ctx).Debugf("SelectCoordinatorNode: selected ordering node %s", orderingNode)
+	return environment.GetBlockHeight(), orderingNode, nil
+}
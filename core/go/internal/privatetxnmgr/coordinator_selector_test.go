@@ -0,0 +1,225 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package privatetxnmgr
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/internal/components"
+	"github.com/kaleido-io/paladin/core/internal/privatetxnmgr/ptmgrtypes"
+	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func endorsedByNodesTx(nodes ...string) *components.PrivateTransaction {
+	parties := make([]string, len(nodes))
+	for i, node := range nodes {
+		parties[i] = "endorser@" + node
+	}
+	return &components.PrivateTransaction{
+		PostAssembly: &components.TransactionPostAssembly{
+			AttestationPlan: []*prototk.AttestationRequest{
+				{AttestationType: prototk.AttestationType_ENDORSE, Parties: parties},
+			},
+		},
+	}
+}
+
+func newTestFailoverSelector(t *testing.T, mode CoordinatorSelectionMode, localNode string) (ptmgrtypes.CoordinatorSelector, *coordinatorFailover, func(), *fakeClock) {
+	originalMode := EndorsementCoordinatorSelectionMode
+	EndorsementCoordinatorSelectionMode = mode
+	selector, err := NewCoordinatorSelector(context.Background(), localNode, &prototk.ContractConfig{
+		CoordinatorSelection: prototk.ContractConfig_COORDINATOR_ENDORSER,
+	}, pldconf.PrivateTxManagerSequencerConfig{
+		CoordinatorFailoverTimeout:          confutil.P("1m"),
+		RoundRobinCoordinatorBlockRangeSize: confutil.P(10),
+	}, nil)
+	require.NoError(t, err)
+	clock := &fakeClock{}
+	var cf *coordinatorFailover
+	switch s := selector.(type) {
+	case *endorsementSetHashSelection:
+		cf = s.coordinatorFailover
+	case *roundRobinCoordinatorSelectorPolicy:
+		cf = s.coordinatorFailover
+	}
+	require.NotNil(t, cf)
+	cf.clock = clock
+	return selector, cf, func() { EndorsementCoordinatorSelectionMode = originalMode }, clock
+}
+
+func TestEndorsementSetHashSelectionFailover(t *testing.T) {
+	ctx := context.Background()
+	selector, _, done, clock := newTestFailoverSelector(t, HashedSelection, "node4")
+	defer done()
+
+	tx := endorsedByNodesTx("node1", "node2", "node3")
+	env := &sequencerEnvironment{blockHeight: 10}
+	_, selected, err := selector.SelectCoordinatorNode(ctx, tx, env)
+	require.NoError(t, err)
+	assert.Equal(t, "node1", selected)
+
+	// Failing over moves deterministically to the next node in the sorted candidate list
+	assert.True(t, selector.CoordinatorUnavailable(ctx, "node1"))
+	assert.Equal(t, []string{"node1"}, selector.UnavailableCoordinators(ctx))
+	_, selected, err = selector.SelectCoordinatorNode(ctx, tx, env)
+	require.NoError(t, err)
+	assert.Equal(t, "node2", selected)
+
+	// We never fail over from ourselves
+	assert.False(t, selector.CoordinatorUnavailable(ctx, "node4"))
+
+	// Once the failover timeout has passed, the node is a candidate again
+	clock.timePassed = 1*time.Minute + 1*time.Second
+	_, selected, err = selector.SelectCoordinatorNode(ctx, tx, env)
+	require.NoError(t, err)
+	assert.Equal(t, "node1", selected)
+	assert.Empty(t, selector.UnavailableCoordinators(ctx))
+}
+
+func TestRoundRobinSelectionFailover(t *testing.T) {
+	ctx := context.Background()
+	selector, _, done, _ := newTestFailoverSelector(t, BlockHeightRoundRobin, "node1")
+	defer done()
+
+	tx := endorsedByNodesTx("node1", "node2", "node3")
+	_, selected, err := selector.SelectCoordinatorNode(ctx, tx, &sequencerEnvironment{blockHeight: 25})
+	require.NoError(t, err)
+	assert.Equal(t, "node3", selected)
+
+	assert.True(t, selector.CoordinatorUnavailable(ctx, "node3"))
+	assert.True(t, selector.CoordinatorUnavailable(ctx, "node2"))
+	assert.Equal(t, []string{"node2", "node3"}, selector.UnavailableCoordinators(ctx))
+	// wraps round to the start of the list
+	_, selected, err = selector.SelectCoordinatorNode(ctx, tx, &sequencerEnvironment{blockHeight: 25})
+	require.NoError(t, err)
+	assert.Equal(t, "node1", selected)
+	_, selected, err = selector.SelectCoordinatorNode(ctx, tx, &sequencerEnvironment{blockHeight: 15})
+	require.NoError(t, err)
+	assert.Equal(t, "node1", selected)
+}
+
+func TestFailoverAllCandidatesUnavailable(t *testing.T) {
+	ctx := context.Background()
+	_, cf, done, _ := newTestFailoverSelector(t, HashedSelection, "node1")
+	defer done()
+	cf.CoordinatorUnavailable(ctx, "node2")
+	cf.CoordinatorUnavailable(ctx, "node3")
+	assert.Equal(t, "node3", cf.selectAvailable(ctx, []string{"node2", "node3"}, 1))
+}
+
+func TestStaticCoordinatorNoFailover(t *testing.T) {
+	ctx := context.Background()
+	selector, err := NewCoordinatorSelector(ctx, "node1", &prototk.ContractConfig{
+		CoordinatorSelection: prototk.ContractConfig_COORDINATOR_STATIC,
+		StaticCoordinator:    confutil.P("notary@node2"),
+	}, pldconf.PrivateTxManagerSequencerConfig{}, nil)
+	require.NoError(t, err)
+	assert.False(t, selector.CoordinatorUnavailable(ctx, "node2"))
+	assert.Empty(t, selector.UnavailableCoordinators(ctx))
+	_, selected, err := selector.SelectCoordinatorNode(ctx, nil, &sequencerEnvironment{})
+	require.NoError(t, err)
+	assert.Equal(t, "node2", selected)
+}
+
+func TestOrderingServiceSelection(t *testing.T) {
+	ctx := context.Background()
+	selector, err := NewCoordinatorSelector(ctx, "node4", &prototk.ContractConfig{
+		CoordinatorSelection: prototk.ContractConfig_COORDINATOR_ENDORSER,
+	}, pldconf.PrivateTxManagerSequencerConfig{
+		CoordinatorFailoverTimeout: confutil.P("1m"),
+	}, []string{"node3", "node2", "node3"})
+	require.NoError(t, err)
+	s := selector.(*orderingServiceSelectorPolicy)
+	assert.Equal(t, []string{"node2", "node3"}, s.orderingNodes)
+	clock := &fakeClock{}
+	s.clock = clock
+
+	// The ordering node is chosen before assembly, and does not depend on the transaction
+	env := &sequencerEnvironment{blockHeight: 15}
+	blockHeight, selected, err := selector.SelectCoordinatorNode(ctx, &components.PrivateTransaction{}, env)
+	require.NoError(t, err)
+	assert.Equal(t, int64(15), blockHeight)
+	assert.Equal(t, "node2", selected)
+
+	// Fail over to the next ordering node, and back again after the failover timeout
+	assert.True(t, selector.CoordinatorUnavailable(ctx, "node2"))
+	_, selected, err = selector.SelectCoordinatorNode(ctx, &components.PrivateTransaction{}, env)
+	require.NoError(t, err)
+	assert.Equal(t, "node3", selected)
+	clock.timePassed = 2 * time.Minute
+	_, selected, err = selector.SelectCoordinatorNode(ctx, &components.PrivateTransaction{}, env)
+	require.NoError(t, err)
+	assert.Equal(t, "node2", selected)
+}
+
+func TestOrderingServiceAnySelectionMode(t *testing.T) {
+	ctx := context.Background()
+	for _, contractConfig := range []*prototk.ContractConfig{
+		{CoordinatorSelection: prototk.ContractConfig_COORDINATOR_SENDER},
+		{CoordinatorSelection: prototk.ContractConfig_COORDINATOR_STATIC, StaticCoordinator: confutil.P("notary@node2")},
+	} {
+		selector, err := NewCoordinatorSelector(ctx, "node1", contractConfig, pldconf.PrivateTxManagerSequencerConfig{}, []string{"node2"})
+		require.NoError(t, err)
+		_, selected, err := selector.SelectCoordinatorNode(ctx, &components.PrivateTransaction{}, &sequencerEnvironment{})
+		require.NoError(t, err)
+		assert.Equal(t, "node2", selected)
+	}
+}
+
+func TestOrderingServiceEndorsementSet(t *testing.T) {
+	ctx := context.Background()
+	selector, err := NewCoordinatorSelector(ctx, "node1", &prototk.ContractConfig{
+		CoordinatorSelection: prototk.ContractConfig_COORDINATOR_STATIC,
+		StaticCoordinator:    confutil.P("notary@node2"),
+	}, pldconf.PrivateTxManagerSequencerConfig{}, []string{"node2", "node3"})
+	require.NoError(t, err)
+	assembled := func(parties ...string) *components.PrivateTransaction {
+		return &components.PrivateTransaction{
+			ID: uuid.New(),
+			PostAssembly: &components.TransactionPostAssembly{
+				AttestationPlan: []*prototk.AttestationRequest{
+					{AttestationType: prototk.AttestationType_SIGN, Parties: []string{"alice@node1"}},
+					{AttestationType: prototk.AttestationType_ENDORSE, Parties: parties},
+				},
+			},
+		}
+	}
+
+	// The ordering node is an endorser
+	_, selected, err := selector.SelectCoordinatorNode(ctx, assembled("notary@node2"), &sequencerEnvironment{})
+	require.NoError(t, err)
+	assert.Equal(t, "node2", selected)
+
+	// Transactions with no endorsers can be ordered by any node
+	_, selected, err = selector.SelectCoordinatorNode(ctx, assembled(), &sequencerEnvironment{})
+	require.NoError(t, err)
+	assert.Equal(t, "node2", selected)
+
+	// After failing over to an ordering node that is not an endorser, the transaction cannot be coordinated
+	assert.True(t, selector.CoordinatorUnavailable(ctx, "node2"))
+	_, _, err = selector.SelectCoordinatorNode(ctx, assembled("notary@node2"), &sequencerEnvironment{})
+	assert.Regexp(t, `PD011842.*node3.*\[node2\]`, err)
+
+	_, _, err = selector.SelectCoordinatorNode(ctx, assembled("not@@valid"), &sequencerEnvironment{})
+	assert.Regexp(t, "PD011801", err)
+}
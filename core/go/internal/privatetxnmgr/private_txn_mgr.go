@@ -18,6 +18,8 @@ package privatetxnmgr
 import (
 	"context"
 	"encoding/json"
+	"slices"
+	"sort"
 	"sync"
 
 	"github.com/google/uuid"
@@ -41,6 +43,7 @@ import (
 	"google.golang.org/protobuf/types/known/anypb"
 
 	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/toolkit/pkg/keyedqueue"
 	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
 )
 
@@ -48,8 +51,7 @@ type privateTxManager struct {
 	ctx                  context.Context
 	ctxCancel            func()
 	config               *pldconf.PrivateTxManagerConfig
-	sequencers           map[string]*Sequencer
-	sequencersLock       sync.RWMutex
+	sequencers           *keyedqueue.Pool[string, *Sequencer]
 	endorsementGatherers map[string]ptmgrtypes.EndorsementGatherer
 	components           components.AllComponents
 	nodeName             string
@@ -61,6 +63,11 @@ type privateTxManager struct {
 
 // Init implements Engine.
 func (p *privateTxManager) PreInit(c components.PreInitComponents) (*components.ManagerInitResult, error) {
+	for _, orderedContract := range p.config.OrderedContracts {
+		if _, err := pldtypes.ParseEthAddress(orderedContract.ContractAddress); err != nil || len(orderedContract.OrderingNodes) == 0 || slices.Contains(orderedContract.OrderingNodes, "") {
+			return nil, i18n.NewError(p.ctx, msgs.MsgPrivateTxManagerInvalidOrderedContract, orderedContract.ContractAddress)
+		}
+	}
 	return &components.ManagerInitResult{
 		PreCommitHandler: func(ctx context.Context, dbTX persistence.DBTX, blocks []*pldapi.IndexedBlock, transactions []*blockindexer.IndexedTransactionNotify) error {
 			log.L(ctx).Debug("PrivateTxManager PreCommitHandler")
@@ -87,25 +94,24 @@ func (p *privateTxManager) Start() error {
 }
 
 func (p *privateTxManager) Stop() {
+	p.sequencers.Drain(p.ctx)
 }
 
 func NewPrivateTransactionMgr(ctx context.Context, config *pldconf.PrivateTxManagerConfig) components.PrivateTxManager {
 	p := &privateTxManager{
 		config:               config,
-		sequencers:           make(map[string]*Sequencer),
 		endorsementGatherers: make(map[string]ptmgrtypes.EndorsementGatherer),
 		subscribers:          make([]components.PrivateTxEventSubscriber, 0),
 	}
 	p.ctx, p.ctxCancel = context.WithCancel(ctx)
+	p.sequencers = keyedqueue.NewPool[string, *Sequencer](p.ctx, &config.SequencerPool)
 	return p
 }
 
 func (p *privateTxManager) OnNewBlockHeight(ctx context.Context, blockHeight int64) {
 	p.blockHeight = blockHeight
 
-	p.sequencersLock.RLock()
-	defer p.sequencersLock.RUnlock()
-	for _, sequencer := range p.sequencers {
+	for _, sequencer := range p.sequencers.Workers() {
 		sequencer.OnNewBlockHeight(ctx, blockHeight)
 	}
 }
@@ -120,69 +126,51 @@ func (p *privateTxManager) getSequencerForContract(ctx context.Context, dbTX per
 		}
 	}
 
-	readlock := true
-	p.sequencersLock.RLock()
-	defer func() {
-		if readlock {
-			p.sequencersLock.RUnlock()
+	// Each contract has a single sequencer, and the pool bounds how many run concurrently.
+	// The sequencer is removed from the pool when it stops.
+	return p.sequencers.GetOrStart(ctx, contractAddr.String(), func() (*Sequencer, error) {
+		transportWriter := NewTransportWriter(domainAPI.Domain().Name(), &contractAddr, p.nodeName, p.components.TransportManager(), &p.config.Sequencer)
+		publisher := NewPublisher(p, contractAddr.String())
+
+		endorsementGatherer, err := p.getEndorsementGathererForContract(ctx, dbTX, contractAddr)
+		if err != nil {
+			log.L(ctx).Errorf("Failed to get endorsement gatherer for contract %s: %s", contractAddr.String(), err)
+			return nil, err
+		}
+
+		newSequencer, err := NewSequencer(
+			p.ctx,
+			p,
+			p.nodeName,
+			contractAddr,
+			&p.config.Sequencer,
+			p.orderingNodesForContract(contractAddr),
+			p.components,
+			domainAPI,
+			endorsementGatherer,
+			publisher,
+			p.syncPoints,
+			p.components.IdentityResolver(),
+			transportWriter,
+			confutil.DurationMin(p.config.RequestTimeout, 0, *pldconf.PrivateTxManagerDefaults.RequestTimeout),
+			p.blockHeight,
+		)
+		if err != nil {
+			log.L(ctx).Errorf("Failed to create sequencer for contract %s: %s", contractAddr.String(), err)
+			return nil, err
 		}
-	}()
-	if p.sequencers[contractAddr.String()] == nil {
-		//swap the read lock for a write lock
-		p.sequencersLock.RUnlock()
-		readlock = false
-		p.sequencersLock.Lock()
-		defer p.sequencersLock.Unlock()
-		//double check in case another goroutine has created the sequencer while we were waiting for the write lock
-		if p.sequencers[contractAddr.String()] == nil {
-			transportWriter := NewTransportWriter(domainAPI.Domain().Name(), &contractAddr, p.nodeName, p.components.TransportManager())
-			publisher := NewPublisher(p, contractAddr.String())
-
-			endorsementGatherer, err := p.getEndorsementGathererForContract(ctx, dbTX, contractAddr)
-			if err != nil {
-				log.L(ctx).Errorf("Failed to get endorsement gatherer for contract %s: %s", contractAddr.String(), err)
-				return nil, err
-			}
-
-			newSequencer, err := NewSequencer(
-				p.ctx,
-				p,
-				p.nodeName,
-				contractAddr,
-				&p.config.Sequencer,
-				p.components,
-				domainAPI,
-				endorsementGatherer,
-				publisher,
-				p.syncPoints,
-				p.components.IdentityResolver(),
-				transportWriter,
-				confutil.DurationMin(p.config.RequestTimeout, 0, *pldconf.PrivateTxManagerDefaults.RequestTimeout),
-				p.blockHeight,
-			)
-			if err != nil {
-				log.L(ctx).Errorf("Failed to create sequencer for contract %s: %s", contractAddr.String(), err)
-				return nil, err
-			}
-			p.sequencers[contractAddr.String()] = newSequencer
-
-			sequencerDone, err := p.sequencers[contractAddr.String()].Start(ctx)
-			if err != nil {
-				log.L(ctx).Errorf("Failed to start sequencer for contract %s: %s", contractAddr.String(), err)
-				return nil, err
-			}
-
-			go func() {
-
-				<-sequencerDone
-				log.L(ctx).Infof("Sequencer for contract %s has stopped", contractAddr.String())
-				p.sequencersLock.Lock()
-				defer p.sequencersLock.Unlock()
-				delete(p.sequencers, contractAddr.String())
-			}()
+		return newSequencer, nil
+	})
+}
+
+// Returns the configured ordering nodes for the contract, or nil if the contract is coordinated according to the domain's policy
+func (p *privateTxManager) orderingNodesForContract(contractAddr pldtypes.EthAddress) []string {
+	for _, orderedContract := range p.config.OrderedContracts {
+		if address, err := pldtypes.ParseEthAddress(orderedContract.ContractAddress); err == nil && *address == contractAddr {
+			return orderedContract.OrderingNodes
 		}
 	}
-	return p.sequencers[contractAddr.String()], nil
+	return nil
 }
 
 func (p *privateTxManager) getEndorsementGathererForContract(ctx context.Context, dbTX persistence.DBTX, contractAddr pldtypes.EthAddress) (ptmgrtypes.EndorsementGatherer, error) {
@@ -195,7 +183,7 @@ func (p *privateTxManager) getEndorsementGathererForContract(ctx context.Context
 	}
 	if p.endorsementGatherers[contractAddr.String()] == nil {
 		// TODO: Consider scope of state in privateTxManager threading model
-		dCtx := p.components.StateManager().NewDomainContext(p.ctx /* background context */, domainSmartContract.Domain(), contractAddr)
+		dCtx := p.components.StateManager().NewDomainContext(p.ctx /* background context */, domainSmartContract.Domain(), contractAddr, components.DomainContextIsolationSpeculative)
 		endorsementGatherer := NewEndorsementGatherer(p.components.Persistence(), domainSmartContract, dCtx, p.components.KeyManager())
 		p.endorsementGatherers[contractAddr.String()] = endorsementGatherer
 	}
@@ -239,6 +227,7 @@ func (p *privateTxManager) HandleNewTx(ctx context.Context, dbTX persistence.DBT
 // We are currently proving out this pattern on the boundary of the private transaction manager and the public transaction manager and once that has settled, we will implement the same pattern here.
 // In the meantime, we a single function to submit a transaction and there is currently no persistence of the submission record.  It is all held in memory only
 func (p *privateTxManager) handleNewTx(ctx context.Context, dbTX persistence.DBTX, tx *components.PrivateTransaction, localTx *components.ResolvedTransaction) error {
+	ctx = log.WithLogField(ctx, log.FieldTxID, tx.ID.String())
 	log.L(ctx).Debugf("Handling new transaction: %v", tx)
 
 	contractAddr := *localTx.Transaction.To
@@ -296,7 +285,8 @@ func (p *privateTxManager) validateDelegatedTransaction(ctx context.Context, tx
 
 }
 
-func (p *privateTxManager) handleDelegatedTransaction(ctx context.Context, dbTX persistence.DBTX, delegationBlockHeight int64, delegatingNodeName string, delegationId string, tx *components.PrivateTransaction) error {
+func (p *privateTxManager) handleDelegatedTransaction(ctx context.Context, dbTX persistence.DBTX, delegationBlockHeight int64, delegatingNodeName string, delegationId string, unavailableCoordinators []string, tx *components.PrivateTransaction) error {
+	ctx = log.WithLogField(ctx, log.FieldTxID, tx.ID.String())
 	log.L(ctx).Debugf("Handling delegated transaction: %v", tx)
 
 	domainAPI, err := p.components.DomainManager().GetSmartContractByAddress(ctx, dbTX, tx.Address)
@@ -308,6 +298,11 @@ func (p *privateTxManager) handleDelegatedTransaction(ctx context.Context, dbTX
 	if err != nil {
 		return err
 	}
+	// If the delegating node has failed over from other coordinators, we need to skip the same nodes so that we agree
+	// that we are the coordinator, rather than delegating straight back to a node that is not responding
+	for _, node := range unavailableCoordinators {
+		sequencer.coordinatorSelector.CoordinatorUnavailable(ctx, node)
+	}
 	queued := sequencer.ProcessInFlightTransaction(ctx, tx, &delegationBlockHeight)
 	if queued {
 		log.L(ctx).Debugf("Delegated Transaction with ID %s queued in database", tx.ID)
@@ -352,23 +347,9 @@ func (p *privateTxManager) deploymentLoop(ctx context.Context, domain components
 
 	var err error
 
-	// Resolve keys synchronously on this go routine so that we can return an error if any key resolution fails
-	tx.Verifiers = make([]*prototk.ResolvedVerifier, len(tx.RequiredVerifiers))
-	for i, v := range tx.RequiredVerifiers {
-		// TODO: This is a synchronous cross-node exchange, done sequentially for each verifier.
-		// Potentially needs to move to an event-driven model like on invocation.
-		verifier, resolveErr := p.components.IdentityResolver().ResolveVerifier(ctx, v.Lookup, v.Algorithm, v.VerifierType)
-		if resolveErr != nil {
-			err = i18n.WrapError(ctx, resolveErr, msgs.MsgKeyResolutionFailed, v.Lookup, v.Algorithm, v.VerifierType)
-			break
-		}
-		tx.Verifiers[i] = &prototk.ResolvedVerifier{
-			Lookup:       v.Lookup,
-			Algorithm:    v.Algorithm,
-			Verifier:     verifier,
-			VerifierType: v.VerifierType,
-		}
-	}
+	// Resolve keys synchronously on this go routine so that we can return an error if any key resolution fails.
+	// The lookups are done in parallel, as a deployment can reference many parties on different nodes.
+	tx.Verifiers, err = p.components.IdentityResolver().ResolveVerifiers(ctx, tx.RequiredVerifiers)
 
 	if err == nil {
 		err = p.evaluateDeployment(ctx, domain, tx)
@@ -495,10 +476,8 @@ func (p *privateTxManager) evaluateDeployment(ctx context.Context, domain compon
 func (p *privateTxManager) GetTxStatus(ctx context.Context, domainAddress string, txID uuid.UUID) (status components.PrivateTxStatus, err error) {
 	// this returns status that we happen to have in memory at the moment and might be useful for debugging
 
-	p.sequencersLock.RLock()
-	defer p.sequencersLock.RUnlock()
-	targetSequencer := p.sequencers[domainAddress]
-	if targetSequencer == nil {
+	targetSequencer, ok := p.sequencers.Get(domainAddress)
+	if !ok {
 		return components.PrivateTxStatus{
 			TxID:   txID.String(),
 			Status: "unknown",
@@ -510,11 +489,19 @@ func (p *privateTxManager) GetTxStatus(ctx context.Context, domainAddress string
 
 }
 
+func (p *privateTxManager) GetSequencerSnapshots(ctx context.Context) []*components.SequencerSnapshot {
+	sequencers := p.sequencers.Workers()
+	snapshots := make([]*components.SequencerSnapshot, 0, len(sequencers))
+	for _, s := range sequencers {
+		snapshots = append(snapshots, s.GetSnapshot(ctx))
+	}
+	sort.Slice(snapshots, func(i, j int) bool { return snapshots[i].ContractAddress < snapshots[j].ContractAddress })
+	return snapshots
+}
+
 func (p *privateTxManager) HandleNewEvent(ctx context.Context, event ptmgrtypes.PrivateTransactionEvent) {
-	p.sequencersLock.RLock()
-	defer p.sequencersLock.RUnlock()
-	targetSequencer := p.sequencers[event.GetContractAddress()]
-	if targetSequencer == nil { // this is an event that belongs to a contract that's not in flight, throw it away and rely on the engine to trigger the action again when the sequencer is wake up. (an enhanced version is to add weight on queueing an sequencer)
+	targetSequencer, ok := p.sequencers.Get(event.GetContractAddress())
+	if !ok { // this is an event that belongs to a contract that's not in flight, throw it away and rely on the engine to trigger the action again when the sequencer is wake up. (an enhanced version is to add weight on queueing an sequencer)
 		log.L(ctx).Warnf("Ignored %T event for domain contract %s and transaction %s . If this happens a lot, check the sequencer idle timeout is set to a reasonable number", event, event.GetContractAddress(), event.GetTransactionID())
 	} else {
 		targetSequencer.HandleEvent(ctx, event)
@@ -528,17 +515,81 @@ func (p *privateTxManager) handleEndorsementRequest(ctx context.Context, message
 		log.L(ctx).Errorf("Failed to unmarshal endorsement request: %s", err)
 		return
 	}
+
+	endorsementResponse := p.endorse(ctx, endorsementRequest)
+	if endorsementResponse == nil {
+		return
+	}
+	endorsementResponseBytes, err := proto.Marshal(endorsementResponse)
+	if err != nil {
+		log.L(ctx).Errorf("Failed to marshal endorsement response: %s", err)
+		return
+	}
+
+	err = p.components.TransportManager().Send(ctx, &components.FireAndForgetMessageSend{
+		MessageType: "EndorsementResponse",
+		Payload:     endorsementResponseBytes,
+		Node:        replyTo,
+		Component:   prototk.PaladinMsg_TRANSACTION_ENGINE,
+	})
+	if err != nil {
+		log.L(ctx).Errorf("Failed to send endorsement response: %s", err)
+		return
+	}
+}
+
+// The requests in a batch are endorsed in order, and all the responses are returned together in a single message.
+// Any requests that fail are left out of the response, and the coordinator will re-send them after its request timeout.
+func (p *privateTxManager) handleEndorsementRequestBatch(ctx context.Context, messagePayload []byte, replyTo string) {
+	endorsementRequestBatch := &pbEngine.EndorsementRequestBatch{}
+	err := proto.Unmarshal(messagePayload, endorsementRequestBatch)
+	if err != nil {
+		log.L(ctx).Errorf("Failed to unmarshal endorsement request batch: %s", err)
+		return
+	}
+
+	endorsementResponseBatch := &pbEngine.EndorsementResponseBatch{
+		EndorsementResponses: make([]*pbEngine.EndorsementResponse, 0, len(endorsementRequestBatch.EndorsementRequests)),
+	}
+	for _, endorsementRequest := range endorsementRequestBatch.EndorsementRequests {
+		if endorsementResponse := p.endorse(ctx, endorsementRequest); endorsementResponse != nil {
+			endorsementResponseBatch.EndorsementResponses = append(endorsementResponseBatch.EndorsementResponses, endorsementResponse)
+		}
+	}
+	if len(endorsementResponseBatch.EndorsementResponses) == 0 {
+		return
+	}
+	endorsementResponseBatchBytes, err := proto.Marshal(endorsementResponseBatch)
+	if err != nil {
+		log.L(ctx).Errorf("Failed to marshal endorsement response batch: %s", err)
+		return
+	}
+
+	err = p.components.TransportManager().Send(ctx, &components.FireAndForgetMessageSend{
+		MessageType: "EndorsementResponseBatch",
+		Payload:     endorsementResponseBatchBytes,
+		Node:        replyTo,
+		Component:   prototk.PaladinMsg_TRANSACTION_ENGINE,
+	})
+	if err != nil {
+		log.L(ctx).Errorf("Failed to send endorsement response batch: %s", err)
+		return
+	}
+}
+
+// Returns nil if the request cannot be endorsed, having logged the reason
+func (p *privateTxManager) endorse(ctx context.Context, endorsementRequest *pbEngine.EndorsementRequest) *pbEngine.EndorsementResponse {
 	contractAddressString := endorsementRequest.ContractAddress
 	contractAddress, err := pldtypes.ParseEthAddress(contractAddressString)
 	if err != nil {
 		log.L(ctx).Errorf("Failed to parse contract address %s: %s", contractAddressString, err)
-		return
+		return nil
 	}
 
 	endorsementGatherer, err := p.getEndorsementGathererForContract(ctx, p.components.Persistence().NOTX(), *contractAddress)
 	if err != nil {
 		log.L(ctx).Errorf("Failed to get endorsement gatherer for contract address %s: %s", contractAddressString, err)
-		return
+		return nil
 	}
 
 	//TODO the following is temporary code to unmarshal the fields of the endorsement request
@@ -550,7 +601,7 @@ func (p *privateTxManager) handleEndorsementRequest(ctx context.Context, message
 	err = transactionSpecificationAny.UnmarshalTo(transactionSpecification)
 	if err != nil {
 		log.L(ctx).Errorf("Failed to unmarshal transaction specification: %s", err)
-		return
+		return nil
 	}
 
 	attestationRequestAny := endorsementRequest.GetAttestationRequest()
@@ -558,7 +609,7 @@ func (p *privateTxManager) handleEndorsementRequest(ctx context.Context, message
 	err = attestationRequestAny.UnmarshalTo(attestationRequest)
 	if err != nil {
 		log.L(ctx).Errorf("Failed to unmarshal attestation request: %s", err)
-		return
+		return nil
 	}
 
 	verifiersAny := endorsementRequest.GetVerifiers()
@@ -568,7 +619,7 @@ func (p *privateTxManager) handleEndorsementRequest(ctx context.Context, message
 		err = v.UnmarshalTo(verifiers[i])
 		if err != nil {
 			log.L(ctx).Errorf("Failed to unmarshal attestation request: %s", err)
-			return
+			return nil
 		}
 	}
 
@@ -578,7 +629,7 @@ func (p *privateTxManager) handleEndorsementRequest(ctx context.Context, message
 		err = s.UnmarshalTo(signatures[i])
 		if err != nil {
 			log.L(ctx).Errorf("Failed to unmarshal attestation request: %s", err)
-			return
+			return nil
 		}
 	}
 
@@ -588,7 +639,7 @@ func (p *privateTxManager) handleEndorsementRequest(ctx context.Context, message
 		err = s.UnmarshalTo(inputStates[i])
 		if err != nil {
 			log.L(ctx).Errorf("Failed to unmarshal attestation request: %s", err)
-			return
+			return nil
 		}
 	}
 
@@ -598,7 +649,7 @@ func (p *privateTxManager) handleEndorsementRequest(ctx context.Context, message
 		err = s.UnmarshalTo(readStates[i])
 		if err != nil {
 			log.L(ctx).Errorf("Failed to unmarshal attestation request: %s", err)
-			return
+			return nil
 		}
 	}
 
@@ -608,7 +659,7 @@ func (p *privateTxManager) handleEndorsementRequest(ctx context.Context, message
 		err = s.UnmarshalTo(outputStates[i])
 		if err != nil {
 			log.L(ctx).Errorf("Failed to unmarshal attestation request: %s", err)
-			return
+			return nil
 		}
 	}
 
@@ -618,7 +669,7 @@ func (p *privateTxManager) handleEndorsementRequest(ctx context.Context, message
 		err = s.UnmarshalTo(infoStates[i])
 		if err != nil {
 			log.L(ctx).Errorf("Failed to unmarshal attestation request: %s", err)
-			return
+			return nil
 		}
 	}
 
@@ -634,16 +685,16 @@ func (p *privateTxManager) handleEndorsementRequest(ctx context.Context, message
 		attestationRequest)
 	if err != nil {
 		log.L(ctx).Errorf("Failed to gather endorsement: %s", err)
-		return
+		return nil
 	}
 
 	endorsementAny, err := anypb.New(endorsement)
 	if err != nil {
 		log.L(ctx).Errorf("Failed marshal endorsement: %s", err)
-		return
+		return nil
 	}
 
-	endorsementResponse := &pbEngine.EndorsementResponse{
+	return &pbEngine.EndorsementResponse{
 		IdempotencyKey:         endorsementRequest.IdempotencyKey,
 		ContractAddress:        contractAddressString,
 		TransactionId:          endorsementRequest.TransactionId,
@@ -652,22 +703,6 @@ func (p *privateTxManager) handleEndorsementRequest(ctx context.Context, message
 		Party:                  endorsementRequest.Party,
 		AttestationRequestName: attestationRequest.Name,
 	}
-	endorsementResponseBytes, err := proto.Marshal(endorsementResponse)
-	if err != nil {
-		log.L(ctx).Errorf("Failed to marshal endorsement response: %s", err)
-		return
-	}
-
-	err = p.components.TransportManager().Send(ctx, &components.FireAndForgetMessageSend{
-		MessageType: "EndorsementResponse",
-		Payload:     endorsementResponseBytes,
-		Node:        replyTo,
-		Component:   prototk.PaladinMsg_TRANSACTION_ENGINE,
-	})
-	if err != nil {
-		log.L(ctx).Errorf("Failed to send endorsement response: %s", err)
-		return
-	}
 }
 
 func (p *privateTxManager) handleDelegationRequest(ctx context.Context, messagePayload []byte, replyTo string) {
@@ -691,11 +726,19 @@ func (p *privateTxManager) handleDelegationRequest(ctx context.Context, messageP
 		return
 	}
 
-	//TODO not quite figured out how to receive an assembled transaction because it will have been assembled
-	// in the domain context of the sender.  In some cases, it will be using committed states so that will be ok.
-	// for now, in the interest of simplicity, we just trash the PostAssembly and start again
-	transaction.PostAssembly = nil
-	err = p.handleDelegatedTransaction(ctx, p.components.Persistence().NOTX(), delegationRequest.BlockHeight, replyTo, delegationRequest.DelegationId, transaction)
+	if len(delegationRequest.UnavailableCoordinators) > 0 && transaction.PostAssembly != nil {
+		// The delegating node has failed over to us from another coordinator, which might still dispatch the assembly it
+		// was given. So we must dispatch exactly the same assembly, rather than re-assembling. The states are written
+		// and locked in our own domain context when the transaction is swapped in.
+		transaction.PostAssembly.OutputStates = nil
+		transaction.PostAssembly.InfoStates = nil
+	} else {
+		//TODO not quite figured out how to receive an assembled transaction because it will have been assembled
+		// in the domain context of the sender.  In some cases, it will be using committed states so that will be ok.
+		// for now, in the interest of simplicity, we just trash the PostAssembly and start again
+		transaction.PostAssembly = nil
+	}
+	err = p.handleDelegatedTransaction(ctx, p.components.Persistence().NOTX(), delegationRequest.BlockHeight, replyTo, delegationRequest.DelegationId, delegationRequest.UnavailableCoordinators, transaction)
 	if err != nil {
 		log.L(ctx).Errorf("Failed to handle delegated transaction: %s", err)
 		// do not send an ack and let the sender retry
@@ -721,6 +764,42 @@ func (p *privateTxManager) handleDelegationRequestAcknowledgment(ctx context.Con
 
 }
 
+func (p *privateTxManager) handleDelegationHeartbeat(ctx context.Context, messagePayload []byte, fromNode string) {
+	delegationHeartbeat := &pbEngine.DelegationHeartbeat{}
+	err := proto.Unmarshal(messagePayload, delegationHeartbeat)
+	if err != nil {
+		log.L(ctx).Errorf("Failed to unmarshal delegation heartbeat: %s", err)
+		return
+	}
+
+	p.HandleNewEvent(ctx, &ptmgrtypes.DelegationHeartbeatEvent{
+		PrivateTransactionEventBase: ptmgrtypes.PrivateTransactionEventBase{
+			TransactionID:   delegationHeartbeat.TransactionId,
+			ContractAddress: delegationHeartbeat.ContractAddress,
+		},
+		// we trust the transport for the identity of the sender, not the payload
+		DelegateNodeID: fromNode,
+		Dispatched:     delegationHeartbeat.Dispatched,
+	})
+}
+
+func (p *privateTxManager) handleDelegationRevocation(ctx context.Context, messagePayload []byte, fromNode string) {
+	delegationRevocation := &pbEngine.DelegationRevocation{}
+	err := proto.Unmarshal(messagePayload, delegationRevocation)
+	if err != nil {
+		log.L(ctx).Errorf("Failed to unmarshal delegation revocation: %s", err)
+		return
+	}
+
+	p.HandleNewEvent(ctx, &ptmgrtypes.DelegationRevokedEvent{
+		PrivateTransactionEventBase: ptmgrtypes.PrivateTransactionEventBase{
+			TransactionID:   delegationRevocation.TransactionId,
+			ContractAddress: delegationRevocation.ContractAddress,
+		},
+		DelegatingNodeID: fromNode,
+	})
+}
+
 func (p *privateTxManager) handleEndorsementResponse(ctx context.Context, messagePayload []byte) {
 
 	endorsementResponse := &pbEngine.EndorsementResponse{}
@@ -729,6 +808,22 @@ func (p *privateTxManager) handleEndorsementResponse(ctx context.Context, messag
 		log.L(ctx).Errorf("Failed to unmarshal endorsementResponse: %s", err)
 		return
 	}
+	p.applyEndorsementResponse(ctx, endorsementResponse)
+}
+
+func (p *privateTxManager) handleEndorsementResponseBatch(ctx context.Context, messagePayload []byte) {
+	endorsementResponseBatch := &pbEngine.EndorsementResponseBatch{}
+	err := proto.Unmarshal(messagePayload, endorsementResponseBatch)
+	if err != nil {
+		log.L(ctx).Errorf("Failed to unmarshal endorsement response batch: %s", err)
+		return
+	}
+	for _, endorsementResponse := range endorsementResponseBatch.EndorsementResponses {
+		p.applyEndorsementResponse(ctx, endorsementResponse)
+	}
+}
+
+func (p *privateTxManager) applyEndorsementResponse(ctx context.Context, endorsementResponse *pbEngine.EndorsementResponse) {
 	contractAddressString := endorsementResponse.ContractAddress
 
 	var revertReason *string
@@ -736,7 +831,7 @@ func (p *privateTxManager) handleEndorsementResponse(ctx context.Context, messag
 		revertReason = confutil.P(endorsementResponse.GetRevertReason())
 	}
 	endorsement := &prototk.AttestationResult{}
-	err = endorsementResponse.GetEndorsement().UnmarshalTo(endorsement)
+	err := endorsementResponse.GetEndorsement().UnmarshalTo(endorsement)
 	if err != nil {
 		// TODO this is only temporary until we stop using anypb in EndorsementResponse
 		log.L(ctx).Errorf("Wrong type received in EndorsementResponse")
@@ -827,7 +922,23 @@ func (p *privateTxManager) handleAssembleRequest(ctx context.Context, messagePay
 		return
 	}
 
-	postAssembly, err := sequencer.assembleForRemoteCoordinator(ctx, transactionID, preAssembly, assembleRequest.StateLocks, assembleRequest.BlockHeight)
+	// An assemble request tells us that the coordinator is still working on the transaction - unless it is a coordinator
+	// we have failed over from, which we must not give a fresh assembly to
+	if sequencer.hasFailedOverFrom(ctx, transactionIDString, replyTo) {
+		err := i18n.NewError(ctx, msgs.MsgPrivateTxManagerCoordinatorRevoked, replyTo, transactionIDString)
+		log.L(ctx).Error(err.Error())
+		p.sendAssembleError(ctx, replyTo, assembleRequest.AssembleRequestId, assembleRequest.ContractAddress, assembleRequest.TransactionId, err)
+		return
+	}
+	p.HandleNewEvent(ctx, &ptmgrtypes.DelegationHeartbeatEvent{
+		PrivateTransactionEventBase: ptmgrtypes.PrivateTransactionEventBase{
+			TransactionID:   transactionIDString,
+			ContractAddress: contractAddress.String(),
+		},
+		DelegateNodeID: replyTo,
+	})
+
+	postAssembly, err := sequencer.assembleForRemoteCoordinator(ctx, transactionID, replyTo, preAssembly, assembleRequest.StateLocks, assembleRequest.BlockHeight)
 	if err != nil {
 		log.L(ctx).Errorf("Failed to assemble for coordinator: %s", err)
 		p.sendAssembleError(ctx, replyTo, assembleRequest.AssembleRequestId, assembleRequest.ContractAddress, assembleRequest.TransactionId, err)
@@ -971,6 +1082,10 @@ func (p *privateTxManager) NotifyFailedPublicTx(ctx context.Context, dbTX persis
 			},
 			RevertData: tx.RevertReason,
 		}
+		if tx.Expired {
+			privateFailureReceipts[i].ReceiptType = components.RT_FailedWithMessage
+			privateFailureReceipts[i].FailureMessage = i18n.NewError(ctx, msgs.MsgPublicTxExpired, tx.Hash).Error()
+		}
 	}
 	return p.components.TxManager().FinalizeTransactions(ctx, dbTX, privateFailureReceipts)
 }
@@ -991,6 +1106,10 @@ func (p *privateTxManager) PrivateTransactionConfirmed(ctx context.Context, rece
 	}
 }
 
+func (p *privateTxManager) SubmitExternalCalls(ctx context.Context, dbTX persistence.DBTX, txIDs []uuid.UUID) error {
+	return p.syncPoints.SubmitExternalCalls(ctx, dbTX, txIDs)
+}
+
 func (p *privateTxManager) CallPrivateSmartContract(ctx context.Context, call *components.ResolvedTransaction) (*abi.ComponentValue, error) {
 
 	callTx := call.Transaction
@@ -1012,23 +1131,13 @@ func (p *privateTxManager) CallPrivateSmartContract(ctx context.Context, call *c
 	}
 
 	// Do the verification in-line and synchronously for call (there is caching in the identity resolver)
-	identityResolver := p.components.IdentityResolver()
-	verifiers := make([]*prototk.ResolvedVerifier, len(requiredVerifiers))
-	for i, r := range requiredVerifiers {
-		verifier, err := identityResolver.ResolveVerifier(ctx, r.Lookup, r.Algorithm, r.VerifierType)
-		if err != nil {
-			return nil, err
-		}
-		verifiers[i] = &prototk.ResolvedVerifier{
-			Lookup:       r.Lookup,
-			Algorithm:    r.Algorithm,
-			VerifierType: r.VerifierType,
-			Verifier:     verifier,
-		}
+	verifiers, err := p.components.IdentityResolver().ResolveVerifiers(ctx, requiredVerifiers)
+	if err != nil {
+		return nil, err
 	}
 
 	// Create a throwaway domain context for this call
-	dCtx := p.components.StateManager().NewDomainContext(ctx, psc.Domain(), psc.Address())
+	dCtx := p.components.StateManager().NewDomainContext(ctx, psc.Domain(), psc.Address(), components.DomainContextIsolationSpeculative)
 	defer dCtx.Close()
 
 	// Do the actual call
@@ -101,6 +101,32 @@ func TestPrivateTxManagerInit(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestPrivateTxManagerOrderedContracts(t *testing.T) {
+	ctx := context.Background()
+	contractAddr := pldtypes.RandAddress()
+	ptm := NewPrivateTransactionMgr(ctx, &pldconf.PrivateTxManagerConfig{
+		OrderedContracts: []pldconf.PrivateTxManagerOrderedContract{
+			{ContractAddress: contractAddr.String(), OrderingNodes: []string{"node2", "node3"}},
+		},
+	}).(*privateTxManager)
+	_, err := ptm.PreInit(nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"node2", "node3"}, ptm.orderingNodesForContract(*contractAddr))
+	assert.Nil(t, ptm.orderingNodesForContract(*pldtypes.RandAddress()))
+
+	for _, orderedContract := range []pldconf.PrivateTxManagerOrderedContract{
+		{ContractAddress: "not an address", OrderingNodes: []string{"node2"}},
+		{ContractAddress: contractAddr.String()},
+		{ContractAddress: contractAddr.String(), OrderingNodes: []string{"node2", ""}},
+	} {
+		ptm := NewPrivateTransactionMgr(ctx, &pldconf.PrivateTxManagerConfig{
+			OrderedContracts: []pldconf.PrivateTxManagerOrderedContract{orderedContract},
+		})
+		_, err := ptm.PreInit(nil)
+		assert.Regexp(t, "PD011841", err)
+	}
+}
+
 func TestPrivateTxManagerInvalidTransactionMissingDomain(t *testing.T) {
 	t.Skip("This test is not valid because the code accepts empty domain. TODO: remove this test or change the code and migrate any consumers")
 	ctx := context.Background()
@@ -381,7 +407,18 @@ func TestPrivateTxManagerSimpleTransaction(t *testing.T) {
 
 	<-dcFlushed
 
+	// the notary's endorsement is recorded with the dispatch
+	mocks.txManager.AssertCalled(t, "WriteTransactionEndorsements", mock.Anything, mock.Anything, mock.MatchedBy(func(endorsements []*pldapi.TransactionEndorsement) bool {
+		return len(endorsements) == 1 &&
+			endorsements[0].TransactionID == *testTransactionID &&
+			endorsements[0].Name == "notary" &&
+			endorsements[0].AttestationType == "ENDORSE" &&
+			endorsements[0].Lookup == notary.identityLocator
+	}))
+
+	// stopping drains the sequencers
 	privateTxManager.Stop()
+	assert.Empty(t, privateTxManager.GetSequencerSnapshots(ctx))
 
 }
 
@@ -1168,6 +1205,105 @@ func TestPrivateTxManagerRemoteNotaryEndorserRetry(t *testing.T) {
 
 }
 
+func TestPrivateTxManagerEndorsementRequestBatch(t *testing.T) {
+	ctx := context.Background()
+	domainAddress := pldtypes.RandAddress()
+	remoteNodeName := "node2"
+
+	remoteEngine, remoteEngineMocks := NewPrivateTransactionMgrForPackageTesting(t, remoteNodeName)
+	remoteEngineMocks.mockDomain(domainAddress)
+	notary := newPartyForTesting(ctx, "notary", remoteNodeName, remoteEngineMocks)
+
+	remoteEngineMocks.domainSmartContract.On("EndorseTransaction", mock.Anything, mock.Anything, mock.Anything).Return(&components.EndorsementResult{
+		Result:  prototk.EndorseTransactionResponse_SIGN,
+		Payload: []byte("some-endorsement-bytes"),
+		Endorser: &prototk.ResolvedVerifier{
+			Lookup:       notary.identityLocator,
+			Verifier:     notary.verifier,
+			Algorithm:    algorithms.ECDSA_SECP256K1,
+			VerifierType: verifiers.ETH_ADDRESS,
+		},
+	}, nil)
+	notary.mockSign([]byte("some-endorsement-bytes"), []byte("some-signature-bytes"))
+
+	responses := make(chan *components.FireAndForgetMessageSend, 1)
+	remoteEngineMocks.transportManager.On("Send", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		responses <- args.Get(1).(*components.FireAndForgetMessageSend)
+	}).Return(nil)
+
+	// Use a transport writer to build a batch of two requests for the notary
+	tw, _, sent := newTransportWriterForTesting(t, &pldconf.PrivateTxManagerSequencerConfig{
+		EndorsementBatchMaxSize: confutil.P(2),
+		EndorsementBatchTimeout: confutil.P("1h"),
+	})
+	tw.contractAddress = domainAddress
+	for _, txID := range []string{"tx1", "tx2"} {
+		err := tw.SendEndorsementRequest(ctx, "idem-"+txID, notary.identityLocator, remoteNodeName, domainAddress.String(), txID,
+			&prototk.AttestationRequest{
+				Name:            "notary",
+				AttestationType: prototk.AttestationType_ENDORSE,
+				Algorithm:       algorithms.ECDSA_SECP256K1,
+				VerifierType:    verifiers.ETH_ADDRESS,
+				PayloadType:     signpayloads.OPAQUE_TO_RSV,
+			},
+			&prototk.TransactionSpecification{TransactionId: txID},
+			nil, nil, nil, nil, nil)
+		require.NoError(t, err)
+	}
+	requestBatchMsg := <-sent
+	require.Equal(t, "EndorsementRequestBatch", requestBatchMsg.MessageType)
+
+	// Add a request that cannot be endorsed, which is left out of the response batch
+	requestBatch := &pbEngine.EndorsementRequestBatch{}
+	require.NoError(t, proto.Unmarshal(requestBatchMsg.Payload, requestBatch))
+	requestBatch.EndorsementRequests = append(requestBatch.EndorsementRequests, &pbEngine.EndorsementRequest{ContractAddress: "bad address"})
+	requestBatchBytes, err := proto.Marshal(requestBatch)
+	require.NoError(t, err)
+
+	remoteEngine.HandlePaladinMsg(ctx, &components.ReceivedMessage{
+		FromNode:    "node1",
+		MessageType: "EndorsementRequestBatch",
+		Payload:     requestBatchBytes,
+	})
+
+	responseBatchMsg := <-responses
+	assert.Equal(t, "EndorsementResponseBatch", responseBatchMsg.MessageType)
+	assert.Equal(t, "node1", responseBatchMsg.Node)
+	responseBatch := &pbEngine.EndorsementResponseBatch{}
+	require.NoError(t, proto.Unmarshal(responseBatchMsg.Payload, responseBatch))
+	require.Len(t, responseBatch.EndorsementResponses, 2)
+	for i, txID := range []string{"tx1", "tx2"} {
+		resp := responseBatch.EndorsementResponses[i]
+		assert.Equal(t, txID, resp.TransactionId)
+		assert.Equal(t, "idem-"+txID, resp.IdempotencyKey)
+		endorsement := &prototk.AttestationResult{}
+		require.NoError(t, resp.Endorsement.UnmarshalTo(endorsement))
+		assert.Equal(t, []byte("some-signature-bytes"), endorsement.Payload)
+	}
+
+	// Nothing is sent back for a batch where nothing can be endorsed, or that cannot be parsed
+	onlyBadBytes, err := proto.Marshal(&pbEngine.EndorsementRequestBatch{
+		EndorsementRequests: []*pbEngine.EndorsementRequest{{ContractAddress: "bad address"}},
+	})
+	require.NoError(t, err)
+	remoteEngine.HandlePaladinMsg(ctx, &components.ReceivedMessage{
+		FromNode:    "node1",
+		MessageType: "EndorsementRequestBatch",
+		Payload:     onlyBadBytes,
+	})
+	remoteEngine.HandlePaladinMsg(ctx, &components.ReceivedMessage{
+		FromNode:    "node1",
+		MessageType: "EndorsementRequestBatch",
+		Payload:     []byte("!!! not protobuf"),
+	})
+	remoteEngine.HandlePaladinMsg(ctx, &components.ReceivedMessage{
+		FromNode:    "node1",
+		MessageType: "EndorsementResponseBatch",
+		Payload:     []byte("!!! not protobuf"),
+	})
+	assert.Empty(t, responses)
+}
+
 func TestPrivateTxManagerEndorsementGroup(t *testing.T) {
 
 	ctx := context.Background()
@@ -2879,6 +3015,7 @@ func NewPrivateTransactionMgrForPackageTesting(t *testing.T, nodeName string) (p
 	mocks.transportManager.On("LocalNodeName").Return(nodeName)
 	mocks.allComponents.On("KeyManager").Return(mocks.keyManager).Maybe()
 	mocks.allComponents.On("TxManager").Return(mocks.txManager).Maybe()
+	mocks.txManager.On("WriteTransactionEndorsements", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
 	mocks.allComponents.On("PublicTxManager").Return(mocks.publicTxManager).Maybe()
 	mocks.allComponents.On("Persistence").Return(mocks.persistence).Maybe()
 	mocks.domainSmartContract.On("Domain").Return(mocks.domain).Maybe()
@@ -2906,6 +3043,19 @@ func NewPrivateTransactionMgrForPackageTesting(t *testing.T, nodeName string) (p
 	mocks.transportManager.On("RegisterClient", mock.Anything, mock.Anything).Return(nil).Maybe()
 	//It is not valid to reference LateBound components before PostInit
 	mocks.allComponents.On("IdentityResolver").Return(mocks.identityResolver).Maybe()
+	// bulk resolution is answered from whatever individual verifiers each test has mocked
+	mocks.identityResolver.On("ResolveVerifiers", mock.Anything, mock.Anything).Return(
+		func(ctx context.Context, requests []*prototk.ResolveVerifierRequest) ([]*prototk.ResolvedVerifier, error) {
+			resolved := make([]*prototk.ResolvedVerifier, len(requests))
+			for i, r := range requests {
+				verifier, err := mocks.identityResolver.ResolveVerifier(ctx, r.Lookup, r.Algorithm, r.VerifierType)
+				if err != nil {
+					return nil, err
+				}
+				resolved[i] = &prototk.ResolvedVerifier{Lookup: r.Lookup, Algorithm: r.Algorithm, VerifierType: r.VerifierType, Verifier: verifier}
+			}
+			return resolved, nil
+		}).Maybe()
 	preInitResult, err := e.PreInit(mocks.preInitComponents)
 	assert.NoError(t, err)
 	err = mocks.persistence.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
@@ -3066,7 +3216,7 @@ func mockDomainSmartContractAndCtx(t *testing.T, m *dependencyMocks) (*component
 	m.domainMgr.On("GetSmartContractByAddress", mock.Anything, mock.Anything, contractAddr).Return(mPSC, nil)
 
 	mDC := componentmocks.NewDomainContext(t)
-	m.stateStore.On("NewDomainContext", mock.Anything, mDomain, contractAddr).Return(mDC).Maybe()
+	m.stateStore.On("NewDomainContext", mock.Anything, mDomain, contractAddr, mock.Anything).Return(mDC).Maybe()
 	mDC.On("Close").Return().Maybe()
 
 	return mDomain, mPSC
@@ -118,6 +118,19 @@ type TransactionDelegationAcknowledgedEvent struct {
 	DelegationRequestID string
 }
 
+// The coordinator we delegated to has been in contact, either with a heartbeat or with a request to assemble the transaction
+type DelegationHeartbeatEvent struct {
+	PrivateTransactionEventBase
+	DelegateNodeID string
+	Dispatched     bool
+}
+
+// The node that delegated the transaction to us has failed over to another coordinator
+type DelegationRevokedEvent struct {
+	PrivateTransactionEventBase
+	DelegatingNodeID string
+}
+
 type TransactionBlockedEvent struct {
 	PrivateTransactionEventBase
 }
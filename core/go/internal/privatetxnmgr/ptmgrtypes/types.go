@@ -100,8 +100,10 @@ type ContentionResolver interface {
 }
 
 type TransportWriter interface {
-	SendDelegationRequest(ctx context.Context, delegationId string, delegateNodeName string, transaction *components.PrivateTransaction, blockHeight int64) error
+	SendDelegationRequest(ctx context.Context, delegationId string, delegateNodeName string, transaction *components.PrivateTransaction, blockHeight int64, unavailableCoordinators []string) error
 	SendDelegationRequestAcknowledgment(ctx context.Context, delegatingNodeName string, delegationId string, delegateNodeName string, transactionID string) error
+	SendDelegationHeartbeat(ctx context.Context, delegatingNodeName string, transactionID string, dispatched bool) error
+	SendDelegationRevocation(ctx context.Context, delegateNodeName string, transactionID string) error
 	SendEndorsementRequest(ctx context.Context, idempotencyKey string, party string, targetNode string, contractAddress string, transactionID string, attRequest *prototk.AttestationRequest, transactionSpecification *prototk.TransactionSpecification, verifiers []*prototk.ResolvedVerifier, signatures []*prototk.AttestationResult, inputStates []*components.FullState, outputStates []*components.FullState, infoStates []*components.FullState) error
 	SendAssembleRequest(ctx context.Context, assemblingNode string, assembleRequestID string, txID uuid.UUID, contractAddress string, preAssembly *components.TransactionPreAssembly, stateLocksJSON []byte, blockHeight int64) error
 }
@@ -124,6 +126,8 @@ type TransactionFlow interface {
 	PrepareTransaction(ctx context.Context, defaultSigner string) (*components.PrivateTransaction, error)
 	GetStateDistributions(ctx context.Context) (*components.StateDistributionSet, error)
 	CoordinatingLocally(ctx context.Context) bool
+	DelegatingNode(ctx context.Context) string
+	HasFailedOverFrom(ctx context.Context, node string) bool
 	IsComplete(ctx context.Context) bool
 	ReadyForSequencing(ctx context.Context) bool
 	Dispatched(ctx context.Context) bool
@@ -150,6 +154,12 @@ func RealClock() Clock {
 
 type CoordinatorSelector interface {
 	SelectCoordinatorNode(ctx context.Context, transaction *components.PrivateTransaction, environment SequencerEnvironment) (int64, string, error)
+	// Records that a coordinator node has stopped responding, so selection fails over to the next candidate node
+	// until the failover timeout has passed. Every node that has seen the same failures selects the same coordinator.
+	// Returns false if the policy has no other node that can take over.
+	CoordinatorUnavailable(ctx context.Context, node string) bool
+	// The coordinator nodes currently being failed over from, in sorted order
+	UnavailableCoordinators(ctx context.Context) []string
 }
 
 type SequencerEnvironment interface {
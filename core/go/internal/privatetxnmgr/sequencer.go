@@ -18,6 +18,7 @@ package privatetxnmgr
 import (
 	"context"
 	"fmt"
+	"sort"
 	"sync"
 	"time"
 
@@ -32,6 +33,7 @@ import (
 
 	"github.com/kaleido-io/paladin/common/go/pkg/log"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/retry"
 )
 
 /*
@@ -120,6 +122,11 @@ type Sequencer struct {
 	transportWriter          ptmgrtypes.TransportWriter
 	graph                    Graph
 	requestTimeout           time.Duration
+	failoverTimeout          time.Duration
+	heartbeatInterval        time.Duration
+	remoteAssembliesLock     sync.Mutex
+	remoteAssemblies         map[uuid.UUID]*remoteAssembly // cache of the persisted assembly we produced for each transaction we delegated to a remote coordinator
+	reassembleRetry          *retry.Retry
 	coordinatorSelector      ptmgrtypes.CoordinatorSelector
 	newBlockEvents           chan int64
 	assembleCoordinator      ptmgrtypes.AssembleCoordinator
@@ -132,6 +139,7 @@ func NewSequencer(
 	nodeName string,
 	contractAddress pldtypes.EthAddress,
 	sequencerConfig *pldconf.PrivateTxManagerSequencerConfig,
+	orderingNodes []string,
 	allComponents components.AllComponents,
 	domainAPI components.DomainSmartContract,
 	endorsementGatherer ptmgrtypes.EndorsementGatherer,
@@ -172,6 +180,10 @@ func NewSequencer(
 		transportWriter:              transportWriter,
 		graph:                        NewGraph(),
 		requestTimeout:               requestTimeout,
+		failoverTimeout:              confutil.DurationMin(sequencerConfig.CoordinatorFailoverTimeout, 1*time.Millisecond, *pldconf.PrivateTxManagerDefaults.Sequencer.CoordinatorFailoverTimeout),
+		heartbeatInterval:            confutil.DurationMin(sequencerConfig.CoordinatorHeartbeatInterval, 1*time.Millisecond, *pldconf.PrivateTxManagerDefaults.Sequencer.CoordinatorHeartbeatInterval),
+		remoteAssemblies:             make(map[uuid.UUID]*remoteAssembly),
+		reassembleRetry:              retry.NewRetryLimited(&sequencerConfig.ReassembleRetry, &pldconf.PrivateTxManagerDefaults.Sequencer.ReassembleRetry),
 		environment: &sequencerEnvironment{
 			blockHeight: blockHeight,
 		},
@@ -185,7 +197,7 @@ func NewSequencer(
 
 	log.L(ctx).Debugf("NewSequencer for contract address %s created: %+v", newSequencer.contractAddress, newSequencer)
 
-	coordinatorSelector, err := NewCoordinatorSelector(ctx, nodeName, domainAPI.ContractConfig(), *sequencerConfig)
+	coordinatorSelector, err := NewCoordinatorSelector(ctx, nodeName, domainAPI.ContractConfig(), *sequencerConfig, orderingNodes)
 	if err != nil {
 		log.L(ctx).Errorf("Failed to create coordinator selector: %s", err)
 		return nil, i18n.WrapError(ctx, err, msgs.MsgPrivateTxManagerNewSequencerError, domainAPI.ContractConfig().GetCoordinatorSelection())
@@ -202,8 +214,9 @@ func NewSequencer(
 	}
 
 	// create 2 domain contexts. One to keep track of all transactions that we are coordinating and one for assembling transactions on behalf of a remote coordinator
-	newSequencer.coordinatorDomainContext = allComponents.StateManager().NewDomainContext(newSequencer.ctx /* background context */, domainSmartContract.Domain(), contractAddress)
-	newSequencer.delegateDomainContext = allComponents.StateManager().NewDomainContext(newSequencer.ctx /* background context */, domainSmartContract.Domain(), contractAddress)
+	// both with the isolation the domain has configured
+	newSequencer.coordinatorDomainContext = allComponents.StateManager().NewDomainContext(newSequencer.ctx /* background context */, domainSmartContract.Domain(), contractAddress, "")
+	newSequencer.delegateDomainContext = allComponents.StateManager().NewDomainContext(newSequencer.ctx /* background context */, domainSmartContract.Domain(), contractAddress, "")
 
 	newSequencer.assembleCoordinator = NewAssembleCoordinator(
 		ctx,
@@ -238,10 +251,44 @@ func (s *Sequencer) getTransactionProcessor(txID string) ptmgrtypes.TransactionF
 	return transactionProcessor
 }
 
-func (s *Sequencer) removeTransactionProcessor(txID string) {
+func (s *Sequencer) removeTransactionProcessor(ctx context.Context, txID string) {
 	s.incompleteTxProcessMapMutex.Lock()
-	defer s.incompleteTxProcessMapMutex.Unlock()
 	delete(s.incompleteTxSProcessMap, txID)
+	s.incompleteTxProcessMapMutex.Unlock()
+	if id, err := uuid.Parse(txID); err == nil {
+		s.deleteRemoteAssembly(ctx, id)
+	}
+}
+
+// Whether we have failed over from the given coordinator for a transaction we delegated, in which case we must not
+// assemble for it any more
+func (s *Sequencer) hasFailedOverFrom(ctx context.Context, txID string, node string) bool {
+	transactionProcessor := s.getTransactionProcessor(txID)
+	return transactionProcessor != nil && transactionProcessor.HasFailedOverFrom(ctx, node)
+}
+
+// Let the nodes that delegated transactions to us know that we are still working on them, so they do not fail over to another coordinator
+func (s *Sequencer) sendDelegationHeartbeats(ctx context.Context) {
+	type heartbeat struct {
+		txID           string
+		delegatingNode string
+		dispatched     bool
+	}
+	var heartbeats []heartbeat
+	s.incompleteTxProcessMapMutex.Lock()
+	for txID, transactionProcessor := range s.incompleteTxSProcessMap {
+		if delegatingNode := transactionProcessor.DelegatingNode(ctx); delegatingNode != "" {
+			heartbeats = append(heartbeats, heartbeat{txID, delegatingNode, transactionProcessor.Dispatched(ctx)})
+		}
+	}
+	s.incompleteTxProcessMapMutex.Unlock()
+
+	// we do not hold the lock while sending, as the transport might block
+	for _, hb := range heartbeats {
+		if err := s.transportWriter.SendDelegationHeartbeat(ctx, hb.delegatingNode, hb.txID, hb.dispatched); err != nil {
+			log.L(ctx).Errorf("Failed to send delegation heartbeat for transaction %s to %s: %s", hb.txID, hb.delegatingNode, err)
+		}
+	}
 }
 
 func (s *Sequencer) OnNewBlockHeight(ctx context.Context, blockHeight int64) {
@@ -259,7 +306,7 @@ func (s *Sequencer) ProcessNewTransaction(ctx context.Context, tx *components.Pr
 			// tx processing pool is full, queue the item
 			return true
 		} else {
-			s.incompleteTxSProcessMap[tx.ID.String()] = NewTransactionFlow(ctx, tx, s.nodeName, s.components, s.domainAPI, s.coordinatorDomainContext, s.publisher, s.endorsementGatherer, s.identityResolver, s.syncPoints, s.transportWriter, s.requestTimeout, s.coordinatorSelector, s.assembleCoordinator, s.environment)
+			s.incompleteTxSProcessMap[tx.ID.String()] = NewTransactionFlow(ctx, tx, s.nodeName, s.components, s.domainAPI, s.coordinatorDomainContext, s.publisher, s.endorsementGatherer, s.identityResolver, s.syncPoints, s.transportWriter, s.requestTimeout, s.failoverTimeout, s.reassembleRetry, s.coordinatorSelector, s.assembleCoordinator, s.environment)
 		}
 		s.pendingTransactionEvents <- &ptmgrtypes.TransactionSubmittedEvent{
 			PrivateTransactionEventBase: ptmgrtypes.PrivateTransactionEventBase{TransactionID: tx.ID.String()},
@@ -294,7 +341,7 @@ func (s *Sequencer) ProcessInFlightTransaction(ctx context.Context, tx *componen
 			// tx processing pool is full, queue the item
 			return true
 		} else {
-			s.incompleteTxSProcessMap[tx.ID.String()] = NewTransactionFlow(ctx, tx, s.nodeName, s.components, s.domainAPI, s.coordinatorDomainContext, s.publisher, s.endorsementGatherer, s.identityResolver, s.syncPoints, s.transportWriter, s.requestTimeout, s.coordinatorSelector, s.assembleCoordinator, s.environment)
+			s.incompleteTxSProcessMap[tx.ID.String()] = NewTransactionFlow(ctx, tx, s.nodeName, s.components, s.domainAPI, s.coordinatorDomainContext, s.publisher, s.endorsementGatherer, s.identityResolver, s.syncPoints, s.transportWriter, s.requestTimeout, s.failoverTimeout, s.reassembleRetry, s.coordinatorSelector, s.assembleCoordinator, s.environment)
 		}
 		s.pendingTransactionEvents <- &ptmgrtypes.TransactionSwappedInEvent{
 			PrivateTransactionEventBase: ptmgrtypes.PrivateTransactionEventBase{TransactionID: tx.ID.String()},
@@ -329,6 +376,21 @@ func (s *Sequencer) Stop() {
 
 }
 
+// IsBusy reports whether the sequencer holds in-flight work that cannot be handed over if it is
+// swapped out of the sequencer pool: transactions delegated to or from another node, or state
+// locks held by the transactions it is coordinating
+func (s *Sequencer) IsBusy() bool {
+	ctx := s.ctx
+	s.incompleteTxProcessMapMutex.Lock()
+	defer s.incompleteTxProcessMapMutex.Unlock()
+	for _, txProc := range s.incompleteTxSProcessMap {
+		if !txProc.CoordinatingLocally(ctx) || txProc.DelegatingNode(ctx) != "" {
+			return true
+		}
+	}
+	return s.coordinatorDomainContext.HasStateLocks()
+}
+
 func (s *Sequencer) TriggerSequencerEvaluation() {
 	// try to send an item in `processNow` channel, which has a buffer of 1
 	// if it already has an item in the channel, this function does nothing
@@ -369,3 +431,22 @@ func (s *Sequencer) GetTxStatus(ctx context.Context, txID uuid.UUID) (components
 		FailureMessage: failureMessage,
 	}, nil
 }
+
+// GetSnapshot returns the status of the transactions in memory, without the full transaction content
+func (s *Sequencer) GetSnapshot(ctx context.Context) *components.SequencerSnapshot {
+	s.incompleteTxProcessMapMutex.Lock()
+	defer s.incompleteTxProcessMapMutex.Unlock()
+	snapshot := &components.SequencerSnapshot{
+		ContractAddress: s.contractAddress.String(),
+		Domain:          s.domainAPI.Domain().Name(),
+		Initiated:       pldtypes.Timestamp(s.initiated.UnixNano()),
+		InFlight:        make([]components.PrivateTxStatus, 0, len(s.incompleteTxSProcessMap)),
+	}
+	for _, txProc := range s.incompleteTxSProcessMap {
+		status, _ := txProc.GetTxStatus(ctx) // never errors for in-flight transactions
+		status.Transaction = nil
+		snapshot.InFlight = append(snapshot.InFlight, status)
+	}
+	sort.Slice(snapshot.InFlight, func(i, j int) bool { return snapshot.InFlight[i].TxID < snapshot.InFlight[j].TxID })
+	return snapshot
+}
@@ -64,6 +64,8 @@ func (s *Sequencer) DispatchTransactions(ctx context.Context, dispatchableTransa
 				//TODO this is a really bad time to be getting an error.  need to think carefully about how to handle this
 				return err
 			}
+			dispatchBatch.Endorsements = append(dispatchBatch.Endorsements, mapTransactionEndorsements(preparedTransaction)...)
+			dispatchBatch.ExternalCalls = append(dispatchBatch.ExternalCalls, mapExternalCalls(preparedTransaction)...)
 			hasPublicTransaction := preparedTransaction.PreparedPublicTransaction != nil
 			hasPrivateTransaction := preparedTransaction.PreparedPrivateTransaction != nil
 			switch {
@@ -228,3 +230,51 @@ func mapPreparedTransaction(tx *components.PrivateTransaction) *components.Prepa
 	return pt
 
 }
+
+// The attestations are recorded when we dispatch, so they can be included in the evidence exported for the transaction
+func mapTransactionEndorsements(tx *components.PrivateTransaction) []*pldapi.TransactionEndorsement {
+	if tx.PostAssembly == nil {
+		return nil
+	}
+	now := pldtypes.TimestampNow()
+	attestations := append(append([]*prototk.AttestationResult{}, tx.PostAssembly.Signatures...), tx.PostAssembly.Endorsements...)
+	endorsements := make([]*pldapi.TransactionEndorsement, 0, len(attestations))
+	for _, ar := range attestations {
+		e := &pldapi.TransactionEndorsement{
+			TransactionID:   tx.ID,
+			Created:         now,
+			Name:            ar.Name,
+			AttestationType: ar.AttestationType.String(),
+			Verifier:        &pldapi.KeyVerifier{},
+			PayloadType:     ar.GetPayloadType(),
+			Payload:         ar.Payload,
+		}
+		if ar.Verifier != nil {
+			e.Lookup = ar.Verifier.Lookup
+			e.Verifier.Verifier = ar.Verifier.Verifier
+			e.Verifier.Algorithm = ar.Verifier.Algorithm
+			e.Verifier.Type = ar.Verifier.VerifierType
+		}
+		for _, c := range ar.Constraints {
+			e.Constraints = append(e.Constraints, c.String())
+		}
+		endorsements = append(endorsements, e)
+	}
+	return endorsements
+}
+
+// External calls are recorded when we dispatch, and submitted when the transaction is confirmed
+func mapExternalCalls(tx *components.PrivateTransaction) []*syncpoints.ExternalCallPersisted {
+	now := pldtypes.TimestampNow()
+	externalCalls := make([]*syncpoints.ExternalCallPersisted, len(tx.PreparedExternalCalls))
+	for i, ec := range tx.PreparedExternalCalls {
+		externalCalls[i] = &syncpoints.ExternalCallPersisted{
+			DedupKey:    ec.DedupKey,
+			Transaction: tx.ID,
+			Idx:         i,
+			Created:     now,
+			Call:        pldtypes.JSONString(ec.Transaction),
+		}
+	}
+	return externalCalls
+}
@@ -38,6 +38,7 @@ func (s *Sequencer) evaluationLoop() {
 	defer close(s.sequencerLoopDone)
 
 	ticker := time.NewTicker(s.evalInterval)
+	heartbeatTicker := time.NewTicker(s.heartbeatInterval)
 	for {
 		// an InFlight
 		select {
@@ -48,6 +49,8 @@ func (s *Sequencer) evaluationLoop() {
 			s.handleTransactionEvent(ctx, pendingEvent)
 		case <-s.orchestrationEvalRequestChan:
 		case <-ticker.C:
+		case <-heartbeatTicker.C:
+			s.sendDelegationHeartbeats(ctx)
 		case <-ctx.Done():
 			log.L(ctx).Infof("Sequencer loop exit due to canceled context, it processed %d transaction during its lifetime.", s.totalCompleted)
 			return
@@ -107,7 +110,7 @@ func (s *Sequencer) handleTransactionEvent(ctx context.Context, event ptmgrtypes
 	if transactionProcessor.IsComplete(ctx) {
 
 		s.graph.RemoveTransaction(ctx, transactionID)
-		s.removeTransactionProcessor(transactionID)
+		s.removeTransactionProcessor(ctx, transactionID)
 	} else {
 
 		/*
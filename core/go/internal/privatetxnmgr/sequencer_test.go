@@ -18,6 +18,7 @@ package privatetxnmgr
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
@@ -31,6 +32,7 @@ import (
 	"github.com/kaleido-io/paladin/core/pkg/persistence"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/toolkit/pkg/keyedqueue"
 	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -56,6 +58,17 @@ type sequencerDepencyMocks struct {
 }
 
 func newSequencerForTesting(t *testing.T, ctx context.Context, domainAddress *pldtypes.EthAddress) (*Sequencer, *sequencerDepencyMocks, func()) {
+	o, mocks, persistenceDone := newUnstartedSequencerForTesting(t, ctx, domainAddress)
+	ocDone, err := o.Start(ctx)
+	require.NoError(t, err)
+
+	return o, mocks, func() {
+		<-ocDone
+		persistenceDone()
+	}
+}
+
+func newUnstartedSequencerForTesting(t *testing.T, ctx context.Context, domainAddress *pldtypes.EthAddress) (*Sequencer, *sequencerDepencyMocks, func()) {
 	if domainAddress == nil {
 		domainAddress = pldtypes.MustEthAddress(pldtypes.RandHex(20))
 	}
@@ -95,19 +108,13 @@ func newSequencerForTesting(t *testing.T, ctx context.Context, domainAddress *pl
 	})
 
 	mocks.stateStore.On("NewDomainContext", mock.Anything, mocks.domain, *domainAddress, mock.Anything).Return(mocks.domainContext).Maybe()
-	//mocks.domain.On("Configuration").Return(&prototk.DomainConfig{}).Maybe()
+	mocks.domain.On("Configuration").Return(&prototk.DomainConfig{}).Maybe()
 
 	syncPoints := syncpoints.NewSyncPoints(ctx, &pldconf.FlushWriterConfig{}, p, mocks.txManager, mocks.pubTxManager, mocks.transportManager)
-	o, err := NewSequencer(ctx, mocks.privateTxManager, pldtypes.RandHex(16), *domainAddress, &pldconf.PrivateTxManagerSequencerConfig{}, mocks.allComponents, mocks.domainSmartContract, mocks.endorsementGatherer, mocks.publisher, syncPoints, mocks.identityResolver, mocks.transportWriter, 30*time.Second, 0)
+	o, err := NewSequencer(ctx, mocks.privateTxManager, pldtypes.RandHex(16), *domainAddress, &pldconf.PrivateTxManagerSequencerConfig{}, nil, mocks.allComponents, mocks.domainSmartContract, mocks.endorsementGatherer, mocks.publisher, syncPoints, mocks.identityResolver, mocks.transportWriter, 30*time.Second, 0)
 	require.NoError(t, err)
-	ocDone, err := o.Start(ctx)
-	require.NoError(t, err)
-
-	return o, mocks, func() {
-		<-ocDone
-		persistenceDone()
-	}
 
+	return o, mocks, persistenceDone
 }
 
 func waitForChannel[T any](t *testing.T, ch chan T) T {
@@ -133,7 +140,14 @@ func TestNewSequencerProcessNewTransactionAssemblyFailed(t *testing.T) {
 
 	ctx := context.Background()
 
-	testOc, dependencyMocks, _ := newSequencerForTesting(t, ctx, nil)
+	testOc, dependencyMocks, _ := newUnstartedSequencerForTesting(t, ctx, nil)
+
+	// the sequencer is started by the pool, as it is for a new contract
+	ptm := &privateTxManager{sequencers: keyedqueue.NewPool[string, *Sequencer](ctx, &pldconf.KeyedQueueConfig{})}
+	_, err := ptm.sequencers.GetOrStart(ctx, testOc.contractAddress.String(), func() (*Sequencer, error) {
+		return testOc, nil
+	})
+	require.NoError(t, err)
 
 	newTxID := uuid.New()
 	testTx := &components.PrivateTransaction{
@@ -182,6 +196,14 @@ func TestNewSequencerProcessNewTransactionAssemblyFailed(t *testing.T) {
 	assert.False(t, testOc.ProcessNewTransaction(ctx, testTx))
 	assert.Equal(t, 1, len(testOc.incompleteTxSProcessMap))
 
+	snapshots := ptm.GetSequencerSnapshots(ctx)
+	require.Len(t, snapshots, 1)
+	assert.Equal(t, testOc.contractAddress.String(), snapshots[0].ContractAddress)
+	assert.Equal(t, "domain1", snapshots[0].Domain)
+	require.Len(t, snapshots[0].InFlight, 1)
+	assert.Equal(t, newTxID.String(), snapshots[0].InFlight[0].TxID)
+	assert.Nil(t, snapshots[0].InFlight[0].Transaction)
+
 }
 
 func TestSequencerPollingLoopStop(t *testing.T) {
@@ -203,3 +225,99 @@ func TestSequencerPollingLoopCancelContext(t *testing.T) {
 
 	cancel()
 }
+
+func TestSequencerSendDelegationHeartbeats(t *testing.T) {
+	ctx := context.Background()
+	testOc, mocks, done := newUnstartedSequencerForTesting(t, ctx, nil)
+	defer done()
+
+	delegatedTxID := uuid.New().String()
+	delegatedTx := privatetxnmgrmocks.NewTransactionFlow(t)
+	delegatedTx.On("DelegatingNode", ctx).Return("node1")
+	delegatedTx.On("Dispatched", ctx).Return(true)
+	localTx := privatetxnmgrmocks.NewTransactionFlow(t)
+	localTx.On("DelegatingNode", ctx).Return("")
+	testOc.incompleteTxSProcessMap[delegatedTxID] = delegatedTx
+	testOc.incompleteTxSProcessMap[uuid.New().String()] = localTx
+
+	mocks.transportWriter.On("SendDelegationHeartbeat", ctx, "node1", delegatedTxID, true).Return(nil).Once()
+	testOc.sendDelegationHeartbeats(ctx)
+
+	// failures are logged, and we try again on the next interval
+	mocks.transportWriter.On("SendDelegationHeartbeat", ctx, "node1", delegatedTxID, true).Return(fmt.Errorf("pop")).Once()
+	testOc.sendDelegationHeartbeats(ctx)
+}
+
+func TestSequencerIsBusy(t *testing.T) {
+	ctx := context.Background()
+	testOc, mocks, done := newUnstartedSequencerForTesting(t, ctx, nil)
+	defer done()
+
+	localTx := privatetxnmgrmocks.NewTransactionFlow(t)
+	localTx.On("CoordinatingLocally", mock.Anything).Return(true)
+	localTx.On("DelegatingNode", mock.Anything).Return("")
+	testOc.incompleteTxSProcessMap[uuid.New().String()] = localTx
+
+	mocks.domainContext.On("HasStateLocks").Return(false).Once()
+	assert.False(t, testOc.IsBusy())
+
+	// locks held by the transactions we are coordinating
+	mocks.domainContext.On("HasStateLocks").Return(true).Once()
+	assert.True(t, testOc.IsBusy())
+
+	// transactions we have delegated to another node
+	delegatedTxID := uuid.New().String()
+	delegatedTx := privatetxnmgrmocks.NewTransactionFlow(t)
+	delegatedTx.On("CoordinatingLocally", mock.Anything).Return(false)
+	testOc.incompleteTxSProcessMap[delegatedTxID] = delegatedTx
+	assert.True(t, testOc.IsBusy())
+	delete(testOc.incompleteTxSProcessMap, delegatedTxID)
+
+	// transactions delegated to us by another node
+	remoteTx := privatetxnmgrmocks.NewTransactionFlow(t)
+	remoteTx.On("CoordinatingLocally", mock.Anything).Return(true)
+	remoteTx.On("DelegatingNode", mock.Anything).Return("node1")
+	testOc.incompleteTxSProcessMap[uuid.New().String()] = remoteTx
+	assert.True(t, testOc.IsBusy())
+}
+
+func TestSequencerAssembleForRemoteCoordinatorAfterFailover(t *testing.T) {
+	ctx := context.Background()
+	testOc, _, done := newUnstartedSequencerForTesting(t, ctx, nil)
+	defer done()
+
+	txID := uuid.New()
+	previousAssembly := &components.TransactionPostAssembly{
+		AssemblyResult: prototk.AssembleTransactionResponse_OK,
+		InputStates:    []*components.FullState{{ID: pldtypes.RandBytes(32), Data: pldtypes.RawJSON(`{"amount":10}`)}},
+	}
+	err := testOc.storeRemoteAssembly(ctx, txID, &remoteAssembly{coordinator: "node2", postAssembly: previousAssembly})
+	require.NoError(t, err)
+
+	// The assembly is persisted, so survives a restart of this node
+	testOc.remoteAssemblies = make(map[uuid.UUID]*remoteAssembly)
+
+	// A new coordinator gets exactly what we gave the old one, without re-assembling
+	postAssembly, err := testOc.assembleForRemoteCoordinator(ctx, txID, "node3", &components.TransactionPreAssembly{}, nil, 10)
+	require.NoError(t, err)
+	assert.Equal(t, pldtypes.JSONString(previousAssembly), pldtypes.JSONString(postAssembly))
+
+	// The assembly is now recorded against the new coordinator, so it can ask for a fresh assembly if it needs one
+	testOc.remoteAssemblies = make(map[uuid.UUID]*remoteAssembly)
+	ra, err := testOc.getRemoteAssembly(ctx, txID)
+	require.NoError(t, err)
+	assert.Equal(t, "node3", ra.coordinator)
+
+	// We refuse to assemble for a coordinator we have failed over from
+	flow := privatetxnmgrmocks.NewTransactionFlow(t)
+	flow.On("HasFailedOverFrom", ctx, "node2").Return(true)
+	testOc.incompleteTxSProcessMap[txID.String()] = flow
+	assert.True(t, testOc.hasFailedOverFrom(ctx, txID.String(), "node2"))
+	assert.False(t, testOc.hasFailedOverFrom(ctx, uuid.NewString(), "node2"))
+
+	testOc.removeTransactionProcessor(ctx, txID.String())
+	assert.Empty(t, testOc.remoteAssemblies)
+	ra, err = testOc.getRemoteAssembly(ctx, txID)
+	require.NoError(t, err)
+	assert.Nil(t, ra)
+}
@@ -33,6 +33,8 @@ type dispatchOperation struct {
 	privateDispatches    []*components.ValidatedTransaction
 	localPreparedTxns    []*components.PreparedTransactionWithRefs
 	preparedReliableMsgs []*pldapi.ReliableMessage
+	endorsements         []*pldapi.TransactionEndorsement
+	externalCalls        []*ExternalCallPersisted
 }
 
 type DispatchPersisted struct {
@@ -54,6 +56,8 @@ type DispatchBatch struct {
 	PublicDispatches     []*PublicDispatch
 	PrivateDispatches    []*components.ValidatedTransaction
 	PreparedTransactions []*components.PreparedTransactionWithRefs
+	Endorsements         []*pldapi.TransactionEndorsement
+	ExternalCalls        []*ExternalCallPersisted
 }
 
 // PersistDispatches persists the dispatches to the database and coordinates with the public transaction manager
@@ -95,6 +99,8 @@ func (s *syncPoints) PersistDispatchBatch(dCtx components.DomainContext, contrac
 			privateDispatches:    dispatchBatch.PrivateDispatches,
 			localPreparedTxns:    localPreparedTxns,
 			preparedReliableMsgs: preparedReliableMsgs,
+			endorsements:         dispatchBatch.Endorsements,
+			externalCalls:        dispatchBatch.ExternalCalls,
 		},
 	})
 
@@ -191,6 +197,24 @@ func (s *syncPoints) writeDispatchOperations(ctx context.Context, dbTX persisten
 			}
 		}
 
+		if len(op.endorsements) > 0 {
+			log.L(ctx).Debugf("Writing %d endorsements", len(op.endorsements))
+			err := s.txMgr.WriteTransactionEndorsements(ctx, dbTX, op.endorsements)
+			if err != nil {
+				log.L(ctx).Errorf("Error persisting endorsements: %s", err)
+				return err
+			}
+		}
+
+		if len(op.externalCalls) > 0 {
+			log.L(ctx).Debugf("Writing %d external calls", len(op.externalCalls))
+			err := s.writeExternalCalls(ctx, dbTX, op.externalCalls)
+			if err != nil {
+				log.L(ctx).Errorf("Error persisting external calls: %s", err)
+				return err
+			}
+		}
+
 		if len(op.preparedReliableMsgs) == 0 {
 			log.L(ctx).Debug("No prepared reliable messages to persist to persist")
 		} else {
@@ -0,0 +1,114 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncpoints
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/core/pkg/persistence"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"gorm.io/gorm/clause"
+)
+
+// An external call is written with the dispatch of the private transaction that declared it,
+// and is submitted as a public transaction when that private transaction is confirmed
+type ExternalCallPersisted struct {
+	DedupKey    string              `gorm:"column:dedup_key;primaryKey"`
+	Transaction uuid.UUID           `gorm:"column:transaction"`
+	Idx         int                 `gorm:"column:idx"`
+	Created     pldtypes.Timestamp  `gorm:"column:created"`
+	Call        pldtypes.RawJSON    `gorm:"column:call"`
+	Submitted   *pldtypes.Timestamp `gorm:"column:submitted"`
+	PublicTX    *uuid.UUID          `gorm:"column:public_tx"`
+}
+
+func (ExternalCallPersisted) TableName() string {
+	return "external_calls"
+}
+
+func (s *syncPoints) writeExternalCalls(ctx context.Context, dbTX persistence.DBTX, externalCalls []*ExternalCallPersisted) error {
+	// If the dedup key already exists (because the transaction was re-dispatched, or another
+	// transaction declared the same call) then the existing record wins
+	return dbTX.DB().
+		WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "dedup_key"}},
+			DoNothing: true,
+		}).
+		Create(externalCalls).
+		Error
+}
+
+func (s *syncPoints) SubmitExternalCalls(ctx context.Context, dbTX persistence.DBTX, txIDs []uuid.UUID) error {
+	var externalCalls []*ExternalCallPersisted
+	err := dbTX.DB().
+		WithContext(ctx).
+		Where(`"transaction" IN (?)`, txIDs).
+		Where("submitted IS NULL").
+		Find(&externalCalls).
+		Error
+	if err != nil || len(externalCalls) == 0 {
+		return err
+	}
+
+	// Submit in the order the transactions were confirmed, then the order the domain declared them
+	confirmOrder := make(map[uuid.UUID]int, len(txIDs))
+	for i, txID := range txIDs {
+		confirmOrder[txID] = i
+	}
+	sort.Slice(externalCalls, func(i, j int) bool {
+		ci, cj := externalCalls[i], externalCalls[j]
+		if ci.Transaction != cj.Transaction {
+			return confirmOrder[ci.Transaction] < confirmOrder[cj.Transaction]
+		}
+		return ci.Idx < cj.Idx
+	})
+
+	txs := make([]*pldapi.TransactionInput, len(externalCalls))
+	for i, ec := range externalCalls {
+		if err := json.Unmarshal(ec.Call, &txs[i]); err != nil {
+			return err
+		}
+	}
+	publicTxIDs, err := s.txMgr.SendTransactions(ctx, dbTX, txs...)
+	if err != nil {
+		return err
+	}
+
+	now := pldtypes.TimestampNow()
+	for i, ec := range externalCalls {
+		log.L(ctx).Infof("Submitted external call %s for transaction %s as public transaction %s", ec.DedupKey, ec.Transaction, publicTxIDs[i])
+		err := dbTX.DB().
+			WithContext(ctx).
+			Model(&ExternalCallPersisted{}).
+			Where("dedup_key = ?", ec.DedupKey).
+			Updates(map[string]any{
+				"submitted": now,
+				"public_tx": publicTxIDs[i],
+			}).
+			Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
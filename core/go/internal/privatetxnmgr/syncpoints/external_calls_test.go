@@ -0,0 +1,194 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncpoints
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/kaleido-io/paladin/core/pkg/persistence"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func testExternalCallRow(rows *sqlmock.Rows, dedupKey string, txID uuid.UUID, idx int, call string) *sqlmock.Rows {
+	return rows.AddRow(dedupKey, txID, idx, pldtypes.TimestampNow(), call)
+}
+
+func newExternalCallRows() *sqlmock.Rows {
+	return sqlmock.NewRows([]string{"dedup_key", "transaction", "idx", "created", "call"})
+}
+
+func TestWriteDispatchOperationsExternalCalls(t *testing.T) {
+	ctx := context.Background()
+	s, m := newSyncPointsForTesting(t)
+
+	m.persistence.Mock.ExpectBegin()
+	m.persistence.Mock.ExpectExec("INSERT.*external_calls.*ON CONFLICT").WillReturnResult(driver.ResultNoRows)
+	m.persistence.Mock.ExpectCommit()
+
+	err := m.persistence.P.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
+		return s.writeDispatchOperations(ctx, dbTX, []*dispatchOperation{{
+			externalCalls: []*ExternalCallPersisted{{
+				DedupKey:    "notify1",
+				Transaction: uuid.New(),
+				Created:     pldtypes.TimestampNow(),
+				Call:        pldtypes.RawJSON(`{}`),
+			}},
+		}})
+	})
+	require.NoError(t, err)
+	require.NoError(t, m.persistence.Mock.ExpectationsWereMet())
+}
+
+func TestWriteDispatchOperationsExternalCallsFail(t *testing.T) {
+	ctx := context.Background()
+	s, m := newSyncPointsForTesting(t)
+
+	m.persistence.Mock.ExpectBegin()
+	m.persistence.Mock.ExpectExec("INSERT.*external_calls").WillReturnError(fmt.Errorf("pop"))
+	m.persistence.Mock.ExpectRollback()
+
+	err := m.persistence.P.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
+		return s.writeDispatchOperations(ctx, dbTX, []*dispatchOperation{{
+			externalCalls: []*ExternalCallPersisted{{DedupKey: "notify1"}},
+		}})
+	})
+	assert.Regexp(t, "pop", err)
+}
+
+func TestSubmitExternalCalls(t *testing.T) {
+	ctx := context.Background()
+	s, m := newSyncPointsForTesting(t)
+
+	tx1, tx2 := uuid.New(), uuid.New()
+	publicTx1, publicTx2, publicTx3 := uuid.New(), uuid.New(), uuid.New()
+	bridge := pldtypes.RandAddress()
+	call := func(name string) string {
+		return pldtypes.JSONString(&pldapi.TransactionInput{
+			TransactionBase: pldapi.TransactionBase{
+				Type:     pldapi.TransactionTypePublic.Enum(),
+				From:     "signer1",
+				To:       bridge,
+				Function: name,
+			},
+		}).String()
+	}
+
+	m.persistence.Mock.ExpectBegin()
+	// returned out of order - submission follows confirmation order, then declaration order
+	rows := newExternalCallRows()
+	testExternalCallRow(rows, "c", tx2, 0, call("notify3"))
+	testExternalCallRow(rows, "b", tx1, 1, call("notify2"))
+	testExternalCallRow(rows, "a", tx1, 0, call("notify1"))
+	m.persistence.Mock.ExpectQuery("SELECT.*external_calls.*submitted IS NULL").WillReturnRows(rows)
+	m.persistence.Mock.ExpectExec("UPDATE.*external_calls").WithArgs(publicTx1, sqlmock.AnyArg(), "a").WillReturnResult(driver.ResultNoRows)
+	m.persistence.Mock.ExpectExec("UPDATE.*external_calls").WithArgs(publicTx2, sqlmock.AnyArg(), "b").WillReturnResult(driver.ResultNoRows)
+	m.persistence.Mock.ExpectExec("UPDATE.*external_calls").WithArgs(publicTx3, sqlmock.AnyArg(), "c").WillReturnResult(driver.ResultNoRows)
+	m.persistence.Mock.ExpectCommit()
+
+	m.txMgr.On("SendTransactions", mock.Anything, mock.Anything,
+		mock.MatchedBy(func(tx *pldapi.TransactionInput) bool { return tx.Function == "notify1" }),
+		mock.MatchedBy(func(tx *pldapi.TransactionInput) bool { return tx.Function == "notify2" }),
+		mock.MatchedBy(func(tx *pldapi.TransactionInput) bool { return tx.Function == "notify3" && *tx.To == *bridge }),
+	).Return([]uuid.UUID{publicTx1, publicTx2, publicTx3}, nil)
+
+	err := m.persistence.P.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
+		return s.SubmitExternalCalls(ctx, dbTX, []uuid.UUID{tx1, tx2})
+	})
+	require.NoError(t, err)
+	require.NoError(t, m.persistence.Mock.ExpectationsWereMet())
+}
+
+func TestSubmitExternalCallsNone(t *testing.T) {
+	ctx := context.Background()
+	s, m := newSyncPointsForTesting(t)
+
+	m.persistence.Mock.ExpectBegin()
+	m.persistence.Mock.ExpectQuery("SELECT.*external_calls").WillReturnRows(newExternalCallRows())
+	m.persistence.Mock.ExpectCommit()
+
+	err := m.persistence.P.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
+		return s.SubmitExternalCalls(ctx, dbTX, []uuid.UUID{uuid.New()})
+	})
+	require.NoError(t, err)
+}
+
+func TestSubmitExternalCallsErrors(t *testing.T) {
+	ctx := context.Background()
+	txID := uuid.New()
+
+	for _, tc := range []struct {
+		name  string
+		setup func(m *dependencyMocks)
+		err   string
+	}{
+		{
+			name: "query",
+			setup: func(m *dependencyMocks) {
+				m.persistence.Mock.ExpectQuery("SELECT.*external_calls").WillReturnError(fmt.Errorf("pop"))
+			},
+			err: "pop",
+		},
+		{
+			name: "bad call",
+			setup: func(m *dependencyMocks) {
+				m.persistence.Mock.ExpectQuery("SELECT.*external_calls").WillReturnRows(
+					testExternalCallRow(newExternalCallRows(), "a", txID, 0, "!!!wrong"))
+			},
+			err: "invalid",
+		},
+		{
+			name: "send",
+			setup: func(m *dependencyMocks) {
+				m.persistence.Mock.ExpectQuery("SELECT.*external_calls").WillReturnRows(
+					testExternalCallRow(newExternalCallRows(), "a", txID, 0, "{}"))
+				m.txMgr.On("SendTransactions", mock.Anything, mock.Anything, mock.Anything).Return(nil, fmt.Errorf("pop"))
+			},
+			err: "pop",
+		},
+		{
+			name: "update",
+			setup: func(m *dependencyMocks) {
+				m.persistence.Mock.ExpectQuery("SELECT.*external_calls").WillReturnRows(
+					testExternalCallRow(newExternalCallRows(), "a", txID, 0, "{}"))
+				m.txMgr.On("SendTransactions", mock.Anything, mock.Anything, mock.Anything).Return([]uuid.UUID{uuid.New()}, nil)
+				m.persistence.Mock.ExpectExec("UPDATE.*external_calls").WillReturnError(fmt.Errorf("pop"))
+			},
+			err: "pop",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			s, m := newSyncPointsForTesting(t)
+			m.persistence.Mock.ExpectBegin()
+			tc.setup(m)
+			m.persistence.Mock.ExpectRollback()
+
+			err := m.persistence.P.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
+				return s.SubmitExternalCalls(ctx, dbTX, []uuid.UUID{txID})
+			})
+			assert.Regexp(t, tc.err, err)
+		})
+	}
+}
@@ -60,6 +60,11 @@ type SyncPoints interface {
 	// the onCommit and onRollback callbacks are called, on a separate goroutine when the transaction is committed or rolled back
 	QueueTransactionFinalize(ctx context.Context, domain string, contractAddress pldtypes.EthAddress, transactionID uuid.UUID, failureMessage string, onCommit func(context.Context), onRollback func(context.Context, error))
 
+	// SubmitExternalCalls submits any external calls that were written with the dispatch of the given transactions,
+	// and have not already been submitted. Unlike the other syncpoints, this runs synchronously on the DB transaction
+	// of the caller, as it must be atomic with the processing of the confirmation of those transactions
+	SubmitExternalCalls(ctx context.Context, dbTX persistence.DBTX, txIDs []uuid.UUID) error
+
 	Close()
 }
 
@@ -28,6 +28,8 @@ import (
 	"github.com/kaleido-io/paladin/core/internal/privatetxnmgr/syncpoints"
 
 	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/retry"
 	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
 )
 
@@ -44,6 +46,8 @@ func NewTransactionFlow(
 	syncPoints syncpoints.SyncPoints,
 	transportWriter ptmgrtypes.TransportWriter,
 	requestTimeout time.Duration,
+	failoverTimeout time.Duration,
+	reassembleRetry *retry.Retry,
 	selectCoordinator ptmgrtypes.CoordinatorSelector,
 	assembleCoordinator ptmgrtypes.AssembleCoordinator,
 	environment ptmgrtypes.SequencerEnvironment,
@@ -67,12 +71,15 @@ func NewTransactionFlow(
 		requestedVerifierResolution: false,
 		requestedSignatures:         false,
 		pendingEndorsementRequests:  make(map[string]map[string]*endorsementRequest),
+		revokedCoordinators:         make(map[string]bool),
 		complete:                    false,
 		localCoordinator:            true,
 		dispatched:                  false,
 		prepared:                    false,
 		clock:                       ptmgrtypes.RealClock(),
 		requestTimeout:              requestTimeout,
+		failoverTimeout:             failoverTimeout,
+		reassembleRetry:             reassembleRetry,
 		selectCoordinator:           selectCoordinator,
 		assembleCoordinator:         assembleCoordinator,
 		environment:                 environment,
@@ -109,6 +116,10 @@ type transactionFlow struct {
 	delegateRequestBlockHeight  int64
 	delegated                   bool
 	delegateRequestTimer        *time.Timer
+	delegateNode                string          // the node we have delegated coordination to
+	delegateNodeLastContact     time.Time       // when we first delegated to the node, or last heard from it - whichever is later
+	delegateDispatched          bool            // the node we delegated to has dispatched the transaction, so we must not fail over from it
+	revokedCoordinators         map[string]bool // coordinators we have failed over from, and whether they have been sent a revocation
 	assemblePending             bool
 	complete                    bool
 	requestedVerifierResolution bool                                      //TODO add precision here so that we can track individual requests and implement retry as per endorsement
@@ -119,6 +130,11 @@ type transactionFlow struct {
 	prepared                    bool
 	clock                       ptmgrtypes.Clock
 	requestTimeout              time.Duration
+	failoverTimeout             time.Duration
+	reassembleRetry             *retry.Retry
+	reassembleAttempts          int       // number of times we have re-assembled, because an endorser rejected the previous assembly
+	reassembleNotBefore         time.Time // backoff before the next re-assembly
+	reassembleTimer             *time.Timer
 	selectCoordinator           ptmgrtypes.CoordinatorSelector
 	assembleCoordinator         ptmgrtypes.AssembleCoordinator
 	environment                 ptmgrtypes.SequencerEnvironment
@@ -146,6 +162,26 @@ func (tf *transactionFlow) CoordinatingLocally(_ context.Context) bool {
 	return tf.localCoordinator
 }
 
+// The node that delegated this transaction to us, if we are coordinating it on behalf of another node
+func (tf *transactionFlow) DelegatingNode(ctx context.Context) string {
+	if !tf.localCoordinator || tf.complete || tf.transaction.PreAssembly == nil || tf.transaction.PreAssembly.TransactionSpecification == nil {
+		return ""
+	}
+	node, err := pldtypes.PrivateIdentityLocator(tf.transaction.PreAssembly.TransactionSpecification.From).Node(ctx, true)
+	if err != nil || node == tf.nodeName {
+		return ""
+	}
+	return node
+}
+
+// Whether we have failed over from the given coordinator. Called off the sequencer event loop, when the coordinator asks us to assemble
+func (tf *transactionFlow) HasFailedOverFrom(_ context.Context, node string) bool {
+	tf.statusLock.RLock()
+	defer tf.statusLock.RUnlock()
+	_, failedOver := tf.revokedCoordinators[node]
+	return failedOver
+}
+
 func (tf *transactionFlow) PrepareTransaction(ctx context.Context, defaultSigner string) (*components.PrivateTransaction, error) {
 
 	if tf.transaction.Signer == "" {
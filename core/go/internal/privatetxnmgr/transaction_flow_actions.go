@@ -17,7 +17,9 @@ package privatetxnmgr
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math/rand/v2"
 	"time"
 
 	"github.com/google/uuid"
@@ -107,6 +109,11 @@ func (tf *transactionFlow) Action(ctx context.Context) {
 			return
 		}
 
+		if tf.clock.Now().Before(tf.reassembleNotBefore) {
+			tf.logActionInfof(ctx, "Transaction not ready to re-assemble. Waiting until %s", tf.reassembleNotBefore)
+			return
+		}
+
 		tf.requestAssemble(ctx)
 		if tf.transaction.PostAssembly == nil {
 			tf.logActionInfo(ctx, "Transaction not assembled. Waiting for assembler to return")
@@ -239,6 +246,31 @@ func (tf *transactionFlow) setTransactionSigner(ctx context.Context) (reDelegate
 	return false, nil
 }
 
+// An endorser rejecting the assembled transaction is usually the result of contention, such as another transaction
+// spending one of the input states first. So we re-assemble with a fresh selection of states, after a jittered backoff
+// so that the contending coordinators are less likely to collide again. The transaction is only reverted once the
+// retry budget is used up.
+func (tf *transactionFlow) scheduleReassemble(ctx context.Context, rejectReason string) {
+	tf.reassembleAttempts++
+	maxAttempts := tf.reassembleRetry.MaxAttempts()
+	if maxAttempts > 0 && tf.reassembleAttempts > maxAttempts {
+		tf.revertTransaction(ctx, i18n.ExpandWithCode(ctx, i18n.MessageKey(msgs.MsgPrivateTxManagerReassembleExhausted), maxAttempts, rejectReason))
+		return
+	}
+
+	// wait for at least half of the backoff delay, plus a random share of the other half
+	delay := tf.reassembleRetry.Delay(tf.reassembleAttempts)
+	delay = delay/2 + rand.N(delay/2+1)
+	log.L(ctx).Infof("Re-assembling transaction %s in %s (attempt=%d): %s", tf.transaction.ID.String(), delay, tf.reassembleAttempts, rejectReason)
+	tf.reassembleNotBefore = tf.clock.Now().Add(delay)
+	if tf.reassembleTimer != nil {
+		tf.reassembleTimer.Stop()
+	}
+	tf.reassembleTimer = time.AfterFunc(delay, func() {
+		tf.publisher.PublishNudgeEvent(ctx, tf.transaction.ID.String())
+	})
+}
+
 func (tf *transactionFlow) revertTransaction(ctx context.Context, revertReason string) {
 	log.L(ctx).Errorf("Reverting transaction %s: %s", tf.transaction.ID.String(), revertReason)
 	//trigger a finalize and update the transaction state so that finalize can be retried if it fails
@@ -292,6 +324,11 @@ func (tf *transactionFlow) finalize(ctx context.Context) {
 
 func (tf *transactionFlow) delegateIfRequired(ctx context.Context) (doContinue bool) {
 
+	if (tf.delegatePending || tf.delegated) && !tf.delegateDispatched && tf.clock.Now().After(tf.delegateNodeLastContact.Add(tf.failoverTimeout)) {
+		tf.failoverCoordinator(ctx)
+	}
+	tf.revokeCoordinators(ctx)
+
 	if tf.delegatePending {
 		tf.logActionInfof(ctx, "Transaction is delegating since %s (block=%d)", tf.delegateRequestTime, tf.delegateRequestBlockHeight)
 		if tf.clock.Now().Before(tf.delegateRequestTime.Add(tf.requestTimeout)) {
@@ -311,6 +348,12 @@ func (tf *transactionFlow) delegateIfRequired(ctx context.Context) (doContinue b
 	// There may be a potential optimization we can add where, in certain domain configurations, we can optimistically proceed without delegation and only delegate once we detect
 	// potential contention with other active nodes.  For now, we keep it simple and strictly abide by the configuration of the domain
 	blockHeight, coordinatorNode, err := tf.selectCoordinator.SelectCoordinatorNode(ctx, tf.transaction, tf.environment)
+	var pdErr i18n.PDError
+	if errors.As(err, &pdErr) && pdErr.MessageKey() == msgs.MsgPrivateTxManagerOrderingNodeNotEndorser {
+		// the configured ordering nodes cannot coordinate this transaction, however it is assembled
+		tf.revertTransaction(ctx, err.Error())
+		return false
+	}
 	if err != nil {
 		// errors from here are most likely a problem resolving the node name from the parties in the attestation plan
 		// so there is no point retrying although if we redo the assemble stage, we may get a different result
@@ -345,12 +388,21 @@ func (tf *transactionFlow) delegateIfRequired(ctx context.Context) (doContinue b
 
 	delegationRequestID := uuid.New().String()
 
+	if coordinatorNode != tf.delegateNode {
+		// the failover timer starts from the first request to each coordinator, not from each retry
+		tf.delegateNode = coordinatorNode
+		tf.delegateNodeLastContact = tf.clock.Now()
+		// we might be going back to a node we failed over from in the past, in which case it is no longer revoked
+		delete(tf.revokedCoordinators, coordinatorNode)
+	}
+
 	err = tf.transportWriter.SendDelegationRequest(
 		ctx,
 		delegationRequestID,
 		coordinatorNode,
 		tf.transaction,
 		blockHeight,
+		tf.selectCoordinator.UnavailableCoordinators(ctx),
 	)
 	if err != nil {
 		tf.latestError = i18n.ExpandWithCode(ctx, i18n.MessageKey(msgs.MsgPrivateTxManagerInternalError), err.Error())
@@ -369,6 +421,57 @@ func (tf *transactionFlow) delegateIfRequired(ctx context.Context) (doContinue b
 
 }
 
+// We have not heard from the coordinator we delegated to, by acknowledgment, heartbeat or assemble request, within the
+// failover timeout. If the coordinator selection policy allows another node to take over, we mark it as unavailable and go
+// back through coordinator selection, which deterministically picks the next candidate (possibly this node).
+//
+// The old coordinator might only be slow (or partitioned from us but not from the base ledger), so:
+//   - we revoke the delegation, so it drops the transaction if it has not already dispatched it
+//   - we refuse any further assemble requests from it
+//   - we keep the assembly (and the state locks in our domain context) if we have one, so the new coordinator dispatches
+//     exactly the same state transition. If both end up dispatching, the base ledger rejects whichever arrives second,
+//     as its input states have been spent and its output states already exist
+func (tf *transactionFlow) failoverCoordinator(ctx context.Context) {
+	if !tf.selectCoordinator.CoordinatorUnavailable(ctx, tf.delegateNode) {
+		// there is no other node that can coordinate this transaction, so we keep waiting for this one
+		tf.delegateNodeLastContact = tf.clock.Now()
+		return
+	}
+	failoverError := i18n.NewError(ctx, msgs.MsgPrivateTxManagerCoordinatorFailover, tf.delegateNode, tf.failoverTimeout)
+	tf.logActionError(ctx, "Coordinator failover", failoverError)
+	tf.latestError = failoverError.Error()
+
+	tf.revokedCoordinators[tf.delegateNode] = false
+	tf.status = "new"
+	tf.delegated = false
+	tf.delegatePending = false
+	tf.pendingDelegationRequestID = ""
+	tf.delegateNode = ""
+	tf.localCoordinator = true
+	if tf.delegateRequestTimer != nil {
+		tf.delegateRequestTimer.Stop()
+	}
+	tf.delegateRequestTimer = nil
+
+	// any endorsements were gathered by the old coordinator, so if we coordinate locally we need to ask again
+	tf.pendingEndorsementRequests = make(map[string]map[string]*endorsementRequest)
+}
+
+// Tell the coordinators we have failed over from to drop the transaction. This is best effort, as the coordinator might
+// be down - we send again if it gets back in contact with us, and we refuse to assemble for it in the meantime.
+func (tf *transactionFlow) revokeCoordinators(ctx context.Context) {
+	for node, sent := range tf.revokedCoordinators {
+		if sent {
+			continue
+		}
+		if err := tf.transportWriter.SendDelegationRevocation(ctx, node, tf.transaction.ID.String()); err != nil {
+			tf.logActionError(ctx, fmt.Sprintf("Failed to revoke delegation to %s", node), err)
+			continue
+		}
+		tf.revokedCoordinators[node] = true
+	}
+}
+
 func (tf *transactionFlow) writeAndLockStates(ctx context.Context) {
 	//this needs to be carefully coordinated with the assemble requester thread and the sequencer event loop thread
 	// we are accessing the transactionFlow's PrivateTransaction object which is only safe to do on the sequencer thread
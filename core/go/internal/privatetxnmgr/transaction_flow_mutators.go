@@ -17,6 +17,7 @@ package privatetxnmgr
 
 import (
 	"context"
+	"time"
 
 	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
 	"github.com/kaleido-io/paladin/common/go/pkg/log"
@@ -53,6 +54,10 @@ func (tf *transactionFlow) ApplyEvent(ctx context.Context, event ptmgrtypes.Priv
 		tf.applyTransactionRevertedEvent(ctx, event)
 	case *ptmgrtypes.TransactionDelegationAcknowledgedEvent:
 		tf.applyTransactionDelegationAcknowledgedEvent(ctx, event)
+	case *ptmgrtypes.DelegationHeartbeatEvent:
+		tf.applyDelegationHeartbeatEvent(ctx, event)
+	case *ptmgrtypes.DelegationRevokedEvent:
+		tf.applyDelegationRevokedEvent(ctx, event)
 	case *ptmgrtypes.ResolveVerifierResponseEvent:
 		tf.applyResolveVerifierResponseEvent(ctx, event)
 	case *ptmgrtypes.ResolveVerifierErrorEvent:
@@ -82,7 +87,11 @@ func (tf *transactionFlow) applyTransactionSwappedInEvent(ctx context.Context, _
 	log.L(ctx).Debug("transactionFlow:applyTransactionSwappedInEvent")
 
 	tf.latestEvent = "TransactionSwappedInEvent"
-
+	if tf.transaction.PostAssembly != nil && tf.status == "new" {
+		// a delegating node has failed over to us, with an assembly that it already gave to another coordinator
+		tf.status = "assembled"
+		tf.writeAndLockStates(ctx)
+	}
 }
 
 func (tf *transactionFlow) applyTransactionAssembledEvent(ctx context.Context, event *ptmgrtypes.TransactionAssembledEvent) {
@@ -98,7 +107,14 @@ func (tf *transactionFlow) applyTransactionAssembledEvent(ctx context.Context, e
 		if event.PostAssembly.RevertReason != nil {
 			revertReason = *event.PostAssembly.RevertReason
 		}
-		tf.revertTransaction(ctx, i18n.ExpandWithCode(ctx, i18n.MessageKey(msgs.MsgPrivateTxManagerAssembleRevert), revertReason))
+		if tf.reassembleAttempts > 0 {
+			// the states available to the assembler may still be in flux after the contention that
+			// caused the previous assembly to be rejected, so this counts against the re-assemble budget
+			tf.transaction.PostAssembly = nil
+			tf.scheduleReassemble(ctx, revertReason)
+		} else {
+			tf.revertTransaction(ctx, i18n.ExpandWithCode(ctx, i18n.MessageKey(msgs.MsgPrivateTxManagerAssembleRevert), revertReason))
+		}
 		tf.assembleCoordinator.Complete(event.AssembleRequestID)
 		return
 	}
@@ -159,16 +175,15 @@ func (tf *transactionFlow) applyTransactionEndorsedEvent(ctx context.Context, ev
 
 	if event.RevertReason != nil {
 		log.L(ctx).Infof("Endorsement for transaction %s was rejected: %s", tf.transaction.ID.String(), *event.RevertReason)
-		// endorsement errors trigger a re-assemble
-		// if the reason for the endorsement error is a change of state of the universe since the transaction was assembled, then the re-assemble may fail and cause the transaction to be reverted
-		// on the other hand, the re-assemble may result in an endorsable version of the transaction.
-		// either way, we trigger the re-assembly and hope for the best
+		// endorsement errors trigger a re-assemble, up to the limit of the re-assemble retry budget
+		// if the reason for the endorsement error is a change of state of the universe since the transaction was assembled, then the re-assemble may result in an endorsable version of the transaction.
 		//TODO - there may be other endorsements that are en route, based on the previous assembly.  Need to make sure that
 		// we discard them when they do return.
 		//only apply at this stage, action will be taken later
 		tf.transaction.PostAssembly = nil
 		// remove all pending endorsement request records because they are no longer valid
 		tf.pendingEndorsementRequests = make(map[string]map[string]*endorsementRequest)
+		tf.scheduleReassemble(ctx, *event.RevertReason)
 
 	} else {
 		log.L(ctx).Infof("Adding endorsement from %s to transaction %s", event.Endorsement.Verifier.Lookup, tf.transaction.ID.String())
@@ -214,10 +229,91 @@ func (tf *transactionFlow) applyTransactionDelegationAcknowledgedEvent(ctx conte
 	tf.status = "delegated"
 	tf.delegated = true
 	tf.delegatePending = false
+	tf.delegateNodeLastContact = tf.clock.Now()
+	tf.scheduleFailoverCheck(ctx)
+}
+
+func (tf *transactionFlow) applyDelegationHeartbeatEvent(ctx context.Context, event *ptmgrtypes.DelegationHeartbeatEvent) {
+	log.L(ctx).Debugf("transactionFlow:applyDelegationHeartbeatEvent transactionID:%s delegate:%s dispatched:%t", tf.transaction.ID.String(), event.DelegateNodeID, event.Dispatched)
+	tf.latestEvent = "DelegationHeartbeatEvent"
+	if _, failedOver := tf.revokedCoordinators[event.DelegateNodeID]; failedOver {
+		if event.Dispatched && !tf.dispatched && !tf.delegateDispatched {
+			// The coordinator we failed over from was only slow, and got there first. We go back to it, and revoke the
+			// delegation to the new coordinator (or stop coordinating locally) - if the new coordinator has also dispatched, the
+			// base ledger rejects the duplicate as it is the same assembly
+			log.L(ctx).Warnf("Transaction %s was dispatched by coordinator %s after we failed over from it", tf.transaction.ID.String(), event.DelegateNodeID)
+			if tf.delegateNode != "" {
+				tf.revokedCoordinators[tf.delegateNode] = false
+			}
+			delete(tf.revokedCoordinators, event.DelegateNodeID)
+			tf.delegateNode = event.DelegateNodeID
+			tf.delegateNodeLastContact = tf.clock.Now()
+			tf.delegateDispatched = true
+			tf.status = "delegated"
+			tf.delegated = true
+			tf.delegatePending = false
+			tf.localCoordinator = false
+			tf.scheduleFailoverCheck(ctx)
+			return
+		}
+		// it has not had our revocation, so we send it again
+		log.L(ctx).Warnf("Received heartbeat for transaction %s from coordinator %s, which we have failed over from", tf.transaction.ID.String(), event.DelegateNodeID)
+		tf.revokedCoordinators[event.DelegateNodeID] = false
+		return
+	}
+	if event.DelegateNodeID != tf.delegateNode || !(tf.delegatePending || tf.delegated) {
+		log.L(ctx).Warnf("Received heartbeat for transaction %s from %s, which is not the coordinator", tf.transaction.ID.String(), event.DelegateNodeID)
+		return
+	}
+	if tf.delegatePending {
+		// the acknowledgment might have been lost, but the coordinator has the transaction
+		tf.status = "delegated"
+		tf.delegated = true
+		tf.delegatePending = false
+	}
+	tf.delegateNodeLastContact = tf.clock.Now()
+	tf.delegateDispatched = tf.delegateDispatched || event.Dispatched
+	tf.scheduleFailoverCheck(ctx)
+}
+
+// If we do not hear from the coordinator again before the failover timeout, we need to wake up and fail over.
+// Once the coordinator has dispatched the transaction, we wait for it to be confirmed however long that takes.
+func (tf *transactionFlow) scheduleFailoverCheck(ctx context.Context) {
 	if tf.delegateRequestTimer != nil {
 		tf.delegateRequestTimer.Stop()
 	}
 	tf.delegateRequestTimer = nil
+	if tf.delegateDispatched {
+		return
+	}
+	tf.delegateRequestTimer = time.AfterFunc(tf.failoverTimeout, func() {
+		tf.publisher.PublishNudgeEvent(ctx, tf.transaction.ID.String())
+	})
+}
+
+func (tf *transactionFlow) applyDelegationRevokedEvent(ctx context.Context, event *ptmgrtypes.DelegationRevokedEvent) {
+	log.L(ctx).Debugf("transactionFlow:applyDelegationRevokedEvent transactionID:%s delegatingNode:%s", tf.transaction.ID.String(), event.DelegatingNodeID)
+	tf.latestEvent = "DelegationRevokedEvent"
+	if event.DelegatingNodeID == "" || event.DelegatingNodeID != tf.DelegatingNode(ctx) {
+		log.L(ctx).Warnf("Ignoring revocation of transaction %s from %s, which did not delegate it to us", tf.transaction.ID.String(), event.DelegatingNodeID)
+		return
+	}
+	if tf.dispatched || tf.prepared {
+		// too late - our heartbeats tell the delegating node that we have dispatched it
+		log.L(ctx).Warnf("Ignoring revocation of transaction %s, which is already dispatched", tf.transaction.ID.String())
+		return
+	}
+	log.L(ctx).Infof("Delegation of transaction %s revoked by %s", tf.transaction.ID.String(), event.DelegatingNodeID)
+	tf.status = "revoked"
+	tf.complete = true
+	if tf.delegateRequestTimer != nil {
+		tf.delegateRequestTimer.Stop()
+	}
+	if tf.reassembleTimer != nil {
+		tf.reassembleTimer.Stop()
+	}
+	// release any states we wrote and locked for the transaction
+	tf.domainContext.ResetTransactions(tf.transaction.ID)
 }
 
 func (tf *transactionFlow) applyResolveVerifierResponseEvent(ctx context.Context, event *ptmgrtypes.ResolveVerifierResponseEvent) {
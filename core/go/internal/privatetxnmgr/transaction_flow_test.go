@@ -17,17 +17,23 @@ package privatetxnmgr
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
 	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
 	"github.com/kaleido-io/paladin/core/internal/components"
+	"github.com/kaleido-io/paladin/core/internal/msgs"
 	"github.com/kaleido-io/paladin/core/internal/privatetxnmgr/ptmgrtypes"
 	"github.com/kaleido-io/paladin/core/mocks/componentmocks"
 	"github.com/kaleido-io/paladin/core/mocks/privatetxnmgrmocks"
 	"github.com/kaleido-io/paladin/core/mocks/prvtxsyncpointsmocks"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/retry"
 	"github.com/kaleido-io/paladin/toolkit/pkg/algorithms"
 	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
 	"github.com/kaleido-io/paladin/toolkit/pkg/signpayloads"
@@ -81,6 +87,8 @@ func newTransactionFlowForTesting(t *testing.T, ctx context.Context, transaction
 	mocks.allComponents.On("KeyManager").Return(mocks.keyManager).Maybe()
 	mocks.endorsementGatherer.On("DomainContext").Return(mocks.domainContext).Maybe()
 	mocks.domainSmartContract.On("Address").Return(*contractAddress).Maybe()
+	// timers wake the transaction flow up asynchronously, for example to re-assemble after a backoff
+	mocks.publisher.On("PublishNudgeEvent", mock.Anything, mock.Anything).Maybe()
 	mocks.domainSmartContract.On("ContractConfig").Return(&prototk.ContractConfig{
 		CoordinatorSelection: prototk.ContractConfig_COORDINATOR_ENDORSER,
 	}).Maybe()
@@ -91,7 +99,7 @@ func newTransactionFlowForTesting(t *testing.T, ctx context.Context, transaction
 
 	assembleCoordinator := NewAssembleCoordinator(ctx, nodeName, 1, mocks.allComponents, mocks.domainSmartContract, mocks.domainContext, mocks.transportWriter, *contractAddress, mocks.environment, 1*time.Second, mocks.localAssembler)
 
-	tp := NewTransactionFlow(ctx, transaction, nodeName, mocks.allComponents, mocks.domainSmartContract, mocks.domainContext, mocks.publisher, mocks.endorsementGatherer, mocks.identityResolver, mocks.syncPoints, mocks.transportWriter, 1*time.Minute, mocks.coordinatorSelector, assembleCoordinator, mocks.environment)
+	tp := NewTransactionFlow(ctx, transaction, nodeName, mocks.allComponents, mocks.domainSmartContract, mocks.domainContext, mocks.publisher, mocks.endorsementGatherer, mocks.identityResolver, mocks.syncPoints, mocks.transportWriter, 1*time.Minute, 5*time.Minute, retry.NewRetryLimited(&pldconf.PrivateTxManagerDefaults.Sequencer.ReassembleRetry), mocks.coordinatorSelector, assembleCoordinator, mocks.environment)
 
 	return tp.(*transactionFlow), mocks
 }
@@ -1847,3 +1855,329 @@ type fakeClock struct {
 func (f *fakeClock) Now() time.Time {
 	return time.Now().Add(f.timePassed)
 }
+
+func TestDelegatedCoordinatorFailover(t *testing.T) {
+	ctx := context.Background()
+	testTx := &components.PrivateTransaction{
+		ID: uuid.New(),
+		PreAssembly: &components.TransactionPreAssembly{
+			TransactionSpecification: &prototk.TransactionSpecification{
+				From: "alice@node1",
+			},
+		},
+		PostAssembly: &components.TransactionPostAssembly{},
+	}
+	tp, mocks := newTransactionFlowForTesting(t, ctx, testTx, "node1")
+	fakeClock := &fakeClock{timePassed: 0}
+	tp.clock = fakeClock
+	tp.status = "delegated"
+	tp.delegated = true
+	tp.localCoordinator = false
+	tp.delegateNode = "node2"
+	tp.delegateNodeLastContact = fakeClock.Now()
+
+	// Within the failover timeout we keep waiting for the coordinator
+	assert.False(t, tp.delegateIfRequired(ctx))
+	assert.Equal(t, "delegated", tp.status)
+
+	// After the timeout we fail over to the next coordinator, and tell it which node we failed over from
+	fakeClock.timePassed = 5*time.Minute + 1*time.Second
+	mocks.coordinatorSelector.On("CoordinatorUnavailable", ctx, "node2").Return(true).Once()
+	mocks.coordinatorSelector.On("SelectCoordinatorNode", ctx, testTx, mocks.environment).Return(int64(10), "node3", nil).Once()
+	mocks.coordinatorSelector.On("UnavailableCoordinators", ctx).Return([]string{"node2"}).Once()
+	mocks.transportWriter.On("SendDelegationRequest", ctx, mock.Anything, "node3", testTx, int64(10), []string{"node2"}).Return(nil).Once()
+	mocks.transportWriter.On("SendDelegationRevocation", ctx, "node2", testTx.ID.String()).Return(fmt.Errorf("pop")).Once()
+
+	assert.False(t, tp.delegateIfRequired(ctx))
+	assert.Equal(t, "delegating", tp.status)
+	assert.Equal(t, "node3", tp.delegateNode)
+	assert.True(t, tp.delegatePending)
+	assert.False(t, tp.delegated)
+	// the new coordinator gets the same assembly, in case the old one dispatches it
+	assert.NotNil(t, testTx.PostAssembly)
+	assert.Regexp(t, "PD011839.*node2", tp.latestError)
+	assert.True(t, tp.HasFailedOverFrom(ctx, "node2"))
+	assert.False(t, tp.HasFailedOverFrom(ctx, "node3"))
+	tp.delegateRequestTimer.Stop()
+
+	// The revocation is sent again until it succeeds
+	mocks.transportWriter.On("SendDelegationRevocation", ctx, "node2", testTx.ID.String()).Return(nil).Once()
+	assert.False(t, tp.delegateIfRequired(ctx))
+	assert.True(t, tp.revokedCoordinators["node2"])
+	assert.False(t, tp.delegateIfRequired(ctx))
+}
+
+func TestDelegationHeartbeatRefreshesLastContact(t *testing.T) {
+	ctx := context.Background()
+	testTx := &components.PrivateTransaction{
+		ID: uuid.New(),
+		PreAssembly: &components.TransactionPreAssembly{
+			TransactionSpecification: &prototk.TransactionSpecification{
+				From: "alice@node1",
+			},
+		},
+		PostAssembly: &components.TransactionPostAssembly{},
+	}
+	tp, _ := newTransactionFlowForTesting(t, ctx, testTx, "node1")
+	fakeClock := &fakeClock{timePassed: 0}
+	tp.clock = fakeClock
+	tp.status = "delegating"
+	tp.delegatePending = true
+	tp.localCoordinator = false
+	tp.delegateNode = "node2"
+	tp.delegateNodeLastContact = fakeClock.Now()
+	tp.delegateRequestTime = fakeClock.Now()
+
+	// A heartbeat from the coordinator counts as an acknowledgment if we missed that, and resets the failover timer
+	fakeClock.timePassed = 4 * time.Minute
+	tp.ApplyEvent(ctx, &ptmgrtypes.DelegationHeartbeatEvent{
+		PrivateTransactionEventBase: ptmgrtypes.PrivateTransactionEventBase{TransactionID: testTx.ID.String()},
+		DelegateNodeID:              "node2",
+	})
+	assert.Equal(t, "delegated", tp.status)
+	assert.True(t, tp.delegated)
+	assert.False(t, tp.delegatePending)
+	require.NotNil(t, tp.delegateRequestTimer)
+
+	fakeClock.timePassed = 8 * time.Minute
+	assert.False(t, tp.delegateIfRequired(ctx))
+	assert.Equal(t, "delegated", tp.status)
+	assert.Equal(t, "node2", tp.delegateNode)
+
+	// Heartbeats from other nodes do not count
+	lastContact := tp.delegateNodeLastContact
+	tp.ApplyEvent(ctx, &ptmgrtypes.DelegationHeartbeatEvent{
+		PrivateTransactionEventBase: ptmgrtypes.PrivateTransactionEventBase{TransactionID: testTx.ID.String()},
+		DelegateNodeID:              "node3",
+	})
+	assert.Equal(t, lastContact, tp.delegateNodeLastContact)
+
+	// Once the coordinator has dispatched the transaction, we never fail over from it
+	tp.ApplyEvent(ctx, &ptmgrtypes.DelegationHeartbeatEvent{
+		PrivateTransactionEventBase: ptmgrtypes.PrivateTransactionEventBase{TransactionID: testTx.ID.String()},
+		DelegateNodeID:              "node2",
+		Dispatched:                  true,
+	})
+	assert.True(t, tp.delegateDispatched)
+	assert.Nil(t, tp.delegateRequestTimer)
+	fakeClock.timePassed = 1 * time.Hour
+	assert.False(t, tp.delegateIfRequired(ctx))
+	assert.Equal(t, "delegated", tp.status)
+}
+
+func TestDelegationHeartbeatFromFailedOverCoordinator(t *testing.T) {
+	ctx := context.Background()
+	testTx := &components.PrivateTransaction{
+		ID: uuid.New(),
+		PreAssembly: &components.TransactionPreAssembly{
+			TransactionSpecification: &prototk.TransactionSpecification{
+				From: "alice@node1",
+			},
+		},
+		PostAssembly: &components.TransactionPostAssembly{},
+	}
+	tp, mocks := newTransactionFlowForTesting(t, ctx, testTx, "node1")
+	fakeClock := &fakeClock{timePassed: 0}
+	tp.clock = fakeClock
+	tp.status = "delegated"
+	tp.delegated = true
+	tp.localCoordinator = false
+	tp.delegateNode = "node3"
+	tp.delegateNodeLastContact = fakeClock.Now()
+	tp.revokedCoordinators["node2"] = true
+
+	// The old coordinator has not had the revocation, so we send it again
+	tp.ApplyEvent(ctx, &ptmgrtypes.DelegationHeartbeatEvent{
+		PrivateTransactionEventBase: ptmgrtypes.PrivateTransactionEventBase{TransactionID: testTx.ID.String()},
+		DelegateNodeID:              "node2",
+	})
+	assert.False(t, tp.revokedCoordinators["node2"])
+	assert.Equal(t, "node3", tp.delegateNode)
+	mocks.transportWriter.On("SendDelegationRevocation", ctx, "node2", testTx.ID.String()).Return(nil).Once()
+	assert.False(t, tp.delegateIfRequired(ctx))
+
+	// The old coordinator got there first, so we go back to it and revoke the new one
+	tp.ApplyEvent(ctx, &ptmgrtypes.DelegationHeartbeatEvent{
+		PrivateTransactionEventBase: ptmgrtypes.PrivateTransactionEventBase{TransactionID: testTx.ID.String()},
+		DelegateNodeID:              "node2",
+		Dispatched:                  true,
+	})
+	assert.Equal(t, "node2", tp.delegateNode)
+	assert.True(t, tp.delegateDispatched)
+	assert.False(t, tp.HasFailedOverFrom(ctx, "node2"))
+	assert.True(t, tp.HasFailedOverFrom(ctx, "node3"))
+	mocks.transportWriter.On("SendDelegationRevocation", ctx, "node3", testTx.ID.String()).Return(nil).Once()
+	fakeClock.timePassed = 1 * time.Hour
+	assert.False(t, tp.delegateIfRequired(ctx))
+	assert.Equal(t, "delegated", tp.status)
+}
+
+func TestDelegationRevoked(t *testing.T) {
+	ctx := context.Background()
+	testTx := &components.PrivateTransaction{
+		ID: uuid.New(),
+		PreAssembly: &components.TransactionPreAssembly{
+			TransactionSpecification: &prototk.TransactionSpecification{
+				From: "alice@node1",
+			},
+		},
+		PostAssembly: &components.TransactionPostAssembly{},
+	}
+	tp, mocks := newTransactionFlowForTesting(t, ctx, testTx, "node2")
+	assert.Equal(t, "node1", tp.DelegatingNode(ctx))
+
+	// Only the node that delegated the transaction to us can revoke it
+	tp.ApplyEvent(ctx, &ptmgrtypes.DelegationRevokedEvent{
+		PrivateTransactionEventBase: ptmgrtypes.PrivateTransactionEventBase{TransactionID: testTx.ID.String()},
+		DelegatingNodeID:            "node3",
+	})
+	assert.False(t, tp.IsComplete(ctx))
+
+	// Once dispatched it is too late
+	tp.dispatched = true
+	tp.ApplyEvent(ctx, &ptmgrtypes.DelegationRevokedEvent{
+		PrivateTransactionEventBase: ptmgrtypes.PrivateTransactionEventBase{TransactionID: testTx.ID.String()},
+		DelegatingNodeID:            "node1",
+	})
+	assert.False(t, tp.IsComplete(ctx))
+
+	tp.dispatched = false
+	mocks.domainContext.On("ResetTransactions", testTx.ID).Once()
+	tp.ApplyEvent(ctx, &ptmgrtypes.DelegationRevokedEvent{
+		PrivateTransactionEventBase: ptmgrtypes.PrivateTransactionEventBase{TransactionID: testTx.ID.String()},
+		DelegatingNodeID:            "node1",
+	})
+	assert.True(t, tp.IsComplete(ctx))
+	assert.Equal(t, "revoked", tp.status)
+	assert.Empty(t, tp.DelegatingNode(ctx))
+}
+
+func TestDelegatedCoordinatorNoFailover(t *testing.T) {
+	ctx := context.Background()
+	testTx := &components.PrivateTransaction{
+		ID: uuid.New(),
+		PreAssembly: &components.TransactionPreAssembly{
+			TransactionSpecification: &prototk.TransactionSpecification{
+				From: "alice@node1",
+			},
+		},
+		PostAssembly: &components.TransactionPostAssembly{},
+	}
+	tp, mocks := newTransactionFlowForTesting(t, ctx, testTx, "node1")
+	fakeClock := &fakeClock{timePassed: 5*time.Minute + 1*time.Second}
+	tp.clock = fakeClock
+	tp.status = "delegated"
+	tp.delegated = true
+	tp.delegateNode = "notary"
+	tp.delegateNodeLastContact = time.Now()
+
+	// A static coordinator has nobody to fail over to, so we keep waiting (and do not re-assemble)
+	mocks.coordinatorSelector.On("CoordinatorUnavailable", ctx, "notary").Return(false).Once()
+	before := fakeClock.Now()
+	assert.False(t, tp.delegateIfRequired(ctx))
+	assert.Equal(t, "delegated", tp.status)
+	assert.NotNil(t, testTx.PostAssembly)
+	assert.False(t, tp.delegateNodeLastContact.Before(before))
+}
+
+func TestEndorsementRejectedReassembleWithBackoff(t *testing.T) {
+	ctx := context.Background()
+	newTxID := uuid.New()
+	testTx := &components.PrivateTransaction{
+		ID: newTxID,
+		PreAssembly: &components.TransactionPreAssembly{
+			TransactionSpecification: &prototk.TransactionSpecification{
+				From:          "alice@node1",
+				TransactionId: newTxID.String(),
+			},
+		},
+		PostAssembly: &components.TransactionPostAssembly{},
+	}
+	tp, mocks := newTransactionFlowForTesting(t, ctx, testTx, "node1")
+	mocks.coordinatorSelector.On("SelectCoordinatorNode", mock.Anything, mock.Anything, mock.Anything).Return(int64(0), "node1", nil)
+	fakeClock := &fakeClock{timePassed: 0}
+	tp.clock = fakeClock
+	tp.reassembleRetry = retry.NewRetryLimited(&pldconf.RetryConfigWithMax{
+		RetryConfig: pldconf.RetryConfig{
+			InitialDelay: confutil.P("1s"),
+			MaxDelay:     confutil.P("1s"),
+		},
+		MaxAttempts: confutil.P(2),
+	})
+	tp.pendingEndorsementRequests = map[string]map[string]*endorsementRequest{
+		"foo": {"bob@node2": {idempotencyKey: "key1", requestTime: fakeClock.Now()}},
+	}
+
+	// The rejected endorsement schedules a re-assemble, after a jittered backoff
+	before := fakeClock.Now()
+	tp.applyTransactionEndorsedEvent(ctx, &ptmgrtypes.TransactionEndorsedEvent{
+		PrivateTransactionEventBase: ptmgrtypes.PrivateTransactionEventBase{
+			TransactionID: newTxID.String(),
+		},
+		Party:                  "bob@node2",
+		AttestationRequestName: "foo",
+		IdempotencyKey:         "key1",
+		RevertReason:           confutil.P("state already spent"),
+	})
+	assert.Nil(t, testTx.PostAssembly)
+	assert.Equal(t, 1, tp.reassembleAttempts)
+	assert.False(t, tp.reassembleNotBefore.Before(before.Add(500*time.Millisecond)))
+	assert.False(t, tp.reassembleNotBefore.After(fakeClock.Now().Add(1*time.Second)))
+
+	tp.Action(ctx)
+	assert.False(t, tp.assemblePending)
+
+	fakeClock.timePassed = 2 * time.Second
+	tp.Action(ctx)
+	assert.True(t, tp.assemblePending)
+
+	// Assembly reverting while we are re-assembling uses up the budget, rather than reverting the transaction
+	tp.applyTransactionAssembledEvent(ctx, &ptmgrtypes.TransactionAssembledEvent{
+		PrivateTransactionEventBase: ptmgrtypes.PrivateTransactionEventBase{
+			TransactionID: newTxID.String(),
+		},
+		PostAssembly: &components.TransactionPostAssembly{
+			AssemblyResult: prototk.AssembleTransactionResponse_REVERT,
+			RevertReason:   confutil.P("insufficient funds"),
+		},
+		AssembleRequestID: "request1",
+	})
+	assert.Nil(t, testTx.PostAssembly)
+	assert.False(t, tp.assemblePending)
+	assert.Equal(t, 2, tp.reassembleAttempts)
+	assert.False(t, tp.finalizeRequired)
+
+	// Once the budget is exhausted, the transaction reverts with a distinct reason
+	mocks.syncPoints.On("QueueTransactionFinalize", ctx, mock.Anything, mock.Anything, newTxID, mock.MatchedBy(func(reason string) bool {
+		return strings.HasPrefix(reason, "PD011840") && strings.HasSuffix(reason, "state spent again")
+	}), mock.Anything, mock.Anything).Once()
+	tp.scheduleReassemble(ctx, "state spent again")
+	assert.True(t, tp.finalizeRequired)
+	tp.reassembleTimer.Stop()
+}
+
+func TestOrderingNodeNotEndorserReverts(t *testing.T) {
+	ctx := context.Background()
+	newTxID := uuid.New()
+	testTx := &components.PrivateTransaction{
+		ID: newTxID,
+		PreAssembly: &components.TransactionPreAssembly{
+			TransactionSpecification: &prototk.TransactionSpecification{
+				From:          "alice@node1",
+				TransactionId: newTxID.String(),
+			},
+		},
+		PostAssembly: &components.TransactionPostAssembly{},
+	}
+	tp, mocks := newTransactionFlowForTesting(t, ctx, testTx, "node1")
+
+	// Re-assembly would not help, so the transaction is reverted with the reason
+	mocks.coordinatorSelector.On("SelectCoordinatorNode", ctx, testTx, mocks.environment).
+		Return(int64(-1), "", i18n.NewError(ctx, msgs.MsgPrivateTxManagerOrderingNodeNotEndorser, "node1", newTxID, []string{"node2"}))
+	mocks.syncPoints.On("QueueTransactionFinalize", ctx, mock.Anything, mock.Anything, newTxID, mock.MatchedBy(func(reason string) bool {
+		return strings.HasPrefix(reason, "PD011842")
+	}), mock.Anything, mock.Anything).Once()
+	assert.False(t, tp.delegateIfRequired(ctx))
+	assert.True(t, tp.finalizeRequired)
+	assert.NotNil(t, testTx.PostAssembly)
+}
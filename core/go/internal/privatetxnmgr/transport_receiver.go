@@ -33,12 +33,20 @@ func (p *privateTxManager) HandlePaladinMsg(ctx context.Context, message *compon
 	switch message.MessageType {
 	case "EndorsementRequest":
 		go p.handleEndorsementRequest(p.ctx, messagePayload, fromNode)
+	case "EndorsementRequestBatch":
+		go p.handleEndorsementRequestBatch(p.ctx, messagePayload, fromNode)
 	case "EndorsementResponse":
 		go p.handleEndorsementResponse(p.ctx, messagePayload)
+	case "EndorsementResponseBatch":
+		go p.handleEndorsementResponseBatch(p.ctx, messagePayload)
 	case "DelegationRequest":
 		go p.handleDelegationRequest(p.ctx, messagePayload, fromNode)
 	case "DelegationRequestAcknowledgment":
 		go p.handleDelegationRequestAcknowledgment(p.ctx, messagePayload)
+	case "DelegationHeartbeat":
+		go p.handleDelegationHeartbeat(p.ctx, messagePayload, fromNode)
+	case "DelegationRevocation":
+		go p.handleDelegationRevocation(p.ctx, messagePayload, fromNode)
 	case "AssembleRequest":
 		go p.handleAssembleRequest(p.ctx, messagePayload, fromNode)
 	case "AssembleResponse":
@@ -18,9 +18,13 @@ package privatetxnmgr
 import (
 	"context"
 	"encoding/json"
+	"sync"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
 	"github.com/kaleido-io/paladin/core/internal/components"
 	engineProto "github.com/kaleido-io/paladin/core/pkg/proto/engine"
 	pb "github.com/kaleido-io/paladin/core/pkg/proto/engine"
@@ -30,20 +34,34 @@ import (
 	"google.golang.org/protobuf/types/known/anypb"
 )
 
-func NewTransportWriter(domainName string, contractAddress *pldtypes.EthAddress, nodeID string, transportManager components.TransportManager) *transportWriter {
+func NewTransportWriter(domainName string, contractAddress *pldtypes.EthAddress, nodeID string, transportManager components.TransportManager, sequencerConfig *pldconf.PrivateTxManagerSequencerConfig) *transportWriter {
 	return &transportWriter{
-		nodeID:           nodeID,
-		transportManager: transportManager,
-		domainName:       domainName,
-		contractAddress:  contractAddress,
+		nodeID:                  nodeID,
+		transportManager:        transportManager,
+		domainName:              domainName,
+		contractAddress:         contractAddress,
+		endorsementBatchMaxSize: confutil.IntMin(sequencerConfig.EndorsementBatchMaxSize, 1, *pldconf.PrivateTxManagerDefaults.Sequencer.EndorsementBatchMaxSize),
+		endorsementBatchTimeout: confutil.DurationMin(sequencerConfig.EndorsementBatchTimeout, 0, *pldconf.PrivateTxManagerDefaults.Sequencer.EndorsementBatchTimeout),
+		endorsementBatches:      make(map[string]*endorsementBatch),
 	}
 }
 
 type transportWriter struct {
-	nodeID           string
-	transportManager components.TransportManager
-	domainName       string
-	contractAddress  *pldtypes.EthAddress
+	nodeID                  string
+	transportManager        components.TransportManager
+	domainName              string
+	contractAddress         *pldtypes.EthAddress
+	endorsementBatchMaxSize int
+	endorsementBatchTimeout time.Duration
+	endorsementBatchLock    sync.Mutex
+	endorsementBatches      map[string]*endorsementBatch // pending batches, by target node
+}
+
+// Endorsement requests for the same node, from any of the transactions in flight for the contract, are collected
+// into a batch until either the batch is full or the batch timeout expires
+type endorsementBatch struct {
+	requests []*engineProto.EndorsementRequest
+	timer    *time.Timer
 }
 
 func (tw *transportWriter) SendDelegationRequest(
@@ -52,6 +70,7 @@ func (tw *transportWriter) SendDelegationRequest(
 	delegateNodeId string,
 	transaction *components.PrivateTransaction,
 	blockHeight int64,
+	unavailableCoordinators []string,
 ) error {
 
 	transactionBytes, err := json.Marshal(transaction)
@@ -61,11 +80,12 @@ func (tw *transportWriter) SendDelegationRequest(
 		return err
 	}
 	delegationRequest := &pb.DelegationRequest{
-		DelegationId:       delegationId,
-		TransactionId:      transaction.ID.String(),
-		DelegateNodeId:     delegateNodeId,
-		PrivateTransaction: transactionBytes,
-		BlockHeight:        blockHeight,
+		DelegationId:            delegationId,
+		TransactionId:           transaction.ID.String(),
+		DelegateNodeId:          delegateNodeId,
+		PrivateTransaction:      transactionBytes,
+		BlockHeight:             blockHeight,
+		UnavailableCoordinators: unavailableCoordinators,
 	}
 	delegationRequestBytes, err := proto.Marshal(delegationRequest)
 	if err != nil {
@@ -116,6 +136,58 @@ func (tw *transportWriter) SendDelegationRequestAcknowledgment(
 	return nil
 }
 
+func (tw *transportWriter) SendDelegationHeartbeat(
+	ctx context.Context,
+	delegatingNodeName string,
+	transactionID string,
+	dispatched bool,
+) error {
+
+	delegationHeartbeat := &pb.DelegationHeartbeat{
+		TransactionId:   transactionID,
+		ContractAddress: tw.contractAddress.String(),
+		DelegateNodeId:  tw.nodeID,
+		Dispatched:      dispatched,
+	}
+	delegationHeartbeatBytes, err := proto.Marshal(delegationHeartbeat)
+	if err != nil {
+		log.L(ctx).Errorf("Error marshalling delegationHeartbeat message: %s", err)
+		return err
+	}
+
+	return tw.transportManager.Send(ctx, &components.FireAndForgetMessageSend{
+		MessageType: "DelegationHeartbeat",
+		Payload:     delegationHeartbeatBytes,
+		Component:   prototk.PaladinMsg_TRANSACTION_ENGINE,
+		Node:        delegatingNodeName,
+	})
+}
+
+func (tw *transportWriter) SendDelegationRevocation(
+	ctx context.Context,
+	delegateNodeName string,
+	transactionID string,
+) error {
+
+	delegationRevocation := &pb.DelegationRevocation{
+		TransactionId:   transactionID,
+		ContractAddress: tw.contractAddress.String(),
+		DelegateNodeId:  delegateNodeName,
+	}
+	delegationRevocationBytes, err := proto.Marshal(delegationRevocation)
+	if err != nil {
+		log.L(ctx).Errorf("Error marshalling delegationRevocation message: %s", err)
+		return err
+	}
+
+	return tw.transportManager.Send(ctx, &components.FireAndForgetMessageSend{
+		MessageType: "DelegationRevocation",
+		Payload:     delegationRevocationBytes,
+		Component:   prototk.PaladinMsg_TRANSACTION_ENGINE,
+		Node:        delegateNodeName,
+	})
+}
+
 // TODO do we have duplication here?  contractAddress and transactionID are in the transactionSpecification
 func (tw *transportWriter) SendEndorsementRequest(ctx context.Context, idempotencyKey string, party string, targetNode string, contractAddress string, transactionID string, attRequest *prototk.AttestationRequest, transactionSpecification *prototk.TransactionSpecification, verifiers []*prototk.ResolvedVerifier, signatures []*prototk.AttestationResult, inputStates []*components.FullState, outputStates []*components.FullState, infoStates []*components.FullState) error {
 	attRequestAny, err := anypb.New(attRequest)
@@ -195,17 +267,72 @@ func (tw *transportWriter) SendEndorsementRequest(ctx context.Context, idempoten
 		InfoStates:               infoStatesAny,
 	}
 
-	endorsementRequestBytes, err := proto.Marshal(endorsementRequest)
+	if tw.endorsementBatchMaxSize <= 1 {
+		return tw.sendEndorsementRequests(ctx, targetNode, []*engineProto.EndorsementRequest{endorsementRequest})
+	}
+	return tw.batchEndorsementRequest(ctx, targetNode, endorsementRequest)
+}
+
+func (tw *transportWriter) batchEndorsementRequest(ctx context.Context, targetNode string, endorsementRequest *engineProto.EndorsementRequest) error {
+	tw.endorsementBatchLock.Lock()
+	batch := tw.endorsementBatches[targetNode]
+	if batch == nil {
+		batch = &endorsementBatch{}
+		tw.endorsementBatches[targetNode] = batch
+		batch.timer = time.AfterFunc(tw.endorsementBatchTimeout, func() {
+			// Errors are logged, and the transaction flow will re-send the request after the request timeout
+			_ = tw.flushEndorsementBatch(ctx, targetNode, batch)
+		})
+	}
+	batch.requests = append(batch.requests, endorsementRequest)
+	full := len(batch.requests) >= tw.endorsementBatchMaxSize
+	tw.endorsementBatchLock.Unlock()
+
+	if full {
+		batch.timer.Stop()
+		return tw.flushEndorsementBatch(ctx, targetNode, batch)
+	}
+	return nil
+}
+
+func (tw *transportWriter) flushEndorsementBatch(ctx context.Context, targetNode string, batch *endorsementBatch) error {
+	tw.endorsementBatchLock.Lock()
+	if tw.endorsementBatches[targetNode] != batch {
+		// already flushed by the timer, or because it filled up
+		tw.endorsementBatchLock.Unlock()
+		return nil
+	}
+	delete(tw.endorsementBatches, targetNode)
+	tw.endorsementBatchLock.Unlock()
+
+	log.L(ctx).Debugf("Sending batch of %d endorsement requests to node %s", len(batch.requests), targetNode)
+	return tw.sendEndorsementRequests(ctx, targetNode, batch.requests)
+}
+
+func (tw *transportWriter) sendEndorsementRequests(ctx context.Context, targetNode string, endorsementRequests []*engineProto.EndorsementRequest) (err error) {
+	messageType := "EndorsementRequest"
+	var payload []byte
+	if len(endorsementRequests) == 1 {
+		payload, err = proto.Marshal(endorsementRequests[0])
+	} else {
+		messageType = "EndorsementRequestBatch"
+		payload, err = proto.Marshal(&engineProto.EndorsementRequestBatch{
+			EndorsementRequests: endorsementRequests,
+		})
+	}
 	if err != nil {
 		log.L(ctx).Error("Error marshalling endorsement request", err)
 		return err
 	}
 	err = tw.transportManager.Send(ctx, &components.FireAndForgetMessageSend{
-		MessageType: "EndorsementRequest",
+		MessageType: messageType,
 		Node:        targetNode,
 		Component:   prototk.PaladinMsg_TRANSACTION_ENGINE,
-		Payload:     endorsementRequestBytes,
+		Payload:     payload,
 	})
+	if err != nil {
+		log.L(ctx).Errorf("Failed to send %d endorsement request(s) to node %s: %s", len(endorsementRequests), targetNode, err)
+	}
 	return err
 }
 
@@ -0,0 +1,128 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package privatetxnmgr
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/internal/components"
+	"github.com/kaleido-io/paladin/core/mocks/componentmocks"
+	engineProto "github.com/kaleido-io/paladin/core/pkg/proto/engine"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func newTransportWriterForTesting(t *testing.T, sequencerConfig *pldconf.PrivateTxManagerSequencerConfig) (*transportWriter, *componentmocks.TransportManager, chan *components.FireAndForgetMessageSend) {
+	tm := componentmocks.NewTransportManager(t)
+	sent := make(chan *components.FireAndForgetMessageSend, 10)
+	tm.On("Send", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		sent <- args[1].(*components.FireAndForgetMessageSend)
+	}).Maybe()
+	return NewTransportWriter("domain1", pldtypes.RandAddress(), "node1", tm, sequencerConfig), tm, sent
+}
+
+func sendTestEndorsementRequest(t *testing.T, tw *transportWriter, targetNode, transactionID string) error {
+	return tw.SendEndorsementRequest(context.Background(),
+		"idem-"+transactionID, "party@"+targetNode, targetNode, tw.contractAddress.String(), transactionID,
+		&prototk.AttestationRequest{Name: "notary"},
+		&prototk.TransactionSpecification{TransactionId: transactionID},
+		nil, nil, nil, nil, nil)
+}
+
+func TestSendEndorsementRequestUnbatched(t *testing.T) {
+	tw, _, sent := newTransportWriterForTesting(t, &pldconf.PrivateTxManagerSequencerConfig{})
+
+	for i := 0; i < 2; i++ {
+		err := sendTestEndorsementRequest(t, tw, "node2", fmt.Sprintf("tx%d", i))
+		require.NoError(t, err)
+		msg := <-sent
+		assert.Equal(t, "EndorsementRequest", msg.MessageType)
+		assert.Equal(t, "node2", msg.Node)
+		req := &engineProto.EndorsementRequest{}
+		require.NoError(t, proto.Unmarshal(msg.Payload, req))
+		assert.Equal(t, fmt.Sprintf("tx%d", i), req.TransactionId)
+	}
+	assert.Empty(t, tw.endorsementBatches)
+}
+
+func TestSendEndorsementRequestBatchFull(t *testing.T) {
+	tw, _, sent := newTransportWriterForTesting(t, &pldconf.PrivateTxManagerSequencerConfig{
+		EndorsementBatchMaxSize: confutil.P(2),
+		EndorsementBatchTimeout: confutil.P("1h"),
+	})
+
+	require.NoError(t, sendTestEndorsementRequest(t, tw, "node2", "tx1"))
+	require.NoError(t, sendTestEndorsementRequest(t, tw, "node3", "tx1"))
+	assert.Empty(t, sent)
+	require.NoError(t, sendTestEndorsementRequest(t, tw, "node2", "tx2"))
+
+	msg := <-sent
+	assert.Equal(t, "EndorsementRequestBatch", msg.MessageType)
+	assert.Equal(t, "node2", msg.Node)
+	batch := &engineProto.EndorsementRequestBatch{}
+	require.NoError(t, proto.Unmarshal(msg.Payload, batch))
+	require.Len(t, batch.EndorsementRequests, 2)
+	assert.Equal(t, "tx1", batch.EndorsementRequests[0].TransactionId)
+	assert.Equal(t, "tx2", batch.EndorsementRequests[1].TransactionId)
+
+	// node3 is still waiting for its batch to fill, or time out
+	assert.Empty(t, sent)
+	assert.Len(t, tw.endorsementBatches, 1)
+	tw.endorsementBatches["node3"].timer.Stop()
+}
+
+func TestSendEndorsementRequestBatchTimeout(t *testing.T) {
+	tw, _, sent := newTransportWriterForTesting(t, &pldconf.PrivateTxManagerSequencerConfig{
+		EndorsementBatchMaxSize: confutil.P(10),
+		EndorsementBatchTimeout: confutil.P("1ms"),
+	})
+
+	require.NoError(t, sendTestEndorsementRequest(t, tw, "node2", "tx1"))
+
+	// A batch of one is sent as a plain endorsement request
+	msg := <-sent
+	assert.Equal(t, "EndorsementRequest", msg.MessageType)
+	req := &engineProto.EndorsementRequest{}
+	require.NoError(t, proto.Unmarshal(msg.Payload, req))
+	assert.Equal(t, "tx1", req.TransactionId)
+}
+
+func TestSendEndorsementRequestBatchSendFail(t *testing.T) {
+	tm := componentmocks.NewTransportManager(t)
+	tm.On("Send", mock.Anything, mock.Anything).Return(fmt.Errorf("pop"))
+	tw := NewTransportWriter("domain1", pldtypes.RandAddress(), "node1", tm, &pldconf.PrivateTxManagerSequencerConfig{
+		EndorsementBatchMaxSize: confutil.P(1),
+	})
+
+	err := sendTestEndorsementRequest(t, tw, "node2", "tx1")
+	assert.Regexp(t, "pop", err)
+}
+
+func TestFlushEndorsementBatchAlreadyFlushed(t *testing.T) {
+	tw, _, sent := newTransportWriterForTesting(t, &pldconf.PrivateTxManagerSequencerConfig{})
+
+	err := tw.flushEndorsementBatch(context.Background(), "node2", &endorsementBatch{})
+	require.NoError(t, err)
+	assert.Empty(t, sent)
+}
@@ -18,7 +18,9 @@ package publictxmgr
 import (
 	"context"
 	"math/big"
+	"sort"
 	"sync"
+	"time"
 
 	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
 	"github.com/kaleido-io/paladin/config/pkg/confutil"
@@ -45,14 +47,8 @@ type BalanceManagerWithInMemoryTracking struct {
 	// balance cache is used to store cached balances of any address
 	balanceCache cache.Cache[pldtypes.EthAddress, *big.Int]
 
-	// the unresolved signer to use when submitting transactions
-	source string
-
-	// if set to a valid ethereum address, autofueling is turned on
-	sourceAddress *pldtypes.EthAddress
-
-	// reject autofueling when the source address below this balance
-	minSourceBalance *big.Int
+	// the sources to fuel from, in priority order - if there are any, autofueling is turned on
+	sources []*fuelingSource
 
 	// if number of transactions is below this number, apply multiplier to the spent to calculate the top up amount
 	// to fill the extra slots
@@ -68,6 +64,9 @@ type BalanceManagerWithInMemoryTracking struct {
 	// if set, any top up request with amount required below this threshold won't happen
 	minThreshold *big.Int
 
+	// the thresholds above can be replaced when the config is reloaded
+	thresholdsMux sync.RWMutex
+
 	// a map of fueling destination addresses and a mutex to indicate whether it's no longer the first
 	// time the current balance manager instance is handling fueling request to this destination address.
 	// When the mutex is set, balance manager will confidently use the internal trackedFuelingTransactions map
@@ -84,19 +83,97 @@ type BalanceManagerWithInMemoryTracking struct {
 	// the balance of the signing address from the chain
 	addressBalanceChangedMap    map[pldtypes.EthAddress]bool
 	addressBalanceChangedMapMux sync.Mutex
+
+	// the estimated cost of the in-flight transactions of each signing address, reserved against its balance
+	gasTanks   map[pldtypes.EthAddress]*gasTank
+	gasTankMux sync.Mutex
+}
+
+// gasTank holds the reservations for the in-flight transactions of a signing address. A transaction reserves its
+// estimated cost when it is submitted (re-reserving if it is re-priced), and the reservation is settled when the
+// transaction completes - at which point the actual cost is reflected in the balance on chain.
+//
+// The balance we cache for an address does not change while transactions are in flight, so without reservations
+// a fueling source would be judged able to fund every request up to its full balance, however many fueling
+// transactions it already has pending.
+type gasTank struct {
+	reservations map[uint64]*big.Int
+	reserved     *big.Int
+}
+
+// fuelingSource is an address that fueling transactions are submitted from. The value transferred is
+// tracked in memory for the current UTC day, so the daily spending cap is reset on restart.
+type fuelingSource struct {
+	// the unresolved signer to use when submitting transactions
+	source  string
+	address pldtypes.EthAddress
+
+	priority int
+
+	// reject autofueling from this source when its balance is below this
+	minBalance *big.Int
+
+	// reject autofueling from this source when it would take the value transferred today beyond this
+	dailySpendingCap *big.Int
+	spendMux         sync.Mutex
+	spendDay         string
+	spentToday       *big.Int
+}
+
+const (
+	fuelingFailoverReasonStale    = "stale"
+	fuelingFailoverReasonDepleted = "depleted"
+	fuelingFailoverReasonDailyCap = "dailyCap"
+)
+
+// reserveSpend adds the value to the amount spent today, if it is within the daily spending cap
+func (fs *fuelingSource) reserveSpend(ctx context.Context, value *big.Int) error {
+	fs.spendMux.Lock()
+	defer fs.spendMux.Unlock()
+	today := time.Now().UTC().Format(time.DateOnly)
+	if fs.spendDay != today {
+		fs.spendDay = today
+		fs.spentToday = big.NewInt(0)
+	}
+	newSpent := new(big.Int).Add(fs.spentToday, value)
+	if fs.dailySpendingCap != nil && newSpent.Cmp(fs.dailySpendingCap) > 0 {
+		return i18n.NewError(ctx, msgs.MsgFuelingSourceDailyCapReached, fs.address, fs.spentToday.String(), value.String(), fs.dailySpendingCap.String())
+	}
+	fs.spentToday = newSpent
+	return nil
+}
+
+// releaseSpend returns a reservation, when the fueling transaction could not be submitted
+func (fs *fuelingSource) releaseSpend(value *big.Int) {
+	fs.spendMux.Lock()
+	defer fs.spendMux.Unlock()
+	fs.spentToday = new(big.Int).Sub(fs.spentToday, value)
 }
 
 func (af *BalanceManagerWithInMemoryTracking) TopUpAccount(ctx context.Context, addAccount *AddressAccount) (mtx *pldapi.PublicTx, err error) {
-	if af.sourceAddress == nil {
+	if len(af.sources) == 0 {
 		log.L(ctx).Debugf("Skip top up transaction as no fueling source configured")
 		// No-op
 		return nil, nil
 	}
 
-	if af.maxDestBalance != nil && af.maxDestBalance.Cmp(addAccount.Balance) < 0 {
+	if topUpAmount := af.CalculateTopUp(ctx, addAccount); topUpAmount != nil {
+		return af.TransferGasFromAutoFuelingSource(ctx, addAccount.Address, topUpAmount)
+	}
+	return nil, nil
+}
+
+// CalculateTopUp returns the amount that the account would be topped up by to cover what it has spent, or nil if
+// no top up is required. It does not check whether any fueling source is available to provide it.
+func (af *BalanceManagerWithInMemoryTracking) CalculateTopUp(ctx context.Context, addAccount *AddressAccount) *big.Int {
+	af.thresholdsMux.RLock()
+	minDestBalance, maxDestBalance, minThreshold := af.minDestBalance, af.maxDestBalance, af.minThreshold
+	af.thresholdsMux.RUnlock()
+
+	if maxDestBalance != nil && maxDestBalance.Cmp(addAccount.Balance) < 0 {
 		// account already reached maximum balance, no op
-		log.L(ctx).Debugf("Skip top up transaction as target account %s, has %s balance which is higher than the configured max top up %s", addAccount.Address, addAccount.Balance.String(), af.maxDestBalance.String())
-		return nil, nil
+		log.L(ctx).Debugf("Skip top up transaction as target account %s, has %s balance which is higher than the configured max top up %s", addAccount.Address, addAccount.Balance.String(), maxDestBalance.String())
+		return nil
 	}
 	log.L(ctx).Debugf("Calculate the amount to be topped up for address %+v ; autoFueling config: %+v", addAccount, af)
 
@@ -121,42 +198,41 @@ func (af *BalanceManagerWithInMemoryTracking) TopUpAccount(ctx context.Context,
 		}
 
 		// after proactiveFuelingTransactionTotal check, we'll do the threshold check if set
-		if af.minDestBalance != nil {
+		if minDestBalance != nil {
 			balanceCopy := new(big.Int)
 			balanceCopy.Set(addAccount.Balance)
 			newBalance := balanceCopy.Add(balanceCopy, topUpAmount)
-			if af.minDestBalance.Cmp(newBalance) > 0 {
+			if minDestBalance.Cmp(newBalance) > 0 {
 				// top up value below minimum, increase it
 				minDestBalanceCopy := new(big.Int)
-				minDestBalanceCopy.Set(af.minDestBalance)
+				minDestBalanceCopy.Set(minDestBalance)
 
 				topUpAmount = minDestBalanceCopy.Sub(minDestBalanceCopy, addAccount.Balance)
 			}
 		}
 
-		if af.maxDestBalance != nil {
+		if maxDestBalance != nil {
 			balanceCopy := new(big.Int)
 			balanceCopy.Set(addAccount.Balance)
 			newBalance := balanceCopy.Add(balanceCopy, topUpAmount)
-			if af.maxDestBalance.Cmp(newBalance) < 0 {
+			if maxDestBalance.Cmp(newBalance) < 0 {
 				// top up value beyond maximum, decrease it
 				maxDestBalanceCopy := new(big.Int)
-				maxDestBalanceCopy.Set(af.maxDestBalance)
+				maxDestBalanceCopy.Set(maxDestBalance)
 
 				topUpAmount = maxDestBalanceCopy.Sub(maxDestBalanceCopy, addAccount.Balance)
 			}
 		}
 
-		if af.minThreshold != nil && af.minThreshold.Cmp(topUpAmount) > 0 {
+		if minThreshold != nil && minThreshold.Cmp(topUpAmount) > 0 {
 			// top up amount too low, do not submit any fueling transaction
-			log.L(ctx).Debugf("Skipped top up for address %s as calculated amount: %s is below the min threshold %s", addAccount.Address, topUpAmount.String(), af.minThreshold.String())
-			return nil, nil
+			log.L(ctx).Debugf("Skipped top up for address %s as calculated amount: %s is below the min threshold %s", addAccount.Address, topUpAmount.String(), minThreshold.String())
+			return nil
 		}
-		log.L(ctx).Debugf("Requesting top up for address %s using calculated amount: %s based on spent: %s", addAccount.Address, topUpAmount.String(), addAccount.Spent.String())
-		// after all the above amount tuning, do a final threshold check if there is one
-		return af.TransferGasFromAutoFuelingSource(ctx, addAccount.Address, topUpAmount)
+		log.L(ctx).Debugf("Calculated top up for address %s of amount: %s based on spent: %s", addAccount.Address, topUpAmount.String(), addAccount.Spent.String())
+		return topUpAmount
 	}
-	return nil, nil
+	return nil
 }
 
 func (af *BalanceManagerWithInMemoryTracking) NotifyAddressBalanceChanged(ctx context.Context, address pldtypes.EthAddress) {
@@ -165,8 +241,105 @@ func (af *BalanceManagerWithInMemoryTracking) NotifyAddressBalanceChanged(ctx co
 	af.addressBalanceChangedMap[address] = true
 }
 
+// ReserveGas records the estimated cost of an in-flight transaction against the balance of its signer,
+// replacing any previous reservation for the same transaction
+func (af *BalanceManagerWithInMemoryTracking) ReserveGas(ctx context.Context, address pldtypes.EthAddress, pubTxnID uint64, cost *big.Int) {
+	af.gasTankMux.Lock()
+	defer af.gasTankMux.Unlock()
+	tank := af.gasTanks[address]
+	if tank == nil {
+		tank = &gasTank{reservations: make(map[uint64]*big.Int), reserved: big.NewInt(0)}
+		af.gasTanks[address] = tank
+	}
+	if existing := tank.reservations[pubTxnID]; existing != nil {
+		tank.reserved.Sub(tank.reserved, existing)
+	}
+	reservation := new(big.Int).Set(cost)
+	tank.reservations[pubTxnID] = reservation
+	tank.reserved.Add(tank.reserved, reservation)
+	log.L(ctx).Tracef("Reserved %s for transaction %d of %s, total reserved %s", reservation.String(), pubTxnID, address, tank.reserved.String())
+}
+
+// SettleGas releases the reservation of a transaction that is no longer in flight. The balance is re-read from
+// the chain on the next request, so it reflects what the transaction actually cost.
+func (af *BalanceManagerWithInMemoryTracking) SettleGas(ctx context.Context, address pldtypes.EthAddress, pubTxnID uint64) {
+	af.gasTankMux.Lock()
+	tank := af.gasTanks[address]
+	var reservation *big.Int
+	if tank != nil {
+		reservation = tank.reservations[pubTxnID]
+	}
+	if reservation != nil {
+		delete(tank.reservations, pubTxnID)
+		tank.reserved.Sub(tank.reserved, reservation)
+		if len(tank.reservations) == 0 {
+			delete(af.gasTanks, address)
+		}
+	}
+	af.gasTankMux.Unlock()
+
+	if reservation != nil {
+		log.L(ctx).Tracef("Settled reservation of %s for transaction %d of %s", reservation.String(), pubTxnID, address)
+		af.NotifyAddressBalanceChanged(ctx, address)
+	}
+}
+
+// GetReservedGas returns the total reserved for the in-flight transactions of the address
+func (af *BalanceManagerWithInMemoryTracking) GetReservedGas(ctx context.Context, address pldtypes.EthAddress) *big.Int {
+	af.gasTankMux.Lock()
+	defer af.gasTankMux.Unlock()
+	if tank := af.gasTanks[address]; tank != nil {
+		return new(big.Int).Set(tank.reserved)
+	}
+	return big.NewInt(0)
+}
+
 func (af *BalanceManagerWithInMemoryTracking) IsAutoFuelingEnabled(ctx context.Context) bool {
-	return af.sourceAddress != nil
+	return len(af.sources) > 0
+}
+
+// isSourceStale checks whether the orchestrator for a fueling source has stopped making progress, in which case
+// any fueling transactions it has pending are unlikely to be mined in a timely way
+func (af *BalanceManagerWithInMemoryTracking) isSourceStale(address pldtypes.EthAddress) bool {
+	oc := af.pubTxMgr.getOrchestratorForAddress(address)
+	return oc != nil && oc.state == OrchestratorStateStale
+}
+
+// checkSourceAvailable returns an error, and the failover reason, if the source cannot currently fuel the requested value
+func (af *BalanceManagerWithInMemoryTracking) checkSourceAvailable(ctx context.Context, fs *fuelingSource, value *big.Int) (string, error) {
+	if af.isSourceStale(fs.address) {
+		log.L(ctx).Warnf("TransferGasFromAutoFuelingSource orchestrator for source %s is stale", fs.address)
+		return fuelingFailoverReasonStale, i18n.NewError(ctx, msgs.MsgFuelingSourceStale, fs.address)
+	}
+
+	// Check balance of source address to ensure we have enough to transfer
+	sourceAccount, err := af.GetAddressBalance(ctx, fs.address)
+	if err != nil {
+		log.L(ctx).Errorf("TransferGasFromAutoFuelingSource failed to get balance of source: %s", fs.address)
+		return fuelingFailoverReasonDepleted, err
+	}
+	// What the source has in flight is already committed, so is not available to fuel from
+	reserved := af.GetReservedGas(ctx, fs.address)
+	available := new(big.Int).Sub(sourceAccount.Balance, reserved)
+	log.L(ctx).Tracef("TransferGasFromAutoFuelingSource source %s balance: (%v) reserved: (%v)", fs.address, sourceAccount.Balance.String(), reserved.String())
+
+	if fs.minBalance != nil && available.Cmp(fs.minBalance) < 0 {
+		log.L(ctx).Errorf("TransferGasFromAutoFuelingSource source balance of %s: %s (after %s reserved) is below the configured minimum: %s", sourceAccount.Address, available.String(), reserved.String(), fs.minBalance.String())
+		return fuelingFailoverReasonDepleted, i18n.NewError(ctx, msgs.MsgBalanceBelowMinimum, available.String(), sourceAccount.Address, fs.minBalance.String())
+	}
+
+	if available.Cmp(value) < 0 {
+		log.L(ctx).Errorf("TransferGasFromAutoFuelingSource source balance of %s: %s (after %s reserved) is below the requested amount: %s", sourceAccount.Address, available.String(), reserved.String(), value.String())
+		return fuelingFailoverReasonDepleted, i18n.NewError(ctx, msgs.MsgInsufficientBalance, available.String(), sourceAccount.Address, value.String())
+	}
+
+	// for the situation of the requested value + gas fee is greater than the balance, we only figure this out after the new transaction is executed
+
+	if err := fs.reserveSpend(ctx, value); err != nil {
+		log.L(ctx).Errorf("TransferGasFromAutoFuelingSource source %s cannot transfer %s: %s", fs.address, value.String(), err)
+		return fuelingFailoverReasonDailyCap, err
+	}
+	return "", nil
 }
 
 func (af *BalanceManagerWithInMemoryTracking) GetAddressBalance(ctx context.Context, address pldtypes.EthAddress) (*AddressAccount, error) {
@@ -208,7 +381,7 @@ func (af *BalanceManagerWithInMemoryTracking) GetAddressBalance(ctx context.Cont
 func (af *BalanceManagerWithInMemoryTracking) TransferGasFromAutoFuelingSource(ctx context.Context, destAddress pldtypes.EthAddress, value *big.Int) (fuelingTx *pldapi.PublicTx, err error) {
 	// check whether there is a pending fueling transaction already
 	// check whether the current balance manager already tracking the existing in-flight fueling transactions
-	log.L(ctx).Tracef("TransferGasFromAutoFuelingSource entry, source address: %s, destination address: %s, amount: %s", af.sourceAddress, destAddress, value.String())
+	log.L(ctx).Tracef("TransferGasFromAutoFuelingSource entry, sources: %d, destination address: %s, amount: %s", len(af.sources), destAddress, value.String())
 
 	af.destinationAddressesFuelingTrackedMux.Lock()
 	perAddressMux, ok := af.destinationAddressesFuelingTracked[destAddress]
@@ -228,16 +401,19 @@ func (af *BalanceManagerWithInMemoryTracking) TransferGasFromAutoFuelingSource(c
 		log.L(ctx).Debugf("TransferGasFromAutoFuelingSource no existing tracking fueling request for  destination address: %s", destAddress)
 		// there is no tracked fueling transaction for this address, do a lookup in the db in case we've restarted or couldn't record the last one submitted
 		// in the middle of tracking
-		fuelingTx, err = af.pubTxMgr.GetPendingFuelingTransaction(ctx, *af.sourceAddress, destAddress)
-		if err != nil {
-			log.L(ctx).Errorf("TransferGasFromAutoFuelingSource error occurred when getting pending fueling tx for address: %s, error: %+v", destAddress, err)
-			// we don't risk the chance of having duplicate fueling transactions when we cannot fetching all the in-flight transactions
-			return nil, err
-		}
-		if fuelingTx != nil {
-			af.trackedFuelingTransactionsMux.Lock()
-			af.trackedFuelingTransactions[destAddress] = fuelingTx
-			af.trackedFuelingTransactionsMux.Unlock()
+		for _, fs := range af.sources {
+			fuelingTx, err = af.pubTxMgr.GetPendingFuelingTransaction(ctx, fs.address, destAddress)
+			if err != nil {
+				log.L(ctx).Errorf("TransferGasFromAutoFuelingSource error occurred when getting pending fueling tx for address: %s, error: %+v", destAddress, err)
+				// we don't risk the chance of having duplicate fueling transactions when we cannot fetching all the in-flight transactions
+				return nil, err
+			}
+			if fuelingTx != nil {
+				af.trackedFuelingTransactionsMux.Lock()
+				af.trackedFuelingTransactions[destAddress] = fuelingTx
+				af.trackedFuelingTransactionsMux.Unlock()
+				break
+			}
 		}
 	}
 	if fuelingTx != nil {
@@ -245,10 +421,17 @@ func (af *BalanceManagerWithInMemoryTracking) TransferGasFromAutoFuelingSource(c
 		if err != nil {
 			return nil, err
 		}
-		if !completed {
+		switch {
+		case completed:
+			// the orchestrator settles on completion too, but we might have found it from the DB
+			af.SettleGas(ctx, fuelingTx.From, *fuelingTx.LocalID)
+		case !af.isSourceStale(fuelingTx.From):
 			log.L(ctx).Debugf("TransferGasFromAutoFuelingSource fueling request from=%s nonce=%d for destination address: %s still not complete", fuelingTx.From, fuelingTx.Nonce, destAddress)
 			// transaction is tracked and is still pending, return the transaction as it is
 			return fuelingTx, nil
+		default:
+			// the source is stuck, so we fail over to fuel from another source
+			log.L(ctx).Warnf("TransferGasFromAutoFuelingSource fueling request from=%s nonce=%d for destination address: %s is pending on a stale source", fuelingTx.From, fuelingTx.Nonce, destAddress)
 		}
 	}
 
@@ -259,46 +442,41 @@ func (af *BalanceManagerWithInMemoryTracking) TransferGasFromAutoFuelingSource(c
 	delete(af.trackedFuelingTransactions, destAddress)
 	af.trackedFuelingTransactionsMux.Unlock()
 
-	// 1) Check balance of source address to ensure we have enough to transfer
-	sourceAccount, err := af.GetAddressBalance(ctx, *af.sourceAddress)
-
-	if err != nil {
-		log.L(ctx).Errorf("TransferGasFromAutoFuelingSource failed to get balance of source: %s", af.sourceAddress)
-		return nil, err
-	}
-	log.L(ctx).Tracef("TransferGasFromAutoFuelingSource source balance: (%v)", sourceAccount.Balance.String())
+	// Use the first source in priority order that can fuel the requested amount
+	var sourceErr error
+	for _, fs := range af.sources {
+		var reason string
+		if reason, sourceErr = af.checkSourceAvailable(ctx, fs, value); sourceErr != nil {
+			af.pubTxMgr.thMetrics.RecordFuelingFailoverMetrics(ctx, fs.address.String(), reason)
+			continue
+		}
 
-	if af.minSourceBalance != nil && sourceAccount.Balance.Cmp(af.minSourceBalance) < 0 {
-		log.L(ctx).Errorf("TransferGasFromAutoFuelingSource source balance of %s: %s is below the configured minimum: %s", sourceAccount.Address, sourceAccount.Balance.String(), af.minSourceBalance.String())
-		// if the balance of the source account goes below configured minimum, we return an error to the caller to decide what to do
-		return nil, i18n.NewError(ctx, msgs.MsgBalanceBelowMinimum, sourceAccount.Balance.String(), sourceAccount.Address, af.minSourceBalance.String())
+		log.L(ctx).Debugf("TransferGasFromAutoFuelingSource submitting a fueling tx from source %s for destination address: %s ", fs.address, destAddress)
+		fuelingTx, err = af.pubTxMgr.SingleTransactionSubmit(ctx, &components.PublicTxSubmission{
+			PublicTxInput: pldapi.PublicTxInput{
+				From: &fs.address,
+				To:   &destAddress,
+				PublicTxOptions: pldapi.PublicTxOptions{
+					Value: (*pldtypes.HexUint256)(value),
+				},
+			},
+		})
+		if err != nil {
+			log.L(ctx).Errorf("TransferGasFromAutoFuelingSource fueling tx submission for destination address: %s failed due to: %+v", destAddress, err)
+			fs.releaseSpend(value)
+			return nil, err
+		}
+		// Reserve the value straight away, so concurrent requests cannot commit it again before the
+		// orchestrator of the source picks up the transaction and reserves its full cost
+		af.ReserveGas(ctx, fs.address, *fuelingTx.LocalID, value)
+		af.pubTxMgr.thMetrics.RecordFuelingSpendMetrics(ctx, fs.address.String(), value)
+		break
 	}
-
-	if sourceAccount.Balance.Cmp(value) < 0 {
-		log.L(ctx).Errorf("TransferGasFromAutoFuelingSource source balance of %s: %s is below the requested amount: %s", sourceAccount.Address, sourceAccount.Balance.String(), value.String())
-		// if the balance of the source account is not enough to cover the requested amount ,we return an error to the caller to decide what to do
-		return nil, i18n.NewError(ctx, msgs.MsgInsufficientBalance, sourceAccount.Balance.String(), sourceAccount.Address, value.String())
+	if sourceErr != nil {
+		// none of the sources could fuel, so we return the error from the last one to the caller to decide what to do
+		return nil, sourceErr
 	}
 
-	// for the situation of the requested value + gas fee is greater than the balance, we only figure this out after the new transaction is executed
-
-	// 2) Perform transaction to transfer value to the dest address
-
-	log.L(ctx).Debugf("TransferGasFromAutoFuelingSource submitting a fueling tx for  destination address: %s ", destAddress)
-	fuelingTx, err = af.pubTxMgr.SingleTransactionSubmit(ctx, &components.PublicTxSubmission{
-		PublicTxInput: pldapi.PublicTxInput{
-			From: af.sourceAddress,
-			To:   &destAddress,
-			PublicTxOptions: pldapi.PublicTxOptions{
-				Value: (*pldtypes.HexUint256)(value),
-			},
-		},
-	})
-
-	if err != nil {
-		log.L(ctx).Errorf("TransferGasFromAutoFuelingSource fueling tx submission for destination address: %s failed due to: %+v", destAddress, err)
-		return nil, err
-	}
 	log.L(ctx).Debugf("TransferGasFromAutoFuelingSource tracking fueling tx with from=%s nonce=%d, for destination address: %s ", fuelingTx.From, fuelingTx.Nonce, destAddress)
 	// start tracking the new transactions
 	af.trackedFuelingTransactionsMux.Lock()
@@ -307,46 +485,93 @@ func (af *BalanceManagerWithInMemoryTracking) TransferGasFromAutoFuelingSource(c
 	return fuelingTx, nil
 }
 
-func NewBalanceManagerWithInMemoryTracking(ctx context.Context, conf *pldconf.PublicTxManagerConfig, publicTxMgr *pubTxManager) (_ BalanceManager, err error) {
-
-	minSourceBalance := confutil.BigIntOrNil(conf.BalanceManager.AutoFueling.MinDestBalance)
-	minDestBalance := confutil.BigIntOrNil(conf.BalanceManager.AutoFueling.MinDestBalance)
-	maxDestBalance := confutil.BigIntOrNil(conf.BalanceManager.AutoFueling.MaxDestBalance)
-	minThreshold := confutil.BigIntOrNil(conf.BalanceManager.AutoFueling.MinThreshold)
+func parseAutoFuelingThresholds(ctx context.Context, conf *pldconf.AutoFuelingConfig) (minDestBalance, maxDestBalance, minThreshold *big.Int, err error) {
+	minDestBalance = confutil.BigIntOrNil(conf.MinDestBalance)
+	maxDestBalance = confutil.BigIntOrNil(conf.MaxDestBalance)
+	minThreshold = confutil.BigIntOrNil(conf.MinThreshold)
 
 	if maxDestBalance != nil && minDestBalance != nil {
 		if maxDestBalance.Cmp(minDestBalance) < 0 {
-			log.L(ctx).Errorf("Failed initialization due to maxDestBalance is not greater than minDestBalance")
-			return nil, i18n.NewError(ctx, msgs.MsgMaxBelowMin, "maxDestBalance")
+			log.L(ctx).Errorf("Invalid auto-fueling thresholds: maxDestBalance is not greater than minDestBalance")
+			return nil, nil, nil, i18n.NewError(ctx, msgs.MsgMaxBelowMin, "maxDestBalance")
 		}
 	}
 
 	if maxDestBalance != nil && minThreshold != nil {
 		if maxDestBalance.Cmp(minThreshold) < 0 {
-			log.L(ctx).Errorf("Failed initialization due to maxDestBalance is not greater than minThreshold")
-			return nil, i18n.NewError(ctx, msgs.MsgMaxBelowMinThreshold, "maxDestBalance")
+			log.L(ctx).Errorf("Invalid auto-fueling thresholds: maxDestBalance is not greater than minThreshold")
+			return nil, nil, nil, i18n.NewError(ctx, msgs.MsgMaxBelowMinThreshold, "maxDestBalance")
 		}
 	}
-	var autoFuelingSourceAddress *pldtypes.EthAddress
+	return minDestBalance, maxDestBalance, minThreshold, nil
+}
+
+// SetAutoFuelingThresholds replaces the top up thresholds when the config is reloaded. The fueling
+// sources are not changed, as they are resolved to keys and checked at startup.
+func (af *BalanceManagerWithInMemoryTracking) SetAutoFuelingThresholds(ctx context.Context, conf *pldconf.AutoFuelingConfig) error {
+	minDestBalance, maxDestBalance, minThreshold, err := parseAutoFuelingThresholds(ctx, conf)
+	if err != nil {
+		return err
+	}
+	af.thresholdsMux.Lock()
+	defer af.thresholdsMux.Unlock()
+	af.minDestBalance = minDestBalance
+	af.maxDestBalance = maxDestBalance
+	af.minThreshold = minThreshold
+	log.L(ctx).Infof("Auto-fueling thresholds set: minDestBalance=%v maxDestBalance=%v minThreshold=%v", minDestBalance, maxDestBalance, minThreshold)
+	return nil
+}
+
+func NewBalanceManagerWithInMemoryTracking(ctx context.Context, conf *pldconf.PublicTxManagerConfig, publicTxMgr *pubTxManager) (_ BalanceManager, err error) {
+
+	minDestBalance, maxDestBalance, minThreshold, err := parseAutoFuelingThresholds(ctx, &conf.BalanceManager.AutoFueling)
+	if err != nil {
+		return nil, err
+	}
+
+	sourceConfs := conf.BalanceManager.AutoFueling.Sources
 	autoFuelingSource := confutil.StringOrEmpty(conf.BalanceManager.AutoFueling.Source, "")
 	if autoFuelingSource != "" {
-		// We must be able to resolve the supplied auto fueling source at startup, so we can check its balance
-		resolved, err := publicTxMgr.keymgr.ResolveKeyNewDatabaseTX(ctx, autoFuelingSource, algorithms.ECDSA_SECP256K1, verifiers.ETH_ADDRESS)
+		sourceConfs = append([]*pldconf.AutoFuelingSourceConfig{{
+			Source:           autoFuelingSource,
+			Priority:         0,
+			MinBalance:       conf.BalanceManager.AutoFueling.SourceAddressMinBalance,
+			DailySpendingCap: conf.BalanceManager.AutoFueling.SourceDailySpendingCap,
+		}}, sourceConfs...)
+	}
+	sources := make([]*fuelingSource, 0, len(sourceConfs))
+	for _, sourceConf := range sourceConfs {
+		// We must be able to resolve the supplied auto fueling sources at startup, so we can check their balance
+		var sourceAddress *pldtypes.EthAddress
+		resolved, err := publicTxMgr.keymgr.ResolveKeyNewDatabaseTX(ctx, sourceConf.Source, algorithms.ECDSA_SECP256K1, verifiers.ETH_ADDRESS)
 		if err == nil {
-			autoFuelingSourceAddress, err = pldtypes.ParseEthAddress(resolved.Verifier.Verifier)
+			sourceAddress, err = pldtypes.ParseEthAddress(resolved.Verifier.Verifier)
 		}
 		if err != nil {
-			return nil, i18n.WrapError(ctx, err, msgs.MsgInvalidAutoFuelSource, autoFuelingSource)
+			return nil, i18n.WrapError(ctx, err, msgs.MsgInvalidAutoFuelSource, sourceConf.Source)
 		}
+		for _, existing := range sources {
+			if existing.address == *sourceAddress {
+				return nil, i18n.NewError(ctx, msgs.MsgFuelingSourceDuplicate, sourceConf.Source, sourceAddress)
+			}
+		}
+		sources = append(sources, &fuelingSource{
+			source:           sourceConf.Source,
+			address:          *sourceAddress,
+			priority:         sourceConf.Priority,
+			minBalance:       confutil.BigIntOrNil(sourceConf.MinBalance),
+			dailySpendingCap: confutil.BigIntOrNil(sourceConf.DailySpendingCap),
+			spentToday:       big.NewInt(0),
+		})
 	}
+	sort.SliceStable(sources, func(i, j int) bool { return sources[i].priority < sources[j].priority })
+
 	calcMethod := confutil.StringNotEmpty(conf.BalanceManager.AutoFueling.ProactiveCostEstimationMethod, string(pldconf.ProactiveAutoFuelingCalcMethodMax))
 	log.L(ctx).Debugf("Balance manager calcMethod setting: %s", calcMethod)
 	bm := &BalanceManagerWithInMemoryTracking{
-		source:                             autoFuelingSource,
-		sourceAddress:                      autoFuelingSourceAddress,
+		sources:                            sources,
 		pubTxMgr:                           publicTxMgr,
-		balanceCache:                       cache.NewCache[pldtypes.EthAddress, *big.Int](&conf.BalanceManager.Cache, &pldconf.PublicTxManagerDefaults.BalanceManager.Cache),
-		minSourceBalance:                   minSourceBalance,
+		balanceCache:                       cache.NewNamedCache[pldtypes.EthAddress, *big.Int]("publictxmgr.balances", &conf.BalanceManager.Cache, &pldconf.PublicTxManagerDefaults.BalanceManager.Cache),
 		proactiveFuelingTransactionTotal:   confutil.IntMin(conf.BalanceManager.AutoFueling.ProactiveFuelingTransactionTotal, 0, *pldconf.PublicTxManagerDefaults.BalanceManager.AutoFueling.ProactiveFuelingTransactionTotal),
 		proactiveFuelingCalcMethod:         pldconf.ProactiveAutoFuelingCalcMethod(calcMethod),
 		minDestBalance:                     minDestBalance,
@@ -355,6 +580,7 @@ func NewBalanceManagerWithInMemoryTracking(ctx context.Context, conf *pldconf.Pu
 		destinationAddressesFuelingTracked: make(map[pldtypes.EthAddress]*sync.Mutex),
 		trackedFuelingTransactions:         make(map[pldtypes.EthAddress]*pldapi.PublicTx),
 		addressBalanceChangedMap:           make(map[pldtypes.EthAddress]bool),
+		gasTanks:                           make(map[pldtypes.EthAddress]*gasTank),
 	}
 	return bm, nil
 }
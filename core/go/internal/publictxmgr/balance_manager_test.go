@@ -253,7 +253,7 @@ func TestTopUpAddressNoOpScenarios(t *testing.T) {
 	assert.Nil(t, fuelingTx)
 
 	// no source address configured
-	bm.sourceAddress = nil
+	bm.sources = nil
 	fuelingTx, err = bm.TopUpAccount(ctx, &AddressAccount{
 		Spent:                 big.NewInt(10),
 		Balance:               big.NewInt(0),
@@ -278,7 +278,7 @@ func mockAutoFuelTransactionSubmit(m *mocksAndTestControl, bm *BalanceManagerWit
 
 	if uncachedBalance {
 		// Mock the sufficient balance on the auto-fueling source address, and the nonce assignment
-		m.ethClient.On("GetBalance", mock.Anything, *bm.sourceAddress, "latest").Return(pldtypes.Uint64ToUint256(400), nil).Once()
+		m.ethClient.On("GetBalance", mock.Anything, bm.sources[0].address, "latest").Return(pldtypes.Uint64ToUint256(400), nil).Once()
 	}
 
 	// Gas estimate for the auto-fueling TX
@@ -311,7 +311,7 @@ func TestTopUpWithNoAmountModificationWithMultipleFuelingTxs(t *testing.T) {
 	expectedTopUpAmount := big.NewInt(100)
 	fuelingTx, err := bm.TopUpAccount(ctx, accountToTopUp)
 	require.NoError(t, err)
-	expectFuelingEqual(t, fuelingTx, expectedTopUpAmount.Uint64(), *bm.sourceAddress, testDestAddress)
+	expectFuelingEqual(t, fuelingTx, expectedTopUpAmount.Uint64(), bm.sources[0].address, testDestAddress)
 
 	// Test no new fueling transaction when the current one is pending
 	accountToTopUp2 := &AddressAccount{
@@ -326,23 +326,24 @@ func TestTopUpWithNoAmountModificationWithMultipleFuelingTxs(t *testing.T) {
 	// return not yet completed, so should return the existing pending transaction
 	m.db.ExpectQuery("SELECT.*public_txns").
 		WillReturnRows(sqlmock.NewRows([]string{"from"}).AddRow(
-			*bm.sourceAddress,
+			bm.sources[0].address,
 		))
 
 	newFuelingTx, err := bm.TopUpAccount(ctx, accountToTopUp2)
 	require.NoError(t, err)
-	expectFuelingEqual(t, newFuelingTx, expectedTopUpAmount.Uint64(), *bm.sourceAddress, testDestAddress)
+	expectFuelingEqual(t, newFuelingTx, expectedTopUpAmount.Uint64(), bm.sources[0].address, testDestAddress)
 
 	// current transaction completed, replace with new transaction
 	expectedTopUpAmount2 := big.NewInt(50)
 	m.db.ExpectQuery("SELECT.*public_txns").WillReturnRows(sqlmock.NewRows([]string{"from", `Completed__tx_hash`}).
-		AddRow(*bm.sourceAddress, pldtypes.RandBytes32()))
+		AddRow(bm.sources[0].address, pldtypes.RandBytes32()))
 
-	mockAutoFuelTransactionSubmit(m, bm, false)
+	// settling the completed transaction means the source balance is re-read
+	mockAutoFuelTransactionSubmit(m, bm, true)
 
 	fuelingTx2, err := bm.TopUpAccount(ctx, accountToTopUp2)
 	require.NoError(t, err)
-	expectFuelingEqual(t, fuelingTx2, expectedTopUpAmount2.Uint64(), *bm.sourceAddress, testDestAddress)
+	expectFuelingEqual(t, fuelingTx2, expectedTopUpAmount2.Uint64(), bm.sources[0].address, testDestAddress)
 
 	// test when couldn't record the result of the submitted transaction
 	// also do a balance look up
@@ -355,11 +356,11 @@ func TestTopUpWithNoAmountModificationWithMultipleFuelingTxs(t *testing.T) {
 		MaxCost:               big.NewInt(50),
 	}
 	expectedTopUpAmount3 := big.NewInt(50)
-	bm.NotifyAddressBalanceChanged(ctx, *bm.sourceAddress)
-	m.ethClient.On("GetBalance", mock.Anything, *bm.sourceAddress, "latest").Return(pldtypes.Uint64ToUint256(50), nil).Once()
+	bm.NotifyAddressBalanceChanged(ctx, bm.sources[0].address)
+	m.ethClient.On("GetBalance", mock.Anything, bm.sources[0].address, "latest").Return(pldtypes.Uint64ToUint256(50), nil).Once()
 
 	m.db.ExpectQuery("SELECT.*public_txns").WillReturnRows(sqlmock.NewRows([]string{"from", `Completed__tx_hash`}).
-		AddRow(*bm.sourceAddress, pldtypes.RandBytes32()))
+		AddRow(bm.sources[0].address, pldtypes.RandBytes32()))
 	m.db.ExpectBegin()
 
 	m.ethClient.On("EstimateGasNoResolve", mock.Anything, mock.Anything, mock.Anything).
@@ -374,13 +375,13 @@ func TestTopUpWithNoAmountModificationWithMultipleFuelingTxs(t *testing.T) {
 	// also do a address balance re-lookup
 	m.db.ExpectQuery("SELECT.*public_txns").
 		WillReturnRows(sqlmock.NewRows([]string{"from", "to", "value"}).AddRow(
-			*bm.sourceAddress, testDestAddress, (*pldtypes.HexUint256)(expectedTopUpAmount3),
+			bm.sources[0].address, testDestAddress, (*pldtypes.HexUint256)(expectedTopUpAmount3),
 		))
 	m.db.ExpectQuery("SELECT.*public_txns").WillReturnRows(sqlmock.NewRows([]string{"from", "to", "value", `Completed__tx_hash`}).
-		AddRow(*bm.sourceAddress, testDestAddress, (*pldtypes.HexUint256)(expectedTopUpAmount3), nil /* incomplete */))
+		AddRow(bm.sources[0].address, testDestAddress, (*pldtypes.HexUint256)(expectedTopUpAmount3), nil /* incomplete */))
 	fuelingTx3, err := bm.TopUpAccount(ctx, accountToTopUp3)
 	require.NoError(t, err)
-	expectFuelingEqual(t, fuelingTx3, expectedTopUpAmount3.Uint64(), *bm.sourceAddress, testDestAddress)
+	expectFuelingEqual(t, fuelingTx3, expectedTopUpAmount3.Uint64(), bm.sources[0].address, testDestAddress)
 }
 
 func TestTopUpSuccessTopUpMinAheadUseMin(t *testing.T) {
@@ -413,7 +414,7 @@ func TestTopUpSuccessTopUpMinAheadUseMin(t *testing.T) {
 
 	fuelingTx, err := bm.TopUpAccount(ctx, accountToTopUp)
 	require.NoError(t, err)
-	expectFuelingEqual(t, fuelingTx, expectedTopUpAmount.Uint64(), *bm.sourceAddress, testDestAddress)
+	expectFuelingEqual(t, fuelingTx, expectedTopUpAmount.Uint64(), bm.sources[0].address, testDestAddress)
 
 }
 
@@ -447,7 +448,7 @@ func TestTopUpSuccessTopUpMinAheadUseMax(t *testing.T) {
 
 	fuelingTx, err := bm.TopUpAccount(ctx, accountToTopUp)
 	require.NoError(t, err)
-	expectFuelingEqual(t, fuelingTx, expectedTopUpAmount.Uint64(), *bm.sourceAddress, testDestAddress)
+	expectFuelingEqual(t, fuelingTx, expectedTopUpAmount.Uint64(), bm.sources[0].address, testDestAddress)
 
 }
 
@@ -481,7 +482,7 @@ func TestTopUpSuccessTopUpMinAheadUseAvg(t *testing.T) {
 
 	fuelingTx, err := bm.TopUpAccount(ctx, accountToTopUp)
 	require.NoError(t, err)
-	expectFuelingEqual(t, fuelingTx, expectedTopUpAmount.Uint64(), *bm.sourceAddress, testDestAddress)
+	expectFuelingEqual(t, fuelingTx, expectedTopUpAmount.Uint64(), bm.sources[0].address, testDestAddress)
 
 }
 
@@ -512,7 +513,7 @@ func TestTopUpSuccessUseMinDestBalance(t *testing.T) {
 
 	fuelingTx, err := bm.TopUpAccount(ctx, accountToTopUp)
 	require.NoError(t, err)
-	expectFuelingEqual(t, fuelingTx, expectedTopUpAmount.Uint64(), *bm.sourceAddress, testDestAddress)
+	expectFuelingEqual(t, fuelingTx, expectedTopUpAmount.Uint64(), bm.sources[0].address, testDestAddress)
 }
 
 func TestTopUpSuccessUseMaxDestBalance(t *testing.T) {
@@ -542,7 +543,7 @@ func TestTopUpSuccessUseMaxDestBalance(t *testing.T) {
 
 	fuelingTx, err := bm.TopUpAccount(ctx, accountToTopUp)
 	require.NoError(t, err)
-	expectFuelingEqual(t, fuelingTx, expectedTopUpAmount.Uint64(), *bm.sourceAddress, testDestAddress)
+	expectFuelingEqual(t, fuelingTx, expectedTopUpAmount.Uint64(), bm.sources[0].address, testDestAddress)
 }
 
 func TestTopUpNoOpAlreadyAboveMaxDestBalance(t *testing.T) {
@@ -613,15 +614,15 @@ func TestTopUpFailedDueToSourceBalanceBelowMin(t *testing.T) {
 	m.db.ExpectQuery("SELECT.*public_txns.*data IS NULL").WillReturnRows(sqlmock.NewRows([]string{}))
 
 	// Mock the sufficient balance on the auto-fueling source address, and the nonce assignment
-	m.ethClient.On("GetBalance", mock.Anything, *bm.sourceAddress, "latest").Return(pldtypes.Uint64ToUint256(400), nil).Once()
+	m.ethClient.On("GetBalance", mock.Anything, bm.sources[0].address, "latest").Return(pldtypes.Uint64ToUint256(400), nil).Once()
 
 	// set min source balance to 1000, which is way beyond 400
-	bm.minSourceBalance = big.NewInt(1000)
+	bm.sources[0].minBalance = big.NewInt(1000)
 
 	fuelingTx, err := bm.TopUpAccount(ctx, accountToTopUp)
 	assert.Error(t, err)
 	assert.Nil(t, fuelingTx)
-	assert.Regexp(t, fmt.Sprintf("PD011901: Balance 400 of fueling source address %s is below the configured minimum balance 1000", bm.sourceAddress), err.Error())
+	assert.Regexp(t, fmt.Sprintf("PD011901: Balance 400 of fueling source address %s is below the configured minimum balance 1000", bm.sources[0].address), err.Error())
 }
 
 func TestTopUpFailedDueToSourceBalanceBelowRequestedAmount(t *testing.T) {
@@ -644,12 +645,12 @@ func TestTopUpFailedDueToSourceBalanceBelowRequestedAmount(t *testing.T) {
 	m.db.ExpectQuery("SELECT.*public_txns.*data IS NULL").WillReturnRows(sqlmock.NewRows([]string{}))
 
 	// Mock the sufficient balance on the auto-fueling source address, and the nonce assignment
-	m.ethClient.On("GetBalance", mock.Anything, *bm.sourceAddress, "latest").Return(pldtypes.Uint64ToUint256(400), nil).Once()
+	m.ethClient.On("GetBalance", mock.Anything, bm.sources[0].address, "latest").Return(pldtypes.Uint64ToUint256(400), nil).Once()
 
 	fuelingTx, err := bm.TopUpAccount(ctx, accountToTopUp)
 	assert.Error(t, err)
 	assert.Nil(t, fuelingTx)
-	assert.Regexp(t, fmt.Sprintf("PD011900: Balance 400 of fueling source address %s is below the required amount 1900", bm.sourceAddress), err.Error())
+	assert.Regexp(t, fmt.Sprintf("PD011900: Balance 400 of fueling source address %s is below the required amount 1900", bm.sources[0].address), err.Error())
 }
 
 func TestTopUpFailedDueToSourceBalanceBelowRequestedAmountConcurrencyTest(t *testing.T) {
@@ -666,7 +667,7 @@ func TestTopUpFailedDueToSourceBalanceBelowRequestedAmountConcurrencyTest(t *tes
 	}
 
 	// Mock the sufficient balance on the auto-fueling source address, and the nonce assignment
-	m.ethClient.On("GetBalance", mock.Anything, *bm.sourceAddress, "latest").Return(pldtypes.Uint64ToUint256(400), nil).Once() // called once and then cached
+	m.ethClient.On("GetBalance", mock.Anything, bm.sources[0].address, "latest").Return(pldtypes.Uint64ToUint256(400), nil).Once() // called once and then cached
 
 	var wg sync.WaitGroup
 	for i := 0; i < testConcurrency; i++ {
@@ -683,7 +684,7 @@ func TestTopUpFailedDueToSourceBalanceBelowRequestedAmountConcurrencyTest(t *tes
 			})
 			assert.Error(t, err)
 			assert.Nil(t, fuelingTx)
-			assert.Regexp(t, fmt.Sprintf("PD011900: Balance 400 of fueling source address %s is below the required amount 1900", bm.sourceAddress), err.Error())
+			assert.Regexp(t, fmt.Sprintf("PD011900: Balance 400 of fueling source address %s is below the required amount 1900", bm.sources[0].address), err.Error())
 		}()
 	}
 	wg.Wait()
@@ -734,10 +735,218 @@ func TestTopUpFailedDueToUnableToGetSourceAddressBalance(t *testing.T) {
 	m.db.ExpectQuery("SELECT.*public_txns.*data IS NULL").WillReturnRows(sqlmock.NewRows([]string{}))
 
 	// Mock the sufficient balance on the auto-fueling source address, and the nonce assignment
-	m.ethClient.On("GetBalance", mock.Anything, *bm.sourceAddress, "latest").Return(pldtypes.Uint64ToUint256(0), fmt.Errorf("pop")).Once()
+	m.ethClient.On("GetBalance", mock.Anything, bm.sources[0].address, "latest").Return(pldtypes.Uint64ToUint256(0), fmt.Errorf("pop")).Once()
 
 	fuelingTx, err := bm.TopUpAccount(ctx, accountToTopUp)
 	assert.Error(t, err)
 	assert.Nil(t, fuelingTx)
 	assert.Regexp(t, "pop", err.Error())
 }
+
+func withBackupFuelingSource(backupAddr *pldtypes.EthAddress, backupConf *pldconf.AutoFuelingSourceConfig) func(m *mocksAndTestControl, conf *pldconf.PublicTxManagerConfig) {
+	return func(m *mocksAndTestControl, conf *pldconf.PublicTxManagerConfig) {
+		m.disableManagerStart = true
+		conf.BalanceManager.AutoFueling.Sources = []*pldconf.AutoFuelingSourceConfig{backupConf}
+		mockKeyMgr := m.keyManager.(*componentmocks.KeyManager)
+		mockKeyMgr.On("ResolveKeyNewDatabaseTX", mock.Anything, backupConf.Source, mock.Anything, mock.Anything).
+			Return(&pldapi.KeyMappingAndVerifier{
+				KeyMappingWithPath: &pldapi.KeyMappingWithPath{KeyMapping: &pldapi.KeyMapping{Identifier: backupConf.Source}},
+				Verifier:           &pldapi.KeyVerifier{Verifier: backupAddr.String()},
+			}, nil).Maybe()
+	}
+}
+
+func TestNewBalanceManagerMultipleSources(t *testing.T) {
+	backupAddr := pldtypes.RandAddress()
+	_, bm, _, _, done := newTestBalanceManager(t, true, func(m *mocksAndTestControl, conf *pldconf.PublicTxManagerConfig) {
+		conf.BalanceManager.AutoFueling.SourceAddressMinBalance = confutil.P("100")
+		conf.BalanceManager.AutoFueling.SourceDailySpendingCap = confutil.P("1000")
+	}, withBackupFuelingSource(backupAddr, &pldconf.AutoFuelingSourceConfig{
+		Source:           "backup",
+		Priority:         -1,
+		DailySpendingCap: confutil.P("2000"),
+	}))
+	defer done()
+
+	require.Len(t, bm.sources, 2)
+	// the backup is used first, as it has a lower priority value
+	assert.Equal(t, "backup", bm.sources[0].source)
+	assert.Equal(t, *backupAddr, bm.sources[0].address)
+	assert.Nil(t, bm.sources[0].minBalance)
+	assert.Equal(t, int64(2000), bm.sources[0].dailySpendingCap.Int64())
+	assert.Equal(t, "autofueler", bm.sources[1].source)
+	assert.Equal(t, int64(100), bm.sources[1].minBalance.Int64())
+	assert.Equal(t, int64(1000), bm.sources[1].dailySpendingCap.Int64())
+}
+
+func TestNewBalanceManagerSourceErrors(t *testing.T) {
+	ctx, ble, m, done := newTestPublicTxManager(t, false)
+	defer done()
+
+	dupAddr := pldtypes.RandAddress()
+	withBackupFuelingSource(dupAddr, &pldconf.AutoFuelingSourceConfig{Source: "backup1"})(m, ble.conf)
+	withBackupFuelingSource(dupAddr, &pldconf.AutoFuelingSourceConfig{Source: "backup2"})(m, ble.conf)
+	ble.conf.BalanceManager.AutoFueling.Sources = []*pldconf.AutoFuelingSourceConfig{{Source: "backup1"}, {Source: "backup2"}}
+	_, err := NewBalanceManagerWithInMemoryTracking(ctx, ble.conf, ble)
+	assert.Regexp(t, "PD011955.*backup2", err)
+
+	m.keyManager.(*componentmocks.KeyManager).On("ResolveKeyNewDatabaseTX", mock.Anything, "unknown", mock.Anything, mock.Anything).
+		Return(nil, fmt.Errorf("pop"))
+	ble.conf.BalanceManager.AutoFueling.Sources = []*pldconf.AutoFuelingSourceConfig{{Source: "unknown"}}
+	_, err = NewBalanceManagerWithInMemoryTracking(ctx, ble.conf, ble)
+	assert.Regexp(t, "PD011934.*pop", err)
+}
+
+func TestTopUpFailoverToBackupSourceWhenDepleted(t *testing.T) {
+	backupAddr := pldtypes.RandAddress()
+	ctx, bm, _, m, done := newTestBalanceManager(t, true, withBackupFuelingSource(backupAddr, &pldconf.AutoFuelingSourceConfig{
+		Source:   "backup",
+		Priority: 1,
+	}))
+	defer done()
+
+	testDestAddress := *pldtypes.RandAddress()
+
+	// Mock no auto-fueling TX in flight from either source
+	m.db.ExpectQuery("SELECT.*public_txns.*data IS NULL").WillReturnRows(sqlmock.NewRows([]string{}))
+	m.db.ExpectQuery("SELECT.*public_txns.*data IS NULL").WillReturnRows(sqlmock.NewRows([]string{}))
+
+	// The primary source does not have enough to cover the transfer
+	m.ethClient.On("GetBalance", mock.Anything, bm.sources[0].address, "latest").Return(pldtypes.Uint64ToUint256(50), nil).Once()
+	m.ethClient.On("GetBalance", mock.Anything, *backupAddr, "latest").Return(pldtypes.Uint64ToUint256(400), nil).Once()
+	mockAutoFuelTransactionSubmit(m, bm, false)
+
+	fuelingTx, err := bm.TransferGasFromAutoFuelingSource(ctx, testDestAddress, big.NewInt(100))
+	require.NoError(t, err)
+	expectFuelingEqual(t, fuelingTx, 100, *backupAddr, testDestAddress)
+	assert.Equal(t, int64(0), bm.sources[0].spentToday.Int64())
+	assert.Equal(t, int64(100), bm.sources[1].spentToday.Int64())
+
+	fueling := bm.pubTxMgr.GetEngineMetrics(ctx).Fueling
+	assert.Equal(t, map[string]int64{fuelingFailoverReasonDepleted: 1}, fueling[bm.sources[0].address.String()].Failovers)
+	assert.Equal(t, int64(1), fueling[backupAddr.String()].Transactions)
+	assert.Equal(t, int64(100), fueling[backupAddr.String()].Spent.Int().Int64())
+}
+
+func TestTopUpFailoverFromStaleSource(t *testing.T) {
+	backupAddr := pldtypes.RandAddress()
+	ctx, bm, ble, m, done := newTestBalanceManager(t, true, withBackupFuelingSource(backupAddr, &pldconf.AutoFuelingSourceConfig{
+		Source:   "backup",
+		Priority: 1,
+	}), func(m *mocksAndTestControl, conf *pldconf.PublicTxManagerConfig) {
+		conf.Manager.MaxInFlightOrchestrators = confutil.P(2) // one for each source
+	})
+	defer done()
+
+	testDestAddress := *pldtypes.RandAddress()
+	addTestOrchestrator(t, ble, &orchestrator{signingAddress: bm.sources[0].address, state: OrchestratorStateStale})
+
+	// There is a fueling transaction pending from the primary source, but its orchestrator is stale
+	m.db.ExpectQuery("SELECT.*public_txns.*data IS NULL").
+		WillReturnRows(sqlmock.NewRows([]string{"from", "to", "value"}).AddRow(
+			bm.sources[0].address, testDestAddress, pldtypes.Uint64ToUint256(100),
+		))
+	m.db.ExpectQuery("SELECT.*public_txns").WillReturnRows(sqlmock.NewRows([]string{"from", "to", "value", `Completed__tx_hash`}).
+		AddRow(bm.sources[0].address, testDestAddress, pldtypes.Uint64ToUint256(100), nil /* incomplete */))
+
+	m.ethClient.On("GetBalance", mock.Anything, *backupAddr, "latest").Return(pldtypes.Uint64ToUint256(400), nil).Once()
+	mockAutoFuelTransactionSubmit(m, bm, false)
+
+	fuelingTx, err := bm.TransferGasFromAutoFuelingSource(ctx, testDestAddress, big.NewInt(100))
+	require.NoError(t, err)
+	expectFuelingEqual(t, fuelingTx, 100, *backupAddr, testDestAddress)
+
+	// With no sources left we get the error
+	addTestOrchestrator(t, ble, &orchestrator{signingAddress: *backupAddr, state: OrchestratorStateStale})
+	m.db.ExpectQuery("SELECT.*public_txns").WillReturnRows(sqlmock.NewRows([]string{"from", "to", "value", `Completed__tx_hash`}).
+		AddRow(*backupAddr, testDestAddress, pldtypes.Uint64ToUint256(100), nil /* incomplete */))
+	_, err = bm.TransferGasFromAutoFuelingSource(ctx, testDestAddress, big.NewInt(100))
+	assert.Regexp(t, "PD011954", err)
+}
+
+func TestTopUpDailySpendingCap(t *testing.T) {
+	ctx, bm, _, m, done := newTestBalanceManager(t, true, func(m *mocksAndTestControl, conf *pldconf.PublicTxManagerConfig) {
+		m.disableManagerStart = true
+		conf.BalanceManager.AutoFueling.SourceDailySpendingCap = confutil.P("150")
+	})
+	defer done()
+
+	// Mock no auto-fueling TX in flight
+	m.db.ExpectQuery("SELECT.*public_txns.*data IS NULL").WillReturnRows(sqlmock.NewRows([]string{}))
+	mockAutoFuelTransactionSubmit(m, bm, true)
+
+	fuelingTx, err := bm.TransferGasFromAutoFuelingSource(ctx, *pldtypes.RandAddress(), big.NewInt(100))
+	require.NoError(t, err)
+	assert.NotNil(t, fuelingTx)
+
+	// The next transfer would take us over the cap
+	m.db.ExpectQuery("SELECT.*public_txns.*data IS NULL").WillReturnRows(sqlmock.NewRows([]string{}))
+	_, err = bm.TransferGasFromAutoFuelingSource(ctx, *pldtypes.RandAddress(), big.NewInt(100))
+	assert.Regexp(t, "PD011953", err)
+
+	// Until the next day
+	bm.sources[0].spendDay = "2000-01-01"
+	m.db.ExpectQuery("SELECT.*public_txns.*data IS NULL").WillReturnRows(sqlmock.NewRows([]string{}))
+	mockAutoFuelTransactionSubmit(m, bm, false)
+	fuelingTx, err = bm.TransferGasFromAutoFuelingSource(ctx, *pldtypes.RandAddress(), big.NewInt(100))
+	require.NoError(t, err)
+	assert.NotNil(t, fuelingTx)
+	assert.Equal(t, int64(100), bm.sources[0].spentToday.Int64())
+}
+
+func TestGasTankReserveAndSettle(t *testing.T) {
+	ctx, bm, _, _, done := newTestBalanceManager(t, false)
+	defer done()
+
+	signer := *pldtypes.RandAddress()
+	assert.Equal(t, "0", bm.GetReservedGas(ctx, signer).String())
+
+	bm.ReserveGas(ctx, signer, 1, big.NewInt(100))
+	bm.ReserveGas(ctx, signer, 2, big.NewInt(50))
+	assert.Equal(t, "150", bm.GetReservedGas(ctx, signer).String())
+
+	// Re-pricing replaces the reservation
+	bm.ReserveGas(ctx, signer, 1, big.NewInt(120))
+	assert.Equal(t, "170", bm.GetReservedGas(ctx, signer).String())
+
+	// Settling releases the reservation, and means the balance is re-read from the chain
+	bm.SettleGas(ctx, signer, 1)
+	assert.Equal(t, "50", bm.GetReservedGas(ctx, signer).String())
+	assert.True(t, bm.addressBalanceChangedMap[signer])
+
+	// Settling again, or something never reserved, is a no-op
+	bm.addressBalanceChangedMap[signer] = false
+	bm.SettleGas(ctx, signer, 1)
+	bm.SettleGas(ctx, *pldtypes.RandAddress(), 1)
+	assert.False(t, bm.addressBalanceChangedMap[signer])
+
+	bm.SettleGas(ctx, signer, 2)
+	assert.Equal(t, "0", bm.GetReservedGas(ctx, signer).String())
+	assert.Empty(t, bm.gasTanks)
+}
+
+func TestTopUpSourceReservationsPreventOverCommit(t *testing.T) {
+	ctx, bm, _, m, done := newTestBalanceManager(t, true, func(m *mocksAndTestControl, conf *pldconf.PublicTxManagerConfig) {
+		m.disableManagerStart = true
+	})
+	defer done()
+
+	// The first fueling transaction reserves its value against the source
+	m.db.ExpectQuery("SELECT.*public_txns.*data IS NULL").WillReturnRows(sqlmock.NewRows([]string{}))
+	mockAutoFuelTransactionSubmit(m, bm, true)
+	fuelingTx, err := bm.TransferGasFromAutoFuelingSource(ctx, *pldtypes.RandAddress(), big.NewInt(300))
+	require.NoError(t, err)
+	assert.Equal(t, "300", bm.GetReservedGas(ctx, bm.sources[0].address).String())
+
+	// So the cached balance of 400 cannot fund another while it is in flight
+	m.db.ExpectQuery("SELECT.*public_txns.*data IS NULL").WillReturnRows(sqlmock.NewRows([]string{}))
+	_, err = bm.TransferGasFromAutoFuelingSource(ctx, *pldtypes.RandAddress(), big.NewInt(200))
+	assert.Regexp(t, "PD011900: Balance 100 of fueling source address", err)
+
+	// Once it completes, the balance is re-read to see what it actually cost
+	bm.SettleGas(ctx, bm.sources[0].address, *fuelingTx.LocalID)
+	m.db.ExpectQuery("SELECT.*public_txns.*data IS NULL").WillReturnRows(sqlmock.NewRows([]string{}))
+	m.ethClient.On("GetBalance", mock.Anything, bm.sources[0].address, "latest").Return(pldtypes.Uint64ToUint256(99), nil).Once()
+	_, err = bm.TransferGasFromAutoFuelingSource(ctx, *pldtypes.RandAddress(), big.NewInt(200))
+	assert.Regexp(t, "PD011900: Balance 99 of fueling source address", err)
+}
@@ -0,0 +1,176 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package publictxmgr
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/core/internal/components"
+	"github.com/kaleido-io/paladin/core/pkg/persistence"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+)
+
+type signerImpact struct {
+	impact    *pldapi.PublicTxSignerImpact
+	nextNonce uint64
+	gasCost   *big.Int
+	value     *big.Int
+	minCost   *big.Int
+	maxCost   *big.Int
+}
+
+// EstimateBatchImpact predicts the nonces a batch of transactions would be assigned, what they could cost each
+// signer, and whether auto-fueling would be needed to fund them. Nothing is persisted or reserved, so the result
+// is only provisional - other transactions submitted before the batch take the nonces and balance first.
+func (ptm *pubTxManager) EstimateBatchImpact(ctx context.Context, dbTX persistence.DBTX, txis []*components.PublicTxSubmission) (*pldapi.PublicTxBatchImpact, error) {
+	result := &pldapi.PublicTxBatchImpact{
+		Transactions: make([]*pldapi.PublicTxImpact, len(txis)),
+		Signers:      []*pldapi.PublicTxSignerImpact{},
+	}
+	signers := make(map[pldtypes.EthAddress]*signerImpact)
+	var signerOrder []*signerImpact
+	var nodeGasPricing *pldapi.PublicTxGasPricing
+	var nodeBlobGasPrice *pldtypes.HexUint256
+	for i, txi := range txis {
+		// Gas estimation (if required) and validation are shared with the real submission path
+		if err := ptm.ValidateTransaction(ctx, dbTX, txi); err != nil {
+			return nil, err
+		}
+
+		si := signers[*txi.From]
+		if si == nil {
+			nonce, err := ptm.provisionalNonce(ctx, dbTX, *txi.From)
+			if err != nil {
+				return nil, err
+			}
+			si = &signerImpact{
+				impact: &pldapi.PublicTxSignerImpact{
+					Signer:     *txi.From,
+					FirstNonce: pldtypes.HexUint64(nonce),
+				},
+				nextNonce: nonce,
+				gasCost:   big.NewInt(0),
+				value:     big.NewInt(0),
+				minCost:   big.NewInt(0),
+				maxCost:   big.NewInt(0),
+			}
+			signers[*txi.From] = si
+			signerOrder = append(signerOrder, si)
+		}
+
+		gasPricing := txi.PublicTxGasPricing
+		if !gasPricingSet(gasPricing) {
+			if nodeGasPricing == nil {
+				gpo, err := ptm.gasPriceClient.GetGasPriceObject(ctx)
+				if err != nil {
+					return nil, err
+				}
+				nodeGasPricing = gpo
+			}
+			gasPricing = *nodeGasPricing
+		}
+		if len(txi.Blobs) > 0 && gasPricing.MaxFeePerBlobGas == nil {
+			if nodeBlobGasPrice == nil {
+				blobGasPrice, err := ptm.gasPriceClient.GetBlobGasPrice(ctx)
+				if err != nil {
+					return nil, err
+				}
+				nodeBlobGasPrice = blobGasPrice
+			}
+			gasPricing.MaxFeePerBlobGas = nodeBlobGasPrice
+		}
+
+		// Costed just as the orchestrator costs each in-flight transaction against the balance of the signer
+		gasCost, _ := calculateGasRequiredForTransaction(ctx, &gasPricing, txi.Gas.Uint64())
+		if gasCost == nil {
+			gasCost = big.NewInt(0)
+		}
+		if len(txi.Blobs) > 0 && gasPricing.MaxFeePerBlobGas != nil {
+			gasCost.Add(gasCost, new(big.Int).Mul(gasPricing.MaxFeePerBlobGas.Int(), big.NewInt(int64(len(txi.Blobs)*blobGasPerBlob))))
+		}
+		si.gasCost.Add(si.gasCost, gasCost)
+		if txi.Value != nil {
+			si.value.Add(si.value, txi.Value.Int())
+		}
+		if si.minCost.Sign() == 0 || si.minCost.Cmp(gasCost) > 0 {
+			si.minCost = gasCost
+		}
+		if si.maxCost.Cmp(gasCost) < 0 {
+			si.maxCost = gasCost
+		}
+
+		result.Transactions[i] = &pldapi.PublicTxImpact{
+			From:    *txi.From,
+			To:      txi.To,
+			Nonce:   pldtypes.HexUint64(si.nextNonce),
+			GasCost: (*pldtypes.HexUint256)(gasCost),
+			PublicTxOptions: pldapi.PublicTxOptions{
+				Gas:                txi.Gas, // set by ValidateTransaction
+				Value:              txi.Value,
+				PublicTxGasPricing: gasPricing,
+			},
+		}
+		si.nextNonce++
+		si.impact.Transactions++
+	}
+
+	for _, si := range signerOrder {
+		if err := ptm.estimateSignerFunding(ctx, si); err != nil {
+			return nil, err
+		}
+		result.Signers = append(result.Signers, si.impact)
+	}
+	return result, nil
+}
+
+func (ptm *pubTxManager) estimateSignerFunding(ctx context.Context, si *signerImpact) error {
+	account, err := ptm.balanceManager.GetAddressBalance(ctx, si.impact.Signer)
+	if err != nil {
+		return err
+	}
+	reserved := ptm.balanceManager.GetReservedGas(ctx, si.impact.Signer)
+
+	si.impact.NextNonce = pldtypes.HexUint64(si.nextNonce)
+	si.impact.GasCost = (*pldtypes.HexUint256)(si.gasCost)
+	si.impact.Value = (*pldtypes.HexUint256)(si.value)
+	si.impact.Balance = (*pldtypes.HexUint256)(account.Balance)
+	si.impact.Reserved = (*pldtypes.HexUint256)(reserved)
+
+	// What is already reserved for transactions in flight is spent ahead of the batch
+	required := new(big.Int).Add(reserved, si.gasCost)
+	required.Add(required, si.value)
+	if required.Cmp(account.Balance) <= 0 {
+		return nil
+	}
+	si.impact.Shortfall = (*pldtypes.HexUint256)(new(big.Int).Sub(required, account.Balance))
+
+	if ptm.balanceManager.IsAutoFuelingEnabled(ctx) {
+		account.Spent = required
+		account.SpentTransactionCount = si.impact.Transactions
+		account.MinCost = new(big.Int).Set(si.minCost)
+		account.MaxCost = new(big.Int).Set(si.maxCost)
+		if topUp := ptm.balanceManager.CalculateTopUp(ctx, account); topUp != nil {
+			si.impact.AutoFuel = true
+			si.impact.AutoFuelAmount = (*pldtypes.HexUint256)(topUp)
+		}
+	}
+	log.L(ctx).Debugf("Estimated batch impact for signer %s: transactions=%d gasCost=%s value=%s balance=%s reserved=%s autoFuel=%t",
+		si.impact.Signer, si.impact.Transactions, si.gasCost, si.value, account.Balance, reserved, si.impact.AutoFuel)
+	return nil
+}
@@ -0,0 +1,174 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package publictxmgr
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/internal/components"
+	"github.com/kaleido-io/paladin/core/pkg/ethclient"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/toolkit/pkg/algorithms"
+	"github.com/kaleido-io/paladin/toolkit/pkg/verifiers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEstimateBatchImpactRealDB(t *testing.T) {
+	ctx, ptm, m, done := newTestPublicTxManager(t, true, func(mocks *mocksAndTestControl, conf *pldconf.PublicTxManagerConfig) {
+		conf.GasPrice.FixedGasPrice = nil
+		conf.BalanceManager.AutoFueling.Source = confutil.P("autofueler")
+	})
+	defer done()
+
+	m.ethClient.On("GasPrice", mock.Anything).Return(pldtypes.MustParseHexUint256("1000"), nil).Once()
+	m.ethClient.On("EstimateGasNoResolve", mock.Anything, mock.Anything, mock.Anything).
+		Return(ethclient.EstimateGasResult{GasLimit: pldtypes.HexUint64(20000)}, nil)
+	m.ethClient.On("GetTransactionCount", mock.Anything, mock.Anything).
+		Return(confutil.P(pldtypes.HexUint64(5)), nil)
+
+	resolveKey := func(name string) *pldtypes.EthAddress {
+		keyMapping, err := m.keyManager.ResolveKeyNewDatabaseTX(ctx, name, algorithms.ECDSA_SECP256K1, verifiers.ETH_ADDRESS)
+		require.NoError(t, err)
+		return pldtypes.MustEthAddress(keyMapping.Verifier.Verifier)
+	}
+	signer1 := resolveKey("signer1")
+	signer2 := resolveKey("signer2")
+	m.ethClient.On("GetBalance", mock.Anything, *signer1, "latest").Return(pldtypes.Uint64ToUint256(50_000_000), nil)
+	m.ethClient.On("GetBalance", mock.Anything, *signer2, "latest").Return(pldtypes.Uint64ToUint256(1_000_000_000), nil)
+
+	// An in-flight transaction of signer2 already has funds reserved
+	ptm.balanceManager.ReserveGas(ctx, *signer2, 1, big.NewInt(999_000_000))
+
+	to := pldtypes.RandAddress()
+	impact, err := ptm.EstimateBatchImpact(ctx, ptm.p.NOTX(), []*components.PublicTxSubmission{
+		{PublicTxInput: pldapi.PublicTxInput{From: signer1, To: to}},
+		{PublicTxInput: pldapi.PublicTxInput{From: signer2, To: to, PublicTxOptions: pldapi.PublicTxOptions{
+			Gas:   confutil.P(pldtypes.HexUint64(21000)),
+			Value: pldtypes.Uint64ToUint256(100),
+			PublicTxGasPricing: pldapi.PublicTxGasPricing{
+				GasPrice: pldtypes.Uint64ToUint256(10),
+			},
+		}}},
+		{PublicTxInput: pldapi.PublicTxInput{From: signer1, To: to}},
+	})
+	require.NoError(t, err)
+
+	require.Len(t, impact.Transactions, 3)
+	assert.Equal(t, pldtypes.HexUint64(5), impact.Transactions[0].Nonce)
+	assert.Equal(t, pldtypes.HexUint64(5), impact.Transactions[1].Nonce)
+	assert.Equal(t, pldtypes.HexUint64(6), impact.Transactions[2].Nonce)
+	assert.Equal(t, pldtypes.HexUint64(30000), *impact.Transactions[0].Gas)
+	assert.Equal(t, "30000000", impact.Transactions[0].GasCost.Int().String())
+	assert.Equal(t, "210000", impact.Transactions[1].GasCost.Int().String())
+
+	require.Len(t, impact.Signers, 2)
+	s1 := impact.Signers[0]
+	assert.Equal(t, *signer1, s1.Signer)
+	assert.Equal(t, 2, s1.Transactions)
+	assert.Equal(t, pldtypes.HexUint64(5), s1.FirstNonce)
+	assert.Equal(t, pldtypes.HexUint64(7), s1.NextNonce)
+	assert.Equal(t, "60000000", s1.GasCost.Int().String())
+	assert.Equal(t, "10000000", s1.Shortfall.Int().String())
+	assert.True(t, s1.AutoFuel)
+	assert.GreaterOrEqual(t, s1.AutoFuelAmount.Int().Cmp(s1.Shortfall.Int()), 0)
+
+	s2 := impact.Signers[1]
+	assert.Equal(t, *signer2, s2.Signer)
+	assert.Equal(t, "100", s2.Value.Int().String())
+	assert.Equal(t, "999000000", s2.Reserved.Int().String())
+	assert.Nil(t, s2.Shortfall)
+	assert.False(t, s2.AutoFuel)
+
+	// Nothing was written
+	var count int64
+	err = ptm.p.DB().Table("public_txns").Count(&count).Error
+	require.NoError(t, err)
+	assert.Zero(t, count)
+
+	// Reservations push signer2 into a shortfall, which is reported but not fueled without auto-fueling
+	ptm.balanceManager.(*BalanceManagerWithInMemoryTracking).sources = nil
+	ptm.balanceManager.ReserveGas(ctx, *signer2, 2, big.NewInt(1_000_000))
+	impact, err = ptm.EstimateBatchImpact(ctx, ptm.p.NOTX(), []*components.PublicTxSubmission{
+		{PublicTxInput: pldapi.PublicTxInput{From: signer2, To: to, PublicTxOptions: pldapi.PublicTxOptions{
+			Gas: confutil.P(pldtypes.HexUint64(21000)),
+			PublicTxGasPricing: pldapi.PublicTxGasPricing{
+				MaxFeePerGas:         pldtypes.Uint64ToUint256(10),
+				MaxPriorityFeePerGas: pldtypes.Uint64ToUint256(1),
+			},
+		}}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "210000", impact.Signers[0].Shortfall.Int().String())
+	assert.False(t, impact.Signers[0].AutoFuel)
+	assert.Nil(t, impact.Signers[0].AutoFuelAmount)
+}
+
+func TestEstimateBatchImpactErrors(t *testing.T) {
+	ctx, ptm, m, done := newTestPublicTxManager(t, false, func(mocks *mocksAndTestControl, conf *pldconf.PublicTxManagerConfig) {
+		conf.GasPrice.FixedGasPrice = nil
+	})
+	defer done()
+
+	from := pldtypes.RandAddress()
+	batch := func() []*components.PublicTxSubmission {
+		return []*components.PublicTxSubmission{{PublicTxInput: pldapi.PublicTxInput{
+			From: from,
+			To:   pldtypes.RandAddress(),
+			PublicTxOptions: pldapi.PublicTxOptions{
+				Gas:   confutil.P(pldtypes.HexUint64(21000)),
+				Blobs: []pldtypes.HexBytes{[]byte("blob zero")},
+			},
+		}}}
+	}
+
+	m.ethClient.On("GetTransactionCount", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("pop")).Once()
+	m.db.ExpectQuery("SELECT.*public_txns").WillReturnRows(m.db.NewRows([]string{}))
+	_, err := ptm.EstimateBatchImpact(ctx, ptm.p.NOTX(), batch())
+	assert.Regexp(t, "pop", err)
+
+	m.ethClient.On("GetTransactionCount", mock.Anything, mock.Anything).Return(confutil.P(pldtypes.HexUint64(5)), nil)
+	m.ethClient.On("GasPrice", mock.Anything).Return(nil, fmt.Errorf("pop")).Once()
+	m.db.ExpectQuery("SELECT.*public_txns").WillReturnRows(m.db.NewRows([]string{}))
+	_, err = ptm.EstimateBatchImpact(ctx, ptm.p.NOTX(), batch())
+	assert.Regexp(t, "pop", err)
+
+	m.ethClient.On("GasPrice", mock.Anything).Return(pldtypes.MustParseHexUint256("1000"), nil)
+	m.ethClient.On("BlobBaseFee", mock.Anything).Return(nil, fmt.Errorf("pop")).Once()
+	m.db.ExpectQuery("SELECT.*public_txns").WillReturnRows(m.db.NewRows([]string{}))
+	_, err = ptm.EstimateBatchImpact(ctx, ptm.p.NOTX(), batch())
+	assert.Regexp(t, "pop", err)
+
+	m.ethClient.On("BlobBaseFee", mock.Anything).Return(pldtypes.MustParseHexUint256("50"), nil)
+	m.ethClient.On("GetBalance", mock.Anything, *from, "latest").Return(nil, fmt.Errorf("pop")).Once()
+	m.db.ExpectQuery("SELECT.*public_txns").WillReturnRows(m.db.NewRows([]string{}))
+	_, err = ptm.EstimateBatchImpact(ctx, ptm.p.NOTX(), batch())
+	assert.Regexp(t, "pop", err)
+
+	m.ethClient.On("EstimateGasNoResolve", mock.Anything, mock.Anything, mock.Anything).
+		Return(ethclient.EstimateGasResult{}, fmt.Errorf("pop"))
+	_, err = ptm.EstimateBatchImpact(ctx, ptm.p.NOTX(), []*components.PublicTxSubmission{{PublicTxInput: pldapi.PublicTxInput{
+		From: from,
+		To:   pldtypes.RandAddress(),
+	}}})
+	assert.Regexp(t, "pop", err)
+}
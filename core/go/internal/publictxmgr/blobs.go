@@ -0,0 +1,231 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package publictxmgr
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"math/big"
+	"sync"
+
+	gokzg4844 "github.com/crate-crypto/go-kzg-4844"
+	"github.com/hyperledger/firefly-signer/pkg/ethsigner"
+	"github.com/hyperledger/firefly-signer/pkg/rlp"
+	"github.com/hyperledger/firefly-signer/pkg/secp256k1"
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/core/internal/msgs"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+)
+
+const (
+	blobSize                 = gokzg4844.ScalarsPerBlob * gokzg4844.SerializedScalarSize
+	blobPackedDataSize       = gokzg4844.ScalarsPerBlob * (gokzg4844.SerializedScalarSize - 1) // the top byte of each field element is left zero
+	blobGasPerBlob           = 1 << 17
+	maxBlobsPerTransaction   = 6
+	blobTransactionType      = 0x03
+	blobCommitmentVersionKZG = 0x01
+)
+
+// The order of the BLS12-381 scalar field, which every 32 byte field element of a blob must be less than
+var blsModulus = pldtypes.MustParseHexBytes("0x73eda753299d7d483339d80809a1d80553bda402fffe5bfeffffffff00000001")
+
+// Loading the trusted setup is expensive, so we only do it the first time a blob transaction is submitted
+var kzg struct {
+	once sync.Once
+	ctx  *gokzg4844.Context
+	err  error
+}
+
+func getKZGContext() (*gokzg4844.Context, error) {
+	kzg.once.Do(func() {
+		kzg.ctx, kzg.err = gokzg4844.NewContext4096Secure()
+	})
+	return kzg.ctx, kzg.err
+}
+
+// blobTransaction is the additional information needed to sign a type 3 transaction
+type blobTransaction struct {
+	maxFeePerBlobGas *big.Int
+	sidecar          []*DBPublicTxnBlob
+}
+
+// validateBlobs checks the blobs supplied on a transaction can be encoded, without computing the commitments.
+// Data can be supplied either as a complete blob of field elements, or as a shorter payload that is packed
+// 31 bytes into each field element.
+func validateBlobs(ctx context.Context, blobs []pldtypes.HexBytes) error {
+	if len(blobs) > maxBlobsPerTransaction {
+		return i18n.NewError(ctx, msgs.MsgBlobTxTooManyBlobs, len(blobs), maxBlobsPerTransaction)
+	}
+	for i, data := range blobs {
+		switch {
+		case len(data) == blobSize:
+			for fe := 0; fe < gokzg4844.ScalarsPerBlob; fe++ {
+				element := data[fe*gokzg4844.SerializedScalarSize : (fe+1)*gokzg4844.SerializedScalarSize]
+				if bytes.Compare(element, blsModulus) >= 0 {
+					return i18n.NewError(ctx, msgs.MsgBlobTxInvalidFieldElement, i, fe)
+				}
+			}
+		case len(data) > blobPackedDataSize:
+			return i18n.NewError(ctx, msgs.MsgBlobTxBlobTooLarge, i, len(data), blobPackedDataSize, blobSize)
+		}
+	}
+	return nil
+}
+
+func toKZGBlob(data []byte) *gokzg4844.Blob {
+	var blob gokzg4844.Blob
+	if len(data) == blobSize {
+		copy(blob[:], data)
+		return &blob
+	}
+	for fe := 0; len(data) > 0; fe++ {
+		n := copy(blob[fe*gokzg4844.SerializedScalarSize+1:(fe+1)*gokzg4844.SerializedScalarSize], data)
+		data = data[n:]
+	}
+	return &blob
+}
+
+func blobVersionedHash(commitment []byte) pldtypes.Bytes32 {
+	hash := pldtypes.Bytes32(sha256.Sum256(commitment))
+	hash[0] = blobCommitmentVersionKZG
+	return hash
+}
+
+func blobHashes(sidecar []*DBPublicTxnBlob) []pldtypes.Bytes32 {
+	if len(sidecar) == 0 {
+		return nil
+	}
+	hashes := make([]pldtypes.Bytes32, len(sidecar))
+	for i, b := range sidecar {
+		hashes[i] = b.VersionedHash
+	}
+	return hashes
+}
+
+// buildBlobSidecar encodes the blobs, and computes the KZG commitment, proof and versioned hash of each
+func buildBlobSidecar(ctx context.Context, blobs []pldtypes.HexBytes) ([]*DBPublicTxnBlob, error) {
+	if err := validateBlobs(ctx, blobs); err != nil {
+		return nil, err
+	}
+	kzgCtx, err := getKZGContext()
+	if err != nil {
+		return nil, err
+	}
+	sidecar := make([]*DBPublicTxnBlob, len(blobs))
+	for i, data := range blobs {
+		blob := toKZGBlob(data)
+		commitment, err := kzgCtx.BlobToKZGCommitment(blob, 0)
+		if err != nil {
+			return nil, i18n.WrapError(ctx, err, msgs.MsgBlobTxInvalidBlob, i)
+		}
+		proof, err := kzgCtx.ComputeBlobKZGProof(blob, commitment, 0)
+		if err != nil {
+			return nil, i18n.WrapError(ctx, err, msgs.MsgBlobTxInvalidBlob, i)
+		}
+		sidecar[i] = &DBPublicTxnBlob{
+			Index:         i,
+			VersionedHash: blobVersionedHash(commitment[:]),
+			Commitment:    commitment[:],
+			Proof:         proof[:],
+			Blob:          blob[:],
+		}
+	}
+	return sidecar, nil
+}
+
+// blobGasPricingSet checks that fixed gas pricing for a blob transaction is complete, as we cannot
+// supplement it with the blob base fee from the node (or the reverse)
+func blobGasPricingSet(ctx context.Context, gasPricing *pldapi.PublicTxGasPricing) (bool, error) {
+	set := gasPricingSet(*gasPricing)
+	if set != (gasPricing.MaxFeePerBlobGas != nil) {
+		return false, i18n.NewError(ctx, msgs.MsgBlobTxMissingBlobFee)
+	}
+	return set, nil
+}
+
+// buildBlobTxPayload builds the list of fields for a type 3 transaction, which is the same as
+// EIP-1559 with the max fee per blob gas and the versioned hashes of the blobs added at the end.
+// A legacy gas price is used for both the max fee and max priority fee.
+func buildBlobTxPayload(chainID int64, ethTx *ethsigner.Transaction, blobTx *blobTransaction) rlp.List {
+	maxPriorityFeePerGas, maxFeePerGas := ethTx.MaxPriorityFeePerGas.BigInt(), ethTx.MaxFeePerGas.BigInt()
+	if ethTx.MaxFeePerGas == nil && ethTx.MaxPriorityFeePerGas == nil {
+		maxPriorityFeePerGas, maxFeePerGas = ethTx.GasPrice.BigInt(), ethTx.GasPrice.BigInt()
+	}
+	versionedHashes := make(rlp.List, len(blobTx.sidecar))
+	for i, b := range blobTx.sidecar {
+		versionedHashes[i] = rlp.Data(b.VersionedHash[:])
+	}
+	return rlp.List{
+		rlp.WrapInt(big.NewInt(chainID)),
+		rlp.WrapInt(ethTx.Nonce.BigInt()),
+		rlp.WrapInt(maxPriorityFeePerGas),
+		rlp.WrapInt(maxFeePerGas),
+		rlp.WrapInt(ethTx.GasLimit.BigInt()),
+		rlp.WrapAddress(ethTx.To),
+		rlp.WrapInt(ethTx.Value.BigInt()),
+		rlp.Data(ethTx.Data),
+		rlp.List{}, // access list not currently supported
+		rlp.WrapInt(blobTx.maxFeePerBlobGas),
+		versionedHashes,
+	}
+}
+
+// finalizeBlobTx returns the network form of the signed transaction, which wraps the transaction with the
+// blobs, commitments and proofs for eth_sendRawTransaction, along with the hash of the transaction (which
+// does not include the sidecar)
+func finalizeBlobTx(payload rlp.List, sig *secp256k1.SignatureData, blobTx *blobTransaction) ([]byte, *pldtypes.Bytes32) {
+	sig.UpdateEIP2930()
+	signed := append(payload, rlp.WrapInt(sig.V), rlp.WrapInt(sig.R), rlp.WrapInt(sig.S))
+	txHash := calculateTransactionHash(append([]byte{blobTransactionType}, signed.Encode()...))
+
+	blobs := make(rlp.List, len(blobTx.sidecar))
+	commitments := make(rlp.List, len(blobTx.sidecar))
+	proofs := make(rlp.List, len(blobTx.sidecar))
+	for i, b := range blobTx.sidecar {
+		blobs[i] = rlp.Data(b.Blob)
+		commitments[i] = rlp.Data(b.Commitment)
+		proofs[i] = rlp.Data(b.Proof)
+	}
+	networkForm := rlp.List{signed, blobs, commitments, proofs}
+	return append([]byte{blobTransactionType}, networkForm.Encode()...), txHash
+}
+
+// loadBlobTransaction reads the sidecar back from the DB to sign the transaction, as it is too large
+// to keep in memory for every in-flight transaction
+func (it *inFlightTransactionStageController) loadBlobTransaction(ctx context.Context, pubTxnID uint64, blobHashes []pldtypes.Bytes32, maxFeePerBlobGas *big.Int) (*blobTransaction, error) {
+	var sidecar []*DBPublicTxnBlob
+	err := it.p.DB().
+		WithContext(ctx).
+		Where("pub_txn_id = ?", pubTxnID).
+		Order("idx").
+		Find(&sidecar).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	if len(sidecar) != len(blobHashes) {
+		return nil, i18n.NewError(ctx, msgs.MsgBlobTxMissingSidecar, pubTxnID, len(blobHashes), len(sidecar))
+	}
+	if maxFeePerBlobGas == nil {
+		maxFeePerBlobGas = new(big.Int)
+	}
+	return &blobTransaction{
+		maxFeePerBlobGas: maxFeePerBlobGas,
+		sidecar:          sidecar,
+	}, nil
+}
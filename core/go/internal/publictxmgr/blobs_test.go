@@ -0,0 +1,297 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package publictxmgr
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	gokzg4844 "github.com/crate-crypto/go-kzg-4844"
+	"github.com/hyperledger/firefly-signer/pkg/rlp"
+	"github.com/hyperledger/firefly-signer/pkg/secp256k1"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/internal/components"
+	"github.com/kaleido-io/paladin/core/pkg/ethclient"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/query"
+	"github.com/kaleido-io/paladin/toolkit/pkg/algorithms"
+	"github.com/kaleido-io/paladin/toolkit/pkg/verifiers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateBlobs(t *testing.T) {
+	ctx := context.Background()
+
+	tooMany := make([]pldtypes.HexBytes, maxBlobsPerTransaction+1)
+	assert.Regexp(t, "PD011946", validateBlobs(ctx, tooMany))
+
+	assert.Regexp(t, "PD011947.*126,977", validateBlobs(ctx, []pldtypes.HexBytes{make([]byte, blobPackedDataSize+1)}))
+
+	fullBlob := make([]byte, blobSize)
+	require.NoError(t, validateBlobs(ctx, []pldtypes.HexBytes{fullBlob, make([]byte, blobPackedDataSize)}))
+
+	copy(fullBlob[64:], blsModulus)
+	assert.Regexp(t, "PD011950.*Blob 1.*index 2", validateBlobs(ctx, []pldtypes.HexBytes{{}, fullBlob}))
+}
+
+func TestBuildBlobSidecar(t *testing.T) {
+	ctx := context.Background()
+
+	data := make([]byte, 100)
+	for i := range data {
+		data[i] = 0xff
+	}
+	sidecar, err := buildBlobSidecar(ctx, []pldtypes.HexBytes{data})
+	require.NoError(t, err)
+	require.Len(t, sidecar, 1)
+
+	// Packed 31 bytes into each field element, leaving the top byte zero
+	blob := sidecar[0].Blob
+	require.Len(t, blob, blobSize)
+	assert.Equal(t, byte(0), blob[0])
+	assert.Equal(t, data[0:31], []byte(blob[1:32]))
+	assert.Equal(t, byte(0), blob[32])
+	assert.Equal(t, data[31:62], []byte(blob[33:64]))
+	assert.Equal(t, make([]byte, blobSize-128), []byte(blob[128:]))
+
+	assert.Equal(t, byte(blobCommitmentVersionKZG), sidecar[0].VersionedHash[0])
+	assert.Equal(t, blobVersionedHash(sidecar[0].Commitment), sidecar[0].VersionedHash)
+
+	kzgCtx, err := getKZGContext()
+	require.NoError(t, err)
+	err = kzgCtx.VerifyBlobKZGProof(
+		(*gokzg4844.Blob)(blob),
+		gokzg4844.KZGCommitment(sidecar[0].Commitment),
+		gokzg4844.KZGProof(sidecar[0].Proof),
+	)
+	require.NoError(t, err)
+
+	_, err = buildBlobSidecar(ctx, make([]pldtypes.HexBytes, maxBlobsPerTransaction+1))
+	assert.Regexp(t, "PD011946", err)
+}
+
+func TestBlobGasPricingSet(t *testing.T) {
+	ctx := context.Background()
+
+	set, err := blobGasPricingSet(ctx, &pldapi.PublicTxGasPricing{})
+	require.NoError(t, err)
+	assert.False(t, set)
+
+	set, err = blobGasPricingSet(ctx, &pldapi.PublicTxGasPricing{
+		GasPrice:         pldtypes.Int64ToInt256(10),
+		MaxFeePerBlobGas: pldtypes.Int64ToInt256(1),
+	})
+	require.NoError(t, err)
+	assert.True(t, set)
+
+	_, err = blobGasPricingSet(ctx, &pldapi.PublicTxGasPricing{MaxFeePerGas: pldtypes.Int64ToInt256(10)})
+	assert.Regexp(t, "PD011951", err)
+
+	_, err = blobGasPricingSet(ctx, &pldapi.PublicTxGasPricing{MaxFeePerBlobGas: pldtypes.Int64ToInt256(1)})
+	assert.Regexp(t, "PD011951", err)
+}
+
+func TestBlobTransactionValidation(t *testing.T) {
+	ctx, ptm, _, done := newTestPublicTxManager(t, false)
+	defer done()
+
+	err := ptm.ValidateTransaction(ctx, ptm.p.NOTX(), &components.PublicTxSubmission{
+		PublicTxInput: pldapi.PublicTxInput{
+			From:            pldtypes.RandAddress(),
+			PublicTxOptions: pldapi.PublicTxOptions{Blobs: []pldtypes.HexBytes{{0x01}}},
+		},
+	})
+	assert.Regexp(t, "PD011949", err)
+
+	err = ptm.ValidateTransaction(ctx, ptm.p.NOTX(), &components.PublicTxSubmission{
+		PublicTxInput: pldapi.PublicTxInput{
+			From: pldtypes.RandAddress(),
+			To:   pldtypes.RandAddress(),
+			PublicTxOptions: pldapi.PublicTxOptions{
+				Blobs: []pldtypes.HexBytes{{0x01}},
+				PublicTxGasPricing: pldapi.PublicTxGasPricing{
+					GasPrice: pldtypes.Int64ToInt256(10),
+				},
+			},
+		},
+	})
+	assert.Regexp(t, "PD011951", err)
+
+	err = ptm.ValidateTransaction(ctx, ptm.p.NOTX(), &components.PublicTxSubmission{
+		PublicTxInput: pldapi.PublicTxInput{
+			From:            pldtypes.RandAddress(),
+			To:              pldtypes.RandAddress(),
+			PublicTxOptions: pldapi.PublicTxOptions{Blobs: []pldtypes.HexBytes{make([]byte, blobSize+1)}},
+		},
+	})
+	assert.Regexp(t, "PD011947", err)
+}
+
+func TestBlobTransactionLifecycleRealKeyMgrAndDB(t *testing.T) {
+	ctx, ptm, m, done := newTestPublicTxManager(t, true, func(mocks *mocksAndTestControl, conf *pldconf.PublicTxManagerConfig) {
+		conf.Manager.Interval = confutil.P("50ms")
+		conf.Orchestrator.Interval = confutil.P("50ms")
+		conf.GasPrice.FixedGasPrice = nil
+	})
+	defer done()
+
+	chainID := int64(12345)
+	m.ethClient.On("GasPrice", mock.Anything).Return(pldtypes.MustParseHexUint256("1000"), nil)
+	m.ethClient.On("BlobBaseFee", mock.Anything).Return(pldtypes.MustParseHexUint256("50"), nil)
+	m.ethClient.On("ChainID").Return(chainID)
+	m.ethClient.On("EstimateGasNoResolve", mock.Anything, mock.Anything, mock.Anything).
+		Return(ethclient.EstimateGasResult{GasLimit: pldtypes.HexUint64(21000)}, nil)
+	m.ethClient.On("GetTransactionCount", mock.Anything, mock.Anything).
+		Return(confutil.P(pldtypes.HexUint64(5)), nil).Once()
+
+	keyMapping, err := m.keyManager.ResolveKeyNewDatabaseTX(ctx, "signer1", algorithms.ECDSA_SECP256K1, verifiers.ETH_ADDRESS)
+	require.NoError(t, err)
+	resolvedKey := pldtypes.MustEthAddress(keyMapping.Verifier.Verifier)
+
+	submitted := make(chan pldtypes.HexBytes, 1)
+	srtx := m.ethClient.On("SendRawTransaction", mock.Anything, mock.Anything)
+	srtx.Run(func(args mock.Arguments) {
+		submitted <- args[1].(pldtypes.HexBytes)
+		srtx.Return(nil, nil)
+	})
+
+	to := pldtypes.RandAddress()
+	tx, err := ptm.SingleTransactionSubmit(ctx, &components.PublicTxSubmission{
+		PublicTxInput: pldapi.PublicTxInput{
+			From: resolvedKey,
+			To:   to,
+			PublicTxOptions: pldapi.PublicTxOptions{
+				Blobs: []pldtypes.HexBytes{[]byte("blob zero"), []byte("blob one")},
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, tx.BlobHashes, 2)
+
+	// The hashes are returned on queries
+	txRead, err := ptm.QueryPublicTxWithBindings(ctx, ptm.p.NOTX(), query.NewQueryBuilder().Equal("localId", *tx.LocalID).Limit(1).Query())
+	require.NoError(t, err)
+	require.Len(t, txRead, 1)
+	assert.Equal(t, tx.BlobHashes, txRead[0].BlobHashes)
+
+	var signedMessage pldtypes.HexBytes
+	select {
+	case signedMessage = <-submitted:
+	case <-time.After(10 * time.Second):
+		require.FailNow(t, "timed out waiting for submission")
+	}
+
+	// Network form is the type byte, then the signed transaction wrapped with the sidecar
+	require.Equal(t, byte(blobTransactionType), signedMessage[0])
+	decoded, _, err := rlp.Decode(signedMessage[1:])
+	require.NoError(t, err)
+	networkForm := decoded.(rlp.List)
+	require.Len(t, networkForm, 4)
+	signed := networkForm[0].(rlp.List)
+	require.Len(t, signed, 14)
+	assert.Equal(t, int64(chainID), signed[0].(rlp.Data).Int().Int64())
+	assert.Equal(t, int64(5), signed[1].(rlp.Data).Int().Int64())
+	assert.Equal(t, int64(1000), signed[2].(rlp.Data).Int().Int64()) // legacy gas price used for both fees
+	assert.Equal(t, int64(1000), signed[3].(rlp.Data).Int().Int64())
+	assert.Equal(t, to.String(), signed[5].(rlp.Data).Address().String())
+	assert.Equal(t, int64(100), signed[9].(rlp.Data).Int().Int64()) // blob base fee with the default multiplier of 2
+	versionedHashes := signed[10].(rlp.List)
+	require.Len(t, versionedHashes, 2)
+	for i, h := range versionedHashes {
+		assert.Equal(t, tx.BlobHashes[i][:], []byte(h.(rlp.Data)))
+	}
+	require.Len(t, networkForm[1].(rlp.List), 2)
+	assert.Len(t, []byte(networkForm[1].(rlp.List)[0].(rlp.Data)), blobSize)
+
+	// Signed by the key over the payload without the signature
+	sig := &secp256k1.SignatureData{
+		V: signed[11].(rlp.Data).IntOrZero(),
+		R: signed[12].(rlp.Data).Int(),
+		S: signed[13].(rlp.Data).Int(),
+	}
+	signer, err := sig.Recover(append([]byte{blobTransactionType}, signed[0:11].Encode()...), chainID)
+	require.NoError(t, err)
+	assert.Equal(t, resolvedKey.String(), signer.String())
+
+	// The transaction hash recorded does not include the sidecar
+	expectedHash := calculateTransactionHash(append([]byte{blobTransactionType}, signed.Encode()...))
+	require.Eventually(t, func() bool {
+		ptx, err := ptm.GetPublicTransactionForHash(ctx, ptm.p.NOTX(), *expectedHash)
+		require.NoError(t, err)
+		return ptx != nil
+	}, 10*time.Second, 50*time.Millisecond)
+}
+
+func TestCalculateNewBlobGasPrice(t *testing.T) {
+	ctx, o, _, done := newTestOrchestrator(t)
+	defer done()
+	it, _ := newInflightTransaction(o, 1)
+	it.blobFeeIncreasePercent = 100
+	it.blobFeeIncreaseMax = big.NewInt(300)
+
+	// A higher blob fee from the node is used as is
+	gpo := it.calculateNewGasPrice(ctx,
+		&pldapi.PublicTxGasPricing{GasPrice: pldtypes.Int64ToInt256(10), MaxFeePerBlobGas: pldtypes.Int64ToInt256(100)},
+		&pldapi.PublicTxGasPricing{GasPrice: pldtypes.Int64ToInt256(20), MaxFeePerBlobGas: pldtypes.Int64ToInt256(120)},
+	)
+	assert.Equal(t, int64(20), gpo.GasPrice.Int().Int64())
+	assert.Equal(t, int64(120), gpo.MaxFeePerBlobGas.Int().Int64())
+
+	// Otherwise the blob fee is escalated separately to the gas price
+	newGpo := &pldapi.PublicTxGasPricing{GasPrice: pldtypes.Int64ToInt256(20), MaxFeePerBlobGas: pldtypes.Int64ToInt256(100)}
+	gpo = it.calculateNewGasPrice(ctx,
+		&pldapi.PublicTxGasPricing{GasPrice: pldtypes.Int64ToInt256(10), MaxFeePerBlobGas: pldtypes.Int64ToInt256(100)},
+		newGpo,
+	)
+	assert.Equal(t, int64(20), gpo.GasPrice.Int().Int64())
+	assert.Equal(t, int64(200), gpo.MaxFeePerBlobGas.Int().Int64())
+	assert.Equal(t, int64(100), newGpo.MaxFeePerBlobGas.Int().Int64())
+
+	// Up to the max
+	gpo = it.calculateNewGasPrice(ctx,
+		&pldapi.PublicTxGasPricing{GasPrice: pldtypes.Int64ToInt256(10), MaxFeePerBlobGas: pldtypes.Int64ToInt256(200)},
+		&pldapi.PublicTxGasPricing{GasPrice: pldtypes.Int64ToInt256(20), MaxFeePerBlobGas: pldtypes.Int64ToInt256(50)},
+	)
+	assert.Equal(t, int64(300), gpo.MaxFeePerBlobGas.Int().Int64())
+}
+
+func TestLoadBlobTransactionErrors(t *testing.T) {
+	ctx, o, m, done := newTestOrchestrator(t)
+	defer done()
+	it, _ := newInflightTransaction(o, 1)
+	blobHashes := []pldtypes.Bytes32{pldtypes.RandBytes32()}
+
+	m.db.ExpectQuery("SELECT.*public_txn_blobs").WillReturnError(fmt.Errorf("pop"))
+	_, err := it.loadBlobTransaction(ctx, 12345, blobHashes, nil)
+	assert.Regexp(t, "pop", err)
+
+	m.db.ExpectQuery("SELECT.*public_txn_blobs").WillReturnRows(sqlmock.NewRows([]string{}))
+	_, err = it.loadBlobTransaction(ctx, 12345, blobHashes, nil)
+	assert.Regexp(t, "PD011952", err)
+
+	m.db.ExpectQuery("SELECT.*public_txn_blobs").WillReturnRows(sqlmock.NewRows([]string{"pub_txn_id", "idx"}).AddRow(12345, 0))
+	blobTx, err := it.loadBlobTransaction(ctx, 12345, blobHashes, nil)
+	require.NoError(t, err)
+	assert.Zero(t, blobTx.maxFeePerBlobGas.Sign())
+}
@@ -0,0 +1,103 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package publictxmgr
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"context"
+	"io"
+	"strings"
+
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/core/internal/msgs"
+	"gorm.io/gorm"
+)
+
+const (
+	calldataCodecGzip    = "gzip"
+	calldataCodecDeflate = "deflate"
+)
+
+// Returns the empty string if compression is disabled
+func parseCalldataCodec(ctx context.Context, codec string) (string, error) {
+	switch strings.ToLower(codec) {
+	case "", "none":
+		return "", nil
+	case calldataCodecGzip:
+		return calldataCodecGzip, nil
+	case calldataCodecDeflate:
+		return calldataCodecDeflate, nil
+	default:
+		return "", i18n.NewError(ctx, msgs.MsgPublicTxCalldataCodecInvalid, codec)
+	}
+}
+
+// Returns a copy of the transaction for writing to the DB, with the calldata compressed if it is
+// over the threshold. The transaction passed in is not modified, as the orchestrators and API
+// results work with the uncompressed calldata.
+func (ptm *pubTxManager) compressCalldata(ptx *DBPublicTxn) *DBPublicTxn {
+	if ptm.calldataCodec == "" || int64(len(ptx.Data)) < ptm.calldataCompressionThreshold {
+		return ptx
+	}
+	var buf bytes.Buffer
+	var w io.WriteCloser
+	if ptm.calldataCodec == calldataCodecGzip {
+		w = gzip.NewWriter(&buf)
+	} else {
+		w, _ = flate.NewWriter(&buf, flate.DefaultCompression) // only errors on an invalid level
+	}
+	// Writes to an in-memory buffer cannot fail
+	_, _ = w.Write(ptx.Data)
+	_ = w.Close()
+	// Calldata that does not compress (it might already be compressed) is stored as-is
+	if buf.Len() >= len(ptx.Data) {
+		return ptx
+	}
+	compressed := *ptx
+	compressed.Data = buf.Bytes()
+	compressed.DataCodec = &ptm.calldataCodec
+	return &compressed
+}
+
+// Calldata is decompressed as it is read, so it is never seen compressed outside of the DB
+func (ptx *DBPublicTxn) AfterFind(tx *gorm.DB) error {
+	if ptx.DataCodec == nil {
+		return nil
+	}
+	ctx := tx.Statement.Context
+	var r io.Reader
+	switch *ptx.DataCodec {
+	case calldataCodecGzip:
+		gzr, err := gzip.NewReader(bytes.NewReader(ptx.Data))
+		if err != nil {
+			return i18n.WrapError(ctx, err, msgs.MsgPublicTxCalldataDecodeFailed, ptx.PublicTxnID, *ptx.DataCodec)
+		}
+		r = gzr
+	case calldataCodecDeflate:
+		r = flate.NewReader(bytes.NewReader(ptx.Data))
+	default:
+		return i18n.NewError(ctx, msgs.MsgPublicTxCalldataDecodeFailed, ptx.PublicTxnID, *ptx.DataCodec)
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return i18n.WrapError(ctx, err, msgs.MsgPublicTxCalldataDecodeFailed, ptx.PublicTxnID, *ptx.DataCodec)
+	}
+	ptx.Data = data
+	ptx.DataCodec = nil
+	return nil
+}
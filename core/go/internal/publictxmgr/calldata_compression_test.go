@@ -0,0 +1,151 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package publictxmgr
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/internal/components"
+	"github.com/kaleido-io/paladin/core/pkg/persistence"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/query"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type storedCalldata struct {
+	Data      []byte  `gorm:"column:data"`
+	DataCodec *string `gorm:"column:data_codec"`
+}
+
+func readStoredCalldata(t *testing.T, ptm *pubTxManager, pubTxnID uint64) *storedCalldata {
+	var stored storedCalldata
+	err := ptm.p.DB().Table("public_txns").Where("pub_txn_id = ?", pubTxnID).Select("data", "data_codec").Take(&stored).Error
+	require.NoError(t, err)
+	return &stored
+}
+
+func writeTestCalldataTX(t *testing.T, ctx context.Context, ptm *pubTxManager, data []byte) (uuid.UUID, *pldapi.PublicTx) {
+	txID := uuid.New()
+	var pubTxns []*pldapi.PublicTx
+	err := ptm.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) (err error) {
+		pubTxns, err = ptm.WriteNewTransactions(ctx, dbTX, []*components.PublicTxSubmission{{
+			Bindings: []*components.PaladinTXReference{
+				{TransactionID: txID, TransactionType: pldapi.TransactionTypePublic.Enum()},
+			},
+			PublicTxInput: pldapi.PublicTxInput{
+				From: pldtypes.RandAddress(),
+				Data: data,
+				PublicTxOptions: pldapi.PublicTxOptions{
+					Gas: confutil.P(pldtypes.HexUint64(100000)),
+				},
+			},
+		}})
+		return err
+	})
+	require.NoError(t, err)
+	require.Len(t, pubTxns, 1)
+	// The calldata returned from the write is never compressed
+	assert.Equal(t, pldtypes.HexBytes(data), pubTxns[0].Data)
+	return txID, pubTxns[0]
+}
+
+func readTestCalldataTX(t *testing.T, ctx context.Context, ptm *pubTxManager, txID uuid.UUID) *pldapi.PublicTx {
+	results, err := ptm.QueryPublicTxForTransactions(ctx, ptm.p.NOTX(), []uuid.UUID{txID}, query.NewQueryBuilder().Limit(1).Query())
+	require.NoError(t, err)
+	require.Len(t, results[txID], 1)
+	return results[txID][0]
+}
+
+func TestCalldataCompressionRealDB(t *testing.T) {
+	for _, codec := range []string{"gzip", "DEFLATE"} {
+		t.Run(codec, func(t *testing.T) {
+			ctx, ptm, _, done := newTestPublicTxManager(t, true, func(mocks *mocksAndTestControl, conf *pldconf.PublicTxManagerConfig) {
+				mocks.disableManagerStart = true
+				conf.Compression.Codec = confutil.P(codec)
+				conf.Compression.Threshold = confutil.P("1Kb")
+			})
+			defer done()
+
+			// Large compressible calldata is compressed at rest, and decompressed on read
+			large := bytes.Repeat([]byte{0xfe, 0xed, 0xbe, 0xef}, 4096)
+			txID, pubTx := writeTestCalldataTX(t, ctx, ptm, large)
+			stored := readStoredCalldata(t, ptm, *pubTx.LocalID)
+			require.NotNil(t, stored.DataCodec)
+			assert.Less(t, len(stored.Data), len(large))
+			assert.Equal(t, pldtypes.HexBytes(large), readTestCalldataTX(t, ctx, ptm, txID).Data)
+
+			// Updating to small calldata stores it uncompressed, and clears the codec
+			small := []byte{0x01, 0x02, 0x03}
+			err := ptm.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
+				return ptm.writeUpdatedTransaction(ctx, dbTX, *pubTx.LocalID, pubTx.From, &DBPublicTxn{From: pubTx.From, Data: small})
+			})
+			require.NoError(t, err)
+			stored = readStoredCalldata(t, ptm, *pubTx.LocalID)
+			assert.Nil(t, stored.DataCodec)
+			assert.Equal(t, pldtypes.HexBytes(small), readTestCalldataTX(t, ctx, ptm, txID).Data)
+
+			// Large calldata that does not compress is stored as-is
+			random := pldtypes.RandBytes(4096)
+			txID, pubTx = writeTestCalldataTX(t, ctx, ptm, random)
+			stored = readStoredCalldata(t, ptm, *pubTx.LocalID)
+			assert.Nil(t, stored.DataCodec)
+			assert.Equal(t, pldtypes.HexBytes(random), readTestCalldataTX(t, ctx, ptm, txID).Data)
+		})
+	}
+}
+
+func TestCalldataCompressionDisabledRealDB(t *testing.T) {
+	ctx, ptm, _, done := newTestPublicTxManager(t, true, func(mocks *mocksAndTestControl, conf *pldconf.PublicTxManagerConfig) {
+		mocks.disableManagerStart = true
+		conf.Compression.Codec = confutil.P("none")
+	})
+	defer done()
+
+	large := bytes.Repeat([]byte{0xfe}, 64*1024)
+	txID, pubTx := writeTestCalldataTX(t, ctx, ptm, large)
+	assert.Nil(t, readStoredCalldata(t, ptm, *pubTx.LocalID).DataCodec)
+	assert.Equal(t, pldtypes.HexBytes(large), readTestCalldataTX(t, ctx, ptm, txID).Data)
+}
+
+func TestCalldataCorruptRealDB(t *testing.T) {
+	ctx, ptm, _, done := newTestPublicTxManager(t, true, func(mocks *mocksAndTestControl, conf *pldconf.PublicTxManagerConfig) {
+		mocks.disableManagerStart = true
+	})
+	defer done()
+
+	txID, pubTx := writeTestCalldataTX(t, ctx, ptm, []byte{0x01})
+	for _, codec := range []string{"gzip", "deflate", "unknown"} {
+		err := ptm.p.DB().Table("public_txns").Where("pub_txn_id = ?", *pubTx.LocalID).UpdateColumn("data_codec", codec).Error
+		require.NoError(t, err)
+		_, err = ptm.QueryPublicTxForTransactions(ctx, ptm.p.NOTX(), []uuid.UUID{txID}, query.NewQueryBuilder().Limit(1).Query())
+		assert.Regexp(t, "PD011985", err)
+	}
+}
+
+func TestCalldataCodecInvalid(t *testing.T) {
+	ptm := NewPublicTransactionManager(context.Background(), &pldconf.PublicTxManagerConfig{
+		Compression: pldconf.PublicTxCompressionConfig{Codec: confutil.P("zstd")},
+	})
+	_, err := ptm.PreInit(nil)
+	assert.Regexp(t, "PD011984", err)
+}
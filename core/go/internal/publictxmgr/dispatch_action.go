@@ -28,6 +28,7 @@ const (
 	ActionSuspend AsyncRequestType = iota
 	ActionResume
 	ActionCompleted
+	ActionCancel
 )
 
 func (ptm *pubTxManager) persistSuspendedFlag(ctx context.Context, from pldtypes.EthAddress, nonce uint64, suspended bool) error {
@@ -41,15 +42,29 @@ func (ptm *pubTxManager) persistSuspendedFlag(ctx context.Context, from pldtypes
 		Error
 }
 
+// persistCancel sets the expiry of the transaction to now, so it is replaced with a no-op transaction that consumes
+// the same nonce. A suspended transaction is resumed, as the no-op needs to be submitted for the nonce to be released.
+func (ptm *pubTxManager) persistCancel(ctx context.Context, from pldtypes.EthAddress, nonce uint64, expiry pldtypes.Timestamp) error {
+	log.L(ctx).Infof("Cancelling transaction %s:%d with expiry %s", from, nonce, expiry)
+	return ptm.p.DB().
+		WithContext(ctx).
+		Table("public_txns").
+		Where(`"from" = ?`, from).
+		Where("nonce = ?", nonce).
+		Updates(map[string]any{
+			"expiry":    expiry,
+			"suspended": false,
+		}).
+		Error
+}
+
 // TODO: this code needs to stop using from and nonce as the way of identifying a transaction. It didn't get edited
 // with the move to delayed nonce assignment, where pubTXID became the primary key for a public transaction instead
 // of from and nonce as a composite primary key. This isn't a problem for dispatching a confirm action because a
 // confirmed transaction must have a nonce, but it isn't guaranteed to work for suspend and resume. Those actions
 // have been copied across but aren't wired up above this level so they aren't obviously broken yet.
 func (ptm *pubTxManager) dispatchAction(ctx context.Context, from pldtypes.EthAddress, nonce uint64, action AsyncRequestType) error {
-	ptm.inFlightOrchestratorMux.Lock()
-	defer ptm.inFlightOrchestratorMux.Unlock()
-	inFlightOrchestrator, orchestratorInFlight := ptm.inFlightOrchestrators[from]
+	inFlightOrchestrator, orchestratorInFlight := ptm.orchestrators.Get(from)
 	switch action {
 	case ActionCompleted:
 		// Only need to pass this on if there's an orchestrator in flight for this signing address
@@ -67,6 +82,12 @@ func (ptm *pubTxManager) dispatchAction(ctx context.Context, from pldtypes.EthAd
 		}
 		// has to be done in the context of the orchestrator
 		return inFlightOrchestrator.dispatchAction(ctx, nonce, action)
+	case ActionCancel:
+		if !orchestratorInFlight {
+			// the expiry is checked when an orchestrator next loads the transaction
+			return ptm.persistCancel(ctx, from, nonce, pldtypes.TimestampNow())
+		}
+		return inFlightOrchestrator.dispatchAction(ctx, nonce, action)
 	}
 	return nil
 }
@@ -98,8 +119,16 @@ func (oc *orchestrator) dispatchAction(ctx context.Context, nonce uint64, action
 			// Ok we've now got the lock that means we can write to the DB
 			// No optimization of this write, as it's a user action from the side of normal processing
 			err = oc.persistSuspendedFlag(ctx, oc.signingAddress, nonce, suspendedFlag)
+		case ActionCancel:
+			expiry := pldtypes.TimestampNow()
+			_, _ = pending.NotifyStatusUpdate(ctx, InFlightStatusPending)
+			pending.stateManager.SetExpiry(&expiry)
+			err = oc.persistCancel(ctx, oc.signingAddress, nonce, expiry)
 		}
 		oc.MarkInFlightTxStale()
+	} else if action == ActionCancel {
+		// the transaction is not loaded into this orchestrator yet, and will pick up the expiry when it is
+		err = oc.persistCancel(ctx, oc.signingAddress, nonce, pldtypes.TimestampNow())
 	}
 	return err
 }
@@ -0,0 +1,119 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package publictxmgr
+
+import (
+	"context"
+
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/core/internal/components"
+	"github.com/kaleido-io/paladin/core/pkg/persistence"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+)
+
+// DryRunTransaction builds and signs a transaction exactly as the orchestrator would for its first submission,
+// but returns the result rather than persisting it. Nothing is reserved, so the nonce is only provisional.
+func (ptm *pubTxManager) DryRunTransaction(ctx context.Context, dbTX persistence.DBTX, txi *components.PublicTxSubmission) (*pldapi.PublicTxDryRun, error) {
+	// Gas estimation (if required) and validation are shared with the real submission path
+	if err := ptm.ValidateTransaction(ctx, dbTX, txi); err != nil {
+		return nil, err
+	}
+
+	nonce, err := ptm.provisionalNonce(ctx, dbTX, *txi.From)
+	if err != nil {
+		return nil, err
+	}
+
+	gasPricing := txi.PublicTxGasPricing
+	if !gasPricingSet(gasPricing) {
+		gpo, err := ptm.gasPriceClient.GetGasPriceObject(ctx)
+		if err != nil {
+			return nil, err
+		}
+		gasPricing = *gpo
+		if len(txi.Blobs) > 0 {
+			if gasPricing.MaxFeePerBlobGas, err = ptm.gasPriceClient.GetBlobGasPrice(ctx); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	var blobTx *blobTransaction
+	if len(txi.Blobs) > 0 {
+		sidecar, err := buildBlobSidecar(ctx, txi.Blobs)
+		if err != nil {
+			return nil, err
+		}
+		blobTx = &blobTransaction{
+			maxFeePerBlobGas: gasPricing.MaxFeePerBlobGas.Int(),
+			sidecar:          sidecar,
+		}
+	}
+
+	options := pldapi.PublicTxOptions{
+		Gas:                txi.Gas, // set by ValidateTransaction
+		Value:              txi.Value,
+		PublicTxGasPricing: gasPricing,
+	}
+	ethTx := buildEthTX(*txi.From, &nonce, txi.To, txi.Data, &options)
+	signedMessage, txHash, err := ptm.signTx(ctx, *txi.From, ethTx, blobTx)
+	if err != nil {
+		return nil, err
+	}
+	log.L(ctx).Debugf("Dry run of transaction from %s with provisional nonce %d built transaction %s", txi.From, nonce, txHash)
+
+	var hashes []pldtypes.Bytes32
+	if blobTx != nil {
+		hashes = blobHashes(blobTx.sidecar)
+	}
+	return &pldapi.PublicTxDryRun{
+		From:            *txi.From,
+		To:              txi.To,
+		Data:            txi.Data,
+		Nonce:           pldtypes.HexUint64(nonce),
+		TransactionHash: *txHash,
+		RawTransaction:  signedMessage,
+		BlobHashes:      hashes,
+		PublicTxOptions: options,
+	}, nil
+}
+
+// provisionalNonce returns the nonce the next transaction from the signer is likely to be assigned, which is the
+// later of the next nonce after those already assigned in our DB, and the pending transaction count from the node
+func (ptm *pubTxManager) provisionalNonce(ctx context.Context, dbTX persistence.DBTX, from pldtypes.EthAddress) (uint64, error) {
+	var txns []*DBPublicTxn
+	err := dbTX.DB().
+		WithContext(ctx).
+		Where(`"from" = ?`, from).
+		Where("nonce IS NOT NULL").
+		Order("nonce DESC").
+		Limit(1).
+		Find(&txns).
+		Error
+	if err != nil {
+		return 0, err
+	}
+	txCount, err := ptm.ethClient.GetTransactionCount(ctx, from)
+	if err != nil {
+		return 0, err
+	}
+	nonce := txCount.Uint64()
+	if len(txns) > 0 && *txns[0].Nonce >= nonce {
+		nonce = *txns[0].Nonce + 1
+	}
+	return nonce, nil
+}
@@ -0,0 +1,235 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package publictxmgr
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/firefly-signer/pkg/ethsigner"
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/internal/components"
+	"github.com/kaleido-io/paladin/core/mocks/componentmocks"
+	"github.com/kaleido-io/paladin/core/pkg/ethclient"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/toolkit/pkg/algorithms"
+	"github.com/kaleido-io/paladin/toolkit/pkg/verifiers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDryRunTransactionRealKeyMgrAndDB(t *testing.T) {
+	ctx, ptm, m, done := newTestPublicTxManager(t, true, func(mocks *mocksAndTestControl, conf *pldconf.PublicTxManagerConfig) {
+		conf.GasPrice.FixedGasPrice = nil
+	})
+	defer done()
+
+	chainID := int64(12345)
+	m.ethClient.On("GasPrice", mock.Anything).Return(pldtypes.MustParseHexUint256("1000"), nil)
+	m.ethClient.On("BlobBaseFee", mock.Anything).Return(pldtypes.MustParseHexUint256("50"), nil)
+	m.ethClient.On("ChainID").Return(chainID)
+	m.ethClient.On("EstimateGasNoResolve", mock.Anything, mock.Anything, mock.Anything).
+		Return(ethclient.EstimateGasResult{GasLimit: pldtypes.HexUint64(20000)}, nil)
+	m.ethClient.On("GetTransactionCount", mock.Anything, mock.Anything).
+		Return(confutil.P(pldtypes.HexUint64(5)), nil)
+
+	keyMapping, err := m.keyManager.ResolveKeyNewDatabaseTX(ctx, "signer1", algorithms.ECDSA_SECP256K1, verifiers.ETH_ADDRESS)
+	require.NoError(t, err)
+	resolvedKey := pldtypes.MustEthAddress(keyMapping.Verifier.Verifier)
+
+	to := pldtypes.RandAddress()
+	dryRun, err := ptm.DryRunTransaction(ctx, ptm.p.NOTX(), &components.PublicTxSubmission{
+		PublicTxInput: pldapi.PublicTxInput{
+			From: resolvedKey,
+			To:   to,
+			Data: pldtypes.HexBytes("some data"),
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, *resolvedKey, dryRun.From)
+	assert.Equal(t, pldtypes.HexUint64(5), dryRun.Nonce) // from the node, as we have none in our DB
+	assert.Equal(t, pldtypes.HexUint64(30000), *dryRun.Gas)
+	assert.Equal(t, "1000", dryRun.GasPrice.Int().String())
+	assert.Equal(t, *calculateTransactionHash(dryRun.RawTransaction), dryRun.TransactionHash)
+
+	// The raw transaction is signed by the resolved key
+	signer, ethTx, err := ethsigner.RecoverRawTransaction(ctx, ethtypes.HexBytes0xPrefix(dryRun.RawTransaction), chainID)
+	require.NoError(t, err)
+	assert.Equal(t, resolvedKey.String(), signer.String())
+	assert.Equal(t, int64(5), ethTx.Nonce.Int64())
+	assert.Equal(t, int64(30000), ethTx.GasLimit.Int64())
+	assert.Equal(t, to.String(), ethTx.To.String())
+	assert.Equal(t, "some data", string(ethTx.Data))
+
+	// Nothing was written
+	var count int64
+	err = ptm.p.DB().Table("public_txns").Count(&count).Error
+	require.NoError(t, err)
+	assert.Zero(t, count)
+
+	// Once we have nonces assigned in our DB ahead of the node, the next one is used.
+	// Suspended so that the orchestrator does not pick it up.
+	err = ptm.p.DB().Table("public_txns").Create(&DBPublicTxn{
+		From:      *resolvedKey,
+		Nonce:     confutil.P(uint64(10)),
+		Gas:       21000,
+		Suspended: true,
+	}).Error
+	require.NoError(t, err)
+
+	// Blobs are included in the signed transaction with the blob gas price
+	dryRun, err = ptm.DryRunTransaction(ctx, ptm.p.NOTX(), &components.PublicTxSubmission{
+		PublicTxInput: pldapi.PublicTxInput{
+			From: resolvedKey,
+			To:   to,
+			PublicTxOptions: pldapi.PublicTxOptions{
+				Blobs: []pldtypes.HexBytes{[]byte("blob zero")},
+			},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, pldtypes.HexUint64(11), dryRun.Nonce)
+	assert.Equal(t, "100", dryRun.MaxFeePerBlobGas.Int().String())
+	require.Len(t, dryRun.BlobHashes, 1)
+	assert.Nil(t, dryRun.Blobs)
+	assert.Equal(t, byte(blobTransactionType), dryRun.RawTransaction[0])
+}
+
+func TestDryRunTransactionFixedGasPricing(t *testing.T) {
+	ctx, ptm, m, done := newTestPublicTxManager(t, false)
+	defer done()
+
+	m.db.ExpectQuery("SELECT.*public_txns").WillReturnRows(m.db.NewRows([]string{}))
+	m.ethClient.On("GetTransactionCount", mock.Anything, mock.Anything).
+		Return(confutil.P(pldtypes.HexUint64(5)), nil)
+	m.keyManager.(*componentmocks.KeyManager).On("ReverseKeyLookup", mock.Anything, mock.Anything, algorithms.ECDSA_SECP256K1, verifiers.ETH_ADDRESS, mock.Anything).
+		Return(nil, fmt.Errorf("pop"))
+
+	_, err := ptm.DryRunTransaction(ctx, ptm.p.NOTX(), &components.PublicTxSubmission{
+		PublicTxInput: pldapi.PublicTxInput{
+			From: pldtypes.RandAddress(),
+			PublicTxOptions: pldapi.PublicTxOptions{
+				Gas: confutil.P(pldtypes.HexUint64(21000)),
+				PublicTxGasPricing: pldapi.PublicTxGasPricing{
+					MaxFeePerGas:         pldtypes.Uint64ToUint256(100),
+					MaxPriorityFeePerGas: pldtypes.Uint64ToUint256(10),
+				},
+			},
+		},
+	})
+	assert.Regexp(t, "pop", err)
+}
+
+func TestDryRunTransactionEstimateFail(t *testing.T) {
+	ctx, ptm, m, done := newTestPublicTxManager(t, false)
+	defer done()
+
+	m.ethClient.On("EstimateGasNoResolve", mock.Anything, mock.Anything, mock.Anything).
+		Return(ethclient.EstimateGasResult{}, fmt.Errorf("pop"))
+
+	_, err := ptm.DryRunTransaction(ctx, ptm.p.NOTX(), &components.PublicTxSubmission{
+		PublicTxInput: pldapi.PublicTxInput{
+			From: pldtypes.RandAddress(),
+		},
+	})
+	assert.Regexp(t, "pop", err)
+}
+
+func TestDryRunTransactionNonceQueryFail(t *testing.T) {
+	ctx, ptm, m, done := newTestPublicTxManager(t, false)
+	defer done()
+
+	m.db.ExpectQuery("SELECT.*public_txns").WillReturnError(fmt.Errorf("pop"))
+
+	_, err := ptm.DryRunTransaction(ctx, ptm.p.NOTX(), &components.PublicTxSubmission{
+		PublicTxInput: pldapi.PublicTxInput{
+			From: pldtypes.RandAddress(),
+			PublicTxOptions: pldapi.PublicTxOptions{
+				Gas: confutil.P(pldtypes.HexUint64(21000)),
+			},
+		},
+	})
+	assert.Regexp(t, "pop", err)
+}
+
+func TestDryRunTransactionCountFail(t *testing.T) {
+	ctx, ptm, m, done := newTestPublicTxManager(t, false)
+	defer done()
+
+	m.db.ExpectQuery("SELECT.*public_txns").WillReturnRows(m.db.NewRows([]string{}))
+	m.ethClient.On("GetTransactionCount", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("pop"))
+
+	_, err := ptm.DryRunTransaction(ctx, ptm.p.NOTX(), &components.PublicTxSubmission{
+		PublicTxInput: pldapi.PublicTxInput{
+			From: pldtypes.RandAddress(),
+			PublicTxOptions: pldapi.PublicTxOptions{
+				Gas: confutil.P(pldtypes.HexUint64(21000)),
+			},
+		},
+	})
+	assert.Regexp(t, "pop", err)
+}
+
+func TestDryRunTransactionGasPriceFail(t *testing.T) {
+	ctx, ptm, m, done := newTestPublicTxManager(t, false, func(mocks *mocksAndTestControl, conf *pldconf.PublicTxManagerConfig) {
+		conf.GasPrice.FixedGasPrice = nil
+	})
+	defer done()
+
+	m.db.ExpectQuery("SELECT.*public_txns").WillReturnRows(m.db.NewRows([]string{}))
+	m.ethClient.On("GetTransactionCount", mock.Anything, mock.Anything).
+		Return(confutil.P(pldtypes.HexUint64(5)), nil)
+	m.ethClient.On("GasPrice", mock.Anything).Return(nil, fmt.Errorf("pop"))
+
+	_, err := ptm.DryRunTransaction(ctx, ptm.p.NOTX(), &components.PublicTxSubmission{
+		PublicTxInput: pldapi.PublicTxInput{
+			From: pldtypes.RandAddress(),
+			PublicTxOptions: pldapi.PublicTxOptions{
+				Gas: confutil.P(pldtypes.HexUint64(21000)),
+			},
+		},
+	})
+	assert.Regexp(t, "pop", err)
+}
+
+func TestDryRunTransactionBlobGasPriceFail(t *testing.T) {
+	ctx, ptm, m, done := newTestPublicTxManager(t, false, func(mocks *mocksAndTestControl, conf *pldconf.PublicTxManagerConfig) {
+		conf.GasPrice.FixedGasPrice = nil
+	})
+	defer done()
+
+	m.db.ExpectQuery("SELECT.*public_txns").WillReturnRows(m.db.NewRows([]string{}))
+	m.ethClient.On("GetTransactionCount", mock.Anything, mock.Anything).
+		Return(confutil.P(pldtypes.HexUint64(5)), nil)
+	m.ethClient.On("GasPrice", mock.Anything).Return(pldtypes.MustParseHexUint256("1000"), nil)
+	m.ethClient.On("BlobBaseFee", mock.Anything).Return(nil, fmt.Errorf("pop"))
+
+	_, err := ptm.DryRunTransaction(ctx, ptm.p.NOTX(), &components.PublicTxSubmission{
+		PublicTxInput: pldapi.PublicTxInput{
+			From: pldtypes.RandAddress(),
+			To:   pldtypes.RandAddress(),
+			PublicTxOptions: pldapi.PublicTxOptions{
+				Gas:   confutil.P(pldtypes.HexUint64(21000)),
+				Blobs: []pldtypes.HexBytes{[]byte("blob zero")},
+			},
+		},
+	})
+	assert.Regexp(t, "pop", err)
+}
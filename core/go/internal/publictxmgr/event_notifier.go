@@ -0,0 +1,381 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package publictxmgr
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/core/internal/components"
+	"github.com/kaleido-io/paladin/core/internal/msgs"
+	"github.com/kaleido-io/paladin/core/pkg/persistence"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var txIDPrefixRegexp = regexp.MustCompile(`^[0-9a-f-]*$`)
+
+type DBPublicTxnEvent struct {
+	Sequence        uint64             `gorm:"column:sequence;autoIncrement"`
+	PublicTxnID     uint64             `gorm:"column:pub_txn_id"`
+	EventType       string             `gorm:"column:event_type"`
+	TransactionHash *pldtypes.Bytes32  `gorm:"column:tx_hash"`
+	Created         pldtypes.Timestamp `gorm:"column:created;autoCreateTime:false"`
+	PublicTx        *txFromNonce       `gorm:"foreignKey:pub_txn_id;references:pub_txn_id"` // only loaded on queries
+}
+
+func (DBPublicTxnEvent) TableName() string {
+	return "public_txn_events"
+}
+
+type txFromNonce struct {
+	PublicTxnID uint64              `gorm:"column:pub_txn_id;primaryKey"`
+	From        pldtypes.EthAddress `gorm:"column:from"`
+	Nonce       *uint64             `gorm:"column:nonce"`
+}
+
+func (txFromNonce) TableName() string {
+	return "public_txns"
+}
+
+type DBPublicTxnEventCursor struct {
+	Subscriber string             `gorm:"column:subscriber;primaryKey"`
+	Sequence   uint64             `gorm:"column:sequence"`
+	Time       pldtypes.Timestamp `gorm:"column:time"`
+}
+
+func (DBPublicTxnEventCursor) TableName() string {
+	return "public_txn_event_cursors"
+}
+
+type eventSubscriber struct {
+	ptm       *pubTxManager
+	ctx       context.Context
+	name      string
+	filter    components.PublicTxEventFilter
+	receiver  components.PublicTxEventReceiver
+	cursor    *uint64
+	newEvents chan struct{}
+	done      chan struct{}
+}
+
+func (ptm *pubTxManager) AddEventSubscriber(ctx context.Context, name string, filter *components.PublicTxEventFilter, r components.PublicTxEventReceiver) error {
+	ptm.eventSubscribersMux.Lock()
+	defer ptm.eventSubscribersMux.Unlock()
+
+	if ptm.eventSubscribersStarted {
+		return i18n.NewError(ctx, msgs.MsgPublicTxEventSubscriberStarted, name)
+	}
+	if _, exists := ptm.eventSubscribers[name]; exists {
+		return i18n.NewError(ctx, msgs.MsgPublicTxEventSubscriberExists, name)
+	}
+	es := &eventSubscriber{
+		ptm:       ptm,
+		name:      name,
+		receiver:  r,
+		newEvents: make(chan struct{}, 1),
+		done:      make(chan struct{}),
+	}
+	if filter != nil {
+		es.filter = *filter
+	}
+	es.filter.TransactionIDPrefix = strings.ToLower(es.filter.TransactionIDPrefix)
+	if !txIDPrefixRegexp.MatchString(es.filter.TransactionIDPrefix) {
+		return i18n.NewError(ctx, msgs.MsgPublicTxEventInvalidTxIDPrefix, es.filter.TransactionIDPrefix, name)
+	}
+	ptm.eventSubscribers[name] = es
+	return nil
+}
+
+func (ptm *pubTxManager) startEventSubscribers() {
+	ptm.eventSubscribersMux.Lock()
+	defer ptm.eventSubscribersMux.Unlock()
+
+	if !ptm.eventSubscribersStarted {
+		ptm.eventSubscribersStarted = true
+		for _, es := range ptm.eventSubscribers {
+			es.ctx = log.WithLogField(ptm.ctx, "event_subscriber", es.name)
+			go es.run()
+		}
+	}
+}
+
+func (ptm *pubTxManager) stopEventSubscribers() {
+	ptm.eventSubscribersMux.Lock()
+	started := ptm.eventSubscribersStarted
+	ptm.eventSubscribersMux.Unlock()
+
+	if started {
+		for _, es := range ptm.getEventSubscribers() {
+			<-es.done
+		}
+	}
+}
+
+func (ptm *pubTxManager) getEventSubscribers() []*eventSubscriber {
+	ptm.eventSubscribersMux.Lock()
+	defer ptm.eventSubscribersMux.Unlock()
+	subscribers := make([]*eventSubscriber, 0, len(ptm.eventSubscribers))
+	for _, es := range ptm.eventSubscribers {
+		subscribers = append(subscribers, es)
+	}
+	return subscribers
+}
+
+// writeEvents records events in the DB transaction, if there are any subscribers to deliver them to
+func (ptm *pubTxManager) writeEvents(ctx context.Context, dbTX persistence.DBTX, events []*DBPublicTxnEvent) error {
+	subscribers := ptm.getEventSubscribers()
+	if len(events) == 0 || len(subscribers) == 0 {
+		return nil
+	}
+	// As with receipts, sequence numbers must be allocated in commit order so subscribers do not miss events
+	// that appear behind their cursor.
+	err := ptm.p.TakeNamedLock(ctx, dbTX, "public_txn_events")
+	if err == nil {
+		err = dbTX.DB().
+			WithContext(ctx).
+			Omit("PublicTx").
+			Create(events).
+			Error
+	}
+	if err != nil {
+		return err
+	}
+	dbTX.AddPostCommit(func(ctx context.Context) {
+		for _, es := range subscribers {
+			select {
+			case es.newEvents <- struct{}{}:
+			default:
+			}
+		}
+	})
+	return nil
+}
+
+func newTxEvents(eventType components.PublicTxEventType, txHash *pldtypes.Bytes32, pubTxnIDs ...uint64) []*DBPublicTxnEvent {
+	now := pldtypes.TimestampNow()
+	events := make([]*DBPublicTxnEvent, len(pubTxnIDs))
+	for i, pubTxnID := range pubTxnIDs {
+		events[i] = &DBPublicTxnEvent{
+			PublicTxnID:     pubTxnID,
+			EventType:       string(eventType),
+			TransactionHash: txHash,
+			Created:         now,
+		}
+	}
+	return events
+}
+
+func (es *eventSubscriber) loadCursor() error {
+	var cursors []*DBPublicTxnEventCursor
+	err := es.ptm.p.DB().
+		WithContext(es.ctx).
+		Where("subscriber = ?", es.name).
+		Limit(1).
+		Find(&cursors).
+		Error
+	if err != nil {
+		return err
+	}
+	if len(cursors) == 0 {
+		log.L(es.ctx).Infof("Started event subscriber from the first recorded event")
+	} else {
+		es.cursor = &cursors[0].Sequence
+		log.L(es.ctx).Infof("Started event subscriber with cursor=%d", *es.cursor)
+	}
+	return nil
+}
+
+func (es *eventSubscriber) buildQuery(db *gorm.DB) *gorm.DB {
+	q := db.
+		WithContext(es.ctx).
+		Joins("PublicTx").
+		Order(`"public_txn_events"."sequence"`).
+		Limit(es.ptm.eventBatchSize)
+	if es.cursor != nil {
+		q = q.Where(`"public_txn_events"."sequence" > ?`, *es.cursor)
+	}
+	if len(es.filter.Signers) > 0 {
+		q = q.Where(`"PublicTx"."from" IN (?)`, es.filter.Signers)
+	}
+	if len(es.filter.Types) > 0 {
+		q = q.Where(`"public_txn_events"."event_type" IN (?)`, es.filter.Types)
+	}
+	if es.filter.TransactionIDPrefix != "" {
+		q = q.Where(`"public_txn_events"."pub_txn_id" IN (?)`, db.
+			Table("public_txn_bindings").
+			Select("pub_txn_id").
+			Where(`CAST("transaction" AS TEXT) LIKE ?`, es.filter.TransactionIDPrefix+"%"))
+	}
+	return q
+}
+
+func (es *eventSubscriber) readPage() (events []*components.PublicTxEvent, err error) {
+	var page []*DBPublicTxnEvent
+	var bindings []*DBPublicTxnBinding
+	err = es.ptm.retry.Do(es.ctx, func(attempt int) (retryable bool, err error) {
+		db := es.ptm.p.DB()
+		err = es.buildQuery(db).Find(&page).Error
+		if err == nil && len(page) > 0 {
+			pubTxnIDs := make([]uint64, len(page))
+			for i, e := range page {
+				pubTxnIDs[i] = e.PublicTxnID
+			}
+			err = db.
+				WithContext(es.ctx).
+				Where("pub_txn_id IN (?)", pubTxnIDs).
+				Find(&bindings).
+				Error
+		}
+		return true, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	events = make([]*components.PublicTxEvent, len(page))
+	for i, e := range page {
+		events[i] = &components.PublicTxEvent{
+			Sequence:        e.Sequence,
+			PublicTxnID:     e.PublicTxnID,
+			Type:            components.PublicTxEventType(e.EventType),
+			TransactionHash: e.TransactionHash,
+			Bindings:        []*components.PaladinTXReference{},
+			Created:         e.Created,
+		}
+		if e.PublicTx != nil {
+			events[i].From = e.PublicTx.From
+			events[i].Nonce = e.PublicTx.Nonce
+		}
+		for _, b := range bindings {
+			if b.PublicTxnID == e.PublicTxnID {
+				events[i].Bindings = append(events[i].Bindings, &components.PaladinTXReference{
+					TransactionID:   b.Transaction,
+					TransactionType: b.TransactionType,
+				})
+			}
+		}
+	}
+	return events, nil
+}
+
+func (es *eventSubscriber) updateCursor(newSequence uint64) error {
+	err := es.ptm.p.DB().
+		WithContext(es.ctx).
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "subscriber"}},
+			DoUpdates: clause.AssignmentColumns([]string{
+				"sequence",
+				"time",
+			}),
+		}).
+		Create(&DBPublicTxnEventCursor{
+			Subscriber: es.name,
+			Sequence:   newSequence,
+			Time:       pldtypes.TimestampNow(),
+		}).
+		Error
+	if err != nil {
+		return err
+	}
+	es.ptm.eventSubscribersMux.Lock()
+	es.cursor = &newSequence
+	es.ptm.eventSubscribersMux.Unlock()
+	return nil
+}
+
+// pruneEvents removes events that every subscriber has been delivered (or was not interested in)
+func (ptm *pubTxManager) pruneEvents(ctx context.Context) error {
+	ptm.eventSubscribersMux.Lock()
+	var minSequence *uint64
+	for _, es := range ptm.eventSubscribers {
+		if es.cursor == nil {
+			ptm.eventSubscribersMux.Unlock()
+			return nil
+		}
+		if minSequence == nil || *es.cursor < *minSequence {
+			minSequence = es.cursor
+		}
+	}
+	ptm.eventSubscribersMux.Unlock()
+	if minSequence == nil {
+		return nil
+	}
+	return ptm.p.DB().
+		WithContext(ctx).
+		Where("sequence <= ?", *minSequence).
+		Delete(&DBPublicTxnEvent{}).
+		Error
+}
+
+func (es *eventSubscriber) run() {
+	defer close(es.done)
+
+	err := es.ptm.retry.Do(es.ctx, func(attempt int) (retryable bool, err error) {
+		return true, es.loadCursor()
+	})
+	if err != nil {
+		log.L(es.ctx).Warnf("Event subscriber stopping before reading cursor: %s", err)
+		return
+	}
+
+	ticker := time.NewTicker(es.ptm.eventPollInterval)
+	defer ticker.Stop()
+	for {
+		page, err := es.readPage()
+		if err != nil {
+			log.L(es.ctx).Warnf("Event subscriber stopping: %s", err) // cancelled context
+			return
+		}
+
+		if len(page) > 0 {
+			// Delivery is retried indefinitely, as we do not move the cursor until the receiver has accepted the batch
+			err := es.ptm.retry.Do(es.ctx, func(attempt int) (retryable bool, err error) {
+				log.L(es.ctx).Debugf("Delivering %d events from sequence %d (attempt=%d)", len(page), page[0].Sequence, attempt)
+				return true, es.receiver.DeliverPublicTxEvents(es.ctx, page)
+			})
+			if err == nil {
+				err = es.ptm.retry.Do(es.ctx, func(attempt int) (retryable bool, err error) {
+					return true, es.updateCursor(page[len(page)-1].Sequence)
+				})
+			}
+			if err == nil {
+				if pruneErr := es.ptm.pruneEvents(es.ctx); pruneErr != nil {
+					log.L(es.ctx).Warnf("Failed to prune delivered events: %s", pruneErr)
+				}
+			}
+			if err != nil {
+				log.L(es.ctx).Warnf("Event subscriber stopping (delivering events from sequence %d): %s", page[0].Sequence, err) // cancelled context
+				return
+			}
+		}
+
+		// If our page was not full, wait for notification of new events before we look again
+		if len(page) < es.ptm.eventBatchSize {
+			select {
+			case <-es.newEvents:
+			case <-ticker.C:
+			case <-es.ctx.Done():
+				log.L(es.ctx).Debugf("Event subscriber stopping (waiting for new events)")
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,249 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package publictxmgr
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/internal/components"
+	"github.com/kaleido-io/paladin/core/pkg/blockindexer"
+	"github.com/kaleido-io/paladin/core/pkg/persistence"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testEventReceiver struct {
+	failNext bool
+	events   chan *components.PublicTxEvent
+}
+
+func newTestEventReceiver() *testEventReceiver {
+	return &testEventReceiver{events: make(chan *components.PublicTxEvent, 100)}
+}
+
+func (r *testEventReceiver) DeliverPublicTxEvents(ctx context.Context, events []*components.PublicTxEvent) error {
+	if r.failNext {
+		r.failNext = false
+		return fmt.Errorf("pop")
+	}
+	for _, e := range events {
+		r.events <- e
+	}
+	return nil
+}
+
+func (r *testEventReceiver) next(t *testing.T) *components.PublicTxEvent {
+	select {
+	case e := <-r.events:
+		return e
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "timed out waiting for event")
+		return nil
+	}
+}
+
+func TestAddEventSubscriberErrors(t *testing.T) {
+	ctx, ptm, _, done := newTestPublicTxManager(t, false, func(mocks *mocksAndTestControl, conf *pldconf.PublicTxManagerConfig) {
+		mocks.disableManagerStart = true
+	})
+	defer done()
+
+	err := ptm.AddEventSubscriber(ctx, "sub1", nil, newTestEventReceiver())
+	require.NoError(t, err)
+
+	err = ptm.AddEventSubscriber(ctx, "sub1", nil, newTestEventReceiver())
+	assert.Regexp(t, "PD011957", err)
+
+	err = ptm.AddEventSubscriber(ctx, "sub2", &components.PublicTxEventFilter{
+		TransactionIDPrefix: "xyz%",
+	}, newTestEventReceiver())
+	assert.Regexp(t, "PD011958", err)
+
+	ptm.eventSubscribersStarted = true
+	err = ptm.AddEventSubscriber(ctx, "sub3", nil, newTestEventReceiver())
+	assert.Regexp(t, "PD011956", err)
+	ptm.eventSubscribersStarted = false
+}
+
+func TestWriteEventsNoSubscribers(t *testing.T) {
+	ctx, ptm, _, done := newTestPublicTxManager(t, false, func(mocks *mocksAndTestControl, conf *pldconf.PublicTxManagerConfig) {
+		mocks.disableManagerStart = true
+	})
+	defer done()
+
+	// No DB calls are expected, as nobody is listening
+	err := ptm.writeEvents(ctx, ptm.p.NOTX(), newTxEvents(components.PublicTxEventCreated, nil, 1, 2))
+	require.NoError(t, err)
+}
+
+func TestEventSubscribersFilteredDelivery(t *testing.T) {
+	ctx, ptm, _, done := newTestPublicTxManager(t, true, func(mocks *mocksAndTestControl, conf *pldconf.PublicTxManagerConfig) {
+		mocks.disableManagerStart = true
+		conf.Manager.EventNotifier.PollInterval = confutil.P("10ms")
+		conf.Manager.Retry = pldconf.RetryConfig{
+			InitialDelay: confutil.P("0ms"),
+		}
+	})
+	defer done()
+
+	signer1 := pldtypes.RandAddress()
+	signer2 := pldtypes.RandAddress()
+	txID1 := uuid.New()
+	txID2 := uuid.New()
+
+	allReceiver := newTestEventReceiver()
+	allReceiver.failNext = true // check we redeliver
+	err := ptm.AddEventSubscriber(ctx, "all", nil, allReceiver)
+	require.NoError(t, err)
+
+	filteredReceiver := newTestEventReceiver()
+	err = ptm.AddEventSubscriber(ctx, "filtered", &components.PublicTxEventFilter{
+		Signers:             []pldtypes.EthAddress{*signer1},
+		TransactionIDPrefix: txID1.String()[0:8],
+		Types:               []components.PublicTxEventType{components.PublicTxEventCreated, components.PublicTxEventSucceeded},
+	}, filteredReceiver)
+	require.NoError(t, err)
+
+	ptm.startEventSubscribers()
+
+	var pubTxns []*pldapi.PublicTx
+	err = ptm.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
+		pubTxns, err = ptm.WriteNewTransactions(ctx, dbTX, []*components.PublicTxSubmission{
+			{
+				Bindings: []*components.PaladinTXReference{{TransactionID: txID1, TransactionType: pldapi.TransactionTypePublic.Enum()}},
+				PublicTxInput: pldapi.PublicTxInput{
+					From:            signer1,
+					PublicTxOptions: pldapi.PublicTxOptions{Gas: confutil.P(pldtypes.HexUint64(21000))},
+				},
+			},
+			{
+				Bindings: []*components.PaladinTXReference{{TransactionID: txID2, TransactionType: pldapi.TransactionTypePublic.Enum()}},
+				PublicTxInput: pldapi.PublicTxInput{
+					From:            signer2,
+					PublicTxOptions: pldapi.PublicTxOptions{Gas: confutil.P(pldtypes.HexUint64(21000))},
+				},
+			},
+		})
+		return err
+	})
+	require.NoError(t, err)
+	require.Len(t, pubTxns, 2)
+
+	txHash := pldtypes.RandBytes32()
+	err = ptm.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
+		_, err := ptm.submissionWriter.runBatch(ctx, dbTX, []*DBPubTxnSubmission{
+			{PublicTxnID: *pubTxns[0].LocalID, TransactionHash: txHash, Created: pldtypes.TimestampNow()},
+		})
+		return err
+	})
+	require.NoError(t, err)
+
+	err = ptm.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
+		_, err := ptm.MatchUpdateConfirmedTransactions(ctx, dbTX, []*blockindexer.IndexedTransactionNotify{
+			{IndexedTransaction: pldapi.IndexedTransaction{Hash: txHash, Result: pldapi.TXResult_SUCCESS.Enum()}},
+		})
+		return err
+	})
+	require.NoError(t, err)
+
+	// Everything in order for the unfiltered subscriber
+	e := allReceiver.next(t)
+	assert.Equal(t, components.PublicTxEventCreated, e.Type)
+	assert.Equal(t, *signer1, e.From)
+	assert.Equal(t, txID1, e.Bindings[0].TransactionID)
+	e = allReceiver.next(t)
+	assert.Equal(t, components.PublicTxEventCreated, e.Type)
+	assert.Equal(t, *signer2, e.From)
+	assert.Equal(t, txID2, e.Bindings[0].TransactionID)
+	e = allReceiver.next(t)
+	assert.Equal(t, components.PublicTxEventSubmitted, e.Type)
+	assert.Equal(t, txHash, *e.TransactionHash)
+	e = allReceiver.next(t)
+	assert.Equal(t, components.PublicTxEventSucceeded, e.Type)
+	assert.Equal(t, txHash, *e.TransactionHash)
+	lastSequence := e.Sequence
+
+	// Only the matching signer, transaction and types for the filtered subscriber
+	e = filteredReceiver.next(t)
+	assert.Equal(t, components.PublicTxEventCreated, e.Type)
+	assert.Equal(t, *pubTxns[0].LocalID, e.PublicTxnID)
+	e = filteredReceiver.next(t)
+	assert.Equal(t, components.PublicTxEventSucceeded, e.Type)
+	assert.Equal(t, *pubTxns[0].LocalID, e.PublicTxnID)
+
+	// Check the cursors are persisted, and the events pruned once all subscribers have them
+	require.Eventually(t, func() bool {
+		var cursors []*DBPublicTxnEventCursor
+		err := ptm.p.DB().Order("subscriber").Find(&cursors).Error
+		require.NoError(t, err)
+		return len(cursors) == 2 &&
+			cursors[0].Sequence == lastSequence &&
+			cursors[1].Sequence == lastSequence
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Eventually(t, func() bool {
+		var remaining int64
+		err := ptm.p.DB().Model(&DBPublicTxnEvent{}).Count(&remaining).Error
+		require.NoError(t, err)
+		return remaining == 0
+	}, 5*time.Second, 10*time.Millisecond)
+
+	assert.Empty(t, allReceiver.events)
+	assert.Empty(t, filteredReceiver.events)
+}
+
+func TestEventSubscriberResumesFromCursor(t *testing.T) {
+	ctx, ptm, _, done := newTestPublicTxManager(t, true, func(mocks *mocksAndTestControl, conf *pldconf.PublicTxManagerConfig) {
+		mocks.disableManagerStart = true
+	})
+	defer done()
+
+	receiver := newTestEventReceiver()
+	err := ptm.AddEventSubscriber(ctx, "sub1", nil, receiver)
+	require.NoError(t, err)
+
+	var pubTxns []*pldapi.PublicTx
+	err = ptm.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
+		pubTxns, err = ptm.WriteNewTransactions(ctx, dbTX, []*components.PublicTxSubmission{
+			{PublicTxInput: pldapi.PublicTxInput{From: pldtypes.RandAddress(), PublicTxOptions: pldapi.PublicTxOptions{Gas: confutil.P(pldtypes.HexUint64(21000))}}},
+			{PublicTxInput: pldapi.PublicTxInput{From: pldtypes.RandAddress(), PublicTxOptions: pldapi.PublicTxOptions{Gas: confutil.P(pldtypes.HexUint64(21000))}}},
+		})
+		return err
+	})
+	require.NoError(t, err)
+
+	// Simulate a previous run that delivered the first event
+	var events []*DBPublicTxnEvent
+	err = ptm.p.DB().Order("sequence").Find(&events).Error
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	err = ptm.p.DB().Create(&DBPublicTxnEventCursor{Subscriber: "sub1", Sequence: events[0].Sequence, Time: pldtypes.TimestampNow()}).Error
+	require.NoError(t, err)
+
+	ptm.startEventSubscribers()
+
+	e := receiver.next(t)
+	assert.Equal(t, events[1].Sequence, e.Sequence)
+	assert.Equal(t, *pubTxns[1].LocalID, e.PublicTxnID)
+	assert.Empty(t, e.Bindings)
+}
@@ -0,0 +1,135 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build faultinjection
+// +build faultinjection
+
+package publictxmgr
+
+import (
+	"context"
+	"math/rand"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/core/internal/msgs"
+)
+
+// FaultInjectionSeedEnvVar can be set to reproduce a failing run, using the seed it logged
+const FaultInjectionSeedEnvVar = "PALADIN_FAULT_INJECTION_SEED"
+
+// FaultInjection configures random failures in the public transaction manager, so that tests can
+// exercise the recovery paths. Probabilities are between 0 (never) and 1 (always).
+// Only available in builds with the "faultinjection" tag.
+type FaultInjection struct {
+	Seed                       int64
+	SubmissionErrorProbability float64       // the submission fails with a transient error, and is retried
+	NonceRaceProbability       float64       // the node reports the nonce was used, as if another process submitted with it
+	ReceiptDelayProbability    float64       // the confirmation reaches the orchestrator late
+	ReceiptDelay               time.Duration // how late a delayed confirmation is
+}
+
+type faultType string
+
+const (
+	faultSubmissionError faultType = "submissionError"
+	faultNonceRace       faultType = "nonceRace"
+	faultReceiptDelay    faultType = "receiptDelay"
+)
+
+type faultInjector struct {
+	conf     FaultInjection
+	mux      sync.Mutex
+	rand     *rand.Rand
+	injected map[faultType]int
+}
+
+// FaultInjectionSeed returns the seed from the environment if set, or a new seed otherwise.
+// The seed is logged, so the sequence of faults can be reproduced when a test fails.
+// Note the sequence is only fully deterministic when a single orchestrator is active.
+func FaultInjectionSeed(ctx context.Context) int64 {
+	seed := time.Now().UnixNano()
+	if envSeed := os.Getenv(FaultInjectionSeedEnvVar); envSeed != "" {
+		if parsed, err := strconv.ParseInt(envSeed, 10, 64); err == nil {
+			seed = parsed
+		} else {
+			log.L(ctx).Warnf("Ignoring invalid %s='%s': %s", FaultInjectionSeedEnvVar, envSeed, err)
+		}
+	}
+	log.L(ctx).Infof("Fault injection seed: %d (set %s to reproduce)", seed, FaultInjectionSeedEnvVar)
+	return seed
+}
+
+// SetFaultInjection must be called before the manager is started
+func (ptm *pubTxManager) SetFaultInjection(conf *FaultInjection) {
+	ptm.faults = &faultInjector{
+		conf:     *conf,
+		rand:     rand.New(rand.NewSource(conf.Seed)),
+		injected: make(map[faultType]int),
+	}
+}
+
+// InjectedFaults returns how many of each type of fault have been injected
+func (ptm *pubTxManager) InjectedFaults() map[string]int {
+	counts := make(map[string]int)
+	if fi := ptm.faults; fi != nil {
+		fi.mux.Lock()
+		defer fi.mux.Unlock()
+		for ft, count := range fi.injected {
+			counts[string(ft)] = count
+		}
+	}
+	return counts
+}
+
+func (fi *faultInjector) roll(ft faultType, probability float64) bool {
+	if probability <= 0 {
+		return false
+	}
+	fi.mux.Lock()
+	defer fi.mux.Unlock()
+	if fi.rand.Float64() >= probability {
+		return false
+	}
+	fi.injected[ft]++
+	return true
+}
+
+// submissionFault is called in place of sending the transaction, and returns errors
+// that are mapped in the same way as the equivalent errors from the blockchain node
+func (fi *faultInjector) submissionFault(ctx context.Context) error {
+	if fi == nil {
+		return nil
+	}
+	switch {
+	case fi.roll(faultNonceRace, fi.conf.NonceRaceProbability):
+		return i18n.NewError(ctx, msgs.MsgPublicTxInjectedFault, "nonce too low")
+	case fi.roll(faultSubmissionError, fi.conf.SubmissionErrorProbability):
+		return i18n.NewError(ctx, msgs.MsgPublicTxInjectedFault, "connection reset by peer")
+	}
+	return nil
+}
+
+func (fi *faultInjector) receiptDelay() time.Duration {
+	if fi != nil && fi.roll(faultReceiptDelay, fi.conf.ReceiptDelayProbability) {
+		return fi.conf.ReceiptDelay
+	}
+	return 0
+}
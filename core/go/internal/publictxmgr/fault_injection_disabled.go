@@ -0,0 +1,36 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !faultinjection
+// +build !faultinjection
+
+package publictxmgr
+
+import (
+	"context"
+	"time"
+)
+
+// faultInjector only injects faults in builds with the "faultinjection" tag
+type faultInjector struct{}
+
+func (fi *faultInjector) submissionFault(ctx context.Context) error {
+	return nil
+}
+
+func (fi *faultInjector) receiptDelay() time.Duration {
+	return 0
+}
@@ -0,0 +1,148 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build faultinjection
+// +build faultinjection
+
+package publictxmgr
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/internal/components"
+	"github.com/kaleido-io/paladin/core/pkg/blockindexer"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestFaultInjectionOrchestrator(t *testing.T, faults *FaultInjection) (context.Context, *orchestrator, *mocksAndTestControl, func()) {
+	ctx, o, m, done := newTestOrchestrator(t, func(mocks *mocksAndTestControl, conf *pldconf.PublicTxManagerConfig) {
+		conf.Orchestrator.SubmissionRetry.InitialDelay = confutil.P("0s")
+		conf.Orchestrator.SubmissionRetry.MaxAttempts = confutil.P(100)
+	})
+	faults.Seed = FaultInjectionSeed(ctx)
+	o.pubTxManager.SetFaultInjection(faults)
+	return ctx, o, m, done
+}
+
+func submitTestTx(ctx context.Context, it *inFlightTransactionStageController) (*pldtypes.Bytes32, SubmissionOutcome, error) {
+	txHash, _, _, outcome, err := it.submitTX(ctx,
+		[]byte(testTransactionData),
+		it.stateManager.GetTransactionHash(),
+		it.stateManager.GetSignerNonce(),
+		it.stateManager.GetLastSubmitTime(),
+		testCancel)
+	return txHash, outcome, err
+}
+
+func TestFaultInjectionSubmissionErrorsRetried(t *testing.T) {
+	ctx, o, m, done := newTestFaultInjectionOrchestrator(t, &FaultInjection{
+		SubmissionErrorProbability: 0.5,
+	})
+	defer done()
+
+	txHash := pldtypes.MustParseBytes32(testTxHash)
+	m.ethClient.On("SendRawTransaction", mock.Anything, mock.Anything).Return(&txHash, nil)
+
+	for nonce := uint64(0); nonce < 20; nonce++ {
+		it, ifts := newInflightTransaction(o, nonce)
+		ifts.ApplyInMemoryUpdates(ctx, &BaseTXUpdates{TransactionHash: &txHash})
+		submittedHash, outcome, err := submitTestTx(ctx, it)
+		require.NoError(t, err)
+		assert.Equal(t, SubmissionOutcomeSubmittedNew, outcome)
+		assert.Equal(t, txHash, *submittedHash)
+	}
+	assert.Positive(t, o.pubTxManager.InjectedFaults()["submissionError"])
+	m.ethClient.AssertNumberOfCalls(t, "SendRawTransaction", 20)
+}
+
+func TestFaultInjectionNonceRace(t *testing.T) {
+	ctx, o, _, done := newTestFaultInjectionOrchestrator(t, &FaultInjection{
+		NonceRaceProbability: 1,
+	})
+	defer done()
+
+	txHash := pldtypes.MustParseBytes32(testTxHash)
+	it, ifts := newInflightTransaction(o, 1)
+	ifts.ApplyInMemoryUpdates(ctx, &BaseTXUpdates{TransactionHash: &txHash})
+
+	// We continue to track our calculated hash, without the node having seen the transaction
+	submittedHash, outcome, err := submitTestTx(ctx, it)
+	require.NoError(t, err)
+	assert.Equal(t, SubmissionOutcomeNonceTooLow, outcome)
+	assert.Equal(t, txHash, *submittedHash)
+	assert.Equal(t, map[string]int{"nonceRace": 1}, o.pubTxManager.InjectedFaults())
+}
+
+func TestFaultInjectionReceiptDelay(t *testing.T) {
+	ctx, o, _, done := newTestFaultInjectionOrchestrator(t, &FaultInjection{
+		ReceiptDelayProbability: 1,
+		ReceiptDelay:            50 * time.Millisecond,
+	})
+	defer done()
+	addTestOrchestrator(t, o.pubTxManager, o)
+	it, _ := newInflightTransaction(o, 1)
+	o.inFlightTxs = append(o.inFlightTxs, it)
+
+	getNewStatus := func() *InFlightStatus {
+		it.transactionMux.Lock()
+		defer it.transactionMux.Unlock()
+		return it.newStatus
+	}
+
+	o.pubTxManager.NotifyConfirmPersisted(ctx, []*components.PublicTxMatch{{
+		IndexedTransactionNotify: &blockindexer.IndexedTransactionNotify{
+			IndexedTransaction: pldapi.IndexedTransaction{
+				From:  &o.signingAddress,
+				Nonce: 1,
+			},
+		},
+	}})
+	assert.Nil(t, getNewStatus())
+	assert.Eventually(t, func() bool {
+		status := getNewStatus()
+		return status != nil && *status == InFlightStatusConfirmReceived
+	}, 5*time.Second, 5*time.Millisecond)
+}
+
+func TestFaultInjectionSeedFromEnv(t *testing.T) {
+	ctx := context.Background()
+	t.Setenv(FaultInjectionSeedEnvVar, "12345")
+	assert.Equal(t, int64(12345), FaultInjectionSeed(ctx))
+
+	t.Setenv(FaultInjectionSeedEnvVar, "not a number")
+	assert.NotEqual(t, int64(12345), FaultInjectionSeed(ctx))
+}
+
+func TestFaultInjectionDeterministic(t *testing.T) {
+	sequence := func() []bool {
+		ptm := &pubTxManager{}
+		ptm.SetFaultInjection(&FaultInjection{Seed: 42, SubmissionErrorProbability: 0.5})
+		results := make([]bool, 50)
+		for i := range results {
+			results[i] = ptm.faults.submissionFault(context.Background()) != nil
+		}
+		return results
+	}
+	assert.Equal(t, sequence(), sequence())
+}
@@ -20,11 +20,14 @@ import (
 	"encoding/json"
 	"fmt"
 	"math/big"
+	"sync"
+	"time"
 
 	"github.com/hyperledger/firefly-common/pkg/fftypes"
 	"github.com/hyperledger/firefly-signer/pkg/ethsigner"
 	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
 	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
 	"github.com/kaleido-io/paladin/config/pkg/pldconf"
 	"github.com/kaleido-io/paladin/core/internal/msgs"
 
@@ -42,6 +45,10 @@ type GasPriceClient interface {
 	GetFixedGasPriceJSON(ctx context.Context) (gasPrice *fftypes.JSONAny)
 	ParseGasPriceJSON(ctx context.Context, input *fftypes.JSONAny) (gpo *pldapi.PublicTxGasPricing, err error)
 	GetGasPriceObject(ctx context.Context) (gasPrice *pldapi.PublicTxGasPricing, err error)
+	GetGasPriceIncreaseOverrides(ctx context.Context) (increasePercentage *int, increaseMax *big.Int)
+	GetBlobGasPrice(ctx context.Context) (maxFeePerBlobGas *pldtypes.HexUint256, err error)
+	GetSchedules(ctx context.Context) []*pldconf.GasPriceScheduleConfig
+	SetSchedules(ctx context.Context, schedules []*pldconf.GasPriceScheduleConfig) error
 	Init(ctx context.Context, cAPI ethclient.EthClient)
 }
 
@@ -50,11 +57,18 @@ type GasPriceClient interface {
 //   - Cached gas price
 //   - Gas Oracle
 //   - Node gas_Price
+//
+// Unless there is a fixed gas price, the first active schedule (if any) is then applied to the gas price from the node.
 type HybridGasPriceClient struct {
 	hasZeroGasPrice bool
 	fixedGasPrice   *fftypes.JSONAny
 	ethClient       ethclient.EthClient
 	gasPriceCache   cache.Cache[string, *fftypes.JSONAny]
+	scheduleLock    sync.RWMutex
+	scheduleConf    []*pldconf.GasPriceScheduleConfig
+	schedules       []*gasPriceSchedule
+	now             func() time.Time
+	blobFeeFactor   float64
 }
 
 func (hGpc *HybridGasPriceClient) HasZeroGasPrice(ctx context.Context) bool {
@@ -88,7 +102,95 @@ func (hGpc *HybridGasPriceClient) GetGasPriceObject(ctx context.Context) (gasPri
 		return nil, err
 	}
 
-	return hGpc.ParseGasPriceJSON(ctx, gasPriceJSON)
+	gasPrice, err = hGpc.ParseGasPriceJSON(ctx, gasPriceJSON)
+	if err != nil || !hGpc.fixedGasPrice.IsNil() {
+		// schedules do not apply when the gas price is fixed for the whole node
+		return gasPrice, err
+	}
+
+	if schedule := hGpc.activeSchedule(gasPrice.GasPrice); schedule != nil {
+		log.L(ctx).Debugf("Applying gas price schedule %s to node gas price %s", schedule.name, gasPrice.GasPrice)
+		return schedule.apply(ctx, hGpc, gasPrice)
+	}
+	return gasPrice, nil
+}
+
+// GetGasPriceIncreaseOverrides returns the gas price increase settings for resubmission from the active
+// schedule, if there is one that overrides them. The cached gas price from the node is used to check any
+// congestion threshold, so that the node is not queried.
+func (hGpc *HybridGasPriceClient) GetGasPriceIncreaseOverrides(ctx context.Context) (increasePercentage *int, increaseMax *big.Int) {
+	if !hGpc.fixedGasPrice.IsNil() {
+		return nil, nil
+	}
+	var nodeGasPrice *pldtypes.HexUint256
+	if cachedGasPrice, _ := hGpc.gasPriceCache.Get("gasPrice"); cachedGasPrice != nil {
+		if gpo, err := hGpc.ParseGasPriceJSON(ctx, cachedGasPrice); err == nil {
+			nodeGasPrice = gpo.GasPrice
+		}
+	}
+	if schedule := hGpc.activeSchedule(nodeGasPrice); schedule != nil {
+		return schedule.increasePercentage, schedule.increaseMax
+	}
+	return nil, nil
+}
+
+// GetBlobGasPrice returns the max fee per blob gas for a blob transaction, which is the blob base fee
+// from the node with headroom for it to rise before the transaction is mined.
+// Schedules and fixed gas prices do not apply to blob gas, as it is priced by a separate market.
+func (hGpc *HybridGasPriceClient) GetBlobGasPrice(ctx context.Context) (*pldtypes.HexUint256, error) {
+	blobBaseFee, err := hGpc.ethClient.BlobBaseFee(ctx)
+	if err != nil {
+		log.L(ctx).Errorf("Failed to retrieve blob base fee from the node: %s", err)
+		return nil, err
+	}
+	if hGpc.blobFeeFactor <= 0 {
+		return blobBaseFee, nil
+	}
+	scaled, _ := new(big.Float).Mul(new(big.Float).SetInt(blobBaseFee.Int()), big.NewFloat(hGpc.blobFeeFactor)).Int(nil)
+	return (*pldtypes.HexUint256)(scaled), nil
+}
+
+func (hGpc *HybridGasPriceClient) activeSchedule(nodeGasPrice *pldtypes.HexUint256) *gasPriceSchedule {
+	hGpc.scheduleLock.RLock()
+	defer hGpc.scheduleLock.RUnlock()
+	if len(hGpc.schedules) == 0 {
+		return nil
+	}
+	now := time.Now()
+	if hGpc.now != nil {
+		now = hGpc.now()
+	}
+	for _, s := range hGpc.schedules {
+		if s.isActive(now, nodeGasPrice.Int()) {
+			return s
+		}
+	}
+	return nil
+}
+
+func (hGpc *HybridGasPriceClient) GetSchedules(ctx context.Context) []*pldconf.GasPriceScheduleConfig {
+	hGpc.scheduleLock.RLock()
+	defer hGpc.scheduleLock.RUnlock()
+	return hGpc.scheduleConf
+}
+
+// SetSchedules validates and replaces the full list of schedules, so they can be changed without a restart.
+// The list is not persisted, so the configured schedules are restored on restart.
+func (hGpc *HybridGasPriceClient) SetSchedules(ctx context.Context, scheduleConf []*pldconf.GasPriceScheduleConfig) error {
+	schedules := make([]*gasPriceSchedule, len(scheduleConf))
+	for i, conf := range scheduleConf {
+		s, err := newGasPriceSchedule(ctx, i, conf)
+		if err != nil {
+			return err
+		}
+		schedules[i] = s
+	}
+	hGpc.scheduleLock.Lock()
+	defer hGpc.scheduleLock.Unlock()
+	hGpc.scheduleConf = scheduleConf
+	hGpc.schedules = schedules
+	log.L(ctx).Debugf("Gas price schedules set: %d", len(schedules))
+	return nil
 }
 
 func (hGpc *HybridGasPriceClient) getGasPriceJSON(ctx context.Context) (gasPriceJSON *fftypes.JSONAny, err error) {
@@ -144,7 +246,7 @@ func (hGpc *HybridGasPriceClient) DeleteCache(ctx context.Context) {
 }
 
 func NewGasPriceClient(ctx context.Context, conf *pldconf.PublicTxManagerConfig) GasPriceClient {
-	gasPriceCache := cache.NewCache[string, *fftypes.JSONAny](&conf.GasPrice.Cache, &pldconf.PublicTxManagerDefaults.GasPrice.Cache)
+	gasPriceCache := cache.NewNamedCache[string, *fftypes.JSONAny]("publictxmgr.gasPrices", &conf.GasPrice.Cache, &pldconf.PublicTxManagerDefaults.GasPrice.Cache)
 	log.L(ctx).Debugf("Gas price cache size: %d", gasPriceCache.Capacity())
 	gasPriceClient := &HybridGasPriceClient{}
 	// initialize gas oracle
@@ -154,6 +256,7 @@ func NewGasPriceClient(ctx context.Context, conf *pldconf.PublicTxManagerConfig)
 		gasPriceClient.fixedGasPrice = fftypes.JSONAnyPtrBytes(b)
 	}
 	gasPriceClient.gasPriceCache = gasPriceCache
+	gasPriceClient.blobFeeFactor = confutil.Float64Min(conf.GasPrice.Blob.FeeMultiplier, 1.0, *pldconf.PublicTxManagerDefaults.GasPrice.Blob.FeeMultiplier)
 	return gasPriceClient
 }
 
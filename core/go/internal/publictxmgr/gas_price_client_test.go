@@ -20,9 +20,11 @@ import (
 	"fmt"
 	"math/big"
 	"testing"
+	"time"
 
 	"github.com/hyperledger/firefly-common/pkg/fftypes"
 	"github.com/hyperledger/firefly-signer/pkg/ethsigner"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
 	"github.com/kaleido-io/paladin/config/pkg/pldconf"
 
 	"github.com/kaleido-io/paladin/core/mocks/ethclientmocks"
@@ -175,3 +177,111 @@ func TestGasPriceClient(t *testing.T) {
 	assert.Regexp(t, "doesn't work", err)
 	assert.Nil(t, gpo)
 }
+
+func TestGasPriceClientSchedules(t *testing.T) {
+	ctx := context.Background()
+
+	gasPriceClient := NewGasPriceClient(ctx, &pldconf.PublicTxManagerConfig{})
+	hgc := gasPriceClient.(*HybridGasPriceClient)
+	now, _ := time.Parse(time.RFC3339, "2025-01-06T12:00:00Z")
+	hgc.now = func() time.Time { return now }
+
+	mEC := ethclientmocks.NewEthClient(t)
+	hgc.Init(ctx, mEC)
+	mEC.On("GasPrice", ctx, mock.Anything).Return(pldtypes.Uint64ToUint256(1000), nil)
+
+	err := hgc.SetSchedules(ctx, []*pldconf.GasPriceScheduleConfig{{TimeZone: "wrong"}})
+	assert.Regexp(t, "PD011943", err)
+
+	schedules := []*pldconf.GasPriceScheduleConfig{
+		{
+			Name:               "congested",
+			MinNodeGasPrice:    confutil.P("5000"),
+			FixedGasPrice:      100000,
+			IncreasePercentage: confutil.P(100),
+		},
+		{
+			Name:               "settlement",
+			StartTime:          "11:00",
+			EndTime:            "13:00",
+			Multiplier:         confutil.P(2.0),
+			IncreasePercentage: confutil.P(50),
+			IncreaseMax:        confutil.P("3000"),
+		},
+	}
+	err = hgc.SetSchedules(ctx, schedules)
+	require.NoError(t, err)
+	assert.Equal(t, schedules, hgc.GetSchedules(ctx))
+
+	// Nothing cached yet for the congestion check
+	increasePercentage, increaseMax := hgc.GetGasPriceIncreaseOverrides(ctx)
+	assert.Equal(t, 50, *increasePercentage)
+	assert.Equal(t, int64(3000), increaseMax.Int64())
+
+	gpo, err := hgc.GetGasPriceObject(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2000), gpo.GasPrice.Int().Int64())
+
+	// Outside the window
+	now = now.Add(2 * time.Hour)
+	gpo, err = hgc.GetGasPriceObject(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1000), gpo.GasPrice.Int().Int64())
+	increasePercentage, increaseMax = hgc.GetGasPriceIncreaseOverrides(ctx)
+	assert.Nil(t, increasePercentage)
+	assert.Nil(t, increaseMax)
+
+	// Congested network
+	hgc.gasPriceCache.Set("gasPrice", fftypes.JSONAnyPtr(`"0x1388"`))
+	gpo, err = hgc.GetGasPriceObject(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(100000), gpo.GasPrice.Int().Int64())
+	increasePercentage, increaseMax = hgc.GetGasPriceIncreaseOverrides(ctx)
+	assert.Equal(t, 100, *increasePercentage)
+	assert.Nil(t, increaseMax)
+
+	// Schedules do not apply with a fixed gas price
+	fixedHgc := NewTestFixedPriceGasPriceClient(t).(*HybridGasPriceClient)
+	err = fixedHgc.SetSchedules(ctx, schedules)
+	require.NoError(t, err)
+	gpo, err = fixedHgc.GetGasPriceObject(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(10), gpo.GasPrice.Int().Int64())
+	increasePercentage, increaseMax = fixedHgc.GetGasPriceIncreaseOverrides(ctx)
+	assert.Nil(t, increasePercentage)
+	assert.Nil(t, increaseMax)
+
+	// Schedules can be removed
+	err = hgc.SetSchedules(ctx, nil)
+	require.NoError(t, err)
+	gpo, err = hgc.GetGasPriceObject(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(5000), gpo.GasPrice.Int().Int64())
+}
+
+func TestGetBlobGasPrice(t *testing.T) {
+	ctx := context.Background()
+	mEC := ethclientmocks.NewEthClient(t)
+	hgc := NewGasPriceClient(ctx, &pldconf.PublicTxManagerConfig{
+		GasPrice: pldconf.GasPriceConfig{
+			Blob: pldconf.BlobGasPriceConfig{FeeMultiplier: confutil.P(1.5)},
+		},
+	}).(*HybridGasPriceClient)
+	hgc.Init(ctx, mEC)
+
+	mEC.On("BlobBaseFee", ctx).Return(pldtypes.MustParseHexUint256("1000"), nil).Once()
+	maxFeePerBlobGas, err := hgc.GetBlobGasPrice(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1500), maxFeePerBlobGas.Int().Int64())
+
+	// Without a multiplier the base fee is used as is
+	hgc.blobFeeFactor = 0
+	mEC.On("BlobBaseFee", ctx).Return(pldtypes.MustParseHexUint256("1000"), nil).Once()
+	maxFeePerBlobGas, err = hgc.GetBlobGasPrice(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1000), maxFeePerBlobGas.Int().Int64())
+
+	mEC.On("BlobBaseFee", ctx).Return(nil, fmt.Errorf("pop")).Once()
+	_, err = hgc.GetBlobGasPrice(ctx)
+	assert.Regexp(t, "pop", err)
+}
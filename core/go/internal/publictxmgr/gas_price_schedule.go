@@ -0,0 +1,201 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package publictxmgr
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/internal/msgs"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+)
+
+// gasPriceSchedule is the parsed form of a pldconf.GasPriceScheduleConfig
+type gasPriceSchedule struct {
+	name               string
+	days               map[time.Weekday]bool // nil for every day
+	start              time.Duration         // offset from midnight
+	end                time.Duration         // offset from midnight - equal to start for the whole day
+	location           *time.Location
+	minNodeGasPrice    *big.Int
+	fixedGasPrice      *fftypes.JSONAny
+	multiplier         *big.Float
+	maxGasPrice        *big.Int
+	increasePercentage *int
+	increaseMax        *big.Int
+}
+
+func newGasPriceSchedule(ctx context.Context, idx int, conf *pldconf.GasPriceScheduleConfig) (s *gasPriceSchedule, err error) {
+	s = &gasPriceSchedule{
+		name:               conf.Name,
+		location:           time.UTC,
+		increasePercentage: conf.IncreasePercentage,
+	}
+	if s.name == "" {
+		s.name = fmt.Sprintf("schedule[%d]", idx)
+	}
+
+	for _, day := range conf.Days {
+		weekday, ok := parseWeekday(day)
+		if !ok {
+			return nil, i18n.NewError(ctx, msgs.MsgGasPriceScheduleInvalidDay, day, s.name)
+		}
+		if s.days == nil {
+			s.days = make(map[time.Weekday]bool)
+		}
+		s.days[weekday] = true
+	}
+
+	if conf.StartTime != "" || conf.EndTime != "" {
+		if s.start, err = parseTimeOfDay(ctx, s.name, conf.StartTime); err != nil {
+			return nil, err
+		}
+		if s.end, err = parseTimeOfDay(ctx, s.name, conf.EndTime); err != nil {
+			return nil, err
+		}
+	}
+
+	if conf.TimeZone != "" {
+		if s.location, err = time.LoadLocation(conf.TimeZone); err != nil {
+			return nil, i18n.WrapError(ctx, err, msgs.MsgGasPriceScheduleInvalidTimeZone, conf.TimeZone, s.name)
+		}
+	}
+
+	if s.minNodeGasPrice, err = parseScheduleGasPrice(ctx, s.name, conf.MinNodeGasPrice); err != nil {
+		return nil, err
+	}
+	if s.maxGasPrice, err = parseScheduleGasPrice(ctx, s.name, conf.MaxGasPrice); err != nil {
+		return nil, err
+	}
+	if s.increaseMax, err = parseScheduleGasPrice(ctx, s.name, conf.IncreaseMax); err != nil {
+		return nil, err
+	}
+
+	if conf.Multiplier != nil {
+		if *conf.Multiplier <= 0 {
+			return nil, i18n.NewError(ctx, msgs.MsgGasPriceScheduleInvalidMult, *conf.Multiplier, s.name)
+		}
+		s.multiplier = big.NewFloat(*conf.Multiplier)
+	}
+
+	if conf.FixedGasPrice != nil {
+		b, _ := json.Marshal(conf.FixedGasPrice)
+		s.fixedGasPrice = fftypes.JSONAnyPtrBytes(b)
+		// Check it parses now, rather than every time the schedule is active
+		if _, err := (&HybridGasPriceClient{}).ParseGasPriceJSON(ctx, s.fixedGasPrice); err != nil {
+			return nil, i18n.NewError(ctx, msgs.MsgGasPriceScheduleInvalidPrice, s.fixedGasPrice, s.name)
+		}
+	}
+
+	return s, nil
+}
+
+func parseWeekday(day string) (time.Weekday, bool) {
+	day = strings.ToLower(day)
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		fullName := strings.ToLower(d.String())
+		if day == fullName || day == fullName[0:3] {
+			return d, true
+		}
+	}
+	return 0, false
+}
+
+func parseTimeOfDay(ctx context.Context, name, hhmm string) (time.Duration, error) {
+	t, err := time.Parse("15:04", hhmm)
+	if err != nil {
+		return 0, i18n.NewError(ctx, msgs.MsgGasPriceScheduleInvalidTime, hhmm, name)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+func parseScheduleGasPrice(ctx context.Context, name string, sVal *string) (*big.Int, error) {
+	if sVal == nil {
+		return nil, nil
+	}
+	bi, ok := new(big.Int).SetString(*sVal, 0)
+	if !ok || bi.Sign() < 0 {
+		return nil, i18n.NewError(ctx, msgs.MsgGasPriceScheduleInvalidPrice, *sVal, name)
+	}
+	return bi, nil
+}
+
+// isActive checks whether the time is within the window of the schedule, and the
+// gas price reported by the node meets the congestion threshold (if there is one).
+// The node gas price can be nil if it is not known, in which case schedules with
+// a congestion threshold are not active.
+func (s *gasPriceSchedule) isActive(now time.Time, nodeGasPrice *big.Int) bool {
+	if s.minNodeGasPrice != nil && (nodeGasPrice == nil || nodeGasPrice.Cmp(s.minNodeGasPrice) < 0) {
+		return false
+	}
+
+	local := now.In(s.location)
+	offset := time.Duration(local.Hour())*time.Hour + time.Duration(local.Minute())*time.Minute + time.Duration(local.Second())*time.Second
+	windowDay := local.Weekday()
+	switch {
+	case s.start == s.end:
+		// whole day
+	case s.start < s.end:
+		if offset < s.start || offset >= s.end {
+			return false
+		}
+	default:
+		// the window spans midnight, so the early hours belong to the window that started the previous day
+		if offset >= s.end && offset < s.start {
+			return false
+		}
+		if offset < s.end {
+			windowDay = (windowDay + 6) % 7
+		}
+	}
+	return s.days == nil || s.days[windowDay]
+}
+
+// apply adjusts the gas price that has been obtained from the node, according to the schedule
+func (s *gasPriceSchedule) apply(ctx context.Context, gpc GasPriceClient, gpo *pldapi.PublicTxGasPricing) (*pldapi.PublicTxGasPricing, error) {
+	if s.fixedGasPrice != nil {
+		var err error
+		if gpo, err = gpc.ParseGasPriceJSON(ctx, s.fixedGasPrice); err != nil {
+			return nil, err
+		}
+	} else if s.multiplier != nil && gpo.GasPrice != nil {
+		scaled, _ := new(big.Float).Mul(new(big.Float).SetInt(gpo.GasPrice.Int()), s.multiplier).Int(nil)
+		gpo = &pldapi.PublicTxGasPricing{GasPrice: (*pldtypes.HexUint256)(scaled)}
+	}
+
+	if s.maxGasPrice != nil {
+		capped := *gpo
+		if capped.GasPrice != nil && capped.GasPrice.Int().Cmp(s.maxGasPrice) > 0 {
+			capped.GasPrice = (*pldtypes.HexUint256)(new(big.Int).Set(s.maxGasPrice))
+		}
+		if capped.MaxFeePerGas != nil && capped.MaxFeePerGas.Int().Cmp(s.maxGasPrice) > 0 {
+			capped.MaxFeePerGas = (*pldtypes.HexUint256)(new(big.Int).Set(s.maxGasPrice))
+		}
+		if capped.MaxPriorityFeePerGas != nil && capped.MaxPriorityFeePerGas.Int().Cmp(s.maxGasPrice) > 0 {
+			capped.MaxPriorityFeePerGas = (*pldtypes.HexUint256)(new(big.Int).Set(s.maxGasPrice))
+		}
+		gpo = &capped
+	}
+	return gpo, nil
+}
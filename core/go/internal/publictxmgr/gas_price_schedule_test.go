@@ -0,0 +1,141 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package publictxmgr
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGasPriceScheduleParseErrors(t *testing.T) {
+	ctx := context.Background()
+	for _, tc := range []struct {
+		conf pldconf.GasPriceScheduleConfig
+		err  string
+	}{
+		{pldconf.GasPriceScheduleConfig{Days: []string{"funday"}}, "PD011942.*funday.*schedule\\[0\\]"},
+		{pldconf.GasPriceScheduleConfig{Name: "s1", StartTime: "9am", EndTime: "17:00"}, "PD011941.*9am.*s1"},
+		{pldconf.GasPriceScheduleConfig{StartTime: "09:00"}, "PD011941"},
+		{pldconf.GasPriceScheduleConfig{TimeZone: "Mars/Olympus_Mons"}, "PD011943.*Mars"},
+		{pldconf.GasPriceScheduleConfig{MinNodeGasPrice: confutil.P("lots")}, "PD011944.*lots"},
+		{pldconf.GasPriceScheduleConfig{MaxGasPrice: confutil.P("-1")}, "PD011944"},
+		{pldconf.GasPriceScheduleConfig{IncreaseMax: confutil.P("")}, "PD011944"},
+		{pldconf.GasPriceScheduleConfig{Multiplier: confutil.P(0.0)}, "PD011945"},
+		{pldconf.GasPriceScheduleConfig{FixedGasPrice: "not a number"}, "PD011944"},
+	} {
+		_, err := newGasPriceSchedule(ctx, 0, &tc.conf)
+		assert.Regexp(t, tc.err, err)
+	}
+}
+
+func TestGasPriceScheduleIsActive(t *testing.T) {
+	ctx := context.Background()
+
+	// Monday 2025-01-06
+	monday := func(hhmm string) time.Time {
+		t, _ := time.Parse("2006-01-02 15:04", "2025-01-06 "+hhmm)
+		return t
+	}
+
+	businessHours, err := newGasPriceSchedule(ctx, 0, &pldconf.GasPriceScheduleConfig{
+		Days:      []string{"Monday", "tue", "WED", "thu", "fri"},
+		StartTime: "09:00",
+		EndTime:   "17:00",
+	})
+	require.NoError(t, err)
+	assert.False(t, businessHours.isActive(monday("08:59"), nil))
+	assert.True(t, businessHours.isActive(monday("09:00"), nil))
+	assert.True(t, businessHours.isActive(monday("16:59"), nil))
+	assert.False(t, businessHours.isActive(monday("17:00"), nil))
+	assert.False(t, businessHours.isActive(monday("12:00").Add(-24*time.Hour), nil)) // sunday
+
+	// Window that spans midnight, starting on Sunday evenings only
+	overnight, err := newGasPriceSchedule(ctx, 0, &pldconf.GasPriceScheduleConfig{
+		Days:      []string{"sun"},
+		StartTime: "22:00",
+		EndTime:   "02:00",
+	})
+	require.NoError(t, err)
+	assert.True(t, overnight.isActive(monday("01:59"), nil)) // started on sunday
+	assert.False(t, overnight.isActive(monday("02:00"), nil))
+	assert.False(t, overnight.isActive(monday("22:00"), nil)) // monday evening
+	assert.True(t, overnight.isActive(monday("22:00").Add(-24*time.Hour), nil))
+
+	// Time zone - 09:00 in New York is 14:00 UTC in January
+	newYork, err := newGasPriceSchedule(ctx, 0, &pldconf.GasPriceScheduleConfig{
+		StartTime: "09:00",
+		EndTime:   "10:00",
+		TimeZone:  "America/New_York",
+	})
+	require.NoError(t, err)
+	assert.False(t, newYork.isActive(monday("09:30"), nil))
+	assert.True(t, newYork.isActive(monday("14:30"), nil))
+
+	// Congestion only, all day
+	congested, err := newGasPriceSchedule(ctx, 0, &pldconf.GasPriceScheduleConfig{
+		MinNodeGasPrice: confutil.P("1000"),
+	})
+	require.NoError(t, err)
+	assert.False(t, congested.isActive(monday("12:00"), nil))
+	assert.False(t, congested.isActive(monday("12:00"), big.NewInt(999)))
+	assert.True(t, congested.isActive(monday("12:00"), big.NewInt(1000)))
+}
+
+func TestGasPriceScheduleApply(t *testing.T) {
+	ctx := context.Background()
+	hgc := &HybridGasPriceClient{}
+	nodeGasPrice := &pldapi.PublicTxGasPricing{GasPrice: pldtypes.Int64ToInt256(1000)}
+
+	s, err := newGasPriceSchedule(ctx, 0, &pldconf.GasPriceScheduleConfig{Multiplier: confutil.P(1.5)})
+	require.NoError(t, err)
+	gpo, err := s.apply(ctx, hgc, nodeGasPrice)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1500), gpo.GasPrice.Int().Int64())
+	assert.Equal(t, int64(1000), nodeGasPrice.GasPrice.Int().Int64())
+
+	s, err = newGasPriceSchedule(ctx, 0, &pldconf.GasPriceScheduleConfig{Multiplier: confutil.P(3.0), MaxGasPrice: confutil.P("2000")})
+	require.NoError(t, err)
+	gpo, err = s.apply(ctx, hgc, nodeGasPrice)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2000), gpo.GasPrice.Int().Int64())
+
+	s, err = newGasPriceSchedule(ctx, 0, &pldconf.GasPriceScheduleConfig{
+		FixedGasPrice: map[string]any{"maxFeePerGas": 5000, "maxPriorityFeePerGas": 3000},
+		MaxGasPrice:   confutil.P("0x7d0"),
+	})
+	require.NoError(t, err)
+	gpo, err = s.apply(ctx, hgc, nodeGasPrice)
+	require.NoError(t, err)
+	assert.Nil(t, gpo.GasPrice)
+	assert.Equal(t, int64(2000), gpo.MaxFeePerGas.Int().Int64())
+	assert.Equal(t, int64(2000), gpo.MaxPriorityFeePerGas.Int().Int64())
+
+	// With no adjustments the node gas price is returned unchanged
+	s.fixedGasPrice = nil
+	s.maxGasPrice = nil
+	gpo, err = s.apply(ctx, hgc, nodeGasPrice)
+	require.NoError(t, err)
+	assert.Equal(t, nodeGasPrice, gpo)
+}
@@ -22,6 +22,7 @@ import (
 	"math/big"
 	"runtime/debug"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/hyperledger/firefly-common/pkg/fftypes"
@@ -73,6 +74,9 @@ type inFlightTransactionStageController struct {
 	updates   []*DBPublicTxn
 	updateMux sync.Mutex
 
+	// set when the node has lost our pending transactions, to sign and submit again without waiting for the resubmit interval
+	rebroadcastRequested atomic.Bool
+
 	// deleteRequested bool // figure out what's the reliable approach for deletion
 }
 
@@ -139,6 +143,10 @@ func NewInFlightTransactionStageController(
 	ift.MarkTime("wait_in_inflight_queue")
 	imtxs := NewInMemoryTxStateManager(enth.ctx, ptx)
 	ift.stateManager = NewInFlightTransactionStateManager(enth.thMetrics, enth.balanceManager, ift, imtxs, oc, oc.submissionWriter, ift.testOnlyNoEventMode)
+	if enth.takeRecoveredTracking(ptx.PublicTxnID) {
+		// recovery on start found the chain already has the submission we recorded, so there is no need to submit it again
+		ift.stateManager.GetCurrentGeneration(enth.ctx).SetValidatedTransactionHashMatchState(enth.ctx, true)
+	}
 	return ift
 }
 
@@ -211,11 +219,17 @@ func (it *inFlightTransactionStageController) ProduceLatestInFlightStageContext(
 		}
 	}
 
-	if madeUpdate {
+	cancelling := it.checkExpiry(ctx)
+
+	if madeUpdate || cancelling {
 		// If we have made an update we don't wait to collect the output of whatever stages might be already running before starting
 		// the process of submitting the transaction with its new values.
 		it.stateManager.NewGeneration(ctx)
 	}
+	if cancelling && it.stateManager.GetTransactionHash() != nil {
+		// the node will only accept the replacement if it is priced above the transaction it has in its pool
+		it.TriggerNewStageRun(ctx, InFlightTxStageRetrieveGasPrice, BaseTxSubStatusStale)
+	}
 
 	// update the transaction orchestrator context
 	it.stateManager.SetOrchestratorContext(ctx, tIn)
@@ -231,6 +245,10 @@ func (it *inFlightTransactionStageController) ProduceLatestInFlightStageContext(
 			c, err := calculateGasRequiredForTransaction(ctx, gpo, it.stateManager.GetGasLimit())
 			if err == nil {
 				tOut.Cost = c
+				if blobCount := len(it.stateManager.GetBlobHashes()); blobCount > 0 && c != nil && gpo.MaxFeePerBlobGas != nil {
+					blobCost := new(big.Int).Mul(gpo.MaxFeePerBlobGas.Int(), big.NewInt(int64(blobCount*blobGasPerBlob)))
+					tOut.Cost = c.Add(c, blobCost)
+				}
 			}
 		}
 	}
@@ -247,6 +265,21 @@ func (it *inFlightTransactionStageController) ProduceLatestInFlightStageContext(
 	return tOut
 }
 
+// checkExpiry switches the transaction to cancellation once its expiry has passed, returning true if it did so.
+// If the original transaction is mined before the replacement, it completes as normal.
+func (it *inFlightTransactionStageController) checkExpiry(ctx context.Context) bool {
+	expiry := it.stateManager.GetExpiry()
+	if expiry == nil || it.stateManager.IsCancelling() || it.stateManager.IsReadyToExit() || time.Now().Before(expiry.Time()) {
+		return false
+	}
+	log.L(ctx).Infof("Transaction with ID %s was not mined before its expiry %s - replacing it with a no-op transaction", it.stateManager.GetSignerNonce(), expiry)
+	it.stateManager.StartCancellation()
+	return true
+}
+
+// waiting for a receipt is not a stage of its own, but it times out like one when the transaction is re-submitted
+const stageTimeoutReceiptWait = "receiptWait"
+
 func (it *inFlightTransactionStageController) processCurrentGenerationStageOutputs(ctx context.Context) (err error) {
 	currentGeneration := it.stateManager.GetCurrentGeneration(ctx)
 	if currentGeneration.GetRunningStageContext(ctx) != nil {
@@ -296,6 +329,13 @@ func (it *inFlightTransactionStageController) processCurrentGenerationStageOutpu
 				// if the stage didn't succeed, we retry the stage after the stage timeout
 				log.L(ctx).Debugf("Retrying stage: %s, for transaction with ID: %s after %s", rsc.Stage, rsc.InMemoryTx.GetSignerNonce(), time.Since(rsc.StageStartTime))
 				currentGeneration.ClearRunningStageContext(ctx)
+			} else if timeout, ok := it.stageTimeouts[rsc.Stage]; ok && !rsc.StageErrored &&
+				currentGeneration.GetRunningStageContext(ctx) == rsc && time.Since(rsc.StageStartTime) > timeout {
+				// the stage is stuck (for example a signer or node that never responds), so we abandon it and run it again.
+				// Each of the stages is safe to repeat, as the transaction is signed with the same nonce and gas price.
+				log.L(ctx).Warnf("Stage %s for transaction with ID %s timed out after %s", rsc.Stage, rsc.InMemoryTx.GetSignerNonce(), time.Since(rsc.StageStartTime))
+				it.thMetrics.RecordStageTimeoutMetrics(ctx, string(rsc.Stage))
+				currentGeneration.ClearRunningStageContext(ctx)
 			}
 		}
 	}
@@ -371,6 +411,7 @@ func (it *inFlightTransactionStageController) processSigningStageOutput(ctx cont
 			// signed data received
 			if rsIn.SignOutput.SignedMessage != nil {
 				// signed message can be nil when no signer is configured
+				it.latency.record(ctx, rsc.InMemoryTx.GetPubTxnID(), pldapi.PublicTxStageSigned)
 				rsc.StageOutputsToBePersisted.UpdateSubStatus(BaseTxActionSign, fftypes.JSONAnyPtr(fmt.Sprintf(`{"hash":"%s"}`, rsIn.SignOutput.TxHash)), nil)
 			}
 		}
@@ -392,6 +433,8 @@ func (it *inFlightTransactionStageController) processSigningStageOutput(ctx cont
 				Created:         pldtypes.TimestampNow(),
 				TransactionHash: *rsc.StageOutput.SignOutput.TxHash,
 				GasPricing:      gasPriceJSON,
+				Cancel:          rsc.InMemoryTx.IsCancelling(),
+				RawTransaction:  rsc.StageOutput.SignOutput.SignedMessage,
 			}
 			rsc.StageOutputsToBePersisted.TxUpdates.TransactionHash = rsc.StageOutput.SignOutput.TxHash
 		}
@@ -441,6 +484,7 @@ func (it *inFlightTransactionStageController) processSubmittingStageOutput(ctx c
 				rsc.StageOutputsToBePersisted.TxUpdates = &BaseTXUpdates{}
 			}
 			rsc.StageOutputsToBePersisted.TxUpdates.LastSubmit = stageOutput.SubmitOutput.SubmissionTime
+			it.latency.record(ctx, rsc.InMemoryTx.GetPubTxnID(), pldapi.PublicTxStageSubmitted)
 
 			if stageOutput.SubmitOutput.SubmissionOutcome == SubmissionOutcomeSubmittedNew {
 				// new transaction submitted successfully
@@ -527,9 +571,15 @@ func (it *inFlightTransactionStageController) startNewStage(ctx context.Context,
 		} else {
 			// once we validated the transaction hash matched the transaction state
 			lastSubmitTime := it.stateManager.GetLastSubmitTime()
-			if lastSubmitTime != nil && time.Since(lastSubmitTime.Time()) > it.resubmitInterval {
+			if it.rebroadcastRequested.Load() && it.stateManager.CanSubmit(ctx, cost) {
+				// the node lost the transaction, so sign it again with the same gas price - which gives the same hash
+				it.rebroadcastRequested.Store(false)
+				log.L(ctx).Infof("Transaction with ID %s entering signing stage to re-broadcast to the blockchain node.", it.stateManager.GetSignerNonce())
+				it.TriggerNewStageRun(ctx, InFlightTxStageSigning, BaseTxSubStatusTracking)
+			} else if lastSubmitTime != nil && time.Since(lastSubmitTime.Time()) > it.resubmitInterval {
 				// do a resubmission when exceeded the resubmit interval
 				log.L(ctx).Debugf("Transaction with ID %s entering retrieve gas price as exceeded resubmit interval of %s.", it.stateManager.GetSignerNonce(), it.resubmitInterval.String())
+				it.thMetrics.RecordStageTimeoutMetrics(ctx, stageTimeoutReceiptWait)
 				it.TriggerNewStageRun(ctx, InFlightTxStageRetrieveGasPrice, BaseTxSubStatusStale)
 			} else {
 				// check and track the existing transaction hash
@@ -551,29 +601,47 @@ func (it *inFlightTransactionStageController) calculateNewGasPrice(ctx context.C
 	// The change is not made here to InMemoryTx, but rather pushed to TxUpdates for persisting.
 	// So we need to make sure we don't edit the in-memory existing object by passing it to calculateNewGasPrice
 
-	if newGpo.GasPrice != nil && existingGpo.GasPrice != nil && existingGpo.GasPrice.Int().Cmp(newGpo.GasPrice.Int()) == 1 {
+	// An active gas price schedule can override how aggressively we increase
+	gasPriceIncreasePercent, gasPriceIncreaseMax := it.getGasPriceIncrease()
+	overridePercent, overrideMax := it.gasPriceClient.GetGasPriceIncreaseOverrides(ctx)
+	if overridePercent != nil {
+		gasPriceIncreasePercent = *overridePercent
+	}
+	if overrideMax != nil {
+		gasPriceIncreaseMax = overrideMax
+	}
+
+	// A no-op replacement of an expired transaction is only accepted by the node if it is priced sufficiently
+	// above the transaction in the pool, so it needs an increase even if the market price has not moved
+	minCmp := 1
+	if it.stateManager.IsCancelling() {
+		minCmp = 0
+		gasPriceIncreasePercent = max(gasPriceIncreasePercent, cancelMinGasPriceIncreasePercent)
+	}
+
+	if newGpo.GasPrice != nil && existingGpo.GasPrice != nil && existingGpo.GasPrice.Int().Cmp(newGpo.GasPrice.Int()) >= minCmp {
 		// existing gas price already above the new gas price, increase using percentage
 		newPercentage := big.NewInt(100)
-		newPercentage = newPercentage.Add(newPercentage, big.NewInt(int64(it.gasPriceIncreasePercent)))
+		newPercentage = newPercentage.Add(newPercentage, big.NewInt(int64(gasPriceIncreasePercent)))
 		newGasPrice := new(big.Int).Mul(existingGpo.GasPrice.Int(), newPercentage)
 		newGasPrice = newGasPrice.Div(newGasPrice, big.NewInt(100))
-		if it.gasPriceIncreaseMax != nil && newGasPrice.Cmp(it.gasPriceIncreaseMax) == 1 {
-			newGasPrice.Set(it.gasPriceIncreaseMax)
+		if gasPriceIncreaseMax != nil && newGasPrice.Cmp(gasPriceIncreaseMax) == 1 {
+			newGasPrice.Set(gasPriceIncreaseMax)
 		}
 		newGpo = &pldapi.PublicTxGasPricing{
 			GasPrice:             (*pldtypes.HexUint256)(newGasPrice),
 			MaxFeePerGas:         existingGpo.MaxFeePerGas,         // copy over unchanged (although expected to be unset)
 			MaxPriorityFeePerGas: existingGpo.MaxPriorityFeePerGas, //   "
 		}
-	} else if newGpo.MaxFeePerGas != nil && existingGpo.MaxFeePerGas != nil && existingGpo.MaxFeePerGas.Int().Cmp(newGpo.MaxFeePerGas.Int()) == 1 {
+	} else if newGpo.MaxFeePerGas != nil && existingGpo.MaxFeePerGas != nil && existingGpo.MaxFeePerGas.Int().Cmp(newGpo.MaxFeePerGas.Int()) >= minCmp {
 		// existing MaxFeePerGas already above the new MaxFeePerGas, increase using percentage
 		newPercentage := big.NewInt(100)
 
-		newPercentage = newPercentage.Add(newPercentage, big.NewInt(int64(it.gasPriceIncreasePercent)))
+		newPercentage = newPercentage.Add(newPercentage, big.NewInt(int64(gasPriceIncreasePercent)))
 		newMaxFeePerGas := new(big.Int).Mul(existingGpo.MaxFeePerGas.Int(), newPercentage)
 		newMaxFeePerGas = newMaxFeePerGas.Div(newMaxFeePerGas, big.NewInt(100))
-		if it.gasPriceIncreaseMax != nil && newMaxFeePerGas.Cmp(it.gasPriceIncreaseMax) == 1 {
-			newMaxFeePerGas.Set(it.gasPriceIncreaseMax)
+		if gasPriceIncreaseMax != nil && newMaxFeePerGas.Cmp(gasPriceIncreaseMax) == 1 {
+			newMaxFeePerGas.Set(gasPriceIncreaseMax)
 		}
 		newGpo = &pldapi.PublicTxGasPricing{
 			GasPrice:             existingGpo.GasPrice, // copy over unchanged (although expected to be unset)
@@ -582,9 +650,32 @@ func (it *inFlightTransactionStageController) calculateNewGasPrice(ctx context.C
 		}
 	}
 
+	if existingGpo.MaxFeePerBlobGas != nil && newGpo.MaxFeePerBlobGas != nil {
+		newGpo = it.calculateNewBlobGasPrice(existingGpo, newGpo)
+	}
+
 	return newGpo
 }
 
+// calculateNewBlobGasPrice escalates the max fee per blob gas separately from the execution gas price, as it has its own
+// market, and nodes reject a replacement blob transaction unless every fee is increased (typically by 100%)
+func (it *inFlightTransactionStageController) calculateNewBlobGasPrice(existingGpo *pldapi.PublicTxGasPricing, newGpo *pldapi.PublicTxGasPricing) *pldapi.PublicTxGasPricing {
+	if existingGpo.MaxFeePerBlobGas.Int().Cmp(newGpo.MaxFeePerBlobGas.Int()) < 0 {
+		return newGpo
+	}
+	newPercentage := big.NewInt(100)
+	newPercentage = newPercentage.Add(newPercentage, big.NewInt(int64(it.blobFeeIncreasePercent)))
+	newMaxFeePerBlobGas := new(big.Int).Mul(existingGpo.MaxFeePerBlobGas.Int(), newPercentage)
+	newMaxFeePerBlobGas = newMaxFeePerBlobGas.Div(newMaxFeePerBlobGas, big.NewInt(100))
+	if it.blobFeeIncreaseMax != nil && newMaxFeePerBlobGas.Cmp(it.blobFeeIncreaseMax) == 1 {
+		newMaxFeePerBlobGas.Set(it.blobFeeIncreaseMax)
+	}
+	// the new gas price object might be the one that is in-memory, so we do not modify it
+	updated := *newGpo
+	updated.MaxFeePerBlobGas = (*pldtypes.HexUint256)(newMaxFeePerBlobGas)
+	return &updated
+}
+
 func calculateGasRequiredForTransaction(ctx context.Context, gpo *pldapi.PublicTxGasPricing, gasLimit uint64) (gasRequired *big.Int, err error) {
 	if gpo.GasPrice != nil {
 		log.L(ctx).Debugf("gas calculation using GasPrice (%+v)", gpo.GasPrice)
@@ -625,8 +716,17 @@ func (it *inFlightTransactionStageController) NotifyStatusUpdate(ctx context.Con
 
 func (it *inFlightTransactionStageController) TriggerRetrieveGasPrice(ctx context.Context) error {
 	generation := it.stateManager.GetCurrentGeneration(ctx)
+	hasBlobs := len(it.stateManager.GetBlobHashes()) > 0
 	it.executeAsync(func() {
 		gasPrice, err := it.gasPriceClient.GetGasPriceObject(ctx)
+		if err == nil && hasBlobs {
+			var maxFeePerBlobGas *pldtypes.HexUint256
+			if maxFeePerBlobGas, err = it.gasPriceClient.GetBlobGasPrice(ctx); err == nil {
+				withBlobFee := *gasPrice
+				withBlobFee.MaxFeePerBlobGas = maxFeePerBlobGas
+				gasPrice = &withBlobFee
+			}
+		}
 		generation.AddGasPriceOutput(ctx, gasPrice, err)
 	}, ctx, generation, false)
 	return nil
@@ -651,8 +751,27 @@ func (it *inFlightTransactionStageController) TriggerSignTx(ctx context.Context)
 	generation := it.stateManager.GetCurrentGeneration(ctx)
 	from := it.stateManager.GetFrom()
 	ethTX := it.stateManager.BuildEthTX()
+	pubTxnID := it.stateManager.GetPubTxnID()
+	blobHashes := it.stateManager.GetBlobHashes()
+	var maxFeePerBlobGas *big.Int
+	if gpo := it.stateManager.GetGasPriceObject(); gpo != nil && gpo.MaxFeePerBlobGas != nil {
+		maxFeePerBlobGas = gpo.MaxFeePerBlobGas.Int()
+	}
 	it.executeAsync(func() {
-		signedMessage, txHash, err := it.signTx(ctx, from, ethTX)
+		var blobTx *blobTransaction
+		var err error
+		if len(blobHashes) > 0 {
+			blobTx, err = it.loadBlobTransaction(ctx, pubTxnID, blobHashes, maxFeePerBlobGas)
+			if err != nil {
+				generation.AddSignOutput(ctx, nil, nil, err)
+				return
+			}
+		}
+		// Signing (which might be an asynchronous session with an MPC/TSS provider) is bounded by the sign stage
+		// timeout, so it does not carry on after the stage has been abandoned and run again
+		signCtx, cancelSign := context.WithTimeout(ctx, it.stageTimeouts[InFlightTxStageSigning])
+		signedMessage, txHash, err := it.signTx(signCtx, from, ethTX, blobTx)
+		cancelSign()
 		log.L(ctx).Debugf("Adding signed message to output, hash %s, signedMessage not nil %t, err %+v", txHash, signedMessage != nil, err)
 		generation.AddSignOutput(ctx, signedMessage, txHash, err)
 	}, ctx, generation, false)
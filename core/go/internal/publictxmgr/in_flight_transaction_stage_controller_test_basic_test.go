@@ -20,6 +20,10 @@ import (
 	"testing"
 	"time"
 
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -158,3 +162,70 @@ func TestProduceLatestInFlightStageContextStatusUpdatePanic(t *testing.T) {
 	assert.NotEqual(t, rsc, it.stateManager.GetCurrentGeneration(ctx).GetRunningStageContext(ctx))
 	currentGeneration.bufferedStageOutputs = make([]*StageOutput, 0)
 }
+
+func TestProduceLatestInFlightStageContextStageTimeout(t *testing.T) {
+	ctx, o, _, done := newTestOrchestrator(t)
+	defer done()
+	it, mTS := newInflightTransaction(o, 1)
+	it.testOnlyNoActionMode = true
+	mTS.ApplyInMemoryUpdates(ctx, &BaseTXUpdates{
+		GasPricing: &pldapi.PublicTxGasPricing{
+			GasPrice: pldtypes.Uint64ToUint256(10),
+		},
+	})
+
+	// trigger signing, which never produces a result
+	_ = it.ProduceLatestInFlightStageContext(ctx, &OrchestratorContext{})
+	rsc := it.stateManager.GetCurrentGeneration(ctx).GetRunningStageContext(ctx)
+	require.NotNil(t, rsc)
+	assert.Equal(t, InFlightTxStageSigning, rsc.Stage)
+
+	// not timed out yet
+	_ = it.ProduceLatestInFlightStageContext(ctx, &OrchestratorContext{})
+	assert.Same(t, rsc, it.stateManager.GetCurrentGeneration(ctx).GetRunningStageContext(ctx))
+	assert.Empty(t, o.thMetrics.StageTimeoutCounts())
+
+	// once timed out, the stage is run again
+	rsc.StageStartTime = time.Now().Add(-2 * o.stageTimeouts[InFlightTxStageSigning])
+	_ = it.ProduceLatestInFlightStageContext(ctx, &OrchestratorContext{})
+	newRSC := it.stateManager.GetCurrentGeneration(ctx).GetRunningStageContext(ctx)
+	require.NotNil(t, newRSC)
+	assert.NotSame(t, rsc, newRSC)
+	assert.Equal(t, InFlightTxStageSigning, newRSC.Stage)
+	assert.Equal(t, map[string]int64{"sign": 1}, o.thMetrics.StageTimeoutCounts())
+}
+
+func TestProduceLatestInFlightStageContextReceiptWaitTimeout(t *testing.T) {
+	ctx, o, _, done := newTestOrchestrator(t, func(mocks *mocksAndTestControl, conf *pldconf.PublicTxManagerConfig) {
+		conf.Orchestrator.StageTimeouts.ReceiptWait = confutil.P("1h")
+	})
+	defer done()
+	assert.Equal(t, 1*time.Hour, o.resubmitInterval)
+
+	it, _ := addSubmittedInFlightTx(ctx, o, 1)
+	it.testOnlyNoActionMode = true
+	it.stateManager.GetCurrentGeneration(ctx).SetValidatedTransactionHashMatchState(ctx, true)
+
+	// still waiting for the receipt
+	_ = it.ProduceLatestInFlightStageContext(ctx, &OrchestratorContext{})
+	assert.Nil(t, it.stateManager.GetCurrentGeneration(ctx).GetRunningStageContext(ctx))
+
+	// gave up waiting, so re-price and re-submit
+	it.resubmitInterval = 0
+	_ = it.ProduceLatestInFlightStageContext(ctx, &OrchestratorContext{})
+	rsc := it.stateManager.GetCurrentGeneration(ctx).GetRunningStageContext(ctx)
+	require.NotNil(t, rsc)
+	assert.Equal(t, InFlightTxStageRetrieveGasPrice, rsc.Stage)
+	assert.Equal(t, map[string]int64{"receiptWait": 1}, o.GetEngineMetrics(ctx).StageTimeouts)
+}
+
+func TestStageTimeoutsConfig(t *testing.T) {
+	timeouts := newStageTimeouts(&pldconf.StageTimeoutsConfig{
+		Submit: confutil.P("5s"),
+	})
+	assert.Equal(t, map[InFlightTxStage]time.Duration{
+		InFlightTxStageSigning:      1 * time.Minute,
+		InFlightTxStageSubmitting:   5 * time.Second,
+		InFlightTxStageStatusUpdate: 1 * time.Minute,
+	}, timeouts)
+}
@@ -23,9 +23,12 @@ import (
 	"time"
 
 	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 type mockStatusUpdater struct {
@@ -592,3 +595,27 @@ func TestProduceLatestInFlightStageContextRetrieveGasPanic(t *testing.T) {
 	assert.NotEqual(t, rsc, it.stateManager.GetCurrentGeneration(ctx).GetRunningStageContext(ctx))
 	currentGeneration.bufferedStageOutputs = make([]*StageOutput, 0)
 }
+
+func TestCalculateNewGasPriceScheduleOverrides(t *testing.T) {
+	ctx, o, _, done := newTestOrchestrator(t)
+	defer done()
+	it, _ := newInflightTransaction(o, 1)
+	it.gasPriceIncreasePercent = 10
+	it.gasPriceIncreaseMax = big.NewInt(1000)
+
+	hgc := NewTestNodeGasPriceClient(t, nil).(*HybridGasPriceClient)
+	it.gasPriceClient = hgc
+	existing := &pldapi.PublicTxGasPricing{GasPrice: pldtypes.Uint64ToUint256(200)}
+	retrieved := &pldapi.PublicTxGasPricing{GasPrice: pldtypes.Uint64ToUint256(100)}
+
+	gpo := it.calculateNewGasPrice(ctx, existing, retrieved)
+	assert.Equal(t, int64(220), gpo.GasPrice.Int().Int64())
+
+	err := hgc.SetSchedules(ctx, []*pldconf.GasPriceScheduleConfig{{
+		IncreasePercentage: confutil.P(100),
+		IncreaseMax:        confutil.P("300"),
+	}})
+	require.NoError(t, err)
+	gpo = it.calculateNewGasPrice(ctx, existing, retrieved)
+	assert.Equal(t, int64(300), gpo.GasPrice.Int().Int64())
+}
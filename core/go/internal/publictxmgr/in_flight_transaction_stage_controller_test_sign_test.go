@@ -232,3 +232,27 @@ func TestProduceLatestInFlightStageContextTriggerSign(t *testing.T) {
 	assert.Nil(t, currentGeneration.bufferedStageOutputs[0].SignOutput.SignedMessage)
 	assert.Empty(t, currentGeneration.bufferedStageOutputs[0].SignOutput.TxHash)
 }
+
+func TestTriggerSignTxBoundedBySignStageTimeout(t *testing.T) {
+	ctx, o, m, done := newTestOrchestrator(t)
+	defer done()
+	it, _ := newInflightTransaction(o, 1)
+	o.stageTimeouts[InFlightTxStageSigning] = 5 * time.Second
+
+	mockKeyManager := m.keyManager.(*componentmocks.KeyManager)
+	mockKeyManager.On("ReverseKeyLookup", mock.Anything, mock.Anything, algorithms.ECDSA_SECP256K1, verifiers.ETH_ADDRESS, o.signingAddress.String()).
+		Run(func(args mock.Arguments) {
+			deadline, ok := args[0].(context.Context).Deadline()
+			assert.True(t, ok)
+			assert.WithinDuration(t, time.Now().Add(5*time.Second), deadline, time.Second)
+		}).
+		Return(nil, fmt.Errorf("pop")).Once()
+	err := it.TriggerSignTx(ctx)
+	require.NoError(t, err)
+	ticker := time.NewTicker(10 * time.Millisecond)
+	currentGeneration := it.stateManager.GetCurrentGeneration(ctx).(*inFlightTransactionStateGeneration)
+	for !t.Failed() && len(currentGeneration.bufferedStageOutputs) == 0 {
+		<-ticker.C
+	}
+	assert.Regexp(t, "pop", currentGeneration.bufferedStageOutputs[0].SignOutput.Err)
+}
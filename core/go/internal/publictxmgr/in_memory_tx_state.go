@@ -40,8 +40,16 @@ type managedTx struct {
 	TransactionHash *pldtypes.Bytes32         // the most recently submitted transaction hash (not guaranteed to be the one mined)
 	FirstSubmit     *pldtypes.Timestamp       // the time this runtime instance first did a submit JSON/RPC call (for success or failure)
 	LastSubmit      *pldtypes.Timestamp       // the last time runtime instance first did a submit JSON/RPC call (for success or failure)
+	Cancelling      bool                      // the expiry passed, so the nonce is being replaced with a no-op transaction
 }
 
+const (
+	// a no-op replacement is a zero value transfer to ourselves, which only needs the intrinsic gas of a transaction
+	cancelGasLimit = 21000
+	// the minimum price bump that common nodes (such as Geth and Besu) require to replace a transaction in the pool
+	cancelMinGasPriceIncreasePercent = 10
+)
+
 type inMemoryTxState struct {
 	managedTxMux sync.Mutex
 	mtx          *managedTx
@@ -71,6 +79,10 @@ func NewInMemoryTxStateManager(ctx context.Context, ptx *DBPublicTxn) InMemoryTx
 			lastGasPricing := recoverGasPriceOptions(lastSub.GasPricing)
 			imtxs.mtx.GasPricing = lastGasPricing
 		}
+		if lastSub.Cancel {
+			// we were already part way through cancelling before a restart
+			imtxs.StartCancellation()
+		}
 	}
 	return imtxs
 }
@@ -78,6 +90,10 @@ func NewInMemoryTxStateManager(ctx context.Context, ptx *DBPublicTxn) InMemoryTx
 func (imtxs *inMemoryTxState) UpdateTransaction(newPtx *DBPublicTxn) {
 	imtxs.managedTxMux.Lock()
 	defer imtxs.managedTxMux.Unlock()
+	if imtxs.mtx.Cancelling {
+		// an update cannot bring back a transaction that has expired
+		return
+	}
 	// If this update didn't involve a change to how fixed gas pricing is set (i.e. it wasn't set before
 	// and it isn't set now) then we don't want to change the gas pricing back to empty because
 	// it could result in us retrieving a gas price that is lower than one we've already submitted
@@ -95,6 +111,27 @@ func (imtxs *inMemoryTxState) UpdateTransaction(newPtx *DBPublicTxn) {
 	ptx.Value = newPtx.Value
 }
 
+// StartCancellation replaces the transaction with a no-op that consumes the same nonce.
+// Only the in-memory copy is changed - the original transaction data remains in the DB, as it might still be mined first.
+func (imtxs *inMemoryTxState) StartCancellation() {
+	imtxs.managedTxMux.Lock()
+	defer imtxs.managedTxMux.Unlock()
+
+	ptx := imtxs.mtx.ptx
+	ptx.To = &ptx.From
+	ptx.Data = nil
+	ptx.Value = nil
+	ptx.Gas = cancelGasLimit
+	imtxs.mtx.Cancelling = true
+}
+
+// SetExpiry brings forward the time the transaction is cancelled, if it has not been mined by then
+func (imtxs *inMemoryTxState) SetExpiry(expiry *pldtypes.Timestamp) {
+	imtxs.managedTxMux.Lock()
+	defer imtxs.managedTxMux.Unlock()
+	imtxs.mtx.ptx.Expiry = expiry
+}
+
 func (imtxs *inMemoryTxState) ApplyInMemoryUpdates(ctx context.Context, txUpdates *BaseTXUpdates) {
 	imtxs.managedTxMux.Lock()
 	defer imtxs.managedTxMux.Unlock()
@@ -199,6 +236,10 @@ func (imtxs *inMemoryTxState) BuildEthTX() *ethsigner.Transaction {
 	)
 }
 
+func (imtxs *inMemoryTxState) GetBlobHashes() []pldtypes.Bytes32 {
+	return blobHashes(imtxs.mtx.ptx.Blobs)
+}
+
 func (imtxs *inMemoryTxState) GetFirstSubmit() *pldtypes.Timestamp {
 	return imtxs.mtx.FirstSubmit
 }
@@ -211,6 +252,14 @@ func (imtxs *inMemoryTxState) GetGasPriceObject() *pldapi.PublicTxGasPricing {
 	return nil
 }
 
+func (imtxs *inMemoryTxState) GetExpiry() *pldtypes.Timestamp {
+	return imtxs.mtx.ptx.Expiry
+}
+
+func (imtxs *inMemoryTxState) IsCancelling() bool {
+	return imtxs.mtx.Cancelling
+}
+
 func (imtxs *inMemoryTxState) GetLastSubmitTime() *pldtypes.Timestamp {
 	return imtxs.mtx.LastSubmit
 }
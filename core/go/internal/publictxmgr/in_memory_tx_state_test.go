@@ -175,3 +175,46 @@ func TestSettersAndGetters(t *testing.T) {
 	assert.Equal(t, maxPriorityFeePerGas.Int(), imts.GetGasPriceObject().MaxPriorityFeePerGas.Int())
 
 }
+
+func TestStartCancellation(t *testing.T) {
+	from := pldtypes.RandAddress()
+	expiry := pldtypes.TimestampNow()
+	imtxs := NewInMemoryTxStateManager(context.Background(), &DBPublicTxn{
+		From:   *from,
+		To:     pldtypes.RandAddress(),
+		Nonce:  confutil.P(uint64(1)),
+		Gas:    2000000,
+		Value:  pldtypes.Uint64ToUint256(200),
+		Data:   pldtypes.RandBytes(32),
+		Expiry: &expiry,
+	})
+	assert.Equal(t, &expiry, imtxs.GetExpiry())
+	assert.False(t, imtxs.IsCancelling())
+
+	imtxs.StartCancellation()
+	assert.True(t, imtxs.IsCancelling())
+	ethTx := imtxs.BuildEthTX()
+	assert.Equal(t, from.String(), ethTx.To.String())
+	assert.Empty(t, ethTx.Data)
+	assert.Nil(t, ethTx.Value)
+	assert.Equal(t, uint64(cancelGasLimit), imtxs.GetGasLimit())
+
+	// updates cannot bring back the original
+	imtxs.UpdateTransaction(&DBPublicTxn{To: pldtypes.RandAddress(), Gas: 12345})
+	assert.Equal(t, from, imtxs.GetTo())
+	assert.Equal(t, uint64(cancelGasLimit), imtxs.GetGasLimit())
+
+	// after a restart, we pick up where we left off if the last submission was a cancellation
+	imtxs = NewInMemoryTxStateManager(context.Background(), &DBPublicTxn{
+		From:   *from,
+		To:     pldtypes.RandAddress(),
+		Nonce:  confutil.P(uint64(1)),
+		Expiry: &expiry,
+		Submissions: []*DBPubTxnSubmission{
+			{TransactionHash: pldtypes.RandBytes32(), Cancel: true},
+			{TransactionHash: pldtypes.RandBytes32()},
+		},
+	})
+	assert.True(t, imtxs.IsCancelling())
+	assert.Equal(t, from, imtxs.GetTo())
+}
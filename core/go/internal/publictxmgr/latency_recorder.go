@@ -0,0 +1,201 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package publictxmgr
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/toolkit/pkg/cache"
+)
+
+// The latency recorder timestamps the first time each public transaction reaches each stage,
+// keeping the timelines of recent transactions in an in-memory cache, and a sliding window
+// of samples per stage from which percentiles are calculated.
+//
+// All functions are safe to call on a nil recorder (when tracing is disabled).
+type latencyRecorder struct {
+	metrics   PublicTxManagerMetricsManager
+	timelines cache.Cache[uint64, *txTimeline]
+	window    int
+
+	statsLock sync.Mutex
+	stats     map[pldapi.PublicTxStage]*stageSamples
+}
+
+type txTimeline struct {
+	lock   sync.Mutex
+	stages []*pldapi.PublicTxStageTime
+}
+
+type stageSamples struct {
+	sincePrev  *sampleWindow
+	sinceStart *sampleWindow
+}
+
+// fixed size ring buffer of the most recent samples
+type sampleWindow struct {
+	samples []int64
+	next    int
+	full    bool
+}
+
+var allPublicTxStages = func() []pldapi.PublicTxStage {
+	options := pldapi.PublicTxStage("").Options()
+	stages := make([]pldapi.PublicTxStage, len(options))
+	for i, o := range options {
+		stages[i] = pldapi.PublicTxStage(o)
+	}
+	return stages
+}()
+
+func newLatencyRecorder(conf *pldconf.PublicTxManagerLatencyTracingConfig, metrics PublicTxManagerMetricsManager) *latencyRecorder {
+	defs := &pldconf.PublicTxManagerDefaults.Manager.LatencyTracing
+	if !confutil.Bool(conf.Enabled, *defs.Enabled) {
+		return nil
+	}
+	lr := &latencyRecorder{
+		metrics:   metrics,
+		timelines: cache.NewNamedCache[uint64, *txTimeline]("publictxmgr.timelines", &conf.CacheConfig, &defs.CacheConfig),
+		window:    confutil.IntMin(conf.SampleWindow, 1, *defs.SampleWindow),
+		stats:     make(map[pldapi.PublicTxStage]*stageSamples),
+	}
+	for _, stage := range allPublicTxStages {
+		lr.stats[stage] = &stageSamples{
+			sincePrev:  &sampleWindow{samples: make([]int64, lr.window)},
+			sinceStart: &sampleWindow{samples: make([]int64, lr.window)},
+		}
+	}
+	return lr
+}
+
+func (lr *latencyRecorder) record(ctx context.Context, pubTxnID uint64, stage pldapi.PublicTxStage) {
+	lr.recordAt(ctx, pubTxnID, stage, time.Now())
+}
+
+// Only the first time a transaction reaches a given stage is recorded (so resubmissions do not
+// move the signed/submitted times). Stages can be recorded out of order, as some stages are
+// reached on different threads - but the time since the previous stage is always calculated
+// against the latest stage recorded before this one.
+func (lr *latencyRecorder) recordAt(ctx context.Context, pubTxnID uint64, stage pldapi.PublicTxStage, t time.Time) {
+	if lr == nil {
+		return
+	}
+	tl, _ := lr.timelines.Get(pubTxnID)
+	if tl == nil {
+		tl = &txTimeline{}
+		lr.timelines.Set(pubTxnID, tl)
+	}
+
+	tl.lock.Lock()
+	for _, existing := range tl.stages {
+		if existing.Stage.V() == stage {
+			tl.lock.Unlock()
+			return
+		}
+	}
+	st := &pldapi.PublicTxStageTime{
+		Stage: stage.Enum(),
+		Time:  pldtypes.Timestamp(t.UnixNano()),
+	}
+	hasPrev := len(tl.stages) > 0
+	if hasPrev {
+		st.SincePrevMS = t.Sub(tl.stages[len(tl.stages)-1].Time.Time()).Milliseconds()
+		st.SinceStartMS = t.Sub(tl.stages[0].Time.Time()).Milliseconds()
+	}
+	tl.stages = append(tl.stages, st)
+	tl.lock.Unlock()
+
+	if hasPrev {
+		lr.statsLock.Lock()
+		samples := lr.stats[stage]
+		samples.sincePrev.add(st.SincePrevMS)
+		samples.sinceStart.add(st.SinceStartMS)
+		lr.statsLock.Unlock()
+		lr.metrics.RecordStageLatencyMetrics(ctx, string(stage), float64(st.SincePrevMS)/1000, float64(st.SinceStartMS)/1000)
+	}
+}
+
+func (lr *latencyRecorder) getTimeline(pubTxnID uint64) []*pldapi.PublicTxStageTime {
+	if lr == nil {
+		return []*pldapi.PublicTxStageTime{}
+	}
+	tl, _ := lr.timelines.Get(pubTxnID)
+	if tl == nil {
+		return []*pldapi.PublicTxStageTime{}
+	}
+	tl.lock.Lock()
+	defer tl.lock.Unlock()
+	return append([]*pldapi.PublicTxStageTime{}, tl.stages...)
+}
+
+func (lr *latencyRecorder) getStats() []*pldapi.PublicTxLatencyStats {
+	if lr == nil {
+		return []*pldapi.PublicTxLatencyStats{}
+	}
+	lr.statsLock.Lock()
+	defer lr.statsLock.Unlock()
+	results := make([]*pldapi.PublicTxLatencyStats, 0, len(allPublicTxStages)-1)
+	for _, stage := range allPublicTxStages[1:] /* nothing to measure for the first stage */ {
+		samples := lr.stats[stage]
+		results = append(results, &pldapi.PublicTxLatencyStats{
+			Stage:      stage.Enum(),
+			SincePrev:  samples.sincePrev.percentiles(),
+			SinceStart: samples.sinceStart.percentiles(),
+		})
+	}
+	return results
+}
+
+func (sw *sampleWindow) add(v int64) {
+	sw.samples[sw.next] = v
+	sw.next++
+	if sw.next == len(sw.samples) {
+		sw.next = 0
+		sw.full = true
+	}
+}
+
+func (sw *sampleWindow) percentiles() (p pldapi.PublicTxLatencyPercentiles) {
+	count := sw.next
+	if sw.full {
+		count = len(sw.samples)
+	}
+	if count == 0 {
+		return p
+	}
+	sorted := make([]int64, count)
+	copy(sorted, sw.samples[0:count])
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	// nearest-rank method
+	rank := func(pct int) int64 {
+		idx := (pct*count + 99) / 100
+		return sorted[idx-1]
+	}
+	return pldapi.PublicTxLatencyPercentiles{
+		Count: count,
+		P50MS: rank(50),
+		P90MS: rank(90),
+		P99MS: rank(99),
+		MaxMS: sorted[count-1],
+	}
+}
@@ -0,0 +1,100 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package publictxmgr
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLatencyRecorderTimeline(t *testing.T) {
+	ctx := context.Background()
+	lr := newLatencyRecorder(&pldconf.PublicTxManagerLatencyTracingConfig{}, &publicTxEngineMetrics{})
+
+	start := time.Now()
+	lr.recordAt(ctx, 1, pldapi.PublicTxStageReceived, start)
+	lr.recordAt(ctx, 1, pldapi.PublicTxStageNonceAssigned, start.Add(10*time.Millisecond))
+	lr.recordAt(ctx, 1, pldapi.PublicTxStageSigned, start.Add(30*time.Millisecond))
+	// second signature (resubmission) is ignored
+	lr.recordAt(ctx, 1, pldapi.PublicTxStageSigned, start.Add(100*time.Millisecond))
+
+	tl := lr.getTimeline(1)
+	require.Len(t, tl, 3)
+	assert.Equal(t, pldapi.PublicTxStageReceived, tl[0].Stage.V())
+	assert.Equal(t, int64(0), tl[0].SinceStartMS)
+	assert.Equal(t, int64(20), tl[2].SincePrevMS)
+	assert.Equal(t, int64(30), tl[2].SinceStartMS)
+
+	assert.Empty(t, lr.getTimeline(2))
+
+	stats := lr.getStats()
+	require.Len(t, stats, 5)
+	assert.Equal(t, pldapi.PublicTxStageNonceAssigned, stats[0].Stage.V())
+	assert.Equal(t, pldapi.PublicTxLatencyPercentiles{Count: 1, P50MS: 10, P90MS: 10, P99MS: 10, MaxMS: 10}, stats[0].SincePrev)
+	assert.Equal(t, 30, int(stats[1].SinceStart.P99MS))
+	assert.Equal(t, 0, stats[2].SincePrev.Count)
+}
+
+func TestLatencyRecorderPercentilesWindow(t *testing.T) {
+	ctx := context.Background()
+	lr := newLatencyRecorder(&pldconf.PublicTxManagerLatencyTracingConfig{
+		SampleWindow: confutil.P(100),
+	}, &publicTxEngineMetrics{})
+
+	start := time.Now()
+	// 150 transactions with 1..150ms latency, only the last 100 will be in the window
+	for i := 1; i <= 150; i++ {
+		lr.recordAt(ctx, uint64(i), pldapi.PublicTxStageReceived, start)
+		lr.recordAt(ctx, uint64(i), pldapi.PublicTxStageNonceAssigned, start.Add(time.Duration(i)*time.Millisecond))
+	}
+
+	p := lr.getStats()[0].SincePrev
+	assert.Equal(t, 100, p.Count)
+	assert.Equal(t, int64(100), p.P50MS)
+	assert.Equal(t, int64(140), p.P90MS)
+	assert.Equal(t, int64(149), p.P99MS)
+	assert.Equal(t, int64(150), p.MaxMS)
+}
+
+func TestLatencyRecorderDisabled(t *testing.T) {
+	ctx := context.Background()
+	lr := newLatencyRecorder(&pldconf.PublicTxManagerLatencyTracingConfig{
+		Enabled: confutil.P(false),
+	}, &publicTxEngineMetrics{})
+	assert.Nil(t, lr)
+
+	lr.record(ctx, 1, pldapi.PublicTxStageReceived)
+	assert.Empty(t, lr.getTimeline(1))
+	assert.Empty(t, lr.getStats())
+}
+
+func TestGetTransactionTimelinesQueryFail(t *testing.T) {
+	ctx, ptm, m, done := newTestPublicTxManager(t, false)
+	defer done()
+
+	m.db.ExpectQuery("SELECT.*public_txn").WillReturnError(assert.AnError)
+
+	_, err := ptm.GetTransactionTimelines(ctx, ptm.p.NOTX(), uuid.New())
+	assert.Regexp(t, assert.AnError.Error(), err)
+}
@@ -17,8 +17,12 @@ package publictxmgr
 
 import (
 	"context"
+	"math/big"
+	"sync"
 
 	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/core/internal/components"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
 )
 
 type PublicTxManagerMetricsManager interface {
@@ -26,10 +30,47 @@ type PublicTxManagerMetricsManager interface {
 	RecordOperationMetrics(ctx context.Context, operationName string, operationResult string, durationInSeconds float64)
 	RecordStageChangeMetrics(ctx context.Context, stage string, durationInSeconds float64)
 	RecordInFlightTxQueueMetrics(ctx context.Context, usedCountPerStage map[string]int, freeCount int)
+	RecordOverflowQueueMetrics(ctx context.Context, signer string, usedCount int, freeCount int)
 	RecordCompletedTransactionCountMetrics(ctx context.Context, processStatus string)
+	RecordStageLatencyMetrics(ctx context.Context, stage string, sincePrevInSeconds float64, sinceStartInSeconds float64)
+	RecordFuelingSpendMetrics(ctx context.Context, sourceAddress string, value *big.Int)
+	RecordFuelingFailoverMetrics(ctx context.Context, sourceAddress string, reason string)
+	RecordStageTimeoutMetrics(ctx context.Context, stage string)
 }
 
 type publicTxEngineMetrics struct {
+	stageTimeoutsMux sync.Mutex
+	stageTimeouts    map[string]int64
+
+	stageLatencyMux sync.Mutex
+	stageLatency    map[string]*stageHistograms
+
+	fuelingMux sync.Mutex
+	fueling    map[string]*fuelingCounters
+
+	overflowMux sync.Mutex
+	overflow    map[string]*components.PublicTxOverflowQueueMetrics
+}
+
+type fuelingCounters struct {
+	transactions int64
+	spent        *big.Int
+	failovers    map[string]int64
+}
+
+// Upper bounds of the latency histogram buckets, which span from a transaction being signed
+// immediately through to waiting several blocks (on a slow chain) for a receipt
+var latencyBucketsSeconds = []float64{0.01, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60, 300}
+
+type stageHistograms struct {
+	sincePrev  latencyHistogram
+	sinceStart latencyHistogram
+}
+
+type latencyHistogram struct {
+	count  int64
+	sum    float64
+	counts []int64 // per bucket, not cumulative
 }
 
 func (thm *publicTxEngineMetrics) InitMetrics(ctx context.Context) {
@@ -57,7 +98,164 @@ func (thm *publicTxEngineMetrics) RecordInFlightTxQueueMetrics(ctx context.Conte
 	// TODO
 }
 
+func (thm *publicTxEngineMetrics) RecordOverflowQueueMetrics(ctx context.Context, signer string, usedCount int, freeCount int) {
+	log.L(ctx).Tracef("RecordOverflowQueueMetrics")
+	thm.overflowMux.Lock()
+	defer thm.overflowMux.Unlock()
+	if usedCount == 0 {
+		// only signers with a queue are reported, so we do not accumulate an entry for every signer ever used
+		delete(thm.overflow, signer)
+		return
+	}
+	if thm.overflow == nil {
+		thm.overflow = make(map[string]*components.PublicTxOverflowQueueMetrics)
+	}
+	thm.overflow[signer] = &components.PublicTxOverflowQueueMetrics{Queued: usedCount, Free: freeCount}
+}
+
+// OverflowQueueDepths returns the depth of the overflow queue of each signer that has one
+func (thm *publicTxEngineMetrics) OverflowQueueDepths() map[string]*components.PublicTxOverflowQueueMetrics {
+	thm.overflowMux.Lock()
+	defer thm.overflowMux.Unlock()
+	depths := make(map[string]*components.PublicTxOverflowQueueMetrics, len(thm.overflow))
+	for signer, q := range thm.overflow {
+		depths[signer] = &components.PublicTxOverflowQueueMetrics{Queued: q.Queued, Free: q.Free}
+	}
+	return depths
+}
+
 func (thm *publicTxEngineMetrics) RecordCompletedTransactionCountMetrics(ctx context.Context, processStatus string) {
 	log.L(ctx).Tracef("RecordCompletedTransactionCountMetrics")
 	// TODO
 }
+
+func (thm *publicTxEngineMetrics) RecordStageLatencyMetrics(ctx context.Context, stage string, sincePrevInSeconds float64, sinceStartInSeconds float64) {
+	log.L(ctx).Tracef("RecordStageLatencyMetrics")
+	thm.stageLatencyMux.Lock()
+	defer thm.stageLatencyMux.Unlock()
+	if thm.stageLatency == nil {
+		thm.stageLatency = make(map[string]*stageHistograms)
+	}
+	sh := thm.stageLatency[stage]
+	if sh == nil {
+		sh = &stageHistograms{}
+		thm.stageLatency[stage] = sh
+	}
+	sh.sincePrev.observe(sincePrevInSeconds)
+	sh.sinceStart.observe(sinceStartInSeconds)
+}
+
+// StageLatencyHistograms returns the latency histograms for each stage that has been reached
+func (thm *publicTxEngineMetrics) StageLatencyHistograms() map[string]*components.PublicTxStageHistogram {
+	thm.stageLatencyMux.Lock()
+	defer thm.stageLatencyMux.Unlock()
+	histograms := make(map[string]*components.PublicTxStageHistogram, len(thm.stageLatency))
+	for stage, sh := range thm.stageLatency {
+		histograms[stage] = &components.PublicTxStageHistogram{
+			SincePrev:  sh.sincePrev.snapshot(),
+			SinceStart: sh.sinceStart.snapshot(),
+		}
+	}
+	return histograms
+}
+
+func (h *latencyHistogram) observe(seconds float64) {
+	if h.counts == nil {
+		h.counts = make([]int64, len(latencyBucketsSeconds))
+	}
+	h.count++
+	h.sum += seconds
+	for i, le := range latencyBucketsSeconds {
+		if seconds <= le {
+			h.counts[i]++
+			break
+		}
+	}
+}
+
+func (h *latencyHistogram) snapshot() *components.PublicTxLatencyHistogram {
+	snap := &components.PublicTxLatencyHistogram{
+		Count:      h.count,
+		SumSeconds: h.sum,
+		Buckets:    make([]*components.PublicTxLatencyBucket, len(latencyBucketsSeconds)),
+	}
+	var cumulative int64
+	for i, le := range latencyBucketsSeconds {
+		if h.counts != nil {
+			cumulative += h.counts[i]
+		}
+		snap.Buckets[i] = &components.PublicTxLatencyBucket{LESeconds: le, Count: cumulative}
+	}
+	return snap
+}
+
+func (thm *publicTxEngineMetrics) RecordFuelingSpendMetrics(ctx context.Context, sourceAddress string, value *big.Int) {
+	log.L(ctx).Tracef("RecordFuelingSpendMetrics")
+	thm.fuelingMux.Lock()
+	defer thm.fuelingMux.Unlock()
+	fc := thm.fuelingSource(sourceAddress)
+	fc.transactions++
+	if value != nil {
+		fc.spent.Add(fc.spent, value)
+	}
+}
+
+func (thm *publicTxEngineMetrics) RecordFuelingFailoverMetrics(ctx context.Context, sourceAddress string, reason string) {
+	log.L(ctx).Tracef("RecordFuelingFailoverMetrics")
+	thm.fuelingMux.Lock()
+	defer thm.fuelingMux.Unlock()
+	thm.fuelingSource(sourceAddress).failovers[reason]++
+}
+
+// must be called holding fuelingMux
+func (thm *publicTxEngineMetrics) fuelingSource(sourceAddress string) *fuelingCounters {
+	if thm.fueling == nil {
+		thm.fueling = make(map[string]*fuelingCounters)
+	}
+	fc := thm.fueling[sourceAddress]
+	if fc == nil {
+		fc = &fuelingCounters{spent: new(big.Int), failovers: make(map[string]int64)}
+		thm.fueling[sourceAddress] = fc
+	}
+	return fc
+}
+
+// FuelingCounts returns the spend and failover counts of each auto-fueling source that has been used
+func (thm *publicTxEngineMetrics) FuelingCounts() map[string]*components.PublicTxFuelingMetrics {
+	thm.fuelingMux.Lock()
+	defer thm.fuelingMux.Unlock()
+	counts := make(map[string]*components.PublicTxFuelingMetrics, len(thm.fueling))
+	for source, fc := range thm.fueling {
+		failovers := make(map[string]int64, len(fc.failovers))
+		for reason, count := range fc.failovers {
+			failovers[reason] = count
+		}
+		counts[source] = &components.PublicTxFuelingMetrics{
+			Transactions: fc.transactions,
+			Spent:        (*pldtypes.HexUint256)(new(big.Int).Set(fc.spent)),
+			Failovers:    failovers,
+		}
+	}
+	return counts
+}
+
+func (thm *publicTxEngineMetrics) RecordStageTimeoutMetrics(ctx context.Context, stage string) {
+	log.L(ctx).Tracef("RecordStageTimeoutMetrics")
+	thm.stageTimeoutsMux.Lock()
+	defer thm.stageTimeoutsMux.Unlock()
+	if thm.stageTimeouts == nil {
+		thm.stageTimeouts = make(map[string]int64)
+	}
+	thm.stageTimeouts[stage]++
+}
+
+// StageTimeoutCounts returns the number of times each stage has timed out
+func (thm *publicTxEngineMetrics) StageTimeoutCounts() map[string]int64 {
+	thm.stageTimeoutsMux.Lock()
+	defer thm.stageTimeoutsMux.Unlock()
+	counts := make(map[string]int64, len(thm.stageTimeouts))
+	for stage, count := range thm.stageTimeouts {
+		counts[stage] = count
+	}
+	return counts
+}
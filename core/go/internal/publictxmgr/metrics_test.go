@@ -17,7 +17,12 @@ package publictxmgr
 
 import (
 	"context"
+	"math/big"
 	"testing"
+
+	"github.com/kaleido-io/paladin/core/internal/components"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestMetrics(t *testing.T) {
@@ -32,3 +37,77 @@ func TestMetrics(t *testing.T) {
 	btem.RecordInFlightTxQueueMetrics(ctx, nil, 1)
 	btem.RecordCompletedTransactionCountMetrics(ctx, "test")
 }
+
+func TestStageTimeoutMetrics(t *testing.T) {
+	btem := &publicTxEngineMetrics{}
+	ctx := context.Background()
+	assert.Empty(t, btem.StageTimeoutCounts())
+	btem.RecordStageTimeoutMetrics(ctx, "sign")
+	btem.RecordStageTimeoutMetrics(ctx, "sign")
+	btem.RecordStageTimeoutMetrics(ctx, "submit")
+	assert.Equal(t, map[string]int64{"sign": 2, "submit": 1}, btem.StageTimeoutCounts())
+}
+
+func TestStageLatencyMetrics(t *testing.T) {
+	btem := &publicTxEngineMetrics{}
+	ctx := context.Background()
+	assert.Empty(t, btem.StageLatencyHistograms())
+	btem.RecordStageLatencyMetrics(ctx, "signed", 0.2, 0.3)
+	btem.RecordStageLatencyMetrics(ctx, "signed", 0.02, 4)
+	btem.RecordStageLatencyMetrics(ctx, "signed", 0.2, 1000)
+
+	histograms := btem.StageLatencyHistograms()
+	require.Len(t, histograms, 1)
+	sincePrev := histograms["signed"].SincePrev
+	assert.Equal(t, int64(3), sincePrev.Count)
+	assert.InDelta(t, 0.42, sincePrev.SumSeconds, 0.0001)
+	require.Len(t, sincePrev.Buckets, len(latencyBucketsSeconds))
+	assert.Equal(t, &components.PublicTxLatencyBucket{LESeconds: 0.01, Count: 0}, sincePrev.Buckets[0])
+	assert.Equal(t, &components.PublicTxLatencyBucket{LESeconds: 0.05, Count: 1}, sincePrev.Buckets[1])
+	assert.Equal(t, &components.PublicTxLatencyBucket{LESeconds: 0.25, Count: 3}, sincePrev.Buckets[3])
+	assert.Equal(t, int64(3), sincePrev.Buckets[len(sincePrev.Buckets)-1].Count)
+
+	// samples beyond the largest bucket are only in the total count
+	sinceStart := histograms["signed"].SinceStart
+	assert.Equal(t, int64(3), sinceStart.Count)
+	assert.Equal(t, &components.PublicTxLatencyBucket{LESeconds: 5, Count: 2}, sinceStart.Buckets[7])
+	assert.Equal(t, int64(2), sinceStart.Buckets[len(sinceStart.Buckets)-1].Count)
+}
+
+func TestFuelingMetrics(t *testing.T) {
+	btem := &publicTxEngineMetrics{}
+	ctx := context.Background()
+	assert.Empty(t, btem.FuelingCounts())
+	btem.RecordFuelingSpendMetrics(ctx, "0xaaaa", big.NewInt(100))
+	btem.RecordFuelingSpendMetrics(ctx, "0xaaaa", big.NewInt(50))
+	btem.RecordFuelingFailoverMetrics(ctx, "0xbbbb", "stale")
+	btem.RecordFuelingFailoverMetrics(ctx, "0xbbbb", "stale")
+	btem.RecordFuelingFailoverMetrics(ctx, "0xbbbb", "balance")
+
+	counts := btem.FuelingCounts()
+	require.Len(t, counts, 2)
+	assert.Equal(t, int64(2), counts["0xaaaa"].Transactions)
+	assert.Equal(t, int64(150), counts["0xaaaa"].Spent.Int().Int64())
+	assert.Empty(t, counts["0xaaaa"].Failovers)
+	assert.Equal(t, int64(0), counts["0xbbbb"].Transactions)
+	assert.Equal(t, map[string]int64{"stale": 2, "balance": 1}, counts["0xbbbb"].Failovers)
+
+	// the snapshot is a copy
+	btem.RecordFuelingSpendMetrics(ctx, "0xaaaa", big.NewInt(1))
+	assert.Equal(t, int64(150), counts["0xaaaa"].Spent.Int().Int64())
+}
+
+func TestOverflowQueueMetrics(t *testing.T) {
+	btem := &publicTxEngineMetrics{}
+	ctx := context.Background()
+	assert.Empty(t, btem.OverflowQueueDepths())
+	btem.RecordOverflowQueueMetrics(ctx, "0xaaaa", 10, 990)
+	btem.RecordOverflowQueueMetrics(ctx, "0xbbbb", 0, 1000)
+	assert.Equal(t, map[string]*components.PublicTxOverflowQueueMetrics{
+		"0xaaaa": {Queued: 10, Free: 990},
+	}, btem.OverflowQueueDepths())
+
+	// drained queues are removed
+	btem.RecordOverflowQueueMetrics(ctx, "0xaaaa", 0, 1000)
+	assert.Empty(t, btem.OverflowQueueDepths())
+}
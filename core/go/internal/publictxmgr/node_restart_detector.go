@@ -0,0 +1,149 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package publictxmgr
+
+import (
+	"context"
+	"time"
+
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+)
+
+// The node restart detector runs on the engine loop, and probes the blockchain node to spot
+// that it has restarted or flushed its transaction pool. Without this, the transactions we
+// submitted are silently lost, and we only find out when the resubmit interval expires.
+//
+// A restart is detected when:
+// - the node becomes reachable again, after failing a previous probe
+// - the node has lost all its peers, having had some on the previous probe
+// - a transaction we submitted (and have not seen confirmed) is unknown to the node
+type nodeRestartDetector struct {
+	interval        time.Duration
+	probeSize       int
+	lastProbe       time.Time
+	nodeUnreachable bool
+	lastPeerCount   uint64
+}
+
+func newNodeRestartDetector(conf *pldconf.PublicTxManagerNodeRestartDetectionConfig) *nodeRestartDetector {
+	if !confutil.Bool(conf.Enabled, *pldconf.PublicTxManagerDefaults.Manager.NodeRestartDetection.Enabled) {
+		return nil
+	}
+	return &nodeRestartDetector{
+		interval:  confutil.DurationMin(conf.Interval, 100*time.Millisecond, *pldconf.PublicTxManagerDefaults.Manager.NodeRestartDetection.Interval),
+		probeSize: confutil.IntMin(conf.ProbeSize, 1, *pldconf.PublicTxManagerDefaults.Manager.NodeRestartDetection.ProbeSize),
+		lastProbe: time.Now(),
+	}
+}
+
+// checkNodeRestart is called on each engine loop, and performs a probe if the interval has passed
+func (ptm *pubTxManager) checkNodeRestart(ctx context.Context) {
+	nd := ptm.restartDetector
+	if nd == nil || time.Since(nd.lastProbe) < nd.interval {
+		return
+	}
+	nd.lastProbe = time.Now()
+	if ptm.detectNodeRestart(ctx) {
+		ptm.requestRebroadcast(ctx)
+	}
+}
+
+func (ptm *pubTxManager) detectNodeRestart(ctx context.Context) bool {
+	nd := ptm.restartDetector
+
+	peerCount, err := ptm.ethClient.PeerCount(ctx)
+	if err != nil {
+		log.L(ctx).Warnf("Blockchain node unavailable during restart detection probe: %s", err)
+		nd.nodeUnreachable = true
+		return false
+	}
+	wasUnreachable, lastPeerCount := nd.nodeUnreachable, nd.lastPeerCount
+	nd.nodeUnreachable = false
+	nd.lastPeerCount = peerCount
+	if wasUnreachable {
+		log.L(ctx).Infof("Blockchain node available again after a failed probe (peers=%d)", peerCount)
+		return true
+	}
+	if lastPeerCount > 0 && peerCount == 0 {
+		log.L(ctx).Infof("Blockchain node lost all %d peers", lastPeerCount)
+		return true
+	}
+
+	for _, txHash := range ptm.getSubmittedTxHashes(nd.probeSize) {
+		tx, err := ptm.ethClient.GetTransactionByHash(ctx, txHash)
+		if err != nil {
+			// we'll try again on the next probe
+			return false
+		}
+		if tx == nil {
+			log.L(ctx).Infof("Submitted transaction %s is not known to the blockchain node - pending transactions have been lost", txHash)
+			return true
+		}
+	}
+	return false
+}
+
+// getSubmittedTxHashes returns up to max transaction hashes of in-flight transactions that have been
+// submitted to our blockchain node, taking the oldest (lowest nonce) of each signing address first
+func (ptm *pubTxManager) getSubmittedTxHashes(max int) []pldtypes.Bytes32 {
+	txHashes := make([]pldtypes.Bytes32, 0, max)
+	for _, oc := range ptm.orchestrators.Workers() {
+		if !oc.submitsToNode() {
+			continue
+		}
+		oc.inFlightTxsMux.Lock()
+		for _, it := range oc.inFlightTxs {
+			if txHash := it.stateManager.GetTransactionHash(); txHash != nil && !it.stateManager.IsReadyToExit() {
+				txHashes = append(txHashes, *txHash)
+				break
+			}
+		}
+		oc.inFlightTxsMux.Unlock()
+		if len(txHashes) >= max {
+			break
+		}
+	}
+	return txHashes
+}
+
+// requestRebroadcast asks every in-flight transaction submitted to our node to be signed and
+// submitted again with its existing gas price, so it re-enters the pool with the same hash
+func (ptm *pubTxManager) requestRebroadcast(ctx context.Context) {
+	count := 0
+	for _, oc := range ptm.orchestrators.Workers() {
+		if !oc.submitsToNode() {
+			continue
+		}
+		oc.inFlightTxsMux.Lock()
+		for _, it := range oc.inFlightTxs {
+			if it.stateManager.GetTransactionHash() != nil {
+				it.rebroadcastRequested.Store(true)
+				count++
+			}
+		}
+		oc.inFlightTxsMux.Unlock()
+		oc.MarkInFlightTxStale()
+	}
+	log.L(ctx).Infof("Blockchain node restart detected: re-broadcasting %d in-flight transactions", count)
+}
+
+func (oc *orchestrator) submitsToNode() bool {
+	_, isNode := oc.submissionBackend.(*nodeSubmissionBackend)
+	return isNode
+}
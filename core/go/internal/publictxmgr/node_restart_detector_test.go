@@ -0,0 +1,161 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package publictxmgr
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/pkg/ethclient"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRestartDetectionOrchestrator(t *testing.T) (context.Context, *orchestrator, *mocksAndTestControl, func()) {
+	ctx, o, m, done := newTestOrchestrator(t, func(mocks *mocksAndTestControl, conf *pldconf.PublicTxManagerConfig) {
+		conf.Manager.NodeRestartDetection.Interval = confutil.P("100ms")
+		conf.Manager.NodeRestartDetection.ProbeSize = confutil.P(1)
+	})
+	addTestOrchestrator(t, o.pubTxManager, o)
+	return ctx, o, m, done
+}
+
+func addSubmittedInFlightTx(ctx context.Context, o *orchestrator, nonce uint64) (*inFlightTransactionStageController, pldtypes.Bytes32) {
+	it, mTS := newInflightTransaction(o, nonce)
+	txHash := pldtypes.RandBytes32()
+	mTS.ApplyInMemoryUpdates(ctx, &BaseTXUpdates{
+		GasPricing: &pldapi.PublicTxGasPricing{
+			GasPrice: pldtypes.Uint64ToUint256(10),
+		},
+		TransactionHash: &txHash,
+		LastSubmit:      confutil.P(pldtypes.TimestampNow()),
+	})
+	o.inFlightTxs = append(o.inFlightTxs, it)
+	return it, txHash
+}
+
+func TestNodeRestartDetectionDisabled(t *testing.T) {
+	assert.Nil(t, newNodeRestartDetector(&pldconf.PublicTxManagerNodeRestartDetectionConfig{
+		Enabled: confutil.P(false),
+	}))
+	nd := newNodeRestartDetector(&pldconf.PublicTxManagerNodeRestartDetectionConfig{})
+	require.NotNil(t, nd)
+	assert.Equal(t, 30*time.Second, nd.interval)
+	assert.Equal(t, 5, nd.probeSize)
+
+	ptm := &pubTxManager{}
+	ptm.checkNodeRestart(context.Background())
+}
+
+func TestNodeRestartDetectionUnreachableThenAvailable(t *testing.T) {
+	ctx, o, m, done := newTestRestartDetectionOrchestrator(t)
+	defer done()
+	it, _ := addSubmittedInFlightTx(ctx, o, 1)
+
+	m.ethClient.On("PeerCount", mock.Anything).Return(uint64(0), fmt.Errorf("pop")).Once()
+	assert.False(t, o.detectNodeRestart(ctx))
+	assert.True(t, o.restartDetector.nodeUnreachable)
+
+	m.ethClient.On("PeerCount", mock.Anything).Return(uint64(0), nil).Once()
+	o.restartDetector.lastProbe = time.Time{}
+	o.checkNodeRestart(ctx)
+	assert.False(t, o.restartDetector.nodeUnreachable)
+	assert.True(t, it.rebroadcastRequested.Load())
+}
+
+func TestNodeRestartDetectionPeersLost(t *testing.T) {
+	ctx, o, m, done := newTestRestartDetectionOrchestrator(t)
+	defer done()
+	_, txHash := addSubmittedInFlightTx(ctx, o, 1)
+
+	m.ethClient.On("PeerCount", mock.Anything).Return(uint64(3), nil).Once()
+	m.ethClient.On("GetTransactionByHash", mock.Anything, txHash).Return(&ethclient.TransactionByHashResponse{Hash: txHash}, nil).Once()
+	assert.False(t, o.detectNodeRestart(ctx))
+
+	m.ethClient.On("PeerCount", mock.Anything).Return(uint64(0), nil).Once()
+	assert.True(t, o.detectNodeRestart(ctx))
+}
+
+func TestNodeRestartDetectionPoolFlushed(t *testing.T) {
+	ctx, o, m, done := newTestRestartDetectionOrchestrator(t)
+	defer done()
+	it1, txHash1 := addSubmittedInFlightTx(ctx, o, 1)
+	it2, _ := addSubmittedInFlightTx(ctx, o, 2)
+	it3, _ := newInflightTransaction(o, 3) // not submitted yet
+	o.inFlightTxs = append(o.inFlightTxs, it3)
+
+	m.ethClient.On("PeerCount", mock.Anything).Return(uint64(0), nil)
+	m.ethClient.On("GetTransactionByHash", mock.Anything, txHash1).Return(nil, fmt.Errorf("pop")).Once()
+	assert.False(t, o.detectNodeRestart(ctx))
+
+	// Only the first tx is probed (probe size 1)
+	m.ethClient.On("GetTransactionByHash", mock.Anything, txHash1).Return(nil, nil).Once()
+	o.restartDetector.lastProbe = time.Time{}
+	o.checkNodeRestart(ctx)
+	assert.True(t, it1.rebroadcastRequested.Load())
+	assert.True(t, it2.rebroadcastRequested.Load())
+	assert.False(t, it3.rebroadcastRequested.Load())
+
+	// No further probe until the interval expires
+	o.checkNodeRestart(ctx)
+}
+
+func TestNodeRestartDetectionSkipsOtherBackends(t *testing.T) {
+	ctx, o, m, done := newTestRestartDetectionOrchestrator(t)
+	defer done()
+	it, _ := addSubmittedInFlightTx(ctx, o, 1)
+	o.submissionBackend = &fileSubmissionBackend{name: "file1", directory: t.TempDir()}
+
+	m.ethClient.On("PeerCount", mock.Anything).Return(uint64(0), nil).Once()
+	assert.False(t, o.detectNodeRestart(ctx))
+
+	o.requestRebroadcast(ctx)
+	assert.False(t, it.rebroadcastRequested.Load())
+}
+
+func TestStartNewStageRebroadcast(t *testing.T) {
+	ctx, o, _, done := newTestOrchestrator(t)
+	defer done()
+	it, _ := addSubmittedInFlightTx(ctx, o, 1)
+	it.testOnlyNoActionMode = true
+	it.stateManager.(*inFlightTransactionState).orchestratorContext = &OrchestratorContext{}
+	it.stateManager.(*inFlightTransactionState).statusUpdater = &mockStatusUpdater{
+		updateSubStatus: func(ctx context.Context, imtx InMemoryTxStateReadOnly, subStatus BaseTxSubStatus, action BaseTxAction, info, err *fftypes.JSONAny, actionOccurred *pldtypes.Timestamp) error {
+			return nil
+		},
+	}
+	currentGeneration := it.stateManager.GetCurrentGeneration(ctx).(*inFlightTransactionStateGeneration)
+	currentGeneration.SetValidatedTransactionHashMatchState(ctx, true)
+
+	// without a request we're just tracking
+	it.startNewStage(ctx, big.NewInt(0))
+	assert.Nil(t, currentGeneration.runningStageContext)
+
+	it.rebroadcastRequested.Store(true)
+	it.startNewStage(ctx, big.NewInt(0))
+	require.NotNil(t, currentGeneration.runningStageContext)
+	assert.Equal(t, InFlightTxStageSigning, currentGeneration.runningStageContext.Stage)
+	assert.False(t, it.rebroadcastRequested.Load())
+}
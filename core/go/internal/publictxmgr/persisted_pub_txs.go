@@ -33,9 +33,13 @@ type DBPublicTxn struct {
 	FixedGasPricing pldtypes.RawJSON       `gorm:"column:fixed_gas_pricing"`
 	Value           *pldtypes.HexUint256   `gorm:"column:value"`
 	Data            pldtypes.HexBytes      `gorm:"column:data"`
+	DataCodec       *string                `gorm:"column:data_codec"`                           // set if the calldata is compressed at rest
 	Suspended       bool                   `gorm:"column:suspended"`                            // excluded from processing because it's suspended by user
+	Expiry          *pldtypes.Timestamp    `gorm:"column:expiry"`                               // cancelled by replacing the nonce, if not mined by this time
+	Metadata        pldtypes.RawJSON       `gorm:"column:metadata"`                             // supplied by the submitter for correlation
 	Completed       *DBPublicTxnCompletion `gorm:"foreignKey:pub_txn_id;references:pub_txn_id"` // excluded from processing because it's done
 	Submissions     []*DBPubTxnSubmission  `gorm:"-"`                                           // we do the aggregation, not GORM
+	Blobs           []*DBPublicTxnBlob     `gorm:"-"`                                           // only the versioned hashes, unless the sidecar is loaded for signing
 	// Binding is used only on queries by transaction (GORM doesn't seem to allow us to define a separate struct for this)
 	Binding *DBPublicTxnBinding `gorm:"foreignKey:pub_txn_id;references:pub_txn_id;"`
 }
@@ -54,12 +58,27 @@ func (DBPublicTxnBinding) TableName() string {
 	return "public_txn_bindings"
 }
 
+type DBPublicTxnBlob struct {
+	PublicTxnID   uint64            `gorm:"column:pub_txn_id;primaryKey"`
+	Index         int               `gorm:"column:idx;primaryKey"`
+	VersionedHash pldtypes.Bytes32  `gorm:"column:versioned_hash"`
+	Commitment    pldtypes.HexBytes `gorm:"column:commitment"`
+	Proof         pldtypes.HexBytes `gorm:"column:proof"`
+	Blob          pldtypes.HexBytes `gorm:"column:blob"`
+}
+
+func (DBPublicTxnBlob) TableName() string {
+	return "public_txn_blobs"
+}
+
 type DBPubTxnSubmission struct {
 	from            string             `gorm:"-"` // just used to ensure we dispatch to same writer as the associated pubic TX
 	PublicTxnID     uint64             `gorm:"column:pub_txn_id"`
 	Created         pldtypes.Timestamp `gorm:"column:created;autoCreateTime:false"` // we set this as we track the record in memory too
 	TransactionHash pldtypes.Bytes32   `gorm:"column:tx_hash;primaryKey"`
 	GasPricing      pldtypes.RawJSON   `gorm:"column:gas_pricing"` // no filtering allowed on this field as it's complex JSON gasPrice/maxFeePerGas/maxPriorityFeePerGas calculation
+	Cancel          bool               `gorm:"column:cancel"`      // a no-op replacement of an expired transaction
+	RawTransaction  pldtypes.HexBytes  `gorm:"column:raw_tx"`      // only loaded when requested, as this is large
 }
 
 func (DBPubTxnSubmission) TableName() string {
@@ -72,6 +91,7 @@ type DBPublicTxnCompletion struct {
 	TransactionHash pldtypes.Bytes32   `gorm:"column:tx_hash"`
 	Success         bool               `gorm:"column:success"`
 	RevertData      pldtypes.HexBytes  `gorm:"column:revert_data"` // block indexer does not keep this for all TXs
+	Expired         bool               `gorm:"column:expired"`     // the no-op replacement was mined, rather than the transaction itself
 }
 
 func (DBPublicTxnCompletion) TableName() string {
@@ -0,0 +1,123 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package publictxmgr
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+
+	"github.com/hyperledger/firefly-signer/pkg/abi"
+	"github.com/hyperledger/firefly-signer/pkg/ethsigner"
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/internal/components"
+	"github.com/kaleido-io/paladin/core/internal/msgs"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+)
+
+var policyCheckABI = &abi.Entry{
+	Type: abi.Function,
+	Name: "checkTransaction",
+	Inputs: abi.ParameterArray{
+		{Name: "digest", Type: "bytes32"},
+		{Name: "from", Type: "address"},
+		{Name: "to", Type: "address"},
+		{Name: "value", Type: "uint256"},
+		{Name: "data", Type: "bytes"},
+	},
+	Outputs: abi.ParameterArray{
+		{Name: "allowed", Type: "bool"},
+	},
+	StateMutability: abi.View,
+}
+
+var policyDigestParams = abi.ParameterArray{
+	{Name: "chainId", Type: "uint256"},
+	{Name: "from", Type: "address"},
+	{Name: "to", Type: "address"},
+	{Name: "value", Type: "uint256"},
+	{Name: "dataHash", Type: "bytes32"},
+}
+
+// Returns nil if the policy contract check is disabled
+func parsePolicyContract(ctx context.Context, conf *pldconf.PublicTxPolicyContractConfig) (*pldtypes.EthAddress, error) {
+	if conf.Address == nil || *conf.Address == "" {
+		return nil, nil
+	}
+	addr, err := pldtypes.ParseEthAddress(*conf.Address)
+	if err != nil {
+		return nil, i18n.WrapError(ctx, err, msgs.MsgPublicTxPolicyContractInvalid, *conf.Address)
+	}
+	return addr, nil
+}
+
+// The digest commits to everything about the transaction that cannot change after it is accepted,
+// so excludes the nonce, gas limit and gas pricing.
+func policyTransactionDigest(ctx context.Context, chainID int64, from, to pldtypes.EthAddress, value *big.Int, data []byte) (pldtypes.Bytes32, error) {
+	encoded, err := policyDigestParams.EncodeABIDataValuesCtx(ctx, []any{
+		big.NewInt(chainID), from.String(), to.String(), value, pldtypes.Bytes32Keccak(data).String(),
+	})
+	if err != nil {
+		return pldtypes.Bytes32{}, err
+	}
+	return pldtypes.Bytes32Keccak(encoded), nil
+}
+
+func (ptm *pubTxManager) checkPolicyContract(ctx context.Context, txi *components.PublicTxSubmission) error {
+	if ptm.policyContract == nil {
+		return nil
+	}
+	to := pldtypes.EthAddress{} // zero address for a deploy
+	if txi.To != nil {
+		to = *txi.To
+	}
+	value := big.NewInt(0)
+	if txi.Value != nil {
+		value = txi.Value.Int()
+	}
+	digest, err := policyTransactionDigest(ctx, ptm.ethClient.ChainID(), *txi.From, to, value, txi.Data)
+	var callData []byte
+	if err == nil {
+		callData, err = policyCheckABI.EncodeCallDataValuesCtx(ctx, []any{
+			digest.String(), txi.From.String(), to.String(), value, txi.Data.String(),
+		})
+	}
+	if err != nil {
+		return err
+	}
+
+	res, err := ptm.ethClient.CallContractNoResolve(ctx, &ethsigner.Transaction{
+		From: json.RawMessage(pldtypes.JSONString(txi.From)),
+		To:   ptm.policyContract.Address0xHex(),
+		Data: ethtypes.HexBytes0xPrefix(callData),
+	}, "latest")
+	var result *abi.ComponentValue
+	if err == nil {
+		result, err = policyCheckABI.Outputs.DecodeABIDataCtx(ctx, res.Data, 0)
+	}
+	if err != nil {
+		return i18n.WrapError(ctx, err, msgs.MsgPublicTxPolicyCheckFailed, txi.From, ptm.policyContract)
+	}
+	// ABI bools are decoded as integers
+	if allowed, _ := result.Children[0].Value.(*big.Int); allowed == nil || allowed.Sign() == 0 {
+		log.L(ctx).Warnf("Policy contract %s denied transaction from %s with digest %s", ptm.policyContract, txi.From, digest)
+		return i18n.NewError(ctx, msgs.MsgPublicTxPolicyDenied, txi.From, digest, ptm.policyContract)
+	}
+	return nil
+}
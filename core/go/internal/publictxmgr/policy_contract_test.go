@@ -0,0 +1,150 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package publictxmgr
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"net/http"
+	"testing"
+
+	"github.com/hyperledger/firefly-signer/pkg/ethsigner"
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/internal/components"
+	"github.com/kaleido-io/paladin/core/pkg/ethclient"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPolicyContractAllowDeny(t *testing.T) {
+	policyAddr := pldtypes.RandAddress()
+	allowedTarget := pldtypes.RandAddress()
+	ctx, ptm, m, done := newTestPublicTxManager(t, false, func(mocks *mocksAndTestControl, conf *pldconf.PublicTxManagerConfig) {
+		conf.Validation.PolicyContract.Address = confutil.P(policyAddr.String())
+	})
+	defer done()
+
+	m.ethClient.On("ChainID").Return(int64(1337))
+	var lastDigest pldtypes.Bytes32
+	m.ethClient.On("CallContractNoResolve", mock.Anything, mock.MatchedBy(func(tx *ethsigner.Transaction) bool {
+		return tx.To.String() == policyAddr.String()
+	}), "latest").Return(func(_ context.Context, tx *ethsigner.Transaction, _ string, _ ...ethclient.CallOption) (ethclient.CallResult, error) {
+		params, err := policyCheckABI.DecodeCallDataCtx(ctx, tx.Data)
+		require.NoError(t, err)
+		lastDigest = pldtypes.Bytes32(params.Children[0].Value.([]byte))
+		to := params.Children[2].Value.(*big.Int)
+		allowed, err := policyCheckABI.Outputs.EncodeABIDataValuesCtx(ctx, []any{to.Cmp(new(big.Int).SetBytes(allowedTarget[:])) == 0})
+		require.NoError(t, err)
+		return ethclient.CallResult{Data: allowed}, nil
+	})
+
+	validate := func(to *pldtypes.EthAddress, value int64) error {
+		return ptm.ValidateTransaction(ctx, ptm.p.NOTX(), &components.PublicTxSubmission{
+			PublicTxInput: pldapi.PublicTxInput{
+				From: pldtypes.RandAddress(),
+				To:   to,
+				Data: pldtypes.HexBytes("some calldata"),
+				PublicTxOptions: pldapi.PublicTxOptions{
+					Gas:   confutil.P(pldtypes.HexUint64(50000)),
+					Value: pldtypes.Uint64ToUint256(uint64(value)),
+				},
+			},
+		})
+	}
+
+	require.NoError(t, validate(allowedTarget, 0))
+
+	err := validate(pldtypes.RandAddress(), 10)
+	assert.Regexp(t, "PD011988.*"+lastDigest.String(), err)
+	assert.Equal(t, http.StatusForbidden, err.(i18n.PDError).HTTPStatus())
+
+	// Deploys are checked with the zero address
+	assert.Regexp(t, "PD011988", validate(nil, 0))
+}
+
+func TestPolicyTransactionDigest(t *testing.T) {
+	ctx := context.Background()
+	from, to := *pldtypes.RandAddress(), *pldtypes.RandAddress()
+
+	d1, err := policyTransactionDigest(ctx, 1337, from, to, big.NewInt(1), []byte("data"))
+	require.NoError(t, err)
+	d2, err := policyTransactionDigest(ctx, 1337, from, to, big.NewInt(1), []byte("data"))
+	require.NoError(t, err)
+	assert.Equal(t, d1, d2)
+
+	for _, changed := range []func() (pldtypes.Bytes32, error){
+		func() (pldtypes.Bytes32, error) {
+			return policyTransactionDigest(ctx, 1, from, to, big.NewInt(1), []byte("data"))
+		},
+		func() (pldtypes.Bytes32, error) {
+			return policyTransactionDigest(ctx, 1337, to, from, big.NewInt(1), []byte("data"))
+		},
+		func() (pldtypes.Bytes32, error) {
+			return policyTransactionDigest(ctx, 1337, from, to, big.NewInt(2), []byte("data"))
+		},
+		func() (pldtypes.Bytes32, error) {
+			return policyTransactionDigest(ctx, 1337, from, to, big.NewInt(1), []byte("other"))
+		},
+	} {
+		d, err := changed()
+		require.NoError(t, err)
+		assert.NotEqual(t, d1, d)
+	}
+
+	_, err = policyTransactionDigest(ctx, 1337, from, to, big.NewInt(-1), nil)
+	assert.Error(t, err)
+}
+
+func TestPolicyContractCheckFailed(t *testing.T) {
+	policyAddr := pldtypes.RandAddress()
+	ctx, ptm, m, done := newTestPublicTxManager(t, false, func(mocks *mocksAndTestControl, conf *pldconf.PublicTxManagerConfig) {
+		conf.Validation.PolicyContract.Address = confutil.P(policyAddr.String())
+	})
+	defer done()
+
+	m.ethClient.On("ChainID").Return(int64(1337))
+	m.ethClient.On("CallContractNoResolve", mock.Anything, mock.Anything, "latest").
+		Return(ethclient.CallResult{}, fmt.Errorf("pop")).Once()
+	m.ethClient.On("CallContractNoResolve", mock.Anything, mock.Anything, "latest").
+		Return(ethclient.CallResult{Data: []byte{0x01}}, nil).Once()
+
+	txi := &components.PublicTxSubmission{
+		PublicTxInput: pldapi.PublicTxInput{From: pldtypes.RandAddress()},
+	}
+	assert.Regexp(t, "PD011989.*pop", ptm.checkPolicyContract(ctx, txi))
+	// a result that is not an ABI encoded bool
+	assert.Regexp(t, "PD011989", ptm.checkPolicyContract(ctx, txi))
+}
+
+func TestPolicyContractBadAddress(t *testing.T) {
+	pmgr := NewPublicTransactionManager(context.Background(), &pldconf.PublicTxManagerConfig{
+		Validation: pldconf.PublicTxValidationConfig{
+			PolicyContract: pldconf.PublicTxPolicyContractConfig{Address: confutil.P("not an address")},
+		},
+	})
+	err := pmgr.PostInit(baseMocks(t).allComponents)
+	assert.Regexp(t, "PD011987", err)
+
+	addr, err := parsePolicyContract(context.Background(), &pldconf.PublicTxPolicyContractConfig{Address: confutil.P("")})
+	require.NoError(t, err)
+	assert.Nil(t, addr)
+}
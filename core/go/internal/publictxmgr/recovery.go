@@ -0,0 +1,242 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package publictxmgr
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/internal/components"
+	"github.com/kaleido-io/paladin/core/internal/msgs"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+)
+
+// The recovery phase runs once on the engine loop, before the first poll starts any orchestrators.
+//
+// Without it, every transaction loaded after a restart that has a recorded submission is signed
+// and submitted again to validate its hash, and inconsistencies (nonces the chain has already
+// passed, nonce gaps, lost submissions) are only found as each orchestrator hits errors.
+// Instead we reconcile the DB with the chain up-front:
+// - transactions the node still has in its pool, or that were mined, are tracked without re-submitting
+// - transactions the node has lost are left to be re-submitted as normal
+// - nonce gaps, and nonces consumed without a recorded submission, are reported
+type startupRecovery struct {
+	reportOnly bool
+	lock       sync.Mutex
+	summary    *components.PublicTxRecoverySummary
+	tracked    map[uint64]bool // transactions that can be tracked without re-submitting
+}
+
+type recoveryOutcome string
+
+const (
+	recoveryOutcomeMined         recoveryOutcome = "mined"
+	recoveryOutcomeInPool        recoveryOutcome = "in_pool"
+	recoveryOutcomeResubmit      recoveryOutcome = "resubmit"
+	recoveryOutcomeNonceConsumed recoveryOutcome = "nonce_consumed"
+)
+
+func newStartupRecovery(conf *pldconf.PublicTxManagerRecoveryConfig) *startupRecovery {
+	if !confutil.Bool(conf.Enabled, *pldconf.PublicTxManagerDefaults.Manager.Recovery.Enabled) {
+		return nil
+	}
+	return &startupRecovery{
+		reportOnly: confutil.Bool(conf.ReportOnly, *pldconf.PublicTxManagerDefaults.Manager.Recovery.ReportOnly),
+		tracked:    make(map[uint64]bool),
+	}
+}
+
+func (ptm *pubTxManager) GetRecoverySummary(ctx context.Context) *components.PublicTxRecoverySummary {
+	r := ptm.recovery
+	if r == nil {
+		return nil
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.summary
+}
+
+// takeRecoveredTracking is called as each transaction is loaded into an orchestrator, and returns
+// true (once) if recovery found the transaction does not need re-submitting to validate its hash
+func (ptm *pubTxManager) takeRecoveredTracking(pubTxnID uint64) bool {
+	r := ptm.recovery
+	if r == nil {
+		return false
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	tracked := r.tracked[pubTxnID]
+	delete(r.tracked, pubTxnID)
+	return tracked
+}
+
+// recoverOnStart is called on the engine loop before the first poll
+func (ptm *pubTxManager) recoverOnStart(ctx context.Context) {
+	r := ptm.recovery
+	if r == nil {
+		return
+	}
+	summary := &components.PublicTxRecoverySummary{
+		Started:    pldtypes.TimestampNow(),
+		ReportOnly: r.reportOnly,
+		Signers:    []*components.PublicTxSignerRecovery{},
+	}
+	r.lock.Lock()
+	r.summary = summary
+	r.lock.Unlock()
+
+	// We retry the get from persistence indefinitely (until the context cancels)
+	var pending []*DBPublicTxn
+	err := ptm.retry.Do(ctx, func(attempt int) (retry bool, err error) {
+		q := ptm.p.DB().
+			WithContext(ctx).
+			Table("public_txns").
+			Joins("Completed").
+			Where(`"Completed"."tx_hash" IS NULL`).
+			Order(`"public_txns"."pub_txn_id"`)
+		pending, err = ptm.runTransactionQuery(ctx, ptm.p.NOTX(), false, nil, q)
+		return true, err
+	})
+	if err != nil {
+		log.L(ctx).Infof("Engine recovery context cancelled while retrying")
+		return
+	}
+
+	bySigner := make(map[pldtypes.EthAddress][]*DBPublicTxn)
+	signers := []pldtypes.EthAddress{}
+	for _, ptx := range pending {
+		if bySigner[ptx.From] == nil {
+			signers = append(signers, ptx.From)
+		}
+		bySigner[ptx.From] = append(bySigner[ptx.From], ptx)
+	}
+
+	tracked := make(map[uint64]bool)
+	for _, signer := range signers {
+		sr := ptm.recoverSigner(ctx, signer, bySigner[signer], tracked)
+		summary.Signers = append(summary.Signers, sr)
+		confirmedNonce := "unknown"
+		if sr.ConfirmedNonce != nil {
+			confirmedNonce = strconv.FormatUint(*sr.ConfirmedNonce, 10)
+		}
+		log.L(ctx).Infof("Engine recovery for %s: confirmedNonce=%s pending=%d suspended=%d unassigned=%d unsubmitted=%d mined=%d inPool=%d resubmit=%d nonceConsumed=%d nonceGaps=%d",
+			signer, confirmedNonce, sr.Pending, sr.Suspended, sr.Unassigned, sr.Unsubmitted, sr.Mined, sr.InPool, sr.Resubmit, sr.NonceConsumed, sr.NonceGaps)
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if !r.reportOnly {
+		r.tracked = tracked
+	}
+	summary.Completed = confutil.P(pldtypes.TimestampNow())
+	log.L(ctx).Infof("Engine recovery completed for %d pending transactions across %d signers in %s",
+		len(pending), len(signers), summary.Completed.Time().Sub(summary.Started.Time()))
+}
+
+func (ptm *pubTxManager) recoverSigner(ctx context.Context, signer pldtypes.EthAddress, ptxs []*DBPublicTxn, tracked map[uint64]bool) *components.PublicTxSignerRecovery {
+	sr := &components.PublicTxSignerRecovery{Signer: signer}
+
+	nonces := make([]uint64, 0, len(ptxs))
+	for _, ptx := range ptxs {
+		if ptx.Nonce != nil {
+			nonces = append(nonces, *ptx.Nonce)
+		}
+	}
+	if len(nonces) > 0 {
+		txCount, err := ptm.ethClient.GetTransactionCount(ctx, signer)
+		if err != nil {
+			log.L(ctx).Warnf("Engine recovery could not get the confirmed nonce for %s: %s", signer, err)
+			sr.ChainError = err.Error()
+		} else {
+			sr.ConfirmedNonce = confutil.P(txCount.Uint64())
+		}
+	}
+	_, submitsToNode := ptm.getSubmissionBackend(signer).(*nodeSubmissionBackend)
+
+	for _, ptx := range ptxs {
+		if ptx.Suspended {
+			sr.Suspended++
+			continue
+		}
+		sr.Pending++
+		if ptx.Nonce == nil {
+			sr.Unassigned++
+			continue
+		}
+		nonce := *ptx.Nonce
+		var outcome recoveryOutcome
+		switch {
+		case len(ptx.Submissions) == 0 && sr.ConfirmedNonce != nil && nonce < *sr.ConfirmedNonce:
+			log.L(ctx).Warnf("Engine recovery found nonce %d for %s was consumed on chain, with no submission recorded for transaction %d", nonce, signer, ptx.PublicTxnID)
+			sr.NonceConsumed++
+			outcome = recoveryOutcomeNonceConsumed
+		case len(ptx.Submissions) == 0:
+			sr.Unsubmitted++
+			continue
+		case sr.ConfirmedNonce != nil && nonce < *sr.ConfirmedNonce:
+			// the block indexer will confirm it as it catches up
+			sr.Mined++
+			tracked[ptx.PublicTxnID] = true
+			outcome = recoveryOutcomeMined
+		case sr.ConfirmedNonce != nil && submitsToNode:
+			tx, err := ptm.ethClient.GetTransactionByHash(ctx, ptx.Submissions[0].TransactionHash)
+			if err == nil && tx != nil {
+				sr.InPool++
+				tracked[ptx.PublicTxnID] = true
+				outcome = recoveryOutcomeInPool
+			} else {
+				if err != nil {
+					log.L(ctx).Warnf("Engine recovery could not check transaction %s with the blockchain node: %s", ptx.Submissions[0].TransactionHash, err)
+				}
+				sr.Resubmit++
+				outcome = recoveryOutcomeResubmit
+			}
+		default:
+			sr.Resubmit++
+			outcome = recoveryOutcomeResubmit
+		}
+		ptm.addActivityRecord(ptx.PublicTxnID,
+			i18n.ExpandWithCode(ctx, i18n.MessageKey(msgs.MsgPublicTxRecovered), signer, nonce, outcome))
+	}
+
+	// Count the nonces that nothing pending holds, between the confirmed nonce and the highest pending
+	// nonce. Nothing after a gap can be mined until it is filled.
+	if len(nonces) > 0 {
+		sort.Slice(nonces, func(i, j int) bool { return nonces[i] < nonces[j] })
+		next := nonces[0]
+		if sr.ConfirmedNonce != nil {
+			next = *sr.ConfirmedNonce
+		}
+		for _, nonce := range nonces {
+			if nonce > next {
+				sr.NonceGaps += int(nonce - next)
+			}
+			if nonce >= next {
+				next = nonce + 1
+			}
+		}
+		if sr.NonceGaps > 0 {
+			log.L(ctx).Warnf("Engine recovery found %d nonces for %s with no pending transaction, below the highest pending nonce %d", sr.NonceGaps, signer, nonces[len(nonces)-1])
+		}
+	}
+	return sr
+}
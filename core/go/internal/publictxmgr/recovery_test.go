@@ -0,0 +1,196 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package publictxmgr
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/pkg/ethclient"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRecoveryManager(t *testing.T, reportOnly bool) (context.Context, *pubTxManager, *mocksAndTestControl, func()) {
+	return newTestPublicTxManager(t, true, func(mocks *mocksAndTestControl, conf *pldconf.PublicTxManagerConfig) {
+		mocks.disableManagerStart = true
+		conf.Manager.Recovery.Enabled = confutil.P(true)
+		conf.Manager.Recovery.ReportOnly = confutil.P(reportOnly)
+	})
+}
+
+func insertRecoveryTestTx(t *testing.T, ptm *pubTxManager, from pldtypes.EthAddress, nonce *uint64, suspended bool, txHash *pldtypes.Bytes32) *DBPublicTxn {
+	ptx := &DBPublicTxn{
+		From:      from,
+		Nonce:     nonce,
+		Gas:       21000,
+		Suspended: suspended,
+	}
+	require.NoError(t, ptm.p.DB().Create(ptx).Error)
+	if txHash != nil {
+		sub := &DBPubTxnSubmission{
+			PublicTxnID:     ptx.PublicTxnID,
+			Created:         pldtypes.TimestampNow(),
+			TransactionHash: *txHash,
+			GasPricing:      pldtypes.RawJSON(`{"gasPrice":"10"}`),
+		}
+		require.NoError(t, ptm.p.DB().Create(sub).Error)
+		ptx.Submissions = []*DBPubTxnSubmission{sub}
+	}
+	return ptx
+}
+
+func TestRecoveryDisabled(t *testing.T) {
+	assert.Nil(t, newStartupRecovery(&pldconf.PublicTxManagerRecoveryConfig{
+		Enabled: confutil.P(false),
+	}))
+	r := newStartupRecovery(&pldconf.PublicTxManagerRecoveryConfig{})
+	require.NotNil(t, r)
+	assert.False(t, r.reportOnly)
+
+	ptm := &pubTxManager{}
+	ptm.recoverOnStart(context.Background())
+	assert.Nil(t, ptm.GetRecoverySummary(context.Background()))
+	assert.False(t, ptm.takeRecoveredTracking(1))
+}
+
+func TestRecoveryReconcilesWithChain(t *testing.T) {
+	ctx, ptm, m, done := newTestRecoveryManager(t, false)
+	defer done()
+
+	signer1 := *pldtypes.RandAddress()
+	signer2 := *pldtypes.RandAddress()
+	hashMined, hashInPool, hashLost, hashOther := pldtypes.RandBytes32(), pldtypes.RandBytes32(), pldtypes.RandBytes32(), pldtypes.RandBytes32()
+
+	txMined := insertRecoveryTestTx(t, ptm, signer1, confutil.P(uint64(5)), false, &hashMined)
+	txConsumed := insertRecoveryTestTx(t, ptm, signer1, confutil.P(uint64(6)), false, nil)
+	txInPool := insertRecoveryTestTx(t, ptm, signer1, confutil.P(uint64(7)), false, &hashInPool)
+	txLost := insertRecoveryTestTx(t, ptm, signer1, confutil.P(uint64(8)), false, &hashLost)
+	_ = insertRecoveryTestTx(t, ptm, signer1, confutil.P(uint64(10)), false, nil) // nonce 9 is a gap
+	_ = insertRecoveryTestTx(t, ptm, signer1, confutil.P(uint64(11)), true, nil)
+	_ = insertRecoveryTestTx(t, ptm, signer1, nil, false, nil)
+	txOther := insertRecoveryTestTx(t, ptm, signer2, confutil.P(uint64(1)), false, &hashOther)
+
+	// Completed transactions are not part of recovery
+	txDone := insertRecoveryTestTx(t, ptm, signer1, confutil.P(uint64(4)), false, nil)
+	require.NoError(t, ptm.p.DB().Create(&DBPublicTxnCompletion{
+		PublicTxnID:     txDone.PublicTxnID,
+		TransactionHash: pldtypes.RandBytes32(),
+		Success:         true,
+	}).Error)
+
+	m.ethClient.On("GetTransactionCount", mock.Anything, signer1).Return(confutil.P(pldtypes.HexUint64(7)), nil)
+	m.ethClient.On("GetTransactionCount", mock.Anything, signer2).Return(nil, fmt.Errorf("pop"))
+	m.ethClient.On("GetTransactionByHash", mock.Anything, hashInPool).Return(&ethclient.TransactionByHashResponse{Hash: hashInPool}, nil)
+	m.ethClient.On("GetTransactionByHash", mock.Anything, hashLost).Return(nil, nil)
+
+	ptm.recoverOnStart(ctx)
+
+	summary := ptm.GetRecoverySummary(ctx)
+	require.NotNil(t, summary)
+	assert.NotNil(t, summary.Completed)
+	assert.False(t, summary.ReportOnly)
+	require.Len(t, summary.Signers, 2)
+
+	sr1 := summary.Signers[0]
+	assert.Equal(t, signer1, sr1.Signer)
+	assert.Equal(t, uint64(7), *sr1.ConfirmedNonce)
+	assert.Equal(t, 6, sr1.Pending)
+	assert.Equal(t, 1, sr1.Suspended)
+	assert.Equal(t, 1, sr1.Unassigned)
+	assert.Equal(t, 1, sr1.Unsubmitted)
+	assert.Equal(t, 1, sr1.Mined)
+	assert.Equal(t, 1, sr1.InPool)
+	assert.Equal(t, 1, sr1.Resubmit)
+	assert.Equal(t, 1, sr1.NonceConsumed)
+	assert.Equal(t, 1, sr1.NonceGaps)
+
+	sr2 := summary.Signers[1]
+	assert.Equal(t, signer2, sr2.Signer)
+	assert.Nil(t, sr2.ConfirmedNonce)
+	assert.Equal(t, "pop", sr2.ChainError)
+	assert.Equal(t, 1, sr2.Resubmit)
+
+	assert.Regexp(t, "PD011981.*outcome=mined", ptm.getActivityRecords(txMined.PublicTxnID)[0].Message)
+	assert.Regexp(t, "PD011981.*outcome=nonce_consumed", ptm.getActivityRecords(txConsumed.PublicTxnID)[0].Message)
+	assert.Regexp(t, "PD011981.*outcome=resubmit", ptm.getActivityRecords(txOther.PublicTxnID)[0].Message)
+
+	// Transactions the chain already has are tracked without re-submitting when they are loaded
+	o := NewOrchestrator(ptm, signer1, ptm.conf)
+	it := NewInFlightTransactionStageController(ptm, o, txInPool)
+	assert.True(t, it.stateManager.GetCurrentGeneration(ctx).ValidatedTransactionHashMatchState(ctx))
+	it = NewInFlightTransactionStageController(ptm, o, txLost)
+	assert.False(t, it.stateManager.GetCurrentGeneration(ctx).ValidatedTransactionHashMatchState(ctx))
+
+	// Only the first time each is loaded
+	assert.True(t, ptm.takeRecoveredTracking(txMined.PublicTxnID))
+	assert.False(t, ptm.takeRecoveredTracking(txMined.PublicTxnID))
+	assert.False(t, ptm.takeRecoveredTracking(txInPool.PublicTxnID))
+}
+
+func TestRecoveryReportOnly(t *testing.T) {
+	ctx, ptm, m, done := newTestRecoveryManager(t, true)
+	defer done()
+
+	signer := *pldtypes.RandAddress()
+	txHash := pldtypes.RandBytes32()
+	ptx := insertRecoveryTestTx(t, ptm, signer, confutil.P(uint64(3)), false, &txHash)
+
+	m.ethClient.On("GetTransactionCount", mock.Anything, signer).Return(confutil.P(pldtypes.HexUint64(3)), nil)
+	m.ethClient.On("GetTransactionByHash", mock.Anything, txHash).Return(&ethclient.TransactionByHashResponse{Hash: txHash}, nil)
+
+	ptm.recoverOnStart(ctx)
+
+	summary := ptm.GetRecoverySummary(ctx)
+	assert.True(t, summary.ReportOnly)
+	require.Len(t, summary.Signers, 1)
+	assert.Equal(t, 1, summary.Signers[0].InPool)
+	assert.False(t, ptm.takeRecoveredTracking(ptx.PublicTxnID))
+}
+
+func TestRecoveryNoPendingTransactions(t *testing.T) {
+	ctx, ptm, _, done := newTestRecoveryManager(t, false)
+	defer done()
+
+	ptm.recoverOnStart(ctx)
+
+	summary := ptm.GetRecoverySummary(ctx)
+	assert.NotNil(t, summary.Completed)
+	assert.Empty(t, summary.Signers)
+}
+
+func TestRecoveryContextCancelled(t *testing.T) {
+	_, ptm, m, done := newTestPublicTxManager(t, false, func(mocks *mocksAndTestControl, conf *pldconf.PublicTxManagerConfig) {
+		mocks.disableManagerStart = true
+		conf.Manager.Recovery.Enabled = confutil.P(true)
+	})
+	defer done()
+
+	m.db.ExpectQuery("SELECT.*public_txns").WillReturnError(fmt.Errorf("pop"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	ptm.recoverOnStart(ctx)
+
+	summary := ptm.GetRecoverySummary(ctx)
+	require.NotNil(t, summary)
+	assert.Nil(t, summary.Completed)
+}
@@ -0,0 +1,133 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package publictxmgr
+
+import (
+	"context"
+	"path"
+	"strings"
+
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/internal/msgs"
+	"github.com/kaleido-io/paladin/core/pkg/persistence"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/toolkit/pkg/algorithms"
+	"github.com/kaleido-io/paladin/toolkit/pkg/verifiers"
+)
+
+// The signer policy restricts which resolved signing addresses the engine accepts transactions for,
+// so a node shared between tenants can keep each to the keys it owns.
+type signerPolicy struct {
+	allow        []*signerPattern // empty allows any signer that is not denied
+	deny         []*signerPattern
+	needIdentity bool // only look up the key identifier if there are patterns to match it
+}
+
+type signerPattern struct {
+	address   *pldtypes.EthAddress
+	segments  []string
+	subKeys   bool // the pattern ended "**", so matches any number of further segments
+	configStr string
+}
+
+func newSignerPolicy(ctx context.Context, conf *pldconf.PublicTxSignerPolicyConfig) (sp *signerPolicy, err error) {
+	sp = &signerPolicy{}
+	if sp.allow, err = sp.parsePatterns(ctx, conf.Allow); err == nil {
+		sp.deny, err = sp.parsePatterns(ctx, conf.Deny)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return sp, nil
+}
+
+func (sp *signerPolicy) parsePatterns(ctx context.Context, patterns []string) ([]*signerPattern, error) {
+	parsed := make([]*signerPattern, len(patterns))
+	for i, s := range patterns {
+		p := &signerPattern{configStr: s}
+		if strings.HasPrefix(s, "0x") {
+			addr, err := pldtypes.ParseEthAddress(s)
+			if err != nil {
+				return nil, i18n.WrapError(ctx, err, msgs.MsgPublicTxInvalidSignerPattern, s)
+			}
+			p.address = addr
+		} else {
+			p.segments = strings.Split(s, ".")
+			if last := len(p.segments) - 1; last > 0 && p.segments[last] == "**" {
+				p.segments, p.subKeys = p.segments[:last], true
+			}
+			for _, segment := range p.segments {
+				if _, err := path.Match(segment, ""); err != nil || segment == "" || strings.Contains(segment, "**") {
+					return nil, i18n.NewError(ctx, msgs.MsgPublicTxInvalidSignerPattern, s)
+				}
+			}
+			sp.needIdentity = true
+		}
+		parsed[i] = p
+	}
+	return parsed, nil
+}
+
+func (p *signerPattern) matches(address pldtypes.EthAddress, identifier string) bool {
+	if p.address != nil {
+		return *p.address == address
+	}
+	if identifier == "" {
+		return false
+	}
+	segments := strings.Split(identifier, ".")
+	if len(segments) < len(p.segments) || (!p.subKeys && len(segments) != len(p.segments)) ||
+		(p.subKeys && len(segments) == len(p.segments)) {
+		return false
+	}
+	for i, pattern := range p.segments {
+		if match, _ := path.Match(pattern, segments[i]); !match {
+			return false
+		}
+	}
+	return true
+}
+
+func matchesAny(patterns []*signerPattern, address pldtypes.EthAddress, identifier string) bool {
+	for _, p := range patterns {
+		if p.matches(address, identifier) {
+			return true
+		}
+	}
+	return false
+}
+
+func (ptm *pubTxManager) checkSignerPermitted(ctx context.Context, dbTX persistence.DBTX, from pldtypes.EthAddress) error {
+	sp := ptm.signerPolicy
+	if len(sp.allow) == 0 && len(sp.deny) == 0 {
+		return nil
+	}
+	identifier := ""
+	if sp.needIdentity {
+		// The key must be in the key manager for us to sign with it, so a failure here is an error
+		mapping, err := ptm.keymgr.ReverseKeyLookup(ctx, dbTX, algorithms.ECDSA_SECP256K1, verifiers.ETH_ADDRESS, from.String())
+		if err != nil {
+			return err
+		}
+		identifier = mapping.Identifier
+	}
+	if matchesAny(sp.deny, from, identifier) ||
+		(len(sp.allow) > 0 && !matchesAny(sp.allow, from, identifier)) {
+		return i18n.NewError(ctx, msgs.MsgPublicTxSignerNotPermitted, from, identifier)
+	}
+	return nil
+}
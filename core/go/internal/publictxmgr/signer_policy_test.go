@@ -0,0 +1,144 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package publictxmgr
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/internal/components"
+	"github.com/kaleido-io/paladin/core/mocks/componentmocks"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/toolkit/pkg/algorithms"
+	"github.com/kaleido-io/paladin/toolkit/pkg/verifiers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignerPolicyBadPatterns(t *testing.T) {
+	ctx := context.Background()
+	for _, bad := range []string{"0xnothex", "tenant1..alice", "tenant1.[", "**", "tenant1.**.alice", "tenant1.a**"} {
+		_, err := newSignerPolicy(ctx, &pldconf.PublicTxSignerPolicyConfig{Deny: []string{bad}})
+		assert.Regexp(t, "PD011983", err, bad)
+	}
+	_, err := newSignerPolicy(ctx, &pldconf.PublicTxSignerPolicyConfig{Allow: []string{"0xnothex"}})
+	assert.Regexp(t, "PD011983", err)
+
+	pmgr := NewPublicTransactionManager(ctx, &pldconf.PublicTxManagerConfig{
+		Validation: pldconf.PublicTxValidationConfig{
+			Signers: pldconf.PublicTxSignerPolicyConfig{Allow: []string{"a..b"}},
+		},
+	})
+	err = pmgr.PostInit(baseMocks(t).allComponents)
+	assert.Regexp(t, "PD011983", err)
+}
+
+func TestSignerPatternMatching(t *testing.T) {
+	addr := *pldtypes.RandAddress()
+	sp, err := newSignerPolicy(context.Background(), &pldconf.PublicTxSignerPolicyConfig{
+		Allow: []string{addr.String(), "tenant1.**", "tenant2.*", "tenant3.app?.key[0-9]"},
+	})
+	require.NoError(t, err)
+	assert.True(t, sp.needIdentity)
+
+	for identifier, expected := range map[string]bool{
+		"tenant1":            false,
+		"tenant1.alice":      true,
+		"tenant1.a.b.c":      true,
+		"tenant2":            false,
+		"tenant2.alice":      true,
+		"tenant2.alice.sub":  false,
+		"tenant3.app1.key5":  true,
+		"tenant3.app10.key5": false,
+		"tenant3.app1.keyA":  false,
+		"tenant10.alice":     false,
+		"":                   false,
+	} {
+		assert.Equal(t, expected, matchesAny(sp.allow, *pldtypes.RandAddress(), identifier), identifier)
+	}
+	assert.True(t, matchesAny(sp.allow, addr, "anything"))
+
+	sp, err = newSignerPolicy(context.Background(), &pldconf.PublicTxSignerPolicyConfig{
+		Deny: []string{addr.String()},
+	})
+	require.NoError(t, err)
+	assert.False(t, sp.needIdentity)
+}
+
+func TestSignerPolicyRealKeyMgr(t *testing.T) {
+	deniedAddr := *pldtypes.RandAddress()
+	ctx, ptm, m, done := newTestPublicTxManager(t, true, func(mocks *mocksAndTestControl, conf *pldconf.PublicTxManagerConfig) {
+		conf.Validation.Signers = pldconf.PublicTxSignerPolicyConfig{
+			Allow: []string{"tenant1.**", deniedAddr.String()},
+			Deny:  []string{"tenant1.restricted", deniedAddr.String()},
+		}
+	})
+	defer done()
+
+	resolve := func(identifier string) *pldtypes.EthAddress {
+		keyMapping, err := m.keyManager.ResolveKeyNewDatabaseTX(ctx, identifier, algorithms.ECDSA_SECP256K1, verifiers.ETH_ADDRESS)
+		require.NoError(t, err)
+		return pldtypes.MustEthAddress(keyMapping.Verifier.Verifier)
+	}
+	validate := func(from *pldtypes.EthAddress) error {
+		return ptm.ValidateTransaction(ctx, ptm.p.NOTX(), &components.PublicTxSubmission{
+			PublicTxInput: pldapi.PublicTxInput{
+				From:            from,
+				PublicTxOptions: pldapi.PublicTxOptions{Gas: confutil.P(pldtypes.HexUint64(50000))},
+			},
+		})
+	}
+
+	require.NoError(t, validate(resolve("tenant1.alice")))
+
+	err := validate(resolve("tenant1.restricted"))
+	assert.Regexp(t, "PD011982.*tenant1.restricted", err)
+	assert.Equal(t, http.StatusForbidden, err.(i18n.PDError).HTTPStatus())
+
+	assert.Regexp(t, "PD011982.*tenant2.bob", validate(resolve("tenant2.bob")))
+
+	// Not known to the key manager
+	assert.Error(t, validate(&deniedAddr))
+}
+
+func TestSignerPolicyAddressOnlyNoLookup(t *testing.T) {
+	allowedAddr := *pldtypes.RandAddress()
+	ctx, ptm, _, done := newTestPublicTxManager(t, false, func(mocks *mocksAndTestControl, conf *pldconf.PublicTxManagerConfig) {
+		conf.Validation.Signers.Allow = []string{allowedAddr.String()}
+	})
+	defer done()
+
+	require.NoError(t, ptm.checkSignerPermitted(ctx, ptm.p.NOTX(), allowedAddr))
+	assert.Regexp(t, "PD011982", ptm.checkSignerPermitted(ctx, ptm.p.NOTX(), *pldtypes.RandAddress()))
+}
+
+func TestSignerPolicyLookupFail(t *testing.T) {
+	ctx, ptm, m, done := newTestPublicTxManager(t, false, func(mocks *mocksAndTestControl, conf *pldconf.PublicTxManagerConfig) {
+		conf.Validation.Signers.Deny = []string{"tenant1.*"}
+	})
+	defer done()
+
+	m.keyManager.(*componentmocks.KeyManager).On("ReverseKeyLookup", mock.Anything, mock.Anything, algorithms.ECDSA_SECP256K1, verifiers.ETH_ADDRESS, mock.Anything).
+		Return(nil, fmt.Errorf("pop"))
+	assert.Regexp(t, "pop", ptm.checkSignerPermitted(ctx, ptm.p.NOTX(), *pldtypes.RandAddress()))
+}
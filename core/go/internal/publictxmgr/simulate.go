@@ -0,0 +1,99 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package publictxmgr
+
+import (
+	"context"
+	"strings"
+
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/core/internal/components"
+	"github.com/kaleido-io/paladin/core/internal/msgs"
+	"github.com/kaleido-io/paladin/core/pkg/persistence"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+)
+
+// SimulateTransaction executes the transaction against the latest block, without signing or submitting it,
+// returning the call trace where the node supports it. Revert data in the trace is decoded against the ABIs
+// known to the node, so the reason for a revert deep in a call tree can be seen before any gas is spent.
+func (ptm *pubTxManager) SimulateTransaction(ctx context.Context, dbTX persistence.DBTX, txi *components.PublicTxSubmission) (*pldapi.PublicTxSimulation, error) {
+	if txi.From == nil {
+		return nil, i18n.NewError(ctx, msgs.MsgInvalidTXMissingFromAddr)
+	}
+	sim, err := ptm.ethClient.SimulateTransactionNoResolve(ctx, buildEthTX(
+		*txi.From,
+		nil, /* nonce not assigned */
+		txi.To,
+		txi.Data,
+		&txi.PublicTxOptions,
+	), "latest")
+	if err != nil {
+		return nil, err
+	}
+	if sim.Call != nil {
+		ptm.decodeSimulatedReverts(ctx, dbTX, sim.Call)
+		sim.RevertReason = sim.Call.RevertReason
+	} else if sim.Reverted {
+		if de, err := ptm.rootTxMgr.DecodeRevertError(ctx, dbTX, sim.RevertData, ""); err == nil {
+			sim.RevertReason = de.Summary
+		}
+	}
+	return sim, nil
+}
+
+func (ptm *pubTxManager) decodeSimulatedReverts(ctx context.Context, dbTX persistence.DBTX, call *pldapi.PublicTxSimulatedCall) {
+	if call.Error != "" && len(call.Output) > 0 {
+		if de, err := ptm.rootTxMgr.DecodeRevertError(ctx, dbTX, call.Output, ""); err == nil {
+			call.RevertReason = de.Summary
+		}
+	}
+	for _, child := range call.Calls {
+		ptm.decodeSimulatedReverts(ctx, dbTX, child)
+	}
+}
+
+// traceRevertedEstimate is used during validation, when gas estimation is rejected, to log the path
+// through the call tree to the innermost frame that reverted
+func (ptm *pubTxManager) traceRevertedEstimate(ctx context.Context, dbTX persistence.DBTX, txi *components.PublicTxSubmission) {
+	sim, err := ptm.SimulateTransaction(ctx, dbTX, txi)
+	if err != nil || sim.Call == nil {
+		log.L(ctx).Warnf("Unable to trace reverted gas estimate: err=%v", err)
+		return
+	}
+	if !sim.Reverted {
+		log.L(ctx).Warnf("Gas estimate was rejected, but the transaction did not revert when traced")
+		return
+	}
+	path := []string{}
+	for call := sim.Call; call != nil; {
+		to := "<create>"
+		if call.To != nil {
+			to = call.To.String()
+		}
+		path = append(path, to)
+		var failedChild *pldapi.PublicTxSimulatedCall
+		for _, child := range call.Calls {
+			if child.Error != "" {
+				failedChild = child // the last to fail is the one the revert propagated from
+			}
+		}
+		if failedChild == nil {
+			log.L(ctx).Warnf("Estimate gas reverted in call %s: %s (%s)", strings.Join(path, " -> "), call.Error, call.RevertReason)
+		}
+		call = failedChild
+	}
+}
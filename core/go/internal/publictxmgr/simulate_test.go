@@ -0,0 +1,141 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package publictxmgr
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/firefly-signer/pkg/ethsigner"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/internal/components"
+	"github.com/kaleido-io/paladin/core/pkg/ethclient"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSimulateTransactionDecodesReverts(t *testing.T) {
+	ctx, ptm, m, done := newTestPublicTxManager(t, false)
+	defer done()
+
+	from := pldtypes.RandAddress()
+	to := pldtypes.RandAddress()
+	outerRevert := pldtypes.HexBytes("outer revert")
+	innerRevert := pldtypes.HexBytes("inner revert")
+	m.ethClient.On("SimulateTransactionNoResolve", mock.Anything, mock.MatchedBy(func(tx *ethsigner.Transaction) bool {
+		return tx.To.String() == to.String() && tx.Nonce == nil
+	}), "latest").Return(&pldapi.PublicTxSimulation{
+		Traced:     true,
+		Reverted:   true,
+		RevertData: outerRevert,
+		Call: &pldapi.PublicTxSimulatedCall{
+			To:     to,
+			Output: outerRevert,
+			Error:  "execution reverted",
+			Calls: []*pldapi.PublicTxSimulatedCall{
+				{To: pldtypes.RandAddress()},
+				{To: pldtypes.RandAddress(), Output: innerRevert, Error: "execution reverted", RevertReason: "from node"},
+			},
+		},
+	}, nil)
+	m.txManager.On("DecodeRevertError", mock.Anything, mock.Anything, outerRevert, pldtypes.JSONFormatOptions("")).
+		Return(&pldapi.ABIDecodedData{Summary: "OuterError()"}, nil)
+	m.txManager.On("DecodeRevertError", mock.Anything, mock.Anything, innerRevert, pldtypes.JSONFormatOptions("")).
+		Return(nil, fmt.Errorf("no matching ABI"))
+
+	sim, err := ptm.SimulateTransaction(ctx, ptm.p.NOTX(), &components.PublicTxSubmission{
+		PublicTxInput: pldapi.PublicTxInput{From: from, To: to},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "OuterError()", sim.RevertReason)
+	assert.Equal(t, "OuterError()", sim.Call.RevertReason)
+	assert.Empty(t, sim.Call.Calls[0].RevertReason)
+	assert.Equal(t, "from node", sim.Call.Calls[1].RevertReason)
+}
+
+func TestSimulateTransactionUntracedRevert(t *testing.T) {
+	ctx, ptm, m, done := newTestPublicTxManager(t, false)
+	defer done()
+
+	revertData := pldtypes.HexBytes("revert data")
+	m.ethClient.On("SimulateTransactionNoResolve", mock.Anything, mock.Anything, "latest").Return(&pldapi.PublicTxSimulation{
+		Reverted:   true,
+		RevertData: revertData,
+	}, nil)
+	m.txManager.On("DecodeRevertError", mock.Anything, mock.Anything, revertData, pldtypes.JSONFormatOptions("")).
+		Return(&pldapi.ABIDecodedData{Summary: "MyError()"}, nil)
+
+	sim, err := ptm.SimulateTransaction(ctx, ptm.p.NOTX(), &components.PublicTxSubmission{
+		PublicTxInput: pldapi.PublicTxInput{From: pldtypes.RandAddress()},
+	})
+	require.NoError(t, err)
+	assert.False(t, sim.Traced)
+	assert.Equal(t, "MyError()", sim.RevertReason)
+}
+
+func TestSimulateTransactionErrors(t *testing.T) {
+	ctx, ptm, m, done := newTestPublicTxManager(t, false)
+	defer done()
+
+	_, err := ptm.SimulateTransaction(ctx, ptm.p.NOTX(), &components.PublicTxSubmission{})
+	assert.Regexp(t, "PD011936", err)
+
+	m.ethClient.On("SimulateTransactionNoResolve", mock.Anything, mock.Anything, "latest").Return(nil, fmt.Errorf("pop"))
+	_, err = ptm.SimulateTransaction(ctx, ptm.p.NOTX(), &components.PublicTxSubmission{
+		PublicTxInput: pldapi.PublicTxInput{From: pldtypes.RandAddress()},
+	})
+	assert.Regexp(t, "pop", err)
+}
+
+func TestValidateTransactionTraceReverts(t *testing.T) {
+	ctx, ptm, m, done := newTestPublicTxManager(t, false, func(mocks *mocksAndTestControl, conf *pldconf.PublicTxManagerConfig) {
+		conf.GasLimit.TraceReverts = confutil.P(true)
+	})
+	defer done()
+
+	revertData := pldtypes.HexBytes("revert data")
+	m.ethClient.On("EstimateGasNoResolve", mock.Anything, mock.Anything, mock.Anything).
+		Return(ethclient.EstimateGasResult{RevertData: revertData}, fmt.Errorf("execution reverted"))
+	m.txManager.On("CalculateRevertError", mock.Anything, mock.Anything, revertData).Return(fmt.Errorf("mapped revert error"))
+	m.txManager.On("DecodeRevertError", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, fmt.Errorf("no matching ABI"))
+
+	txi := &components.PublicTxSubmission{
+		PublicTxInput: pldapi.PublicTxInput{From: pldtypes.RandAddress()},
+	}
+	for _, simResult := range []*pldapi.PublicTxSimulation{
+		// a revert nested in the call tree, including a create
+		{Traced: true, Reverted: true, Call: &pldapi.PublicTxSimulatedCall{
+			To:    pldtypes.RandAddress(),
+			Error: "execution reverted",
+			Calls: []*pldapi.PublicTxSimulatedCall{
+				{Error: "execution reverted", RevertReason: "failed create"},
+			},
+		}},
+		// succeeds when traced
+		{Traced: true, Call: &pldapi.PublicTxSimulatedCall{To: pldtypes.RandAddress()}},
+		// node does not support tracing
+		{Reverted: true},
+	} {
+		m.ethClient.On("SimulateTransactionNoResolve", mock.Anything, mock.Anything, "latest").Return(simResult, nil).Once()
+		err := ptm.ValidateTransaction(ctx, ptm.p.NOTX(), txi)
+		assert.Regexp(t, "mapped revert error", err)
+	}
+	m.ethClient.AssertNumberOfCalls(t, "SimulateTransactionNoResolve", 3)
+}
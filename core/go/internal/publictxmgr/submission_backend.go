@@ -0,0 +1,165 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package publictxmgr
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/internal/msgs"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/rpcclient"
+)
+
+type SignedTransaction struct {
+	From            pldtypes.EthAddress `json:"from"`
+	Nonce           pldtypes.HexUint64  `json:"nonce"`
+	TransactionHash pldtypes.Bytes32    `json:"transactionHash"`
+	RawTransaction  pldtypes.HexBytes   `json:"rawTransaction"`
+}
+
+// SubmissionBackend performs the "send raw transaction" stage for the signers that are configured to use it.
+// Errors are mapped with ethclient.MapError, so backends that relay to a node should preserve the node's error text.
+// The returned hash is checked against the calculated hash, and can be nil if the backend does not return one.
+type SubmissionBackend interface {
+	Name() string
+	SendRawTransaction(ctx context.Context, tx *SignedTransaction) (*pldtypes.Bytes32, error)
+}
+
+// Submits directly to the blockchain node the Paladin node is connected to
+type nodeSubmissionBackend struct {
+	ptm *pubTxManager
+}
+
+func (nb *nodeSubmissionBackend) Name() string {
+	return pldconf.SubmissionBackendNode
+}
+
+func (nb *nodeSubmissionBackend) SendRawTransaction(ctx context.Context, tx *SignedTransaction) (*pldtypes.Bytes32, error) {
+	return nb.ptm.ethClient.SendRawTransaction(ctx, tx.RawTransaction)
+}
+
+// Submits to a private relay, which accepts the signed transaction as a "tx" field in the
+// first parameter in the style of Flashbots eth_sendPrivateTransaction
+type relaySubmissionBackend struct {
+	name   string
+	method string
+	rpc    rpcclient.Client
+}
+
+func (rb *relaySubmissionBackend) Name() string {
+	return rb.name
+}
+
+func (rb *relaySubmissionBackend) SendRawTransaction(ctx context.Context, tx *SignedTransaction) (*pldtypes.Bytes32, error) {
+	var txHash *pldtypes.Bytes32
+	if rpcErr := rb.rpc.CallRPC(ctx, &txHash, rb.method, map[string]any{"tx": tx.RawTransaction}); rpcErr != nil {
+		return nil, i18n.WrapError(ctx, rpcErr, msgs.MsgSubmissionBackendRelayFailed, rb.name, rb.method)
+	}
+	return txHash, nil
+}
+
+// Writes each signed transaction to a JSON file in a directory, for a separate process to broadcast.
+// The calculated hash is returned, as nothing is known about the transaction until it is confirmed.
+type fileSubmissionBackend struct {
+	name      string
+	directory string
+}
+
+func (fb *fileSubmissionBackend) Name() string {
+	return fb.name
+}
+
+func (fb *fileSubmissionBackend) SendRawTransaction(ctx context.Context, tx *SignedTransaction) (*pldtypes.Bytes32, error) {
+	// Resubmissions with a new gas price have a new hash, so are written to a new file
+	fileName := filepath.Join(fb.directory, fmt.Sprintf("%s_%d_%s.json", tx.From, tx.Nonce, tx.TransactionHash))
+	// Write then rename, so the reader never sees a partial file
+	tmpFileName := fileName + ".tmp"
+	err := os.WriteFile(tmpFileName, pldtypes.JSONString(tx), 0600)
+	if err == nil {
+		err = os.Rename(tmpFileName, fileName)
+	}
+	if err != nil {
+		return nil, i18n.WrapError(ctx, err, msgs.MsgSubmissionBackendFileFailed, fb.name)
+	}
+	log.L(ctx).Infof("Transaction %s:%d written to %s", tx.From, tx.Nonce, fileName)
+	return &tx.TransactionHash, nil
+}
+
+func (ptm *pubTxManager) initSubmissionBackends(ctx context.Context, conf *pldconf.PublicTxSubmissionConfig) error {
+	ptm.submissionBackends = map[string]SubmissionBackend{
+		pldconf.SubmissionBackendNode: &nodeSubmissionBackend{ptm: ptm},
+	}
+	for name, bc := range conf.Backends {
+		var backend SubmissionBackend
+		switch bc.Type {
+		case pldconf.SubmissionBackendTypeNode:
+			backend = &nodeSubmissionBackend{ptm: ptm}
+		case pldconf.SubmissionBackendTypeRelay:
+			rpc, err := rpcclient.NewHTTPClient(ctx, &bc.Relay.HTTPClientConfig)
+			if err != nil {
+				return err
+			}
+			backend = &relaySubmissionBackend{
+				name:   name,
+				method: confutil.StringNotEmpty(bc.Relay.Method, *pldconf.SubmissionRelayBackendDefaults.Method),
+				rpc:    rpc,
+			}
+		case pldconf.SubmissionBackendTypeFile:
+			if bc.File.Directory == "" {
+				return i18n.NewError(ctx, msgs.MsgSubmissionBackendMissingDir, name)
+			}
+			backend = &fileSubmissionBackend{name: name, directory: bc.File.Directory}
+		default:
+			return i18n.NewError(ctx, msgs.MsgSubmissionBackendInvalidType, bc.Type, name)
+		}
+		ptm.submissionBackends[name] = backend
+	}
+
+	ptm.defaultSubmissionBackend = confutil.StringNotEmpty(conf.DefaultBackend, *pldconf.PublicTxManagerDefaults.Submission.DefaultBackend)
+	if ptm.submissionBackends[ptm.defaultSubmissionBackend] == nil {
+		return i18n.NewError(ctx, msgs.MsgSubmissionBackendNotFound, ptm.defaultSubmissionBackend)
+	}
+	ptm.signerSubmissionBackends = make(map[pldtypes.EthAddress]string, len(conf.Signers))
+	for signer, name := range conf.Signers {
+		addr, err := pldtypes.ParseEthAddress(signer)
+		if err != nil {
+			return i18n.WrapError(ctx, err, msgs.MsgSubmissionBackendInvalidSigner, signer)
+		}
+		if ptm.submissionBackends[name] == nil {
+			return i18n.NewError(ctx, msgs.MsgSubmissionBackendNotFound, name)
+		}
+		ptm.signerSubmissionBackends[*addr] = name
+	}
+	return nil
+}
+
+func (ptm *pubTxManager) getSubmissionBackend(signer pldtypes.EthAddress) SubmissionBackend {
+	name, ok := ptm.signerSubmissionBackends[signer]
+	if !ok {
+		name = ptm.defaultSubmissionBackend
+	}
+	if backend := ptm.submissionBackends[name]; backend != nil {
+		return backend
+	}
+	return &nodeSubmissionBackend{ptm: ptm}
+}
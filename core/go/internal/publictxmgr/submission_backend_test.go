@@ -0,0 +1,214 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package publictxmgr
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubmissionBackendConfigErrors(t *testing.T) {
+	for _, tc := range []struct {
+		conf  pldconf.PublicTxSubmissionConfig
+		error string
+	}{
+		{
+			conf: pldconf.PublicTxSubmissionConfig{
+				Backends: map[string]*pldconf.SubmissionBackendConfig{"wrong": {Type: "wrong"}},
+			},
+			error: "PD011963.*wrong",
+		},
+		{
+			conf: pldconf.PublicTxSubmissionConfig{
+				Backends: map[string]*pldconf.SubmissionBackendConfig{"airgap": {Type: pldconf.SubmissionBackendTypeFile}},
+			},
+			error: "PD011966.*airgap",
+		},
+		{
+			conf: pldconf.PublicTxSubmissionConfig{
+				Backends: map[string]*pldconf.SubmissionBackendConfig{"relay": {Type: pldconf.SubmissionBackendTypeRelay}},
+			},
+			error: "PD020501", // no URL
+		},
+		{
+			conf:  pldconf.PublicTxSubmissionConfig{DefaultBackend: confutil.P("missing")},
+			error: "PD011964.*missing",
+		},
+		{
+			conf:  pldconf.PublicTxSubmissionConfig{Signers: map[string]string{"wrong": "node"}},
+			error: "PD011965.*wrong",
+		},
+		{
+			conf:  pldconf.PublicTxSubmissionConfig{Signers: map[string]string{pldtypes.RandAddress().String(): "missing"}},
+			error: "PD011964.*missing",
+		},
+	} {
+		mocks := baseMocks(t)
+		pmgr := NewPublicTransactionManager(context.Background(), &pldconf.PublicTxManagerConfig{Submission: tc.conf})
+		err := pmgr.PostInit(mocks.allComponents)
+		assert.Regexp(t, tc.error, err)
+	}
+}
+
+func TestSubmissionBackendPerSigner(t *testing.T) {
+	relaySigner := *pldtypes.RandAddress()
+	fileSigner := *pldtypes.RandAddress()
+	_, ptm, _, done := newTestPublicTxManager(t, false, func(mocks *mocksAndTestControl, conf *pldconf.PublicTxManagerConfig) {
+		mocks.disableManagerStart = true
+		conf.Submission = pldconf.PublicTxSubmissionConfig{
+			Backends: map[string]*pldconf.SubmissionBackendConfig{
+				"relay1":  {Type: pldconf.SubmissionBackendTypeRelay, Relay: pldconf.SubmissionRelayBackendConfig{HTTPClientConfig: pldconf.HTTPClientConfig{URL: "http://localhost:8545"}}},
+				"airgap":  {Type: pldconf.SubmissionBackendTypeFile, File: pldconf.SubmissionFileBackendConfig{Directory: t.TempDir()}},
+				"node2":   {Type: pldconf.SubmissionBackendTypeNode},
+				"unused1": {Type: pldconf.SubmissionBackendTypeNode},
+			},
+			DefaultBackend: confutil.P("node2"),
+			Signers: map[string]string{
+				relaySigner.String(): "relay1",
+				fileSigner.String():  "airgap",
+			},
+		}
+	})
+	defer done()
+
+	assert.Equal(t, "relay1", ptm.getSubmissionBackend(relaySigner).Name())
+	assert.Equal(t, "airgap", ptm.getSubmissionBackend(fileSigner).Name())
+	assert.Equal(t, pldconf.SubmissionBackendNode, ptm.getSubmissionBackend(*pldtypes.RandAddress()).Name())
+	assert.Equal(t, "airgap", NewOrchestrator(ptm, fileSigner, ptm.conf).submissionBackend.Name())
+
+	// Without any config (as in a manager that has not been initialized) we submit to the node
+	assert.IsType(t, &nodeSubmissionBackend{}, (&pubTxManager{}).getSubmissionBackend(relaySigner))
+}
+
+func TestRelaySubmissionBackend(t *testing.T) {
+	ctx := context.Background()
+	tx := &SignedTransaction{
+		From:            *pldtypes.RandAddress(),
+		Nonce:           12345,
+		TransactionHash: pldtypes.RandBytes32(),
+		RawTransaction:  pldtypes.RandBytes(64),
+	}
+
+	var fail bool
+	relay := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer token1", r.Header.Get("Authorization"))
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		var req struct {
+			ID     json.RawMessage `json:"id"`
+			Method string          `json:"method"`
+			Params []struct {
+				Tx pldtypes.HexBytes `json:"tx"`
+			} `json:"params"`
+		}
+		require.NoError(t, json.Unmarshal(body, &req))
+		assert.Equal(t, "eth_sendPrivateTransaction", req.Method)
+		assert.Equal(t, tx.RawTransaction, req.Params[0].Tx)
+		w.Header().Set("Content-Type", "application/json")
+		if fail {
+			_, _ = fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%s,"error":{"code":-32000,"message":"nonce too low"}}`, req.ID)
+			return
+		}
+		_, _ = fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%s,"result":"%s"}`, req.ID, tx.TransactionHash)
+	}))
+	defer relay.Close()
+
+	ptm := &pubTxManager{}
+	err := ptm.initSubmissionBackends(ctx, &pldconf.PublicTxSubmissionConfig{
+		Backends: map[string]*pldconf.SubmissionBackendConfig{
+			"relay1": {
+				Type: pldconf.SubmissionBackendTypeRelay,
+				Relay: pldconf.SubmissionRelayBackendConfig{HTTPClientConfig: pldconf.HTTPClientConfig{
+					URL:         relay.URL,
+					HTTPHeaders: map[string]interface{}{"Authorization": "Bearer token1"},
+				}},
+			},
+		},
+		DefaultBackend: confutil.P("relay1"),
+	})
+	require.NoError(t, err)
+	backend := ptm.getSubmissionBackend(tx.From)
+
+	txHash, err := backend.SendRawTransaction(ctx, tx)
+	require.NoError(t, err)
+	assert.Equal(t, tx.TransactionHash, *txHash)
+
+	fail = true
+	_, err = backend.SendRawTransaction(ctx, tx)
+	assert.Regexp(t, "PD011967.*relay1.*nonce too low", err)
+}
+
+func TestFileSubmissionBackend(t *testing.T) {
+	textTxHashByte32 := pldtypes.MustParseBytes32(testTxHash)
+	dir := t.TempDir()
+	ctx, o, _, done := newTestOrchestrator(t, func(mocks *mocksAndTestControl, conf *pldconf.PublicTxManagerConfig) {
+		conf.Orchestrator.SubmissionRetry.MaxAttempts = confutil.P(1)
+		conf.Submission = pldconf.PublicTxSubmissionConfig{
+			Backends: map[string]*pldconf.SubmissionBackendConfig{
+				"airgap": {Type: pldconf.SubmissionBackendTypeFile, File: pldconf.SubmissionFileBackendConfig{Directory: dir}},
+			},
+			DefaultBackend: confutil.P("airgap"),
+		}
+	})
+	defer done()
+	it, _ := newInflightTransaction(o, 42)
+
+	txHash, _, errReason, outcome, err := it.submitTX(ctx,
+		[]byte(testTransactionData),
+		&textTxHashByte32,
+		it.stateManager.GetSignerNonce(),
+		it.stateManager.GetLastSubmitTime(),
+		testCancel)
+	require.NoError(t, err)
+	assert.Empty(t, errReason)
+	assert.Equal(t, SubmissionOutcomeSubmittedNew, outcome)
+	assert.Equal(t, textTxHashByte32, *txHash)
+
+	b, err := os.ReadFile(filepath.Join(dir, fmt.Sprintf("%s_42_%s.json", o.signingAddress, textTxHashByte32)))
+	require.NoError(t, err)
+	var written SignedTransaction
+	require.NoError(t, json.Unmarshal(b, &written))
+	assert.Equal(t, SignedTransaction{
+		From:            o.signingAddress,
+		Nonce:           42,
+		TransactionHash: textTxHashByte32,
+		RawTransaction:  []byte(testTransactionData),
+	}, written)
+
+	// a failure to write is retried by the orchestrator
+	require.NoError(t, os.RemoveAll(dir))
+	_, _, _, outcome, err = it.submitTX(ctx,
+		[]byte(testTransactionData),
+		&textTxHashByte32,
+		it.stateManager.GetSignerNonce(),
+		it.stateManager.GetLastSubmitTime(),
+		testCancel)
+	assert.Regexp(t, "PD011968.*airgap", err)
+	assert.Equal(t, SubmissionOutcomeFailedRequiresRetry, outcome)
+}
@@ -19,6 +19,7 @@ import (
 	"context"
 
 	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/internal/components"
 	"github.com/kaleido-io/paladin/core/internal/flushwriter"
 
 	"github.com/kaleido-io/paladin/core/pkg/persistence"
@@ -30,10 +31,11 @@ type noResult struct{}
 
 type submissionWriter struct {
 	flushwriter.Writer[*DBPubTxnSubmission, *noResult]
+	writeEvents func(ctx context.Context, dbTX persistence.DBTX, events []*DBPublicTxnEvent) error
 }
 
-func newSubmissionWriter(bgCtx context.Context, p persistence.Persistence, conf *pldconf.PublicTxManagerConfig) *submissionWriter {
-	sw := &submissionWriter{}
+func newSubmissionWriter(bgCtx context.Context, p persistence.Persistence, conf *pldconf.PublicTxManagerConfig, writeEvents func(ctx context.Context, dbTX persistence.DBTX, events []*DBPublicTxnEvent) error) *submissionWriter {
+	sw := &submissionWriter{writeEvents: writeEvents}
 	sw.Writer = flushwriter.NewWriter(bgCtx, sw.runBatch, p, &conf.Manager.SubmissionWriter, &pldconf.PublicTxManagerDefaults.Manager.SubmissionWriter)
 	return sw
 }
@@ -47,6 +49,13 @@ func (sw *submissionWriter) runBatch(ctx context.Context, tx persistence.DBTX, v
 		}).
 		Create(values).
 		Error
+	if err == nil {
+		events := make([]*DBPublicTxnEvent, len(values))
+		for i, v := range values {
+			events[i] = newTxEvents(components.PublicTxEventSubmitted, &v.TransactionHash, v.PublicTxnID)[0]
+		}
+		err = sw.writeEvents(ctx, tx, events)
+	}
 	if err != nil {
 		return nil, err
 	}
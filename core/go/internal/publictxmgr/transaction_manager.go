@@ -20,6 +20,7 @@ import (
 	"encoding/json"
 	"math/big"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/google/uuid"
@@ -41,6 +42,7 @@ import (
 	"github.com/kaleido-io/paladin/sdk/go/pkg/query"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/retry"
 	"github.com/kaleido-io/paladin/toolkit/pkg/cache"
+	"github.com/kaleido-io/paladin/toolkit/pkg/keyedqueue"
 
 	"github.com/kaleido-io/paladin/core/internal/msgs"
 
@@ -86,15 +88,27 @@ type pubTxManager struct {
 	keymgr           components.KeyManager
 	rootTxMgr        components.TXManager
 	ethClientFactory ethclient.EthClientFactory
+	// calldata compression at rest
+	calldataCodec                string
+	calldataCompressionThreshold int64
 	// gas price
 	gasPriceClient   GasPriceClient
 	submissionWriter *submissionWriter
 
-	// a map of signing addresses and transaction engines
-	inFlightOrchestrators       map[pldtypes.EthAddress]*orchestrator
-	signingAddressesPausedUntil map[pldtypes.EthAddress]time.Time
-	inFlightOrchestratorMux     sync.Mutex
-	inFlightOrchestratorStale   chan bool
+	// submission backends, selected by signing address
+	submissionBackends       map[string]SubmissionBackend
+	signerSubmissionBackends map[pldtypes.EthAddress]string
+	defaultSubmissionBackend string
+
+	// only set in builds with the "faultinjection" tag
+	faults *faultInjector
+
+	// the pool of orchestrators, one for each signing address being processed
+	orchestrators             *keyedqueue.Pool[pldtypes.EthAddress, *orchestrator]
+	waitingSigners            map[pldtypes.EthAddress]bool // signers waiting for a slot in the pool
+	waitingSignersMux         sync.Mutex
+	inFlightOrchestratorStale chan bool
+	quiesced                  atomic.Bool
 
 	// inbound concurrency control TBD
 
@@ -102,24 +116,44 @@ type pubTxManager struct {
 	maxInflight              int
 	orchestratorIdleTimeout  time.Duration
 	orchestratorStaleTimeout time.Duration
-	orchestratorSwapTimeout  time.Duration
 	retry                    *retry.Retry
 	enginePollingInterval    time.Duration
+	restartDetector          *nodeRestartDetector // nil if disabled
+	recovery                 *startupRecovery     // nil if disabled
 	nonceCacheTimeout        time.Duration
 	engineLoopDone           chan struct{}
 
 	activityRecordCache     cache.Cache[uint64, *txActivityRecords]
 	maxActivityRecordsPerTx int
 
+	latency *latencyRecorder
+
+	// event subscribers are all added before we start
+	eventSubscribers        map[string]*eventSubscriber
+	eventSubscribersMux     sync.Mutex
+	eventSubscribersStarted bool
+	eventBatchSize          int
+	eventPollInterval       time.Duration
+
 	// balance manager
 	balanceManager BalanceManager
 
 	// orchestrator config
+	gasPriceIncreaseMux     sync.RWMutex // the increase policy can be changed by a config reload
 	gasPriceIncreaseMax     *big.Int
 	gasPriceIncreasePercent int
+	blobFeeIncreaseMax      *big.Int
+	blobFeeIncreasePercent  int
 
 	// gas limit config
-	gasEstimateFactor float64
+	gasEstimateFactor  float64
+	gasEstimatePadding *ethclient.GasEstimatePadding
+	traceReverts       bool
+
+	// input validation before nonce assignment
+	validation     *txValidation
+	signerPolicy   *signerPolicy
+	policyContract *pldtypes.EthAddress // nil if the policy contract check is disabled
 
 	// updates
 	updates   []*transactionUpdate
@@ -132,6 +166,7 @@ type txActivityRecords struct {
 }
 
 func NewPublicTransactionManager(ctx context.Context, conf *pldconf.PublicTxManagerConfig) components.PublicTxManager {
+	ctx = log.WithSubsystem(ctx, "publictxmgr")
 	log.L(ctx).Debugf("Creating new public transaction manager")
 
 	gasPriceClient := NewGasPriceClient(ctx, conf)
@@ -142,29 +177,48 @@ func NewPublicTransactionManager(ctx context.Context, conf *pldconf.PublicTxMana
 
 	ptmCtx, ptmCtxCancel := context.WithCancel(log.WithLogField(ctx, "role", "public_tx_mgr"))
 
-	return &pubTxManager{
-		ctx:                         ptmCtx,
-		ctxCancel:                   ptmCtxCancel,
-		conf:                        conf,
-		gasPriceClient:              gasPriceClient,
-		inFlightOrchestratorStale:   make(chan bool, 1),
-		signingAddressesPausedUntil: make(map[pldtypes.EthAddress]time.Time),
-		maxInflight:                 confutil.IntMin(conf.Manager.MaxInFlightOrchestrators, 1, *pldconf.PublicTxManagerDefaults.Manager.MaxInFlightOrchestrators),
-		orchestratorSwapTimeout:     confutil.DurationMin(conf.Manager.OrchestratorSwapTimeout, 0, *pldconf.PublicTxManagerDefaults.Manager.OrchestratorSwapTimeout),
-		orchestratorStaleTimeout:    confutil.DurationMin(conf.Manager.OrchestratorStaleTimeout, 0, *pldconf.PublicTxManagerDefaults.Manager.OrchestratorStaleTimeout),
-		orchestratorIdleTimeout:     confutil.DurationMin(conf.Manager.OrchestratorIdleTimeout, 0, *pldconf.PublicTxManagerDefaults.Manager.OrchestratorIdleTimeout),
-		enginePollingInterval:       confutil.DurationMin(conf.Manager.Interval, 50*time.Millisecond, *pldconf.PublicTxManagerDefaults.Manager.Interval),
-		nonceCacheTimeout:           confutil.DurationMin(conf.Manager.NonceCacheTimeout, 0, *pldconf.PublicTxManagerDefaults.Manager.NonceCacheTimeout),
-		retry:                       retry.NewRetryIndefinite(&conf.Manager.Retry),
-		gasPriceIncreaseMax:         gasPriceIncreaseMax,
-		gasPriceIncreasePercent:     confutil.Int(conf.GasPrice.IncreasePercentage, *pldconf.PublicTxManagerDefaults.GasPrice.IncreasePercentage),
-		activityRecordCache:         cache.NewCache[uint64, *txActivityRecords](&conf.Manager.ActivityRecords.CacheConfig, &pldconf.PublicTxManagerDefaults.Manager.ActivityRecords.CacheConfig),
-		maxActivityRecordsPerTx:     confutil.Int(conf.Manager.ActivityRecords.RecordsPerTransaction, *pldconf.PublicTxManagerDefaults.Manager.ActivityRecords.RecordsPerTransaction),
-		gasEstimateFactor:           gasEstimateFactor,
+	ptm := &pubTxManager{
+		ctx:                          ptmCtx,
+		ctxCancel:                    ptmCtxCancel,
+		conf:                         conf,
+		gasPriceClient:               gasPriceClient,
+		inFlightOrchestratorStale:    make(chan bool, 1),
+		waitingSigners:               make(map[pldtypes.EthAddress]bool),
+		maxInflight:                  confutil.IntMin(conf.Manager.MaxInFlightOrchestrators, 1, *pldconf.PublicTxManagerDefaults.Manager.MaxInFlightOrchestrators),
+		orchestratorStaleTimeout:     confutil.DurationMin(conf.Manager.OrchestratorStaleTimeout, 0, *pldconf.PublicTxManagerDefaults.Manager.OrchestratorStaleTimeout),
+		orchestratorIdleTimeout:      confutil.DurationMin(conf.Manager.OrchestratorIdleTimeout, 0, *pldconf.PublicTxManagerDefaults.Manager.OrchestratorIdleTimeout),
+		enginePollingInterval:        confutil.DurationMin(conf.Manager.Interval, 50*time.Millisecond, *pldconf.PublicTxManagerDefaults.Manager.Interval),
+		nonceCacheTimeout:            confutil.DurationMin(conf.Manager.NonceCacheTimeout, 0, *pldconf.PublicTxManagerDefaults.Manager.NonceCacheTimeout),
+		retry:                        retry.NewRetryIndefinite(&conf.Manager.Retry),
+		gasPriceIncreaseMax:          gasPriceIncreaseMax,
+		gasPriceIncreasePercent:      confutil.Int(conf.GasPrice.IncreasePercentage, *pldconf.PublicTxManagerDefaults.GasPrice.IncreasePercentage),
+		blobFeeIncreaseMax:           confutil.BigIntOrNil(conf.GasPrice.Blob.IncreaseMax),
+		blobFeeIncreasePercent:       confutil.Int(conf.GasPrice.Blob.IncreasePercentage, *pldconf.PublicTxManagerDefaults.GasPrice.Blob.IncreasePercentage),
+		activityRecordCache:          cache.NewNamedCache[uint64, *txActivityRecords]("publictxmgr.activityRecords", &conf.Manager.ActivityRecords.CacheConfig, &pldconf.PublicTxManagerDefaults.Manager.ActivityRecords.CacheConfig),
+		maxActivityRecordsPerTx:      confutil.Int(conf.Manager.ActivityRecords.RecordsPerTransaction, *pldconf.PublicTxManagerDefaults.Manager.ActivityRecords.RecordsPerTransaction),
+		gasEstimateFactor:            gasEstimateFactor,
+		traceReverts:                 confutil.Bool(conf.GasLimit.TraceReverts, *pldconf.PublicTxManagerDefaults.GasLimit.TraceReverts),
+		eventSubscribers:             make(map[string]*eventSubscriber),
+		eventBatchSize:               confutil.IntMin(conf.Manager.EventNotifier.BatchSize, 1, *pldconf.PublicTxManagerDefaults.Manager.EventNotifier.BatchSize),
+		eventPollInterval:            confutil.DurationMin(conf.Manager.EventNotifier.PollInterval, 10*time.Millisecond, *pldconf.PublicTxManagerDefaults.Manager.EventNotifier.PollInterval),
+		restartDetector:              newNodeRestartDetector(&conf.Manager.NodeRestartDetection),
+		recovery:                     newStartupRecovery(&conf.Manager.Recovery),
+		thMetrics:                    &publicTxEngineMetrics{},
+		calldataCompressionThreshold: confutil.ByteSize(conf.Compression.Threshold, 0, *pldconf.PublicTxManagerDefaults.Compression.Threshold),
 	}
+	ptm.orchestrators = keyedqueue.NewPool[pldtypes.EthAddress, *orchestrator](ptmCtx, &pldconf.KeyedQueueConfig{
+		MaxActive:   &ptm.maxInflight,
+		SwapTimeout: confutil.P(confutil.DurationMin(conf.Manager.OrchestratorSwapTimeout, 0, *pldconf.PublicTxManagerDefaults.Manager.OrchestratorSwapTimeout).String()),
+	})
+	ptm.latency = newLatencyRecorder(&conf.Manager.LatencyTracing, ptm.thMetrics)
+	return ptm
 }
 
 func (ptm *pubTxManager) PreInit(pic components.PreInitComponents) (result *components.ManagerInitResult, err error) {
+	ptm.calldataCodec, err = parseCalldataCodec(ptm.ctx, confutil.StringNotEmpty(ptm.conf.Compression.Codec, *pldconf.PublicTxManagerDefaults.Compression.Codec))
+	if err != nil {
+		return nil, err
+	}
 	return &components.ManagerInitResult{}, nil
 }
 
@@ -172,12 +226,33 @@ func (ptm *pubTxManager) PreInit(pic components.PreInitComponents) (result *comp
 func (ptm *pubTxManager) PostInit(pic components.AllComponents) error {
 	ctx := ptm.ctx
 	log.L(ctx).Debugf("Initializing public transaction manager")
+	if err := ptm.gasPriceClient.SetSchedules(ctx, ptm.conf.GasPrice.Schedules); err != nil {
+		return err
+	}
+	if err := ptm.initSubmissionBackends(ctx, &ptm.conf.Submission); err != nil {
+		return err
+	}
+	gasEstimatePadding, err := ethclient.NewGasEstimatePadding(ctx, &ptm.conf.GasLimit.Padding, ptm.gasEstimateFactor)
+	if err != nil {
+		return err
+	}
+	ptm.gasEstimatePadding = gasEstimatePadding
+	if ptm.validation, err = newTxValidation(ctx, &ptm.conf.Validation); err != nil {
+		return err
+	}
+	if ptm.signerPolicy, err = newSignerPolicy(ctx, &ptm.conf.Validation.Signers); err != nil {
+		return err
+	}
+	if ptm.policyContract, err = parsePolicyContract(ctx, &ptm.conf.Validation.PolicyContract); err != nil {
+		return err
+	}
+
 	ptm.ethClientFactory = pic.EthClientFactory()
 	ptm.keymgr = pic.KeyManager()
 	ptm.p = pic.Persistence()
 	ptm.bIndexer = pic.BlockIndexer()
 	ptm.rootTxMgr = pic.TxManager()
-	ptm.submissionWriter = newSubmissionWriter(ptm.ctx, ptm.p, ptm.conf)
+	ptm.submissionWriter = newSubmissionWriter(ptm.ctx, ptm.p, ptm.conf, ptm.writeEvents)
 
 	balanceManager, err := NewBalanceManagerWithInMemoryTracking(ctx, ptm.conf, ptm)
 	if err != nil {
@@ -204,6 +279,7 @@ func (ptm *pubTxManager) Start() error {
 	}
 	ptm.MarkInFlightOrchestratorsStale()
 	ptm.submissionWriter.Start()
+	ptm.startEventSubscribers()
 	log.L(ctx).Infof("Started public transaction manager")
 	return nil
 }
@@ -216,6 +292,7 @@ func (ptm *pubTxManager) Stop() {
 	if ptm.engineLoopDone != nil {
 		<-ptm.engineLoopDone
 	}
+	ptm.stopEventSubscribers()
 }
 
 func buildEthTX(
@@ -265,6 +342,50 @@ func (ptm *pubTxManager) ValidateTransaction(ctx context.Context, dbTX persisten
 		return i18n.NewError(ctx, msgs.MsgInvalidTXMissingFromAddr)
 	}
 
+	if err := ptm.validation.validateInput(ctx, txi); err != nil {
+		return err
+	}
+	if err := ptm.checkSignerPermitted(ctx, dbTX, *txi.From); err != nil {
+		return err
+	}
+	if txi.ChainID != nil {
+		if chainID := ptm.ethClient.ChainID(); txi.ChainID.Uint64() != uint64(chainID) {
+			return i18n.NewError(ctx, msgs.MsgPublicTxChainIDMismatch, txi.ChainID.Uint64(), chainID)
+		}
+	}
+
+	if txi.Expiry != nil {
+		if len(txi.Blobs) > 0 {
+			// nodes do not allow a blob transaction to be replaced by a regular one
+			return i18n.NewError(ctx, msgs.MsgPublicTxExpiryBlobs)
+		}
+		if !time.Now().Before(txi.Expiry.Time()) {
+			return i18n.NewError(ctx, msgs.MsgPublicTxExpiryPassed, txi.Expiry)
+		}
+	}
+
+	if txi.Metadata != nil {
+		if err := validateMetadata(ctx, txi); err != nil {
+			return err
+		}
+	}
+
+	if len(txi.Blobs) > 0 {
+		if txi.To == nil {
+			return i18n.NewError(ctx, msgs.MsgBlobTxMissingTo)
+		}
+		if _, err := blobGasPricingSet(ctx, &txi.PublicTxGasPricing); err != nil {
+			return err
+		}
+		if err := validateBlobs(ctx, txi.Blobs); err != nil {
+			return err
+		}
+	}
+
+	if err := ptm.checkPolicyContract(ctx, txi); err != nil {
+		return err
+	}
+
 	prepareStart := time.Now()
 	var txType InFlightTxOperation
 
@@ -287,13 +408,19 @@ func (ptm *pubTxManager) ValidateTransaction(ctx context.Context, dbTX persisten
 					err = ptm.rootTxMgr.CalculateRevertError(ctx, dbTX, gasEstimateResult.RevertData)
 					log.L(ctx).Warnf("Estimate gas reverted (%s): %s", err, err)
 				}
+				if ptm.traceReverts {
+					ptm.traceRevertedEstimate(ctx, dbTX, txi)
+				}
 				return err
 			}
 			return err
 		}
-		factoredGasLimit := pldtypes.HexUint64((float64)(gasEstimateResult.GasLimit) * ptm.gasEstimateFactor)
-		txi.Gas = &factoredGasLimit
-		log.L(ctx).Tracef("HandleNewTx <%s> using the estimated gas limit %s multiplied by the gas estimate factor %.f (=%s) for transaction: %+v", txType, gasEstimateResult.GasLimit, ptm.gasEstimateFactor, factoredGasLimit, txi)
+		paddedGasLimit := pldtypes.HexUint64(ptm.gasEstimatePadding.PadGasEstimate(txi.To, gasEstimateResult.GasLimit.Uint64()))
+		if err := ptm.validation.validateMaxGas(ctx, paddedGasLimit.Uint64()); err != nil {
+			return err
+		}
+		txi.Gas = &paddedGasLimit
+		log.L(ctx).Tracef("HandleNewTx <%s> using the estimated gas limit %s with padding (=%s) for transaction: %+v", txType, gasEstimateResult.GasLimit, paddedGasLimit, txi)
 	} else {
 		log.L(ctx).Tracef("HandleNewTx <%s> using the provided gas limit %s for transaction: %+v", txType, txi.Gas, txi)
 	}
@@ -313,17 +440,31 @@ func (ptm *pubTxManager) WriteNewTransactions(ctx context.Context, dbTX persiste
 			Value:           txi.Value,
 			Data:            txi.Data,
 			FixedGasPricing: pldtypes.JSONString(txi.PublicTxGasPricing),
+			Expiry:          txi.Expiry,
+			Metadata:        txi.Metadata,
+		}
+		if len(txi.Blobs) > 0 {
+			if persistedTransactions[i].Blobs, err = buildBlobSidecar(ctx, txi.Blobs); err != nil {
+				return nil, err
+			}
 		}
 	}
 	// All the nonce processing to this point should have ensured we do not have a conflict on nonces.
 	// It is the caller's responsibility to ensure we do not have a conflict on transaction+resubmit_idx.
 	if len(persistedTransactions) > 0 {
+		toInsert := make([]*DBPublicTxn, len(persistedTransactions))
+		for i, ptx := range persistedTransactions {
+			toInsert[i] = ptm.compressCalldata(ptx)
+		}
 		err = dbTX.DB().
 			WithContext(ctx).
 			Table("public_txns").
 			Clauses(clause.Returning{Columns: []clause.Column{{Name: "pub_txn_id"}}}).
-			Create(persistedTransactions).
+			Create(toInsert).
 			Error
+		for i, ptx := range toInsert {
+			persistedTransactions[i].PublicTxnID = ptx.PublicTxnID
+		}
 	}
 	if err == nil {
 		publicTxBindings := make([]*DBPublicTxnBinding, 0, len(transactions))
@@ -345,6 +486,29 @@ func (ptm *pubTxManager) WriteNewTransactions(ctx context.Context, dbTX persiste
 				Error
 		}
 	}
+	if err == nil {
+		var publicTxBlobs []*DBPublicTxnBlob
+		for _, ptx := range persistedTransactions {
+			for _, blob := range ptx.Blobs {
+				blob.PublicTxnID = ptx.PublicTxnID
+				publicTxBlobs = append(publicTxBlobs, blob)
+			}
+		}
+		if len(publicTxBlobs) > 0 {
+			err = dbTX.DB().
+				WithContext(ctx).
+				Table("public_txn_blobs").
+				Create(publicTxBlobs).
+				Error
+		}
+	}
+	if err == nil {
+		pubTxnIDs := make([]uint64, len(persistedTransactions))
+		for i, ptx := range persistedTransactions {
+			pubTxnIDs[i] = ptx.PublicTxnID
+		}
+		err = ptm.writeEvents(ctx, dbTX, newTxEvents(components.PublicTxEventCreated, nil, pubTxnIDs...))
+	}
 	if err == nil {
 		pubTxns = make([]*pldapi.PublicTx, len(persistedTransactions))
 		toNotify := make(map[pldtypes.EthAddress]bool)
@@ -352,6 +516,7 @@ func (ptm *pubTxManager) WriteNewTransactions(ctx context.Context, dbTX persiste
 			pubTxns[i] = mapPersistedTransaction(ptx)
 			toNotify[ptx.From] = true
 		}
+		dbTX.AddPostCommit(ptm.postCommitRecordLatency(persistedTransactions, pldapi.PublicTxStageReceived, time.Now()))
 		dbTX.AddPostCommit(ptm.postCommitNewTransactions(toNotify))
 	}
 
@@ -359,12 +524,22 @@ func (ptm *pubTxManager) WriteNewTransactions(ctx context.Context, dbTX persiste
 }
 
 func (ptm *pubTxManager) writeUpdatedTransaction(ctx context.Context, dbTX persistence.DBTX, pubTXID uint64, from pldtypes.EthAddress, newPtx *DBPublicTxn) error {
+	toWrite := ptm.compressCalldata(newPtx)
 	err := dbTX.DB().
 		WithContext(ctx).
 		Table("public_txns").
 		Where("pub_txn_id = ?", pubTXID).
-		Updates(newPtx).
+		Updates(toWrite).
 		Error
+	if err == nil && len(toWrite.Data) > 0 {
+		// The codec is replaced along with the calldata, even when the new calldata is uncompressed
+		err = dbTX.DB().
+			WithContext(ctx).
+			Table("public_txns").
+			Where("pub_txn_id = ?", pubTXID).
+			UpdateColumn("data_codec", toWrite.DataCodec).
+			Error
+	}
 
 	if err == nil {
 		toNotify := map[pldtypes.EthAddress]bool{
@@ -375,6 +550,15 @@ func (ptm *pubTxManager) writeUpdatedTransaction(ctx context.Context, dbTX persi
 	return err
 }
 
+// we only record the time of stages that are reached in a DB transaction once that transaction commits
+func (ptm *pubTxManager) postCommitRecordLatency(ptxs []*DBPublicTxn, stage pldapi.PublicTxStage, t time.Time) func(ctx context.Context) {
+	return func(ctx context.Context) {
+		for _, ptx := range ptxs {
+			ptm.latency.recordAt(ctx, ptx.PublicTxnID, stage, t)
+		}
+	}
+}
+
 func (ptm *pubTxManager) postCommitNewTransactions(toNotify map[pldtypes.EthAddress]bool) func(ctx context.Context) {
 	return func(ctx context.Context) {
 		// Mark any active orchestrators stale
@@ -439,6 +623,10 @@ func (ptm *pubTxManager) queryPublicTxWithBinding(ctx context.Context, dbTX pers
 	if jq != nil {
 		q = filters.BuildGORM(ctx, jq, q, components.PublicTxFilterFields)
 	}
+	return ptm.runPublicTxWithBindingQuery(ctx, dbTX, scopeToTxns, q)
+}
+
+func (ptm *pubTxManager) runPublicTxWithBindingQuery(ctx context.Context, dbTX persistence.DBTX, scopeToTxns []uuid.UUID, q *gorm.DB) ([]*pldapi.PublicTxWithBinding, error) {
 	ptxs, err := ptm.runTransactionQuery(ctx, dbTX, true /* one record per TX binding */, scopeToTxns, q)
 	if err != nil {
 		return nil, err
@@ -465,6 +653,30 @@ func (ptm *pubTxManager) queryPublicTxWithBinding(ctx context.Context, dbTX pers
 	return results, nil
 }
 
+func (ptm *pubTxManager) GetSignerNonces(ctx context.Context, dbTX persistence.DBTX) ([]*pldapi.SignerNonce, error) {
+	var highest []*DBPublicTxn
+	err := dbTX.DB().
+		WithContext(ctx).
+		Table("public_txns").
+		Select(`"from", MAX("nonce") AS "nonce"`).
+		Where(`"nonce" IS NOT NULL`).
+		Group("from").
+		Order(`"from"`).
+		Find(&highest).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	signerNonces := make([]*pldapi.SignerNonce, len(highest))
+	for i, h := range highest {
+		signerNonces[i] = &pldapi.SignerNonce{
+			Signer:    h.From,
+			NextNonce: pldtypes.HexUint64(*h.Nonce + 1),
+		}
+	}
+	return signerNonces, nil
+}
+
 func (ptm *pubTxManager) CheckTransactionCompleted(ctx context.Context, pubTxnID uint64) (bool, error) {
 	// Runs a DB query to see if the transaction is marked completed (for good or bad)
 	// A non existent transaction results in false
@@ -543,6 +755,17 @@ func (ptm *pubTxManager) runTransactionQuery(ctx context.Context, dbTX persisten
 				}
 			}
 		}
+		allBlobHashes, err := ptm.getTransactionBlobHashes(ctx, dbTX, publicTxRefs)
+		if err != nil {
+			return nil, err
+		}
+		for _, blob := range allBlobHashes {
+			for _, ptx := range ptxs {
+				if blob.PublicTxnID == ptx.PublicTxnID {
+					ptx.Blobs = append(ptx.Blobs, blob)
+				}
+			}
+		}
 	}
 	return ptxs, nil
 }
@@ -559,7 +782,10 @@ func mapPersistedTransaction(ptx *DBPublicTxn) *pldapi.PublicTx {
 			Gas:                (*pldtypes.HexUint64)(&ptx.Gas),
 			Value:              ptx.Value,
 			PublicTxGasPricing: recoverGasPriceOptions(ptx.FixedGasPricing),
+			Expiry:             ptx.Expiry,
+			Metadata:           ptx.Metadata,
 		},
+		BlobHashes: blobHashes(ptx.Blobs),
 	}
 	// We use a separate Table in the DB for the completion data, but
 	// we allow a single query and return interface for users.
@@ -569,6 +795,7 @@ func mapPersistedTransaction(ptx *DBPublicTxn) *pldapi.PublicTx {
 		tx.TransactionHash = &completed.TransactionHash
 		tx.Success = &completed.Success
 		tx.RevertData = completed.RevertData
+		tx.Expired = completed.Expired
 	}
 	// Note: Submissions (sent to the mempool of the chain, but not yet complete) are separate.
 	// See mapPersistedSubmissionData()
@@ -579,6 +806,7 @@ func mapPersistedSubmissionData(pSub *DBPubTxnSubmission) *pldapi.PublicTxSubmis
 	return &pldapi.PublicTxSubmissionData{
 		Time:               pSub.Created,
 		TransactionHash:    pldtypes.Bytes32(pSub.TransactionHash),
+		Cancel:             pSub.Cancel,
 		PublicTxGasPricing: recoverGasPriceOptions(pSub.GasPricing),
 	}
 }
@@ -588,6 +816,7 @@ func (ptm *pubTxManager) getTransactionSubmissions(ctx context.Context, dbTX per
 	err := dbTX.DB().
 		WithContext(ctx).
 		Table("public_submissions").
+		Omit("raw_tx").
 		Where("pub_txn_id IN (?)", pubTxnIDs).
 		Order("created DESC").
 		Find(&ptxs).
@@ -595,6 +824,21 @@ func (ptm *pubTxManager) getTransactionSubmissions(ctx context.Context, dbTX per
 	return ptxs, err
 }
 
+// getTransactionBlobHashes returns just the versioned hashes, as the sidecar is only needed to sign the transaction
+func (ptm *pubTxManager) getTransactionBlobHashes(ctx context.Context, dbTX persistence.DBTX, pubTxnIDs []uint64) ([]*DBPublicTxnBlob, error) {
+	var blobs []*DBPublicTxnBlob
+	err := dbTX.DB().
+		WithContext(ctx).
+		Table("public_txn_blobs").
+		Select("pub_txn_id", "idx", "versioned_hash").
+		Where("pub_txn_id IN (?)", pubTxnIDs).
+		Order("pub_txn_id").
+		Order("idx").
+		Find(&blobs).
+		Error
+	return blobs, err
+}
+
 func (ptm *pubTxManager) SuspendTransaction(ctx context.Context, from pldtypes.EthAddress, nonce uint64) error {
 	if err := ptm.dispatchAction(ctx, from, nonce, ActionSuspend); err != nil {
 		return err
@@ -609,6 +853,40 @@ func (ptm *pubTxManager) ResumeTransaction(ctx context.Context, from pldtypes.Et
 	return nil
 }
 
+// CancelTransaction replaces a pending transaction with a no-op transaction that consumes the same nonce,
+// using the same processing as a transaction that reaches its expiry. If the original transaction is mined
+// first it completes as normal, otherwise it completes as expired with a failure receipt.
+func (ptm *pubTxManager) CancelTransaction(ctx context.Context, from pldtypes.EthAddress, nonce uint64) error {
+	var ptxs []*DBPublicTxn
+	err := ptm.p.DB().
+		WithContext(ctx).
+		Table("public_txns").
+		Where(`"public_txns"."from" = ?`, from).
+		Where(`"public_txns"."nonce" = ?`, nonce).
+		Joins("Completed").
+		Limit(1).
+		Find(&ptxs).
+		Error
+	if err != nil {
+		return err
+	}
+	if len(ptxs) == 0 {
+		return i18n.NewError(ctx, msgs.MsgPublicTxNonceNotFound, from, nonce)
+	}
+	if ptxs[0].Completed != nil {
+		return i18n.NewError(ctx, msgs.MsgPublicTxCancelCompleted, from, nonce)
+	}
+	blobs, err := ptm.getTransactionBlobHashes(ctx, ptm.p.NOTX(), []uint64{ptxs[0].PublicTxnID})
+	if err != nil {
+		return err
+	}
+	if len(blobs) > 0 {
+		// the same restriction as setting an expiry on submission
+		return i18n.NewError(ctx, msgs.MsgPublicTxExpiryBlobs)
+	}
+	return ptm.dispatchAction(ctx, from, nonce, ActionCancel)
+}
+
 func (ptm *pubTxManager) UpdateTransaction(ctx context.Context, id uuid.UUID, pubTXID uint64, from *pldtypes.EthAddress, tx *pldapi.TransactionInput, publicTxData []byte, txmgrDBUpdate func(dbTX persistence.DBTX) error) error {
 	ptxs := []*DBPublicTxn{}
 	err := ptm.p.DB().
@@ -753,6 +1031,68 @@ func (ptm *pubTxManager) getActivityRecords(pubTxID uint64) []pldapi.Transaction
 	return []pldapi.TransactionActivityRecord{}
 }
 
+func (ptm *pubTxManager) GetTransactionTimelines(ctx context.Context, dbTX persistence.DBTX, txID uuid.UUID) ([]*pldapi.PublicTxTimeline, error) {
+	ptxs, err := ptm.queryPublicTxWithBinding(ctx, dbTX, []uuid.UUID{txID}, query.NewQueryBuilder().Sort("localId").Query())
+	if err != nil {
+		return nil, err
+	}
+	timelines := make([]*pldapi.PublicTxTimeline, len(ptxs))
+	for i, ptx := range ptxs {
+		binding := ptx.PublicTxBinding
+		timelines[i] = &pldapi.PublicTxTimeline{
+			LocalID: *ptx.LocalID,
+			Stages:  ptm.latency.getTimeline(*ptx.LocalID),
+			Binding: &binding,
+		}
+	}
+	return timelines, nil
+}
+
+func (ptm *pubTxManager) GetLatencyStats(ctx context.Context) []*pldapi.PublicTxLatencyStats {
+	return ptm.latency.getStats()
+}
+
+func (ptm *pubTxManager) GetEngineMetrics(ctx context.Context) *components.PublicTxEngineMetrics {
+	return &components.PublicTxEngineMetrics{
+		StageTimeouts: ptm.thMetrics.StageTimeoutCounts(),
+		StageLatency:  ptm.thMetrics.StageLatencyHistograms(),
+		Fueling:       ptm.thMetrics.FuelingCounts(),
+		OverflowQueue: ptm.thMetrics.OverflowQueueDepths(),
+	}
+}
+
+func (ptm *pubTxManager) GetGasPriceSchedules(ctx context.Context) []*pldconf.GasPriceScheduleConfig {
+	return ptm.gasPriceClient.GetSchedules(ctx)
+}
+
+func (ptm *pubTxManager) SetGasPriceSchedules(ctx context.Context, schedules []*pldconf.GasPriceScheduleConfig) error {
+	return ptm.gasPriceClient.SetSchedules(ctx, schedules)
+}
+
+// ReloadConfig applies the gas price increase policy and schedules, and the auto-fueling thresholds, from
+// an updated config. The thresholds and schedules are validated before anything is changed.
+func (ptm *pubTxManager) ReloadConfig(ctx context.Context, conf *pldconf.PublicTxManagerConfig) error {
+	if _, _, _, err := parseAutoFuelingThresholds(ctx, &conf.BalanceManager.AutoFueling); err != nil {
+		return err
+	}
+	if err := ptm.gasPriceClient.SetSchedules(ctx, conf.GasPrice.Schedules); err != nil {
+		return err
+	}
+
+	ptm.gasPriceIncreaseMux.Lock()
+	ptm.gasPriceIncreaseMax = confutil.BigIntOrNil(conf.GasPrice.IncreaseMax)
+	ptm.gasPriceIncreasePercent = confutil.Int(conf.GasPrice.IncreasePercentage, *pldconf.PublicTxManagerDefaults.GasPrice.IncreasePercentage)
+	ptm.gasPriceIncreaseMux.Unlock()
+
+	return ptm.balanceManager.SetAutoFuelingThresholds(ctx, &conf.BalanceManager.AutoFueling)
+}
+
+func (ptm *pubTxManager) getGasPriceIncrease() (int, *big.Int) {
+	ptm.gasPriceIncreaseMux.RLock()
+	defer ptm.gasPriceIncreaseMux.RUnlock()
+	return ptm.gasPriceIncreasePercent, ptm.gasPriceIncreaseMax
+}
+
 func (ptm *pubTxManager) GetPublicTransactionForHash(ctx context.Context, dbTX persistence.DBTX, hash pldtypes.Bytes32) (*pldapi.PublicTxWithBinding, error) {
 	var publicTxnIDs []uint64
 	var txns []*pldapi.PublicTxWithBinding
@@ -774,6 +1114,43 @@ func (ptm *pubTxManager) GetPublicTransactionForHash(ctx context.Context, dbTX p
 	return txns[0], nil
 }
 
+func (ptm *pubTxManager) GetRawSubmission(ctx context.Context, dbTX persistence.DBTX, hash pldtypes.Bytes32) (*pldapi.PublicTxRawSubmission, error) {
+	var submissions []*DBPubTxnSubmission
+	err := dbTX.DB().
+		WithContext(ctx).
+		Table("public_submissions").
+		Where("tx_hash = ?", hash).
+		Limit(1).
+		Find(&submissions).
+		Error
+	if err != nil || len(submissions) == 0 {
+		return nil, err
+	}
+	sub := submissions[0]
+	var ptxs []*DBPublicTxn
+	err = dbTX.DB().
+		WithContext(ctx).
+		Table("public_txns").
+		Select("pub_txn_id", "from", "nonce").
+		Where("pub_txn_id = ?", sub.PublicTxnID).
+		Find(&ptxs).
+		Error
+	if err != nil || len(ptxs) == 0 {
+		return nil, err
+	}
+	raw := &pldapi.PublicTxRawSubmission{
+		PublicTxSubmission: pldapi.PublicTxSubmission{
+			From:                   ptxs[0].From,
+			PublicTxSubmissionData: *mapPersistedSubmissionData(sub),
+		},
+		RawTransaction: sub.RawTransaction,
+	}
+	if ptxs[0].Nonce != nil {
+		raw.Nonce = pldtypes.HexUint64(*ptxs[0].Nonce)
+	}
+	return raw, nil
+}
+
 // note this function guarantees the return order of the matches corresponds to the input order
 func (ptm *pubTxManager) MatchUpdateConfirmedTransactions(ctx context.Context, dbTX persistence.DBTX, itxs []*blockindexer.IndexedTransactionNotify) ([]*components.PublicTxMatch, error) {
 
@@ -787,7 +1164,7 @@ func (ptm *pubTxManager) MatchUpdateConfirmedTransactions(ctx context.Context, d
 	var lookups []*bindingsMatchingSubmission
 	err := dbTX.DB().
 		Table("public_txn_bindings").
-		Select(`"transaction"`, `"tx_type"`, `"Submission"."pub_txn_id"`, `"Submission"."tx_hash"`).
+		Select(`"transaction"`, `"tx_type"`, `"Submission"."pub_txn_id"`, `"Submission"."tx_hash"`, `"Submission"."cancel"`).
 		Joins("Submission").
 		Where(`"Submission"."tx_hash" IN (?)`, txHashes).
 		Find(&lookups).
@@ -810,13 +1187,17 @@ func (ptm *pubTxManager) MatchUpdateConfirmedTransactions(ctx context.Context, d
 						TransactionType: match.TransactionType,
 					},
 					IndexedTransactionNotify: txi,
+					PublicTxnID:              match.PublicTxnID,
+					Expired:                  match.Submission.Cancel,
 				})
+				ptm.latency.record(ctx, match.PublicTxnID, pldapi.PublicTxStageIndexed)
 				// completions to insert, in the order of the inputs
 				completions = append(completions, &DBPublicTxnCompletion{
 					PublicTxnID:     match.PublicTxnID,
 					TransactionHash: txi.Hash,
 					Success:         txi.Result.V() == pldapi.TXResult_SUCCESS,
 					RevertData:      txi.RevertReason,
+					Expired:         match.Submission.Cancel,
 				})
 				break
 			}
@@ -836,6 +1217,20 @@ func (ptm *pubTxManager) MatchUpdateConfirmedTransactions(ctx context.Context, d
 		if err != nil {
 			return nil, err
 		}
+		var events []*DBPublicTxnEvent
+		for _, c := range completions {
+			eventType := components.PublicTxEventSucceeded
+			switch {
+			case c.Expired:
+				eventType = components.PublicTxEventExpired
+			case !c.Success:
+				eventType = components.PublicTxEventFailed
+			}
+			events = append(events, newTxEvents(eventType, &c.TransactionHash, c.PublicTxnID)...)
+		}
+		if err := ptm.writeEvents(ctx, dbTX, events); err != nil {
+			return nil, err
+		}
 	}
 
 	return results, nil
@@ -845,6 +1240,12 @@ func (ptm *pubTxManager) MatchUpdateConfirmedTransactions(ctx context.Context, d
 // on each of these transactions
 func (ptm *pubTxManager) NotifyConfirmPersisted(ctx context.Context, confirms []*components.PublicTxMatch) {
 	for _, conf := range confirms {
+		ptm.latency.record(ctx, conf.PublicTxnID, pldapi.PublicTxStageConfirmed)
+		if delay := ptm.faults.receiptDelay(); delay > 0 {
+			from, nonce := *conf.From, conf.Nonce
+			time.AfterFunc(delay, func() { _ = ptm.dispatchAction(ptm.ctx, from, nonce, ActionCompleted) })
+			continue
+		}
 		_ = ptm.dispatchAction(ctx, *conf.From, conf.Nonce, ActionCompleted)
 	}
 }
@@ -17,9 +17,13 @@ package publictxmgr
 
 import (
 	"context"
+	"sort"
 	"time"
 
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
 	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/core/internal/components"
+	"github.com/kaleido-io/paladin/core/internal/msgs"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
 )
 
@@ -45,6 +49,7 @@ import (
 func (ptm *pubTxManager) engineLoop() {
 	defer close(ptm.engineLoopDone)
 	ctx := log.WithLogField(ptm.ctx, "role", "engine-loop")
+	ptm.recoverOnStart(ctx)
 	log.L(ctx).Infof("Engine started polling on interval %s", ptm.enginePollingInterval)
 
 	ticker := time.NewTicker(ptm.enginePollingInterval)
@@ -60,30 +65,36 @@ func (ptm *pubTxManager) engineLoop() {
 		}
 
 		ptm.handleUpdates()
+		ptm.checkNodeRestart(ctx)
 		polled, total := ptm.poll(ctx)
 		log.L(ctx).Debugf("Engine polling complete: %d transaction orchestrators were created, there are %d transaction orchestrators in flight", polled, total)
 	}
 }
 
+// getOrchestratorCount includes the signers waiting for a slot in the pool, as they might still start
+// an orchestrator
 func (ptm *pubTxManager) getOrchestratorCount() int {
-	ptm.inFlightOrchestratorMux.Lock()
-	defer ptm.inFlightOrchestratorMux.Unlock()
-	return len(ptm.inFlightOrchestrators)
+	return ptm.orchestrators.Stats().Active + len(ptm.getWaitingSigners())
 }
 
 func (ptm *pubTxManager) getOrchestratorForAddress(signer pldtypes.EthAddress) *orchestrator {
-	ptm.inFlightOrchestratorMux.Lock()
-	defer ptm.inFlightOrchestratorMux.Unlock()
-	return ptm.inFlightOrchestrators[signer]
+	oc, _ := ptm.orchestrators.Get(signer)
+	return oc
 }
 
-func (ptm *pubTxManager) flushStaleOrchestratorsGetCount(ctx context.Context) (inFlightSigningAddresses []pldtypes.EthAddress, stateCounts map[string]int, totalAfterFlush int) {
-	ptm.inFlightOrchestratorMux.Lock()
-	defer ptm.inFlightOrchestratorMux.Unlock()
+func (ptm *pubTxManager) getWaitingSigners() []pldtypes.EthAddress {
+	ptm.waitingSignersMux.Lock()
+	defer ptm.waitingSignersMux.Unlock()
+	waiting := make([]pldtypes.EthAddress, 0, len(ptm.waitingSigners))
+	for signer := range ptm.waitingSigners {
+		waiting = append(waiting, signer)
+	}
+	return waiting
+}
 
-	oldInFlight := ptm.inFlightOrchestrators
-	ptm.inFlightOrchestrators = make(map[pldtypes.EthAddress]*orchestrator)
-	inFlightSigningAddresses = make([]pldtypes.EthAddress, 0, len(oldInFlight))
+func (ptm *pubTxManager) flushStaleOrchestratorsGetCount(ctx context.Context) (inFlightSigningAddresses []pldtypes.EthAddress, stateCounts map[string]int, totalAfterFlush int) {
+	orchestrators := ptm.orchestrators.Workers()
+	inFlightSigningAddresses = make([]pldtypes.EthAddress, 0, len(orchestrators))
 
 	stateCounts = make(map[string]int)
 	for _, sName := range AllOrchestratorStates {
@@ -91,49 +102,45 @@ func (ptm *pubTxManager) flushStaleOrchestratorsGetCount(ctx context.Context) (i
 		stateCounts[sName] = 0
 	}
 
-	// Run through copying across from the old InFlight list to the new one, those that aren't ready to be deleted
-	for signingAddress, oc := range oldInFlight {
+	// Stop those that are ready to be deleted - they are removed from the pool once they have exited
+	for signingAddress, oc := range orchestrators {
 		log.L(ctx).Debugf("Engine checking orchestrator for %s: state: %s, state duration: %s, number of transactions: %d", oc.signingAddress, oc.state, time.Since(oc.stateEntryTime), len(oc.inFlightTxs))
-		if oc.state == OrchestratorStateIdle && time.Since(oc.stateEntryTime) > ptm.orchestratorIdleTimeout ||
+		if ptm.quiesced.Load() ||
+			oc.state == OrchestratorStateIdle && time.Since(oc.stateEntryTime) > ptm.orchestratorIdleTimeout ||
 			oc.state == OrchestratorStateStale && time.Since(oc.stateEntryTime) > ptm.orchestratorStaleTimeout {
 			// tell transaction orchestrator to stop, there is a chance we later found new transaction for this address, but we got to make a call at some point
 			// so it's here. The transaction orchestrator won't be removed immediately as the state update is async
 			oc.Stop()
 		}
 		if oc.state != OrchestratorStateStopped {
-			ptm.inFlightOrchestrators[signingAddress] = oc
 			oc.MarkInFlightTxStale()
 			stateCounts[string(oc.state)] = stateCounts[string(oc.state)] + 1
 			inFlightSigningAddresses = append(inFlightSigningAddresses, signingAddress)
-		} else {
-			log.L(ctx).Infof("Engine removed orchestrator for signing address %s", signingAddress)
 		}
 	}
 
-	totalAfterFlush = len(ptm.inFlightOrchestrators)
-	return inFlightSigningAddresses, stateCounts, totalAfterFlush
+	return inFlightSigningAddresses, stateCounts, len(inFlightSigningAddresses)
 }
 
 func (ptm *pubTxManager) poll(ctx context.Context) (polled int, total int) {
 	pollStart := time.Now()
 
 	// Perform locked processing to determine if there are spaces to fill
-	inFlightSigningAddresses, stateCounts, totalBeforePoll := ptm.flushStaleOrchestratorsGetCount(ctx)
-
-	// check and poll new signers from the persistence if there are more transaction orchestrators slots
-	spaces := ptm.maxInflight - totalBeforePoll
-	if spaces > 0 {
-
-		// Run through the paused orchestrators for fairness control
-		// Note not controlled by mutex, as only modified on this routine.
-		for signingAddress, pausedUntil := range ptm.signingAddressesPausedUntil {
-			if time.Now().Before(pausedUntil) {
-				log.L(ctx).Debugf("Engine excluded orchestrator for signing address %s from polling as it's paused util %s", signingAddress, pausedUntil.String())
-				stateCounts[string(OrchestratorStatePaused)] = stateCounts[string(OrchestratorStatePaused)] + 1
-				inFlightSigningAddresses = append(inFlightSigningAddresses, signingAddress)
-			}
-		}
-
+	inFlightSigningAddresses, stateCounts, total := ptm.flushStaleOrchestratorsGetCount(ctx)
+
+	// Signers waiting for a slot in the pool are paused - when all the slots are full, the pool swaps
+	// out orchestrators that have been running for longer than the swap timeout to make room for them
+	waitingSigners := ptm.getWaitingSigners()
+	stateCounts[string(OrchestratorStatePaused)] = len(waitingSigners)
+
+	// check and poll new signers from the persistence to fill the free slots, and to queue up to one signer
+	// for each slot to be swapped in for fairness
+	spaces := 2*ptm.maxInflight - total - len(waitingSigners)
+	if ptm.quiesced.Load() {
+		// no new orchestrators are started while quiesced, and existing ones were asked to stop above
+		log.L(ctx).Debugf("Engine quiesced with %d orchestrators still draining", total)
+	} else if spaces > 0 {
+		excludedSigners := append(inFlightSigningAddresses, waitingSigners...)
 		var additionalNonInFlightSigners []*txFromOnly
 		// We retry the get from persistence indefinitely (until the context cancels)
 		err := ptm.retry.Do(ctx, func(attempt int) (retry bool, err error) {
@@ -143,73 +150,66 @@ func (ptm *pubTxManager) poll(ctx context.Context) (polled int, total int) {
 				`WHERE c."pub_txn_id" IS NULL AND "suspended" IS FALSE`
 
 			const dbQueryNothingInFlight = dbQueryBase + ` LIMIT ?`
-			if len(inFlightSigningAddresses) == 0 {
+			if len(excludedSigners) == 0 {
 				return true, ptm.p.DB().Raw(dbQueryNothingInFlight, spaces).Scan(&additionalNonInFlightSigners).Error
 			}
 
 			const dbQueryInFlight = dbQueryBase + ` AND t."from" NOT IN (?) LIMIT ?`
-			return true, ptm.p.DB().Raw(dbQueryInFlight, inFlightSigningAddresses, spaces).Scan(&additionalNonInFlightSigners).Error
+			return true, ptm.p.DB().Raw(dbQueryInFlight, excludedSigners, spaces).Scan(&additionalNonInFlightSigners).Error
 		})
 		if err != nil {
 			log.L(ctx).Infof("Engine polling context cancelled while retrying")
-			return -1, totalBeforePoll
+			return -1, total
 		}
 
 		log.L(ctx).Debugf("Engine polled %d items to fill in %d empty slots.", len(additionalNonInFlightSigners), spaces)
 
-		// (Re)obtain the lock to add the additional ones
-		ptm.inFlightOrchestratorMux.Lock()
-		defer ptm.inFlightOrchestratorMux.Unlock()
-
 		for _, r := range additionalNonInFlightSigners {
-			if _, exist := ptm.inFlightOrchestrators[r.From]; !exist {
-				oc := NewOrchestrator(ptm, r.From, ptm.conf)
-				ptm.inFlightOrchestrators[r.From] = oc
-				stateCounts[string(oc.state)] = stateCounts[string(oc.state)] + 1
-				_, _ = oc.Start(ptm.ctx)
-				log.L(ctx).Infof("Engine added orchestrator for signing address %s", r.From)
-			}
-		}
-		total = len(ptm.inFlightOrchestrators)
-		if total > 0 {
-			polled = total - totalBeforePoll
-		}
-	} else {
-
-		// (Re)obtain the lock to do fairness control
-		ptm.inFlightOrchestratorMux.Lock()
-		defer ptm.inFlightOrchestratorMux.Unlock()
-
-		// the in-flight orchestrator pool is full, do the fairness control
-
-		// TODO: don't stop more than required number of slots
-
-		// Run through the existing running orchestrators and stop the ones that exceeded the max process timeout
-		for signingAddress, oc := range ptm.inFlightOrchestrators {
-			if time.Since(oc.orchestratorBirthTime) > ptm.orchestratorSwapTimeout {
-				log.L(ctx).Infof("Engine pause, attempt to stop orchestrator for signing address %s", signingAddress)
-				oc.Stop()
-				ptm.signingAddressesPausedUntil[signingAddress] = time.Now().Add(ptm.orchestratorSwapTimeout)
-			}
+			ptm.queueOrchestrator(ctx, r.From)
 		}
+		polled = len(additionalNonInFlightSigners)
 	}
-	ptm.thMetrics.RecordInFlightOrchestratorPoolMetrics(ctx, stateCounts, ptm.maxInflight-len(ptm.inFlightOrchestrators))
+	ptm.thMetrics.RecordInFlightOrchestratorPoolMetrics(ctx, stateCounts, ptm.maxInflight-total)
 	log.L(ctx).Debugf("Engine poll loop took %s", time.Since(pollStart))
 	return polled, total
 }
 
+// queueOrchestrator starts an orchestrator for the signer as soon as it is given a slot in the pool,
+// without blocking the engine loop while it waits
+func (ptm *pubTxManager) queueOrchestrator(ctx context.Context, signer pldtypes.EthAddress) {
+	ptm.waitingSignersMux.Lock()
+	defer ptm.waitingSignersMux.Unlock()
+	if ptm.waitingSigners[signer] {
+		return
+	}
+	ptm.waitingSigners[signer] = true
+
+	go func() {
+		defer func() {
+			ptm.waitingSignersMux.Lock()
+			delete(ptm.waitingSigners, signer)
+			ptm.waitingSignersMux.Unlock()
+		}()
+		_, err := ptm.orchestrators.GetOrStart(ptm.ctx, signer, func() (*orchestrator, error) {
+			if ptm.quiesced.Load() {
+				return nil, i18n.NewError(ctx, msgs.MsgPublicTxOrchestratorQuiesced, signer)
+			}
+			return NewOrchestrator(ptm, signer, ptm.conf), nil
+		})
+		if err == nil {
+			log.L(ctx).Infof("Engine added orchestrator for signing address %s", signer)
+		}
+	}()
+}
+
 func (ptm *pubTxManager) handleUpdates() {
 	ptm.updateMux.Lock()
 	updates := ptm.updates
 	ptm.updates = nil
 	ptm.updateMux.Unlock()
 
-	ptm.inFlightOrchestratorMux.Lock()
-	defer ptm.inFlightOrchestratorMux.Unlock()
-
 	for _, update := range updates {
-		inFlightOrchestrator, orchestratorInFlight := ptm.inFlightOrchestrators[*update.from]
-		if orchestratorInFlight {
+		if inFlightOrchestrator, orchestratorInFlight := ptm.orchestrators.Get(*update.from); orchestratorInFlight {
 			inFlightOrchestrator.dispatchUpdate(update)
 		}
 	}
@@ -224,3 +224,46 @@ func (ptm *pubTxManager) MarkInFlightOrchestratorsStale() {
 	default:
 	}
 }
+
+// Quiesce stops the engine from starting any new orchestrators, and asks all in-flight orchestrators
+// to stop after their current processing cycle. It returns once they have all exited, at which point no
+// further nonces will be allocated or transactions submitted until Unquiesce is called.
+func (ptm *pubTxManager) Quiesce(ctx context.Context) error {
+	log.L(ctx).Infof("Quiescing public transaction manager")
+	ptm.quiesced.Store(true)
+	ticker := time.NewTicker(ptm.enginePollingInterval)
+	defer ticker.Stop()
+	for {
+		ptm.MarkInFlightOrchestratorsStale()
+		remaining := ptm.getOrchestratorCount()
+		if remaining == 0 {
+			log.L(ctx).Infof("Public transaction manager quiesced")
+			return nil
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return i18n.NewError(ctx, msgs.MsgPublicTxQuiesceTimeout, remaining)
+		}
+	}
+}
+
+func (ptm *pubTxManager) Unquiesce(ctx context.Context) {
+	log.L(ctx).Infof("Resuming public transaction manager")
+	ptm.quiesced.Store(false)
+	ptm.MarkInFlightOrchestratorsStale()
+}
+
+func (ptm *pubTxManager) IsQuiesced() bool {
+	return ptm.quiesced.Load()
+}
+
+func (ptm *pubTxManager) GetOrchestratorSnapshots(ctx context.Context) []*components.PublicTxOrchestratorSnapshot {
+	orchestrators := ptm.orchestrators.Workers()
+	snapshots := make([]*components.PublicTxOrchestratorSnapshot, 0, len(orchestrators))
+	for _, oc := range orchestrators {
+		snapshots = append(snapshots, oc.getSnapshot(ctx))
+	}
+	sort.Slice(snapshots, func(i, j int) bool { return snapshots[i].Signer.String() < snapshots[j].Signer.String() })
+	return snapshots
+}
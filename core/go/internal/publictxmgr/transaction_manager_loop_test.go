@@ -16,6 +16,7 @@
 package publictxmgr
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -25,6 +26,7 @@ import (
 
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNewEnginePollingCancelledContext(t *testing.T) {
@@ -35,6 +37,18 @@ func TestNewEnginePollingCancelledContext(t *testing.T) {
 	assert.Equal(t, -1, polled)
 }
 
+// addTestOrchestrator puts an orchestrator in the pool without running its loop - the test can run
+// the loop directly, or close orchestratorLoopDone for it to be removed from the pool
+func addTestOrchestrator(t *testing.T, ptm *pubTxManager, oc *orchestrator) {
+	if oc.orchestratorLoopDone == nil {
+		oc.orchestratorLoopDone = make(chan struct{})
+	}
+	_, err := ptm.orchestrators.GetOrStart(context.Background(), oc.signingAddress, func() (*orchestrator, error) {
+		return oc, nil
+	})
+	require.NoError(t, err)
+}
+
 func TestNewEnginePollingStoppingAnOrchestratorForFairnessControl(t *testing.T) {
 	testSigningAddr1 := pldtypes.RandAddress()
 	testSigningAddr2 := pldtypes.RandAddress()
@@ -57,37 +71,87 @@ func TestNewEnginePollingStoppingAnOrchestratorForFairnessControl(t *testing.T)
 		InFlightTxsStale:            make(chan bool, 1),
 		stopProcess:                 make(chan bool, 1),
 	}
-	ble.inFlightOrchestrators = map[pldtypes.EthAddress]*orchestrator{
-		*testSigningAddr1: existingOrchestrator, // already has an orchestrator for 0x1
-	}
+	addTestOrchestrator(t, ble, existingOrchestrator)
 
-	// we should stop the first, and swap in the second
+	// signing address 2 queues for the slot, and the first is stopped to swap it in
 	m.db.ExpectQuery("SELECT.*public_txn").WillReturnRows(sqlmock.NewRows([]string{"from", "nonce"}).AddRow(testSigningAddr2, 12345))
 
-	ble.poll(ctx)
-	existingOrchestrator.orchestratorLoopDone = make(chan struct{})
-	existingOrchestrator.orchestratorLoop()
-	<-existingOrchestrator.orchestratorLoopDone
-	assert.Equal(t, OrchestratorStateStopped, existingOrchestrator.state)
+	polled, total := ble.poll(ctx)
+	assert.Equal(t, 1, polled)
+	assert.Equal(t, 1, total)
+	<-existingOrchestrator.stopProcess
+	assert.Equal(t, []pldtypes.EthAddress{*testSigningAddr2}, ble.getWaitingSigners())
+	assert.Equal(t, uint64(1), ble.orchestrators.Stats().Swapped)
+
+	// the waiting signer is not polled again
+	m.db.ExpectQuery("SELECT.*public_txn.*NOT IN").WillReturnRows(sqlmock.NewRows([]string{"from"}))
+	polled, _ = ble.poll(ctx)
+	assert.Equal(t, 0, polled)
 }
 
-func TestNewEnginePollingExcludePausedOrchestrator(t *testing.T) {
+func TestNewEnginePollingNoOrchestratorsWhileQuiesced(t *testing.T) {
+	ctx, ble, _, done := newTestPublicTxManager(t, false, func(mocks *mocksAndTestControl, conf *pldconf.PublicTxManagerConfig) {
+		mocks.disableManagerStart = true
+	})
+	defer done()
 
-	testSigningAddr1 := *pldtypes.RandAddress()
+	// a signer given a slot once the manager is quiesced does not start an orchestrator
+	ble.quiesced.Store(true)
+	ble.queueOrchestrator(ctx, *pldtypes.RandAddress())
+	require.Eventually(t, func() bool { return ble.getOrchestratorCount() == 0 }, 5*time.Second, 1*time.Millisecond)
+	assert.Empty(t, ble.orchestrators.Workers())
+}
 
-	ctx, ble, m, done := newTestPublicTxManager(t, false, func(mocks *mocksAndTestControl, conf *pldconf.PublicTxManagerConfig) {
-		mocks.disableManagerStart = true                         // we don't want the manager running... yet
-		conf.Manager.MaxInFlightOrchestrators = confutil.P(1)    // we only have one slot
-		conf.Manager.OrchestratorSwapTimeout = confutil.P("1ms") // we're very aggressive on swapping out
+func TestQuiesceDrainsOrchestrators(t *testing.T) {
+	ctx, ble, _, done := newTestPublicTxManager(t, false, func(mocks *mocksAndTestControl, conf *pldconf.PublicTxManagerConfig) {
+		mocks.disableManagerStart = true // we drive the engine polling directly
+		conf.Manager.Interval = confutil.P("50ms")
 	})
 	defer done()
 
-	m.db.ExpectQuery("SELECT.*public_txn").WillReturnRows(sqlmock.NewRows([]string{"from"}))
+	existingOrchestrator := &orchestrator{
+		signingAddress:        *pldtypes.RandAddress(),
+		orchestratorBirthTime: time.Now(),
+		pubTxManager:          ble,
+		state:                 OrchestratorStateRunning,
+		stateEntryTime:        time.Now(),
+		InFlightTxsStale:      make(chan bool, 1),
+		stopProcess:           make(chan bool, 1),
+	}
+	addTestOrchestrator(t, ble, existingOrchestrator)
+
+	quiesceErr := make(chan error)
+	go func() {
+		quiesceErr <- ble.Quiesce(ctx)
+	}()
+	require.Eventually(t, ble.IsQuiesced, 5*time.Second, 1*time.Millisecond)
+
+	// polling asks the orchestrator to stop, and does not look for new signers (no DB query)
+	_, total := ble.poll(ctx)
+	assert.Equal(t, 1, total)
+	<-existingOrchestrator.stopProcess
+
+	// once it has exited it is removed, and the quiesce completes
+	existingOrchestrator.state = OrchestratorStateStopped
+	close(existingOrchestrator.orchestratorLoopDone)
+	require.NoError(t, <-quiesceErr)
+	_, total = ble.poll(ctx)
+	assert.Equal(t, 0, total)
+
+	ble.Unquiesce(ctx)
+	assert.False(t, ble.IsQuiesced())
+}
 
-	// already has a running orchestrator for the address so no new orchestrator should be started
-	ble.inFlightOrchestrators = map[pldtypes.EthAddress]*orchestrator{}
-	ble.signingAddressesPausedUntil = map[pldtypes.EthAddress]time.Time{testSigningAddr1: time.Now().Add(1 * time.Hour)}
+func TestQuiesceTimeout(t *testing.T) {
+	ctx, ble, _, done := newTestPublicTxManager(t, false, func(mocks *mocksAndTestControl, conf *pldconf.PublicTxManagerConfig) {
+		mocks.disableManagerStart = true // nothing will drain the orchestrator
+	})
+	defer done()
 
-	ble.poll(ctx)
+	addTestOrchestrator(t, ble, &orchestrator{signingAddress: *pldtypes.RandAddress(), state: OrchestratorStateRunning})
 
+	cancelledCtx, cancelCtx := context.WithCancel(ctx)
+	cancelCtx()
+	err := ble.Quiesce(cancelledCtx)
+	assert.Regexp(t, "PD011959", err)
 }
@@ -77,6 +77,7 @@ func baseMocks(t *testing.T) *mocksAndTestControl {
 	mocks.allComponents.On("EthClientFactory").Return(mocks.ethClientFactory).Maybe()
 	mocks.ethClientFactory.On("SharedWS").Return(mocks.ethClient).Maybe()
 	mocks.ethClientFactory.On("HTTPClient").Return(mocks.ethClient).Maybe()
+	mocks.ethClient.On("ChainProfile").Return(ethclient.DefaultChainProfile()).Maybe()
 	mocks.allComponents.On("BlockIndexer").Return(mocks.blockIndexer).Maybe()
 	mocks.allComponents.On("TxManager").Return(mocks.txManager).Maybe()
 	return mocks
@@ -89,6 +90,9 @@ func newTestPublicTxManager(t *testing.T, realDBAndSigner bool, extraSetup ...fu
 		Manager: pldconf.PublicTxManagerManagerConfig{
 			Interval:                 confutil.P("1h"),
 			MaxInFlightOrchestrators: confutil.P(1),
+			Recovery: pldconf.PublicTxManagerRecoveryConfig{
+				Enabled: confutil.P(false),
+			},
 			SubmissionWriter: pldconf.FlushWriterConfig{
 				WorkerCount: confutil.P(1),
 			},
@@ -202,6 +206,83 @@ func TestNewEngineErrors(t *testing.T) {
 	assert.Regexp(t, "lookup failed", err)
 }
 
+func TestNewEngineBadGasPriceSchedule(t *testing.T) {
+	mocks := baseMocks(t)
+	pmgr := NewPublicTransactionManager(context.Background(), &pldconf.PublicTxManagerConfig{
+		GasPrice: pldconf.GasPriceConfig{
+			Schedules: []*pldconf.GasPriceScheduleConfig{{Days: []string{"someday"}}},
+		},
+	})
+	err := pmgr.PostInit(mocks.allComponents)
+	assert.Regexp(t, "PD011942", err)
+}
+
+func TestGasPriceSchedulesGetSet(t *testing.T) {
+	ctx, ptm, _, done := newTestPublicTxManager(t, false, func(mocks *mocksAndTestControl, conf *pldconf.PublicTxManagerConfig) {
+		conf.GasPrice.Schedules = []*pldconf.GasPriceScheduleConfig{{Name: "configured"}}
+	})
+	defer done()
+
+	assert.Equal(t, "configured", ptm.GetGasPriceSchedules(ctx)[0].Name)
+	err := ptm.SetGasPriceSchedules(ctx, []*pldconf.GasPriceScheduleConfig{{Name: "updated"}})
+	require.NoError(t, err)
+	assert.Equal(t, "updated", ptm.GetGasPriceSchedules(ctx)[0].Name)
+}
+
+func TestReloadConfig(t *testing.T) {
+	ctx, ptm, _, done := newTestPublicTxManager(t, false, func(mocks *mocksAndTestControl, conf *pldconf.PublicTxManagerConfig) {
+		conf.GasPrice.Schedules = []*pldconf.GasPriceScheduleConfig{{Name: "configured"}}
+	})
+	defer done()
+	bm := ptm.balanceManager.(*BalanceManagerWithInMemoryTracking)
+
+	err := ptm.ReloadConfig(ctx, &pldconf.PublicTxManagerConfig{
+		GasPrice: pldconf.GasPriceConfig{
+			IncreasePercentage: confutil.P(25),
+			IncreaseMax:        confutil.P("1000"),
+			Schedules:          []*pldconf.GasPriceScheduleConfig{{Name: "reloaded"}},
+		},
+		BalanceManager: pldconf.BalanceManagerConfig{
+			AutoFueling: pldconf.AutoFuelingConfig{
+				MinDestBalance: confutil.P("10"),
+				MaxDestBalance: confutil.P("100"),
+				MinThreshold:   confutil.P("5"),
+			},
+		},
+	})
+	require.NoError(t, err)
+	increasePercent, increaseMax := ptm.getGasPriceIncrease()
+	assert.Equal(t, 25, increasePercent)
+	assert.Equal(t, int64(1000), increaseMax.Int64())
+	assert.Equal(t, "reloaded", ptm.GetGasPriceSchedules(ctx)[0].Name)
+	assert.Equal(t, int64(10), bm.minDestBalance.Int64())
+	assert.Equal(t, int64(100), bm.maxDestBalance.Int64())
+	assert.Equal(t, int64(5), bm.minThreshold.Int64())
+
+	// invalid thresholds are rejected before anything changes
+	err = ptm.ReloadConfig(ctx, &pldconf.PublicTxManagerConfig{
+		GasPrice: pldconf.GasPriceConfig{IncreasePercentage: confutil.P(50)},
+		BalanceManager: pldconf.BalanceManagerConfig{
+			AutoFueling: pldconf.AutoFuelingConfig{MinDestBalance: confutil.P("100"), MaxDestBalance: confutil.P("10")},
+		},
+	})
+	assert.Regexp(t, "PD011903", err)
+	increasePercent, _ = ptm.getGasPriceIncrease()
+	assert.Equal(t, 25, increasePercent)
+	assert.Equal(t, "reloaded", ptm.GetGasPriceSchedules(ctx)[0].Name)
+
+	err = ptm.ReloadConfig(ctx, &pldconf.PublicTxManagerConfig{
+		GasPrice: pldconf.GasPriceConfig{
+			IncreasePercentage: confutil.P(50),
+			Schedules:          []*pldconf.GasPriceScheduleConfig{{Days: []string{"someday"}}},
+		},
+	})
+	assert.Regexp(t, "PD011942", err)
+	increasePercent, _ = ptm.getGasPriceIncrease()
+	assert.Equal(t, 25, increasePercent)
+	assert.Equal(t, int64(10), bm.minDestBalance.Int64())
+}
+
 func TestInit(t *testing.T) {
 	_, _, _, done := newTestPublicTxManager(t, false)
 	defer done()
@@ -358,6 +439,13 @@ func TestTransactionLifecycleRealKeyMgrAndDB(t *testing.T) {
 			require.NotNil(t, ptxQuery)
 			require.Len(t, ptxQuery.Submissions, 1)
 			require.Equal(t, ptxQuery.Nonce.Uint64(), confirmation.Nonce)
+
+			// The raw transaction is stored, as it was submitted
+			rawSub, err := ptm.GetRawSubmission(ctx, ptm.p.NOTX(), confirmation.Hash)
+			require.NoError(t, err)
+			assert.Equal(t, confirmation.Hash, *calculateTransactionHash(rawSub.RawTransaction))
+			assert.Equal(t, *resolvedKey, rawSub.From)
+			assert.Equal(t, confirmation.Nonce, rawSub.Nonce.Uint64())
 		case <-ticker.C:
 			if t.Failed() {
 				return
@@ -365,6 +453,13 @@ func TestTransactionLifecycleRealKeyMgrAndDB(t *testing.T) {
 		}
 	}
 
+	// Every nonce is now allocated, so the next nonce for the signer is known
+	signerNonces, err := ptm.GetSignerNonces(ctx, ptm.p.NOTX())
+	require.NoError(t, err)
+	assert.Equal(t, []*pldapi.SignerNonce{
+		{Signer: *resolvedKey, NextNonce: pldtypes.HexUint64(baseNonce + transactionCount)},
+	}, signerNonces)
+
 	// Simulate detection of the receipt by the blockexplorer - phase 1 in the DB Transaction
 	var allMatches []*components.PublicTxMatch
 	confirmationsMatched := make(map[uuid.UUID]*components.PublicTxMatch)
@@ -405,6 +500,23 @@ func TestTransactionLifecycleRealKeyMgrAndDB(t *testing.T) {
 	}
 	ticker.Stop()
 
+	// Check the latency timeline was recorded through every stage
+	timelines, err := ptm.GetTransactionTimelines(ctx, ptm.p.NOTX(), txIDs[0])
+	require.NoError(t, err)
+	require.Len(t, timelines, 1)
+	assert.Equal(t, *singleTx.LocalID, timelines[0].LocalID)
+	assert.Equal(t, txIDs[0], timelines[0].Binding.Transaction)
+	recordedStages := make([]string, len(timelines[0].Stages))
+	for i, s := range timelines[0].Stages {
+		recordedStages[i] = string(s.Stage)
+	}
+	assert.ElementsMatch(t, pldapi.PublicTxStage("").Options(), recordedStages)
+	stats := ptm.GetLatencyStats(ctx)
+	require.Len(t, stats, 5)
+	for _, s := range stats {
+		assert.Equal(t, len(txs), s.SinceStart.Count)
+	}
+
 }
 
 func fakeTxManagerInsert(t *testing.T, db *gorm.DB, txID uuid.UUID, fromStr string) {
@@ -773,3 +885,408 @@ func TestGasEstimateFactor(t *testing.T) {
 	require.NoError(t, ptm.ValidateTransaction(ctx, ptm.p.NOTX(), tx))
 	assert.Equal(t, pldtypes.MustParseHexUint64("0xc5f0"), *tx.Gas)
 }
+
+func TestGasEstimatePaddingContractOverride(t *testing.T) {
+	ctx := context.Background()
+	divergentContract := pldtypes.RandAddress()
+	_, ptm, m, done := newTestPublicTxManager(t, false, func(mocks *mocksAndTestControl, conf *pldconf.PublicTxManagerConfig) {
+		conf.GasLimit.Padding = pldconf.GasEstimatePaddingConfig{
+			Percent: confutil.P(10.0),
+			Contracts: map[string]*pldconf.GasEstimatePaddingOverride{
+				divergentContract.String(): {Minimum: confutil.P(uint64(100000))},
+			},
+		}
+	})
+	defer done()
+
+	m.ethClient.On("EstimateGasNoResolve", mock.Anything, mock.Anything, mock.Anything).
+		Return(ethclient.EstimateGasResult{GasLimit: pldtypes.HexUint64(50000)}, nil)
+
+	tx := &components.PublicTxSubmission{
+		PublicTxInput: pldapi.PublicTxInput{
+			From: pldtypes.RandAddress(),
+			To:   pldtypes.RandAddress(),
+		},
+	}
+	require.NoError(t, ptm.ValidateTransaction(ctx, ptm.p.NOTX(), tx))
+	assert.Equal(t, pldtypes.HexUint64(55000), *tx.Gas)
+
+	tx = &components.PublicTxSubmission{
+		PublicTxInput: pldapi.PublicTxInput{
+			From: pldtypes.RandAddress(),
+			To:   divergentContract,
+		},
+	}
+	require.NoError(t, ptm.ValidateTransaction(ctx, ptm.p.NOTX(), tx))
+	assert.Equal(t, pldtypes.HexUint64(150000), *tx.Gas)
+}
+
+func TestGasEstimatePaddingBadConfig(t *testing.T) {
+	mocks := baseMocks(t)
+	mocks.allComponents.On("KeyManager").Return(mocks.keyManager).Maybe()
+	mocks.allComponents.On("Persistence").Return(mocks.db).Maybe()
+	pmgr := NewPublicTransactionManager(context.Background(), &pldconf.PublicTxManagerConfig{
+		GasLimit: pldconf.GasLimitConfig{
+			Padding: pldconf.GasEstimatePaddingConfig{
+				Contracts: map[string]*pldconf.GasEstimatePaddingOverride{"wrong": {}},
+			},
+		},
+	})
+	err := pmgr.PostInit(mocks.allComponents)
+	assert.Regexp(t, "PD011518", err)
+}
+
+func TestGetSignerNoncesFail(t *testing.T) {
+	ctx, ptm, m, done := newTestPublicTxManager(t, false)
+	defer done()
+
+	m.db.ExpectQuery("SELECT.*MAX").WillReturnError(fmt.Errorf("pop"))
+
+	_, err := ptm.GetSignerNonces(ctx, ptm.p.NOTX())
+	assert.Regexp(t, "pop", err)
+}
+
+func TestGetRawSubmissionNotFound(t *testing.T) {
+	ctx, ptm, _, done := newTestPublicTxManager(t, true)
+	defer done()
+
+	rawSub, err := ptm.GetRawSubmission(ctx, ptm.p.NOTX(), pldtypes.RandBytes32())
+	require.NoError(t, err)
+	assert.Nil(t, rawSub)
+}
+
+func TestGetRawSubmissionFail(t *testing.T) {
+	ctx, ptm, m, done := newTestPublicTxManager(t, false)
+	defer done()
+
+	m.db.ExpectQuery("SELECT.*public_submissions").WillReturnError(fmt.Errorf("pop"))
+	_, err := ptm.GetRawSubmission(ctx, ptm.p.NOTX(), pldtypes.RandBytes32())
+	assert.Regexp(t, "pop", err)
+
+	m.db.ExpectQuery("SELECT.*public_submissions").WillReturnRows(sqlmock.NewRows([]string{"pub_txn_id"}).AddRow(12345))
+	m.db.ExpectQuery("SELECT.*public_txns").WillReturnError(fmt.Errorf("pop"))
+	_, err = ptm.GetRawSubmission(ctx, ptm.p.NOTX(), pldtypes.RandBytes32())
+	assert.Regexp(t, "pop", err)
+}
+
+func TestTransactionExpiryRealDB(t *testing.T) {
+	ctx, ptm, m, done := newTestPublicTxManager(t, true, func(mocks *mocksAndTestControl, conf *pldconf.PublicTxManagerConfig) {
+		conf.Manager.Interval = confutil.P("50ms")
+		conf.Orchestrator.Interval = confutil.P("50ms")
+		conf.Manager.OrchestratorIdleTimeout = confutil.P("1ms")
+		conf.GasPrice.FixedGasPrice = nil
+	})
+	defer done()
+
+	keyMapping, err := m.keyManager.ResolveKeyNewDatabaseTX(ctx, "signer1", algorithms.ECDSA_SECP256K1, verifiers.ETH_ADDRESS)
+	require.NoError(t, err)
+	resolvedKey := pldtypes.MustEthAddress(keyMapping.Verifier.Verifier)
+
+	chainID, _ := rand.Int(rand.Reader, big.NewInt(100000000000000))
+	m.ethClient.On("ChainID").Return(chainID.Int64())
+	m.ethClient.On("GasPrice", mock.Anything).Return(pldtypes.MustParseHexUint256("1000000000"), nil)
+	m.ethClient.On("GetTransactionCount", mock.Anything, mock.Anything).Return(confutil.P(pldtypes.HexUint64(1122334455)), nil)
+
+	// The original transaction sits in the pool, until the no-op replacement is mined
+	confirmations := make(chan *blockindexer.IndexedTransactionNotify, 1)
+	srtx := m.ethClient.On("SendRawTransaction", mock.Anything, mock.Anything)
+	srtx.Run(func(args mock.Arguments) {
+		signedMessage := args[1].(pldtypes.HexBytes)
+		_, ethTx, err := ethsigner.RecoverRawTransaction(ctx, ethtypes.HexBytes0xPrefix(signedMessage), m.ethClient.ChainID())
+		require.NoError(t, err)
+		txHash := calculateTransactionHash(signedMessage)
+		if (*pldtypes.EthAddress)(ethTx.To).Equals(resolvedKey) {
+			assert.Empty(t, ethTx.Data)
+			assert.Equal(t, int64(cancelGasLimit), ethTx.GasLimit.Int64())
+			select {
+			case confirmations <- &blockindexer.IndexedTransactionNotify{
+				IndexedTransaction: pldapi.IndexedTransaction{
+					Hash:        *txHash,
+					BlockNumber: 11223344,
+					From:        resolvedKey,
+					To:          resolvedKey,
+					Nonce:       ethTx.Nonce.Uint64(),
+					Result:      pldapi.TXResult_SUCCESS.Enum(),
+				},
+			}:
+			default:
+			}
+		}
+		srtx.Return(txHash, nil)
+	})
+
+	txID := uuid.New()
+	pubTx, err := ptm.SingleTransactionSubmit(ctx, &components.PublicTxSubmission{
+		Bindings: []*components.PaladinTXReference{
+			{TransactionID: txID, TransactionType: pldapi.TransactionTypePublic.Enum()},
+		},
+		PublicTxInput: pldapi.PublicTxInput{
+			From: resolvedKey,
+			To:   pldtypes.RandAddress(),
+			Data: pldtypes.RandBytes(32),
+			PublicTxOptions: pldapi.PublicTxOptions{
+				Gas:    confutil.P(pldtypes.HexUint64(1223451)),
+				Expiry: confutil.P(pldtypes.Timestamp(time.Now().Add(1 * time.Second).UnixNano())),
+			},
+		},
+	})
+	require.NoError(t, err)
+	assert.NotNil(t, pubTx.Expiry)
+
+	var match []*components.PublicTxMatch
+	select {
+	case confirmation := <-confirmations:
+		match, err = ptm.MatchUpdateConfirmedTransactions(ctx, ptm.p.NOTX(), []*blockindexer.IndexedTransactionNotify{confirmation})
+		require.NoError(t, err)
+		ptm.NotifyConfirmPersisted(ctx, match)
+	case <-time.After(10 * time.Second):
+		require.Fail(t, "timed out waiting for the replacement transaction")
+	}
+	require.Len(t, match, 1)
+	assert.True(t, match[0].Expired)
+	assert.Equal(t, txID, match[0].TransactionID)
+
+	txs, err := ptm.QueryPublicTxWithBindings(ctx, ptm.p.NOTX(), query.NewQueryBuilder().Equal("status", "expired").Query())
+	require.NoError(t, err)
+	require.Len(t, txs, 1)
+	assert.True(t, txs[0].Expired)
+	require.Len(t, txs[0].Submissions, 2)
+	assert.True(t, txs[0].Submissions[0].Cancel)
+	assert.False(t, txs[0].Submissions[1].Cancel)
+	// the replacement is priced above the original, even though the market price has not changed
+	assert.Equal(t, "1100000000", txs[0].Submissions[0].GasPrice.Int().String())
+	assert.Equal(t, "1000000000", txs[0].Submissions[1].GasPrice.Int().String())
+}
+
+func TestCancelTransactionRealDB(t *testing.T) {
+	ctx, ptm, m, done := newTestPublicTxManager(t, true, func(mocks *mocksAndTestControl, conf *pldconf.PublicTxManagerConfig) {
+		conf.Manager.Interval = confutil.P("50ms")
+		conf.Orchestrator.Interval = confutil.P("50ms")
+		conf.Manager.OrchestratorIdleTimeout = confutil.P("1ms")
+		conf.GasPrice.FixedGasPrice = nil
+	})
+	defer done()
+
+	keyMapping, err := m.keyManager.ResolveKeyNewDatabaseTX(ctx, "signer1", algorithms.ECDSA_SECP256K1, verifiers.ETH_ADDRESS)
+	require.NoError(t, err)
+	resolvedKey := pldtypes.MustEthAddress(keyMapping.Verifier.Verifier)
+
+	chainID, _ := rand.Int(rand.Reader, big.NewInt(100000000000000))
+	m.ethClient.On("ChainID").Return(chainID.Int64())
+	m.ethClient.On("GasPrice", mock.Anything).Return(pldtypes.MustParseHexUint256("1000000000"), nil)
+	m.ethClient.On("GetTransactionCount", mock.Anything, mock.Anything).Return(confutil.P(pldtypes.HexUint64(1122334455)), nil)
+
+	// The original transaction sits in the pool, until it is cancelled and the no-op replacement is mined
+	submitted := make(chan uint64, 1)
+	confirmations := make(chan *blockindexer.IndexedTransactionNotify, 1)
+	srtx := m.ethClient.On("SendRawTransaction", mock.Anything, mock.Anything)
+	srtx.Run(func(args mock.Arguments) {
+		signedMessage := args[1].(pldtypes.HexBytes)
+		_, ethTx, err := ethsigner.RecoverRawTransaction(ctx, ethtypes.HexBytes0xPrefix(signedMessage), m.ethClient.ChainID())
+		require.NoError(t, err)
+		txHash := calculateTransactionHash(signedMessage)
+		if (*pldtypes.EthAddress)(ethTx.To).Equals(resolvedKey) {
+			assert.Empty(t, ethTx.Data)
+			select {
+			case confirmations <- &blockindexer.IndexedTransactionNotify{
+				IndexedTransaction: pldapi.IndexedTransaction{
+					Hash:        *txHash,
+					BlockNumber: 11223344,
+					From:        resolvedKey,
+					To:          resolvedKey,
+					Nonce:       ethTx.Nonce.Uint64(),
+					Result:      pldapi.TXResult_SUCCESS.Enum(),
+				},
+			}:
+			default:
+			}
+		} else {
+			select {
+			case submitted <- ethTx.Nonce.Uint64():
+			default:
+			}
+		}
+		srtx.Return(txHash, nil)
+	})
+
+	txID := uuid.New()
+	_, err = ptm.SingleTransactionSubmit(ctx, &components.PublicTxSubmission{
+		Bindings: []*components.PaladinTXReference{
+			{TransactionID: txID, TransactionType: pldapi.TransactionTypePublic.Enum()},
+		},
+		PublicTxInput: pldapi.PublicTxInput{
+			From: resolvedKey,
+			To:   pldtypes.RandAddress(),
+			Data: pldtypes.RandBytes(32),
+			PublicTxOptions: pldapi.PublicTxOptions{
+				Gas: confutil.P(pldtypes.HexUint64(1223451)),
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	var nonce uint64
+	select {
+	case nonce = <-submitted:
+	case <-time.After(10 * time.Second):
+		require.Fail(t, "timed out waiting for the original transaction")
+	}
+
+	err = ptm.CancelTransaction(ctx, *resolvedKey, nonce+1)
+	assert.Regexp(t, "PD011990", err)
+
+	err = ptm.CancelTransaction(ctx, *resolvedKey, nonce)
+	require.NoError(t, err)
+
+	var match []*components.PublicTxMatch
+	select {
+	case confirmation := <-confirmations:
+		match, err = ptm.MatchUpdateConfirmedTransactions(ctx, ptm.p.NOTX(), []*blockindexer.IndexedTransactionNotify{confirmation})
+		require.NoError(t, err)
+		ptm.NotifyConfirmPersisted(ctx, match)
+	case <-time.After(10 * time.Second):
+		require.Fail(t, "timed out waiting for the replacement transaction")
+	}
+	require.Len(t, match, 1)
+	assert.True(t, match[0].Expired)
+	assert.Equal(t, txID, match[0].TransactionID)
+
+	err = ptm.CancelTransaction(ctx, *resolvedKey, nonce)
+	assert.Regexp(t, "PD011991", err)
+}
+
+func TestCancelTransactionNotInFlight(t *testing.T) {
+	ctx, ptm, m, done := newTestPublicTxManager(t, false, func(mocks *mocksAndTestControl, conf *pldconf.PublicTxManagerConfig) {
+		mocks.disableManagerStart = true
+	})
+	defer done()
+
+	from := *pldtypes.RandAddress()
+	m.db.ExpectQuery("SELECT.*public_txns").WillReturnRows(sqlmock.NewRows([]string{"pub_txn_id"}).AddRow(12345))
+	m.db.ExpectQuery("SELECT.*public_txn_blobs").WillReturnRows(sqlmock.NewRows([]string{}))
+	m.db.ExpectExec("UPDATE.*public_txns.*expiry").WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := ptm.CancelTransaction(ctx, from, 10)
+	require.NoError(t, err)
+	require.NoError(t, m.db.ExpectationsWereMet())
+}
+
+func TestCancelTransactionErrors(t *testing.T) {
+	ctx, ptm, m, done := newTestPublicTxManager(t, false, func(mocks *mocksAndTestControl, conf *pldconf.PublicTxManagerConfig) {
+		mocks.disableManagerStart = true
+	})
+	defer done()
+
+	from := *pldtypes.RandAddress()
+	m.db.ExpectQuery("SELECT.*public_txns").WillReturnError(fmt.Errorf("pop"))
+	err := ptm.CancelTransaction(ctx, from, 10)
+	assert.Regexp(t, "pop", err)
+
+	m.db.ExpectQuery("SELECT.*public_txns").WillReturnRows(sqlmock.NewRows([]string{"pub_txn_id"}).AddRow(12345))
+	m.db.ExpectQuery("SELECT.*public_txn_blobs").WillReturnError(fmt.Errorf("pop"))
+	err = ptm.CancelTransaction(ctx, from, 10)
+	assert.Regexp(t, "pop", err)
+
+	m.db.ExpectQuery("SELECT.*public_txns").WillReturnRows(sqlmock.NewRows([]string{"pub_txn_id"}).AddRow(12345))
+	m.db.ExpectQuery("SELECT.*public_txn_blobs").WillReturnRows(sqlmock.NewRows([]string{"pub_txn_id", "idx"}).AddRow(12345, 0))
+	err = ptm.CancelTransaction(ctx, from, 10)
+	assert.Regexp(t, "PD011969", err)
+}
+
+func TestValidateTransactionExpiry(t *testing.T) {
+	ctx, ptm, _, done := newTestPublicTxManager(t, false)
+	defer done()
+
+	err := ptm.ValidateTransaction(ctx, ptm.p.NOTX(), &components.PublicTxSubmission{
+		PublicTxInput: pldapi.PublicTxInput{
+			From: pldtypes.RandAddress(),
+			PublicTxOptions: pldapi.PublicTxOptions{
+				Expiry: confutil.P(pldtypes.TimestampNow()),
+				Blobs:  []pldtypes.HexBytes{pldtypes.RandBytes(32)},
+			},
+		},
+	})
+	assert.Regexp(t, "PD011969", err)
+
+	err = ptm.ValidateTransaction(ctx, ptm.p.NOTX(), &components.PublicTxSubmission{
+		PublicTxInput: pldapi.PublicTxInput{
+			From: pldtypes.RandAddress(),
+			PublicTxOptions: pldapi.PublicTxOptions{
+				Expiry: confutil.P(pldtypes.Timestamp(time.Now().Add(-1 * time.Second).UnixNano())),
+			},
+		},
+	})
+	assert.Regexp(t, "PD011970", err)
+}
+
+func TestTransactionMetadataRealDB(t *testing.T) {
+	ctx, ptm, _, done := newTestPublicTxManager(t, true, func(mocks *mocksAndTestControl, conf *pldconf.PublicTxManagerConfig) {
+		mocks.disableManagerStart = true
+	})
+	defer done()
+
+	writeTX := func(metadata string) uuid.UUID {
+		txi := &components.PublicTxSubmission{
+			PublicTxInput: pldapi.PublicTxInput{
+				From: pldtypes.RandAddress(),
+				PublicTxOptions: pldapi.PublicTxOptions{
+					Gas:      confutil.P(pldtypes.HexUint64(100000)),
+					Metadata: pldtypes.RawJSON(metadata),
+				},
+			},
+		}
+		require.NoError(t, validateMetadata(ctx, txi))
+		txID := uuid.New()
+		txi.Bindings = []*components.PaladinTXReference{
+			{TransactionID: txID, TransactionType: pldapi.TransactionTypePublic.Enum()},
+		}
+		err := ptm.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
+			_, err := ptm.WriteNewTransactions(ctx, dbTX, []*components.PublicTxSubmission{txi})
+			return err
+		})
+		require.NoError(t, err)
+		return txID
+	}
+	tx1 := writeTX(`{ "order": { "id": "order-001", "lines": [1, 2] } }`)
+	writeTX(`{"order":{"id":"order-002"}}`)
+
+	txs, err := ptm.QueryPublicTxWithBindings(ctx, ptm.p.NOTX(),
+		query.NewQueryBuilder().JSONEqual("metadata", "order.id", "order-001").Limit(10).Query())
+	require.NoError(t, err)
+	require.Len(t, txs, 1)
+	assert.Equal(t, tx1, txs[0].Transaction)
+	assert.JSONEq(t, `{"order":{"id":"order-001","lines":[1,2]}}`, txs[0].Metadata.String())
+
+	txs, err = ptm.QueryPublicTxWithBindings(ctx, ptm.p.NOTX(),
+		query.NewQueryBuilder().JSONEqual("metadata", "order.lines.1", 2).Limit(10).Query())
+	require.NoError(t, err)
+	require.Len(t, txs, 1)
+	assert.Equal(t, tx1, txs[0].Transaction)
+}
+
+func TestValidateTransactionMetadata(t *testing.T) {
+	ctx, ptm, _, done := newTestPublicTxManager(t, false)
+	defer done()
+
+	for _, metadata := range []string{`"order-001"`, `[1,2]`, `null`, `{"bad":`} {
+		err := ptm.ValidateTransaction(ctx, ptm.p.NOTX(), &components.PublicTxSubmission{
+			PublicTxInput: pldapi.PublicTxInput{
+				From: pldtypes.RandAddress(),
+				PublicTxOptions: pldapi.PublicTxOptions{
+					Metadata: pldtypes.RawJSON(metadata),
+				},
+			},
+		})
+		assert.Regexp(t, "PD011986", err)
+	}
+
+	txi := &components.PublicTxSubmission{
+		PublicTxInput: pldapi.PublicTxInput{
+			PublicTxOptions: pldapi.PublicTxOptions{
+				Metadata: pldtypes.RawJSON(`{ "a" : 1 }`),
+			},
+		},
+	}
+	require.NoError(t, validateMetadata(ctx, txi))
+	assert.Equal(t, `{"a":1}`, txi.Metadata.String())
+}
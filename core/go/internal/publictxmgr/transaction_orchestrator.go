@@ -23,11 +23,13 @@ import (
 
 	"github.com/kaleido-io/paladin/config/pkg/confutil"
 	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/internal/components"
 	"github.com/kaleido-io/paladin/core/pkg/blockindexer"
 	"github.com/kaleido-io/paladin/core/pkg/persistence"
 
 	"github.com/kaleido-io/paladin/common/go/pkg/log"
 	"github.com/kaleido-io/paladin/core/pkg/ethclient"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/retry"
 )
@@ -109,7 +111,9 @@ type orchestrator struct {
 	resubmitInterval        time.Duration
 	stageRetryTimeout       time.Duration
 	persistenceRetryTimeout time.Duration
+	stageTimeouts           map[InFlightTxStage]time.Duration
 	ethClient               ethclient.EthClient
+	submissionBackend       SubmissionBackend
 	bIndexer                blockindexer.BlockIndexer
 
 	transactionSubmissionRetry *retry.Retry
@@ -125,6 +129,8 @@ type orchestrator struct {
 
 	// in flight txs array
 	maxInFlightTxs       int
+	maxOverflowTxs       int                                   // depth of the DB queue behind the in-flight txs, beyond which it is reported as full
+	overflowDepth        int                                   // transactions waiting in the DB (without a nonce) for an in-flight slot, as of the last poll
 	inFlightTxs          []*inFlightTransactionStageController // a queue of all the in flight transactions
 	inFlightTxsMux       sync.Mutex
 	orchestratorLoopDone chan struct{}
@@ -153,6 +159,17 @@ type orchestrator struct {
 
 const veryShortMinimum = 50 * time.Millisecond
 
+// newStageTimeouts returns the time each stage can run without producing a result. Stages without
+// an entry are not timed out.
+func newStageTimeouts(conf *pldconf.StageTimeoutsConfig) map[InFlightTxStage]time.Duration {
+	defaults := &pldconf.PublicTxManagerDefaults.Orchestrator.StageTimeouts
+	return map[InFlightTxStage]time.Duration{
+		InFlightTxStageSigning:      confutil.DurationMin(conf.Sign, veryShortMinimum, *defaults.Sign),
+		InFlightTxStageSubmitting:   confutil.DurationMin(conf.Submit, veryShortMinimum, *defaults.Submit),
+		InFlightTxStageStatusUpdate: confutil.DurationMin(conf.Confirm, veryShortMinimum, *defaults.Confirm),
+	}
+}
+
 func NewOrchestrator(
 	ptm *pubTxManager,
 	signingAddress pldtypes.EthAddress,
@@ -165,6 +182,7 @@ func NewOrchestrator(
 		orchestratorBirthTime:       time.Now(),
 		orchestratorPollingInterval: confutil.DurationMin(conf.Orchestrator.Interval, veryShortMinimum, *pldconf.PublicTxManagerDefaults.Orchestrator.Interval),
 		maxInFlightTxs:              confutil.IntMin(conf.Orchestrator.MaxInFlight, 1, *pldconf.PublicTxManagerDefaults.Orchestrator.MaxInFlight),
+		maxOverflowTxs:              confutil.IntMin(conf.Orchestrator.MaxOverflow, 0, *pldconf.PublicTxManagerDefaults.Orchestrator.MaxOverflow),
 		signingAddress:              signingAddress,
 		state:                       OrchestratorStateNew,
 		stateEntryTime:              time.Now(),
@@ -175,6 +193,7 @@ func NewOrchestrator(
 		resubmitInterval:        confutil.DurationMin(conf.Orchestrator.ResubmitInterval, veryShortMinimum, *pldconf.PublicTxManagerDefaults.Orchestrator.ResubmitInterval),
 		stageRetryTimeout:       confutil.DurationMin(conf.Orchestrator.StageRetryTime, veryShortMinimum, *pldconf.PublicTxManagerDefaults.Orchestrator.StageRetryTime),
 		persistenceRetryTimeout: confutil.DurationMin(conf.Orchestrator.PersistenceRetryTime, veryShortMinimum, *pldconf.PublicTxManagerDefaults.Orchestrator.PersistenceRetryTime),
+		stageTimeouts:           newStageTimeouts(&conf.Orchestrator.StageTimeouts),
 
 		// submission retry
 		transactionSubmissionRetry: retry.NewRetryLimited(&conf.Orchestrator.SubmissionRetry),
@@ -183,17 +202,22 @@ func NewOrchestrator(
 		InFlightTxsStale:           make(chan bool, 1),
 		stopProcess:                make(chan bool, 1),
 		ethClient:                  ptm.ethClient,
+		submissionBackend:          ptm.getSubmissionBackend(signingAddress),
 		bIndexer:                   ptm.bIndexer,
 		timeLineLoggingMaxEntries:  conf.Orchestrator.TimeLineLoggingMaxEntries,
 	}
 
+	if conf.Orchestrator.StageTimeouts.ReceiptWait != nil {
+		newOrchestrator.resubmitInterval = confutil.DurationMin(conf.Orchestrator.StageTimeouts.ReceiptWait, veryShortMinimum, *pldconf.PublicTxManagerDefaults.Orchestrator.ResubmitInterval)
+	}
+
 	log.L(ctx).Debugf("NewOrchestrator for signing address %s created: %+v", newOrchestrator.signingAddress, newOrchestrator)
 
 	return newOrchestrator
 }
 
 func (oc *orchestrator) orchestratorLoop() {
-	ctx := log.WithLogField(oc.ctx, "role", "orchestrator-loop")
+	ctx := log.WithLogField(log.WithLogField(oc.ctx, "role", "orchestrator-loop"), log.FieldSigner, oc.signingAddress.String())
 	log.L(ctx).Infof("Orchestrator for signing address %s started polling based on interval %s", oc.signingAddress, oc.orchestratorPollingInterval)
 
 	defer close(oc.orchestratorLoopDone)
@@ -215,8 +239,10 @@ func (oc *orchestrator) orchestratorLoop() {
 			return
 		case <-oc.stopProcess:
 			log.L(ctx).Infof("Orchestrator loop process stopped, it processed %d transaction during its lifetime.", oc.totalCompleted)
+			oc.inFlightTxsMux.Lock()
 			oc.state = OrchestratorStateStopped
 			oc.stateEntryTime = time.Now()
+			oc.inFlightTxsMux.Unlock()
 			oc.MarkInFlightOrchestratorsStale() // trigger engine loop for removal
 			return
 		}
@@ -346,6 +372,7 @@ func (oc *orchestrator) allocateNonces(ctx context.Context, txns []*DBPublicTxn)
 	for i, tx := range toAlloc {
 		nonce := newNonces[i]
 		tx.Nonce = &nonce
+		oc.latency.record(ctx, tx.PublicTxnID, pldapi.PublicTxStageNonceAssigned)
 	}
 	oc.lastNonceAlloc = time.Now()
 	oc.nextNonce = &newNextNonce
@@ -376,6 +403,7 @@ func (oc *orchestrator) pollAndProcess(ctx context.Context) (polled int, total i
 			highestInFlightNonce = &newHighest
 		}
 		if p.stateManager.CanBeRemoved(ctx) {
+			oc.balanceManager.SettleGas(ctx, oc.signingAddress, p.stateManager.GetPubTxnID())
 			oc.totalCompleted = oc.totalCompleted + 1
 			queueUpdated = true
 			log.L(ctx).Debugf("Orchestrator poll and process, marking %s as complete after: %s", p.stateManager.GetSignerNonce(), time.Since(p.stateManager.GetCreatedTime().Time()))
@@ -459,6 +487,10 @@ func (oc *orchestrator) pollAndProcess(ctx context.Context) (polled int, total i
 		}
 		oc.thMetrics.RecordInFlightTxQueueMetrics(ctx, stageCounts, oc.maxInFlightTxs-len(oc.inFlightTxs))
 	}
+	if err := oc.updateOverflowDepth(ctx); err != nil {
+		log.L(ctx).Infof("Orchestrator poll and process: context cancelled while retrying")
+		return -1, len(oc.inFlightTxs)
+	}
 	log.L(ctx).Debugf("Orchestrator polling from DB took %s", time.Since(pollStart))
 	// now check and process each transaction
 
@@ -486,6 +518,39 @@ func (oc *orchestrator) pollAndProcess(ctx context.Context) (polled int, total i
 	return polled, total
 }
 
+// When all the in-flight slots are full, the transactions for the signer that are not yet in flight
+// form the overflow queue. This is just the rows in the DB, in the order they were written, as a
+// nonce is only allocated when a transaction is promoted into the in-flight set by the poll.
+// The depth of the queue is counted on each poll while the slots are full (unless maxOverflow
+// is zero), with the same indefinite retry as the poll itself, so that it can be monitored.
+func (oc *orchestrator) updateOverflowDepth(ctx context.Context) error {
+	depth := 0
+	if oc.maxOverflowTxs > 0 && len(oc.inFlightTxs) >= oc.maxInFlightTxs {
+		var count int64
+		err := oc.retry.Do(ctx, func(attempt int) (retry bool, err error) {
+			// As with the poll, there is no need for a DB transaction as we only read the transactions table
+			return true, oc.p.NOTX().DB().
+				WithContext(ctx).
+				Model(&DBPublicTxn{}).
+				Where(`"from" = ?`, oc.signingAddress).
+				Where("nonce IS NULL").
+				Where("suspended IS FALSE").
+				Count(&count).
+				Error
+		})
+		if err != nil {
+			return err
+		}
+		depth = int(count)
+	}
+	if depth > 0 && depth >= oc.maxOverflowTxs && oc.overflowDepth < oc.maxOverflowTxs {
+		log.L(ctx).Warnf("Orchestrator for %s has %d transactions queued behind %d in-flight transactions (maxOverflow=%d)", oc.signingAddress, depth, len(oc.inFlightTxs), oc.maxOverflowTxs)
+	}
+	oc.overflowDepth = depth
+	oc.thMetrics.RecordOverflowQueueMetrics(ctx, oc.signingAddress.String(), depth, max(oc.maxOverflowTxs-depth, 0))
+	return nil
+}
+
 // this function should only have one running instance at any given time
 func (oc *orchestrator) ProcessInFlightTransactions(ctx context.Context, its []*inFlightTransactionStageController) (waitingForBalance bool, err error) {
 	processStart := time.Now()
@@ -526,6 +591,14 @@ func (oc *orchestrator) ProcessInFlightTransactions(ctx context.Context, its []*
 			AvailableToSpend:         availableToSpend,
 			PreviousNonceCostUnknown: previousNonceCostUnknown,
 		})
+		if triggerNextStageOutput.Cost != nil {
+			// hold what the transaction could cost against the balance of the signer, until it completes
+			reservation := new(big.Int).Set(triggerNextStageOutput.Cost)
+			if value := it.stateManager.GetValue(); value != nil {
+				reservation.Add(reservation, value.Int())
+			}
+			oc.balanceManager.ReserveGas(ctx, oc.signingAddress, it.stateManager.GetPubTxnID(), reservation)
+		}
 		if !skipBalanceCheck {
 			if triggerNextStageOutput.Cost != nil {
 				_ = addressAccount.Spend(ctx, triggerNextStageOutput.Cost)
@@ -551,9 +624,12 @@ func (oc *orchestrator) ProcessInFlightTransactions(ctx context.Context, its []*
 	return waitingForBalance, nil
 }
 
+// Start runs the orchestrator loop, unless the loop is already being driven (as it is in unit tests)
 func (oc *orchestrator) Start(ctx context.Context) (done <-chan struct{}, err error) {
-	oc.orchestratorLoopDone = make(chan struct{})
-	go oc.orchestratorLoop()
+	if oc.orchestratorLoopDone == nil {
+		oc.orchestratorLoopDone = make(chan struct{})
+		go oc.orchestratorLoop()
+	}
 	oc.MarkInFlightTxStale()
 	return oc.orchestratorLoopDone, nil
 }
@@ -576,3 +652,27 @@ func (oc *orchestrator) MarkInFlightTxStale() {
 	default:
 	}
 }
+
+func (oc *orchestrator) getSnapshot(ctx context.Context) *components.PublicTxOrchestratorSnapshot {
+	oc.inFlightTxsMux.Lock()
+	defer oc.inFlightTxsMux.Unlock()
+	snapshot := &components.PublicTxOrchestratorSnapshot{
+		Signer:         oc.signingAddress,
+		State:          string(oc.state),
+		StateEntryTime: pldtypes.Timestamp(oc.stateEntryTime.UnixNano()),
+		Started:        pldtypes.Timestamp(oc.orchestratorBirthTime.UnixNano()),
+		InFlight:       make([]*components.PublicTxInFlightSnapshot, len(oc.inFlightTxs)),
+		Overflow:       oc.overflowDepth,
+	}
+	for i, it := range oc.inFlightTxs {
+		snapshot.InFlight[i] = &components.PublicTxInFlightSnapshot{
+			LocalID:         it.stateManager.GetPubTxnID(),
+			Nonce:           it.stateManager.GetNonce(),
+			Stage:           string(it.stateManager.GetStage(ctx)),
+			InFlightStatus:  it.stateManager.GetInFlightStatus().String(),
+			TransactionHash: it.stateManager.GetTransactionHash(),
+			LastSubmit:      it.stateManager.GetLastSubmitTime(),
+		}
+	}
+	return snapshot
+}
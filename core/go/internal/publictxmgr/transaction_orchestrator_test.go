@@ -25,6 +25,7 @@ import (
 	"github.com/kaleido-io/paladin/config/pkg/confutil"
 	"github.com/kaleido-io/paladin/config/pkg/pldconf"
 
+	"github.com/kaleido-io/paladin/core/internal/components"
 	"github.com/kaleido-io/paladin/core/mocks/componentmocks"
 	"github.com/kaleido-io/paladin/core/pkg/ethclient"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
@@ -36,7 +37,8 @@ import (
 
 func newTestOrchestrator(t *testing.T, cbs ...func(mocks *mocksAndTestControl, conf *pldconf.PublicTxManagerConfig)) (context.Context, *orchestrator, *mocksAndTestControl, func()) {
 	ctx, ptm, m, done := newTestPublicTxManager(t, false, func(mocks *mocksAndTestControl, conf *pldconf.PublicTxManagerConfig) {
-		mocks.disableManagerStart = true // we don't want the manager running - this gives us a fake nonce manager too
+		mocks.disableManagerStart = true              // we don't want the manager running - this gives us a fake nonce manager too
+		conf.Orchestrator.MaxOverflow = confutil.P(0) // the overflow queries are tested separately
 		for _, cb := range cbs {
 			cb(mocks, conf)
 		}
@@ -84,6 +86,7 @@ func TestNewOrchestratorLoadsSecondTxAndQueuesBalanceCheck(t *testing.T) {
 	}
 	// Do not return any submissions for it
 	m.db.ExpectQuery("SELECT.*public_submissions").WillReturnRows(sqlmock.NewRows([]string{}))
+	m.db.ExpectQuery("SELECT.*public_txn_blobs").WillReturnRows(sqlmock.NewRows([]string{}))
 
 	addressBalanceChecked := make(chan bool)
 	m.ethClient.On("GetBalance", mock.Anything, o.signingAddress, "latest").Return(pldtypes.Uint64ToUint256(100), nil).Run(func(args mock.Arguments) {
@@ -188,6 +191,7 @@ func TestOrchestratorTriggerTopUp(t *testing.T) {
 
 	mockIT, txState := newInflightTransaction(o, 1, func(tx *DBPublicTxn) {
 		tx.Gas = 100
+		tx.Value = pldtypes.Uint64ToUint256(5)
 	})
 	txState.ApplyInMemoryUpdates(ctx, &BaseTXUpdates{
 		GasPricing: &pldapi.PublicTxGasPricing{
@@ -233,6 +237,91 @@ func TestOrchestratorTriggerTopUp(t *testing.T) {
 		af.addressBalanceChangedMapMux.Unlock()
 	}
 
+	// The in-flight transaction holds its maximum cost plus value against the signer, and the
+	// fueling transaction holds its value against the source
+	assert.Equal(t, "100005", o.balanceManager.GetReservedGas(ctx, o.signingAddress).String())
+	assert.Equal(t, trackedTx.Value.Int().String(), o.balanceManager.GetReservedGas(ctx, autoFuelingSourceAddr).String())
+
 	o.Stop()
 	<-oDone
 }
+
+func TestOrchestratorOverflowDepthRealDB(t *testing.T) {
+	ctx, ptm, _, done := newTestPublicTxManager(t, true, func(mocks *mocksAndTestControl, conf *pldconf.PublicTxManagerConfig) {
+		mocks.disableManagerStart = true
+		conf.Orchestrator.MaxInFlight = confutil.P(1)
+		conf.Orchestrator.MaxOverflow = confutil.P(3)
+	})
+	defer done()
+
+	signingAddress := pldtypes.EthAddress(pldtypes.RandBytes(20))
+	o := NewOrchestrator(ptm, signingAddress, ptm.conf)
+
+	// Five transactions waiting for a slot, one of which is suspended
+	for i := 0; i < 5; i++ {
+		err := ptm.p.DB().Create(&DBPublicTxn{
+			From:      signingAddress,
+			Gas:       2000,
+			Suspended: i == 1,
+		}).Error
+		require.NoError(t, err)
+	}
+
+	// Nothing is queued while there are free slots, as the poll takes them straight into flight
+	require.NoError(t, o.updateOverflowDepth(ctx))
+	assert.Zero(t, o.overflowDepth)
+
+	// Once the single in-flight slot is full, the rest are queued
+	mockIT, _ := newInflightTransaction(o, 10)
+	o.inFlightTxs = []*inFlightTransactionStageController{mockIT}
+	require.NoError(t, o.updateOverflowDepth(ctx))
+	assert.Equal(t, 4, o.overflowDepth)
+	assert.Equal(t, 4, o.getSnapshot(ctx).Overflow)
+	assert.Equal(t, &components.PublicTxOverflowQueueMetrics{Queued: 4, Free: 0},
+		ptm.GetEngineMetrics(ctx).OverflowQueue[signingAddress.String()])
+
+	// The queue does not have nonces - they are only allocated on entering the in-flight set
+	var assigned int64
+	err := ptm.p.DB().Model(&DBPublicTxn{}).Where(`"from" = ?`, signingAddress).Where("nonce IS NOT NULL").Count(&assigned).Error
+	require.NoError(t, err)
+	assert.Zero(t, assigned)
+
+	// Once the slot frees up, the queue is drained
+	o.inFlightTxs = nil
+	require.NoError(t, o.updateOverflowDepth(ctx))
+	assert.Zero(t, o.overflowDepth)
+	assert.Empty(t, ptm.GetEngineMetrics(ctx).OverflowQueue)
+}
+
+func TestOrchestratorOverflowDepthRetry(t *testing.T) {
+	ctx, o, m, done := newTestOrchestrator(t, func(mocks *mocksAndTestControl, conf *pldconf.PublicTxManagerConfig) {
+		conf.Orchestrator.MaxInFlight = confutil.P(1)
+		conf.Orchestrator.MaxOverflow = confutil.P(5)
+	})
+	defer done()
+
+	m.db.ExpectQuery("SELECT count.*public_txn").WillReturnError(fmt.Errorf("pop"))
+	m.db.ExpectQuery("SELECT count.*public_txn").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+
+	mockIT, _ := newInflightTransaction(o, 1)
+	o.inFlightTxs = []*inFlightTransactionStageController{mockIT}
+	require.NoError(t, o.updateOverflowDepth(ctx))
+	assert.Equal(t, 2, o.overflowDepth)
+	require.NoError(t, m.db.ExpectationsWereMet())
+}
+
+func TestOrchestratorOverflowDepthCancelled(t *testing.T) {
+	ctx, o, m, done := newTestOrchestrator(t, func(mocks *mocksAndTestControl, conf *pldconf.PublicTxManagerConfig) {
+		conf.Orchestrator.MaxInFlight = confutil.P(1)
+		conf.Orchestrator.MaxOverflow = confutil.P(5)
+	})
+	defer done()
+
+	m.db.ExpectQuery("SELECT count.*public_txn").WillReturnError(fmt.Errorf("pop"))
+
+	mockIT, _ := newInflightTransaction(o, 1)
+	o.inFlightTxs = []*inFlightTransactionStageController{mockIT}
+	cancelledCtx, cancel := context.WithCancel(ctx)
+	cancel()
+	assert.Regexp(t, "PD020000", o.updateOverflowDepth(cancelledCtx))
+}
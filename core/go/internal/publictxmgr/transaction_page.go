@@ -0,0 +1,147 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package publictxmgr
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/core/internal/components"
+	"github.com/kaleido-io/paladin/core/internal/filters"
+	"github.com/kaleido-io/paladin/core/internal/msgs"
+	"github.com/kaleido-io/paladin/core/pkg/persistence"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+)
+
+// The position after the last transaction of a page, in the keyset of the sort order
+type publicTxPageCursor struct {
+	LocalID uint64              `json:"localId"`
+	Created *pldtypes.Timestamp `json:"created,omitempty"` // only when sorting by created
+}
+
+type publicTxPageSort struct {
+	byCreated  bool
+	descending bool
+}
+
+func parsePublicTxPageSort(ctx context.Context, sort []string) (*publicTxPageSort, error) {
+	if len(sort) == 0 {
+		// Newest first by default
+		return &publicTxPageSort{descending: true}, nil
+	}
+	if len(sort) == 1 {
+		fieldAndDirection := strings.SplitN(sort[0], " ", 2)
+		fieldName, negated := strings.CutPrefix(fieldAndDirection[0], "-")
+		descending := negated || (len(fieldAndDirection) == 2 && strings.EqualFold(fieldAndDirection[1], "desc"))
+		switch fieldName {
+		case "localId":
+			return &publicTxPageSort{descending: descending}, nil
+		case "created":
+			return &publicTxPageSort{byCreated: true, descending: descending}, nil
+		}
+	}
+	return nil, i18n.NewError(ctx, msgs.MsgPublicTxPageInvalidSort, sort)
+}
+
+func decodePublicTxPageCursor(ctx context.Context, after string, sort *publicTxPageSort) (*publicTxPageCursor, error) {
+	var cursor publicTxPageCursor
+	b, err := base64.RawURLEncoding.DecodeString(after)
+	if err == nil {
+		err = json.Unmarshal(b, &cursor)
+	}
+	if err != nil || (sort.byCreated && cursor.Created == nil) {
+		return nil, i18n.NewError(ctx, msgs.MsgPublicTxPageInvalidCursor, after)
+	}
+	return &cursor, nil
+}
+
+func encodePublicTxPageCursor(tx *pldapi.PublicTx, sort *publicTxPageSort) string {
+	cursor := &publicTxPageCursor{LocalID: *tx.LocalID}
+	if sort.byCreated {
+		cursor.Created = &tx.Created
+	}
+	return base64.RawURLEncoding.EncodeToString(pldtypes.JSONString(cursor))
+}
+
+// Component interface: query a page of public transactions, using keyset pagination so that pages remain
+// consistent while new transactions are being written
+func (ptm *pubTxManager) QueryPublicTxPage(ctx context.Context, dbTX persistence.DBTX, ptq *pldapi.PublicTxQuery) (*pldapi.PublicTxPage, error) {
+	if err := filters.CheckLimitSet(ctx, &ptq.QueryJSON); err != nil {
+		return nil, err
+	}
+	sort, err := parsePublicTxPageSort(ctx, ptq.Sort)
+	if err != nil {
+		return nil, err
+	}
+
+	// We apply the sort ourselves, and query one extra row to find out if there is a next page
+	limit := *ptq.Limit
+	jq := ptq.QueryJSON
+	jq.Sort = nil
+	jq.Limit = confutil.P(limit + 1)
+	q := filters.BuildGORM(ctx, &jq,
+		dbTX.DB().Table("public_txns").WithContext(ctx).Joins("Completed"),
+		components.PublicTxFilterFields)
+
+	if ptq.PendingLongerThan != nil {
+		pendingLongerThan, err := time.ParseDuration(*ptq.PendingLongerThan)
+		if err != nil || pendingLongerThan < 0 {
+			return nil, i18n.NewError(ctx, msgs.MsgPublicTxPageInvalidDuration, *ptq.PendingLongerThan)
+		}
+		q = q.Where(`"Completed"."tx_hash" IS NULL`).
+			Where(`"public_txns"."created" <= ?`, pldtypes.TimestampNow()-pldtypes.Timestamp(pendingLongerThan))
+	}
+
+	op, direction := ">", "ASC"
+	if sort.descending {
+		op, direction = "<", "DESC"
+	}
+	if ptq.After != "" {
+		cursor, err := decodePublicTxPageCursor(ctx, ptq.After, sort)
+		if err != nil {
+			return nil, err
+		}
+		if sort.byCreated {
+			q = q.Where(fmt.Sprintf(`("public_txns"."created" %[1]s ? OR ("public_txns"."created" = ? AND "public_txns"."pub_txn_id" %[1]s ?))`, op),
+				*cursor.Created, *cursor.Created, cursor.LocalID)
+		} else {
+			q = q.Where(fmt.Sprintf(`"public_txns"."pub_txn_id" %s ?`, op), cursor.LocalID)
+		}
+	}
+	if sort.byCreated {
+		q = q.Order(`"public_txns"."created" ` + direction)
+	}
+	// The local ID is unique, so gives a stable order for transactions created at the same time
+	q = q.Order(`"public_txns"."pub_txn_id" ` + direction)
+
+	items, err := ptm.runPublicTxWithBindingQuery(ctx, dbTX, nil, q)
+	if err != nil {
+		return nil, err
+	}
+	page := &pldapi.PublicTxPage{Items: items}
+	if len(items) > limit {
+		page.Items = items[:limit]
+		page.Next = encodePublicTxPageCursor(page.Items[limit-1].PublicTx, sort)
+	}
+	return page, nil
+}
@@ -0,0 +1,167 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package publictxmgr
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/query"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryPublicTxPageRealDB(t *testing.T) {
+	ctx, ptm, _, done := newTestPublicTxManager(t, true, func(mocks *mocksAndTestControl, conf *pldconf.PublicTxManagerConfig) {
+		mocks.disableManagerStart = true
+	})
+	defer done()
+
+	// Ten transactions from two signers, created a minute apart, where the odd ones are complete
+	signers := []pldtypes.EthAddress{*pldtypes.RandAddress(), *pldtypes.RandAddress()}
+	start := pldtypes.TimestampNow() - pldtypes.Timestamp(time.Hour)
+	ptxs := make([]*DBPublicTxn, 10)
+	for i := range ptxs {
+		ptxs[i] = &DBPublicTxn{
+			From:    signers[i%2],
+			Nonce:   confutil.P(uint64(i / 2)),
+			Created: start + pldtypes.Timestamp(time.Duration(i)*time.Minute),
+			Gas:     21000,
+		}
+	}
+	// The last two share a created time, so their order comes from the local ID
+	ptxs[9].Created = ptxs[8].Created
+	require.NoError(t, ptm.p.DB().Create(ptxs).Error)
+	for i, ptx := range ptxs {
+		if i%2 == 1 {
+			require.NoError(t, ptm.p.DB().Create(&DBPublicTxnCompletion{
+				PublicTxnID:     ptx.PublicTxnID,
+				TransactionHash: pldtypes.RandBytes32(),
+				Success:         i != 9,
+			}).Error)
+		}
+	}
+
+	localIDs := func(page *pldapi.PublicTxPage) (ids []uint64) {
+		for _, tx := range page.Items {
+			ids = append(ids, *tx.LocalID)
+		}
+		return ids
+	}
+
+	// Default sort is newest first, through every page
+	var all []uint64
+	ptq := &pldapi.PublicTxQuery{QueryJSON: *query.NewQueryBuilder().Limit(4).Query()}
+	for {
+		page, err := ptm.QueryPublicTxPage(ctx, ptm.p.NOTX(), ptq)
+		require.NoError(t, err)
+		all = append(all, localIDs(page)...)
+		if page.Next == "" {
+			break
+		}
+		ptq.After = page.Next
+	}
+	require.Len(t, all, 10)
+	for i := range all {
+		assert.Equal(t, ptxs[9-i].PublicTxnID, all[i])
+	}
+
+	// Sorting by created time, ascending
+	ptq = &pldapi.PublicTxQuery{QueryJSON: *query.NewQueryBuilder().Limit(9).Sort("created").Query()}
+	page, err := ptm.QueryPublicTxPage(ctx, ptm.p.NOTX(), ptq)
+	require.NoError(t, err)
+	require.Len(t, page.Items, 9)
+	require.NotEmpty(t, page.Next)
+	ptq.After = page.Next
+	page, err = ptm.QueryPublicTxPage(ctx, ptm.p.NOTX(), ptq)
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{ptxs[9].PublicTxnID}, localIDs(page))
+	assert.Empty(t, page.Next)
+
+	// Filter by status, signer and nonce range
+	page, err = ptm.QueryPublicTxPage(ctx, ptm.p.NOTX(), &pldapi.PublicTxQuery{QueryJSON: *query.NewQueryBuilder().
+		Limit(10).
+		Sort("localId").
+		Equal("status", "success").
+		Equal("from", signers[1]).
+		GreaterThanOrEqual("nonce", 1).
+		Query()})
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{ptxs[3].PublicTxnID, ptxs[5].PublicTxnID, ptxs[7].PublicTxnID}, localIDs(page))
+
+	page, err = ptm.QueryPublicTxPage(ctx, ptm.p.NOTX(), &pldapi.PublicTxQuery{QueryJSON: *query.NewQueryBuilder().
+		Limit(10).Equal("status", "failed").Query()})
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{ptxs[9].PublicTxnID}, localIDs(page))
+
+	// Filter by created time, and pending duration
+	page, err = ptm.QueryPublicTxPage(ctx, ptm.p.NOTX(), &pldapi.PublicTxQuery{
+		QueryJSON:         *query.NewQueryBuilder().Limit(10).Sort("-created").GreaterThan("created", ptxs[2].Created).Query(),
+		PendingLongerThan: confutil.P(fmt.Sprintf("%dm", 60-6)),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{ptxs[6].PublicTxnID, ptxs[4].PublicTxnID}, localIDs(page))
+}
+
+func TestQueryPublicTxPageErrors(t *testing.T) {
+	ctx, ptm, m, done := newTestPublicTxManager(t, false, func(mocks *mocksAndTestControl, conf *pldconf.PublicTxManagerConfig) {
+		mocks.disableManagerStart = true
+	})
+	defer done()
+
+	_, err := ptm.QueryPublicTxPage(ctx, ptm.p.NOTX(), &pldapi.PublicTxQuery{})
+	assert.Regexp(t, "PD010721", err)
+
+	_, err = ptm.QueryPublicTxPage(ctx, ptm.p.NOTX(), &pldapi.PublicTxQuery{
+		QueryJSON: *query.NewQueryBuilder().Limit(1).Sort("nonce").Query(),
+	})
+	assert.Regexp(t, "PD011960", err)
+
+	_, err = ptm.QueryPublicTxPage(ctx, ptm.p.NOTX(), &pldapi.PublicTxQuery{
+		QueryJSON: *query.NewQueryBuilder().Limit(1).Sort("created", "localId").Query(),
+	})
+	assert.Regexp(t, "PD011960", err)
+
+	_, err = ptm.QueryPublicTxPage(ctx, ptm.p.NOTX(), &pldapi.PublicTxQuery{
+		QueryJSON: *query.NewQueryBuilder().Limit(1).Query(),
+		After:     "!!!",
+	})
+	assert.Regexp(t, "PD011961", err)
+
+	// a cursor from a page sorted by local ID cannot be used when sorting by created time
+	_, err = ptm.QueryPublicTxPage(ctx, ptm.p.NOTX(), &pldapi.PublicTxQuery{
+		QueryJSON: *query.NewQueryBuilder().Limit(1).Sort("created DESC").Query(),
+		After:     encodePublicTxPageCursor(&pldapi.PublicTx{LocalID: confutil.P(uint64(1))}, &publicTxPageSort{}),
+	})
+	assert.Regexp(t, "PD011961", err)
+
+	_, err = ptm.QueryPublicTxPage(ctx, ptm.p.NOTX(), &pldapi.PublicTxQuery{
+		QueryJSON:         *query.NewQueryBuilder().Limit(1).Query(),
+		PendingLongerThan: confutil.P("wrong"),
+	})
+	assert.Regexp(t, "PD011962", err)
+
+	m.db.ExpectQuery("SELECT.*public_txns").WillReturnError(fmt.Errorf("pop"))
+	_, err = ptm.QueryPublicTxPage(ctx, ptm.p.NOTX(), &pldapi.PublicTxQuery{
+		QueryJSON: *query.NewQueryBuilder().Limit(1).Query(),
+	})
+	assert.Regexp(t, "pop", err)
+}
@@ -20,6 +20,7 @@ import (
 	"time"
 
 	"github.com/hyperledger/firefly-signer/pkg/ethsigner"
+	"github.com/hyperledger/firefly-signer/pkg/rlp"
 	"github.com/hyperledger/firefly-signer/pkg/secp256k1"
 	"github.com/kaleido-io/paladin/common/go/pkg/log"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
@@ -29,40 +30,60 @@ import (
 	"golang.org/x/crypto/sha3"
 )
 
-func (it *inFlightTransactionStageController) signTx(ctx context.Context, from pldtypes.EthAddress, ethTx *ethsigner.Transaction) ([]byte, *pldtypes.Bytes32, error) {
+// signTx signs a transaction using the type and signature encoding of the chain profile, or a type 3 transaction
+// if blobTx is non-nil, returning the message to send to the node and the hash of the transaction
+func (ptm *pubTxManager) signTx(ctx context.Context, from pldtypes.EthAddress, ethTx *ethsigner.Transaction, blobTx *blobTransaction) ([]byte, *pldtypes.Bytes32, error) {
 	log.L(ctx).Debugf("signTx entry")
 	signStart := time.Now()
 
 	// Reverse resolve the key - to get to this point it will be in the key management system
-	resolvedKey, err := it.keymgr.ReverseKeyLookup(ctx, it.pubTxManager.p.NOTX(), algorithms.ECDSA_SECP256K1, verifiers.ETH_ADDRESS, from.String())
+	resolvedKey, err := ptm.keymgr.ReverseKeyLookup(ctx, ptm.p.NOTX(), algorithms.ECDSA_SECP256K1, verifiers.ETH_ADDRESS, from.String())
 	if err != nil {
 		log.L(ctx).Errorf("signing failed to resolve key %s for signing: %s", from.String(), err)
-		it.thMetrics.RecordOperationMetrics(ctx, string(InFlightTxOperationSign), string(GenericStatusFail), time.Since(signStart).Seconds())
+		ptm.thMetrics.RecordOperationMetrics(ctx, string(InFlightTxOperationSign), string(GenericStatusFail), time.Since(signStart).Seconds())
 		return nil, nil, err
 	}
 	// Sign
-	sigPayload := ethTx.SignaturePayloadEIP1559(it.ethClient.ChainID())
+	chainProfile := ptm.ethClient.ChainProfile()
+	var sigPayload *ethsigner.TransactionSignaturePayload
+	var blobTxPayload rlp.List
+	var payloadBytes []byte
+	if blobTx != nil {
+		blobTxPayload = buildBlobTxPayload(ptm.ethClient.ChainID(), ethTx, blobTx)
+		payloadBytes = append([]byte{blobTransactionType}, blobTxPayload.Encode()...)
+	} else {
+		sigPayload = chainProfile.SignaturePayload(ethTx, ptm.ethClient.ChainID())
+		payloadBytes = sigPayload.Bytes()
+	}
+	// The payload depends only on the persisted state of the transaction. So when signing is retried, including after
+	// a restart, a signing module that signs in asynchronous multi-round sessions (such as an MPC/TSS provider)
+	// resumes the session already in progress for this payload rather than starting another.
 	sigPayloadHash := sha3.NewLegacyKeccak256()
-	_, err = sigPayloadHash.Write(sigPayload.Bytes())
+	_, err = sigPayloadHash.Write(payloadBytes)
 	var signatureRSV []byte
 	if err == nil {
-		signatureRSV, err = it.keymgr.Sign(ctx, resolvedKey, signpayloads.OPAQUE_TO_RSV, pldtypes.HexBytes(sigPayloadHash.Sum(nil)))
+		signatureRSV, err = ptm.keymgr.Sign(ctx, resolvedKey, signpayloads.OPAQUE_TO_RSV, pldtypes.HexBytes(sigPayloadHash.Sum(nil)))
 	}
 	var sig *secp256k1.SignatureData
 	if err == nil {
 		sig, err = secp256k1.DecodeCompactRSV(ctx, signatureRSV)
 	}
 	var signedMessage []byte
+	var calculatedHash *pldtypes.Bytes32
 	if err == nil {
-		signedMessage, err = ethTx.FinalizeEIP1559WithSignature(sigPayload, sig)
+		if blobTx != nil {
+			// the hash of a blob transaction does not include the sidecar that is sent to the node
+			signedMessage, calculatedHash = finalizeBlobTx(blobTxPayload, sig, blobTx)
+		} else if signedMessage, err = chainProfile.FinalizeWithSignature(ethTx, sigPayload, sig, ptm.ethClient.ChainID()); err == nil {
+			calculatedHash = calculateTransactionHash(signedMessage)
+		}
 	}
 	if err != nil {
 		log.L(ctx).Errorf("signing failed with keyHandle %s (addr=%s): %s", resolvedKey.KeyHandle, resolvedKey.Verifier.Verifier, err)
-		it.thMetrics.RecordOperationMetrics(ctx, string(InFlightTxOperationSign), string(GenericStatusFail), time.Since(signStart).Seconds())
+		ptm.thMetrics.RecordOperationMetrics(ctx, string(InFlightTxOperationSign), string(GenericStatusFail), time.Since(signStart).Seconds())
 		return nil, nil, err
 	}
-	calculatedHash := calculateTransactionHash(signedMessage)
 	log.L(ctx).Debugf("Calculated Hash %s of transaction %s:%d", calculatedHash, ethTx.From, ethTx.Nonce.Uint64())
-	it.thMetrics.RecordOperationMetrics(ctx, string(InFlightTxOperationSign), string(GenericStatusSuccess), time.Since(signStart).Seconds())
+	ptm.thMetrics.RecordOperationMetrics(ctx, string(InFlightTxOperationSign), string(GenericStatusSuccess), time.Since(signStart).Seconds())
 	return signedMessage, calculatedHash, err
 }
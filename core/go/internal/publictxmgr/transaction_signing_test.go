@@ -16,12 +16,18 @@
 package publictxmgr
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
 	"github.com/hyperledger/firefly-signer/pkg/ethsigner"
 	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/hyperledger/firefly-signer/pkg/secp256k1"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
 	"github.com/kaleido-io/paladin/core/mocks/componentmocks"
+	"github.com/kaleido-io/paladin/core/mocks/ethclientmocks"
+	"github.com/kaleido-io/paladin/core/pkg/ethclient"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
 	"github.com/kaleido-io/paladin/toolkit/pkg/algorithms"
@@ -29,6 +35,7 @@ import (
 	"github.com/kaleido-io/paladin/toolkit/pkg/verifiers"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 func TestInFlightTxSignFail(t *testing.T) {
@@ -60,8 +67,114 @@ func TestInFlightTxSignFail(t *testing.T) {
 		Nonce: ethtypes.NewHexInteger64(12345),
 	}
 
-	_, txHash, err := it.signTx(ctx, fromAddr, ethTx)
+	_, txHash, err := it.signTx(ctx, fromAddr, ethTx, nil)
 	assert.Regexp(t, "sign failed", err)
 	assert.Nil(t, txHash)
 
 }
+
+func TestInFlightTxSignPayloadStableAcrossRetries(t *testing.T) {
+	ctx, o, m, done := newTestOrchestrator(t)
+	defer done()
+	it, _ := newInflightTransaction(o, 1)
+
+	fromAddr := *pldtypes.RandAddress()
+
+	m.ethClient.On("ChainID").Return(int64(1122334455))
+	keyMapping := &pldapi.KeyMappingAndVerifier{
+		KeyMappingWithPath: &pldapi.KeyMappingWithPath{
+			KeyMapping: &pldapi.KeyMapping{
+				Identifier: "any.key",
+			},
+		},
+		Verifier: &pldapi.KeyVerifier{
+			Verifier: fromAddr.String(),
+		},
+	}
+
+	var payloads [][]byte
+	mockKeyManager := m.keyManager.(*componentmocks.KeyManager)
+	mockKeyManager.On("ReverseKeyLookup", mock.Anything, mock.Anything, algorithms.ECDSA_SECP256K1, verifiers.ETH_ADDRESS, fromAddr.String()).
+		Return(keyMapping, nil)
+	mockKeyManager.On("Sign", mock.Anything, keyMapping, signpayloads.OPAQUE_TO_RSV, mock.Anything).
+		Run(func(args mock.Arguments) {
+			payloads = append(payloads, args[3].([]byte))
+		}).
+		Return(nil, fmt.Errorf("signing session still in progress")).Twice()
+
+	// Each attempt builds the transaction from the persisted state afresh
+	buildTx := func() *ethsigner.Transaction {
+		return &ethsigner.Transaction{
+			Nonce:                ethtypes.NewHexInteger64(12345),
+			GasLimit:             ethtypes.NewHexInteger64(100000),
+			MaxFeePerGas:         ethtypes.NewHexInteger64(2000),
+			MaxPriorityFeePerGas: ethtypes.NewHexInteger64(1000),
+			Data:                 ethtypes.MustNewHexBytes0xPrefix("0xfeedbeef"),
+		}
+	}
+	_, _, err := it.signTx(ctx, fromAddr, buildTx(), nil)
+	assert.Regexp(t, "still in progress", err)
+	_, _, err = it.signTx(ctx, fromAddr, buildTx(), nil)
+	assert.Regexp(t, "still in progress", err)
+
+	assert.Len(t, payloads, 2)
+	assert.Equal(t, payloads[0], payloads[1])
+}
+
+func TestInFlightTxSignLegacyChainProfile(t *testing.T) {
+	ctx, o, m, done := newTestOrchestrator(t)
+	defer done()
+	it, _ := newInflightTransaction(o, 1)
+
+	kp, err := secp256k1.GenerateSecp256k1KeyPair()
+	require.NoError(t, err)
+	fromAddr := pldtypes.EthAddress(kp.Address)
+
+	chainProfile, err := ethclient.NewChainProfile(ctx, &pldconf.ChainProfileConfig{
+		TransactionType: confutil.P("legacy_eip155"),
+	})
+	require.NoError(t, err)
+	legacyClient := ethclientmocks.NewEthClient(t)
+	legacyClient.On("ChainID").Return(int64(1122334455))
+	legacyClient.On("ChainProfile").Return(chainProfile)
+	it.pubTxManager.ethClient = legacyClient
+
+	keyMapping := &pldapi.KeyMappingAndVerifier{
+		KeyMappingWithPath: &pldapi.KeyMappingWithPath{
+			KeyMapping: &pldapi.KeyMapping{
+				Identifier: "any.key",
+			},
+		},
+		Verifier: &pldapi.KeyVerifier{
+			Verifier: fromAddr.String(),
+		},
+	}
+	mockKeyManager := m.keyManager.(*componentmocks.KeyManager)
+	mockKeyManager.On("ReverseKeyLookup", mock.Anything, mock.Anything, algorithms.ECDSA_SECP256K1, verifiers.ETH_ADDRESS, fromAddr.String()).
+		Return(keyMapping, nil)
+	mockKeyManager.On("Sign", mock.Anything, keyMapping, signpayloads.OPAQUE_TO_RSV, mock.Anything).
+		Return(func(_ context.Context, _ *pldapi.KeyMappingAndVerifier, _ string, payload []byte) ([]byte, error) {
+			sig, err := kp.SignDirect(payload)
+			if err != nil {
+				return nil, err
+			}
+			return sig.CompactRSV(), nil
+		})
+
+	signedMessage, txHash, err := it.signTx(ctx, fromAddr, &ethsigner.Transaction{
+		Nonce:                ethtypes.NewHexInteger64(12345),
+		GasLimit:             ethtypes.NewHexInteger64(100000),
+		MaxFeePerGas:         ethtypes.NewHexInteger64(2000),
+		MaxPriorityFeePerGas: ethtypes.NewHexInteger64(1000),
+		Data:                 ethtypes.MustNewHexBytes0xPrefix("0xfeedbeef"),
+	}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, calculateTransactionHash(signedMessage), txHash)
+
+	// A legacy transaction is an RLP list with no type byte, signed with the chain ID in the V value
+	assert.GreaterOrEqual(t, signedMessage[0], byte(0xc0))
+	signer, ethTx, err := ethsigner.RecoverRawTransaction(ctx, signedMessage, 1122334455)
+	require.NoError(t, err)
+	assert.Equal(t, kp.Address, *signer)
+	assert.Equal(t, int64(2000), ethTx.GasPrice.Int64())
+}
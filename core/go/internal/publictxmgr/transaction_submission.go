@@ -45,7 +45,7 @@ func (it *inFlightTransactionStageController) submitTX(ctx context.Context, sign
 	if calculatedTxHash == nil {
 		return nil, nil, ethclient.ErrorReasonInvalidInputs, SubmissionOutcomeFailedRequiresRetry, i18n.NewError(ctx, msgs.MsgInvalidStateMissingTXHash)
 	}
-	log.L(ctx).Debugf("Sending raw transaction %s (lastSubmit=%s), Hash=%s", signerNonce, lastSubmitTime, calculatedTxHash)
+	log.L(ctx).Debugf("Sending raw transaction %s via %s (lastSubmit=%s), Hash=%s", signerNonce, it.submissionBackend.Name(), lastSubmitTime, calculatedTxHash)
 
 	submissionTime := confutil.P(pldtypes.TimestampNow())
 	var submissionErrorReason ethclient.ErrorReason // TODO: fix reason parsing
@@ -56,7 +56,14 @@ func (it *inFlightTransactionStageController) submitTX(ctx context.Context, sign
 		if cancelled(ctx) {
 			return false, nil
 		}
-		txHash, submissionError = it.ethClient.SendRawTransaction(ctx, pldtypes.HexBytes(signedMessage))
+		if submissionError = it.pubTxManager.faults.submissionFault(ctx); submissionError == nil {
+			txHash, submissionError = it.submissionBackend.SendRawTransaction(ctx, &SignedTransaction{
+				From:            it.signingAddress,
+				Nonce:           pldtypes.HexUint64(it.stateManager.GetNonce()),
+				TransactionHash: *calculatedTxHash,
+				RawTransaction:  signedMessage,
+			})
+		}
 		if submissionError == nil {
 			submissionOutcome = SubmissionOutcomeFailedRequiresRetry
 			it.thMetrics.RecordOperationMetrics(ctx, string(InFlightTxOperationTransactionSend), string(GenericStatusSuccess), time.Since(sendStart).Seconds())
@@ -24,6 +24,7 @@ import (
 	"github.com/hyperledger/firefly-signer/pkg/ethsigner"
 	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
 	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
 	"github.com/kaleido-io/paladin/core/pkg/ethclient"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
@@ -115,9 +116,14 @@ type TransactionHeaders struct {
 
 type BalanceManager interface {
 	TopUpAccount(ctx context.Context, addAccount *AddressAccount) (mtx *pldapi.PublicTx, err error)
+	CalculateTopUp(ctx context.Context, addAccount *AddressAccount) *big.Int
 	IsAutoFuelingEnabled(ctx context.Context) bool
 	GetAddressBalance(ctx context.Context, address pldtypes.EthAddress) (*AddressAccount, error)
 	NotifyAddressBalanceChanged(ctx context.Context, address pldtypes.EthAddress)
+	SetAutoFuelingThresholds(ctx context.Context, conf *pldconf.AutoFuelingConfig) error
+	ReserveGas(ctx context.Context, address pldtypes.EthAddress, pubTxnID uint64, cost *big.Int)
+	SettleGas(ctx context.Context, address pldtypes.EthAddress, pubTxnID uint64)
+	GetReservedGas(ctx context.Context, address pldtypes.EthAddress) *big.Int
 }
 
 type AutoFuelTransactionHandler interface {
@@ -264,12 +270,15 @@ type InMemoryTxStateReadOnly interface {
 	GetValue() *pldtypes.HexUint256
 	BuildEthTX() *ethsigner.Transaction
 	GetGasPriceObject() *pldapi.PublicTxGasPricing
+	GetBlobHashes() []pldtypes.Bytes32
 	GetFirstSubmit() *pldtypes.Timestamp
 	GetLastSubmitTime() *pldtypes.Timestamp
 	GetUnflushedSubmission() *DBPubTxnSubmission
 	GetInFlightStatus() InFlightStatus
 	GetSignerNonce() string
 	GetGasLimit() uint64
+	GetExpiry() *pldtypes.Timestamp
+	IsCancelling() bool
 	IsReadyToExit() bool
 }
 
@@ -281,6 +290,8 @@ type InMemoryTxStateManager interface {
 type InMemoryTxStateSetters interface {
 	ApplyInMemoryUpdates(ctx context.Context, txUpdates *BaseTXUpdates)
 	UpdateTransaction(newPtx *DBPublicTxn)
+	StartCancellation()
+	SetExpiry(expiry *pldtypes.Timestamp)
 	ResetTransactionHash()
 }
 
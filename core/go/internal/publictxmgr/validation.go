@@ -0,0 +1,94 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package publictxmgr
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"math/big"
+
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/internal/components"
+	"github.com/kaleido-io/paladin/core/internal/msgs"
+)
+
+// Every transaction costs at least this much gas, so a lower limit can never be mined
+const intrinsicGas = 21000
+
+type txValidation struct {
+	maxGas          uint64
+	maxValue        *big.Int // nil for no limit
+	maxCalldataSize int64
+}
+
+func newTxValidation(ctx context.Context, conf *pldconf.PublicTxValidationConfig) (*txValidation, error) {
+	v := &txValidation{
+		maxGas:          uint64(confutil.Int64Min(conf.MaxGas, intrinsicGas, *pldconf.PublicTxManagerDefaults.Validation.MaxGas)),
+		maxCalldataSize: confutil.ByteSize(conf.MaxCalldataSize, 0, *pldconf.PublicTxManagerDefaults.Validation.MaxCalldataSize),
+	}
+	if conf.MaxValue != nil {
+		if v.maxValue = confutil.BigIntOrNil(conf.MaxValue); v.maxValue == nil || v.maxValue.Sign() < 0 {
+			return nil, i18n.NewError(ctx, msgs.MsgPublicTxInvalidMaxValue, *conf.MaxValue)
+		}
+	}
+	return v, nil
+}
+
+// validateInput performs the checks that do not need a call to the chain. The errors all have
+// a bad request status hint, so they are distinguishable from failures of the node itself.
+func (v *txValidation) validateInput(ctx context.Context, txi *components.PublicTxSubmission) error {
+	if txi.From.IsZero() {
+		return i18n.NewError(ctx, msgs.MsgPublicTxFromZeroAddress)
+	}
+	if txi.To != nil && txi.To.IsZero() {
+		return i18n.NewError(ctx, msgs.MsgPublicTxToZeroAddress)
+	}
+	if len(txi.Data) > int(v.maxCalldataSize) {
+		return i18n.NewError(ctx, msgs.MsgPublicTxCalldataTooLarge, len(txi.Data), v.maxCalldataSize)
+	}
+	if v.maxValue != nil && txi.Value != nil && txi.Value.Int().Cmp(v.maxValue) > 0 {
+		return i18n.NewError(ctx, msgs.MsgPublicTxValueAboveMax, txi.Value.Int().Text(10), v.maxValue.Text(10))
+	}
+	if txi.Gas != nil && *txi.Gas != 0 {
+		if *txi.Gas < intrinsicGas {
+			return i18n.NewError(ctx, msgs.MsgPublicTxGasBelowIntrinsic, txi.Gas.Uint64(), intrinsicGas)
+		}
+		return v.validateMaxGas(ctx, txi.Gas.Uint64())
+	}
+	return nil
+}
+
+// validateMaxGas is also used for the gas limit from estimation, as padding can take it over the maximum
+func (v *txValidation) validateMaxGas(ctx context.Context, gas uint64) error {
+	if gas > v.maxGas {
+		return i18n.NewError(ctx, msgs.MsgPublicTxGasAboveMax, gas, v.maxGas)
+	}
+	return nil
+}
+
+// Metadata is stored compacted, so that it is returned exactly as it is matched by jsonEq queries.
+// Only objects are accepted, as the paths of a query are meaningless against anything else.
+func validateMetadata(ctx context.Context, txi *components.PublicTxSubmission) error {
+	buff := new(bytes.Buffer)
+	if err := json.Compact(buff, txi.Metadata); err != nil || buff.Len() == 0 || buff.Bytes()[0] != '{' {
+		return i18n.NewError(ctx, msgs.MsgPublicTxMetadataInvalid)
+	}
+	txi.Metadata = buff.Bytes()
+	return nil
+}
@@ -0,0 +1,153 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package publictxmgr
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/internal/components"
+	"github.com/kaleido-io/paladin/core/pkg/ethclient"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateTransactionInput(t *testing.T) {
+	ctx := context.Background()
+	v, err := newTxValidation(ctx, &pldconf.PublicTxValidationConfig{
+		MaxGas:          confutil.P(int64(100000)),
+		MaxValue:        confutil.P("1000"),
+		MaxCalldataSize: confutil.P("1Kb"),
+	})
+	require.NoError(t, err)
+
+	validTx := func() *components.PublicTxSubmission {
+		return &components.PublicTxSubmission{
+			PublicTxInput: pldapi.PublicTxInput{
+				From: pldtypes.RandAddress(),
+				To:   pldtypes.RandAddress(),
+				Data: pldtypes.RandBytes(1024),
+				PublicTxOptions: pldapi.PublicTxOptions{
+					Gas:   confutil.P(pldtypes.HexUint64(100000)),
+					Value: pldtypes.Uint64ToUint256(1000),
+				},
+			},
+		}
+	}
+	require.NoError(t, v.validateInput(ctx, validTx()))
+
+	for _, tc := range []struct {
+		errCode string
+		modify  func(txi *components.PublicTxSubmission)
+	}{
+		{"PD011973", func(txi *components.PublicTxSubmission) { txi.From = &pldtypes.EthAddress{} }},
+		{"PD011974", func(txi *components.PublicTxSubmission) { txi.To = &pldtypes.EthAddress{} }},
+		{"PD011975", func(txi *components.PublicTxSubmission) { txi.Gas = confutil.P(pldtypes.HexUint64(20999)) }},
+		{"PD011976", func(txi *components.PublicTxSubmission) { txi.Gas = confutil.P(pldtypes.HexUint64(100001)) }},
+		{"PD011977", func(txi *components.PublicTxSubmission) { txi.Value = pldtypes.Uint64ToUint256(1001) }},
+		{"PD011978", func(txi *components.PublicTxSubmission) { txi.Data = pldtypes.RandBytes(1025) }},
+	} {
+		txi := validTx()
+		tc.modify(txi)
+		err := v.validateInput(ctx, txi)
+		assert.Regexp(t, tc.errCode, err)
+		// All are reported as a problem with the request
+		var pdErr i18n.PDError
+		require.True(t, errors.As(err, &pdErr))
+		assert.Equal(t, http.StatusBadRequest, pdErr.HTTPStatus())
+	}
+
+	// A deploy with no gas limit is fine, as it will be estimated
+	txi := validTx()
+	txi.To = nil
+	txi.Gas = nil
+	require.NoError(t, v.validateInput(ctx, txi))
+}
+
+func TestValidationDefaults(t *testing.T) {
+	v, err := newTxValidation(context.Background(), &pldconf.PublicTxValidationConfig{})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(30000000), v.maxGas)
+	assert.Equal(t, int64(128*1024), v.maxCalldataSize)
+	assert.Nil(t, v.maxValue)
+}
+
+func TestValidationBadMaxValue(t *testing.T) {
+	_, err := newTxValidation(context.Background(), &pldconf.PublicTxValidationConfig{
+		MaxValue: confutil.P("-1"),
+	})
+	assert.Regexp(t, "PD011980", err)
+
+	mocks := baseMocks(t)
+	pmgr := NewPublicTransactionManager(context.Background(), &pldconf.PublicTxManagerConfig{
+		Validation: pldconf.PublicTxValidationConfig{
+			MaxValue: confutil.P("lots"),
+		},
+	})
+	err = pmgr.PostInit(mocks.allComponents)
+	assert.Regexp(t, "PD011980", err)
+}
+
+func TestValidateTransactionChainID(t *testing.T) {
+	ctx, ptm, m, done := newTestPublicTxManager(t, false)
+	defer done()
+
+	m.ethClient.On("ChainID").Return(int64(1337))
+
+	err := ptm.ValidateTransaction(ctx, ptm.p.NOTX(), &components.PublicTxSubmission{
+		PublicTxInput: pldapi.PublicTxInput{
+			From: pldtypes.RandAddress(),
+		},
+		ChainID: confutil.P(pldtypes.HexUint64(1)),
+	})
+	assert.Regexp(t, "PD011979", err)
+
+	err = ptm.ValidateTransaction(ctx, ptm.p.NOTX(), &components.PublicTxSubmission{
+		PublicTxInput: pldapi.PublicTxInput{
+			From:            pldtypes.RandAddress(),
+			PublicTxOptions: pldapi.PublicTxOptions{Gas: confutil.P(pldtypes.HexUint64(50000))},
+		},
+		ChainID: confutil.P(pldtypes.HexUint64(1337)),
+	})
+	require.NoError(t, err)
+}
+
+func TestValidateTransactionEstimateAboveMax(t *testing.T) {
+	ctx, ptm, m, done := newTestPublicTxManager(t, false, func(mocks *mocksAndTestControl, conf *pldconf.PublicTxManagerConfig) {
+		conf.Validation.MaxGas = confutil.P(int64(100000))
+	})
+	defer done()
+
+	// The estimate is within the limit, but not once it is padded
+	m.ethClient.On("EstimateGasNoResolve", mock.Anything, mock.Anything, mock.Anything).
+		Return(ethclient.EstimateGasResult{GasLimit: pldtypes.HexUint64(90000)}, nil)
+
+	err := ptm.ValidateTransaction(ctx, ptm.p.NOTX(), &components.PublicTxSubmission{
+		PublicTxInput: pldapi.PublicTxInput{
+			From: pldtypes.RandAddress(),
+			To:   pldtypes.RandAddress(),
+		},
+	})
+	assert.Regexp(t, "PD011976", err)
+}
@@ -0,0 +1,256 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package registrymgr
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/core/internal/msgs"
+	"github.com/kaleido-io/paladin/core/pkg/persistence"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"gorm.io/gorm/clause"
+)
+
+func parseTrustedIssuers(ctx context.Context, regName string, issuers []string) ([]*pldtypes.EthAddress, error) {
+	trusted := make([]*pldtypes.EthAddress, len(issuers))
+	for i, issuer := range issuers {
+		addr, err := pldtypes.ParseEthAddress(issuer)
+		if err != nil {
+			return nil, i18n.WrapError(ctx, err, msgs.MsgRegistryTrustedIssuerInvalid, regName, issuer)
+		}
+		trusted[i] = addr
+	}
+	return trusted, nil
+}
+
+func containsIssuer(issuers []*pldtypes.EthAddress, issuer *pldtypes.EthAddress) bool {
+	for _, i := range issuers {
+		if i.Equals(issuer) {
+			return true
+		}
+	}
+	return false
+}
+
+// If no trusted issuers are configured for the registry, then any issuer with a valid proof is accepted
+func (r *registry) issuerTrusted(issuer *pldtypes.EthAddress) bool {
+	return len(r.trustedIssuers) == 0 || containsIssuer(r.trustedIssuers, issuer)
+}
+
+func (r *registry) credentialCacheKey(entryID pldtypes.HexBytes) string {
+	return r.name + "/" + entryID.String()
+}
+
+func (r *registry) mapCredential(dbc *DBCredential) (*pldapi.RegistryCredential, error) {
+	var cred pldapi.RegistryCredential
+	if err := json.Unmarshal(dbc.Credential, &cred); err != nil {
+		return nil, err
+	}
+	cred.Registry = r.name
+	cred.Attached = &dbc.Attached
+	cred.Revoked = dbc.Revoked
+	return &cred, nil
+}
+
+func (r *registry) AttachCredential(ctx context.Context, dbTX persistence.DBTX, cred *pldapi.RegistryCredential) (*pldapi.RegistryCredential, error) {
+	if cred == nil || cred.ID == "" || len(cred.EntryID) == 0 || cred.Type == "" || cred.Issuer == nil {
+		return nil, i18n.NewError(ctx, msgs.MsgRegistryCredentialMissingFields)
+	}
+	if err := cred.VerifyProof(ctx); err != nil {
+		return nil, i18n.WrapError(ctx, err, msgs.MsgRegistryCredentialProofInvalid, cred.ID)
+	}
+	if !r.issuerTrusted(cred.Issuer) {
+		return nil, i18n.NewError(ctx, msgs.MsgRegistryCredentialIssuerUntrusted, cred.Issuer, r.name)
+	}
+	now := pldtypes.TimestampNow()
+	if cred.ExpirationDate != nil && *cred.ExpirationDate <= now {
+		return nil, i18n.NewError(ctx, msgs.MsgRegistryCredentialExpired, cred.ID, cred.ExpirationDate)
+	}
+
+	var entries []*DBEntry
+	err := dbTX.DB().WithContext(ctx).
+		Where("registry = ?", r.name).
+		Where("id = ?", cred.EntryID).
+		Limit(1).
+		Find(&entries).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, i18n.NewError(ctx, msgs.MsgRegistryEntryNotFound, cred.EntryID, r.name)
+	}
+
+	stored := *cred
+	stored.Registry = r.name
+	stored.Attached = &now
+	stored.Revoked = nil
+	result := dbTX.DB().WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&DBCredential{
+			Registry:   r.name,
+			ID:         stored.ID,
+			EntryID:    stored.EntryID,
+			Type:       stored.Type,
+			Issuer:     *stored.Issuer,
+			Expires:    stored.ExpirationDate,
+			Attached:   now,
+			Credential: pldtypes.JSONString(&stored),
+		})
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, i18n.NewError(ctx, msgs.MsgRegistryCredentialExists, cred.ID, r.name)
+	}
+	log.L(ctx).Infof("Credential '%s' of type '%s' from issuer %s attached to entry '%s' in registry '%s'", stored.ID, stored.Type, stored.Issuer, stored.EntryID, r.name)
+
+	cacheKey := r.credentialCacheKey(stored.EntryID)
+	dbTX.AddPostCommit(func(ctx context.Context) {
+		r.rm.credentialCache.Delete(cacheKey)
+	})
+	return &stored, nil
+}
+
+func (r *registry) GetCredentials(ctx context.Context, dbTX persistence.DBTX, entryID pldtypes.HexBytes, includeRevoked bool) ([]*pldapi.RegistryCredential, error) {
+	q := dbTX.DB().WithContext(ctx).
+		Where("registry = ?", r.name).
+		Where("entry_id = ?", entryID)
+	if !includeRevoked {
+		q = q.Where("revoked IS NULL")
+	}
+	var dbCreds []*DBCredential
+	if err := q.Order("attached").Find(&dbCreds).Error; err != nil {
+		return nil, err
+	}
+	creds := make([]*pldapi.RegistryCredential, len(dbCreds))
+	for i, dbc := range dbCreds {
+		cred, err := r.mapCredential(dbc)
+		if err != nil {
+			return nil, err
+		}
+		creds[i] = cred
+	}
+	return creds, nil
+}
+
+// Revocation is idempotent - revoking a credential that is already revoked leaves the original revocation time
+func (r *registry) RevokeCredential(ctx context.Context, dbTX persistence.DBTX, id string) error {
+	var dbCreds []*DBCredential
+	err := dbTX.DB().WithContext(ctx).
+		Where("registry = ?", r.name).
+		Where("id = ?", id).
+		Limit(1).
+		Find(&dbCreds).
+		Error
+	if err != nil {
+		return err
+	}
+	if len(dbCreds) == 0 {
+		return i18n.NewError(ctx, msgs.MsgRegistryCredentialNotFound, id, r.name)
+	}
+	if dbCreds[0].Revoked != nil {
+		return nil
+	}
+	err = dbTX.DB().WithContext(ctx).
+		Model(&DBCredential{}).
+		Where("registry = ?", r.name).
+		Where("id = ?", id).
+		Update("revoked", pldtypes.TimestampNow()).
+		Error
+	if err != nil {
+		return err
+	}
+	log.L(ctx).Infof("Credential '%s' revoked in registry '%s'", id, r.name)
+
+	cacheKey := r.credentialCacheKey(dbCreds[0].EntryID)
+	dbTX.AddPostCommit(func(ctx context.Context) {
+		r.rm.credentialCache.Delete(cacheKey)
+	})
+	return nil
+}
+
+// Credential checks are frequent (domains can make them on every endorsement), so the un-revoked credentials
+// of each entry are cached. Attach and revoke invalidate the cache, and expiry is evaluated on each check.
+func (r *registry) activeCredentials(ctx context.Context, dbTX persistence.DBTX, entryID pldtypes.HexBytes) ([]*pldapi.RegistryCredential, error) {
+	cacheKey := r.credentialCacheKey(entryID)
+	creds, present := r.rm.credentialCache.Get(cacheKey)
+	if present {
+		return creds, nil
+	}
+	creds, err := r.GetCredentials(ctx, dbTX, entryID, false)
+	if err != nil {
+		return nil, err
+	}
+	r.rm.credentialCache.Set(cacheKey, creds)
+	return creds, nil
+}
+
+func (r *registry) checkCredential(ctx context.Context, dbTX persistence.DBTX, check *pldapi.RegistryCredentialCheck) (*pldapi.RegistryCredentialCheckResult, error) {
+	entryID := check.EntryID
+	entryRef := entryID.String()
+	if len(check.EntryPath) > 0 {
+		entryRef = strings.Join(check.EntryPath, "/")
+		entry, err := r.resolveEntryPath(ctx, dbTX, check.EntryPath)
+		if err != nil {
+			return nil, err
+		}
+		if entry == nil {
+			return &pldapi.RegistryCredentialCheckResult{
+				Reason: i18n.NewError(ctx, msgs.MsgRegistryEntryNotFound, entryRef, r.name).Error(),
+			}, nil
+		}
+		entryID = entry.ID
+	}
+
+	creds, err := r.activeCredentials(ctx, dbTX, entryID)
+	if err != nil {
+		return nil, err
+	}
+	now := pldtypes.TimestampNow()
+	for _, cred := range creds {
+		if cred.Type != check.Type ||
+			(cred.ExpirationDate != nil && *cred.ExpirationDate <= now) ||
+			// the trusted issuers of the registry are re-checked, in case the configuration changed since attach
+			!r.issuerTrusted(cred.Issuer) ||
+			(len(check.Issuers) > 0 && !containsIssuer(check.Issuers, cred.Issuer)) {
+			continue
+		}
+		return &pldapi.RegistryCredentialCheckResult{
+			Valid:      true,
+			Credential: cred,
+		}, nil
+	}
+	return &pldapi.RegistryCredentialCheckResult{
+		Reason: i18n.NewError(ctx, msgs.MsgRegistryCredentialNotHeld, check.Type, entryRef, r.name).Error(),
+	}, nil
+}
+
+func (rm *registryManager) CheckCredential(ctx context.Context, dbTX persistence.DBTX, check *pldapi.RegistryCredentialCheck) (*pldapi.RegistryCredentialCheckResult, error) {
+	if check == nil || check.Registry == "" || check.Type == "" || (len(check.EntryID) == 0) == (len(check.EntryPath) == 0) {
+		return nil, i18n.NewError(ctx, msgs.MsgRegistryCredentialCheckInvalid)
+	}
+	r, err := rm.getRegistry(ctx, check.Registry)
+	if err != nil {
+		return nil, err
+	}
+	return r.checkCredential(ctx, dbTX, check)
+}
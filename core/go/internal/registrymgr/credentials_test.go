@@ -0,0 +1,312 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package registrymgr
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/hyperledger/firefly-signer/pkg/secp256k1"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/pkg/persistence"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestIssuer(t *testing.T) *secp256k1.KeyPair {
+	kp, err := secp256k1.GenerateSecp256k1KeyPair()
+	require.NoError(t, err)
+	return kp
+}
+
+func newSignedCredential(t *testing.T, issuer *secp256k1.KeyPair, entryID pldtypes.HexBytes, credType string) *pldapi.RegistryCredential {
+	cred := &pldapi.RegistryCredential{
+		ID:           pldtypes.RandHex(8),
+		EntryID:      entryID,
+		Type:         credType,
+		Issuer:       (*pldtypes.EthAddress)(&issuer.Address),
+		IssuanceDate: pldtypes.TimestampNow(),
+		Claims:       map[string]string{"level": "enhanced"},
+	}
+	signCredential(t, issuer, cred)
+	return cred
+}
+
+func signCredential(t *testing.T, issuer *secp256k1.KeyPair, cred *pldapi.RegistryCredential) {
+	hash := cred.SigningHash()
+	sig, err := issuer.SignDirect(hash[:])
+	require.NoError(t, err)
+	cred.Proof = sig.CompactRSV()
+}
+
+func TestCredentialsLifecycleRealDB(t *testing.T) {
+	trusted := newTestIssuer(t)
+	untrusted := newTestIssuer(t)
+
+	ctx, rm, tp, _, done := newTestRegistry(t, true, func(mc *mockComponents, conf *pldconf.RegistryManagerConfig, regConf *prototk.RegistryConfig) {
+		conf.Registries["test1"].Credentials.TrustedIssuers = []string{trusted.Address.String()}
+	})
+	defer done()
+
+	rpc, rpcDone := newTestRPCServer(t, ctx, rm)
+	defer rpcDone()
+
+	rootEntry := &prototk.RegistryEntry{Id: randID(), Name: "org1", Active: true}
+	nodeEntry := &prototk.RegistryEntry{Id: randID(), Name: "node1", ParentId: rootEntry.Id, Active: true}
+	_, err := tp.r.UpsertRegistryRecords(ctx, &prototk.UpsertRegistryRecordsRequest{
+		Entries: []*prototk.RegistryEntry{rootEntry, nodeEntry},
+	})
+	require.NoError(t, err)
+	nodeEntryID := pldtypes.MustParseHexBytes(nodeEntry.Id)
+
+	cred := newSignedCredential(t, trusted, nodeEntryID, "KYCPassed")
+	var stored *pldapi.RegistryCredential
+	err = rpc.CallRPC(ctx, &stored, "reg_attachCredential", "test1", cred)
+	require.NoError(t, err)
+	assert.Equal(t, "test1", stored.Registry)
+	assert.NotNil(t, stored.Attached)
+	assert.Equal(t, cred.Claims, stored.Claims)
+
+	err = rpc.CallRPC(ctx, &stored, "reg_attachCredential", "test1", cred)
+	assert.Regexp(t, "PD012113", err)
+
+	err = rpc.CallRPC(ctx, &stored, "reg_attachCredential", "test1", newSignedCredential(t, untrusted, nodeEntryID, "KYCPassed"))
+	assert.Regexp(t, "PD012111", err)
+
+	tampered := newSignedCredential(t, trusted, nodeEntryID, "KYCPassed")
+	tampered.Claims["level"] = "basic"
+	err = rpc.CallRPC(ctx, &stored, "reg_attachCredential", "test1", tampered)
+	assert.Regexp(t, "PD012118", err)
+
+	expired := newSignedCredential(t, trusted, nodeEntryID, "KYCPassed")
+	expiredAt := pldtypes.Timestamp(time.Now().Add(-1 * time.Hour).UnixNano())
+	expired.ExpirationDate = &expiredAt
+	signCredential(t, trusted, expired)
+	err = rpc.CallRPC(ctx, &stored, "reg_attachCredential", "test1", expired)
+	assert.Regexp(t, "PD012112", err)
+
+	err = rpc.CallRPC(ctx, &stored, "reg_attachCredential", "test1", newSignedCredential(t, trusted, pldtypes.RandBytes(32), "KYCPassed"))
+	assert.Regexp(t, "PD012115", err)
+
+	err = rpc.CallRPC(ctx, &stored, "reg_attachCredential", "test1", &pldapi.RegistryCredential{ID: "missing.fields"})
+	assert.Regexp(t, "PD012110", err)
+
+	err = rpc.CallRPC(ctx, &stored, "reg_attachCredential", "unknown", cred)
+	assert.Regexp(t, "PD012101", err)
+
+	// Check by path and by ID
+	var result *pldapi.RegistryCredentialCheckResult
+	err = rpc.CallRPC(ctx, &result, "reg_checkCredential", &pldapi.RegistryCredentialCheck{
+		Registry:  "test1",
+		EntryPath: []string{"org1", "node1"},
+		Type:      "KYCPassed",
+	})
+	require.NoError(t, err)
+	assert.True(t, result.Valid)
+	assert.Equal(t, cred.ID, result.Credential.ID)
+	_, cached := rm.credentialCache.Get(tp.r.credentialCacheKey(nodeEntryID))
+	assert.True(t, cached)
+
+	err = rpc.CallRPC(ctx, &result, "reg_checkCredential", &pldapi.RegistryCredentialCheck{
+		Registry: "test1",
+		EntryID:  nodeEntryID,
+		Type:     "KYCPassed",
+		Issuers:  []*pldtypes.EthAddress{(*pldtypes.EthAddress)(&trusted.Address)},
+	})
+	require.NoError(t, err)
+	assert.True(t, result.Valid)
+
+	// Checks that do not match
+	err = rpc.CallRPC(ctx, &result, "reg_checkCredential", &pldapi.RegistryCredentialCheck{
+		Registry: "test1",
+		EntryID:  nodeEntryID,
+		Type:     "KYCPassed",
+		Issuers:  []*pldtypes.EthAddress{(*pldtypes.EthAddress)(&untrusted.Address)},
+	})
+	require.NoError(t, err)
+	assert.False(t, result.Valid)
+	assert.Regexp(t, "PD012119", result.Reason)
+
+	err = rpc.CallRPC(ctx, &result, "reg_checkCredential", &pldapi.RegistryCredentialCheck{
+		Registry: "test1",
+		EntryID:  nodeEntryID,
+		Type:     "Accredited",
+	})
+	require.NoError(t, err)
+	assert.False(t, result.Valid)
+
+	err = rpc.CallRPC(ctx, &result, "reg_checkCredential", &pldapi.RegistryCredentialCheck{
+		Registry:  "test1",
+		EntryPath: []string{"org1", "node2"},
+		Type:      "KYCPassed",
+	})
+	require.NoError(t, err)
+	assert.False(t, result.Valid)
+	assert.Regexp(t, "PD012115.*org1/node2", result.Reason)
+
+	err = rpc.CallRPC(ctx, &result, "reg_checkCredential", &pldapi.RegistryCredentialCheck{
+		Registry:  "test1",
+		EntryID:   nodeEntryID,
+		EntryPath: []string{"org1", "node1"},
+		Type:      "KYCPassed",
+	})
+	assert.Regexp(t, "PD012117", err)
+
+	err = rpc.CallRPC(ctx, &result, "reg_checkCredential", &pldapi.RegistryCredentialCheck{
+		Registry: "unknown",
+		EntryID:  nodeEntryID,
+		Type:     "KYCPassed",
+	})
+	assert.Regexp(t, "PD012101", err)
+
+	var creds []*pldapi.RegistryCredential
+	err = rpc.CallRPC(ctx, &creds, "reg_getCredentials", "test1", nodeEntryID, false)
+	require.NoError(t, err)
+	require.Len(t, creds, 1)
+	assert.Equal(t, cred.ID, creds[0].ID)
+	require.NoError(t, creds[0].VerifyProof(ctx))
+
+	// Revoke, which is idempotent, and clears the cache
+	var success bool
+	err = rpc.CallRPC(ctx, &success, "reg_revokeCredential", "test1", cred.ID)
+	require.NoError(t, err)
+	assert.True(t, success)
+	_, cached = rm.credentialCache.Get(tp.r.credentialCacheKey(nodeEntryID))
+	assert.False(t, cached)
+
+	err = rpc.CallRPC(ctx, &success, "reg_revokeCredential", "test1", cred.ID)
+	require.NoError(t, err)
+	assert.True(t, success)
+
+	err = rpc.CallRPC(ctx, &success, "reg_revokeCredential", "test1", "unknown")
+	assert.Regexp(t, "PD012114", err)
+
+	err = rpc.CallRPC(ctx, &result, "reg_checkCredential", &pldapi.RegistryCredentialCheck{
+		Registry: "test1",
+		EntryID:  nodeEntryID,
+		Type:     "KYCPassed",
+	})
+	require.NoError(t, err)
+	assert.False(t, result.Valid)
+
+	err = rpc.CallRPC(ctx, &creds, "reg_getCredentials", "test1", nodeEntryID, false)
+	require.NoError(t, err)
+	assert.Empty(t, creds)
+
+	err = rpc.CallRPC(ctx, &creds, "reg_getCredentials", "test1", nodeEntryID, true)
+	require.NoError(t, err)
+	require.Len(t, creds, 1)
+	assert.NotNil(t, creds[0].Revoked)
+}
+
+func TestCredentialExpiresAfterAttachRealDB(t *testing.T) {
+	issuer := newTestIssuer(t)
+	ctx, rm, tp, _, done := newTestRegistry(t, true)
+	defer done()
+
+	entry := &prototk.RegistryEntry{Id: randID(), Name: "node1", Active: true}
+	_, err := tp.r.UpsertRegistryRecords(ctx, &prototk.UpsertRegistryRecordsRequest{
+		Entries: []*prototk.RegistryEntry{entry},
+	})
+	require.NoError(t, err)
+	entryID := pldtypes.MustParseHexBytes(entry.Id)
+
+	cred := newSignedCredential(t, issuer, entryID, "KYCPassed")
+	expires := pldtypes.Timestamp(time.Now().Add(100 * time.Millisecond).UnixNano())
+	cred.ExpirationDate = &expires
+	signCredential(t, issuer, cred)
+	err = rm.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
+		_, err := tp.r.AttachCredential(ctx, dbTX, cred)
+		return err
+	})
+	require.NoError(t, err)
+
+	check := &pldapi.RegistryCredentialCheck{Registry: "test1", EntryID: entryID, Type: "KYCPassed"}
+	result, err := rm.CheckCredential(ctx, rm.p.NOTX(), check)
+	require.NoError(t, err)
+	assert.True(t, result.Valid)
+
+	// Expiry is evaluated on each check, even when the credential is cached
+	time.Sleep(time.Until(time.Unix(0, int64(expires))))
+	result, err = rm.CheckCredential(ctx, rm.p.NOTX(), check)
+	require.NoError(t, err)
+	assert.False(t, result.Valid)
+}
+
+func TestBadTrustedIssuer(t *testing.T) {
+	_, rm, mc, done := newTestRegistryManager(t, false, &pldconf.RegistryManagerConfig{
+		Registries: map[string]*pldconf.RegistryConfig{
+			"test1": {
+				Credentials: pldconf.RegistryCredentialsConfig{
+					TrustedIssuers: []string{"wrong"},
+				},
+			},
+		},
+	}, func(mc *mockComponents) { mc.noInit = true })
+	defer done()
+
+	_, err := rm.PreInit(mc.allComponents)
+	require.Regexp(t, "PD012116.*test1", err)
+}
+
+func TestCredentialsDBErrors(t *testing.T) {
+	issuer := newTestIssuer(t)
+	ctx, rm, tp, m, done := newTestRegistry(t, false)
+	defer done()
+
+	entryID := pldtypes.RandBytes(32)
+	cred := newSignedCredential(t, issuer, entryID, "KYCPassed")
+
+	m.db.ExpectQuery("SELECT.*reg_entries").WillReturnError(fmt.Errorf("pop"))
+	_, err := tp.r.AttachCredential(ctx, rm.p.NOTX(), cred)
+	assert.Regexp(t, "pop", err)
+
+	m.db.ExpectQuery("SELECT.*reg_entries").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(entryID))
+	m.db.ExpectExec("INSERT.*reg_credentials").WillReturnError(fmt.Errorf("pop"))
+	_, err = tp.r.AttachCredential(ctx, rm.p.NOTX(), cred)
+	assert.Regexp(t, "pop", err)
+
+	m.db.ExpectQuery("SELECT.*reg_credentials").WillReturnError(fmt.Errorf("pop"))
+	_, err = tp.r.GetCredentials(ctx, rm.p.NOTX(), entryID, true)
+	assert.Regexp(t, "pop", err)
+
+	m.db.ExpectQuery("SELECT.*reg_credentials").WillReturnRows(sqlmock.NewRows([]string{"id", "credential"}).AddRow("cred1", "!!!wrong"))
+	_, err = tp.r.GetCredentials(ctx, rm.p.NOTX(), entryID, true)
+	assert.Error(t, err)
+
+	m.db.ExpectQuery("SELECT.*reg_credentials").WillReturnError(fmt.Errorf("pop"))
+	err = tp.r.RevokeCredential(ctx, rm.p.NOTX(), "cred1")
+	assert.Regexp(t, "pop", err)
+
+	m.db.ExpectQuery("SELECT.*reg_credentials").WillReturnRows(sqlmock.NewRows([]string{"id", "entry_id"}).AddRow("cred1", entryID))
+	m.db.ExpectExec("UPDATE.*reg_credentials").WillReturnError(fmt.Errorf("pop"))
+	err = tp.r.RevokeCredential(ctx, rm.p.NOTX(), "cred1")
+	assert.Regexp(t, "pop", err)
+
+	m.db.ExpectQuery("SELECT.*reg_entries").WillReturnError(fmt.Errorf("pop"))
+	_, err = rm.CheckCredential(ctx, rm.p.NOTX(), &pldapi.RegistryCredentialCheck{Registry: "test1", EntryPath: []string{"node1"}, Type: "KYCPassed"})
+	assert.Regexp(t, "pop", err)
+
+	m.db.ExpectQuery("SELECT.*reg_credentials").WillReturnError(fmt.Errorf("pop"))
+	_, err = rm.CheckCredential(ctx, rm.p.NOTX(), &pldapi.RegistryCredentialCheck{Registry: "test1", EntryID: entryID, Type: "KYCPassed"})
+	assert.Regexp(t, "pop", err)
+}
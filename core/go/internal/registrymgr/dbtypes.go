@@ -54,3 +54,19 @@ type DBProperty struct {
 func (dbe DBProperty) TableName() string {
 	return "reg_props"
 }
+
+type DBCredential struct {
+	Registry   string              `gorm:"column:registry;primaryKey"`
+	ID         string              `gorm:"column:id;primaryKey"`
+	EntryID    pldtypes.HexBytes   `gorm:"column:entry_id"`
+	Type       string              `gorm:"column:type"`
+	Issuer     pldtypes.EthAddress `gorm:"column:issuer"`
+	Expires    *pldtypes.Timestamp `gorm:"column:expires"`
+	Attached   pldtypes.Timestamp  `gorm:"column:attached"`
+	Revoked    *pldtypes.Timestamp `gorm:"column:revoked"`
+	Credential pldtypes.RawJSON    `gorm:"column:credential"`
+}
+
+func (dbc DBCredential) TableName() string {
+	return "reg_credentials"
+}
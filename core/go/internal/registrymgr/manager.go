@@ -27,6 +27,8 @@ import (
 	"github.com/kaleido-io/paladin/core/internal/msgs"
 	"github.com/kaleido-io/paladin/core/pkg/blockindexer"
 	"github.com/kaleido-io/paladin/core/pkg/persistence"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
 
 	"github.com/kaleido-io/paladin/common/go/pkg/log"
 	"github.com/kaleido-io/paladin/toolkit/pkg/cache"
@@ -47,10 +49,16 @@ type registryManager struct {
 	// We provide a high level of customization of how the nodes are looked up in the registry
 	registryTransportLookups map[string]*transportLookup
 
+	// Issuers whose credentials can be attached to entries in each registry (empty means any issuer)
+	registryTrustedIssuers map[string][]*pldtypes.EthAddress
+
 	// Due to the high frequency of calls to the registry for node details, we maintain
 	// a cache of resolved nodes by name - which is a global index, across all registries.
 	transportDetailsCache cache.Cache[string, []*components.RegistryNodeTransportEntry]
 
+	// Un-revoked credentials attached to each entry, keyed by registry name and entry ID
+	credentialCache cache.Cache[string, []*pldapi.RegistryCredential]
+
 	registriesByID   map[uuid.UUID]*registry
 	registriesByName map[string]*registry
 }
@@ -62,7 +70,9 @@ func NewRegistryManager(bgCtx context.Context, conf *pldconf.RegistryManagerConf
 		registriesByID:           make(map[uuid.UUID]*registry),
 		registriesByName:         make(map[string]*registry),
 		registryTransportLookups: make(map[string]*transportLookup),
-		transportDetailsCache:    cache.NewCache[string, []*components.RegistryNodeTransportEntry](&conf.RegistryManager.RegistryCache, pldconf.RegistryCacheDefaults),
+		registryTrustedIssuers:   make(map[string][]*pldtypes.EthAddress),
+		transportDetailsCache:    cache.NewNamedCache[string, []*components.RegistryNodeTransportEntry]("registrymgr.transportDetails", &conf.RegistryManager.RegistryCache, pldconf.RegistryCacheDefaults),
+		credentialCache:          cache.NewNamedCache[string, []*pldapi.RegistryCredential]("registrymgr.credentials", &conf.RegistryManager.CredentialCache, pldconf.RegistryCredentialCacheDefaults),
 	}
 }
 
@@ -77,6 +87,9 @@ func (rm *registryManager) PreInit(pic components.PreInitComponents) (_ *compone
 			}
 			log.L(rm.bgCtx).Infof("Transport lookups enabled for registry '%s' with matcher '%s'", regName, rm.registryTransportLookups[regName].propertyRegexp)
 		}
+		if rm.registryTrustedIssuers[regName], err = parseTrustedIssuers(rm.bgCtx, regName, regConf.Credentials.TrustedIssuers); err != nil {
+			return nil, err
+		}
 	}
 	rm.initRPC()
 	return &components.ManagerInitResult{
@@ -149,6 +162,10 @@ func (rm *registryManager) RegistryRegistered(name string, id uuid.UUID, toRegis
 }
 
 func (rm *registryManager) GetRegistry(ctx context.Context, name string) (components.Registry, error) {
+	return rm.getRegistry(ctx, name)
+}
+
+func (rm *registryManager) getRegistry(ctx context.Context, name string) (*registry, error) {
 	rm.mux.Lock()
 	defer rm.mux.Unlock()
 
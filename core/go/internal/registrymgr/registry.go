@@ -50,6 +50,8 @@ type registry struct {
 	name string
 	api  components.RegistryManagerToRegistry
 
+	trustedIssuers []*pldtypes.EthAddress
+
 	initialized atomic.Bool
 	initRetry   *retry.Retry
 
@@ -62,13 +64,14 @@ type registry struct {
 
 func (rm *registryManager) newRegistry(id uuid.UUID, name string, conf *pldconf.RegistryConfig, toRegistry components.RegistryManagerToRegistry) *registry {
 	r := &registry{
-		rm:        rm,
-		conf:      conf,
-		initRetry: retry.NewRetryIndefinite(&conf.Init.Retry),
-		name:      name,
-		id:        id,
-		api:       toRegistry,
-		initDone:  make(chan struct{}),
+		rm:             rm,
+		conf:           conf,
+		initRetry:      retry.NewRetryIndefinite(&conf.Init.Retry),
+		name:           name,
+		id:             id,
+		api:            toRegistry,
+		trustedIssuers: rm.registryTrustedIssuers[name],
+		initDone:       make(chan struct{}),
 	}
 	r.ctx, r.cancelCtx = context.WithCancel(log.WithLogField(rm.bgCtx, "registry", r.name))
 	return r
@@ -541,6 +544,31 @@ func (r *registry) QueryEntriesWithProps(ctx context.Context, dbTX persistence.D
 	return withProps, nil
 }
 
+// Resolves each name in the path in turn, from a root entry down to the leaf. Returns nil if any level does not exist.
+func (r *registry) resolveEntryPath(ctx context.Context, dbTX persistence.DBTX, path []string) (*pldapi.RegistryEntryWithProperties, error) {
+	var lookupParentID pldtypes.HexBytes
+	var entry *pldapi.RegistryEntryWithProperties
+	for _, entryName := range path {
+		q := query.NewQueryBuilder().Equal(".name", entryName).Limit(1)
+		if lookupParentID == nil {
+			q = q.Null(".parentId")
+		} else {
+			q = q.Equal(".parentId", lookupParentID)
+		}
+		entries, err := r.QueryEntriesWithProps(ctx, dbTX, pldapi.ActiveFilterActive, q.Query())
+		if err != nil {
+			return nil, err
+		}
+		if len(entries) == 0 {
+			log.L(ctx).Infof("Entry '%s' not found in registry '%s' (path=%v,parentId='%s')", entryName, r.name, path, lookupParentID)
+			return nil, nil
+		}
+		entry = entries[0]
+		lookupParentID = entry.ID
+	}
+	return entry, nil
+}
+
 func (r *registry) close() {
 	r.cancelCtx()
 	<-r.initDone
@@ -20,6 +20,7 @@ import (
 	"context"
 
 	"github.com/kaleido-io/paladin/core/internal/components"
+	"github.com/kaleido-io/paladin/core/pkg/persistence"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/query"
@@ -35,7 +36,11 @@ func (rm *registryManager) initRPC() {
 		Add("reg_registries", rm.rpcListRegistries()).
 		Add("reg_queryEntries", rm.rpcQueryEntries()).
 		Add("reg_queryEntriesWithProps", rm.rpcQueryEntriesWithProps()).
-		Add("reg_getEntryProperties", rm.rpcGetEntryProperties())
+		Add("reg_getEntryProperties", rm.rpcGetEntryProperties()).
+		Add("reg_attachCredential", rm.rpcAttachCredential()).
+		Add("reg_getCredentials", rm.rpcGetCredentials()).
+		Add("reg_revokeCredential", rm.rpcRevokeCredential()).
+		Add("reg_checkCredential", rm.rpcCheckCredential())
 }
 
 func (rm *registryManager) rpcListRegistries() rpcserver.RPCHandler {
@@ -94,3 +99,58 @@ func (rm *registryManager) rpcGetEntryProperties() rpcserver.RPCHandler {
 		)
 	})
 }
+
+func (rm *registryManager) rpcAttachCredential() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod2(func(ctx context.Context,
+		registryName string,
+		credential *pldapi.RegistryCredential,
+	) (stored *pldapi.RegistryCredential, err error) {
+		return withRegistry(ctx, rm, registryName,
+			func(r components.Registry) (*pldapi.RegistryCredential, error) {
+				err := rm.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) (err error) {
+					stored, err = r.AttachCredential(ctx, dbTX, credential)
+					return err
+				})
+				return stored, err
+			},
+		)
+	})
+}
+
+func (rm *registryManager) rpcGetCredentials() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod3(func(ctx context.Context,
+		registryName string,
+		entryID pldtypes.HexBytes,
+		includeRevoked bool,
+	) ([]*pldapi.RegistryCredential, error) {
+		return withRegistry(ctx, rm, registryName,
+			func(r components.Registry) ([]*pldapi.RegistryCredential, error) {
+				return r.GetCredentials(ctx, rm.p.NOTX(), entryID, includeRevoked)
+			},
+		)
+	})
+}
+
+func (rm *registryManager) rpcRevokeCredential() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod2(func(ctx context.Context,
+		registryName string,
+		credentialID string,
+	) (bool, error) {
+		return withRegistry(ctx, rm, registryName,
+			func(r components.Registry) (bool, error) {
+				err := rm.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
+					return r.RevokeCredential(ctx, dbTX, credentialID)
+				})
+				return err == nil, err
+			},
+		)
+	})
+}
+
+func (rm *registryManager) rpcCheckCredential() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod1(func(ctx context.Context,
+		check *pldapi.RegistryCredentialCheck,
+	) (*pldapi.RegistryCredentialCheckResult, error) {
+		return rm.CheckCredential(ctx, rm.p.NOTX(), check)
+	})
+}
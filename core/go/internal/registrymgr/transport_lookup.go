@@ -27,9 +27,6 @@ import (
 	"github.com/kaleido-io/paladin/core/internal/components"
 	"github.com/kaleido-io/paladin/core/internal/msgs"
 	"github.com/kaleido-io/paladin/core/pkg/persistence"
-	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
-	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
-	"github.com/kaleido-io/paladin/sdk/go/pkg/query"
 )
 
 type transportLookup struct {
@@ -80,27 +77,10 @@ func (tl *transportLookup) getNodeTransports(ctx context.Context, dbTX persisten
 		hierarchy = strings.Split(lookup, tl.hierarchySplitter)
 	}
 
-	// Resolve all the items in the hierarchy to find the leaf
-	var lookupParentID pldtypes.HexBytes
-	var entry *pldapi.RegistryEntryWithProperties
-	for _, entryName := range hierarchy {
-		q := query.NewQueryBuilder().Equal(".name", entryName).Limit(1)
-		if lookupParentID == nil {
-			q = q.Null(".parentId")
-		} else {
-			q = q.Equal(".parentId", lookupParentID)
-		}
-		entries, err := r.QueryEntriesWithProps(ctx, dbTX, pldapi.ActiveFilterActive, q.Query())
-		if err != nil {
-			return nil, err
-		}
-		if len(entries) == 0 {
-			log.L(ctx).Infof("Node lookup '%s' did not match an entry in registry '%s' (fullLookup='%s',requiredPrefix='%s',parentId='%s')",
-				entryName, tl.regName, lookup, tl.requiredPrefix, lookupParentID)
-			return nil, nil
-		}
-		entry = entries[0]
-		lookupParentID = entry.ID
+	entry, err := r.resolveEntryPath(ctx, dbTX, hierarchy)
+	if err != nil || entry == nil {
+		log.L(ctx).Infof("Node lookup '%s' did not resolve in registry '%s' (requiredPrefix='%s')", fullLookup, tl.regName, tl.requiredPrefix)
+		return nil, err
 	}
 
 	// We now have a node that we trust with a matching name, go through the properties to find matching transports.
@@ -375,10 +375,12 @@ func (as *abiSchema) ProcessState(ctx context.Context, contractAddress *pldtypes
 	for i := range psd.labels {
 		psd.labels[i].DomainName = as.Schema.DomainName
 		psd.labels[i].State = id
+		psd.labels[i].ContractAddress = contractAddress
 	}
 	for i := range psd.int64Labels {
 		psd.int64Labels[i].DomainName = as.Schema.DomainName
 		psd.int64Labels[i].State = id
+		psd.int64Labels[i].ContractAddress = contractAddress
 	}
 
 	now := pldtypes.TimestampNow()
@@ -400,6 +402,13 @@ func (as *abiSchema) ProcessState(ctx context.Context, contractAddress *pldtypes
 }
 
 func (as *abiSchema) RecoverLabels(ctx context.Context, s *pldapi.State) (*components.StateWithLabels, error) {
+	if s.Data.IsNil() {
+		// The data has been purged, so only the base labels remain
+		return &components.StateWithLabels{
+			State:       s,
+			LabelValues: addStateBaseLabels(make(filters.PassthroughValueSet), s.ID, s.Created),
+		}, nil
+	}
 	psd, err := as.parseStateData(ctx, s.Data)
 	if err != nil {
 		return nil, err
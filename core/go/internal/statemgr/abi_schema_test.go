@@ -156,24 +156,24 @@ func TestStoreRetrieveABISchema(t *testing.T) {
 	state1 := states[0]
 	assert.Equal(t, []*pldapi.StateLabel{
 		// uint256 written as zero padded string
-		{DomainName: "domain1", State: state1.ID, Label: "field1", Value: "0000000000000000000000000123456789012345678901234567890123456789"},
+		{DomainName: "domain1", State: state1.ID, ContractAddress: contractAddress, Label: "field1", Value: "0000000000000000000000000123456789012345678901234567890123456789"},
 		// string written as it is
-		{DomainName: "domain1", State: state1.ID, Label: "field2", Value: "hello world"},
+		{DomainName: "domain1", State: state1.ID, ContractAddress: contractAddress, Label: "field2", Value: "hello world"},
 		// address is really a uint160, so that's how we handle it
-		{DomainName: "domain1", State: state1.ID, Label: "field5", Value: "000000000000000000000000687414c0b8b4182b823aec5436965cf19b197386"},
+		{DomainName: "domain1", State: state1.ID, ContractAddress: contractAddress, Label: "field5", Value: "000000000000000000000000687414c0b8b4182b823aec5436965cf19b197386"},
 		// int256 needs an extra byte ahead of the zero-padded string to say it's negative,
 		// and is two's complement for that negative number so less negative number are string "higher"
-		{DomainName: "domain1", State: state1.ID, Label: "field6", Value: "0ffffffffffffffffffffffffffffffffffffffffffffffffffdbc0638301b8e7"},
+		{DomainName: "domain1", State: state1.ID, ContractAddress: contractAddress, Label: "field6", Value: "0ffffffffffffffffffffffffffffffffffffffffffffffffffdbc0638301b8e7"},
 		// bytes are just bytes
-		{DomainName: "domain1", State: state1.ID, Label: "field7", Value: "feedbeef"},
+		{DomainName: "domain1", State: state1.ID, ContractAddress: contractAddress, Label: "field7", Value: "feedbeef"},
 	}, state1.Labels)
 	assert.Equal(t, []*pldapi.StateInt64Label{
 		// int64 can just be stored directly in a numeric index
-		{DomainName: "domain1", State: state1.ID, Label: "field3", Value: 42},
+		{DomainName: "domain1", State: state1.ID, ContractAddress: contractAddress, Label: "field3", Value: 42},
 		// bool also gets an efficient numeric index - we don't attempt to allocate anything smaller than int64 to this
-		{DomainName: "domain1", State: state1.ID, Label: "field4", Value: 1},
+		{DomainName: "domain1", State: state1.ID, ContractAddress: contractAddress, Label: "field4", Value: 1},
 		// uint32 also
-		{DomainName: "domain1", State: state1.ID, Label: "field8", Value: 12345},
+		{DomainName: "domain1", State: state1.ID, ContractAddress: contractAddress, Label: "field8", Value: 12345},
 	}, state1.Int64Labels)
 	assert.Equal(t, "0x90c1f63e32a708ef59b3708c57d165a87bddf758709313c57448e85a10c59544", state1.ID.String())
 
@@ -0,0 +1,282 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statemgr
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hyperledger/firefly-signer/pkg/abi"
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/internal/msgs"
+	"github.com/kaleido-io/paladin/core/pkg/persistence"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/toolkit/pkg/rpcserver"
+)
+
+// The function on the StateAnchorRegistry contract that records each root
+var anchorFunctionABI = &abi.Entry{
+	Type: abi.Function,
+	Name: "anchor",
+	Inputs: abi.ParameterArray{
+		{Name: "domain", Type: "string"},
+		{Name: "root", Type: "bytes32"},
+		{Name: "leafCount", Type: "uint256"},
+	},
+}
+
+type persistedStateAnchor struct {
+	ID              uuid.UUID           `gorm:"column:id;primaryKey"`
+	Created         pldtypes.Timestamp  `gorm:"column:created"`
+	DomainName      string              `gorm:"column:domain_name"`
+	Root            pldtypes.Bytes32    `gorm:"column:root"`
+	LeafCount       int                 `gorm:"column:leaf_count"`
+	ContractAddress pldtypes.EthAddress `gorm:"column:contract_address"`
+	Transaction     uuid.UUID           `gorm:"column:transaction"`
+}
+
+func (persistedStateAnchor) TableName() string {
+	return "state_anchors"
+}
+
+type persistedStateAnchorLeaf struct {
+	DomainName string            `gorm:"column:domain_name;primaryKey"`
+	State      pldtypes.HexBytes `gorm:"column:state;primaryKey"`
+	Anchor     uuid.UUID         `gorm:"column:anchor"`
+	LeafIndex  int               `gorm:"column:leaf_index"`
+}
+
+func (persistedStateAnchorLeaf) TableName() string {
+	return "state_anchor_leaves"
+}
+
+// Anchoring runs as a recurring job in the job scheduler, so that when replicas share a database
+// only one of them submits each anchor.
+const stateAnchoringJobType = "state_anchoring"
+
+type stateAnchorer struct {
+	ss              *stateManager
+	interval        time.Duration
+	contractAddress pldtypes.EthAddress
+	from            string
+	batchMaxSize    int
+}
+
+func newStateAnchorer(ctx context.Context, ss *stateManager, conf *pldconf.StateAnchoringConfig) (*stateAnchorer, error) {
+	if !confutil.Bool(conf.Enabled, *pldconf.StateAnchoringDefaults.Enabled) {
+		return nil, nil
+	}
+	contractAddress, err := pldtypes.ParseEthAddress(conf.ContractAddress)
+	if err != nil || conf.From == "" {
+		return nil, i18n.NewError(ctx, msgs.MsgStateAnchoringInvalidConfig)
+	}
+	return &stateAnchorer{
+		ss:              ss,
+		interval:        confutil.DurationMin(conf.Interval, 0, *pldconf.StateAnchoringDefaults.Interval),
+		contractAddress: *contractAddress,
+		from:            conf.From,
+		batchMaxSize:    confutil.IntMin(conf.BatchMaxSize, 1, *pldconf.StateAnchoringDefaults.BatchMaxSize),
+	}, nil
+}
+
+func (sa *stateAnchorer) schedule() string {
+	return "@every " + sa.interval.String()
+}
+
+// Keeps going while there are full batches. Any failure is retried by the job scheduler.
+func (sa *stateAnchorer) runJob(ctx context.Context, _ *pldapi.ScheduledJob) error {
+	for more := true; more; {
+		var err error
+		if more, err = sa.anchorPending(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Anchors the next batch of confirmed states that are not yet in an anchor, with one anchor per domain
+func (sa *stateAnchorer) anchorPending(ctx context.Context) (more bool, err error) {
+	var pending []*persistedStateAnchorLeaf
+	err = sa.ss.p.DB().
+		WithContext(ctx).
+		Table("state_confirm_records").
+		Select(`"state_confirm_records"."domain_name", "state_confirm_records"."state"`).
+		Joins(`LEFT JOIN "state_anchor_leaves" ON "state_anchor_leaves"."domain_name" = "state_confirm_records"."domain_name" AND "state_anchor_leaves"."state" = "state_confirm_records"."state"`).
+		Where(`"state_anchor_leaves"."anchor" IS NULL`).
+		Order(`"state_confirm_records"."domain_name"`).
+		Limit(sa.batchMaxSize).
+		Scan(&pending).
+		Error
+	if err != nil {
+		return false, err
+	}
+
+	byDomain := make(map[string][]pldtypes.HexBytes)
+	domains := []string{}
+	for _, p := range pending {
+		if _, exists := byDomain[p.DomainName]; !exists {
+			domains = append(domains, p.DomainName)
+		}
+		byDomain[p.DomainName] = append(byDomain[p.DomainName], p.State)
+	}
+	for _, domainName := range domains {
+		if err := sa.anchorDomain(ctx, domainName, byDomain[domainName]); err != nil {
+			return false, err
+		}
+	}
+	return len(pending) == sa.batchMaxSize, nil
+}
+
+func (sa *stateAnchorer) anchorDomain(ctx context.Context, domainName string, stateIDs []pldtypes.HexBytes) error {
+	// Leaves are in a deterministic order, so the tree can be rebuilt from the DB to generate proofs
+	sort.Slice(stateIDs, func(i, j int) bool { return bytes.Compare(stateIDs[i], stateIDs[j]) < 0 })
+	leaves := make([]pldtypes.Bytes32, len(stateIDs))
+	for i, stateID := range stateIDs {
+		leaves[i] = merkleLeaf(stateID)
+	}
+	root, _ := merkleRootAndProof(leaves, -1)
+
+	anchor := &persistedStateAnchor{
+		ID:              uuid.New(),
+		Created:         pldtypes.TimestampNow(),
+		DomainName:      domainName,
+		Root:            root,
+		LeafCount:       len(leaves),
+		ContractAddress: sa.contractAddress,
+	}
+	anchorLeaves := make([]*persistedStateAnchorLeaf, len(stateIDs))
+	for i, stateID := range stateIDs {
+		anchorLeaves[i] = &persistedStateAnchorLeaf{
+			DomainName: domainName,
+			State:      stateID,
+			Anchor:     anchor.ID,
+			LeafIndex:  i,
+		}
+	}
+
+	// The public transaction is submitted in the same DB transaction that records the leaves
+	return sa.ss.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
+		txIDs, err := sa.ss.txManager.SendTransactions(ctx, dbTX, &pldapi.TransactionInput{
+			TransactionBase: pldapi.TransactionBase{
+				IdempotencyKey: "state_anchor_" + anchor.ID.String(),
+				Type:           pldapi.TransactionTypePublic.Enum(),
+				Function:       anchorFunctionABI.String(),
+				From:           sa.from,
+				To:             &sa.contractAddress,
+				Data: pldtypes.JSONString(map[string]any{
+					"domain":    domainName,
+					"root":      root,
+					"leafCount": len(leaves),
+				}),
+			},
+			ABI: abi.ABI{anchorFunctionABI},
+		})
+		if err == nil {
+			anchor.Transaction = txIDs[0]
+			err = dbTX.DB().WithContext(ctx).Create(anchor).Error
+		}
+		if err == nil {
+			err = dbTX.DB().WithContext(ctx).Create(anchorLeaves).Error
+		}
+		if err == nil {
+			log.L(ctx).Infof("Anchoring %d states in domain %s with root %s (anchor=%s tx=%s)", len(leaves), domainName, root, anchor.ID, anchor.Transaction)
+		}
+		return err
+	})
+}
+
+func (ss *stateManager) GetStateAnchorProof(ctx context.Context, dbTX persistence.DBTX, domainName string, stateID pldtypes.HexBytes) (*pldapi.StateAnchorProof, error) {
+	if rpcserver.TenantFromContext(ctx) != nil {
+		// Tenants only get proofs for the states of their own contracts
+		var count int64
+		err := tenantContractScope(ctx, dbTX, dbTX.DB().WithContext(ctx).Table("states")).
+			Where(`"states"."domain_name" = ?`, domainName).
+			Where(`"states"."id" = ?`, stateID).
+			Count(&count).
+			Error
+		if err != nil || count == 0 {
+			return nil, err
+		}
+	}
+
+	var leafRecords []*persistedStateAnchorLeaf
+	err := dbTX.DB().
+		WithContext(ctx).
+		Where("domain_name = ?", domainName).
+		Where("state = ?", stateID).
+		Limit(1).
+		Find(&leafRecords).
+		Error
+	if err != nil || len(leafRecords) == 0 {
+		return nil, err
+	}
+	leafRecord := leafRecords[0]
+
+	var anchors []*persistedStateAnchor
+	err = dbTX.DB().
+		WithContext(ctx).
+		Where("id = ?", leafRecord.Anchor).
+		Limit(1).
+		Find(&anchors).
+		Error
+	if err != nil || len(anchors) == 0 {
+		return nil, err
+	}
+	anchor := anchors[0]
+
+	var anchorLeaves []*persistedStateAnchorLeaf
+	err = dbTX.DB().
+		WithContext(ctx).
+		Where("anchor = ?", anchor.ID).
+		Order("leaf_index").
+		Find(&anchorLeaves).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	leaves := make([]pldtypes.Bytes32, len(anchorLeaves))
+	for i, l := range anchorLeaves {
+		leaves[i] = merkleLeaf(l.State)
+	}
+	root, proof := merkleRootAndProof(leaves, leafRecord.LeafIndex)
+	if root != anchor.Root {
+		return nil, i18n.NewError(ctx, msgs.MsgStateAnchorRootMismatch, root, anchor.ID, anchor.Root)
+	}
+
+	return &pldapi.StateAnchorProof{
+		Anchor: &pldapi.StateAnchor{
+			ID:              anchor.ID,
+			Created:         anchor.Created,
+			Domain:          anchor.DomainName,
+			Root:            anchor.Root,
+			LeafCount:       anchor.LeafCount,
+			ContractAddress: anchor.ContractAddress,
+			Transaction:     anchor.Transaction,
+		},
+		State:     leafRecord.State,
+		LeafIndex: leafRecord.LeafIndex,
+		Leaf:      leaves[leafRecord.LeafIndex],
+		Proof:     proof,
+	}, nil
+}
@@ -0,0 +1,254 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statemgr
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/mocks/componentmocks"
+	"github.com/kaleido-io/paladin/core/pkg/persistence"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestStateAnchorer(t *testing.T, ss *stateManager, batchMaxSize int) *stateAnchorer {
+	sa, err := newStateAnchorer(context.Background(), ss, &pldconf.StateAnchoringConfig{
+		Enabled:         confutil.P(true),
+		Interval:        confutil.P("10ms"),
+		ContractAddress: pldtypes.RandAddress().String(),
+		From:            "anchorer",
+		BatchMaxSize:    confutil.P(batchMaxSize),
+	})
+	require.NoError(t, err)
+	require.NotNil(t, sa)
+	return sa
+}
+
+func insertTestConfirmRecords(t *testing.T, ctx context.Context, ss *stateManager, domainName string, count int) []pldtypes.HexBytes {
+	stateIDs := make([]pldtypes.HexBytes, count)
+	records := make([]*pldapi.StateConfirmRecord, count)
+	for i := range records {
+		stateIDs[i] = pldtypes.RandBytes(32)
+		records[i] = &pldapi.StateConfirmRecord{DomainName: domainName, State: stateIDs[i], Transaction: uuid.New()}
+	}
+	err := ss.p.DB().WithContext(ctx).Create(records).Error
+	require.NoError(t, err)
+	return stateIDs
+}
+
+func TestNewStateAnchorerConfig(t *testing.T) {
+	ctx := context.Background()
+
+	sa, err := newStateAnchorer(ctx, nil, &pldconf.StateAnchoringConfig{})
+	require.NoError(t, err)
+	assert.Nil(t, sa)
+
+	_, err = newStateAnchorer(ctx, nil, &pldconf.StateAnchoringConfig{
+		Enabled: confutil.P(true),
+		From:    "anchorer",
+	})
+	assert.Regexp(t, "PD010140", err)
+
+	_, err = newStateAnchorer(ctx, nil, &pldconf.StateAnchoringConfig{
+		Enabled:         confutil.P(true),
+		ContractAddress: pldtypes.RandAddress().String(),
+	})
+	assert.Regexp(t, "PD010140", err)
+
+	sa, err = newStateAnchorer(ctx, nil, &pldconf.StateAnchoringConfig{
+		Enabled:         confutil.P(true),
+		ContractAddress: pldtypes.RandAddress().String(),
+		From:            "anchorer",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1*time.Minute, sa.interval)
+	assert.Equal(t, 1000, sa.batchMaxSize)
+	assert.Equal(t, "@every 1m0s", sa.schedule())
+}
+
+func TestStateAnchoringBatchesAndProofs(t *testing.T) {
+	ctx, ss, m, done := newDBTestStateManager(t)
+	defer done()
+
+	sa := newTestStateAnchorer(t, ss, 2)
+	domain1States := insertTestConfirmRecords(t, ctx, ss, "domain1", 3)
+	domain2States := insertTestConfirmRecords(t, ctx, ss, "domain2", 1)
+
+	var submitted []*pldapi.TransactionInput
+	m.txManager.On("SendTransactions", mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			submitted = append(submitted, args[2].(*pldapi.TransactionInput))
+		}).
+		Return([]uuid.UUID{uuid.New()}, nil)
+
+	// The first two batches are full, and the third finds nothing to do
+	more, err := sa.anchorPending(ctx)
+	require.NoError(t, err)
+	assert.True(t, more)
+	more, err = sa.anchorPending(ctx)
+	require.NoError(t, err)
+	assert.True(t, more)
+	more, err = sa.anchorPending(ctx)
+	require.NoError(t, err)
+	assert.False(t, more)
+	require.Len(t, submitted, 3)
+
+	tx := submitted[0]
+	assert.Equal(t, pldapi.TransactionTypePublic.Enum(), tx.Type)
+	assert.Equal(t, "anchorer", tx.From)
+	assert.Equal(t, sa.contractAddress, *tx.To)
+	assert.Equal(t, anchorFunctionABI.String(), tx.Function)
+	var txData map[string]any
+	err = json.Unmarshal(tx.Data, &txData)
+	require.NoError(t, err)
+	assert.Equal(t, "domain1", txData["domain"])
+	assert.Equal(t, float64(2), txData["leafCount"])
+
+	for _, stateID := range append(domain1States, domain2States...) {
+		domainName := "domain1"
+		if stateID.Equals(domain2States[0]) {
+			domainName = "domain2"
+		}
+		proof, err := ss.GetStateAnchorProof(ctx, ss.p.NOTX(), domainName, stateID)
+		require.NoError(t, err)
+		require.NotNil(t, proof)
+		assert.Equal(t, domainName, proof.Anchor.Domain)
+		assert.Equal(t, sa.contractAddress, proof.Anchor.ContractAddress)
+		assert.Equal(t, merkleLeaf(stateID), proof.Leaf)
+		assert.True(t, verifyMerkleProof(proof.Anchor.Root, proof.Leaf, proof.Proof))
+	}
+
+	// Unknown states, or states in a different domain, are not anchored
+	proof, err := ss.GetStateAnchorProof(ctx, ss.p.NOTX(), "domain2", domain1States[0])
+	require.NoError(t, err)
+	assert.Nil(t, proof)
+}
+
+func TestStateAnchoringRootMismatch(t *testing.T) {
+	ctx, ss, m, done := newDBTestStateManager(t)
+	defer done()
+
+	sa := newTestStateAnchorer(t, ss, 10)
+	stateIDs := insertTestConfirmRecords(t, ctx, ss, "domain1", 3)
+	m.txManager.On("SendTransactions", mock.Anything, mock.Anything, mock.Anything).Return([]uuid.UUID{uuid.New()}, nil)
+
+	_, err := sa.anchorPending(ctx)
+	require.NoError(t, err)
+
+	err = ss.p.DB().WithContext(ctx).
+		Model(&persistedStateAnchor{}).
+		Where("domain_name = ?", "domain1").
+		Update("root", pldtypes.RandBytes32()).
+		Error
+	require.NoError(t, err)
+
+	_, err = ss.GetStateAnchorProof(ctx, ss.p.NOTX(), "domain1", stateIDs[0])
+	assert.Regexp(t, "PD010141", err)
+}
+
+func TestStateAnchoringSendFail(t *testing.T) {
+	ctx, ss, m, done := newDBTestStateManager(t)
+	defer done()
+
+	sa := newTestStateAnchorer(t, ss, 10)
+	stateIDs := insertTestConfirmRecords(t, ctx, ss, "domain1", 1)
+	m.txManager.On("SendTransactions", mock.Anything, mock.Anything, mock.Anything).Return(nil, fmt.Errorf("pop"))
+
+	_, err := sa.anchorPending(ctx)
+	assert.Regexp(t, "pop", err)
+
+	// Nothing is recorded, so the state is picked up again on the next attempt
+	proof, err := ss.GetStateAnchorProof(ctx, ss.p.NOTX(), "domain1", stateIDs[0])
+	require.NoError(t, err)
+	assert.Nil(t, proof)
+}
+
+func TestStateAnchoringJob(t *testing.T) {
+	ctx, ss, m, done := newDBTestStateManager(t)
+	defer done()
+
+	sa := newTestStateAnchorer(t, ss, 2)
+	stateIDs := insertTestConfirmRecords(t, ctx, ss, "domain1", 3)
+	m.txManager.On("SendTransactions", mock.Anything, mock.Anything, mock.Anything).Return(nil, fmt.Errorf("pop")).Once()
+	m.txManager.On("SendTransactions", mock.Anything, mock.Anything, mock.Anything).Return([]uuid.UUID{uuid.New()}, nil)
+
+	// The failure is returned for the scheduler to retry
+	err := sa.runJob(ctx, &pldapi.ScheduledJob{})
+	assert.Regexp(t, "pop", err)
+
+	// All the batches are anchored in one run
+	err = sa.runJob(ctx, &pldapi.ScheduledJob{})
+	require.NoError(t, err)
+	for _, stateID := range stateIDs {
+		proof, err := ss.GetStateAnchorProof(ctx, ss.p.NOTX(), "domain1", stateID)
+		require.NoError(t, err)
+		assert.NotNil(t, proof)
+	}
+}
+
+func TestStateManagerAnchoringJobScheduled(t *testing.T) {
+	ctx := context.Background()
+	p, pDone, err := persistence.NewUnitTestPersistence(ctx, "statemgr")
+	require.NoError(t, err)
+	defer pDone()
+	ss := NewStateManager(ctx, &pldconf.StateStoreConfig{
+		Anchoring: pldconf.StateAnchoringConfig{
+			Enabled:         confutil.P(true),
+			Interval:        confutil.P("30s"),
+			ContractAddress: pldtypes.RandAddress().String(),
+			From:            "anchorer",
+		},
+	}, p)
+	defer ss.Stop()
+
+	m := newMockComponents(t)
+	js := componentmocks.NewJobScheduler(t)
+	m.allComponents.On("JobScheduler").Return(js)
+	js.On("RegisterHandler", stateAnchoringJobType, mock.Anything).Return()
+	js.On("EnsureRecurringJob", mock.Anything, mock.Anything, stateAnchoringJobType, stateAnchoringJobType, "@every 30s").Return(nil)
+
+	_, err = ss.PreInit(m.allComponents)
+	require.NoError(t, err)
+	err = ss.PostInit(m.allComponents)
+	require.NoError(t, err)
+	err = ss.Start()
+	require.NoError(t, err)
+}
+
+func TestStateManagerAnchoringInvalidConfig(t *testing.T) {
+	ctx := context.Background()
+	ss := NewStateManager(ctx, &pldconf.StateStoreConfig{
+		Anchoring: pldconf.StateAnchoringConfig{Enabled: confutil.P(true)},
+	}, nil)
+
+	m := newMockComponents(t)
+	_, err := ss.PreInit(m.allComponents)
+	require.NoError(t, err)
+
+	err = ss.PostInit(m.allComponents)
+	assert.Regexp(t, "PD010140", err)
+}
@@ -43,6 +43,7 @@ type domainContext struct {
 	domainName         string
 	customHashFunction bool
 	contractAddress    pldtypes.EthAddress
+	isolation          components.DomainContextIsolation
 	stateLock          sync.Mutex
 	unFlushed          *pendingStateWrites
 	flushing           *pendingStateWrites
@@ -59,9 +60,13 @@ type domainContext struct {
 	txLocks []*pldapi.StateLock
 }
 
-// Very important that callers Close domain contexts they open
-func (ss *stateManager) NewDomainContext(ctx context.Context, domain components.Domain, contractAddress pldtypes.EthAddress) components.DomainContext {
+// Very important that callers Close domain contexts they open.
+// An empty isolation gives the context the isolation the domain has configured.
+func (ss *stateManager) NewDomainContext(ctx context.Context, domain components.Domain, contractAddress pldtypes.EthAddress, isolation components.DomainContextIsolation) components.DomainContext {
 	id := uuid.New()
+	if isolation == "" {
+		isolation = components.DomainStateIsolation(domain.Configuration())
+	}
 	log.L(ctx).Debugf("Domain context %s for domain %s contract %s closed", id, domain.Name(), contractAddress)
 
 	ss.domainContextLock.Lock()
@@ -74,6 +79,7 @@ func (ss *stateManager) NewDomainContext(ctx context.Context, domain components.
 		domainName:         domain.Name(),
 		customHashFunction: domain.CustomHashFunction(),
 		contractAddress:    contractAddress,
+		isolation:          isolation,
 		creatingStates:     make(map[string]*components.StateWithLabels),
 		domainContexts:     make(map[uuid.UUID]*domainContext),
 	}
@@ -93,6 +99,18 @@ func (ss *stateManager) GetDomainContext(ctx context.Context, id uuid.UUID) comp
 	return nil // means an actual nil value to the interface
 }
 
+// EvictCachedStates drops any copies of the states held in memory by the domain contexts of the domain
+func (ss *stateManager) EvictCachedStates(domainName string, stateIDs ...pldtypes.HexBytes) {
+	ss.domainContextLock.Lock()
+	defer ss.domainContextLock.Unlock()
+
+	for _, dc := range ss.domainContexts {
+		if dc.domainName == domainName {
+			dc.evictStates(stateIDs)
+		}
+	}
+}
+
 func (ss *stateManager) ListDomainContexts() []components.DomainContextInfo {
 	ss.domainContextLock.Lock()
 	defer ss.domainContextLock.Unlock()
@@ -134,7 +152,10 @@ func (dc *domainContext) getUnFlushedSpends() (spending []pldtypes.HexBytes, nul
 }
 
 func (dc *domainContext) mergeUnFlushedApplyLocks(schema components.Schema, dbStates []*pldapi.State, query *query.QueryJSON, excludeSpent, requireNullifier bool) (_ []*pldapi.State, err error) {
-	log.L(dc).Debugf("domainContext:mergeUnFlushedApplyLocks dc.txLocks: %d creatingStates: %d", len(dc.txLocks), len(dc.creatingStates))
+	log.L(dc).Debugf("domainContext:mergeUnFlushedApplyLocks dc.txLocks: %d creatingStates: %d isolation: %s", len(dc.txLocks), len(dc.creatingStates), dc.isolation)
+	// The states being created in other contexts must be gathered before we take our own lock
+	shared := dc.sharedSpeculativeStates()
+
 	dc.stateLock.Lock()
 	defer dc.stateLock.Unlock()
 	if flushErr := dc.checkResetInitUnFlushed(); flushErr != nil {
@@ -142,7 +163,10 @@ func (dc *domainContext) mergeUnFlushedApplyLocks(schema components.Schema, dbSt
 	}
 
 	retStates := dbStates
-	matches, err := dc.mergeUnFlushed(schema, dbStates, query, excludeSpent, requireNullifier)
+	var matches []*components.StateWithLabels
+	if dc.isolation != components.DomainContextIsolationStrict {
+		matches, err = dc.mergeUnFlushed(schema, dbStates, shared, query, excludeSpent, requireNullifier)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -158,12 +182,21 @@ func (dc *domainContext) mergeUnFlushedApplyLocks(schema components.Schema, dbSt
 	return dc.applyLocks(retStates), nil
 }
 
-func (dc *domainContext) mergeUnFlushed(schema components.Schema, dbStates []*pldapi.State, query *query.QueryJSON, excludeSpent, requireNullifier bool) (_ []*components.StateWithLabels, err error) {
+func (dc *domainContext) mergeUnFlushed(schema components.Schema, dbStates []*pldapi.State, shared []*components.StateWithLabels, query *query.QueryJSON, excludeSpent, requireNullifier bool) (_ []*components.StateWithLabels, err error) {
 
 	// Get the list of new un-flushed states, which are not already locked for spend
-	matches := make([]*components.StateWithLabels, 0, len(dc.creatingStates))
-	schemaId := schema.Persisted().ID
+	candidates := make([]*components.StateWithLabels, 0, len(dc.creatingStates)+len(shared))
 	for _, state := range dc.creatingStates {
+		candidates = append(candidates, state)
+	}
+	for _, state := range shared {
+		if dc.creatingStates[state.ID.String()] == nil {
+			candidates = append(candidates, state)
+		}
+	}
+	matches := make([]*components.StateWithLabels, 0, len(candidates))
+	schemaId := schema.Persisted().ID
+	for _, state := range candidates {
 		if !state.Schema.Equals(&schemaId) {
 			continue
 		}
@@ -211,11 +244,56 @@ func (dc *domainContext) mergeUnFlushed(schema components.Schema, dbStates []*pl
 	return matches, nil
 }
 
+// sharedSpeculativeStates returns the states being created in the other open contexts for the same
+// contract, when this context has shared speculative isolation. States the other contexts have locked
+// for spending are not included. Must not be called holding our own state lock.
+func (dc *domainContext) sharedSpeculativeStates() []*components.StateWithLabels {
+	if dc.isolation != components.DomainContextIsolationSharedSpeculative {
+		return nil
+	}
+
+	dc.ss.domainContextLock.Lock()
+	others := make([]*domainContext, 0, len(dc.ss.domainContexts))
+	for _, other := range dc.ss.domainContexts {
+		if other != dc && other.domainName == dc.domainName && other.contractAddress == dc.contractAddress {
+			others = append(others, other)
+		}
+	}
+	dc.ss.domainContextLock.Unlock()
+
+	var shared []*components.StateWithLabels
+	for _, other := range others {
+		shared = append(shared, other.unspentCreatingStates()...)
+	}
+	return shared
+}
+
+func (dc *domainContext) unspentCreatingStates() []*components.StateWithLabels {
+	dc.stateLock.Lock()
+	defer dc.stateLock.Unlock()
+
+	states := make([]*components.StateWithLabels, 0, len(dc.creatingStates))
+	for _, state := range dc.creatingStates {
+		spent := false
+		for _, lock := range dc.txLocks {
+			if lock.StateID.Equals(state.ID) && lock.Type.V() == pldapi.StateLockTypeSpend {
+				spent = true
+				break
+			}
+		}
+		if !spent {
+			states = append(states, state)
+		}
+	}
+	return states
+}
+
 func (dc *domainContext) Info() components.DomainContextInfo {
 	return components.DomainContextInfo{
 		ID:              dc.id,
 		DomainName:      dc.domainName,
 		ContractAddress: dc.contractAddress,
+		Isolation:       dc.isolation,
 	}
 }
 
@@ -270,8 +348,10 @@ func (dc *domainContext) GetStatesByID(dbTX persistence.DBTX, schemaID pldtypes.
 		StatusQualifier: pldapi.StateStatusAll,
 	})
 	if err == nil {
+		// We return the states being created in this context regardless of isolation, as the domain
+		// needs to be able to resolve the states it has itself written
 		var memMatches []*components.StateWithLabels
-		memMatches, err = dc.mergeUnFlushed(schema, matches, query, false /* locked states are fine */, false /* nullifiers not required */)
+		memMatches, err = dc.mergeUnFlushed(schema, matches, dc.sharedSpeculativeStates(), query, false /* locked states are fine */, false /* nullifiers not required */)
 		if err == nil && len(memMatches) > 0 {
 			matches, err = dc.mergeInMemoryMatches(schema, matches, memMatches, query)
 		}
@@ -497,6 +577,16 @@ func (dc *domainContext) ResetTransactions(transactions ...uuid.UUID) {
 	dc.txLocks = newLocks
 }
 
+// evictStates drops the in-memory copies of states, as their data has been purged from the DB
+func (dc *domainContext) evictStates(stateIDs []pldtypes.HexBytes) {
+	dc.stateLock.Lock()
+	defer dc.stateLock.Unlock()
+
+	for _, id := range stateIDs {
+		delete(dc.creatingStates, id.String())
+	}
+}
+
 func (dc *domainContext) StateLocksByTransaction() map[uuid.UUID][]pldapi.StateLock {
 	dc.stateLock.Lock()
 	defer dc.stateLock.Unlock()
@@ -508,6 +598,12 @@ func (dc *domainContext) StateLocksByTransaction() map[uuid.UUID][]pldapi.StateL
 	return txLocksCopy
 }
 
+func (dc *domainContext) HasStateLocks() bool {
+	dc.stateLock.Lock()
+	defer dc.stateLock.Unlock()
+	return len(dc.txLocks) > 0
+}
+
 // Reset puts the world back to fresh - including completing any flush.
 //
 // Must be called after a flush error before the context can be used, as on a flush
@@ -28,10 +28,12 @@ import (
 	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
 	"github.com/kaleido-io/paladin/core/internal/components"
 	"github.com/kaleido-io/paladin/core/internal/filters"
+	"github.com/kaleido-io/paladin/core/mocks/componentmocks"
 	"github.com/kaleido-io/paladin/core/pkg/persistence"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/query"
+	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -279,6 +281,7 @@ func TestStateContextMintSpendMint(t *testing.T) {
 
 	// Check we can query the current state of locks.
 	// The map is by TXID, and within the map the locks are in order created by TX
+	assert.True(t, dc.HasStateLocks())
 	lockView := dc.StateLocksByTransaction()
 	assert.Equal(t, map[uuid.UUID][]pldapi.StateLock{
 		transactionID1: {
@@ -855,6 +858,42 @@ func TestDSIMergeUnFlushedBadDBRecord(t *testing.T) {
 
 }
 
+func TestDSIMergeUnFlushedPurgedDBRecord(t *testing.T) {
+
+	ctx, ss, _, _, done := newDBMockStateManager(t)
+	defer done()
+
+	schema1, err := newABISchema(ctx, "domain1", testABIParam(t, fakeCoinABI))
+	require.NoError(t, err)
+	ss.abiSchemaCache.Set(schemaCacheKey("domain1", schema1.ID()), schema1)
+
+	contractAddress, dc := newTestDomainContext(t, ctx, ss, "domain1", false)
+	defer dc.Close()
+
+	s1, err := schema1.ProcessState(ctx, contractAddress, pldtypes.RawJSON(fmt.Sprintf(
+		`{"amount": 20, "owner": "0x615dD09124271D8008225054d85Ffe720E7a447A", "salt": "%s"}`,
+		pldtypes.RandHex(32))), nil, dc.customHashFunction)
+	require.NoError(t, err)
+
+	dc.creatingStates[s1.ID.String()] = s1
+
+	// A state with its data purged still sorts on its base labels
+	purged := &pldapi.State{StateBase: pldapi.StateBase{ID: pldtypes.RandBytes(32), Created: s1.Created - 1}}
+	states, err := dc.mergeUnFlushedApplyLocks(schema1, []*pldapi.State{purged},
+		query.NewQueryBuilder().Sort(".created").Query(), true, false)
+	require.NoError(t, err)
+	require.Len(t, states, 2)
+	assert.Equal(t, purged.ID, states[0].ID)
+	assert.Equal(t, s1.ID, states[1].ID)
+
+	// Once purged, the copy held in memory is dropped too
+	ss.EvictCachedStates("domain2", s1.ID)
+	assert.NotNil(t, dc.creatingStates[s1.ID.String()])
+	ss.EvictCachedStates("domain1", s1.ID)
+	assert.Nil(t, dc.creatingStates[s1.ID.String()])
+
+}
+
 func TestDCMergeUnFlushedWhileFlushingDedup(t *testing.T) {
 
 	ctx, ss, _, _, done := newDBMockStateManager(t)
@@ -1348,3 +1387,116 @@ func TestGetStatesByIDFail(t *testing.T) {
 	_, _, err := dc.GetStatesByID(dc.ss.p.NOTX(), pldtypes.Bytes32(pldtypes.RandBytes(32)), []string{pldtypes.RandHex(32)})
 	assert.Regexp(t, "pop", err)
 }
+
+func TestDomainContextIsolation(t *testing.T) {
+
+	ctx, ss, _, done := newDBTestStateManager(t)
+	defer done()
+
+	schemas, err := ss.EnsureABISchemas(ctx, ss.p.NOTX(), "domain1", []*abi.Parameter{testABIParam(t, fakeCoinABI)})
+	require.NoError(t, err)
+	schemaID := schemas[0].ID()
+
+	md := componentmocks.NewDomain(t)
+	md.On("Name").Return("domain1")
+	md.On("CustomHashFunction").Return(false)
+	contractAddress := *pldtypes.RandAddress()
+	newDC := func(isolation components.DomainContextIsolation) *domainContext {
+		dc := ss.NewDomainContext(ctx, md, contractAddress, isolation).(*domainContext)
+		t.Cleanup(dc.Close)
+		return dc
+	}
+	creator := newDC(components.DomainContextIsolationSpeculative)
+	speculative := newDC(components.DomainContextIsolationSpeculative)
+	strict := newDC(components.DomainContextIsolationStrict)
+	shared := newDC(components.DomainContextIsolationSharedSpeculative)
+	assert.Equal(t, components.DomainContextIsolationSharedSpeculative, shared.Info().Isolation)
+
+	// A context on another contract is never shared with
+	_, otherContract := newTestDomainContext(t, ctx, ss, "domain1", false)
+	defer otherContract.Close()
+
+	// Two states created by a transaction in one context, and one of them spent by another transaction
+	transactionID1 := uuid.New()
+	transactionID2 := uuid.New()
+	states, err := creator.UpsertStates(ss.p.NOTX(),
+		&components.StateUpsert{Schema: schemaID, Data: pldtypes.RawJSON(fmt.Sprintf(`{"amount": 100, "owner": "0xf7b1c69F5690993F2C8ecE56cc89D42b1e737180", "salt": "%s"}`, pldtypes.RandHex(32))), CreatedBy: &transactionID1},
+		&components.StateUpsert{Schema: schemaID, Data: pldtypes.RawJSON(fmt.Sprintf(`{"amount": 10,  "owner": "0xf7b1c69F5690993F2C8ecE56cc89D42b1e737180", "salt": "%s"}`, pldtypes.RandHex(32))), CreatedBy: &transactionID1},
+	)
+	require.NoError(t, err)
+	err = creator.AddStateLocks(&pldapi.StateLock{Type: pldapi.StateLockTypeSpend.Enum(), StateID: states[1].ID, Transaction: transactionID2})
+	require.NoError(t, err)
+
+	findAvailable := func(dc *domainContext) []*pldapi.State {
+		_, found, err := dc.FindAvailableStates(ss.p.NOTX(), schemaID, query.NewQueryBuilder().Sort("amount").Query())
+		require.NoError(t, err)
+		return found
+	}
+
+	// The creating context sees its own unspent state
+	found := findAvailable(creator)
+	require.Len(t, found, 1)
+	assert.Equal(t, states[0].ID, found[0].ID)
+
+	// Other speculative contexts, and strict contexts, do not see it
+	assert.Empty(t, findAvailable(speculative))
+	assert.Empty(t, findAvailable(strict))
+	assert.Empty(t, findAvailable(otherContract))
+
+	// The shared speculative context does, but not the one spent in the other context
+	found = findAvailable(shared)
+	require.Len(t, found, 1)
+	assert.Equal(t, states[0].ID, found[0].ID)
+	_, found, err = shared.GetStatesByID(ss.p.NOTX(), schemaID, []string{states[0].ID.String()})
+	require.NoError(t, err)
+	assert.Len(t, found, 1)
+
+	// A strict context only sees its own states when they are asked for by ID
+	strictStates, err := strict.UpsertStates(ss.p.NOTX(),
+		&components.StateUpsert{Schema: schemaID, Data: pldtypes.RawJSON(fmt.Sprintf(`{"amount": 5, "owner": "0xf7b1c69F5690993F2C8ecE56cc89D42b1e737180", "salt": "%s"}`, pldtypes.RandHex(32))), CreatedBy: &transactionID2},
+	)
+	require.NoError(t, err)
+	assert.Empty(t, findAvailable(strict))
+	_, found, err = strict.GetStatesByID(ss.p.NOTX(), schemaID, []string{strictStates[0].ID.String()})
+	require.NoError(t, err)
+	assert.Len(t, found, 1)
+
+	// Once the creating transaction is reset, the state is no longer shared
+	creator.ResetTransactions(transactionID1)
+	found = findAvailable(shared)
+	require.Len(t, found, 1)
+	assert.Equal(t, strictStates[0].ID, found[0].ID)
+}
+
+func TestDomainStateIsolation(t *testing.T) {
+	assert.Equal(t, components.DomainContextIsolationSpeculative, components.DomainStateIsolation(nil))
+	assert.Equal(t, components.DomainContextIsolationStrict, components.DomainStateIsolation(&prototk.DomainConfig{
+		StateIsolation: prototk.DomainConfig_STATE_ISOLATION_STRICT,
+	}))
+	assert.Equal(t, components.DomainContextIsolationSharedSpeculative, components.DomainStateIsolation(&prototk.DomainConfig{
+		StateIsolation: prototk.DomainConfig_STATE_ISOLATION_SHARED_SPECULATIVE,
+	}))
+}
+
+func TestNewDomainContextDefaultIsolation(t *testing.T) {
+
+	ctx, ss, _, done := newDBTestStateManager(t)
+	defer done()
+
+	md := componentmocks.NewDomain(t)
+	md.On("Name").Return("domain1")
+	md.On("CustomHashFunction").Return(false)
+	md.On("Configuration").Return(&prototk.DomainConfig{
+		StateIsolation: prototk.DomainConfig_STATE_ISOLATION_STRICT,
+	}).Once()
+
+	// The domain configuration is used when no isolation is given
+	dc1 := ss.NewDomainContext(ctx, md, *pldtypes.RandAddress(), "")
+	defer dc1.Close()
+	assert.Equal(t, components.DomainContextIsolationStrict, dc1.Info().Isolation)
+
+	// ... and is overridden otherwise
+	dc2 := ss.NewDomainContext(ctx, md, *pldtypes.RandAddress(), components.DomainContextIsolationSharedSpeculative)
+	defer dc2.Close()
+	assert.Equal(t, components.DomainContextIsolationSharedSpeculative, dc2.Info().Isolation)
+}
@@ -0,0 +1,294 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statemgr
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/core/internal/components"
+	"github.com/kaleido-io/paladin/core/pkg/persistence"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/query"
+)
+
+// Statistics on the cardinality of each label of a schema, that are calculated on demand
+// and cached for a configurable time. They are used to put the most selective predicates
+// (and label joins) first in the generated SQL.
+type schemaLabelStats struct {
+	calculated time.Time
+	labels     map[string]*pldapi.StateLabelStats
+}
+
+type queryPlan struct {
+	query   *query.QueryJSON
+	indexed bool
+	stats   *schemaLabelStats         // nil if no labels are used in the predicates of the query
+	labels  []*pldapi.StateLabelStats // the labels joined into the query, in the order they are joined
+}
+
+type opKind int
+
+const (
+	opKindEqual opKind = iota
+	opKindRange
+	opKindIn
+	opKindLike
+	opKindOther // contains, null, negated operations and JSON path - none of these can use an index
+)
+
+func (ss *stateManager) getLabelStats(ctx context.Context, dbTX persistence.DBTX, domainName string, schema components.Schema) (*schemaLabelStats, error) {
+	cacheKey := schemaCacheKey(domainName, schema.Persisted().ID)
+	if stats, cached := ss.labelStatsCache.Get(cacheKey); cached && time.Since(stats.calculated) < ss.labelStatsMaxAge {
+		return stats, nil
+	}
+
+	stats := &schemaLabelStats{
+		calculated: time.Now(),
+		labels:     make(map[string]*pldapi.StateLabelStats),
+	}
+	for _, lt := range []struct{ table, labelType string }{
+		{table: "state_labels", labelType: "string"},
+		{table: "state_int64_labels", labelType: "int64"},
+	} {
+		var rows []*pldapi.StateLabelStats
+		err := dbTX.DB().
+			WithContext(ctx).
+			Table(lt.table+` AS "l"`).
+			Select(`"l"."label" AS "label", COUNT(DISTINCT "l"."value") AS "distinct_values", COUNT(*) AS "states"`).
+			Joins(`INNER JOIN "states" ON "states"."domain_name" = "l"."domain_name" AND "states"."id" = "l"."state"`).
+			Where(`"l"."domain_name" = ?`, domainName).
+			Where(`"states"."schema" = ?`, schema.Persisted().ID).
+			Group(`"l"."label"`).
+			Scan(&rows).
+			Error
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range rows {
+			r.Type = lt.labelType
+			stats.labels[r.Label] = r
+		}
+	}
+	log.L(ctx).Debugf("Calculated label statistics for schema %s in domain %s: %d labels", schema.Persisted().ID, domainName, len(stats.labels))
+	ss.labelStatsCache.Set(cacheKey, stats)
+	return stats, nil
+}
+
+// The ID is unique, so is always the most selective. Otherwise labels with more distinct values are more selective.
+func (sls *schemaLabelStats) selectivity(fieldName string) int64 {
+	if fieldName == ".id" {
+		return math.MaxInt64
+	}
+	if ls := sls.get(fieldName); ls != nil {
+		return ls.DistinctValues
+	}
+	return 0
+}
+
+// Safe to call on nil, for queries that only use labels for sorting
+func (sls *schemaLabelStats) get(label string) *pldapi.StateLabelStats {
+	if sls == nil {
+		return nil
+	}
+	return sls.labels[label]
+}
+
+// Returns the stats for the labels used in the query, most selective first
+func (sls *schemaLabelStats) orderedLabels(used map[string]*schemaLabelInfo) []*pldapi.StateLabelStats {
+	ordered := make([]*pldapi.StateLabelStats, 0, len(used))
+	for label, fi := range used {
+		ls := sls.get(label)
+		if ls == nil {
+			// no states yet have a value for this label
+			ls = &pldapi.StateLabelStats{Label: label, Type: "string"}
+			if fi.labelType == labelTypeInt64 || fi.labelType == labelTypeBool {
+				ls.Type = "int64"
+			}
+		}
+		ordered = append(ordered, ls)
+	}
+	slices.SortFunc(ordered, func(a, b *pldapi.StateLabelStats) int {
+		if c := cmp.Compare(b.DistinctValues, a.DistinctValues); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Label, b.Label)
+	})
+	return ordered
+}
+
+func (sls *schemaLabelStats) compareSelectivity(a, b string) int {
+	return cmp.Compare(sls.selectivity(b), sls.selectivity(a))
+}
+
+func orderSingleValOps(sls *schemaLabelStats, ops []*query.OpSingleVal) []*query.OpSingleVal {
+	if len(ops) < 2 {
+		return ops
+	}
+	ordered := slices.Clone(ops)
+	slices.SortStableFunc(ordered, func(a, b *query.OpSingleVal) int { return sls.compareSelectivity(a.Field, b.Field) })
+	return ordered
+}
+
+func orderMultiValOps(sls *schemaLabelStats, ops []*query.OpMultiVal) []*query.OpMultiVal {
+	if len(ops) < 2 {
+		return ops
+	}
+	ordered := slices.Clone(ops)
+	slices.SortStableFunc(ordered, func(a, b *query.OpMultiVal) int { return sls.compareSelectivity(a.Field, b.Field) })
+	return ordered
+}
+
+// Returns a copy of the statements, with the predicates of each operation ordered most selective first
+func (sls *schemaLabelStats) orderStatements(s *query.Statements) *query.Statements {
+	ordered := &query.Statements{Ops: s.Ops}
+	ops := &ordered.Ops
+	ops.Equal = orderSingleValOps(sls, ops.Equal)
+	ops.Eq = orderSingleValOps(sls, ops.Eq)
+	ops.NEq = orderSingleValOps(sls, ops.NEq)
+	ops.Like = orderSingleValOps(sls, ops.Like)
+	ops.Contains = orderSingleValOps(sls, ops.Contains)
+	ops.LessThan = orderSingleValOps(sls, ops.LessThan)
+	ops.LT = orderSingleValOps(sls, ops.LT)
+	ops.LessThanOrEqual = orderSingleValOps(sls, ops.LessThanOrEqual)
+	ops.LTE = orderSingleValOps(sls, ops.LTE)
+	ops.GreaterThan = orderSingleValOps(sls, ops.GreaterThan)
+	ops.GT = orderSingleValOps(sls, ops.GT)
+	ops.GreaterThanOrEqual = orderSingleValOps(sls, ops.GreaterThanOrEqual)
+	ops.GTE = orderSingleValOps(sls, ops.GTE)
+	ops.In = orderMultiValOps(sls, ops.In)
+	ops.NIn = orderMultiValOps(sls, ops.NIn)
+	for _, or := range s.Or {
+		ordered.Or = append(ordered.Or, sls.orderStatements(or))
+	}
+	return ordered
+}
+
+// Calls the supplied function for each operation directly in the statements (not in nested OR statements)
+func forEachOp(s *query.Statements, fn func(op *query.Op, kind opKind, value pldtypes.RawJSON)) {
+	singleVal := func(ops []*query.OpSingleVal, kind opKind) {
+		for _, o := range ops {
+			fn(&o.Op, kind, o.Value)
+		}
+	}
+	multiVal := func(ops []*query.OpMultiVal, kind opKind) {
+		for _, o := range ops {
+			fn(&o.Op, kind, nil)
+		}
+	}
+	singleVal(s.Equal, opKindEqual)
+	singleVal(s.Eq, opKindEqual)
+	singleVal(s.NEq, opKindOther)
+	singleVal(s.Like, opKindLike)
+	singleVal(s.Contains, opKindOther)
+	singleVal(s.LessThan, opKindRange)
+	singleVal(s.LT, opKindRange)
+	singleVal(s.LessThanOrEqual, opKindRange)
+	singleVal(s.LTE, opKindRange)
+	singleVal(s.GreaterThan, opKindRange)
+	singleVal(s.GT, opKindRange)
+	singleVal(s.GreaterThanOrEqual, opKindRange)
+	singleVal(s.GTE, opKindRange)
+	multiVal(s.In, opKindIn)
+	multiVal(s.NIn, opKindOther)
+	for _, o := range s.Null {
+		fn(o, opKindOther, nil)
+	}
+	for _, o := range s.JSONEq {
+		fn(&o.Op, opKindOther, nil)
+	}
+}
+
+func queryUsesLabels(s *query.Statements, tracker *trackingLabelSet) (used bool) {
+	forEachOp(s, func(op *query.Op, _ opKind, _ pldtypes.RawJSON) {
+		used = used || tracker.labels[op.Field] != nil
+	})
+	for _, or := range s.Or {
+		used = used || queryUsesLabels(or, tracker)
+	}
+	return used
+}
+
+func isIndexableOp(op *query.Op, kind opKind, value pldtypes.RawJSON, tracker *trackingLabelSet) bool {
+	if op.Field != ".id" && tracker.labels[op.Field] == nil {
+		return false
+	}
+	// Negated and case-insensitive matches cannot use the index on the value
+	if op.Not || op.CaseInsensitive {
+		return false
+	}
+	switch kind {
+	case opKindEqual, opKindRange, opKindIn:
+		return true
+	case opKindLike:
+		// Only a fixed prefix can use the index
+		var pattern string
+		return json.Unmarshal(value, &pattern) == nil && len(pattern) > 0 &&
+			pattern[0] != '%' && pattern[0] != '_'
+	default:
+		return false
+	}
+}
+
+// A set of statements can use an index if any of the predicates ANDed together can,
+// or if every branch of an OR can.
+func isIndexable(s *query.Statements, tracker *trackingLabelSet) (indexable bool) {
+	forEachOp(s, func(op *query.Op, kind opKind, value pldtypes.RawJSON) {
+		indexable = indexable || isIndexableOp(op, kind, value, tracker)
+	})
+	if indexable || len(s.Or) == 0 {
+		return indexable
+	}
+	for _, or := range s.Or {
+		if !isIndexable(or, tracker) {
+			return false
+		}
+	}
+	return true
+}
+
+func hasPredicates(s *query.Statements) (has bool) {
+	forEachOp(s, func(*query.Op, opKind, pldtypes.RawJSON) { has = true })
+	return has || len(s.Or) > 0
+}
+
+func (ss *stateManager) planQuery(ctx context.Context, dbTX persistence.DBTX, domainName string, schema components.Schema, tracker *trackingLabelSet, jq *query.QueryJSON) (*queryPlan, error) {
+	plan := &queryPlan{
+		query:   jq,
+		indexed: isIndexable(&jq.Statements, tracker),
+	}
+	if queryUsesLabels(&jq.Statements, tracker) {
+		stats, err := ss.getLabelStats(ctx, dbTX, domainName, schema)
+		if err != nil {
+			return nil, err
+		}
+		plan.stats = stats
+		plan.query = &query.QueryJSON{
+			Statements: *stats.orderStatements(&jq.Statements),
+			Limit:      jq.Limit,
+			Sort:       jq.Sort,
+		}
+	}
+	return plan, nil
+}
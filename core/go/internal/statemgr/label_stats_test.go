@@ -0,0 +1,212 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statemgr
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hyperledger/firefly-signer/pkg/abi"
+	"github.com/kaleido-io/paladin/core/internal/components"
+	"github.com/kaleido-io/paladin/core/pkg/persistence"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/query"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testOwner1 = "0x615dd09124271d8008225054d85ffe720e7a447a"
+	testOwner2 = "0x2a3b4c5d6e7f8091a2b3c4d5e6f708192a3b4c5d"
+)
+
+func writeLabelStatsTestStates(t *testing.T, ctx context.Context, ss *stateManager, schemaID pldtypes.Bytes32, contractAddress *pldtypes.EthAddress, count int) {
+	upserts := make([]*components.StateUpsertOutsideContext, count)
+	for i := range upserts {
+		owner := testOwner1
+		if i%2 == 1 {
+			owner = testOwner2
+		}
+		upserts[i] = &components.StateUpsertOutsideContext{
+			SchemaID:        schemaID,
+			ContractAddress: contractAddress,
+			Data: pldtypes.RawJSON(fmt.Sprintf(`{"amount": %d, "owner": "%s", "salt": "%s"}`,
+				(i+1)*10, owner, pldtypes.RandHex(32))),
+		}
+	}
+	err := ss.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
+		_, err := ss.WritePreVerifiedStates(ctx, dbTX, "domain1", upserts)
+		return err
+	})
+	require.NoError(t, err)
+}
+
+func parseTestQuery(t *testing.T, jq string) *query.QueryJSON {
+	var q query.QueryJSON
+	err := json.Unmarshal([]byte(jq), &q)
+	require.NoError(t, err)
+	return &q
+}
+
+func TestLabelStatsExplainAndFind(t *testing.T) {
+	ctx, ss, m, done := newDBTestStateManager(t)
+	defer done()
+
+	_ = mockDomain(t, m, "domain1", false)
+	mockStateCallback(m)
+
+	schemas, err := ss.EnsureABISchemas(ctx, ss.p.NOTX(), "domain1", []*abi.Parameter{testABIParam(t, fakeCoinABI)})
+	require.NoError(t, err)
+	schemaID := schemas[0].ID()
+	contractAddress := pldtypes.RandAddress()
+	writeLabelStatsTestStates(t, ctx, ss, schemaID, contractAddress, 6)
+
+	ownerAndAmount := parseTestQuery(t, fmt.Sprintf(`{
+		"eq": [
+			{"field": "owner", "value": "%s"},
+			{"field": "amount", "value": 30}
+		]
+	}`, testOwner1))
+	explanation, err := ss.ExplainStatesQuery(ctx, ss.p.NOTX(), "domain1", contractAddress, schemaID, ownerAndAmount, pldapi.StateStatusAll)
+	require.NoError(t, err)
+	assert.True(t, explanation.Indexed)
+	assert.NotZero(t, explanation.StatsTime)
+	assert.Equal(t, []*pldapi.StateLabelStats{
+		{Label: "amount", Type: "string", DistinctValues: 6, States: 6},
+		{Label: "owner", Type: "string", DistinctValues: 2, States: 6},
+	}, explanation.LabelStats)
+	// The more selective amount label is joined, and filtered, first
+	amountJoin := strings.Index(explanation.SQL, "JOIN state_labels AS l1")
+	ownerJoin := strings.Index(explanation.SQL, "JOIN state_labels AS l0")
+	assert.Greater(t, amountJoin, 0)
+	assert.Greater(t, ownerJoin, amountJoin)
+	assert.Less(t, strings.Index(explanation.SQL, "l1.value ="), strings.Index(explanation.SQL, "l0.value ="))
+	assert.Contains(t, explanation.SQL, strings.TrimPrefix(contractAddress.String(), "0x"))
+	// The query passed in is not modified
+	assert.Equal(t, "owner", ownerAndAmount.Eq[0].Field)
+
+	states, err := ss.FindContractStates(ctx, ss.p.NOTX(), "domain1", contractAddress, schemaID, ownerAndAmount, pldapi.StateStatusAll)
+	require.NoError(t, err)
+	assert.Len(t, states, 1)
+
+	// Stats are cached until they reach the max age
+	writeLabelStatsTestStates(t, ctx, ss, schemaID, contractAddress, 2)
+	explanation, err = ss.ExplainStatesQuery(ctx, ss.p.NOTX(), "domain1", nil, schemaID, ownerAndAmount, "")
+	require.NoError(t, err)
+	assert.Equal(t, int64(6), explanation.LabelStats[1].States)
+	ss.labelStatsMaxAge = 0
+	explanation, err = ss.ExplainStatesQuery(ctx, ss.p.NOTX(), "domain1", nil, schemaID, ownerAndAmount, pldapi.StateStatusAvailable)
+	require.NoError(t, err)
+	assert.Equal(t, int64(8), explanation.LabelStats[1].States)
+	assert.Contains(t, explanation.SQL, `"Confirmed"."transaction" IS NOT NULL`)
+
+	// Sorting by a label joins it, without needing stats
+	explanation, err = ss.ExplainStatesQuery(ctx, ss.p.NOTX(), "domain1", nil, schemaID, parseTestQuery(t, `{"sort": ["owner"]}`), pldapi.StateStatusAll)
+	require.NoError(t, err)
+	assert.False(t, explanation.Indexed)
+	assert.Zero(t, explanation.StatsTime)
+	assert.Equal(t, []*pldapi.StateLabelStats{{Label: "owner", Type: "string"}}, explanation.LabelStats)
+
+	_, err = ss.ExplainStatesQuery(ctx, ss.p.NOTX(), "domain1", nil, schemaID, ownerAndAmount, pldapi.StateStatusQualifier(pldtypes.RandHex(16)))
+	assert.Regexp(t, "PD010139", err)
+
+	_, err = ss.ExplainStatesQuery(ctx, ss.p.NOTX(), "domain1", nil, pldtypes.RandBytes32(), ownerAndAmount, pldapi.StateStatusAll)
+	assert.Regexp(t, "PD010106", err)
+}
+
+func TestQueryIndexable(t *testing.T) {
+	tracker := &trackingLabelSet{labels: map[string]*schemaLabelInfo{
+		"owner":  {label: "owner"},
+		"amount": {label: "amount"},
+	}}
+
+	for _, tc := range []struct {
+		query     string
+		indexable bool
+	}{
+		{query: `{}`, indexable: false},
+		{query: `{"eq": [{"field": "owner", "value": "x"}]}`, indexable: true},
+		{query: `{"eq": [{"field": ".id", "value": "0x01"}]}`, indexable: true},
+		{query: `{"eq": [{"field": ".created", "value": 1}]}`, indexable: false},
+		{query: `{"eq": [{"field": "owner", "value": "x", "caseInsensitive": true}]}`, indexable: false},
+		{query: `{"neq": [{"field": "owner", "value": "x"}]}`, indexable: false},
+		{query: `{"neq": [{"field": "owner", "value": "x"}], "gt": [{"field": "amount", "value": 1}]}`, indexable: true},
+		{query: `{"in": [{"field": "owner", "values": ["x", "y"]}]}`, indexable: true},
+		{query: `{"nin": [{"field": "owner", "values": ["x", "y"]}]}`, indexable: false},
+		{query: `{"like": [{"field": "owner", "value": "0x61%"}]}`, indexable: true},
+		{query: `{"like": [{"field": "owner", "value": "%7a"}]}`, indexable: false},
+		{query: `{"like": [{"field": "owner", "value": "_x"}]}`, indexable: false},
+		{query: `{"like": [{"field": "owner", "value": 12345}]}`, indexable: false},
+		{query: `{"contains": [{"field": "owner", "value": "x"}]}`, indexable: false},
+		{query: `{"null": [{"field": "owner"}]}`, indexable: false},
+		{query: `{"or": [{"eq": [{"field": "owner", "value": "x"}]}, {"lte": [{"field": "amount", "value": 1}]}]}`, indexable: true},
+		{query: `{"or": [{"eq": [{"field": "owner", "value": "x"}]}, {"neq": [{"field": "amount", "value": 1}]}]}`, indexable: false},
+	} {
+		jq := parseTestQuery(t, tc.query)
+		assert.Equal(t, tc.indexable, isIndexable(&jq.Statements, tracker), tc.query)
+	}
+}
+
+func TestOrderStatements(t *testing.T) {
+	sls := &schemaLabelStats{labels: map[string]*pldapi.StateLabelStats{
+		"low":  {Label: "low", DistinctValues: 2},
+		"high": {Label: "high", DistinctValues: 1000},
+	}}
+
+	jq := parseTestQuery(t, `{
+		"eq": [{"field": "low", "value": 1}, {"field": "unknown", "value": 1}, {"field": "high", "value": 1}],
+		"in": [{"field": "low", "values": [1]}, {"field": ".id", "values": ["0x01"]}],
+		"or": [{
+			"gt": [{"field": "low", "value": 1}, {"field": "high", "value": 1}]
+		}]
+	}`)
+	ordered := sls.orderStatements(&jq.Statements)
+
+	fields := func(ops []*query.OpSingleVal) (f []string) {
+		for _, o := range ops {
+			f = append(f, o.Field)
+		}
+		return f
+	}
+	assert.Equal(t, []string{"high", "low", "unknown"}, fields(ordered.Eq))
+	assert.Equal(t, ".id", ordered.In[0].Field)
+	assert.Equal(t, "low", ordered.In[1].Field)
+	assert.Equal(t, []string{"high", "low"}, fields(ordered.Or[0].GT))
+
+	// Original is untouched
+	assert.Equal(t, []string{"low", "unknown", "high"}, fields(jq.Eq))
+	assert.Equal(t, []string{"low", "high"}, fields(jq.Or[0].GT))
+}
+
+func TestLabelStatsQueryFail(t *testing.T) {
+	ctx, ss, mdb, _, done := newDBMockStateManager(t)
+	defer done()
+
+	schemaID := pldtypes.RandBytes32()
+	as, err := newABISchema(ctx, "domain1", testABIParam(t, fakeCoinABI))
+	require.NoError(t, err)
+	ss.abiSchemaCache.Set(schemaCacheKey("domain1", schemaID), as)
+
+	mdb.ExpectQuery("SELECT.*state_labels").WillReturnError(fmt.Errorf("pop"))
+
+	_, err = ss.FindStates(ctx, ss.p.NOTX(), "domain1", schemaID, parseTestQuery(t, `{"eq": [{"field": "owner", "value": "x"}]}`), nil)
+	assert.Regexp(t, "pop", err)
+}
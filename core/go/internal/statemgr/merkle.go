@@ -0,0 +1,71 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statemgr
+
+import (
+	"bytes"
+
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+)
+
+// The merkle trees used for state anchoring hash each pair of nodes in sorted order, so a proof
+// is just the list of siblings from the leaf to the root (no left/right flags) which is what the
+// OpenZeppelin MerkleProof library used by the StateAnchorRegistry contract expects.
+// A node without a sibling is promoted unchanged to the next level.
+
+func merkleLeaf(stateID pldtypes.HexBytes) pldtypes.Bytes32 {
+	return pldtypes.Bytes32Keccak(stateID)
+}
+
+func merkleHashPair(a, b pldtypes.Bytes32) pldtypes.Bytes32 {
+	if bytes.Compare(a[:], b[:]) > 0 {
+		a, b = b, a
+	}
+	return pldtypes.Bytes32Keccak(append(a[:], b[:]...))
+}
+
+// Calculates the root of a non-empty set of leaves, and the proof for the leaf at the
+// supplied index (pass -1 if only the root is required)
+func merkleRootAndProof(leaves []pldtypes.Bytes32, index int) (root pldtypes.Bytes32, proof []pldtypes.Bytes32) {
+	level := leaves
+	for len(level) > 1 {
+		if index >= 0 {
+			if sibling := index ^ 1; sibling < len(level) {
+				proof = append(proof, level[sibling])
+			}
+			index /= 2
+		}
+		next := make([]pldtypes.Bytes32, (len(level)+1)/2)
+		for i := 0; i < len(level); i += 2 {
+			if i+1 < len(level) {
+				next[i/2] = merkleHashPair(level[i], level[i+1])
+			} else {
+				next[i/2] = level[i]
+			}
+		}
+		level = next
+	}
+	return level[0], proof
+}
+
+func verifyMerkleProof(root, leaf pldtypes.Bytes32, proof []pldtypes.Bytes32) bool {
+	hash := leaf
+	for _, sibling := range proof {
+		hash = merkleHashPair(hash, sibling)
+	}
+	return hash == root
+}
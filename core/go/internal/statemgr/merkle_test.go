@@ -0,0 +1,73 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statemgr
+
+import (
+	"testing"
+
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/stretchr/testify/assert"
+)
+
+func testMerkleLeaves(count int) []pldtypes.Bytes32 {
+	leaves := make([]pldtypes.Bytes32, count)
+	for i := range leaves {
+		leaves[i] = merkleLeaf(pldtypes.RandBytes(32))
+	}
+	return leaves
+}
+
+func TestMerkleSingleLeaf(t *testing.T) {
+	leaves := testMerkleLeaves(1)
+	root, proof := merkleRootAndProof(leaves, 0)
+	assert.Equal(t, leaves[0], root)
+	assert.Empty(t, proof)
+	assert.True(t, verifyMerkleProof(root, leaves[0], proof))
+}
+
+func TestMerkleHashPairSorted(t *testing.T) {
+	leaves := testMerkleLeaves(2)
+	assert.Equal(t, merkleHashPair(leaves[0], leaves[1]), merkleHashPair(leaves[1], leaves[0]))
+
+	root, proof := merkleRootAndProof(leaves, 1)
+	assert.Equal(t, merkleHashPair(leaves[0], leaves[1]), root)
+	assert.Equal(t, []pldtypes.Bytes32{leaves[0]}, proof)
+}
+
+func TestMerkleProofs(t *testing.T) {
+	for _, count := range []int{2, 3, 4, 5, 8, 13} {
+		leaves := testMerkleLeaves(count)
+		rootOnly, noProof := merkleRootAndProof(leaves, -1)
+		assert.Nil(t, noProof)
+		for i := range leaves {
+			root, proof := merkleRootAndProof(leaves, i)
+			assert.Equal(t, rootOnly, root)
+			assert.True(t, verifyMerkleProof(root, leaves[i], proof), "count=%d index=%d", count, i)
+
+			// A proof for one leaf does not prove any other leaf
+			other := leaves[(i+1)%count]
+			assert.False(t, verifyMerkleProof(root, other, proof), "count=%d index=%d", count, i)
+		}
+	}
+}
+
+func TestMerkleProofTampered(t *testing.T) {
+	leaves := testMerkleLeaves(5)
+	root, proof := merkleRootAndProof(leaves, 2)
+	proof[0] = pldtypes.RandBytes32()
+	assert.False(t, verifyMerkleProof(root, leaves[2], proof))
+}
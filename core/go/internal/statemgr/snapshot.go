@@ -0,0 +1,250 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statemgr
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"slices"
+
+	"github.com/hyperledger/firefly-signer/pkg/abi"
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/core/internal/components"
+	"github.com/kaleido-io/paladin/core/internal/msgs"
+	"github.com/kaleido-io/paladin/core/pkg/persistence"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"golang.org/x/crypto/sha3"
+)
+
+// The snapshot hash covers the identity of everything in the snapshot, and the confirm/spend/nullifier
+// records of each state. The data of each state is covered by its ID, which is verified against
+// the data on import (by the schema, or by the domain for custom hash functions).
+func snapshotHash(s *pldapi.StateSnapshot) pldtypes.Bytes32 {
+	hash := sha3.NewLegacyKeccak256()
+	writeField := func(b []byte) {
+		// length prefix each field, so the concatenation is unambiguous
+		_ = binary.Write(hash, binary.BigEndian, uint32(len(b)))
+		hash.Write(b)
+	}
+	writeAddress := func(a *pldtypes.EthAddress) {
+		if a == nil {
+			writeField(nil)
+		} else {
+			writeField(a[:])
+		}
+	}
+	writeField([]byte(s.Domain))
+	writeAddress(s.ContractAddress)
+	for _, schema := range s.Schemas {
+		writeField(schema.ID[:])
+	}
+	for _, st := range s.States {
+		writeField(st.ID)
+		writeField(st.Schema[:])
+		writeAddress(st.ContractAddress)
+		if st.Confirmed != nil {
+			writeField(st.Confirmed[:])
+		} else {
+			writeField(nil)
+		}
+		if st.Spent != nil {
+			writeField(st.Spent[:])
+		} else {
+			writeField(nil)
+		}
+		writeField(st.Nullifier)
+	}
+	var h32 pldtypes.Bytes32
+	_ = hash.Sum(h32[0:0])
+	return h32
+}
+
+func (ss *stateManager) ExportStateSnapshot(ctx context.Context, dbTX persistence.DBTX, domainName string, contractAddress *pldtypes.EthAddress) (*pldapi.StateSnapshot, error) {
+	schemas, err := ss.ListSchemasForJSON(ctx, dbTX, domainName)
+	if err != nil {
+		return nil, err
+	}
+
+	var states []*pldapi.State
+	q := dbTX.DB().
+		WithContext(ctx).
+		Table("states").
+		Joins("Confirmed", dbTX.DB().Select("transaction")).
+		Joins("Spent", dbTX.DB().Select("transaction")).
+		Joins("Nullifier", dbTX.DB().Select(`"Nullifier"."id"`)).
+		Joins("Nullifier.Spent", dbTX.DB().Select("transaction")).
+		Where(`"states"."domain_name" = ?`, domainName).
+		Where(`"states"."data" IS NOT NULL`) // purged states cannot be verified on import, so are not exported
+	if contractAddress != nil {
+		q = q.Where(`"states"."contract_address" = ?`, contractAddress)
+	}
+	err = tenantContractScope(ctx, dbTX, q).
+		Order(`"states"."created"`).
+		Order(`"states"."id"`).
+		Find(&states).
+		Error
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := &pldapi.StateSnapshot{
+		Domain:          domainName,
+		ContractAddress: contractAddress,
+		Created:         pldtypes.TimestampNow(),
+		Schemas:         schemas,
+		States:          make([]*pldapi.StateSnapshotEntry, len(states)),
+	}
+	for i, s := range states {
+		entry := &pldapi.StateSnapshotEntry{StateBase: s.StateBase}
+		if s.Confirmed != nil {
+			entry.Confirmed = &s.Confirmed.Transaction
+		}
+		if s.Nullifier != nil && len(s.Nullifier.ID) > 0 {
+			// For states with a nullifier, it is the nullifier that is spent
+			entry.Nullifier = s.Nullifier.ID
+			if s.Nullifier.Spent != nil {
+				entry.Spent = &s.Nullifier.Spent.Transaction
+			}
+		} else if s.Spent != nil {
+			entry.Spent = &s.Spent.Transaction
+		}
+		snapshot.States[i] = entry
+	}
+	snapshot.Hash = snapshotHash(snapshot)
+	return snapshot, nil
+}
+
+// Importing is idempotent, as all the records are immutable. So a snapshot can be imported
+// into a node that already has some (or all) of the states.
+func (ss *stateManager) ImportStateSnapshot(ctx context.Context, dbTX persistence.DBTX, snapshot *pldapi.StateSnapshot) (*pldapi.StateSnapshotImportResult, error) {
+	hash := snapshotHash(snapshot)
+	if hash != snapshot.Hash {
+		return nil, i18n.NewError(ctx, msgs.MsgStateSnapshotHashMismatch, snapshot.Hash, hash)
+	}
+
+	d, err := ss.domainManager.GetDomainByName(ctx, snapshot.Domain)
+	if err != nil {
+		return nil, err
+	}
+
+	// A tenant can only import the states of contracts it has access to
+	contractAddresses := make([]*pldtypes.EthAddress, 0, 1)
+	for _, s := range snapshot.States {
+		if !slices.ContainsFunc(contractAddresses, s.ContractAddress.Equals) {
+			contractAddresses = append(contractAddresses, s.ContractAddress)
+		}
+	}
+	if err := checkTenantContracts(ctx, dbTX, contractAddresses); err != nil {
+		return nil, err
+	}
+
+	// Check each schema ID is the hash of its definition, before we store any of them
+	schemaIDs := make(map[pldtypes.Bytes32]bool)
+	schemaDefs := make([]*abi.Parameter, len(snapshot.Schemas))
+	for i, schema := range snapshot.Schemas {
+		if schema.DomainName != snapshot.Domain {
+			return nil, i18n.NewError(ctx, msgs.MsgStateSnapshotWrongDomain, schema.ID, schema.DomainName, snapshot.Domain)
+		}
+		if schema.Type.V() != pldapi.SchemaTypeABI {
+			return nil, i18n.NewError(ctx, msgs.MsgStateInvalidSchemaType, schema.Type)
+		}
+		if err := json.Unmarshal(schema.Definition, &schemaDefs[i]); err != nil {
+			return nil, i18n.WrapError(ctx, err, msgs.MsgStateInvalidSchema)
+		}
+		as, err := newABISchema(ctx, snapshot.Domain, schemaDefs[i])
+		if err != nil {
+			return nil, err
+		}
+		if as.ID() != schema.ID {
+			return nil, i18n.NewError(ctx, msgs.MsgStateSnapshotSchemaMismatch, schema.ID, as.ID())
+		}
+		schemaIDs[schema.ID] = true
+	}
+	if _, err := ss.EnsureABISchemas(ctx, dbTX, snapshot.Domain, schemaDefs); err != nil {
+		return nil, err
+	}
+
+	upserts := make([]*components.StateUpsertOutsideContext, len(snapshot.States))
+	var nullifiers []*components.NullifierUpsert
+	var confirms []*pldapi.StateConfirmRecord
+	var spends []*pldapi.StateSpendRecord
+	for i, s := range snapshot.States {
+		if s.DomainName != snapshot.Domain {
+			return nil, i18n.NewError(ctx, msgs.MsgStateSnapshotWrongDomain, s.ID, s.DomainName, snapshot.Domain)
+		}
+		if snapshot.ContractAddress != nil && !snapshot.ContractAddress.Equals(s.ContractAddress) {
+			return nil, i18n.NewError(ctx, msgs.MsgStateSnapshotWrongContract, s.ID, snapshot.ContractAddress)
+		}
+		if !schemaIDs[s.Schema] {
+			return nil, i18n.NewError(ctx, msgs.MsgStateSnapshotSchemaMissing, s.ID, s.Schema)
+		}
+		upserts[i] = &components.StateUpsertOutsideContext{
+			ID:              s.ID,
+			SchemaID:        s.Schema,
+			ContractAddress: s.ContractAddress,
+			Data:            s.Data,
+		}
+		if s.Confirmed != nil {
+			confirms = append(confirms, &pldapi.StateConfirmRecord{DomainName: snapshot.Domain, State: s.ID, Transaction: *s.Confirmed})
+		}
+		spentID := s.ID
+		if s.Nullifier != nil {
+			nullifiers = append(nullifiers, &components.NullifierUpsert{ID: s.Nullifier, State: s.ID})
+			spentID = s.Nullifier
+		}
+		if s.Spent != nil {
+			spends = append(spends, &pldapi.StateSpendRecord{DomainName: snapshot.Domain, State: spentID, Transaction: *s.Spent})
+		}
+	}
+
+	// The IDs are verified against the data here - we do not trust the snapshot
+	if err := ss.validateCustomStateHashes(ctx, d, upserts); err != nil {
+		return nil, err
+	}
+	states, err := ss.processStates(ctx, dbTX, d, upserts)
+	if err != nil {
+		return nil, err
+	}
+	for i, s := range states {
+		if !s.ID.Equals(snapshot.States[i].ID) {
+			return nil, i18n.NewError(ctx, msgs.MsgStateHashMismatch, snapshot.States[i].ID, s.ID)
+		}
+		// Keep the original creation time, as that determines the order states are selected in
+		if snapshot.States[i].Created != 0 {
+			s.Created = snapshot.States[i].Created
+		}
+	}
+	if err := ss.writeStates(ctx, dbTX, states); err != nil {
+		return nil, err
+	}
+	if err := ss.WriteNullifiersForReceivedStates(ctx, dbTX, snapshot.Domain, nullifiers); err != nil {
+		return nil, err
+	}
+	if err := ss.WriteStateFinalizations(ctx, dbTX, spends, nil, confirms, nil); err != nil {
+		return nil, err
+	}
+	dbTX.AddPostCommit(ss.txManager.NotifyStatesDBChanged)
+
+	return &pldapi.StateSnapshotImportResult{
+		Domain:  snapshot.Domain,
+		Hash:    hash,
+		Schemas: len(snapshot.Schemas),
+		States:  len(snapshot.States),
+	}, nil
+}
@@ -0,0 +1,262 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statemgr
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hyperledger/firefly-signer/pkg/abi"
+	"github.com/kaleido-io/paladin/core/internal/components"
+	"github.com/kaleido-io/paladin/core/pkg/persistence"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/query"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func writeSnapshotTestStates(t *testing.T, ctx context.Context, ss *stateManager, contract1, contract2 *pldtypes.EthAddress) (states []*pldapi.State, nullifier pldtypes.HexBytes, txIDs []uuid.UUID) {
+	schemas, err := ss.EnsureABISchemas(ctx, ss.p.NOTX(), "domain1", []*abi.Parameter{testABIParam(t, fakeCoinABI)})
+	require.NoError(t, err)
+
+	upserts := make([]*components.StateUpsertOutsideContext, 3)
+	for i := range upserts {
+		contract := contract1
+		if i == 2 {
+			contract = contract2
+		}
+		upserts[i] = &components.StateUpsertOutsideContext{
+			SchemaID:        schemas[0].ID(),
+			ContractAddress: contract,
+			Data: pldtypes.RawJSON(fmt.Sprintf(`{"amount": %d, "owner": "0x615dD09124271D8008225054d85Ffe720E7a447A", "salt": "%s"}`,
+				(i+1)*10, pldtypes.RandHex(32))),
+		}
+	}
+	err = ss.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) (err error) {
+		states, err = ss.WritePreVerifiedStates(ctx, dbTX, "domain1", upserts)
+		return err
+	})
+	require.NoError(t, err)
+
+	nullifier = pldtypes.RandBytes(32)
+	err = ss.WriteNullifiersForReceivedStates(ctx, ss.p.NOTX(), "domain1", []*components.NullifierUpsert{
+		{ID: nullifier, State: states[2].ID},
+	})
+	require.NoError(t, err)
+
+	txIDs = []uuid.UUID{uuid.New(), uuid.New(), uuid.New()}
+	err = ss.WriteStateFinalizations(ctx, ss.p.NOTX(),
+		[]*pldapi.StateSpendRecord{
+			{DomainName: "domain1", State: states[0].ID, Transaction: txIDs[1]},
+			{DomainName: "domain1", State: nullifier, Transaction: txIDs[2]},
+		},
+		nil,
+		[]*pldapi.StateConfirmRecord{
+			{DomainName: "domain1", State: states[0].ID, Transaction: txIDs[0]},
+			{DomainName: "domain1", State: states[1].ID, Transaction: txIDs[0]},
+			{DomainName: "domain1", State: states[2].ID, Transaction: txIDs[1]},
+		},
+		nil)
+	require.NoError(t, err)
+	return states, nullifier, txIDs
+}
+
+func importTestSnapshot(ctx context.Context, ss *stateManager, snapshot *pldapi.StateSnapshot) (result *pldapi.StateSnapshotImportResult, err error) {
+	err = ss.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) (err error) {
+		result, err = ss.ImportStateSnapshot(ctx, dbTX, snapshot)
+		return err
+	})
+	return result, err
+}
+
+func copySnapshot(t *testing.T, snapshot *pldapi.StateSnapshot) *pldapi.StateSnapshot {
+	var copied *pldapi.StateSnapshot
+	err := json.Unmarshal(pldtypes.JSONString(snapshot), &copied)
+	require.NoError(t, err)
+	return copied
+}
+
+func TestStateSnapshotExportImport(t *testing.T) {
+	ctx, ss1, m1, done1 := newDBTestStateManager(t)
+	defer done1()
+	mockDomain(t, m1, "domain1", false)
+	mockStateCallback(m1)
+
+	contract1 := pldtypes.RandAddress()
+	contract2 := pldtypes.RandAddress()
+	states, nullifier, txIDs := writeSnapshotTestStates(t, ctx, ss1, contract1, contract2)
+
+	snapshot, err := ss1.ExportStateSnapshot(ctx, ss1.p.NOTX(), "domain1", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "domain1", snapshot.Domain)
+	require.Len(t, snapshot.Schemas, 1)
+	require.Len(t, snapshot.States, 3)
+	assert.Equal(t, snapshotHash(snapshot), snapshot.Hash)
+	byID := make(map[string]*pldapi.StateSnapshotEntry)
+	for _, s := range snapshot.States {
+		byID[s.ID.String()] = s
+	}
+	s0 := byID[states[0].ID.String()]
+	assert.Equal(t, txIDs[0], *s0.Confirmed)
+	assert.Equal(t, txIDs[1], *s0.Spent)
+	assert.Nil(t, s0.Nullifier)
+	s1 := byID[states[1].ID.String()]
+	assert.Equal(t, txIDs[0], *s1.Confirmed)
+	assert.Nil(t, s1.Spent)
+	s2 := byID[states[2].ID.String()]
+	assert.Equal(t, txIDs[1], *s2.Confirmed)
+	assert.Equal(t, txIDs[2], *s2.Spent)
+	assert.Equal(t, nullifier, s2.Nullifier)
+
+	contractSnapshot, err := ss1.ExportStateSnapshot(ctx, ss1.p.NOTX(), "domain1", contract1)
+	require.NoError(t, err)
+	assert.Len(t, contractSnapshot.States, 2)
+	assert.NotEqual(t, snapshot.Hash, contractSnapshot.Hash)
+
+	// Import into a second node, and check we get the same thing back out
+	_, ss2, m2, done2 := newDBTestStateManager(t)
+	defer done2()
+	mockDomain(t, m2, "domain1", false)
+	mockStateCallback(m2)
+
+	result, err := importTestSnapshot(ctx, ss2, copySnapshot(t, snapshot))
+	require.NoError(t, err)
+	assert.Equal(t, &pldapi.StateSnapshotImportResult{
+		Domain:  "domain1",
+		Hash:    snapshot.Hash,
+		Schemas: 1,
+		States:  3,
+	}, result)
+
+	// Importing again is harmless
+	_, err = importTestSnapshot(ctx, ss2, copySnapshot(t, snapshot))
+	require.NoError(t, err)
+
+	reExported, err := ss2.ExportStateSnapshot(ctx, ss2.p.NOTX(), "domain1", nil)
+	require.NoError(t, err)
+	assert.Equal(t, snapshot.Hash, reExported.Hash)
+	assert.Equal(t, pldtypes.JSONString(snapshot.States).Pretty(), pldtypes.JSONString(reExported.States).Pretty())
+
+	// The states are available for query on the new node
+	available, err := ss2.FindStates(ctx, ss2.p.NOTX(), "domain1", snapshot.Schemas[0].ID, query.NewQueryBuilder().Query(), &components.StateQueryOptions{
+		StatusQualifier: pldapi.StateStatusAvailable,
+	})
+	require.NoError(t, err)
+	// The nullifier spend of states[2] is only applied to queries that use nullifiers
+	require.Len(t, available, 2)
+	assert.Equal(t, states[1].ID, available[0].ID)
+	assert.Equal(t, states[2].ID, available[1].ID)
+
+	// States with their data purged are not exported, as they cannot be verified on import
+	err = ss2.p.NOTX().DB().Table("states").Where("id = ?", states[0].ID).Update("data", nil).Error
+	require.NoError(t, err)
+	reExported, err = ss2.ExportStateSnapshot(ctx, ss2.p.NOTX(), "domain1", nil)
+	require.NoError(t, err)
+	assert.Len(t, reExported.States, 2)
+	_, err = importTestSnapshot(ctx, ss1, reExported)
+	require.NoError(t, err)
+}
+
+func TestStateSnapshotImportErrors(t *testing.T) {
+	ctx, ss1, m1, done1 := newDBTestStateManager(t)
+	defer done1()
+	mockDomain(t, m1, "domain1", false)
+	mockStateCallback(m1)
+
+	contract1 := pldtypes.RandAddress()
+	writeSnapshotTestStates(t, ctx, ss1, contract1, contract1)
+	snapshot, err := ss1.ExportStateSnapshot(ctx, ss1.p.NOTX(), "domain1", contract1)
+	require.NoError(t, err)
+
+	_, ss2, m2, done2 := newDBTestStateManager(t)
+	defer done2()
+	md := mockDomain(t, m2, "domain1", true)
+	md.On("ValidateStateHashes", mock.Anything, mock.Anything).Return(func(ctx context.Context, states []*components.FullState) ([]pldtypes.HexBytes, error) {
+		ids := make([]pldtypes.HexBytes, len(states))
+		for i := range states {
+			ids[i] = pldtypes.RandBytes(32)
+		}
+		return ids, nil
+	})
+
+	tamper := func(fn func(s *pldapi.StateSnapshot)) *pldapi.StateSnapshot {
+		s := copySnapshot(t, snapshot)
+		fn(s)
+		s.Hash = snapshotHash(s)
+		return s
+	}
+
+	bad := copySnapshot(t, snapshot)
+	bad.States = bad.States[1:]
+	_, err = importTestSnapshot(ctx, ss2, bad)
+	assert.Regexp(t, "PD010134", err)
+
+	_, err = importTestSnapshot(ctx, ss2, tamper(func(s *pldapi.StateSnapshot) {
+		s.Schemas[0].ID = pldtypes.RandBytes32()
+	}))
+	assert.Regexp(t, "PD010135", err)
+
+	_, err = importTestSnapshot(ctx, ss2, tamper(func(s *pldapi.StateSnapshot) {
+		s.Schemas[0].DomainName = "domain2"
+	}))
+	assert.Regexp(t, "PD010136", err)
+
+	_, err = importTestSnapshot(ctx, ss2, tamper(func(s *pldapi.StateSnapshot) {
+		s.Schemas[0].Type = "wrong"
+	}))
+	assert.Regexp(t, "PD010103", err)
+
+	_, err = importTestSnapshot(ctx, ss2, tamper(func(s *pldapi.StateSnapshot) {
+		s.Schemas[0].Definition = pldtypes.RawJSON(`!{`)
+	}))
+	assert.Regexp(t, "PD010113", err)
+
+	_, err = importTestSnapshot(ctx, ss2, tamper(func(s *pldapi.StateSnapshot) {
+		s.States[0].DomainName = "domain2"
+	}))
+	assert.Regexp(t, "PD010136", err)
+
+	_, err = importTestSnapshot(ctx, ss2, tamper(func(s *pldapi.StateSnapshot) {
+		s.States[0].ContractAddress = pldtypes.RandAddress()
+	}))
+	assert.Regexp(t, "PD010137", err)
+
+	_, err = importTestSnapshot(ctx, ss2, tamper(func(s *pldapi.StateSnapshot) {
+		s.States[0].Schema = pldtypes.RandBytes32()
+	}))
+	assert.Regexp(t, "PD010138", err)
+
+	// The domain does not agree with the IDs
+	_, err = importTestSnapshot(ctx, ss2, copySnapshot(t, snapshot))
+	assert.Regexp(t, "PD010129", err)
+}
+
+func TestStateSnapshotImportBadDomain(t *testing.T) {
+	ctx, ss, m, done := newDBTestStateManager(t)
+	defer done()
+	m.domainManager.On("GetDomainByName", mock.Anything, "domain1").Return(nil, fmt.Errorf("not found"))
+
+	s := &pldapi.StateSnapshot{Domain: "domain1"}
+	s.Hash = snapshotHash(s)
+	_, err := importTestSnapshot(ctx, ss, s)
+	assert.Regexp(t, "not found", err)
+}
@@ -22,6 +22,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
 	"github.com/kaleido-io/paladin/core/internal/components"
 	"github.com/kaleido-io/paladin/core/internal/filters"
 	"github.com/kaleido-io/paladin/core/internal/msgs"
@@ -64,29 +65,39 @@ func (ss *stateManager) WriteReceivedStates(ctx context.Context, dbTX persistenc
 		return nil, err
 	}
 
-	if d.CustomHashFunction() {
-		dStates := make([]*components.FullState, len(states))
-		for i, s := range states {
-			dStates[i] = &components.FullState{
-				ID:     s.ID,
-				Schema: s.SchemaID,
-				Data:   s.Data,
-			}
-		}
-		ids, err := d.ValidateStateHashes(ctx, dStates)
-		if err != nil {
-			// Whole batch fails if any state in the batch is invalid
-			return nil, err
-		}
-		for i, s := range states {
-			// The domain is responsible for generating any missing IDs
-			s.ID = ids[i]
-		}
+	if err := ss.validateCustomStateHashes(ctx, d, states); err != nil {
+		return nil, err
 	}
 
 	return ss.processInsertStates(ctx, dbTX, d, states)
 }
 
+// For domains with a custom hash function, the domain validates (or generates) the IDs.
+// Default hashes are validated by the schema as each state is processed.
+func (ss *stateManager) validateCustomStateHashes(ctx context.Context, d components.Domain, states []*components.StateUpsertOutsideContext) error {
+	if !d.CustomHashFunction() {
+		return nil
+	}
+	dStates := make([]*components.FullState, len(states))
+	for i, s := range states {
+		dStates[i] = &components.FullState{
+			ID:     s.ID,
+			Schema: s.SchemaID,
+			Data:   s.Data,
+		}
+	}
+	ids, err := d.ValidateStateHashes(ctx, dStates)
+	if err != nil {
+		// Whole batch fails if any state in the batch is invalid
+		return err
+	}
+	for i, s := range states {
+		// The domain is responsible for generating any missing IDs
+		s.ID = ids[i]
+	}
+	return nil
+}
+
 func (ss *stateManager) WriteNullifiersForReceivedStates(ctx context.Context, dbTX persistence.DBTX, domainName string, upserts []*components.NullifierUpsert) (err error) {
 	d, err := ss.domainManager.GetDomainByName(ctx, domainName)
 	if err != nil {
@@ -117,6 +128,22 @@ func (ss *stateManager) WriteNullifiersForReceivedStates(ctx context.Context, db
 
 func (ss *stateManager) processInsertStates(ctx context.Context, dbTX persistence.DBTX, d components.Domain, inStates []*components.StateUpsertOutsideContext) (processedStates []*pldapi.State, err error) {
 
+	processedStates, err = ss.processStates(ctx, dbTX, d, inStates)
+	if err != nil {
+		return nil, err
+	}
+
+	// Write them directly
+	if err = ss.writeStates(ctx, dbTX, processedStates); err != nil {
+		return nil, err
+	}
+
+	dbTX.AddPostCommit(ss.txManager.NotifyStatesDBChanged)
+	return processedStates, nil
+}
+
+func (ss *stateManager) processStates(ctx context.Context, dbTX persistence.DBTX, d components.Domain, inStates []*components.StateUpsertOutsideContext) (processedStates []*pldapi.State, err error) {
+
 	processedStates = make([]*pldapi.State, len(inStates))
 	for i, inState := range inStates {
 		schema, err := ss.getSchemaByID(ctx, dbTX, d.Name(), inState.SchemaID, true)
@@ -130,13 +157,6 @@ func (ss *stateManager) processInsertStates(ctx context.Context, dbTX persistenc
 		}
 		processedStates[i] = s.State
 	}
-
-	// Write them directly
-	if err = ss.writeStates(ctx, dbTX, processedStates); err != nil {
-		return nil, err
-	}
-
-	dbTX.AddPostCommit(ss.txManager.NotifyStatesDBChanged)
 	return processedStates, nil
 }
 
@@ -280,22 +300,7 @@ func (ss *stateManager) findStates(
 	}
 	whereClause, isPlainDB := whereClauseForQual(dbTX.DB(), options.StatusQualifier, "Spent")
 	if isPlainDB {
-		return ss.findStatesCommon(ctx, dbTX, domainName, contractAddress, schemaID, jq, func(dbTX persistence.DBTX, q *gorm.DB) *gorm.DB {
-			q = q.Joins("Confirmed", dbTX.DB().Select("transaction")).
-				Joins("Spent", dbTX.DB().Select("transaction"))
-
-			if len(options.ExcludedIDs) > 0 {
-				q = q.Not(`"states"."id" IN(?)`, options.ExcludedIDs)
-			}
-
-			// Scope the query based on the status qualifier
-			q = q.Where(whereClause)
-
-			if options.QueryModifier != nil {
-				q = options.QueryModifier(dbTX, q)
-			}
-			return q
-		})
+		return ss.findStatesCommon(ctx, dbTX, domainName, contractAddress, schemaID, jq, statesQueryModifier(whereClause, options))
 	}
 
 	// Otherwise, we need to run it against the specified domain context
@@ -312,6 +317,25 @@ func (ss *stateManager) findStates(
 	return dc.FindAvailableStates(dbTX, schemaID, jq)
 }
 
+func statesQueryModifier(whereClause *gorm.DB, options *components.StateQueryOptions) func(dbTX persistence.DBTX, q *gorm.DB) *gorm.DB {
+	return func(dbTX persistence.DBTX, q *gorm.DB) *gorm.DB {
+		q = q.Joins("Confirmed", dbTX.DB().Select("transaction", "tx_hash", "block_number")).
+			Joins("Spent", dbTX.DB().Select("transaction"))
+
+		if len(options.ExcludedIDs) > 0 {
+			q = q.Not(`"states"."id" IN(?)`, options.ExcludedIDs)
+		}
+
+		// Scope the query based on the status qualifier
+		q = q.Where(whereClause)
+
+		if options.QueryModifier != nil {
+			q = options.QueryModifier(dbTX, q)
+		}
+		return q
+	}
+}
+
 func (ss *stateManager) findNullifiers(
 	ctx context.Context,
 	dbTX persistence.DBTX,
@@ -328,7 +352,7 @@ func (ss *stateManager) findNullifiers(
 		return ss.findStatesCommon(ctx, dbTX, domainName, contractAddress, schemaID, jq, func(dbTX persistence.DBTX, q *gorm.DB) *gorm.DB {
 			hasNullifier := dbTX.DB().Where(`"Nullifier"."id" IS NOT NULL`)
 
-			q = q.Joins("Confirmed", dbTX.DB().Select("transaction")).
+			q = q.Joins("Confirmed", dbTX.DB().Select("transaction", "tx_hash", "block_number")).
 				Joins("Nullifier", dbTX.DB().Select(`"Nullifier"."id"`)).
 				Joins("Nullifier.Spent", dbTX.DB().Select("transaction")).
 				Where(hasNullifier)
@@ -369,30 +393,69 @@ func (ss *stateManager) findStatesCommon(
 	jq *query.QueryJSON,
 	modifyQuery func(dbTX persistence.DBTX, q *gorm.DB) *gorm.DB,
 ) (schema components.Schema, s []*pldapi.State, err error) {
+	schema, q, _, err := ss.buildStatesQuery(ctx, dbTX, domainName, contractAddress, schemaID, jq, modifyQuery)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var states []*pldapi.State
+	q = q.Find(&states)
+	if q.Error != nil {
+		return nil, nil, q.Error
+	}
+	return schema, states, nil
+}
+
+func (ss *stateManager) buildStatesQuery(
+	ctx context.Context,
+	dbTX persistence.DBTX,
+	domainName string,
+	contractAddress *pldtypes.EthAddress,
+	schemaID pldtypes.Bytes32,
+	jq *query.QueryJSON,
+	modifyQuery func(dbTX persistence.DBTX, q *gorm.DB) *gorm.DB,
+) (schema components.Schema, q *gorm.DB, plan *queryPlan, err error) {
 	if len(jq.Sort) == 0 {
 		jq.Sort = []string{".created"}
 	}
 
 	schema, err = ss.getSchemaByID(ctx, dbTX, domainName, schemaID, true)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 
 	tracker := ss.labelSetFor(schema)
+	plan, err = ss.planQuery(ctx, dbTX, domainName, schema, tracker, jq)
+	if err != nil {
+		return nil, nil, nil, err
+	}
 
 	// Build the query
-	q := filters.BuildGORM(ctx, jq, dbTX.DB().Table("states"), tracker)
+	q = filters.BuildGORM(ctx, plan.query, dbTX.DB().Table("states"), tracker)
 	if q.Error != nil {
-		return nil, nil, q.Error
+		return nil, nil, nil, q.Error
+	}
+	if !plan.indexed && hasPredicates(&jq.Statements) {
+		log.L(ctx).Warnf("Query for schema %s in domain %s has no predicates that can use a label index, so will scan all states of the schema: %s",
+			schemaID, domainName, jq)
 	}
 
-	// Add joins only for the fields actually used in the query
-	for _, fi := range tracker.used {
+	// Add joins only for the fields actually used in the query, most selective first.
+	// When scoped to a contract, the labels are filtered by that contract too, so we only
+	// use the part of the label index for that contract.
+	plan.labels = plan.stats.orderedLabels(tracker.used)
+	for _, ls := range plan.labels {
+		fi := tracker.used[ls.Label]
 		typeMod := ""
 		if fi.labelType == labelTypeInt64 || fi.labelType == labelTypeBool {
 			typeMod = "int64_"
 		}
-		q = q.Joins(fmt.Sprintf(`INNER JOIN state_%[1]slabels AS %[2]s ON %[2]s.state = "states"."id" AND %[2]s.label = ?`, typeMod, fi.virtualColumn), fi.label)
+		joinSQL := fmt.Sprintf(`INNER JOIN state_%[1]slabels AS %[2]s ON %[2]s.domain_name = "states"."domain_name" AND %[2]s.state = "states"."id" AND %[2]s.label = ?`, typeMod, fi.virtualColumn)
+		if contractAddress != nil {
+			q = q.Joins(joinSQL+fmt.Sprintf(` AND %s.contract_address = ?`, fi.virtualColumn), fi.label, contractAddress)
+		} else {
+			q = q.Joins(joinSQL, fi.label)
+		}
 	}
 
 	q = q.Where("states.domain_name = ?", domainName).
@@ -400,12 +463,38 @@ func (ss *stateManager) findStatesCommon(
 	if contractAddress != nil {
 		q = q.Where("states.contract_address = ?", contractAddress)
 	}
+	q = tenantContractScope(ctx, dbTX, q)
 	q = modifyQuery(dbTX, q)
+	return schema, q, plan, nil
+}
+
+// Returns the SQL that would be run for a query, along with the label statistics the planner used to build it
+func (ss *stateManager) ExplainStatesQuery(ctx context.Context, dbTX persistence.DBTX, domainName string, contractAddress *pldtypes.EthAddress, schemaID pldtypes.Bytes32, jq *query.QueryJSON, status pldapi.StateStatusQualifier) (*pldapi.StateQueryExplanation, error) {
+	if status == "" {
+		status = pldapi.StateStatusAll
+	}
+	whereClause, isPlainDB := whereClauseForQual(dbTX.DB(), status, "Spent")
+	if !isPlainDB {
+		return nil, i18n.NewError(ctx, msgs.MsgStateExplainDomainContext, status)
+	}
+	_, q, plan, err := ss.buildStatesQuery(ctx, dbTX, domainName, contractAddress, schemaID, jq,
+		statesQueryModifier(whereClause, &components.StateQueryOptions{StatusQualifier: status}))
+	if err != nil {
+		return nil, err
+	}
 
 	var states []*pldapi.State
-	q = q.Find(&states)
-	if q.Error != nil {
-		return nil, nil, q.Error
+	stmt := q.Session(&gorm.Session{DryRun: true}).Find(&states).Statement
+	if stmt.Error != nil {
+		return nil, stmt.Error
 	}
-	return schema, states, nil
+	explanation := &pldapi.StateQueryExplanation{
+		SQL:        dbTX.DB().Dialector.Explain(stmt.SQL.String(), stmt.Vars...),
+		Indexed:    plan.indexed,
+		LabelStats: plan.labels,
+	}
+	if plan.stats != nil {
+		explanation.StatsTime = pldtypes.Timestamp(plan.stats.calculated.UnixNano())
+	}
+	return explanation, nil
 }
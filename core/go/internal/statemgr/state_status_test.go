@@ -107,7 +107,7 @@ func newTestDomainContext(t *testing.T, ctx context.Context, ss *stateManager, n
 	md.On("Name").Return(name)
 	md.On("CustomHashFunction").Return(customHashFunction)
 	contractAddress := pldtypes.RandAddress()
-	dc := ss.NewDomainContext(ctx, md, *contractAddress)
+	dc := ss.NewDomainContext(ctx, md, *contractAddress, components.DomainContextIsolationSpeculative)
 	return contractAddress, dc.(*domainContext)
 }
 
@@ -281,3 +281,56 @@ func TestStateLockingQuery(t *testing.T) {
 	checkQuery(query.NewQueryBuilder().Equal("color", "pink").Query(), pldapi.StateStatusAvailable)
 
 }
+
+func TestStateConfirmLocationInQueryResults(t *testing.T) {
+
+	ctx, ss, m, done := newDBTestStateManager(t)
+	defer done()
+
+	_ = mockDomain(t, m, "domain1", false)
+	mockStateCallback(m)
+
+	schema, err := newABISchema(ctx, "domain1", testABIParam(t, widgetABI))
+	require.NoError(t, err)
+	err = ss.persistSchemas(ctx, ss.p.NOTX(), []*pldapi.Schema{schema.Schema})
+	require.NoError(t, err)
+	schemaID := schema.ID()
+
+	contractAddress := pldtypes.RandAddress()
+	widgets := makeWidgets(t, ctx, ss, "domain1", contractAddress, schemaID, []string{
+		`{"size": 11111, "color": "red",  "price": 100}`,
+		`{"size": 22222, "color": "blue", "price": 150}`,
+	})
+
+	// One confirmation with the on-chain location, and one without (such as one imported from a snapshot)
+	txHash := pldtypes.RandBytes32()
+	blockNumber := int64(12345)
+	txID1 := uuid.New()
+	txID2 := uuid.New()
+	err = ss.WriteStateFinalizations(ctx, ss.p.NOTX(), []*pldapi.StateSpendRecord{}, []*pldapi.StateReadRecord{},
+		[]*pldapi.StateConfirmRecord{
+			{DomainName: "domain1", State: widgets[0].ID, Transaction: txID1, TransactionHash: &txHash, BlockNumber: &blockNumber},
+			{DomainName: "domain1", State: widgets[1].ID, Transaction: txID2},
+		}, []*pldapi.StateInfoRecord{})
+	require.NoError(t, err)
+
+	states, err := ss.FindContractStates(ctx, ss.p.NOTX(), "domain1", contractAddress, schemaID,
+		query.NewQueryBuilder().Sort("price").Query(), pldapi.StateStatusAvailable)
+	require.NoError(t, err)
+	require.Len(t, states, 2)
+
+	require.NotNil(t, states[0].Confirmed)
+	assert.Equal(t, txID1, states[0].Confirmed.Transaction)
+	assert.Equal(t, txHash, *states[0].Confirmed.TransactionHash)
+	assert.Equal(t, blockNumber, *states[0].Confirmed.BlockNumber)
+
+	require.NotNil(t, states[1].Confirmed)
+	assert.Equal(t, txID2, states[1].Confirmed.Transaction)
+	assert.Nil(t, states[1].Confirmed.TransactionHash)
+	assert.Nil(t, states[1].Confirmed.BlockNumber)
+
+	// The location is serialized with the confirmation in API results
+	b, err := json.Marshal(states[0].Confirmed)
+	require.NoError(t, err)
+	assert.JSONEq(t, fmt.Sprintf(`{"transaction":"%s","transactionHash":"%s","blockNumber":12345}`, txID1, txHash), string(b))
+}
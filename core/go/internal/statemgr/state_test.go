@@ -17,6 +17,7 @@
 package statemgr
 
 import (
+	"context"
 	"fmt"
 	"testing"
 	"time"
@@ -289,7 +290,7 @@ func TestFindNullifiersInContext(t *testing.T) {
 	td.On("Name").Return("domain1")
 	td.On("CustomHashFunction").Return(false)
 
-	dCtx := ss.NewDomainContext(ctx, td, *pldtypes.RandAddress())
+	dCtx := ss.NewDomainContext(ctx, td, *pldtypes.RandAddress(), components.DomainContextIsolationSpeculative)
 	defer dCtx.Close()
 
 	contractAddress := pldtypes.RandAddress()
@@ -351,3 +352,60 @@ func TestFindStatesWithNilOptions(t *testing.T) {
 	assert.Regexp(t, "called", err)
 
 }
+
+func TestFindStatesByContractRealDB(t *testing.T) {
+	ctx, ss, m, done := newDBTestStateManager(t)
+	defer done()
+
+	_ = mockDomain(t, m, "domain1", false)
+	mockStateCallback(m)
+
+	schemas, err := ss.EnsureABISchemas(ctx, ss.p.NOTX(), "domain1", []*abi.Parameter{testABIParam(t, fakeCoinABI)})
+	require.NoError(t, err)
+	schemaID := schemas[0].ID()
+
+	contract1 := pldtypes.RandAddress()
+	contract2 := pldtypes.RandAddress()
+	var upserts []*components.StateUpsertOutsideContext
+	for _, contract := range []*pldtypes.EthAddress{contract1, contract2, contract1, nil} {
+		upserts = append(upserts, &components.StateUpsertOutsideContext{
+			SchemaID:        schemaID,
+			ContractAddress: contract,
+			Data: pldtypes.RawJSON(fmt.Sprintf(`{"amount": 10, "owner": "0x615dD09124271D8008225054d85Ffe720E7a447A", "salt": "%s"}`,
+				pldtypes.RandHex(32))),
+		})
+	}
+	err = ss.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) (err error) {
+		_, err = ss.WritePreVerifiedStates(ctx, dbTX, "domain1", upserts)
+		return err
+	})
+	require.NoError(t, err)
+
+	amountQuery := query.NewQueryBuilder().Equal("amount", 10).Equal("owner", "0x615dD09124271D8008225054d85Ffe720E7a447A").Query()
+	states, err := ss.FindStates(ctx, ss.p.NOTX(), "domain1", schemaID, amountQuery, nil)
+	require.NoError(t, err)
+	assert.Len(t, states, 4)
+
+	states, err = ss.FindContractStates(ctx, ss.p.NOTX(), "domain1", contract1, schemaID, amountQuery, pldapi.StateStatusAll)
+	require.NoError(t, err)
+	require.Len(t, states, 2)
+	for _, s := range states {
+		assert.Equal(t, contract1, s.ContractAddress)
+	}
+
+	states, err = ss.FindContractStates(ctx, ss.p.NOTX(), "domain1", contract2, schemaID, query.NewQueryBuilder().Query(), pldapi.StateStatusAll)
+	require.NoError(t, err)
+	require.Len(t, states, 1)
+	assert.Equal(t, contract2, states[0].ContractAddress)
+
+	// The labels are stored against the contract, so the index can be scoped to it
+	var labelledStates int64
+	err = ss.p.DB().Table("state_labels").Where("contract_address = ?", contract2).Distinct("state").Count(&labelledStates).Error
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), labelledStates)
+
+	// ... and the label joins of a contract query are filtered by the contract, to use that part of the index
+	explanation, err := ss.ExplainStatesQuery(ctx, ss.p.NOTX(), "domain1", contract1, schemaID, amountQuery, pldapi.StateStatusAll)
+	require.NoError(t, err)
+	assert.Contains(t, explanation.SQL, `AND l0.label = "owner" AND l0.contract_address = "`+contract1.HexString()+`"`)
+}
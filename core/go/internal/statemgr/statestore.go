@@ -19,6 +19,7 @@ package statemgr
 import (
 	"context"
 	"sync"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/kaleido-io/paladin/config/pkg/confutil"
@@ -38,10 +39,14 @@ type stateManager struct {
 	conf              *pldconf.StateStoreConfig
 	domainManager     components.DomainManager
 	txManager         components.TXManager
+	jobScheduler      components.JobScheduler
 	abiSchemaCache    cache.Cache[string, components.Schema]
+	labelStatsCache   cache.Cache[string, *schemaLabelStats]
+	labelStatsMaxAge  time.Duration
 	rpcModule         *rpcserver.RPCModule
 	domainContextLock sync.Mutex
 	domainContexts    map[uuid.UUID]*domainContext
+	anchorer          *stateAnchorer
 }
 
 var SchemaCacheDefaults = &pldconf.CacheConfig{
@@ -50,10 +55,12 @@ var SchemaCacheDefaults = &pldconf.CacheConfig{
 
 func NewStateManager(ctx context.Context, conf *pldconf.StateStoreConfig, p persistence.Persistence) components.StateManager {
 	ss := &stateManager{
-		p:              p,
-		conf:           conf,
-		abiSchemaCache: cache.NewCache[string, components.Schema](&conf.SchemaCache, SchemaCacheDefaults),
-		domainContexts: make(map[uuid.UUID]*domainContext),
+		p:                p,
+		conf:             conf,
+		abiSchemaCache:   cache.NewNamedCache[string, components.Schema]("statemgr.abiSchemas", &conf.SchemaCache, SchemaCacheDefaults),
+		labelStatsCache:  cache.NewNamedCache[string, *schemaLabelStats]("statemgr.labelStats", &conf.LabelStats.Cache, &pldconf.LabelStatsDefaults.Cache),
+		labelStatsMaxAge: confutil.DurationMin(conf.LabelStats.MaxAge, 0, *pldconf.LabelStatsDefaults.MaxAge),
+		domainContexts:   make(map[uuid.UUID]*domainContext),
 	}
 	ss.bgCtx, ss.cancelCtx = context.WithCancel(ctx)
 	return ss
@@ -66,13 +73,23 @@ func (ss *stateManager) PreInit(c components.PreInitComponents) (*components.Man
 	}, nil
 }
 
-func (ss *stateManager) PostInit(c components.AllComponents) error {
+func (ss *stateManager) PostInit(c components.AllComponents) (err error) {
 	ss.domainManager = c.DomainManager()
 	ss.txManager = c.TxManager()
-	return nil
+	ss.anchorer, err = newStateAnchorer(ss.bgCtx, ss, &ss.conf.Anchoring)
+	if ss.anchorer != nil {
+		ss.jobScheduler = c.JobScheduler()
+		ss.jobScheduler.RegisterHandler(stateAnchoringJobType, ss.anchorer.runJob)
+	}
+	return err
 }
 
 func (ss *stateManager) Start() error {
+	if ss.anchorer != nil {
+		return ss.p.Transaction(ss.bgCtx, func(ctx context.Context, dbTX persistence.DBTX) error {
+			return ss.jobScheduler.EnsureRecurringJob(ctx, dbTX, stateAnchoringJobType, stateAnchoringJobType, ss.anchorer.schedule())
+		})
+	}
 	return nil
 }
 
@@ -39,13 +39,20 @@ func (ss *stateManager) initRPC() {
 		Add("pstate_queryStates", ss.rpcQueryStates()).
 		Add("pstate_queryContractStates", ss.rpcQueryContractStates()).
 		Add("pstate_queryNullifiers", ss.rpcQueryNullifiers()).
-		Add("pstate_queryContractNullifiers", ss.rpcQueryContractNullifiers())
+		Add("pstate_queryContractNullifiers", ss.rpcQueryContractNullifiers()).
+		Add("pstate_explainQuery", ss.rpcExplainQuery()).
+		Add("pstate_exportSnapshot", ss.rpcExportSnapshot()).
+		Add("pstate_importSnapshot", ss.rpcImportSnapshot()).
+		Add("pstate_getStateAnchorProof", ss.rpcGetStateAnchorProof())
 }
 
 func (ss *stateManager) rpcListSchema() rpcserver.RPCHandler {
 	return rpcserver.RPCMethod1(func(ctx context.Context,
 		domain string,
 	) ([]*pldapi.Schema, error) {
+		if err := rpcserver.CheckTenantDomain(ctx, domain); err != nil {
+			return nil, err
+		}
 		return ss.ListSchemasForJSON(ctx, ss.p.NOTX(), domain)
 	})
 }
@@ -57,8 +64,16 @@ func (ss *stateManager) rpcStoreState() rpcserver.RPCHandler {
 		schema pldtypes.Bytes32,
 		data pldtypes.RawJSON,
 	) (*pldapi.State, error) {
+		if err := rpcserver.CheckTenantDomain(ctx, domain); err != nil {
+			return nil, err
+		}
 		var state *pldapi.State
 		err := ss.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
+			if contractAddress != nil {
+				if err := checkTenantContracts(ctx, dbTX, []*pldtypes.EthAddress{contractAddress}); err != nil {
+					return err
+				}
+			}
 			newStates, err := ss.WriteReceivedStates(ctx, dbTX, domain, []*components.StateUpsertOutsideContext{
 				{
 					ContractAddress: contractAddress,
@@ -82,6 +97,9 @@ func (ss *stateManager) rpcQueryStates() rpcserver.RPCHandler {
 		query query.QueryJSON,
 		status pldapi.StateStatusQualifier,
 	) ([]*pldapi.State, error) {
+		if err := rpcserver.CheckTenantDomain(ctx, domain); err != nil {
+			return nil, err
+		}
 		return ss.FindStates(ctx, ss.p.NOTX(), domain, schema, &query, &components.StateQueryOptions{StatusQualifier: status})
 	})
 }
@@ -94,6 +112,9 @@ func (ss *stateManager) rpcQueryContractStates() rpcserver.RPCHandler {
 		query query.QueryJSON,
 		status pldapi.StateStatusQualifier,
 	) ([]*pldapi.State, error) {
+		if err := rpcserver.CheckTenantDomain(ctx, domain); err != nil {
+			return nil, err
+		}
 		return ss.FindContractStates(ctx, ss.p.NOTX(), domain, contractAddress, schema, &query, status)
 	})
 }
@@ -105,6 +126,9 @@ func (ss *stateManager) rpcQueryNullifiers() rpcserver.RPCHandler {
 		query query.QueryJSON,
 		status pldapi.StateStatusQualifier,
 	) ([]*pldapi.State, error) {
+		if err := rpcserver.CheckTenantDomain(ctx, domain); err != nil {
+			return nil, err
+		}
 		return ss.FindNullifiers(ctx, ss.p.NOTX(), domain, schema, &query, status)
 	})
 }
@@ -117,15 +141,75 @@ func (ss *stateManager) rpcQueryContractNullifiers() rpcserver.RPCHandler {
 		query query.QueryJSON,
 		status pldapi.StateStatusQualifier,
 	) ([]*pldapi.State, error) {
+		if err := rpcserver.CheckTenantDomain(ctx, domain); err != nil {
+			return nil, err
+		}
 		return ss.FindContractNullifiers(ctx, ss.p.NOTX(), domain, contractAddress, schema, &query, status)
 	})
 }
 
+func (ss *stateManager) rpcExplainQuery() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod5(func(ctx context.Context,
+		domain string,
+		contractAddress *pldtypes.EthAddress,
+		schema pldtypes.Bytes32,
+		query query.QueryJSON,
+		status pldapi.StateStatusQualifier,
+	) (*pldapi.StateQueryExplanation, error) {
+		if err := rpcserver.CheckTenantDomain(ctx, domain); err != nil {
+			return nil, err
+		}
+		return ss.ExplainStatesQuery(ctx, ss.p.NOTX(), domain, contractAddress, schema, &query, status)
+	})
+}
+
 func (ss *stateManager) rpcGetSchemaByID() rpcserver.RPCHandler {
 	return rpcserver.RPCMethod2(func(ctx context.Context,
 		domain string,
 		schemaID pldtypes.Bytes32,
 	) (*pldapi.Schema, error) {
+		if err := rpcserver.CheckTenantDomain(ctx, domain); err != nil {
+			return nil, err
+		}
 		return ss.GetSchemaByID(ctx, ss.p.NOTX(), domain, schemaID, false /* null on not found */)
 	})
 }
+
+func (ss *stateManager) rpcExportSnapshot() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod2(func(ctx context.Context,
+		domain string,
+		contractAddress *pldtypes.EthAddress,
+	) (*pldapi.StateSnapshot, error) {
+		if err := rpcserver.CheckTenantDomain(ctx, domain); err != nil {
+			return nil, err
+		}
+		return ss.ExportStateSnapshot(ctx, ss.p.NOTX(), domain, contractAddress)
+	})
+}
+
+func (ss *stateManager) rpcImportSnapshot() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod1(func(ctx context.Context,
+		snapshot pldapi.StateSnapshot,
+	) (result *pldapi.StateSnapshotImportResult, err error) {
+		if err := rpcserver.CheckTenantDomain(ctx, snapshot.Domain); err != nil {
+			return nil, err
+		}
+		err = ss.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
+			result, err = ss.ImportStateSnapshot(ctx, dbTX, &snapshot)
+			return err
+		})
+		return result, err
+	})
+}
+
+func (ss *stateManager) rpcGetStateAnchorProof() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod2(func(ctx context.Context,
+		domain string,
+		stateID pldtypes.HexBytes,
+	) (*pldapi.StateAnchorProof, error) {
+		if err := rpcserver.CheckTenantDomain(ctx, domain); err != nil {
+			return nil, err
+		}
+		return ss.GetStateAnchorProof(ctx, ss.p.NOTX(), domain, stateID)
+	})
+}
@@ -165,4 +165,91 @@ func TestRPC(t *testing.T) {
 	assert.Equal(t, state.ID, states[0].ID)
 	assert.Equal(t, nullifier1, states[0].Nullifier.ID)
 
+	var explanation *pldapi.StateQueryExplanation
+	rpcErr = c.CallRPC(ctx, &explanation, "pstate_explainQuery", "domain1", contractAddress.String(), schemas[0].ID, pldtypes.RawJSON(`{
+		"eq": [{
+		  "field": "color",
+		  "value": "blue"
+		}]
+	}`), "available")
+	jsonTestLog(t, "pstate_explainQuery", explanation)
+	assert.Nil(t, rpcErr)
+	assert.True(t, explanation.Indexed)
+	assert.Len(t, explanation.LabelStats, 1)
+	assert.Contains(t, explanation.SQL, "SELECT")
+
+	var snapshot *pldapi.StateSnapshot
+	rpcErr = c.CallRPC(ctx, &snapshot, "pstate_exportSnapshot", "domain1", contractAddress.String())
+	jsonTestLog(t, "pstate_exportSnapshot", snapshot)
+	assert.Nil(t, rpcErr)
+	require.Len(t, snapshot.States, 1)
+	assert.Equal(t, state.ID, snapshot.States[0].ID)
+	assert.Equal(t, nullifier1, snapshot.States[0].Nullifier)
+
+	var importResult *pldapi.StateSnapshotImportResult
+	rpcErr = c.CallRPC(ctx, &importResult, "pstate_importSnapshot", snapshot)
+	jsonTestLog(t, "pstate_importSnapshot", importResult)
+	assert.Nil(t, rpcErr)
+	assert.Equal(t, snapshot.Hash, importResult.Hash)
+	assert.Equal(t, 1, importResult.States)
+
+	var anchorProof *pldapi.StateAnchorProof
+	rpcErr = c.CallRPC(ctx, &anchorProof, "pstate_getStateAnchorProof", "domain1", state.ID)
+	assert.Nil(t, rpcErr)
+	assert.Nil(t, anchorProof) // anchoring is not enabled
+
+}
+
+func TestRPCTenantDomainDenied(t *testing.T) {
+	_, ss, _, done := newDBTestStateManager(t)
+	defer done()
+
+	ctx := rpcserver.WithTenant(context.Background(), &rpcserver.Tenant{Name: "app1", Domains: []string{"domain1"}})
+	zeroAddr := `"0x0000000000000000000000000000000000000000"`
+	zeroID := `"0x0000000000000000000000000000000000000000000000000000000000000000"`
+	for _, tc := range []struct {
+		handler rpcserver.RPCHandler
+		params  string
+	}{
+		{ss.rpcListSchema(), `["domain2"]`},
+		{ss.rpcGetSchemaByID(), `["domain2",` + zeroID + `]`},
+		{ss.rpcStoreState(), `["domain2",` + zeroAddr + `,` + zeroID + `,{}]`},
+		{ss.rpcQueryStates(), `["domain2",` + zeroID + `,{},"all"]`},
+		{ss.rpcQueryContractStates(), `["domain2",` + zeroAddr + `,` + zeroID + `,{},"all"]`},
+		{ss.rpcQueryNullifiers(), `["domain2",` + zeroID + `,{},"all"]`},
+		{ss.rpcQueryContractNullifiers(), `["domain2",` + zeroAddr + `,` + zeroID + `,{},"all"]`},
+		{ss.rpcExplainQuery(), `["domain2",` + zeroAddr + `,` + zeroID + `,{},"all"]`},
+		{ss.rpcExportSnapshot(), `["domain2",null]`},
+		{ss.rpcImportSnapshot(), `[{"domain":"domain2"}]`},
+		{ss.rpcGetStateAnchorProof(), `["domain2","0x"]`},
+	} {
+		var params []pldtypes.RawJSON
+		require.NoError(t, json.Unmarshal([]byte(tc.params), &params))
+		res := tc.handler.Handle(ctx, &rpcclient.RPCRequest{
+			JSONRpc: "2.0",
+			ID:      pldtypes.RawJSON(`1`),
+			Params:  params,
+		})
+		require.NotNil(t, res.Error, tc.params)
+		assert.Regexp(t, "PD020712.*app1.*domain2", res.Error.Message, tc.params)
+	}
+}
+
+func TestRPCStoreStateTenantContractDenied(t *testing.T) {
+	_, ss, _, done := newDBTestStateManager(t)
+	defer done()
+
+	contract1 := pldtypes.RandAddress()
+	ctx := rpcserver.WithTenant(context.Background(), &rpcserver.Tenant{Name: "app1", Contracts: []*pldtypes.EthAddress{contract1}})
+	zeroID := `"0x0000000000000000000000000000000000000000000000000000000000000000"`
+
+	var params []pldtypes.RawJSON
+	require.NoError(t, json.Unmarshal([]byte(`["domain1","`+pldtypes.RandAddress().String()+`",`+zeroID+`,{}]`), &params))
+	res := ss.rpcStoreState().Handle(ctx, &rpcclient.RPCRequest{
+		JSONRpc: "2.0",
+		ID:      pldtypes.RawJSON(`1`),
+		Params:  params,
+	})
+	require.NotNil(t, res.Error)
+	assert.Regexp(t, "PD020716.*app1", res.Error.Message)
 }
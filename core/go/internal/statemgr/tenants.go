@@ -0,0 +1,76 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package statemgr
+
+import (
+	"context"
+	"slices"
+
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/common/go/pkg/pldmsgs"
+	"github.com/kaleido-io/paladin/core/pkg/persistence"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/toolkit/pkg/rpcserver"
+	"gorm.io/gorm"
+)
+
+// Restricts a query on the states table to the contracts of the tenant of the calling RPC request (if any).
+// A tenant owns the private contracts deployed by transactions it submitted, and can be configured with
+// access to others. Callers without a tenant (admin, internal processing, or nodes without tenant auth) see everything.
+func tenantContractScope(ctx context.Context, dbTX persistence.DBTX, q *gorm.DB) *gorm.DB {
+	tenant := rpcserver.TenantFromContext(ctx)
+	if tenant == nil {
+		return q
+	}
+	deployed := dbTX.DB().
+		Table("private_smart_contracts").
+		Select(`"address"`).
+		Where(`"deploy_tx" IN (?)`, dbTX.DB().Table("transactions").Select(`"id"`).Where(`"tenant" = ?`, tenant.Name))
+	if len(tenant.Contracts) == 0 {
+		return q.Where(`"states"."contract_address" IN (?)`, deployed)
+	}
+	return q.Where(dbTX.DB().
+		Where(`"states"."contract_address" IN (?)`, deployed).
+		Or(`"states"."contract_address" IN (?)`, tenant.Contracts))
+}
+
+// Returns an error if the tenant of the calling RPC request (if any) cannot access any of the contracts
+func checkTenantContracts(ctx context.Context, dbTX persistence.DBTX, contractAddresses []*pldtypes.EthAddress) error {
+	tenant := rpcserver.TenantFromContext(ctx)
+	if tenant == nil {
+		return nil
+	}
+	for _, addr := range contractAddresses {
+		if slices.ContainsFunc(tenant.Contracts, addr.Equals) {
+			continue
+		}
+		var count int64
+		err := dbTX.DB().
+			WithContext(ctx).
+			Table("private_smart_contracts").
+			Where(`"address" = ?`, addr).
+			Where(`"deploy_tx" IN (?)`, dbTX.DB().Table("transactions").Select(`"id"`).Where(`"tenant" = ?`, tenant.Name)).
+			Count(&count).
+			Error
+		if err != nil {
+			return err
+		}
+		if count == 0 {
+			return i18n.NewError(ctx, pldmsgs.MsgJSONRPCTenantContractDenied, tenant.Name, addr)
+		}
+	}
+	return nil
+}
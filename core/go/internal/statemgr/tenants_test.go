@@ -0,0 +1,112 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package statemgr
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/kaleido-io/paladin/core/internal/components"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/query"
+	"github.com/kaleido-io/paladin/toolkit/pkg/rpcserver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTenantStateIsolation(t *testing.T) {
+	ctx, ss, m, done := newDBTestStateManager(t)
+	defer done()
+	mockDomain(t, m, "domain1", false)
+	mockStateCallback(m)
+
+	contract1 := pldtypes.RandAddress()
+	contract2 := pldtypes.RandAddress()
+	states, _, _ := writeSnapshotTestStates(t, ctx, ss, contract1, contract2)
+	schemaID := states[0].Schema
+
+	// app1 deployed contract1, and app2 deployed contract2
+	db := ss.p.DB()
+	abiHash := pldtypes.RandBytes32()
+	require.NoError(t, db.Exec(`INSERT INTO abis ("hash", "abi", "created") VALUES (?, '[]', 0)`, abiHash).Error)
+	for tenant, contract := range map[string]*pldtypes.EthAddress{"app1": contract1, "app2": contract2} {
+		deployTX := uuid.New()
+		require.NoError(t, db.Exec(`INSERT INTO transactions ("id", "created", "type", "submit_mode", "abi_ref", "from", "tenant") VALUES (?, 0, 'private', 'auto', ?, 'me', ?)`,
+			deployTX, abiHash, tenant).Error)
+		require.NoError(t, db.Exec(`INSERT INTO private_smart_contracts ("deploy_tx", "domain_address", "address", "config_bytes") VALUES (?, ?, ?, '0x')`,
+			deployTX, pldtypes.RandAddress(), contract).Error)
+	}
+
+	app1Ctx := rpcserver.WithTenant(ctx, &rpcserver.Tenant{Name: "app1"})
+	app2Ctx := rpcserver.WithTenant(ctx, &rpcserver.Tenant{Name: "app2"})
+	app3Ctx := rpcserver.WithTenant(ctx, &rpcserver.Tenant{Name: "app3", Contracts: []*pldtypes.EthAddress{contract2}})
+
+	stateIDs := func(states []*pldapi.State) (ids []string) {
+		for _, s := range states {
+			ids = append(ids, s.ID.String())
+		}
+		return ids
+	}
+	all := &components.StateQueryOptions{StatusQualifier: pldapi.StateStatusAll}
+
+	// Each tenant only sees the states of its own contracts
+	found, err := ss.FindStates(app1Ctx, ss.p.NOTX(), "domain1", schemaID, query.NewQueryBuilder().Query(), all)
+	require.NoError(t, err)
+	assert.Equal(t, []string{states[0].ID.String(), states[1].ID.String()}, stateIDs(found))
+	found, err = ss.FindStates(app2Ctx, ss.p.NOTX(), "domain1", schemaID, query.NewQueryBuilder().Query(), all)
+	require.NoError(t, err)
+	assert.Equal(t, []string{states[2].ID.String()}, stateIDs(found))
+	found, err = ss.FindStates(app3Ctx, ss.p.NOTX(), "domain1", schemaID, query.NewQueryBuilder().Query(), all)
+	require.NoError(t, err)
+	assert.Equal(t, []string{states[2].ID.String()}, stateIDs(found))
+	found, err = ss.FindStates(ctx, ss.p.NOTX(), "domain1", schemaID, query.NewQueryBuilder().Query(), all)
+	require.NoError(t, err)
+	assert.Len(t, found, 3)
+
+	found, err = ss.FindContractStates(app1Ctx, ss.p.NOTX(), "domain1", contract2, schemaID, query.NewQueryBuilder().Query(), pldapi.StateStatusAll)
+	require.NoError(t, err)
+	assert.Empty(t, found)
+	found, err = ss.FindNullifiers(app1Ctx, ss.p.NOTX(), "domain1", schemaID, query.NewQueryBuilder().Query(), pldapi.StateStatusAll)
+	require.NoError(t, err)
+	assert.Empty(t, found)
+	found, err = ss.FindNullifiers(app2Ctx, ss.p.NOTX(), "domain1", schemaID, query.NewQueryBuilder().Query(), pldapi.StateStatusAll)
+	require.NoError(t, err)
+	assert.Equal(t, []string{states[2].ID.String()}, stateIDs(found))
+
+	proof, err := ss.GetStateAnchorProof(app1Ctx, ss.p.NOTX(), "domain1", states[2].ID)
+	require.NoError(t, err)
+	assert.Nil(t, proof)
+
+	// Snapshots are scoped in the same way
+	snapshot, err := ss.ExportStateSnapshot(app1Ctx, ss.p.NOTX(), "domain1", nil)
+	require.NoError(t, err)
+	assert.Len(t, snapshot.States, 2)
+	snapshot, err = ss.ExportStateSnapshot(app1Ctx, ss.p.NOTX(), "domain1", contract2)
+	require.NoError(t, err)
+	assert.Empty(t, snapshot.States)
+
+	snapshot, err = ss.ExportStateSnapshot(ctx, ss.p.NOTX(), "domain1", nil)
+	require.NoError(t, err)
+	_, err = importTestSnapshot(app1Ctx, ss, copySnapshot(t, snapshot))
+	assert.Regexp(t, "PD020716.*app1", err)
+	snapshot, err = ss.ExportStateSnapshot(ctx, ss.p.NOTX(), "domain1", contract2)
+	require.NoError(t, err)
+	_, err = importTestSnapshot(app2Ctx, ss, copySnapshot(t, snapshot))
+	require.NoError(t, err)
+	_, err = importTestSnapshot(app3Ctx, ss, copySnapshot(t, snapshot))
+	require.NoError(t, err)
+}
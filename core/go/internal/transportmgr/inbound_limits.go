@@ -0,0 +1,153 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package transportmgr
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"time"
+
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/core/internal/msgs"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
+	"golang.org/x/time/rate"
+)
+
+func rateLimit(perSecond float64) rate.Limit {
+	if perSecond <= 0 {
+		return rate.Inf
+	}
+	return rate.Limit(perSecond)
+}
+
+func (tm *transportManager) newInboundLimiters() (msgLimiter, byteLimiter *rate.Limiter) {
+	return rate.NewLimiter(tm.inboundMessageRate, tm.inboundMessageBurst),
+		rate.NewLimiter(tm.inboundByteRate, tm.inboundByteBurst)
+}
+
+// Bans are held on the transport manager rather than the peer, so they are not lost when an
+// inactive peer is reaped (which is likely to happen while all its messages are being rejected).
+func (tm *transportManager) checkPeerBanned(ctx context.Context, nodeName string) error {
+	tm.bansLock.Lock()
+	defer tm.bansLock.Unlock()
+
+	ban := tm.bans[nodeName]
+	if ban == nil {
+		return nil
+	}
+	if pldtypes.TimestampNow() >= ban.Expires {
+		log.L(ctx).Infof("ban expired for peer %s", nodeName)
+		delete(tm.bans, nodeName)
+		return nil
+	}
+	ban.Rejected++
+	return i18n.NewError(ctx, msgs.MsgTransportPeerBanned, nodeName, ban.Expires, ban.Reason)
+}
+
+func (tm *transportManager) banPeer(ctx context.Context, nodeName string, violations int, reason string) {
+	tm.bansLock.Lock()
+	defer tm.bansLock.Unlock()
+
+	now := pldtypes.TimestampNow()
+	expires := pldtypes.Timestamp(now.Time().Add(tm.banDuration).UnixNano())
+	log.L(ctx).Warnf("banning peer %s until %s after %d violations in %s: %s", nodeName, expires, violations, tm.banWindow, reason)
+	tm.bans[nodeName] = &pldapi.PeerBan{
+		Node:       nodeName,
+		Banned:     now,
+		Expires:    expires,
+		Violations: violations,
+		Reason:     reason,
+	}
+}
+
+func (tm *transportManager) listPeerBans() []*pldapi.PeerBan {
+	tm.bansLock.Lock()
+	defer tm.bansLock.Unlock()
+
+	now := pldtypes.TimestampNow()
+	bans := make([]*pldapi.PeerBan, 0, len(tm.bans))
+	for nodeName, ban := range tm.bans {
+		if now >= ban.Expires {
+			delete(tm.bans, nodeName)
+			continue
+		}
+		banCopy := *ban
+		bans = append(bans, &banCopy)
+	}
+	slices.SortFunc(bans, func(a, b *pldapi.PeerBan) int { return cmp.Compare(a.Node, b.Node) })
+	return bans
+}
+
+// Returns true if there was a ban in place to clear. Also clears any violations recorded against
+// the peer, so that it starts again from a clean slate.
+func (tm *transportManager) clearPeerBan(ctx context.Context, nodeName string) bool {
+	tm.bansLock.Lock()
+	_, banned := tm.bans[nodeName]
+	delete(tm.bans, nodeName)
+	tm.bansLock.Unlock()
+
+	if p := tm.getActivePeer(nodeName); p != nil {
+		p.statsLock.Lock()
+		p.violations = nil
+		p.statsLock.Unlock()
+	}
+	log.L(ctx).Infof("ban cleared for peer %s (banned=%t)", nodeName, banned)
+	return banned
+}
+
+func (p *peer) checkInboundLimits(ctx context.Context, msg *prototk.PaladinMsg) error {
+	size := len(msg.Payload)
+	var err error
+	oversized := int64(size) > p.tm.inboundMaxMessageSize
+	if oversized {
+		err = i18n.NewError(ctx, msgs.MsgTransportInboundMessageTooLarge, msg.MessageId, p.Name, size, p.tm.inboundMaxMessageSize)
+	} else if !p.msgLimiter.Allow() || !p.byteLimiter.AllowN(time.Now(), size) {
+		err = i18n.NewError(ctx, msgs.MsgTransportPeerRateLimited, p.Name)
+	}
+	if err != nil {
+		log.L(ctx).Warnf("rejected message %s from %s: %s", msg.MessageId, p.Name, err)
+		p.recordViolation(ctx, oversized, err.Error())
+	}
+	return err
+}
+
+func (p *peer) recordViolation(ctx context.Context, oversized bool, reason string) {
+	now := time.Now()
+	p.statsLock.Lock()
+	p.Stats.RejectedMsgs++
+	if oversized {
+		p.Stats.OversizedMsgs++
+	} else {
+		p.Stats.RateLimitedMsgs++
+	}
+	cutoff := now.Add(-p.tm.banWindow)
+	p.violations = slices.DeleteFunc(p.violations, func(v time.Time) bool { return v.Before(cutoff) })
+	p.violations = append(p.violations, now)
+	violations := len(p.violations)
+	ban := p.tm.banThreshold > 0 && violations >= p.tm.banThreshold
+	if ban {
+		p.violations = nil
+	}
+	p.statsLock.Unlock()
+
+	if ban {
+		p.tm.banPeer(ctx, p.Name, violations, reason)
+	}
+}
@@ -0,0 +1,202 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package transportmgr
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldclient"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+)
+
+func inboundTestMessage(payloadSize int) *prototk.ReceiveMessageRequest {
+	return &prototk.ReceiveMessageRequest{
+		FromNode: "node2",
+		Message: &prototk.PaladinMsg{
+			MessageId:   uuid.NewString(),
+			Component:   prototk.PaladinMsg_TRANSACTION_ENGINE,
+			MessageType: "myMessageType",
+			Payload:     make([]byte, payloadSize),
+		},
+	}
+}
+
+func TestInboundOversizedMessagesBanPeer(t *testing.T) {
+	ctx, tm, tp, done := newTestTransport(t, false, func(mc *mockComponents, conf *pldconf.TransportManagerConfig) {
+		conf.InboundLimits.MaxMessageSize = confutil.P("1Kb")
+		conf.InboundLimits.BanThreshold = confutil.P(2)
+		mc.privateTxManager.On("HandlePaladinMsg", mock.Anything, mock.Anything).Return()
+	})
+	defer done()
+
+	client, rpcDone := newTestRPCServer(t, ctx, tm)
+	defer rpcDone()
+	transportRPC := pldclient.Wrap(client).Transport()
+
+	_, err := tp.t.ReceiveMessage(ctx, inboundTestMessage(1024))
+	require.NoError(t, err)
+
+	_, err = tp.t.ReceiveMessage(ctx, inboundTestMessage(1025))
+	assert.Regexp(t, "PD012030", err)
+	bans, err := transportRPC.ListPeerBans(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, bans)
+
+	// Second violation triggers the ban
+	_, err = tp.t.ReceiveMessage(ctx, inboundTestMessage(1025))
+	assert.Regexp(t, "PD012030", err)
+	_, err = tp.t.ReceiveMessage(ctx, inboundTestMessage(10))
+	assert.Regexp(t, "PD012029.*node2", err)
+
+	p := tm.getActivePeer("node2")
+	assert.Equal(t, uint64(1), p.Stats.ReceivedMsgs)
+	assert.Equal(t, uint64(2), p.Stats.RejectedMsgs)
+	assert.Equal(t, uint64(2), p.Stats.OversizedMsgs)
+	assert.Zero(t, p.Stats.RateLimitedMsgs)
+	assert.Empty(t, p.violations)
+
+	bans, err = transportRPC.ListPeerBans(ctx)
+	require.NoError(t, err)
+	require.Len(t, bans, 1)
+	assert.Equal(t, "node2", bans[0].Node)
+	assert.Equal(t, 2, bans[0].Violations)
+	assert.Equal(t, uint64(1), bans[0].Rejected)
+	assert.Regexp(t, "PD012030", bans[0].Reason)
+	assert.Greater(t, bans[0].Expires, bans[0].Banned)
+
+	cleared, err := transportRPC.ClearPeerBan(ctx, "node2")
+	require.NoError(t, err)
+	assert.True(t, cleared)
+	cleared, err = transportRPC.ClearPeerBan(ctx, "node2")
+	require.NoError(t, err)
+	assert.False(t, cleared)
+
+	_, err = tp.t.ReceiveMessage(ctx, inboundTestMessage(10))
+	require.NoError(t, err)
+}
+
+func TestInboundMessageRateLimited(t *testing.T) {
+	ctx, tm, tp, done := newTestTransport(t, false, func(mc *mockComponents, conf *pldconf.TransportManagerConfig) {
+		conf.InboundLimits.MessageRate = confutil.P(0.001)
+		conf.InboundLimits.MessageBurst = confutil.P(1)
+		conf.InboundLimits.BanThreshold = confutil.P(0)
+		mc.privateTxManager.On("HandlePaladinMsg", mock.Anything, mock.Anything).Return()
+	})
+	defer done()
+
+	_, err := tp.t.ReceiveMessage(ctx, inboundTestMessage(10))
+	require.NoError(t, err)
+
+	for i := 0; i < 5; i++ {
+		_, err = tp.t.ReceiveMessage(ctx, inboundTestMessage(10))
+		assert.Regexp(t, "PD012031", err)
+	}
+
+	// Banning is disabled
+	assert.Empty(t, tm.listPeerBans())
+	p := tm.getActivePeer("node2")
+	assert.Equal(t, uint64(5), p.Stats.RateLimitedMsgs)
+	assert.Equal(t, uint64(5), p.Stats.RejectedMsgs)
+}
+
+func TestInboundByteRateLimited(t *testing.T) {
+	ctx, tm, tp, done := newTestTransport(t, false, func(mc *mockComponents, conf *pldconf.TransportManagerConfig) {
+		conf.InboundLimits.ByteRate = confutil.P("1")
+		conf.InboundLimits.ByteBurst = confutil.P("1") // raised to the max message size
+		conf.InboundLimits.MaxMessageSize = confutil.P("1Kb")
+		mc.privateTxManager.On("HandlePaladinMsg", mock.Anything, mock.Anything).Return()
+	})
+	defer done()
+	assert.Equal(t, 1024, tm.inboundByteBurst)
+
+	_, err := tp.t.ReceiveMessage(ctx, inboundTestMessage(1000))
+	require.NoError(t, err)
+	_, err = tp.t.ReceiveMessage(ctx, inboundTestMessage(1000))
+	assert.Regexp(t, "PD012031", err)
+}
+
+func TestInboundLimitsDisabled(t *testing.T) {
+	_, tm, _, done := newTestTransport(t, false, func(mc *mockComponents, conf *pldconf.TransportManagerConfig) {
+		conf.InboundLimits.MessageRate = confutil.P(0.0)
+		conf.InboundLimits.ByteRate = confutil.P("0")
+	})
+	defer done()
+	assert.Equal(t, rate.Inf, tm.inboundMessageRate)
+	assert.Equal(t, rate.Inf, tm.inboundByteRate)
+}
+
+func TestPeerBanExpiry(t *testing.T) {
+	ctx, tm, _, done := newTestTransport(t, false)
+	defer done()
+
+	tm.banPeer(ctx, "node2", 1, "reason2")
+	tm.banPeer(ctx, "node3", 1, "reason3")
+	tm.banPeer(ctx, "node4", 1, "reason4")
+	assert.Equal(t, []string{"node2", "node3", "node4"}, banNodes(tm.listPeerBans()))
+
+	past := pldtypes.Timestamp(time.Now().Add(-1 * time.Second).UnixNano())
+	tm.bans["node2"].Expires = past
+	tm.bans["node3"].Expires = past
+
+	assert.NoError(t, tm.checkPeerBanned(ctx, "node2"))
+	assert.NotContains(t, tm.bans, "node2")
+	assert.Equal(t, []string{"node4"}, banNodes(tm.listPeerBans()))
+	assert.Regexp(t, "PD012029.*reason4", tm.checkPeerBanned(ctx, "node4"))
+}
+
+func TestInboundViolationWindow(t *testing.T) {
+	ctx, tm, _, done := newTestTransport(t, false, func(mc *mockComponents, conf *pldconf.TransportManagerConfig) {
+		conf.InboundLimits.BanThreshold = confutil.P(2)
+	})
+	defer done()
+
+	p, err := tm.getPeer(ctx, "node2", false)
+	require.NoError(t, err)
+
+	// A violation outside the window does not count towards the threshold
+	p.violations = []time.Time{time.Now().Add(-tm.banWindow - time.Second)}
+	p.recordViolation(ctx, false, "reason")
+	assert.Len(t, p.violations, 1)
+	assert.Empty(t, tm.listPeerBans())
+
+	p.recordViolation(ctx, false, "reason")
+	assert.Len(t, tm.listPeerBans(), 1)
+}
+
+func banNodes(bans []*pldapi.PeerBan) []string {
+	nodes := make([]string, len(bans))
+	for i, b := range bans {
+		nodes[i] = b.Node
+	}
+	return nodes
+}
+
+func TestClearPeerBanNoPeer(t *testing.T) {
+	_, tm, _, done := newTestTransport(t, false)
+	defer done()
+	assert.False(t, tm.clearPeerBan(context.Background(), "node2"))
+}
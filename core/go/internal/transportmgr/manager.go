@@ -31,6 +31,7 @@ import (
 	"github.com/kaleido-io/paladin/core/pkg/persistence"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/query"
+	"golang.org/x/time/rate"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 
@@ -58,6 +59,7 @@ type transportManager struct {
 	privateTxManager components.PrivateTxManager
 	identityResolver components.IdentityResolver
 	groupManager     components.GroupManager
+	contractManager  components.ContractManager
 	persistence      persistence.Persistence
 
 	transportsByID   map[uuid.UUID]*transport
@@ -78,6 +80,23 @@ type transportManager struct {
 	senderBufferLen         int
 	reliableMessageResend   time.Duration
 	reliableMessagePageSize int
+
+	compressionCodec       prototk.PaladinMsg_PayloadEncoding
+	compressionThreshold   int64
+	chunkSize              int64
+	maxMessageSize         int64
+	chunkReassemblyTimeout time.Duration
+
+	inboundMessageRate    rate.Limit
+	inboundMessageBurst   int
+	inboundByteRate       rate.Limit
+	inboundByteBurst      int
+	inboundMaxMessageSize int64
+	banThreshold          int
+	banWindow             time.Duration
+	banDuration           time.Duration
+	bansLock              sync.Mutex
+	bans                  map[string]*pldapi.PeerBan
 }
 
 var reliableMessageFilters = filters.FieldMap{
@@ -101,6 +120,7 @@ func NewTransportManager(bgCtx context.Context, conf *pldconf.TransportManagerCo
 		transportsByID:          make(map[uuid.UUID]*transport),
 		transportsByName:        make(map[string]*transport),
 		peers:                   make(map[string]*peer),
+		bans:                    make(map[string]*pldapi.PeerBan),
 		senderBufferLen:         confutil.IntMin(conf.SendQueueLen, 0, *pldconf.TransportManagerDefaults.SendQueueLen),
 		reliableMessageResend:   confutil.DurationMin(conf.ReliableMessageResend, 100*time.Millisecond, *pldconf.TransportManagerDefaults.ReliableMessageResend),
 		sendShortRetry:          retry.NewRetryLimited(&conf.SendRetry, &pldconf.TransportManagerDefaults.SendRetry),
@@ -109,8 +129,23 @@ func NewTransportManager(bgCtx context.Context, conf *pldconf.TransportManagerCo
 		peerReaperInterval:      confutil.DurationMin(conf.PeerReaperInterval, 100*time.Millisecond, *pldconf.TransportManagerDefaults.PeerReaperInterval),
 		quiesceTimeout:          1 * time.Second, // not currently tunable (considered very small edge case)
 		reliableMessagePageSize: 100,             // not currently tunable
+		compressionThreshold:    confutil.ByteSize(conf.Compression.Threshold, 0, *pldconf.TransportManagerDefaults.Compression.Threshold),
+		chunkSize:               confutil.ByteSize(conf.Chunking.ChunkSize, 1024, *pldconf.TransportManagerDefaults.Chunking.ChunkSize),
+		maxMessageSize:          confutil.ByteSize(conf.Chunking.MaxMessageSize, 1024, *pldconf.TransportManagerDefaults.Chunking.MaxMessageSize),
+		chunkReassemblyTimeout:  confutil.DurationMin(conf.Chunking.ReassemblyTimeout, 100*time.Millisecond, *pldconf.TransportManagerDefaults.Chunking.ReassemblyTimeout),
 	}
-	tm.bgCtx, tm.cancelCtx = context.WithCancel(bgCtx)
+	limitsConf := &conf.InboundLimits
+	limitsDefaults := &pldconf.TransportManagerDefaults.InboundLimits
+	tm.inboundMessageRate = rateLimit(confutil.Float64Min(limitsConf.MessageRate, 0, *limitsDefaults.MessageRate))
+	tm.inboundMessageBurst = confutil.IntMin(limitsConf.MessageBurst, 1, *limitsDefaults.MessageBurst)
+	tm.inboundByteRate = rateLimit(float64(confutil.ByteSize(limitsConf.ByteRate, 0, *limitsDefaults.ByteRate)))
+	tm.inboundMaxMessageSize = confutil.ByteSize(limitsConf.MaxMessageSize, 1024, *limitsDefaults.MaxMessageSize)
+	// The burst must allow at least one message of the maximum size, otherwise such a message could never be accepted
+	tm.inboundByteBurst = int(confutil.ByteSize(limitsConf.ByteBurst, tm.inboundMaxMessageSize, *limitsDefaults.ByteBurst))
+	tm.banThreshold = confutil.IntMin(limitsConf.BanThreshold, 0, *limitsDefaults.BanThreshold)
+	tm.banWindow = confutil.DurationMin(limitsConf.BanWindow, 0, *limitsDefaults.BanWindow)
+	tm.banDuration = confutil.DurationMin(limitsConf.BanDuration, 0, *limitsDefaults.BanDuration)
+	tm.bgCtx, tm.cancelCtx = context.WithCancel(log.WithSubsystem(bgCtx, "transports"))
 	return tm
 }
 
@@ -118,6 +153,11 @@ func (tm *transportManager) PreInit(pic components.PreInitComponents) (*componen
 	if tm.localNodeName == "" {
 		return nil, i18n.NewError(tm.bgCtx, msgs.MsgTransportNodeNameNotConfigured)
 	}
+	codec, err := parseCompressionCodec(tm.bgCtx, confutil.StringNotEmpty(tm.conf.Compression.Codec, *pldconf.TransportManagerDefaults.Compression.Codec))
+	if err != nil {
+		return nil, err
+	}
+	tm.compressionCodec = codec
 	tm.initRPC()
 	return &components.ManagerInitResult{
 		RPCModules: []*rpcserver.RPCModule{tm.rpcModule},
@@ -136,6 +176,7 @@ func (tm *transportManager) PostInit(c components.AllComponents) error {
 	tm.privateTxManager = c.PrivateTxManager()
 	tm.identityResolver = c.IdentityResolver()
 	tm.groupManager = c.GroupManager()
+	tm.contractManager = c.ContractManager()
 	tm.persistence = c.Persistence()
 	tm.reliableMsgWriter = flushwriter.NewWriter(tm.bgCtx, tm.handleReliableMsgBatch, tm.persistence,
 		&tm.conf.ReliableMessageWriter, &pldconf.TransportManagerDefaults.ReliableMessageWriter)
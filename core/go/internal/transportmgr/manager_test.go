@@ -47,6 +47,7 @@ type mockComponents struct {
 	privateTxManager *componentmocks.PrivateTxManager
 	identityResolver *componentmocks.IdentityResolver
 	groupManager     *componentmocks.GroupManager
+	contractManager  *componentmocks.ContractManager
 }
 
 func newMockComponents(t *testing.T, realDB bool) *mockComponents {
@@ -59,6 +60,7 @@ func newMockComponents(t *testing.T, realDB bool) *mockComponents {
 	mc.privateTxManager = componentmocks.NewPrivateTxManager(t)
 	mc.identityResolver = componentmocks.NewIdentityResolver(t)
 	mc.groupManager = componentmocks.NewGroupManager(t)
+	mc.contractManager = componentmocks.NewContractManager(t)
 	if realDB {
 		p, cleanup, err := persistence.NewUnitTestPersistence(context.Background(), "transportmgr")
 		require.NoError(t, err)
@@ -79,6 +81,7 @@ func newMockComponents(t *testing.T, realDB bool) *mockComponents {
 	mc.c.On("PrivateTxManager").Return(mc.privateTxManager).Maybe()
 	mc.c.On("IdentityResolver").Return(mc.identityResolver).Maybe()
 	mc.c.On("GroupManager").Return(mc.groupManager).Maybe()
+	mc.c.On("ContractManager").Return(mc.contractManager).Maybe()
 	return mc
 }
 
@@ -0,0 +1,208 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package transportmgr
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"context"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/core/internal/msgs"
+	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
+)
+
+// Large payloads (such as proofs and state distributions) are compressed, and then split into chunks
+// to stay under the message size limits of transports such as gRPC. This is transparent to the
+// transport plugins, which see each chunk as an individual message, and to the components, which
+// only see the reassembled and decompressed payload.
+type inboundChunkedMsg struct {
+	started   time.Time
+	totalSize uint64
+	buffered  uint64
+	received  int
+	chunks    [][]byte
+}
+
+func parseCompressionCodec(ctx context.Context, codec string) (prototk.PaladinMsg_PayloadEncoding, error) {
+	switch strings.ToLower(codec) {
+	case "", "none":
+		return prototk.PaladinMsg_NONE, nil
+	case "gzip":
+		return prototk.PaladinMsg_GZIP, nil
+	case "deflate":
+		return prototk.PaladinMsg_DEFLATE, nil
+	default:
+		return prototk.PaladinMsg_NONE, i18n.NewError(ctx, msgs.MsgTransportCompressionCodecInvalid, codec)
+	}
+}
+
+func compressPayload(encoding prototk.PaladinMsg_PayloadEncoding, payload []byte) []byte {
+	var buf bytes.Buffer
+	var w io.WriteCloser
+	if encoding == prototk.PaladinMsg_GZIP {
+		w = gzip.NewWriter(&buf)
+	} else {
+		w, _ = flate.NewWriter(&buf, flate.DefaultCompression) // only errors on an invalid level
+	}
+	// Writes to an in-memory buffer cannot fail
+	_, _ = w.Write(payload)
+	_ = w.Close()
+	return buf.Bytes()
+}
+
+// Compresses the payload if configured, and it is over the threshold, then splits the encoded payload
+// into chunks if it is larger than the chunk size. Small messages are returned unmodified.
+func (tm *transportManager) encodeMessage(msg *prototk.PaladinMsg) []*prototk.PaladinMsg {
+	payload := msg.Payload
+	encoding := prototk.PaladinMsg_NONE
+	if tm.compressionCodec != prototk.PaladinMsg_NONE && int64(len(payload)) >= tm.compressionThreshold {
+		compressed := compressPayload(tm.compressionCodec, payload)
+		// We only send compressed if it saved space (the payload might already be compressed/random)
+		if len(compressed) < len(payload) {
+			payload = compressed
+			encoding = tm.compressionCodec
+		}
+	}
+	if encoding == prototk.PaladinMsg_NONE && int64(len(payload)) <= tm.chunkSize {
+		return []*prototk.PaladinMsg{msg}
+	}
+
+	chunkSize := int(tm.chunkSize)
+	count := (len(payload) + chunkSize - 1) / chunkSize
+	parts := make([]*prototk.PaladinMsg, count)
+	for i := 0; i < count; i++ {
+		part := &prototk.PaladinMsg{
+			MessageId:       msg.MessageId,
+			CorrelationId:   msg.CorrelationId,
+			Component:       msg.Component,
+			MessageType:     msg.MessageType,
+			Payload:         payload[i*chunkSize : min((i+1)*chunkSize, len(payload))],
+			PayloadEncoding: encoding,
+		}
+		if count > 1 {
+			part.Chunk = &prototk.PaladinMsg_Chunk{
+				Index:     uint32(i),
+				Count:     uint32(count),
+				TotalSize: uint64(len(payload)),
+			}
+		}
+		parts[i] = part
+	}
+	return parts
+}
+
+// Returns the decompressed payload of a complete (not chunked, or reassembled) message
+func (tm *transportManager) decodePayload(ctx context.Context, msg *prototk.PaladinMsg) ([]byte, error) {
+	var r io.Reader
+	switch msg.PayloadEncoding {
+	case prototk.PaladinMsg_NONE:
+		return msg.Payload, nil
+	case prototk.PaladinMsg_GZIP:
+		gzr, err := gzip.NewReader(bytes.NewReader(msg.Payload))
+		if err != nil {
+			return nil, i18n.WrapError(ctx, err, msgs.MsgTransportPayloadDecodeFailed, msg.PayloadEncoding, msg.MessageId)
+		}
+		r = gzr
+	case prototk.PaladinMsg_DEFLATE:
+		r = flate.NewReader(bytes.NewReader(msg.Payload))
+	default:
+		return nil, i18n.NewError(ctx, msgs.MsgTransportPayloadDecodeFailed, msg.PayloadEncoding, msg.MessageId)
+	}
+	// Limit the decompressed size, so a malicious or corrupt payload cannot exhaust memory
+	payload, err := io.ReadAll(io.LimitReader(r, tm.maxMessageSize+1))
+	if err != nil {
+		return nil, i18n.WrapError(ctx, err, msgs.MsgTransportPayloadDecodeFailed, msg.PayloadEncoding, msg.MessageId)
+	}
+	if int64(len(payload)) > tm.maxMessageSize {
+		return nil, i18n.NewError(ctx, msgs.MsgTransportMessageTooLarge, msg.MessageId, tm.maxMessageSize)
+	}
+	return payload, nil
+}
+
+// Buffers a chunk of a message received from this peer. Returns the reassembled message once all the chunks
+// have been received, or nil if more chunks are still expected. Messages that are not chunked are returned as-is.
+func (p *peer) reassembleChunks(ctx context.Context, msg *prototk.PaladinMsg) (*prototk.PaladinMsg, error) {
+	c := msg.Chunk
+	if c == nil {
+		return msg, nil
+	}
+	if c.Count == 0 || c.Index >= c.Count || uint64(c.Count) > c.TotalSize {
+		return nil, i18n.NewError(ctx, msgs.MsgTransportChunkInvalid, c.Index, c.Count, c.TotalSize, msg.MessageId)
+	}
+	if c.TotalSize > uint64(p.tm.maxMessageSize) {
+		return nil, i18n.NewError(ctx, msgs.MsgTransportMessageTooLarge, msg.MessageId, p.tm.maxMessageSize)
+	}
+
+	p.chunksLock.Lock()
+	defer p.chunksLock.Unlock()
+
+	now := time.Now()
+	p.expireChunkedMsgs(ctx, now)
+
+	im := p.inboundChunks[msg.MessageId]
+	if im == nil {
+		im = &inboundChunkedMsg{
+			started:   now,
+			totalSize: c.TotalSize,
+			chunks:    make([][]byte, c.Count),
+		}
+		p.inboundChunks[msg.MessageId] = im
+	} else if im.totalSize != c.TotalSize || len(im.chunks) != int(c.Count) {
+		return nil, i18n.NewError(ctx, msgs.MsgTransportChunkInvalid, c.Index, c.Count, c.TotalSize, msg.MessageId)
+	}
+
+	// Chunks might be re-delivered if the sender retried, in which case we keep the first copy
+	if im.chunks[c.Index] == nil {
+		im.chunks[c.Index] = msg.Payload
+		im.received++
+		im.buffered += uint64(len(msg.Payload))
+	}
+	if im.buffered > im.totalSize || (im.received == len(im.chunks) && im.buffered != im.totalSize) {
+		delete(p.inboundChunks, msg.MessageId)
+		return nil, i18n.NewError(ctx, msgs.MsgTransportChunkInvalid, c.Index, c.Count, c.TotalSize, msg.MessageId)
+	}
+	if im.received < len(im.chunks) {
+		log.L(ctx).Debugf("received chunk %d/%d of message %s from %s", c.Index+1, c.Count, msg.MessageId, p.Name)
+		return nil, nil
+	}
+	delete(p.inboundChunks, msg.MessageId)
+	log.L(ctx).Debugf("reassembled %d chunks of message %s from %s (%d bytes)", c.Count, msg.MessageId, p.Name, im.totalSize)
+
+	return &prototk.PaladinMsg{
+		MessageId:       msg.MessageId,
+		CorrelationId:   msg.CorrelationId,
+		Component:       msg.Component,
+		MessageType:     msg.MessageType,
+		Payload:         bytes.Join(im.chunks, nil),
+		PayloadEncoding: msg.PayloadEncoding,
+	}, nil
+}
+
+// Must be called holding the chunks lock
+func (p *peer) expireChunkedMsgs(ctx context.Context, now time.Time) {
+	for msgID, im := range p.inboundChunks {
+		if now.Sub(im.started) > p.tm.chunkReassemblyTimeout {
+			log.L(ctx).Warnf("discarding message %s from %s after receiving %d/%d chunks", msgID, p.Name, im.received, len(im.chunks))
+			delete(p.inboundChunks, msgID)
+		}
+	}
+}
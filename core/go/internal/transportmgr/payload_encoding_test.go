@@ -0,0 +1,301 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package transportmgr
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/internal/components"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func smallChunks(codec string) func(mc *mockComponents, conf *pldconf.TransportManagerConfig) {
+	return func(mc *mockComponents, conf *pldconf.TransportManagerConfig) {
+		conf.Compression.Codec = confutil.P(codec)
+		conf.Compression.Threshold = confutil.P("1Kb")
+		conf.Chunking.ChunkSize = confutil.P("1Kb")
+	}
+}
+
+// JSON-like data with some randomness compresses to roughly a third of its size, so is both compressed and chunked
+func compressiblePayload(size int) []byte {
+	b := make([]byte, 0, size)
+	for i := 0; len(b) < size; i++ {
+		b = fmt.Appendf(b, `{"index":%d,"data":"%s"},`, i, pldtypes.RandHex(8))
+	}
+	return b[:size]
+}
+
+func TestChunkedMessageRoundTrip(t *testing.T) {
+	for _, codec := range []string{"none", "gzip", "deflate"} {
+		t.Run(codec, func(t *testing.T) {
+			receivedMessages := make(chan *components.ReceivedMessage, 1)
+			ctx, tm, tp, done := newTestTransport(t, false, smallChunks(codec), func(mc *mockComponents, conf *pldconf.TransportManagerConfig) {
+				mc.privateTxManager.On("HandlePaladinMsg", mock.Anything, mock.Anything).Return().Run(func(args mock.Arguments) {
+					receivedMessages <- args[1].(*components.ReceivedMessage)
+				})
+			})
+			defer done()
+
+			msg := &prototk.PaladinMsg{
+				MessageId:     uuid.NewString(),
+				CorrelationId: confutil.P(uuid.NewString()),
+				Component:     prototk.PaladinMsg_TRANSACTION_ENGINE,
+				MessageType:   "myMessageType",
+				Payload:       compressiblePayload(8192),
+			}
+			parts := tm.encodeMessage(msg)
+			require.Greater(t, len(parts), 1)
+			for i, part := range parts {
+				assert.Equal(t, uint32(i), part.Chunk.Index)
+				assert.Equal(t, uint32(len(parts)), part.Chunk.Count)
+				assert.Equal(t, msg.MessageId, part.MessageId)
+				assert.Equal(t, tm.compressionCodec, part.PayloadEncoding)
+			}
+			if codec != "none" {
+				assert.Less(t, len(parts), 8)
+			}
+
+			// Deliver out of order, with a duplicate
+			require.GreaterOrEqual(t, len(parts), 3)
+			deliver := append([]*prototk.PaladinMsg{parts[len(parts)-1]}, parts[:len(parts)-2]...)
+			deliver = append(deliver, parts[0])
+			for i, part := range deliver {
+				_, err := tp.t.ReceiveMessage(ctx, &prototk.ReceiveMessageRequest{FromNode: "node2", Message: part})
+				require.NoError(t, err)
+				assert.Empty(t, receivedMessages, "delivered after %d chunks", i+1)
+			}
+			_, err := tp.t.ReceiveMessage(ctx, &prototk.ReceiveMessageRequest{FromNode: "node2", Message: parts[len(parts)-2]})
+			require.NoError(t, err)
+			rMsg := <-receivedMessages
+			assert.Equal(t, msg.MessageId, rMsg.MessageID.String())
+			assert.Equal(t, *msg.CorrelationId, rMsg.CorrelationID.String())
+			assert.Equal(t, msg.Payload, rMsg.Payload)
+			assert.Empty(t, tm.getActivePeer("node2").inboundChunks)
+		})
+	}
+}
+
+func TestSendChunkedMessage(t *testing.T) {
+	ctx, tm, tp, done := newTestTransport(t, false,
+		mockEmptyReliableMsgs,
+		mockGoodTransport,
+		smallChunks("gzip"))
+	defer done()
+
+	message := testMessage()
+	message.Payload = compressiblePayload(8192)
+
+	sentMessages := make(chan *prototk.PaladinMsg, 10)
+	mockActivateDeactivateOk(tp)
+	tp.Functions.SendMessage = func(ctx context.Context, req *prototk.SendMessageRequest) (*prototk.SendMessageResponse, error) {
+		sentMessages <- req.Message
+		return nil, nil
+	}
+
+	err := tm.Send(ctx, message)
+	require.NoError(t, err)
+
+	first := <-sentMessages
+	require.NotNil(t, first.Chunk)
+	assert.Equal(t, prototk.PaladinMsg_GZIP, first.PayloadEncoding)
+	for i := 1; i < int(first.Chunk.Count); i++ {
+		part := <-sentMessages
+		assert.Equal(t, uint32(i), part.Chunk.Index)
+	}
+
+	p := tm.getActivePeer("node2")
+	require.Eventually(t, func() bool {
+		p.statsLock.Lock()
+		defer p.statsLock.Unlock()
+		return p.Stats.SentMsgs == uint64(first.Chunk.Count)
+	}, 1*time.Second, 10*time.Millisecond)
+}
+
+func TestEncodeMessageSmallOrIncompressible(t *testing.T) {
+	_, tm, _, done := newTestTransport(t, false, smallChunks("gzip"))
+	defer done()
+
+	small := &prototk.PaladinMsg{Payload: []byte("small")}
+	parts := tm.encodeMessage(small)
+	assert.Equal(t, []*prototk.PaladinMsg{small}, parts)
+
+	// Random data does not compress, so is sent as-is
+	random := &prototk.PaladinMsg{Payload: pldtypes.RandBytes(1024)}
+	parts = tm.encodeMessage(random)
+	assert.Equal(t, []*prototk.PaladinMsg{random}, parts)
+
+	random.Payload = pldtypes.RandBytes(1025)
+	parts = tm.encodeMessage(random)
+	require.Len(t, parts, 2)
+	assert.Equal(t, prototk.PaladinMsg_NONE, parts[0].PayloadEncoding)
+	assert.Len(t, parts[1].Payload, 1)
+
+	// Compressed into a single chunk
+	compressible := &prototk.PaladinMsg{Payload: make([]byte, 4096)}
+	parts = tm.encodeMessage(compressible)
+	require.Len(t, parts, 1)
+	assert.Nil(t, parts[0].Chunk)
+	assert.Equal(t, prototk.PaladinMsg_GZIP, parts[0].PayloadEncoding)
+	payload, err := tm.decodePayload(context.Background(), parts[0])
+	require.NoError(t, err)
+	assert.Equal(t, compressible.Payload, payload)
+}
+
+func TestBadCompressionCodec(t *testing.T) {
+	tm := NewTransportManager(context.Background(), &pldconf.TransportManagerConfig{
+		NodeName: "node1",
+		Compression: pldconf.TransportCompressionConfig{
+			Codec: confutil.P("wrong"),
+		},
+	})
+	_, err := tm.PreInit(newMockComponents(t, false).c)
+	assert.Regexp(t, "PD012025", err)
+}
+
+func TestReassembleChunksErrors(t *testing.T) {
+	ctx, tm, _, done := newTestTransport(t, false)
+	defer done()
+
+	p, err := tm.getPeer(ctx, "node2", false)
+	require.NoError(t, err)
+
+	chunk := func(id string, index, count uint32, totalSize uint64, payload string) *prototk.PaladinMsg {
+		return &prototk.PaladinMsg{
+			MessageId: id,
+			Payload:   []byte(payload),
+			Chunk:     &prototk.PaladinMsg_Chunk{Index: index, Count: count, TotalSize: totalSize},
+		}
+	}
+
+	_, err = p.reassembleChunks(ctx, chunk("m1", 2, 2, 10, "aaaaa"))
+	assert.Regexp(t, "PD012026", err)
+	_, err = p.reassembleChunks(ctx, chunk("m1", 0, 0, 10, "aaaaa"))
+	assert.Regexp(t, "PD012026", err)
+	_, err = p.reassembleChunks(ctx, chunk("m1", 0, 11, 10, "aaaaa"))
+	assert.Regexp(t, "PD012026", err)
+	_, err = p.reassembleChunks(ctx, chunk("m1", 0, 2, uint64(tm.maxMessageSize)+1, "aaaaa"))
+	assert.Regexp(t, "PD012027", err)
+
+	// Inconsistent chunk counts
+	msg, err := p.reassembleChunks(ctx, chunk("m2", 0, 2, 10, "aaaaa"))
+	require.NoError(t, err)
+	assert.Nil(t, msg)
+	_, err = p.reassembleChunks(ctx, chunk("m2", 1, 3, 10, "aaaaa"))
+	assert.Regexp(t, "PD012026", err)
+
+	// Chunks bigger than the declared size
+	_, err = p.reassembleChunks(ctx, chunk("m2", 1, 2, 10, "aaaaaa"))
+	assert.Regexp(t, "PD012026", err)
+	assert.NotContains(t, p.inboundChunks, "m2")
+
+	// Chunks smaller than the declared size
+	_, err = p.reassembleChunks(ctx, chunk("m3", 0, 2, 10, "aaaaa"))
+	require.NoError(t, err)
+	_, err = p.reassembleChunks(ctx, chunk("m3", 1, 2, 10, "aaaa"))
+	assert.Regexp(t, "PD012026", err)
+	assert.NotContains(t, p.inboundChunks, "m3")
+}
+
+func TestReassembleChunksExpiry(t *testing.T) {
+	ctx, tm, _, done := newTestTransport(t, false)
+	defer done()
+
+	p, err := tm.getPeer(ctx, "node2", false)
+	require.NoError(t, err)
+
+	_, err = p.reassembleChunks(ctx, &prototk.PaladinMsg{
+		MessageId: "m1",
+		Payload:   []byte("aaaaa"),
+		Chunk:     &prototk.PaladinMsg_Chunk{Index: 0, Count: 2, TotalSize: 10},
+	})
+	require.NoError(t, err)
+	p.inboundChunks["m1"].started = time.Now().Add(-tm.chunkReassemblyTimeout - time.Second)
+
+	_, err = p.reassembleChunks(ctx, &prototk.PaladinMsg{
+		MessageId: "m2",
+		Payload:   []byte("aaaaa"),
+		Chunk:     &prototk.PaladinMsg_Chunk{Index: 0, Count: 2, TotalSize: 10},
+	})
+	require.NoError(t, err)
+	assert.NotContains(t, p.inboundChunks, "m1")
+	assert.Contains(t, p.inboundChunks, "m2")
+}
+
+func TestDecodePayloadErrors(t *testing.T) {
+	ctx, tm, _, done := newTestTransport(t, false)
+	defer done()
+
+	_, err := tm.decodePayload(ctx, &prototk.PaladinMsg{PayloadEncoding: prototk.PaladinMsg_GZIP, Payload: []byte("not gzip")})
+	assert.Regexp(t, "PD012028", err)
+
+	_, err = tm.decodePayload(ctx, &prototk.PaladinMsg{PayloadEncoding: prototk.PaladinMsg_DEFLATE, Payload: []byte{0xff, 0xff}})
+	assert.Regexp(t, "PD012028", err)
+
+	_, err = tm.decodePayload(ctx, &prototk.PaladinMsg{PayloadEncoding: prototk.PaladinMsg_PayloadEncoding(99), Payload: []byte("data")})
+	assert.Regexp(t, "PD012028", err)
+
+	tm.maxMessageSize = 1024
+	_, err = tm.decodePayload(ctx, &prototk.PaladinMsg{
+		PayloadEncoding: prototk.PaladinMsg_GZIP,
+		Payload:         compressPayload(prototk.PaladinMsg_GZIP, make([]byte, 1025)),
+	})
+	assert.Regexp(t, "PD012027", err)
+}
+
+func TestReceiveMessageBadChunk(t *testing.T) {
+	ctx, _, tp, done := newTestTransport(t, false)
+	defer done()
+
+	_, err := tp.t.ReceiveMessage(ctx, &prototk.ReceiveMessageRequest{
+		FromNode: "node2",
+		Message: &prototk.PaladinMsg{
+			MessageId:   uuid.NewString(),
+			Component:   prototk.PaladinMsg_TRANSACTION_ENGINE,
+			MessageType: "myMessageType",
+			Payload:     []byte("some data"),
+			Chunk:       &prototk.PaladinMsg_Chunk{Index: 1, Count: 1, TotalSize: 9},
+		},
+	})
+	assert.Regexp(t, "PD012026", err)
+}
+
+func TestReceiveMessageBadEncoding(t *testing.T) {
+	ctx, _, tp, done := newTestTransport(t, false)
+	defer done()
+
+	_, err := tp.t.ReceiveMessage(ctx, &prototk.ReceiveMessageRequest{
+		FromNode: "node2",
+		Message: &prototk.PaladinMsg{
+			MessageId:       uuid.NewString(),
+			Component:       prototk.PaladinMsg_TRANSACTION_ENGINE,
+			MessageType:     "myMessageType",
+			Payload:         []byte("some data"),
+			PayloadEncoding: prototk.PaladinMsg_GZIP,
+		},
+	})
+	assert.Regexp(t, "PD012028", err)
+}
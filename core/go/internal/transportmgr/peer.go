@@ -31,6 +31,7 @@ import (
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
 	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
+	"golang.org/x/time/rate"
 	"gorm.io/gorm/clause"
 )
 
@@ -47,6 +48,15 @@ type peer struct {
 	persistedMsgsAvailable chan struct{}
 	sendQueue              chan *prototk.PaladinMsg
 
+	// Inbound limits (violations protected by the stats lock)
+	msgLimiter  *rate.Limiter
+	byteLimiter *rate.Limiter
+	violations  []time.Time
+
+	// Partially received chunked messages, by message ID
+	chunksLock    sync.Mutex
+	inboundChunks map[string]*inboundChunkedMsg
+
 	// Send loop state (no lock as only used on the loop)
 	lastFullScan          time.Time
 	lastDrainHWM          *uint64
@@ -183,8 +193,10 @@ func (tm *transportManager) connectPeer(ctx context.Context, nodeName string, se
 			},
 			persistedMsgsAvailable: make(chan struct{}, 1),
 			sendQueue:              make(chan *prototk.PaladinMsg, tm.senderBufferLen),
+			inboundChunks:          make(map[string]*inboundChunkedMsg),
 			senderDone:             make(chan struct{}),
 		}
+		p.msgLimiter, p.byteLimiter = tm.newInboundLimiters()
 		p.ctx, p.cancelCtx = context.WithCancel(
 			log.WithLogField(tm.bgCtx /* go-routine need bg context*/, "peer", nodeName))
 	}
@@ -256,6 +268,16 @@ func (p *peer) notifyPersistedMsgAvailable() {
 }
 
 func (p *peer) send(msg *prototk.PaladinMsg, reliableSeq *uint64) error {
+	// Large messages are compressed and chunked, with each chunk sent (and retried) individually
+	for _, part := range p.tm.encodeMessage(msg) {
+		if err := p.sendPart(part, reliableSeq); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *peer) sendPart(msg *prototk.PaladinMsg, reliableSeq *uint64) error {
 	err := p.tm.sendShortRetry.Do(p.ctx, func(attempt int) (retryable bool, err error) {
 		return true, p.transport.send(p.ctx, p.Name, msg)
 	})
@@ -386,6 +408,8 @@ func (p *peer) processReliableMsgPage(dbTX persistence.DBTX, page []*pldapi.Reli
 			msg, errorAck, err = p.tm.buildPrivacyGroupDistributionMsg(p.ctx, dbTX, rm)
 		case pldapi.RMTPrivacyGroupMessage:
 			msg, errorAck, err = p.tm.buildPrivacyGroupMessageMsg(p.ctx, dbTX, rm)
+		case pldapi.RMTStateRequest:
+			msg, errorAck, err = p.tm.buildStateRequestMsg(p.ctx, rm)
 		case pldapi.RMTReceipt:
 			// TODO: Implement for receipt distribution
 			fallthrough
@@ -40,6 +40,7 @@ const (
 	RMHMessageTypePreparedTransaction = string(pldapi.RMTPreparedTransaction)
 	RMHMessageTypePrivacyGroup        = string(pldapi.RMTPrivacyGroup)
 	RMHMessageTypePrivacyGroupMessage = string(pldapi.RMTPrivacyGroupMessage)
+	RMHMessageTypeStateRequest        = string(pldapi.RMTStateRequest)
 )
 
 type reliableMsgOp struct {
@@ -90,6 +91,7 @@ func (tm *transportManager) handleReliableMsgBatch(ctx context.Context, dbTX per
 	var txReceiptsToFinalize []*components.ReceiptInput
 	var msgsToReceive []*receivedPrivacyGroupMessage
 	var privacyGroupsToAdd []*receivedPrivacyGroup
+	var stateRequests []*receivedStateRequest
 
 	dbTX.AddPostCommit(func(ctx context.Context) {
 		// We've committed the database work ok - send the acks/nacks to the other side
@@ -147,6 +149,15 @@ func (tm *transportManager) handleReliableMsgBatch(ctx context.Context, dbTX per
 			} else {
 				msgsToReceive = append(msgsToReceive, &receivedPrivacyGroupMessage{node: v.p.Name, rMsgID: v.msg.MessageID, message: msg})
 			}
+		case RMHMessageTypeStateRequest:
+			req, err := parseStateRequest(ctx, v.msg.MessageID, v.msg.Payload)
+			if err != nil {
+				acksToSend = append(acksToSend,
+					&ackInfo{node: v.p.Name, id: v.msg.MessageID, Error: err.Error()}, // reject the message permanently
+				)
+			} else {
+				stateRequests = append(stateRequests, &receivedStateRequest{node: v.p.Name, rMsgID: v.msg.MessageID, req: req})
+			}
 		case RMHMessageTypePreparedTransaction:
 			var pt components.PreparedTransactionWithRefs
 			err := json.Unmarshal(v.msg.Payload, &pt)
@@ -323,6 +334,19 @@ func (tm *transportManager) handleReliableMsgBatch(ctx context.Context, dbTX per
 		}
 	}
 
+	// Queue re-distribution of any states that have been requested
+	for _, sr := range stateRequests {
+		validationErr, persistErr := tm.processStateRequest(ctx, dbTX, sr)
+		if persistErr != nil {
+			return nil, persistErr
+		}
+		var ackErr string
+		if validationErr != nil {
+			ackErr = validationErr.Error()
+		}
+		acksToSend = append(acksToSend, &ackInfo{node: sr.node, id: sr.rMsgID, Error: ackErr})
+	}
+
 	// We use a post-commit handler to send back any acks to the other side that are required
 	return make([]flushwriter.Result[*noResult], len(values)), nil
 
@@ -379,7 +403,7 @@ func (tm *transportManager) buildStateDistributionMsg(ctx context.Context, dbTX
 	if err != nil {
 		return nil, nil, err
 	}
-	if len(states) != 1 {
+	if len(states) != 1 || states[0].Data.IsNil() /* purged */ {
 		return nil,
 			i18n.NewError(ctx, msgs.MsgTransportStateNotAvailableLocally, sd.Domain, parsed.ContractAddress, parsed.ID),
 			nil
@@ -430,7 +454,7 @@ func (tm *transportManager) buildPrivacyGroupDistributionMsg(ctx context.Context
 	if err != nil {
 		return nil, nil, err
 	}
-	if len(states) != 1 {
+	if len(states) != 1 || states[0].Data.IsNil() /* purged */ {
 		return nil,
 			i18n.NewError(ctx, msgs.MsgTransportStateNotAvailableLocally, domainName, nil, parsed.ID),
 			nil
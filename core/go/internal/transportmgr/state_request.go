@@ -0,0 +1,139 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package transportmgr
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/core/internal/components"
+	"github.com/kaleido-io/paladin/core/internal/msgs"
+	"github.com/kaleido-io/paladin/core/pkg/persistence"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
+)
+
+type receivedStateRequest struct {
+	rMsgID uuid.UUID
+	node   string
+	req    *components.StateRequest
+}
+
+// See docs in components package
+func (tm *transportManager) RequestStates(ctx context.Context, dbTX persistence.DBTX, node string, req *components.StateRequest) (*pldapi.ReliableMessage, error) {
+	if req.Domain == "" || len(req.StateIDs) == 0 {
+		return nil, i18n.NewError(ctx, msgs.MsgTransportStateRequestEmpty)
+	}
+	rm := &pldapi.ReliableMessage{
+		Node:        node,
+		MessageType: pldapi.RMTStateRequest.Enum(),
+		Metadata:    pldtypes.JSONString(req),
+	}
+	if err := tm.SendReliable(ctx, dbTX, rm); err != nil {
+		return nil, err
+	}
+	return rm, nil
+}
+
+func parseStateRequest(ctx context.Context, msgID uuid.UUID, data []byte) (req *components.StateRequest, err error) {
+	err = json.Unmarshal(data, &req)
+	if err == nil && (req.Domain == "" || len(req.StateIDs) == 0) {
+		err = i18n.NewError(ctx, msgs.MsgTransportStateRequestEmpty)
+	}
+	if err != nil {
+		return nil, i18n.WrapError(ctx, err, msgs.MsgTransportInvalidMessageData, msgID)
+	}
+	return req, nil
+}
+
+func (tm *transportManager) buildStateRequestMsg(ctx context.Context, rm *pldapi.ReliableMessage) (*prototk.PaladinMsg, error, error) {
+
+	// The request is entirely contained in the metadata
+	req, parseErr := parseStateRequest(ctx, rm.ID, rm.Metadata)
+	if parseErr != nil {
+		return nil, parseErr, nil
+	}
+
+	return &prototk.PaladinMsg{
+		MessageId:   rm.ID.String(),
+		Component:   prototk.PaladinMsg_RELIABLE_MESSAGE_HANDLER,
+		MessageType: RMHMessageTypeStateRequest,
+		Payload:     pldtypes.JSONString(req),
+	}, nil, nil
+}
+
+// A node can only ask us to re-send states that we previously distributed to it, which we determine by
+// finding the original state distribution in our reliable message history.
+// A new reliable state distribution message is queued for each of those, and the error returned (to nack
+// the request) lists any requested states that we have no record of distributing to the node.
+func (tm *transportManager) processStateRequest(ctx context.Context, dbTX persistence.DBTX, sr *receivedStateRequest) (validationErr, persistErr error) {
+
+	// The metadata is stored as text, so we narrow down the candidates in the DB and then parse each one
+	candidates := dbTX.DB().WithContext(ctx)
+	for _, stateID := range sr.req.StateIDs {
+		candidates = candidates.Or("metadata LIKE ?", "%"+strings.TrimPrefix(stateID.String(), "0x")+"%")
+	}
+	var previous []*pldapi.ReliableMessage
+	err := dbTX.DB().
+		WithContext(ctx).
+		Where("node = ?", sr.node).
+		Where("msg_type = ?", pldapi.RMTState.Enum()).
+		Where(candidates).
+		Order("sequence DESC").
+		Find(&previous).
+		Error
+	if err != nil {
+		return nil, err
+	}
+
+	var toResend []*pldapi.ReliableMessage
+	var missing []string
+	for _, stateID := range sr.req.StateIDs {
+		var match *pldapi.ReliableMessage
+		for _, rm := range previous {
+			sd, parsed, err := parseStateDistribution(ctx, rm.ID, rm.Metadata)
+			if err == nil && sd.Domain == sr.req.Domain && parsed.ID.Equals(stateID) {
+				match = rm
+				break
+			}
+		}
+		if match == nil {
+			missing = append(missing, stateID.String())
+			continue
+		}
+		toResend = append(toResend, &pldapi.ReliableMessage{
+			Node:        sr.node,
+			MessageType: pldapi.RMTState.Enum(),
+			Metadata:    match.Metadata,
+		})
+	}
+
+	if len(toResend) > 0 {
+		log.L(ctx).Infof("Re-sending %d states in domain %s requested by node %s", len(toResend), sr.req.Domain, sr.node)
+		if err := tm.SendReliable(ctx, dbTX, toResend...); err != nil {
+			return nil, err
+		}
+	}
+	if len(missing) > 0 {
+		return i18n.NewError(ctx, msgs.MsgTransportRequestedStatesNotDistributed, sr.node, sr.req.Domain, strings.Join(missing, ",")), nil
+	}
+	return nil, nil
+}
@@ -0,0 +1,250 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package transportmgr
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/internal/components"
+	"github.com/kaleido-io/paladin/core/pkg/persistence"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldclient"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func captureSentMessages(tp *testPlugin) chan *prototk.PaladinMsg {
+	mockActivateDeactivateOk(tp)
+	sentMessages := make(chan *prototk.PaladinMsg, 10)
+	tp.Functions.SendMessage = func(ctx context.Context, req *prototk.SendMessageRequest) (*prototk.SendMessageResponse, error) {
+		sentMessages <- req.Message
+		return nil, nil
+	}
+	return sentMessages
+}
+
+func testStateDistribution(domain string, stateID pldtypes.HexBytes) *components.StateDistribution {
+	return &components.StateDistribution{
+		Domain:          domain,
+		ContractAddress: pldtypes.RandAddress().String(),
+		SchemaID:        pldtypes.RandHex(32),
+		StateID:         stateID.String(),
+		IdentityLocator: "me@node2",
+	}
+}
+
+func TestRequestStatesSendsRequest(t *testing.T) {
+	ctx, tm, tp, done := newTestTransport(t, true, mockGoodTransport)
+	defer done()
+
+	sentMessages := captureSentMessages(tp)
+
+	stateIDs := []pldtypes.HexBytes{pldtypes.RandBytes(32), pldtypes.RandBytes(32)}
+	var rm *pldapi.ReliableMessage
+	err := tm.persistence.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) (err error) {
+		rm, err = tm.RequestStates(ctx, dbTX, "node2", &components.StateRequest{Domain: "domain1", StateIDs: stateIDs})
+		return err
+	})
+	require.NoError(t, err)
+	assert.Equal(t, pldapi.RMTStateRequest, rm.MessageType.V())
+
+	sent := <-sentMessages
+	assert.Equal(t, rm.ID.String(), sent.MessageId)
+	assert.Equal(t, RMHMessageTypeStateRequest, sent.MessageType)
+	var req components.StateRequest
+	err = json.Unmarshal(sent.Payload, &req)
+	require.NoError(t, err)
+	assert.Equal(t, "domain1", req.Domain)
+	assert.Equal(t, stateIDs, req.StateIDs)
+}
+
+func TestRequestStatesEmpty(t *testing.T) {
+	ctx, tm, _, done := newTestTransport(t, false)
+	defer done()
+
+	_, err := tm.RequestStates(ctx, tm.persistence.NOTX(), "node2", &components.StateRequest{Domain: "domain1"})
+	assert.Regexp(t, "PD012023", err)
+}
+
+func TestHandleStateRequestRedistributesRealDB(t *testing.T) {
+	ctx, tm, tp, done := newTestTransport(t, true,
+		mockGoodTransport,
+		func(mc *mockComponents, conf *pldconf.TransportManagerConfig) {
+			// The re-sent distribution is built by the peer sender
+			mc.stateManager.On("GetStatesByID", mock.Anything, mock.Anything, "domain1", mock.Anything, mock.Anything, false, false).
+				Return([]*pldapi.State{{StateBase: pldapi.StateBase{Data: pldtypes.RawJSON(`{"some":"data"}`)}}}, nil).Maybe()
+		},
+	)
+	defer done()
+
+	sentMessages := captureSentMessages(tp)
+
+	// We previously sent state1 to node2, and state2 only to node3
+	state1 := pldtypes.HexBytes(pldtypes.RandBytes(32))
+	state2 := pldtypes.HexBytes(pldtypes.RandBytes(32))
+	sd1 := testStateDistribution("domain1", state1)
+	err := tm.persistence.DB().Create([]*pldapi.ReliableMessage{
+		{ID: uuid.New(), Created: pldtypes.TimestampNow(), Node: "node2", MessageType: pldapi.RMTState.Enum(), Metadata: pldtypes.JSONString(sd1)},
+		{ID: uuid.New(), Created: pldtypes.TimestampNow(), Node: "node3", MessageType: pldapi.RMTState.Enum(), Metadata: pldtypes.JSONString(testStateDistribution("domain1", state2))},
+	}).Error
+	require.NoError(t, err)
+
+	msg := testReceivedReliableMsg(RMHMessageTypeStateRequest, &components.StateRequest{
+		Domain:   "domain1",
+		StateIDs: []pldtypes.HexBytes{state1, state2},
+	})
+
+	p, err := tm.getPeer(ctx, "node2", false)
+	require.NoError(t, err)
+
+	err = tm.persistence.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
+		_, err := tm.handleReliableMsgBatch(ctx, dbTX, []*reliableMsgOp{
+			{p: p, msg: msg},
+		})
+		return err
+	})
+	require.NoError(t, err)
+
+	// The request is nack'd for the state we never sent to node2, and state1 is sent again
+	var nack, sentState *prototk.PaladinMsg
+	for nack == nil || sentState == nil {
+		sent := <-sentMessages
+		switch {
+		case sent.CorrelationId != nil && *sent.CorrelationId == msg.MessageID.String():
+			nack = sent
+		case sent.MessageType == RMHMessageTypeStateDistribution:
+			sentState = sent
+		}
+	}
+	assert.Equal(t, RMHMessageTypeNack, nack.MessageType)
+	var ai ackInfo
+	err = json.Unmarshal(nack.Payload, &ai)
+	require.NoError(t, err)
+	assert.Regexp(t, fmt.Sprintf("PD012024.*%s", state2), ai.Error)
+	assert.NotContains(t, ai.Error, state1.String())
+	var sd components.StateDistributionWithData
+	err = json.Unmarshal(sentState.Payload, &sd)
+	require.NoError(t, err)
+	assert.Equal(t, sd1.StateID, sd.StateID)
+
+	// A new distribution of state1 was queued for node2
+	var resent []*pldapi.ReliableMessage
+	err = tm.persistence.DB().
+		Where("node = ?", "node2").
+		Where("msg_type = ?", pldapi.RMTState.Enum()).
+		Find(&resent).
+		Error
+	require.NoError(t, err)
+	require.Len(t, resent, 2)
+	assert.JSONEq(t, resent[0].Metadata.String(), resent[1].Metadata.String())
+}
+
+func TestHandleStateRequestBad(t *testing.T) {
+	ctx, tm, tp, done := newTestTransport(t, false,
+		mockGoodTransport,
+		mockEmptyReliableMsgs,
+		func(mc *mockComponents, conf *pldconf.TransportManagerConfig) {
+			mc.db.Mock.ExpectBegin()
+			mc.db.Mock.ExpectCommit()
+		},
+	)
+	defer done()
+
+	msg := testReceivedReliableMsg(RMHMessageTypeStateRequest, &components.StateRequest{Domain: "domain1"})
+	ackNackCheck := setupAckOrNackCheck(t, tp, msg.MessageID, "PD012016.*PD012023")
+
+	p, err := tm.getPeer(ctx, "node2", false)
+	require.NoError(t, err)
+
+	err = tm.persistence.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
+		_, err := tm.handleReliableMsgBatch(ctx, dbTX, []*reliableMsgOp{
+			{p: p, msg: msg},
+		})
+		return err
+	})
+	require.NoError(t, err)
+
+	ackNackCheck()
+}
+
+func TestHandleStateRequestQueryFail(t *testing.T) {
+	ctx, tm, _, done := newTestTransport(t, false,
+		func(mc *mockComponents, conf *pldconf.TransportManagerConfig) {
+			mc.db.Mock.ExpectBegin()
+			mc.db.Mock.ExpectQuery("SELECT.*reliable_msgs").WillReturnError(fmt.Errorf("pop"))
+			mc.db.Mock.ExpectRollback()
+		},
+	)
+	defer done()
+
+	msg := testReceivedReliableMsg(RMHMessageTypeStateRequest, &components.StateRequest{
+		Domain:   "domain1",
+		StateIDs: []pldtypes.HexBytes{pldtypes.RandBytes(32)},
+	})
+
+	p, err := tm.getPeer(ctx, "node2", false)
+	require.NoError(t, err)
+
+	err = tm.persistence.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
+		_, err := tm.handleReliableMsgBatch(ctx, dbTX, []*reliableMsgOp{
+			{p: p, msg: msg},
+		})
+		return err
+	})
+	assert.Regexp(t, "pop", err)
+}
+
+func TestBuildStateRequestMsgBadMsg(t *testing.T) {
+	ctx, tm, _, done := newTestTransport(t, false)
+	defer done()
+
+	_, errorAck, err := tm.buildStateRequestMsg(ctx, &pldapi.ReliableMessage{
+		ID:       uuid.New(),
+		Metadata: pldtypes.RawJSON(`{!!! bad json`),
+	})
+	require.NoError(t, err)
+	assert.Regexp(t, "PD012016", errorAck)
+}
+
+func TestRPCRequestStates(t *testing.T) {
+	ctx, tm, tp, done := newTestTransport(t, true, mockGoodTransport)
+	defer done()
+
+	mockActivateDeactivateOk(tp)
+
+	client, rpcDone := newTestRPCServer(t, ctx, tm)
+	defer rpcDone()
+
+	transportRPC := pldclient.Wrap(client).Transport()
+
+	stateID := pldtypes.HexBytes(pldtypes.RandBytes(32))
+	rm, err := transportRPC.RequestStates(ctx, "node2", "domain1", []pldtypes.HexBytes{stateID})
+	require.NoError(t, err)
+	assert.Equal(t, "node2", rm.Node)
+	assert.Equal(t, pldapi.RMTStateRequest, rm.MessageType.V())
+	assert.JSONEq(t, fmt.Sprintf(`{"domain":"domain1","stateIds":["%s"]}`, stateID), rm.Metadata.String())
+
+	_, err = transportRPC.RequestStates(ctx, "node2", "", nil)
+	assert.Regexp(t, "PD012023", err)
+}
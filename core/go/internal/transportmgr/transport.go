@@ -170,13 +170,32 @@ func (t *transport) ReceiveMessage(ctx context.Context, req *prototk.ReceiveMess
 		return nil, err
 	}
 
+	if err := t.tm.checkPeerBanned(ctx, req.FromNode); err != nil {
+		return nil, err
+	}
+
 	p, err := t.tm.getPeer(ctx, req.FromNode, false /* we do not require a connection for sending here */)
 	if err != nil {
 		return nil, err
 	}
 
+	if err := p.checkInboundLimits(ctx, msg); err != nil {
+		return nil, err
+	}
 	p.updateReceivedStats(msg)
 
+	// Chunks are buffered until the whole message has arrived, before being decompressed
+	msg, err = p.reassembleChunks(ctx, msg)
+	if err != nil {
+		return nil, err
+	}
+	if msg == nil {
+		return &prototk.ReceiveMessageResponse{}, nil
+	}
+	if rMsg.Payload, err = t.tm.decodePayload(ctx, msg); err != nil {
+		return nil, err
+	}
+
 	log.L(ctx).Debugf("transport %s message received from %s id=%s (cid=%s)", t.name, p.Name, rMsg.MessageID, pldtypes.StrOrEmpty(msg.CorrelationId))
 	if log.IsTraceEnabled() {
 		log.L(ctx).Tracef("transport %s message received: %s", t.name, protoToJSON(msg))
@@ -201,6 +220,8 @@ func (t *transport) deliverMessage(ctx context.Context, p *peer, component proto
 		t.tm.privateTxManager.HandlePaladinMsg(ctx, msg)
 	case prototk.PaladinMsg_IDENTITY_RESOLVER:
 		t.tm.identityResolver.HandlePaladinMsg(ctx, msg)
+	case prototk.PaladinMsg_CONTRACT_MANAGER:
+		t.tm.contractManager.HandlePaladinMsg(ctx, msg)
 	default:
 		log.L(ctx).Errorf("Component not found for message '%s': %s", msg.MessageID, component)
 		return i18n.NewError(ctx, msgs.MsgTransportComponentNotFound, component.String())
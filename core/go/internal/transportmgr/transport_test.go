@@ -372,6 +372,33 @@ func TestReceiveMessageIdentityResolver(t *testing.T) {
 	<-receivedMessages
 }
 
+func TestReceiveMessageContractManager(t *testing.T) {
+	receivedMessages := make(chan *components.ReceivedMessage, 1)
+
+	ctx, _, tp, done := newTestTransport(t, false, func(mc *mockComponents, conf *pldconf.TransportManagerConfig) {
+		mc.contractManager.On("HandlePaladinMsg", mock.Anything, mock.Anything).Return().Run(func(args mock.Arguments) {
+			receivedMessages <- args[1].(*components.ReceivedMessage)
+		})
+	})
+	defer done()
+
+	msg := &prototk.PaladinMsg{
+		MessageId:   uuid.NewString(),
+		Component:   prototk.PaladinMsg_CONTRACT_MANAGER,
+		MessageType: "myMessageType",
+		Payload:     []byte("some data"),
+	}
+
+	rmr, err := tp.t.ReceiveMessage(ctx, &prototk.ReceiveMessageRequest{
+		FromNode: "node2",
+		Message:  msg,
+	})
+	require.NoError(t, err)
+	assert.NotNil(t, rmr)
+
+	<-receivedMessages
+}
+
 func TestReceiveMessageInvalidComponent(t *testing.T) {
 	ctx, _, tp, done := newTestTransport(t, false)
 	defer done()
@@ -19,7 +19,10 @@ package transportmgr
 import (
 	"context"
 
+	"github.com/kaleido-io/paladin/core/internal/components"
+	"github.com/kaleido-io/paladin/core/pkg/persistence"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/query"
 	"github.com/kaleido-io/paladin/toolkit/pkg/rpcserver"
 )
@@ -36,7 +39,10 @@ func (tm *transportManager) initRPC() {
 		Add("transport_peers", tm.rpcPeers()).
 		Add("transport_peerInfo", tm.rpcPeerInfo()).
 		Add("transport_queryReliableMessages", tm.rpcQueryReliableMessages()).
-		Add("transport_queryReliableMessageAcks", tm.rpcQueryReliableMessageAcks())
+		Add("transport_queryReliableMessageAcks", tm.rpcQueryReliableMessageAcks()).
+		Add("transport_requestStates", tm.rpcRequestStates()).
+		Add("transport_listPeerBans", tm.rpcListPeerBans()).
+		Add("transport_clearPeerBan", tm.rpcClearPeerBan())
 }
 
 func (tm *transportManager) rpcNodeName() rpcserver.RPCHandler {
@@ -84,3 +90,25 @@ func (tm *transportManager) rpcQueryReliableMessageAcks() rpcserver.RPCHandler {
 		return tm.QueryReliableMessageAcks(ctx, tm.persistence.NOTX(), &jq)
 	})
 }
+
+func (tm *transportManager) rpcRequestStates() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod3(func(ctx context.Context, node, domain string, stateIDs []pldtypes.HexBytes) (rm *pldapi.ReliableMessage, err error) {
+		err = tm.persistence.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
+			rm, err = tm.RequestStates(ctx, dbTX, node, &components.StateRequest{Domain: domain, StateIDs: stateIDs})
+			return err
+		})
+		return rm, err
+	})
+}
+
+func (tm *transportManager) rpcListPeerBans() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod0(func(ctx context.Context) ([]*pldapi.PeerBan, error) {
+		return tm.listPeerBans(), nil
+	})
+}
+
+func (tm *transportManager) rpcClearPeerBan() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod1(func(ctx context.Context, nodeName string) (bool, error) {
+		return tm.clearPeerBan(ctx, nodeName), nil
+	})
+}
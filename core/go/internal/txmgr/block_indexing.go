@@ -18,8 +18,10 @@ package txmgr
 import (
 	"context"
 
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
 	"github.com/kaleido-io/paladin/common/go/pkg/log"
 	"github.com/kaleido-io/paladin/core/internal/components"
+	"github.com/kaleido-io/paladin/core/internal/msgs"
 	"github.com/kaleido-io/paladin/core/pkg/blockindexer"
 	"github.com/kaleido-io/paladin/core/pkg/persistence"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
@@ -54,9 +56,9 @@ func (tm *txManager) blockIndexerPreCommit(
 			log.L(ctx).Infof("Writing receipt for transaction %s hash=%s block=%d result=%s",
 				match.TransactionID, match.Hash, match.BlockNumber, match.Result)
 			// Map to the common format for finalizing transactions whether the make it on chain or not
-			finalizeInfo = append(finalizeInfo, tm.mapBlockchainReceipt(match))
+			finalizeInfo = append(finalizeInfo, tm.mapBlockchainReceipt(ctx, match))
 		case pldapi.TransactionTypePrivate:
-			if match.Result.V() != pldapi.TXResult_SUCCESS {
+			if match.Result.V() != pldapi.TXResult_SUCCESS || match.Expired {
 				log.L(ctx).Infof("Base ledger transaction for private transaction %s FAILED hash=%s block=%d result=%s",
 					match.TransactionID, match.Hash, match.BlockNumber, match.Result)
 				failedForPrivateTx = append(failedForPrivateTx, match)
@@ -64,6 +66,13 @@ func (tm *txManager) blockIndexerPreCommit(
 		}
 	}
 
+	// Transactions submitted outside of Paladin can be watched, to get the same receipts
+	externalReceipts, err := tm.matchExternalTxWatches(ctx, dbTX, transactions)
+	if err != nil {
+		return err
+	}
+	finalizeInfo = append(finalizeInfo, externalReceipts...)
+
 	// Write the receipts themselves - only way of duplicates should be a rewind of
 	// the block explorer, so we simply OnConflict ignore
 	err = tm.FinalizeTransactions(ctx, dbTX, finalizeInfo)
@@ -71,6 +80,12 @@ func (tm *txManager) blockIndexerPreCommit(
 		return err
 	}
 
+	// Record the fees paid for everything we submitted, public or private, for chargeback
+	err = tm.recordTransactionFees(ctx, dbTX, blocks, txMatches)
+	if err != nil {
+		return err
+	}
+
 	// Deliver the failures to the private transaction manager
 	if len(failedForPrivateTx) > 0 {
 		err = tm.privateTxMgr.NotifyFailedPublicTx(ctx, dbTX, failedForPrivateTx)
@@ -90,7 +105,7 @@ func (tm *txManager) blockIndexerPreCommit(
 	return nil
 }
 
-func (tm *txManager) mapBlockchainReceipt(pubTx *components.PublicTxMatch) *components.ReceiptInput {
+func (tm *txManager) mapBlockchainReceipt(ctx context.Context, pubTx *components.PublicTxMatch) *components.ReceiptInput {
 	receipt := &components.ReceiptInput{
 		TransactionID: pubTx.TransactionID,
 		OnChain: pldtypes.OnChainLocation{
@@ -102,7 +117,11 @@ func (tm *txManager) mapBlockchainReceipt(pubTx *components.PublicTxMatch) *comp
 		ContractAddress: pubTx.ContractAddress,
 		RevertData:      pubTx.RevertReason,
 	}
-	if pubTx.Result.V() == pldapi.TXResult_SUCCESS {
+	if pubTx.Expired {
+		// the no-op replacement succeeding does not mean the transaction did
+		receipt.ReceiptType = components.RT_FailedWithMessage
+		receipt.FailureMessage = i18n.NewError(ctx, msgs.MsgPublicTxExpired, pubTx.Hash).Error()
+	} else if pubTx.Result.V() == pldapi.TXResult_SUCCESS {
 		receipt.ReceiptType = components.RT_Success
 	} else {
 		receipt.ReceiptType = components.RT_FailedOnChainWithRevertData
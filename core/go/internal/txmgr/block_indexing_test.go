@@ -130,6 +130,10 @@ func mockEmptyReceiptListeners(conf *pldconf.TxManagerConfig, mc *mockComponents
 	mc.db.ExpectQuery("SELECT.*receipt_listeners").WillReturnRows(sqlmock.NewRows([]string{}))
 }
 
+func mockNoExternalTxWatches(mc *mockComponents) {
+	mc.db.ExpectQuery("SELECT.*external_tx_watches").WillReturnRows(sqlmock.NewRows([]string{}))
+}
+
 func mockNoGaps(conf *pldconf.TxManagerConfig, mc *mockComponents) {
 	mc.db.MatchExpectationsInOrder(false)
 	mc.db.ExpectQuery("SELECT.*receipt_listener_gap").WillReturnRows(sqlmock.NewRows([]string{}))
@@ -156,6 +160,7 @@ func TestPublicConfirmMatch(t *testing.T) {
 				}, nil)
 
 			mc.db.ExpectBegin()
+			mockNoExternalTxWatches(mc)
 			mc.db.ExpectQuery("INSERT.*transaction_receipts").WillReturnRows(sqlmock.NewRows([]string{"sequence"}).AddRow(12345))
 			mc.db.ExpectCommit()
 
@@ -185,12 +190,14 @@ func TestPrivateConfirmMatchPrivateFailures(t *testing.T) {
 	txID1 := uuid.New()
 	txiFail2 := newTestConfirm(revertData) // one failed
 	txID2 := uuid.New()
+	txiExpired3 := newTestConfirm() // one expired, so the no-op replacement succeeded
+	txID3 := uuid.New()
 
 	ctx, txm, done := newTestTransactionManager(t, false,
 		mockEmptyReceiptListeners,
 		func(conf *pldconf.TxManagerConfig, mc *mockComponents) {
 			mc.publicTxMgr.On("MatchUpdateConfirmedTransactions", mock.Anything, mock.Anything,
-				[]*blockindexer.IndexedTransactionNotify{txiOk1, txiFail2}).
+				[]*blockindexer.IndexedTransactionNotify{txiOk1, txiFail2, txiExpired3}).
 				Return([]*components.PublicTxMatch{
 					{
 						PaladinTXReference: components.PaladinTXReference{
@@ -206,30 +213,55 @@ func TestPrivateConfirmMatchPrivateFailures(t *testing.T) {
 						},
 						IndexedTransactionNotify: txiFail2,
 					},
+					{
+						PaladinTXReference: components.PaladinTXReference{
+							TransactionID:   txID3,
+							TransactionType: pldapi.TransactionTypePrivate.Enum(),
+						},
+						IndexedTransactionNotify: txiExpired3,
+						Expired:                  true,
+					},
 				}, nil)
 
 			mc.db.ExpectBegin()
+			mockNoExternalTxWatches(mc)
 			mc.db.ExpectCommit()
 			mc.privateTxMgr.On("NotifyFailedPublicTx", mock.Anything, mock.Anything, mock.MatchedBy(func(matches []*components.PublicTxMatch) bool {
-				return len(matches) == 1 &&
-					matches[0].TransactionID == txID2
+				return len(matches) == 2 &&
+					matches[0].TransactionID == txID2 &&
+					matches[1].TransactionID == txID3
 			})).Return(nil)
 
 			mc.publicTxMgr.On("NotifyConfirmPersisted", mock.Anything, mock.MatchedBy(func(matches []*components.PublicTxMatch) bool {
-				return len(matches) == 2 &&
+				return len(matches) == 3 &&
 					matches[0].TransactionID == txID1 &&
-					matches[1].TransactionID == txID2
+					matches[1].TransactionID == txID2 &&
+					matches[2].TransactionID == txID3
 			}))
 		})
 	defer done()
 
 	err = txm.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) (err error) {
 		return txm.blockIndexerPreCommit(ctx, dbTX, []*pldapi.IndexedBlock{},
-			[]*blockindexer.IndexedTransactionNotify{txiOk1, txiFail2})
+			[]*blockindexer.IndexedTransactionNotify{txiOk1, txiFail2, txiExpired3})
 	})
 	require.NoError(t, err)
 }
 
+func TestMapBlockchainReceiptExpired(t *testing.T) {
+	ctx, txm, done := newTestTransactionManager(t, false, mockEmptyReceiptListeners)
+	defer done()
+
+	txi := newTestConfirm()
+	receipt := txm.mapBlockchainReceipt(ctx, &components.PublicTxMatch{
+		PaladinTXReference:       components.PaladinTXReference{TransactionID: uuid.New()},
+		IndexedTransactionNotify: txi,
+		Expired:                  true,
+	})
+	assert.Equal(t, components.RT_FailedWithMessage, receipt.ReceiptType)
+	assert.Regexp(t, "PD011971.*"+txi.Hash.String(), receipt.FailureMessage)
+}
+
 func TestNoConfirmMatch(t *testing.T) {
 
 	txi := newTestConfirm()
@@ -238,6 +270,7 @@ func TestNoConfirmMatch(t *testing.T) {
 		mockEmptyReceiptListeners,
 		func(conf *pldconf.TxManagerConfig, mc *mockComponents) {
 			mc.db.ExpectBegin()
+			mockNoExternalTxWatches(mc)
 			mc.publicTxMgr.On("MatchUpdateConfirmedTransactions", mock.Anything, mock.Anything, []*blockindexer.IndexedTransactionNotify{txi}).
 				Return(nil, nil)
 			mc.db.ExpectCommit()
@@ -280,6 +313,7 @@ func TestPrivateConfirmError(t *testing.T) {
 		mockEmptyReceiptListeners,
 		func(conf *pldconf.TxManagerConfig, mc *mockComponents) {
 			mc.db.ExpectBegin()
+			mockNoExternalTxWatches(mc)
 			mc.publicTxMgr.On("MatchUpdateConfirmedTransactions", mock.Anything, mock.Anything, []*blockindexer.IndexedTransactionNotify{txi}).
 				Return([]*components.PublicTxMatch{
 					{
@@ -321,6 +355,7 @@ func TestConfirmInsertError(t *testing.T) {
 				}, nil)
 
 			mc.db.ExpectBegin()
+			mockNoExternalTxWatches(mc)
 			mc.db.ExpectQuery("INSERT.*transaction_receipts").WillReturnError(fmt.Errorf("pop"))
 		})
 	defer done()
@@ -227,6 +227,30 @@ func (tm *txManager) GetBlockchainEventListenerStatus(ctx context.Context, name
 	}, nil
 }
 
+// ReplayBlockchainEvents re-delivers the events emitted by the base ledger transaction that confirmed a
+// Paladin transaction, to the receivers of a blockchain event listener
+func (tm *txManager) ReplayBlockchainEvents(ctx context.Context, name string, txID uuid.UUID) (*pldapi.IndexerEventReplayResult, error) {
+	tm.blockchainEventListenerLock.Lock()
+	el := tm.blockchainEventListeners[name]
+	tm.blockchainEventListenerLock.Unlock()
+	if el == nil {
+		return nil, i18n.NewError(ctx, msgs.MsgTxMgrBlockchainEventListenerNotLoaded, name)
+	}
+
+	receipt, err := tm.GetTransactionReceiptByID(ctx, txID)
+	if err != nil {
+		return nil, err
+	}
+	if receipt == nil || receipt.TransactionHash == nil {
+		return nil, i18n.NewError(ctx, msgs.MsgTxMgrReplayNoTransactionHash, txID)
+	}
+	return tm.blockIndexer.ReplayTransactionEvents(ctx, &pldapi.IndexerEventReplayRequest{
+		StreamType:      string(ES_TYPE),
+		StreamName:      el.definition.Name,
+		TransactionHash: *receipt.TransactionHash,
+	})
+}
+
 func (tm *txManager) validateBlockchainEventListenerSpec(ctx context.Context, spec *pldapi.BlockchainEventListener) error {
 	if err := pldtypes.ValidateSafeCharsStartEndAlphaNum(ctx, spec.Name, pldtypes.DefaultNameMaxLen, "name"); err != nil {
 		return err
@@ -260,9 +284,10 @@ func (tm *txManager) mapEventStream(el *pldapi.BlockchainEventListener) *blockin
 		Type:    ES_TYPE,
 		Started: el.Started,
 		Config: blockindexer.EventStreamConfig{
-			BatchSize:    el.Options.BatchSize,
-			BatchTimeout: el.Options.BatchTimeout,
-			FromBlock:    el.Options.FromBlock,
+			BatchSize:     el.Options.BatchSize,
+			BatchTimeout:  el.Options.BatchTimeout,
+			FromBlock:     el.Options.FromBlock,
+			Confirmations: el.Options.Confirmations,
 		},
 	}
 
@@ -282,9 +307,10 @@ func (tm *txManager) mapBlockchainEventListener(es *blockindexer.EventStream) *p
 		Started: es.Started,
 		Created: es.Created,
 		Options: pldapi.BlockchainEventListenerOptions{
-			BatchSize:    es.Config.BatchSize,
-			BatchTimeout: es.Config.BatchTimeout,
-			FromBlock:    es.Config.FromBlock,
+			BatchSize:     es.Config.BatchSize,
+			BatchTimeout:  es.Config.BatchTimeout,
+			FromBlock:     es.Config.FromBlock,
+			Confirmations: es.Config.Confirmations,
 		},
 	}
 	for _, source := range es.Sources {
@@ -29,6 +29,7 @@ import (
 	"github.com/kaleido-io/paladin/core/internal/components"
 	"github.com/kaleido-io/paladin/core/mocks/componentmocks"
 	"github.com/kaleido-io/paladin/core/pkg/blockindexer"
+	"github.com/kaleido-io/paladin/core/pkg/persistence"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/query"
@@ -261,9 +262,10 @@ func TestQueryBlockchainEventListeners(t *testing.T) {
 			Name:    "bel1",
 			Started: confutil.P(true),
 			Config: blockindexer.EventStreamConfig{
-				BatchTimeout: confutil.P("1m"),
-				BatchSize:    confutil.P(100),
-				FromBlock:    json.RawMessage(`"latest"`),
+				BatchTimeout:  confutil.P("1m"),
+				BatchSize:     confutil.P(100),
+				FromBlock:     json.RawMessage(`"latest"`),
+				Confirmations: confutil.P(5),
 			},
 			Sources: blockindexer.EventSources{{
 				ABI:     mockABI,
@@ -283,6 +285,7 @@ func TestQueryBlockchainEventListeners(t *testing.T) {
 	assert.Equal(t, "1m", *listeners[0].Options.BatchTimeout)
 	assert.Equal(t, 100, *listeners[0].Options.BatchSize)
 	assert.Equal(t, "\"latest\"", string(listeners[0].Options.FromBlock))
+	assert.Equal(t, 5, *listeners[0].Options.Confirmations)
 	assert.Equal(t, mockABI, listeners[0].Sources[0].ABI)
 	assert.Equal(t, mockAddress, listeners[0].Sources[0].Address)
 
@@ -536,3 +539,64 @@ func TestHandleEventBatch(t *testing.T) {
 	<-gotError
 
 }
+
+func TestReplayBlockchainEvents(t *testing.T) {
+	txHash := pldtypes.RandBytes32()
+	ctx, txm, done := newTestTransactionManager(t, true, mockDomainContractResolve(t, "domain1"), func(conf *pldconf.TxManagerConfig, mc *mockComponents) {
+		mc.privateTxMgr.On("HandleNewTx", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+		mc.blockIndexer.On("StopEventStream", mock.Anything, mock.Anything).Return(nil)
+		mc.blockIndexer.On("ReplayTransactionEvents", mock.Anything, &pldapi.IndexerEventReplayRequest{
+			StreamType:      string(blockindexer.EventStreamTypePTXBlockchainEventListener),
+			StreamName:      "bel1",
+			TransactionHash: txHash,
+		}).Return(&pldapi.IndexerEventReplayResult{StreamName: "bel1", TransactionHash: txHash, Events: 3}, nil)
+	})
+	defer done()
+
+	_, err := txm.ReplayBlockchainEvents(ctx, "bel1", uuid.New())
+	assert.ErrorContains(t, err, "PD012248")
+
+	txm.blockchainEventListeners["bel1"] = &blockchainEventListener{
+		definition: &blockindexer.EventStream{
+			Name: "bel1",
+			ID:   uuid.New(),
+		},
+	}
+
+	// No receipt yet
+	exampleABI := abi.ABI{{Type: abi.Function, Name: "doIt"}}
+	callData, err := exampleABI[0].EncodeCallDataJSON([]byte(`[]`))
+	require.NoError(t, err)
+	txID, err := txm.sendTransactionNewDBTX(ctx, &pldapi.TransactionInput{
+		TransactionBase: pldapi.TransactionBase{
+			From:     "me",
+			Type:     pldapi.TransactionTypePrivate.Enum(),
+			Domain:   "domain1",
+			Function: "doIt",
+			To:       pldtypes.MustEthAddress(pldtypes.RandHex(20)),
+			Data:     pldtypes.JSONString(pldtypes.HexBytes(callData)),
+		},
+		ABI: exampleABI,
+	})
+	require.NoError(t, err)
+	_, err = txm.ReplayBlockchainEvents(ctx, "bel1", *txID)
+	assert.ErrorContains(t, err, "PD012265")
+
+	err = txm.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) (err error) {
+		return txm.FinalizeTransactions(ctx, dbTX, []*components.ReceiptInput{{
+			TransactionID: *txID,
+			Domain:        "domain1",
+			ReceiptType:   components.RT_Success,
+			OnChain: pldtypes.OnChainLocation{
+				Type:            pldtypes.OnChainTransaction,
+				TransactionHash: txHash,
+				BlockNumber:     12345,
+			},
+		}})
+	})
+	require.NoError(t, err)
+
+	result, err := txm.ReplayBlockchainEvents(ctx, "bel1", *txID)
+	require.NoError(t, err)
+	assert.Equal(t, 3, result.Events)
+}
@@ -0,0 +1,284 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package txmgr
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/hyperledger/firefly-signer/pkg/abi"
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/core/internal/filters"
+	"github.com/kaleido-io/paladin/core/internal/msgs"
+	"github.com/kaleido-io/paladin/core/pkg/persistence"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/query"
+	"gorm.io/gorm/clause"
+)
+
+type persistedContractABI struct {
+	Address  *pldtypes.EthAddress `gorm:"column:address"`
+	CodeHash *pldtypes.Bytes32    `gorm:"column:code_hash"`
+	ABIHash  pldtypes.Bytes32     `gorm:"column:abi_hash"`
+	Created  pldtypes.Timestamp   `gorm:"column:created"`
+}
+
+var contractABIFilters = filters.FieldMap{
+	"address":  filters.HexBytesField("address"),
+	"codeHash": filters.Bytes32Field("code_hash"),
+	"abiHash":  filters.Bytes32Field("abi_hash"),
+	"created":  filters.TimestampField("created"),
+}
+
+// RegisterContractABI stores the ABI (so its entries are also available to the selector based decoding
+// of calls, errors and events) and records it against either a single contract address, or the hash
+// of a runtime bytecode. Re-registering replaces the previous ABI for the same key.
+func (tm *txManager) RegisterContractABI(ctx context.Context, input *pldapi.ContractABIInput) (*pldapi.ContractABI, error) {
+	if (input.Address == nil) == (input.CodeHash == nil) {
+		return nil, i18n.NewError(ctx, msgs.MsgTxMgrContractABIKeyRequired)
+	}
+	if len(input.ABI) == 0 {
+		return nil, i18n.NewError(ctx, msgs.MsgTxMgrContractABIEmpty)
+	}
+	pca := &persistedContractABI{
+		Address:  input.Address,
+		CodeHash: input.CodeHash,
+		Created:  pldtypes.TimestampNow(),
+	}
+	keyColumn := "address"
+	if input.CodeHash != nil {
+		keyColumn = "code_hash"
+	}
+	var stored *pldapi.StoredABI
+	err := tm.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) (err error) {
+		stored, err = tm.UpsertABI(ctx, dbTX, input.ABI)
+		if err == nil {
+			pca.ABIHash = stored.Hash
+			err = dbTX.DB().
+				Table("contract_abis").
+				Clauses(clause.OnConflict{
+					Columns:   []clause.Column{{Name: keyColumn}},
+					DoUpdates: clause.AssignmentColumns([]string{"abi_hash", "created"}),
+				}).
+				Create(pca).
+				Error
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return mapContractABI(pca, stored.ABI), nil
+}
+
+func mapContractABI(pca *persistedContractABI, a abi.ABI) *pldapi.ContractABI {
+	ca := &pldapi.ContractABI{
+		Address:  pca.Address,
+		CodeHash: pca.CodeHash,
+		Source:   pldapi.ContractABISourceAddress,
+		ABIHash:  &pca.ABIHash,
+		ABI:      a,
+		Created:  &pca.Created,
+	}
+	if pca.CodeHash != nil {
+		ca.Source = pldapi.ContractABISourceCodeHash
+	}
+	return ca
+}
+
+func (tm *txManager) getRegisteredContractABI(ctx context.Context, dbTX persistence.DBTX, column string, value any) (*pldapi.ContractABI, error) {
+	var pcas []*persistedContractABI
+	err := dbTX.DB().
+		WithContext(ctx).
+		Table("contract_abis").
+		Where(column+" = ?", value).
+		Limit(1).
+		Find(&pcas).
+		Error
+	if err != nil || len(pcas) == 0 {
+		return nil, err
+	}
+	stored, err := tm.getABIByHash(ctx, dbTX, pcas[0].ABIHash)
+	if err != nil || stored == nil {
+		return nil, err
+	}
+	return mapContractABI(pcas[0], stored.ABI), nil
+}
+
+// GetContractABI resolves the ABI for a deployed contract, or nil if none is known. In order of precedence:
+// - an ABI registered against the address
+// - the events declared by the domain, if the address is a private smart contract
+// - an ABI registered against the hash of the code deployed at the address
+func (tm *txManager) GetContractABI(ctx context.Context, dbTX persistence.DBTX, address pldtypes.EthAddress) (*pldapi.ContractABI, error) {
+	ca, err := tm.getRegisteredContractABI(ctx, dbTX, "address", address)
+	if ca != nil || err != nil {
+		return ca, err
+	}
+
+	// Not finding a private smart contract is the normal case, so any error just moves us on
+	if psc, err := tm.domainMgr.GetSmartContractByAddress(ctx, dbTX, address); err == nil {
+		domain := psc.Domain()
+		var domainABI abi.ABI
+		if eventsJSON := domain.Configuration().AbiEventsJson; eventsJSON != "" {
+			if err := json.Unmarshal([]byte(eventsJSON), &domainABI); err != nil {
+				log.L(ctx).Warnf("Invalid events ABI for domain %s: %s", domain.Name(), err)
+			}
+		}
+		if len(domainABI) > 0 {
+			return &pldapi.ContractABI{
+				Address: &address,
+				Source:  pldapi.ContractABISourceDomain,
+				Domain:  domain.Name(),
+				ABI:     domainABI,
+			}, nil
+		}
+	}
+
+	code, err := tm.ethClientFactory.HTTPClient().GetCode(ctx, address, "latest")
+	if err != nil || len(code) == 0 {
+		return nil, err
+	}
+	ca, err = tm.getRegisteredContractABI(ctx, dbTX, "code_hash", pldtypes.Bytes32Keccak(code))
+	if ca != nil {
+		ca.Address = &address
+	}
+	return ca, err
+}
+
+func (tm *txManager) QueryContractABIs(ctx context.Context, jq *query.QueryJSON) ([]*pldapi.ContractABI, error) {
+	qw := &filters.QueryWrapper[persistedContractABI, pldapi.ContractABI]{
+		P:           tm.p,
+		Table:       "contract_abis",
+		DefaultSort: "-created",
+		Filters:     contractABIFilters,
+		Query:       jq,
+		MapResult: func(pca *persistedContractABI) (*pldapi.ContractABI, error) {
+			stored, err := tm.getABIByHash(ctx, tm.p.NOTX(), pca.ABIHash)
+			if err != nil || stored == nil {
+				return nil, err
+			}
+			return mapContractABI(pca, stored.ABI), nil
+		},
+	}
+	return qw.Run(ctx, nil)
+}
+
+// DecodeIndexedTransaction turns an indexed base ledger transaction into human readable form.
+// The calldata is decoded with the ABI resolved for the target contract where there is one, falling back
+// to any stored function with a matching selector. A failure to decode the calldata is reported in the
+// result rather than as an error, as it is expected for contracts nobody has told us about.
+func (tm *txManager) DecodeIndexedTransaction(ctx context.Context, txHash pldtypes.Bytes32, dataFormat pldtypes.JSONFormatOptions) (*pldapi.DecodedTransaction, error) {
+	itx, err := tm.blockIndexer.GetIndexedTransactionByHash(ctx, txHash)
+	if err != nil {
+		return nil, err
+	}
+	if itx == nil {
+		return nil, i18n.NewError(ctx, msgs.MsgTxMgrDecodeTxNotIndexed, txHash)
+	}
+	decoded := &pldapi.DecodedTransaction{Transaction: itx}
+
+	if itx.To != nil {
+		if decoded.ContractABI, err = tm.GetContractABI(ctx, tm.p.NOTX(), *itx.To); err != nil {
+			return nil, err
+		}
+		// The calldata is not held by the indexer, so we need to go back to the node for it
+		ethTX, err := tm.ethClientFactory.HTTPClient().GetTransactionByHash(ctx, txHash)
+		if err != nil {
+			return nil, err
+		}
+		if ethTX == nil {
+			return nil, i18n.NewError(ctx, msgs.MsgTxMgrDecodeTxNotFound, txHash)
+		}
+		if decoded.Call, err = tm.decodeContractCall(ctx, decoded.ContractABI, ethTX.Input, dataFormat); err != nil {
+			decoded.CallError = err.Error()
+		}
+	}
+
+	eventsABI, err := tm.getTransactionEventsABI(ctx, txHash, decoded.ContractABI)
+	if err == nil {
+		decoded.Events, err = tm.blockIndexer.DecodeTransactionEvents(ctx, txHash, eventsABI, dataFormat)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return decoded, nil
+}
+
+func (tm *txManager) decodeContractCall(ctx context.Context, ca *pldapi.ContractABI, callData pldtypes.HexBytes, dataFormat pldtypes.JSONFormatOptions) (*pldapi.ABIDecodedData, error) {
+	if ca != nil {
+		for _, e := range ca.ABI {
+			if e.Type != abi.Function {
+				continue
+			}
+			cv, err := e.DecodeCallDataCtx(ctx, callData)
+			if err != nil {
+				continue
+			}
+			de := &pldapi.ABIDecodedData{
+				Definition: e,
+				Signature:  e.String(),
+			}
+			serializer, err := dataFormat.GetABISerializer(ctx)
+			if err == nil {
+				de.Data, err = serializer.SerializeJSONCtx(ctx, cv)
+			}
+			return de, err
+		}
+	}
+	return tm.DecodeCall(ctx, tm.p.NOTX(), callData, dataFormat)
+}
+
+// getTransactionEventsABI builds an ABI to decode the events of a transaction, from the events of
+// the contract ABI (if any) followed by every stored event definition matching the signatures emitted.
+func (tm *txManager) getTransactionEventsABI(ctx context.Context, txHash pldtypes.Bytes32, ca *pldapi.ContractABI) (abi.ABI, error) {
+	eventsABI := abi.ABI{}
+	if ca != nil {
+		for _, e := range ca.ABI {
+			if e.Type == abi.Event {
+				eventsABI = append(eventsABI, e)
+			}
+		}
+	}
+
+	events, err := tm.blockIndexer.GetTransactionEventsByHash(ctx, txHash)
+	if err != nil || len(events) == 0 {
+		return eventsABI, err
+	}
+	signatures := make([]pldtypes.Bytes32, len(events))
+	for i, e := range events {
+		signatures[i] = e.Signature
+	}
+	var eventDefs []*PersistedABIEntry
+	err = tm.p.DB().Table("abi_entries").
+		WithContext(ctx).
+		Where("full_hash IN (?)", signatures).
+		Where("type = ?", abi.Event).
+		Distinct("full_hash", "definition").
+		Find(&eventDefs).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	for _, def := range eventDefs {
+		var e abi.Entry
+		if err := json.Unmarshal(def.Definition, &e); err == nil {
+			eventsABI = append(eventsABI, &e)
+		}
+	}
+	return eventsABI, nil
+}
@@ -0,0 +1,271 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package txmgr
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/firefly-signer/pkg/abi"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/mocks/componentmocks"
+	"github.com/kaleido-io/paladin/core/mocks/ethclientmocks"
+	"github.com/kaleido-io/paladin/core/pkg/ethclient"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/query"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/rpcclient"
+	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var testContractABI = abi.ABI{
+	{Type: abi.Function, Name: "set", Inputs: abi.ParameterArray{
+		{Name: "key", Type: "string"},
+		{Name: "value", Type: "uint256"},
+	}},
+	{Type: abi.Event, Name: "Changed", Inputs: abi.ParameterArray{
+		{Name: "key", Type: "string"},
+		{Name: "value", Type: "uint256"},
+	}},
+}
+
+func TestContractABIRegistryRealDB(t *testing.T) {
+	registeredAddr := pldtypes.RandAddress()
+	sharedCodeAddr := pldtypes.RandAddress()
+	unknownAddr := pldtypes.RandAddress()
+	sharedCode := pldtypes.HexBytes(pldtypes.RandBytes(64))
+	codeHash := pldtypes.Bytes32Keccak(sharedCode)
+
+	ctx, url, _, done := newTestTransactionManagerWithRPC(t, func(conf *pldconf.TxManagerConfig, mc *mockComponents) {
+		mc.domainManager.On("GetSmartContractByAddress", mock.Anything, mock.Anything, mock.Anything).Return(nil, fmt.Errorf("not found"))
+		ec := ethclientmocks.NewEthClient(t)
+		ec.On("GetCode", mock.Anything, *sharedCodeAddr, "latest").Return(sharedCode, nil)
+		ec.On("GetCode", mock.Anything, *unknownAddr, "latest").Return(pldtypes.HexBytes{}, nil)
+		mc.ethClientFactory.On("HTTPClient").Return(ec)
+	})
+	defer done()
+
+	rpcClient, err := rpcclient.NewHTTPClient(ctx, &pldconf.HTTPClientConfig{URL: url})
+	require.NoError(t, err)
+
+	var byAddr, byCode *pldapi.ContractABI
+	err = rpcClient.CallRPC(ctx, &byAddr, "ptx_registerContractABI", &pldapi.ContractABIInput{
+		Address: registeredAddr,
+		ABI:     testContractABI,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, pldapi.ContractABISourceAddress, byAddr.Source)
+	require.NotNil(t, byAddr.ABIHash)
+
+	err = rpcClient.CallRPC(ctx, &byCode, "ptx_registerContractABI", &pldapi.ContractABIInput{
+		CodeHash: &codeHash,
+		ABI:      abi.ABI{{Type: abi.Function, Name: "get"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, pldapi.ContractABISourceCodeHash, byCode.Source)
+
+	// Re-registering replaces the ABI for the code hash
+	err = rpcClient.CallRPC(ctx, &byCode, "ptx_registerContractABI", &pldapi.ContractABIInput{
+		CodeHash: &codeHash,
+		ABI:      testContractABI,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, *byAddr.ABIHash, *byCode.ABIHash)
+
+	var resolved *pldapi.ContractABI
+	err = rpcClient.CallRPC(ctx, &resolved, "ptx_getContractABI", registeredAddr)
+	require.NoError(t, err)
+	assert.Equal(t, pldapi.ContractABISourceAddress, resolved.Source)
+	assert.Len(t, resolved.ABI, 2)
+
+	err = rpcClient.CallRPC(ctx, &resolved, "ptx_getContractABI", sharedCodeAddr)
+	require.NoError(t, err)
+	assert.Equal(t, pldapi.ContractABISourceCodeHash, resolved.Source)
+	assert.Equal(t, sharedCodeAddr, resolved.Address)
+	assert.Equal(t, codeHash, *resolved.CodeHash)
+
+	resolved = nil
+	err = rpcClient.CallRPC(ctx, &resolved, "ptx_getContractABI", unknownAddr)
+	require.NoError(t, err)
+	assert.Nil(t, resolved)
+
+	var registrations []*pldapi.ContractABI
+	err = rpcClient.CallRPC(ctx, &registrations, "ptx_queryContractABIs", query.NewQueryBuilder().Limit(10).Query())
+	require.NoError(t, err)
+	assert.Len(t, registrations, 2)
+
+	err = rpcClient.CallRPC(ctx, &registrations, "ptx_queryContractABIs", query.NewQueryBuilder().Limit(10).Equal("address", registeredAddr).Query())
+	require.NoError(t, err)
+	require.Len(t, registrations, 1)
+	assert.Equal(t, registeredAddr, registrations[0].Address)
+}
+
+func TestRegisterContractABIInvalid(t *testing.T) {
+	ctx, txm, done := newTestTransactionManager(t, false, mockEmptyReceiptListeners)
+	defer done()
+
+	_, err := txm.RegisterContractABI(ctx, &pldapi.ContractABIInput{ABI: testContractABI})
+	assert.Regexp(t, "PD012278", err)
+
+	codeHash := pldtypes.RandBytes32()
+	_, err = txm.RegisterContractABI(ctx, &pldapi.ContractABIInput{Address: pldtypes.RandAddress(), CodeHash: &codeHash, ABI: testContractABI})
+	assert.Regexp(t, "PD012278", err)
+
+	_, err = txm.RegisterContractABI(ctx, &pldapi.ContractABIInput{Address: pldtypes.RandAddress()})
+	assert.Regexp(t, "PD012279", err)
+}
+
+func TestGetContractABIFromDomain(t *testing.T) {
+	addr := pldtypes.RandAddress()
+	eventsABI, err := json.Marshal(abi.ABI{testContractABI[1]})
+	require.NoError(t, err)
+
+	ctx, txm, done := newTestTransactionManager(t, true, func(conf *pldconf.TxManagerConfig, mc *mockComponents) {
+		md := componentmocks.NewDomain(t)
+		md.On("Name").Return("domain1")
+		md.On("Configuration").Return(&prototk.DomainConfig{AbiEventsJson: string(eventsABI)})
+		mpsc := componentmocks.NewDomainSmartContract(t)
+		mpsc.On("Domain").Return(md)
+		mc.domainManager.On("GetSmartContractByAddress", mock.Anything, mock.Anything, *addr).Return(mpsc, nil)
+	})
+	defer done()
+
+	ca, err := txm.GetContractABI(ctx, txm.p.NOTX(), *addr)
+	require.NoError(t, err)
+	assert.Equal(t, pldapi.ContractABISourceDomain, ca.Source)
+	assert.Equal(t, "domain1", ca.Domain)
+	assert.Len(t, ca.ABI, 1)
+}
+
+func TestGetContractABIGetCodeFail(t *testing.T) {
+	ctx, txm, done := newTestTransactionManager(t, true, func(conf *pldconf.TxManagerConfig, mc *mockComponents) {
+		mc.domainManager.On("GetSmartContractByAddress", mock.Anything, mock.Anything, mock.Anything).Return(nil, fmt.Errorf("not found"))
+		ec := ethclientmocks.NewEthClient(t)
+		ec.On("GetCode", mock.Anything, mock.Anything, "latest").Return(nil, fmt.Errorf("pop"))
+		mc.ethClientFactory.On("HTTPClient").Return(ec)
+	})
+	defer done()
+
+	_, err := txm.GetContractABI(ctx, txm.p.NOTX(), *pldtypes.RandAddress())
+	assert.Regexp(t, "pop", err)
+}
+
+func TestDecodeIndexedTransaction(t *testing.T) {
+	contractAddr := pldtypes.RandAddress()
+	txHash := pldtypes.RandBytes32()
+	changedSig, err := testContractABI[1].SignatureHash()
+	require.NoError(t, err)
+	callData, err := testContractABI[0].EncodeCallDataJSON([]byte(`{"key":"k1","value":"42"}`))
+	require.NoError(t, err)
+
+	ctx, txm, done := newTestTransactionManager(t, true, func(conf *pldconf.TxManagerConfig, mc *mockComponents) {
+		mc.blockIndexer.On("GetIndexedTransactionByHash", mock.Anything, txHash).Return(&pldapi.IndexedTransaction{
+			Hash: txHash,
+			To:   contractAddr,
+		}, nil)
+		mc.blockIndexer.On("GetTransactionEventsByHash", mock.Anything, txHash).Return([]*pldapi.IndexedEvent{
+			{TransactionHash: txHash, Signature: pldtypes.Bytes32(changedSig)},
+		}, nil)
+		mc.blockIndexer.On("DecodeTransactionEvents", mock.Anything, txHash, mock.MatchedBy(func(a abi.ABI) bool {
+			// The event from the registered ABI, and from the stored abi_entries
+			return len(a) == 2 && a[0].Name == "Changed" && a[1].Name == "Changed"
+		}), pldtypes.JSONFormatOptions("")).Return([]*pldapi.EventWithData{
+			{SoliditySignature: "event Changed(string key, uint256 value)"},
+		}, nil)
+		ec := ethclientmocks.NewEthClient(t)
+		ec.On("GetTransactionByHash", mock.Anything, txHash).Return(&ethclient.TransactionByHashResponse{
+			Hash:  txHash,
+			To:    contractAddr,
+			Input: pldtypes.HexBytes(callData),
+		}, nil)
+		mc.ethClientFactory.On("HTTPClient").Return(ec)
+	})
+	defer done()
+
+	_, err = txm.RegisterContractABI(ctx, &pldapi.ContractABIInput{Address: contractAddr, ABI: testContractABI})
+	require.NoError(t, err)
+
+	decoded, err := txm.DecodeIndexedTransaction(ctx, txHash, "")
+	require.NoError(t, err)
+	assert.Equal(t, pldapi.ContractABISourceAddress, decoded.ContractABI.Source)
+	require.NotNil(t, decoded.Call)
+	assert.Equal(t, "set(string,uint256)", decoded.Call.Signature)
+	assert.JSONEq(t, `{"key":"k1","value":"42"}`, decoded.Call.Data.String())
+	assert.Empty(t, decoded.CallError)
+	assert.Len(t, decoded.Events, 1)
+}
+
+func TestDecodeIndexedTransactionUnknownCall(t *testing.T) {
+	txHash := pldtypes.RandBytes32()
+
+	ctx, txm, done := newTestTransactionManager(t, true, func(conf *pldconf.TxManagerConfig, mc *mockComponents) {
+		mc.blockIndexer.On("GetIndexedTransactionByHash", mock.Anything, txHash).Return(&pldapi.IndexedTransaction{
+			Hash: txHash,
+			To:   pldtypes.RandAddress(),
+		}, nil)
+		mc.blockIndexer.On("GetTransactionEventsByHash", mock.Anything, txHash).Return([]*pldapi.IndexedEvent{}, nil)
+		mc.blockIndexer.On("DecodeTransactionEvents", mock.Anything, txHash, abi.ABI{}, pldtypes.JSONFormatOptions("")).Return([]*pldapi.EventWithData{}, nil)
+		mc.domainManager.On("GetSmartContractByAddress", mock.Anything, mock.Anything, mock.Anything).Return(nil, fmt.Errorf("not found"))
+		ec := ethclientmocks.NewEthClient(t)
+		ec.On("GetCode", mock.Anything, mock.Anything, "latest").Return(pldtypes.HexBytes{}, nil)
+		ec.On("GetTransactionByHash", mock.Anything, txHash).Return(&ethclient.TransactionByHashResponse{
+			Hash:  txHash,
+			Input: pldtypes.MustParseHexBytes("0xfeedbeef"),
+		}, nil)
+		mc.ethClientFactory.On("HTTPClient").Return(ec)
+	})
+	defer done()
+
+	decoded, err := txm.DecodeIndexedTransaction(ctx, txHash, "")
+	require.NoError(t, err)
+	assert.Nil(t, decoded.ContractABI)
+	assert.Nil(t, decoded.Call)
+	assert.Regexp(t, "PD012227", decoded.CallError)
+}
+
+func TestDecodeIndexedTransactionErrors(t *testing.T) {
+	notIndexed := pldtypes.RandBytes32()
+	notOnNode := pldtypes.RandBytes32()
+	indexerFail := pldtypes.RandBytes32()
+
+	ctx, txm, done := newTestTransactionManager(t, true, func(conf *pldconf.TxManagerConfig, mc *mockComponents) {
+		mc.blockIndexer.On("GetIndexedTransactionByHash", mock.Anything, notIndexed).Return(nil, nil)
+		mc.blockIndexer.On("GetIndexedTransactionByHash", mock.Anything, indexerFail).Return(nil, fmt.Errorf("pop"))
+		mc.blockIndexer.On("GetIndexedTransactionByHash", mock.Anything, notOnNode).Return(&pldapi.IndexedTransaction{
+			Hash: notOnNode,
+			To:   pldtypes.RandAddress(),
+		}, nil)
+		mc.domainManager.On("GetSmartContractByAddress", mock.Anything, mock.Anything, mock.Anything).Return(nil, fmt.Errorf("not found"))
+		ec := ethclientmocks.NewEthClient(t)
+		ec.On("GetCode", mock.Anything, mock.Anything, "latest").Return(pldtypes.HexBytes{}, nil)
+		ec.On("GetTransactionByHash", mock.Anything, notOnNode).Return(nil, nil)
+		mc.ethClientFactory.On("HTTPClient").Return(ec)
+	})
+	defer done()
+
+	_, err := txm.DecodeIndexedTransaction(ctx, notIndexed, "")
+	assert.Regexp(t, "PD012280", err)
+
+	_, err = txm.DecodeIndexedTransaction(ctx, notOnNode, "")
+	assert.Regexp(t, "PD012281", err)
+
+	_, err = txm.DecodeIndexedTransaction(ctx, indexerFail, "")
+	assert.Regexp(t, "pop", err)
+}
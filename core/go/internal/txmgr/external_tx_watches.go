@@ -0,0 +1,233 @@
+/*
+ * Copyright © 2024 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package txmgr
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/core/internal/components"
+	"github.com/kaleido-io/paladin/core/internal/filters"
+	"github.com/kaleido-io/paladin/core/internal/msgs"
+	"github.com/kaleido-io/paladin/core/pkg/blockindexer"
+	"github.com/kaleido-io/paladin/core/pkg/persistence"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/query"
+)
+
+// DB persisted record for a watch on a transaction submitted outside of Paladin
+type persistedExternalTxWatch struct {
+	ID              uuid.UUID            `gorm:"column:id;primaryKey"`
+	Created         pldtypes.Timestamp   `gorm:"column:created"`
+	TransactionHash *pldtypes.Bytes32    `gorm:"column:tx_hash"`
+	From            *pldtypes.EthAddress `gorm:"column:from"`
+	Nonce           *pldtypes.HexUint64  `gorm:"column:nonce"`
+	Completed       *pldtypes.Timestamp  `gorm:"column:completed"`
+}
+
+func (persistedExternalTxWatch) TableName() string {
+	return "external_tx_watches"
+}
+
+var externalTxWatchFilters = filters.FieldMap{
+	"id":              filters.UUIDField(`"id"`),
+	"created":         filters.TimestampField("created"),
+	"transactionHash": filters.HexBytesField("tx_hash"),
+	"from":            filters.HexBytesField(`"from"`),
+	"nonce":           filters.Int64Field("nonce"),
+	"completed":       filters.TimestampField("completed"),
+}
+
+func (w *persistedExternalTxWatch) matches(txi *pldapi.IndexedTransaction) bool {
+	if w.TransactionHash != nil {
+		return *w.TransactionHash == txi.Hash
+	}
+	return w.From.Equals(txi.From) && w.Nonce.Uint64() == txi.Nonce
+}
+
+func mapExternalTxWatch(pw *persistedExternalTxWatch) *pldapi.ExternalTransactionWatch {
+	return &pldapi.ExternalTransactionWatch{
+		ID:              pw.ID,
+		Created:         pw.Created,
+		TransactionHash: pw.TransactionHash,
+		From:            pw.From,
+		Nonce:           pw.Nonce,
+		Completed:       pw.Completed,
+	}
+}
+
+func (tm *txManager) WatchExternalTransaction(ctx context.Context, watch *pldapi.ExternalTransactionWatch) (*pldapi.ExternalTransactionWatch, error) {
+	// We watch either by hash, or by sender+nonce (which survives replacement of the transaction)
+	if watch.TransactionHash != nil {
+		if watch.From != nil || watch.Nonce != nil {
+			return nil, i18n.NewError(ctx, msgs.MsgTxMgrExternalTxWatchInvalid)
+		}
+	} else if watch.From == nil || watch.Nonce == nil {
+		return nil, i18n.NewError(ctx, msgs.MsgTxMgrExternalTxWatchInvalid)
+	}
+
+	pw := &persistedExternalTxWatch{
+		ID:              watch.ID,
+		Created:         pldtypes.TimestampNow(),
+		TransactionHash: watch.TransactionHash,
+		From:            watch.From,
+		Nonce:           watch.Nonce,
+	}
+	if pw.ID == uuid.Nil {
+		pw.ID = uuid.New()
+	}
+
+	// The lock serializes us with the block indexer, so either it sees our watch when it commits
+	// a matching transaction, or we see the indexed transaction in the check below.
+	err := tm.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
+		err := tm.p.TakeNamedLock(ctx, dbTX, "external_tx_watches")
+		if err == nil {
+			err = dbTX.DB().Create(pw).Error
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	log.L(ctx).Infof("Watching external transaction id=%s hash=%v from=%v nonce=%v", pw.ID, pw.TransactionHash, pw.From, pw.Nonce)
+
+	// The transaction might have been mined before we were asked to watch it
+	var txi *pldapi.IndexedTransaction
+	if pw.TransactionHash != nil {
+		txi, err = tm.blockIndexer.GetIndexedTransactionByHash(ctx, *pw.TransactionHash)
+	} else {
+		txi, err = tm.blockIndexer.GetIndexedTransactionByNonce(ctx, *pw.From, pw.Nonce.Uint64())
+	}
+	if err == nil && txi != nil {
+		err = tm.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
+			err := tm.p.TakeNamedLock(ctx, dbTX, "external_tx_watches")
+			if err != nil {
+				return err
+			}
+			receipts, err := tm.completeExternalTxWatches(ctx, dbTX, []*persistedExternalTxWatch{pw},
+				[]*blockindexer.IndexedTransactionNotify{{IndexedTransaction: *txi}})
+			if err == nil {
+				err = tm.FinalizeTransactions(ctx, dbTX, receipts)
+			}
+			return err
+		})
+	}
+	if err != nil {
+		return nil, err
+	}
+	return mapExternalTxWatch(pw), nil
+}
+
+func (tm *txManager) GetExternalTransactionWatch(ctx context.Context, id uuid.UUID) (*pldapi.ExternalTransactionWatch, error) {
+	watches, err := tm.QueryExternalTransactionWatches(ctx, tm.p.NOTX(), query.NewQueryBuilder().Limit(1).Equal("id", id).Query())
+	if len(watches) == 0 || err != nil {
+		return nil, err
+	}
+	return watches[0], nil
+}
+
+func (tm *txManager) QueryExternalTransactionWatches(ctx context.Context, dbTX persistence.DBTX, jq *query.QueryJSON) ([]*pldapi.ExternalTransactionWatch, error) {
+	qw := &filters.QueryWrapper[persistedExternalTxWatch, pldapi.ExternalTransactionWatch]{
+		P:           tm.p,
+		Table:       "external_tx_watches",
+		DefaultSort: "-created",
+		Filters:     externalTxWatchFilters,
+		Query:       jq,
+		MapResult: func(pw *persistedExternalTxWatch) (*pldapi.ExternalTransactionWatch, error) {
+			return mapExternalTxWatch(pw), nil
+		},
+	}
+	return qw.Run(ctx, dbTX)
+}
+
+func (tm *txManager) DeleteExternalTransactionWatch(ctx context.Context, id uuid.UUID) error {
+	result := tm.p.DB().
+		WithContext(ctx).
+		Where("id = ?", id).
+		Delete(&persistedExternalTxWatch{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return i18n.NewError(ctx, msgs.MsgTxMgrExternalTxWatchNotFound, id)
+	}
+	return nil
+}
+
+// Called by the block indexing routine for every batch of transactions, to find any that
+// are being watched. Receipts are returned to be written along with those for Paladin transactions.
+func (tm *txManager) matchExternalTxWatches(ctx context.Context, dbTX persistence.DBTX, transactions []*blockindexer.IndexedTransactionNotify) ([]*components.ReceiptInput, error) {
+	if len(transactions) == 0 {
+		return nil, nil
+	}
+
+	err := tm.p.TakeNamedLock(ctx, dbTX, "external_tx_watches")
+	if err != nil {
+		return nil, err
+	}
+
+	hashes := make([]pldtypes.Bytes32, len(transactions))
+	senders := make([]*pldtypes.EthAddress, len(transactions))
+	for i, txi := range transactions {
+		hashes[i] = txi.Hash
+		senders[i] = txi.From
+	}
+	var watches []*persistedExternalTxWatch
+	err = dbTX.DB().
+		Where("completed IS NULL").
+		Where(dbTX.DB().Where("tx_hash IN (?)", hashes).Or(`"from" IN (?)`, senders)).
+		Find(&watches).
+		Error
+	if err != nil || len(watches) == 0 {
+		return nil, err
+	}
+	return tm.completeExternalTxWatches(ctx, dbTX, watches, transactions)
+}
+
+func (tm *txManager) completeExternalTxWatches(ctx context.Context, dbTX persistence.DBTX, watches []*persistedExternalTxWatch, transactions []*blockindexer.IndexedTransactionNotify) ([]*components.ReceiptInput, error) {
+	now := pldtypes.TimestampNow()
+	receipts := make([]*components.ReceiptInput, 0, len(watches))
+	for _, pw := range watches {
+		for _, txi := range transactions {
+			if !pw.matches(&txi.IndexedTransaction) {
+				continue
+			}
+			// Only complete each watch once, regardless of which routine found the transaction first
+			result := dbTX.DB().
+				Model(&persistedExternalTxWatch{}).
+				Where("id = ?", pw.ID).
+				Where("completed IS NULL").
+				Update("completed", now)
+			if result.Error != nil {
+				return nil, result.Error
+			}
+			if result.RowsAffected > 0 {
+				log.L(ctx).Infof("External transaction watch %s matched hash=%s block=%d result=%s",
+					pw.ID, txi.Hash, txi.BlockNumber, txi.Result)
+				pw.Completed = &now
+				receipts = append(receipts, tm.mapBlockchainReceipt(ctx, &components.PublicTxMatch{
+					PaladinTXReference:       components.PaladinTXReference{TransactionID: pw.ID},
+					IndexedTransactionNotify: txi,
+				}))
+			}
+			break
+		}
+	}
+	return receipts, nil
+}
@@ -0,0 +1,227 @@
+/*
+ * Copyright © 2024 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package txmgr
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/pkg/blockindexer"
+	"github.com/kaleido-io/paladin/core/pkg/persistence"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/query"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/rpcclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExternalTxWatchLifecycleRealDB(t *testing.T) {
+	txiByHash := newTestConfirm()
+	txiByNonce := newTestConfirm([]byte{}) // failed with no revert data
+	txiOther := newTestConfirm()
+
+	ctx, url, txm, done := newTestTransactionManagerWithRPC(t, func(conf *pldconf.TxManagerConfig, mc *mockComponents) {
+		mc.blockIndexer.On("GetIndexedTransactionByHash", mock.Anything, txiByHash.Hash).Return(nil, nil)
+		mc.blockIndexer.On("GetIndexedTransactionByNonce", mock.Anything, *txiByNonce.From, txiByNonce.Nonce).Return(nil, nil)
+		mc.publicTxMgr.On("MatchUpdateConfirmedTransactions", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
+	})
+	defer done()
+
+	rpcClient, err := rpcclient.NewHTTPClient(ctx, &pldconf.HTTPClientConfig{URL: url})
+	require.NoError(t, err)
+
+	var watchByHash, watchByNonce *pldapi.ExternalTransactionWatch
+	err = rpcClient.CallRPC(ctx, &watchByHash, "ptx_watchExternalTransaction", &pldapi.ExternalTransactionWatch{
+		TransactionHash: &txiByHash.Hash,
+	})
+	require.NoError(t, err)
+	require.NotEqual(t, uuid.Nil, watchByHash.ID)
+	assert.Nil(t, watchByHash.Completed)
+
+	// The caller can choose the ID, to use the same receipt ID across systems
+	nonceWatchID := uuid.New()
+	err = rpcClient.CallRPC(ctx, &watchByNonce, "ptx_watchExternalTransaction", &pldapi.ExternalTransactionWatch{
+		ID:    nonceWatchID,
+		From:  txiByNonce.From,
+		Nonce: confutil.P(pldtypes.HexUint64(txiByNonce.Nonce)),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, nonceWatchID, watchByNonce.ID)
+
+	var watches []*pldapi.ExternalTransactionWatch
+	err = rpcClient.CallRPC(ctx, &watches, "ptx_queryExternalTransactionWatches", query.NewQueryBuilder().Limit(10).Null("completed").Query())
+	require.NoError(t, err)
+	require.Len(t, watches, 2)
+
+	// Index a block containing both, plus an unrelated transaction
+	err = txm.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
+		return txm.blockIndexerPreCommit(ctx, dbTX, []*pldapi.IndexedBlock{},
+			[]*blockindexer.IndexedTransactionNotify{txiOther, txiByHash, txiByNonce})
+	})
+	require.NoError(t, err)
+
+	receipt, err := txm.GetTransactionReceiptByID(ctx, watchByHash.ID)
+	require.NoError(t, err)
+	require.NotNil(t, receipt)
+	assert.True(t, receipt.Success)
+	assert.Equal(t, txiByHash.Hash, *receipt.TransactionHash)
+	assert.Empty(t, receipt.Domain)
+
+	receipt, err = txm.GetTransactionReceiptByID(ctx, nonceWatchID)
+	require.NoError(t, err)
+	require.NotNil(t, receipt)
+	assert.False(t, receipt.Success)
+	assert.Equal(t, txiByNonce.Hash, *receipt.TransactionHash)
+
+	var w *pldapi.ExternalTransactionWatch
+	err = rpcClient.CallRPC(ctx, &w, "ptx_getExternalTransactionWatch", watchByHash.ID)
+	require.NoError(t, err)
+	require.NotNil(t, w.Completed)
+
+	// Re-indexing the same block does not match completed watches again
+	err = txm.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
+		receipts, err := txm.matchExternalTxWatches(ctx, dbTX, []*blockindexer.IndexedTransactionNotify{txiByHash, txiByNonce})
+		assert.Empty(t, receipts)
+		return err
+	})
+	require.NoError(t, err)
+
+	// Delete
+	var boolRes bool
+	err = rpcClient.CallRPC(ctx, &boolRes, "ptx_deleteExternalTransactionWatch", watchByHash.ID)
+	require.NoError(t, err)
+	assert.True(t, boolRes)
+	err = rpcClient.CallRPC(ctx, &w, "ptx_getExternalTransactionWatch", watchByHash.ID)
+	require.NoError(t, err)
+	assert.Nil(t, w)
+	err = rpcClient.CallRPC(ctx, &boolRes, "ptx_deleteExternalTransactionWatch", watchByHash.ID)
+	assert.Regexp(t, "PD012264", err)
+}
+
+func TestExternalTxWatchAlreadyMinedRealDB(t *testing.T) {
+	txi := newTestConfirm()
+
+	ctx, txm, done := newTestTransactionManager(t, true, func(conf *pldconf.TxManagerConfig, mc *mockComponents) {
+		mc.blockIndexer.On("GetIndexedTransactionByNonce", mock.Anything, *txi.From, txi.Nonce).Return(&txi.IndexedTransaction, nil)
+	})
+	defer done()
+
+	w, err := txm.WatchExternalTransaction(ctx, &pldapi.ExternalTransactionWatch{
+		From:  txi.From,
+		Nonce: confutil.P(pldtypes.HexUint64(txi.Nonce)),
+	})
+	require.NoError(t, err)
+	require.NotNil(t, w.Completed)
+
+	receipt, err := txm.GetTransactionReceiptByID(ctx, w.ID)
+	require.NoError(t, err)
+	require.NotNil(t, receipt)
+	assert.True(t, receipt.Success)
+	assert.Equal(t, txi.BlockNumber, receipt.BlockNumber)
+}
+
+func TestExternalTxWatchInvalid(t *testing.T) {
+	ctx, txm, done := newTestTransactionManager(t, false, mockEmptyReceiptListeners)
+	defer done()
+
+	for _, w := range []*pldapi.ExternalTransactionWatch{
+		{},
+		{From: pldtypes.RandAddress()},
+		{Nonce: confutil.P(pldtypes.HexUint64(1))},
+		{TransactionHash: confutil.P(pldtypes.RandBytes32()), Nonce: confutil.P(pldtypes.HexUint64(1))},
+	} {
+		_, err := txm.WatchExternalTransaction(ctx, w)
+		assert.Regexp(t, "PD012263", err)
+	}
+}
+
+func TestExternalTxWatchInsertFail(t *testing.T) {
+	ctx, txm, done := newTestTransactionManager(t, false, mockEmptyReceiptListeners, func(conf *pldconf.TxManagerConfig, mc *mockComponents) {
+		mc.db.ExpectBegin()
+		mc.db.ExpectExec("INSERT.*external_tx_watches").WillReturnError(fmt.Errorf("pop"))
+		mc.db.ExpectRollback()
+	})
+	defer done()
+
+	_, err := txm.WatchExternalTransaction(ctx, &pldapi.ExternalTransactionWatch{
+		TransactionHash: confutil.P(pldtypes.RandBytes32()),
+	})
+	assert.Regexp(t, "pop", err)
+}
+
+func TestExternalTxWatchIndexerFail(t *testing.T) {
+	ctx, txm, done := newTestTransactionManager(t, false, mockEmptyReceiptListeners, func(conf *pldconf.TxManagerConfig, mc *mockComponents) {
+		mc.db.ExpectBegin()
+		mc.db.ExpectExec("INSERT.*external_tx_watches").WillReturnResult(sqlmock.NewResult(1, 1))
+		mc.db.ExpectCommit()
+		mc.blockIndexer.On("GetIndexedTransactionByHash", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("pop"))
+	})
+	defer done()
+
+	_, err := txm.WatchExternalTransaction(ctx, &pldapi.ExternalTransactionWatch{
+		TransactionHash: confutil.P(pldtypes.RandBytes32()),
+	})
+	assert.Regexp(t, "pop", err)
+}
+
+func TestExternalTxWatchCompleteFail(t *testing.T) {
+	txi := newTestConfirm()
+	ctx, txm, done := newTestTransactionManager(t, false, mockEmptyReceiptListeners, func(conf *pldconf.TxManagerConfig, mc *mockComponents) {
+		mc.db.ExpectBegin()
+		mc.db.ExpectQuery("SELECT.*external_tx_watches").WillReturnRows(sqlmock.NewRows([]string{"id", "tx_hash"}).AddRow(uuid.New(), txi.Hash))
+		mc.db.ExpectExec("UPDATE.*external_tx_watches").WillReturnError(fmt.Errorf("pop"))
+		mc.db.ExpectRollback()
+	})
+	defer done()
+
+	err := txm.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
+		_, err := txm.matchExternalTxWatches(ctx, dbTX, []*blockindexer.IndexedTransactionNotify{txi})
+		return err
+	})
+	assert.Regexp(t, "pop", err)
+}
+
+func TestExternalTxWatchQueryFail(t *testing.T) {
+	ctx, txm, done := newTestTransactionManager(t, false, mockEmptyReceiptListeners, func(conf *pldconf.TxManagerConfig, mc *mockComponents) {
+		mc.db.ExpectBegin()
+		mc.db.ExpectQuery("SELECT.*external_tx_watches").WillReturnError(fmt.Errorf("pop"))
+		mc.db.ExpectRollback()
+		mc.publicTxMgr.On("MatchUpdateConfirmedTransactions", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
+	})
+	defer done()
+
+	err := txm.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
+		return txm.blockIndexerPreCommit(ctx, dbTX, []*pldapi.IndexedBlock{}, []*blockindexer.IndexedTransactionNotify{newTestConfirm()})
+	})
+	assert.Regexp(t, "pop", err)
+}
+
+func TestExternalTxWatchDeleteFail(t *testing.T) {
+	ctx, txm, done := newTestTransactionManager(t, false, mockEmptyReceiptListeners, func(conf *pldconf.TxManagerConfig, mc *mockComponents) {
+		mc.db.ExpectExec("DELETE.*external_tx_watches").WillReturnError(fmt.Errorf("pop"))
+	})
+	defer done()
+
+	err := txm.DeleteExternalTransactionWatch(ctx, uuid.New())
+	assert.Regexp(t, "pop", err)
+}
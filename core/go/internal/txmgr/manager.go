@@ -39,12 +39,13 @@ func NewTXManager(ctx context.Context, conf *pldconf.TxManagerConfig) components
 	tm := &txManager{
 		bgCtx:    ctx,
 		conf:     conf,
-		abiCache: cache.NewCache[pldtypes.Bytes32, *pldapi.StoredABI](&conf.ABI.Cache, &pldconf.TxManagerDefaults.ABI.Cache),
-		txCache:  cache.NewCache[uuid.UUID, *components.ResolvedTransaction](&conf.Transactions.Cache, &pldconf.TxManagerDefaults.Transactions.Cache),
+		abiCache: cache.NewNamedCache[pldtypes.Bytes32, *pldapi.StoredABI]("txmgr.abis", &conf.ABI.Cache, &pldconf.TxManagerDefaults.ABI.Cache),
+		txCache:  cache.NewNamedCache[uuid.UUID, *components.ResolvedTransaction]("txmgr.transactions", &conf.Transactions.Cache, &pldconf.TxManagerDefaults.Transactions.Cache),
 	}
 	tm.receiptsInit()
 	tm.blockchainEventsInit()
 	tm.rpcEventStreams = newRPCEventStreams(tm)
+	tm.rpcDomainEvents = &rpcDomainEvents{es: tm.rpcEventStreams}
 	return tm
 }
 
@@ -62,11 +63,13 @@ type txManager struct {
 	identityResolver    components.IdentityResolver
 	blockIndexer        blockindexer.BlockIndexer
 	rpcEventStreams     *rpcEventStreams
+	rpcDomainEvents     *rpcDomainEvents
 	txCache             cache.Cache[uuid.UUID, *components.ResolvedTransaction]
 	abiCache            cache.Cache[pldtypes.Bytes32, *pldapi.StoredABI]
 	rpcModule           *rpcserver.RPCModule
 	debugRpcModule      *rpcserver.RPCModule
 	lastStateUpdateTime atomic.Int64
+	draining            atomic.Bool
 
 	receiptsRetry                *retry.Retry
 	receiptsReadPageSize         int
@@ -78,9 +81,20 @@ type txManager struct {
 	blockchainEventListenerLock          sync.Mutex
 	blockchainEventListeners             map[string]*blockchainEventListener
 	blockchainEventListenersLoadPageSize int
+
+	signingPools map[string]*signingPool
+
+	schedulerInterval  time.Duration
+	schedulerBatchSize int
+	schedulerCtx       context.Context
+	schedulerCancel    context.CancelFunc
+	schedulerDone      chan struct{}
 }
 
 func (tm *txManager) PreInit(c components.PreInitComponents) (*components.ManagerInitResult, error) {
+	if err := tm.signingPoolsInit(tm.bgCtx); err != nil {
+		return nil, err
+	}
 	tm.buildRPCModule()
 	return &components.ManagerInitResult{
 		RPCModules:       []*rpcserver.RPCModule{tm.rpcModule, tm.debugRpcModule},
@@ -106,10 +120,12 @@ func (tm *txManager) PostInit(c components.AllComponents) error {
 
 func (tm *txManager) Start() error {
 	tm.startReceiptListeners()
+	tm.startScheduler()
 	return nil
 }
 
 func (tm *txManager) Stop() {
+	tm.stopScheduler()
 	tm.rpcEventStreams.stop()
 	tm.stopReceiptListeners()
 	tm.stopBlockchainEventListeners()
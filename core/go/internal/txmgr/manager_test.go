@@ -54,6 +54,11 @@ func newTestTransactionManager(t *testing.T, realDB bool, init ...func(conf *pld
 		ReceiptListeners: pldconf.ReceiptListeners{
 			StateGapCheckInterval: confutil.P("100ms"),
 		},
+		Transactions: pldconf.TransactionsConfig{
+			Scheduler: pldconf.TransactionsSchedulerConfig{
+				Interval: confutil.P("1h"), // tests drive the scheduler directly
+			},
+		},
 	}
 	mc := &mockComponents{
 		c:                componentmocks.NewAllComponents(t),
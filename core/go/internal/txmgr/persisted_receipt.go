@@ -31,6 +31,8 @@ import (
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/query"
+	"github.com/kaleido-io/paladin/toolkit/pkg/rpcserver"
+	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
 
@@ -328,6 +330,12 @@ func (tm *txManager) QueryTransactionReceipts(ctx context.Context, jq *query.Que
 		DefaultSort: "-sequence",
 		Filters:     transactionReceiptFilters,
 		Query:       jq,
+		Finalize: func(q *gorm.DB) *gorm.DB {
+			if tenant := rpcserver.TenantName(ctx); tenant != "" {
+				q = q.Where(`"transaction" IN (?)`, tm.p.DB().Table("transactions").Select("id").Where(`"tenant" = ?`, tenant))
+			}
+			return q
+		},
 		MapResult: func(pt *transactionReceipt) (*pldapi.TransactionReceipt, error) {
 			return &pldapi.TransactionReceipt{
 				ID:                     pt.TransactionID,
@@ -375,6 +383,9 @@ func (tm *txManager) GetTransactionReceiptByIDFull(ctx context.Context, id uuid.
 }
 
 func (tm *txManager) GetDomainReceiptByID(ctx context.Context, domain string, id uuid.UUID) (pldtypes.RawJSON, error) {
+	if err := tm.checkTenantTransaction(ctx, tm.p.NOTX(), id); err != nil {
+		return nil, err
+	}
 	d, err := tm.domainMgr.GetDomainByName(ctx, domain)
 	if err != nil {
 		return nil, err
@@ -383,5 +394,8 @@ func (tm *txManager) GetDomainReceiptByID(ctx context.Context, domain string, id
 }
 
 func (tm *txManager) GetStateReceiptByID(ctx context.Context, id uuid.UUID) (*pldapi.TransactionStates, error) {
+	if err := tm.checkTenantTransaction(ctx, tm.p.NOTX(), id); err != nil {
+		return nil, err
+	}
 	return tm.stateMgr.GetTransactionStates(ctx, tm.p.NOTX(), id)
 }
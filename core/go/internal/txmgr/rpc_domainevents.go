@@ -0,0 +1,184 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package txmgr
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/hyperledger/firefly-signer/pkg/abi"
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/core/internal/msgs"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/rpcclient"
+	"github.com/kaleido-io/paladin/toolkit/pkg/rpcserver"
+)
+
+// rpcDomainEvents provides ptx_subscribeDomainEvents, which is a convenience over ptx_subscribe
+// that builds (and durably stores) a blockchain event listener from the event ABI of a domain,
+// scoped to the contracts the application is interested in.
+//
+// Subscriptions are tracked by rpcEventStreams, so ptx_ack/ptx_nack/ptx_unsubscribe work unchanged.
+type rpcDomainEvents struct {
+	es *rpcEventStreams
+}
+
+func (de *rpcDomainEvents) StartMethod() string {
+	return "ptx_subscribeDomainEvents"
+}
+
+func (de *rpcDomainEvents) LifecycleMethods() []string {
+	return nil
+}
+
+func (de *rpcDomainEvents) HandleLifecycle(ctx context.Context, req *rpcclient.RPCRequest) *rpcclient.RPCResponse {
+	return de.es.HandleLifecycle(ctx, req)
+}
+
+func (de *rpcDomainEvents) HandleStart(ctx context.Context, req *rpcclient.RPCRequest, ctrl rpcserver.RPCAsyncControl) (rpcserver.RPCAsyncInstance, *rpcclient.RPCResponse) {
+	var spec *pldapi.DomainEventSubscription
+	if len(req.Params) >= 1 {
+		if err := json.Unmarshal(req.Params[0].Bytes(), &spec); err != nil {
+			return nil, rpcclient.NewRPCErrorResponse(i18n.WrapError(ctx, err, msgs.MsgTxMgrDomainEventSubscriptionInvalid), req.ID, rpcclient.RPCCodeInvalidRequest)
+		}
+	}
+	if spec == nil {
+		return nil, rpcclient.NewRPCErrorResponse(i18n.NewError(ctx, msgs.MsgTxMgrDomainEventSubscriptionInvalid), req.ID, rpcclient.RPCCodeInvalidRequest)
+	}
+
+	if err := de.es.tm.ensureDomainEventListener(ctx, spec); err != nil {
+		return nil, rpcclient.NewRPCErrorResponse(err, req.ID, rpcclient.RPCCodeInvalidRequest)
+	}
+
+	es := de.es
+	es.subLock.Lock()
+	defer es.subLock.Unlock()
+
+	sub := &listenerSubscription{
+		es:        es,
+		ctrl:      ctrl,
+		acksNacks: make(chan *rpcAckNack, 1),
+		closed:    make(chan struct{}),
+	}
+	es.subs[ctrl.ID()] = sub
+	var err error
+	sub.rrc, err = es.tm.AddBlockchainEventReceiver(ctx, spec.Name, sub)
+	if err != nil {
+		delete(es.subs, ctrl.ID())
+		return nil, rpcclient.NewRPCErrorResponse(err, req.ID, rpcclient.RPCCodeInvalidRequest)
+	}
+
+	return sub, &rpcclient.RPCResponse{
+		JSONRpc: "2.0",
+		ID:      req.ID,
+		Result:  pldtypes.JSONString(ctrl.ID()),
+	}
+}
+
+// ensureDomainEventListener creates the listener behind a domain event subscription the first time it is
+// used, and checks it still matches on subsequent uses - so the existing checkpoint is safe to resume from.
+func (tm *txManager) ensureDomainEventListener(ctx context.Context, spec *pldapi.DomainEventSubscription) error {
+	eventsABI, err := tm.getDomainEventsABI(ctx, spec.Domain, spec.Events)
+	if err != nil {
+		return err
+	}
+
+	if len(spec.Contracts) == 0 {
+		return i18n.NewError(ctx, msgs.MsgTxMgrDomainEventNoContracts, spec.Domain)
+	}
+	listener := &pldapi.BlockchainEventListener{
+		Name:    spec.Name,
+		Options: spec.Options,
+	}
+	for _, addr := range spec.Contracts {
+		if addr == nil {
+			return i18n.NewError(ctx, msgs.MsgTxMgrDomainEventSubscriptionInvalid)
+		}
+		psc, err := tm.domainMgr.GetSmartContractByAddress(ctx, tm.p.NOTX(), *addr)
+		if err != nil {
+			return err
+		}
+		if psc.Domain().Name() != spec.Domain {
+			return i18n.NewError(ctx, msgs.MsgTxMgrDomainEventContractMismatch, addr, psc.Domain().Name(), spec.Domain)
+		}
+		listener.Sources = append(listener.Sources, pldapi.BlockchainEventListenerSource{
+			ABI:     eventsABI,
+			Address: addr,
+		})
+	}
+
+	existing := tm.GetBlockchainEventListener(ctx, spec.Name)
+	if existing == nil {
+		if err := tm.CreateBlockchainEventListener(ctx, listener); err != nil {
+			return err
+		}
+	} else {
+		log.L(ctx).Infof("Resuming domain event subscription '%s' from existing listener checkpoint", spec.Name)
+		existingHash, err := tm.mapEventStream(existing).Sources.Hash(ctx)
+		if err != nil {
+			return err
+		}
+		requestedHash, err := tm.mapEventStream(listener).Sources.Hash(ctx)
+		if err != nil {
+			return err
+		}
+		if *existingHash != *requestedHash {
+			return i18n.NewError(ctx, msgs.MsgTxMgrDomainEventListenerMismatch, spec.Name)
+		}
+	}
+
+	return tm.StartBlockchainEventListener(ctx, spec.Name)
+}
+
+// getDomainEventsABI returns the events a domain declares, optionally filtered to a set of event names
+func (tm *txManager) getDomainEventsABI(ctx context.Context, domainName string, eventNames []string) (abi.ABI, error) {
+	domain, err := tm.domainMgr.GetDomainByName(ctx, domainName)
+	if err != nil {
+		return nil, err
+	}
+
+	var domainABI abi.ABI
+	if eventsJSON := domain.Configuration().AbiEventsJson; eventsJSON != "" {
+		if err := json.Unmarshal([]byte(eventsJSON), &domainABI); err != nil {
+			return nil, err
+		}
+	}
+	eventsByName := make(map[string]*abi.Entry)
+	var eventsABI abi.ABI
+	for _, entry := range domainABI {
+		if entry.Type == abi.Event {
+			eventsByName[entry.Name] = entry
+			eventsABI = append(eventsABI, entry)
+		}
+	}
+	if len(eventsABI) == 0 {
+		return nil, i18n.NewError(ctx, msgs.MsgTxMgrDomainEventsNotDeclared, domainName)
+	}
+	if len(eventNames) == 0 {
+		return eventsABI, nil
+	}
+
+	filtered := make(abi.ABI, len(eventNames))
+	for i, name := range eventNames {
+		filtered[i] = eventsByName[name]
+		if filtered[i] == nil {
+			return nil, i18n.NewError(ctx, msgs.MsgTxMgrDomainEventUnknown, name, domainName)
+		}
+	}
+	return filtered, nil
+}
@@ -0,0 +1,206 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package txmgr
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hyperledger/firefly-common/pkg/wsclient"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/mocks/componentmocks"
+	"github.com/kaleido-io/paladin/core/pkg/blockindexer"
+	"github.com/kaleido-io/paladin/core/pkg/persistence"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/rpcclient"
+	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const testDomainEventsABI = `[
+	{"type":"event","name":"Transfer","inputs":[{"name":"data","type":"bytes"}]},
+	{"type":"event","name":"Mint","inputs":[{"name":"data","type":"bytes"}]},
+	{"type":"error","name":"BadThing","inputs":[]}
+]`
+
+func mockDomainForEvents(t *testing.T, mc *mockComponents, domainName, eventsABI string, contracts ...*pldtypes.EthAddress) {
+	md := componentmocks.NewDomain(t)
+	md.On("Name").Return(domainName).Maybe()
+	md.On("Configuration").Return(&prototk.DomainConfig{AbiEventsJson: eventsABI}).Maybe()
+	mc.domainManager.On("GetDomainByName", mock.Anything, domainName).Return(md, nil).Maybe()
+	for _, addr := range contracts {
+		psc := componentmocks.NewDomainSmartContract(t)
+		psc.On("Domain").Return(md).Maybe()
+		mc.domainManager.On("GetSmartContractByAddress", mock.Anything, mock.Anything, *addr).Return(psc, nil).Maybe()
+	}
+}
+
+func mockEventStreamCreate(mc *mockComponents) {
+	mc.blockIndexer.On("AddEventStream", mock.Anything, mock.Anything, mock.Anything).Return(
+		func(ctx context.Context, dbTX persistence.DBTX, ies *blockindexer.InternalEventStream) (*blockindexer.EventStream, error) {
+			es := *ies.Definition
+			es.ID = uuid.New()
+			return &es, nil
+		}).Maybe()
+	mc.blockIndexer.On("StartEventStream", mock.Anything, mock.Anything).Return(nil).Maybe()
+	mc.blockIndexer.On("StopEventStream", mock.Anything, mock.Anything).Return(nil).Maybe()
+}
+
+func subscribeDomainEvents(t *testing.T, ctx context.Context, wsc wsclient.WSClient, spec any) *rpcclient.RPCResponse {
+	_, req := rpcTestRequest("ptx_subscribeDomainEvents", spec)
+	err := wsc.Send(ctx, req)
+	require.NoError(t, err)
+
+	var res *rpcclient.RPCResponse
+	err = json.Unmarshal(<-wsc.Receive(), &res)
+	require.NoError(t, err)
+	return res
+}
+
+func newTestDomainEventsWSClient(t *testing.T, ctx context.Context, url string) wsclient.WSClient {
+	wscConf, err := rpcclient.ParseWSConfig(ctx, &pldconf.WSClientConfig{
+		HTTPClientConfig: pldconf.HTTPClientConfig{URL: url},
+	})
+	require.NoError(t, err)
+	wsc, err := wsclient.New(ctx, wscConf, nil, nil)
+	require.NoError(t, err)
+	err = wsc.Connect()
+	require.NoError(t, err)
+	return wsc
+}
+
+func TestRPCDomainEventsE2E(t *testing.T) {
+	contract1 := pldtypes.RandAddress()
+	ctx, url, txm, done := newTestTransactionManagerWithWebSocketRPC(t, func(conf *pldconf.TxManagerConfig, mc *mockComponents) {
+		mockDomainForEvents(t, mc, "domain1", testDomainEventsABI, contract1)
+		mockEventStreamCreate(mc)
+	})
+	defer done()
+
+	wsc := newTestDomainEventsWSClient(t, ctx, url)
+	defer wsc.Close()
+
+	spec := &pldapi.DomainEventSubscription{
+		Name:      "sub1",
+		Domain:    "domain1",
+		Contracts: []*pldtypes.EthAddress{contract1},
+		Events:    []string{"Transfer"},
+		Options: pldapi.BlockchainEventListenerOptions{
+			FromBlock: json.RawMessage(`0`),
+		},
+	}
+	res := subscribeDomainEvents(t, ctx, wsc, spec)
+	require.Nil(t, res.Error)
+	subID := res.Result.StringValue()
+
+	// The listener is durable, and only contains the requested event for the contract
+	l := txm.GetBlockchainEventListener(ctx, "sub1")
+	require.NotNil(t, l)
+	require.Len(t, l.Sources, 1)
+	assert.Equal(t, contract1, l.Sources[0].Address)
+	require.Len(t, l.Sources[0].ABI, 1)
+	assert.Equal(t, "Transfer", l.Sources[0].ABI[0].Name)
+	assert.JSONEq(t, `0`, string(l.Options.FromBlock))
+
+	el := txm.blockchainEventListeners["sub1"]
+	go func() {
+		<-wsc.Receive()
+		_, req := rpcTestRequest("ptx_ack", subID)
+		err := wsc.Send(ctx, req)
+		require.NoError(t, err)
+	}()
+	err := el.handleEventBatch(ctx, &blockindexer.EventDeliveryBatch{
+		Events: []*pldapi.EventWithData{
+			{IndexedEvent: &pldapi.IndexedEvent{BlockNumber: 1}},
+		},
+	})
+	require.NoError(t, err)
+
+	_, req := rpcTestRequest("ptx_unsubscribe", subID)
+	err = wsc.Send(ctx, req)
+	require.NoError(t, err)
+	<-wsc.Receive()
+
+	// Subscribing again resumes the same listener
+	res = subscribeDomainEvents(t, ctx, wsc, spec)
+	require.Nil(t, res.Error)
+
+	// ... but only if the contracts/events are unchanged
+	spec.Events = nil
+	res = subscribeDomainEvents(t, ctx, wsc, spec)
+	require.Regexp(t, "PD012262", res.Error.Error())
+}
+
+func TestRPCDomainEventsBadParams(t *testing.T) {
+	ctx, url, _, done := newTestTransactionManagerWithWebSocketRPC(t)
+	defer done()
+
+	wsc := newTestDomainEventsWSClient(t, ctx, url)
+	defer wsc.Close()
+
+	_, req := rpcTestRequest("ptx_subscribeDomainEvents")
+	err := wsc.Send(ctx, req)
+	require.NoError(t, err)
+	var res *rpcclient.RPCResponse
+	err = json.Unmarshal(<-wsc.Receive(), &res)
+	require.NoError(t, err)
+	require.Regexp(t, "PD012257", res.Error.Error())
+
+	res = subscribeDomainEvents(t, ctx, wsc, []string{"wrong"})
+	require.Regexp(t, "PD012257", res.Error.Error())
+}
+
+func TestRPCDomainEventsValidation(t *testing.T) {
+	contract1 := pldtypes.RandAddress()
+	contract2 := pldtypes.RandAddress()
+	ctx, url, _, done := newTestTransactionManagerWithWebSocketRPC(t, func(conf *pldconf.TxManagerConfig, mc *mockComponents) {
+		mockDomainForEvents(t, mc, "domain1", testDomainEventsABI, contract1)
+		mockDomainForEvents(t, mc, "domain2", testDomainEventsABI, contract2)
+		mockDomainForEvents(t, mc, "noevents", "")
+		mockDomainForEvents(t, mc, "badabi", "!wrong")
+		mc.domainManager.On("GetDomainByName", mock.Anything, "unknown").Return(nil, fmt.Errorf("pop")).Maybe()
+		mc.domainManager.On("GetSmartContractByAddress", mock.Anything, mock.Anything, mock.Anything).Return(nil, fmt.Errorf("not found")).Maybe()
+	})
+	defer done()
+
+	wsc := newTestDomainEventsWSClient(t, ctx, url)
+	defer wsc.Close()
+
+	for _, test := range []struct {
+		spec  *pldapi.DomainEventSubscription
+		regex string
+	}{
+		{spec: &pldapi.DomainEventSubscription{Name: "sub1", Domain: "unknown"}, regex: "pop"},
+		{spec: &pldapi.DomainEventSubscription{Name: "sub1", Domain: "badabi"}, regex: "invalid"},
+		{spec: &pldapi.DomainEventSubscription{Name: "sub1", Domain: "noevents"}, regex: "PD012258"},
+		{spec: &pldapi.DomainEventSubscription{Name: "sub1", Domain: "domain1", Events: []string{"BadThing"}}, regex: "PD012259"},
+		{spec: &pldapi.DomainEventSubscription{Name: "sub1", Domain: "domain1"}, regex: "PD012260"},
+		{spec: &pldapi.DomainEventSubscription{Name: "sub1", Domain: "domain1", Contracts: []*pldtypes.EthAddress{nil}}, regex: "PD012257"},
+		{spec: &pldapi.DomainEventSubscription{Name: "sub1", Domain: "domain1", Contracts: []*pldtypes.EthAddress{pldtypes.RandAddress()}}, regex: "not found"},
+		{spec: &pldapi.DomainEventSubscription{Name: "sub1", Domain: "domain1", Contracts: []*pldtypes.EthAddress{contract2}}, regex: "PD012261"},
+		{spec: &pldapi.DomainEventSubscription{Name: "", Domain: "domain1", Contracts: []*pldtypes.EthAddress{contract1}}, regex: "PD020005"},
+	} {
+		res := subscribeDomainEvents(t, ctx, wsc, test.spec)
+		require.NotNil(t, res.Error)
+		assert.Regexp(t, test.regex, res.Error.Error())
+	}
+}
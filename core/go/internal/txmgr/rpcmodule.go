@@ -24,6 +24,7 @@ import (
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/query"
+	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
 	"github.com/kaleido-io/paladin/toolkit/pkg/rpcserver"
 )
 
@@ -35,6 +36,9 @@ func (tm *txManager) buildRPCModule() {
 		Add("ptx_prepareTransactions", tm.rpcPrepareTransactions()).
 		Add("ptx_updateTransaction", tm.rpcUpdateTransaction()).
 		Add("ptx_call", tm.rpcCall()).
+		Add("ptx_dryRunTransaction", tm.rpcDryRunTransaction()).
+		Add("ptx_simulateTransaction", tm.rpcSimulateTransaction()).
+		Add("ptx_estimateBatchImpact", tm.rpcEstimateBatchImpact()).
 		Add("ptx_getTransaction", tm.rpcGetTransaction()).
 		Add("ptx_getTransactionFull", tm.rpcGetTransactionFull()).
 		Add("ptx_getTransactionByIdempotencyKey", tm.rpcGetTransactionByIdempotencyKey()).
@@ -45,27 +49,44 @@ func (tm *txManager) buildRPCModule() {
 		Add("ptx_getTransactionReceiptFull", tm.rpcGetTransactionReceiptFull()).
 		Add("ptx_getDomainReceipt", tm.rpcGetDomainReceipt()).
 		Add("ptx_getStateReceipt", tm.rpcGetStateReceipt()).
+		Add("ptx_exportTransactionEvidence", tm.rpcExportTransactionEvidence()).
 		Add("ptx_queryTransactionReceipts", tm.rpcQueryTransactionReceipts()).
 		Add("ptx_getTransactionDependencies", tm.rpcGetTransactionDependencies()).
 		Add("ptx_queryPublicTransactions", tm.rpcQueryPublicTransactions()).
 		Add("ptx_queryPendingPublicTransactions", tm.rpcQueryPendingPublicTransactions()).
+		Add("ptx_queryPublicTransactionsPage", tm.rpcQueryPublicTransactionsPage()).
 		Add("ptx_getPublicTransactionByNonce", tm.rpcGetPublicTransactionByNonce()).
 		Add("ptx_getPublicTransactionByHash", tm.rpcGetPublicTransactionByHash()).
+		Add("ptx_getPublicTransactionRawSubmission", tm.rpcGetPublicTransactionRawSubmission()).
+		Add("ptx_suspendPublicTransaction", tm.rpcSuspendPublicTransaction()).
+		Add("ptx_resumePublicTransaction", tm.rpcResumePublicTransaction()).
+		Add("ptx_cancelPublicTransaction", tm.rpcCancelPublicTransaction()).
 		Add("ptx_getPreparedTransaction", tm.rpcGetPreparedTransaction()).
 		Add("ptx_queryPreparedTransactions", tm.rpcQueryPreparedTransactions()).
 		Add("ptx_storeABI", tm.rpcStoreABI()).
 		Add("ptx_getStoredABI", tm.rpcGetStoredABI()).
 		Add("ptx_queryStoredABIs", tm.rpcQueryStoredABIs()).
+		Add("ptx_registerContractABI", tm.rpcRegisterContractABI()).
+		Add("ptx_getContractABI", tm.rpcGetContractABI()).
+		Add("ptx_queryContractABIs", tm.rpcQueryContractABIs()).
+		Add("ptx_decodeIndexedTransaction", tm.rpcDecodeIndexedTransaction()).
 		Add("ptx_decodeCall", tm.rpcDecodeCall()).
 		Add("ptx_decodeEvent", tm.rpcDecodeEvent()).
 		Add("ptx_decodeError", tm.rpcDecodeError()).
 		Add("ptx_resolveVerifier", tm.rpcResolveVerifier()).
+		Add("ptx_resolveVerifiers", tm.rpcResolveVerifiers()).
 		Add("ptx_createReceiptListener", tm.rpcCreateReceiptListener()).
 		Add("ptx_queryReceiptListeners", tm.rpcQueryReceiptListeners()).
 		Add("ptx_getReceiptListener", tm.rpcGetReceiptListener()).
 		Add("ptx_startReceiptListener", tm.rpcStartReceiptListener()).
 		Add("ptx_stopReceiptListener", tm.rpcStopReceiptListener()).
 		Add("ptx_deleteReceiptListener", tm.rpcDeleteReceiptListener()).
+		Add("ptx_watchExternalTransaction", tm.rpcWatchExternalTransaction()).
+		Add("ptx_getExternalTransactionWatch", tm.rpcGetExternalTransactionWatch()).
+		Add("ptx_queryExternalTransactionWatches", tm.rpcQueryExternalTransactionWatches()).
+		Add("ptx_deleteExternalTransactionWatch", tm.rpcDeleteExternalTransactionWatch()).
+		Add("ptx_queryTransactionFees", tm.rpcQueryTransactionFees()).
+		Add("ptx_getTransactionFeeReport", tm.rpcGetTransactionFeeReport()).
 		Add("ptx_createBlockchainEventListener", tm.rpcCreateBlockchainEventListener()).
 		Add("ptx_queryBlockchainEventListeners", tm.rpcQueryBlockchainEventListeners()).
 		Add("ptx_getBlockchainEventListener", tm.rpcGetBlockchainEventListener()).
@@ -73,10 +94,20 @@ func (tm *txManager) buildRPCModule() {
 		Add("ptx_stopBlockchainEventListener", tm.rpcStopBlockchainEventListener()).
 		Add("ptx_deleteBlockchainEventListener", tm.rpcDeleteBlockchainEventListener()).
 		Add("ptx_getBlockchainEventListenerStatus", tm.rpcGetBlockchainEventListenerStatus()).
-		AddAsync(tm.rpcEventStreams)
+		Add("ptx_replayBlockchainEvents", tm.rpcReplayBlockchainEvents()).
+		Add("ptx_prepareSwap", tm.rpcPrepareSwap()).
+		Add("ptx_getSwap", tm.rpcGetSwap()).
+		Add("ptx_querySwaps", tm.rpcQuerySwaps()).
+		Add("ptx_approveSwap", tm.rpcApproveSwap()).
+		Add("ptx_executeSwap", tm.rpcExecuteSwap()).
+		Add("ptx_rollbackSwap", tm.rpcRollbackSwap()).
+		AddAsync(tm.rpcEventStreams).
+		AddAsync(tm.rpcDomainEvents)
 
 	tm.debugRpcModule = rpcserver.NewRPCModule("debug").
-		Add("debug_getTransactionStatus", tm.rpcDebugTransactionStatus())
+		Add("debug_getTransactionStatus", tm.rpcDebugTransactionStatus()).
+		Add("debug_getTransactionTimeline", tm.rpcDebugTransactionTimeline()).
+		Add("debug_getLatencyStats", tm.rpcDebugLatencyStats())
 }
 
 func (tm *txManager) rpcSendTransaction() rpcserver.RPCHandler {
@@ -129,6 +160,30 @@ func (tm *txManager) rpcCall() rpcserver.RPCHandler {
 	})
 }
 
+func (tm *txManager) rpcDryRunTransaction() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod1(func(ctx context.Context,
+		tx pldapi.TransactionInput,
+	) (*pldapi.PublicTxDryRun, error) {
+		return tm.dryRunTransactionNewDBTX(ctx, &tx)
+	})
+}
+
+func (tm *txManager) rpcSimulateTransaction() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod1(func(ctx context.Context,
+		tx pldapi.TransactionInput,
+	) (*pldapi.PublicTxSimulation, error) {
+		return tm.simulateTransactionNewDBTX(ctx, &tx)
+	})
+}
+
+func (tm *txManager) rpcEstimateBatchImpact() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod1(func(ctx context.Context,
+		txs []*pldapi.TransactionInput,
+	) (*pldapi.PublicTxBatchImpact, error) {
+		return tm.estimateBatchImpactNewDBTX(ctx, txs)
+	})
+}
+
 func (tm *txManager) rpcGetTransaction() rpcserver.RPCHandler {
 	return rpcserver.RPCMethod1(func(ctx context.Context,
 		id uuid.UUID,
@@ -197,6 +252,14 @@ func (tm *txManager) rpcGetTransactionReceiptFull() rpcserver.RPCHandler {
 	})
 }
 
+func (tm *txManager) rpcExportTransactionEvidence() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod1(func(ctx context.Context,
+		id uuid.UUID,
+	) (*pldapi.SignedTransactionEvidence, error) {
+		return tm.ExportTransactionEvidence(ctx, id)
+	})
+}
+
 func (tm *txManager) rpcGetPreparedTransaction() rpcserver.RPCHandler {
 	return rpcserver.RPCMethod1(func(ctx context.Context,
 		id uuid.UUID,
@@ -238,6 +301,22 @@ func (tm *txManager) rpcQueryTransactionReceipts() rpcserver.RPCHandler {
 	})
 }
 
+func (tm *txManager) rpcQueryTransactionFees() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod1(func(ctx context.Context,
+		query query.QueryJSON,
+	) ([]*pldapi.TransactionFee, error) {
+		return tm.QueryTransactionFees(ctx, tm.p.NOTX(), &query)
+	})
+}
+
+func (tm *txManager) rpcGetTransactionFeeReport() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod1(func(ctx context.Context,
+		input pldapi.TransactionFeeReportInput,
+	) (*pldapi.TransactionFeeReport, error) {
+		return tm.GetTransactionFeeReport(ctx, tm.p.NOTX(), &input)
+	})
+}
+
 func (tm *txManager) rpcQueryPreparedTransactions() rpcserver.RPCHandler {
 	return rpcserver.RPCMethod1(func(ctx context.Context,
 		query query.QueryJSON,
@@ -262,6 +341,14 @@ func (tm *txManager) rpcQueryPendingPublicTransactions() rpcserver.RPCHandler {
 	})
 }
 
+func (tm *txManager) rpcQueryPublicTransactionsPage() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod1(func(ctx context.Context,
+		query pldapi.PublicTxQuery,
+	) (*pldapi.PublicTxPage, error) {
+		return tm.publicTxMgr.QueryPublicTxPage(ctx, tm.p.NOTX(), &query)
+	})
+}
+
 func (tm *txManager) rpcGetPublicTransactionByNonce() rpcserver.RPCHandler {
 	return rpcserver.RPCMethod2(func(ctx context.Context,
 		from pldtypes.EthAddress,
@@ -271,6 +358,33 @@ func (tm *txManager) rpcGetPublicTransactionByNonce() rpcserver.RPCHandler {
 	})
 }
 
+func (tm *txManager) rpcSuspendPublicTransaction() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod2(func(ctx context.Context,
+		from pldtypes.EthAddress,
+		nonce pldtypes.HexUint64,
+	) (bool, error) {
+		return true, tm.publicTxMgr.SuspendTransaction(ctx, from, nonce.Uint64())
+	})
+}
+
+func (tm *txManager) rpcResumePublicTransaction() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod2(func(ctx context.Context,
+		from pldtypes.EthAddress,
+		nonce pldtypes.HexUint64,
+	) (bool, error) {
+		return true, tm.publicTxMgr.ResumeTransaction(ctx, from, nonce.Uint64())
+	})
+}
+
+func (tm *txManager) rpcCancelPublicTransaction() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod2(func(ctx context.Context,
+		from pldtypes.EthAddress,
+		nonce pldtypes.HexUint64,
+	) (bool, error) {
+		return true, tm.publicTxMgr.CancelTransaction(ctx, from, nonce.Uint64())
+	})
+}
+
 func (tm *txManager) rpcGetPublicTransactionByHash() rpcserver.RPCHandler {
 	return rpcserver.RPCMethod1(func(ctx context.Context,
 		hash pldtypes.Bytes32,
@@ -279,6 +393,14 @@ func (tm *txManager) rpcGetPublicTransactionByHash() rpcserver.RPCHandler {
 	})
 }
 
+func (tm *txManager) rpcGetPublicTransactionRawSubmission() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod1(func(ctx context.Context,
+		hash pldtypes.Bytes32,
+	) (*pldapi.PublicTxRawSubmission, error) {
+		return tm.GetPublicTransactionRawSubmission(ctx, hash)
+	})
+}
+
 func (tm *txManager) rpcStoreABI() rpcserver.RPCHandler {
 	return rpcserver.RPCMethod1(func(ctx context.Context,
 		a abi.ABI,
@@ -303,6 +425,39 @@ func (tm *txManager) rpcQueryStoredABIs() rpcserver.RPCHandler {
 	})
 }
 
+func (tm *txManager) rpcRegisterContractABI() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod1(func(ctx context.Context,
+		contractABI pldapi.ContractABIInput,
+	) (*pldapi.ContractABI, error) {
+		return tm.RegisterContractABI(ctx, &contractABI)
+	})
+}
+
+func (tm *txManager) rpcGetContractABI() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod1(func(ctx context.Context,
+		address pldtypes.EthAddress,
+	) (*pldapi.ContractABI, error) {
+		return tm.GetContractABI(ctx, tm.p.NOTX(), address)
+	})
+}
+
+func (tm *txManager) rpcQueryContractABIs() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod1(func(ctx context.Context,
+		query query.QueryJSON,
+	) ([]*pldapi.ContractABI, error) {
+		return tm.QueryContractABIs(ctx, &query)
+	})
+}
+
+func (tm *txManager) rpcDecodeIndexedTransaction() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod2(func(ctx context.Context,
+		txHash pldtypes.Bytes32,
+		dataFormat pldtypes.JSONFormatOptions,
+	) (*pldapi.DecodedTransaction, error) {
+		return tm.DecodeIndexedTransaction(ctx, txHash, dataFormat)
+	})
+}
+
 func (tm *txManager) rpcResolveVerifier() rpcserver.RPCHandler {
 	return rpcserver.RPCMethod3(func(ctx context.Context,
 		lookup string,
@@ -313,6 +468,33 @@ func (tm *txManager) rpcResolveVerifier() rpcserver.RPCHandler {
 	})
 }
 
+func (tm *txManager) rpcResolveVerifiers() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod1(func(ctx context.Context,
+		lookups []*pldapi.VerifierLookup,
+	) ([]*pldapi.ResolvedVerifier, error) {
+		requests := make([]*prototk.ResolveVerifierRequest, len(lookups))
+		for i, l := range lookups {
+			requests[i] = &prototk.ResolveVerifierRequest{
+				Lookup:       l.Lookup,
+				Algorithm:    l.Algorithm,
+				VerifierType: l.VerifierType,
+			}
+		}
+		resolved, err := tm.identityResolver.ResolveVerifiers(ctx, requests)
+		if err != nil {
+			return nil, err
+		}
+		verifiers := make([]*pldapi.ResolvedVerifier, len(resolved))
+		for i, r := range resolved {
+			verifiers[i] = &pldapi.ResolvedVerifier{
+				VerifierLookup: lookups[i],
+				Verifier:       r.Verifier,
+			}
+		}
+		return verifiers, nil
+	})
+}
+
 func (tm *txManager) rpcDebugTransactionStatus() rpcserver.RPCHandler {
 	return rpcserver.RPCMethod2(func(ctx context.Context,
 		contractAddress string,
@@ -322,6 +504,20 @@ func (tm *txManager) rpcDebugTransactionStatus() rpcserver.RPCHandler {
 	})
 }
 
+func (tm *txManager) rpcDebugTransactionTimeline() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod1(func(ctx context.Context,
+		id uuid.UUID,
+	) ([]*pldapi.PublicTxTimeline, error) {
+		return tm.publicTxMgr.GetTransactionTimelines(ctx, tm.p.NOTX(), id)
+	})
+}
+
+func (tm *txManager) rpcDebugLatencyStats() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod0(func(ctx context.Context) ([]*pldapi.PublicTxLatencyStats, error) {
+		return tm.publicTxMgr.GetLatencyStats(ctx), nil
+	})
+}
+
 func (tm *txManager) rpcDecodeError() rpcserver.RPCHandler {
 	return rpcserver.RPCMethod2(func(ctx context.Context,
 		revertError pldtypes.HexBytes,
@@ -399,6 +595,86 @@ func (tm *txManager) rpcDeleteReceiptListener() rpcserver.RPCHandler {
 	})
 }
 
+func (tm *txManager) rpcWatchExternalTransaction() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod1(func(ctx context.Context,
+		watch *pldapi.ExternalTransactionWatch,
+	) (*pldapi.ExternalTransactionWatch, error) {
+		return tm.WatchExternalTransaction(ctx, watch)
+	})
+}
+
+func (tm *txManager) rpcGetExternalTransactionWatch() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod1(func(ctx context.Context,
+		id uuid.UUID,
+	) (*pldapi.ExternalTransactionWatch, error) {
+		return tm.GetExternalTransactionWatch(ctx, id)
+	})
+}
+
+func (tm *txManager) rpcQueryExternalTransactionWatches() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod1(func(ctx context.Context,
+		query query.QueryJSON,
+	) ([]*pldapi.ExternalTransactionWatch, error) {
+		return tm.QueryExternalTransactionWatches(ctx, tm.p.NOTX(), &query)
+	})
+}
+
+func (tm *txManager) rpcDeleteExternalTransactionWatch() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod1(func(ctx context.Context,
+		id uuid.UUID,
+	) (bool, error) {
+		return true, tm.DeleteExternalTransactionWatch(ctx, id)
+	})
+}
+
+func (tm *txManager) rpcPrepareSwap() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod1(func(ctx context.Context,
+		swap *pldapi.SwapInput,
+	) (*pldapi.Swap, error) {
+		return tm.PrepareSwap(ctx, swap)
+	})
+}
+
+func (tm *txManager) rpcGetSwap() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod1(func(ctx context.Context,
+		id uuid.UUID,
+	) (*pldapi.Swap, error) {
+		return tm.GetSwap(ctx, id)
+	})
+}
+
+func (tm *txManager) rpcQuerySwaps() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod1(func(ctx context.Context,
+		query query.QueryJSON,
+	) ([]*pldapi.Swap, error) {
+		return tm.QuerySwaps(ctx, tm.p.NOTX(), &query)
+	})
+}
+
+func (tm *txManager) rpcApproveSwap() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod1(func(ctx context.Context,
+		id uuid.UUID,
+	) (*pldapi.Swap, error) {
+		return tm.ApproveSwap(ctx, id)
+	})
+}
+
+func (tm *txManager) rpcExecuteSwap() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod1(func(ctx context.Context,
+		id uuid.UUID,
+	) (*pldapi.Swap, error) {
+		return tm.ExecuteSwap(ctx, id)
+	})
+}
+
+func (tm *txManager) rpcRollbackSwap() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod1(func(ctx context.Context,
+		id uuid.UUID,
+	) (*pldapi.Swap, error) {
+		return tm.RollbackSwap(ctx, id)
+	})
+}
+
 func (tm *txManager) rpcCreateBlockchainEventListener() rpcserver.RPCHandler {
 	return rpcserver.RPCMethod1(func(ctx context.Context,
 		listener *pldapi.BlockchainEventListener,
@@ -455,3 +731,12 @@ func (tm *txManager) rpcGetBlockchainEventListenerStatus() rpcserver.RPCHandler
 		return tm.GetBlockchainEventListenerStatus(ctx, name)
 	})
 }
+
+func (tm *txManager) rpcReplayBlockchainEvents() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod2(func(ctx context.Context,
+		name string,
+		txID uuid.UUID,
+	) (*pldapi.IndexerEventReplayResult, error) {
+		return tm.ReplayBlockchainEvents(ctx, name, txID)
+	})
+}
@@ -37,6 +37,7 @@ import (
 	"github.com/kaleido-io/paladin/sdk/go/pkg/query"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/rpcclient"
 	"github.com/kaleido-io/paladin/toolkit/pkg/algorithms"
+	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
 	"github.com/kaleido-io/paladin/toolkit/pkg/rpcserver"
 	"github.com/kaleido-io/paladin/toolkit/pkg/verifiers"
 	"github.com/stretchr/testify/assert"
@@ -427,9 +428,17 @@ func TestPublicTransactionPassthroughQueries(t *testing.T) {
 	}
 	var mockQuery func(jq *query.QueryJSON) ([]*pldapi.PublicTxWithBinding, error)
 	var mockGetByHash func(hash pldtypes.Bytes32) (*pldapi.PublicTxWithBinding, error)
+	var mockGetRawSubmission func(hash pldtypes.Bytes32) (*pldapi.PublicTxRawSubmission, error)
 	ctx, url, _, done := newTestTransactionManagerWithRPC(t,
 		mockQueryPublicTxWithBindings(func(jq *query.QueryJSON) ([]*pldapi.PublicTxWithBinding, error) { return mockQuery(jq) }),
 		mockGetPublicTransactionForHash(func(hash pldtypes.Bytes32) (*pldapi.PublicTxWithBinding, error) { return mockGetByHash(hash) }),
+		func(conf *pldconf.TxManagerConfig, mc *mockComponents) {
+			mqb := mc.publicTxMgr.On("GetRawSubmission", mock.Anything, mock.Anything, mock.Anything)
+			mqb.Run(func(args mock.Arguments) {
+				result, err := mockGetRawSubmission(args[2].(pldtypes.Bytes32))
+				mqb.Return(result, err)
+			})
+		},
 	)
 	defer done()
 
@@ -496,6 +505,53 @@ func TestPublicTransactionPassthroughQueries(t *testing.T) {
 	err = rpcClient.CallRPC(ctx, &txn, "ptx_getPublicTransactionByHash", txHash)
 	require.NoError(t, err)
 	assert.Equal(t, sampleTxns[0], txn)
+
+	// Raw submission by hash
+	rawSub := &pldapi.PublicTxRawSubmission{
+		PublicTxSubmission: pldapi.PublicTxSubmission{
+			From:  tx.From,
+			Nonce: *tx.Nonce,
+			PublicTxSubmissionData: pldapi.PublicTxSubmissionData{
+				TransactionHash: txHash,
+			},
+		},
+		RawTransaction: pldtypes.RandBytes(100),
+	}
+	mockGetRawSubmission = func(hash pldtypes.Bytes32) (*pldapi.PublicTxRawSubmission, error) {
+		assert.Equal(t, txHash, hash)
+		return rawSub, nil
+	}
+	var rawSubRes *pldapi.PublicTxRawSubmission
+	err = rpcClient.CallRPC(ctx, &rawSubRes, "ptx_getPublicTransactionRawSubmission", txHash)
+	require.NoError(t, err)
+	assert.Equal(t, rawSub, rawSubRes)
+}
+
+func TestQueryPublicTransactionsPageRPC(t *testing.T) {
+	page := &pldapi.PublicTxPage{
+		Items: []*pldapi.PublicTxWithBinding{{PublicTx: &pldapi.PublicTx{LocalID: confutil.P(uint64(12345))}}},
+		Next:  "cursor1",
+	}
+	ctx, url, _, done := newTestTransactionManagerWithRPC(t, func(tmc *pldconf.TxManagerConfig, mc *mockComponents) {
+		mc.publicTxMgr.On("QueryPublicTxPage", mock.Anything, mock.Anything, mock.MatchedBy(func(ptq *pldapi.PublicTxQuery) bool {
+			return *ptq.Limit == 10 && *ptq.PendingLongerThan == "5m" && ptq.After == "cursor0" && ptq.Sort[0] == "created"
+		})).Return(page, nil)
+	})
+	defer done()
+
+	rpcClient, err := rpcclient.NewHTTPClient(ctx, &pldconf.HTTPClientConfig{URL: url})
+	require.NoError(t, err)
+
+	var result *pldapi.PublicTxPage
+	err = rpcClient.CallRPC(ctx, &result, "ptx_queryPublicTransactionsPage", map[string]any{
+		"limit":             10,
+		"sort":              []string{"created"},
+		"eq":                []map[string]any{{"field": "status", "value": "pending"}},
+		"pendingLongerThan": "5m",
+		"after":             "cursor0",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, page, result)
 }
 
 func TestDetailedReceiptRPCsNotFound(t *testing.T) {
@@ -528,6 +584,10 @@ func TestDetailedReceiptRPCsNotFound(t *testing.T) {
 	require.NoError(t, err)
 	assert.JSONEq(t, `{}`, domainReceipt.Pretty())
 
+	var evidence *pldapi.SignedTransactionEvidence
+	err = rpcClient.CallRPC(ctx, &evidence, "ptx_exportTransactionEvidence", uuid.New())
+	assert.Regexp(t, "PD012254", err)
+
 }
 
 func TestIdentityResolvePassthroughQueries(t *testing.T) {
@@ -536,6 +596,13 @@ func TestIdentityResolvePassthroughQueries(t *testing.T) {
 		func(tmc *pldconf.TxManagerConfig, mc *mockComponents) {
 			mc.identityResolver.On("ResolveVerifier", mock.Anything, "lookup1", algorithms.ECDSA_SECP256K1, verifiers.ETH_ADDRESS).
 				Return("0x6f4b36e614cf32a20f4c2146d9db4c59a699ea65", nil)
+			mc.identityResolver.On("ResolveVerifiers", mock.Anything, []*prototk.ResolveVerifierRequest{
+				{Lookup: "lookup1", Algorithm: algorithms.ECDSA_SECP256K1, VerifierType: verifiers.ETH_ADDRESS},
+				{Lookup: "lookup2@node2", Algorithm: algorithms.ECDSA_SECP256K1, VerifierType: verifiers.ETH_ADDRESS},
+			}).Return([]*prototk.ResolvedVerifier{
+				{Lookup: "lookup1", Algorithm: algorithms.ECDSA_SECP256K1, VerifierType: verifiers.ETH_ADDRESS, Verifier: "0x6f4b36e614cf32a20f4c2146d9db4c59a699ea65"},
+				{Lookup: "lookup2@node2", Algorithm: algorithms.ECDSA_SECP256K1, VerifierType: verifiers.ETH_ADDRESS, Verifier: "0x2a1c0ff2fe76bd5c1a1c7b3d7a4ab5c0a8c2d9e1"},
+			}, nil)
 		},
 	)
 	defer done()
@@ -548,6 +615,16 @@ func TestIdentityResolvePassthroughQueries(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, "0x6f4b36e614cf32a20f4c2146d9db4c59a699ea65", verifier)
 
+	var resolved []*pldapi.ResolvedVerifier
+	err = rpcClient.CallRPC(ctx, &resolved, "ptx_resolveVerifiers", []*pldapi.VerifierLookup{
+		{Lookup: "lookup1", Algorithm: algorithms.ECDSA_SECP256K1, VerifierType: verifiers.ETH_ADDRESS},
+		{Lookup: "lookup2@node2", Algorithm: algorithms.ECDSA_SECP256K1, VerifierType: verifiers.ETH_ADDRESS},
+	})
+	require.NoError(t, err)
+	require.Len(t, resolved, 2)
+	assert.Equal(t, "lookup2@node2", resolved[1].Lookup)
+	assert.Equal(t, "0x2a1c0ff2fe76bd5c1a1c7b3d7a4ab5c0a8c2d9e1", resolved[1].Verifier)
+
 }
 
 func TestDebugTransactionStatus(t *testing.T) {
@@ -580,6 +657,45 @@ func TestDebugTransactionStatus(t *testing.T) {
 
 }
 
+func TestDebugLatencyTracing(t *testing.T) {
+
+	txID := uuid.New()
+
+	ctx, url, _, done := newTestTransactionManagerWithRPC(t,
+		func(tmc *pldconf.TxManagerConfig, mc *mockComponents) {
+			mc.publicTxMgr.On("GetTransactionTimelines", mock.Anything, mock.Anything, txID).Return([]*pldapi.PublicTxTimeline{
+				{
+					LocalID: 12345,
+					Stages: []*pldapi.PublicTxStageTime{
+						{Stage: pldapi.PublicTxStageReceived.Enum(), Time: pldtypes.TimestampNow()},
+					},
+				},
+			}, nil)
+			mc.publicTxMgr.On("GetLatencyStats", mock.Anything).Return([]*pldapi.PublicTxLatencyStats{
+				{Stage: pldapi.PublicTxStageConfirmed.Enum(), SinceStart: pldapi.PublicTxLatencyPercentiles{Count: 1, P99MS: 100}},
+			})
+		},
+	)
+	defer done()
+
+	rpcClient, err := rpcclient.NewHTTPClient(ctx, &pldconf.HTTPClientConfig{URL: url})
+	require.NoError(t, err)
+
+	var timelines []*pldapi.PublicTxTimeline
+	err = rpcClient.CallRPC(ctx, &timelines, "debug_getTransactionTimeline", txID)
+	require.NoError(t, err)
+	require.Len(t, timelines, 1)
+	assert.Equal(t, uint64(12345), timelines[0].LocalID)
+	assert.Equal(t, pldapi.PublicTxStageReceived, timelines[0].Stages[0].Stage.V())
+
+	var stats []*pldapi.PublicTxLatencyStats
+	err = rpcClient.CallRPC(ctx, &stats, "debug_getLatencyStats")
+	require.NoError(t, err)
+	require.Len(t, stats, 1)
+	assert.Equal(t, int64(100), stats[0].SinceStart.P99MS)
+
+}
+
 func TestQueryPreparedTransactionsNotFound(t *testing.T) {
 
 	ctx, url, _, done := newTestTransactionManagerWithRPC(t)
@@ -653,6 +769,48 @@ func TestPrepareTransactions(t *testing.T) {
 
 }
 
+func TestSubmitWhileDraining(t *testing.T) {
+
+	ctx, url, txm, done := newTestTransactionManagerWithRPC(t, mockDomainContractResolve(t, "domain1"), func(tmc *pldconf.TxManagerConfig, mc *mockComponents) {
+		mc.privateTxMgr.On("HandleNewTx", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	})
+	defer done()
+
+	rpcClient, err := rpcclient.NewHTTPClient(ctx, &pldconf.HTTPClientConfig{URL: url})
+	require.NoError(t, err)
+
+	tx := &pldapi.TransactionInput{
+		ABI: abi.ABI{{Type: abi.Function, Name: "doStuff"}},
+		TransactionBase: pldapi.TransactionBase{
+			Type:   pldapi.TransactionTypePrivate.Enum(),
+			Domain: "domain1",
+			From:   "sender1",
+			To:     pldtypes.RandAddress(),
+			Data:   pldtypes.RawJSON(`[]`),
+		},
+	}
+
+	txm.SetDraining(ctx, true)
+
+	var txID *uuid.UUID
+	err = rpcClient.CallRPC(ctx, &txID, "ptx_sendTransaction", tx)
+	assert.Regexp(t, "PD012274", err)
+	err = rpcClient.CallRPC(ctx, &txID, "ptx_prepareTransaction", tx)
+	assert.Regexp(t, "PD012274", err)
+
+	// Internal submissions are still accepted, to complete the work in flight
+	err = txm.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
+		_, err := txm.SendTransactions(ctx, dbTX, tx)
+		return err
+	})
+	require.NoError(t, err)
+
+	txm.SetDraining(ctx, false)
+	err = rpcClient.CallRPC(ctx, &txID, "ptx_sendTransaction", tx)
+	require.NoError(t, err)
+
+}
+
 func TestRPCReceiptListenersCRUDRealDB(t *testing.T) {
 	ctx, url, txm, done := newTestTransactionManagerWithRPC(t)
 	defer done()
@@ -838,3 +996,204 @@ func TestRPCBlockchainEventListenersCRUD(t *testing.T) {
 	require.NoError(t, err)
 	assert.Nil(t, l)
 }
+
+func TestSuspendResumePublicTransactionRPCs(t *testing.T) {
+	from := *pldtypes.RandAddress()
+	ctx, url, _, done := newTestTransactionManagerWithRPC(t, func(conf *pldconf.TxManagerConfig, mc *mockComponents) {
+		mc.publicTxMgr.On("SuspendTransaction", mock.Anything, from, uint64(10)).Return(nil).Once()
+		mc.publicTxMgr.On("SuspendTransaction", mock.Anything, from, uint64(11)).Return(fmt.Errorf("pop")).Once()
+		mc.publicTxMgr.On("ResumeTransaction", mock.Anything, from, uint64(10)).Return(nil).Once()
+		mc.publicTxMgr.On("CancelTransaction", mock.Anything, from, uint64(10)).Return(nil).Once()
+		mc.publicTxMgr.On("CancelTransaction", mock.Anything, from, uint64(11)).Return(fmt.Errorf("pop")).Once()
+	})
+	defer done()
+
+	rpcClient, err := rpcclient.NewHTTPClient(ctx, &pldconf.HTTPClientConfig{URL: url})
+	require.NoError(t, err)
+
+	var success bool
+	err = rpcClient.CallRPC(ctx, &success, "ptx_suspendPublicTransaction", from, pldtypes.HexUint64(10))
+	require.NoError(t, err)
+	assert.True(t, success)
+
+	err = rpcClient.CallRPC(ctx, &success, "ptx_suspendPublicTransaction", from, pldtypes.HexUint64(11))
+	assert.Regexp(t, "pop", err)
+
+	err = rpcClient.CallRPC(ctx, &success, "ptx_resumePublicTransaction", from, pldtypes.HexUint64(10))
+	require.NoError(t, err)
+	assert.True(t, success)
+
+	err = rpcClient.CallRPC(ctx, &success, "ptx_cancelPublicTransaction", from, pldtypes.HexUint64(10))
+	require.NoError(t, err)
+	assert.True(t, success)
+
+	err = rpcClient.CallRPC(ctx, &success, "ptx_cancelPublicTransaction", from, pldtypes.HexUint64(11))
+	assert.Regexp(t, "pop", err)
+}
+
+func TestDryRunTransactionRPC(t *testing.T) {
+	senderAddr := pldtypes.RandAddress()
+	contractAddr := pldtypes.RandAddress()
+	exampleABI := abi.ABI{{Type: abi.Function, Name: "doIt"}}
+	dryRun := &pldapi.PublicTxDryRun{
+		From:            *senderAddr,
+		To:              contractAddr,
+		Nonce:           10,
+		TransactionHash: pldtypes.RandBytes32(),
+		RawTransaction:  pldtypes.RandBytes(100),
+	}
+	ctx, url, _, done := newTestTransactionManagerWithRPC(t, func(conf *pldconf.TxManagerConfig, mc *mockComponents) {
+		mockResolveKey(t, mc, "sender1", senderAddr)
+		mc.publicTxMgr.On("DryRunTransaction", mock.Anything, mock.Anything, mock.MatchedBy(func(ptx *components.PublicTxSubmission) bool {
+			return ptx.From.Equals(senderAddr) &&
+				ptx.To.Equals(contractAddr) &&
+				ptx.Data.Equals(pldtypes.HexBytes(exampleABI[0].FunctionSelectorBytes())) &&
+				len(ptx.Bindings) == 0
+		})).Return(dryRun, nil).Once()
+		mc.publicTxMgr.On("DryRunTransaction", mock.Anything, mock.Anything, mock.Anything).Return(nil, fmt.Errorf("pop")).Once()
+	})
+	defer done()
+
+	rpcClient, err := rpcclient.NewHTTPClient(ctx, &pldconf.HTTPClientConfig{URL: url})
+	require.NoError(t, err)
+
+	tx := &pldapi.TransactionInput{
+		TransactionBase: pldapi.TransactionBase{
+			Type:     pldapi.TransactionTypePublic.Enum(),
+			Function: "doIt",
+			From:     "sender1",
+			To:       contractAddr,
+			Data:     pldtypes.RawJSON(`[]`),
+		},
+		ABI: exampleABI,
+	}
+
+	var result *pldapi.PublicTxDryRun
+	err = rpcClient.CallRPC(ctx, &result, "ptx_dryRunTransaction", tx)
+	require.NoError(t, err)
+	assert.Equal(t, dryRun, result)
+
+	// Nothing is stored for the transaction
+	var txns []*pldapi.Transaction
+	err = rpcClient.CallRPC(ctx, &txns, "ptx_queryTransactions", query.NewQueryBuilder().Limit(1).Query())
+	require.NoError(t, err)
+	assert.Empty(t, txns)
+
+	// The DB transaction is rolled back, so the ABI is not stored either
+	var abis []*pldapi.StoredABI
+	err = rpcClient.CallRPC(ctx, &abis, "ptx_queryStoredABIs", query.NewQueryBuilder().Limit(1).Query())
+	require.NoError(t, err)
+	assert.Empty(t, abis)
+
+	err = rpcClient.CallRPC(ctx, &result, "ptx_dryRunTransaction", tx)
+	assert.Regexp(t, "pop", err)
+
+	tx.Type = pldapi.TransactionTypePrivate.Enum()
+	err = rpcClient.CallRPC(ctx, &result, "ptx_dryRunTransaction", tx)
+	assert.Regexp(t, "PD012253", err)
+}
+
+func TestSimulateTransactionRPC(t *testing.T) {
+	senderAddr := pldtypes.RandAddress()
+	contractAddr := pldtypes.RandAddress()
+	exampleABI := abi.ABI{{Type: abi.Function, Name: "doIt"}}
+	sim := &pldapi.PublicTxSimulation{
+		Traced:       true,
+		Reverted:     true,
+		RevertData:   pldtypes.RandBytes(4),
+		RevertReason: "MyError()",
+		Call: &pldapi.PublicTxSimulatedCall{
+			Type:  "CALL",
+			From:  *senderAddr,
+			To:    contractAddr,
+			Error: "execution reverted",
+			Calls: []*pldapi.PublicTxSimulatedCall{{Type: "STATICCALL", From: *contractAddr}},
+		},
+		StorageChanges: []*pldapi.PublicTxStorageChange{{Address: *contractAddr, Slot: pldtypes.RandBytes32(), After: pldtypes.RandBytes32()}},
+	}
+	ctx, url, _, done := newTestTransactionManagerWithRPC(t, func(conf *pldconf.TxManagerConfig, mc *mockComponents) {
+		mockResolveKey(t, mc, "sender1", senderAddr)
+		mc.publicTxMgr.On("SimulateTransaction", mock.Anything, mock.Anything, mock.MatchedBy(func(ptx *components.PublicTxSubmission) bool {
+			return ptx.From.Equals(senderAddr) &&
+				ptx.To.Equals(contractAddr) &&
+				ptx.Data.Equals(pldtypes.HexBytes(exampleABI[0].FunctionSelectorBytes()))
+		})).Return(sim, nil).Once()
+		mc.publicTxMgr.On("SimulateTransaction", mock.Anything, mock.Anything, mock.Anything).Return(nil, fmt.Errorf("pop")).Once()
+	})
+	defer done()
+
+	rpcClient, err := rpcclient.NewHTTPClient(ctx, &pldconf.HTTPClientConfig{URL: url})
+	require.NoError(t, err)
+
+	tx := &pldapi.TransactionInput{
+		TransactionBase: pldapi.TransactionBase{
+			Type:     pldapi.TransactionTypePublic.Enum(),
+			Function: "doIt",
+			From:     "sender1",
+			To:       contractAddr,
+			Data:     pldtypes.RawJSON(`[]`),
+		},
+		ABI: exampleABI,
+	}
+
+	var result *pldapi.PublicTxSimulation
+	err = rpcClient.CallRPC(ctx, &result, "ptx_simulateTransaction", tx)
+	require.NoError(t, err)
+	assert.Equal(t, sim, result)
+
+	err = rpcClient.CallRPC(ctx, &result, "ptx_simulateTransaction", tx)
+	assert.Regexp(t, "pop", err)
+
+	tx.Type = pldapi.TransactionTypePrivate.Enum()
+	err = rpcClient.CallRPC(ctx, &result, "ptx_simulateTransaction", tx)
+	assert.Regexp(t, "PD012253", err)
+}
+
+func TestEstimateBatchImpactRPC(t *testing.T) {
+	senderAddr := pldtypes.RandAddress()
+	contractAddr := pldtypes.RandAddress()
+	exampleABI := abi.ABI{{Type: abi.Function, Name: "doIt"}}
+	impact := &pldapi.PublicTxBatchImpact{
+		Transactions: []*pldapi.PublicTxImpact{
+			{From: *senderAddr, To: contractAddr, Nonce: 5, GasCost: pldtypes.Uint64ToUint256(1000)},
+			{From: *senderAddr, To: contractAddr, Nonce: 6, GasCost: pldtypes.Uint64ToUint256(1000)},
+		},
+		Signers: []*pldapi.PublicTxSignerImpact{
+			{Signer: *senderAddr, Transactions: 2, FirstNonce: 5, NextNonce: 7, GasCost: pldtypes.Uint64ToUint256(2000),
+				Shortfall: pldtypes.Uint64ToUint256(500), AutoFuel: true, AutoFuelAmount: pldtypes.Uint64ToUint256(500)},
+		},
+	}
+	ctx, url, _, done := newTestTransactionManagerWithRPC(t, func(conf *pldconf.TxManagerConfig, mc *mockComponents) {
+		mockResolveKey(t, mc, "sender1", senderAddr)
+		mc.publicTxMgr.On("EstimateBatchImpact", mock.Anything, mock.Anything, mock.MatchedBy(func(ptxs []*components.PublicTxSubmission) bool {
+			return len(ptxs) == 2 &&
+				ptxs[0].From.Equals(senderAddr) &&
+				ptxs[1].To.Equals(contractAddr)
+		})).Return(impact, nil).Once()
+	})
+	defer done()
+
+	rpcClient, err := rpcclient.NewHTTPClient(ctx, &pldconf.HTTPClientConfig{URL: url})
+	require.NoError(t, err)
+
+	tx := &pldapi.TransactionInput{
+		TransactionBase: pldapi.TransactionBase{
+			Type:     pldapi.TransactionTypePublic.Enum(),
+			Function: "doIt",
+			From:     "sender1",
+			To:       contractAddr,
+			Data:     pldtypes.RawJSON(`[]`),
+		},
+		ABI: exampleABI,
+	}
+
+	var result *pldapi.PublicTxBatchImpact
+	err = rpcClient.CallRPC(ctx, &result, "ptx_estimateBatchImpact", []*pldapi.TransactionInput{tx, tx})
+	require.NoError(t, err)
+	assert.Equal(t, impact, result)
+
+	privateTX := *tx
+	privateTX.Type = pldapi.TransactionTypePrivate.Enum()
+	err = rpcClient.CallRPC(ctx, &result, "ptx_estimateBatchImpact", []*pldapi.TransactionInput{tx, &privateTX})
+	assert.Regexp(t, "PD012253", err)
+}
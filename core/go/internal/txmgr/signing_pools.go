@@ -0,0 +1,59 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package txmgr
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/core/internal/msgs"
+)
+
+type signingPool struct {
+	members []string
+	next    atomic.Uint64
+}
+
+func (tm *txManager) signingPoolsInit(ctx context.Context) error {
+	tm.signingPools = make(map[string]*signingPool, len(tm.conf.SigningPools))
+	for _, poolConf := range tm.conf.SigningPools {
+		if poolConf.Identity == "" || poolConf.Size < 1 {
+			return i18n.NewError(ctx, msgs.MsgTxMgrSigningPoolInvalid, poolConf.Identity)
+		}
+		if tm.signingPools[poolConf.Identity] != nil {
+			return i18n.NewError(ctx, msgs.MsgTxMgrSigningPoolDuplicate, poolConf.Identity)
+		}
+		pool := &signingPool{members: make([]string, poolConf.Size)}
+		for i := range pool.members {
+			pool.members[i] = fmt.Sprintf("%s.%d", poolConf.Identity, i)
+		}
+		tm.signingPools[poolConf.Identity] = pool
+	}
+	return nil
+}
+
+// Returns the identifier of the key to sign with, for a public transaction submitted from the supplied
+// local identifier. For a signing pool this is the next member in rotation - each member address gets its
+// own nonce stream and orchestrator in the public transaction manager, so they are processed in parallel.
+func (tm *txManager) signerForSubmission(identifier string) string {
+	pool := tm.signingPools[identifier]
+	if pool == nil {
+		return identifier
+	}
+	return pool.members[(pool.next.Add(1)-1)%uint64(len(pool.members))]
+}
@@ -0,0 +1,130 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package txmgr
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hyperledger/firefly-signer/pkg/abi"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/internal/components"
+	"github.com/kaleido-io/paladin/core/pkg/persistence"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/query"
+	"github.com/kaleido-io/paladin/toolkit/pkg/algorithms"
+	"github.com/kaleido-io/paladin/toolkit/pkg/verifiers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSigningPoolSubmissionsRotate(t *testing.T) {
+	poolAddrs := []*pldtypes.EthAddress{pldtypes.RandAddress(), pldtypes.RandAddress()}
+	var submittedFrom []pldtypes.EthAddress
+	var updatedFrom *pldtypes.EthAddress
+	ctx, txm, done := newTestTransactionManager(t, true, func(conf *pldconf.TxManagerConfig, mc *mockComponents) {
+		conf.SigningPools = []*pldconf.SigningPoolConfig{{Identity: "pool1", Size: 2}}
+		kr := mockKeyResolver(t, mc)
+		for i, addr := range poolAddrs {
+			kr.On("ResolveKey", mock.Anything, fmt.Sprintf("pool1.%d", i), algorithms.ECDSA_SECP256K1, verifiers.ETH_ADDRESS).
+				Return(&pldapi.KeyMappingAndVerifier{Verifier: &pldapi.KeyVerifier{Verifier: addr.String()}}, nil)
+		}
+		mc.publicTxMgr.On("ValidateTransaction", mock.Anything, mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+			submittedFrom = append(submittedFrom, *args[2].(*components.PublicTxSubmission).From)
+		})
+		mc.publicTxMgr.On("WriteNewTransactions", mock.Anything, mock.Anything, mock.Anything).Return([]*pldapi.PublicTx{{}, {}, {}}, nil)
+		mockQueryPublicTxForTransactions(func(ids []uuid.UUID, jq *query.QueryJSON) (map[uuid.UUID][]*pldapi.PublicTx, error) {
+			return map[uuid.UUID][]*pldapi.PublicTx{ids[0]: {{LocalID: confutil.P(uint64(12345)), From: *poolAddrs[1]}}}, nil
+		})(conf, mc)
+		mc.publicTxMgr.On("UpdateTransaction", mock.Anything, mock.Anything, uint64(12345), mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+			updatedFrom = args[3].(*pldtypes.EthAddress)
+		})
+	})
+	defer done()
+
+	newTX := func() *pldapi.TransactionInput {
+		return &pldapi.TransactionInput{
+			ABI: abi.ABI{{Type: abi.Function, Name: "doStuff"}},
+			TransactionBase: pldapi.TransactionBase{
+				Type: pldapi.TransactionTypePublic.Enum(),
+				From: "pool1",
+				To:   pldtypes.RandAddress(),
+				Data: pldtypes.RawJSON(`[]`),
+			},
+		}
+	}
+	var txIDs []uuid.UUID
+	err := txm.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) (err error) {
+		txIDs, err = txm.SendTransactions(ctx, dbTX, newTX(), newTX(), newTX())
+		return err
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []pldtypes.EthAddress{*poolAddrs[0], *poolAddrs[1], *poolAddrs[0]}, submittedFrom)
+
+	// The transactions are recorded against the pool identity
+	tx, err := txm.GetTransactionByID(ctx, txIDs[1])
+	require.NoError(t, err)
+	assert.Equal(t, "pool1@node1", tx.From)
+
+	// An update goes to the pool member that holds the nonce, rather than the next in rotation
+	_, err = txm.UpdateTransaction(ctx, txIDs[1], newTX())
+	require.NoError(t, err)
+	assert.Equal(t, poolAddrs[1], updatedFrom)
+}
+
+func TestSigningPoolsInitErrors(t *testing.T) {
+	ctx := context.Background()
+
+	txm := NewTXManager(ctx, &pldconf.TxManagerConfig{
+		SigningPools: []*pldconf.SigningPoolConfig{{Identity: "pool1"}},
+	}).(*txManager)
+	err := txm.signingPoolsInit(ctx)
+	assert.Regexp(t, "PD012276.*pool1", err)
+
+	txm = NewTXManager(ctx, &pldconf.TxManagerConfig{
+		SigningPools: []*pldconf.SigningPoolConfig{{Size: 1}},
+	}).(*txManager)
+	err = txm.signingPoolsInit(ctx)
+	assert.Regexp(t, "PD012276", err)
+
+	txm = NewTXManager(ctx, &pldconf.TxManagerConfig{
+		SigningPools: []*pldconf.SigningPoolConfig{{Identity: "pool1", Size: 1}, {Identity: "pool1", Size: 2}},
+	}).(*txManager)
+	err = txm.signingPoolsInit(ctx)
+	assert.Regexp(t, "PD012277.*pool1", err)
+	_, err = txm.PreInit(nil)
+	assert.Regexp(t, "PD012277.*pool1", err)
+}
+
+func TestSignerForSubmission(t *testing.T) {
+	ctx := context.Background()
+	txm := NewTXManager(ctx, &pldconf.TxManagerConfig{
+		SigningPools: []*pldconf.SigningPoolConfig{{Identity: "pool1", Size: 3}},
+	}).(*txManager)
+	require.NoError(t, txm.signingPoolsInit(ctx))
+
+	assert.Equal(t, "pool1.0", txm.signerForSubmission("pool1"))
+	assert.Equal(t, "pool1.1", txm.signerForSubmission("pool1"))
+	assert.Equal(t, "pool1.2", txm.signerForSubmission("pool1"))
+	assert.Equal(t, "pool1.0", txm.signerForSubmission("pool1"))
+	assert.Equal(t, "sender1", txm.signerForSubmission("sender1"))
+	assert.Equal(t, "pool1.0", txm.signerForSubmission("pool1.0"))
+}
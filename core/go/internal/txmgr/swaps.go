@@ -0,0 +1,447 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package txmgr
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+
+	"github.com/google/uuid"
+	"github.com/hyperledger/firefly-signer/pkg/abi"
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/core/internal/filters"
+	"github.com/kaleido-io/paladin/core/internal/msgs"
+	"github.com/kaleido-io/paladin/core/pkg/persistence"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/query"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/solutils"
+)
+
+//go:embed abis/AtomFactory.json
+var atomFactoryBuildJSON []byte
+
+//go:embed abis/Atom.json
+var atomBuildJSON []byte
+
+var atomFactoryABI = solutils.MustLoadBuild(atomFactoryBuildJSON).ABI
+var atomABI = solutils.MustLoadBuild(atomBuildJSON).ABI
+
+// DB persisted record for a swap
+type persistedSwap struct {
+	ID          uuid.UUID                        `gorm:"column:id;primaryKey"`
+	Name        string                           `gorm:"column:name"`
+	Created     pldtypes.Timestamp               `gorm:"column:created"`
+	Updated     pldtypes.Timestamp               `gorm:"column:updated"`
+	From        string                           `gorm:"column:from"`
+	AtomFactory pldtypes.EthAddress              `gorm:"column:atom_factory"`
+	Status      pldtypes.Enum[pldapi.SwapStatus] `gorm:"column:status"`
+	Legs        pldtypes.RawJSON                 `gorm:"column:legs"`
+	Operations  pldtypes.RawJSON                 `gorm:"column:operations"`
+	Atom        *pldtypes.EthAddress             `gorm:"column:atom"`
+	ApproveTX   *uuid.UUID                       `gorm:"column:approve_tx"`
+	ExecuteTX   *uuid.UUID                       `gorm:"column:execute_tx"`
+	RollbackTX  *uuid.UUID                       `gorm:"column:rollback_tx"`
+	Failure     *string                          `gorm:"column:failure"`
+}
+
+func (persistedSwap) TableName() string {
+	return "swaps"
+}
+
+var swapFilters = filters.FieldMap{
+	"id":      filters.UUIDField(`"id"`),
+	"name":    filters.StringField("name"),
+	"created": filters.TimestampField("created"),
+	"updated": filters.TimestampField("updated"),
+	"status":  filters.StringField("status"),
+	"atom":    filters.HexBytesField("atom"),
+}
+
+func mapSwap(ps *persistedSwap) (*pldapi.Swap, error) {
+	swap := &pldapi.Swap{
+		ID:                  ps.ID,
+		Name:                ps.Name,
+		Created:             ps.Created,
+		Updated:             ps.Updated,
+		From:                ps.From,
+		AtomFactory:         ps.AtomFactory,
+		Status:              ps.Status,
+		Atom:                ps.Atom,
+		ApproveTransaction:  ps.ApproveTX,
+		ExecuteTransaction:  ps.ExecuteTX,
+		RollbackTransaction: ps.RollbackTX,
+	}
+	if ps.Failure != nil {
+		swap.FailureMessage = *ps.Failure
+	}
+	if err := json.Unmarshal(ps.Legs, &swap.Legs); err != nil {
+		return nil, err
+	}
+	if ps.Operations != nil {
+		if err := json.Unmarshal(ps.Operations, &swap.Operations); err != nil {
+			return nil, err
+		}
+	}
+	return swap, nil
+}
+
+// Each leg is submitted as a private transaction with the prepare intent, so the domain
+// assembles and endorses it, but the resulting base ledger call is stored rather than submitted.
+func (tm *txManager) PrepareSwap(ctx context.Context, input *pldapi.SwapInput) (*pldapi.Swap, error) {
+	if len(input.Legs) < 2 {
+		return nil, i18n.NewError(ctx, msgs.MsgTxMgrSwapLegsRequired)
+	}
+	if input.From == "" {
+		return nil, i18n.NewError(ctx, msgs.MsgTxMgrSwapFieldRequired, "from")
+	}
+	if input.AtomFactory == nil {
+		return nil, i18n.NewError(ctx, msgs.MsgTxMgrSwapFieldRequired, "atomFactory")
+	}
+
+	now := pldtypes.TimestampNow()
+	ps := &persistedSwap{
+		ID:          uuid.New(),
+		Name:        input.Name,
+		Created:     now,
+		Updated:     now,
+		From:        input.From,
+		AtomFactory: *input.AtomFactory,
+		Status:      pldapi.SwapStatusPreparing.Enum(),
+	}
+	err := tm.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
+		txIDs, err := tm.PrepareTransactions(ctx, dbTX, input.Legs...)
+		if err != nil {
+			return err
+		}
+		legs := make([]*pldapi.SwapLeg, len(txIDs))
+		for i, txID := range txIDs {
+			legs[i] = &pldapi.SwapLeg{
+				TransactionID: txID,
+				Domain:        input.Legs[i].Domain, // resolved from the contract address during submission
+				To:            input.Legs[i].To,
+			}
+		}
+		ps.Legs = pldtypes.JSONString(legs)
+		return dbTX.DB().Create(ps).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	log.L(ctx).Infof("Swap %s preparing %d legs", ps.ID, len(input.Legs))
+	return mapSwap(ps)
+}
+
+func (tm *txManager) getPersistedSwap(ctx context.Context, dbTX persistence.DBTX, id uuid.UUID) (*persistedSwap, error) {
+	var swaps []*persistedSwap
+	err := dbTX.DB().
+		WithContext(ctx).
+		Where("id = ?", id).
+		Limit(1).
+		Find(&swaps).
+		Error
+	if err != nil || len(swaps) == 0 {
+		return nil, err
+	}
+	return swaps[0], nil
+}
+
+// The status of the swap is progressed on each read, by checking the outcome of the transactions
+// submitted for the current stage. Queries return the status as it was last observed.
+func (tm *txManager) GetSwap(ctx context.Context, id uuid.UUID) (*pldapi.Swap, error) {
+	ps, err := tm.getPersistedSwap(ctx, tm.p.NOTX(), id)
+	if err == nil && ps != nil {
+		ps, err = tm.refreshSwap(ctx, ps)
+	}
+	if err != nil || ps == nil {
+		return nil, err
+	}
+	return mapSwap(ps)
+}
+
+func (tm *txManager) QuerySwaps(ctx context.Context, dbTX persistence.DBTX, jq *query.QueryJSON) ([]*pldapi.Swap, error) {
+	qw := &filters.QueryWrapper[persistedSwap, pldapi.Swap]{
+		P:           tm.p,
+		Table:       "swaps",
+		DefaultSort: "-created",
+		Filters:     swapFilters,
+		Query:       jq,
+		MapResult:   mapSwap,
+	}
+	return qw.Run(ctx, dbTX)
+}
+
+// Moves the swap on from the status it was read in, so that concurrent callers cannot make the same
+// transition twice. Returns false if the swap had already moved on.
+func (tm *txManager) transitionSwap(ctx context.Context, dbTX persistence.DBTX, ps *persistedSwap, changes map[string]any) (bool, error) {
+	changes["updated"] = pldtypes.TimestampNow()
+	result := dbTX.DB().
+		WithContext(ctx).
+		Model(&persistedSwap{}).
+		Where("id = ?", ps.ID).
+		Where("status = ?", ps.Status).
+		Updates(changes)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	if result.RowsAffected > 0 {
+		log.L(ctx).Infof("Swap %s moved from %s to %s", ps.ID, ps.Status, changes["status"])
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (tm *txManager) refreshSwap(ctx context.Context, ps *persistedSwap) (*persistedSwap, error) {
+	var changes map[string]any
+	var err error
+	switch ps.Status.V() {
+	case pldapi.SwapStatusPreparing:
+		changes, err = tm.checkSwapPrepared(ctx, ps)
+	case pldapi.SwapStatusApproving:
+		changes, err = tm.checkSwapTransaction(ctx, ps, *ps.ApproveTX, pldapi.SwapStatusApproved, pldapi.SwapStatusPrepared)
+	case pldapi.SwapStatusExecuting:
+		changes, err = tm.checkSwapTransaction(ctx, ps, *ps.ExecuteTX, pldapi.SwapStatusExecuted, pldapi.SwapStatusApproved)
+	case pldapi.SwapStatusRollingBack:
+		changes, err = tm.checkSwapTransaction(ctx, ps, *ps.RollbackTX, pldapi.SwapStatusRolledBack, pldapi.SwapStatusApproved)
+	}
+	if err != nil || changes == nil {
+		return ps, err
+	}
+	if _, err := tm.transitionSwap(ctx, tm.p.NOTX(), ps, changes); err != nil {
+		return nil, err
+	}
+	// Whether we made the transition or someone else did, the DB has the latest
+	return tm.getPersistedSwap(ctx, tm.p.NOTX(), ps.ID)
+}
+
+// Once every leg has a prepared transaction, the base ledger call from each becomes a settlement operation
+func (tm *txManager) checkSwapPrepared(ctx context.Context, ps *persistedSwap) (map[string]any, error) {
+	var legs []*pldapi.SwapLeg
+	if err := json.Unmarshal(ps.Legs, &legs); err != nil {
+		return nil, err
+	}
+	operations := make([]*pldapi.SwapOperation, len(legs))
+	waiting := false
+	for i, leg := range legs {
+		receipt, err := tm.GetTransactionReceiptByID(ctx, leg.TransactionID)
+		if err != nil {
+			return nil, err
+		}
+		if receipt != nil && !receipt.Success {
+			return swapFailed(i18n.NewError(ctx, msgs.MsgTxMgrSwapLegFailed, leg.TransactionID, receipt.FailureMessage)), nil
+		}
+		pt, err := tm.GetPreparedTransactionByID(ctx, tm.p.NOTX(), leg.TransactionID)
+		if err != nil {
+			return nil, err
+		}
+		if pt == nil {
+			// still waiting for this leg, but check the others for failures
+			waiting = true
+			continue
+		}
+		tx := pt.Transaction
+		if tx.Type.V() != pldapi.TransactionTypePublic || tx.To == nil {
+			return swapFailed(i18n.NewError(ctx, msgs.MsgTxMgrSwapLegNotPublic, leg.TransactionID, tx.Type)), nil
+		}
+		fn, cv, _, err := tm.ResolveTransactionInputs(ctx, tm.p.NOTX(), &tx)
+		var callData []byte
+		if err == nil {
+			callData, err = tm.getPublicTxData(ctx, fn.Definition, nil, cv)
+		}
+		if err != nil {
+			return swapFailed(err), nil
+		}
+		operations[i] = &pldapi.SwapOperation{
+			ContractAddress: *tx.To,
+			CallData:        callData,
+		}
+	}
+	if waiting {
+		return nil, nil
+	}
+	return map[string]any{
+		"status":     pldapi.SwapStatusPrepared,
+		"operations": pldtypes.JSONString(operations),
+	}, nil
+}
+
+func swapFailed(err error) map[string]any {
+	return map[string]any{
+		"status":  pldapi.SwapStatusFailed,
+		"failure": err.Error(),
+	}
+}
+
+// A reverted approve/execute/rollback transaction returns the swap to the status it was in before,
+// with the failure recorded, so the step can be retried (or the swap rolled back).
+func (tm *txManager) checkSwapTransaction(ctx context.Context, ps *persistedSwap, txID uuid.UUID, success, revert pldapi.SwapStatus) (map[string]any, error) {
+	receipt, err := tm.GetTransactionReceiptByID(ctx, txID)
+	if err != nil || receipt == nil {
+		return nil, err
+	}
+	if !receipt.Success {
+		return map[string]any{
+			"status":  revert,
+			"failure": receipt.FailureMessage,
+		}, nil
+	}
+	changes := map[string]any{
+		"status":  success,
+		"failure": nil,
+	}
+	if success == pldapi.SwapStatusApproved {
+		atom, err := tm.findDeployedAtom(ctx, ps, receipt)
+		if err != nil {
+			return nil, err
+		}
+		changes["atom"] = atom
+	}
+	return changes, nil
+}
+
+func (tm *txManager) findDeployedAtom(ctx context.Context, ps *persistedSwap, receipt *pldapi.TransactionReceipt) (*pldtypes.EthAddress, error) {
+	if receipt.TransactionHash == nil {
+		return nil, i18n.NewError(ctx, msgs.MsgTxMgrSwapAtomNotFound, receipt.ID)
+	}
+	events, err := tm.blockIndexer.DecodeTransactionEvents(ctx, *receipt.TransactionHash, atomFactoryABI, "")
+	if err != nil {
+		return nil, err
+	}
+	deployedSig := pldtypes.NewBytes32FromSlice(atomFactoryABI.Events()["AtomDeployed"].SignatureHashBytes())
+	for _, event := range events {
+		if event.Address == ps.AtomFactory && event.Signature == deployedSig && event.Data != nil {
+			var deployed struct {
+				Addr pldtypes.EthAddress `json:"addr"`
+			}
+			if err := json.Unmarshal(event.Data, &deployed); err != nil {
+				return nil, err
+			}
+			return &deployed.Addr, nil
+		}
+	}
+	return nil, i18n.NewError(ctx, msgs.MsgTxMgrSwapAtomNotFound, receipt.ID)
+}
+
+func (tm *txManager) getSwapForAction(ctx context.Context, id uuid.UUID, action string, allowed ...pldapi.SwapStatus) (*persistedSwap, error) {
+	ps, err := tm.getPersistedSwap(ctx, tm.p.NOTX(), id)
+	if err == nil && ps == nil {
+		err = i18n.NewError(ctx, msgs.MsgTxMgrSwapNotFound, id)
+	}
+	if err == nil {
+		ps, err = tm.refreshSwap(ctx, ps)
+	}
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range allowed {
+		if ps.Status.V() == s {
+			return ps, nil
+		}
+	}
+	return nil, i18n.NewError(ctx, msgs.MsgTxMgrSwapInvalidStatus, id, action, ps.Status)
+}
+
+// Submits a public transaction against the settlement contract (or its factory), in the same
+// DB transaction as the status change, so exactly one is submitted for each transition.
+func (tm *txManager) submitSwapTransaction(ctx context.Context, ps *persistedSwap, action string, tx *pldapi.TransactionInput, status pldapi.SwapStatus, txColumn string) (*pldapi.Swap, error) {
+	tx.Type = pldapi.TransactionTypePublic.Enum()
+	tx.From = ps.From
+	err := tm.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
+		txIDs, err := tm.SendTransactions(ctx, dbTX, tx)
+		if err != nil {
+			return err
+		}
+		ok, err := tm.transitionSwap(ctx, dbTX, ps, map[string]any{
+			"status":  status,
+			txColumn:  txIDs[0],
+			"failure": nil,
+		})
+		if err == nil && !ok {
+			err = i18n.NewError(ctx, msgs.MsgTxMgrSwapInvalidStatus, ps.ID, action, ps.Status)
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return tm.GetSwap(ctx, ps.ID)
+}
+
+// Approval creates the settlement contract with the operations derived from the prepared legs.
+// Each party then authorizes the contract in their own domain (for example by delegating a lock
+// to it) before the swap is executed.
+func (tm *txManager) ApproveSwap(ctx context.Context, id uuid.UUID) (*pldapi.Swap, error) {
+	ps, err := tm.getSwapForAction(ctx, id, "approved", pldapi.SwapStatusPrepared)
+	if err != nil {
+		return nil, err
+	}
+	var operations []*pldapi.SwapOperation
+	if err := json.Unmarshal(ps.Operations, &operations); err != nil {
+		return nil, err
+	}
+	return tm.submitSwapTransaction(ctx, ps, "approved", &pldapi.TransactionInput{
+		TransactionBase: pldapi.TransactionBase{
+			To:       &ps.AtomFactory,
+			Function: "create",
+			Data:     pldtypes.JSONString(map[string]any{"operations": operations}),
+		},
+		ABI: atomFactoryABI,
+	}, pldapi.SwapStatusApproving, "approve_tx")
+}
+
+// Execution runs every operation in a single base ledger transaction, which reverts as a whole if
+// any leg cannot settle. A reverted execution can be retried, or the swap rolled back.
+func (tm *txManager) ExecuteSwap(ctx context.Context, id uuid.UUID) (*pldapi.Swap, error) {
+	ps, err := tm.getSwapForAction(ctx, id, "executed", pldapi.SwapStatusApproved)
+	if err != nil {
+		return nil, err
+	}
+	return tm.submitSwapTransaction(ctx, ps, "executed", swapAtomCall(ps, "execute"), pldapi.SwapStatusExecuting, "execute_tx")
+}
+
+// Rollback cancels the settlement contract if it has been created, so it can never be executed.
+// Before that point, the swap is simply marked as rolled back, and the prepared legs are abandoned.
+func (tm *txManager) RollbackSwap(ctx context.Context, id uuid.UUID) (*pldapi.Swap, error) {
+	ps, err := tm.getSwapForAction(ctx, id, "rolled back",
+		pldapi.SwapStatusPreparing, pldapi.SwapStatusPrepared, pldapi.SwapStatusApproved, pldapi.SwapStatusFailed)
+	if err != nil {
+		return nil, err
+	}
+	if ps.Atom != nil {
+		return tm.submitSwapTransaction(ctx, ps, "rolled back", swapAtomCall(ps, "cancel"), pldapi.SwapStatusRollingBack, "rollback_tx")
+	}
+	ok, err := tm.transitionSwap(ctx, tm.p.NOTX(), ps, map[string]any{
+		"status": pldapi.SwapStatusRolledBack,
+	})
+	if err == nil && !ok {
+		err = i18n.NewError(ctx, msgs.MsgTxMgrSwapInvalidStatus, ps.ID, "rolled back", ps.Status)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return tm.GetSwap(ctx, ps.ID)
+}
+
+func swapAtomCall(ps *persistedSwap, function string) *pldapi.TransactionInput {
+	return &pldapi.TransactionInput{
+		TransactionBase: pldapi.TransactionBase{
+			To:       ps.Atom,
+			Function: function,
+		},
+		ABI: abi.ABI{atomABI.Functions()[function]},
+	}
+}
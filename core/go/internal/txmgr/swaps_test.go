@@ -0,0 +1,423 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package txmgr
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hyperledger/firefly-signer/pkg/abi"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/internal/components"
+	"github.com/kaleido-io/paladin/core/mocks/componentmocks"
+	"github.com/kaleido-io/paladin/core/pkg/persistence"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/query"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/rpcclient"
+	"github.com/kaleido-io/paladin/toolkit/pkg/algorithms"
+	"github.com/kaleido-io/paladin/toolkit/pkg/verifiers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var testSwapLegABI = abi.ABI{
+	{Type: abi.Function, Name: "transfer", Inputs: abi.ParameterArray{
+		{Name: "to", Type: "string"},
+		{Name: "amount", Type: "uint256"},
+	}},
+}
+
+var testSwapBaseLedgerABI = abi.ABI{
+	{Type: abi.Function, Name: "transferWithLock", Inputs: abi.ParameterArray{
+		{Name: "lockId", Type: "bytes32"},
+	}},
+}
+
+type testSwapSetup struct {
+	ctx         context.Context
+	txm         *txManager
+	atomFactory *pldtypes.EthAddress
+	atom        *pldtypes.EthAddress
+	contracts   []pldtypes.EthAddress
+}
+
+func newTestSwapManager(t *testing.T, init ...func(conf *pldconf.TxManagerConfig, mc *mockComponents)) (*testSwapSetup, func()) {
+	ts := &testSwapSetup{
+		atomFactory: pldtypes.RandAddress(),
+		atom:        pldtypes.RandAddress(),
+		contracts:   []pldtypes.EthAddress{*pldtypes.RandAddress(), *pldtypes.RandAddress()},
+	}
+	init = append(init, func(conf *pldconf.TxManagerConfig, mc *mockComponents) {
+		// Not every test reaches every stage of the swap
+		mgsc := mc.domainManager.On("GetSmartContractByAddress", mock.Anything, mock.Anything, mock.Anything).Maybe()
+		mgsc.Run(func(args mock.Arguments) {
+			domainName := "domain1"
+			if args[2].(pldtypes.EthAddress) == ts.contracts[1] {
+				domainName = "domain2"
+			}
+			mpsc := componentmocks.NewDomainSmartContract(t)
+			mdmn := componentmocks.NewDomain(t)
+			mdmn.On("Name").Return(domainName)
+			mpsc.On("Domain").Return(mdmn)
+			mpsc.On("Address").Return(args[2].(pldtypes.EthAddress)).Maybe()
+			mgsc.Return(mpsc, nil)
+		})
+		kr := componentmocks.NewKeyResolver(t)
+		mc.keyManager.On("KeyResolverForDBTX", mock.Anything).Return(kr).Maybe()
+		kr.On("ResolveKey", mock.Anything, "sender1", algorithms.ECDSA_SECP256K1, verifiers.ETH_ADDRESS).
+			Return(&pldapi.KeyMappingAndVerifier{Verifier: &pldapi.KeyVerifier{
+				Verifier: pldtypes.RandAddress().String(),
+			}}, nil).Maybe()
+		mc.publicTxMgr.On("ValidateTransaction", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+		mc.publicTxMgr.On("WriteNewTransactions", mock.Anything, mock.Anything, mock.Anything).Return([]*pldapi.PublicTx{
+			{LocalID: confutil.P(uint64(12345))},
+		}, nil).Maybe()
+		mc.privateTxMgr.On("HandleNewTx", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+		mc.blockIndexer.On("DecodeTransactionEvents", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(
+			[]*pldapi.EventWithData{
+				{
+					IndexedEvent: &pldapi.IndexedEvent{Signature: pldtypes.RandBytes32()},
+					Address:      *ts.atomFactory,
+				},
+				{
+					IndexedEvent: &pldapi.IndexedEvent{
+						Signature: pldtypes.NewBytes32FromSlice(atomFactoryABI.Events()["AtomDeployed"].SignatureHashBytes()),
+					},
+					Address: *ts.atomFactory,
+					Data:    pldtypes.JSONString(map[string]any{"addr": ts.atom}),
+				},
+			}, nil).Maybe()
+	})
+	var done func()
+	ts.ctx, ts.txm, done = newTestTransactionManager(t, true, init...)
+	return ts, done
+}
+
+func (ts *testSwapSetup) prepareSwap(t *testing.T) *pldapi.Swap {
+	legs := make([]*pldapi.TransactionInput, len(ts.contracts))
+	for i := range ts.contracts {
+		legs[i] = &pldapi.TransactionInput{
+			TransactionBase: pldapi.TransactionBase{
+				From:     "me",
+				Type:     pldapi.TransactionTypePrivate.Enum(),
+				To:       &ts.contracts[i],
+				Function: "transfer",
+				Data:     pldtypes.RawJSON(`{"to":"you","amount":100}`),
+			},
+			ABI: testSwapLegABI,
+		}
+	}
+	swap, err := ts.txm.PrepareSwap(ts.ctx, &pldapi.SwapInput{
+		Name:        "swap1",
+		From:        "sender1",
+		AtomFactory: ts.atomFactory,
+		Legs:        legs,
+	})
+	require.NoError(t, err)
+	return swap
+}
+
+func (ts *testSwapSetup) writePreparedLegs(t *testing.T, swap *pldapi.Swap, txType pldapi.TransactionType) {
+	err := ts.txm.p.Transaction(ts.ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
+		for _, leg := range swap.Legs {
+			err := ts.txm.WritePreparedTransactions(ctx, dbTX, []*components.PreparedTransactionWithRefs{{
+				PreparedTransactionBase: &pldapi.PreparedTransactionBase{
+					ID:     leg.TransactionID,
+					Domain: leg.Domain,
+					To:     leg.To,
+					Transaction: pldapi.TransactionInput{
+						TransactionBase: pldapi.TransactionBase{
+							From:     "notary",
+							Type:     txType.Enum(),
+							To:       leg.To,
+							Function: "transferWithLock",
+							Data:     pldtypes.RawJSON(`{"lockId":"0x0000000000000000000000000000000000000000000000000000000000000001"}`),
+						},
+						ABI: testSwapBaseLedgerABI,
+					},
+				},
+			}})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	require.NoError(t, err)
+}
+
+func (ts *testSwapSetup) writeReceipt(t *testing.T, txID uuid.UUID, failureMessage string) {
+	receipt := &components.ReceiptInput{
+		TransactionID: txID,
+		ReceiptType:   components.RT_Success,
+		OnChain: pldtypes.OnChainLocation{
+			Type:            pldtypes.OnChainTransaction,
+			TransactionHash: pldtypes.RandBytes32(),
+			BlockNumber:     12345,
+		},
+	}
+	if failureMessage != "" {
+		receipt.ReceiptType = components.RT_FailedWithMessage
+		receipt.FailureMessage = failureMessage
+	}
+	err := ts.txm.p.Transaction(ts.ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
+		return ts.txm.FinalizeTransactions(ctx, dbTX, []*components.ReceiptInput{receipt})
+	})
+	require.NoError(t, err)
+}
+
+func TestSwapLifecycle(t *testing.T) {
+	ts, done := newTestSwapManager(t)
+	defer done()
+	ctx := ts.ctx
+
+	swap := ts.prepareSwap(t)
+	assert.Equal(t, pldapi.SwapStatusPreparing, swap.Status.V())
+	require.Len(t, swap.Legs, 2)
+	assert.Equal(t, "domain1", swap.Legs[0].Domain)
+	assert.Equal(t, "domain2", swap.Legs[1].Domain)
+
+	// Nothing prepared yet
+	swap, err := ts.txm.GetSwap(ctx, swap.ID)
+	require.NoError(t, err)
+	assert.Equal(t, pldapi.SwapStatusPreparing, swap.Status.V())
+
+	_, err = ts.txm.ApproveSwap(ctx, swap.ID)
+	assert.Regexp(t, "PD012269", err)
+
+	// Once the legs are prepared, the operations are derived
+	ts.writePreparedLegs(t, swap, pldapi.TransactionTypePublic)
+	swap, err = ts.txm.GetSwap(ctx, swap.ID)
+	require.NoError(t, err)
+	assert.Equal(t, pldapi.SwapStatusPrepared, swap.Status.V())
+	require.Len(t, swap.Operations, 2)
+	expectedCallData, err := testSwapBaseLedgerABI.Functions()["transferWithLock"].EncodeCallDataJSON(
+		[]byte(`{"lockId":"0x0000000000000000000000000000000000000000000000000000000000000001"}`))
+	require.NoError(t, err)
+	for i, op := range swap.Operations {
+		assert.Equal(t, ts.contracts[i], op.ContractAddress)
+		assert.Equal(t, pldtypes.HexBytes(expectedCallData), op.CallData)
+	}
+
+	// Approve creates the atom - the first attempt reverts
+	swap, err = ts.txm.ApproveSwap(ctx, swap.ID)
+	require.NoError(t, err)
+	assert.Equal(t, pldapi.SwapStatusApproving, swap.Status.V())
+	require.NotNil(t, swap.ApproveTransaction)
+	ts.writeReceipt(t, *swap.ApproveTransaction, "pop")
+	swap, err = ts.txm.GetSwap(ctx, swap.ID)
+	require.NoError(t, err)
+	assert.Equal(t, pldapi.SwapStatusPrepared, swap.Status.V())
+	assert.Equal(t, "pop", swap.FailureMessage)
+
+	swap, err = ts.txm.ApproveSwap(ctx, swap.ID)
+	require.NoError(t, err)
+	assert.Empty(t, swap.FailureMessage)
+	tx, err := ts.txm.GetTransactionByID(ctx, *swap.ApproveTransaction)
+	require.NoError(t, err)
+	assert.Equal(t, ts.atomFactory, tx.To)
+	assert.Equal(t, "sender1@node1", tx.From)
+	ts.writeReceipt(t, *swap.ApproveTransaction, "")
+	swap, err = ts.txm.GetSwap(ctx, swap.ID)
+	require.NoError(t, err)
+	assert.Equal(t, pldapi.SwapStatusApproved, swap.Status.V())
+	assert.Equal(t, ts.atom, swap.Atom)
+
+	// Execute
+	swap, err = ts.txm.ExecuteSwap(ctx, swap.ID)
+	require.NoError(t, err)
+	assert.Equal(t, pldapi.SwapStatusExecuting, swap.Status.V())
+	tx, err = ts.txm.GetTransactionByID(ctx, *swap.ExecuteTransaction)
+	require.NoError(t, err)
+	assert.Equal(t, ts.atom, tx.To)
+
+	_, err = ts.txm.RollbackSwap(ctx, swap.ID)
+	assert.Regexp(t, "PD012269", err)
+
+	ts.writeReceipt(t, *swap.ExecuteTransaction, "")
+	swap, err = ts.txm.GetSwap(ctx, swap.ID)
+	require.NoError(t, err)
+	assert.Equal(t, pldapi.SwapStatusExecuted, swap.Status.V())
+
+	swaps, err := ts.txm.QuerySwaps(ctx, ts.txm.p.NOTX(), query.NewQueryBuilder().Equal("status", "executed").Limit(10).Query())
+	require.NoError(t, err)
+	require.Len(t, swaps, 1)
+	assert.Equal(t, swap.ID, swaps[0].ID)
+	assert.Equal(t, "swap1", swaps[0].Name)
+}
+
+func TestSwapRollbackAfterApproval(t *testing.T) {
+	ts, done := newTestSwapManager(t)
+	defer done()
+	ctx := ts.ctx
+
+	swap := ts.prepareSwap(t)
+	ts.writePreparedLegs(t, swap, pldapi.TransactionTypePublic)
+	swap, err := ts.txm.ApproveSwap(ctx, swap.ID)
+	require.NoError(t, err)
+	ts.writeReceipt(t, *swap.ApproveTransaction, "")
+
+	swap, err = ts.txm.RollbackSwap(ctx, swap.ID)
+	require.NoError(t, err)
+	assert.Equal(t, pldapi.SwapStatusRollingBack, swap.Status.V())
+	tx, err := ts.txm.GetTransactionByID(ctx, *swap.RollbackTransaction)
+	require.NoError(t, err)
+	assert.Equal(t, ts.atom, tx.To)
+	assert.Equal(t, "cancel()", tx.Function)
+
+	// A reverted cancel returns to approved
+	ts.writeReceipt(t, *swap.RollbackTransaction, "pop")
+	swap, err = ts.txm.GetSwap(ctx, swap.ID)
+	require.NoError(t, err)
+	assert.Equal(t, pldapi.SwapStatusApproved, swap.Status.V())
+
+	swap, err = ts.txm.RollbackSwap(ctx, swap.ID)
+	require.NoError(t, err)
+	ts.writeReceipt(t, *swap.RollbackTransaction, "")
+	swap, err = ts.txm.GetSwap(ctx, swap.ID)
+	require.NoError(t, err)
+	assert.Equal(t, pldapi.SwapStatusRolledBack, swap.Status.V())
+
+	_, err = ts.txm.ExecuteSwap(ctx, swap.ID)
+	assert.Regexp(t, "PD012269", err)
+}
+
+func TestSwapRollbackBeforeApproval(t *testing.T) {
+	ts, done := newTestSwapManager(t)
+	defer done()
+
+	swap := ts.prepareSwap(t)
+	swap, err := ts.txm.RollbackSwap(ts.ctx, swap.ID)
+	require.NoError(t, err)
+	assert.Equal(t, pldapi.SwapStatusRolledBack, swap.Status.V())
+	assert.Nil(t, swap.RollbackTransaction)
+}
+
+func TestSwapLegFailed(t *testing.T) {
+	ts, done := newTestSwapManager(t)
+	defer done()
+
+	swap := ts.prepareSwap(t)
+	ts.writeReceipt(t, swap.Legs[1].TransactionID, "pop")
+	swap, err := ts.txm.GetSwap(ts.ctx, swap.ID)
+	require.NoError(t, err)
+	assert.Equal(t, pldapi.SwapStatusFailed, swap.Status.V())
+	assert.Regexp(t, "PD012270.*pop", swap.FailureMessage)
+}
+
+func TestSwapLegNotPublic(t *testing.T) {
+	ts, done := newTestSwapManager(t)
+	defer done()
+
+	swap := ts.prepareSwap(t)
+	ts.writePreparedLegs(t, swap, pldapi.TransactionTypePrivate)
+	swap, err := ts.txm.GetSwap(ts.ctx, swap.ID)
+	require.NoError(t, err)
+	assert.Equal(t, pldapi.SwapStatusFailed, swap.Status.V())
+	assert.Regexp(t, "PD012271", swap.FailureMessage)
+}
+
+func TestSwapAtomNotFound(t *testing.T) {
+	ts, done := newTestSwapManager(t)
+	defer done()
+	ctx := ts.ctx
+
+	swap := ts.prepareSwap(t)
+	ts.writePreparedLegs(t, swap, pldapi.TransactionTypePublic)
+	swap, err := ts.txm.ApproveSwap(ctx, swap.ID)
+	require.NoError(t, err)
+
+	// The factory is not the one that emitted the event
+	err = ts.txm.p.DB().Model(&persistedSwap{}).Where("id = ?", swap.ID).Update("atom_factory", pldtypes.RandAddress()).Error
+	require.NoError(t, err)
+	ts.writeReceipt(t, *swap.ApproveTransaction, "")
+	_, err = ts.txm.GetSwap(ctx, swap.ID)
+	assert.Regexp(t, "PD012272", err)
+}
+
+func TestPrepareSwapBadInput(t *testing.T) {
+	ts, done := newTestSwapManager(t)
+	defer done()
+	ctx := ts.ctx
+
+	leg := &pldapi.TransactionInput{}
+	_, err := ts.txm.PrepareSwap(ctx, &pldapi.SwapInput{Legs: []*pldapi.TransactionInput{leg}})
+	assert.Regexp(t, "PD012266", err)
+
+	_, err = ts.txm.PrepareSwap(ctx, &pldapi.SwapInput{Legs: []*pldapi.TransactionInput{leg, leg}})
+	assert.Regexp(t, "PD012267.*from", err)
+
+	_, err = ts.txm.PrepareSwap(ctx, &pldapi.SwapInput{From: "sender1", Legs: []*pldapi.TransactionInput{leg, leg}})
+	assert.Regexp(t, "PD012267.*atomFactory", err)
+
+	// Public legs cannot be prepared
+	_, err = ts.txm.PrepareSwap(ctx, &pldapi.SwapInput{
+		From:        "sender1",
+		AtomFactory: ts.atomFactory,
+		Legs: []*pldapi.TransactionInput{{
+			TransactionBase: pldapi.TransactionBase{
+				From:     "sender1",
+				Type:     pldapi.TransactionTypePublic.Enum(),
+				To:       pldtypes.RandAddress(),
+				Function: "transferWithLock",
+			},
+			ABI: testSwapBaseLedgerABI,
+		}, leg},
+	})
+	assert.Error(t, err)
+}
+
+func TestSwapNotFound(t *testing.T) {
+	ts, done := newTestSwapManager(t)
+	defer done()
+
+	swap, err := ts.txm.GetSwap(ts.ctx, uuid.New())
+	require.NoError(t, err)
+	assert.Nil(t, swap)
+
+	_, err = ts.txm.ExecuteSwap(ts.ctx, uuid.New())
+	assert.Regexp(t, "PD012268", err)
+}
+
+func TestSwapRPC(t *testing.T) {
+	ctx, url, _, done := newTestTransactionManagerWithRPC(t)
+	defer done()
+
+	rpcClient, err := rpcclient.NewHTTPClient(ctx, &pldconf.HTTPClientConfig{URL: url})
+	require.NoError(t, err)
+
+	var swap *pldapi.Swap
+	err = rpcClient.CallRPC(ctx, &swap, "ptx_prepareSwap", &pldapi.SwapInput{})
+	assert.Regexp(t, "PD012266", err)
+
+	err = rpcClient.CallRPC(ctx, &swap, "ptx_getSwap", uuid.New())
+	require.NoError(t, err)
+	assert.Nil(t, swap)
+
+	var swaps []*pldapi.Swap
+	err = rpcClient.CallRPC(ctx, &swaps, "ptx_querySwaps", query.NewQueryBuilder().Limit(10).Query())
+	require.NoError(t, err)
+	assert.Empty(t, swaps)
+
+	for _, method := range []string{"ptx_approveSwap", "ptx_executeSwap", "ptx_rollbackSwap"} {
+		err = rpcClient.CallRPC(ctx, &swap, method, uuid.New())
+		assert.Regexp(t, "PD012268", err)
+	}
+}
@@ -0,0 +1,84 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package txmgr
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/core/internal/msgs"
+	"github.com/kaleido-io/paladin/core/pkg/persistence"
+	"github.com/kaleido-io/paladin/toolkit/pkg/rpcserver"
+	"gorm.io/gorm"
+)
+
+// Restricts a query to the transactions of the tenant of the calling RPC request (if any).
+// Callers without a tenant (admin, internal processing, or nodes without tenant auth) see everything.
+func tenantScope(ctx context.Context, q *gorm.DB, column string) *gorm.DB {
+	if tenant := rpcserver.TenantName(ctx); tenant != "" {
+		q = q.Where(column+" = ?", tenant)
+	}
+	return q
+}
+
+// Returns not found for a transaction that was not submitted by the tenant of the calling RPC request (if any)
+func (tm *txManager) checkTenantTransaction(ctx context.Context, dbTX persistence.DBTX, txID uuid.UUID) error {
+	tenant := rpcserver.TenantName(ctx)
+	if tenant == "" {
+		return nil
+	}
+	var count int64
+	err := dbTX.DB().
+		WithContext(ctx).
+		Table("transactions").
+		Where(`"id" = ?`, txID).
+		Where(`"tenant" = ?`, tenant).
+		Count(&count).
+		Error
+	if err == nil && count == 0 {
+		err = i18n.NewError(ctx, msgs.MsgTxMgrTransactionNotFound, txID)
+	}
+	return err
+}
+
+func (tm *txManager) countTenantPending(ctx context.Context, dbTX persistence.DBTX, tenant string) (count int64, err error) {
+	err = dbTX.DB().
+		WithContext(ctx).
+		Table("transactions").
+		Joins(`LEFT JOIN "transaction_receipts" ON "transaction_receipts"."transaction" = "transactions"."id"`).
+		Where(`"transactions"."tenant" = ?`, tenant).
+		Where(`"transaction_receipts"."transaction" IS NULL`).
+		Count(&count).
+		Error
+	return count, err
+}
+
+// Applies the quota of the tenant of the calling RPC request to a batch of new submissions
+func (tm *txManager) checkTenantQuota(ctx context.Context, dbTX persistence.DBTX, newTXCount int) error {
+	tenant := rpcserver.TenantFromContext(ctx)
+	if tenant == nil || tenant.MaxPendingTransactions <= 0 {
+		return nil
+	}
+	pending, err := tm.countTenantPending(ctx, dbTX, tenant.Name)
+	if err != nil {
+		return err
+	}
+	if pending+int64(newTXCount) > int64(tenant.MaxPendingTransactions) {
+		return i18n.NewError(ctx, msgs.MsgTxMgrTenantQuotaExceeded, tenant.Name, pending, newTXCount, tenant.MaxPendingTransactions)
+	}
+	return nil
+}
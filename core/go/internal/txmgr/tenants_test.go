@@ -0,0 +1,213 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package txmgr
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hyperledger/firefly-signer/pkg/abi"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/internal/components"
+	"github.com/kaleido-io/paladin/core/mocks/componentmocks"
+	"github.com/kaleido-io/paladin/core/pkg/persistence"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/query"
+	"github.com/kaleido-io/paladin/toolkit/pkg/rpcserver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTenantScopedSubmissionAndQuery(t *testing.T) {
+
+	ctx, txm, done := newTestTransactionManager(t, true, mockDomainContractResolve(t, "domain1"), func(tmc *pldconf.TxManagerConfig, mc *mockComponents) {
+		mc.privateTxMgr.On("HandleNewTx", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+		mc.publicTxMgr.On("QueryPublicTxForTransactions", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(map[uuid.UUID][]*pldapi.PublicTx{}, nil)
+	})
+	defer done()
+
+	app1Ctx := rpcserver.WithTenant(ctx, &rpcserver.Tenant{Name: "app1", Domains: []string{"domain1"}, MaxPendingTransactions: 2})
+	app2Ctx := rpcserver.WithTenant(ctx, &rpcserver.Tenant{Name: "app2"})
+	app3Ctx := rpcserver.WithTenant(ctx, &rpcserver.Tenant{Name: "app3", Domains: []string{"domain2"}})
+
+	send := func(ctx context.Context) (uuid.UUID, error) {
+		var txIDs []uuid.UUID
+		err := txm.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) (err error) {
+			txIDs, err = txm.SendTransactions(ctx, dbTX, &pldapi.TransactionInput{
+				ABI: abi.ABI{{Type: abi.Function, Name: "doStuff"}},
+				TransactionBase: pldapi.TransactionBase{
+					Type:   pldapi.TransactionTypePrivate.Enum(),
+					Domain: "domain1",
+					From:   "sender1",
+					To:     pldtypes.RandAddress(),
+					Data:   pldtypes.RawJSON(`[]`),
+				},
+			})
+			return err
+		})
+		if err != nil {
+			return uuid.Nil, err
+		}
+		return txIDs[0], nil
+	}
+
+	app1TX1, err := send(app1Ctx)
+	require.NoError(t, err)
+	_, err = send(app1Ctx)
+	require.NoError(t, err)
+	_, err = send(app1Ctx)
+	assert.Regexp(t, "PD012275.*app1.*2.*1.*2", err)
+
+	app2TX, err := send(app2Ctx)
+	require.NoError(t, err)
+	_, err = send(app3Ctx)
+	assert.Regexp(t, "PD020712.*app3.*domain1", err)
+	_, err = send(ctx)
+	require.NoError(t, err)
+
+	// Each tenant only sees its own transactions
+	txs, err := txm.QueryTransactions(app1Ctx, query.NewQueryBuilder().Limit(10).Query(), txm.p.NOTX(), false)
+	require.NoError(t, err)
+	require.Len(t, txs, 2)
+	assert.Equal(t, "app1", txs[0].Tenant)
+	fullTX, err := txm.GetTransactionByIDFull(app1Ctx, app2TX)
+	require.NoError(t, err)
+	assert.Nil(t, fullTX)
+	fullTX, err = txm.GetTransactionByIDFull(app2Ctx, app2TX)
+	require.NoError(t, err)
+	assert.Equal(t, "app2", fullTX.Tenant)
+	txs, err = txm.QueryTransactions(ctx, query.NewQueryBuilder().Limit(10).Equal("tenant", "app2").Query(), txm.p.NOTX(), false)
+	require.NoError(t, err)
+	assert.Len(t, txs, 1)
+	txs, err = txm.QueryTransactions(ctx, query.NewQueryBuilder().Limit(10).Query(), txm.p.NOTX(), false)
+	require.NoError(t, err)
+	assert.Len(t, txs, 4)
+
+	// Receipts are scoped in the same way, and free up quota
+	err = txm.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
+		return txm.FinalizeTransactions(ctx, dbTX, []*components.ReceiptInput{
+			{TransactionID: app1TX1, ReceiptType: components.RT_FailedWithMessage, FailureMessage: "pop"},
+		})
+	})
+	require.NoError(t, err)
+	receipt, err := txm.GetTransactionReceiptByID(app2Ctx, app1TX1)
+	require.NoError(t, err)
+	assert.Nil(t, receipt)
+	receipt, err = txm.GetTransactionReceiptByID(app1Ctx, app1TX1)
+	require.NoError(t, err)
+	require.NotNil(t, receipt)
+	_, err = send(app1Ctx)
+	require.NoError(t, err)
+}
+
+func TestTenantQuotaCountFail(t *testing.T) {
+	ctx, txm, done := newTestTransactionManager(t, false, mockEmptyReceiptListeners, func(conf *pldconf.TxManagerConfig, mc *mockComponents) {
+		mc.db.ExpectQuery("SELECT.*transactions").WillReturnError(fmt.Errorf("pop"))
+	})
+	defer done()
+
+	app1Ctx := rpcserver.WithTenant(ctx, &rpcserver.Tenant{Name: "app1", MaxPendingTransactions: 1})
+	err := txm.checkTenantQuota(app1Ctx, txm.p.NOTX(), 1)
+	assert.Regexp(t, "pop", err)
+}
+
+func TestTenantIdentitiesAndReceipts(t *testing.T) {
+
+	ctx, txm, done := newTestTransactionManager(t, true, mockDomainContractResolve(t, "domain1"), func(tmc *pldconf.TxManagerConfig, mc *mockComponents) {
+		mc.privateTxMgr.On("HandleNewTx", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+		md := componentmocks.NewDomain(t)
+		md.On("GetDomainReceipt", mock.Anything, mock.Anything, mock.Anything).Return(pldtypes.RawJSON(`{"some":"receipt"}`), nil)
+		mc.domainManager.On("GetDomainByName", mock.Anything, "domain1").Return(md, nil)
+		mc.stateMgr.On("GetTransactionStates", mock.Anything, mock.Anything, mock.Anything).Return(&pldapi.TransactionStates{}, nil)
+	})
+	defer done()
+
+	app1Ctx := rpcserver.WithTenant(ctx, &rpcserver.Tenant{Name: "app1", Identities: []string{"app1."}})
+	app2Ctx := rpcserver.WithTenant(ctx, &rpcserver.Tenant{Name: "app2", Identities: []string{"app2."}})
+
+	send := func(ctx context.Context, from string) (uuid.UUID, error) {
+		var txIDs []uuid.UUID
+		err := txm.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) (err error) {
+			txIDs, err = txm.SendTransactions(ctx, dbTX, &pldapi.TransactionInput{
+				ABI: abi.ABI{{Type: abi.Function, Name: "doStuff"}},
+				TransactionBase: pldapi.TransactionBase{
+					Type:   pldapi.TransactionTypePrivate.Enum(),
+					Domain: "domain1",
+					From:   from,
+					To:     pldtypes.RandAddress(),
+					Data:   pldtypes.RawJSON(`[]`),
+				},
+			})
+			return err
+		})
+		if err != nil {
+			return uuid.Nil, err
+		}
+		return txIDs[0], nil
+	}
+
+	// Tenants cannot submit as the identities of another tenant
+	_, err := send(app1Ctx, "app2.sender")
+	assert.Regexp(t, "PD020715.*app1.*app2.sender", err)
+	_, err = send(app1Ctx, "sender1")
+	assert.Regexp(t, "PD020715", err)
+	_, err = send(app1Ctx, "app2.sender@node1")
+	assert.Regexp(t, "PD020715", err)
+	app1TX, err := send(app1Ctx, "app1.sender")
+	require.NoError(t, err)
+
+	// ... or prepare as them
+	_, err = txm.PrepareTransactions(app2Ctx, txm.p.NOTX(), &pldapi.TransactionInput{
+		ABI: abi.ABI{{Type: abi.Function, Name: "doStuff"}},
+		TransactionBase: pldapi.TransactionBase{
+			Type:   pldapi.TransactionTypePrivate.Enum(),
+			Domain: "domain1",
+			From:   "app1.sender",
+			To:     pldtypes.RandAddress(),
+			Data:   pldtypes.RawJSON(`[]`),
+		},
+	})
+	assert.Regexp(t, "PD020715.*app2.*app1.sender", err)
+
+	// The domain and state receipts of a transaction are only available to the tenant that submitted it
+	_, err = txm.GetDomainReceiptByID(app2Ctx, "domain1", app1TX)
+	assert.Regexp(t, "PD012244", err)
+	_, err = txm.GetStateReceiptByID(app2Ctx, app1TX)
+	assert.Regexp(t, "PD012244", err)
+	domainReceipt, err := txm.GetDomainReceiptByID(app1Ctx, "domain1", app1TX)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"some":"receipt"}`, domainReceipt.String())
+	_, err = txm.GetStateReceiptByID(app1Ctx, app1TX)
+	require.NoError(t, err)
+	_, err = txm.GetStateReceiptByID(ctx, app1TX)
+	require.NoError(t, err)
+}
+
+func TestTenantTransactionCheckFail(t *testing.T) {
+	ctx, txm, done := newTestTransactionManager(t, false, mockEmptyReceiptListeners, func(conf *pldconf.TxManagerConfig, mc *mockComponents) {
+		mc.db.ExpectQuery("SELECT.*transactions").WillReturnError(fmt.Errorf("pop"))
+	})
+	defer done()
+
+	app1Ctx := rpcserver.WithTenant(ctx, &rpcserver.Tenant{Name: "app1"})
+	_, err := txm.GetStateReceiptByID(app1Ctx, uuid.New())
+	assert.Regexp(t, "pop", err)
+}
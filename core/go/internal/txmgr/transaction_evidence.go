@@ -0,0 +1,178 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package txmgr
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/google/uuid"
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/internal/msgs"
+	"github.com/kaleido-io/paladin/core/pkg/persistence"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/toolkit/pkg/algorithms"
+	"github.com/kaleido-io/paladin/toolkit/pkg/signpayloads"
+	"github.com/kaleido-io/paladin/toolkit/pkg/verifiers"
+	"gorm.io/gorm/clause"
+)
+
+// DB persisted record for an attestation gathered by the coordinator of a private transaction
+type transactionEndorsement struct {
+	Transaction     uuid.UUID          `gorm:"column:transaction"`
+	Idx             int                `gorm:"column:idx"`
+	Created         pldtypes.Timestamp `gorm:"column:created"`
+	Name            string             `gorm:"column:name"`
+	AttestationType string             `gorm:"column:attestation_type"`
+	Lookup          string             `gorm:"column:lookup"`
+	Verifier        string             `gorm:"column:verifier"`
+	Algorithm       string             `gorm:"column:algorithm"`
+	VerifierType    string             `gorm:"column:verifier_type"`
+	PayloadType     *string            `gorm:"column:payload_type"`
+	Payload         pldtypes.HexBytes  `gorm:"column:payload"`
+	Constraints     pldtypes.RawJSON   `gorm:"column:constraints"`
+}
+
+func (transactionEndorsement) TableName() string {
+	return "transaction_endorsements"
+}
+
+func (tm *txManager) WriteTransactionEndorsements(ctx context.Context, dbTX persistence.DBTX, endorsements []*pldapi.TransactionEndorsement) error {
+	if len(endorsements) == 0 {
+		return nil
+	}
+	dbEndorsements := make([]*transactionEndorsement, len(endorsements))
+	idx := make(map[uuid.UUID]int)
+	for i, e := range endorsements {
+		dbEndorsements[i] = &transactionEndorsement{
+			Transaction:     e.TransactionID,
+			Idx:             idx[e.TransactionID],
+			Created:         e.Created,
+			Name:            e.Name,
+			AttestationType: e.AttestationType,
+			Lookup:          e.Lookup,
+			Verifier:        e.Verifier.Verifier,
+			Algorithm:       e.Verifier.Algorithm,
+			VerifierType:    e.Verifier.Type,
+			Payload:         e.Payload,
+		}
+		idx[e.TransactionID]++
+		if e.PayloadType != "" {
+			dbEndorsements[i].PayloadType = &e.PayloadType
+		}
+		if len(e.Constraints) > 0 {
+			dbEndorsements[i].Constraints = pldtypes.JSONString(e.Constraints)
+		}
+	}
+	log.L(ctx).Debugf("Inserting %d endorsements for %d transactions", len(dbEndorsements), len(idx))
+	return dbTX.DB().WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true /* immutable */}).
+		Create(dbEndorsements).
+		Error
+}
+
+func (tm *txManager) GetTransactionEndorsements(ctx context.Context, dbTX persistence.DBTX, id uuid.UUID) ([]*pldapi.TransactionEndorsement, error) {
+	var dbEndorsements []*transactionEndorsement
+	err := dbTX.DB().WithContext(ctx).
+		Where(`"transaction" = ?`, id).
+		Order(`"idx"`).
+		Find(&dbEndorsements).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	endorsements := make([]*pldapi.TransactionEndorsement, len(dbEndorsements))
+	for i, e := range dbEndorsements {
+		endorsements[i] = &pldapi.TransactionEndorsement{
+			TransactionID:   e.Transaction,
+			Created:         e.Created,
+			Name:            e.Name,
+			AttestationType: e.AttestationType,
+			Lookup:          e.Lookup,
+			Verifier: &pldapi.KeyVerifier{
+				Verifier:  e.Verifier,
+				Algorithm: e.Algorithm,
+				Type:      e.VerifierType,
+			},
+			PayloadType: confutil.StringOrEmpty(e.PayloadType, ""),
+			Payload:     e.Payload,
+		}
+		if e.Constraints != nil {
+			if err := json.Unmarshal(e.Constraints, &endorsements[i].Constraints); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return endorsements, nil
+}
+
+// ExportTransactionEvidence builds the evidence for a completed transaction, from the view of this node,
+// and signs it with the configured evidence key so that it can be checked by someone who does not run Paladin
+func (tm *txManager) ExportTransactionEvidence(ctx context.Context, id uuid.UUID) (*pldapi.SignedTransactionEvidence, error) {
+	receipt, err := tm.GetTransactionReceiptByIDFull(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if receipt == nil {
+		return nil, i18n.NewError(ctx, msgs.MsgTxMgrEvidenceNoReceipt, id)
+	}
+
+	evidence := &pldapi.TransactionEvidence{
+		Node:          tm.localNodeName,
+		TransactionID: id,
+		Exported:      pldtypes.TimestampNow(),
+		Receipt:       receipt,
+		Events:        []*pldapi.IndexedEvent{},
+	}
+	if receipt.TransactionReceiptDataOnchain != nil && receipt.TransactionHash != nil {
+		evidence.Events, err = tm.blockIndexer.GetTransactionEventsByHash(ctx, *receipt.TransactionHash)
+		if err != nil {
+			return nil, err
+		}
+	}
+	evidence.Endorsements, err = tm.GetTransactionEndorsements(ctx, tm.p.NOTX(), id)
+	if err != nil {
+		return nil, err
+	}
+
+	payload, err := json.Marshal(evidence)
+	if err != nil {
+		return nil, err
+	}
+	payloadHash := pldtypes.Bytes32Keccak(payload)
+
+	signingKey := confutil.StringNotEmpty(tm.conf.Evidence.SigningKey, *pldconf.TxManagerDefaults.Evidence.SigningKey)
+	resolvedKey, err := tm.keyManager.ResolveKeyNewDatabaseTX(ctx, signingKey, algorithms.ECDSA_SECP256K1, verifiers.ETH_ADDRESS)
+	var signature []byte
+	if err == nil {
+		signature, err = tm.keyManager.Sign(ctx, resolvedKey, signpayloads.OPAQUE_TO_RSV, payloadHash[:])
+	}
+	if err != nil {
+		return nil, i18n.WrapError(ctx, err, msgs.MsgTxMgrEvidenceSigningFailed, id, signingKey)
+	}
+
+	return &pldapi.SignedTransactionEvidence{
+		Format:      pldapi.TransactionEvidenceFormatV1,
+		Payload:     payload,
+		PayloadHash: payloadHash,
+		Signer:      resolvedKey.Verifier,
+		Signature:   signature,
+	}, nil
+}
@@ -0,0 +1,235 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package txmgr
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hyperledger/firefly-signer/pkg/abi"
+	"github.com/hyperledger/firefly-signer/pkg/secp256k1"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/internal/components"
+	"github.com/kaleido-io/paladin/core/mocks/componentmocks"
+	"github.com/kaleido-io/paladin/core/pkg/persistence"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/toolkit/pkg/algorithms"
+	"github.com/kaleido-io/paladin/toolkit/pkg/signpayloads"
+	"github.com/kaleido-io/paladin/toolkit/pkg/verifiers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func mockEvidenceSigningKey(t *testing.T, mc *mockComponents, identifier string) *pldapi.KeyMappingAndVerifier {
+	kp, err := secp256k1.GenerateSecp256k1KeyPair()
+	require.NoError(t, err)
+	resolvedKey := &pldapi.KeyMappingAndVerifier{
+		KeyMappingWithPath: &pldapi.KeyMappingWithPath{KeyMapping: &pldapi.KeyMapping{Identifier: identifier}},
+		Verifier: &pldapi.KeyVerifier{
+			Verifier:  kp.Address.String(),
+			Type:      verifiers.ETH_ADDRESS,
+			Algorithm: algorithms.ECDSA_SECP256K1,
+		},
+	}
+	mc.keyManager.On("ResolveKeyNewDatabaseTX", mock.Anything, identifier, algorithms.ECDSA_SECP256K1, verifiers.ETH_ADDRESS).
+		Return(resolvedKey, nil)
+	mc.keyManager.On("Sign", mock.Anything, resolvedKey, signpayloads.OPAQUE_TO_RSV, mock.Anything).
+		Return(func(ctx context.Context, mapping *pldapi.KeyMappingAndVerifier, payloadType string, payload []byte) ([]byte, error) {
+			sig, err := kp.SignDirect(payload)
+			if err != nil {
+				return nil, err
+			}
+			return sig.CompactRSV(), nil
+		})
+	return resolvedKey
+}
+
+func TestExportTransactionEvidence(t *testing.T) {
+
+	txHash := pldtypes.RandBytes32()
+	var resolvedKey *pldapi.KeyMappingAndVerifier
+	ctx, txm, done := newTestTransactionManager(t, true, mockDomainContractResolve(t, "domain1"), func(conf *pldconf.TxManagerConfig, mc *mockComponents) {
+		mc.privateTxMgr.On("HandleNewTx", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+		mc.stateMgr.On("GetTransactionStates", mock.Anything, mock.Anything, mock.Anything).Return(
+			&pldapi.TransactionStates{None: true}, nil,
+		)
+
+		md := componentmocks.NewDomain(t)
+		mc.domainManager.On("GetDomainByName", mock.Anything, "domain1").Return(md, nil)
+		md.On("BuildDomainReceipt", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(pldtypes.RawJSON(`{"some":"receipt"}`), nil)
+
+		mc.blockIndexer.On("GetTransactionEventsByHash", mock.Anything, txHash).Return([]*pldapi.IndexedEvent{
+			{BlockNumber: 12345, TransactionIndex: 10, LogIndex: 5, TransactionHash: txHash, Signature: pldtypes.RandBytes32()},
+		}, nil)
+
+		conf.Evidence.SigningKey = confutil.P("evidence.key")
+		resolvedKey = mockEvidenceSigningKey(t, mc, "evidence.key")
+	})
+	defer done()
+
+	exampleABI := abi.ABI{{Type: abi.Function, Name: "doIt"}}
+	callData, err := exampleABI[0].EncodeCallDataJSON([]byte(`[]`))
+	require.NoError(t, err)
+
+	txID, err := txm.sendTransactionNewDBTX(ctx, &pldapi.TransactionInput{
+		TransactionBase: pldapi.TransactionBase{
+			From:     "me",
+			Type:     pldapi.TransactionTypePrivate.Enum(),
+			Domain:   "domain1",
+			Function: "doIt",
+			To:       pldtypes.RandAddress(),
+			Data:     pldtypes.JSONString(pldtypes.HexBytes(callData)),
+		},
+		ABI: exampleABI,
+	})
+	require.NoError(t, err)
+
+	notarySig := pldtypes.RandBytes(65)
+	err = txm.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) (err error) {
+		err = txm.WriteTransactionEndorsements(ctx, dbTX, []*pldapi.TransactionEndorsement{
+			{
+				TransactionID:   *txID,
+				Created:         pldtypes.TimestampNow(),
+				Name:            "sender",
+				AttestationType: "SIGN",
+				Lookup:          "me@node1",
+				Verifier:        &pldapi.KeyVerifier{Verifier: "0x1111", Algorithm: algorithms.ECDSA_SECP256K1, Type: verifiers.ETH_ADDRESS},
+			},
+			{
+				TransactionID:   *txID,
+				Created:         pldtypes.TimestampNow(),
+				Name:            "notary",
+				AttestationType: "ENDORSE",
+				Lookup:          "notary@node2",
+				Verifier:        &pldapi.KeyVerifier{Verifier: "0x2222", Algorithm: algorithms.ECDSA_SECP256K1, Type: verifiers.ETH_ADDRESS},
+				PayloadType:     signpayloads.OPAQUE_TO_RSV,
+				Payload:         notarySig,
+				Constraints:     []string{"ENDORSER_MUST_SUBMIT"},
+			},
+		})
+		if err == nil {
+			err = txm.FinalizeTransactions(ctx, dbTX, []*components.ReceiptInput{
+				{
+					TransactionID: *txID,
+					Domain:        "domain1",
+					ReceiptType:   components.RT_Success,
+					OnChain: pldtypes.OnChainLocation{
+						Type:             pldtypes.OnChainEvent,
+						TransactionHash:  txHash,
+						BlockNumber:      12345,
+						TransactionIndex: 10,
+						LogIndex:         5,
+						Source:           pldtypes.RandAddress(),
+					},
+				},
+			})
+		}
+		return err
+	})
+	require.NoError(t, err)
+
+	signed, err := txm.ExportTransactionEvidence(ctx, *txID)
+	require.NoError(t, err)
+	assert.Equal(t, pldapi.TransactionEvidenceFormatV1, signed.Format)
+	assert.Equal(t, resolvedKey.Verifier, signed.Signer)
+
+	evidence, err := signed.Verify(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, *txID, evidence.TransactionID)
+	assert.Equal(t, "node1", evidence.Node)
+	assert.True(t, evidence.Receipt.Success)
+	assert.JSONEq(t, `{"some":"receipt"}`, evidence.Receipt.DomainReceipt.String())
+	require.Len(t, evidence.Events, 1)
+	assert.Equal(t, int64(5), evidence.Events[0].LogIndex)
+	require.Len(t, evidence.Endorsements, 2)
+	assert.Equal(t, "sender", evidence.Endorsements[0].Name)
+	assert.Empty(t, evidence.Endorsements[0].PayloadType)
+	assert.Nil(t, evidence.Endorsements[0].Constraints)
+	assert.Equal(t, "notary", evidence.Endorsements[1].Name)
+	assert.Equal(t, "0x2222", evidence.Endorsements[1].Verifier.Verifier)
+	assert.Equal(t, pldtypes.HexBytes(notarySig), evidence.Endorsements[1].Payload)
+	assert.Equal(t, []string{"ENDORSER_MUST_SUBMIT"}, evidence.Endorsements[1].Constraints)
+}
+
+func TestExportTransactionEvidenceNoReceipt(t *testing.T) {
+	ctx, txm, done := newTestTransactionManager(t, true)
+	defer done()
+
+	_, err := txm.ExportTransactionEvidence(ctx, uuid.New())
+	assert.Regexp(t, "PD012254", err)
+}
+
+func TestExportTransactionEvidenceReceiptFail(t *testing.T) {
+	ctx, txm, done := newTestTransactionManager(t, false, mockEmptyReceiptListeners, func(conf *pldconf.TxManagerConfig, mc *mockComponents) {
+		mc.db.ExpectQuery("SELECT.*transaction_receipts").WillReturnError(fmt.Errorf("pop"))
+	})
+	defer done()
+
+	_, err := txm.ExportTransactionEvidence(ctx, uuid.New())
+	assert.Regexp(t, "pop", err)
+}
+
+func TestExportTransactionEvidenceEventsFail(t *testing.T) {
+	txHash := pldtypes.RandBytes32()
+	ctx, txm, done := newTestTransactionManager(t, false, mockEmptyReceiptListeners, func(conf *pldconf.TxManagerConfig, mc *mockComponents) {
+		mc.db.ExpectQuery("SELECT.*transaction_receipts").WillReturnRows(mc.db.NewRows([]string{"transaction", "tx_hash"}).AddRow(uuid.New(), txHash))
+		mc.blockIndexer.On("GetTransactionEventsByHash", mock.Anything, txHash).Return(nil, fmt.Errorf("pop"))
+	})
+	defer done()
+
+	_, err := txm.ExportTransactionEvidence(ctx, uuid.New())
+	assert.Regexp(t, "pop", err)
+}
+
+func TestExportTransactionEvidenceEndorsementsFail(t *testing.T) {
+	ctx, txm, done := newTestTransactionManager(t, false, mockEmptyReceiptListeners, func(conf *pldconf.TxManagerConfig, mc *mockComponents) {
+		mc.db.ExpectQuery("SELECT.*transaction_receipts").WillReturnRows(mc.db.NewRows([]string{"transaction"}).AddRow(uuid.New()))
+		mc.db.ExpectQuery("SELECT.*transaction_endorsements").WillReturnError(fmt.Errorf("pop"))
+	})
+	defer done()
+
+	_, err := txm.ExportTransactionEvidence(ctx, uuid.New())
+	assert.Regexp(t, "pop", err)
+}
+
+func TestExportTransactionEvidenceBadConstraints(t *testing.T) {
+	ctx, txm, done := newTestTransactionManager(t, false, mockEmptyReceiptListeners, func(conf *pldconf.TxManagerConfig, mc *mockComponents) {
+		mc.db.ExpectQuery("SELECT.*transaction_endorsements").WillReturnRows(mc.db.NewRows([]string{"transaction", "constraints"}).AddRow(uuid.New(), "!!!{bad"))
+	})
+	defer done()
+
+	_, err := txm.GetTransactionEndorsements(ctx, txm.p.NOTX(), uuid.New())
+	assert.Error(t, err)
+}
+
+func TestExportTransactionEvidenceSignFail(t *testing.T) {
+	ctx, txm, done := newTestTransactionManager(t, false, mockEmptyReceiptListeners, func(conf *pldconf.TxManagerConfig, mc *mockComponents) {
+		mc.db.ExpectQuery("SELECT.*transaction_receipts").WillReturnRows(mc.db.NewRows([]string{"transaction"}).AddRow(uuid.New()))
+		mc.db.ExpectQuery("SELECT.*transaction_endorsements").WillReturnRows(mc.db.NewRows([]string{}))
+		mc.keyManager.On("ResolveKeyNewDatabaseTX", mock.Anything, "node.evidence", algorithms.ECDSA_SECP256K1, verifiers.ETH_ADDRESS).
+			Return(nil, fmt.Errorf("pop"))
+	})
+	defer done()
+
+	_, err := txm.ExportTransactionEvidence(ctx, uuid.New())
+	assert.Regexp(t, "PD012255.*node.evidence.*pop", err)
+}
@@ -0,0 +1,275 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package txmgr
+
+import (
+	"context"
+	"math/big"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/core/internal/components"
+	"github.com/kaleido-io/paladin/core/internal/filters"
+	"github.com/kaleido-io/paladin/core/pkg/persistence"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/query"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const feeReportBatchSize = 1000
+
+// DB persisted record of the fee paid for a base ledger transaction submitted by this node
+type persistedTransactionFee struct {
+	TransactionHash   pldtypes.Bytes32     `gorm:"column:tx_hash;primaryKey"`
+	Transaction       uuid.UUID            `gorm:"column:transaction"`
+	Time              pldtypes.Timestamp   `gorm:"column:time"`
+	BlockNumber       int64                `gorm:"column:block_number"`
+	Signer            pldtypes.EthAddress  `gorm:"column:signer"`
+	Identity          string               `gorm:"column:identity"`
+	Tenant            string               `gorm:"column:tenant"`
+	Domain            *string              `gorm:"column:domain"`
+	GasUsed           int64                `gorm:"column:gas_used"`
+	EffectiveGasPrice *pldtypes.HexUint256 `gorm:"column:effective_gas_price"`
+	Fee               *pldtypes.HexUint256 `gorm:"column:fee"`
+}
+
+func (persistedTransactionFee) TableName() string {
+	return "transaction_fees"
+}
+
+var transactionFeeFilters = filters.FieldMap{
+	"transactionHash":   filters.Bytes32Field("tx_hash"),
+	"transaction":       filters.UUIDField(`"transaction"`),
+	"time":              filters.TimestampField(`"time"`),
+	"blockNumber":       filters.Int64Field("block_number"),
+	"signer":            filters.HexBytesField("signer"),
+	"identity":          filters.StringField("identity"),
+	"tenant":            filters.StringField("tenant"),
+	"domain":            filters.StringField("domain"),
+	"gasUsed":           filters.Int64Field("gas_used"),
+	"effectiveGasPrice": filters.Uint256Field("effective_gas_price"),
+	"fee":               filters.Uint256Field("fee"),
+}
+
+func mapTransactionFee(pf *persistedTransactionFee) *pldapi.TransactionFee {
+	f := &pldapi.TransactionFee{
+		TransactionHash:   pf.TransactionHash,
+		Transaction:       pf.Transaction,
+		Time:              pf.Time,
+		BlockNumber:       pf.BlockNumber,
+		Signer:            pf.Signer,
+		Identity:          pf.Identity,
+		Tenant:            pf.Tenant,
+		GasUsed:           pldtypes.HexUint64(pf.GasUsed),
+		EffectiveGasPrice: pf.EffectiveGasPrice,
+		Fee:               pf.Fee,
+	}
+	if pf.Domain != nil {
+		f.Domain = *pf.Domain
+	}
+	return f
+}
+
+// The tenant is the first segment of the identity, so "tenant1.app1.key1@node1" is charged to "tenant1"
+func identityTenant(identity string) string {
+	identity, _, _ = strings.Cut(identity, "@")
+	tenant, _, _ := strings.Cut(identity, ".")
+	return tenant
+}
+
+// Called by the block indexing routine with the base ledger transactions matched to Paladin transactions,
+// to record the fee paid for each in the same DB transaction as the receipts.
+func (tm *txManager) recordTransactionFees(ctx context.Context, dbTX persistence.DBTX, blocks []*pldapi.IndexedBlock, matches []*components.PublicTxMatch) error {
+	txIDs := make([]uuid.UUID, 0, len(matches))
+	for _, match := range matches {
+		if match.GasUsed != nil && match.EffectiveGasPrice != nil {
+			txIDs = append(txIDs, match.TransactionID)
+		} else {
+			log.L(ctx).Debugf("No fee information in the receipt for transaction %s hash=%s", match.TransactionID, match.Hash)
+		}
+	}
+	if len(txIDs) == 0 {
+		return nil
+	}
+
+	var txns []*persistedTransaction
+	err := dbTX.DB().
+		WithContext(ctx).
+		Select("id", `"from"`, "domain").
+		Where("id IN (?)", txIDs).
+		Find(&txns).
+		Error
+	if err != nil {
+		return err
+	}
+	txByID := make(map[uuid.UUID]*persistedTransaction, len(txns))
+	for _, txn := range txns {
+		txByID[txn.ID] = txn
+	}
+	blockTimes := make(map[int64]pldtypes.Timestamp, len(blocks))
+	for _, block := range blocks {
+		blockTimes[block.Number] = block.Timestamp
+	}
+
+	fees := make([]*persistedTransactionFee, 0, len(txIDs))
+	for _, match := range matches {
+		txn := txByID[match.TransactionID]
+		if txn == nil || match.From == nil || match.GasUsed == nil || match.EffectiveGasPrice == nil {
+			continue
+		}
+		fee := new(big.Int).Mul(new(big.Int).SetUint64(match.GasUsed.Uint64()), match.EffectiveGasPrice.Int())
+		blockTime, ok := blockTimes[match.BlockNumber]
+		if !ok {
+			blockTime = pldtypes.TimestampNow()
+		}
+		fees = append(fees, &persistedTransactionFee{
+			TransactionHash:   match.Hash,
+			Transaction:       match.TransactionID,
+			Time:              blockTime,
+			BlockNumber:       match.BlockNumber,
+			Signer:            *match.From,
+			Identity:          txn.From,
+			Tenant:            identityTenant(txn.From),
+			Domain:            txn.Domain,
+			GasUsed:           int64(match.GasUsed.Uint64()),
+			EffectiveGasPrice: match.EffectiveGasPrice,
+			Fee:               (*pldtypes.HexUint256)(fee),
+		})
+	}
+	if len(fees) == 0 {
+		return nil
+	}
+	// Only way of duplicates should be a rewind of the block indexer
+	return dbTX.DB().
+		WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "tx_hash"}},
+			DoNothing: true,
+		}).
+		Create(fees).
+		Error
+}
+
+func (tm *txManager) QueryTransactionFees(ctx context.Context, dbTX persistence.DBTX, jq *query.QueryJSON) ([]*pldapi.TransactionFee, error) {
+	qw := &filters.QueryWrapper[persistedTransactionFee, pldapi.TransactionFee]{
+		P:           tm.p,
+		Table:       "transaction_fees",
+		DefaultSort: "-time",
+		Filters:     transactionFeeFilters,
+		Query:       jq,
+		MapResult: func(pf *persistedTransactionFee) (*pldapi.TransactionFee, error) {
+			return mapTransactionFee(pf), nil
+		},
+	}
+	return qw.Run(ctx, dbTX)
+}
+
+// GetTransactionFeeReport totals the recorded fees in groups, reading through the records in batches
+// as the totals can exceed what the database can sum with full precision
+func (tm *txManager) GetTransactionFeeReport(ctx context.Context, dbTX persistence.DBTX, input *pldapi.TransactionFeeReportInput) (*pldapi.TransactionFeeReport, error) {
+	groupBy := pldapi.TransactionFeeGroupByDay
+	if input.GroupBy != "" {
+		var err error
+		if groupBy, err = input.GroupBy.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	q := dbTX.DB().WithContext(ctx).Model(&persistedTransactionFee{})
+	if input.Since != nil {
+		q = q.Where(`"time" >= ?`, *input.Since)
+	}
+	if input.Until != nil {
+		q = q.Where(`"time" < ?`, *input.Until)
+	}
+	if input.Signer != nil {
+		q = q.Where("signer = ?", *input.Signer)
+	}
+	if input.Tenant != "" {
+		q = q.Where("tenant = ?", input.Tenant)
+	}
+	if input.Domain != "" {
+		q = q.Where("domain = ?", input.Domain)
+	}
+
+	type feeTotal struct {
+		transactions int
+		gasUsed      uint64
+		fee          *big.Int
+	}
+	totals := make(map[string]*feeTotal)
+	total := &feeTotal{fee: new(big.Int)}
+	var batch []*persistedTransactionFee
+	err := q.FindInBatches(&batch, feeReportBatchSize, func(_ *gorm.DB, _ int) error {
+		for _, pf := range batch {
+			var key string
+			switch groupBy {
+			case pldapi.TransactionFeeGroupBySigner:
+				key = pf.Signer.String()
+			case pldapi.TransactionFeeGroupByIdentity:
+				key = pf.Identity
+			case pldapi.TransactionFeeGroupByTenant:
+				key = pf.Tenant
+			case pldapi.TransactionFeeGroupByDomain:
+				if pf.Domain != nil {
+					key = *pf.Domain
+				}
+			default:
+				key = pf.Time.Time().UTC().Format(time.DateOnly)
+			}
+			t := totals[key]
+			if t == nil {
+				t = &feeTotal{fee: new(big.Int)}
+				totals[key] = t
+			}
+			for _, t := range []*feeTotal{t, total} {
+				t.transactions++
+				t.gasUsed += uint64(pf.GasUsed)
+				t.fee.Add(t.fee, pf.Fee.Int())
+			}
+		}
+		return nil
+	}).Error
+	if err != nil {
+		return nil, err
+	}
+
+	mapEntry := func(key string, t *feeTotal) *pldapi.TransactionFeeReportEntry {
+		return &pldapi.TransactionFeeReportEntry{
+			Key:          key,
+			Transactions: t.transactions,
+			GasUsed:      pldtypes.HexUint64(t.gasUsed),
+			Fee:          (*pldtypes.HexUint256)(t.fee),
+		}
+	}
+	report := &pldapi.TransactionFeeReport{
+		GroupBy: groupBy.Enum(),
+		Since:   input.Since,
+		Until:   input.Until,
+		Entries: make([]*pldapi.TransactionFeeReportEntry, 0, len(totals)),
+		Total:   mapEntry("", total),
+	}
+	for key, t := range totals {
+		report.Entries = append(report.Entries, mapEntry(key, t))
+	}
+	sort.Slice(report.Entries, func(i, j int) bool { return report.Entries[i].Key < report.Entries[j].Key })
+	return report, nil
+}
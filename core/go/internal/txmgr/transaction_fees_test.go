@@ -0,0 +1,239 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package txmgr
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hyperledger/firefly-signer/pkg/abi"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/internal/components"
+	"github.com/kaleido-io/paladin/core/pkg/blockindexer"
+	"github.com/kaleido-io/paladin/core/pkg/persistence"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/query"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/rpcclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestFeeConfirm(block int64, gasUsed uint64, gasPrice uint64) *blockindexer.IndexedTransactionNotify {
+	txi := newTestConfirm()
+	txi.BlockNumber = block
+	txi.GasUsed = confutil.P(pldtypes.HexUint64(gasUsed))
+	txi.EffectiveGasPrice = pldtypes.Uint64ToUint256(gasPrice)
+	return txi
+}
+
+func TestIdentityTenant(t *testing.T) {
+	assert.Equal(t, "tenant1", identityTenant("tenant1.app1.key1@node1"))
+	assert.Equal(t, "tenant1", identityTenant("tenant1.key1"))
+	assert.Equal(t, "key1", identityTenant("key1@node1"))
+	assert.Equal(t, "key1", identityTenant("key1"))
+}
+
+func TestTransactionFeesRealDB(t *testing.T) {
+	day1 := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	day2 := day1.Add(24 * time.Hour)
+	blocks := []*pldapi.IndexedBlock{
+		{Number: 100, Timestamp: pldtypes.Timestamp(day1.UnixNano())},
+		{Number: 101, Timestamp: pldtypes.Timestamp(day2.UnixNano())},
+	}
+
+	txID1, txID2, txID3, txIDUnknown := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	txi1 := newTestFeeConfirm(100, 21000, 10)
+	txi2 := newTestFeeConfirm(100, 50000, 20)
+	txi3 := newTestFeeConfirm(101, 30000, 10)
+	txiNoFee := newTestConfirm()
+	txiUnknown := newTestFeeConfirm(101, 1, 1)
+	matches := []*components.PublicTxMatch{
+		{PaladinTXReference: components.PaladinTXReference{TransactionID: txID1, TransactionType: pldapi.TransactionTypePrivate.Enum()}, IndexedTransactionNotify: txi1},
+		{PaladinTXReference: components.PaladinTXReference{TransactionID: txID2, TransactionType: pldapi.TransactionTypePrivate.Enum()}, IndexedTransactionNotify: txi2},
+		{PaladinTXReference: components.PaladinTXReference{TransactionID: txID3, TransactionType: pldapi.TransactionTypePrivate.Enum()}, IndexedTransactionNotify: txi3},
+		{PaladinTXReference: components.PaladinTXReference{TransactionID: uuid.New(), TransactionType: pldapi.TransactionTypePrivate.Enum()}, IndexedTransactionNotify: txiNoFee},
+		{PaladinTXReference: components.PaladinTXReference{TransactionID: txIDUnknown, TransactionType: pldapi.TransactionTypePrivate.Enum()}, IndexedTransactionNotify: txiUnknown},
+	}
+
+	ctx, url, txm, done := newTestTransactionManagerWithRPC(t, func(conf *pldconf.TxManagerConfig, mc *mockComponents) {
+		mc.publicTxMgr.On("MatchUpdateConfirmedTransactions", mock.Anything, mock.Anything, mock.Anything).Return(matches, nil)
+		mc.publicTxMgr.On("NotifyConfirmPersisted", mock.Anything, matches)
+	})
+	defer done()
+
+	var abiRef *pldtypes.Bytes32
+	err := txm.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) (err error) {
+		abiRef, err = txm.storeABI(ctx, dbTX, abi.ABI{{Type: abi.Function, Name: "doIt"}})
+		return err
+	})
+	require.NoError(t, err)
+	for _, tx := range []*persistedTransaction{
+		{ID: txID1, From: "tenant1.app1.key1@node1", Domain: confutil.P("noto")},
+		{ID: txID2, From: "tenant1.app2.key1", Domain: confutil.P("zeto")},
+		{ID: txID3, From: "tenant2.key1", Domain: confutil.P("noto")},
+	} {
+		tx.Type = pldapi.TransactionTypePrivate.Enum()
+		tx.Created = pldtypes.TimestampNow()
+		tx.ABIReference = abiRef
+		require.NoError(t, txm.p.DB().Create(tx).Error)
+	}
+
+	// Index twice, as a rewind of the block indexer would
+	for i := 0; i < 2; i++ {
+		err = txm.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
+			return txm.blockIndexerPreCommit(ctx, dbTX, blocks,
+				[]*blockindexer.IndexedTransactionNotify{txi1, txi2, txi3, txiNoFee, txiUnknown})
+		})
+		require.NoError(t, err)
+	}
+
+	rpcClient, err := rpcclient.NewHTTPClient(ctx, &pldconf.HTTPClientConfig{URL: url})
+	require.NoError(t, err)
+
+	var fees []*pldapi.TransactionFee
+	err = rpcClient.CallRPC(ctx, &fees, "ptx_queryTransactionFees", query.NewQueryBuilder().Limit(10).Sort("blockNumber", "fee").Query())
+	require.NoError(t, err)
+	require.Len(t, fees, 3)
+	assert.Equal(t, txi1.Hash, fees[0].TransactionHash)
+	assert.Equal(t, txID1, fees[0].Transaction)
+	assert.Equal(t, *txi1.From, fees[0].Signer)
+	assert.Equal(t, "tenant1.app1.key1@node1", fees[0].Identity)
+	assert.Equal(t, "tenant1", fees[0].Tenant)
+	assert.Equal(t, "noto", fees[0].Domain)
+	assert.Equal(t, blocks[0].Timestamp, fees[0].Time)
+	assert.Equal(t, uint64(21000), fees[0].GasUsed.Uint64())
+	assert.Equal(t, int64(210000), fees[0].Fee.Int().Int64())
+	assert.Equal(t, txID2, fees[1].Transaction)
+	assert.Equal(t, int64(1000000), fees[1].Fee.Int().Int64())
+	assert.Equal(t, txID3, fees[2].Transaction)
+
+	err = rpcClient.CallRPC(ctx, &fees, "ptx_queryTransactionFees", query.NewQueryBuilder().Limit(10).GreaterThan("fee", 500000).Query())
+	require.NoError(t, err)
+	require.Len(t, fees, 1)
+	assert.Equal(t, txID2, fees[0].Transaction)
+
+	var report *pldapi.TransactionFeeReport
+	err = rpcClient.CallRPC(ctx, &report, "ptx_getTransactionFeeReport", &pldapi.TransactionFeeReportInput{})
+	require.NoError(t, err)
+	assert.Equal(t, pldapi.TransactionFeeGroupByDay, report.GroupBy.V())
+	require.Len(t, report.Entries, 2)
+	assert.Equal(t, "2025-03-01", report.Entries[0].Key)
+	assert.Equal(t, 2, report.Entries[0].Transactions)
+	assert.Equal(t, uint64(71000), report.Entries[0].GasUsed.Uint64())
+	assert.Equal(t, int64(1210000), report.Entries[0].Fee.Int().Int64())
+	assert.Equal(t, "2025-03-02", report.Entries[1].Key)
+	assert.Equal(t, int64(300000), report.Entries[1].Fee.Int().Int64())
+	assert.Equal(t, 3, report.Total.Transactions)
+	assert.Equal(t, int64(1510000), report.Total.Fee.Int().Int64())
+
+	err = rpcClient.CallRPC(ctx, &report, "ptx_getTransactionFeeReport", &pldapi.TransactionFeeReportInput{
+		GroupBy: pldapi.TransactionFeeGroupByTenant.Enum(),
+	})
+	require.NoError(t, err)
+	require.Len(t, report.Entries, 2)
+	assert.Equal(t, "tenant1", report.Entries[0].Key)
+	assert.Equal(t, int64(1210000), report.Entries[0].Fee.Int().Int64())
+	assert.Equal(t, "tenant2", report.Entries[1].Key)
+
+	err = rpcClient.CallRPC(ctx, &report, "ptx_getTransactionFeeReport", &pldapi.TransactionFeeReportInput{
+		GroupBy: pldapi.TransactionFeeGroupByIdentity.Enum(),
+		Tenant:  "tenant1",
+	})
+	require.NoError(t, err)
+	require.Len(t, report.Entries, 2)
+	assert.Equal(t, "tenant1.app1.key1@node1", report.Entries[0].Key)
+	assert.Equal(t, "tenant1.app2.key1", report.Entries[1].Key)
+
+	err = rpcClient.CallRPC(ctx, &report, "ptx_getTransactionFeeReport", &pldapi.TransactionFeeReportInput{
+		GroupBy: pldapi.TransactionFeeGroupByDomain.Enum(),
+		Since:   &blocks[1].Timestamp,
+	})
+	require.NoError(t, err)
+	require.Len(t, report.Entries, 1)
+	assert.Equal(t, "noto", report.Entries[0].Key)
+	assert.Equal(t, 1, report.Total.Transactions)
+
+	until := pldtypes.Timestamp(day2.UnixNano())
+	err = rpcClient.CallRPC(ctx, &report, "ptx_getTransactionFeeReport", &pldapi.TransactionFeeReportInput{
+		GroupBy: pldapi.TransactionFeeGroupBySigner.Enum(),
+		Until:   &until,
+		Signer:  txi2.From,
+		Domain:  "zeto",
+	})
+	require.NoError(t, err)
+	require.Len(t, report.Entries, 1)
+	assert.Equal(t, txi2.From.String(), report.Entries[0].Key)
+	assert.Equal(t, int64(1000000), report.Total.Fee.Int().Int64())
+
+	err = rpcClient.CallRPC(ctx, &report, "ptx_getTransactionFeeReport", &pldapi.TransactionFeeReportInput{
+		GroupBy: "wrong",
+	})
+	assert.Regexp(t, "PD020003", err)
+}
+
+func TestRecordTransactionFeesLookupFail(t *testing.T) {
+	ctx, txm, done := newTestTransactionManager(t, false, mockEmptyReceiptListeners, func(conf *pldconf.TxManagerConfig, mc *mockComponents) {
+		mc.db.ExpectBegin()
+		mc.db.ExpectQuery("SELECT.*transactions").WillReturnError(fmt.Errorf("pop"))
+		mc.db.ExpectRollback()
+	})
+	defer done()
+
+	err := txm.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
+		return txm.recordTransactionFees(ctx, dbTX, nil, []*components.PublicTxMatch{
+			{IndexedTransactionNotify: newTestFeeConfirm(100, 1, 1)},
+		})
+	})
+	assert.Regexp(t, "pop", err)
+}
+
+func TestBlockIndexerPreCommitFeesFail(t *testing.T) {
+	txi := newTestFeeConfirm(100, 1, 1)
+	ctx, txm, done := newTestTransactionManager(t, false, mockEmptyReceiptListeners, func(conf *pldconf.TxManagerConfig, mc *mockComponents) {
+		mc.publicTxMgr.On("MatchUpdateConfirmedTransactions", mock.Anything, mock.Anything, mock.Anything).Return([]*components.PublicTxMatch{
+			{
+				PaladinTXReference:       components.PaladinTXReference{TransactionID: uuid.New(), TransactionType: pldapi.TransactionTypePrivate.Enum()},
+				IndexedTransactionNotify: txi,
+			},
+		}, nil)
+		mc.db.ExpectBegin()
+		mockNoExternalTxWatches(mc)
+		mc.db.ExpectQuery("SELECT.*transactions").WillReturnError(fmt.Errorf("pop"))
+		mc.db.ExpectRollback()
+	})
+	defer done()
+
+	err := txm.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
+		return txm.blockIndexerPreCommit(ctx, dbTX, nil, []*blockindexer.IndexedTransactionNotify{txi})
+	})
+	assert.Regexp(t, "pop", err)
+}
+
+func TestGetTransactionFeeReportQueryFail(t *testing.T) {
+	ctx, txm, done := newTestTransactionManager(t, false, mockEmptyReceiptListeners, func(conf *pldconf.TxManagerConfig, mc *mockComponents) {
+		mc.db.ExpectQuery("SELECT.*transaction_fees").WillReturnError(fmt.Errorf("pop"))
+	})
+	defer done()
+
+	_, err := txm.GetTransactionFeeReport(ctx, txm.p.NOTX(), &pldapi.TransactionFeeReportInput{})
+	assert.Regexp(t, "pop", err)
+}
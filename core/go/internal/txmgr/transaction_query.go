@@ -41,6 +41,7 @@ var transactionFilters = filters.FieldMap{
 	"from":           filters.StringField(`"from"`),
 	"to":             filters.HexBytesField(`"to"`),
 	"type":           filters.StringField(`"type"`),
+	"tenant":         filters.StringField(`"transactions"."tenant"`),
 }
 
 func (tm *txManager) mapPersistedTXBase(pt *persistedTransaction) *pldapi.Transaction {
@@ -48,6 +49,7 @@ func (tm *txManager) mapPersistedTXBase(pt *persistedTransaction) *pldapi.Transa
 		ID:         &pt.ID,
 		Created:    pt.Created,
 		SubmitMode: pt.SubmitMode,
+		Tenant:     stringOrEmpty(pt.Tenant),
 		TransactionBase: pldapi.TransactionBase{
 			IdempotencyKey: stringOrEmpty(pt.IdempotencyKey),
 			Type:           pt.Type,
@@ -73,6 +75,9 @@ func (tm *txManager) mapPersistedTXFull(pt *persistedTransaction) *pldapi.Transa
 	for _, dep := range pt.TransactionDeps {
 		res.DependsOn = append(res.DependsOn, dep.DependsOn)
 	}
+	if pt.TransactionSchedule != nil {
+		res.Scheduled = mapPersistedTransactionSchedule(pt.TransactionSchedule)
+	}
 
 	return res
 }
@@ -124,7 +129,7 @@ func (tm *txManager) QueryTransactions(ctx context.Context, jq *query.QueryJSON,
 				q = q.Joins("TransactionReceipt").
 					Where(`"TransactionReceipt"."transaction" IS NULL`)
 			}
-			return q
+			return tenantScope(ctx, q, `"transactions"."tenant"`)
 		},
 		MapResult: func(pt *persistedTransaction) (*pldapi.Transaction, error) {
 			return tm.mapPersistedTXBase(pt), nil
@@ -175,12 +180,13 @@ func (tm *txManager) QueryTransactionsFullTx(ctx context.Context, jq *query.Quer
 		Finalize: func(q *gorm.DB) *gorm.DB {
 			q = q.
 				Preload("TransactionDeps").
+				Joins("TransactionSchedule").
 				Joins("TransactionReceipt")
 
 			if pending {
 				q = q.Where(`"TransactionReceipt"."transaction" IS NULL`)
 			}
-			return q
+			return tenantScope(ctx, q, `"transactions"."tenant"`)
 		},
 		MapResult: func(pt *persistedTransaction) (*pldapi.TransactionFull, error) {
 			return tm.mapPersistedTXFull(pt), nil
@@ -290,6 +296,12 @@ func (tm *txManager) GetResolvedTransactionByID(ctx context.Context, id uuid.UUI
 	return rtxs[0], nil
 }
 
+func (tm *txManager) EvictCachedTransactions(txIDs ...uuid.UUID) {
+	for _, id := range txIDs {
+		tm.txCache.Delete(id)
+	}
+}
+
 func (tm *txManager) GetTransactionByID(ctx context.Context, id uuid.UUID) (*pldapi.Transaction, error) {
 	ptxs, err := tm.QueryTransactions(ctx, query.NewQueryBuilder().Limit(1).Equal("id", id).Query(), tm.p.NOTX(), false)
 	if len(ptxs) == 0 || err != nil {
@@ -354,3 +366,7 @@ func (tm *txManager) GetPublicTransactionByNonce(ctx context.Context, from pldty
 func (tm *txManager) GetPublicTransactionByHash(ctx context.Context, hash pldtypes.Bytes32) (*pldapi.PublicTxWithBinding, error) {
 	return tm.publicTxMgr.GetPublicTransactionForHash(ctx, tm.p.NOTX(), hash)
 }
+
+func (tm *txManager) GetPublicTransactionRawSubmission(ctx context.Context, hash pldtypes.Bytes32) (*pldapi.PublicTxRawSubmission, error) {
+	return tm.publicTxMgr.GetRawSubmission(ctx, tm.p.NOTX(), hash)
+}
@@ -141,6 +141,17 @@ func TestGetResolvedTransactionByIDFail(t *testing.T) {
 	assert.Regexp(t, "pop", err)
 }
 
+func TestEvictCachedTransactions(t *testing.T) {
+	_, txm, done := newTestTransactionManager(t, false, mockEmptyReceiptListeners)
+	defer done()
+
+	txID := uuid.New()
+	txm.txCache.Set(txID, &components.ResolvedTransaction{})
+	txm.EvictCachedTransactions(txID)
+	_, found := txm.txCache.Get(txID)
+	assert.False(t, found)
+}
+
 func TestResolveABIReferencesAndCacheFail(t *testing.T) {
 	ctx, txm, done := newTestTransactionManager(t, false,
 		mockEmptyReceiptListeners,
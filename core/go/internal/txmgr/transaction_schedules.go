@@ -0,0 +1,215 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package txmgr
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/internal/components"
+	"github.com/kaleido-io/paladin/core/internal/msgs"
+	"github.com/kaleido-io/paladin/core/pkg/persistence"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/query"
+)
+
+// A transaction submitted with a notBefore time and/or block is persisted as normal, but
+// is held here rather than being passed to the private or public transaction manager.
+// For public transactions the validated submission (with the resolved signing address)
+// is stored, so that it can be written to the public transaction manager on release.
+type persistedTransactionSchedule struct {
+	Transaction    uuid.UUID           `gorm:"column:transaction;primaryKey"`
+	Scheduled      pldtypes.Timestamp  `gorm:"column:scheduled"`
+	NotBefore      *pldtypes.Timestamp `gorm:"column:not_before"`
+	NotBeforeBlock *pldtypes.HexUint64 `gorm:"column:not_before_block"`
+	PublicTx       pldtypes.RawJSON    `gorm:"column:public_tx"`
+	Released       *pldtypes.Timestamp `gorm:"column:released"`
+}
+
+func (persistedTransactionSchedule) TableName() string {
+	return "transaction_schedules"
+}
+
+func isScheduled(tx *pldapi.TransactionInput) bool {
+	return tx.NotBefore != nil || tx.NotBeforeBlock != nil
+}
+
+func newPersistedTransactionSchedule(txID uuid.UUID, tx *pldapi.TransactionInput) *persistedTransactionSchedule {
+	return &persistedTransactionSchedule{
+		Transaction:    txID,
+		Scheduled:      pldtypes.TimestampNow(),
+		NotBefore:      tx.NotBefore,
+		NotBeforeBlock: tx.NotBeforeBlock,
+	}
+}
+
+func mapPersistedTransactionSchedule(ps *persistedTransactionSchedule) *pldapi.TransactionSchedule {
+	return &pldapi.TransactionSchedule{
+		TransactionScheduleInput: pldapi.TransactionScheduleInput{
+			NotBefore:      ps.NotBefore,
+			NotBeforeBlock: ps.NotBeforeBlock,
+		},
+		Released: ps.Released,
+	}
+}
+
+func (tm *txManager) insertTransactionSchedules(ctx context.Context, dbTX persistence.DBTX, schedules []*persistedTransactionSchedule) error {
+	return dbTX.DB().
+		WithContext(ctx).
+		Create(schedules).
+		Error
+}
+
+func (tm *txManager) schedulerInit() {
+	tm.schedulerInterval = confutil.DurationMin(tm.conf.Transactions.Scheduler.Interval, 10*time.Millisecond, *pldconf.TxManagerDefaults.Transactions.Scheduler.Interval)
+	tm.schedulerBatchSize = confutil.IntMin(tm.conf.Transactions.Scheduler.BatchSize, 1, *pldconf.TxManagerDefaults.Transactions.Scheduler.BatchSize)
+}
+
+func (tm *txManager) startScheduler() {
+	if tm.schedulerDone == nil {
+		tm.schedulerInit()
+		tm.schedulerCtx, tm.schedulerCancel = context.WithCancel(log.WithLogField(tm.bgCtx, "role", "transaction-scheduler"))
+		tm.schedulerDone = make(chan struct{})
+		go tm.runScheduler()
+	}
+}
+
+func (tm *txManager) stopScheduler() {
+	if tm.schedulerDone != nil {
+		tm.schedulerCancel()
+		<-tm.schedulerDone
+		tm.schedulerDone = nil
+	}
+}
+
+func (tm *txManager) runScheduler() {
+	defer close(tm.schedulerDone)
+
+	ticker := time.NewTicker(tm.schedulerInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+		case <-tm.schedulerCtx.Done():
+			log.L(tm.schedulerCtx).Debugf("Transaction scheduler stopping")
+			return
+		}
+		// Keep releasing while we are finding full batches
+		for {
+			released, err := tm.releaseScheduledTransactions(tm.schedulerCtx)
+			if err != nil {
+				log.L(tm.schedulerCtx).Errorf("Failed to release scheduled transactions: %s", err)
+			}
+			if err != nil || released < tm.schedulerBatchSize {
+				break
+			}
+		}
+	}
+}
+
+func (tm *txManager) releaseScheduledTransactions(ctx context.Context) (int, error) {
+	// If we have not yet indexed any blocks, then no block based schedule can be met
+	blockHeight := int64(-1)
+	confirmed, err := tm.blockIndexer.GetConfirmedBlockHeight(ctx)
+	if err == nil {
+		blockHeight = int64(confirmed)
+	}
+
+	var schedules []*persistedTransactionSchedule
+	err = tm.p.DB().
+		WithContext(ctx).
+		Where("released IS NULL").
+		Where("not_before IS NULL OR not_before <= ?", pldtypes.TimestampNow()).
+		Where("not_before_block IS NULL OR not_before_block <= ?", blockHeight).
+		Order("scheduled").
+		Limit(tm.schedulerBatchSize).
+		Find(&schedules).
+		Error
+	if err != nil {
+		return 0, err
+	}
+
+	for _, s := range schedules {
+		releaseErr := tm.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
+			return tm.releaseScheduledTransaction(ctx, dbTX, s)
+		})
+		if releaseErr != nil {
+			// The transaction would have been rejected if it had been submitted without a schedule,
+			// so we fail it with a receipt now (rather than leaving it scheduled indefinitely)
+			log.L(ctx).Errorf("Failed to release scheduled transaction %s: %s", s.Transaction, releaseErr)
+			err = tm.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
+				err := tm.FinalizeTransactions(ctx, dbTX, []*components.ReceiptInput{{
+					ReceiptType:    components.RT_FailedWithMessage,
+					TransactionID:  s.Transaction,
+					FailureMessage: i18n.NewError(ctx, msgs.MsgTxMgrScheduledReleaseFailed, releaseErr).Error(),
+				}})
+				if err == nil {
+					err = tm.markScheduleReleased(ctx, dbTX, s)
+				}
+				return err
+			})
+		}
+		if err != nil {
+			return 0, err
+		}
+	}
+	return len(schedules), nil
+}
+
+func (tm *txManager) releaseScheduledTransaction(ctx context.Context, dbTX persistence.DBTX, s *persistedTransactionSchedule) error {
+	log.L(ctx).Infof("Releasing scheduled transaction %s", s.Transaction)
+	rtxs, err := tm.QueryTransactionsResolved(ctx, query.NewQueryBuilder().Limit(1).Equal("id", s.Transaction).Query(), dbTX, false)
+	if err != nil {
+		return err
+	}
+	if len(rtxs) == 0 {
+		return i18n.NewError(ctx, msgs.MsgTxMgrTransactionNotFound, s.Transaction)
+	}
+	rtx := rtxs[0]
+
+	switch rtx.Transaction.Type.V() {
+	case pldapi.TransactionTypePublic:
+		var ptx components.PublicTxSubmission
+		if err := json.Unmarshal(s.PublicTx, &ptx); err != nil {
+			return err
+		}
+		if _, err := tm.publicTxMgr.WriteNewTransactions(ctx, dbTX, []*components.PublicTxSubmission{&ptx}); err != nil {
+			return err
+		}
+	case pldapi.TransactionTypePrivate:
+		if err := tm.privateTxMgr.HandleNewTx(ctx, dbTX, &components.ValidatedTransaction{ResolvedTransaction: *rtx}); err != nil {
+			return err
+		}
+	}
+	return tm.markScheduleReleased(ctx, dbTX, s)
+}
+
+func (tm *txManager) markScheduleReleased(ctx context.Context, dbTX persistence.DBTX, s *persistedTransactionSchedule) error {
+	return dbTX.DB().
+		WithContext(ctx).
+		Model(&persistedTransactionSchedule{}).
+		Where(`"transaction" = ?`, s.Transaction).
+		Update("released", pldtypes.TimestampNow()).
+		Error
+}
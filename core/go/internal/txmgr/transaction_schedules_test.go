@@ -0,0 +1,255 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package txmgr
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/hyperledger/firefly-signer/pkg/abi"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/internal/components"
+	"github.com/kaleido-io/paladin/core/pkg/persistence"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/query"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func sendScheduledPrivateTX(t *testing.T, ctx context.Context, txm *txManager, schedule pldapi.TransactionScheduleInput) uuid.UUID {
+	txIDs, err := txm.sendTransactionsNewDBTX(ctx, []*pldapi.TransactionInput{{
+		TransactionBase: pldapi.TransactionBase{
+			From:     "me",
+			Type:     pldapi.TransactionTypePrivate.Enum(),
+			Domain:   "domain1",
+			Function: "doIt",
+			To:       pldtypes.RandAddress(),
+			Data:     pldtypes.RawJSON(`{}`),
+		},
+		ABI:                      abi.ABI{{Type: abi.Function, Name: "doIt"}},
+		TransactionScheduleInput: schedule,
+	}})
+	require.NoError(t, err)
+	return txIDs[0]
+}
+
+func TestScheduledPublicTransactionByBlock(t *testing.T) {
+	senderAddr := pldtypes.RandAddress()
+	var publicTxs []*components.PublicTxSubmission
+	ctx, txm, done := newTestTransactionManager(t, true,
+		func(conf *pldconf.TxManagerConfig, mc *mockComponents) {
+			mockResolveKey(t, mc, "sender1", senderAddr)
+			mc.publicTxMgr.On("ValidateTransaction", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			mc.publicTxMgr.On("WriteNewTransactions", mock.Anything, mock.Anything, mock.Anything).
+				Return(nil, nil).
+				Run(func(args mock.Arguments) {
+					publicTxs = append(publicTxs, args[2].([]*components.PublicTxSubmission)...)
+				})
+			mc.publicTxMgr.On("QueryPublicTxForTransactions", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+				Return(map[uuid.UUID][]*pldapi.PublicTx{}, nil)
+			mc.blockIndexer.On("GetConfirmedBlockHeight", mock.Anything).Return(pldtypes.HexUint64(10), nil).Once()
+			mc.blockIndexer.On("GetConfirmedBlockHeight", mock.Anything).Return(pldtypes.HexUint64(11), nil).Once()
+		})
+	defer done()
+
+	to := pldtypes.RandAddress()
+	txIDs, err := txm.sendTransactionsNewDBTX(ctx, []*pldapi.TransactionInput{{
+		TransactionBase: pldapi.TransactionBase{
+			From:     "sender1",
+			Type:     pldapi.TransactionTypePublic.Enum(),
+			Function: "doIt",
+			To:       to,
+			Data:     pldtypes.RawJSON(`{}`),
+		},
+		ABI: abi.ABI{{Type: abi.Function, Name: "doIt"}},
+		TransactionScheduleInput: pldapi.TransactionScheduleInput{
+			NotBeforeBlock: confutil.P(pldtypes.HexUint64(11)),
+		},
+	}})
+	require.NoError(t, err)
+	txID := txIDs[0]
+	assert.Empty(t, publicTxs)
+
+	tx, err := txm.GetTransactionByIDFull(ctx, txID)
+	require.NoError(t, err)
+	require.NotNil(t, tx.Scheduled)
+	assert.Equal(t, uint64(11), tx.Scheduled.NotBeforeBlock.Uint64())
+	assert.Nil(t, tx.Scheduled.Released)
+
+	// Block 10 is not late enough
+	released, err := txm.releaseScheduledTransactions(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, released)
+	assert.Empty(t, publicTxs)
+
+	// Block 11 is
+	released, err = txm.releaseScheduledTransactions(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, released)
+	require.Len(t, publicTxs, 1)
+	assert.Equal(t, txID, publicTxs[0].Bindings[0].TransactionID)
+	assert.Equal(t, senderAddr, publicTxs[0].From)
+	assert.Equal(t, to, publicTxs[0].To)
+
+	tx, err = txm.GetTransactionByIDFull(ctx, txID)
+	require.NoError(t, err)
+	assert.NotNil(t, tx.Scheduled.Released)
+}
+
+func TestScheduledPrivateTransactionByTime(t *testing.T) {
+	var handled []uuid.UUID
+	ctx, txm, done := newTestTransactionManager(t, true,
+		mockDomainContractResolve(t, "domain1"),
+		func(conf *pldconf.TxManagerConfig, mc *mockComponents) {
+			mc.privateTxMgr.On("HandleNewTx", mock.Anything, mock.Anything, mock.Anything).
+				Return(nil).
+				Run(func(args mock.Arguments) {
+					txi := args[2].(*components.ValidatedTransaction)
+					assert.Equal(t, "doIt()", txi.Function.Signature)
+					handled = append(handled, *txi.Transaction.ID)
+				})
+			mc.blockIndexer.On("GetConfirmedBlockHeight", mock.Anything).Return(pldtypes.HexUint64(0), fmt.Errorf("no blocks yet"))
+		})
+	defer done()
+
+	past := pldtypes.Timestamp(time.Now().Add(-1 * time.Minute).UnixNano())
+	future := pldtypes.Timestamp(time.Now().Add(1 * time.Hour).UnixNano())
+	pastTxID := sendScheduledPrivateTX(t, ctx, txm, pldapi.TransactionScheduleInput{NotBefore: &past})
+	sendScheduledPrivateTX(t, ctx, txm, pldapi.TransactionScheduleInput{NotBefore: &future})
+	// No blocks have been indexed, so this cannot be released even though the time has passed
+	sendScheduledPrivateTX(t, ctx, txm, pldapi.TransactionScheduleInput{
+		NotBefore:      &past,
+		NotBeforeBlock: confutil.P(pldtypes.HexUint64(0)),
+	})
+	assert.Empty(t, handled)
+
+	released, err := txm.releaseScheduledTransactions(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, released)
+	assert.Equal(t, []uuid.UUID{pastTxID}, handled)
+
+	// Nothing further to release
+	released, err = txm.releaseScheduledTransactions(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, released)
+}
+
+func TestScheduledTransactionReleaseFail(t *testing.T) {
+	ctx, txm, done := newTestTransactionManager(t, true,
+		mockDomainContractResolve(t, "domain1"),
+		func(conf *pldconf.TxManagerConfig, mc *mockComponents) {
+			mc.privateTxMgr.On("HandleNewTx", mock.Anything, mock.Anything, mock.Anything).Return(fmt.Errorf("pop"))
+			mc.blockIndexer.On("GetConfirmedBlockHeight", mock.Anything).Return(pldtypes.HexUint64(100), nil)
+		})
+	defer done()
+
+	txID := sendScheduledPrivateTX(t, ctx, txm, pldapi.TransactionScheduleInput{
+		NotBeforeBlock: confutil.P(pldtypes.HexUint64(100)),
+	})
+
+	released, err := txm.releaseScheduledTransactions(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, released)
+
+	receipt, err := txm.GetTransactionReceiptByID(ctx, txID)
+	require.NoError(t, err)
+	require.NotNil(t, receipt)
+	assert.False(t, receipt.Success)
+	assert.Regexp(t, "PD012273.*pop", receipt.FailureMessage)
+
+	// Not retried
+	released, err = txm.releaseScheduledTransactions(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, released)
+}
+
+func TestScheduledTransactionReleaseNotFound(t *testing.T) {
+	ctx, txm, done := newTestTransactionManager(t, false, mockEmptyReceiptListeners, func(conf *pldconf.TxManagerConfig, mc *mockComponents) {
+		mc.db.ExpectBegin()
+		mc.db.ExpectQuery("SELECT.*transactions").WillReturnRows(sqlmock.NewRows([]string{}))
+		mc.db.ExpectRollback()
+	})
+	defer done()
+
+	err := txm.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
+		return txm.releaseScheduledTransaction(ctx, dbTX, &persistedTransactionSchedule{Transaction: uuid.New()})
+	})
+	assert.Regexp(t, "PD012244", err)
+}
+
+func TestScheduledTransactionQueryFail(t *testing.T) {
+	ctx, txm, done := newTestTransactionManager(t, false, mockEmptyReceiptListeners, func(conf *pldconf.TxManagerConfig, mc *mockComponents) {
+		mc.blockIndexer.On("GetConfirmedBlockHeight", mock.Anything).Return(pldtypes.HexUint64(0), nil)
+		mc.db.ExpectQuery("SELECT.*transaction_schedules").WillReturnError(fmt.Errorf("pop"))
+	})
+	defer done()
+
+	_, err := txm.releaseScheduledTransactions(ctx)
+	assert.Regexp(t, "pop", err)
+}
+
+func TestScheduledTransactionFailReceiptFail(t *testing.T) {
+	ctx, txm, done := newTestTransactionManager(t, false, mockEmptyReceiptListeners, func(conf *pldconf.TxManagerConfig, mc *mockComponents) {
+		mc.blockIndexer.On("GetConfirmedBlockHeight", mock.Anything).Return(pldtypes.HexUint64(0), nil)
+		mc.db.ExpectQuery("SELECT.*transaction_schedules").WillReturnRows(sqlmock.NewRows([]string{"transaction"}).AddRow(uuid.New()))
+		mc.db.ExpectBegin()
+		mc.db.ExpectQuery("SELECT.*transactions").WillReturnError(fmt.Errorf("pop1"))
+		mc.db.ExpectRollback()
+		mc.db.ExpectBegin()
+		mc.db.ExpectQuery("INSERT.*transaction_receipts").WillReturnError(fmt.Errorf("pop2"))
+		mc.db.ExpectRollback()
+	})
+	defer done()
+
+	_, err := txm.releaseScheduledTransactions(ctx)
+	assert.Regexp(t, "pop2", err)
+}
+
+func TestSchedulerLoop(t *testing.T) {
+	handled := make(chan uuid.UUID, 1)
+	ctx, txm, done := newTestTransactionManager(t, true,
+		mockDomainContractResolve(t, "domain1"),
+		func(conf *pldconf.TxManagerConfig, mc *mockComponents) {
+			conf.Transactions.Scheduler.Interval = confutil.P("10ms")
+			mc.privateTxMgr.On("HandleNewTx", mock.Anything, mock.Anything, mock.Anything).
+				Return(nil).
+				Run(func(args mock.Arguments) {
+					handled <- *args[2].(*components.ValidatedTransaction).Transaction.ID
+				})
+			mc.publicTxMgr.On("QueryPublicTxForTransactions", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+				Return(map[uuid.UUID][]*pldapi.PublicTx{}, nil)
+			mc.blockIndexer.On("GetConfirmedBlockHeight", mock.Anything).Return(pldtypes.HexUint64(0), nil)
+		})
+	defer done()
+
+	txID := sendScheduledPrivateTX(t, ctx, txm, pldapi.TransactionScheduleInput{
+		NotBefore: confutil.P(pldtypes.TimestampNow()),
+	})
+	assert.Equal(t, txID, <-handled)
+
+	txs, err := txm.QueryTransactionsFull(ctx, query.NewQueryBuilder().Equal("id", txID).Limit(1).Query(), txm.p.NOTX(), false)
+	require.NoError(t, err)
+	require.Len(t, txs, 1)
+	assert.NotNil(t, txs[0].Scheduled)
+}
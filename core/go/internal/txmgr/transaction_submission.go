@@ -34,6 +34,7 @@ import (
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/query"
 	"github.com/kaleido-io/paladin/toolkit/pkg/algorithms"
+	"github.com/kaleido-io/paladin/toolkit/pkg/rpcserver"
 	"github.com/kaleido-io/paladin/toolkit/pkg/verifiers"
 	"gorm.io/gorm/clause"
 )
@@ -42,19 +43,21 @@ import (
 // We keep this separate from the pldapi.TransactionXYZ interfaces that clients and applications use to interact
 // with this, so we have a separation of concerns on the GORM annotations and data serialization format
 type persistedTransaction struct {
-	ID                 uuid.UUID                             `gorm:"column:id;primaryKey"`
-	IdempotencyKey     *string                               `gorm:"column:idempotency_key"`
-	SubmitMode         pldtypes.Enum[pldapi.SubmitMode]      `gorm:"column:submit_mode"`
-	Type               pldtypes.Enum[pldapi.TransactionType] `gorm:"column:type"`
-	Created            pldtypes.Timestamp                    `gorm:"column:created;autoCreateTime:false"` // set by code before insert
-	ABIReference       *pldtypes.Bytes32                     `gorm:"column:abi_ref"`
-	Function           *string                               `gorm:"column:function"`
-	Domain             *string                               `gorm:"column:domain"`
-	From               string                                `gorm:"column:from"`
-	To                 *pldtypes.EthAddress                  `gorm:"column:to"`
-	Data               pldtypes.RawJSON                      `gorm:"column:data"` // we always store in JSON object format
-	TransactionDeps    []*transactionDep                     `gorm:"foreignKey:transaction;references:id"`
-	TransactionReceipt *transactionReceipt                   `gorm:"foreignKey:transaction;references:id"`
+	ID                  uuid.UUID                             `gorm:"column:id;primaryKey"`
+	IdempotencyKey      *string                               `gorm:"column:idempotency_key"`
+	SubmitMode          pldtypes.Enum[pldapi.SubmitMode]      `gorm:"column:submit_mode"`
+	Type                pldtypes.Enum[pldapi.TransactionType] `gorm:"column:type"`
+	Created             pldtypes.Timestamp                    `gorm:"column:created;autoCreateTime:false"` // set by code before insert
+	ABIReference        *pldtypes.Bytes32                     `gorm:"column:abi_ref"`
+	Function            *string                               `gorm:"column:function"`
+	Domain              *string                               `gorm:"column:domain"`
+	From                string                                `gorm:"column:from"`
+	To                  *pldtypes.EthAddress                  `gorm:"column:to"`
+	Data                pldtypes.RawJSON                      `gorm:"column:data"` // we always store in JSON object format
+	Tenant              *string                               `gorm:"column:tenant"`
+	TransactionDeps     []*transactionDep                     `gorm:"foreignKey:transaction;references:id"`
+	TransactionReceipt  *transactionReceipt                   `gorm:"foreignKey:transaction;references:id"`
+	TransactionSchedule *persistedTransactionSchedule         `gorm:"foreignKey:transaction;references:id"`
 }
 
 type transactionDep struct {
@@ -324,7 +327,7 @@ func (tm *txManager) callTransactionPublic(ctx context.Context, result any, call
 			Output(result)
 		if call.From != "" {
 			var senderAddr *pldtypes.EthAddress
-			senderAddr, err = tm.keyManager.ResolveEthAddressNewDatabaseTX(ctx, txi.LocalFrom)
+			senderAddr, err = tm.keyManager.ResolveEthAddressNewDatabaseTX(ctx, tm.signerForSubmission(txi.LocalFrom))
 			if err == nil {
 				callReq = callReq.Signer(senderAddr.String())
 			}
@@ -336,6 +339,95 @@ func (tm *txManager) callTransactionPublic(ctx context.Context, result any, call
 	return err
 }
 
+func (tm *txManager) dryRunTransactionNewDBTX(ctx context.Context, tx *pldapi.TransactionInput) (dryRun *pldapi.PublicTxDryRun, err error) {
+	// The same DB operations run as for a real submission (the ABI is stored, and a key might be allocated),
+	// so they run in a DB transaction that is always rolled back to leave nothing behind.
+	_ = tm.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
+		if dryRun, err = tm.DryRunTransaction(ctx, dbTX, tx); err != nil {
+			return err
+		}
+		return i18n.NewError(ctx, msgs.MsgTxMgrDryRunRollback)
+	})
+	return dryRun, err
+}
+
+// DryRunTransaction runs a public transaction through the same resolution, gas estimation, nonce assignment
+// and signing as a real submission, but returns the signed transaction rather than storing or submitting it
+func (tm *txManager) DryRunTransaction(ctx context.Context, dbTX persistence.DBTX, tx *pldapi.TransactionInput) (*pldapi.PublicTxDryRun, error) {
+	ptx, err := tm.resolvePublicTxSubmission(ctx, dbTX, tx)
+	if err != nil {
+		return nil, err
+	}
+	return tm.publicTxMgr.DryRunTransaction(ctx, dbTX, ptx)
+}
+
+func (tm *txManager) simulateTransactionNewDBTX(ctx context.Context, tx *pldapi.TransactionInput) (sim *pldapi.PublicTxSimulation, err error) {
+	err = tm.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) (err error) {
+		sim, err = tm.SimulateTransaction(ctx, dbTX, tx)
+		return err
+	})
+	return sim, err
+}
+
+// SimulateTransaction resolves a public transaction as for a real submission, then executes it against the
+// latest block returning the call trace, logs and storage changes - so reverts can be debugged before spending gas
+func (tm *txManager) SimulateTransaction(ctx context.Context, dbTX persistence.DBTX, tx *pldapi.TransactionInput) (*pldapi.PublicTxSimulation, error) {
+	ptx, err := tm.resolvePublicTxSubmission(ctx, dbTX, tx)
+	if err != nil {
+		return nil, err
+	}
+	return tm.publicTxMgr.SimulateTransaction(ctx, dbTX, ptx)
+}
+
+func (tm *txManager) estimateBatchImpactNewDBTX(ctx context.Context, txs []*pldapi.TransactionInput) (impact *pldapi.PublicTxBatchImpact, err error) {
+	err = tm.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) (err error) {
+		impact, err = tm.EstimateBatchImpact(ctx, dbTX, txs)
+		return err
+	})
+	return impact, err
+}
+
+// EstimateBatchImpact resolves a batch of public transactions as for a real submission, then predicts the nonces
+// they would take, what they could cost each signer in gas, and whether auto-fueling would be triggered to fund them
+func (tm *txManager) EstimateBatchImpact(ctx context.Context, dbTX persistence.DBTX, txs []*pldapi.TransactionInput) (*pldapi.PublicTxBatchImpact, error) {
+	ptxs := make([]*components.PublicTxSubmission, len(txs))
+	for i, tx := range txs {
+		ptx, err := tm.resolvePublicTxSubmission(ctx, dbTX, tx)
+		if err != nil {
+			return nil, err
+		}
+		ptxs[i] = ptx
+	}
+	return tm.publicTxMgr.EstimateBatchImpact(ctx, dbTX, ptxs)
+}
+
+func (tm *txManager) resolvePublicTxSubmission(ctx context.Context, dbTX persistence.DBTX, tx *pldapi.TransactionInput) (*components.PublicTxSubmission, error) {
+	if tx.Type.V() != pldapi.TransactionTypePublic {
+		return nil, i18n.NewError(ctx, msgs.MsgTxMgrDryRunPublicOnly)
+	}
+
+	txi, err := tm.resolveNewTransaction(ctx, dbTX, tx, pldapi.SubmitModeAuto)
+	if err != nil {
+		return nil, err
+	}
+
+	ptx := &components.PublicTxSubmission{
+		PublicTxInput: pldapi.PublicTxInput{
+			To:              tx.To,
+			Data:            txi.PublicTxData,
+			PublicTxOptions: tx.PublicTxOptions,
+		},
+	}
+	resolvedKey, err := tm.keyManager.KeyResolverForDBTX(dbTX).ResolveKey(ctx, tm.signerForSubmission(txi.LocalFrom), algorithms.ECDSA_SECP256K1, verifiers.ETH_ADDRESS)
+	if err == nil {
+		ptx.From, err = pldtypes.ParseEthAddress(resolvedKey.Verifier.Verifier)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return ptx, nil
+}
+
 func (tm *txManager) PrepareInternalPrivateTransaction(ctx context.Context, dbTX persistence.DBTX, tx *pldapi.TransactionInput, submitMode pldapi.SubmitMode) (*components.ValidatedTransaction, error) {
 	tx.Type = pldapi.TransactionTypePrivate.Enum()
 	if tx.IdempotencyKey == "" {
@@ -395,7 +487,24 @@ func (tm *txManager) SendTransactions(ctx context.Context, dbTX persistence.DBTX
 	return tm.processNewTransactions(ctx, dbTX, txs, pldapi.SubmitModeAuto)
 }
 
+// While draining, new transactions submitted through the API are rejected. Transactions submitted
+// internally are still accepted, as those are needed to complete the work that is already in flight.
+func (tm *txManager) SetDraining(ctx context.Context, draining bool) {
+	log.L(ctx).Infof("Transaction manager draining=%t", draining)
+	tm.draining.Store(draining)
+}
+
+func (tm *txManager) checkNotDraining(ctx context.Context) error {
+	if tm.draining.Load() {
+		return i18n.NewError(ctx, msgs.MsgTxMgrDraining)
+	}
+	return nil
+}
+
 func (tm *txManager) sendTransactionsNewDBTX(ctx context.Context, txs []*pldapi.TransactionInput) (txIDs []uuid.UUID, err error) {
+	if err := tm.checkNotDraining(ctx); err != nil {
+		return nil, err
+	}
 	err = tm.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) (err error) {
 		txIDs, err = tm.SendTransactions(ctx, dbTX, txs...)
 		return err
@@ -404,6 +513,9 @@ func (tm *txManager) sendTransactionsNewDBTX(ctx context.Context, txs []*pldapi.
 }
 
 func (tm *txManager) prepareTransactionsNewDBTX(ctx context.Context, txs []*pldapi.TransactionInput) (txIDs []uuid.UUID, err error) {
+	if err := tm.checkNotDraining(ctx); err != nil {
+		return nil, err
+	}
 	err = tm.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) (err error) {
 		txIDs, err = tm.PrepareTransactions(ctx, dbTX, txs...)
 		return err
@@ -422,18 +534,38 @@ func (tm *txManager) processNewTransactions(ctx context.Context, dbTX persistenc
 	// before we open the database transaction
 	var publicTxs []*components.PublicTxSubmission
 	var publicTxSenders []string
+	var publicTxSchedules []*persistedTransactionSchedule
+	var schedules []*persistedTransactionSchedule
 	txis := make([]*components.ValidatedTransaction, len(txs))
 	txIDs = make([]uuid.UUID, len(txs))
+	scheduled := make(map[uuid.UUID]bool)
+
+	if err := tm.checkTenantQuota(ctx, dbTX, len(txs)); err != nil {
+		return nil, err
+	}
 
 	for i, tx := range txs {
 		txi, err := tm.resolveNewTransaction(ctx, dbTX, tx, submitMode)
 		if err != nil {
 			return nil, err
 		}
+		if txi.Transaction.Type.V() == pldapi.TransactionTypePrivate {
+			if err := rpcserver.CheckTenantDomain(ctx, txi.Transaction.Domain); err != nil {
+				return nil, err
+			}
+		}
 		txID := *txi.Transaction.ID
 		txis[i] = txi
 		txIDs[i] = txID
+		// Scheduled transactions are persisted now, but only submitted for processing once released
+		var schedule *persistedTransactionSchedule
+		if isScheduled(tx) {
+			schedule = newPersistedTransactionSchedule(txID, tx)
+			schedules = append(schedules, schedule)
+			scheduled[txID] = true
+		}
 		if tx.Type.V() == pldapi.TransactionTypePublic {
+			publicTxSchedules = append(publicTxSchedules, schedule)
 			publicTxs = append(publicTxs, &components.PublicTxSubmission{
 				// Public transaction bound 1:1 with our parent transaction
 				Bindings: []*components.PaladinTXReference{{TransactionID: txID, TransactionType: pldapi.TransactionTypePublic.Enum()}},
@@ -451,7 +583,7 @@ func (tm *txManager) processNewTransactions(ctx context.Context, dbTX persistenc
 	if len(publicTxs) > 0 {
 		kr := tm.keyManager.KeyResolverForDBTX(dbTX)
 		for i, ptx := range publicTxs {
-			resolvedKey, err := kr.ResolveKey(ctx, publicTxSenders[i], algorithms.ECDSA_SECP256K1, verifiers.ETH_ADDRESS)
+			resolvedKey, err := kr.ResolveKey(ctx, tm.signerForSubmission(publicTxSenders[i]), algorithms.ECDSA_SECP256K1, verifiers.ETH_ADDRESS)
 			if err == nil {
 				ptx.From, err = pldtypes.ParseEthAddress(resolvedKey.Verifier.Verifier)
 			}
@@ -474,9 +606,24 @@ func (tm *txManager) processNewTransactions(ctx context.Context, dbTX persistenc
 		return nil, err
 	}
 
+	// Scheduled public transactions keep their validated submission until they are released
+	var immediatePublicTxs []*components.PublicTxSubmission
+	for i, ptx := range publicTxs {
+		if schedule := publicTxSchedules[i]; schedule != nil {
+			schedule.PublicTx = pldtypes.JSONString(ptx)
+		} else {
+			immediatePublicTxs = append(immediatePublicTxs, ptx)
+		}
+	}
+	if len(schedules) > 0 {
+		if err = tm.insertTransactionSchedules(ctx, dbTX, schedules); err != nil {
+			return nil, err
+		}
+	}
+
 	// Insert any public txns (validated above)
-	if len(publicTxs) > 0 {
-		if _, err = tm.publicTxMgr.WriteNewTransactions(ctx, dbTX, publicTxs); err != nil {
+	if len(immediatePublicTxs) > 0 {
+		if _, err = tm.publicTxMgr.WriteNewTransactions(ctx, dbTX, immediatePublicTxs); err != nil {
 			return nil, err
 		}
 	}
@@ -484,7 +631,7 @@ func (tm *txManager) processNewTransactions(ctx context.Context, dbTX persistenc
 	// TODO: Integrate with private TX manager persistence when available, as it will follow the
 	// same pattern as public transactions above
 	for _, txi := range txis {
-		if txi.Transaction.Type.V() == pldapi.TransactionTypePrivate {
+		if txi.Transaction.Type.V() == pldapi.TransactionTypePrivate && !scheduled[*txi.Transaction.ID] {
 			if err := tm.privateTxMgr.HandleNewTx(ctx, dbTX, txi); err != nil {
 				return nil, err
 			}
@@ -570,6 +717,13 @@ func (tm *txManager) resolveNewTransaction(ctx context.Context, dbTX persistence
 	// Update to normalized JSON in what we store
 	tx.TransactionBase.Data = normalizedJSON
 
+	// A tenant can only act as its own identities, including for prepare and call
+	if tx.From != "" {
+		if err := rpcserver.CheckTenantIdentity(ctx, tx.From); err != nil {
+			return nil, err
+		}
+	}
+
 	var localFrom string
 	bypassFromCheck := submitMode == pldapi.SubmitModePrepare || /* no checking on from for prepare */
 		(submitMode == pldapi.SubmitModeCall && tx.From == "") /* call is allowed no sender */
@@ -647,6 +801,7 @@ func (tm *txManager) insertTransactions(ctx context.Context, dbTX persistence.DB
 		tx.Created = pldtypes.TimestampNow()
 		tx.ABIReference = txi.Function.ABIReference
 		tx.Function = txi.Function.Signature
+		tx.Tenant = rpcserver.TenantName(ctx)
 		// Build the object to insert
 		ptxs[i] = &persistedTransaction{
 			ID:             *tx.ID,
@@ -660,6 +815,7 @@ func (tm *txManager) insertTransactions(ctx context.Context, dbTX persistence.DB
 			From:           tx.From,
 			To:             tx.To,
 			Data:           tx.Data,
+			Tenant:         notEmptyOrNull(tx.Tenant),
 		}
 		for _, d := range txi.DependsOn {
 			transactionDeps = append(transactionDeps, &transactionDep{
@@ -690,7 +846,7 @@ func (tm *txManager) insertTransactions(ctx context.Context, dbTX persistence.DB
 	insert := dbTX.DB().
 		WithContext(ctx).
 		Table("transactions").
-		Omit("TransactionDeps")
+		Omit("TransactionDeps", "TransactionSchedule")
 	if ignoreConflicts {
 		insert = insert.Clauses(clause.OnConflict{DoNothing: true})
 	}
@@ -772,6 +928,11 @@ func (tm *txManager) UpdateTransaction(ctx context.Context, id uuid.UUID, tx *pl
 		from, err = pldtypes.ParseEthAddress(oldTX.From)
 		if err != nil {
 			identifier := strings.Split(oldTX.From, "@")[0]
+			if tm.signingPools[identifier] != nil {
+				// the replacement must come from the pool member that holds the nonce
+				from = &pubTXs[id][0].From
+				return nil
+			}
 			kr := tm.keyManager.KeyResolverForDBTX(dbTX)
 			var resolvedKey *pldapi.KeyMappingAndVerifier
 			resolvedKey, err = kr.ResolveKey(ctx, identifier, algorithms.ECDSA_SECP256K1, verifiers.ETH_ADDRESS)
@@ -0,0 +1,358 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package webhooks
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/core/internal/components"
+	"github.com/kaleido-io/paladin/core/internal/filters"
+	"github.com/kaleido-io/paladin/core/internal/msgs"
+	"github.com/kaleido-io/paladin/core/pkg/persistence"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/query"
+)
+
+const (
+	headerWebhookID        = "X-Paladin-Webhook-Id"
+	headerWebhookTimestamp = "X-Paladin-Webhook-Timestamp"
+	headerWebhookSignature = "X-Paladin-Webhook-Signature"
+)
+
+type persistedDelivery struct {
+	ID             uuid.UUID                                   `gorm:"column:id;primaryKey"`
+	Webhook        string                                      `gorm:"column:webhook"`
+	Created        pldtypes.Timestamp                          `gorm:"column:created"`
+	EventType      pldtypes.Enum[pldapi.WebhookEventType]      `gorm:"column:event_type"`
+	Transaction    uuid.UUID                                   `gorm:"column:transaction"`
+	Sequence       uint64                                      `gorm:"column:sequence"`
+	Payload        pldtypes.RawJSON                            `gorm:"column:payload"`
+	Status         pldtypes.Enum[pldapi.WebhookDeliveryStatus] `gorm:"column:status"`
+	Attempts       int                                         `gorm:"column:attempts"`
+	LastAttempt    *pldtypes.Timestamp                         `gorm:"column:last_attempt"`
+	LastStatusCode *int                                        `gorm:"column:last_status_code"`
+	LastError      *string                                     `gorm:"column:last_error"`
+	Delivered      *pldtypes.Timestamp                         `gorm:"column:delivered"`
+}
+
+func (persistedDelivery) TableName() string {
+	return "webhook_deliveries"
+}
+
+func (pd *persistedDelivery) mapToAPI() *pldapi.WebhookDelivery {
+	return &pldapi.WebhookDelivery{
+		ID:             pd.ID,
+		Webhook:        pd.Webhook,
+		Created:        pd.Created,
+		EventType:      pd.EventType,
+		Transaction:    pd.Transaction,
+		Sequence:       pd.Sequence,
+		Status:         pd.Status,
+		Attempts:       pd.Attempts,
+		LastAttempt:    pd.LastAttempt,
+		LastStatusCode: pd.LastStatusCode,
+		LastError:      pd.LastError,
+		Delivered:      pd.Delivered,
+		Payload:        pd.Payload,
+	}
+}
+
+var deliveryFilters = filters.FieldMap{
+	"id":             filters.UUIDField("id"),
+	"webhook":        filters.StringField("webhook"),
+	"created":        filters.TimestampField("created"),
+	"eventType":      filters.StringField("event_type"),
+	"transaction":    filters.UUIDField(`"transaction"`),
+	"sequence":       filters.Int64Field("sequence"),
+	"status":         filters.StringField("status"),
+	"attempts":       filters.Int64Field("attempts"),
+	"lastAttempt":    filters.TimestampField("last_attempt"),
+	"lastStatusCode": filters.Int64Field("last_status_code"),
+	"lastError":      filters.StringField("last_error"),
+	"delivered":      filters.TimestampField("delivered"),
+}
+
+type deliveryJobPayload struct {
+	Delivery uuid.UUID `json:"delivery"`
+}
+
+// Receipts matching the filters of the webhook are recorded as pending deliveries, with a job to
+// attempt each, in the same DB transaction. So once the batch is acknowledged to the receipt
+// listener, every event is guaranteed to be attempted.
+func (wh *webhook) DeliverReceiptBatch(ctx context.Context, batchID uint64, receipts []*pldapi.TransactionReceiptFull) error {
+	wm := wh.wm
+	matched := make([]*pldapi.TransactionReceiptFull, 0, len(receipts))
+	for _, r := range receipts {
+		if wh.info.Filters.Domain != "" && r.Domain != wh.info.Filters.Domain {
+			continue
+		}
+		if len(wh.eventTypes) > 0 && !wh.eventTypes[receiptEventType(r)] {
+			continue
+		}
+		matched = append(matched, r)
+	}
+	if wh.info.Filters.Signer != "" && len(matched) > 0 {
+		var err error
+		if matched, err = wh.filterBySigner(ctx, matched); err != nil {
+			return err
+		}
+	}
+	if len(matched) == 0 {
+		return nil
+	}
+
+	now := pldtypes.TimestampNow()
+	deliveries := make([]*persistedDelivery, len(matched))
+	for i, r := range matched {
+		event := &pldapi.WebhookEvent{
+			ID:        uuid.New(),
+			Webhook:   wh.name,
+			EventType: receiptEventType(r).Enum(),
+			Created:   now,
+			Receipt:   r,
+		}
+		deliveries[i] = &persistedDelivery{
+			ID:          event.ID,
+			Webhook:     wh.name,
+			Created:     now,
+			EventType:   event.EventType,
+			Transaction: r.ID,
+			Sequence:    r.Sequence,
+			Payload:     pldtypes.JSONString(event),
+			Status:      pldapi.WebhookDeliveryStatusPending.Enum(),
+		}
+	}
+	log.L(ctx).Debugf("Webhook %s recording %d deliveries from receipt batch %d", wh.name, len(deliveries), batchID)
+	return wm.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
+		if err := dbTX.DB().WithContext(ctx).Create(deliveries).Error; err != nil {
+			return err
+		}
+		for _, d := range deliveries {
+			if err := wm.scheduleDelivery(ctx, dbTX, d.ID, nil); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func receiptEventType(r *pldapi.TransactionReceiptFull) pldapi.WebhookEventType {
+	if r.Success {
+		return pldapi.WebhookEventTypeTransactionSuccess
+	}
+	return pldapi.WebhookEventTypeTransactionFailed
+}
+
+// The signer is not on the receipt, so is looked up from the transactions in the batch
+func (wh *webhook) filterBySigner(ctx context.Context, receipts []*pldapi.TransactionReceiptFull) ([]*pldapi.TransactionReceiptFull, error) {
+	ids := make([]any, len(receipts))
+	for i, r := range receipts {
+		ids[i] = r.ID
+	}
+	txs, err := wh.wm.txManager.QueryTransactions(ctx, query.NewQueryBuilder().In("id", ids).Limit(len(ids)).Query(), wh.wm.p.NOTX(), false)
+	if err != nil {
+		return nil, err
+	}
+	signers := make(map[uuid.UUID]string, len(txs))
+	for _, tx := range txs {
+		signers[*tx.ID] = tx.From
+	}
+	matched := make([]*pldapi.TransactionReceiptFull, 0, len(receipts))
+	for _, r := range receipts {
+		if signers[r.ID] == wh.info.Filters.Signer {
+			matched = append(matched, r)
+		}
+	}
+	return matched, nil
+}
+
+func (wm *webhooksManager) scheduleDelivery(ctx context.Context, dbTX persistence.DBTX, id uuid.UUID, runAt *pldtypes.Timestamp) error {
+	_, err := wm.jobScheduler.ScheduleJob(ctx, dbTX, &components.ScheduleJobRequest{
+		JobType: deliveryJobType,
+		Payload: pldtypes.JSONString(&deliveryJobPayload{Delivery: id}),
+		RunAt:   runAt,
+	})
+	return err
+}
+
+// Each attempt is a one-shot job. The outcome of the attempt is recorded on the delivery, and the
+// next attempt scheduled with backoff, so an error is only returned to the job scheduler (for it
+// to retry the job) if the outcome could not be recorded.
+func (wm *webhooksManager) runDeliveryJob(ctx context.Context, job *pldapi.ScheduledJob) error {
+	var payload deliveryJobPayload
+	if err := json.Unmarshal(job.Payload, &payload); err != nil {
+		log.L(ctx).Errorf("Discarding webhook delivery job %s with invalid payload: %s", job.ID, err)
+		return nil
+	}
+	d, err := wm.getDelivery(ctx, wm.p.NOTX(), payload.Delivery)
+	if err != nil {
+		return err
+	}
+	if d == nil || d.Status.V() != pldapi.WebhookDeliveryStatusPending {
+		log.L(ctx).Debugf("Skipping webhook delivery %s as it is no longer pending", payload.Delivery)
+		return nil
+	}
+
+	now := pldtypes.TimestampNow()
+	updates := map[string]any{
+		"attempts":     d.Attempts + 1,
+		"last_attempt": now,
+	}
+	var runAt *pldtypes.Timestamp
+	wh := wm.webhooks[d.Webhook]
+	switch {
+	case wh == nil:
+		updates["status"] = pldapi.WebhookDeliveryStatusFailed.Enum()
+		updates["last_error"] = i18n.NewError(ctx, msgs.MsgWebhooksNotConfigured, d.Webhook).Error()
+	default:
+		statusCode, postErr := wh.post(ctx, d)
+		if statusCode > 0 {
+			updates["last_status_code"] = statusCode
+		}
+		switch {
+		case postErr == nil:
+			updates["status"] = pldapi.WebhookDeliveryStatusDelivered.Enum()
+			updates["last_error"] = nil
+			updates["delivered"] = now
+		case wh.maxAttempts <= 0 || d.Attempts+1 < wh.maxAttempts:
+			updates["last_error"] = postErr.Error()
+			nextAttempt := pldtypes.Timestamp(time.Now().Add(wh.retry.Delay(d.Attempts + 1)).UnixNano())
+			runAt = &nextAttempt
+			log.L(ctx).Warnf("Webhook %s delivery %s attempt %d failed (retrying at %s): %s", wh.name, d.ID, d.Attempts+1, runAt, postErr)
+		default:
+			updates["status"] = pldapi.WebhookDeliveryStatusFailed.Enum()
+			updates["last_error"] = postErr.Error()
+			log.L(ctx).Errorf("Webhook %s delivery %s failed after %d attempts: %s", wh.name, d.ID, d.Attempts+1, postErr)
+		}
+	}
+	return wm.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
+		if err := dbTX.DB().WithContext(ctx).
+			Model(&persistedDelivery{}).
+			Where("id = ?", d.ID).
+			Updates(updates).
+			Error; err != nil {
+			return err
+		}
+		if runAt != nil {
+			return wm.scheduleDelivery(ctx, dbTX, d.ID, runAt)
+		}
+		return nil
+	})
+}
+
+// The payload is sent with a signature over the timestamp and the body, so the endpoint can
+// verify the event came from this node, and reject replays of old events.
+func (wh *webhook) post(ctx context.Context, d *persistedDelivery) (int, error) {
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req := wh.client.R().
+		SetContext(ctx).
+		SetHeader("Content-Type", "application/json").
+		SetHeader(headerWebhookID, d.ID.String()).
+		SetHeader(headerWebhookTimestamp, timestamp).
+		SetBody([]byte(d.Payload))
+	if wh.secret != nil {
+		req.SetHeader(headerWebhookSignature, "sha256="+wh.sign(timestamp, d.Payload))
+	}
+	res, err := req.Post("")
+	if err != nil {
+		return -1, err
+	}
+	if !res.IsSuccess() {
+		return res.StatusCode(), i18n.NewError(ctx, msgs.MsgWebhooksUnexpectedStatus, res.StatusCode())
+	}
+	return res.StatusCode(), nil
+}
+
+func (wh *webhook) sign(timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, wh.secret)
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (wm *webhooksManager) getDelivery(ctx context.Context, dbTX persistence.DBTX, id uuid.UUID) (*persistedDelivery, error) {
+	var deliveries []*persistedDelivery
+	err := dbTX.DB().WithContext(ctx).
+		Where("id = ?", id).
+		Limit(1).
+		Find(&deliveries).
+		Error
+	if err != nil || len(deliveries) == 0 {
+		return nil, err
+	}
+	return deliveries[0], nil
+}
+
+func (wm *webhooksManager) QueryDeliveries(ctx context.Context, dbTX persistence.DBTX, jq *query.QueryJSON) ([]*pldapi.WebhookDelivery, error) {
+	qw := &filters.QueryWrapper[persistedDelivery, pldapi.WebhookDelivery]{
+		P:           wm.p,
+		DefaultSort: "-created",
+		Filters:     deliveryFilters,
+		Query:       jq,
+		MapResult: func(pd *persistedDelivery) (*pldapi.WebhookDelivery, error) {
+			return pd.mapToAPI(), nil
+		},
+	}
+	return qw.Run(ctx, dbTX)
+}
+
+func (wm *webhooksManager) GetDelivery(ctx context.Context, dbTX persistence.DBTX, id uuid.UUID) (*pldapi.WebhookDelivery, error) {
+	d, err := wm.getDelivery(ctx, dbTX, id)
+	if err != nil || d == nil {
+		return nil, err
+	}
+	return d.mapToAPI(), nil
+}
+
+func (wm *webhooksManager) RetryDelivery(ctx context.Context, id uuid.UUID) (delivery *pldapi.WebhookDelivery, err error) {
+	err = wm.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
+		d, err := wm.getDelivery(ctx, dbTX, id)
+		if err != nil {
+			return err
+		}
+		switch {
+		case d == nil:
+			return i18n.NewError(ctx, msgs.MsgWebhooksDeliveryNotFound, id)
+		case wm.webhooks[d.Webhook] == nil:
+			return i18n.NewError(ctx, msgs.MsgWebhooksNotConfigured, d.Webhook)
+		case d.Status.V() == pldapi.WebhookDeliveryStatusPending:
+			return i18n.NewError(ctx, msgs.MsgWebhooksDeliveryPending, id)
+		}
+		d.Status = pldapi.WebhookDeliveryStatusPending.Enum()
+		d.Attempts = 0
+		if err := dbTX.DB().WithContext(ctx).
+			Model(&persistedDelivery{}).
+			Where("id = ?", d.ID).
+			Updates(map[string]any{"status": d.Status, "attempts": d.Attempts}).
+			Error; err != nil {
+			return err
+		}
+		delivery = d.mapToAPI()
+		return wm.scheduleDelivery(ctx, dbTX, d.ID, nil)
+	})
+	return delivery, err
+}
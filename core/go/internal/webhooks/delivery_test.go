@@ -0,0 +1,290 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package webhooks
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/query"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func testReceipt(success bool, domain string) *pldapi.TransactionReceiptFull {
+	return &pldapi.TransactionReceiptFull{
+		TransactionReceipt: &pldapi.TransactionReceipt{
+			ID: uuid.New(),
+			TransactionReceiptData: pldapi.TransactionReceiptData{
+				Success: success,
+				Domain:  domain,
+			},
+		},
+	}
+}
+
+func testWebhookConfig(name, url string) *pldconf.WebhookConfig {
+	return &pldconf.WebhookConfig{
+		Name: name,
+		Retry: pldconf.RetryConfigWithMax{
+			RetryConfig: pldconf.RetryConfig{InitialDelay: confutil.P("1ms")},
+			MaxAttempts: confutil.P(2),
+		},
+		HTTPClientConfig: pldconf.HTTPClientConfig{URL: url},
+	}
+}
+
+// Runs each job scheduled since the last call, as the job scheduler would
+func (mc *mockComponents) runScheduled(t *testing.T, wm *webhooksManager) {
+	scheduled := mc.scheduled
+	mc.scheduled = nil
+	for _, req := range scheduled {
+		assert.Equal(t, deliveryJobType, req.JobType)
+		err := wm.runDeliveryJob(context.Background(), &pldapi.ScheduledJob{ID: uuid.New(), Payload: req.Payload})
+		require.NoError(t, err)
+	}
+}
+
+func queryAllDeliveries(t *testing.T, wm *webhooksManager) []*pldapi.WebhookDelivery {
+	deliveries, err := wm.QueryDeliveries(context.Background(), wm.p.NOTX(), query.NewQueryBuilder().Limit(100).Sort("created").Query())
+	require.NoError(t, err)
+	return deliveries
+}
+
+func TestDeliverReceiptBatchFilters(t *testing.T) {
+	ctx := context.Background()
+	conf := testWebhookConfig("hook1", "http://localhost:12345")
+	conf.Filters = pldconf.WebhookFiltersConfig{
+		EventTypes: []string{"transaction_success"},
+		Domain:     confutil.P("noto"),
+		Signer:     confutil.P("alice"),
+	}
+	wm, mc := newTestWebhooksManager(t, &pldconf.WebhooksConfig{Webhooks: []*pldconf.WebhookConfig{conf}})
+
+	byAlice := testReceipt(true, "noto")
+	byBob := testReceipt(true, "noto")
+	receipts := []*pldapi.TransactionReceiptFull{
+		byAlice,
+		byBob,
+		testReceipt(false, "noto"), // wrong event type
+		testReceipt(true, "pente"), // wrong domain
+		testReceipt(true, ""),      // public
+	}
+	mc.txManager.On("QueryTransactions", mock.Anything, mock.Anything, mock.Anything, false).Return([]*pldapi.Transaction{
+		{ID: &byAlice.ID, TransactionBase: pldapi.TransactionBase{From: "alice"}},
+		{ID: &byBob.ID, TransactionBase: pldapi.TransactionBase{From: "bob"}},
+	}, nil)
+
+	err := wm.webhooks["hook1"].DeliverReceiptBatch(ctx, 1, receipts)
+	require.NoError(t, err)
+
+	deliveries := queryAllDeliveries(t, wm)
+	require.Len(t, deliveries, 1)
+	d := deliveries[0]
+	assert.Equal(t, "hook1", d.Webhook)
+	assert.Equal(t, byAlice.ID, d.Transaction)
+	assert.Equal(t, pldapi.WebhookEventTypeTransactionSuccess, d.EventType.V())
+	assert.Equal(t, pldapi.WebhookDeliveryStatusPending, d.Status.V())
+	require.Len(t, mc.scheduled, 1)
+
+	var event pldapi.WebhookEvent
+	err = json.Unmarshal(d.Payload, &event)
+	require.NoError(t, err)
+	assert.Equal(t, d.ID, event.ID)
+	assert.Equal(t, byAlice.ID, event.Receipt.ID)
+
+	// Nothing to record if no receipt matches
+	err = wm.webhooks["hook1"].DeliverReceiptBatch(ctx, 2, []*pldapi.TransactionReceiptFull{byBob})
+	require.NoError(t, err)
+	assert.Len(t, queryAllDeliveries(t, wm), 1)
+}
+
+func TestDeliverReceiptBatchSignerLookupFail(t *testing.T) {
+	conf := testWebhookConfig("hook1", "http://localhost:12345")
+	conf.Filters.Signer = confutil.P("alice")
+	wm, mc := newTestWebhooksManager(t, &pldconf.WebhooksConfig{Webhooks: []*pldconf.WebhookConfig{conf}})
+
+	mc.txManager.On("QueryTransactions", mock.Anything, mock.Anything, mock.Anything, false).Return(nil, fmt.Errorf("pop"))
+
+	err := wm.webhooks["hook1"].DeliverReceiptBatch(context.Background(), 1, []*pldapi.TransactionReceiptFull{testReceipt(true, "")})
+	assert.Regexp(t, "pop", err)
+}
+
+func TestDeliverySignedSuccess(t *testing.T) {
+	ctx := context.Background()
+	var received []*http.Request
+	var bodies [][]byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received = append(received, r)
+		bodies = append(bodies, body)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	conf := testWebhookConfig("hook1", server.URL)
+	conf.SecretFile = confutil.P(writeSecretFile(t, "my-secret"))
+	wm, mc := newTestWebhooksManager(t, &pldconf.WebhooksConfig{Webhooks: []*pldconf.WebhookConfig{conf}})
+
+	receipt := testReceipt(false, "")
+	err := wm.webhooks["hook1"].DeliverReceiptBatch(ctx, 1, []*pldapi.TransactionReceiptFull{receipt})
+	require.NoError(t, err)
+	mc.runScheduled(t, wm)
+
+	require.Len(t, received, 1)
+	req := received[0]
+	assert.Equal(t, http.MethodPost, req.Method)
+	assert.Equal(t, "application/json", req.Header.Get("Content-Type"))
+
+	var event pldapi.WebhookEvent
+	err = json.Unmarshal(bodies[0], &event)
+	require.NoError(t, err)
+	assert.Equal(t, event.ID.String(), req.Header.Get(headerWebhookID))
+	assert.Equal(t, pldapi.WebhookEventTypeTransactionFailed, event.EventType.V())
+	assert.Equal(t, receipt.ID, event.Receipt.ID)
+
+	mac := hmac.New(sha256.New, []byte("my-secret"))
+	mac.Write([]byte(req.Header.Get(headerWebhookTimestamp) + "."))
+	mac.Write(bodies[0])
+	assert.Equal(t, "sha256="+hex.EncodeToString(mac.Sum(nil)), req.Header.Get(headerWebhookSignature))
+
+	d, err := wm.GetDelivery(ctx, wm.p.NOTX(), event.ID)
+	require.NoError(t, err)
+	assert.Equal(t, pldapi.WebhookDeliveryStatusDelivered, d.Status.V())
+	assert.Equal(t, 1, d.Attempts)
+	assert.Equal(t, http.StatusAccepted, *d.LastStatusCode)
+	assert.NotNil(t, d.Delivered)
+	assert.Nil(t, d.LastError)
+
+	// A second run of the job does not deliver again
+	err = wm.runDeliveryJob(ctx, &pldapi.ScheduledJob{Payload: pldtypes.JSONString(&deliveryJobPayload{Delivery: d.ID})})
+	require.NoError(t, err)
+	assert.Len(t, received, 1)
+}
+
+func TestDeliveryRetryThenFail(t *testing.T) {
+	ctx := context.Background()
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Empty(t, r.Header.Get(headerWebhookSignature))
+		attempts++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	wm, mc := newTestWebhooksManager(t, &pldconf.WebhooksConfig{Webhooks: []*pldconf.WebhookConfig{testWebhookConfig("hook1", server.URL)}})
+
+	err := wm.webhooks["hook1"].DeliverReceiptBatch(ctx, 1, []*pldapi.TransactionReceiptFull{testReceipt(true, "")})
+	require.NoError(t, err)
+
+	mc.runScheduled(t, wm)
+	deliveries := queryAllDeliveries(t, wm)
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, pldapi.WebhookDeliveryStatusPending, deliveries[0].Status.V())
+	assert.Equal(t, 1, deliveries[0].Attempts)
+	assert.Equal(t, http.StatusServiceUnavailable, *deliveries[0].LastStatusCode)
+	assert.Regexp(t, "PD012907", *deliveries[0].LastError)
+	require.Len(t, mc.scheduled, 1)
+	assert.NotNil(t, mc.scheduled[0].RunAt)
+
+	mc.runScheduled(t, wm)
+	deliveries = queryAllDeliveries(t, wm)
+	assert.Equal(t, pldapi.WebhookDeliveryStatusFailed, deliveries[0].Status.V())
+	assert.Equal(t, 2, deliveries[0].Attempts)
+	assert.Empty(t, mc.scheduled)
+	assert.Equal(t, 2, attempts)
+
+	// A manual retry starts a fresh set of attempts
+	d, err := wm.RetryDelivery(ctx, deliveries[0].ID)
+	require.NoError(t, err)
+	assert.Equal(t, pldapi.WebhookDeliveryStatusPending, d.Status.V())
+	assert.Zero(t, d.Attempts)
+	require.Len(t, mc.scheduled, 1)
+
+	_, err = wm.RetryDelivery(ctx, deliveries[0].ID)
+	assert.Regexp(t, "PD012906", err)
+
+	mc.runScheduled(t, wm)
+	assert.Equal(t, 3, attempts)
+}
+
+func TestDeliveryConnectionFailure(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.Close()
+
+	wm, mc := newTestWebhooksManager(t, &pldconf.WebhooksConfig{Webhooks: []*pldconf.WebhookConfig{testWebhookConfig("hook1", server.URL)}})
+
+	err := wm.webhooks["hook1"].DeliverReceiptBatch(ctx, 1, []*pldapi.TransactionReceiptFull{testReceipt(true, "")})
+	require.NoError(t, err)
+	mc.runScheduled(t, wm)
+
+	deliveries := queryAllDeliveries(t, wm)
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, pldapi.WebhookDeliveryStatusPending, deliveries[0].Status.V())
+	assert.Nil(t, deliveries[0].LastStatusCode)
+	assert.NotNil(t, deliveries[0].LastError)
+}
+
+func TestDeliveryWebhookRemoved(t *testing.T) {
+	ctx := context.Background()
+	wm, mc := newTestWebhooksManager(t, &pldconf.WebhooksConfig{Webhooks: []*pldconf.WebhookConfig{testWebhookConfig("hook1", "http://localhost:12345")}})
+
+	err := wm.webhooks["hook1"].DeliverReceiptBatch(ctx, 1, []*pldapi.TransactionReceiptFull{testReceipt(true, "")})
+	require.NoError(t, err)
+	delete(wm.webhooks, "hook1")
+	mc.runScheduled(t, wm)
+
+	deliveries := queryAllDeliveries(t, wm)
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, pldapi.WebhookDeliveryStatusFailed, deliveries[0].Status.V())
+	assert.Regexp(t, "PD012905", *deliveries[0].LastError)
+
+	_, err = wm.RetryDelivery(ctx, deliveries[0].ID)
+	assert.Regexp(t, "PD012905", err)
+}
+
+func TestDeliveryJobBadPayloadOrMissing(t *testing.T) {
+	ctx := context.Background()
+	wm, _ := newTestWebhooksManager(t, &pldconf.WebhooksConfig{})
+
+	err := wm.runDeliveryJob(ctx, &pldapi.ScheduledJob{Payload: pldtypes.RawJSON(`{!`)})
+	require.NoError(t, err)
+
+	err = wm.runDeliveryJob(ctx, &pldapi.ScheduledJob{Payload: pldtypes.JSONString(&deliveryJobPayload{Delivery: uuid.New()})})
+	require.NoError(t, err)
+
+	d, err := wm.GetDelivery(ctx, wm.p.NOTX(), uuid.New())
+	require.NoError(t, err)
+	assert.Nil(t, d)
+
+	_, err = wm.RetryDelivery(ctx, uuid.New())
+	assert.Regexp(t, "PD012904", err)
+}
@@ -0,0 +1,201 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"os"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/internal/components"
+	"github.com/kaleido-io/paladin/core/internal/msgs"
+	"github.com/kaleido-io/paladin/core/pkg/persistence"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/query"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/retry"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/rpcclient"
+	"github.com/kaleido-io/paladin/toolkit/pkg/rpcserver"
+)
+
+const (
+	deliveryJobType       = "webhook_delivery"
+	receiptListenerPrefix = "webhook_"
+)
+
+type webhook struct {
+	wm          *webhooksManager
+	name        string
+	info        *pldapi.Webhook
+	eventTypes  map[pldapi.WebhookEventType]bool
+	secret      []byte
+	client      *resty.Client
+	retry       *retry.Retry
+	maxAttempts int
+	closer      components.ReceiverCloser
+}
+
+type webhooksManager struct {
+	bgCtx context.Context
+
+	conf         *pldconf.WebhooksConfig
+	p            persistence.Persistence
+	txManager    components.TXManager
+	jobScheduler components.JobScheduler
+	rpcModule    *rpcserver.RPCModule
+
+	webhooks     map[string]*webhook
+	webhookOrder []*webhook
+}
+
+func NewWebhooksManager(bgCtx context.Context, conf *pldconf.WebhooksConfig) components.WebhooksManager {
+	return &webhooksManager{
+		bgCtx:    log.WithLogField(bgCtx, "role", "webhooks-manager"),
+		conf:     conf,
+		webhooks: make(map[string]*webhook),
+	}
+}
+
+func (wm *webhooksManager) PreInit(c components.PreInitComponents) (*components.ManagerInitResult, error) {
+	wm.p = c.Persistence()
+	for _, whc := range wm.conf.Webhooks {
+		wh, err := wm.loadWebhook(wm.bgCtx, whc)
+		if err != nil {
+			return nil, err
+		}
+		wm.webhooks[wh.name] = wh
+		wm.webhookOrder = append(wm.webhookOrder, wh)
+	}
+	wm.initRPC()
+	return &components.ManagerInitResult{
+		RPCModules: []*rpcserver.RPCModule{wm.rpcModule},
+	}, nil
+}
+
+func (wm *webhooksManager) loadWebhook(ctx context.Context, whc *pldconf.WebhookConfig) (_ *webhook, err error) {
+	// The name is also used to name the receipt listener that feeds the webhook
+	if err := pldtypes.ValidateSafeCharsStartEndAlphaNum(ctx, whc.Name, pldtypes.DefaultNameMaxLen-len(receiptListenerPrefix), "name"); err != nil {
+		return nil, err
+	}
+	if wm.webhooks[whc.Name] != nil {
+		return nil, i18n.NewError(ctx, msgs.MsgWebhooksDuplicateName, whc.Name)
+	}
+	wh := &webhook{
+		wm:         wm,
+		name:       whc.Name,
+		eventTypes: make(map[pldapi.WebhookEventType]bool),
+		retry:      retry.NewRetryLimited(&whc.Retry, &pldconf.WebhookDefaults.Retry),
+		info: &pldapi.Webhook{
+			Name: whc.Name,
+			URL:  whc.URL,
+			Filters: pldapi.WebhookFilters{
+				Domain: pldtypes.StrOrEmpty(whc.Filters.Domain),
+				Signer: pldtypes.StrOrEmpty(whc.Filters.Signer),
+			},
+		},
+	}
+	wh.maxAttempts = wh.retry.MaxAttempts()
+	for _, et := range whc.Filters.EventTypes {
+		eventType, err := pldtypes.Enum[pldapi.WebhookEventType](et).Validate()
+		if err != nil {
+			return nil, i18n.WrapError(ctx, err, msgs.MsgWebhooksInvalidEventType, et, whc.Name)
+		}
+		wh.eventTypes[eventType] = true
+		wh.info.Filters.EventTypes = append(wh.info.Filters.EventTypes, eventType)
+	}
+	if whc.SecretFile != nil {
+		secret, err := os.ReadFile(*whc.SecretFile)
+		if err != nil {
+			return nil, i18n.WrapError(ctx, err, msgs.MsgWebhooksSecretFileError, whc.Name)
+		}
+		if wh.secret = bytes.TrimSpace(secret); len(wh.secret) == 0 {
+			return nil, i18n.NewError(ctx, msgs.MsgWebhooksSecretFileEmpty, whc.Name)
+		}
+		wh.info.Signed = true
+	}
+	if wh.client, err = rpcclient.ParseHTTPConfig(ctx, &whc.HTTPClientConfig); err != nil {
+		return nil, err
+	}
+	return wh, nil
+}
+
+func (wm *webhooksManager) PostInit(c components.AllComponents) error {
+	wm.txManager = c.TxManager()
+	wm.jobScheduler = c.JobScheduler()
+	wm.jobScheduler.RegisterHandler(deliveryJobType, wm.runDeliveryJob)
+	return nil
+}
+
+// Each webhook is fed by its own receipt listener, so the checkpoint of what has been
+// turned into deliveries survives restarts. A listener created for a new webhook starts
+// from the latest receipt, rather than replaying the whole history to the endpoint.
+func (wm *webhooksManager) Start() error {
+	ctx := wm.bgCtx
+	for _, wh := range wm.webhookOrder {
+		listenerName := receiptListenerPrefix + wh.name
+		if wm.txManager.GetReceiptListener(ctx, listenerName) == nil {
+			var sequenceAbove *uint64
+			latest, err := wm.txManager.QueryTransactionReceipts(ctx, query.NewQueryBuilder().Sort("-sequence").Limit(1).Query())
+			if err != nil {
+				return err
+			}
+			if len(latest) > 0 {
+				sequenceAbove = &latest[0].Sequence
+			}
+			err = wm.txManager.CreateReceiptListener(ctx, &pldapi.TransactionReceiptListener{
+				Name: listenerName,
+				Filters: pldapi.TransactionReceiptFilters{
+					SequenceAbove: sequenceAbove,
+				},
+				Options: pldapi.TransactionReceiptListenerOptions{
+					DomainReceipts:                 true,
+					IncompleteStateReceiptBehavior: pldapi.IncompleteStateReceiptBehaviorProcess.Enum(),
+				},
+			})
+			if err != nil {
+				return err
+			}
+		}
+		closer, err := wm.txManager.AddReceiptReceiver(ctx, listenerName, wh)
+		if err != nil {
+			return err
+		}
+		wh.closer = closer
+		log.L(ctx).Infof("Webhook %s delivering to %s", wh.name, wh.info.URL)
+	}
+	return nil
+}
+
+func (wm *webhooksManager) Stop() {
+	for _, wh := range wm.webhookOrder {
+		if wh.closer != nil {
+			wh.closer.Close()
+			wh.closer = nil
+		}
+	}
+}
+
+func (wm *webhooksManager) ListWebhooks(ctx context.Context) []*pldapi.Webhook {
+	webhooks := make([]*pldapi.Webhook, len(wm.webhookOrder))
+	for i, wh := range wm.webhookOrder {
+		webhooks[i] = wh.info
+	}
+	return webhooks
+}
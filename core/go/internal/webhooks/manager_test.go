@@ -0,0 +1,243 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package webhooks
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/internal/components"
+	"github.com/kaleido-io/paladin/core/mocks/componentmocks"
+	"github.com/kaleido-io/paladin/core/pkg/persistence"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type mockComponents struct {
+	txManager    *componentmocks.TXManager
+	jobScheduler *componentmocks.JobScheduler
+	scheduled    []*components.ScheduleJobRequest
+}
+
+func newTestWebhooksManager(t *testing.T, conf *pldconf.WebhooksConfig) (*webhooksManager, *mockComponents) {
+	p, pDone, err := persistence.NewUnitTestPersistence(context.Background(), "webhooks")
+	require.NoError(t, err)
+	t.Cleanup(pDone)
+
+	mc := &mockComponents{
+		txManager:    componentmocks.NewTXManager(t),
+		jobScheduler: componentmocks.NewJobScheduler(t),
+	}
+	mc.jobScheduler.On("RegisterHandler", deliveryJobType, mock.Anything).Return()
+	mc.jobScheduler.On("ScheduleJob", mock.Anything, mock.Anything, mock.Anything).Return(uuid.New(), nil).Run(func(args mock.Arguments) {
+		mc.scheduled = append(mc.scheduled, args[2].(*components.ScheduleJobRequest))
+	}).Maybe()
+	pic := componentmocks.NewPreInitComponents(t)
+	pic.On("Persistence").Return(p)
+	ac := componentmocks.NewAllComponents(t)
+	ac.On("TxManager").Return(mc.txManager)
+	ac.On("JobScheduler").Return(mc.jobScheduler)
+
+	wm := NewWebhooksManager(context.Background(), conf).(*webhooksManager)
+	ir, err := wm.PreInit(pic)
+	require.NoError(t, err)
+	assert.NotEmpty(t, ir.RPCModules)
+	err = wm.PostInit(ac)
+	require.NoError(t, err)
+	t.Cleanup(wm.Stop)
+	return wm, mc
+}
+
+func writeSecretFile(t *testing.T, secret string) string {
+	secretFile := filepath.Join(t.TempDir(), "secret")
+	err := os.WriteFile(secretFile, []byte(secret), 0600)
+	require.NoError(t, err)
+	return secretFile
+}
+
+func TestPreInitBadConfig(t *testing.T) {
+	validHTTP := pldconf.HTTPClientConfig{URL: "http://localhost:12345"}
+	for name, tc := range map[string]struct {
+		webhooks []*pldconf.WebhookConfig
+		err      string
+	}{
+		"missing name": {
+			webhooks: []*pldconf.WebhookConfig{{HTTPClientConfig: validHTTP}},
+			err:      "PD020005",
+		},
+		"duplicate name": {
+			webhooks: []*pldconf.WebhookConfig{
+				{Name: "hook1", HTTPClientConfig: validHTTP},
+				{Name: "hook1", HTTPClientConfig: validHTTP},
+			},
+			err: "PD012900",
+		},
+		"bad event type": {
+			webhooks: []*pldconf.WebhookConfig{{
+				Name:             "hook1",
+				Filters:          pldconf.WebhookFiltersConfig{EventTypes: []string{"wrong"}},
+				HTTPClientConfig: validHTTP,
+			}},
+			err: "PD012901",
+		},
+		"missing secret file": {
+			webhooks: []*pldconf.WebhookConfig{{
+				Name:             "hook1",
+				SecretFile:       confutil.P(filepath.Join(t.TempDir(), "missing")),
+				HTTPClientConfig: validHTTP,
+			}},
+			err: "PD012902",
+		},
+		"empty secret file": {
+			webhooks: []*pldconf.WebhookConfig{{
+				Name:             "hook1",
+				SecretFile:       confutil.P(writeSecretFile(t, " \n")),
+				HTTPClientConfig: validHTTP,
+			}},
+			err: "PD012903",
+		},
+		"bad url": {
+			webhooks: []*pldconf.WebhookConfig{{Name: "hook1", HTTPClientConfig: pldconf.HTTPClientConfig{URL: "ws://localhost"}}},
+			err:      "PD020501",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			wm := NewWebhooksManager(context.Background(), &pldconf.WebhooksConfig{Webhooks: tc.webhooks})
+			pic := componentmocks.NewPreInitComponents(t)
+			pic.On("Persistence").Return(nil)
+			_, err := wm.PreInit(pic)
+			assert.Regexp(t, tc.err, err)
+		})
+	}
+}
+
+func TestListWebhooks(t *testing.T) {
+	wm, _ := newTestWebhooksManager(t, &pldconf.WebhooksConfig{
+		Webhooks: []*pldconf.WebhookConfig{
+			{
+				Name:       "hook1",
+				SecretFile: confutil.P(writeSecretFile(t, "secret1\n")),
+				Filters: pldconf.WebhookFiltersConfig{
+					EventTypes: []string{"TRANSACTION_FAILED"},
+					Domain:     confutil.P("noto"),
+					Signer:     confutil.P("alice"),
+				},
+				HTTPClientConfig: pldconf.HTTPClientConfig{URL: "https://hook1.example.com"},
+			},
+			{
+				Name:             "hook2",
+				HTTPClientConfig: pldconf.HTTPClientConfig{URL: "http://hook2.example.com"},
+			},
+		},
+	})
+
+	assert.Equal(t, []byte("secret1"), wm.webhooks["hook1"].secret)
+	assert.Equal(t, 10, wm.webhooks["hook1"].maxAttempts)
+	assert.Equal(t, []*pldapi.Webhook{
+		{
+			Name:   "hook1",
+			URL:    "https://hook1.example.com",
+			Signed: true,
+			Filters: pldapi.WebhookFilters{
+				EventTypes: []pldapi.WebhookEventType{pldapi.WebhookEventTypeTransactionFailed},
+				Domain:     "noto",
+				Signer:     "alice",
+			},
+		},
+		{
+			Name: "hook2",
+			URL:  "http://hook2.example.com",
+		},
+	}, wm.ListWebhooks(context.Background()))
+}
+
+func TestStartCreatesListenerFromLatestReceipt(t *testing.T) {
+	wm, mc := newTestWebhooksManager(t, &pldconf.WebhooksConfig{
+		Webhooks: []*pldconf.WebhookConfig{
+			{Name: "hook1", HTTPClientConfig: pldconf.HTTPClientConfig{URL: "http://localhost:12345"}},
+			{Name: "hook2", HTTPClientConfig: pldconf.HTTPClientConfig{URL: "http://localhost:12345"}},
+		},
+	})
+
+	mc.txManager.On("GetReceiptListener", mock.Anything, "webhook_hook1").Return(nil)
+	mc.txManager.On("GetReceiptListener", mock.Anything, "webhook_hook2").Return(&pldapi.TransactionReceiptListener{Name: "webhook_hook2"})
+	mc.txManager.On("QueryTransactionReceipts", mock.Anything, mock.Anything).Return([]*pldapi.TransactionReceipt{
+		{TransactionReceiptData: pldapi.TransactionReceiptData{Sequence: 42}},
+	}, nil)
+	mc.txManager.On("CreateReceiptListener", mock.Anything, mock.MatchedBy(func(spec *pldapi.TransactionReceiptListener) bool {
+		return spec.Name == "webhook_hook1" &&
+			*spec.Filters.SequenceAbove == 42 &&
+			spec.Options.DomainReceipts &&
+			spec.Options.IncompleteStateReceiptBehavior.V() == pldapi.IncompleteStateReceiptBehaviorProcess
+	})).Return(nil)
+	closer := componentmocks.NewReceiverCloser(t)
+	closer.On("Close").Return().Twice()
+	mc.txManager.On("AddReceiptReceiver", mock.Anything, "webhook_hook1", wm.webhooks["hook1"]).Return(closer, nil)
+	mc.txManager.On("AddReceiptReceiver", mock.Anything, "webhook_hook2", wm.webhooks["hook2"]).Return(closer, nil)
+
+	err := wm.Start()
+	require.NoError(t, err)
+
+	wm.Stop()
+	assert.Nil(t, wm.webhooks["hook1"].closer)
+}
+
+func TestStartNoReceipts(t *testing.T) {
+	wm, mc := newTestWebhooksManager(t, &pldconf.WebhooksConfig{
+		Webhooks: []*pldconf.WebhookConfig{
+			{Name: "hook1", HTTPClientConfig: pldconf.HTTPClientConfig{URL: "http://localhost:12345"}},
+		},
+	})
+
+	mc.txManager.On("GetReceiptListener", mock.Anything, "webhook_hook1").Return(nil)
+	mc.txManager.On("QueryTransactionReceipts", mock.Anything, mock.Anything).Return([]*pldapi.TransactionReceipt{}, nil)
+	mc.txManager.On("CreateReceiptListener", mock.Anything, mock.MatchedBy(func(spec *pldapi.TransactionReceiptListener) bool {
+		return spec.Filters.SequenceAbove == nil
+	})).Return(nil)
+	mc.txManager.On("AddReceiptReceiver", mock.Anything, "webhook_hook1", mock.Anything).Return(nil, fmt.Errorf("pop"))
+
+	err := wm.Start()
+	assert.Regexp(t, "pop", err)
+}
+
+func TestStartErrors(t *testing.T) {
+	conf := &pldconf.WebhooksConfig{
+		Webhooks: []*pldconf.WebhookConfig{
+			{Name: "hook1", HTTPClientConfig: pldconf.HTTPClientConfig{URL: "http://localhost:12345"}},
+		},
+	}
+
+	wm, mc := newTestWebhooksManager(t, conf)
+	mc.txManager.On("GetReceiptListener", mock.Anything, "webhook_hook1").Return(nil)
+	mc.txManager.On("QueryTransactionReceipts", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("pop"))
+	err := wm.Start()
+	assert.Regexp(t, "pop", err)
+
+	wm, mc = newTestWebhooksManager(t, conf)
+	mc.txManager.On("GetReceiptListener", mock.Anything, "webhook_hook1").Return(nil)
+	mc.txManager.On("QueryTransactionReceipts", mock.Anything, mock.Anything).Return(nil, nil)
+	mc.txManager.On("CreateReceiptListener", mock.Anything, mock.Anything).Return(fmt.Errorf("pop"))
+	err = wm.Start()
+	assert.Regexp(t, "pop", err)
+}
@@ -0,0 +1,61 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package webhooks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/query"
+	"github.com/kaleido-io/paladin/toolkit/pkg/rpcserver"
+)
+
+func (wm *webhooksManager) RPCModule() *rpcserver.RPCModule {
+	return wm.rpcModule
+}
+
+func (wm *webhooksManager) initRPC() {
+	wm.rpcModule = rpcserver.NewRPCModule("webhooks").
+		Add("webhooks_listWebhooks", wm.rpcListWebhooks()).
+		Add("webhooks_queryDeliveries", wm.rpcQueryDeliveries()).
+		Add("webhooks_getDelivery", wm.rpcGetDelivery()).
+		Add("webhooks_retryDelivery", wm.rpcRetryDelivery())
+}
+
+func (wm *webhooksManager) rpcListWebhooks() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod0(func(ctx context.Context) ([]*pldapi.Webhook, error) {
+		return wm.ListWebhooks(ctx), nil
+	})
+}
+
+func (wm *webhooksManager) rpcQueryDeliveries() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod1(func(ctx context.Context, jq query.QueryJSON) ([]*pldapi.WebhookDelivery, error) {
+		return wm.QueryDeliveries(ctx, wm.p.NOTX(), &jq)
+	})
+}
+
+func (wm *webhooksManager) rpcGetDelivery() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod1(func(ctx context.Context, id uuid.UUID) (*pldapi.WebhookDelivery, error) {
+		return wm.GetDelivery(ctx, wm.p.NOTX(), id)
+	})
+}
+
+func (wm *webhooksManager) rpcRetryDelivery() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod1(func(ctx context.Context, id uuid.UUID) (*pldapi.WebhookDelivery, error) {
+		return wm.RetryDelivery(ctx, id)
+	})
+}
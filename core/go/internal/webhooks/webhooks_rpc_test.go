@@ -0,0 +1,85 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package webhooks
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldclient"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/query"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/rpcclient"
+	"github.com/kaleido-io/paladin/toolkit/pkg/rpcserver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRPCServer(t *testing.T, wm *webhooksManager) rpcclient.Client {
+	s, err := rpcserver.NewRPCServer(context.Background(), &pldconf.RPCServerConfig{
+		HTTP: pldconf.RPCServerConfigHTTP{
+			HTTPServerConfig: pldconf.HTTPServerConfig{Address: confutil.P("127.0.0.1"), Port: confutil.P(0)},
+		},
+		WS: pldconf.RPCServerConfigWS{Disabled: true},
+	})
+	require.NoError(t, err)
+	err = s.Start()
+	require.NoError(t, err)
+	t.Cleanup(s.Stop)
+
+	s.Register(wm.RPCModule())
+
+	return rpcclient.WrapRestyClient(resty.New().SetBaseURL(fmt.Sprintf("http://%s", s.HTTPAddr())))
+}
+
+func TestRPCWebhooks(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	wm, mc := newTestWebhooksManager(t, &pldconf.WebhooksConfig{Webhooks: []*pldconf.WebhookConfig{testWebhookConfig("hook1", server.URL)}})
+	client := pldclient.Wrap(newTestRPCServer(t, wm)).Webhooks()
+
+	webhooks, err := client.ListWebhooks(ctx)
+	require.NoError(t, err)
+	require.Len(t, webhooks, 1)
+	assert.Equal(t, server.URL, webhooks[0].URL)
+
+	receipt := testReceipt(true, "")
+	err = wm.webhooks["hook1"].DeliverReceiptBatch(ctx, 1, []*pldapi.TransactionReceiptFull{receipt})
+	require.NoError(t, err)
+	mc.runScheduled(t, wm)
+
+	deliveries, err := client.QueryDeliveries(ctx, query.NewQueryBuilder().Equal("transaction", receipt.ID).Equal("status", "delivered").Limit(10).Query())
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+
+	d, err := client.GetDelivery(ctx, deliveries[0].ID)
+	require.NoError(t, err)
+	assert.Equal(t, receipt.ID, d.Transaction)
+
+	d, err = client.RetryDelivery(ctx, d.ID)
+	require.NoError(t, err)
+	assert.Equal(t, pldapi.WebhookDeliveryStatusPending, d.Status.V())
+}
@@ -0,0 +1,241 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package blockindexer
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/core/internal/msgs"
+	"github.com/kaleido-io/paladin/core/pkg/persistence"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+)
+
+// A backfill indexes a historical range of blocks behind the point the main loop is following the
+// chain from, using parallel workers that each fetch and commit a batch of blocks at a time.
+//
+// Because the blocks are all behind the main loop, they are already confirmed, and the main loop's
+// checkpoint is unaffected. The blocks are not passed to pre-commit handlers, or to event streams
+// at the head - event streams with a checkpoint before the range will find them on catch-up.
+type backfillJob struct {
+	bi            *blockIndexer
+	ctx           context.Context
+	cancelCtx     context.CancelFunc
+	fromBlock     uint64
+	toBlock       uint64
+	concurrency   int
+	batchSize     int
+	started       pldtypes.Timestamp
+	blocksIndexed atomic.Uint64
+	done          chan struct{}
+	statusLock    sync.Mutex
+	completed     *pldtypes.Timestamp
+	err           error
+}
+
+type blockRange struct {
+	from uint64
+	to   uint64
+}
+
+func (bi *blockIndexer) StartBackfill(ctx context.Context, req *pldapi.IndexerBackfillRequest) (*pldapi.IndexerBackfillStatus, error) {
+	if req.FromBlock > req.ToBlock {
+		return nil, i18n.NewError(ctx, msgs.MsgBlockIndexerBackfillInvalidRange, req.FromBlock, req.ToBlock)
+	}
+
+	bi.stateLock.Lock()
+	nextBlock := bi.nextBlock
+	bi.stateLock.Unlock()
+	if nextBlock == nil {
+		return nil, i18n.NewError(ctx, msgs.MsgBlockIndexerBackfillNoHead)
+	}
+	if req.ToBlock.Uint64() >= nextBlock.Uint64() {
+		return nil, i18n.NewError(ctx, msgs.MsgBlockIndexerBackfillAheadOfHead, nextBlock.Uint64())
+	}
+
+	bi.backfillLock.Lock()
+	defer bi.backfillLock.Unlock()
+	if bi.backfill != nil && bi.backfill.running() {
+		return nil, i18n.NewError(ctx, msgs.MsgBlockIndexerBackfillRunning, bi.backfill.fromBlock, bi.backfill.toBlock)
+	}
+
+	j := &backfillJob{
+		bi:          bi,
+		fromBlock:   req.FromBlock.Uint64(),
+		toBlock:     req.ToBlock.Uint64(),
+		concurrency: confutil.IntMin(req.Concurrency, 1, bi.backfillConcurrency),
+		batchSize:   confutil.IntMin(req.BatchSize, 1, bi.backfillBatchSize),
+		started:     pldtypes.TimestampNow(),
+		done:        make(chan struct{}),
+	}
+	j.ctx, j.cancelCtx = context.WithCancel(log.WithLogField(bi.parentCtxForReset, "role", fmt.Sprintf("backfill_%d_%d", j.fromBlock, j.toBlock)))
+	bi.backfill = j
+	log.L(ctx).Infof("Starting backfill from block %d to block %d (concurrency=%d,batchSize=%d)", j.fromBlock, j.toBlock, j.concurrency, j.batchSize)
+	go j.run()
+	return j.status(), nil
+}
+
+func (bi *blockIndexer) GetBackfillStatus(ctx context.Context) *pldapi.IndexerBackfillStatus {
+	bi.backfillLock.Lock()
+	defer bi.backfillLock.Unlock()
+	if bi.backfill == nil {
+		return nil
+	}
+	return bi.backfill.status()
+}
+
+func (bi *blockIndexer) StopBackfill(ctx context.Context) (*pldapi.IndexerBackfillStatus, error) {
+	bi.backfillLock.Lock()
+	defer bi.backfillLock.Unlock()
+	if bi.backfill == nil || !bi.backfill.running() {
+		return nil, i18n.NewError(ctx, msgs.MsgBlockIndexerBackfillNotRunning)
+	}
+	bi.backfill.cancelCtx()
+	<-bi.backfill.done
+	return bi.backfill.status(), nil
+}
+
+func (j *backfillJob) running() bool {
+	j.statusLock.Lock()
+	defer j.statusLock.Unlock()
+	return j.completed == nil
+}
+
+func (j *backfillJob) status() *pldapi.IndexerBackfillStatus {
+	j.statusLock.Lock()
+	defer j.statusLock.Unlock()
+	status := &pldapi.IndexerBackfillStatus{
+		FromBlock:     pldtypes.HexUint64(j.fromBlock),
+		ToBlock:       pldtypes.HexUint64(j.toBlock),
+		Concurrency:   j.concurrency,
+		BatchSize:     j.batchSize,
+		BlocksIndexed: j.blocksIndexed.Load(),
+		Running:       j.completed == nil,
+		Started:       j.started,
+		Completed:     j.completed,
+	}
+	if j.err != nil {
+		status.Error = j.err.Error()
+	}
+	return status
+}
+
+func (j *backfillJob) fail(err error) {
+	j.statusLock.Lock()
+	if j.err == nil {
+		j.err = err
+	}
+	j.statusLock.Unlock()
+	// stop the other workers
+	j.cancelCtx()
+}
+
+func (j *backfillJob) run() {
+	defer close(j.done)
+	defer j.cancelCtx()
+
+	ranges := make(chan blockRange)
+	var wg sync.WaitGroup
+	for i := 0; i < j.concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for r := range ranges {
+				if err := j.indexRange(r); err != nil {
+					log.L(j.ctx).Errorf("Backfill failed indexing blocks %d to %d: %s", r.from, r.to, err)
+					j.fail(err)
+					return
+				}
+			}
+		}()
+	}
+
+	// Feed the batches to the workers, in order, until we've passed them all out or we're stopped
+feedLoop:
+	for from := j.fromBlock; ; {
+		to := from + uint64(j.batchSize) - 1
+		if to > j.toBlock || to < from /* overflow */ {
+			to = j.toBlock
+		}
+		select {
+		case ranges <- blockRange{from: from, to: to}:
+		case <-j.ctx.Done():
+			break feedLoop
+		}
+		if to == j.toBlock {
+			break
+		}
+		from = to + 1
+	}
+	close(ranges)
+	wg.Wait()
+
+	total := j.toBlock - j.fromBlock + 1
+	if j.blocksIndexed.Load() < total {
+		// if we did not fail, we were stopped
+		j.fail(i18n.WrapError(j.ctx, j.ctx.Err(), msgs.MsgContextCanceled))
+	}
+	completed := pldtypes.TimestampNow()
+	j.statusLock.Lock()
+	j.completed = &completed
+	j.statusLock.Unlock()
+	log.L(j.ctx).Infof("Backfill finished: %d/%d blocks indexed", j.blocksIndexed.Load(), total)
+}
+
+func (j *backfillJob) indexRange(r blockRange) error {
+	bi := j.bi
+	batch := &blockWriterBatch{}
+	for blockNumber := r.from; blockNumber <= r.to; blockNumber++ {
+		var block *BlockInfoJSONRPC
+		err := bi.retry.Do(j.ctx, func(attempt int) (retryable bool, err error) {
+			block, err = bi.blockListener.getBlockInfoByNumber(j.ctx, ethtypes.HexUint64(blockNumber))
+			if err == nil && block == nil {
+				return false, i18n.NewError(j.ctx, msgs.MsgBlockIndexerBackfillBlockNotFound, blockNumber)
+			}
+			return true, err
+		})
+		if err != nil {
+			return err
+		}
+		bi.dispatchEnrich(j.ctx, batch, block)
+	}
+	batch.wg.Wait()
+	for _, receiptError := range batch.receiptResults {
+		if receiptError != nil {
+			return receiptError
+		}
+	}
+
+	records := bi.buildRecords(j.ctx, batch)
+	err := bi.retry.Do(j.ctx, func(attempt int) (retryable bool, err error) {
+		return true, bi.persistence.Transaction(j.ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
+			return bi.insertRecords(ctx, dbTX, records, true)
+		})
+	})
+	if err != nil {
+		return err
+	}
+	j.blocksIndexed.Add(uint64(len(batch.blocks)))
+	log.L(j.ctx).Debugf("Backfill indexed blocks %d to %d", r.from, r.to)
+	return nil
+}
@@ -0,0 +1,190 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package blockindexer
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/query"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/rpcclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func setTestNextBlock(bi *blockIndexer, n uint64) {
+	bi.stateLock.Lock()
+	defer bi.stateLock.Unlock()
+	nextBlock := ethtypes.HexUint64(n)
+	bi.nextBlock = &nextBlock
+}
+
+func waitBackfillComplete(t *testing.T, ctx context.Context, bi *blockIndexer) *pldapi.IndexerBackfillStatus {
+	var status *pldapi.IndexerBackfillStatus
+	require.Eventually(t, func() bool {
+		status = bi.GetBackfillStatus(ctx)
+		return !status.Running
+	}, 5*time.Second, 5*time.Millisecond)
+	return status
+}
+
+func TestBackfillParallel(t *testing.T) {
+	ctx, bi, mRPC, blDone := newTestBlockIndexer(t)
+	defer blDone()
+
+	blocks, receipts := testBlockArray(t, 20)
+	mockBlocksRPCCalls(mRPC, blocks, receipts)
+	setTestNextBlock(bi, 20)
+
+	assert.Nil(t, bi.GetBackfillStatus(ctx))
+
+	status, err := bi.StartBackfill(ctx, &pldapi.IndexerBackfillRequest{
+		FromBlock:   2,
+		ToBlock:     16,
+		Concurrency: confutil.P(3),
+		BatchSize:   confutil.P(4),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 3, status.Concurrency)
+	assert.Equal(t, 4, status.BatchSize)
+
+	status = waitBackfillComplete(t, ctx, bi)
+	assert.Empty(t, status.Error)
+	assert.Equal(t, uint64(15), status.BlocksIndexed)
+	assert.NotNil(t, status.Completed)
+
+	indexed, err := bi.QueryIndexedBlocks(ctx, query.NewQueryBuilder().Sort("number").Limit(100).Query())
+	require.NoError(t, err)
+	require.Len(t, indexed, 15)
+	for i, b := range indexed {
+		checkIndexedBlockEqual(t, blocks[i+2], b)
+	}
+	txns, err := bi.GetBlockTransactionsByNumber(ctx, 16)
+	require.NoError(t, err)
+	assert.Len(t, txns, len(receipts[blocks[16].Hash.String()]))
+
+	// Running over the same blocks again is harmless, and uses the configured defaults
+	status, err = bi.StartBackfill(ctx, &pldapi.IndexerBackfillRequest{
+		FromBlock: 0,
+		ToBlock:   19,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, bi.backfillConcurrency, status.Concurrency)
+	assert.Equal(t, bi.backfillBatchSize, status.BatchSize)
+	status = waitBackfillComplete(t, ctx, bi)
+	assert.Empty(t, status.Error)
+	assert.Equal(t, uint64(20), status.BlocksIndexed)
+
+	indexed, err = bi.QueryIndexedBlocks(ctx, query.NewQueryBuilder().Limit(100).Query())
+	require.NoError(t, err)
+	require.Len(t, indexed, 20)
+}
+
+func TestBackfillRequestErrors(t *testing.T) {
+	ctx, bi, _, blDone := newTestBlockIndexer(t)
+	defer blDone()
+
+	_, err := bi.StartBackfill(ctx, &pldapi.IndexerBackfillRequest{FromBlock: 10, ToBlock: 9})
+	assert.Regexp(t, "PD011314", err)
+
+	_, err = bi.StartBackfill(ctx, &pldapi.IndexerBackfillRequest{FromBlock: 0, ToBlock: 9})
+	assert.Regexp(t, "PD011315", err)
+
+	setTestNextBlock(bi, 10)
+	_, err = bi.StartBackfill(ctx, &pldapi.IndexerBackfillRequest{FromBlock: 0, ToBlock: 10})
+	assert.Regexp(t, "PD011316", err)
+
+	bi.backfill = &backfillJob{fromBlock: 1, toBlock: 5}
+	_, err = bi.StartBackfill(ctx, &pldapi.IndexerBackfillRequest{FromBlock: 0, ToBlock: 9})
+	assert.Regexp(t, "PD011313.*1.*5", err)
+
+	bi.backfill = nil
+	_, err = bi.StopBackfill(ctx)
+	assert.Regexp(t, "PD011317", err)
+}
+
+func TestBackfillBlockNotFound(t *testing.T) {
+	ctx, bi, mRPC, blDone := newTestBlockIndexer(t)
+	defer blDone()
+
+	blocks, receipts := testBlockArray(t, 5)
+	mockBlocksRPCCalls(mRPC, blocks, receipts)
+	setTestNextBlock(bi, 100)
+
+	_, err := bi.StartBackfill(ctx, &pldapi.IndexerBackfillRequest{
+		FromBlock:   0,
+		ToBlock:     9,
+		Concurrency: confutil.P(1),
+		BatchSize:   confutil.P(5),
+	})
+	require.NoError(t, err)
+
+	status := waitBackfillComplete(t, ctx, bi)
+	assert.Regexp(t, "PD011318.*5", status.Error)
+	assert.Equal(t, uint64(5), status.BlocksIndexed)
+}
+
+func TestBackfillStop(t *testing.T) {
+	ctx, bi, mRPC, blDone := newTestBlockIndexer(t)
+	defer blDone()
+
+	fetching := make(chan struct{}, 1)
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getBlockByNumber", mock.Anything, true).
+		Return(rpcclient.WrapRPCError(rpcclient.RPCCodeInternalError, fmt.Errorf("pop"))).
+		Run(func(args mock.Arguments) {
+			select {
+			case fetching <- struct{}{}:
+			default:
+			}
+		})
+	setTestNextBlock(bi, 100)
+
+	_, err := bi.StartBackfill(ctx, &pldapi.IndexerBackfillRequest{FromBlock: 0, ToBlock: 9})
+	require.NoError(t, err)
+	<-fetching
+
+	status, err := bi.StopBackfill(ctx)
+	require.NoError(t, err)
+	assert.False(t, status.Running)
+	assert.NotEmpty(t, status.Error)
+	assert.Zero(t, status.BlocksIndexed)
+
+	_, err = bi.StopBackfill(ctx)
+	assert.Regexp(t, "PD011317", err)
+}
+
+func TestBackfillBadReceipts(t *testing.T) {
+	ctx, bi, mRPC, blDone := newTestBlockIndexer(t)
+	defer blDone()
+
+	blocks, _ := testBlockArray(t, 5)
+	mockBlocksRPCCalls(mRPC, blocks, map[string][]*TXReceiptJSONRPC{})
+	setTestNextBlock(bi, 5)
+
+	_, err := bi.StartBackfill(ctx, &pldapi.IndexerBackfillRequest{FromBlock: 0, ToBlock: 4})
+	require.NoError(t, err)
+
+	status := waitBackfillComplete(t, ctx, bi)
+	assert.Regexp(t, "PD011310", status.Error)
+	assert.Equal(t, pldtypes.HexUint64(4), status.ToBlock)
+}
@@ -44,6 +44,8 @@ import (
 	"github.com/kaleido-io/paladin/sdk/go/pkg/rpcclient"
 	"github.com/kaleido-io/paladin/toolkit/pkg/inflight"
 	"github.com/kaleido-io/paladin/toolkit/pkg/rpcserver"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type BlockIndexer interface {
@@ -61,6 +63,7 @@ type BlockIndexer interface {
 	GetTransactionEventsByHash(ctx context.Context, hash pldtypes.Bytes32) ([]*pldapi.IndexedEvent, error)
 	QueryIndexedBlocks(ctx context.Context, jq *query.QueryJSON) ([]*pldapi.IndexedBlock, error)
 	QueryIndexedEvents(ctx context.Context, jq *query.QueryJSON) ([]*pldapi.IndexedEvent, error)
+	QueryDecodedEvents(ctx context.Context, jq *query.QueryJSON) ([]*pldapi.DecodedEvent, error)
 	QueryIndexedTransactions(ctx context.Context, jq *query.QueryJSON) ([]*pldapi.IndexedTransaction, error)
 	ListTransactionEvents(ctx context.Context, lastBlock int64, lastIndex, limit int) ([]*pldapi.IndexedEvent, error)
 	DecodeTransactionEvents(ctx context.Context, hash pldtypes.Bytes32, abi abi.ABI, resultFormat pldtypes.JSONFormatOptions) ([]*pldapi.EventWithData, error)
@@ -69,6 +72,13 @@ type BlockIndexer interface {
 	GetBlockListenerHeight(ctx context.Context) (highest uint64, err error)
 	GetConfirmedBlockHeight(ctx context.Context) (confirmed pldtypes.HexUint64, err error)
 	GetEventStreamStatus(ctx context.Context, id uuid.UUID) (*EventStreamStatus, error)
+	GetEventStreamSnapshots(ctx context.Context) []*EventStreamSnapshot
+	StartBackfill(ctx context.Context, req *pldapi.IndexerBackfillRequest) (*pldapi.IndexerBackfillStatus, error)
+	GetBackfillStatus(ctx context.Context) *pldapi.IndexerBackfillStatus
+	StopBackfill(ctx context.Context) (*pldapi.IndexerBackfillStatus, error)
+	ReplayTransactionEvents(ctx context.Context, req *pldapi.IndexerEventReplayRequest) (*pldapi.IndexerEventReplayResult, error)
+	GetChainTime(ctx context.Context) (*pldapi.ChainTime, error)
+	GetHeaderVerificationStatus(ctx context.Context) *pldapi.HeaderVerificationStatus
 	RPCModule() *rpcserver.RPCModule
 }
 
@@ -109,9 +119,15 @@ type blockIndexer struct {
 	processorDone              chan struct{}
 	dispatcherDone             chan struct{}
 	rpcModule                  *rpcserver.RPCModule
+	decodedEventSerializer     *abi.Serializer
+	backfillConcurrency        int
+	backfillBatchSize          int
+	backfillLock               sync.Mutex
+	backfill                   *backfillJob
 }
 
 func NewBlockIndexer(ctx context.Context, config *pldconf.BlockIndexerConfig, wsConfig *pldconf.WSClientConfig, persistence persistence.Persistence) (_ BlockIndexer, err error) {
+	ctx = log.WithSubsystem(ctx, "blockindexer")
 
 	blockListener, err := newBlockListener(ctx, config, wsConfig)
 	if err != nil {
@@ -137,6 +153,9 @@ func newBlockIndexer(ctx context.Context, conf *pldconf.BlockIndexerConfig, pers
 		esBlockDispatchQueueLength: confutil.IntMin(conf.EventStreams.BlockDispatchQueueLength, 0, *pldconf.EventStreamDefaults.BlockDispatchQueueLength),
 		esCatchUpQueryPageSize:     confutil.IntMin(conf.EventStreams.CatchUpQueryPageSize, 0, *pldconf.EventStreamDefaults.CatchUpQueryPageSize),
 		dispatcherTap:              make(chan struct{}, 1),
+		decodedEventSerializer:     pldtypes.JSONFormatOptions("").GetABISerializerIgnoreErrors(ctx),
+		backfillConcurrency:        confutil.IntMin(conf.Backfill.Concurrency, 1, *pldconf.BlockIndexerBackfillDefaults.Concurrency),
+		backfillBatchSize:          confutil.IntMin(conf.Backfill.BatchSize, 1, *pldconf.BlockIndexerBackfillDefaults.BatchSize),
 	}
 	bi.highestConfirmedBlock.Store(-1)
 	bi.fromBlock, err = bi.getFromBlock(ctx, conf.FromBlock, pldconf.BlockIndexerDefaults.FromBlock)
@@ -257,6 +276,40 @@ func (bi *blockIndexer) GetBlockListenerHeight(ctx context.Context) (confirmed u
 	return bi.blockListener.getHighestBlock(ctx)
 }
 
+// The highest block we know exists on the chain - which while we are catching up by querying
+// blocks can be ahead of the head reported by the block listener
+// GetChainTime returns the estimated current time on the chain, based on the timestamp of the highest
+// block received by the block listener. Unlike the local clock, this is consistent with the block
+// timestamps that contracts will see, so is what domains should use to reason about time locks.
+func (bi *blockIndexer) GetChainTime(ctx context.Context) (*pldapi.ChainTime, error) {
+	return bi.blockListener.chainTime.get(ctx)
+}
+
+func (bi *blockIndexer) GetHeaderVerificationStatus(ctx context.Context) *pldapi.HeaderVerificationStatus {
+	if bi.blockListener.headerVerifier == nil {
+		return &pldapi.HeaderVerificationStatus{Enabled: false}
+	}
+	return bi.blockListener.headerVerifier.status()
+}
+
+func (bi *blockIndexer) getChainHead(ctx context.Context) (uint64, error) {
+	chainHead, err := bi.blockListener.getHighestBlock(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if highestConfirmedBlock := bi.highestConfirmedBlock.Load(); highestConfirmedBlock > int64(chainHead) {
+		chainHead = uint64(highestConfirmedBlock)
+	}
+	bi.stateLock.Lock()
+	defer bi.stateLock.Unlock()
+	if len(bi.blocksSinceCheckpoint) > 0 {
+		if readBlock := bi.blocksSinceCheckpoint[len(bi.blocksSinceCheckpoint)-1].Number.Uint64(); readBlock > chainHead {
+			chainHead = readBlock
+		}
+	}
+	return chainHead, nil
+}
+
 func (bi *blockIndexer) getFromBlock(ctx context.Context, fromBlock json.RawMessage, defaultValue json.RawMessage) (*ethtypes.HexUint64, error) {
 	var vUntyped interface{}
 	if fromBlock == nil {
@@ -510,30 +563,39 @@ func (bi *blockIndexer) dispatcher(ctx context.Context) {
 
 func (bi *blockIndexer) hydrateBlock(ctx context.Context, batch *blockWriterBatch, blockIndex int) {
 	defer batch.wg.Done()
+	// The batch might still be growing, so we only access it under the lock
+	batch.lock.Lock()
+	block := batch.blocks[blockIndex]
+	summary := batch.summaries[blockIndex]
+	batch.lock.Unlock()
+	var receipts []*TXReceiptJSONRPC
 	err := bi.retry.Do(ctx, func(attempt int) (bool, error) {
 		// We use eth_getBlockReceipts, which takes either a number or a hash (supported by Besu and go-ethereum)
-		rpcErr := bi.wsConn.CallRPC(ctx, &batch.receipts[blockIndex], "eth_getBlockReceipts", batch.blocks[blockIndex].Hash)
-		if rpcErr != nil || batch.receipts[blockIndex] == nil {
+		rpcErr := bi.wsConn.CallRPC(ctx, &receipts, "eth_getBlockReceipts", block.Hash)
+		if rpcErr != nil || receipts == nil {
 			var err error = rpcErr
 			retry := true
-			log.L(ctx).Errorf("Failed to query block %s: %v", batch.summaries[blockIndex], rpcErr)
+			log.L(ctx).Errorf("Failed to query block %s: %v", summary, rpcErr)
 			if err == nil {
 				// TODO: We've seen this with Besu instead of an error, and need to diagnose
 				// Convert to a not found, but DO retry here.
 				log.L(ctx).Warnf("Blockchain node returned null from eth_getBlockReceipts")
-				err = i18n.NewError(ctx, msgs.MsgBlockIndexerConfirmedBlockNotFound, batch.blocks[blockIndex].Hash, batch.blocks[blockIndex].Number)
+				err = i18n.NewError(ctx, msgs.MsgBlockIndexerConfirmedBlockNotFound, block.Hash, block.Number)
 			} else if isNotFound(err) {
 				// If we get a not-found, that's an indication the confirmations are not set correctly,
 				// but there's no point in continuing to retry as a confirmed block should be available
 				// on our connection.
 				// This nil entry in batch.receipts[blockIndex] triggers a reset.
 				retry = false
-				err = i18n.WrapError(ctx, rpcErr, msgs.MsgBlockIndexerConfirmedBlockNotFound, batch.blocks[blockIndex].Hash, batch.blocks[blockIndex].Number)
+				err = i18n.WrapError(ctx, rpcErr, msgs.MsgBlockIndexerConfirmedBlockNotFound, block.Hash, block.Number)
 			}
 			return retry, err
 		}
 		return false, nil
 	})
+	batch.lock.Lock()
+	defer batch.lock.Unlock()
+	batch.receipts[blockIndex] = receipts
 	batch.receiptResults[blockIndex] = err
 }
 
@@ -551,76 +613,116 @@ func (bi *blockIndexer) logToIndexedEvent(l *LogJSONRPC) *pldapi.IndexedEvent {
 	}
 }
 
-func (bi *blockIndexer) writeBatch(ctx context.Context, batch *blockWriterBatch) {
+type indexedRecords struct {
+	blocks             []*pldapi.IndexedBlock
+	notifyTransactions []*IndexedTransactionNotify
+	transactions       []*pldapi.IndexedTransaction
+	events             []*pldapi.IndexedEvent
+	eventData          []*indexedEventData
+	newHighestBlock    int64
+}
 
-	var blocks []*pldapi.IndexedBlock
-	var notifyTransactions []*IndexedTransactionNotify
-	var transactions []*pldapi.IndexedTransaction
-	var events []*pldapi.IndexedEvent
-	newHighestBlock := int64(-1)
+func (bi *blockIndexer) buildRecords(ctx context.Context, batch *blockWriterBatch) *indexedRecords {
+
+	r := &indexedRecords{newHighestBlock: -1}
+	decoders := bi.getEventDecoders()
 
 	for i, block := range batch.blocks {
-		newHighestBlock = int64(block.Number)
-		blocks = append(blocks, &pldapi.IndexedBlock{
+		r.newHighestBlock = int64(block.Number)
+		r.blocks = append(r.blocks, &pldapi.IndexedBlock{
 			Timestamp: pldtypes.Timestamp(block.Timestamp),
 			Number:    int64(block.Number),
 			Hash:      pldtypes.NewBytes32FromSlice(block.Hash),
 		})
-		for txIndex, r := range batch.receipts[i] {
+		for txIndex, receipt := range batch.receipts[i] {
 			result := pldapi.TXResult_FAILURE.Enum()
-			if r.Status.BigInt().Int64() == 1 {
+			if receipt.Status.BigInt().Int64() == 1 {
 				result = pldapi.TXResult_SUCCESS.Enum()
 			}
 			txn := IndexedTransactionNotify{
 				IndexedTransaction: pldapi.IndexedTransaction{
-					Hash:             pldtypes.NewBytes32FromSlice(r.TransactionHash),
-					BlockNumber:      int64(r.BlockNumber),
+					Hash:             pldtypes.NewBytes32FromSlice(receipt.TransactionHash),
+					BlockNumber:      int64(receipt.BlockNumber),
 					TransactionIndex: int64(txIndex),
-					From:             (*pldtypes.EthAddress)(r.From),
-					To:               (*pldtypes.EthAddress)(r.To),
+					From:             (*pldtypes.EthAddress)(receipt.From),
+					To:               (*pldtypes.EthAddress)(receipt.To),
 					Nonce:            uint64(block.Transactions[txIndex].Nonce),
-					ContractAddress:  (*pldtypes.EthAddress)(r.ContractAddress),
+					ContractAddress:  (*pldtypes.EthAddress)(receipt.ContractAddress),
 					Result:           result,
 				},
-				RevertReason: pldtypes.HexBytes(r.RevertReason),
+				RevertReason: pldtypes.HexBytes(receipt.RevertReason),
+			}
+			if receipt.GasUsed != nil {
+				txn.GasUsed = confutil.P(pldtypes.HexUint64(receipt.GasUsed.BigInt().Uint64()))
 			}
-			notifyTransactions = append(notifyTransactions, &txn)
-			transactions = append(transactions, &txn.IndexedTransaction)
-			for _, l := range r.Logs {
-				events = append(events, bi.logToIndexedEvent(l))
+			if receipt.EffectiveGasPrice != nil {
+				txn.EffectiveGasPrice = (*pldtypes.HexUint256)(receipt.EffectiveGasPrice.BigInt())
+			}
+			r.notifyTransactions = append(r.notifyTransactions, &txn)
+			r.transactions = append(r.transactions, &txn.IndexedTransaction)
+			for _, l := range receipt.Logs {
+				event := bi.logToIndexedEvent(l)
+				r.events = append(r.events, event)
+				if ed := bi.decodeForStorage(ctx, decoders, l, event); ed != nil {
+					r.eventData = append(r.eventData, ed)
+				}
 			}
 		}
 	}
+	return r
+}
+
+// insertRecords writes the blocks, transactions and events. When ignoreExisting is set (for backfill)
+// any records that have already been indexed are skipped, rather than failing the DB transaction.
+func (bi *blockIndexer) insertRecords(ctx context.Context, dbTX persistence.DBTX, r *indexedRecords, ignoreExisting bool) (err error) {
+	db := func() *gorm.DB {
+		db := dbTX.DB().WithContext(ctx)
+		if ignoreExisting {
+			db = db.Clauses(clause.OnConflict{DoNothing: true})
+		}
+		return db
+	}
+	if len(r.blocks) > 0 {
+		err = db().
+			Table("indexed_blocks").
+			Create(r.blocks).
+			Error
+	}
+	if err == nil && len(r.transactions) > 0 {
+		err = db().
+			Table("indexed_transactions").
+			Create(r.transactions).
+			Error
+	}
+	if err == nil && len(r.events) > 0 {
+		err = db().
+			Table("indexed_events").
+			Omit("Transaction").
+			Omit("Event").
+			Create(r.events).
+			Error
+	}
+	if err == nil && len(r.eventData) > 0 {
+		err = db().
+			Create(r.eventData).
+			Error
+	}
+	return err
+}
+
+func (bi *blockIndexer) writeBatch(ctx context.Context, batch *blockWriterBatch) {
+
+	r := bi.buildRecords(ctx, batch)
 
 	err := bi.retry.Do(ctx, func(attempt int) (retryable bool, err error) {
 		err = bi.persistence.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) (err error) {
 			for _, preCommitHandler := range bi.preCommitHandlers {
 				if err == nil {
-					err = preCommitHandler(ctx, dbTX, blocks, notifyTransactions)
+					err = preCommitHandler(ctx, dbTX, r.blocks, r.notifyTransactions)
 				}
 			}
-			if err == nil && len(blocks) > 0 {
-				err = dbTX.DB().
-					WithContext(ctx).
-					Table("indexed_blocks").
-					Create(blocks).
-					Error
-			}
-			if err == nil && len(transactions) > 0 {
-				err = dbTX.DB().
-					WithContext(ctx).
-					Table("indexed_transactions").
-					Create(transactions).
-					Error
-			}
-			if err == nil && len(events) > 0 {
-				err = dbTX.DB().
-					WithContext(ctx).
-					Table("indexed_events").
-					Omit("Transaction").
-					Omit("Event").
-					Create(events).
-					Error
+			if err == nil {
+				err = bi.insertRecords(ctx, dbTX, r, false)
 			}
 			return err
 		})
@@ -630,11 +732,11 @@ func (bi *blockIndexer) writeBatch(ctx context.Context, batch *blockWriterBatch)
 		// Context was cancelled exiting retry - no notification in that case
 		bi.notifyEventStreams(ctx, batch)
 	}
-	if newHighestBlock >= 0 {
-		bi.highestConfirmedBlock.Store(newHighestBlock)
+	if r.newHighestBlock >= 0 {
+		bi.highestConfirmedBlock.Store(r.newHighestBlock)
 	}
 	if err == nil {
-		for _, t := range transactions {
+		for _, t := range r.transactions {
 			if inflight := bi.txWaiters.GetInflight(t.Hash); inflight != nil {
 				inflight.Complete(t)
 			}
@@ -673,6 +775,8 @@ func (bi *blockIndexer) notifyEventStreams(ctx context.Context, batch *blockWrit
 			default:
 			}
 		}
+		// Streams that are holding back events for more confirmations check the head again
+		es.tapHead()
 	}
 }
 
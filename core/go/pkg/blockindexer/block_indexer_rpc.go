@@ -40,8 +40,15 @@ func (bi *blockIndexer) initRPC() {
 		Add("bidx_queryIndexedBlocks", bi.rpcQueryIndexedBlocks()).
 		Add("bidx_queryIndexedTransactions", bi.rpcQueryIndexedTransactions()).
 		Add("bidx_queryIndexedEvents", bi.rpcQueryIndexedEvents()).
+		Add("bidx_queryDecodedEvents", bi.rpcQueryDecodedEvents()).
 		Add("bidx_getConfirmedBlockHeight", bi.rpcGetConfirmedBlockHeight()).
-		Add("bidx_decodeTransactionEvents", bi.rpcDecodeTransactionEvents())
+		Add("bidx_decodeTransactionEvents", bi.rpcDecodeTransactionEvents()).
+		Add("bidx_startBackfill", bi.rpcStartBackfill()).
+		Add("bidx_getBackfillStatus", bi.rpcGetBackfillStatus()).
+		Add("bidx_stopBackfill", bi.rpcStopBackfill()).
+		Add("bidx_replayTransactionEvents", bi.rpcReplayTransactionEvents()).
+		Add("bidx_getChainTime", bi.rpcGetChainTime()).
+		Add("bidx_getHeaderVerificationStatus", bi.rpcGetHeaderVerificationStatus())
 }
 
 func (bi *blockIndexer) rpcGetBlockByNumber() rpcserver.RPCHandler {
@@ -116,6 +123,14 @@ func (bi *blockIndexer) rpcQueryIndexedEvents() rpcserver.RPCHandler {
 	})
 }
 
+func (bi *blockIndexer) rpcQueryDecodedEvents() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod1(func(ctx context.Context,
+		jq query.QueryJSON,
+	) ([]*pldapi.DecodedEvent, error) {
+		return bi.QueryDecodedEvents(ctx, &jq)
+	})
+}
+
 func (bi *blockIndexer) rpcDecodeTransactionEvents() rpcserver.RPCHandler {
 	return rpcserver.RPCMethod3(func(ctx context.Context,
 		hash pldtypes.Bytes32,
@@ -125,3 +140,47 @@ func (bi *blockIndexer) rpcDecodeTransactionEvents() rpcserver.RPCHandler {
 		return bi.DecodeTransactionEvents(ctx, hash, abi, resultFormat)
 	})
 }
+
+func (bi *blockIndexer) rpcStartBackfill() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod1(func(ctx context.Context,
+		req pldapi.IndexerBackfillRequest,
+	) (*pldapi.IndexerBackfillStatus, error) {
+		return bi.StartBackfill(ctx, &req)
+	})
+}
+
+func (bi *blockIndexer) rpcGetBackfillStatus() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod0(func(ctx context.Context,
+	) (*pldapi.IndexerBackfillStatus, error) {
+		return bi.GetBackfillStatus(ctx), nil
+	})
+}
+
+func (bi *blockIndexer) rpcStopBackfill() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod0(func(ctx context.Context,
+	) (*pldapi.IndexerBackfillStatus, error) {
+		return bi.StopBackfill(ctx)
+	})
+}
+
+func (bi *blockIndexer) rpcReplayTransactionEvents() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod1(func(ctx context.Context,
+		req pldapi.IndexerEventReplayRequest,
+	) (*pldapi.IndexerEventReplayResult, error) {
+		return bi.ReplayTransactionEvents(ctx, &req)
+	})
+}
+
+func (bi *blockIndexer) rpcGetChainTime() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod0(func(ctx context.Context,
+	) (*pldapi.ChainTime, error) {
+		return bi.GetChainTime(ctx)
+	})
+}
+
+func (bi *blockIndexer) rpcGetHeaderVerificationStatus() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod0(func(ctx context.Context,
+	) (*pldapi.HeaderVerificationStatus, error) {
+		return bi.GetHeaderVerificationStatus(ctx), nil
+	})
+}
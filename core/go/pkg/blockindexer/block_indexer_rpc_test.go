@@ -83,6 +83,13 @@ func TestBlockIndexRPCCalls(t *testing.T) {
 	assert.Equal(t, rpcBlock.Transactions[0].Hash.String(), idxEvents[0].TransactionHash.String())
 	assert.Equal(t, int64(2), idxEvents[0].LogIndex)
 
+	var storedEvents []*pldapi.DecodedEvent
+	err = rpc.CallRPC(ctx, &storedEvents, "bidx_queryDecodedEvents", query.NewQueryBuilder().
+		Equal("blockNumber", rpcBlock.Number).
+		Limit(1).Query())
+	require.NoError(t, err)
+	assert.Empty(t, storedEvents) // no event streams requested decoded storage
+
 	var decodedEvents []*pldapi.EventWithData
 	err = rpc.CallRPC(ctx, &decodedEvents, "bidx_decodeTransactionEvents",
 		rpcBlock.Transactions[0].Hash,
@@ -93,6 +100,34 @@ func TestBlockIndexRPCCalls(t *testing.T) {
 	assert.Equal(t, rpcBlock.Transactions[0].Hash.String(), decodedEvents[1].TransactionHash.String())
 	assert.JSONEq(t, `["1000000", "event_b_in_block_0"]`, decodedEvents[1].Data.Pretty())
 
+	var backfillStatus *pldapi.IndexerBackfillStatus
+	err = rpc.CallRPC(ctx, &backfillStatus, "bidx_getBackfillStatus")
+	require.NoError(t, err)
+	assert.Nil(t, backfillStatus)
+
+	err = rpc.CallRPC(ctx, &backfillStatus, "bidx_startBackfill", &pldapi.IndexerBackfillRequest{FromBlock: 1, ToBlock: 0})
+	assert.Regexp(t, "PD011314", err)
+
+	err = rpc.CallRPC(ctx, &backfillStatus, "bidx_stopBackfill")
+	assert.Regexp(t, "PD011317", err)
+
+	var chainTime *pldapi.ChainTime
+	err = rpc.CallRPC(ctx, &chainTime, "bidx_getChainTime")
+	assert.Regexp(t, "PD011321", err)
+	bi.blockListener.chainTime.observe(ctx, rpcBlock)
+	err = rpc.CallRPC(ctx, &chainTime, "bidx_getChainTime")
+	require.NoError(t, err)
+	assert.Equal(t, pldtypes.HexUint64(rpcBlock.Number), chainTime.BlockNumber)
+
+	var headerStatus *pldapi.HeaderVerificationStatus
+	err = rpc.CallRPC(ctx, &headerStatus, "bidx_getHeaderVerificationStatus")
+	require.NoError(t, err)
+	assert.False(t, headerStatus.Enabled)
+
+	var replayResult *pldapi.IndexerEventReplayResult
+	err = rpc.CallRPC(ctx, &replayResult, "bidx_replayTransactionEvents", &pldapi.IndexerEventReplayRequest{StreamName: "unknown"})
+	assert.Regexp(t, "PD011312", err)
+
 	var blockHeight pldtypes.HexUint64
 	err = rpc.CallRPC(ctx, &blockHeight, "bidx_getConfirmedBlockHeight")
 	require.NoError(t, err)
@@ -958,6 +958,36 @@ func TestBlockIndexerWaitForTransactionSuccess(t *testing.T) {
 	assert.Equal(t, txHash, tx.Hash)
 }
 
+func TestBlockIndexerReceiptGas(t *testing.T) {
+	_, bi, mRPC, blDone := newTestBlockIndexer(t)
+	defer blDone()
+
+	blocks, receipts := testBlockArray(t, 2)
+	receipt := receipts[blocks[0].Hash.String()][0]
+	receipt.GasUsed = ethtypes.NewHexInteger64(21000)
+	receipt.EffectiveGasPrice = ethtypes.NewHexInteger64(1000000000)
+	mockBlocksRPCCalls(mRPC, blocks, receipts)
+	bi.requiredConfirmations = 0
+
+	utTxNotify := make(chan []*IndexedTransactionNotify, len(blocks))
+	bi.preCommitHandlers = append(bi.preCommitHandlers, func(ctx context.Context, dbTX persistence.DBTX, blocks []*pldapi.IndexedBlock, transactions []*IndexedTransactionNotify) error {
+		utTxNotify <- transactions
+		return nil
+	})
+
+	bi.startOrReset() // do not start block listener
+
+	txs := <-utTxNotify
+	require.Len(t, txs, 1)
+	assert.Equal(t, uint64(21000), txs[0].GasUsed.Uint64())
+	assert.Equal(t, int64(1000000000), txs[0].EffectiveGasPrice.Int().Int64())
+
+	txs = <-utTxNotify
+	require.Len(t, txs, 1)
+	assert.Nil(t, txs[0].GasUsed)
+	assert.Nil(t, txs[0].EffectiveGasPrice)
+}
+
 func TestBlockIndexerWaitForTransactionRevert(t *testing.T) {
 	ctx, bi, mRPC, blDone := newTestBlockIndexer(t)
 	defer blDone()
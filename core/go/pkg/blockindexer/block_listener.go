@@ -19,6 +19,7 @@ package blockindexer
 import (
 	"container/list"
 	"context"
+	"encoding/json"
 	"strings"
 	"sync"
 	"time"
@@ -53,6 +54,8 @@ type blockListener struct {
 	canonicalChain             *list.List
 	retry                      *retry.Retry
 	newBlocks                  chan *BlockInfoJSONRPC
+	chainTime                  *chainTime
+	headerVerifier             *headerVerifier // nil unless header verification is enabled
 }
 
 func newBlockListener(ctx context.Context, conf *pldconf.BlockIndexerConfig, wsConfig *pldconf.WSClientConfig) (bl *blockListener, err error) {
@@ -72,6 +75,10 @@ func newBlockListener(ctx context.Context, conf *pldconf.BlockIndexerConfig, wsC
 		retry:                      retry.NewRetryIndefinite(&conf.Retry),
 		wsConn:                     rpcclient.WrapWSConfig(wscConf),
 		newBlocks:                  make(chan *BlockInfoJSONRPC, chainHeadCacheLen),
+		chainTime:                  newChainTime(confutil.DurationMin(conf.ChainTimeDriftWarning, 0, *pldconf.BlockIndexerDefaults.ChainTimeDriftWarning)),
+	}
+	if bl.headerVerifier, err = newHeaderVerifier(ctx, &conf.HeaderVerification, chainHeadCacheLen); err != nil {
+		return nil, err
 	}
 	return bl, nil
 }
@@ -165,9 +172,21 @@ func isNotFound(err error) bool {
 }
 
 func (bl *blockListener) getBlockInfoByHash(ctx context.Context, blockHash string) (*BlockInfoJSONRPC, error) {
-	var info *BlockInfoJSONRPC
 	log.L(ctx).Debugf("Fetching block by hash %s", blockHash)
-	err := bl.wsConn.CallRPC(ctx, &info, "eth_getBlockByHash", blockHash, true)
+	return bl.getBlockInfo(ctx, "eth_getBlockByHash", blockHash)
+}
+
+func (bl *blockListener) getBlockInfoByNumber(ctx context.Context, blockNumber ethtypes.HexUint64) (*BlockInfoJSONRPC, error) {
+	log.L(ctx).Debugf("Fetching block by number %d", blockNumber)
+	return bl.getBlockInfo(ctx, "eth_getBlockByNumber", blockNumber)
+}
+
+func (bl *blockListener) getBlockInfo(ctx context.Context, method string, blockRef any) (info *BlockInfoJSONRPC, err error) {
+	if bl.headerVerifier != nil {
+		info, err = bl.getVerifiedBlockInfo(ctx, method, blockRef)
+	} else {
+		err = bl.wsConn.CallRPC(ctx, &info, method, blockRef, true)
+	}
 	if err != nil {
 		if isNotFound(err) {
 			return nil, nil
@@ -177,16 +196,23 @@ func (bl *blockListener) getBlockInfoByHash(ctx context.Context, blockHash strin
 	return info, nil
 }
 
-func (bl *blockListener) getBlockInfoByNumber(ctx context.Context, blockNumber ethtypes.HexUint64) (*BlockInfoJSONRPC, error) {
+// getVerifiedBlockInfo parses the full header from the same response as the block, so that it can be
+// verified without retaining it in memory
+func (bl *blockListener) getVerifiedBlockInfo(ctx context.Context, method string, blockRef any) (*BlockInfoJSONRPC, error) {
+	var rawBlock json.RawMessage
+	if rpcErr := bl.wsConn.CallRPC(ctx, &rawBlock, method, blockRef, true); rpcErr != nil {
+		return nil, rpcErr
+	}
 	var info *BlockInfoJSONRPC
-	log.L(ctx).Debugf("Fetching block by number %d", blockNumber)
-	err := bl.wsConn.CallRPC(ctx, &info, "eth_getBlockByNumber", blockNumber, true)
-	if err != nil {
-		if isNotFound(err) {
-			return nil, nil
-		}
+	var header *BlockHeaderJSONRPC
+	err := json.Unmarshal(rawBlock, &info)
+	if err == nil {
+		err = json.Unmarshal(rawBlock, &header)
+	}
+	if err != nil || info == nil {
 		return nil, err
 	}
+	bl.headerVerifier.verify(ctx, header)
 	return info, nil
 }
 
@@ -297,11 +323,7 @@ func (bl *blockListener) notifyBlock(bi *BlockInfoJSONRPC) {
 // work backwards building a new view and notify about all blocks that are changed in that process.
 func (bl *blockListener) reconcileCanonicalChain(bi *BlockInfoJSONRPC) *list.Element {
 
-	bl.highestBlockMux.Lock()
-	if bi.Number.Uint64() > bl.highestBlock {
-		bl.highestBlock = bi.Number.Uint64()
-	}
-	bl.highestBlockMux.Unlock()
+	bl.updateHighestBlock(bi)
 
 	// Find the position of this block in the block sequence
 	pos := bl.canonicalChain.Back()
@@ -426,16 +448,22 @@ func (bl *blockListener) rebuildCanonicalChain() *list.Element {
 			notifyPos = newElem
 		}
 
-		bl.highestBlockMux.Lock()
-		if bi.Number.Uint64() > bl.highestBlock {
-			bl.highestBlock = bi.Number.Uint64()
-		}
-		bl.highestBlockMux.Unlock()
+		bl.updateHighestBlock(bi)
 
 	}
 	return notifyPos
 }
 
+func (bl *blockListener) updateHighestBlock(bi *BlockInfoJSONRPC) {
+	bl.highestBlockMux.Lock()
+	if bi.Number.Uint64() > bl.highestBlock {
+		bl.highestBlock = bi.Number.Uint64()
+	}
+	bl.highestBlockMux.Unlock()
+
+	bl.chainTime.observe(bl.ctx, bi)
+}
+
 func (bl *blockListener) trimToLastValidBlock() (lastValidBlock *BlockInfoJSONRPC) {
 	// First remove from the end until we get a block that matches the current un-cached query view from the chain
 	lastElem := bl.canonicalChain.Back()
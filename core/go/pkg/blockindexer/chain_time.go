@@ -0,0 +1,91 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blockindexer
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/core/internal/msgs"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+)
+
+// chainTime tracks the timestamp of the highest block received from the chain against the local
+// clock, so we can give a consistent estimate of the current time on the chain between blocks.
+//
+// The drift is how far the local clock is ahead of the block timestamp when a block arrives. Some of
+// that is just the time taken to propagate the block to us, but a large drift means either the
+// clocks of the block producers or the local clock are wrong.
+type chainTime struct {
+	mux           sync.Mutex
+	driftWarning  time.Duration
+	blockNumber   uint64
+	blockTime     time.Time
+	observedAt    time.Time
+	driftExceeded bool
+	now           func() time.Time
+}
+
+func newChainTime(driftWarning time.Duration) *chainTime {
+	return &chainTime{
+		driftWarning: driftWarning,
+		now:          time.Now,
+	}
+}
+
+func (ct *chainTime) observe(ctx context.Context, bi *BlockInfoJSONRPC) {
+	ct.mux.Lock()
+	defer ct.mux.Unlock()
+
+	// Blocks can arrive out of order, or be replaced in a re-org - we only track the highest
+	if ct.blockNumber > 0 && bi.Number.Uint64() <= ct.blockNumber {
+		return
+	}
+	ct.blockNumber = bi.Number.Uint64()
+	ct.blockTime = pldtypes.TimestampFromUnix(int64(bi.Timestamp.Uint64())).Time()
+	ct.observedAt = ct.now()
+
+	drift := ct.observedAt.Sub(ct.blockTime)
+	exceeded := ct.driftWarning > 0 && (drift > ct.driftWarning || drift < -ct.driftWarning)
+	if exceeded && !ct.driftExceeded {
+		log.L(ctx).Warnf("Timestamp of block %d differs from the local clock by %s, which is beyond the warning threshold of %s", ct.blockNumber, drift, ct.driftWarning)
+	} else if !exceeded && ct.driftExceeded {
+		log.L(ctx).Infof("Timestamp of block %d differs from the local clock by %s, which is back within the warning threshold of %s", ct.blockNumber, drift, ct.driftWarning)
+	}
+	ct.driftExceeded = exceeded
+}
+
+func (ct *chainTime) get(ctx context.Context) (*pldapi.ChainTime, error) {
+	ct.mux.Lock()
+	defer ct.mux.Unlock()
+
+	if ct.observedAt.IsZero() {
+		return nil, i18n.NewError(ctx, msgs.MsgBlockIndexerNoChainTime)
+	}
+	return &pldapi.ChainTime{
+		BlockNumber:    pldtypes.HexUint64(ct.blockNumber),
+		BlockTimestamp: pldtypes.Timestamp(ct.blockTime.UnixNano()),
+		ObservedAt:     pldtypes.Timestamp(ct.observedAt.UnixNano()),
+		EstimatedTime:  pldtypes.Timestamp(ct.blockTime.Add(ct.now().Sub(ct.observedAt)).UnixNano()),
+		DriftMS:        ct.observedAt.Sub(ct.blockTime).Milliseconds(),
+		DriftExceeded:  ct.driftExceeded,
+	}, nil
+}
@@ -0,0 +1,81 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blockindexer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChainTime(t *testing.T) {
+	ctx := context.Background()
+	ct := newChainTime(1 * time.Minute)
+	now := time.Unix(1700000000, 0)
+	ct.now = func() time.Time { return now }
+
+	_, err := ct.get(ctx)
+	assert.Regexp(t, "PD011321", err)
+
+	// Block arrives 2s after its timestamp
+	ct.observe(ctx, &BlockInfoJSONRPC{Number: 100, Timestamp: ethtypes.HexUint64(now.Unix() - 2)})
+	now = now.Add(5 * time.Second)
+	chainTime, err := ct.get(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, &pldapi.ChainTime{
+		BlockNumber:    100,
+		BlockTimestamp: pldtypes.TimestampFromUnix(1699999998),
+		ObservedAt:     pldtypes.TimestampFromUnix(1700000000),
+		EstimatedTime:  pldtypes.TimestampFromUnix(1700000003),
+		DriftMS:        2000,
+	}, chainTime)
+
+	// Lower blocks are ignored
+	ct.observe(ctx, &BlockInfoJSONRPC{Number: 99, Timestamp: ethtypes.HexUint64(now.Unix())})
+	chainTime, err = ct.get(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, pldtypes.HexUint64(100), chainTime.BlockNumber)
+
+	// A block with a timestamp well in the future exceeds the threshold
+	ct.observe(ctx, &BlockInfoJSONRPC{Number: 101, Timestamp: ethtypes.HexUint64(now.Unix() + 120)})
+	chainTime, err = ct.get(ctx)
+	require.NoError(t, err)
+	assert.True(t, chainTime.DriftExceeded)
+	assert.Equal(t, int64(-120000), chainTime.DriftMS)
+
+	// Until we get one back within it
+	ct.observe(ctx, &BlockInfoJSONRPC{Number: 102, Timestamp: ethtypes.HexUint64(now.Unix())})
+	chainTime, err = ct.get(ctx)
+	require.NoError(t, err)
+	assert.False(t, chainTime.DriftExceeded)
+	assert.Zero(t, chainTime.DriftMS)
+}
+
+func TestChainTimeNoWarning(t *testing.T) {
+	ctx := context.Background()
+	ct := newChainTime(0)
+	ct.observe(ctx, &BlockInfoJSONRPC{Number: 1, Timestamp: 1})
+	chainTime, err := ct.get(ctx)
+	require.NoError(t, err)
+	assert.False(t, chainTime.DriftExceeded)
+}
@@ -0,0 +1,145 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package blockindexer
+
+import (
+	"context"
+	"sort"
+
+	"github.com/hyperledger/firefly-signer/pkg/abi"
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/core/internal/filters"
+	"github.com/kaleido-io/paladin/core/internal/msgs"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/query"
+)
+
+type indexedEventData struct {
+	BlockNumber       int64               `gorm:"column:block_number;primaryKey"`
+	TransactionIndex  int64               `gorm:"column:transaction_index;primaryKey"`
+	LogIndex          int64               `gorm:"column:log_index;primaryKey"`
+	TransactionHash   pldtypes.Bytes32    `gorm:"column:transaction_hash"`
+	Signature         pldtypes.Bytes32    `gorm:"column:signature"`
+	Address           pldtypes.EthAddress `gorm:"column:address"`
+	Topics            []pldtypes.Bytes32  `gorm:"column:topics;serializer:json"`
+	RawData           pldtypes.HexBytes   `gorm:"column:raw_data"`
+	SoliditySignature string              `gorm:"column:solidity_signature"`
+	Data              pldtypes.RawJSON    `gorm:"column:data"`
+}
+
+func (indexedEventData) TableName() string {
+	return "indexed_event_data"
+}
+
+// The event sources of all streams that have asked for decoded events to be stored, indexed by signature.
+// Where the sources of multiple streams match the same log, the first stream (ordered by ID) to decode it wins.
+type eventDecoders map[string][]EventStreamSource
+
+func (bi *blockIndexer) getEventDecoders() eventDecoders {
+	bi.eventStreamsLock.Lock()
+	defer bi.eventStreamsLock.Unlock()
+
+	var streams []*eventStream
+	for _, es := range bi.eventStreams {
+		if confutil.Bool(es.definition.Config.StoreDecoded, false) {
+			streams = append(streams, es)
+		}
+	}
+	sort.Slice(streams, func(i, j int) bool {
+		return streams[i].definition.ID.String() < streams[j].definition.ID.String()
+	})
+
+	decoders := make(eventDecoders)
+	for _, es := range streams {
+		for _, source := range es.definition.Sources {
+			sourceSigs := make(map[string]bool)
+			for _, abiEntry := range source.ABI {
+				if abiEntry.Type == abi.Event {
+					sourceSigs[pldtypes.NewBytes32FromSlice(abiEntry.SignatureHashBytes()).String()] = true
+				}
+			}
+			for sig := range sourceSigs {
+				decoders[sig] = append(decoders[sig], source)
+			}
+		}
+	}
+	return decoders
+}
+
+// decodeForStorage returns nil for logs that no stream has asked to be stored decoded
+func (bi *blockIndexer) decodeForStorage(ctx context.Context, decoders eventDecoders, l *LogJSONRPC, event *pldapi.IndexedEvent) *indexedEventData {
+	if len(l.Topics) == 0 {
+		return nil
+	}
+	for _, source := range decoders[l.Topics[0].String()] {
+		decoded := &pldapi.EventWithData{IndexedEvent: event}
+		if bi.matchLog(ctx, source.ABI, l, decoded, source.Address, bi.decodedEventSerializer) {
+			topics := make([]pldtypes.Bytes32, len(l.Topics))
+			for i, t := range l.Topics {
+				topics[i] = pldtypes.NewBytes32FromSlice(t)
+			}
+			return &indexedEventData{
+				BlockNumber:       event.BlockNumber,
+				TransactionIndex:  event.TransactionIndex,
+				LogIndex:          event.LogIndex,
+				TransactionHash:   event.TransactionHash,
+				Signature:         event.Signature,
+				Address:           decoded.Address,
+				Topics:            topics,
+				RawData:           pldtypes.HexBytes(l.Data),
+				SoliditySignature: decoded.SoliditySignature,
+				Data:              decoded.Data,
+			}
+		}
+	}
+	return nil
+}
+
+func (bi *blockIndexer) QueryDecodedEvents(ctx context.Context, jq *query.QueryJSON) ([]*pldapi.DecodedEvent, error) {
+
+	if jq.Limit == nil || *jq.Limit == 0 {
+		return nil, i18n.NewError(ctx, msgs.MsgBlockIndexerLimitRequired)
+	}
+	db := bi.persistence.DB()
+	q := filters.BuildGORM(ctx, jq, db.Table("indexed_event_data").WithContext(ctx), DecodedEventFilters)
+	var dbEvents []*indexedEventData
+	err := q.Find(&dbEvents).Error
+	if err != nil {
+		return nil, err
+	}
+	results := make([]*pldapi.DecodedEvent, len(dbEvents))
+	for i, e := range dbEvents {
+		results[i] = &pldapi.DecodedEvent{
+			EventWithData: &pldapi.EventWithData{
+				IndexedEvent: &pldapi.IndexedEvent{
+					BlockNumber:      e.BlockNumber,
+					TransactionIndex: e.TransactionIndex,
+					LogIndex:         e.LogIndex,
+					TransactionHash:  e.TransactionHash,
+					Signature:        e.Signature,
+				},
+				SoliditySignature: e.SoliditySignature,
+				Address:           e.Address,
+				Data:              e.Data,
+			},
+			Topics:  e.Topics,
+			RawData: e.RawData,
+		}
+	}
+	return results, nil
+}
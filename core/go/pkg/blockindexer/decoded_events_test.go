@@ -0,0 +1,114 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package blockindexer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hyperledger/firefly-signer/pkg/abi"
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/core/pkg/persistence"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/query"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStoreDecodedEvents(t *testing.T) {
+
+	// This test uses a real DB, includes the full block indexer, but simulates the blockchain.
+	ctx, bi, mRPC, blDone := newTestBlockIndexer(t)
+	defer blDone()
+
+	blocks, receipts := testBlockArray(t, 5)
+	mockBlocksRPCCalls(mRPC, blocks, receipts)
+	mockBlockListenerNil(mRPC)
+
+	eventCollector := make(chan *pldapi.EventWithData)
+
+	// Only event B is of interest, so only that is stored decoded
+	err := bi.Start(&InternalEventStream{
+		HandlerDBTX: func(ctx context.Context, dbTX persistence.DBTX, batch *EventDeliveryBatch) error {
+			for _, e := range batch.Events {
+				select {
+				case eventCollector <- e:
+				case <-ctx.Done():
+				}
+			}
+			return nil
+		},
+		Definition: &EventStream{
+			Name: "unit_test",
+			Config: EventStreamConfig{
+				BatchSize:    confutil.P(1),
+				BatchTimeout: confutil.P("5ms"),
+				StoreDecoded: confutil.P(true),
+			},
+			Sources: []EventStreamSource{{
+				ABI: abi.ABI{testABI[1]},
+			}},
+		},
+	})
+	require.NoError(t, err)
+
+	// Once the events are delivered to the stream, the blocks have been committed
+	for i := 0; i < len(blocks); i++ {
+		<-eventCollector
+	}
+
+	decoded, err := bi.QueryDecodedEvents(ctx, query.NewQueryBuilder().Limit(100).Query())
+	require.NoError(t, err)
+	assert.Len(t, decoded, len(blocks))
+
+	decoded, err = bi.QueryDecodedEvents(ctx, query.NewQueryBuilder().
+		JSONEqual("data", "strParam2", "event_b_in_block_3").
+		Limit(100).Query())
+	require.NoError(t, err)
+	require.Len(t, decoded, 1)
+	e := decoded[0]
+	assert.Equal(t, int64(3), e.BlockNumber)
+	assert.Equal(t, testABI[1].SolString(), e.SoliditySignature)
+	assert.Equal(t, pldtypes.NewBytes32FromSlice(topicB), e.Signature)
+	assert.Equal(t, e.Signature, e.Topics[0])
+	assert.NotEmpty(t, e.RawData)
+	assert.JSONEq(t, `{
+		"intParam1": "1000003",
+		"strParam2": "event_b_in_block_3"
+	}`, e.Data.String())
+
+	// The raw data is what we decoded from
+	cv, err := testABI[1].DecodeEventDataCtx(ctx, []ethtypes.HexBytes0xPrefix{e.Topics[0][:]}, ethtypes.HexBytes0xPrefix(e.RawData))
+	require.NoError(t, err)
+	assert.Len(t, cv.Children, 2)
+}
+
+func TestQueryDecodedEventsLimitRequired(t *testing.T) {
+	ctx, bi, _, blDone := newTestBlockIndexer(t)
+	defer blDone()
+
+	_, err := bi.QueryDecodedEvents(ctx, query.NewQueryBuilder().Query())
+	assert.Regexp(t, "PD011311", err)
+}
+
+func TestDecodeForStorageNoTopics(t *testing.T) {
+	ctx, bi, _, blDone := newTestBlockIndexer(t)
+	defer blDone()
+
+	assert.Nil(t, bi.decodeForStorage(ctx, eventDecoders{}, &LogJSONRPC{}, &pldapi.IndexedEvent{}))
+}
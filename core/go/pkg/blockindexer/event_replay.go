@@ -0,0 +1,123 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blockindexer
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/core/internal/msgs"
+	"github.com/kaleido-io/paladin/core/pkg/persistence"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+)
+
+// ReplayTransactionEvents re-delivers the indexed events of a single transaction to the handler of
+// an event stream, so a downstream projection can be repaired without rewinding the whole stream.
+//
+// The events are delivered in a batch of their own, outside of the normal flow of the stream, and
+// the checkpoint is not affected. Handlers are already required to be idempotent, as delivery is
+// at-least-once, so it is safe to replay events the stream has already delivered.
+func (bi *blockIndexer) ReplayTransactionEvents(ctx context.Context, req *pldapi.IndexerEventReplayRequest) (*pldapi.IndexerEventReplayResult, error) {
+	esType := EventStreamTypeInternal.Enum()
+	if req.StreamType != "" {
+		esType = pldtypes.Enum[EventStreamType](req.StreamType)
+		if _, err := esType.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	es, err := bi.getReplayStream(ctx, esType, req.StreamName)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := bi.getIndexedTransactionByHash(ctx, req.TransactionHash)
+	if err == nil && tx == nil {
+		err = i18n.NewError(ctx, msgs.MsgBlockIndexerReplayTxNotIndexed, req.TransactionHash)
+	}
+	var indexedEvents []*pldapi.IndexedEvent
+	if err == nil {
+		indexedEvents, err = bi.GetTransactionEventsByHash(ctx, req.TransactionHash)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Only the events with signatures the stream is interested in are candidates, and then
+	// only those that decode against the ABI of one of its sources are delivered
+	candidates := make([]*pldapi.EventWithData, 0, len(indexedEvents))
+	for _, event := range indexedEvents {
+		if es.signatures[event.Signature.String()] {
+			candidates = append(candidates, &pldapi.EventWithData{IndexedEvent: event})
+		}
+	}
+	if len(candidates) > 0 {
+		for _, source := range es.definition.Sources {
+			if err := bi.enrichTransactionEvents(ctx, source.ABI, source.Address, req.TransactionHash, candidates, es.serializer, false /* no retry */); err != nil {
+				return nil, err
+			}
+		}
+	}
+	batch := &EventDeliveryBatch{
+		StreamID:   es.definition.ID,
+		StreamName: es.definition.Name,
+		BatchID:    uuid.New(),
+	}
+	for _, event := range candidates {
+		if event.Data != nil {
+			batch.Events = append(batch.Events, event)
+		}
+	}
+
+	if len(batch.Events) > 0 {
+		log.L(ctx).Infof("Replaying %d events from transaction %s to event stream %s [%s] in batch %s", len(batch.Events), req.TransactionHash, es.definition.Name, es.definition.ID, batch.BatchID)
+		if es.useNOTXHandler {
+			err = es.handlerNOTX(ctx, batch)
+		} else {
+			err = bi.persistence.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
+				return es.handlerDBTX(ctx, dbTX, batch)
+			})
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &pldapi.IndexerEventReplayResult{
+		StreamName:      es.definition.Name,
+		TransactionHash: req.TransactionHash,
+		Events:          len(batch.Events),
+	}, nil
+}
+
+func (bi *blockIndexer) getReplayStream(ctx context.Context, esType pldtypes.Enum[EventStreamType], name string) (*eventStream, error) {
+	bi.eventStreamsLock.Lock()
+	defer bi.eventStreamsLock.Unlock()
+
+	for _, es := range bi.eventStreams {
+		if es.definition.Type == esType && es.definition.Name == name {
+			// Streams loaded from the DB only get a handler once the component that owns them registers it
+			if es.handlerNOTX == nil && es.handlerDBTX == nil {
+				return nil, i18n.NewError(ctx, msgs.MsgBlockIndexerReplayNoHandler, name)
+			}
+			return es, nil
+		}
+	}
+	return nil, i18n.NewError(ctx, msgs.MsgBlockIndexerEventStreamNotFound, name)
+}
@@ -0,0 +1,149 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blockindexer
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hyperledger/firefly-signer/pkg/abi"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/pkg/persistence"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplayTransactionEvents(t *testing.T) {
+
+	ctx, bi, mRPC, blDone := newTestBlockIndexer(t)
+	defer blDone()
+
+	blocks, receipts := testBlockArray(t, 5)
+	mockBlocksRPCCalls(mRPC, blocks, receipts)
+	mockBlockListenerNil(mRPC)
+
+	batches := make(chan *EventDeliveryBatch, 10)
+	err := bi.Start(&InternalEventStream{
+		HandlerDBTX: func(ctx context.Context, dbTX persistence.DBTX, batch *EventDeliveryBatch) error {
+			batches <- batch
+			return nil
+		},
+		Definition: &EventStream{
+			Name: "unit_test",
+			Config: EventStreamConfig{
+				BatchSize:    confutil.P(10),
+				BatchTimeout: confutil.P("5ms"),
+			},
+			// Listen to two out of three event types
+			Sources: []EventStreamSource{{
+				ABI: abi.ABI{
+					testABI[1],
+					testABI[2],
+				},
+			}},
+		},
+	})
+	require.NoError(t, err)
+
+	// Wait for the normal delivery of all the events
+	for delivered := 0; delivered < len(blocks)*2; {
+		delivered += len((<-batches).Events)
+	}
+
+	txHash := pldtypes.NewBytes32FromSlice(blocks[2].Transactions[0].Hash)
+	result, err := bi.ReplayTransactionEvents(ctx, &pldapi.IndexerEventReplayRequest{
+		StreamName:      "unit_test",
+		TransactionHash: txHash,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, &pldapi.IndexerEventReplayResult{
+		StreamName:      "unit_test",
+		TransactionHash: txHash,
+		Events:          2,
+	}, result)
+
+	replayed := <-batches
+	require.Len(t, replayed.Events, 2)
+	assert.Equal(t, "unit_test", replayed.StreamName)
+	for _, e := range replayed.Events {
+		assert.Equal(t, txHash, e.TransactionHash)
+		assert.Equal(t, int64(2), e.BlockNumber)
+	}
+	assert.JSONEq(t, fmt.Sprintf(`{
+		"intParam1": "%d",
+		"strParam2": "event_b_in_block_%d"
+	}`, 1000002, 2), string(replayed.Events[0].Data))
+}
+
+func TestReplayTransactionEventsErrors(t *testing.T) {
+	ctx, bi, _, p, done := newMockBlockIndexer(t, &pldconf.BlockIndexerConfig{})
+	defer done()
+
+	_, err := bi.ReplayTransactionEvents(ctx, &pldapi.IndexerEventReplayRequest{
+		StreamType: "wrong",
+		StreamName: "es1",
+	})
+	assert.Regexp(t, "PD020003", err)
+
+	_, err = bi.ReplayTransactionEvents(ctx, &pldapi.IndexerEventReplayRequest{
+		StreamName: "es1",
+	})
+	assert.Regexp(t, "PD011312", err)
+
+	// A stream that has been loaded from the DB, but not registered by its owner
+	bi.initEventStream(ctx, &EventStream{
+		ID:   uuid.New(),
+		Name: "es1",
+		Type: EventStreamTypePTXBlockchainEventListener.Enum(),
+	})
+	_, err = bi.ReplayTransactionEvents(ctx, &pldapi.IndexerEventReplayRequest{
+		StreamType: string(EventStreamTypePTXBlockchainEventListener),
+		StreamName: "es1",
+	})
+	assert.Regexp(t, "PD011319", err)
+
+	es := bi.initEventStreamNOTX(ctx, &EventStream{
+		ID:      uuid.New(),
+		Name:    "es2",
+		Type:    EventStreamTypeInternal.Enum(),
+		Sources: []EventStreamSource{{ABI: testABI}},
+	}, func(ctx context.Context, batch *EventDeliveryBatch) error {
+		return nil
+	})
+	require.NotNil(t, es)
+
+	p.Mock.ExpectQuery("SELECT.*indexed_transactions").WillReturnRows(p.Mock.NewRows([]string{}))
+	_, err = bi.ReplayTransactionEvents(ctx, &pldapi.IndexerEventReplayRequest{
+		StreamName:      "es2",
+		TransactionHash: pldtypes.RandBytes32(),
+	})
+	assert.Regexp(t, "PD011320", err)
+
+	p.Mock.ExpectQuery("SELECT.*indexed_transactions").WillReturnRows(p.Mock.NewRows([]string{"hash"}).AddRow(pldtypes.RandBytes32()))
+	p.Mock.ExpectQuery("SELECT.*indexed_events").WillReturnRows(p.Mock.NewRows([]string{}))
+	result, err := bi.ReplayTransactionEvents(ctx, &pldapi.IndexerEventReplayRequest{
+		StreamName:      "es2",
+		TransactionHash: pldtypes.RandBytes32(),
+	})
+	require.NoError(t, err)
+	assert.Zero(t, result.Events)
+}
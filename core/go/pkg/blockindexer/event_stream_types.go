@@ -33,15 +33,18 @@ import (
 )
 
 type EventStreamConfig struct {
-	BatchSize    *int            `json:"batchSize,omitempty"`
-	BatchTimeout *string         `json:"batchTimeout,omitempty"`
-	FromBlock    json.RawMessage `json:"fromBlock,omitempty"`
+	BatchSize     *int            `json:"batchSize,omitempty"`
+	BatchTimeout  *string         `json:"batchTimeout,omitempty"`
+	FromBlock     json.RawMessage `json:"fromBlock,omitempty"`
+	StoreDecoded  *bool           `json:"storeDecoded,omitempty"`  // the indexer stores events matching the ABI decoded, as blocks are indexed, for QueryDecodedEvents
+	Confirmations *int            `json:"confirmations,omitempty"` // events are held back until this many blocks are mined on top of their block
 }
 
 var EventStreamDefaults = &EventStreamConfig{
-	BatchSize:    confutil.P(50),
-	BatchTimeout: confutil.P("75ms"),
-	FromBlock:    json.RawMessage(`0`),
+	BatchSize:     confutil.P(50),
+	BatchTimeout:  confutil.P("75ms"),
+	FromBlock:     json.RawMessage(`0`),
+	Confirmations: confutil.P(0),
 }
 
 type EventStreamType string
@@ -123,6 +126,17 @@ type EventStreamStatus struct {
 	Catchup         bool
 }
 
+// EventStreamSnapshot is an in-memory view of an event stream, for debugging
+type EventStreamSnapshot struct {
+	ID              uuid.UUID       `json:"id"`
+	Name            string          `json:"name"`
+	Type            EventStreamType `json:"type"`
+	Running         bool            `json:"running"`
+	CheckpointBlock int64           `json:"checkpointBlock"`
+	Catchup         bool            `json:"catchup"`
+	Pending         int64           `json:"pending"` // events not yet delivered and checkpointed
+}
+
 type EventDeliveryBatch struct {
 	StreamID   uuid.UUID               `json:"streamId"`
 	StreamName string                  `json:"streamName"`
@@ -18,6 +18,7 @@ package blockindexer
 
 import (
 	"context"
+	"sort"
 	"sync/atomic"
 	"time"
 
@@ -46,7 +47,9 @@ type eventStream struct {
 	signatureList  []pldtypes.Bytes32
 	batchSize      int
 	batchTimeout   time.Duration
+	confirmations  int
 	blocks         chan *eventStreamBlock
+	headTap        chan struct{}
 	dispatch       chan *eventDispatch
 	useNOTXHandler bool
 	handlerDBTX    InternalStreamCallbackDBTX
@@ -57,6 +60,7 @@ type eventStream struct {
 	fromBlock      *ethtypes.HexUint64 // nil == latest
 	checkpoint     atomic.Int64        // set after we persist checkpoint
 	catchup        atomic.Bool
+	pending        atomic.Int64 // events passed to the dispatcher, but not yet delivered and checkpointed
 }
 
 type eventBatch struct {
@@ -239,6 +243,7 @@ func (bi *blockIndexer) initEventStream(ctx context.Context, definition *EventSt
 			definition: definition,
 			signatures: make(map[string]bool),
 			blocks:     make(chan *eventStreamBlock, bi.esBlockDispatchQueueLength),
+			headTap:    make(chan struct{}, 1),
 			dispatch:   make(chan *eventDispatch, batchSize),
 			serializer: definition.Format.GetABISerializerIgnoreErrors(ctx),
 		}
@@ -247,6 +252,7 @@ func (bi *blockIndexer) initEventStream(ctx context.Context, definition *EventSt
 	// Set the batch config
 	es.batchSize = batchSize
 	es.batchTimeout = confutil.DurationMin(definition.Config.BatchTimeout, 0, *EventStreamDefaults.BatchTimeout)
+	es.confirmations = confutil.IntMin(definition.Config.Confirmations, 0, *EventStreamDefaults.Confirmations)
 	// The error is already checked before writing to the DB
 	es.fromBlock, _ = es.bi.getFromBlock(ctx, definition.Config.FromBlock, EventStreamDefaults.FromBlock)
 	es.checkpoint.Store(-1)
@@ -352,6 +358,26 @@ func (bi *blockIndexer) GetEventStreamStatus(ctx context.Context, id uuid.UUID)
 	}, nil
 }
 
+func (bi *blockIndexer) GetEventStreamSnapshots(ctx context.Context) []*EventStreamSnapshot {
+	bi.eventStreamsLock.Lock()
+	defer bi.eventStreamsLock.Unlock()
+
+	snapshots := make([]*EventStreamSnapshot, 0, len(bi.eventStreams))
+	for _, es := range bi.eventStreams {
+		snapshots = append(snapshots, &EventStreamSnapshot{
+			ID:              es.definition.ID,
+			Name:            es.definition.Name,
+			Type:            es.definition.Type.V(),
+			Running:         es.detectorDone != nil,
+			CheckpointBlock: es.checkpoint.Load(),
+			Catchup:         es.catchup.Load(),
+			Pending:         es.pending.Load(),
+		})
+	}
+	sort.Slice(snapshots, func(i, j int) bool { return snapshots[i].Name < snapshots[j].Name })
+	return snapshots
+}
+
 func (bi *blockIndexer) getStreamList() []*eventStream {
 	bi.eventStreamsLock.Lock()
 	defer bi.eventStreamsLock.Unlock()
@@ -605,11 +631,47 @@ func (es *eventStream) processNotifiedBlock(block *eventStreamBlock, fullBlock b
 	}
 }
 
+// The block indexer only indexes blocks once they have the confirmations configured on the
+// indexer itself. Streams that require more confirmations than that hold each event back
+// until the chain head is far enough past its block.
+func (es *eventStream) waitForConfirmations(blockNumber int64) bool {
+	if es.confirmations <= es.bi.requiredConfirmations {
+		return true
+	}
+	for {
+		chainHead, err := es.bi.getChainHead(es.ctx)
+		if err != nil {
+			return false // context cancelled
+		}
+		if int64(chainHead) >= blockNumber+int64(es.confirmations) {
+			return true
+		}
+		log.L(es.ctx).Debugf("waiting for block %d to reach %d confirmations (head=%d)", blockNumber, es.confirmations, chainHead)
+		select {
+		case <-es.headTap:
+		case <-es.ctx.Done():
+			return false
+		}
+	}
+}
+
+func (es *eventStream) tapHead() {
+	select {
+	case es.headTap <- struct{}{}:
+	default:
+	}
+}
+
 func (es *eventStream) sendToDispatcher(event *pldapi.EventWithData, lastInBlock bool) {
+	if !es.waitForConfirmations(event.BlockNumber) {
+		return
+	}
 	log.L(es.ctx).Debugf("passing event to dispatcher %d/%d/%d (tx=%s,address=%s)", event.BlockNumber, event.TransactionIndex, event.LogIndex, event.TransactionHash, &event.Address)
+	es.pending.Add(1)
 	select {
 	case es.dispatch <- &eventDispatch{event, lastInBlock}:
 	case <-es.ctx.Done():
+		es.pending.Add(-1)
 	}
 }
 
@@ -668,6 +730,7 @@ func (es *eventStream) dispatcher() {
 				l.Debugf("event stream dispatcher ending (during dispatch)")
 				return
 			}
+			es.pending.Add(-int64(len(batch.Events)))
 			batch = nil
 		}
 
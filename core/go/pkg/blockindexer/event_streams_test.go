@@ -22,6 +22,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -131,6 +132,77 @@ func TestInternalEventStreamDeliveryAtHead(t *testing.T) {
 	}
 	assert.True(t, calledPostCommit)
 
+	// Everything is delivered and checkpointed once the final batch completes
+	assert.Eventually(t, func() bool {
+		snapshots := bi.GetEventStreamSnapshots(context.Background())
+		return len(snapshots) == 1 && snapshots[0].Pending == 0
+	}, 5*time.Second, 5*time.Millisecond)
+
+}
+
+func TestInternalEventStreamConfirmations(t *testing.T) {
+
+	// This test uses a real DB, includes the full block indexer, but simulates the blockchain.
+	_, bi, mRPC, blDone := newTestBlockIndexer(t)
+	defer blDone()
+
+	// The chain starts with 15 blocks, and grows to 20
+	blocks, receipts := testBlockArray(t, 20)
+	var available atomic.Int32
+	available.Store(15)
+	mockBlocksRPCCallsDynamic(mRPC, func(args mock.Arguments) ([]*BlockInfoJSONRPC, map[string][]*TXReceiptJSONRPC) {
+		return blocks[0:available.Load()], receipts
+	})
+	mockBlockListenerNil(mRPC)
+
+	eventCollector := make(chan *pldapi.EventWithData, len(blocks))
+	err := bi.Start(&InternalEventStream{
+		HandlerDBTX: func(ctx context.Context, dbTX persistence.DBTX, batch *EventDeliveryBatch) error {
+			for _, e := range batch.Events {
+				eventCollector <- e
+			}
+			return nil
+		},
+		Definition: &EventStream{
+			Name: "unit_test",
+			Config: EventStreamConfig{
+				BatchSize:     confutil.P(1),
+				BatchTimeout:  confutil.P("5ms"),
+				Confirmations: confutil.P(5),
+			},
+			Sources: []EventStreamSource{{
+				ABI: abi.ABI{testABI[1]},
+			}},
+		},
+	})
+	require.NoError(t, err)
+
+	// With the head at block 14, only blocks 0-9 have 5 confirmations
+	for i := 0; i < 10; i++ {
+		e := <-eventCollector
+		assert.Equal(t, int64(i), e.BlockNumber)
+	}
+	select {
+	case e := <-eventCollector:
+		assert.Fail(t, "unexpected event", "block %d", e.BlockNumber)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	// Mine 5 more blocks
+	available.Store(20)
+	for i := 15; i < len(blocks); i++ {
+		bi.blockListener.notifyBlock(blocks[i])
+	}
+	for i := 10; i < 15; i++ {
+		e := <-eventCollector
+		assert.Equal(t, int64(i), e.BlockNumber)
+	}
+	select {
+	case e := <-eventCollector:
+		assert.Fail(t, "unexpected event", "block %d", e.BlockNumber)
+	case <-time.After(50 * time.Millisecond):
+	}
+
 }
 
 func TestInternalEventStreamDeliveryAtHeadWithSourceAddress(t *testing.T) {
@@ -747,6 +819,7 @@ func TestGetEventStreamStatus(t *testing.T) {
 	}
 	eventStream.checkpoint.Store(25)
 	eventStream.catchup.Store(true)
+	eventStream.pending.Store(3)
 	bi.eventStreams[esID] = eventStream
 
 	// success
@@ -754,6 +827,17 @@ func TestGetEventStreamStatus(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, int64(25), status.CheckpointBlock)
 	assert.True(t, status.Catchup)
+
+	eventStream.definition.Name = "stream1"
+	eventStream.definition.Type = EventStreamTypeInternal.Enum()
+	assert.Equal(t, []*EventStreamSnapshot{{
+		ID:              esID,
+		Name:            "stream1",
+		Type:            EventStreamTypeInternal,
+		CheckpointBlock: 25,
+		Catchup:         true,
+		Pending:         3,
+	}}, bi.GetEventStreamSnapshots(ctx))
 }
 
 func TestProcessCheckpointFail(t *testing.T) {
@@ -0,0 +1,333 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blockindexer
+
+import (
+	"container/list"
+	"context"
+	"math/big"
+	"sync"
+
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/hyperledger/firefly-signer/pkg/rlp"
+	"github.com/hyperledger/firefly-signer/pkg/secp256k1"
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/internal/msgs"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+)
+
+type headerConsensus string
+
+const (
+	headerConsensusNone  headerConsensus = "none"
+	headerConsensusQBFT  headerConsensus = "qbft"
+	headerConsensusIBFT2 headerConsensus = "ibft2"
+)
+
+// headerVerifier checks the headers of the blocks returned by the node, rather than trusting it blindly.
+//
+// For every consensus algorithm the block hash is recalculated from the header. The BFT algorithms
+// exclude the committed seals (and the round number) from the block hash, so the extra data is
+// re-encoded without them first. The seals sign a hash of the header that includes the round number,
+// and a quorum of them must recover to the configured validators. The validators listed in the block
+// are not used, as a node returning forged blocks could list validators it holds the keys for.
+//
+// We also remember the hashes of recently verified blocks, and flag any block that does not link to
+// them through its parent hash. BFT blocks are final, so a block that replaces one of them is flagged
+// too, whereas for other consensus algorithms it is a re-org that replaces the blocks above it.
+type headerVerifier struct {
+	mux             sync.Mutex
+	consensus       headerConsensus
+	validators      []*ethtypes.Address0xHex
+	recentLen       int
+	recentBlocks    map[uint64]*verifiedHeader
+	recentOrder     *list.List
+	blocksVerified  uint64
+	highestBlock    *uint64
+	discrepancies   uint64
+	lastDiscrepancy *pldapi.HeaderDiscrepancy
+}
+
+type verifiedHeader struct {
+	hash       ethtypes.HexBytes0xPrefix
+	parentHash ethtypes.HexBytes0xPrefix
+}
+
+// The BFT extra data, as a list of [vanity, validators, vote, round, seals]
+type bftExtraData struct {
+	vanity     rlp.Element
+	validators rlp.List
+	vote       rlp.Element
+	round      rlp.Element
+	seals      rlp.List
+}
+
+// Returns nil if header verification is disabled
+func newHeaderVerifier(ctx context.Context, conf *pldconf.HeaderVerificationConfig, recentLen int) (*headerVerifier, error) {
+	if !confutil.Bool(conf.Enabled, *pldconf.HeaderVerificationDefaults.Enabled) {
+		return nil, nil
+	}
+	hv := &headerVerifier{
+		consensus:    headerConsensus(confutil.StringNotEmpty(conf.Consensus, *pldconf.HeaderVerificationDefaults.Consensus)),
+		recentLen:    recentLen,
+		recentBlocks: make(map[uint64]*verifiedHeader),
+		recentOrder:  list.New(),
+	}
+	switch hv.consensus {
+	case headerConsensusNone, headerConsensusQBFT, headerConsensusIBFT2:
+	default:
+		return nil, i18n.NewError(ctx, msgs.MsgBlockIndexerHeaderConsensusInvalid, hv.consensus)
+	}
+	for _, v := range conf.Validators {
+		addr, err := ethtypes.NewAddress(v)
+		if err != nil {
+			return nil, i18n.WrapError(ctx, err, msgs.MsgBlockIndexerHeaderValidatorInvalid, v)
+		}
+		hv.validators = append(hv.validators, addr)
+	}
+	if hv.consensus != headerConsensusNone && len(hv.validators) == 0 {
+		return nil, i18n.NewError(ctx, msgs.MsgBlockIndexerHeaderNoValidators, hv.consensus)
+	}
+	return hv, nil
+}
+
+func (hv *headerVerifier) verify(ctx context.Context, header *BlockHeaderJSONRPC) {
+	err := hv.checkHeader(ctx, header)
+
+	hv.mux.Lock()
+	defer hv.mux.Unlock()
+
+	if err == nil {
+		err = hv.checkLinkage(ctx, header)
+	}
+	if err != nil {
+		log.L(ctx).Errorf("Block header verification failed: %s", err)
+		hv.discrepancies++
+		hv.lastDiscrepancy = &pldapi.HeaderDiscrepancy{
+			BlockNumber: pldtypes.HexUint64(header.Number),
+			BlockHash:   pldtypes.HexBytes(header.Hash),
+			Detected:    pldtypes.TimestampNow(),
+			Error:       err.Error(),
+		}
+		return
+	}
+	hv.blocksVerified++
+	if hv.highestBlock == nil || header.Number.Uint64() > *hv.highestBlock {
+		hv.highestBlock = confutil.P(header.Number.Uint64())
+	}
+}
+
+func (hv *headerVerifier) checkHeader(ctx context.Context, header *BlockHeaderJSONRPC) error {
+	hashedExtraData := []byte(header.ExtraData)
+	var extraData *bftExtraData
+	if hv.consensus != headerConsensusNone {
+		var err error
+		if extraData, err = parseBFTExtraData(ctx, header); err != nil {
+			return err
+		}
+		hashedExtraData = extraData.encode(hv.consensus, false)
+	}
+
+	calculatedHash := header.keccak(hashedExtraData)
+	if !calculatedHash.Equals(header.Hash) {
+		return i18n.NewError(ctx, msgs.MsgBlockIndexerHeaderHashMismatch, header.Number, header.Hash, calculatedHash)
+	}
+
+	if extraData != nil {
+		return hv.checkSeals(ctx, header, extraData)
+	}
+	return nil
+}
+
+func (hv *headerVerifier) checkSeals(ctx context.Context, header *BlockHeaderJSONRPC, extraData *bftExtraData) error {
+	validators := hv.validators
+	sealedHash := header.keccak(extraData.encode(hv.consensus, true))
+	signers := make(map[ethtypes.Address0xHex]bool)
+	for i, seal := range extraData.seals {
+		sig, err := secp256k1.DecodeCompactRSV(ctx, seal.ToData())
+		var signer *ethtypes.Address0xHex
+		if err == nil {
+			signer, err = sig.RecoverDirect(sealedHash, 0)
+		}
+		if err != nil {
+			log.L(ctx).Warnf("Invalid committed seal %d in block %d: %s", i, header.Number, err)
+			continue
+		}
+		for _, v := range validators {
+			if *v == *signer {
+				signers[*signer] = true
+			}
+		}
+	}
+
+	// The quorum is 2/3 of the validators, rounded up
+	quorum := (2*len(validators) + 2) / 3
+	if len(signers) < quorum {
+		return i18n.NewError(ctx, msgs.MsgBlockIndexerHeaderInsufficientSeals, header.Number, len(signers), len(validators), quorum)
+	}
+	return nil
+}
+
+// Must be called with the lock held
+func (hv *headerVerifier) checkLinkage(ctx context.Context, header *BlockHeaderJSONRPC) error {
+	blockNumber := header.Number.Uint64()
+	if existing := hv.recentBlocks[blockNumber]; existing != nil && !existing.hash.Equals(header.Hash) {
+		if hv.consensus != headerConsensusNone {
+			return i18n.NewError(ctx, msgs.MsgBlockIndexerHeaderFinalityViolated, blockNumber, header.Hash, blockNumber, existing.hash)
+		}
+		// A re-org, so the blocks we verified from this one upwards are no longer on the chain
+		log.L(ctx).Infof("Block %d replaced by %s in a re-org", blockNumber, header.Hash)
+		hv.forgetFrom(blockNumber)
+	}
+	if parent := hv.recentBlocks[blockNumber-1]; blockNumber > 0 && parent != nil && !parent.hash.Equals(header.ParentHash) {
+		return i18n.NewError(ctx, msgs.MsgBlockIndexerHeaderParentMismatch, blockNumber, header.ParentHash, blockNumber-1, parent.hash)
+	}
+	if child := hv.recentBlocks[blockNumber+1]; child != nil && !child.parentHash.Equals(header.Hash) {
+		return i18n.NewError(ctx, msgs.MsgBlockIndexerHeaderParentMismatch, blockNumber+1, child.parentHash, blockNumber, header.Hash)
+	}
+
+	if hv.recentBlocks[blockNumber] == nil {
+		hv.recentBlocks[blockNumber] = &verifiedHeader{hash: header.Hash, parentHash: header.ParentHash}
+		hv.recentOrder.PushBack(blockNumber)
+		for hv.recentOrder.Len() > hv.recentLen {
+			delete(hv.recentBlocks, hv.recentOrder.Remove(hv.recentOrder.Front()).(uint64))
+		}
+	}
+	return nil
+}
+
+// Must be called with the lock held
+func (hv *headerVerifier) forgetFrom(blockNumber uint64) {
+	for e := hv.recentOrder.Front(); e != nil; {
+		next := e.Next()
+		if n := e.Value.(uint64); n >= blockNumber {
+			delete(hv.recentBlocks, n)
+			hv.recentOrder.Remove(e)
+		}
+		e = next
+	}
+}
+
+func (hv *headerVerifier) status() *pldapi.HeaderVerificationStatus {
+	hv.mux.Lock()
+	defer hv.mux.Unlock()
+
+	status := &pldapi.HeaderVerificationStatus{
+		Enabled:         true,
+		Consensus:       string(hv.consensus),
+		BlocksVerified:  hv.blocksVerified,
+		Discrepancies:   hv.discrepancies,
+		LastDiscrepancy: hv.lastDiscrepancy,
+	}
+	if hv.highestBlock != nil {
+		status.HighestBlockVerified = confutil.P(pldtypes.HexUint64(*hv.highestBlock))
+	}
+	return status
+}
+
+func parseBFTExtraData(ctx context.Context, header *BlockHeaderJSONRPC) (*bftExtraData, error) {
+	decoded, _, err := rlp.Decode(header.ExtraData)
+	if err != nil {
+		return nil, i18n.WrapError(ctx, err, msgs.MsgBlockIndexerHeaderInvalid, header.Number)
+	}
+	fields, _ := decoded.(rlp.List)
+	if len(fields) != 5 {
+		return nil, i18n.NewError(ctx, msgs.MsgBlockIndexerHeaderInvalid, header.Number)
+	}
+	validators, _ := fields[1].(rlp.List)
+	seals, _ := fields[4].(rlp.List)
+	if validators == nil || seals == nil {
+		return nil, i18n.NewError(ctx, msgs.MsgBlockIndexerHeaderInvalid, header.Number)
+	}
+	for _, v := range validators {
+		if v.ToData().Address() == nil {
+			return nil, i18n.NewError(ctx, msgs.MsgBlockIndexerHeaderInvalid, header.Number)
+		}
+	}
+	return &bftExtraData{
+		vanity:     fields[0],
+		validators: validators,
+		vote:       fields[2],
+		round:      fields[3],
+		seals:      seals,
+	}, nil
+}
+
+// encode re-encodes the extra data in the form that is hashed, which differs between QBFT and IBFT 2.0:
+// - QBFT always has five fields, with the round zeroed for the block hash, and the seals always empty
+// - IBFT 2.0 drops the round for the block hash, and always drops the seals
+func (ed *bftExtraData) encode(consensus headerConsensus, forSeal bool) []byte {
+	fields := rlp.List{ed.vanity, ed.validators, ed.vote}
+	switch {
+	case consensus == headerConsensusQBFT && forSeal:
+		fields = append(fields, ed.round, rlp.List{})
+	case consensus == headerConsensusQBFT:
+		fields = append(fields, rlp.Data{}, rlp.List{})
+	case forSeal:
+		fields = append(fields, ed.round)
+	}
+	return fields.Encode()
+}
+
+// keccak calculates the hash of the header, with the supplied extra data
+func (h *BlockHeaderJSONRPC) keccak(extraData []byte) ethtypes.HexBytes0xPrefix {
+	fields := rlp.List{
+		rlp.Data(h.ParentHash),
+		rlp.Data(h.Sha3Uncles),
+		rlp.Data(h.Miner),
+		rlp.Data(h.StateRoot),
+		rlp.Data(h.TransactionsRoot),
+		rlp.Data(h.ReceiptsRoot),
+		rlp.Data(h.LogsBloom),
+		rlp.WrapInt(h.Difficulty.BigInt()),
+		rlpUint64(h.Number),
+		rlpUint64(h.GasLimit),
+		rlpUint64(h.GasUsed),
+		rlpUint64(h.Timestamp),
+		rlp.Data(extraData),
+		rlp.Data(h.MixHash),
+		rlp.Data(h.Nonce),
+	}
+	// Fields added by later forks are only present on chains where the fork is active
+	if h.BaseFeePerGas != nil {
+		fields = append(fields, rlp.WrapInt(h.BaseFeePerGas.BigInt()))
+	}
+	if h.WithdrawalsRoot != nil {
+		fields = append(fields, rlp.Data(h.WithdrawalsRoot))
+	}
+	if h.BlobGasUsed != nil {
+		fields = append(fields, rlpUint64(*h.BlobGasUsed))
+	}
+	if h.ExcessBlobGas != nil {
+		fields = append(fields, rlpUint64(*h.ExcessBlobGas))
+	}
+	if h.ParentBeaconBlockRoot != nil {
+		fields = append(fields, rlp.Data(h.ParentBeaconBlockRoot))
+	}
+	if h.RequestsHash != nil {
+		fields = append(fields, rlp.Data(h.RequestsHash))
+	}
+	return ethtypes.HexBytes0xPrefix(pldtypes.Bytes32Keccak(fields.Encode()).Bytes())
+}
+
+func rlpUint64(v ethtypes.HexUint64) rlp.Data {
+	return rlp.WrapInt(new(big.Int).SetUint64(v.Uint64()))
+}
@@ -0,0 +1,362 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blockindexer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/hyperledger/firefly-signer/pkg/rlp"
+	"github.com/hyperledger/firefly-signer/pkg/secp256k1"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/rpcclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func testValidators(t *testing.T, count int) []*secp256k1.KeyPair {
+	keys := make([]*secp256k1.KeyPair, count)
+	for i := range keys {
+		var err error
+		keys[i], err = secp256k1.GenerateSecp256k1KeyPair()
+		require.NoError(t, err)
+	}
+	return keys
+}
+
+func testHeader(number uint64, parentHash ethtypes.HexBytes0xPrefix) *BlockHeaderJSONRPC {
+	return &BlockHeaderJSONRPC{
+		Number:           ethtypes.HexUint64(number),
+		ParentHash:       parentHash,
+		Sha3Uncles:       pldtypes.RandBytes(32),
+		Miner:            pldtypes.RandBytes(20),
+		StateRoot:        pldtypes.RandBytes(32),
+		TransactionsRoot: pldtypes.RandBytes(32),
+		ReceiptsRoot:     pldtypes.RandBytes(32),
+		LogsBloom:        make([]byte, 256),
+		Difficulty:       ethtypes.NewHexInteger64(1),
+		GasLimit:         30000000,
+		GasUsed:          21000,
+		Timestamp:        1700000000,
+		MixHash:          pldtypes.RandBytes(32),
+		Nonce:            make([]byte, 8),
+		BaseFeePerGas:    ethtypes.NewHexInteger64(0),
+	}
+}
+
+// testBFTHeader builds a header sealed by the first signerCount of the validators
+func testBFTHeader(t *testing.T, consensus headerConsensus, validators []*secp256k1.KeyPair, signerCount int, number uint64, parentHash ethtypes.HexBytes0xPrefix) *BlockHeaderJSONRPC {
+	h := testHeader(number, parentHash)
+	extraData := &bftExtraData{
+		vanity: rlp.Data(make([]byte, 32)),
+		vote:   rlp.List{},
+		round:  rlp.Data{0x01},
+		seals:  rlp.List{},
+	}
+	if consensus == headerConsensusIBFT2 {
+		extraData.vote = rlp.Data{}
+		extraData.round = rlp.Data{0x00, 0x00, 0x00, 0x01}
+	}
+	for _, v := range validators {
+		extraData.validators = append(extraData.validators, rlp.WrapAddress(&v.Address))
+	}
+	sealedHash := h.keccak(extraData.encode(consensus, true))
+	for _, v := range validators[:signerCount] {
+		sig, err := v.SignDirect(sealedHash)
+		require.NoError(t, err)
+		extraData.seals = append(extraData.seals, rlp.Data(sig.CompactRSV()))
+	}
+	h.ExtraData = rlp.List{extraData.vanity, extraData.validators, extraData.vote, extraData.round, extraData.seals}.Encode()
+	h.Hash = h.keccak(extraData.encode(consensus, false))
+	return h
+}
+
+func newTestHeaderVerifier(t *testing.T, consensus string, validators ...string) *headerVerifier {
+	hv, err := newHeaderVerifier(context.Background(), &pldconf.HeaderVerificationConfig{
+		Enabled:    confutil.P(true),
+		Consensus:  confutil.P(consensus),
+		Validators: validators,
+	}, 3)
+	require.NoError(t, err)
+	return hv
+}
+
+func TestHeaderVerificationConfig(t *testing.T) {
+	ctx := context.Background()
+
+	hv, err := newHeaderVerifier(ctx, &pldconf.HeaderVerificationConfig{}, 10)
+	require.NoError(t, err)
+	assert.Nil(t, hv)
+
+	hv, err = newHeaderVerifier(ctx, &pldconf.HeaderVerificationConfig{Enabled: confutil.P(true)}, 10)
+	require.NoError(t, err)
+	assert.Equal(t, headerConsensusNone, hv.consensus)
+
+	_, err = newHeaderVerifier(ctx, &pldconf.HeaderVerificationConfig{Enabled: confutil.P(true), Consensus: confutil.P("clique")}, 10)
+	assert.Regexp(t, "PD011322", err)
+
+	_, err = newHeaderVerifier(ctx, &pldconf.HeaderVerificationConfig{Enabled: confutil.P(true), Validators: []string{"wrong"}}, 10)
+	assert.Regexp(t, "PD011323", err)
+
+	for _, consensus := range []string{"qbft", "ibft2"} {
+		_, err = newHeaderVerifier(ctx, &pldconf.HeaderVerificationConfig{Enabled: confutil.P(true), Consensus: confutil.P(consensus)}, 10)
+		assert.Regexp(t, "PD011328.*"+consensus, err)
+	}
+}
+
+func validatorAddresses(validators []*secp256k1.KeyPair) []string {
+	addresses := make([]string, len(validators))
+	for i, v := range validators {
+		addresses[i] = v.Address.String()
+	}
+	return addresses
+}
+
+func TestHeaderVerificationNoConsensus(t *testing.T) {
+	ctx := context.Background()
+	hv := newTestHeaderVerifier(t, "none")
+
+	h := testHeader(100, pldtypes.RandBytes(32))
+	h.ExtraData = []byte("any extra data")
+	h.WithdrawalsRoot = pldtypes.RandBytes(32)
+	h.BlobGasUsed = confutil.P(ethtypes.HexUint64(0))
+	h.ExcessBlobGas = confutil.P(ethtypes.HexUint64(0))
+	h.ParentBeaconBlockRoot = pldtypes.RandBytes(32)
+	h.RequestsHash = pldtypes.RandBytes(32)
+	h.Hash = h.keccak(h.ExtraData)
+	hv.verify(ctx, h)
+
+	// Without finality, a replacement block is a re-org rather than a discrepancy
+	replacement := testHeader(100, pldtypes.RandBytes(32))
+	replacement.Hash = replacement.keccak(nil)
+	hv.verify(ctx, replacement)
+
+	status := hv.status()
+	assert.True(t, status.Enabled)
+	assert.Equal(t, "none", status.Consensus)
+	assert.Equal(t, uint64(2), status.BlocksVerified)
+	assert.Equal(t, pldtypes.HexUint64(100), *status.HighestBlockVerified)
+	assert.Zero(t, status.Discrepancies)
+
+	h.GasUsed++
+	hv.verify(ctx, h)
+	status = hv.status()
+	assert.Equal(t, uint64(1), status.Discrepancies)
+	assert.Equal(t, pldtypes.HexUint64(100), status.LastDiscrepancy.BlockNumber)
+	assert.Equal(t, pldtypes.HexBytes(h.Hash), status.LastDiscrepancy.BlockHash)
+	assert.Regexp(t, "PD011325", status.LastDiscrepancy.Error)
+}
+
+func TestHeaderVerificationNoConsensusLinkage(t *testing.T) {
+	ctx := context.Background()
+	hv := newTestHeaderVerifier(t, "none")
+
+	newBlock := func(number uint64, parentHash ethtypes.HexBytes0xPrefix) *BlockHeaderJSONRPC {
+		h := testHeader(number, parentHash)
+		h.Hash = h.keccak(nil)
+		return h
+	}
+	b1 := newBlock(1, pldtypes.RandBytes(32))
+	b2 := newBlock(2, b1.Hash)
+	hv.verify(ctx, b1)
+	hv.verify(ctx, b2)
+	assert.Zero(t, hv.status().Discrepancies)
+
+	// A block that does not link to the verified chain is flagged, even without finality
+	hv.verify(ctx, newBlock(3, pldtypes.RandBytes(32)))
+	assert.Regexp(t, "PD011326.*Block 3", hv.status().LastDiscrepancy.Error)
+
+	// A re-org replaces block 2, after which a block 3 built on the new block 2 links
+	b2r := newBlock(2, b1.Hash)
+	hv.verify(ctx, b2r)
+	hv.verify(ctx, newBlock(3, b2r.Hash))
+	status := hv.status()
+	assert.Equal(t, uint64(1), status.Discrepancies)
+	assert.Equal(t, uint64(4), status.BlocksVerified)
+
+	// The old block 2 is no longer on the chain, so a block built on it is flagged
+	hv.verify(ctx, newBlock(3, b2.Hash))
+	assert.Equal(t, uint64(2), hv.status().Discrepancies)
+}
+
+func TestHeaderVerificationBFTSeals(t *testing.T) {
+	for _, consensus := range []headerConsensus{headerConsensusQBFT, headerConsensusIBFT2} {
+		t.Run(string(consensus), func(t *testing.T) {
+			ctx := context.Background()
+			validators := testValidators(t, 4)
+			hv := newTestHeaderVerifier(t, string(consensus), validatorAddresses(validators)...)
+
+			// 3 of 4 is a quorum
+			hv.verify(ctx, testBFTHeader(t, consensus, validators, 3, 1, pldtypes.RandBytes(32)))
+			assert.Equal(t, uint64(1), hv.status().BlocksVerified)
+
+			hv.verify(ctx, testBFTHeader(t, consensus, validators, 2, 2, pldtypes.RandBytes(32)))
+			status := hv.status()
+			assert.Equal(t, uint64(1), status.BlocksVerified)
+			assert.Regexp(t, "PD011329.*2 of 4.*quorum of 3", status.LastDiscrepancy.Error)
+
+			// The hash must not cover the seals or round, so re-sealing in a different round keeps the same hash
+			h := testBFTHeader(t, consensus, validators, 4, 3, pldtypes.RandBytes(32))
+			hashBefore := h.Hash
+			extraData, err := parseBFTExtraData(ctx, h)
+			require.NoError(t, err)
+			extraData.seals = extraData.seals[0:1]
+			extraData.round = rlp.Data{0x02}
+			h.ExtraData = rlp.List{extraData.vanity, extraData.validators, extraData.vote, extraData.round, extraData.seals}.Encode()
+			assert.Equal(t, hashBefore, h.keccak(extraData.encode(consensus, false)))
+		})
+	}
+}
+
+func TestHeaderVerificationConfiguredValidators(t *testing.T) {
+	ctx := context.Background()
+	validators := testValidators(t, 4)
+	hv := newTestHeaderVerifier(t, "qbft", validators[0].Address.String())
+
+	// Sealed by validators that the block lists, but only one of them is trusted
+	hv.verify(ctx, testBFTHeader(t, headerConsensusQBFT, validators[1:], 3, 1, pldtypes.RandBytes(32)))
+	assert.Regexp(t, "PD011329.*0 of 1", hv.status().LastDiscrepancy.Error)
+
+	b2 := testBFTHeader(t, headerConsensusQBFT, validators, 1, 2, pldtypes.RandBytes(32))
+	hv.verify(ctx, b2)
+	assert.Equal(t, uint64(1), hv.status().BlocksVerified)
+
+	// Contract based validator selection does not list the validators in the block
+	h := testBFTHeader(t, headerConsensusQBFT, validators[0:1], 1, 3, b2.Hash)
+	extraData, err := parseBFTExtraData(ctx, h)
+	require.NoError(t, err)
+	extraData.validators = rlp.List{}
+	h.ExtraData = rlp.List{extraData.vanity, extraData.validators, extraData.vote, extraData.round, extraData.seals}.Encode()
+	h.Hash = h.keccak(extraData.encode(headerConsensusQBFT, false))
+	sig, err := validators[0].SignDirect(h.keccak(extraData.encode(headerConsensusQBFT, true)))
+	require.NoError(t, err)
+	extraData.seals = rlp.List{rlp.Data(sig.CompactRSV())}
+	h.ExtraData = rlp.List{extraData.vanity, extraData.validators, extraData.vote, extraData.round, extraData.seals}.Encode()
+	hv.verify(ctx, h)
+	assert.Equal(t, uint64(2), hv.status().BlocksVerified)
+}
+
+func TestHeaderVerificationBFTFinality(t *testing.T) {
+	ctx := context.Background()
+	validators := testValidators(t, 1)
+	hv := newTestHeaderVerifier(t, "qbft", validatorAddresses(validators)...)
+
+	b1 := testBFTHeader(t, headerConsensusQBFT, validators, 1, 1, pldtypes.RandBytes(32))
+	b3 := testBFTHeader(t, headerConsensusQBFT, validators, 1, 3, pldtypes.RandBytes(32))
+	hv.verify(ctx, b1)
+	hv.verify(ctx, b3)
+	hv.verify(ctx, b1) // the same block can be fetched again
+
+	// Block 2 must link the two blocks either side of it
+	hv.verify(ctx, testBFTHeader(t, headerConsensusQBFT, validators, 1, 2, pldtypes.RandBytes(32)))
+	assert.Regexp(t, "PD011326.*Block 2", hv.status().LastDiscrepancy.Error)
+	b2 := testBFTHeader(t, headerConsensusQBFT, validators, 1, 2, b1.Hash)
+	hv.verify(ctx, b2)
+	assert.Regexp(t, "PD011326.*Block 3", hv.status().LastDiscrepancy.Error)
+
+	// A final block cannot be replaced
+	hv.verify(ctx, testBFTHeader(t, headerConsensusQBFT, validators, 1, 1, pldtypes.RandBytes(32)))
+	assert.Regexp(t, "PD011327", hv.status().LastDiscrepancy.Error)
+	status := hv.status()
+	assert.Equal(t, uint64(3), status.BlocksVerified)
+	assert.Equal(t, uint64(3), status.Discrepancies)
+
+	// Only the most recent blocks are remembered
+	parentHash := ethtypes.HexBytes0xPrefix(pldtypes.RandBytes(32))
+	for i := uint64(10); i < 13; i++ {
+		h := testBFTHeader(t, headerConsensusQBFT, validators, 1, i, parentHash)
+		hv.verify(ctx, h)
+		parentHash = h.Hash
+	}
+	assert.Len(t, hv.recentBlocks, 3)
+	hv.verify(ctx, testBFTHeader(t, headerConsensusQBFT, validators, 1, 1, pldtypes.RandBytes(32)))
+	assert.Equal(t, uint64(3), hv.status().Discrepancies)
+}
+
+func TestHeaderVerificationBadExtraData(t *testing.T) {
+	ctx := context.Background()
+	validators := testValidators(t, 1)
+	hv := newTestHeaderVerifier(t, "qbft", validatorAddresses(validators)...)
+
+	for _, extraData := range [][]byte{
+		{0xff},                          // invalid RLP
+		rlp.Data("not a list").Encode(), // not a list
+		rlp.List{rlp.Data{}, rlp.Data{}, rlp.List{}, rlp.Data{}, rlp.List{}}.Encode(),                    // validators not a list
+		rlp.List{rlp.Data{}, rlp.List{rlp.Data{0x01}}, rlp.List{}, rlp.Data{}, rlp.List{}}.Encode(),      // validator not an address
+		rlp.List{rlp.Data{}, rlp.List{}, rlp.List{}, rlp.Data{}, rlp.List{rlp.Data{0x01}}}.Encode()[0:5], // truncated
+	} {
+		h := testHeader(1, pldtypes.RandBytes(32))
+		h.ExtraData = extraData
+		hv.verify(ctx, h)
+		assert.Regexp(t, "PD011324", hv.status().LastDiscrepancy.Error)
+	}
+
+	// A seal that is not a signature is ignored
+	h := testHeader(1, pldtypes.RandBytes(32))
+	h.ExtraData = rlp.List{rlp.Data{}, rlp.List{rlp.WrapAddress(&validators[0].Address)}, rlp.List{}, rlp.Data{}, rlp.List{rlp.Data{0x01}}}.Encode()
+	extraData, err := parseBFTExtraData(ctx, h)
+	require.NoError(t, err)
+	h.Hash = h.keccak(extraData.encode(headerConsensusQBFT, false))
+	hv.verify(ctx, h)
+	assert.Regexp(t, "PD011329.*0 of 1", hv.status().LastDiscrepancy.Error)
+}
+
+func TestBlockListenerVerifiesHeaders(t *testing.T) {
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+	bl, mRPC := newTestBlockListenerConf(t, ctx, &pldconf.BlockIndexerConfig{
+		HeaderVerification: pldconf.HeaderVerificationConfig{Enabled: confutil.P(true)},
+	})
+
+	h := testHeader(1000, pldtypes.RandBytes(32))
+	h.Hash = h.keccak(nil)
+	rawBlock, err := json.Marshal(h)
+	require.NoError(t, err)
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getBlockByHash", h.Hash.String(), true).Return(nil).Run(func(args mock.Arguments) {
+		*args[1].(*json.RawMessage) = rawBlock
+	})
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getBlockByNumber", ethtypes.HexUint64(1000), true).Return(nil).Run(func(args mock.Arguments) {
+		*args[1].(*json.RawMessage) = json.RawMessage(`{"number": false}`)
+	})
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getBlockByNumber", ethtypes.HexUint64(1001), true).Return(nil).Run(func(args mock.Arguments) {
+		*args[1].(*json.RawMessage) = json.RawMessage(`null`)
+	})
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getBlockByNumber", ethtypes.HexUint64(1002), true).
+		Return(rpcclient.WrapRPCError(rpcclient.RPCCodeInternalError, fmt.Errorf("pop")))
+
+	bi, err := bl.getBlockInfoByHash(ctx, h.Hash.String())
+	require.NoError(t, err)
+	assert.Equal(t, h.Hash, bi.Hash)
+	assert.Equal(t, uint64(1), bl.headerVerifier.status().BlocksVerified)
+
+	_, err = bl.getBlockInfoByNumber(ctx, 1000)
+	assert.Error(t, err)
+
+	bi, err = bl.getBlockInfoByNumber(ctx, 1001)
+	require.NoError(t, err)
+	assert.Nil(t, bi)
+
+	_, err = bl.getBlockInfoByNumber(ctx, 1002)
+	assert.Regexp(t, "pop", err)
+}
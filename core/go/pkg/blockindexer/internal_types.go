@@ -45,6 +45,17 @@ var IndexedEventFilters filters.FieldSet = filters.FieldMap{
 	"signature":        filters.HexBytesField("signature"),
 }
 
+var DecodedEventFilters filters.FieldSet = filters.FieldMap{
+	"blockNumber":       filters.Int64Field("block_number"),
+	"transactionIndex":  filters.Int64Field("transaction_index"),
+	"logIndex":          filters.Int64Field("log_index"),
+	"transactionHash":   filters.HexBytesField("transaction_hash"),
+	"signature":         filters.HexBytesField("signature"),
+	"address":           filters.HexBytesField("address"),
+	"soliditySignature": filters.StringField("solidity_signature"),
+	"data":              filters.JSONField("data"),
+}
+
 var EventStreamFilters filters.FieldSet = filters.FieldMap{
 	"name":    filters.StringField("name"),
 	"created": filters.TimestampField("created"),
@@ -56,5 +67,7 @@ var EventStreamFilters filters.FieldSet = filters.FieldMap{
 // and persist during PreCommitHandlers and PostCommitHandlers (no JSON serialization for these)
 type IndexedTransactionNotify struct {
 	pldapi.IndexedTransaction
-	RevertReason pldtypes.HexBytes
+	RevertReason      pldtypes.HexBytes
+	GasUsed           *pldtypes.HexUint64  // from the receipt, for fee accounting - not indexed
+	EffectiveGasPrice *pldtypes.HexUint256 // nil if the node does not return it in the receipt
 }
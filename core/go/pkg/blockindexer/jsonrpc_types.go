@@ -28,6 +28,32 @@ type BlockInfoJSONRPC struct {
 	Transactions []*PartialTransactionInfo `json:"transactions"`
 }
 
+// The full header of a block, which is only parsed when header verification is enabled
+type BlockHeaderJSONRPC struct {
+	Number                ethtypes.HexUint64        `json:"number"`
+	Hash                  ethtypes.HexBytes0xPrefix `json:"hash"`
+	ParentHash            ethtypes.HexBytes0xPrefix `json:"parentHash"`
+	Sha3Uncles            ethtypes.HexBytes0xPrefix `json:"sha3Uncles"`
+	Miner                 ethtypes.HexBytes0xPrefix `json:"miner"`
+	StateRoot             ethtypes.HexBytes0xPrefix `json:"stateRoot"`
+	TransactionsRoot      ethtypes.HexBytes0xPrefix `json:"transactionsRoot"`
+	ReceiptsRoot          ethtypes.HexBytes0xPrefix `json:"receiptsRoot"`
+	LogsBloom             ethtypes.HexBytes0xPrefix `json:"logsBloom"`
+	Difficulty            *ethtypes.HexInteger      `json:"difficulty"`
+	GasLimit              ethtypes.HexUint64        `json:"gasLimit"`
+	GasUsed               ethtypes.HexUint64        `json:"gasUsed"`
+	Timestamp             ethtypes.HexUint64        `json:"timestamp"`
+	ExtraData             ethtypes.HexBytes0xPrefix `json:"extraData"`
+	MixHash               ethtypes.HexBytes0xPrefix `json:"mixHash"`
+	Nonce                 ethtypes.HexBytes0xPrefix `json:"nonce"`
+	BaseFeePerGas         *ethtypes.HexInteger      `json:"baseFeePerGas,omitempty"`         // London
+	WithdrawalsRoot       ethtypes.HexBytes0xPrefix `json:"withdrawalsRoot,omitempty"`       // Shanghai
+	BlobGasUsed           *ethtypes.HexUint64       `json:"blobGasUsed,omitempty"`           // Cancun
+	ExcessBlobGas         *ethtypes.HexUint64       `json:"excessBlobGas,omitempty"`         // Cancun
+	ParentBeaconBlockRoot ethtypes.HexBytes0xPrefix `json:"parentBeaconBlockRoot,omitempty"` // Cancun
+	RequestsHash          ethtypes.HexBytes0xPrefix `json:"requestsHash,omitempty"`          // Prague
+}
+
 // For memory efficiency we only retain in memory some of the fields returned in the JSON from the node
 type PartialTransactionInfo struct {
 	Hash  ethtypes.HexBytes0xPrefix `json:"hash"`
@@ -40,6 +66,7 @@ type TXReceiptJSONRPC struct {
 	BlockNumber       ethtypes.HexUint64        `json:"blockNumber"`
 	ContractAddress   *ethtypes.Address0xHex    `json:"contractAddress"`
 	CumulativeGasUsed *ethtypes.HexInteger      `json:"cumulativeGasUsed"`
+	EffectiveGasPrice *ethtypes.HexInteger      `json:"effectiveGasPrice"`
 	From              *ethtypes.Address0xHex    `json:"from"`
 	GasUsed           *ethtypes.HexInteger      `json:"gasUsed"`
 	Logs              []*LogJSONRPC             `json:"logs"`
@@ -0,0 +1,67 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package bootstrap
+
+import (
+	"bytes"
+	"os"
+	"time"
+
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/internal/componentmgr"
+	"github.com/kaleido-io/paladin/core/pkg/config"
+)
+
+// watchConfig polls the config file, and passes it to the component manager to apply the
+// sections that can be changed at runtime whenever the content of the file changes.
+// References to environment variables and secrets are only resolved again when the file changes.
+func (i *instance) watchConfig(cm componentmgr.ComponentManager, conf *pldconf.PaladinConfig, done chan struct{}) {
+	defer close(done)
+	interval := confutil.DurationMin(conf.ConfigReload.Interval, 10*time.Millisecond, *pldconf.ConfigReloadConfigDefaults.Interval)
+	lastContent, _ := os.ReadFile(i.configFile)
+	log.L(i.ctx).Infof("Watching config file %s for changes every %s", i.configFile, interval)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-i.ctx.Done():
+			log.L(i.ctx).Debugf("Config watcher stopped")
+			return
+		case <-ticker.C:
+		}
+		content, err := os.ReadFile(i.configFile)
+		if err != nil || bytes.Equal(content, lastContent) {
+			// a missing file is normal while it is being replaced
+			continue
+		}
+		// We only try each new version of the file once, rather than logging the same error every interval
+		lastContent = content
+		var newConf pldconf.PaladinConfig
+		if err := config.ReadAndParseYAMLFile(i.ctx, i.configFile, &newConf); err != nil {
+			log.L(i.ctx).Errorf("Ignoring changed config file: %s", err)
+			continue
+		}
+		changes, err := cm.ReloadConfig(&newConf)
+		if err != nil {
+			log.L(i.ctx).Errorf("Failed to reload config: %s", err)
+			continue
+		}
+		log.L(i.ctx).Infof("Config file reloaded with %d changes applied", len(changes))
+	}
+}
@@ -0,0 +1,84 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package bootstrap
+
+import (
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/mocks/componentmocks"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func writeWatchedConfig(t *testing.T, configFile, logLevel string) {
+	err := os.WriteFile(configFile, []byte(fmt.Sprintf(`{
+	  "blockchain": { "http": { "url": "http://localhost:8545" } },
+	  "configReload": { "enabled": true, "interval": "10ms" },
+	  "log": { "level": "%s" }
+	}`, logLevel)), 0664)
+	require.NoError(t, err)
+}
+
+func TestConfigWatcherReload(t *testing.T) {
+
+	cmStarted := make(chan struct{})
+	reloaded := make(chan string, 2)
+	socketFile, loaderUUID, configFile, done := setupTestConfig(t, func(mockCM *componentmocks.ComponentManager) {
+		mockCM.On("Init").Return(nil)
+		mockCM.On("StartManagers").Return(nil)
+		mockCM.On("CompleteStart").Return(nil).Run(func(args mock.Arguments) {
+			close(cmStarted)
+		})
+		mockCM.On("ReloadConfig", mock.MatchedBy(func(conf *pldconf.PaladinConfig) bool {
+			return *conf.Log.Level == "wrong"
+		})).Return(nil, fmt.Errorf("pop")).Run(func(args mock.Arguments) {
+			reloaded <- "wrong"
+		})
+		mockCM.On("ReloadConfig", mock.MatchedBy(func(conf *pldconf.PaladinConfig) bool {
+			return *conf.Log.Level == "debug"
+		})).Return([]*pldapi.ConfigChange{{Section: "log"}}, nil).Run(func(args mock.Arguments) {
+			reloaded <- "debug"
+		})
+		mockCM.On("Stop").Return()
+	})
+	defer done()
+	writeWatchedConfig(t, configFile, "info")
+
+	completed := make(chan RC)
+	go func() {
+		completed <- Run(socketFile, loaderUUID, configFile, RunModeEngine)
+	}()
+	<-cmStarted
+
+	// a file that cannot be parsed is skipped, without calling the component manager
+	require.NoError(t, os.WriteFile(configFile, []byte(`{!!!`), 0664))
+	time.Sleep(50 * time.Millisecond)
+
+	writeWatchedConfig(t, configFile, "wrong")
+	require.Equal(t, "wrong", <-reloaded)
+
+	writeWatchedConfig(t, configFile, "debug")
+	require.Equal(t, "debug", <-reloaded)
+
+	Stop()
+	require.Equal(t, RC_OK, <-completed)
+
+}
@@ -17,6 +17,8 @@ package bootstrap
 
 import (
 	"context"
+	"encoding/json"
+	"io"
 	"os"
 	"os/signal"
 	"strconv"
@@ -25,6 +27,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
 	"github.com/kaleido-io/paladin/config/pkg/pldconf"
 	"github.com/kaleido-io/paladin/core/internal/componentmgr"
 	"github.com/kaleido-io/paladin/core/internal/components"
@@ -37,6 +40,14 @@ import (
 
 var componentManagerFactory = componentmgr.NewComponentManager
 
+var validateConfigOutput io.Writer = os.Stdout
+
+const (
+	RunModeEngine         = "engine"
+	RunModeTestbed        = "testbed"
+	RunModeValidateConfig = "validate-config" // loads and checks the config, then exits with a report rather than starting
+)
+
 type instance struct {
 	grpcTarget string
 	loaderUUID string
@@ -93,16 +104,20 @@ func (i *instance) run() RC {
 	}
 
 	var conf pldconf.PaladinConfig
-	if err = config.ReadAndParseYAMLFile(i.ctx, i.configFile, &conf); err != nil {
+	err = config.ReadAndParseYAMLFile(i.ctx, i.configFile, &conf)
+	if i.runMode == RunModeValidateConfig {
+		return i.validateConfig(&conf, err)
+	}
+	if err != nil {
 		log.L(i.ctx).Error(err.Error())
 		return RC_FAIL
 	}
 
 	var additionalManagers []components.AdditionalManager
 	switch i.runMode {
-	case "testbed":
+	case RunModeTestbed:
 		additionalManagers = append(additionalManagers, testbed.NewTestBed())
-	case "engine":
+	case RunModeEngine:
 	default:
 		log.L(i.ctx).Error(i18n.NewError(i.ctx, msgs.MsgEntrypointUnknownRunMode, i.runMode))
 		return RC_FAIL
@@ -129,12 +144,42 @@ func (i *instance) run() RC {
 		return RC_FAIL
 	}
 
+	if confutil.Bool(conf.ConfigReload.Enabled, *pldconf.ConfigReloadConfigDefaults.Enabled) {
+		watcherDone := make(chan struct{})
+		go i.watchConfig(cm, &conf, watcherDone)
+		// The component manager must not be stopped while a reload is being applied
+		defer func() { <-watcherDone }()
+	}
+
 	// We're started... we just wait for the request to stop
 	<-i.ctx.Done()
 
 	return RC_OK
 }
 
+// validateConfig writes a JSON report to stdout, so it can be consumed by deployment tooling
+// before rolling out a config change, and returns RC_FAIL if the node would fail to start
+func (i *instance) validateConfig(conf *pldconf.PaladinConfig, loadErr error) RC {
+	var report *config.ValidationReport
+	if loadErr != nil {
+		report = config.NewLoadFailureReport(i.configFile, loadErr)
+	} else {
+		report = config.ValidatePaladinConfig(i.ctx, i.configFile, conf)
+	}
+	encoder := json.NewEncoder(validateConfigOutput)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		log.L(i.ctx).Errorf("Failed to write config validation report: %s", err)
+		return RC_FAIL
+	}
+	if !report.Valid {
+		log.L(i.ctx).Errorf("Config validation failed with %d errors", len(report.Errors))
+		return RC_FAIL
+	}
+	log.L(i.ctx).Infof("Config validation passed with %d warnings", len(report.Warnings))
+	return RC_OK
+}
+
 func (i *instance) stop() {
 	if i.stopped.CompareAndSwap(false, true) {
 		i.cancelCtx()
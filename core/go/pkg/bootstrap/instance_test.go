@@ -16,14 +16,19 @@
 package bootstrap
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"os"
 	"path"
 	"syscall"
 	"testing"
 
 	"github.com/kaleido-io/paladin/core/mocks/componentmocks"
+	"github.com/kaleido-io/paladin/core/pkg/config"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 func TestSignalHandlerStop(t *testing.T) {
@@ -88,3 +93,78 @@ func TestComponentManagerStartFail(t *testing.T) {
 	Run(socketFile, loaderUUID, configFile, "engine")
 
 }
+
+func runValidateConfig(t *testing.T, configFile string) (RC, *config.ValidationReport) {
+	socketFile, loaderUUID, _, done := setupTestConfig(t)
+	defer done()
+
+	var output bytes.Buffer
+	origOutput := validateConfigOutput
+	validateConfigOutput = &output
+	defer func() { validateConfigOutput = origOutput }()
+
+	rc := Run(socketFile, loaderUUID, configFile, RunModeValidateConfig)
+	var report config.ValidationReport
+	require.NoError(t, json.Unmarshal(output.Bytes(), &report))
+	return rc, &report
+}
+
+func TestValidateConfigOK(t *testing.T) {
+
+	// the component manager is never created, so has no expectations
+	_, _, configFile, done := setupTestConfig(t)
+	defer done()
+
+	rc, report := runValidateConfig(t, configFile)
+	assert.Equal(t, RC_OK, rc)
+	assert.True(t, report.Valid)
+	assert.Equal(t, configFile, report.ConfigFile)
+	assert.Empty(t, report.Errors)
+
+}
+
+func TestValidateConfigErrors(t *testing.T) {
+
+	configFile := path.Join(t.TempDir(), "paladin.conf.yaml")
+	err := os.WriteFile(configFile, []byte(`{
+	  "domains": { "domain1": { "registryAddress": "wrong" } }
+	}`), 0664)
+	require.NoError(t, err)
+
+	rc, report := runValidateConfig(t, configFile)
+	assert.Equal(t, RC_FAIL, rc)
+	assert.False(t, report.Valid)
+	require.Len(t, report.Errors, 1)
+	assert.Equal(t, "domains.domain1.registryAddress", report.Errors[0].Key)
+
+}
+
+func TestValidateConfigLoadFail(t *testing.T) {
+
+	rc, report := runValidateConfig(t, path.Join(t.TempDir(), "wrong.yaml"))
+	assert.Equal(t, RC_FAIL, rc)
+	assert.False(t, report.Valid)
+	require.Len(t, report.Errors, 1)
+	assert.Equal(t, "PD050000", report.Errors[0].Code)
+
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, fmt.Errorf("pop")
+}
+
+func TestValidateConfigWriteFail(t *testing.T) {
+
+	socketFile, loaderUUID, configFile, done := setupTestConfig(t)
+	defer done()
+
+	origOutput := validateConfigOutput
+	validateConfigOutput = failingWriter{}
+	defer func() { validateConfigOutput = origOutput }()
+
+	rc := Run(socketFile, loaderUUID, configFile, RunModeValidateConfig)
+	assert.Equal(t, RC_FAIL, rc)
+
+}
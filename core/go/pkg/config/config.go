@@ -17,6 +17,7 @@ package config
 
 import (
 	"context"
+	"encoding/json"
 	"os"
 
 	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
@@ -35,7 +36,16 @@ func ReadAndParseYAMLFile(ctx context.Context, filePath string, config interface
 		return i18n.NewError(ctx, MsgConfigFileReadError, filePath, err.Error())
 	}
 
-	err = yaml.Unmarshal(data, config)
+	// Resolve any environment variable and secret references on the parsed tree, before we bind
+	// it to the config structure, so that errors can name the key that contains the reference
+	tree, err := parseYAMLTree(data)
+	if err == nil {
+		if tree, err = resolveReferences(ctx, "", tree); err != nil {
+			return err
+		}
+		resolved, _ := json.Marshal(tree)
+		err = yaml.Unmarshal(resolved, config)
+	}
 	if err != nil {
 		return i18n.NewError(ctx, MsgConfigFileParseError, err.Error())
 	}
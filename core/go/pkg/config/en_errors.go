@@ -37,4 +37,19 @@ var (
 	MsgConfigFileReadError             = pde("PD050001", "Failed to read config file %s with error: %s")
 	MsgConfigFileParseError            = pde("PD050002", "Failed to parse config file %s with error: %s")
 	MsgConfigFileMissingMandatoryValue = pde("PD050003", "Mandatory config field %s missing ")
+	MsgConfigRefUnterminated           = pde("PD050004", "Unterminated ${...} reference in config key '%s'")
+	MsgConfigRefEnvVarNotSet           = pde("PD050005", "Environment variable '%s' referenced by config key '%s' is not set")
+	MsgConfigRefFileReadError          = pde("PD050006", "Failed to read secret file '%s' referenced by config key '%s': %s")
+	MsgConfigRefVaultInvalid           = pde("PD050007", "Invalid vault reference '%s' in config key '%s' - the format is vault://<path>#<field>")
+	MsgConfigRefVaultError             = pde("PD050008", "Failed to read vault secret '%s' referenced by config key '%s': %s")
+	MsgConfigRefVaultFieldMissing      = pde("PD050009", "Field '%s' not found in vault secret '%s' referenced by config key '%s'")
+	MsgConfigInvalidKeySelector        = pde("PD050010", "Invalid keySelector for wallet '%s': %s")
+	MsgConfigNoWalletForKey            = pde("PD050011", "No wallet keySelector matches the key '%s' in config key '%s'")
+	MsgConfigInvalidAddress            = pde("PD050012", "Invalid address '%s' in config key '%s': %s")
+	MsgConfigInvalidNumber             = pde("PD050013", "Invalid number '%s' in config key '%s'")
+	MsgConfigMaxBelowMin               = pde("PD050014", "Config key '%s' (%s) must not be less than '%s' (%s)")
+	MsgConfigBelowMinimum              = pde("PD050015", "Config key '%s' must be at least %d (value=%d)")
+	MsgConfigBelowMinimumRaised        = pde("PD050016", "Config key '%s' is below the minimum of %d, and will be raised to it (value=%d)")
+	MsgConfigNoOrderingNodes           = pde("PD050017", "No ordering nodes configured in config key '%s'")
+	MsgConfigDuplicateWalletName       = pde("PD050018", "Duplicate wallet name '%s' in config key '%s'")
 )
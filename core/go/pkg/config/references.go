@@ -0,0 +1,221 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package config
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"sigs.k8s.io/yaml"
+)
+
+// References can be used anywhere within a string value in the config:
+//
+//	${ENV_VAR}                      - the value of an environment variable, which must be set
+//	${ENV_VAR:-default}             - the value of an environment variable, or the default if unset or empty
+//	${file:///path/to/secret}       - the contents of a file, with any trailing newline removed
+//	${vault://secret/data/db#dsn}   - a field from a HashiCorp Vault secret, using VAULT_ADDR and VAULT_TOKEN
+//	$${                             - a literal "${"
+//
+// When a reference is the whole of a value, and resolves to a number or boolean, then the value
+// takes that type - so "port: ${RPC_PORT}" works for an integer field.
+const (
+	refStart       = "${"
+	refEnd         = "}"
+	refEscaped     = "$${"
+	refDefault     = ":-"
+	refFilePrefix  = "file://"
+	refVaultPrefix = "vault://"
+)
+
+var vaultHTTPClient = &http.Client{Timeout: 30 * time.Second}
+
+// parseYAMLTree keeps numbers as json.Number, so large integers are not rounded through float64
+func parseYAMLTree(data []byte) (tree any, err error) {
+	jsonData, err := yaml.YAMLToJSON(data)
+	if err == nil {
+		decoder := json.NewDecoder(bytes.NewReader(jsonData))
+		decoder.UseNumber()
+		err = decoder.Decode(&tree)
+	}
+	return tree, err
+}
+
+func resolveReferences(ctx context.Context, key string, v any) (any, error) {
+	switch tv := v.(type) {
+	case map[string]any:
+		for childKey, childValue := range tv {
+			childPath := childKey
+			if key != "" {
+				childPath = key + "." + childKey
+			}
+			resolved, err := resolveReferences(ctx, childPath, childValue)
+			if err != nil {
+				return nil, err
+			}
+			tv[childKey] = resolved
+		}
+		return tv, nil
+	case []any:
+		for i, childValue := range tv {
+			resolved, err := resolveReferences(ctx, fmt.Sprintf("%s[%d]", key, i), childValue)
+			if err != nil {
+				return nil, err
+			}
+			tv[i] = resolved
+		}
+		return tv, nil
+	case string:
+		return resolveStringValue(ctx, key, tv)
+	default:
+		return v, nil
+	}
+}
+
+func resolveStringValue(ctx context.Context, key, value string) (any, error) {
+	if !strings.Contains(value, refStart) {
+		return value, nil
+	}
+	wholeValueRef := strings.HasPrefix(value, refStart) && strings.Index(value, refEnd) == len(value)-1
+	var sb strings.Builder
+	remaining := value
+	for {
+		idx := strings.Index(remaining, "$")
+		if idx < 0 {
+			sb.WriteString(remaining)
+			break
+		}
+		sb.WriteString(remaining[:idx])
+		remaining = remaining[idx:]
+		switch {
+		case strings.HasPrefix(remaining, refEscaped):
+			sb.WriteString(refStart)
+			remaining = remaining[len(refEscaped):]
+		case strings.HasPrefix(remaining, refStart):
+			end := strings.Index(remaining, refEnd)
+			if end < 0 {
+				return nil, i18n.NewError(ctx, MsgConfigRefUnterminated, key)
+			}
+			resolved, err := resolveReference(ctx, key, remaining[len(refStart):end])
+			if err != nil {
+				return nil, err
+			}
+			sb.WriteString(resolved)
+			remaining = remaining[end+len(refEnd):]
+		default:
+			sb.WriteString("$")
+			remaining = remaining[1:]
+		}
+	}
+	resolved := sb.String()
+	if wholeValueRef {
+		// Allow a reference to supply a number or boolean, in the same way it would if written directly in the YAML
+		if typed, err := parseYAMLTree([]byte(resolved)); err == nil {
+			switch typed.(type) {
+			case json.Number, bool:
+				return typed, nil
+			}
+		}
+	}
+	return resolved, nil
+}
+
+func resolveReference(ctx context.Context, key, ref string) (string, error) {
+	switch {
+	case strings.HasPrefix(ref, refFilePrefix):
+		filePath := strings.TrimPrefix(ref, refFilePrefix)
+		data, err := os.ReadFile(filePath)
+		if err != nil {
+			return "", i18n.NewError(ctx, MsgConfigRefFileReadError, filePath, key, err.Error())
+		}
+		return strings.TrimRight(string(data), "\r\n"), nil
+	case strings.HasPrefix(ref, refVaultPrefix):
+		return resolveVaultReference(ctx, key, strings.TrimPrefix(ref, refVaultPrefix))
+	default:
+		envVar, defValue, hasDefault := strings.Cut(ref, refDefault)
+		value := os.Getenv(envVar)
+		if value == "" {
+			if !hasDefault {
+				return "", i18n.NewError(ctx, MsgConfigRefEnvVarNotSet, envVar, key)
+			}
+			value = defValue
+		}
+		return value, nil
+	}
+}
+
+func resolveVaultReference(ctx context.Context, key, ref string) (string, error) {
+	secretPath, field, ok := strings.Cut(ref, "#")
+	if !ok || secretPath == "" || field == "" {
+		return "", i18n.NewError(ctx, MsgConfigRefVaultInvalid, refVaultPrefix+ref, key)
+	}
+	vaultAddr := os.Getenv("VAULT_ADDR")
+	if vaultAddr == "" {
+		return "", i18n.NewError(ctx, MsgConfigRefVaultError, secretPath, key, "VAULT_ADDR is not set")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(vaultAddr, "/")+"/v1/"+strings.TrimPrefix(secretPath, "/"), nil)
+	if err != nil {
+		return "", i18n.NewError(ctx, MsgConfigRefVaultError, secretPath, key, err.Error())
+	}
+	req.Header.Set("X-Vault-Token", os.Getenv("VAULT_TOKEN"))
+	if namespace := os.Getenv("VAULT_NAMESPACE"); namespace != "" {
+		req.Header.Set("X-Vault-Namespace", namespace)
+	}
+	res, err := vaultHTTPClient.Do(req)
+	if err != nil {
+		return "", i18n.NewError(ctx, MsgConfigRefVaultError, secretPath, key, err.Error())
+	}
+	defer res.Body.Close()
+	body, err := io.ReadAll(res.Body)
+	if err == nil && res.StatusCode != http.StatusOK {
+		err = fmt.Errorf("%d %s", res.StatusCode, http.StatusText(res.StatusCode))
+	}
+	var secret struct {
+		Data map[string]any `json:"data"`
+	}
+	if err == nil {
+		err = json.Unmarshal(body, &secret)
+	}
+	if err != nil {
+		return "", i18n.NewError(ctx, MsgConfigRefVaultError, secretPath, key, err.Error())
+	}
+
+	// The KV v2 secrets engine nests the fields inside a second "data" object, alongside the metadata
+	fields := secret.Data
+	if nested, ok := fields["data"].(map[string]any); ok {
+		if _, hasMetadata := fields["metadata"]; hasMetadata {
+			fields = nested
+		}
+	}
+	switch value := fields[field].(type) {
+	case string:
+		return value, nil
+	case nil:
+		return "", i18n.NewError(ctx, MsgConfigRefVaultFieldMissing, field, secretPath, key)
+	default:
+		b, _ := json.Marshal(value)
+		return string(b), nil
+	}
+}
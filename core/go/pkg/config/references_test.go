@@ -0,0 +1,146 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package config
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testRefConfigChild struct {
+	DSN      *string `json:"dsn"`
+	Password *string `json:"password"`
+}
+
+type testRefConfig struct {
+	Name     *string              `json:"name"`
+	Port     *int                 `json:"port"`
+	Enabled  *bool                `json:"enabled"`
+	Big      *uint64              `json:"big"`
+	Literal  *string              `json:"literal"`
+	Children []testRefConfigChild `json:"children"`
+}
+
+func writeTestConfigFile(t *testing.T, content string) string {
+	fileName := path.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(fileName, []byte(content), 0600))
+	return fileName
+}
+
+func TestReadAndParseYAMLFileReferences(t *testing.T) {
+	secretFile := path.Join(t.TempDir(), "password")
+	require.NoError(t, os.WriteFile(secretFile, []byte("s3cret\n"), 0600))
+
+	vault := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "vtoken", r.Header.Get("X-Vault-Token"))
+		assert.Equal(t, "ns1", r.Header.Get("X-Vault-Namespace"))
+		switch r.URL.Path {
+		case "/v1/secret/data/paladin":
+			_, _ = w.Write([]byte(`{"data":{"data":{"dsn":"postgres://db"},"metadata":{"version":1}}}`))
+		case "/v1/kv1/paladin":
+			_, _ = w.Write([]byte(`{"data":{"port":8545}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer vault.Close()
+
+	t.Setenv("VAULT_ADDR", vault.URL)
+	t.Setenv("VAULT_TOKEN", "vtoken")
+	t.Setenv("VAULT_NAMESPACE", "ns1")
+	t.Setenv("TEST_NODE_NAME", "node1")
+	t.Setenv("TEST_ENABLED", "true")
+
+	fileName := writeTestConfigFile(t, `
+name: paladin-${TEST_NODE_NAME}-${TEST_UNSET_SUFFIX:-a}
+port: ${vault://kv1/paladin#port}
+enabled: ${TEST_ENABLED}
+big: 18446744073709551615
+literal: $${NOT_A_REF} costs $5
+children:
+- dsn: ${vault://secret/data/paladin#dsn}
+  password: ${file://`+secretFile+`}
+`)
+
+	var conf testRefConfig
+	err := ReadAndParseYAMLFile(context.Background(), fileName, &conf)
+	require.NoError(t, err)
+	assert.Equal(t, "paladin-node1-a", *conf.Name)
+	assert.Equal(t, 8545, *conf.Port)
+	assert.True(t, *conf.Enabled)
+	assert.Equal(t, uint64(18446744073709551615), *conf.Big)
+	assert.Equal(t, "${NOT_A_REF} costs $5", *conf.Literal)
+	require.Len(t, conf.Children, 1)
+	assert.Equal(t, "postgres://db", *conf.Children[0].DSN)
+	assert.Equal(t, "s3cret", *conf.Children[0].Password)
+}
+
+func TestReadAndParseYAMLFileReferenceErrors(t *testing.T) {
+	ctx := context.Background()
+
+	vault := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/secret/ok":
+			_, _ = w.Write([]byte(`{"data":{"other":"value"}}`))
+		case "/v1/secret/badjson":
+			_, _ = w.Write([]byte(`!!!`))
+		default:
+			w.WriteHeader(http.StatusForbidden)
+		}
+	}))
+	defer vault.Close()
+	t.Setenv("VAULT_ADDR", vault.URL)
+
+	for _, tc := range []struct {
+		yaml  string
+		error string
+	}{
+		{yaml: "name: ${TEST_NOT_SET_ANYWHERE}", error: "PD050005.*TEST_NOT_SET_ANYWHERE.*'name'"},
+		{yaml: "children: [{dsn: '${unterminated'}]", error: "PD050004.*'children\\[0\\].dsn'"},
+		{yaml: "name: ${file:///does/not/exist}", error: "PD050006.*/does/not/exist.*'name'"},
+		{yaml: "name: ${vault://secret/ok}", error: "PD050007.*'name'"},
+		{yaml: "name: ${vault://secret/ok#missing}", error: "PD050009.*missing.*'name'"},
+		{yaml: "name: ${vault://secret/denied#field}", error: "PD050008.*403"},
+		{yaml: "name: ${vault://secret/badjson#field}", error: "PD050008.*invalid"},
+	} {
+		var conf testRefConfig
+		err := ReadAndParseYAMLFile(ctx, writeTestConfigFile(t, tc.yaml), &conf)
+		assert.Regexp(t, tc.error, err)
+	}
+}
+
+func TestResolveVaultReferenceErrors(t *testing.T) {
+	ctx := context.Background()
+
+	t.Setenv("VAULT_ADDR", "")
+	_, err := resolveVaultReference(ctx, "key1", "secret/path#field")
+	assert.Regexp(t, "PD050008.*VAULT_ADDR", err)
+
+	t.Setenv("VAULT_ADDR", "http://localhost:0")
+	_, err = resolveVaultReference(ctx, "key1", "secret/path#field")
+	assert.Regexp(t, "PD050008", err)
+
+	t.Setenv("VAULT_ADDR", "::::")
+	_, err = resolveVaultReference(ctx, "key1", "secret/path#field")
+	assert.Regexp(t, "PD050008", err)
+}
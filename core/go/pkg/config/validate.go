@@ -0,0 +1,242 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"regexp"
+	"sort"
+
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+)
+
+type ValidationIssue struct {
+	Code    string `json:"code,omitempty"`
+	Key     string `json:"key,omitempty"`
+	Message string `json:"message"`
+}
+
+// ValidationReport is the machine-readable output of validating a config file without starting the node.
+// Errors would stop the node starting (or leave a component unable to function), while warnings
+// describe config that will be adjusted or only fail when a particular feature is used.
+type ValidationReport struct {
+	ConfigFile string             `json:"configFile"`
+	Valid      bool               `json:"valid"`
+	Errors     []*ValidationIssue `json:"errors"`
+	Warnings   []*ValidationIssue `json:"warnings"`
+}
+
+type configValidator struct {
+	ctx     context.Context
+	conf    *pldconf.PaladinConfig
+	report  *ValidationReport
+	wallets []*regexp.Regexp
+}
+
+func newValidationIssue(key string, err error) *ValidationIssue {
+	issue := &ValidationIssue{Key: key, Message: err.Error()}
+	var pdErr i18n.PDError
+	if errors.As(err, &pdErr) {
+		issue.Code = string(pdErr.MessageKey())
+	}
+	return issue
+}
+
+// NewLoadFailureReport reports a config file that could not be read or parsed, so could not be validated
+func NewLoadFailureReport(configFile string, err error) *ValidationReport {
+	return &ValidationReport{
+		ConfigFile: configFile,
+		Errors:     []*ValidationIssue{newValidationIssue("", err)},
+		Warnings:   []*ValidationIssue{},
+	}
+}
+
+// ValidatePaladinConfig performs checks that span multiple parts of the config, which the individual
+// components would otherwise only detect (or silently adjust for) during startup.
+func ValidatePaladinConfig(ctx context.Context, configFile string, conf *pldconf.PaladinConfig) *ValidationReport {
+	v := &configValidator{
+		ctx:  ctx,
+		conf: conf,
+		report: &ValidationReport{
+			ConfigFile: configFile,
+			Errors:     []*ValidationIssue{},
+			Warnings:   []*ValidationIssue{},
+		},
+	}
+	v.validateWallets()
+	v.validateAutoFueling()
+	v.validatePublicTxValidation()
+	v.validateEvidence()
+	v.validateDomains()
+	v.validateOrderedContracts()
+	v.validateOrchestratorLimits()
+	v.report.Valid = len(v.report.Errors) == 0
+	return v.report
+}
+
+func (v *configValidator) addError(key string, msg i18n.ErrorMessageKey, inserts ...any) {
+	v.report.Errors = append(v.report.Errors, newValidationIssue(key, i18n.NewError(v.ctx, msg, inserts...)))
+}
+
+func (v *configValidator) addWarning(key string, msg i18n.ErrorMessageKey, inserts ...any) {
+	v.report.Warnings = append(v.report.Warnings, newValidationIssue(key, i18n.NewError(v.ctx, msg, inserts...)))
+}
+
+func (v *configValidator) validateWallets() {
+	names := make(map[string]bool)
+	for i, w := range v.conf.Wallets {
+		key := fmt.Sprintf("wallets[%d]", i)
+		if names[w.Name] {
+			v.addError(key+".name", MsgConfigDuplicateWalletName, w.Name, key+".name")
+		}
+		names[w.Name] = true
+		keySelector, err := regexp.Compile(confutil.StringNotEmpty(&w.KeySelector, pldconf.WalletDefaults.KeySelector))
+		if err != nil {
+			v.addError(key+".keySelector", MsgConfigInvalidKeySelector, w.Name, err.Error())
+			continue
+		}
+		v.wallets = append(v.wallets, keySelector)
+	}
+}
+
+// hasWalletForKey uses the same ordered keySelector matching as the key manager, to check
+// that resolving the key identifier at runtime will find a wallet to allocate it in
+func (v *configValidator) hasWalletForKey(identifier string) bool {
+	for _, keySelector := range v.wallets {
+		if keySelector.MatchString(identifier) {
+			return true
+		}
+	}
+	return false
+}
+
+func (v *configValidator) parseBigInt(key string, value *string) *big.Int {
+	if value == nil {
+		return nil
+	}
+	bi, ok := new(big.Int).SetString(*value, 0)
+	if !ok {
+		v.addError(key, MsgConfigInvalidNumber, *value, key)
+	}
+	return bi
+}
+
+func (v *configValidator) validateAutoFueling() {
+	const afKey = "publicTxManager.balanceManager.autoFueling"
+	af := &v.conf.PublicTxManager.BalanceManager.AutoFueling
+
+	if source := confutil.StringOrEmpty(af.Source, ""); source != "" && !v.hasWalletForKey(source) {
+		v.addError(afKey+".source", MsgConfigNoWalletForKey, source, afKey+".source")
+	}
+	for i, s := range af.Sources {
+		key := fmt.Sprintf("%s.sources[%d]", afKey, i)
+		if !v.hasWalletForKey(s.Source) {
+			v.addError(key+".source", MsgConfigNoWalletForKey, s.Source, key+".source")
+		}
+		v.parseBigInt(key+".minBalance", s.MinBalance)
+		v.parseBigInt(key+".dailySpendingCap", s.DailySpendingCap)
+	}
+	v.parseBigInt(afKey+".sourceAddressMinBalance", af.SourceAddressMinBalance)
+	v.parseBigInt(afKey+".sourceDailySpendingCap", af.SourceDailySpendingCap)
+
+	minDestBalance := v.parseBigInt(afKey+".minDestBalance", af.MinDestBalance)
+	maxDestBalance := v.parseBigInt(afKey+".maxDestBalance", af.MaxDestBalance)
+	minThreshold := v.parseBigInt(afKey+".minThreshold", af.MinThreshold)
+	if maxDestBalance != nil && minDestBalance != nil && maxDestBalance.Cmp(minDestBalance) < 0 {
+		v.addError(afKey+".maxDestBalance", MsgConfigMaxBelowMin, afKey+".maxDestBalance", maxDestBalance.String(), afKey+".minDestBalance", minDestBalance.String())
+	}
+	if maxDestBalance != nil && minThreshold != nil && maxDestBalance.Cmp(minThreshold) < 0 {
+		v.addError(afKey+".maxDestBalance", MsgConfigMaxBelowMin, afKey+".maxDestBalance", maxDestBalance.String(), afKey+".minThreshold", minThreshold.String())
+	}
+}
+
+func (v *configValidator) validatePublicTxValidation() {
+	v.parseBigInt("publicTxManager.validation.maxValue", v.conf.PublicTxManager.Validation.MaxValue)
+}
+
+func (v *configValidator) validateEvidence() {
+	// The evidence key is only resolved when evidence is exported, so the node starts without it
+	const key = "txManager.evidence.signingKey"
+	signingKey := confutil.StringNotEmpty(v.conf.TxManager.Evidence.SigningKey, *pldconf.TxManagerDefaults.Evidence.SigningKey)
+	if !v.hasWalletForKey(signingKey) {
+		v.addWarning(key, MsgConfigNoWalletForKey, signingKey, key)
+	}
+}
+
+func (v *configValidator) validateDomains() {
+	names := make([]string, 0, len(v.conf.Domains))
+	for name := range v.conf.Domains {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		key := fmt.Sprintf("domains.%s.registryAddress", name)
+		registryAddress := v.conf.Domains[name].RegistryAddress
+		if _, err := pldtypes.ParseEthAddress(registryAddress); err != nil {
+			v.addError(key, MsgConfigInvalidAddress, registryAddress, key, err.Error())
+		}
+	}
+}
+
+func (v *configValidator) validateOrderedContracts() {
+	for i, oc := range v.conf.PrivateTxManager.OrderedContracts {
+		key := fmt.Sprintf("privateTxManager.orderedContracts[%d]", i)
+		if _, err := pldtypes.ParseEthAddress(oc.ContractAddress); err != nil {
+			v.addError(key+".contractAddress", MsgConfigInvalidAddress, oc.ContractAddress, key+".contractAddress", err.Error())
+		}
+		if len(oc.OrderingNodes) == 0 {
+			v.addError(key+".orderingNodes", MsgConfigNoOrderingNodes, key+".orderingNodes")
+		}
+	}
+}
+
+func (v *configValidator) validateOrchestratorLimits() {
+	// The public transaction manager raises values below the minimum, so these are warnings
+	for _, limit := range []struct {
+		key   string
+		value *int
+		min   int
+	}{
+		{key: "publicTxManager.manager.maxInFlightOrchestrators", value: v.conf.PublicTxManager.Manager.MaxInFlightOrchestrators, min: 1},
+		{key: "publicTxManager.orchestrator.maxInFlight", value: v.conf.PublicTxManager.Orchestrator.MaxInFlight, min: 1},
+		{key: "publicTxManager.orchestrator.maxOverflow", value: v.conf.PublicTxManager.Orchestrator.MaxOverflow, min: 0},
+	} {
+		if limit.value != nil && *limit.value < limit.min {
+			v.addWarning(limit.key, MsgConfigBelowMinimumRaised, limit.key, limit.min, *limit.value)
+		}
+	}
+
+	// The private transaction sequencer uses these values directly, so they must be valid as configured
+	sequencer := &v.conf.PrivateTxManager.Sequencer
+	for _, limit := range []struct {
+		key   string
+		value *int
+	}{
+		{key: "privateTxManager.sequencer.maxConcurrentProcess", value: sequencer.MaxConcurrentProcess},
+		{key: "privateTxManager.sequencer.maxInflightTransactions", value: sequencer.MaxInflightTransactions},
+		{key: "privateTxManager.sequencer.maxPendingEvents", value: sequencer.MaxPendingEvents},
+	} {
+		if limit.value != nil && *limit.value < 1 {
+			v.addError(limit.key, MsgConfigBelowMinimum, limit.key, 1, *limit.value)
+		}
+	}
+}
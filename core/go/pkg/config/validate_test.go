@@ -0,0 +1,120 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package config
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func issueKeysAndCodes(issues []*ValidationIssue) map[string]string {
+	keyCodes := make(map[string]string)
+	for _, issue := range issues {
+		keyCodes[issue.Key] = issue.Code
+	}
+	return keyCodes
+}
+
+func TestValidatePaladinConfigOK(t *testing.T) {
+	conf := &pldconf.PaladinConfig{}
+	conf.Wallets = []*pldconf.WalletConfig{
+		{Name: "fuel", KeySelector: `^fuel\.`},
+		{Name: "node", KeySelector: `^node\.`},
+	}
+	conf.Domains = map[string]*pldconf.DomainConfig{
+		"domain1": {RegistryAddress: pldtypes.RandAddress().String()},
+	}
+	conf.PublicTxManager.BalanceManager.AutoFueling = pldconf.AutoFuelingConfig{
+		Source:         confutil.P("fuel.primary"),
+		Sources:        []*pldconf.AutoFuelingSourceConfig{{Source: "fuel.secondary", MinBalance: confutil.P("0x10")}},
+		MinDestBalance: confutil.P("100"),
+		MaxDestBalance: confutil.P("1000"),
+		MinThreshold:   confutil.P("50"),
+	}
+	conf.PrivateTxManager.OrderedContracts = []pldconf.PrivateTxManagerOrderedContract{
+		{ContractAddress: pldtypes.RandAddress().String(), OrderingNodes: []string{"node1"}},
+	}
+
+	report := ValidatePaladinConfig(context.Background(), "paladin.yaml", conf)
+	assert.True(t, report.Valid)
+	assert.Equal(t, "paladin.yaml", report.ConfigFile)
+	assert.Empty(t, report.Errors)
+	assert.Empty(t, report.Warnings)
+}
+
+func TestValidatePaladinConfigErrors(t *testing.T) {
+	conf := &pldconf.PaladinConfig{}
+	conf.Wallets = []*pldconf.WalletConfig{
+		{Name: "wallet1", KeySelector: `^fuel\.`},
+		{Name: "wallet1", KeySelector: `[[[`},
+	}
+	conf.Domains = map[string]*pldconf.DomainConfig{
+		"domain1": {RegistryAddress: ""},
+	}
+	conf.PublicTxManager.BalanceManager.AutoFueling = pldconf.AutoFuelingConfig{
+		Source:                  confutil.P("unmatched.primary"),
+		SourceAddressMinBalance: confutil.P("lots"),
+		Sources:                 []*pldconf.AutoFuelingSourceConfig{{Source: "unmatched.secondary"}},
+		MinDestBalance:          confutil.P("100"),
+		MaxDestBalance:          confutil.P("10"),
+		MinThreshold:            confutil.P("50"),
+	}
+	conf.PublicTxManager.Validation.MaxValue = confutil.P("unlimited")
+	conf.PublicTxManager.Manager.MaxInFlightOrchestrators = confutil.P(0)
+	conf.PublicTxManager.Orchestrator.MaxOverflow = confutil.P(-1)
+	conf.PrivateTxManager.Sequencer.MaxInflightTransactions = confutil.P(0)
+	conf.PrivateTxManager.OrderedContracts = []pldconf.PrivateTxManagerOrderedContract{
+		{ContractAddress: "wrong"},
+	}
+
+	report := ValidatePaladinConfig(context.Background(), "paladin.yaml", conf)
+	assert.False(t, report.Valid)
+	assert.Equal(t, map[string]string{
+		"wallets[1].name":                                                    "PD050018",
+		"wallets[1].keySelector":                                             "PD050010",
+		"domains.domain1.registryAddress":                                    "PD050012",
+		"publicTxManager.balanceManager.autoFueling.source":                  "PD050011",
+		"publicTxManager.balanceManager.autoFueling.sources[0].source":       "PD050011",
+		"publicTxManager.balanceManager.autoFueling.sourceAddressMinBalance": "PD050013",
+		"publicTxManager.balanceManager.autoFueling.maxDestBalance":          "PD050014",
+		"publicTxManager.validation.maxValue":                                "PD050013",
+		"privateTxManager.sequencer.maxInflightTransactions":                 "PD050015",
+		"privateTxManager.orderedContracts[0].contractAddress":               "PD050012",
+		"privateTxManager.orderedContracts[0].orderingNodes":                 "PD050017",
+	}, issueKeysAndCodes(report.Errors))
+	// both the min balance and the min threshold are above the max
+	assert.Len(t, report.Errors, 12)
+	assert.Equal(t, map[string]string{
+		"txManager.evidence.signingKey":                    "PD050011",
+		"publicTxManager.manager.maxInFlightOrchestrators": "PD050016",
+		"publicTxManager.orchestrator.maxOverflow":         "PD050016",
+	}, issueKeysAndCodes(report.Warnings))
+}
+
+func TestNewLoadFailureReport(t *testing.T) {
+	report := NewLoadFailureReport("paladin.yaml", fmt.Errorf("pop"))
+	assert.False(t, report.Valid)
+	require.Len(t, report.Errors, 1)
+	assert.Empty(t, report.Errors[0].Code)
+	assert.Equal(t, "pop", report.Errors[0].Message)
+}
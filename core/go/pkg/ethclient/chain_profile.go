@@ -0,0 +1,121 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package ethclient
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/hyperledger/firefly-signer/pkg/ethsigner"
+	"github.com/hyperledger/firefly-signer/pkg/secp256k1"
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/internal/msgs"
+)
+
+// The V value of a signature for a pre-EIP-155 transaction is 27 + the recovery ID
+const legacyOriginalVBase = 27
+
+// ChainProfile determines how transactions are encoded and signed for the chain, so that legacy
+// and private chains that do not accept EIP-1559 transactions can be submitted to.
+type ChainProfile struct {
+	txVersion EthTXVersion
+	chainID   *int64
+	vBase     *int64
+}
+
+func DefaultChainProfile() *ChainProfile {
+	return &ChainProfile{txVersion: EIP1559}
+}
+
+func NewChainProfile(ctx context.Context, conf *pldconf.ChainProfileConfig) (*ChainProfile, error) {
+	cp := &ChainProfile{
+		txVersion: EthTXVersion(confutil.StringNotEmpty(conf.TransactionType, *pldconf.EthClientDefaults.ChainProfile.TransactionType)),
+		chainID:   conf.ChainID,
+		vBase:     conf.VBase,
+	}
+	switch cp.txVersion {
+	case EIP1559, LEGACY_EIP155, LEGACY_ORIGINAL:
+	default:
+		return nil, i18n.NewError(ctx, msgs.MsgEthClientInvalidTXVersion, cp.txVersion)
+	}
+	if cp.chainID != nil && *cp.chainID <= 0 {
+		return nil, i18n.NewError(ctx, msgs.MsgEthClientInvalidChainIDOverride, *cp.chainID)
+	}
+	if cp.vBase != nil && (cp.txVersion != LEGACY_ORIGINAL || *cp.vBase < 0) {
+		return nil, i18n.NewError(ctx, msgs.MsgEthClientInvalidVBase, *cp.vBase, cp.txVersion)
+	}
+	return cp, nil
+}
+
+func (cp *ChainProfile) TXVersion() EthTXVersion {
+	return cp.txVersion
+}
+
+// withTXVersion returns a copy of the profile that signs with a different transaction type, for callers
+// that choose the type per transaction. The custom V base only applies to legacy_original.
+func (cp *ChainProfile) withTXVersion(ctx context.Context, txVersion EthTXVersion) (*ChainProfile, error) {
+	switch txVersion {
+	case EIP1559, LEGACY_EIP155, LEGACY_ORIGINAL:
+	default:
+		return nil, i18n.NewError(ctx, msgs.MsgEthClientInvalidTXVersion, txVersion)
+	}
+	txcp := &ChainProfile{txVersion: txVersion, chainID: cp.chainID}
+	if txVersion == LEGACY_ORIGINAL {
+		txcp.vBase = cp.vBase
+	}
+	return txcp, nil
+}
+
+// Legacy transactions have a single gas price, so the max fee is used when only EIP-1559 pricing is supplied
+func legacyPricedTX(tx *ethsigner.Transaction) *ethsigner.Transaction {
+	if tx.GasPrice != nil || tx.MaxFeePerGas == nil {
+		return tx
+	}
+	legacyTX := *tx
+	legacyTX.GasPrice = tx.MaxFeePerGas
+	return &legacyTX
+}
+
+// SignaturePayload returns the payload to hash and sign for the transaction
+func (cp *ChainProfile) SignaturePayload(tx *ethsigner.Transaction, chainID int64) *ethsigner.TransactionSignaturePayload {
+	switch cp.txVersion {
+	case LEGACY_EIP155:
+		return legacyPricedTX(tx).SignaturePayloadLegacyEIP155(chainID)
+	case LEGACY_ORIGINAL:
+		return legacyPricedTX(tx).SignaturePayloadLegacyOriginal()
+	default:
+		return tx.SignaturePayloadEIP1559(chainID)
+	}
+}
+
+// FinalizeWithSignature builds the raw transaction to send to the node, from the payload returned by
+// SignaturePayload and the signature over it. The V value of the signature is updated to suit the chain.
+func (cp *ChainProfile) FinalizeWithSignature(tx *ethsigner.Transaction, sigPayload *ethsigner.TransactionSignaturePayload, sig *secp256k1.SignatureData, chainID int64) ([]byte, error) {
+	switch cp.txVersion {
+	case LEGACY_EIP155:
+		return tx.FinalizeLegacyEIP155WithSignature(sigPayload, sig, chainID)
+	case LEGACY_ORIGINAL:
+		if cp.vBase != nil {
+			recoveryID := new(big.Int).Sub(sig.V, big.NewInt(legacyOriginalVBase))
+			sig.V = recoveryID.Add(recoveryID, big.NewInt(*cp.vBase))
+		}
+		return tx.FinalizeLegacyOriginalWithSignature(sigPayload, sig)
+	default:
+		return tx.FinalizeEIP1559WithSignature(sigPayload, sig)
+	}
+}
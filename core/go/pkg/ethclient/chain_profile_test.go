@@ -0,0 +1,175 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package ethclient
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hyperledger/firefly-signer/pkg/ethsigner"
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/hyperledger/firefly-signer/pkg/rlp"
+	"github.com/hyperledger/firefly-signer/pkg/secp256k1"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func signWithProfile(t *testing.T, cp *ChainProfile, chainID int64) (*secp256k1.KeyPair, *ethsigner.Transaction, []byte) {
+	kp, err := secp256k1.GenerateSecp256k1KeyPair()
+	require.NoError(t, err)
+	tx := &ethsigner.Transaction{
+		Nonce:                ethtypes.NewHexInteger64(3),
+		MaxPriorityFeePerGas: ethtypes.NewHexInteger64(1000),
+		MaxFeePerGas:         ethtypes.NewHexInteger64(2000),
+		GasLimit:             ethtypes.NewHexInteger64(100000),
+		To:                   ethtypes.MustNewAddress("0x1d0cD5b99d2E2a380e52b4000377Dd507c6df754"),
+		Value:                ethtypes.NewHexInteger64(0),
+		Data:                 ethtypes.MustNewHexBytes0xPrefix("0xfeedbeef"),
+	}
+	sigPayload := cp.SignaturePayload(tx, chainID)
+	sig, err := kp.Sign(sigPayload.Bytes())
+	require.NoError(t, err)
+	rawTX, err := cp.FinalizeWithSignature(tx, sigPayload, sig, chainID)
+	require.NoError(t, err)
+	return kp, tx, rawTX
+}
+
+func TestChainProfileDefaultEIP1559(t *testing.T) {
+	ctx := context.Background()
+	cp, err := NewChainProfile(ctx, &pldconf.ChainProfileConfig{})
+	require.NoError(t, err)
+	assert.Equal(t, EIP1559, cp.TXVersion())
+
+	kp, _, rawTX := signWithProfile(t, cp, 12345)
+	assert.Equal(t, byte(0x02), rawTX[0])
+	addr, decoded, err := ethsigner.RecoverRawTransaction(ctx, rawTX, 12345)
+	require.NoError(t, err)
+	assert.Equal(t, kp.Address, *addr)
+	assert.Equal(t, int64(2000), decoded.MaxFeePerGas.Int64())
+}
+
+func TestChainProfileLegacyEIP155(t *testing.T) {
+	ctx := context.Background()
+	cp, err := NewChainProfile(ctx, &pldconf.ChainProfileConfig{
+		TransactionType: confutil.P("legacy_eip155"),
+	})
+	require.NoError(t, err)
+
+	kp, tx, rawTX := signWithProfile(t, cp, 12345)
+	addr, decoded, err := ethsigner.RecoverRawTransaction(ctx, rawTX, 12345)
+	require.NoError(t, err)
+	assert.Equal(t, kp.Address, *addr)
+	// The max fee is used as the gas price, without modifying the supplied transaction
+	assert.Equal(t, int64(2000), decoded.GasPrice.Int64())
+	assert.Nil(t, tx.GasPrice)
+}
+
+func TestChainProfileLegacyOriginal(t *testing.T) {
+	ctx := context.Background()
+	cp, err := NewChainProfile(ctx, &pldconf.ChainProfileConfig{
+		TransactionType: confutil.P("legacy_original"),
+	})
+	require.NoError(t, err)
+
+	kp, _, rawTX := signWithProfile(t, cp, 12345)
+	addr, _, err := ethsigner.RecoverLegacyRawTransaction(ctx, rawTX, -1)
+	require.NoError(t, err)
+	assert.Equal(t, kp.Address, *addr)
+}
+
+func TestChainProfileLegacyOriginalCustomVBase(t *testing.T) {
+	ctx := context.Background()
+	cp, err := NewChainProfile(ctx, &pldconf.ChainProfileConfig{
+		TransactionType: confutil.P("legacy_original"),
+		VBase:           confutil.P(int64(37)),
+	})
+	require.NoError(t, err)
+
+	_, _, rawTX := signWithProfile(t, cp, 12345)
+	decoded, _, err := rlp.Decode(rawTX)
+	require.NoError(t, err)
+	fields := decoded.(rlp.List)
+	require.Len(t, fields, 9)
+	v := fields[6].(rlp.Data).Int().Int64()
+	assert.True(t, v == 37 || v == 38, "unexpected V value %d", v)
+}
+
+func TestChainProfileBadConfig(t *testing.T) {
+	ctx := context.Background()
+	_, err := NewChainProfile(ctx, &pldconf.ChainProfileConfig{
+		TransactionType: confutil.P("wrong"),
+	})
+	assert.Regexp(t, "PD011505.*wrong", err)
+
+	_, err = NewChainProfile(ctx, &pldconf.ChainProfileConfig{
+		ChainID: confutil.P(int64(0)),
+	})
+	assert.Regexp(t, "PD011520", err)
+
+	_, err = NewChainProfile(ctx, &pldconf.ChainProfileConfig{
+		VBase: confutil.P(int64(37)),
+	})
+	assert.Regexp(t, "PD011521.*eip1559", err)
+
+	_, err = NewChainProfile(ctx, &pldconf.ChainProfileConfig{
+		TransactionType: confutil.P("legacy_original"),
+		VBase:           confutil.P(int64(-1)),
+	})
+	assert.Regexp(t, "PD011521", err)
+}
+
+func TestChainProfileWithTXVersion(t *testing.T) {
+	ctx := context.Background()
+	cp, err := NewChainProfile(ctx, &pldconf.ChainProfileConfig{
+		TransactionType: confutil.P("legacy_original"),
+		VBase:           confutil.P(int64(37)),
+	})
+	require.NoError(t, err)
+
+	txcp, err := cp.withTXVersion(ctx, LEGACY_EIP155)
+	require.NoError(t, err)
+	assert.Equal(t, LEGACY_EIP155, txcp.TXVersion())
+	assert.Nil(t, txcp.vBase)
+
+	txcp, err = cp.withTXVersion(ctx, LEGACY_ORIGINAL)
+	require.NoError(t, err)
+	assert.Equal(t, int64(37), *txcp.vBase)
+
+	_, err = cp.withTXVersion(ctx, "wrong")
+	assert.Regexp(t, "PD011505", err)
+}
+
+func TestWrapRPCClientChainIDOverride(t *testing.T) {
+	ec, err := WrapRPCClient(context.Background(), nil, &unconnectedRPC{}, &pldconf.EthClientConfig{
+		ChainProfile: pldconf.ChainProfileConfig{
+			ChainID: confutil.P(int64(1337)),
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1337), ec.ChainID())
+	assert.Equal(t, EIP1559, ec.ChainProfile().TXVersion())
+}
+
+func TestWrapRPCClientBadChainProfile(t *testing.T) {
+	_, err := WrapRPCClient(context.Background(), nil, &unconnectedRPC{}, &pldconf.EthClientConfig{
+		ChainProfile: pldconf.ChainProfileConfig{
+			TransactionType: confutil.P("wrong"),
+		},
+	})
+	assert.Regexp(t, "PD011505", err)
+}
@@ -34,6 +34,7 @@ import (
 	"github.com/kaleido-io/paladin/config/pkg/confutil"
 	"github.com/kaleido-io/paladin/config/pkg/pldconf"
 	"github.com/kaleido-io/paladin/core/internal/msgs"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/rpcclient"
 	"github.com/kaleido-io/paladin/toolkit/pkg/algorithms"
@@ -47,15 +48,21 @@ import (
 type EthClient interface {
 	Close()
 	ChainID() int64
+	ChainProfile() *ChainProfile
 
 	GasPrice(ctx context.Context) (gasPrice *pldtypes.HexUint256, err error)
+	BlobBaseFee(ctx context.Context) (blobBaseFee *pldtypes.HexUint256, err error)
 	GetBalance(ctx context.Context, address pldtypes.EthAddress, block string) (balance *pldtypes.HexUint256, err error)
+	GetCode(ctx context.Context, address pldtypes.EthAddress, block string) (code pldtypes.HexBytes, err error)
 	GetTransactionReceipt(ctx context.Context, txHash string) (*TransactionReceiptResponse, error)
 
 	EstimateGasNoResolve(ctx context.Context, tx *ethsigner.Transaction, opts ...CallOption) (res EstimateGasResult, err error)
 	CallContractNoResolve(ctx context.Context, tx *ethsigner.Transaction, block string, opts ...CallOption) (res CallResult, err error)
+	SimulateTransactionNoResolve(ctx context.Context, tx *ethsigner.Transaction, block string, opts ...CallOption) (*pldapi.PublicTxSimulation, error)
 	GetTransactionCount(ctx context.Context, fromAddr pldtypes.EthAddress) (transactionCount *pldtypes.HexUint64, err error)
 	SendRawTransaction(ctx context.Context, rawTX pldtypes.HexBytes) (*pldtypes.Bytes32, error)
+	GetTransactionByHash(ctx context.Context, txHash pldtypes.Bytes32) (*TransactionByHashResponse, error) // nil if the node does not know the transaction
+	PeerCount(ctx context.Context) (uint64, error)
 }
 
 // Higher level client interface to the base Ethereum ledger for TX submission.
@@ -145,19 +152,29 @@ type KeyManager interface {
 }
 
 type ethClient struct {
-	chainID           int64
-	gasEstimateFactor float64
-	rpc               rpcclient.Client
-	keymgr            KeyManager
+	chainID      int64
+	chainProfile *ChainProfile
+	gasPadding   *GasEstimatePadding
+	rpc          rpcclient.Client
+	keymgr       KeyManager
 }
 
 // A direct creation of a dedicated RPC client for things like unit tests outside of Paladin.
 // Within Paladin, use the EthClientFactory instead as passed to your component/manager/engine via the initialization
 func WrapRPCClient(ctx context.Context, keymgr KeyManager, rpc rpcclient.Client, conf *pldconf.EthClientConfig) (EthClient, error) {
+	gasPadding, err := NewGasEstimatePadding(ctx, &conf.GasEstimatePadding, confutil.Float64Min(conf.EstimateGasFactor, 1.0, *pldconf.EthClientDefaults.EstimateGasFactor))
+	if err != nil {
+		return nil, err
+	}
+	chainProfile, err := NewChainProfile(ctx, &conf.ChainProfile)
+	if err != nil {
+		return nil, err
+	}
 	ec := &ethClient{
-		keymgr:            keymgr,
-		rpc:               rpc,
-		gasEstimateFactor: confutil.Float64Min(conf.EstimateGasFactor, 1.0, *pldconf.EthClientDefaults.EstimateGasFactor),
+		keymgr:       keymgr,
+		rpc:          rpc,
+		gasPadding:   gasPadding,
+		chainProfile: chainProfile,
 	}
 	if err := ec.setupChainID(ctx); err != nil {
 		return nil, err
@@ -169,9 +186,10 @@ func WrapRPCClient(ctx context.Context, keymgr KeyManager, rpc rpcclient.Client,
 // All JSON/RPC requests will fail, and there is no chain ID available
 func NewUnconnectedRPCClient(ctx context.Context, conf *pldconf.EthClientConfig, chainID int64) EthClient {
 	return &ethClient{
-		rpc:               &unconnectedRPC{},
-		gasEstimateFactor: confutil.Float64Min(conf.EstimateGasFactor, 1.0, *pldconf.EthClientDefaults.EstimateGasFactor),
-		chainID:           chainID,
+		rpc:          &unconnectedRPC{},
+		gasPadding:   newFactorGasEstimatePadding(confutil.Float64Min(conf.EstimateGasFactor, 1.0, *pldconf.EthClientDefaults.EstimateGasFactor)),
+		chainProfile: DefaultChainProfile(),
+		chainID:      chainID,
 	}
 }
 
@@ -192,7 +210,18 @@ func (ec *ethClient) ChainID() int64 {
 	return ec.chainID
 }
 
+func (ec *ethClient) ChainProfile() *ChainProfile {
+	return ec.chainProfile
+}
+
 func (ec *ethClient) setupChainID(ctx context.Context) error {
+	// Some legacy chains do not support eth_chainId, or need signing with a different chain ID
+	// to the one the node reports, so a configured chain ID is used without querying the node
+	if ec.chainProfile.chainID != nil {
+		ec.chainID = *ec.chainProfile.chainID
+		log.L(ctx).Infof("Using chain ID %d from chain profile", ec.chainID)
+		return nil
+	}
 	var chainID ethtypes.HexUint64
 	if rpcErr := ec.rpc.CallRPC(ctx, &chainID, "eth_chainId"); rpcErr != nil {
 		log.L(ctx).Errorf("eth_chainId failed: %+v", rpcErr)
@@ -285,6 +314,16 @@ func (ec *ethClient) GetBalance(ctx context.Context, address pldtypes.EthAddress
 	return &addressBalance, nil
 }
 
+func (ec *ethClient) GetCode(ctx context.Context, address pldtypes.EthAddress, block string) (pldtypes.HexBytes, error) {
+	var code pldtypes.HexBytes
+
+	if rpcErr := ec.rpc.CallRPC(ctx, &code, "eth_getCode", address, block); rpcErr != nil {
+		log.L(ctx).Errorf("eth_getCode failed: %+v", rpcErr)
+		return nil, rpcErr
+	}
+	return code, nil
+}
+
 func (ec *ethClient) GasPrice(ctx context.Context) (*pldtypes.HexUint256, error) {
 	// currently only support London style gas price
 	// For EIP1559, will need to add support for `eth_maxPriorityFeePerGas`
@@ -297,6 +336,17 @@ func (ec *ethClient) GasPrice(ctx context.Context) (*pldtypes.HexUint256, error)
 	return &gasPrice, nil
 }
 
+func (ec *ethClient) BlobBaseFee(ctx context.Context) (*pldtypes.HexUint256, error) {
+	// Only available on chains that support EIP-4844 blob transactions
+	var blobBaseFee pldtypes.HexUint256
+
+	if rpcErr := ec.rpc.CallRPC(ctx, &blobBaseFee, "eth_blobBaseFee"); rpcErr != nil {
+		log.L(ctx).Errorf("eth_blobBaseFee failed: %+v", rpcErr)
+		return nil, rpcErr
+	}
+	return &blobBaseFee, nil
+}
+
 func (ec *ethClient) GetTransactionReceipt(ctx context.Context, txHash string) (*TransactionReceiptResponse, error) {
 
 	// Get the receipt in the back-end JSON/RPC format
@@ -380,6 +430,24 @@ func (ec *ethClient) GetTransactionCount(ctx context.Context, fromAddr pldtypes.
 	return &transactionCount, nil
 }
 
+func (ec *ethClient) GetTransactionByHash(ctx context.Context, txHash pldtypes.Bytes32) (*TransactionByHashResponse, error) {
+	var tx *TransactionByHashResponse
+	if rpcErr := ec.rpc.CallRPC(ctx, &tx, "eth_getTransactionByHash", txHash); rpcErr != nil {
+		log.L(ctx).Errorf("eth_getTransactionByHash(%s) failed: %+v", txHash, rpcErr)
+		return nil, rpcErr
+	}
+	return tx, nil
+}
+
+func (ec *ethClient) PeerCount(ctx context.Context) (uint64, error) {
+	var peerCount pldtypes.HexUint64
+	if rpcErr := ec.rpc.CallRPC(ctx, &peerCount, "net_peerCount"); rpcErr != nil {
+		log.L(ctx).Errorf("net_peerCount failed: %+v", rpcErr)
+		return 0, rpcErr
+	}
+	return peerCount.Uint64(), nil
+}
+
 func (ec *ethClient) BuildRawTransaction(ctx context.Context, txVersion EthTXVersion, from string, tx *ethsigner.Transaction, opts ...CallOption) (pldtypes.HexBytes, error) {
 	keyHandle, fromAddr, err := ec.resolveFrom(ctx, &from, tx)
 	if err != nil {
@@ -403,23 +471,17 @@ func (ec *ethClient) BuildRawTransaction(ctx context.Context, txVersion EthTXVer
 			log.L(ctx).Errorf("eth_estimateGas failed: %+v", err)
 			return nil, err
 		}
-		// If that went well, so submission with a bump on the estimation
-		factoredGasLimit := int64((float64)(gasEstimate.GasLimit) * ec.gasEstimateFactor)
-		tx.GasLimit = (*ethtypes.HexInteger)(big.NewInt(factoredGasLimit))
+		// If that went well, so submission with padding on the estimation
+		paddedGasLimit := ec.gasPadding.PadGasEstimate((*pldtypes.EthAddress)(tx.To), gasEstimate.GasLimit.Uint64())
+		tx.GasLimit = (*ethtypes.HexInteger)(new(big.Int).SetUint64(paddedGasLimit))
 	}
 
 	// Sign
-	var sigPayload *ethsigner.TransactionSignaturePayload
-	switch txVersion {
-	case EIP1559:
-		sigPayload = tx.SignaturePayloadEIP1559(ec.chainID)
-	case LEGACY_EIP155:
-		sigPayload = tx.SignaturePayloadLegacyEIP155(ec.chainID)
-	case LEGACY_ORIGINAL:
-		sigPayload = tx.SignaturePayloadLegacyOriginal()
-	default:
-		return nil, i18n.NewError(ctx, msgs.MsgEthClientInvalidTXVersion, txVersion)
+	chainProfile, err := ec.chainProfile.withTXVersion(ctx, txVersion)
+	if err != nil {
+		return nil, err
 	}
+	sigPayload := chainProfile.SignaturePayload(tx, ec.chainID)
 	hash := sha3.NewLegacyKeccak256()
 	_, _ = hash.Write(sigPayload.Bytes())
 	signature, err := ec.keymgr.Sign(ctx, &signerapi.SignRequest{
@@ -434,14 +496,7 @@ func (ec *ethClient) BuildRawTransaction(ctx context.Context, txVersion EthTXVer
 	}
 	var rawTX []byte
 	if err == nil {
-		switch txVersion {
-		case EIP1559:
-			rawTX, err = tx.FinalizeEIP1559WithSignature(sigPayload, sig)
-		case LEGACY_EIP155:
-			rawTX, err = tx.FinalizeLegacyEIP155WithSignature(sigPayload, sig, ec.chainID)
-		case LEGACY_ORIGINAL:
-			rawTX, err = tx.FinalizeLegacyOriginalWithSignature(sigPayload, sig)
-		}
+		rawTX, err = chainProfile.FinalizeWithSignature(tx, sigPayload, sig, ec.chainID)
 	}
 	if err != nil {
 		log.L(ctx).Errorf("signing failed with keyHandle %s (addr=%s): %s", keyHandle, fromAddr, err)
@@ -109,6 +109,33 @@ func TestGetBalanceFail(t *testing.T) {
 
 }
 
+func TestGetCode(t *testing.T) {
+	ctx, ec, done := newTestClientAndServer(t, &mockEth{
+		eth_getCode: func(ctx context.Context, ah pldtypes.EthAddress, s string) (pldtypes.HexBytes, error) {
+			return pldtypes.MustParseHexBytes("0x6080"), nil
+		},
+	})
+	defer done()
+
+	code, err := ec.HTTPClient().GetCode(ctx, *pldtypes.MustEthAddress("0x1d0cD5b99d2E2a380e52b4000377Dd507c6df754"), "latest")
+	require.NoError(t, err)
+	assert.Equal(t, "0x6080", code.String())
+
+}
+
+func TestGetCodeFail(t *testing.T) {
+	ctx, ec, done := newTestClientAndServer(t, &mockEth{
+		eth_getCode: func(ctx context.Context, ah pldtypes.EthAddress, s string) (pldtypes.HexBytes, error) {
+			return nil, fmt.Errorf("pop")
+		},
+	})
+	defer done()
+
+	_, err := ec.HTTPClient().GetCode(ctx, *pldtypes.MustEthAddress("0x1d0cD5b99d2E2a380e52b4000377Dd507c6df754"), "latest")
+	assert.Regexp(t, "pop", err)
+
+}
+
 func TestGasPrice(t *testing.T) {
 	gasPriceHexInt := (*pldtypes.HexUint256)(big.NewInt(200000))
 	ctx, ec, done := newTestClientAndServer(t, &mockEth{
@@ -137,6 +164,32 @@ func TestGasPriceFail(t *testing.T) {
 
 }
 
+func TestBlobBaseFee(t *testing.T) {
+	blobBaseFee := (*pldtypes.HexUint256)(big.NewInt(1))
+	ctx, ec, done := newTestClientAndServer(t, &mockEth{
+		eth_blobBaseFee: func(ctx context.Context) (*pldtypes.HexUint256, error) {
+			return blobBaseFee, nil
+		},
+	})
+	defer done()
+
+	fee, err := ec.HTTPClient().BlobBaseFee(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, blobBaseFee, fee)
+}
+
+func TestBlobBaseFeeFail(t *testing.T) {
+	ctx, ec, done := newTestClientAndServer(t, &mockEth{
+		eth_blobBaseFee: func(ctx context.Context) (*pldtypes.HexUint256, error) {
+			return nil, fmt.Errorf("pop")
+		},
+	})
+	defer done()
+
+	_, err := ec.HTTPClient().BlobBaseFee(ctx)
+	assert.Regexp(t, "pop", err)
+}
+
 func TestEstimateGas(t *testing.T) {
 	gasEstimateHexInt := pldtypes.HexUint64(200000)
 	ctx, ec, done := newTestClientAndServer(t, &mockEth{
@@ -194,6 +247,62 @@ func TestGetTransactionCountFail(t *testing.T) {
 	assert.Regexp(t, "pop", err)
 }
 
+func TestGetTransactionByHash(t *testing.T) {
+	txHash := pldtypes.RandBytes32()
+	ctx, ec, done := newTestClientAndServer(t, &mockEth{
+		eth_getTransactionByHash: func(ctx context.Context, h pldtypes.Bytes32) (*TransactionByHashResponse, error) {
+			if h == txHash {
+				return &TransactionByHashResponse{Hash: h, Nonce: 10}, nil
+			}
+			return nil, nil
+		},
+	})
+	defer done()
+
+	tx, err := ec.HTTPClient().GetTransactionByHash(ctx, txHash)
+	require.NoError(t, err)
+	assert.Equal(t, txHash, tx.Hash)
+	assert.Equal(t, uint64(10), tx.Nonce.Uint64())
+	assert.Nil(t, tx.BlockNumber)
+
+	tx, err = ec.HTTPClient().GetTransactionByHash(ctx, pldtypes.RandBytes32())
+	require.NoError(t, err)
+	assert.Nil(t, tx)
+}
+
+func TestGetTransactionByHashFail(t *testing.T) {
+	ctx, ec, done := newTestClientAndServer(t, &mockEth{
+		eth_getTransactionByHash: func(ctx context.Context, h pldtypes.Bytes32) (*TransactionByHashResponse, error) {
+			return nil, fmt.Errorf("pop")
+		},
+	})
+	defer done()
+
+	_, err := ec.HTTPClient().GetTransactionByHash(ctx, pldtypes.RandBytes32())
+	assert.Regexp(t, "pop", err)
+}
+
+func TestPeerCount(t *testing.T) {
+	ctx, ec, done := newTestClientAndServer(t, &mockEth{
+		net_peerCount: func(ctx context.Context) (pldtypes.HexUint64, error) {
+			return 3, nil
+		},
+	})
+	defer done()
+
+	peerCount, err := ec.HTTPClient().PeerCount(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), peerCount)
+}
+
+func TestPeerCountFail(t *testing.T) {
+	ctx, ec, done := newTestClientAndServer(t, &mockEth{})
+	defer done()
+
+	_, err := ec.HTTPClient().PeerCount(ctx)
+	assert.Regexp(t, "not implemented by test", err)
+}
+
 func TestBuildRawTransactionEstimateGasFail(t *testing.T) {
 	ctx, ec, done := newTestClientAndServer(t, &mockEth{
 		eth_getTransactionCount: func(ctx context.Context, ah pldtypes.EthAddress, s string) (pldtypes.HexUint64, error) {
@@ -34,15 +34,20 @@ import (
 
 type mockEth struct {
 	eth_getBalance            func(context.Context, pldtypes.EthAddress, string) (*pldtypes.HexUint256, error)
+	eth_getCode               func(context.Context, pldtypes.EthAddress, string) (pldtypes.HexBytes, error)
 	eth_gasPrice              func(context.Context) (*pldtypes.HexUint256, error)
+	eth_blobBaseFee           func(context.Context) (*pldtypes.HexUint256, error)
 	eth_gasLimit              func(context.Context, ethsigner.Transaction) (*pldtypes.HexUint256, error)
 	eth_chainId               func(context.Context) (pldtypes.HexUint64, error)
 	eth_getTransactionCount   func(context.Context, pldtypes.EthAddress, string) (pldtypes.HexUint64, error)
 	eth_getTransactionReceipt func(context.Context, pldtypes.Bytes32) (*txReceiptJSONRPC, error)
+	eth_getTransactionByHash  func(context.Context, pldtypes.Bytes32) (*TransactionByHashResponse, error)
+	net_peerCount             func(context.Context) (pldtypes.HexUint64, error)
 	eth_estimateGas           func(context.Context, ethsigner.Transaction) (pldtypes.HexUint64, error)
 	eth_sendRawTransaction    func(context.Context, pldtypes.HexBytes) (pldtypes.HexBytes, error)
 	eth_call                  func(context.Context, ethsigner.Transaction, string) (pldtypes.HexBytes, error)
 	eth_callErr               func(ctx context.Context, req *rpcclient.RPCRequest) *rpcclient.RPCResponse
+	debug_traceCall           func(context.Context, ethsigner.Transaction, string, traceCallOptions) (pldtypes.RawJSON, error)
 }
 
 func newTestServer(t *testing.T, ctx context.Context, isWS bool, mEth *mockEth) (rpcServer rpcserver.RPCServer, done func()) {
@@ -84,13 +89,22 @@ func newTestServer(t *testing.T, ctx context.Context, isWS bool, mEth *mockEth)
 		Add("eth_chainId", checkNil(mEth.eth_chainId, rpcserver.RPCMethod0)).
 		Add("eth_getTransactionCount", checkNil(mEth.eth_getTransactionCount, rpcserver.RPCMethod2)).
 		Add("eth_getTransactionReceipt", checkNil(mEth.eth_getTransactionReceipt, rpcserver.RPCMethod1)).
+		Add("eth_getTransactionByHash", checkNil(mEth.eth_getTransactionByHash, rpcserver.RPCMethod1)).
 		Add("eth_estimateGas", checkNil(mEth.eth_estimateGas, rpcserver.RPCMethod1)).
 		Add("eth_sendRawTransaction", checkNil(mEth.eth_sendRawTransaction, rpcserver.RPCMethod1)).
 		Add("eth_call", primarySecondary(mEth.eth_callErr, checkNil(mEth.eth_call, rpcserver.RPCMethod2))).
 		Add("eth_getBalance", checkNil(mEth.eth_getBalance, rpcserver.RPCMethod2)).
+		Add("eth_getCode", checkNil(mEth.eth_getCode, rpcserver.RPCMethod2)).
 		Add("eth_gasPrice", checkNil(mEth.eth_gasPrice, rpcserver.RPCMethod0)).
+		Add("eth_blobBaseFee", checkNil(mEth.eth_blobBaseFee, rpcserver.RPCMethod0)).
 		Add("eth_gasLimit", checkNil(mEth.eth_gasLimit, rpcserver.RPCMethod1)),
 	)
+	rpcServer.Register(rpcserver.NewRPCModule("net").
+		Add("net_peerCount", checkNil(mEth.net_peerCount, rpcserver.RPCMethod0)),
+	)
+	rpcServer.Register(rpcserver.NewRPCModule("debug").
+		Add("debug_traceCall", checkNil(mEth.debug_traceCall, rpcserver.RPCMethod3)),
+	)
 
 	err = rpcServer.Start()
 	require.NoError(t, err)
@@ -214,6 +228,22 @@ func TestNewEthClientFactoryChainIDFail(t *testing.T) {
 
 }
 
+func TestNewEthClientFactoryBadGasEstimatePadding(t *testing.T) {
+	kmgr, done := newTestHDWalletKeyManager(t)
+	defer done()
+	ecf, err := NewEthClientFactoryWithKeyManager(context.Background(), kmgr, &pldconf.EthClientConfig{
+		HTTP: pldconf.HTTPClientConfig{
+			URL: "http://localhost:8545",
+		},
+		GasEstimatePadding: pldconf.GasEstimatePaddingConfig{
+			Contracts: map[string]*pldconf.GasEstimatePaddingOverride{"wrong": {}},
+		},
+	})
+	require.NoError(t, err)
+	err = ecf.Start()
+	assert.Regexp(t, "PD011518", err)
+}
+
 func TestMismatchedChainID(t *testing.T) {
 	ctx := context.Background()
 	mEthHTTP := &mockEth{
@@ -252,7 +252,7 @@ func (ac *abiFunctionClient) ABIEntry() *abi.Entry {
 func (ac *abiFunctionClient) R(ctx context.Context) ABIFunctionRequestBuilder {
 	return &abiFunctionRequestBuilder{
 		ctx:               ctx,
-		txVersion:         EIP1559,
+		txVersion:         ac.ec.chainProfile.txVersion,
 		abiFunctionClient: ac,
 		block:             "latest",
 	}
@@ -0,0 +1,89 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package ethclient
+
+import (
+	"context"
+
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/internal/msgs"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+)
+
+type gasPaddingRule struct {
+	percent float64
+	minimum uint64
+}
+
+// GasEstimatePadding calculates the gas limit to submit a transaction with from its gas estimate,
+// as some contracts use more gas in execution than was estimated.
+type GasEstimatePadding struct {
+	defaultRule gasPaddingRule
+	contracts   map[pldtypes.EthAddress]gasPaddingRule
+}
+
+func (r gasPaddingRule) override(percent *float64, minimum *uint64) gasPaddingRule {
+	if percent != nil {
+		r.percent = *percent
+	}
+	if minimum != nil {
+		r.minimum = *minimum
+	}
+	return r
+}
+
+func newFactorGasEstimatePadding(gasEstimateFactor float64) *GasEstimatePadding {
+	return &GasEstimatePadding{
+		defaultRule: gasPaddingRule{percent: (gasEstimateFactor - 1) * 100},
+		contracts:   map[pldtypes.EthAddress]gasPaddingRule{},
+	}
+}
+
+// NewGasEstimatePadding builds the padding policy from configuration. The gas estimate factor is used
+// when no percentage is configured, so with an empty configuration the gas limit is the estimate
+// multiplied by the factor.
+func NewGasEstimatePadding(ctx context.Context, conf *pldconf.GasEstimatePaddingConfig, gasEstimateFactor float64) (*GasEstimatePadding, error) {
+	p := newFactorGasEstimatePadding(gasEstimateFactor)
+	p.defaultRule = p.defaultRule.override(conf.Percent, conf.Minimum)
+	for addrStr, override := range conf.Contracts {
+		addr, err := pldtypes.ParseEthAddress(addrStr)
+		if err != nil {
+			return nil, i18n.WrapError(ctx, err, msgs.MsgEthClientInvalidPaddingContract, addrStr)
+		}
+		rule := p.defaultRule
+		if override != nil {
+			rule = rule.override(override.Percent, override.Minimum)
+		}
+		p.contracts[*addr] = rule
+	}
+	return p, nil
+}
+
+// PadGasEstimate returns the gas limit for a transaction to the supplied address (nil for a deploy)
+func (p *GasEstimatePadding) PadGasEstimate(to *pldtypes.EthAddress, estimate uint64) uint64 {
+	rule := p.defaultRule
+	if to != nil {
+		if contractRule, ok := p.contracts[*to]; ok {
+			rule = contractRule
+		}
+	}
+	padding := uint64(0)
+	if rule.percent > 0 {
+		padding = uint64(float64(estimate) * rule.percent / 100)
+	}
+	return estimate + max(padding, rule.minimum)
+}
@@ -0,0 +1,73 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package ethclient
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGasEstimatePaddingFactorOnly(t *testing.T) {
+	p, err := NewGasEstimatePadding(context.Background(), &pldconf.GasEstimatePaddingConfig{}, 1.5)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(150000), p.PadGasEstimate(nil, 100000))
+	assert.Equal(t, uint64(150000), p.PadGasEstimate(pldtypes.RandAddress(), 100000))
+
+	p, err = NewGasEstimatePadding(context.Background(), &pldconf.GasEstimatePaddingConfig{}, 1.0)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(100000), p.PadGasEstimate(nil, 100000))
+}
+
+func TestGasEstimatePaddingPercentAndMinimum(t *testing.T) {
+	divergent := pldtypes.RandAddress()
+	floorOnly := pldtypes.RandAddress()
+	inherited := pldtypes.RandAddress()
+	p, err := NewGasEstimatePadding(context.Background(), &pldconf.GasEstimatePaddingConfig{
+		Percent: confutil.P(20.0),
+		Minimum: confutil.P(uint64(30000)),
+		Contracts: map[string]*pldconf.GasEstimatePaddingOverride{
+			divergent.String(): {Percent: confutil.P(100.0)},
+			floorOnly.String(): {Minimum: confutil.P(uint64(500000))},
+			inherited.String(): nil,
+		},
+	}, 2.0)
+	require.NoError(t, err)
+
+	// The percentage replaces the factor, with the minimum applying to small estimates
+	assert.Equal(t, uint64(1200000), p.PadGasEstimate(nil, 1000000))
+	assert.Equal(t, uint64(50000+30000), p.PadGasEstimate(pldtypes.RandAddress(), 50000))
+
+	// Overrides inherit what they do not set
+	assert.Equal(t, uint64(2000000), p.PadGasEstimate(divergent, 1000000))
+	assert.Equal(t, uint64(20000+30000), p.PadGasEstimate(divergent, 20000))
+	assert.Equal(t, uint64(1000000+500000), p.PadGasEstimate(floorOnly, 1000000))
+	assert.Equal(t, uint64(1200000), p.PadGasEstimate(inherited, 1000000))
+}
+
+func TestGasEstimatePaddingBadContract(t *testing.T) {
+	_, err := NewGasEstimatePadding(context.Background(), &pldconf.GasEstimatePaddingConfig{
+		Contracts: map[string]*pldconf.GasEstimatePaddingOverride{
+			"not an address": {},
+		},
+	}, 2.0)
+	assert.Regexp(t, "PD011518.*not an address", err)
+}
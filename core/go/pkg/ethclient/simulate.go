@@ -0,0 +1,238 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package ethclient
+
+import (
+	"bytes"
+	"context"
+	"sort"
+
+	"github.com/hyperledger/firefly-signer/pkg/abi"
+	"github.com/hyperledger/firefly-signer/pkg/ethsigner"
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/core/internal/msgs"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+)
+
+type traceCallOptions struct {
+	Tracer       string         `json:"tracer"`
+	TracerConfig map[string]any `json:"tracerConfig,omitempty"`
+}
+
+// The frame format returned by the callTracer built into geth (and compatible nodes)
+type callTracerFrame struct {
+	Type         string               `json:"type"`
+	From         pldtypes.EthAddress  `json:"from"`
+	To           *pldtypes.EthAddress `json:"to,omitempty"`
+	Value        *pldtypes.HexUint256 `json:"value,omitempty"`
+	Gas          pldtypes.HexUint64   `json:"gas"`
+	GasUsed      pldtypes.HexUint64   `json:"gasUsed"`
+	Input        pldtypes.HexBytes    `json:"input,omitempty"`
+	Output       pldtypes.HexBytes    `json:"output,omitempty"`
+	Error        string               `json:"error,omitempty"`
+	RevertReason string               `json:"revertReason,omitempty"`
+	Logs         []*callTracerLog     `json:"logs,omitempty"`
+	Calls        []*callTracerFrame   `json:"calls,omitempty"`
+}
+
+type callTracerLog struct {
+	Address  pldtypes.EthAddress `json:"address"`
+	Topics   []pldtypes.Bytes32  `json:"topics"`
+	Data     pldtypes.HexBytes   `json:"data,omitempty"`
+	Position pldtypes.HexUint64  `json:"position"` // the index of the sub-call the log was emitted before
+}
+
+// The diffMode format returned by the prestateTracer, where only the modified parts of each account are included
+type prestateDiff struct {
+	Pre  map[string]*prestateAccount `json:"pre"`
+	Post map[string]*prestateAccount `json:"post"`
+}
+
+type prestateAccount struct {
+	Storage map[pldtypes.Bytes32]pldtypes.Bytes32 `json:"storage,omitempty"`
+}
+
+// SimulateTransactionNoResolve executes the transaction against the state at the given block without submitting it.
+// Where the node supports debug_traceCall, the full call tree, logs and storage changes are returned.
+// Otherwise we fall back to eth_call, and the result is marked as not traced.
+//
+// A revert is reported in the result rather than as an error, so that the trace to the revert can be inspected.
+func (ec *ethClient) SimulateTransactionNoResolve(ctx context.Context, tx *ethsigner.Transaction, block string, opts ...CallOption) (sim *pldapi.PublicTxSimulation, err error) {
+	errABI := abi.ABI{}
+	for _, o := range opts {
+		if co := o.(*callOptions); co.errABI != nil {
+			errABI = co.errABI
+		}
+	}
+
+	var frame *callTracerFrame
+	rpcErr := ec.rpc.CallRPC(ctx, &frame, "debug_traceCall", tx, block, &traceCallOptions{
+		Tracer:       "callTracer",
+		TracerConfig: map[string]any{"withLog": true},
+	})
+	if rpcErr != nil || frame == nil {
+		log.L(ctx).Infof("debug_traceCall unavailable (%v) - simulating with eth_call", rpcErr)
+		return ec.simulateWithCall(ctx, errABI, tx, block, opts...)
+	}
+
+	sim = &pldapi.PublicTxSimulation{
+		Traced:  true,
+		GasUsed: &frame.GasUsed,
+		Call:    buildSimulatedCall(ctx, errABI, frame),
+	}
+	if frame.Error != "" {
+		sim.Reverted = true
+		sim.RevertData = frame.Output
+		sim.RevertReason = sim.Call.RevertReason
+	} else {
+		sim.Output = frame.Output
+	}
+	sim.Logs = flattenTracerLogs(frame, []*pldapi.PublicTxSimulatedLog{})
+
+	// Storage changes are a best-effort addition, as not all nodes that support the callTracer support diffMode
+	var diff prestateDiff
+	rpcErr = ec.rpc.CallRPC(ctx, &diff, "debug_traceCall", tx, block, &traceCallOptions{
+		Tracer:       "prestateTracer",
+		TracerConfig: map[string]any{"diffMode": true},
+	})
+	if rpcErr != nil {
+		log.L(ctx).Warnf("debug_traceCall with prestateTracer failed - storage changes unavailable: %s", rpcErr)
+	} else if sim.StorageChanges, err = storageChanges(ctx, &diff); err != nil {
+		return nil, err
+	}
+	return sim, nil
+}
+
+func (ec *ethClient) simulateWithCall(ctx context.Context, errABI abi.ABI, tx *ethsigner.Transaction, block string, opts ...CallOption) (*pldapi.PublicTxSimulation, error) {
+	res, err := ec.CallContractNoResolve(ctx, tx, block, opts...)
+	if err != nil {
+		if len(res.RevertData) == 0 {
+			return nil, err
+		}
+		revertReason, _ := errABI.ErrorStringCtx(ctx, res.RevertData)
+		return &pldapi.PublicTxSimulation{
+			Reverted:     true,
+			RevertData:   res.RevertData,
+			RevertReason: revertReason,
+		}, nil
+	}
+	return &pldapi.PublicTxSimulation{
+		Output: res.Data,
+	}, nil
+}
+
+func buildSimulatedCall(ctx context.Context, errABI abi.ABI, frame *callTracerFrame) *pldapi.PublicTxSimulatedCall {
+	call := &pldapi.PublicTxSimulatedCall{
+		Type:         frame.Type,
+		From:         frame.From,
+		To:           frame.To,
+		Value:        frame.Value,
+		Gas:          frame.Gas,
+		GasUsed:      frame.GasUsed,
+		Input:        frame.Input,
+		Output:       frame.Output,
+		Error:        frame.Error,
+		RevertReason: frame.RevertReason,
+	}
+	if frame.Error != "" && len(frame.Output) > 0 {
+		if errString, _ := errABI.ErrorStringCtx(ctx, frame.Output); errString != "" {
+			call.RevertReason = errString
+		}
+	}
+	for _, child := range frame.Calls {
+		call.Calls = append(call.Calls, buildSimulatedCall(ctx, errABI, child))
+	}
+	return call
+}
+
+// flattenTracerLogs returns the logs in the order they would be emitted on-chain, interleaving the
+// logs of each frame with those of its sub-calls, and dropping any from frames that revert
+func flattenTracerLogs(frame *callTracerFrame, logs []*pldapi.PublicTxSimulatedLog) []*pldapi.PublicTxSimulatedLog {
+	if frame.Error != "" {
+		return logs
+	}
+	nextLog := 0
+	for i := 0; i <= len(frame.Calls); i++ {
+		for ; nextLog < len(frame.Logs) && frame.Logs[nextLog].Position.Uint64() <= uint64(i); nextLog++ {
+			l := frame.Logs[nextLog]
+			logs = append(logs, &pldapi.PublicTxSimulatedLog{
+				Address: l.Address,
+				Topics:  l.Topics,
+				Data:    l.Data,
+			})
+		}
+		if i < len(frame.Calls) {
+			logs = flattenTracerLogs(frame.Calls[i], logs)
+		}
+	}
+	return logs
+}
+
+// storageChanges turns a prestateTracer diff into a sorted list of changed slots.
+// Slots are omitted from "post" when they are zero after the transaction, and from "pre" when they were zero before.
+func storageChanges(ctx context.Context, diff *prestateDiff) ([]*pldapi.PublicTxStorageChange, error) {
+	pre, err := parseStorage(ctx, diff.Pre)
+	if err != nil {
+		return nil, err
+	}
+	post, err := parseStorage(ctx, diff.Post)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := []*pldapi.PublicTxStorageChange{}
+	for _, accounts := range []map[pldtypes.EthAddress]map[pldtypes.Bytes32]pldtypes.Bytes32{pre, post} {
+		for addr, storage := range accounts {
+			for slot := range storage {
+				before, after := pre[addr][slot], post[addr][slot]
+				if before == after {
+					continue // includes the second time we see a slot that is in both
+				}
+				changes = append(changes, &pldapi.PublicTxStorageChange{
+					Address: addr,
+					Slot:    slot,
+					Before:  before,
+					After:   after,
+				})
+				delete(pre[addr], slot)
+				delete(post[addr], slot)
+			}
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		if c := bytes.Compare(changes[i].Address[:], changes[j].Address[:]); c != 0 {
+			return c < 0
+		}
+		return bytes.Compare(changes[i].Slot[:], changes[j].Slot[:]) < 0
+	})
+	return changes, nil
+}
+
+func parseStorage(ctx context.Context, accounts map[string]*prestateAccount) (map[pldtypes.EthAddress]map[pldtypes.Bytes32]pldtypes.Bytes32, error) {
+	parsed := make(map[pldtypes.EthAddress]map[pldtypes.Bytes32]pldtypes.Bytes32, len(accounts))
+	for addrStr, account := range accounts {
+		addr, err := pldtypes.ParseEthAddress(addrStr)
+		if err != nil {
+			return nil, i18n.WrapError(ctx, err, msgs.MsgEthClientInvalidTraceResult, addrStr)
+		}
+		if account != nil && len(account.Storage) > 0 {
+			parsed[*addr] = account.Storage
+		}
+	}
+	return parsed, nil
+}
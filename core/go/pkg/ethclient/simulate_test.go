@@ -0,0 +1,286 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package ethclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/firefly-signer/pkg/abi"
+	"github.com/hyperledger/firefly-signer/pkg/ethsigner"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/rpcclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	simContractA = "0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+	simContractB = "0xbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb"
+	simContractC = "0xcccccccccccccccccccccccccccccccccccccccc"
+	simSender    = "0x1111111111111111111111111111111111111111"
+)
+
+func simSlot(v byte) pldtypes.Bytes32 {
+	var b pldtypes.Bytes32
+	b[31] = v
+	return b
+}
+
+func TestSimulateTransactionTraced(t *testing.T) {
+	callTrace := `{
+		"type": "CALL",
+		"from": "` + simSender + `",
+		"to": "` + simContractA + `",
+		"gas": "0x100000",
+		"gasUsed": "0x5208",
+		"input": "0x01020304",
+		"output": "0xfeedbeef",
+		"logs": [
+			{"address": "` + simContractA + `", "topics": ["` + simSlot(1).String() + `"], "data": "0x01", "position": "0x0"},
+			{"address": "` + simContractA + `", "topics": ["` + simSlot(4).String() + `"], "data": "0x04", "position": "0x2"}
+		],
+		"calls": [
+			{
+				"type": "DELEGATECALL",
+				"from": "` + simContractA + `",
+				"to": "` + simContractB + `",
+				"gas": "0x1000",
+				"gasUsed": "0x100",
+				"logs": [
+					{"address": "` + simContractA + `", "topics": ["` + simSlot(2).String() + `"], "data": "0x02", "position": "0x0"}
+				]
+			},
+			{
+				"type": "CALL",
+				"from": "` + simContractA + `",
+				"to": "` + simContractC + `",
+				"value": "0x0",
+				"gas": "0x1000",
+				"gasUsed": "0x200",
+				"error": "execution reverted",
+				"revertReason": "not today",
+				"logs": [
+					{"address": "` + simContractC + `", "topics": ["` + simSlot(3).String() + `"], "data": "0x03", "position": "0x0"}
+				]
+			}
+		]
+	}`
+	prestateDiff := `{
+		"pre": {
+			"` + simContractA + `": {"balance": "0x0", "storage": {
+				"` + simSlot(1).String() + `": "` + simSlot(10).String() + `",
+				"` + simSlot(2).String() + `": "` + simSlot(20).String() + `",
+				"` + simSlot(5).String() + `": "` + simSlot(50).String() + `"
+			}},
+			"` + simContractB + `": {"storage": {
+				"` + simSlot(1).String() + `": "` + simSlot(10).String() + `"
+			}},
+			"` + simSender + `": {"balance": "0x100", "nonce": 1}
+		},
+		"post": {
+			"0xAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA": {"storage": {
+				"` + simSlot(1).String() + `": "` + simSlot(11).String() + `",
+				"` + simSlot(3).String() + `": "` + simSlot(30).String() + `",
+				"` + simSlot(5).String() + `": "` + simSlot(50).String() + `"
+			}},
+			"` + simSender + `": {"balance": "0x50", "nonce": 2}
+		}
+	}`
+
+	ctx, ecf, done := newTestClientAndServer(t, &mockEth{
+		debug_traceCall: func(ctx context.Context, tx ethsigner.Transaction, block string, opts traceCallOptions) (pldtypes.RawJSON, error) {
+			assert.Equal(t, "latest", block)
+			switch opts.Tracer {
+			case "callTracer":
+				assert.Equal(t, true, opts.TracerConfig["withLog"])
+				return pldtypes.RawJSON(callTrace), nil
+			case "prestateTracer":
+				assert.Equal(t, true, opts.TracerConfig["diffMode"])
+				return pldtypes.RawJSON(prestateDiff), nil
+			}
+			return nil, fmt.Errorf("unexpected tracer %s", opts.Tracer)
+		},
+	})
+	defer done()
+
+	sim, err := ecf.HTTPClient().SimulateTransactionNoResolve(ctx, &ethsigner.Transaction{}, "latest")
+	require.NoError(t, err)
+
+	assert.True(t, sim.Traced)
+	assert.False(t, sim.Reverted)
+	assert.Equal(t, "0xfeedbeef", sim.Output.String())
+	assert.Equal(t, uint64(21000), sim.GasUsed.Uint64())
+
+	require.Len(t, sim.Call.Calls, 2)
+	assert.Equal(t, "DELEGATECALL", sim.Call.Calls[0].Type)
+	assert.Equal(t, simContractC, sim.Call.Calls[1].To.String())
+	assert.Equal(t, "execution reverted", sim.Call.Calls[1].Error)
+	assert.Equal(t, "not today", sim.Call.Calls[1].RevertReason)
+
+	// Logs are interleaved in execution order, without those from the reverted sub-call
+	require.Len(t, sim.Logs, 3)
+	assert.Equal(t, "0x01", sim.Logs[0].Data.String())
+	assert.Equal(t, "0x02", sim.Logs[1].Data.String())
+	assert.Equal(t, "0x04", sim.Logs[2].Data.String())
+
+	// One slot changed, two cleared, one set for the first time - and one unchanged slot ignored
+	require.Len(t, sim.StorageChanges, 4)
+	for i, expected := range []struct {
+		address             string
+		slot, before, after pldtypes.Bytes32
+	}{
+		{simContractA, simSlot(1), simSlot(10), simSlot(11)},
+		{simContractA, simSlot(2), simSlot(20), pldtypes.Bytes32{}},
+		{simContractA, simSlot(3), pldtypes.Bytes32{}, simSlot(30)},
+		{simContractB, simSlot(1), simSlot(10), pldtypes.Bytes32{}},
+	} {
+		assert.Equal(t, expected.address, sim.StorageChanges[i].Address.String())
+		assert.Equal(t, expected.slot, sim.StorageChanges[i].Slot)
+		assert.Equal(t, expected.before, sim.StorageChanges[i].Before)
+		assert.Equal(t, expected.after, sim.StorageChanges[i].After)
+	}
+}
+
+func TestSimulateTransactionTracedRevert(t *testing.T) {
+	var testABI abi.ABI
+	err := json.Unmarshal(testABIJSON, &testABI)
+	require.NoError(t, err)
+	errData, err := testABI.Errors()["WidgetError"].EncodeCallDataJSON([]byte(`{"sku": 1122334455, "issue": "not widgety enough"}`))
+	require.NoError(t, err)
+
+	ctx, ecf, done := newTestClientAndServer(t, &mockEth{
+		debug_traceCall: func(ctx context.Context, tx ethsigner.Transaction, block string, opts traceCallOptions) (pldtypes.RawJSON, error) {
+			if opts.Tracer == "prestateTracer" {
+				return nil, fmt.Errorf("diffMode not supported")
+			}
+			return pldtypes.JSONString(map[string]any{
+				"type":   "CALL",
+				"from":   simSender,
+				"to":     simContractA,
+				"gas":    "0x1000",
+				"output": pldtypes.HexBytes(errData),
+				"error":  "execution reverted",
+				"logs": []any{
+					map[string]any{"address": simContractA, "topics": []any{}, "position": "0x0"},
+				},
+			}), nil
+		},
+	})
+	defer done()
+
+	sim, err := ecf.HTTPClient().SimulateTransactionNoResolve(ctx, &ethsigner.Transaction{}, "latest", WithErrorsFrom(testABI))
+	require.NoError(t, err)
+
+	assert.True(t, sim.Traced)
+	assert.True(t, sim.Reverted)
+	assert.Equal(t, pldtypes.HexBytes(errData), sim.RevertData)
+	assert.Regexp(t, "WidgetError.*not widgety enough", sim.RevertReason)
+	assert.Equal(t, sim.RevertReason, sim.Call.RevertReason)
+	assert.Empty(t, sim.Output)
+	assert.Empty(t, sim.Logs)
+	assert.Nil(t, sim.StorageChanges)
+}
+
+func TestSimulateTransactionTracedBadStorageAddress(t *testing.T) {
+	ctx, ecf, done := newTestClientAndServer(t, &mockEth{
+		debug_traceCall: func(ctx context.Context, tx ethsigner.Transaction, block string, opts traceCallOptions) (pldtypes.RawJSON, error) {
+			if opts.Tracer == "prestateTracer" {
+				return pldtypes.RawJSON(`{"post": {"wrong": {}}}`), nil
+			}
+			return pldtypes.RawJSON(`{"type": "CALL", "from": "` + simSender + `"}`), nil
+		},
+	})
+	defer done()
+
+	_, err := ecf.HTTPClient().SimulateTransactionNoResolve(ctx, &ethsigner.Transaction{}, "latest")
+	assert.Regexp(t, "PD011519.*wrong", err)
+}
+
+func TestSimulateTransactionTracedBadPreStorageAddress(t *testing.T) {
+	ctx, ecf, done := newTestClientAndServer(t, &mockEth{
+		debug_traceCall: func(ctx context.Context, tx ethsigner.Transaction, block string, opts traceCallOptions) (pldtypes.RawJSON, error) {
+			if opts.Tracer == "prestateTracer" {
+				return pldtypes.RawJSON(`{"pre": {"wrong": {}}}`), nil
+			}
+			return pldtypes.RawJSON(`{"type": "CALL", "from": "` + simSender + `"}`), nil
+		},
+	})
+	defer done()
+
+	_, err := ecf.HTTPClient().SimulateTransactionNoResolve(ctx, &ethsigner.Transaction{}, "latest")
+	assert.Regexp(t, "PD011519.*wrong", err)
+}
+
+func TestSimulateTransactionFallbackToCall(t *testing.T) {
+	ctx, ecf, done := newTestClientAndServer(t, &mockEth{
+		eth_call: func(ctx context.Context, tx ethsigner.Transaction, block string) (pldtypes.HexBytes, error) {
+			return pldtypes.MustParseHexBytes("0xfeedbeef"), nil
+		},
+	})
+	defer done()
+
+	sim, err := ecf.HTTPClient().SimulateTransactionNoResolve(ctx, &ethsigner.Transaction{}, "latest")
+	require.NoError(t, err)
+	assert.False(t, sim.Traced)
+	assert.False(t, sim.Reverted)
+	assert.Equal(t, "0xfeedbeef", sim.Output.String())
+	assert.Nil(t, sim.Call)
+}
+
+func TestSimulateTransactionFallbackToCallRevert(t *testing.T) {
+	var testABI abi.ABI
+	err := json.Unmarshal(testABIJSON, &testABI)
+	require.NoError(t, err)
+	errData, err := testABI.Errors()["WidgetError"].EncodeCallDataJSON([]byte(`{"sku": 1122334455, "issue": "not widgety enough"}`))
+	require.NoError(t, err)
+
+	ctx, ecf, done := newTestClientAndServer(t, &mockEth{
+		eth_callErr: func(ctx context.Context, req *rpcclient.RPCRequest) *rpcclient.RPCResponse {
+			return &rpcclient.RPCResponse{
+				JSONRpc: "2.0",
+				ID:      req.ID,
+				Error: &rpcclient.RPCError{
+					Code:    int64(rpcclient.RPCCodeInternalError),
+					Message: "reverted",
+					Data:    pldtypes.JSONString(pldtypes.HexBytes(errData)),
+				},
+			}
+		},
+	})
+	defer done()
+
+	sim, err := ecf.HTTPClient().SimulateTransactionNoResolve(ctx, &ethsigner.Transaction{}, "latest", WithErrorsFrom(testABI))
+	require.NoError(t, err)
+	assert.False(t, sim.Traced)
+	assert.True(t, sim.Reverted)
+	assert.Equal(t, pldtypes.HexBytes(errData), sim.RevertData)
+	assert.Regexp(t, "WidgetError.*not widgety enough", sim.RevertReason)
+}
+
+func TestSimulateTransactionFallbackToCallFail(t *testing.T) {
+	ctx, ecf, done := newTestClientAndServer(t, &mockEth{
+		eth_call: func(ctx context.Context, tx ethsigner.Transaction, block string) (pldtypes.HexBytes, error) {
+			return nil, fmt.Errorf("pop")
+		},
+	})
+	defer done()
+
+	_, err := ecf.HTTPClient().SimulateTransactionNoResolve(ctx, &ethsigner.Transaction{}, "latest")
+	assert.Regexp(t, "pop", err)
+}
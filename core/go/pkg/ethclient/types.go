@@ -20,6 +20,7 @@ import (
 
 	"github.com/hyperledger/firefly-common/pkg/fftypes"
 	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
 )
 
 // ErrorReason are a set of standard error conditions that a blockchain connector can return
@@ -129,6 +130,17 @@ type TransactionReceiptResponse struct {
 	Logs             []fftypes.JSONAny `json:"logs,omitempty"` // all raw un-decoded logs should be included if includeLogs=true
 }
 
+// TransactionByHashResponse is the subset of eth_getTransactionByHash we use to check whether
+// the node still has a transaction - the block number is nil while it is pending in the pool.
+// The target and input are also returned, so that calldata of indexed transactions can be decoded.
+type TransactionByHashResponse struct {
+	Hash        pldtypes.Bytes32     `json:"hash"`
+	Nonce       pldtypes.HexUint64   `json:"nonce"`
+	BlockNumber *pldtypes.HexUint64  `json:"blockNumber"`
+	To          *pldtypes.EthAddress `json:"to,omitempty"`
+	Input       pldtypes.HexBytes    `json:"input,omitempty"`
+}
+
 // receiptExtraInfo is the version of the receipt we store under the TX.
 // - We omit the full logs from the JSON/RPC
 // - We omit fields already in the standardized cross-blockchain section
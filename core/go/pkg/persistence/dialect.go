@@ -0,0 +1,138 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package persistence
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+const (
+	DialectSQLite      = "sqlite"
+	DialectPostgres    = "postgres"
+	DialectCockroachDB = "cockroachdb"
+)
+
+// Dialect holds the SQL that differs between the databases we support, for the places where we
+// build SQL ourselves rather than leaving it to gORM.
+//
+// Upserts are not here, as gORM renders clause.OnConflict the same way for all of them.
+type Dialect interface {
+	Name() string
+	// A WHERE clause that compares the JSON value at a path within a JSON text column with a JSON
+	// test value, and the argument to pass for the path. The clause takes the path then the test value.
+	JSONPathEqual(column string, path []string) (clause string, pathArg string)
+	// A WHERE clause that checks if a text column contains a value, and the argument to pass for it
+	Contains(column string, not, caseInsensitive bool, value string) (clause string, arg string)
+	// A query returning the current position in the transaction log of the database as text, that
+	// a point-in-time recovery can target. Empty if the database does not have one.
+	LogPositionQuery() string
+}
+
+var jsonArrayIndexRegexp = regexp.MustCompile(`^[0-9]+$`)
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// GetDialect returns the dialect of the database behind a gORM DB, which is postgres for
+// any database we do not know about as that is what other databases tend to emulate.
+func GetDialect(db *gorm.DB) Dialect {
+	switch db.Dialector.Name() {
+	case DialectSQLite:
+		return sqliteDialect{}
+	case DialectCockroachDB:
+		return cockroachDialect{}
+	default:
+		return postgresDialect{}
+	}
+}
+
+type sqliteDialect struct{}
+
+func (sqliteDialect) Name() string { return DialectSQLite }
+
+func (sqliteDialect) JSONPathEqual(column string, path []string) (string, string) {
+	// json_extract returns native SQL values for scalars, and compact JSON text for
+	// objects/arrays - so we extract the test value the same way for comparison
+	sqlitePath := new(strings.Builder)
+	sqlitePath.WriteString("$")
+	for _, s := range path {
+		if isJSONArrayIndex(s) {
+			sqlitePath.WriteString("[" + s + "]")
+		} else {
+			sqlitePath.WriteString("." + s)
+		}
+	}
+	return fmt.Sprintf("json_extract(%s, ?) = json_extract(?, '$')", column), sqlitePath.String()
+}
+
+func (sqliteDialect) Contains(column string, not, caseInsensitive bool, value string) (string, string) {
+	negate := ""
+	if not {
+		negate = "NOT "
+	}
+	// SQLite has no ILIKE, and LIKE is case-insensitive (for ASCII) without a pragma,
+	// so we use instr() with explicit case folding
+	if caseInsensitive {
+		return fmt.Sprintf("%sinstr(LOWER(%s), LOWER(?)) > 0", negate, column), value
+	}
+	return fmt.Sprintf("%sinstr(%s, ?) > 0", negate, column), value
+}
+
+func (sqliteDialect) LogPositionQuery() string {
+	return ""
+}
+
+type postgresDialect struct{}
+
+func (postgresDialect) Name() string { return DialectPostgres }
+
+func (postgresDialect) JSONPathEqual(column string, path []string) (string, string) {
+	return fmt.Sprintf("(%s)::jsonb #> ?::text[] = ?::jsonb", column), "{" + strings.Join(path, ",") + "}"
+}
+
+func (postgresDialect) Contains(column string, not, caseInsensitive bool, value string) (string, string) {
+	negate := ""
+	if not {
+		negate = "NOT "
+	}
+	op := "LIKE"
+	if caseInsensitive {
+		op = "ILIKE"
+	}
+	return fmt.Sprintf("%s %s%s ?", column, negate, op), "%" + likeEscaper.Replace(value) + "%"
+}
+
+func (postgresDialect) LogPositionQuery() string {
+	return "SELECT pg_current_wal_lsn()::text"
+}
+
+// CockroachDB speaks the PostgreSQL wire protocol and SQL dialect, including the JSONB operators,
+// but has no write-ahead log positions - restores instead target a cluster timestamp.
+type cockroachDialect struct {
+	postgresDialect
+}
+
+func (cockroachDialect) Name() string { return DialectCockroachDB }
+
+func (cockroachDialect) LogPositionQuery() string {
+	return "SELECT cluster_logical_timestamp()::text"
+}
+
+func isJSONArrayIndex(segment string) bool {
+	return jsonArrayIndexRegexp.MatchString(segment)
+}
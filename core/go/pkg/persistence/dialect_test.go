@@ -0,0 +1,51 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package persistence
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestDialects(t *testing.T) {
+	sqlite := GetDialect(&gorm.DB{Config: &gorm.Config{Dialector: (&sqliteProvider{}).Open("")}})
+	assert.Equal(t, DialectSQLite, sqlite.Name())
+	clause, arg := sqlite.JSONPathEqual("data", []string{"owner", "keys", "0"})
+	assert.Equal(t, "json_extract(data, ?) = json_extract(?, '$')", clause)
+	assert.Equal(t, "$.owner.keys[0]", arg)
+	clause, arg = sqlite.Contains("name", true, true, "50%")
+	assert.Equal(t, "NOT instr(LOWER(name), LOWER(?)) > 0", clause)
+	assert.Equal(t, "50%", arg)
+	assert.Empty(t, sqlite.LogPositionQuery())
+
+	postgres := GetDialect(&gorm.DB{Config: &gorm.Config{Dialector: (&postgresProvider{}).Open("")}})
+	assert.Equal(t, DialectPostgres, postgres.Name())
+	clause, arg = postgres.JSONPathEqual("data", []string{"owner", "keys", "0"})
+	assert.Equal(t, "(data)::jsonb #> ?::text[] = ?::jsonb", clause)
+	assert.Equal(t, "{owner,keys,0}", arg)
+	clause, arg = postgres.Contains("name", false, true, "50%")
+	assert.Equal(t, "name ILIKE ?", clause)
+	assert.Equal(t, `%50\%%`, arg)
+	assert.Regexp(t, "pg_current_wal_lsn", postgres.LogPositionQuery())
+
+	cockroach := GetDialect(&gorm.DB{Config: &gorm.Config{Dialector: cockroachDialector{Dialector: (&postgresProvider{}).Open("")}}})
+	assert.Equal(t, DialectCockroachDB, cockroach.Name())
+	clause, _ = cockroach.JSONPathEqual("data", []string{"owner"})
+	assert.Equal(t, "(data)::jsonb #> ?::text[] = ?::jsonb", clause)
+	assert.Regexp(t, "cluster_logical_timestamp", cockroach.LogPositionQuery())
+}
@@ -52,6 +52,12 @@ type SQLDBProvider interface {
 	TakeNamedLock(ctx context.Context, dbTX DBTX, lockName string) error
 }
 
+// Optionally implemented by providers that can connect to more than one dialect of database, to
+// query which one they are connected to before anything else is done with the connection
+type dialectDetector interface {
+	DetectDialect(ctx context.Context, gdb *gorm.DB) error
+}
+
 func NewSQLProvider(ctx context.Context, p SQLDBProvider, conf *pldconf.SQLDBConfig, defs *pldconf.SQLDBConfig) (_ Persistence, err error) {
 	if conf.DSN == "" {
 		return nil, i18n.WrapError(ctx, err, msgs.MsgPersistenceMissingDSN)
@@ -80,6 +86,11 @@ func NewSQLProvider(ctx context.Context, p SQLDBProvider, conf *pldconf.SQLDBCon
 	if err != nil {
 		return nil, i18n.WrapError(ctx, err, msgs.MsgPersistenceInitFailed)
 	}
+	if dd, ok := p.(dialectDetector); ok {
+		if err = dd.DetectDialect(ctx, gp.gdb); err != nil {
+			return nil, i18n.WrapError(ctx, err, msgs.MsgPersistenceInitFailed)
+		}
+	}
 	if conf.DebugQueries {
 		gp.gdb = gp.gdb.Debug()
 	}
@@ -19,13 +19,16 @@ package persistence
 import (
 	"context"
 	"database/sql"
+	"strings"
 
 	gormPostgres "gorm.io/driver/postgres"
 	"gorm.io/gorm"
 
 	// Import pq driver
 	migratedb "github.com/golang-migrate/migrate/v4/database"
+	"github.com/golang-migrate/migrate/v4/database/cockroachdb"
 	"github.com/golang-migrate/migrate/v4/database/postgres"
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
 	"github.com/kaleido-io/paladin/config/pkg/confutil"
 	"github.com/kaleido-io/paladin/config/pkg/pldconf"
 )
@@ -38,14 +41,45 @@ var PostgresDefaults = &pldconf.SQLDBConfig{
 	StatementCache:  confutil.P(true),
 }
 
-type postgresProvider struct{}
+// The postgres provider is also used for CockroachDB, which speaks the same wire protocol and is
+// detected when we connect. CockroachDB has no advisory locks, so we use its own migration driver
+// and lock rows of the named_locks table instead.
+type postgresProvider struct {
+	cockroachDB bool
+}
 
 func newPostgresProvider(ctx context.Context, conf *pldconf.DBConfig) (p Persistence, err error) {
 	return NewSQLProvider(ctx, &postgresProvider{}, &conf.Postgres.SQLDBConfig, PostgresDefaults)
 }
 
 func (p *postgresProvider) DBName() string {
-	return "postgres"
+	if p.cockroachDB {
+		return DialectCockroachDB
+	}
+	return DialectPostgres
+}
+
+// cockroachDialector is the postgres dialector with the name changed, so that GetDialect can
+// distinguish CockroachDB from PostgreSQL
+type cockroachDialector struct {
+	gorm.Dialector
+}
+
+func (d cockroachDialector) Name() string {
+	return DialectCockroachDB
+}
+
+func (p *postgresProvider) DetectDialect(ctx context.Context, gdb *gorm.DB) error {
+	var version string
+	if err := gdb.WithContext(ctx).Raw("SELECT version()").Scan(&version).Error; err != nil {
+		return err
+	}
+	p.cockroachDB = strings.Contains(version, "CockroachDB")
+	if p.cockroachDB {
+		log.L(ctx).Infof("Connected to CockroachDB: %s", version)
+		gdb.Dialector = cockroachDialector{Dialector: gdb.Dialector}
+	}
+	return nil
 }
 
 func (p *postgresProvider) Open(dsn string) gorm.Dialector {
@@ -53,9 +87,21 @@ func (p *postgresProvider) Open(dsn string) gorm.Dialector {
 }
 
 func (p *postgresProvider) GetMigrationDriver(db *sql.DB) (migratedb.Driver, error) {
+	if p.cockroachDB {
+		return cockroachdb.WithInstance(db, &cockroachdb.Config{})
+	}
 	return postgres.WithInstance(db, &postgres.Config{})
 }
 
 func (p *postgresProvider) TakeNamedLock(ctx context.Context, dbTX DBTX, lockName string) error {
-	return dbTX.DB().Exec(`SELECT pg_advisory_xact_lock( ? )`, hashCode(lockName)).Error
+	if !p.cockroachDB {
+		return dbTX.DB().Exec(`SELECT pg_advisory_xact_lock( ? )`, hashCode(lockName)).Error
+	}
+	// The row lock is held until the transaction ends, like a transaction scoped advisory lock
+	lockID := hashCode(lockName)
+	err := dbTX.DB().Exec(`INSERT INTO named_locks ("id") VALUES ( ? ) ON CONFLICT DO NOTHING`, lockID).Error
+	if err == nil {
+		err = dbTX.DB().Exec(`SELECT "id" FROM named_locks WHERE "id" = ? FOR UPDATE`, lockID).Error
+	}
+	return err
 }
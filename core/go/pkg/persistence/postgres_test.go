@@ -19,6 +19,7 @@ package persistence
 import (
 	"context"
 	"database/sql/driver"
+	"fmt"
 	"reflect"
 	"testing"
 
@@ -34,6 +35,30 @@ func TestPostgresProvider(t *testing.T) {
 	db, _, _ := sqlmock.New()
 	_, err := p.GetMigrationDriver(db)
 	assert.Error(t, err)
+
+	p.cockroachDB = true
+	assert.Equal(t, "cockroachdb", p.DBName())
+	_, err = p.GetMigrationDriver(db)
+	assert.Error(t, err)
+}
+
+func TestPostgresDetectDialect(t *testing.T) {
+	p, mdb := newMockGormPSQLPersistence(t)
+	gp := p.(*provider)
+	pp := gp.p.(*postgresProvider)
+
+	mdb.ExpectQuery("SELECT version()").WillReturnRows(mdb.NewRows([]string{"version"}).AddRow("PostgreSQL 16.4"))
+	require.NoError(t, pp.DetectDialect(context.Background(), gp.gdb))
+	assert.False(t, pp.cockroachDB)
+	assert.Equal(t, DialectPostgres, GetDialect(p.DB()).Name())
+
+	mdb.ExpectQuery("SELECT version()").WillReturnRows(mdb.NewRows([]string{"version"}).AddRow("CockroachDB CCL v24.3.1 (x86_64-pc-linux-gnu)"))
+	require.NoError(t, pp.DetectDialect(context.Background(), gp.gdb))
+	assert.True(t, pp.cockroachDB)
+	assert.Equal(t, DialectCockroachDB, GetDialect(p.DB()).Name())
+
+	mdb.ExpectQuery("SELECT version()").WillReturnError(fmt.Errorf("pop"))
+	assert.Regexp(t, "pop", pp.DetectDialect(context.Background(), gp.gdb))
 }
 
 func TestPosgresNamedLock(t *testing.T) {
@@ -49,3 +74,19 @@ func TestPosgresNamedLock(t *testing.T) {
 	})
 	require.NoError(t, err)
 }
+
+func TestCockroachDBNamedLock(t *testing.T) {
+
+	p, mdb := newMockGormPSQLPersistence(t)
+	p.(*provider).p.(*postgresProvider).cockroachDB = true
+
+	mdb.ExpectBegin()
+	mdb.ExpectExec("INSERT INTO named_locks").WillReturnResult(driver.ResultNoRows)
+	mdb.ExpectExec("SELECT.*named_locks.*FOR UPDATE").WillReturnResult(driver.ResultNoRows)
+	mdb.ExpectCommit()
+
+	err := p.Transaction(context.Background(), func(ctx context.Context, dbTX DBTX) error {
+		return p.TakeNamedLock(ctx, dbTX, "any")
+	})
+	require.NoError(t, err)
+}
@@ -0,0 +1,262 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+// Package multinode starts several in-process testbed nodes that share the
+// base ledger of the testbed configuration, but each have their own state
+// store, keys and transport. The nodes are connected to each other using the
+// gRPC transport and a static registry, so private transaction flows that
+// involve endorsement across parties can be tested without docker-compose.
+package multinode
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/pkg/config"
+	"github.com/kaleido-io/paladin/core/pkg/testbed"
+	"github.com/kaleido-io/paladin/registries/static/pkg/static"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/toolkit/pkg/plugintk"
+	"github.com/kaleido-io/paladin/transports/grpc/pkg/grpc"
+)
+
+const (
+	transportName = "grpc"
+	registryName  = "nodes"
+)
+
+// NodeDomains returns the domains to load into a given node. A new plugin
+// instance is required for each node, but the registry address should be
+// the same on every node.
+type NodeDomains func(nodeName string) map[string]*testbed.TestbedDomain
+
+type Node struct {
+	Name    string
+	URL     string
+	Config  *pldconf.PaladinConfig
+	Testbed testbed.Testbed
+
+	stop func()
+}
+
+type nodeIdentity struct {
+	name string
+	port int
+	cert string
+	key  string
+}
+
+// Start starts one testbed per node name using the supplied config file,
+// and returns once all of them are running. Each node is given its own
+// HD wallet seed and its own SQLite database. The init functions are
+// applied to every node.
+func Start(configFile string, nodeNames []string, domains NodeDomains, initFunctions ...*testbed.UTInitFunction) (nodes []*Node, done func(), err error) {
+	ctx := context.Background()
+
+	var baseConf *pldconf.PaladinConfig
+	if err = config.ReadAndParseYAMLFile(ctx, configFile, &baseConf); err != nil {
+		return nil, nil, err
+	}
+	if err = checkNodeDBConfig(&baseConf.DB); err != nil {
+		return nil, nil, err
+	}
+
+	identities := make([]*nodeIdentity, len(nodeNames))
+	for i, name := range nodeNames {
+		if identities[i], err = newNodeIdentity(name); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	done = func() {
+		for i := len(nodes) - 1; i >= 0; i-- {
+			nodes[i].stop()
+		}
+	}
+	for _, id := range identities {
+		node := &Node{Name: id.name, Testbed: testbed.NewTestBed()}
+		var nodeDomains map[string]*testbed.TestbedDomain
+		if domains != nil {
+			nodeDomains = domains(id.name)
+		}
+		nodeInit := append([]*testbed.UTInitFunction{}, initFunctions...)
+		nodeInit = append(nodeInit, testbed.HDWalletSeedScopedToTest(), &testbed.UTInitFunction{
+			ModifyConfig: func(conf *pldconf.PaladinConfig) {
+				applyNodeConfig(conf, id, identities)
+			},
+			Plugins: map[string]plugintk.Plugin{
+				transportName: grpc.NewPlugin(ctx),
+				registryName:  static.NewPlugin(ctx),
+			},
+		})
+		var stop func()
+		node.URL, node.Config, stop, err = node.Testbed.StartForTest(configFile, nodeDomains, nodeInit...)
+		if err != nil {
+			done()
+			return nil, nil, fmt.Errorf("failed to start node %s: %s", id.name, err)
+		}
+		node.stop = stop
+		log.L(ctx).Infof("Started testbed node %s at %s", id.name, node.URL)
+		nodes = append(nodes, node)
+	}
+	return nodes, done, nil
+}
+
+// applyNodeConfig sets the node name, and configures the transport and a static
+// registry containing the transport details of all the other nodes
+func applyNodeConfig(conf *pldconf.PaladinConfig, self *nodeIdentity, all []*nodeIdentity) {
+	conf.NodeName = self.name
+	conf.DB.SQLite.DSN = nodeSQLiteDSN(conf.DB.SQLite.DSN, self.name)
+	conf.Transports = map[string]*pldconf.TransportConfig{
+		transportName: {
+			Plugin: pldconf.PluginConfig{
+				Type:    string(pldtypes.LibraryTypeCShared),
+				Library: "loaded/via/unit/test/loader",
+			},
+			Config: map[string]any{
+				"address": "127.0.0.1",
+				"port":    self.port,
+				"tls": pldconf.TLSConfig{
+					Cert: self.cert,
+					Key:  self.key,
+				},
+				"directCertVerification": true,
+			},
+		},
+	}
+
+	entries := make(map[string]*static.StaticEntry)
+	for _, peer := range all {
+		if peer.name == self.name {
+			continue
+		}
+		entries[peer.name] = &static.StaticEntry{
+			Properties: map[string]pldtypes.RawJSON{
+				"transport." + transportName: pldtypes.JSONString(&grpc.PublishedTransportDetails{
+					Endpoint: fmt.Sprintf("dns:///127.0.0.1:%d", peer.port),
+					Issuers:  peer.cert,
+				}),
+			},
+		}
+	}
+	conf.Registries = map[string]*pldconf.RegistryConfig{
+		registryName: {
+			Plugin: pldconf.PluginConfig{
+				Type:    string(pldtypes.LibraryTypeCShared),
+				Library: "loaded/via/unit/test/loader",
+			},
+			Config: map[string]any{
+				"entries": entries,
+			},
+		},
+	}
+}
+
+// checkNodeDBConfig rejects database configuration that would be shared between the nodes.
+// Each node needs its own database, which we can only arrange for SQLite.
+func checkNodeDBConfig(db *pldconf.DBConfig) error {
+	if db.Type != "sqlite" {
+		return fmt.Errorf("multiple nodes require a sqlite database, as each node needs its own database (type=%s)", db.Type)
+	}
+	if db.SQLite.DSN == "" {
+		return fmt.Errorf("multiple nodes require a sqlite dsn")
+	}
+	return nil
+}
+
+// nodeSQLiteDSN gives each node its own SQLite database. Every connection to ":memory:" is
+// already a separate database, and for a file we add the node name to the file name.
+func nodeSQLiteDSN(dsn, nodeName string) string {
+	if dsn == ":memory:" {
+		return dsn
+	}
+	file, query, hasQuery := strings.Cut(dsn, "?")
+	ext := filepath.Ext(file)
+	file = fmt.Sprintf("%s-%s%s", strings.TrimSuffix(file, ext), nodeName, ext)
+	if hasQuery {
+		return file + "?" + query
+	}
+	return file
+}
+
+func newNodeIdentity(name string) (*nodeIdentity, error) {
+	port, err := freePort()
+	if err != nil {
+		return nil, err
+	}
+	cert, key, err := selfSignedCert(name)
+	if err != nil {
+		return nil, err
+	}
+	return &nodeIdentity{name: name, port: port, cert: cert, key: key}, nil
+}
+
+func freePort() (int, error) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, err
+	}
+	defer l.Close()
+	return l.Addr().(*net.TCPAddr).Port, nil
+}
+
+// selfSignedCert generates the certificate each node uses for mutual TLS. The other
+// nodes trust it directly, as it is published as the issuer in the registry.
+func selfSignedCert(name string) (certPEM, keyPEM string, err error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return "", "", err
+	}
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return "", "", err
+	}
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-1 * time.Minute),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth, x509.ExtKeyUsageServerAuth},
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		IPAddresses:           []net.IP{net.IPv4(127, 0, 0, 1)},
+		DNSNames:              []string{"localhost"},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return "", "", err
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return "", "", err
+	}
+	certPEM = string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+	keyPEM = string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}))
+	return certPEM, keyPEM, nil
+}
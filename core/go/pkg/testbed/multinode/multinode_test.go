@@ -0,0 +1,125 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package multinode
+
+import (
+	"context"
+	"crypto/tls"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/pkg/config"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/rpcclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+)
+
+func writeTestConfig(t *testing.T) (configFile string) {
+	var conf *pldconf.PaladinConfig
+	err := config.ReadAndParseYAMLFile(context.Background(), "../../../test/config/sqlite.memory.config.yaml", &conf)
+	require.NoError(t, err)
+
+	conf.DB.SQLite.MigrationsDir = "../../../db/migrations/sqlite"
+	conf.DB.Postgres.MigrationsDir = "../../../db/migrations/postgres"
+	conf.Log = pldconf.LogConfig{
+		Level:  confutil.P("debug"),
+		Output: confutil.P("file"),
+		File: pldconf.LogFileConfig{
+			Filename: confutil.P("build/testbed.multinode-test.log"),
+		},
+	}
+
+	configFile = path.Join(t.TempDir(), "test.config.yaml")
+	b, err := yaml.Marshal(conf)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(configFile, b, 0644))
+	return configFile
+}
+
+func TestStartTwoNodes(t *testing.T) {
+	ctx := context.Background()
+
+	nodes, done, err := Start(writeTestConfig(t), []string{"node1", "node2"}, nil)
+	require.NoError(t, err)
+	defer done()
+	require.Len(t, nodes, 2)
+
+	for _, node := range nodes {
+		rpc := rpcclient.WrapRestyClient(resty.New().SetBaseURL(node.URL))
+		var nodeName string
+		rpcErr := rpc.CallRPC(ctx, &nodeName, "transport_nodeName")
+		require.NoError(t, rpcErr)
+		assert.Equal(t, node.Name, nodeName)
+	}
+	// Each node has its own keys
+	assert.NotEqual(t,
+		nodes[0].Config.Wallets[0].Signer.KeyStore.Static.Keys["seed"].Inline,
+		nodes[1].Config.Wallets[0].Signer.KeyStore.Static.Keys["seed"].Inline,
+	)
+}
+
+func TestStartBadConfig(t *testing.T) {
+	_, _, err := Start(path.Join(t.TempDir(), "missing.yaml"), []string{"node1"}, nil)
+	assert.Regexp(t, "PD050000", err)
+}
+
+func TestStartSharedPostgres(t *testing.T) {
+	configFile := path.Join(t.TempDir(), "test.config.yaml")
+	require.NoError(t, os.WriteFile(configFile, []byte(`{"db":{"type":"postgres"}}`), 0644))
+	_, _, err := Start(configFile, []string{"node1", "node2"}, nil)
+	assert.Regexp(t, "require a sqlite database", err)
+
+	require.NoError(t, os.WriteFile(configFile, []byte(`{"db":{"type":"sqlite"}}`), 0644))
+	_, _, err = Start(configFile, []string{"node1", "node2"}, nil)
+	assert.Regexp(t, "require a sqlite dsn", err)
+}
+
+func TestNodeSQLiteDSN(t *testing.T) {
+	assert.Equal(t, ":memory:", nodeSQLiteDSN(":memory:", "node1"))
+	assert.Equal(t, "/tmp/paladin-node1.db", nodeSQLiteDSN("/tmp/paladin.db", "node1"))
+	assert.Equal(t, "file:paladin-node2.db?_journal=WAL", nodeSQLiteDSN("file:paladin.db?_journal=WAL", "node2"))
+	assert.Equal(t, "paladin-node1", nodeSQLiteDSN("paladin", "node1"))
+}
+
+func TestApplyNodeConfig(t *testing.T) {
+	node1, err := newNodeIdentity("node1")
+	require.NoError(t, err)
+	node2, err := newNodeIdentity("node2")
+	require.NoError(t, err)
+
+	conf := &pldconf.PaladinConfig{}
+	conf.DB.SQLite.DSN = "paladin.db"
+	applyNodeConfig(conf, node1, []*nodeIdentity{node1, node2})
+
+	assert.Equal(t, "node1", conf.NodeName)
+	assert.Equal(t, "paladin-node1.db", conf.DB.SQLite.DSN)
+	assert.Equal(t, node1.port, conf.Transports[transportName].Config["port"])
+
+	entries := pldtypes.JSONString(conf.Registries[registryName].Config["entries"])
+	var parsed map[string]map[string]map[string]map[string]string
+	require.NoError(t, yaml.Unmarshal(entries, &parsed))
+	assert.Len(t, parsed, 1)
+	assert.Equal(t, node2.cert, parsed["node2"]["properties"]["transport.grpc"]["issuers"])
+
+	_, err = tls.X509KeyPair([]byte(node1.cert), []byte(node1.key))
+	assert.NoError(t, err)
+}
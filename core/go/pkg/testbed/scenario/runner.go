@@ -0,0 +1,354 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package scenario
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hyperledger/firefly-signer/pkg/abi"
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/core/internal/msgs"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/rpcclient"
+)
+
+var varRegex = regexp.MustCompile(`\$\{([A-Za-z0-9_\-.]+)\}`)
+
+type runner struct {
+	rpc  rpcclient.Client
+	abis map[string]abi.ABI
+	vars map[string]any
+}
+
+// RunFile parses and runs the scenario in the supplied file
+func RunFile(ctx context.Context, rpc rpcclient.Client, path string) error {
+	s, err := ParseFile(ctx, path)
+	if err != nil {
+		return err
+	}
+	return Run(ctx, rpc, s)
+}
+
+// Run executes each step of the scenario in order against the testbed
+// behind the supplied JSON/RPC client, stopping at the first failure
+func Run(ctx context.Context, rpc rpcclient.Client, s *Scenario) (err error) {
+	r := &runner{rpc: rpc, vars: map[string]any{}}
+	if r.abis, err = s.loadABIs(ctx); err != nil {
+		return err
+	}
+	for k, v := range s.Vars {
+		if r.vars[k], err = normalize(v); err != nil {
+			return err
+		}
+	}
+	for i, step := range s.Steps {
+		name := step.Name
+		if name == "" {
+			name = fmt.Sprintf("step%d", i)
+		}
+		log.L(ctx).Infof("Scenario '%s' step %d (%s)", s.Name, i, name)
+		if err := r.runStep(ctx, i, name, step); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *runner) runStep(ctx context.Context, i int, name string, template *Step) error {
+	step, err := r.substituteStep(ctx, template)
+	if err != nil {
+		return err
+	}
+
+	var result pldtypes.RawJSON
+	var rpcErr error
+	switch {
+	case step.Deploy != nil:
+		rpcErr = r.rpc.CallRPC(ctx, &result, "testbed_deploy", step.Deploy.Domain, step.Deploy.From, step.Deploy.Params)
+	case step.Invoke != nil:
+		var tx *pldapi.TransactionInput
+		if tx, err = r.buildTX(ctx, step.Invoke); err == nil {
+			rpcErr = r.rpc.CallRPC(ctx, &result, "testbed_invoke", tx, true)
+		}
+	case step.Call != nil:
+		var tx *pldapi.TransactionInput
+		if tx, err = r.buildTX(ctx, step.Call); err == nil {
+			rpcErr = r.rpc.CallRPC(ctx, &result, "testbed_call", tx, step.Call.DataFormat)
+		}
+	case step.RPC != nil:
+		rpcErr = r.rpc.CallRPC(ctx, &result, step.RPC.Method, step.RPC.Params...)
+	default:
+		// Parse validates this, but a scenario can be built in code and passed straight to Run
+		return i18n.NewError(ctx, msgs.MsgTestbedScenarioInvalidStep, i)
+	}
+	if err != nil {
+		return i18n.WrapError(ctx, err, msgs.MsgTestbedScenarioStepFailed, i, name)
+	}
+
+	expect := step.Expect
+	if expect != nil && expect.Error != "" {
+		if rpcErr == nil {
+			return i18n.NewError(ctx, msgs.MsgTestbedScenarioExpectedError, i, name, expect.Error)
+		}
+		if !strings.Contains(rpcErr.Error(), expect.Error) {
+			return i18n.NewError(ctx, msgs.MsgTestbedScenarioUnexpectedError, i, name, expect.Error, rpcErr.Error())
+		}
+		return nil
+	}
+	if rpcErr != nil {
+		return i18n.WrapError(ctx, rpcErr, msgs.MsgTestbedScenarioStepFailed, i, name)
+	}
+
+	value, err := normalize(result)
+	if err != nil {
+		return i18n.WrapError(ctx, err, msgs.MsgTestbedScenarioStepFailed, i, name)
+	}
+	if step.SaveAs != "" {
+		r.vars[step.SaveAs] = value
+	}
+	if expect != nil {
+		return r.checkExpectations(ctx, i, name, expect, value)
+	}
+	return nil
+}
+
+func (r *runner) buildTX(ctx context.Context, inv *InvokeStep) (*pldapi.TransactionInput, error) {
+	a, ok := r.abis[inv.ABI]
+	if !ok {
+		return nil, i18n.NewError(ctx, msgs.MsgTestbedScenarioABINotFound, inv.ABI)
+	}
+	to, err := pldtypes.ParseEthAddress(inv.To)
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(inv.Data)
+	if err != nil {
+		return nil, err
+	}
+	return &pldapi.TransactionInput{
+		TransactionBase: pldapi.TransactionBase{
+			From:     inv.From,
+			To:       to,
+			Function: inv.Function,
+			Data:     data,
+		},
+		ABI: a,
+	}, nil
+}
+
+func (r *runner) checkExpectations(ctx context.Context, i int, name string, expect *Expect, value any) error {
+	value, ok := lookupPath(value, expect.Path)
+	if !ok {
+		return i18n.NewError(ctx, msgs.MsgTestbedScenarioInvalidPath, expect.Path)
+	}
+	if expect.Length != nil {
+		length := -1
+		switch v := value.(type) {
+		case []any:
+			length = len(v)
+		case map[string]any:
+			length = len(v)
+		}
+		if length != *expect.Length {
+			return i18n.NewError(ctx, msgs.MsgTestbedScenarioAssertionFailed, i, name, expect.Path,
+				fmt.Sprintf("length %d", *expect.Length), fmt.Sprintf("length %d", length))
+		}
+	}
+	if expect.Equals != nil {
+		expected, err := normalize(expect.Equals)
+		if err != nil {
+			return err
+		}
+		if path, ok := matches(expected, value, ""); !ok {
+			e, _ := lookupPath(expected, path)
+			a, _ := lookupPath(value, path)
+			return i18n.NewError(ctx, msgs.MsgTestbedScenarioAssertionFailed, i, name, joinPath(expect.Path, path), toString(e), toString(a))
+		}
+	}
+	return nil
+}
+
+// substituteStep returns a copy of the step with all variable references replaced
+func (r *runner) substituteStep(ctx context.Context, template *Step) (*Step, error) {
+	generic, err := normalize(template)
+	if err != nil {
+		return nil, err
+	}
+	if generic, err = r.substitute(ctx, generic); err != nil {
+		return nil, err
+	}
+	b, _ := json.Marshal(generic)
+	var step Step
+	err = json.Unmarshal(b, &step)
+	return &step, err
+}
+
+func (r *runner) substitute(ctx context.Context, v any) (any, error) {
+	var err error
+	switch v := v.(type) {
+	case map[string]any:
+		for k, child := range v {
+			if v[k], err = r.substitute(ctx, child); err != nil {
+				return nil, err
+			}
+		}
+		return v, nil
+	case []any:
+		for i, child := range v {
+			if v[i], err = r.substitute(ctx, child); err != nil {
+				return nil, err
+			}
+		}
+		return v, nil
+	case string:
+		// A string that is exactly one reference takes the type of the referenced value
+		if m := varRegex.FindStringSubmatch(v); m != nil && m[0] == v {
+			return r.resolveVar(ctx, m[1])
+		}
+		var resolveErr error
+		s := varRegex.ReplaceAllStringFunc(v, func(ref string) string {
+			resolved, err := r.resolveVar(ctx, ref[2:len(ref)-1])
+			if err != nil {
+				resolveErr = err
+			}
+			return toString(resolved)
+		})
+		return s, resolveErr
+	default:
+		return v, nil
+	}
+}
+
+func (r *runner) resolveVar(ctx context.Context, ref string) (any, error) {
+	name, path, _ := strings.Cut(ref, ".")
+	value, ok := r.vars[name]
+	if ok {
+		value, ok = lookupPath(value, path)
+	}
+	if !ok {
+		return nil, i18n.NewError(ctx, msgs.MsgTestbedScenarioVarNotFound, ref)
+	}
+	return value, nil
+}
+
+// normalize converts any value to the generic JSON form, retaining the full precision of numbers
+func normalize(v any) (any, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var generic any
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.UseNumber()
+	err = d.Decode(&generic)
+	return generic, err
+}
+
+func lookupPath(v any, path string) (any, bool) {
+	if path == "" {
+		return v, true
+	}
+	for _, segment := range strings.Split(path, ".") {
+		switch tv := v.(type) {
+		case map[string]any:
+			var ok bool
+			if v, ok = tv[segment]; !ok {
+				return nil, false
+			}
+		case []any:
+			idx, err := strconv.Atoi(segment)
+			if err != nil || idx < 0 || idx >= len(tv) {
+				return nil, false
+			}
+			v = tv[idx]
+		default:
+			return nil, false
+		}
+	}
+	return v, true
+}
+
+// matches performs a subset match of expected against actual, returning the
+// path of the first mismatch. Integers match by value regardless of whether
+// they are formatted as JSON numbers, decimal strings or hex strings.
+func matches(expected, actual any, path string) (string, bool) {
+	switch ev := expected.(type) {
+	case map[string]any:
+		av, ok := actual.(map[string]any)
+		if !ok {
+			return path, false
+		}
+		for k, e := range ev {
+			if p, ok := matches(e, av[k], joinPath(path, k)); !ok {
+				return p, false
+			}
+		}
+		return path, true
+	case []any:
+		av, ok := actual.([]any)
+		if !ok || len(av) != len(ev) {
+			return path, false
+		}
+		for i, e := range ev {
+			if p, ok := matches(e, av[i], joinPath(path, strconv.Itoa(i))); !ok {
+				return p, false
+			}
+		}
+		return path, true
+	case nil:
+		return path, actual == nil
+	default:
+		es, as := toString(expected), toString(actual)
+		if ei, ok := new(big.Int).SetString(es, 0); ok {
+			if ai, ok := new(big.Int).SetString(as, 0); ok {
+				return path, ei.Cmp(ai) == 0
+			}
+		}
+		return path, actual != nil && es == as
+	}
+}
+
+func joinPath(path, segment string) string {
+	if path == "" {
+		return segment
+	}
+	return path + "." + segment
+}
+
+func toString(v any) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case nil:
+		return "null"
+	case map[string]any, []any:
+		b, _ := json.Marshal(v)
+		return string(b)
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,279 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package scenario
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/rpcclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeHandler func(params []json.RawMessage) (any, string)
+
+func newFakeTestbed(t *testing.T, handlers map[string]fakeHandler) rpcclient.Client {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req rpcclient.RPCRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		var params []json.RawMessage
+		for _, p := range req.Params {
+			params = append(params, json.RawMessage(p))
+		}
+		res := map[string]any{"jsonrpc": "2.0", "id": req.ID}
+		h, ok := handlers[req.Method]
+		require.True(t, ok, "unexpected method %s", req.Method)
+		result, errMsg := h(params)
+		if errMsg != "" {
+			res["error"] = map[string]any{"code": -32000, "message": errMsg}
+		} else {
+			res["result"] = result
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(res)
+	}))
+	t.Cleanup(server.Close)
+	return rpcclient.WrapRestyClient(resty.New().SetBaseURL(server.URL))
+}
+
+const tokenABI = `[
+	{"type":"function","name":"mint","inputs":[{"name":"to","type":"string"},{"name":"amount","type":"uint256"}]},
+	{"type":"function","name":"balanceOf","inputs":[{"name":"account","type":"string"}],"outputs":[{"name":"","type":"uint256"}]}
+]`
+
+func writeFile(t *testing.T, dir, name, content string) string {
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	return path
+}
+
+func TestRunFileFullFlow(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	writeFile(t, dir, "token.json", `{"contractName":"Token","abi":`+tokenABI+`}`)
+	path := writeFile(t, dir, "scenario.yaml", `
+name: mint and check
+vars:
+  notary: notary@node1
+  amount: 100
+abis:
+  token: token.json
+steps:
+- name: deploy
+  deploy:
+    domain: simple
+    from: ${notary}
+    params:
+      notary: ${notary}
+  saveAs: contract
+- name: mint
+  invoke:
+    from: ${notary}
+    to: ${contract}
+    abi: token
+    function: mint
+    data:
+      to: wallets.${notary}
+      amount: ${amount}
+  saveAs: mintResult
+  expect:
+    equals:
+      receipt:
+        success: true
+- name: balance
+  call:
+    from: ${notary}
+    to: ${contract}
+    abi: token
+    function: balanceOf
+    data:
+      account: ${notary}
+  expect:
+    path: "0"
+    equals: 100
+- name: states
+  rpc:
+    method: pstate_queryContractStates
+    params: [simple, "${contract}", "${mintResult.receipt.transactionHash}"]
+  expect:
+    length: 2
+    equals:
+    - data: { amount: "0x64" }
+    - data: { amount: 0 }
+- name: bad transfer
+  invoke:
+    from: ${notary}
+    to: ${contract}
+    abi: token
+    function: mint
+    data: { to: nobody, amount: 1 }
+  expect:
+    error: insufficient
+`)
+
+	contract := pldtypes.RandAddress()
+	var calls []string
+	rpc := newFakeTestbed(t, map[string]fakeHandler{
+		"testbed_deploy": func(params []json.RawMessage) (any, string) {
+			calls = append(calls, "deploy")
+			assert.JSONEq(t, `"simple"`, string(params[0]))
+			assert.JSONEq(t, `"notary@node1"`, string(params[1]))
+			assert.JSONEq(t, `{"notary":"notary@node1"}`, string(params[2]))
+			return contract, ""
+		},
+		"testbed_invoke": func(params []json.RawMessage) (any, string) {
+			calls = append(calls, "invoke")
+			var tx pldapi.TransactionInput
+			require.NoError(t, json.Unmarshal(params[0], &tx))
+			assert.Equal(t, contract, tx.To)
+			assert.Equal(t, "mint", tx.Function)
+			assert.Len(t, tx.ABI, 2)
+			if len(calls) > 4 {
+				return nil, "insufficient funds"
+			}
+			assert.JSONEq(t, `{"to":"wallets.notary@node1","amount":100}`, tx.Data.String())
+			return map[string]any{"receipt": map[string]any{"success": true, "transactionHash": "0xaabb"}}, ""
+		},
+		"testbed_call": func(params []json.RawMessage) (any, string) {
+			calls = append(calls, "call")
+			return []string{"100"}, ""
+		},
+		"pstate_queryContractStates": func(params []json.RawMessage) (any, string) {
+			calls = append(calls, "query")
+			assert.JSONEq(t, `"0xaabb"`, string(params[2]))
+			return []any{
+				map[string]any{"id": "s1", "data": map[string]any{"amount": "100"}},
+				map[string]any{"id": "s2", "data": map[string]any{"amount": "0x0"}},
+			}, ""
+		},
+	})
+
+	err := RunFile(ctx, rpc, path)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"deploy", "invoke", "call", "query", "invoke"}, calls)
+}
+
+func TestRunAssertionFailures(t *testing.T) {
+	ctx := context.Background()
+	rpc := newFakeTestbed(t, map[string]fakeHandler{
+		"ok": func(params []json.RawMessage) (any, string) {
+			return map[string]any{"a": []any{"x", map[string]any{"b": 1}}}, ""
+		},
+		"fail": func(params []json.RawMessage) (any, string) {
+			return nil, "pop"
+		},
+	})
+
+	for _, tc := range []struct {
+		yaml string
+		err  string
+	}{
+		{`{steps: [{rpc: {method: ok}, expect: {equals: {a: [x, {b: 2}]}}}]}`, "PD012607.*'a.1.b': expected 2, got 1"},
+		{`{steps: [{rpc: {method: ok}, expect: {equals: {a: [x]}}}]}`, "PD012607.*'a'"},
+		{`{steps: [{rpc: {method: ok}, expect: {equals: {a: {}}}}]}`, "PD012607"},
+		{`{steps: [{rpc: {method: ok}, expect: {equals: {c: null, a: [x, {b: 1}]}}}]}`, ""},
+		{`{steps: [{rpc: {method: ok}, expect: {equals: {a: null}}}]}`, "PD012607"},
+		{`{steps: [{rpc: {method: ok}, expect: {equals: {missing: x}}}]}`, "PD012607.*got null"},
+		{`{steps: [{name: s1, rpc: {method: ok}, expect: {path: a, length: 3}}]}`, "PD012607.*s1.*length 3, got length 2"},
+		{`{steps: [{rpc: {method: ok}, expect: {path: a.1, length: 1}}]}`, ""},
+		{`{steps: [{rpc: {method: ok}, expect: {path: a.9}}]}`, "PD012610"},
+		{`{steps: [{rpc: {method: ok}, expect: {path: a.0.x}}]}`, "PD012610"},
+		{`{steps: [{rpc: {method: ok}, expect: {path: a.z}}]}`, "PD012610"},
+		{`{steps: [{rpc: {method: ok}, expect: {error: pop}}]}`, "PD012608"},
+		{`{steps: [{rpc: {method: fail}, expect: {error: bang}}]}`, "PD012609.*pop"},
+		{`{steps: [{rpc: {method: fail}}]}`, "PD012606.*pop"},
+		{`{steps: [{rpc: {method: "${missing}"}}]}`, "PD012605"},
+		{`{steps: [{rpc: {method: "x${missing.y}"}}]}`, "PD012605"},
+		{`{vars: {v: {a: 1}}, steps: [{rpc: {method: "${v}"}}]}`, "cannot unmarshal"},
+		{`{steps: [{invoke: {abi: missing}}]}`, "PD012606.*PD012603"},
+		{`{abis: {a: []}, steps: [{call: {abi: a, to: bad}}]}`, "PD012606"},
+	} {
+		s, err := Parse(ctx, []byte(tc.yaml), "")
+		require.NoError(t, err)
+		err = Run(ctx, rpc, s)
+		if tc.err == "" {
+			assert.NoError(t, err, tc.yaml)
+		} else {
+			assert.Regexp(t, tc.err, err, tc.yaml)
+		}
+	}
+}
+
+func TestRunStepWithoutAction(t *testing.T) {
+	rpc := newFakeTestbed(t, map[string]fakeHandler{})
+	err := Run(context.Background(), rpc, &Scenario{Steps: []*Step{{Name: "empty"}}})
+	assert.Regexp(t, "PD012602.*0", err)
+}
+
+func TestParseErrors(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := ParseFile(ctx, t.TempDir()+"/missing.yaml")
+	assert.Regexp(t, "PD012600", err)
+
+	_, err = Parse(ctx, []byte(`{!!!`), "")
+	assert.Regexp(t, "PD012601", err)
+
+	_, err = Parse(ctx, []byte(`{steps: [{}]}`), "")
+	assert.Regexp(t, "PD012602.*0", err)
+
+	_, err = Parse(ctx, []byte(`{steps: [{rpc: {method: a}}, {rpc: {method: a}, call: {}, invoke: {}, deploy: {}}]}`), "")
+	assert.Regexp(t, "PD012602.*1", err)
+}
+
+func TestLoadABIErrors(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	writeFile(t, dir, "bad.json", `{"not":"an abi"}`)
+
+	for _, yaml := range []string{
+		`{abis: {a: missing.json}}`,
+		`{abis: {a: bad.json}}`,
+		`{abis: {a: 12345}}`,
+	} {
+		s, err := Parse(ctx, []byte(yaml), dir)
+		require.NoError(t, err)
+		err = Run(ctx, nil, s)
+		assert.Regexp(t, "PD012604", err)
+	}
+
+	s, err := Parse(ctx, []byte(`{vars: {a: {b: c}}, abis: {inline: `+tokenABI+`}}`), dir)
+	require.NoError(t, err)
+	require.NoError(t, Run(ctx, nil, s))
+}
+
+func TestNormalizeError(t *testing.T) {
+	_, err := normalize(map[bool]bool{true: true})
+	assert.Error(t, err)
+
+	s := &Scenario{Vars: map[string]any{"bad": map[bool]bool{true: true}}}
+	assert.Error(t, Run(context.Background(), nil, s))
+}
+
+func TestToString(t *testing.T) {
+	assert.Equal(t, "true", toString(true))
+	assert.Equal(t, "null", toString(nil))
+	assert.Equal(t, `{"a":1}`, toString(map[string]any{"a": 1}))
+}
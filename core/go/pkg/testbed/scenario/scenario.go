@@ -0,0 +1,154 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+// Package scenario executes YAML-described transaction flows against a running
+// testbed, so domain authors can write regression suites without writing Go.
+package scenario
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/hyperledger/firefly-signer/pkg/abi"
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/core/internal/msgs"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"sigs.k8s.io/yaml"
+)
+
+// Scenario is an ordered list of steps, with a set of initial variables and
+// named ABIs that the steps can refer to.
+type Scenario struct {
+	Name  string                      `json:"name"`
+	Vars  map[string]any              `json:"vars,omitempty"`
+	ABIs  map[string]pldtypes.RawJSON `json:"abis,omitempty"` // inline ABI, compiled artifact with an "abi" field, or a file path relative to the scenario
+	Steps []*Step                     `json:"steps"`
+
+	baseDir string
+}
+
+// Step performs exactly one of deploy, invoke, call or rpc. All string values
+// in the step can reference variables using ${name} or ${name.path.0.field}.
+type Step struct {
+	Name   string      `json:"name,omitempty"`
+	Deploy *DeployStep `json:"deploy,omitempty"`
+	Invoke *InvokeStep `json:"invoke,omitempty"`
+	Call   *InvokeStep `json:"call,omitempty"`
+	RPC    *RPCStep    `json:"rpc,omitempty"`
+	SaveAs string      `json:"saveAs,omitempty"` // stores the result as a variable for later steps
+	Expect *Expect     `json:"expect,omitempty"`
+}
+
+// DeployStep deploys a private smart contract via testbed_deploy
+type DeployStep struct {
+	Domain string `json:"domain"`
+	From   string `json:"from"`
+	Params any    `json:"params"`
+}
+
+// InvokeStep invokes (testbed_invoke) or calls (testbed_call) a function on a private smart contract
+type InvokeStep struct {
+	From       string `json:"from"`
+	To         string `json:"to"`
+	ABI        string `json:"abi"` // name of an entry in the scenario abis
+	Function   string `json:"function,omitempty"`
+	Data       any    `json:"data,omitempty"`
+	DataFormat string `json:"dataFormat,omitempty"` // call only
+}
+
+// RPCStep calls any JSON/RPC method exposed by the testbed node, such as the
+// pstate_ methods to query the states of a contract
+type RPCStep struct {
+	Method string `json:"method"`
+	Params []any  `json:"params,omitempty"`
+}
+
+// Expect describes the assertions made on the result of a step
+type Expect struct {
+	Path   string `json:"path,omitempty"`   // dotted path into the result, to which the other assertions apply
+	Equals any    `json:"equals,omitempty"` // every field provided must match - extra fields in the result are ignored
+	Length *int   `json:"length,omitempty"` // length of an array/object result
+	Error  string `json:"error,omitempty"`  // the step must fail, with an error containing this string
+}
+
+// ParseFile reads a scenario from a YAML (or JSON) file. ABI file references
+// are resolved relative to the directory containing the file.
+func ParseFile(ctx context.Context, path string) (*Scenario, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, i18n.WrapError(ctx, err, msgs.MsgTestbedScenarioReadFailed, path)
+	}
+	return Parse(ctx, data, filepath.Dir(path))
+}
+
+// Parse reads a scenario from YAML (or JSON) bytes, with ABI file references
+// resolved relative to baseDir
+func Parse(ctx context.Context, data []byte, baseDir string) (*Scenario, error) {
+	var s Scenario
+	if err := yaml.Unmarshal(data, &s); err != nil {
+		return nil, i18n.WrapError(ctx, err, msgs.MsgTestbedScenarioParseFailed)
+	}
+	for i, step := range s.Steps {
+		set := 0
+		if step.Deploy != nil {
+			set++
+		}
+		if step.Invoke != nil {
+			set++
+		}
+		if step.Call != nil {
+			set++
+		}
+		if step.RPC != nil {
+			set++
+		}
+		if set != 1 {
+			return nil, i18n.NewError(ctx, msgs.MsgTestbedScenarioInvalidStep, i)
+		}
+	}
+	s.baseDir = baseDir
+	return &s, nil
+}
+
+func (s *Scenario) loadABIs(ctx context.Context) (map[string]abi.ABI, error) {
+	abis := make(map[string]abi.ABI, len(s.ABIs))
+	for name, raw := range s.ABIs {
+		var filename string
+		if json.Unmarshal(raw, &filename) == nil {
+			if !filepath.IsAbs(filename) {
+				filename = filepath.Join(s.baseDir, filename)
+			}
+			data, err := os.ReadFile(filename)
+			if err != nil {
+				return nil, i18n.WrapError(ctx, err, msgs.MsgTestbedScenarioABIInvalid, name)
+			}
+			raw = data
+		}
+		var artifact struct {
+			ABI abi.ABI `json:"abi"`
+		}
+		var a abi.ABI
+		if err := json.Unmarshal(raw, &a); err != nil {
+			if err := json.Unmarshal(raw, &artifact); err != nil || artifact.ABI == nil {
+				return nil, i18n.WrapError(ctx, err, msgs.MsgTestbedScenarioABIInvalid, name)
+			}
+			a = artifact.ABI
+		}
+		abis[name] = a
+	}
+	return abis, nil
+}
@@ -46,6 +46,17 @@ func HDWalletSeedScopedToTest() *UTInitFunction {
 	}
 }
 
+// BlockchainEndpoints points the testbed at a different base ledger to the one in
+// the config file, such as the embedded chain in testinfra/pkg/fakeevm
+func BlockchainEndpoints(httpURL, wsURL string) *UTInitFunction {
+	return &UTInitFunction{
+		ModifyConfig: func(conf *pldconf.PaladinConfig) {
+			conf.Blockchain.HTTP.URL = httpURL
+			conf.Blockchain.WS.URL = wsURL
+		},
+	}
+}
+
 type KeyMapping = pldapi.KeyMappingAndVerifier
 
 type Testbed interface {
@@ -120,6 +131,7 @@ type UTInitFunction struct {
 	ModifyConfig     func(conf *pldconf.PaladinConfig)
 	PreManagerStart  func(c AllComponents) error
 	PostManagerStart func(c AllComponents) error
+	Plugins          map[string]plugintk.Plugin // non-domain plugins (transports, registries) to load alongside the domains
 }
 
 func unitTestSocketFile() (fileName string, err error) {
@@ -200,6 +212,11 @@ func (tb *testbed) StartForTest(configFile string, domains map[string]*TestbedDo
 				for name, domain := range domains {
 					loaderMap[name] = domain.Plugin
 				}
+				for _, init := range initFunctions {
+					for name, plugin := range init.Plugins {
+						loaderMap[name] = plugin
+					}
+				}
 				pc := c.PluginManager()
 				pl, err = plugins.NewUnitTestPluginLoader(pc.GRPCTargetURL(), pc.LoaderID().String(), loaderMap)
 				if err != nil {
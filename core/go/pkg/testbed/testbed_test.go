@@ -119,3 +119,10 @@ log:
 
 	assert.NotNil(t, conf.DomainManagerConfig.Domains["pente"].Config)
 }
+
+func TestBlockchainEndpoints(t *testing.T) {
+	conf := &pldconf.PaladinConfig{}
+	BlockchainEndpoints("http://127.0.0.1:1234", "ws://127.0.0.1:2345").ModifyConfig(conf)
+	assert.Equal(t, "http://127.0.0.1:1234", conf.Blockchain.HTTP.URL)
+	assert.Equal(t, "ws://127.0.0.1:2345", conf.Blockchain.WS.URL)
+}
@@ -22,6 +22,7 @@ import io.kaleido.paladin.loader.PluginLoader;
 import io.kaleido.paladin.logging.PaladinLogging;
 import org.apache.logging.log4j.Logger;
 
+import java.util.Arrays;
 import java.util.concurrent.CompletableFuture;
 
 public class Main {
@@ -68,22 +69,31 @@ public class Main {
         LoadBalancerRegistry.getDefaultRegistry().register(new PickFirstLoadBalancerProvider());
     }
 
+    static final String VALIDATE_CONFIG_FLAG = "--validate-config";
+
+    static final String VALIDATE_CONFIG_MODE = "validate-config";
+
     public static int run(String[] args) {
         PluginLoader loader = null;
 
         if (args.length < 2) {
-            throw new Error("usage: <config.paladin.yaml> <engine|testbed>");
+            throw new Error("usage: <config.paladin.yaml> <engine|testbed|%s>".formatted(VALIDATE_CONFIG_FLAG));
         }
         try {
             final String configFile = args[0];
-            final String engineName = args[1];
+            // The validation flag can be added to an existing command line, in place of the engine name or after it
+            final boolean validateConfig = Arrays.asList(args).subList(1, args.length).contains(VALIDATE_CONFIG_FLAG);
+            final String engineName = validateConfig ? VALIDATE_CONFIG_MODE : args[1];
 
             // We have a very limited amount of parsing of the config file that happens in the loader.
             // We just need enough to know whether to use a special temp dir for our socket file,
             // and to initialize the Java logging framework.
             RuntimeInfo runtimeInfo = setRunning(new YamlConfig(configFile).getRuntimeInfo());
 
-            loader = new PluginLoader(runtimeInfo.socketFilename(), runtimeInfo.instanceId());
+            // No plugins are loaded when we are only validating the config
+            if (!validateConfig) {
+                loader = new PluginLoader(runtimeInfo.socketFilename(), runtimeInfo.instanceId());
+            }
 
             var rc = ensureLoaded().Run(
                     runtimeInfo.socketFilename(),
@@ -18,10 +18,12 @@ import io.kaleido.paladin.logging.PaladinLogging;
 import org.apache.logging.log4j.Logger;
 
 import java.io.File;
+import java.io.IOException;
 import java.lang.reflect.Method;
-import java.net.URI;
 import java.net.URL;
 import java.net.URLClassLoader;
+import java.util.ArrayList;
+import java.util.List;
 
 public class PluginJAR extends Plugin {
 
@@ -29,22 +31,41 @@ public class PluginJAR extends Plugin {
 
     private final String libName;
     private final String className;
+    private final List<String> classpath;
+    private URLClassLoader pluginClassLoader;
     private Object pluginImpl;
     private Method stopInstanceMethod;
 
-    PluginJAR(String grpcTarget, PluginInfo info, PluginStopped onStop, String libName, String className) {
+    PluginJAR(String grpcTarget, PluginInfo info, PluginStopped onStop, String libName, String className, List<String> classpath) {
         super(grpcTarget, info, onStop);
         this.libName = libName;
         this.className = className;
+        this.classpath = classpath;
+    }
+
+    /** The JAR itself, plus any additional JAR files or directories configured for the plugin */
+    private URL[] classpathURLs() throws Exception {
+        List<URL> urls = new ArrayList<>();
+        if (libName != null && !libName.isBlank()) {
+            urls.add(new File(libName).toURI().toURL());
+        }
+        for (String entry : classpath) {
+            if (!entry.isBlank()) {
+                urls.add(new File(entry).toURI().toURL());
+            }
+        }
+        return urls.toArray(new URL[0]);
     }
 
     @Override
     public synchronized void loadAndStart() throws Exception {
         LOGGER.info("loading JAR plugin {} libName={}", className, libName);
         ClassLoader classLoader = this.getClass().getClassLoader();
-        if (libName != null && !libName.isBlank()) {
-            URI fileURI = new File(libName).toURI();
-            classLoader = new URLClassLoader(new URL[]{fileURI.toURL()}, classLoader);
+        URL[] urls = classpathURLs();
+        if (urls.length > 0) {
+            // Each plugin gets its own class loader, so it can be loaded again after it is stopped
+            pluginClassLoader = new URLClassLoader(urls, classLoader);
+            classLoader = pluginClassLoader;
         }
         Class<?> clazz = classLoader.loadClass(className);
         pluginImpl = clazz.getDeclaredConstructor().newInstance();
@@ -57,10 +78,26 @@ public class PluginJAR extends Plugin {
 
     @Override
     public synchronized void stop() throws Exception {
-        if (stopInstanceMethod != null) {
-            stopInstanceMethod.invoke(pluginImpl, info.instanceId());
+        Throwable stopError = null;
+        try {
+            if (stopInstanceMethod != null) {
+                stopInstanceMethod.invoke(pluginImpl, info.instanceId());
+            }
+        } catch (Throwable t) {
+            stopError = t;
+        }
+        // Close the class loader so the JAR files are released, and a subsequent load gets a fresh one
+        if (pluginClassLoader != null) {
+            try {
+                pluginClassLoader.close();
+            } catch (IOException e) {
+                LOGGER.warn("failed to close class loader for JAR plugin pluginId={}: {}", info.instanceId(), e.getMessage());
+            }
         }
-        onStop.pluginStopped(info.instanceId(), this, null);
+        pluginClassLoader = null;
+        pluginImpl = null;
+        stopInstanceMethod = null;
+        onStop.pluginStopped(info.instanceId(), this, stopError);
     }
 
 }
@@ -141,6 +141,12 @@ import java.util.concurrent.TimeUnit;
  
      @Override
      public void onNext(PluginLoad loadInstruction) {
+         synchronized (this) {
+             if (shuttingDown) {
+                 LOGGER.info("ignoring load instruction during shutdown");
+                 return;
+             }
+         }
          if (loadInstruction.hasSysCommand()) {
              // we are processing a system command rather than an actual load
              switch (loadInstruction.getSysCommand()) {
@@ -188,6 +194,18 @@ import java.util.concurrent.TimeUnit;
  
      private synchronized void loadPlugin(PluginLoad loadInstruction, Plugin plugin) {
          LOGGER.info("Loading plugin {} type={}", plugin.info.name(), plugin.info.pluginType());
+         Plugin existing = plugins.get(loadInstruction.getPlugin().getId());
+         if (existing != null) {
+             // The controller is asking us to restart a plugin it has not heard from, so we
+             // stop the old instance before we start the new one.
+             LOGGER.info("stopping existing instance of plugin {} before reload", plugin.info.name());
+             plugins.remove(loadInstruction.getPlugin().getId(), existing);
+             try {
+                 existing.stop();
+             } catch (Throwable t) {
+                 LOGGER.error("failed to stop existing plugin instance", t);
+             }
+         }
          try {
              plugins.put(loadInstruction.getPlugin().getId(), plugin);
              plugin.loadAndStart();
@@ -213,7 +231,7 @@ import java.util.concurrent.TimeUnit;
      }
  
      private synchronized void loadJAR(PluginInfo info, PluginLoad loadInstruction) {
-         Plugin plugin = new PluginJAR(grpcTarget, info, this::pluginStopped, loadInstruction.getLibLocation(), loadInstruction.getClass_());
+         Plugin plugin = new PluginJAR(grpcTarget, info, this::pluginStopped, loadInstruction.getLibLocation(), loadInstruction.getClass_(), loadInstruction.getClasspathList());
          loadPlugin(loadInstruction, plugin);
      }
  
@@ -222,7 +240,19 @@ import java.util.concurrent.TimeUnit;
          if (t != null) {
              LOGGER.error(new FormattedMessage("exception from plugin {} {} [{}]", plugin.info.pluginType(), plugin.info.name(), instanceId), t);
          }
-         plugins.remove(instanceId, plugin);
+         boolean removed = plugins.remove(instanceId, plugin);
+         if (removed && t != null && !shuttingDown && stub != null) {
+             // Report the exit to the controller, which will ask us to load the plugin again
+             stub.loadFailed(PluginLoadFailed.newBuilder()
+                             .setPlugin(io.kaleido.paladin.toolkit.PluginInfo.newBuilder()
+                                     .setId(instanceId)
+                                     .setName(plugin.info.name())
+                                     .setPluginType(io.kaleido.paladin.toolkit.PluginInfo.PluginType.valueOf(plugin.info.pluginType()))
+                                     .build())
+                             .setErrorMessage("plugin exited: %s".formatted(t.getMessage()))
+                             .build(),
+                     new LoggingObserver<>("loadFailed"));
+         }
          this.notifyAll();
      }
  
@@ -69,4 +69,18 @@ var (
 	MsgMissingStateData            = pde("PD200029", "Missing state data for one or more states: %s")
 	MsgLockNotAllowed              = pde("PD200030", "Lock is not enabled")
 	MsgUnlockOnlyCreator           = pde("PD200031", "Only the lock creator can perform unlock: expected=%s actual=%s")
+	MsgStateWrongLock              = pde("PD200032", "State '%s' is not locked by '%s'")
+	MsgMintNotAllowed              = pde("PD200033", "Mint can only be initiated by the notary or an allowed minter: %s")
+	MsgMintSupplyExceeded          = pde("PD200034", "Mint of %s would exceed the maximum supply: maxSupply=%s minted=%s")
+	MsgMintQuotaExceeded           = pde("PD200035", "Mint of %s would exceed the quota for minter '%s': quota=%s minted=%s")
+	MsgInvalidMintRecord           = pde("PD200036", "Mint record does not match the mint: %v")
+	MsgFungibleOnly                = pde("PD200037", "Function '%s' is not supported for non-fungible tokens")
+	MsgNonFungibleOnly             = pde("PD200038", "Function '%s' is only supported for non-fungible tokens")
+	MsgTokenAlreadyMinted          = pde("PD200039", "Token ID %s has already been minted")
+	MsgTokenNotOwned               = pde("PD200040", "Token ID %s is not owned by '%s'")
+	MsgInvalidNFTStates            = pde("PD200041", "Invalid token states for '%s': %v")
+	MsgMintPolicyNonFungible       = pde("PD200042", "Mint policy supply caps and quotas are not supported for non-fungible tokens")
+	MsgTransferFeeNotPaid          = pde("PD200043", "Transfer fee of %s was not paid to the fee recipient '%s'")
+	MsgTransferFeeNonFungible      = pde("PD200044", "Transfer fees are not supported for non-fungible tokens")
+	MsgInvalidTransferFeeRate      = pde("PD200045", "Transfer fee basis points must not exceed 10000: %d")
 )
@@ -134,6 +134,19 @@ func (n *Noto) HandleEventBatch(ctx context.Context, req *prototk.HandleEventBat
 			} else {
 				log.L(ctx).Warnf("Ignoring malformed NotoLockDelegated event in batch %s: %s", req.BatchId, err)
 			}
+
+		case eventSignatures[NotoLockExpirySet]:
+			log.L(ctx).Infof("Processing '%s' event in batch %s", ev.SoliditySignature, req.BatchId)
+			var lockExpirySet NotoLockExpirySet_Event
+			if err := json.Unmarshal([]byte(ev.DataJson), &lockExpirySet); err == nil {
+				txData, err := n.decodeTransactionData(ctx, lockExpirySet.Data)
+				if err != nil {
+					return nil, err
+				}
+				n.recordTransactionInfo(ev, txData, &res)
+			} else {
+				log.L(ctx).Warnf("Ignoring malformed NotoLockExpirySet event in batch %s: %s", req.BatchId, err)
+			}
 		}
 	}
 	return &res, nil
@@ -18,6 +18,8 @@ package noto
 import (
 	"context"
 	"encoding/json"
+	"math/big"
+	"slices"
 
 	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
 	"github.com/kaleido-io/paladin/domains/noto/internal/msgs"
@@ -48,19 +50,87 @@ func (h *mintHandler) ValidateParams(ctx context.Context, config *types.NotoPars
 	return &mintParams, nil
 }
 
-func (h *mintHandler) checkAllowed(ctx context.Context, tx *types.ParsedTransaction, from string) error {
+func (h *mintHandler) mintPolicy(tx *types.ParsedTransaction) *types.NotoMintPolicy {
 	if tx.DomainConfig.NotaryMode != types.NotaryModeBasic.Enum() {
 		return nil
 	}
-	if !*tx.DomainConfig.Options.Basic.RestrictMint {
+	return tx.DomainConfig.Options.Basic.MintPolicy
+}
+
+// A mint record is only needed when there is an amount limit to enforce
+func (h *mintHandler) requiresMintRecord(tx *types.ParsedTransaction) bool {
+	policy := h.mintPolicy(tx)
+	return policy != nil && (policy.MaxSupply != nil || len(policy.MinterQuotas) > 0)
+}
+
+func (h *mintHandler) checkAllowed(ctx context.Context, tx *types.ParsedTransaction, from string) error {
+	if tx.DomainConfig.NotaryMode != types.NotaryModeBasic.Enum() {
 		return nil
 	}
 	if from == tx.DomainConfig.NotaryLookup {
 		return nil
 	}
+	if policy := h.mintPolicy(tx); policy != nil && len(policy.Minters) > 0 {
+		if slices.Contains(policy.Minters, from) {
+			return nil
+		}
+		return i18n.NewError(ctx, msgs.MsgMintNotAllowed, from)
+	}
+	if !*tx.DomainConfig.Options.Basic.RestrictMint {
+		return nil
+	}
 	return i18n.NewError(ctx, msgs.MsgMintOnlyNotary, tx.DomainConfig.NotaryLookup, from)
 }
 
+func (h *mintHandler) minterQuota(policy *types.NotoMintPolicy, from string) *pldtypes.HexUint256 {
+	for _, mq := range policy.MinterQuotas {
+		if mq.Minter == from {
+			return mq.Quota
+		}
+	}
+	return nil
+}
+
+// Check the mint record matches the mint, and that the mint stays within the supply cap and the minter's quota.
+// The totals are only complete on the notary, which receives every mint record.
+func (h *mintHandler) checkMintPolicy(ctx context.Context, tx *types.ParsedTransaction, params *types.MintParams, req *prototk.EndorseTransactionRequest, records []*prototk.EndorsableState) error {
+	policy := h.mintPolicy(tx)
+	if len(records) != 1 {
+		return i18n.NewError(ctx, msgs.MsgInvalidMintRecord, len(records))
+	}
+	record, err := h.noto.unmarshalMintRecord(records[0].StateDataJson)
+	if err != nil {
+		return i18n.NewError(ctx, msgs.MsgInvalidStateData, records[0].Id, err)
+	}
+	fromAddress, err := h.noto.findEthAddressVerifier(ctx, "from", tx.Transaction.From, req.ResolvedVerifiers)
+	if err != nil {
+		return err
+	}
+	if !record.Minter.Equals(fromAddress) || record.Amount == nil || record.Amount.Int().Cmp(params.Amount.Int()) != 0 {
+		return i18n.NewError(ctx, msgs.MsgInvalidMintRecord, records[0].StateDataJson)
+	}
+
+	if policy.MaxSupply != nil {
+		minted, err := h.noto.sumMintRecords(ctx, req.StateQueryContext, nil, records[0].Id)
+		if err != nil {
+			return err
+		}
+		if new(big.Int).Add(minted, params.Amount.Int()).Cmp(policy.MaxSupply.Int()) > 0 {
+			return i18n.NewError(ctx, msgs.MsgMintSupplyExceeded, params.Amount.Int().Text(10), policy.MaxSupply.Int().Text(10), minted.Text(10))
+		}
+	}
+	if quota := h.minterQuota(policy, tx.Transaction.From); quota != nil {
+		minted, err := h.noto.sumMintRecords(ctx, req.StateQueryContext, fromAddress, records[0].Id)
+		if err != nil {
+			return err
+		}
+		if new(big.Int).Add(minted, params.Amount.Int()).Cmp(quota.Int()) > 0 {
+			return i18n.NewError(ctx, msgs.MsgMintQuotaExceeded, params.Amount.Int().Text(10), tx.Transaction.From, quota.Int().Text(10), minted.Text(10))
+		}
+	}
+	return nil
+}
+
 func (h *mintHandler) Init(ctx context.Context, tx *types.ParsedTransaction, req *prototk.InitTransactionRequest) (*prototk.InitTransactionResponse, error) {
 	params := tx.Params.(*types.MintParams)
 	notary := tx.DomainConfig.NotaryLookup
@@ -91,6 +161,18 @@ func (h *mintHandler) Assemble(ctx context.Context, tx *types.ParsedTransaction,
 		return nil, err
 	}
 
+	if h.requiresMintRecord(tx) {
+		fromAddress, err := h.noto.findEthAddressVerifier(ctx, "from", tx.Transaction.From, req.ResolvedVerifiers)
+		if err != nil {
+			return nil, err
+		}
+		recordState, err := h.noto.prepareMintRecord(fromAddress, params.Amount, []string{notary, tx.Transaction.From})
+		if err != nil {
+			return nil, err
+		}
+		outputStates.states = append(outputStates.states, recordState)
+	}
+
 	encodedTransfer, err := h.noto.encodeTransferUnmasked(ctx, tx.ContractAddress, nil, outputStates.coins)
 	if err != nil {
 		return nil, err
@@ -135,7 +217,19 @@ func (h *mintHandler) Endorse(ctx context.Context, tx *types.ParsedTransaction,
 	if err != nil {
 		return nil, err
 	}
-	outputs, err := h.noto.parseCoinList(ctx, "output", req.Outputs)
+	coinOutputs := req.Outputs
+	var records []*prototk.EndorsableState
+	if h.requiresMintRecord(tx) {
+		coinOutputs = nil
+		for _, state := range req.Outputs {
+			if state.SchemaId == h.noto.mintRecordSchema.Id {
+				records = append(records, state)
+			} else {
+				coinOutputs = append(coinOutputs, state)
+			}
+		}
+	}
+	outputs, err := h.noto.parseCoinList(ctx, "output", coinOutputs)
 	if err != nil {
 		return nil, err
 	}
@@ -144,6 +238,11 @@ func (h *mintHandler) Endorse(ctx context.Context, tx *types.ParsedTransaction,
 	if err := h.noto.validateMintAmounts(ctx, params, inputs, outputs); err != nil {
 		return nil, err
 	}
+	if h.requiresMintRecord(tx) {
+		if err := h.checkMintPolicy(ctx, tx, params, req, records); err != nil {
+			return nil, err
+		}
+	}
 
 	// Notary checks the signature from the sender, then submits the transaction
 	encodedTransfer, err := h.noto.encodeTransferUnmasked(ctx, tx.ContractAddress, nil, outputs.coins)
@@ -0,0 +1,186 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package noto
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/domains/noto/internal/msgs"
+	"github.com/kaleido-io/paladin/domains/noto/pkg/types"
+	"github.com/kaleido-io/paladin/toolkit/pkg/algorithms"
+	"github.com/kaleido-io/paladin/toolkit/pkg/domain"
+	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
+	"github.com/kaleido-io/paladin/toolkit/pkg/signpayloads"
+	"github.com/kaleido-io/paladin/toolkit/pkg/verifiers"
+)
+
+type mintNFTHandler struct {
+	noto *Noto
+}
+
+func (h *mintNFTHandler) ValidateParams(ctx context.Context, config *types.NotoParsedConfig, params string) (interface{}, error) {
+	var mintParams types.MintNFTParams
+	if err := json.Unmarshal([]byte(params), &mintParams); err != nil {
+		return nil, err
+	}
+	if mintParams.To == "" {
+		return nil, i18n.NewError(ctx, msgs.MsgParameterRequired, "to")
+	}
+	if mintParams.TokenID == nil {
+		return nil, i18n.NewError(ctx, msgs.MsgParameterRequired, "tokenId")
+	}
+	return &mintParams, nil
+}
+
+// Minting a token follows the same rules on who can mint as fungible tokens
+func (h *mintNFTHandler) checkAllowed(ctx context.Context, tx *types.ParsedTransaction, from string) error {
+	return (&mintHandler{noto: h.noto}).checkAllowed(ctx, tx, from)
+}
+
+func (h *mintNFTHandler) Init(ctx context.Context, tx *types.ParsedTransaction, req *prototk.InitTransactionRequest) (*prototk.InitTransactionResponse, error) {
+	params := tx.Params.(*types.MintNFTParams)
+	notary := tx.DomainConfig.NotaryLookup
+	if err := h.checkAllowed(ctx, tx, req.Transaction.From); err != nil {
+		return nil, err
+	}
+
+	return &prototk.InitTransactionResponse{
+		RequiredVerifiers: h.noto.ethAddressVerifiers(notary, tx.Transaction.From, params.To),
+	}, nil
+}
+
+func (h *mintNFTHandler) Assemble(ctx context.Context, tx *types.ParsedTransaction, req *prototk.AssembleTransactionRequest) (*prototk.AssembleTransactionResponse, error) {
+	params := tx.Params.(*types.MintNFTParams)
+	notary := tx.DomainConfig.NotaryLookup
+
+	toAddress, err := h.noto.findEthAddressVerifier(ctx, "to", params.To, req.ResolvedVerifiers)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, _, err := h.noto.findNFT(ctx, req.StateQueryContext, params.TokenID, nil)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		message := i18n.NewError(ctx, msgs.MsgTokenAlreadyMinted, params.TokenID.Int().Text(10)).Error()
+		return &prototk.AssembleTransactionResponse{
+			AssemblyResult: prototk.AssembleTransactionResponse_REVERT,
+			RevertReason:   &message,
+		}, nil
+	}
+
+	nft, outputState, err := h.noto.prepareNFTOutput(params.TokenID, toAddress, params.URI, []string{notary, params.To})
+	if err != nil {
+		return nil, err
+	}
+	infoStates, err := h.noto.prepareInfo(params.Data, []string{notary, params.To})
+	if err != nil {
+		return nil, err
+	}
+
+	encodedMint, err := h.noto.encodeNFTTransfer(ctx, tx.ContractAddress, nil, []*types.NotoNFT{nft})
+	if err != nil {
+		return nil, err
+	}
+
+	return &prototk.AssembleTransactionResponse{
+		AssemblyResult: prototk.AssembleTransactionResponse_OK,
+		AssembledTransaction: &prototk.AssembledTransaction{
+			OutputStates: []*prototk.NewState{outputState},
+			InfoStates:   infoStates,
+		},
+		AttestationPlan: []*prototk.AttestationRequest{
+			// Sender confirms the initial request with a signature
+			{
+				Name:            "sender",
+				AttestationType: prototk.AttestationType_SIGN,
+				Algorithm:       algorithms.ECDSA_SECP256K1,
+				VerifierType:    verifiers.ETH_ADDRESS,
+				Payload:         encodedMint,
+				PayloadType:     signpayloads.OPAQUE_TO_RSV,
+				Parties:         []string{req.Transaction.From},
+			},
+			// Notary will endorse the assembled transaction (by submitting to the ledger)
+			{
+				Name:            "notary",
+				AttestationType: prototk.AttestationType_ENDORSE,
+				Algorithm:       algorithms.ECDSA_SECP256K1,
+				VerifierType:    verifiers.ETH_ADDRESS,
+				Parties:         []string{notary},
+			},
+		},
+	}, nil
+}
+
+func (h *mintNFTHandler) Endorse(ctx context.Context, tx *types.ParsedTransaction, req *prototk.EndorseTransactionRequest) (*prototk.EndorseTransactionResponse, error) {
+	params := tx.Params.(*types.MintNFTParams)
+	if err := h.checkAllowed(ctx, tx, req.Transaction.From); err != nil {
+		return nil, err
+	}
+
+	if len(req.Inputs) > 0 {
+		return nil, i18n.NewError(ctx, msgs.MsgInvalidInputs, "mintNFT", endorsableStateIDs(req.Inputs))
+	}
+	outputs, err := h.noto.parseNFTList(ctx, "output", req.Outputs)
+	if err != nil {
+		return nil, err
+	}
+	toAddress, err := h.noto.findEthAddressVerifier(ctx, "to", params.To, req.ResolvedVerifiers)
+	if err != nil {
+		return nil, err
+	}
+	if err := h.noto.validateNFTOutput(ctx, "mintNFT", outputs, params.TokenID, toAddress, params.URI); err != nil {
+		return nil, err
+	}
+
+	// The token ID must not already exist (the notary receives every token state)
+	existing, _, err := h.noto.findNFT(ctx, req.StateQueryContext, params.TokenID, nil, req.Outputs[0].Id)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, i18n.NewError(ctx, msgs.MsgTokenAlreadyMinted, params.TokenID.Int().Text(10))
+	}
+
+	// Notary checks the signature from the sender, then submits the transaction
+	encodedMint, err := h.noto.encodeNFTTransfer(ctx, tx.ContractAddress, nil, outputs)
+	if err != nil {
+		return nil, err
+	}
+	if err := h.noto.validateSignature(ctx, "sender", req.Signatures, encodedMint); err != nil {
+		return nil, err
+	}
+	return &prototk.EndorseTransactionResponse{
+		EndorsementResult: prototk.EndorseTransactionResponse_ENDORSER_SUBMIT,
+	}, nil
+}
+
+func (h *mintNFTHandler) Prepare(ctx context.Context, tx *types.ParsedTransaction, req *prototk.PrepareTransactionRequest) (*prototk.PrepareTransactionResponse, error) {
+	endorsement := domain.FindAttestation("notary", req.AttestationResult)
+	if endorsement == nil || endorsement.Verifier.Lookup != tx.DomainConfig.NotaryLookup {
+		return nil, i18n.NewError(ctx, msgs.MsgAttestationNotFound, "notary")
+	}
+
+	// The token is created on the base ledger with a regular mint
+	baseTransaction, err := (&mintHandler{noto: h.noto}).baseLedgerInvoke(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return baseTransaction.prepare(nil)
+}
@@ -0,0 +1,289 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package noto
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/hyperledger/firefly-signer/pkg/secp256k1"
+	"github.com/kaleido-io/paladin/domains/noto/pkg/types"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/toolkit/pkg/algorithms"
+	"github.com/kaleido-io/paladin/toolkit/pkg/domain"
+	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
+	"github.com/kaleido-io/paladin/toolkit/pkg/verifiers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var notoNFTConfig = &types.NotoParsedConfig{
+	NotaryMode:   types.NotaryModeBasic.Enum(),
+	NotaryLookup: "notary@node1",
+	Options: types.NotoOptions{
+		Basic: &types.NotoBasicOptions{
+			RestrictMint: &pTrue,
+			AllowBurn:    &pTrue,
+			AllowLock:    &pTrue,
+			NonFungible:  &pTrue,
+		},
+	},
+}
+
+func TestMintNFT(t *testing.T) {
+	var existing []*prototk.StoredState
+	n := &Noto{
+		Callbacks: &domain.MockDomainCallbacks{
+			MockFindAvailableStates: func() (*prototk.FindAvailableStatesResponse, error) {
+				return &prototk.FindAvailableStatesResponse{States: existing}, nil
+			},
+		},
+		dataSchema: &prototk.StateSchema{Id: "data"},
+		nftSchema:  &prototk.StateSchema{Id: "nft"},
+	}
+	ctx := context.Background()
+	fn := types.NotoABI.Functions()["mintNFT"]
+
+	receiverAddress := "0x2000000000000000000000000000000000000000"
+	notaryKey, err := secp256k1.GenerateSecp256k1KeyPair()
+	require.NoError(t, err)
+
+	contractAddress := "0xf6a75f065db3cef95de7aa786eee1d0cb1aeafc3"
+	tx := &prototk.TransactionSpecification{
+		TransactionId: "0x015e1881f2ba769c22d05c841f06949ec6e1bd573f5e1e0328885494212f077d",
+		From:          "notary@node1",
+		ContractInfo: &prototk.ContractInfo{
+			ContractAddress:    contractAddress,
+			ContractConfigJson: mustParseJSON(notoNFTConfig),
+		},
+		FunctionAbiJson:   mustParseJSON(fn),
+		FunctionSignature: fn.SolString(),
+		FunctionParamsJson: `{
+			"to": "receiver@node2",
+			"tokenId": 42,
+			"uri": "https://example.com/tokens/42.json",
+			"data": "0x1234"
+		}`,
+	}
+
+	initRes, err := n.InitTransaction(ctx, &prototk.InitTransactionRequest{
+		Transaction: tx,
+	})
+	require.NoError(t, err)
+	require.Len(t, initRes.RequiredVerifiers, 2)
+
+	verifiers := []*prototk.ResolvedVerifier{
+		{
+			Lookup:       "notary@node1",
+			Algorithm:    algorithms.ECDSA_SECP256K1,
+			VerifierType: verifiers.ETH_ADDRESS,
+			Verifier:     notaryKey.Address.String(),
+		},
+		{
+			Lookup:       "receiver@node2",
+			Algorithm:    algorithms.ECDSA_SECP256K1,
+			VerifierType: verifiers.ETH_ADDRESS,
+			Verifier:     receiverAddress,
+		},
+	}
+
+	assembleRes, err := n.AssembleTransaction(ctx, &prototk.AssembleTransactionRequest{
+		Transaction:       tx,
+		ResolvedVerifiers: verifiers,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, prototk.AssembleTransactionResponse_OK, assembleRes.AssemblyResult)
+	require.Len(t, assembleRes.AssembledTransaction.InputStates, 0)
+	require.Len(t, assembleRes.AssembledTransaction.OutputStates, 1)
+	require.Len(t, assembleRes.AssembledTransaction.InfoStates, 1)
+	assert.Equal(t, "nft", assembleRes.AssembledTransaction.OutputStates[0].SchemaId)
+	assert.Equal(t, []string{"notary@node1", "receiver@node2"}, assembleRes.AssembledTransaction.OutputStates[0].DistributionList)
+
+	outputNFT, err := n.unmarshalNFT(assembleRes.AssembledTransaction.OutputStates[0].StateDataJson)
+	require.NoError(t, err)
+	assert.Equal(t, receiverAddress, outputNFT.Owner.String())
+	assert.Equal(t, "42", outputNFT.TokenID.Int().String())
+	assert.Equal(t, "https://example.com/tokens/42.json", outputNFT.URI)
+
+	encodedMint, err := n.encodeNFTTransfer(ctx, ethtypes.MustNewAddress(contractAddress), []*types.NotoNFT{}, []*types.NotoNFT{outputNFT})
+	require.NoError(t, err)
+	signature, err := notaryKey.SignDirect(encodedMint)
+	require.NoError(t, err)
+	signatureBytes := pldtypes.HexBytes(signature.CompactRSV())
+
+	outputStates := []*prototk.EndorsableState{
+		{
+			SchemaId:      "nft",
+			Id:            "0x26b394af655bdc794a6d7cd7f8004eec20bffb374e4ddd24cdaefe554878d945",
+			StateDataJson: assembleRes.AssembledTransaction.OutputStates[0].StateDataJson,
+		},
+	}
+	infoStates := []*prototk.EndorsableState{
+		{
+			SchemaId:      "data",
+			Id:            "0x4cc7840e186de23c4127b4853c878708d2642f1942959692885e098f1944547d",
+			StateDataJson: assembleRes.AssembledTransaction.InfoStates[0].StateDataJson,
+		},
+	}
+	signatures := []*prototk.AttestationResult{
+		{
+			Name:     "sender",
+			Verifier: &prototk.ResolvedVerifier{Verifier: notaryKey.Address.String()},
+			Payload:  signatureBytes,
+		},
+	}
+
+	// The output of the transaction itself is visible when endorsing, and is not a duplicate
+	existing = []*prototk.StoredState{{Id: outputStates[0].Id, SchemaId: "nft", DataJson: outputStates[0].StateDataJson}}
+	endorseRes, err := n.EndorseTransaction(ctx, &prototk.EndorseTransactionRequest{
+		Transaction:        tx,
+		ResolvedVerifiers:  verifiers,
+		Outputs:            outputStates,
+		Info:               infoStates,
+		EndorsementRequest: &prototk.AttestationRequest{Name: "notary"},
+		Signatures:         signatures,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, prototk.EndorseTransactionResponse_ENDORSER_SUBMIT, endorseRes.EndorsementResult)
+
+	prepareRes, err := n.PrepareTransaction(ctx, &prototk.PrepareTransactionRequest{
+		Transaction:       tx,
+		ResolvedVerifiers: verifiers,
+		OutputStates:      outputStates,
+		InfoStates:        infoStates,
+		AttestationResult: append(signatures, &prototk.AttestationResult{
+			Name:     "notary",
+			Verifier: &prototk.ResolvedVerifier{Lookup: "notary@node1"},
+		}),
+	})
+	require.NoError(t, err)
+	expectedFunction := mustParseJSON(interfaceBuild.ABI.Functions()["mint"])
+	assert.JSONEq(t, expectedFunction, prepareRes.Transaction.FunctionAbiJson)
+	assert.JSONEq(t, fmt.Sprintf(`{
+		"outputs": ["0x26b394af655bdc794a6d7cd7f8004eec20bffb374e4ddd24cdaefe554878d945"],
+		"signature": "%s",
+		"data": "0x00010000015e1881f2ba769c22d05c841f06949ec6e1bd573f5e1e0328885494212f077d000000000000000000000000000000000000000000000000000000000000004000000000000000000000000000000000000000000000000000000000000000014cc7840e186de23c4127b4853c878708d2642f1942959692885e098f1944547d"
+	}`, signatureBytes), prepareRes.Transaction.ParamsJson)
+
+	// A token ID that already exists cannot be minted again
+	existing = []*prototk.StoredState{{Id: pldtypes.RandBytes32().String(), SchemaId: "nft", DataJson: outputStates[0].StateDataJson}}
+	assembleRes, err = n.AssembleTransaction(ctx, &prototk.AssembleTransactionRequest{
+		Transaction:       tx,
+		ResolvedVerifiers: verifiers,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, prototk.AssembleTransactionResponse_REVERT, assembleRes.AssemblyResult)
+	assert.Regexp(t, "PD200039.*42", *assembleRes.RevertReason)
+
+	_, err = n.EndorseTransaction(ctx, &prototk.EndorseTransactionRequest{
+		Transaction:        tx,
+		ResolvedVerifiers:  verifiers,
+		Outputs:            outputStates,
+		Info:               infoStates,
+		EndorsementRequest: &prototk.AttestationRequest{Name: "notary"},
+		Signatures:         signatures,
+	})
+	assert.Regexp(t, "PD200039.*42", err)
+}
+
+func TestMintNFTBadOutputs(t *testing.T) {
+	n := &Noto{
+		Callbacks:  mockCallbacks,
+		coinSchema: &prototk.StateSchema{Id: "coin"},
+		nftSchema:  &prototk.StateSchema{Id: "nft"},
+	}
+	ctx := context.Background()
+	fn := types.NotoABI.Functions()["mintNFT"]
+
+	receiverAddress := "0x2000000000000000000000000000000000000000"
+	tx := &prototk.TransactionSpecification{
+		TransactionId: "0x015e1881f2ba769c22d05c841f06949ec6e1bd573f5e1e0328885494212f077d",
+		From:          "notary@node1",
+		ContractInfo: &prototk.ContractInfo{
+			ContractAddress:    "0xf6a75f065db3cef95de7aa786eee1d0cb1aeafc3",
+			ContractConfigJson: mustParseJSON(notoNFTConfig),
+		},
+		FunctionAbiJson:    mustParseJSON(fn),
+		FunctionSignature:  fn.SolString(),
+		FunctionParamsJson: `{"to": "receiver@node2", "tokenId": 42, "uri": "uri1"}`,
+	}
+	verifiers := []*prototk.ResolvedVerifier{
+		{
+			Lookup:       "receiver@node2",
+			Algorithm:    algorithms.ECDSA_SECP256K1,
+			VerifierType: verifiers.ETH_ADDRESS,
+			Verifier:     receiverAddress,
+		},
+	}
+
+	endorse := func(outputs ...*prototk.EndorsableState) error {
+		_, err := n.EndorseTransaction(ctx, &prototk.EndorseTransactionRequest{
+			Transaction:        tx,
+			ResolvedVerifiers:  verifiers,
+			Outputs:            outputs,
+			EndorsementRequest: &prototk.AttestationRequest{Name: "notary"},
+		})
+		return err
+	}
+	nftState := func(nft *types.NotoNFT) *prototk.EndorsableState {
+		return &prototk.EndorsableState{SchemaId: "nft", Id: pldtypes.RandBytes32().String(), StateDataJson: mustParseJSON(nft)}
+	}
+
+	err := endorse(&prototk.EndorsableState{SchemaId: "coin", Id: "0x01", StateDataJson: "{}"})
+	assert.Regexp(t, "PD200003", err)
+
+	err = endorse(nftState(&types.NotoNFT{TokenID: pldtypes.Uint64ToUint256(43), Owner: pldtypes.MustEthAddress(receiverAddress), URI: "uri1"}))
+	assert.Regexp(t, "PD200041", err)
+
+	err = endorse(nftState(&types.NotoNFT{TokenID: pldtypes.Uint64ToUint256(42), Owner: pldtypes.MustEthAddress(receiverAddress), URI: "uri2"}))
+	assert.Regexp(t, "PD200041", err)
+
+	err = endorse(
+		nftState(&types.NotoNFT{TokenID: pldtypes.Uint64ToUint256(42), Owner: pldtypes.MustEthAddress(receiverAddress), URI: "uri1"}),
+		nftState(&types.NotoNFT{TokenID: pldtypes.Uint64ToUint256(42), Owner: pldtypes.MustEthAddress(receiverAddress), URI: "uri1"}),
+	)
+	assert.Regexp(t, "PD200041", err)
+}
+
+func TestNFTFunctionsCheckTokenType(t *testing.T) {
+	n := &Noto{Callbacks: mockCallbacks}
+	ctx := context.Background()
+
+	newTX := func(fnName string, config *types.NotoParsedConfig) *prototk.TransactionSpecification {
+		fn := types.NotoABI.Functions()[fnName]
+		return &prototk.TransactionSpecification{
+			From: "notary@node1",
+			ContractInfo: &prototk.ContractInfo{
+				ContractAddress:    "0xf6a75f065db3cef95de7aa786eee1d0cb1aeafc3",
+				ContractConfigJson: mustParseJSON(config),
+			},
+			FunctionAbiJson:    mustParseJSON(fn),
+			FunctionSignature:  fn.SolString(),
+			FunctionParamsJson: `{"to": "receiver@node2", "amount": 1, "tokenId": 1}`,
+		}
+	}
+
+	_, err := n.InitTransaction(ctx, &prototk.InitTransactionRequest{Transaction: newTX("mintNFT", notoBasicConfig)})
+	assert.Regexp(t, "PD200038.*mintNFT", err)
+
+	_, err = n.InitTransaction(ctx, &prototk.InitTransactionRequest{Transaction: newTX("transfer", notoNFTConfig)})
+	assert.Regexp(t, "PD200037.*transfer", err)
+
+	_, err = n.InitTransaction(ctx, &prototk.InitTransactionRequest{Transaction: newTX("mintNFT", notoNFTConfig)})
+	assert.NoError(t, err)
+}
@@ -27,6 +27,7 @@ import (
 	"github.com/kaleido-io/paladin/domains/noto/pkg/types"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
 	"github.com/kaleido-io/paladin/toolkit/pkg/algorithms"
+	"github.com/kaleido-io/paladin/toolkit/pkg/domain"
 	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
 	"github.com/kaleido-io/paladin/toolkit/pkg/verifiers"
 	"github.com/stretchr/testify/assert"
@@ -226,3 +227,193 @@ func TestMint(t *testing.T) {
 		}
 	}`, notaryKey.Address, contractAddress, pldtypes.HexBytes(encodedCall)), prepareRes.Transaction.ParamsJson)
 }
+
+func TestMintPolicy(t *testing.T) {
+	var pages [][]*prototk.StoredState
+	n := &Noto{
+		Callbacks: &domain.MockDomainCallbacks{
+			MockFindAvailableStates: func() (*prototk.FindAvailableStatesResponse, error) {
+				var states []*prototk.StoredState
+				if len(pages) > 0 {
+					states, pages = pages[0], pages[1:]
+				}
+				return &prototk.FindAvailableStatesResponse{States: states}, nil
+			},
+		},
+		coinSchema:       &prototk.StateSchema{Id: "coin"},
+		dataSchema:       &prototk.StateSchema{Id: "data"},
+		mintRecordSchema: &prototk.StateSchema{Id: "mintRecord"},
+	}
+	ctx := context.Background()
+	fn := types.NotoABI.Functions()["mint"]
+
+	receiverAddress := "0x2000000000000000000000000000000000000000"
+	notaryAddress := "0x1000000000000000000000000000000000000000"
+	minterKey, err := secp256k1.GenerateSecp256k1KeyPair()
+	require.NoError(t, err)
+
+	contractAddress := "0xf6a75f065db3cef95de7aa786eee1d0cb1aeafc3"
+	tx := &prototk.TransactionSpecification{
+		TransactionId: "0x015e1881f2ba769c22d05c841f06949ec6e1bd573f5e1e0328885494212f077d",
+		From:          "minter@node1",
+		ContractInfo: &prototk.ContractInfo{
+			ContractAddress: contractAddress,
+			ContractConfigJson: mustParseJSON(&types.NotoParsedConfig{
+				NotaryMode:   types.NotaryModeBasic.Enum(),
+				NotaryLookup: "notary@node1",
+				Options: types.NotoOptions{
+					Basic: &types.NotoBasicOptions{
+						RestrictMint: &pTrue,
+						AllowBurn:    &pTrue,
+						AllowLock:    &pTrue,
+						MintPolicy: &types.NotoMintPolicy{
+							MaxSupply: pldtypes.Uint64ToUint256(150),
+							Minters:   []string{"minter@node1"},
+							MinterQuotas: []*types.NotoMinterQuota{
+								{Minter: "minter@node1", Quota: pldtypes.Uint64ToUint256(100)},
+							},
+						},
+					},
+				},
+			}),
+		},
+		FunctionAbiJson:   mustParseJSON(fn),
+		FunctionSignature: fn.SolString(),
+		FunctionParamsJson: `{
+			"to": "receiver@node2",
+			"amount": 100,
+			"data": "0x1234"
+		}`,
+	}
+
+	initRes, err := n.InitTransaction(ctx, &prototk.InitTransactionRequest{
+		Transaction: tx,
+	})
+	require.NoError(t, err)
+	require.Len(t, initRes.RequiredVerifiers, 3)
+	assert.Equal(t, "minter@node1", initRes.RequiredVerifiers[1].Lookup)
+
+	verifiers := []*prototk.ResolvedVerifier{
+		{
+			Lookup:       "notary@node1",
+			Algorithm:    algorithms.ECDSA_SECP256K1,
+			VerifierType: verifiers.ETH_ADDRESS,
+			Verifier:     notaryAddress,
+		},
+		{
+			Lookup:       "minter@node1",
+			Algorithm:    algorithms.ECDSA_SECP256K1,
+			VerifierType: verifiers.ETH_ADDRESS,
+			Verifier:     minterKey.Address.String(),
+		},
+		{
+			Lookup:       "receiver@node2",
+			Algorithm:    algorithms.ECDSA_SECP256K1,
+			VerifierType: verifiers.ETH_ADDRESS,
+			Verifier:     receiverAddress,
+		},
+	}
+
+	assembleRes, err := n.AssembleTransaction(ctx, &prototk.AssembleTransactionRequest{
+		Transaction:       tx,
+		ResolvedVerifiers: verifiers,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, prototk.AssembleTransactionResponse_OK, assembleRes.AssemblyResult)
+	require.Len(t, assembleRes.AssembledTransaction.OutputStates, 2)
+
+	recordState := assembleRes.AssembledTransaction.OutputStates[1]
+	assert.Equal(t, "mintRecord", recordState.SchemaId)
+	assert.Equal(t, []string{"notary@node1", "minter@node1"}, recordState.DistributionList)
+	record, err := n.unmarshalMintRecord(recordState.StateDataJson)
+	require.NoError(t, err)
+	assert.Equal(t, minterKey.Address.String(), record.Minter.String())
+	assert.Equal(t, "100", record.Amount.Int().String())
+
+	outputCoin, err := n.unmarshalCoin(assembleRes.AssembledTransaction.OutputStates[0].StateDataJson)
+	require.NoError(t, err)
+	encodedMint, err := n.encodeTransferUnmasked(ctx, ethtypes.MustNewAddress(contractAddress), []*types.NotoCoin{}, []*types.NotoCoin{outputCoin})
+	require.NoError(t, err)
+	signature, err := minterKey.SignDirect(encodedMint)
+	require.NoError(t, err)
+
+	coinOutput := &prototk.EndorsableState{
+		SchemaId:      "coin",
+		Id:            "0x26b394af655bdc794a6d7cd7f8004eec20bffb374e4ddd24cdaefe554878d945",
+		StateDataJson: assembleRes.AssembledTransaction.OutputStates[0].StateDataJson,
+	}
+	recordOutput := &prototk.EndorsableState{
+		SchemaId:      "mintRecord",
+		Id:            "0x9c4b8d6bd2fcf4b2fd6b1bdbb1ef1b3b27aa2c2da91c12bd39fa4cbd0b4b1e07",
+		StateDataJson: recordState.StateDataJson,
+	}
+	endorse := func(outputs ...*prototk.EndorsableState) (*prototk.EndorseTransactionResponse, error) {
+		return n.EndorseTransaction(ctx, &prototk.EndorseTransactionRequest{
+			Transaction:       tx,
+			ResolvedVerifiers: verifiers,
+			Outputs:           outputs,
+			EndorsementRequest: &prototk.AttestationRequest{
+				Name: "notary",
+			},
+			Signatures: []*prototk.AttestationResult{
+				{
+					Name:     "sender",
+					Verifier: &prototk.ResolvedVerifier{Verifier: minterKey.Address.String()},
+					Payload:  signature.CompactRSV(),
+				},
+			},
+		})
+	}
+	existingRecord := func(id, minter string, amount uint64) *prototk.StoredState {
+		return &prototk.StoredState{
+			Id:        id,
+			CreatedAt: 1000,
+			DataJson:  fmt.Sprintf(`{"salt":"%s","minter":"%s","amount":"%d"}`, pldtypes.RandBytes32(), minter, amount),
+		}
+	}
+
+	// Within both the supply cap and the quota, with the record itself not counted twice
+	pages = [][]*prototk.StoredState{
+		{existingRecord("0x01", notaryAddress, 50), existingRecord(recordOutput.Id, minterKey.Address.String(), 100)},
+		{},
+		{existingRecord(recordOutput.Id, minterKey.Address.String(), 100)},
+		{},
+	}
+	endorseRes, err := endorse(coinOutput, recordOutput)
+	require.NoError(t, err)
+	assert.Equal(t, prototk.EndorseTransactionResponse_ENDORSER_SUBMIT, endorseRes.EndorsementResult)
+
+	// Supply cap exceeded
+	pages = [][]*prototk.StoredState{
+		{existingRecord("0x01", notaryAddress, 51)},
+		{},
+	}
+	_, err = endorse(coinOutput, recordOutput)
+	assert.Regexp(t, "PD200034.*maxSupply=150 minted=51", err)
+
+	// Quota exceeded
+	pages = [][]*prototk.StoredState{
+		{},
+		{existingRecord("0x02", minterKey.Address.String(), 1)},
+		{},
+	}
+	_, err = endorse(coinOutput, recordOutput)
+	assert.Regexp(t, "PD200035.*minter@node1.*quota=100 minted=1", err)
+
+	// Missing or mismatched mint record
+	_, err = endorse(coinOutput)
+	assert.Regexp(t, "PD200036", err)
+	_, err = endorse(coinOutput, &prototk.EndorsableState{
+		SchemaId:      "mintRecord",
+		Id:            recordOutput.Id,
+		StateDataJson: fmt.Sprintf(`{"salt":"%s","minter":"%s","amount":"99"}`, pldtypes.RandBytes32(), minterKey.Address),
+	})
+	assert.Regexp(t, "PD200036", err)
+
+	// Minters not in the list are rejected, even though restrictMint would otherwise allow them
+	tx.From = "other@node1"
+	_, err = n.InitTransaction(ctx, &prototk.InitTransactionRequest{
+		Transaction: tx,
+	})
+	assert.Regexp(t, "PD200033.*other@node1", err)
+}
@@ -0,0 +1,322 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package noto
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/domains/noto/internal/msgs"
+	"github.com/kaleido-io/paladin/domains/noto/pkg/types"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/toolkit/pkg/algorithms"
+	"github.com/kaleido-io/paladin/toolkit/pkg/domain"
+	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
+	"github.com/kaleido-io/paladin/toolkit/pkg/signpayloads"
+	"github.com/kaleido-io/paladin/toolkit/pkg/verifiers"
+)
+
+// Sets an expiry on a prepared unlock. Once the expiry has passed, the prepared unlock can no
+// longer be used, and instead the beneficiary may trigger a release of all the locked states
+// to itself. The release outputs are assembled here, so the release hash is fixed on-chain.
+type setLockExpiryHandler struct {
+	noto *Noto
+}
+
+func (h *setLockExpiryHandler) ValidateParams(ctx context.Context, config *types.NotoParsedConfig, params string) (interface{}, error) {
+	var expiryParams types.SetLockExpiryParams
+	if err := json.Unmarshal([]byte(params), &expiryParams); err != nil {
+		return nil, err
+	}
+	if expiryParams.LockID.IsZero() {
+		return nil, i18n.NewError(ctx, msgs.MsgParameterRequired, "lockId")
+	}
+	if expiryParams.Unlock == nil || len(expiryParams.Unlock.LockedInputs) == 0 {
+		return nil, i18n.NewError(ctx, msgs.MsgParameterRequired, "unlock")
+	}
+	if expiryParams.Expiry == 0 {
+		return nil, i18n.NewError(ctx, msgs.MsgParameterGreaterThanZero, "expiry")
+	}
+	if expiryParams.Beneficiary == "" {
+		return nil, i18n.NewError(ctx, msgs.MsgParameterRequired, "beneficiary")
+	}
+	return &expiryParams, nil
+}
+
+func (h *setLockExpiryHandler) Init(ctx context.Context, tx *types.ParsedTransaction, req *prototk.InitTransactionRequest) (*prototk.InitTransactionResponse, error) {
+	params := tx.Params.(*types.SetLockExpiryParams)
+	notary := tx.DomainConfig.NotaryLookup
+	return &prototk.InitTransactionResponse{
+		RequiredVerifiers: h.noto.ethAddressVerifiers(notary, tx.Transaction.From, params.Beneficiary),
+	}, nil
+}
+
+func (h *setLockExpiryHandler) Assemble(ctx context.Context, tx *types.ParsedTransaction, req *prototk.AssembleTransactionRequest) (*prototk.AssembleTransactionResponse, error) {
+	params := tx.Params.(*types.SetLockExpiryParams)
+	notary := tx.DomainConfig.NotaryLookup
+
+	fromAddress, err := h.noto.findEthAddressVerifier(ctx, "from", tx.Transaction.From, req.ResolvedVerifiers)
+	if err != nil {
+		return nil, err
+	}
+	beneficiaryAddress, err := h.noto.findEthAddressVerifier(ctx, "beneficiary", params.Beneficiary, req.ResolvedVerifiers)
+	if err != nil {
+		return nil, err
+	}
+
+	// The release spends exactly the locked states of the prepared unlock, which the requester must own
+	lockedInputs, err := h.noto.getStates(ctx, req.StateQueryContext, h.noto.lockedCoinSchema.Id, params.Unlock.LockedInputs)
+	if err != nil {
+		return nil, err
+	}
+	if len(lockedInputs) != len(params.Unlock.LockedInputs) {
+		return nil, i18n.NewError(ctx, msgs.MsgMissingStateData, params.Unlock.LockedInputs)
+	}
+	lockedCoins := make([]*types.NotoLockedCoin, len(lockedInputs))
+	lockedStates := make([]*prototk.StateRef, len(lockedInputs))
+	total := big.NewInt(0)
+	for i, state := range lockedInputs {
+		coin, err := h.noto.unmarshalLockedCoin(state.DataJson)
+		if err != nil {
+			return nil, i18n.NewError(ctx, msgs.MsgInvalidStateData, state.Id, err)
+		}
+		var revertErr error
+		if coin.LockID != params.LockID {
+			revertErr = i18n.NewError(ctx, msgs.MsgStateWrongLock, state.Id, params.LockID)
+		} else if !coin.Owner.Equals(fromAddress) {
+			revertErr = i18n.NewError(ctx, msgs.MsgStateWrongOwner, state.Id, tx.Transaction.From)
+		}
+		if revertErr != nil {
+			message := revertErr.Error()
+			return &prototk.AssembleTransactionResponse{
+				AssemblyResult: prototk.AssembleTransactionResponse_REVERT,
+				RevertReason:   &message,
+			}, nil
+		}
+		lockedCoins[i] = coin
+		lockedStates[i] = &prototk.StateRef{SchemaId: state.SchemaId, Id: state.Id}
+		total = total.Add(total, coin.Amount.Int())
+	}
+
+	releaseOutputs, err := h.noto.prepareOutputs(beneficiaryAddress, (*pldtypes.HexUint256)(total), []string{notary, tx.Transaction.From, params.Beneficiary})
+	if err != nil {
+		return nil, err
+	}
+
+	infoStates, err := h.noto.prepareInfo(params.Data, []string{notary, tx.Transaction.From, params.Beneficiary})
+	if err != nil {
+		return nil, err
+	}
+	lockState, err := h.noto.prepareLockInfo(params.LockID, fromAddress, nil, []string{notary, tx.Transaction.From, params.Beneficiary})
+	if err != nil {
+		return nil, err
+	}
+	infoStates = append(infoStates, lockState)
+	infoStates = append(infoStates, releaseOutputs.states...)
+
+	encodedExpiry, err := h.noto.encodeLockExpiry(ctx, tx.ContractAddress, params.LockID, params.Expiry, beneficiaryAddress, lockedCoins, releaseOutputs.coins, params.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &prototk.AssembleTransactionResponse{
+		AssemblyResult: prototk.AssembleTransactionResponse_OK,
+		AssembledTransaction: &prototk.AssembledTransaction{
+			ReadStates: lockedStates,
+			InfoStates: infoStates,
+		},
+		AttestationPlan: []*prototk.AttestationRequest{
+			// Sender confirms the initial request with a signature
+			{
+				Name:            "sender",
+				AttestationType: prototk.AttestationType_SIGN,
+				Algorithm:       algorithms.ECDSA_SECP256K1,
+				VerifierType:    verifiers.ETH_ADDRESS,
+				PayloadType:     signpayloads.OPAQUE_TO_RSV,
+				Payload:         encodedExpiry,
+				Parties:         []string{req.Transaction.From},
+			},
+			// Notary will endorse the assembled transaction (by submitting to the ledger)
+			{
+				Name:            "notary",
+				AttestationType: prototk.AttestationType_ENDORSE,
+				Algorithm:       algorithms.ECDSA_SECP256K1,
+				VerifierType:    verifiers.ETH_ADDRESS,
+				Parties:         []string{notary},
+			},
+		},
+	}, nil
+}
+
+func (h *setLockExpiryHandler) Endorse(ctx context.Context, tx *types.ParsedTransaction, req *prototk.EndorseTransactionRequest) (*prototk.EndorseTransactionResponse, error) {
+	params := tx.Params.(*types.SetLockExpiryParams)
+	inputs, err := h.noto.parseCoinList(ctx, "read", req.Reads)
+	if err != nil {
+		return nil, err
+	}
+	outputs, err := h.noto.parseCoinList(ctx, "info", h.noto.filterSchema(req.Info, []string{h.noto.coinSchema.Id, h.noto.lockedCoinSchema.Id}))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(inputs.lockedCoins) == 0 {
+		return nil, i18n.NewError(ctx, msgs.MsgNoStatesSpecified)
+	}
+	if err := h.noto.validateLockOwners(ctx, tx.Transaction.From, req.ResolvedVerifiers, inputs.lockedCoins, inputs.lockedStates); err != nil {
+		return nil, err
+	}
+	for i, coin := range inputs.lockedCoins {
+		if coin.LockID != params.LockID {
+			return nil, i18n.NewError(ctx, msgs.MsgStateWrongLock, inputs.lockedStates[i].Id, params.LockID)
+		}
+	}
+
+	// The release must return the full locked amount to the beneficiary, as unlocked coins
+	if len(inputs.coins) > 0 || len(outputs.lockedCoins) > 0 {
+		return nil, i18n.NewError(ctx, msgs.MsgInvalidInputs, "setLockExpiry", inputs.coins)
+	}
+	if inputs.lockedTotal.Cmp(outputs.total) != 0 {
+		return nil, i18n.NewError(ctx, msgs.MsgInvalidAmount, "setLockExpiry", inputs.lockedTotal.Text(10), outputs.total.Text(10))
+	}
+	beneficiaryAddress, err := h.noto.findEthAddressVerifier(ctx, "beneficiary", params.Beneficiary, req.ResolvedVerifiers)
+	if err != nil {
+		return nil, err
+	}
+	for i, coin := range outputs.coins {
+		if !coin.Owner.Equals(beneficiaryAddress) {
+			return nil, i18n.NewError(ctx, msgs.MsgStateWrongOwner, outputs.states[i].Id, params.Beneficiary)
+		}
+	}
+
+	// Notary checks the signature from the sender, then submits the transaction
+	encodedExpiry, err := h.noto.encodeLockExpiry(ctx, tx.ContractAddress, params.LockID, params.Expiry, beneficiaryAddress, inputs.lockedCoins, outputs.coins, params.Data)
+	if err != nil {
+		return nil, err
+	}
+	if err := h.noto.validateSignature(ctx, "sender", req.Signatures, encodedExpiry); err != nil {
+		return nil, err
+	}
+	return &prototk.EndorseTransactionResponse{
+		EndorsementResult: prototk.EndorseTransactionResponse_ENDORSER_SUBMIT,
+	}, nil
+}
+
+func (h *setLockExpiryHandler) baseLedgerInvoke(ctx context.Context, tx *types.ParsedTransaction, req *prototk.PrepareTransactionRequest) (*TransactionWrapper, error) {
+	inParams := tx.Params.(*types.SetLockExpiryParams)
+
+	sender := domain.FindAttestation("sender", req.AttestationResult)
+	if sender == nil {
+		return nil, i18n.NewError(ctx, msgs.MsgAttestationNotFound, "sender")
+	}
+	beneficiaryAddress, err := h.noto.findEthAddressVerifier(ctx, "beneficiary", inParams.Beneficiary, req.ResolvedVerifiers)
+	if err != nil {
+		return nil, err
+	}
+
+	unlockHash, err := h.noto.unlockHashFromIDs(ctx, tx.ContractAddress, inParams.Unlock.LockedInputs, inParams.Unlock.LockedOutputs, inParams.Unlock.Outputs, inParams.Unlock.Data)
+	if err != nil {
+		return nil, err
+	}
+	releaseOutputs := h.noto.filterSchema(req.InfoStates, []string{h.noto.coinSchema.Id})
+	releaseHash, err := h.noto.unlockHashFromStates(ctx, tx.ContractAddress, req.ReadStates, nil, releaseOutputs, inParams.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := h.noto.encodeTransactionData(ctx, req.Transaction, req.InfoStates)
+	if err != nil {
+		return nil, err
+	}
+	params := &NotoSetLockExpiryParams{
+		UnlockHash:  pldtypes.Bytes32(unlockHash),
+		Expiry:      inParams.Expiry,
+		Beneficiary: beneficiaryAddress,
+		ReleaseHash: pldtypes.Bytes32(releaseHash),
+		Signature:   sender.Payload,
+		Data:        data,
+	}
+	paramsJSON, err := json.Marshal(params)
+	if err != nil {
+		return nil, err
+	}
+	return &TransactionWrapper{
+		functionABI: interfaceBuild.ABI.Functions()["setLockExpiry"],
+		paramsJSON:  paramsJSON,
+	}, nil
+}
+
+func (h *setLockExpiryHandler) hookInvoke(ctx context.Context, tx *types.ParsedTransaction, req *prototk.PrepareTransactionRequest, baseTransaction *TransactionWrapper) (*TransactionWrapper, error) {
+	inParams := tx.Params.(*types.SetLockExpiryParams)
+
+	fromAddress, err := h.noto.findEthAddressVerifier(ctx, "from", tx.Transaction.From, req.ResolvedVerifiers)
+	if err != nil {
+		return nil, err
+	}
+	beneficiaryAddress, err := h.noto.findEthAddressVerifier(ctx, "beneficiary", inParams.Beneficiary, req.ResolvedVerifiers)
+	if err != nil {
+		return nil, err
+	}
+
+	encodedCall, err := baseTransaction.encode(ctx)
+	if err != nil {
+		return nil, err
+	}
+	params := &SetLockExpiryHookParams{
+		Sender:      fromAddress,
+		LockID:      inParams.LockID,
+		Expiry:      inParams.Expiry,
+		Beneficiary: beneficiaryAddress,
+		Prepared: PreparedTransaction{
+			ContractAddress: (*pldtypes.EthAddress)(tx.ContractAddress),
+			EncodedCall:     encodedCall,
+		},
+	}
+
+	transactionType, functionABI, paramsJSON, err := h.noto.wrapHookTransaction(
+		tx.DomainConfig,
+		hooksBuild.ABI.Functions()["onSetLockExpiry"],
+		params,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TransactionWrapper{
+		transactionType: mapPrepareTransactionType(transactionType),
+		functionABI:     functionABI,
+		paramsJSON:      paramsJSON,
+		contractAddress: tx.DomainConfig.Options.Hooks.PublicAddress,
+	}, nil
+}
+
+func (h *setLockExpiryHandler) Prepare(ctx context.Context, tx *types.ParsedTransaction, req *prototk.PrepareTransactionRequest) (*prototk.PrepareTransactionResponse, error) {
+	baseTransaction, err := h.baseLedgerInvoke(ctx, tx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if tx.DomainConfig.NotaryMode == types.NotaryModeHooks.Enum() {
+		hookTransaction, err := h.hookInvoke(ctx, tx, req, baseTransaction)
+		if err != nil {
+			return nil, err
+		}
+		return hookTransaction.prepare(nil)
+	}
+
+	return baseTransaction.prepare(nil)
+}
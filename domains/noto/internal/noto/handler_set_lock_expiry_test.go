@@ -0,0 +1,355 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package noto
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/firefly-signer/pkg/abi"
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/hyperledger/firefly-signer/pkg/secp256k1"
+	"github.com/kaleido-io/paladin/domains/noto/pkg/types"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/toolkit/pkg/algorithms"
+	"github.com/kaleido-io/paladin/toolkit/pkg/domain"
+	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
+	"github.com/kaleido-io/paladin/toolkit/pkg/verifiers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetLockExpiry(t *testing.T) {
+	lockID := pldtypes.RandBytes32()
+	senderKey, err := secp256k1.GenerateSecp256k1KeyPair()
+	require.NoError(t, err)
+	inputCoin := &types.NotoLockedCoinState{
+		ID: pldtypes.RandBytes32(),
+		Data: types.NotoLockedCoin{
+			LockID: lockID,
+			Owner:  (*pldtypes.EthAddress)(&senderKey.Address),
+			Amount: pldtypes.Int64ToInt256(100),
+		},
+	}
+	n := &Noto{
+		Callbacks: &domain.MockDomainCallbacks{
+			MockGetStatesByID: func(req *prototk.GetStatesByIDRequest) (*prototk.GetStatesByIDResponse, error) {
+				assert.Equal(t, "lockedCoin", req.SchemaId)
+				assert.Equal(t, []string{inputCoin.ID.String()}, req.StateIds)
+				return &prototk.GetStatesByIDResponse{
+					States: []*prototk.StoredState{
+						{
+							Id:       inputCoin.ID.String(),
+							SchemaId: "lockedCoin",
+							DataJson: mustParseJSON(inputCoin.Data),
+						},
+					},
+				}, nil
+			},
+		},
+		coinSchema:       &prototk.StateSchema{Id: "coin"},
+		lockedCoinSchema: &prototk.StateSchema{Id: "lockedCoin"},
+		lockInfoSchema:   &prototk.StateSchema{Id: "lockInfo"},
+		dataSchema:       &prototk.StateSchema{Id: "data"},
+		nftSchema:        &prototk.StateSchema{Id: "nft"},
+	}
+	ctx := context.Background()
+	fn := types.NotoABI.Functions()["setLockExpiry"]
+
+	notaryAddress := "0x1000000000000000000000000000000000000000"
+	beneficiaryAddress := "0x2000000000000000000000000000000000000000"
+	unlockOutputID := pldtypes.RandBytes32()
+
+	contractAddress := "0xf6a75f065db3cef95de7aa786eee1d0cb1aeafc3"
+	tx := &prototk.TransactionSpecification{
+		TransactionId: "0x015e1881f2ba769c22d05c841f06949ec6e1bd573f5e1e0328885494212f077d",
+		From:          "sender@node1",
+		ContractInfo: &prototk.ContractInfo{
+			ContractAddress: contractAddress,
+			ContractConfigJson: mustParseJSON(&types.NotoParsedConfig{
+				NotaryLookup: "notary@node1",
+			}),
+		},
+		FunctionAbiJson:   mustParseJSON(fn),
+		FunctionSignature: fn.SolString(),
+		FunctionParamsJson: fmt.Sprintf(`{
+			"lockId": "%s",
+			"unlock": {
+				"lockedInputs": ["%s"],
+				"lockedOutputs": [],
+				"outputs": ["%s"],
+				"signature": "0x",
+				"data": "0x"
+			},
+			"expiry": 1700000000,
+			"beneficiary": "beneficiary@node1",
+			"data": "0x1234"
+		}`, lockID, inputCoin.ID, unlockOutputID),
+	}
+
+	initRes, err := n.InitTransaction(ctx, &prototk.InitTransactionRequest{
+		Transaction: tx,
+	})
+	require.NoError(t, err)
+	require.Len(t, initRes.RequiredVerifiers, 3)
+	assert.Equal(t, "notary@node1", initRes.RequiredVerifiers[0].Lookup)
+	assert.Equal(t, "sender@node1", initRes.RequiredVerifiers[1].Lookup)
+	assert.Equal(t, "beneficiary@node1", initRes.RequiredVerifiers[2].Lookup)
+
+	verifiers := []*prototk.ResolvedVerifier{
+		{
+			Lookup:       "notary@node1",
+			Algorithm:    algorithms.ECDSA_SECP256K1,
+			VerifierType: verifiers.ETH_ADDRESS,
+			Verifier:     notaryAddress,
+		},
+		{
+			Lookup:       "sender@node1",
+			Algorithm:    algorithms.ECDSA_SECP256K1,
+			VerifierType: verifiers.ETH_ADDRESS,
+			Verifier:     senderKey.Address.String(),
+		},
+		{
+			Lookup:       "beneficiary@node1",
+			Algorithm:    algorithms.ECDSA_SECP256K1,
+			VerifierType: verifiers.ETH_ADDRESS,
+			Verifier:     beneficiaryAddress,
+		},
+	}
+
+	assembleRes, err := n.AssembleTransaction(ctx, &prototk.AssembleTransactionRequest{
+		Transaction:       tx,
+		ResolvedVerifiers: verifiers,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, prototk.AssembleTransactionResponse_OK, assembleRes.AssemblyResult)
+	require.Len(t, assembleRes.AssembledTransaction.InputStates, 0)
+	require.Len(t, assembleRes.AssembledTransaction.OutputStates, 0)
+	require.Len(t, assembleRes.AssembledTransaction.ReadStates, 1)
+	require.Len(t, assembleRes.AssembledTransaction.InfoStates, 3)
+	assert.Equal(t, inputCoin.ID.String(), assembleRes.AssembledTransaction.ReadStates[0].Id)
+	releaseCoin, err := n.unmarshalCoin(assembleRes.AssembledTransaction.InfoStates[2].StateDataJson)
+	require.NoError(t, err)
+	assert.Equal(t, beneficiaryAddress, releaseCoin.Owner.String())
+	assert.Equal(t, "100", releaseCoin.Amount.Int().String())
+	lockInfo, err := n.unmarshalLock(assembleRes.AssembledTransaction.InfoStates[1].StateDataJson)
+	require.NoError(t, err)
+	assert.Equal(t, lockID, lockInfo.LockID)
+
+	encodedExpiry, err := n.encodeLockExpiry(ctx, ethtypes.MustNewAddress(contractAddress), lockID, 1700000000, pldtypes.MustEthAddress(beneficiaryAddress),
+		[]*types.NotoLockedCoin{&inputCoin.Data}, []*types.NotoCoin{releaseCoin}, pldtypes.MustParseHexBytes("0x1234"))
+	require.NoError(t, err)
+	signature, err := senderKey.SignDirect(encodedExpiry)
+	require.NoError(t, err)
+	signatureBytes := pldtypes.HexBytes(signature.CompactRSV())
+
+	readStates := []*prototk.EndorsableState{
+		{
+			SchemaId:      "lockedCoin",
+			Id:            inputCoin.ID.String(),
+			StateDataJson: mustParseJSON(inputCoin.Data),
+		},
+	}
+	infoStates := []*prototk.EndorsableState{
+		{
+			SchemaId:      "data",
+			Id:            "0x4cc7840e186de23c4127b4853c878708d2642f1942959692885e098f1944547d",
+			StateDataJson: assembleRes.AssembledTransaction.InfoStates[0].StateDataJson,
+		},
+		{
+			SchemaId:      "lockInfo",
+			Id:            "0x69101A0740EC8096B83653600FA7553D676FC92BCC6E203C3572D2CAC4F1DB2F",
+			StateDataJson: assembleRes.AssembledTransaction.InfoStates[1].StateDataJson,
+		},
+		{
+			SchemaId:      "coin",
+			Id:            "0x26b394af655bdc794a6d7cd7f8004eec20bffb374e4ddd24cdaefe554878d945",
+			StateDataJson: assembleRes.AssembledTransaction.InfoStates[2].StateDataJson,
+		},
+	}
+	attestations := []*prototk.AttestationResult{
+		{
+			Name:     "sender",
+			Verifier: &prototk.ResolvedVerifier{Verifier: senderKey.Address.String()},
+			Payload:  signatureBytes,
+		},
+		{
+			Name:     "notary",
+			Verifier: &prototk.ResolvedVerifier{Lookup: "notary@node1"},
+		},
+	}
+
+	endorseRes, err := n.EndorseTransaction(ctx, &prototk.EndorseTransactionRequest{
+		Transaction:       tx,
+		ResolvedVerifiers: verifiers,
+		Reads:             readStates,
+		Info:              infoStates,
+		EndorsementRequest: &prototk.AttestationRequest{
+			Name: "notary",
+		},
+		Signatures: attestations[:1],
+	})
+	require.NoError(t, err)
+	assert.Equal(t, prototk.EndorseTransactionResponse_ENDORSER_SUBMIT, endorseRes.EndorsementResult)
+
+	unlockHash, err := n.unlockHashFromIDs(ctx, ethtypes.MustNewAddress(contractAddress), []string{inputCoin.ID.String()}, []string{}, []string{unlockOutputID.String()}, pldtypes.HexBytes{})
+	require.NoError(t, err)
+	releaseHash, err := n.unlockHashFromStates(ctx, ethtypes.MustNewAddress(contractAddress), readStates, nil, n.filterSchema(infoStates, []string{"coin"}), pldtypes.MustParseHexBytes("0x1234"))
+	require.NoError(t, err)
+
+	// Prepare once to test base invoke
+	prepareRes, err := n.PrepareTransaction(ctx, &prototk.PrepareTransactionRequest{
+		Transaction:       tx,
+		ResolvedVerifiers: verifiers,
+		ReadStates:        readStates,
+		InfoStates:        infoStates,
+		AttestationResult: attestations,
+	})
+	require.NoError(t, err)
+	expectedFunction := mustParseJSON(interfaceBuild.ABI.Functions()["setLockExpiry"])
+	assert.JSONEq(t, expectedFunction, prepareRes.Transaction.FunctionAbiJson)
+	assert.Nil(t, prepareRes.Transaction.ContractAddress)
+	assert.JSONEq(t, fmt.Sprintf(`{
+		"unlockHash": "%s",
+		"expiry": "0x6553f100",
+		"beneficiary": "%s",
+		"releaseHash": "%s",
+		"signature": "%s",
+		"data": "0x00010000015e1881f2ba769c22d05c841f06949ec6e1bd573f5e1e0328885494212f077d000000000000000000000000000000000000000000000000000000000000004000000000000000000000000000000000000000000000000000000000000000034cc7840e186de23c4127b4853c878708d2642f1942959692885e098f1944547d69101a0740ec8096b83653600fa7553d676fc92bcc6e203c3572d2cac4f1db2f26b394af655bdc794a6d7cd7f8004eec20bffb374e4ddd24cdaefe554878d945"
+	}`, unlockHash, beneficiaryAddress, releaseHash, signatureBytes), prepareRes.Transaction.ParamsJson)
+
+	var invokeFn abi.Entry
+	err = json.Unmarshal([]byte(prepareRes.Transaction.FunctionAbiJson), &invokeFn)
+	require.NoError(t, err)
+	encodedCall, err := invokeFn.EncodeCallDataJSONCtx(ctx, []byte(prepareRes.Transaction.ParamsJson))
+	require.NoError(t, err)
+
+	// The receipt includes the release call for the beneficiary to submit after the expiry
+	receiptRes, err := n.BuildReceipt(ctx, &prototk.BuildReceiptRequest{
+		ReadStates: readStates,
+		InfoStates: infoStates,
+	})
+	require.NoError(t, err)
+	var receipt types.NotoDomainReceipt
+	require.NoError(t, json.Unmarshal([]byte(receiptRes.ReceiptJson), &receipt))
+	require.NotNil(t, receipt.LockInfo.UnlockParams)
+	assert.Equal(t, []string{inputCoin.ID.String()}, receipt.LockInfo.UnlockParams.LockedInputs)
+	assert.Equal(t, []string{"0x26b394af655bdc794a6d7cd7f8004eec20bffb374e4ddd24cdaefe554878d945"}, receipt.LockInfo.UnlockParams.Outputs)
+
+	// Prepare again to test hook invoke
+	hookAddress := "0x515fba7fe1d8b9181be074bd4c7119544426837c"
+	tx.ContractInfo.ContractConfigJson = mustParseJSON(&types.NotoParsedConfig{
+		NotaryLookup: "notary@node1",
+		NotaryMode:   types.NotaryModeHooks.Enum(),
+		Options: types.NotoOptions{
+			Hooks: &types.NotoHooksOptions{
+				PublicAddress:     pldtypes.MustEthAddress(hookAddress),
+				DevUsePublicHooks: true,
+			},
+		},
+	})
+	prepareRes, err = n.PrepareTransaction(ctx, &prototk.PrepareTransactionRequest{
+		Transaction:       tx,
+		ResolvedVerifiers: verifiers,
+		ReadStates:        readStates,
+		InfoStates:        infoStates,
+		AttestationResult: attestations,
+	})
+	require.NoError(t, err)
+	expectedFunction = mustParseJSON(hooksBuild.ABI.Functions()["onSetLockExpiry"])
+	assert.JSONEq(t, expectedFunction, prepareRes.Transaction.FunctionAbiJson)
+	assert.Equal(t, &hookAddress, prepareRes.Transaction.ContractAddress)
+	assert.JSONEq(t, fmt.Sprintf(`{
+		"sender": "%s",
+		"lockId": "%s",
+		"expiry": "0x6553f100",
+		"beneficiary": "%s",
+		"prepared": {
+			"contractAddress": "%s",
+			"encodedCall": "%s"
+		}
+	}`, senderKey.Address, lockID, beneficiaryAddress, contractAddress, pldtypes.HexBytes(encodedCall)), prepareRes.Transaction.ParamsJson)
+}
+
+func TestSetLockExpiryBadParams(t *testing.T) {
+	h := &setLockExpiryHandler{noto: &Noto{}}
+	ctx := context.Background()
+	lockID := pldtypes.RandBytes32()
+
+	_, err := h.ValidateParams(ctx, nil, "!!wrong")
+	assert.Error(t, err)
+
+	_, err = h.ValidateParams(ctx, nil, `{}`)
+	assert.Regexp(t, "PD200007.*lockId", err)
+
+	_, err = h.ValidateParams(ctx, nil, fmt.Sprintf(`{"lockId":"%s","unlock":{"lockedInputs":[]}}`, lockID))
+	assert.Regexp(t, "PD200007.*unlock", err)
+
+	_, err = h.ValidateParams(ctx, nil, fmt.Sprintf(`{"lockId":"%s","unlock":{"lockedInputs":["%s"]}}`, lockID, lockID))
+	assert.Regexp(t, "PD200008.*expiry", err)
+
+	_, err = h.ValidateParams(ctx, nil, fmt.Sprintf(`{"lockId":"%s","unlock":{"lockedInputs":["%s"]},"expiry":1}`, lockID, lockID))
+	assert.Regexp(t, "PD200007.*beneficiary", err)
+}
+
+func TestSetLockExpiryWrongLock(t *testing.T) {
+	lockID := pldtypes.RandBytes32()
+	senderAddress := pldtypes.RandAddress()
+	inputID := pldtypes.RandBytes32()
+	n := &Noto{
+		Callbacks: &domain.MockDomainCallbacks{
+			MockGetStatesByID: func(req *prototk.GetStatesByIDRequest) (*prototk.GetStatesByIDResponse, error) {
+				return &prototk.GetStatesByIDResponse{
+					States: []*prototk.StoredState{{
+						Id:       inputID.String(),
+						SchemaId: "lockedCoin",
+						DataJson: mustParseJSON(&types.NotoLockedCoin{
+							LockID: pldtypes.RandBytes32(),
+							Owner:  senderAddress,
+							Amount: pldtypes.Int64ToInt256(100),
+						}),
+					}},
+				}, nil
+			},
+		},
+		lockedCoinSchema: &prototk.StateSchema{Id: "lockedCoin"},
+	}
+	h := &setLockExpiryHandler{noto: n}
+	ctx := context.Background()
+
+	tx := &types.ParsedTransaction{
+		Transaction:     &prototk.TransactionSpecification{From: "sender@node1"},
+		ContractAddress: ethtypes.MustNewAddress("0xf6a75f065db3cef95de7aa786eee1d0cb1aeafc3"),
+		DomainConfig:    &types.NotoParsedConfig{NotaryLookup: "notary@node1"},
+		Params: &types.SetLockExpiryParams{
+			LockID:      lockID,
+			Unlock:      &types.UnlockPublicParams{LockedInputs: []string{inputID.String()}},
+			Expiry:      1700000000,
+			Beneficiary: "beneficiary@node1",
+		},
+	}
+	res, err := h.Assemble(ctx, tx, &prototk.AssembleTransactionRequest{
+		ResolvedVerifiers: []*prototk.ResolvedVerifier{
+			{Lookup: "sender@node1", Algorithm: algorithms.ECDSA_SECP256K1, VerifierType: verifiers.ETH_ADDRESS, Verifier: senderAddress.String()},
+			{Lookup: "beneficiary@node1", Algorithm: algorithms.ECDSA_SECP256K1, VerifierType: verifiers.ETH_ADDRESS, Verifier: pldtypes.RandAddress().String()},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, prototk.AssembleTransactionResponse_REVERT, res.AssemblyResult)
+	assert.Regexp(t, "PD200032", *res.RevertReason)
+}
@@ -49,12 +49,56 @@ func (h *transferHandler) ValidateParams(ctx context.Context, config *types.Noto
 	return &transferParams, nil
 }
 
+func (h *transferHandler) transferFee(tx *types.ParsedTransaction) *types.NotoTransferFee {
+	if tx.DomainConfig.NotaryMode != types.NotaryModeBasic.Enum() || tx.DomainConfig.Options.Basic == nil {
+		return nil
+	}
+	return tx.DomainConfig.Options.Basic.TransferFee
+}
+
+// Check the fee recipient receives at least the fee due on the transfer, in addition to any amount they are sent
+func (h *transferHandler) checkTransferFee(ctx context.Context, tx *types.ParsedTransaction, req *prototk.EndorseTransactionRequest, outputs *parsedCoins) error {
+	params := tx.Params.(*types.TransferParams)
+	policy := h.transferFee(tx)
+	fee := policy.Calculate(params.Amount.Int())
+	if fee.Sign() == 0 {
+		return nil
+	}
+
+	feeAddress, err := h.noto.findEthAddressVerifier(ctx, "feeRecipient", policy.Recipient, req.ResolvedVerifiers)
+	if err != nil {
+		return err
+	}
+	toAddress, err := h.noto.findEthAddressVerifier(ctx, "to", params.To, req.ResolvedVerifiers)
+	if err != nil {
+		return err
+	}
+	expected := new(big.Int).Set(fee)
+	if toAddress.Equals(feeAddress) {
+		expected.Add(expected, params.Amount.Int())
+	}
+	received := big.NewInt(0)
+	for _, coin := range outputs.coins {
+		if coin.Owner.Equals(feeAddress) {
+			received.Add(received, coin.Amount.Int())
+		}
+	}
+	if received.Cmp(expected) < 0 {
+		return i18n.NewError(ctx, msgs.MsgTransferFeeNotPaid, fee.Text(10), policy.Recipient)
+	}
+	return nil
+}
+
 func (h *transferHandler) Init(ctx context.Context, tx *types.ParsedTransaction, req *prototk.InitTransactionRequest) (*prototk.InitTransactionResponse, error) {
 	params := tx.Params.(*types.TransferParams)
 	notary := tx.DomainConfig.NotaryLookup
 
+	lookups := []string{notary, tx.Transaction.From, params.To}
+	if fee := h.transferFee(tx); fee != nil {
+		lookups = append(lookups, fee.Recipient)
+	}
 	return &prototk.InitTransactionResponse{
-		RequiredVerifiers: h.noto.ethAddressVerifiers(notary, tx.Transaction.From, params.To),
+		RequiredVerifiers: h.noto.ethAddressVerifiers(lookups...),
 	}, nil
 }
 
@@ -71,7 +115,15 @@ func (h *transferHandler) Assemble(ctx context.Context, tx *types.ParsedTransact
 		return nil, err
 	}
 
-	inputStates, revert, err := h.noto.prepareInputs(ctx, req.StateQueryContext, fromAddress, params.Amount)
+	// The sender pays any fee in addition to the amount transferred
+	total := params.Amount.Int()
+	fee := big.NewInt(0)
+	if policy := h.transferFee(tx); policy != nil {
+		fee = policy.Calculate(params.Amount.Int())
+		total = new(big.Int).Add(total, fee)
+	}
+
+	inputStates, revert, err := h.noto.prepareInputs(ctx, req.StateQueryContext, fromAddress, (*pldtypes.HexUint256)(total))
 	if err != nil {
 		if revert {
 			message := err.Error()
@@ -91,8 +143,22 @@ func (h *transferHandler) Assemble(ctx context.Context, tx *types.ParsedTransact
 		return nil, err
 	}
 
-	if inputStates.total.Cmp(params.Amount.Int()) == 1 {
-		remainder := big.NewInt(0).Sub(inputStates.total, params.Amount.Int())
+	if fee.Sign() > 0 {
+		feeRecipient := h.transferFee(tx).Recipient
+		feeAddress, err := h.noto.findEthAddressVerifier(ctx, "feeRecipient", feeRecipient, req.ResolvedVerifiers)
+		if err != nil {
+			return nil, err
+		}
+		feeStates, err := h.noto.prepareOutputs(feeAddress, (*pldtypes.HexUint256)(fee), []string{notary, tx.Transaction.From, feeRecipient})
+		if err != nil {
+			return nil, err
+		}
+		outputStates.coins = append(outputStates.coins, feeStates.coins...)
+		outputStates.states = append(outputStates.states, feeStates.states...)
+	}
+
+	if inputStates.total.Cmp(total) == 1 {
+		remainder := big.NewInt(0).Sub(inputStates.total, total)
 		returnedStates, err := h.noto.prepareOutputs(fromAddress, (*pldtypes.HexUint256)(remainder), []string{notary, tx.Transaction.From})
 		if err != nil {
 			return nil, err
@@ -154,6 +220,11 @@ func (h *transferHandler) Endorse(ctx context.Context, tx *types.ParsedTransacti
 	if err := h.noto.validateOwners(ctx, tx.Transaction.From, req, inputs.coins, inputs.states); err != nil {
 		return nil, err
 	}
+	if h.transferFee(tx) != nil {
+		if err := h.checkTransferFee(ctx, tx, req, outputs); err != nil {
+			return nil, err
+		}
+	}
 
 	// Notary checks the signature from the sender, then submits the transaction
 	encodedTransfer, err := h.noto.encodeTransferUnmasked(ctx, tx.ContractAddress, inputs.coins, outputs.coins)
@@ -0,0 +1,188 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package noto
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/domains/noto/internal/msgs"
+	"github.com/kaleido-io/paladin/domains/noto/pkg/types"
+	"github.com/kaleido-io/paladin/toolkit/pkg/algorithms"
+	"github.com/kaleido-io/paladin/toolkit/pkg/domain"
+	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
+	"github.com/kaleido-io/paladin/toolkit/pkg/signpayloads"
+	"github.com/kaleido-io/paladin/toolkit/pkg/verifiers"
+)
+
+type transferNFTHandler struct {
+	noto *Noto
+}
+
+func (h *transferNFTHandler) ValidateParams(ctx context.Context, config *types.NotoParsedConfig, params string) (interface{}, error) {
+	var transferParams types.TransferNFTParams
+	if err := json.Unmarshal([]byte(params), &transferParams); err != nil {
+		return nil, err
+	}
+	if transferParams.To == "" {
+		return nil, i18n.NewError(ctx, msgs.MsgParameterRequired, "to")
+	}
+	if transferParams.TokenID == nil {
+		return nil, i18n.NewError(ctx, msgs.MsgParameterRequired, "tokenId")
+	}
+	return &transferParams, nil
+}
+
+func (h *transferNFTHandler) Init(ctx context.Context, tx *types.ParsedTransaction, req *prototk.InitTransactionRequest) (*prototk.InitTransactionResponse, error) {
+	params := tx.Params.(*types.TransferNFTParams)
+	notary := tx.DomainConfig.NotaryLookup
+
+	return &prototk.InitTransactionResponse{
+		RequiredVerifiers: h.noto.ethAddressVerifiers(notary, tx.Transaction.From, params.To),
+	}, nil
+}
+
+func (h *transferNFTHandler) Assemble(ctx context.Context, tx *types.ParsedTransaction, req *prototk.AssembleTransactionRequest) (*prototk.AssembleTransactionResponse, error) {
+	params := tx.Params.(*types.TransferNFTParams)
+	notary := tx.DomainConfig.NotaryLookup
+
+	fromAddress, err := h.noto.findEthAddressVerifier(ctx, "from", tx.Transaction.From, req.ResolvedVerifiers)
+	if err != nil {
+		return nil, err
+	}
+	toAddress, err := h.noto.findEthAddressVerifier(ctx, "to", params.To, req.ResolvedVerifiers)
+	if err != nil {
+		return nil, err
+	}
+
+	inputState, input, err := h.noto.findNFT(ctx, req.StateQueryContext, params.TokenID, fromAddress)
+	if err != nil {
+		return nil, err
+	}
+	if inputState == nil {
+		message := i18n.NewError(ctx, msgs.MsgTokenNotOwned, params.TokenID.Int().Text(10), tx.Transaction.From).Error()
+		return &prototk.AssembleTransactionResponse{
+			AssemblyResult: prototk.AssembleTransactionResponse_REVERT,
+			RevertReason:   &message,
+		}, nil
+	}
+
+	// The token keeps its ID and URI, with a new salt and owner
+	output, outputState, err := h.noto.prepareNFTOutput(input.TokenID, toAddress, input.URI, []string{notary, tx.Transaction.From, params.To})
+	if err != nil {
+		return nil, err
+	}
+	infoStates, err := h.noto.prepareInfo(params.Data, []string{notary, tx.Transaction.From, params.To})
+	if err != nil {
+		return nil, err
+	}
+
+	encodedTransfer, err := h.noto.encodeNFTTransfer(ctx, tx.ContractAddress, []*types.NotoNFT{input}, []*types.NotoNFT{output})
+	if err != nil {
+		return nil, err
+	}
+
+	return &prototk.AssembleTransactionResponse{
+		AssemblyResult: prototk.AssembleTransactionResponse_OK,
+		AssembledTransaction: &prototk.AssembledTransaction{
+			InputStates: []*prototk.StateRef{{
+				SchemaId: inputState.SchemaId,
+				Id:       inputState.Id,
+			}},
+			OutputStates: []*prototk.NewState{outputState},
+			InfoStates:   infoStates,
+		},
+		AttestationPlan: []*prototk.AttestationRequest{
+			// Sender confirms the initial request with a signature
+			{
+				Name:            "sender",
+				AttestationType: prototk.AttestationType_SIGN,
+				Algorithm:       algorithms.ECDSA_SECP256K1,
+				VerifierType:    verifiers.ETH_ADDRESS,
+				Payload:         encodedTransfer,
+				PayloadType:     signpayloads.OPAQUE_TO_RSV,
+				Parties:         []string{req.Transaction.From},
+			},
+			// Notary will endorse the assembled transaction (by submitting to the ledger)
+			{
+				Name:            "notary",
+				AttestationType: prototk.AttestationType_ENDORSE,
+				Algorithm:       algorithms.ECDSA_SECP256K1,
+				VerifierType:    verifiers.ETH_ADDRESS,
+				Parties:         []string{notary},
+			},
+		},
+	}, nil
+}
+
+func (h *transferNFTHandler) Endorse(ctx context.Context, tx *types.ParsedTransaction, req *prototk.EndorseTransactionRequest) (*prototk.EndorseTransactionResponse, error) {
+	params := tx.Params.(*types.TransferNFTParams)
+
+	inputs, err := h.noto.parseNFTList(ctx, "input", req.Inputs)
+	if err != nil {
+		return nil, err
+	}
+	outputs, err := h.noto.parseNFTList(ctx, "output", req.Outputs)
+	if err != nil {
+		return nil, err
+	}
+	fromAddress, err := h.noto.findEthAddressVerifier(ctx, "from", tx.Transaction.From, req.ResolvedVerifiers)
+	if err != nil {
+		return nil, err
+	}
+	toAddress, err := h.noto.findEthAddressVerifier(ctx, "to", params.To, req.ResolvedVerifiers)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate the sender owns the token, and it passes unchanged to the recipient
+	if len(inputs) != 1 || inputs[0].TokenID == nil || inputs[0].TokenID.Int().Cmp(params.TokenID.Int()) != 0 {
+		return nil, i18n.NewError(ctx, msgs.MsgInvalidNFTStates, "transferNFT", mustParseJSON(inputs))
+	}
+	if !inputs[0].Owner.Equals(fromAddress) {
+		return nil, i18n.NewError(ctx, msgs.MsgStateWrongOwner, req.Inputs[0].Id, tx.Transaction.From)
+	}
+	if err := h.noto.validateNFTOutput(ctx, "transferNFT", outputs, params.TokenID, toAddress, inputs[0].URI); err != nil {
+		return nil, err
+	}
+
+	// Notary checks the signature from the sender, then submits the transaction
+	encodedTransfer, err := h.noto.encodeNFTTransfer(ctx, tx.ContractAddress, inputs, outputs)
+	if err != nil {
+		return nil, err
+	}
+	if err := h.noto.validateSignature(ctx, "sender", req.Signatures, encodedTransfer); err != nil {
+		return nil, err
+	}
+	return &prototk.EndorseTransactionResponse{
+		EndorsementResult: prototk.EndorseTransactionResponse_ENDORSER_SUBMIT,
+	}, nil
+}
+
+func (h *transferNFTHandler) Prepare(ctx context.Context, tx *types.ParsedTransaction, req *prototk.PrepareTransactionRequest) (*prototk.PrepareTransactionResponse, error) {
+	endorsement := domain.FindAttestation("notary", req.AttestationResult)
+	if endorsement == nil || endorsement.Verifier.Lookup != tx.DomainConfig.NotaryLookup {
+		return nil, i18n.NewError(ctx, msgs.MsgAttestationNotFound, "notary")
+	}
+
+	// The token moves on the base ledger with a regular transfer
+	baseTransaction, err := (&transferHandler{noto: h.noto}).baseLedgerInvoke(ctx, req, false)
+	if err != nil {
+		return nil, err
+	}
+	return baseTransaction.prepare(nil)
+}
@@ -0,0 +1,285 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package noto
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/hyperledger/firefly-signer/pkg/secp256k1"
+	"github.com/kaleido-io/paladin/domains/noto/pkg/types"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/toolkit/pkg/algorithms"
+	"github.com/kaleido-io/paladin/toolkit/pkg/domain"
+	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
+	"github.com/kaleido-io/paladin/toolkit/pkg/verifiers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransferNFT(t *testing.T) {
+	var available []*prototk.StoredState
+	n := &Noto{
+		Callbacks: &domain.MockDomainCallbacks{
+			MockFindAvailableStates: func() (*prototk.FindAvailableStatesResponse, error) {
+				return &prototk.FindAvailableStatesResponse{States: available}, nil
+			},
+		},
+		dataSchema: &prototk.StateSchema{Id: "data"},
+		nftSchema:  &prototk.StateSchema{Id: "nft"},
+	}
+	ctx := context.Background()
+	fn := types.NotoABI.Functions()["transferNFT"]
+
+	notaryAddress := "0x1000000000000000000000000000000000000000"
+	receiverAddress := "0x2000000000000000000000000000000000000000"
+	senderKey, err := secp256k1.GenerateSecp256k1KeyPair()
+	require.NoError(t, err)
+
+	inputNFT := &types.NotoNFTState{
+		ID: pldtypes.RandBytes32(),
+		Data: types.NotoNFT{
+			Salt:    pldtypes.RandBytes32(),
+			TokenID: pldtypes.Uint64ToUint256(42),
+			Owner:   (*pldtypes.EthAddress)(&senderKey.Address),
+			URI:     "https://example.com/tokens/42.json",
+		},
+	}
+
+	contractAddress := "0xf6a75f065db3cef95de7aa786eee1d0cb1aeafc3"
+	tx := &prototk.TransactionSpecification{
+		TransactionId: "0x015e1881f2ba769c22d05c841f06949ec6e1bd573f5e1e0328885494212f077d",
+		From:          "sender@node1",
+		ContractInfo: &prototk.ContractInfo{
+			ContractAddress:    contractAddress,
+			ContractConfigJson: mustParseJSON(notoNFTConfig),
+		},
+		FunctionAbiJson:   mustParseJSON(fn),
+		FunctionSignature: fn.SolString(),
+		FunctionParamsJson: `{
+			"to": "receiver@node2",
+			"tokenId": 42,
+			"data": "0x1234"
+		}`,
+	}
+
+	initRes, err := n.InitTransaction(ctx, &prototk.InitTransactionRequest{
+		Transaction: tx,
+	})
+	require.NoError(t, err)
+	require.Len(t, initRes.RequiredVerifiers, 3)
+
+	verifiers := []*prototk.ResolvedVerifier{
+		{
+			Lookup:       "notary@node1",
+			Algorithm:    algorithms.ECDSA_SECP256K1,
+			VerifierType: verifiers.ETH_ADDRESS,
+			Verifier:     notaryAddress,
+		},
+		{
+			Lookup:       "sender@node1",
+			Algorithm:    algorithms.ECDSA_SECP256K1,
+			VerifierType: verifiers.ETH_ADDRESS,
+			Verifier:     senderKey.Address.String(),
+		},
+		{
+			Lookup:       "receiver@node2",
+			Algorithm:    algorithms.ECDSA_SECP256K1,
+			VerifierType: verifiers.ETH_ADDRESS,
+			Verifier:     receiverAddress,
+		},
+	}
+
+	// The sender must hold the token
+	assembleRes, err := n.AssembleTransaction(ctx, &prototk.AssembleTransactionRequest{
+		Transaction:       tx,
+		ResolvedVerifiers: verifiers,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, prototk.AssembleTransactionResponse_REVERT, assembleRes.AssemblyResult)
+	assert.Regexp(t, "PD200040.*42.*sender@node1", *assembleRes.RevertReason)
+
+	available = []*prototk.StoredState{
+		{
+			Id:       inputNFT.ID.String(),
+			SchemaId: "nft",
+			DataJson: mustParseJSON(inputNFT.Data),
+		},
+	}
+	assembleRes, err = n.AssembleTransaction(ctx, &prototk.AssembleTransactionRequest{
+		Transaction:       tx,
+		ResolvedVerifiers: verifiers,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, prototk.AssembleTransactionResponse_OK, assembleRes.AssemblyResult)
+	require.Len(t, assembleRes.AssembledTransaction.InputStates, 1)
+	require.Len(t, assembleRes.AssembledTransaction.OutputStates, 1)
+	require.Len(t, assembleRes.AssembledTransaction.InfoStates, 1)
+	assert.Equal(t, inputNFT.ID.String(), assembleRes.AssembledTransaction.InputStates[0].Id)
+	assert.Equal(t, []string{"notary@node1", "sender@node1", "receiver@node2"}, assembleRes.AssembledTransaction.OutputStates[0].DistributionList)
+
+	outputNFT, err := n.unmarshalNFT(assembleRes.AssembledTransaction.OutputStates[0].StateDataJson)
+	require.NoError(t, err)
+	assert.Equal(t, receiverAddress, outputNFT.Owner.String())
+	assert.Equal(t, "42", outputNFT.TokenID.Int().String())
+	assert.Equal(t, inputNFT.Data.URI, outputNFT.URI)
+	assert.NotEqual(t, inputNFT.Data.Salt, outputNFT.Salt)
+
+	encodedTransfer, err := n.encodeNFTTransfer(ctx, ethtypes.MustNewAddress(contractAddress), []*types.NotoNFT{&inputNFT.Data}, []*types.NotoNFT{outputNFT})
+	require.NoError(t, err)
+	signature, err := senderKey.SignDirect(encodedTransfer)
+	require.NoError(t, err)
+	signatureBytes := pldtypes.HexBytes(signature.CompactRSV())
+
+	inputStates := []*prototk.EndorsableState{
+		{
+			SchemaId:      "nft",
+			Id:            inputNFT.ID.String(),
+			StateDataJson: mustParseJSON(inputNFT.Data),
+		},
+	}
+	outputStates := []*prototk.EndorsableState{
+		{
+			SchemaId:      "nft",
+			Id:            "0x0000000000000000000000000000000000000000000000000000000000000001",
+			StateDataJson: assembleRes.AssembledTransaction.OutputStates[0].StateDataJson,
+		},
+	}
+	infoStates := []*prototk.EndorsableState{
+		{
+			SchemaId:      "data",
+			Id:            "0x0000000000000000000000000000000000000000000000000000000000000003",
+			StateDataJson: assembleRes.AssembledTransaction.InfoStates[0].StateDataJson,
+		},
+	}
+	signatures := []*prototk.AttestationResult{
+		{
+			Name:     "sender",
+			Verifier: &prototk.ResolvedVerifier{Verifier: senderKey.Address.String()},
+			Payload:  signatureBytes,
+		},
+	}
+
+	endorseRes, err := n.EndorseTransaction(ctx, &prototk.EndorseTransactionRequest{
+		Transaction:        tx,
+		ResolvedVerifiers:  verifiers,
+		Inputs:             inputStates,
+		Outputs:            outputStates,
+		Info:               infoStates,
+		EndorsementRequest: &prototk.AttestationRequest{Name: "notary"},
+		Signatures:         signatures,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, prototk.EndorseTransactionResponse_ENDORSER_SUBMIT, endorseRes.EndorsementResult)
+
+	prepareRes, err := n.PrepareTransaction(ctx, &prototk.PrepareTransactionRequest{
+		Transaction:       tx,
+		ResolvedVerifiers: verifiers,
+		InputStates:       inputStates,
+		OutputStates:      outputStates,
+		InfoStates:        infoStates,
+		AttestationResult: append(signatures, &prototk.AttestationResult{
+			Name:     "notary",
+			Verifier: &prototk.ResolvedVerifier{Lookup: "notary@node1"},
+		}),
+	})
+	require.NoError(t, err)
+	expectedFunction := mustParseJSON(interfaceBuild.ABI.Functions()["transfer"])
+	assert.JSONEq(t, expectedFunction, prepareRes.Transaction.FunctionAbiJson)
+	assert.JSONEq(t, fmt.Sprintf(`{
+		"inputs": ["%s"],
+		"outputs": ["0x0000000000000000000000000000000000000000000000000000000000000001"],
+		"signature": "%s",
+		"data": "0x00010000015e1881f2ba769c22d05c841f06949ec6e1bd573f5e1e0328885494212f077d000000000000000000000000000000000000000000000000000000000000004000000000000000000000000000000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000000000003"
+	}`, inputNFT.ID, signatureBytes), prepareRes.Transaction.ParamsJson)
+}
+
+func TestTransferNFTBadStates(t *testing.T) {
+	n := &Noto{
+		Callbacks: mockCallbacks,
+		nftSchema: &prototk.StateSchema{Id: "nft"},
+	}
+	ctx := context.Background()
+	fn := types.NotoABI.Functions()["transferNFT"]
+
+	senderAddress := "0x1000000000000000000000000000000000000000"
+	receiverAddress := "0x2000000000000000000000000000000000000000"
+	tx := &prototk.TransactionSpecification{
+		TransactionId: "0x015e1881f2ba769c22d05c841f06949ec6e1bd573f5e1e0328885494212f077d",
+		From:          "sender@node1",
+		ContractInfo: &prototk.ContractInfo{
+			ContractAddress:    "0xf6a75f065db3cef95de7aa786eee1d0cb1aeafc3",
+			ContractConfigJson: mustParseJSON(notoNFTConfig),
+		},
+		FunctionAbiJson:    mustParseJSON(fn),
+		FunctionSignature:  fn.SolString(),
+		FunctionParamsJson: `{"to": "receiver@node2", "tokenId": 42}`,
+	}
+	verifiers := []*prototk.ResolvedVerifier{
+		{
+			Lookup:       "sender@node1",
+			Algorithm:    algorithms.ECDSA_SECP256K1,
+			VerifierType: verifiers.ETH_ADDRESS,
+			Verifier:     senderAddress,
+		},
+		{
+			Lookup:       "receiver@node2",
+			Algorithm:    algorithms.ECDSA_SECP256K1,
+			VerifierType: verifiers.ETH_ADDRESS,
+			Verifier:     receiverAddress,
+		},
+	}
+
+	nftState := func(tokenID uint64, owner, uri string) *prototk.EndorsableState {
+		return &prototk.EndorsableState{
+			SchemaId: "nft",
+			Id:       pldtypes.RandBytes32().String(),
+			StateDataJson: mustParseJSON(&types.NotoNFT{
+				Salt:    pldtypes.RandBytes32(),
+				TokenID: pldtypes.Uint64ToUint256(tokenID),
+				Owner:   pldtypes.MustEthAddress(owner),
+				URI:     uri,
+			}),
+		}
+	}
+	endorse := func(input, output *prototk.EndorsableState) error {
+		_, err := n.EndorseTransaction(ctx, &prototk.EndorseTransactionRequest{
+			Transaction:        tx,
+			ResolvedVerifiers:  verifiers,
+			Inputs:             []*prototk.EndorsableState{input},
+			Outputs:            []*prototk.EndorsableState{output},
+			EndorsementRequest: &prototk.AttestationRequest{Name: "notary"},
+		})
+		return err
+	}
+
+	err := endorse(nftState(43, senderAddress, "uri1"), nftState(43, receiverAddress, "uri1"))
+	assert.Regexp(t, "PD200041", err)
+
+	err = endorse(nftState(42, receiverAddress, "uri1"), nftState(42, receiverAddress, "uri1"))
+	assert.Regexp(t, "PD200018", err)
+
+	err = endorse(nftState(42, senderAddress, "uri1"), nftState(42, senderAddress, "uri1"))
+	assert.Regexp(t, "PD200041", err)
+
+	err = endorse(nftState(42, senderAddress, "uri1"), nftState(42, receiverAddress, "uri2"))
+	assert.Regexp(t, "PD200041", err)
+
+	err = endorse(nftState(42, senderAddress, "uri1"), nftState(42, receiverAddress, "uri1"))
+	assert.Regexp(t, "PD200015.*sender", err)
+}
@@ -364,3 +364,177 @@ func TestTransferAssembleMissingTo(t *testing.T) {
 	_, err := h.Assemble(ctx, parsedTx, req)
 	assert.Regexp(t, "PD200011.*'to'", err)
 }
+
+func TestTransferWithFee(t *testing.T) {
+	n := &Noto{
+		Callbacks:  mockCallbacks,
+		coinSchema: &prototk.StateSchema{Id: "coin"},
+		dataSchema: &prototk.StateSchema{Id: "data"},
+	}
+	ctx := context.Background()
+	fn := types.NotoABI.Functions()["transfer"]
+
+	notaryAddress := "0x1000000000000000000000000000000000000000"
+	receiverAddress := "0x2000000000000000000000000000000000000000"
+	feeAddress := "0x3000000000000000000000000000000000000000"
+	senderKey, err := secp256k1.GenerateSecp256k1KeyPair()
+	require.NoError(t, err)
+
+	inputCoin := &types.NotoCoinState{
+		ID: pldtypes.RandBytes32(),
+		Data: types.NotoCoin{
+			Owner:  (*pldtypes.EthAddress)(&senderKey.Address),
+			Amount: pldtypes.Int64ToInt256(100),
+		},
+	}
+	mockCallbacks.MockFindAvailableStates = func() (*prototk.FindAvailableStatesResponse, error) {
+		return &prototk.FindAvailableStatesResponse{
+			States: []*prototk.StoredState{
+				{
+					Id:       inputCoin.ID.String(),
+					SchemaId: "coin",
+					DataJson: mustParseJSON(inputCoin.Data),
+				},
+			},
+		}, nil
+	}
+
+	// 2% of 75 rounds down to 1, plus the flat fee of 2
+	contractAddress := "0xf6a75f065db3cef95de7aa786eee1d0cb1aeafc3"
+	tx := &prototk.TransactionSpecification{
+		TransactionId: "0x015e1881f2ba769c22d05c841f06949ec6e1bd573f5e1e0328885494212f077d",
+		From:          "sender@node1",
+		ContractInfo: &prototk.ContractInfo{
+			ContractAddress: contractAddress,
+			ContractConfigJson: mustParseJSON(&types.NotoParsedConfig{
+				NotaryMode:   types.NotaryModeBasic.Enum(),
+				NotaryLookup: "notary@node1",
+				Options: types.NotoOptions{
+					Basic: &types.NotoBasicOptions{
+						RestrictMint: &pTrue,
+						AllowBurn:    &pTrue,
+						AllowLock:    &pTrue,
+						TransferFee: &types.NotoTransferFee{
+							Recipient:   "operator@node3",
+							BasisPoints: 200,
+							FlatFee:     pldtypes.Uint64ToUint256(2),
+						},
+					},
+				},
+			}),
+		},
+		FunctionAbiJson:   mustParseJSON(fn),
+		FunctionSignature: fn.SolString(),
+		FunctionParamsJson: `{
+			"to": "receiver@node2",
+			"amount": 75,
+			"data": "0x1234"
+		}`,
+	}
+
+	initRes, err := n.InitTransaction(ctx, &prototk.InitTransactionRequest{
+		Transaction: tx,
+	})
+	require.NoError(t, err)
+	require.Len(t, initRes.RequiredVerifiers, 4)
+	assert.Equal(t, "operator@node3", initRes.RequiredVerifiers[3].Lookup)
+
+	verifiers := []*prototk.ResolvedVerifier{
+		{
+			Lookup:       "notary@node1",
+			Algorithm:    algorithms.ECDSA_SECP256K1,
+			VerifierType: verifiers.ETH_ADDRESS,
+			Verifier:     notaryAddress,
+		},
+		{
+			Lookup:       "sender@node1",
+			Algorithm:    algorithms.ECDSA_SECP256K1,
+			VerifierType: verifiers.ETH_ADDRESS,
+			Verifier:     senderKey.Address.String(),
+		},
+		{
+			Lookup:       "receiver@node2",
+			Algorithm:    algorithms.ECDSA_SECP256K1,
+			VerifierType: verifiers.ETH_ADDRESS,
+			Verifier:     receiverAddress,
+		},
+		{
+			Lookup:       "operator@node3",
+			Algorithm:    algorithms.ECDSA_SECP256K1,
+			VerifierType: verifiers.ETH_ADDRESS,
+			Verifier:     feeAddress,
+		},
+	}
+
+	assembleRes, err := n.AssembleTransaction(ctx, &prototk.AssembleTransactionRequest{
+		Transaction:       tx,
+		ResolvedVerifiers: verifiers,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, prototk.AssembleTransactionResponse_OK, assembleRes.AssemblyResult)
+	require.Len(t, assembleRes.AssembledTransaction.InputStates, 1)
+	require.Len(t, assembleRes.AssembledTransaction.OutputStates, 3)
+
+	outputCoin, err := n.unmarshalCoin(assembleRes.AssembledTransaction.OutputStates[0].StateDataJson)
+	require.NoError(t, err)
+	assert.Equal(t, receiverAddress, outputCoin.Owner.String())
+	assert.Equal(t, "75", outputCoin.Amount.Int().String())
+
+	feeCoin, err := n.unmarshalCoin(assembleRes.AssembledTransaction.OutputStates[1].StateDataJson)
+	require.NoError(t, err)
+	assert.Equal(t, feeAddress, feeCoin.Owner.String())
+	assert.Equal(t, "3", feeCoin.Amount.Int().String())
+	assert.Equal(t, []string{"notary@node1", "sender@node1", "operator@node3"}, assembleRes.AssembledTransaction.OutputStates[1].DistributionList)
+
+	remainderCoin, err := n.unmarshalCoin(assembleRes.AssembledTransaction.OutputStates[2].StateDataJson)
+	require.NoError(t, err)
+	assert.Equal(t, senderKey.Address.String(), remainderCoin.Owner.String())
+	assert.Equal(t, "22", remainderCoin.Amount.Int().String())
+
+	endorse := func(outputCoins ...*types.NotoCoin) error {
+		outputStates := make([]*prototk.EndorsableState, len(outputCoins))
+		for i, coin := range outputCoins {
+			outputStates[i] = &prototk.EndorsableState{
+				SchemaId:      "coin",
+				Id:            pldtypes.RandBytes32().String(),
+				StateDataJson: mustParseJSON(coin),
+			}
+		}
+		encodedTransfer, err := n.encodeTransferUnmasked(ctx, ethtypes.MustNewAddress(contractAddress), []*types.NotoCoin{&inputCoin.Data}, outputCoins)
+		require.NoError(t, err)
+		signature, err := senderKey.SignDirect(encodedTransfer)
+		require.NoError(t, err)
+		_, err = n.EndorseTransaction(ctx, &prototk.EndorseTransactionRequest{
+			Transaction:       tx,
+			ResolvedVerifiers: verifiers,
+			Inputs: []*prototk.EndorsableState{
+				{
+					SchemaId:      "coin",
+					Id:            inputCoin.ID.String(),
+					StateDataJson: mustParseJSON(inputCoin.Data),
+				},
+			},
+			Outputs:            outputStates,
+			EndorsementRequest: &prototk.AttestationRequest{Name: "notary"},
+			Signatures: []*prototk.AttestationResult{
+				{
+					Name:     "sender",
+					Verifier: &prototk.ResolvedVerifier{Verifier: senderKey.Address.String()},
+					Payload:  signature.CompactRSV(),
+				},
+			},
+		})
+		return err
+	}
+
+	err = endorse(outputCoin, feeCoin, remainderCoin)
+	require.NoError(t, err)
+
+	// The fee cannot be kept by the sender
+	err = endorse(outputCoin, &types.NotoCoin{
+		Salt:   pldtypes.RandBytes32(),
+		Owner:  (*pldtypes.EthAddress)(&senderKey.Address),
+		Amount: pldtypes.Int64ToInt256(25),
+	})
+	assert.Regexp(t, "PD200043.*3.*operator@node3", err)
+}
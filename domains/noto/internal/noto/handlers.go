@@ -54,11 +54,34 @@ func (n *Noto) GetHandler(method string) types.DomainHandler {
 		}
 	case "delegateLock":
 		return &delegateLockHandler{noto: n}
+	case "setLockExpiry":
+		return &setLockExpiryHandler{noto: n}
+	case "mintNFT":
+		return &mintNFTHandler{noto: n}
+	case "transferNFT":
+		return &transferNFTHandler{noto: n}
 	default:
 		return nil
 	}
 }
 
+// Functions that are only available (exclusively) on non-fungible contracts
+var nftFunctions = map[string]bool{
+	"mintNFT":     true,
+	"transferNFT": true,
+}
+
+// Check that a non-fungible mint or transfer produces a single state for the recipient, carrying the token forward
+func (n *Noto) validateNFTOutput(ctx context.Context, method string, outputs []*types.NotoNFT, tokenID *pldtypes.HexUint256, owner *pldtypes.EthAddress, uri string) error {
+	if len(outputs) != 1 ||
+		outputs[0].TokenID == nil || outputs[0].TokenID.Int().Cmp(tokenID.Int()) != 0 ||
+		!outputs[0].Owner.Equals(owner) ||
+		outputs[0].URI != uri {
+		return i18n.NewError(ctx, msgs.MsgInvalidNFTStates, method, mustParseJSON(outputs))
+	}
+	return nil
+}
+
 // Check that a mint has no inputs, and an output matching the requested amount
 func (n *Noto) validateMintAmounts(ctx context.Context, params *types.MintParams, inputs, outputs *parsedCoins) error {
 	if len(inputs.coins) > 0 {
@@ -79,6 +79,14 @@ type ApproveUnlockHookParams struct {
 	Prepared PreparedTransaction  `json:"prepared"`
 }
 
+type SetLockExpiryHookParams struct {
+	Sender      *pldtypes.EthAddress `json:"sender"`
+	LockID      pldtypes.Bytes32     `json:"lockId"`
+	Expiry      pldtypes.HexUint64   `json:"expiry"`
+	Beneficiary *pldtypes.EthAddress `json:"beneficiary"`
+	Prepared    PreparedTransaction  `json:"prepared"`
+}
+
 type DelegateUnlockHookParams struct {
 	Sender     *pldtypes.EthAddress       `json:"sender"`
 	LockID     pldtypes.Bytes32           `json:"lockId"`
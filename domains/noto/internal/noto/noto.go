@@ -64,6 +64,7 @@ var (
 	NotoUnlock         = "NotoUnlock"
 	NotoUnlockPrepared = "NotoUnlockPrepared"
 	NotoLockDelegated  = "NotoLockDelegated"
+	NotoLockExpirySet  = "NotoLockExpirySet"
 )
 
 var allEvents = []string{
@@ -73,6 +74,7 @@ var allEvents = []string{
 	NotoUnlock,
 	NotoUnlockPrepared,
 	NotoLockDelegated,
+	NotoLockExpirySet,
 }
 
 var eventsJSON = mustBuildEventsJSON(interfaceBuild.ABI, errorsBuild.ABI)
@@ -83,6 +85,8 @@ var allSchemas = []*abi.Parameter{
 	types.NotoLockInfoABI,
 	types.NotoLockedCoinABI,
 	types.TransactionDataABI,
+	types.NotoMintRecordABI,
+	types.NotoNFTABI,
 }
 
 var schemasJSON = mustParseSchemas(allSchemas)
@@ -97,6 +101,8 @@ type Noto struct {
 	lockedCoinSchema *prototk.StateSchema
 	dataSchema       *prototk.StateSchema
 	lockInfoSchema   *prototk.StateSchema
+	mintRecordSchema *prototk.StateSchema
+	nftSchema        *prototk.StateSchema
 }
 
 type NotoDeployParams struct {
@@ -155,6 +161,15 @@ type NotoDelegateLockParams struct {
 	Data       pldtypes.HexBytes    `json:"data"`
 }
 
+type NotoSetLockExpiryParams struct {
+	UnlockHash  pldtypes.Bytes32     `json:"unlockHash"`
+	Expiry      pldtypes.HexUint64   `json:"expiry"`
+	Beneficiary *pldtypes.EthAddress `json:"beneficiary"`
+	ReleaseHash pldtypes.Bytes32     `json:"releaseHash"`
+	Signature   pldtypes.HexBytes    `json:"signature"`
+	Data        pldtypes.HexBytes    `json:"data"`
+}
+
 type NotoTransfer_Event struct {
 	Inputs    []pldtypes.Bytes32 `json:"inputs"`
 	Outputs   []pldtypes.Bytes32 `json:"outputs"`
@@ -200,6 +215,15 @@ type NotoLockDelegated_Event struct {
 	Data       pldtypes.HexBytes    `json:"data"`
 }
 
+type NotoLockExpirySet_Event struct {
+	UnlockHash  pldtypes.Bytes32     `json:"unlockHash"`
+	Expiry      *pldtypes.HexUint256 `json:"expiry"`
+	Beneficiary *pldtypes.EthAddress `json:"beneficiary"`
+	ReleaseHash pldtypes.Bytes32     `json:"releaseHash"`
+	Signature   pldtypes.HexBytes    `json:"signature"`
+	Data        pldtypes.HexBytes    `json:"data"`
+}
+
 type parsedCoins struct {
 	coins        []*types.NotoCoin
 	states       []*prototk.StateRef
@@ -271,6 +295,14 @@ func (n *Noto) DataSchemaID() string {
 	return n.dataSchema.Id
 }
 
+func (n *Noto) MintRecordSchemaID() string {
+	return n.mintRecordSchema.Id
+}
+
+func (n *Noto) NFTSchemaID() string {
+	return n.nftSchema.Id
+}
+
 func (n *Noto) ConfigureDomain(ctx context.Context, req *prototk.ConfigureDomainRequest) (*prototk.ConfigureDomainResponse, error) {
 	err := json.Unmarshal([]byte(req.ConfigJson), &n.config)
 	if err != nil {
@@ -299,6 +331,10 @@ func (n *Noto) InitDomain(ctx context.Context, req *prototk.InitDomainRequest) (
 			n.dataSchema = req.AbiStateSchemas[i]
 		case types.NotoLockInfoABI.Name:
 			n.lockInfoSchema = req.AbiStateSchemas[i]
+		case types.NotoMintRecordABI.Name:
+			n.mintRecordSchema = req.AbiStateSchemas[i]
+		case types.NotoNFTABI.Name:
+			n.nftSchema = req.AbiStateSchemas[i]
 		}
 	}
 	return &prototk.InitDomainResponse{}, nil
@@ -312,7 +348,21 @@ func (n *Noto) InitDeploy(ctx context.Context, req *prototk.InitDeployRequest) (
 
 	switch params.NotaryMode {
 	case types.NotaryModeBasic:
-		// no required params
+		if params.Options.Basic != nil && isTransferFeeSet(params.Options.Basic.TransferFee) {
+			if err := n.validateTransferFee(ctx, params.Options.Basic); err != nil {
+				return nil, err
+			}
+		}
+		if params.Options.Basic != nil && params.Options.Basic.MintPolicy != nil {
+			if err := n.validateMintPolicy(ctx, params.Options.Basic.MintPolicy); err != nil {
+				return nil, err
+			}
+			policy := params.Options.Basic.MintPolicy
+			nonFungible := params.Options.Basic.NonFungible != nil && *params.Options.Basic.NonFungible
+			if nonFungible && ((policy.MaxSupply != nil && policy.MaxSupply.Int().Sign() > 0) || len(policy.MinterQuotas) > 0) {
+				return nil, i18n.NewError(ctx, msgs.MsgMintPolicyNonFungible)
+			}
+		}
 	case types.NotaryModeHooks:
 		if params.Options.Hooks == nil {
 			return nil, i18n.NewError(ctx, msgs.MsgParameterRequired, "options.hooks")
@@ -343,6 +393,60 @@ func (n *Noto) InitDeploy(ctx context.Context, req *prototk.InitDeployRequest) (
 	}, nil
 }
 
+func (n *Noto) validateMintPolicy(ctx context.Context, policy *types.NotoMintPolicy) error {
+	for i, mq := range policy.MinterQuotas {
+		if mq.Minter == "" {
+			return i18n.NewError(ctx, msgs.MsgParameterRequired, fmt.Sprintf("options.basic.mintPolicy.minterQuotas[%d].minter", i))
+		}
+		if mq.Quota == nil || mq.Quota.Int().Sign() != 1 {
+			return i18n.NewError(ctx, msgs.MsgParameterGreaterThanZero, fmt.Sprintf("options.basic.mintPolicy.minterQuotas[%d].quota", i))
+		}
+	}
+	return nil
+}
+
+// An empty fee policy is treated as unset, as ABI-normalized constructor params always include one
+func isTransferFeeSet(fee *types.NotoTransferFee) bool {
+	return fee != nil && (fee.Recipient != "" || fee.BasisPoints > 0 || (fee.FlatFee != nil && fee.FlatFee.Int().Sign() > 0))
+}
+
+func (n *Noto) validateTransferFee(ctx context.Context, options *types.NotoBasicOptions) error {
+	if options.NonFungible != nil && *options.NonFungible {
+		return i18n.NewError(ctx, msgs.MsgTransferFeeNonFungible)
+	}
+	if options.TransferFee.Recipient == "" {
+		return i18n.NewError(ctx, msgs.MsgParameterRequired, "options.basic.transferFee.recipient")
+	}
+	if options.TransferFee.BasisPoints > 10000 {
+		return i18n.NewError(ctx, msgs.MsgInvalidTransferFeeRate, options.TransferFee.BasisPoints)
+	}
+	return nil
+}
+
+// Minter lookups are stored fully qualified, so they can be compared with the sender of each mint
+func (n *Noto) qualifyMintPolicy(ctx context.Context, policy *types.NotoMintPolicy, localNodeName string) (*types.NotoMintPolicy, error) {
+	qualified := &types.NotoMintPolicy{}
+	// A zero supply cap is treated as unset, as ABI-normalized constructor params always include one
+	if policy.MaxSupply != nil && policy.MaxSupply.Int().Sign() > 0 {
+		qualified.MaxSupply = policy.MaxSupply
+	}
+	for _, lookup := range policy.Minters {
+		minter, err := pldtypes.PrivateIdentityLocator(lookup).FullyQualified(ctx, localNodeName)
+		if err != nil {
+			return nil, err
+		}
+		qualified.Minters = append(qualified.Minters, minter.String())
+	}
+	for _, mq := range policy.MinterQuotas {
+		minter, err := pldtypes.PrivateIdentityLocator(mq.Minter).FullyQualified(ctx, localNodeName)
+		if err != nil {
+			return nil, err
+		}
+		qualified.MinterQuotas = append(qualified.MinterQuotas, &types.NotoMinterQuota{Minter: minter.String(), Quota: mq.Quota})
+	}
+	return qualified, nil
+}
+
 func (n *Noto) PrepareDeploy(ctx context.Context, req *prototk.PrepareDeployRequest) (*prototk.PrepareDeployResponse, error) {
 	params, err := n.validateDeploy(req.Transaction)
 	if err != nil {
@@ -377,6 +481,29 @@ func (n *Noto) PrepareDeploy(ctx context.Context, req *prototk.PrepareDeployRequ
 			if params.Options.Basic.AllowLock != nil {
 				deployData.AllowLock = *params.Options.Basic.AllowLock
 			}
+			if params.Options.Basic.NonFungible != nil {
+				deployData.NonFungible = *params.Options.Basic.NonFungible
+			}
+			if isTransferFeeSet(params.Options.Basic.TransferFee) {
+				// The recipient is stored fully qualified, as it is resolved on every transfer
+				recipient, err := pldtypes.PrivateIdentityLocator(params.Options.Basic.TransferFee.Recipient).FullyQualified(ctx, localNodeName.Name)
+				if err != nil {
+					return nil, err
+				}
+				deployData.TransferFee = &types.NotoTransferFee{
+					Recipient:   recipient.String(),
+					BasisPoints: params.Options.Basic.TransferFee.BasisPoints,
+				}
+				if params.Options.Basic.TransferFee.FlatFee != nil && params.Options.Basic.TransferFee.FlatFee.Int().Sign() > 0 {
+					deployData.TransferFee.FlatFee = params.Options.Basic.TransferFee.FlatFee
+				}
+			}
+			if params.Options.Basic.MintPolicy != nil {
+				deployData.MintPolicy, err = n.qualifyMintPolicy(ctx, params.Options.Basic.MintPolicy, localNodeName.Name)
+				if err != nil {
+					return nil, err
+				}
+			}
 		}
 	case types.NotaryModeHooks:
 		deployData.NotaryMode = types.NotaryModeIntHooks
@@ -452,6 +579,11 @@ func (n *Noto) InitContract(ctx context.Context, req *prototk.InitContractReques
 			RestrictMint: &decodedData.RestrictMint,
 			AllowBurn:    &decodedData.AllowBurn,
 			AllowLock:    &decodedData.AllowLock,
+			MintPolicy:   decodedData.MintPolicy,
+			TransferFee:  decodedData.TransferFee,
+		}
+		if decodedData.NonFungible {
+			parsedConfig.Options.Basic.NonFungible = &decodedData.NonFungible
 		}
 	}
 
@@ -551,6 +683,13 @@ func (n *Noto) validateTransaction(ctx context.Context, tx *prototk.TransactionS
 		return nil, nil, i18n.NewError(ctx, msgs.MsgUnknownFunction, functionABI.Name)
 	}
 
+	if nftFunctions[functionABI.Name] != domainConfig.IsNonFungible() {
+		if domainConfig.IsNonFungible() {
+			return nil, nil, i18n.NewError(ctx, msgs.MsgFungibleOnly, functionABI.Name)
+		}
+		return nil, nil, i18n.NewError(ctx, msgs.MsgNonFungibleOnly, functionABI.Name)
+	}
+
 	contractAddress, err := ethtypes.NewAddress(tx.ContractInfo.ContractAddress)
 	if err != nil {
 		return nil, nil, err
@@ -652,6 +791,27 @@ func (n *Noto) parseCoinList(ctx context.Context, label string, states []*protot
 	return result, nil
 }
 
+func (n *Noto) parseNFTList(ctx context.Context, label string, states []*prototk.EndorsableState) ([]*types.NotoNFT, error) {
+	statesUsed := make(map[string]bool)
+	nfts := make([]*types.NotoNFT, 0, len(states))
+	for i, state := range states {
+		if statesUsed[state.Id] {
+			return nil, i18n.NewError(ctx, msgs.MsgDuplicateStateInList, label, i, state.Id)
+		}
+		statesUsed[state.Id] = true
+
+		if state.SchemaId != n.nftSchema.Id {
+			return nil, i18n.NewError(ctx, msgs.MsgUnexpectedSchema, state.SchemaId)
+		}
+		nft, err := n.unmarshalNFT(state.StateDataJson)
+		if err != nil {
+			return nil, i18n.NewError(ctx, msgs.MsgInvalidListInput, label, i, state.Id, err)
+		}
+		nfts = append(nfts, nft)
+	}
+	return nfts, nil
+}
+
 func (n *Noto) encodeTransactionData(ctx context.Context, transaction *prototk.TransactionSpecification, infoStates []*prototk.EndorsableState) (pldtypes.HexBytes, error) {
 	var err error
 	stateIDs := make([]pldtypes.Bytes32, len(infoStates))
@@ -771,3 +931,7 @@ func (n *Noto) InitPrivacyGroup(ctx context.Context, req *prototk.InitPrivacyGro
 func (n *Noto) WrapPrivacyGroupEVMTX(ctx context.Context, req *prototk.WrapPrivacyGroupEVMTXRequest) (*prototk.WrapPrivacyGroupEVMTXResponse, error) {
 	return nil, i18n.NewError(ctx, msgs.MsgNotImplemented)
 }
+
+func (n *Noto) HandleRPC(ctx context.Context, req *prototk.HandleRPCRequest) (*prototk.HandleRPCResponse, error) {
+	return nil, i18n.NewError(ctx, msgs.MsgNotImplemented)
+}
@@ -77,7 +77,7 @@ func TestNotoDomainInit(t *testing.T) {
 		ConfigJson: "{}",
 	})
 	require.NoError(t, err)
-	assert.Len(t, configureRes.DomainConfig.AbiStateSchemasJson, 4)
+	assert.Len(t, configureRes.DomainConfig.AbiStateSchemasJson, 6)
 
 	initRes, err := n.InitDomain(ctx, &prototk.InitDomainRequest{
 		AbiStateSchemas: []*prototk.StateSchema{
@@ -85,6 +85,8 @@ func TestNotoDomainInit(t *testing.T) {
 			{Id: "schema2"},
 			{Id: "schema3"},
 			{Id: "schema4"},
+			{Id: "schema5"},
+			{Id: "schema6"},
 		},
 	})
 	require.NoError(t, err)
@@ -95,6 +97,8 @@ func TestNotoDomainInit(t *testing.T) {
 	assert.Equal(t, "schema2", n.LockInfoSchemaID())
 	assert.Equal(t, "schema3", n.LockedCoinSchemaID())
 	assert.Equal(t, "schema4", n.DataSchemaID())
+	assert.Equal(t, "schema5", n.MintRecordSchemaID())
+	assert.Equal(t, "schema6", n.NFTSchemaID())
 }
 
 func TestNotoDomainDeployDefaults(t *testing.T) {
@@ -216,6 +220,307 @@ func TestNotoDomainDeployBasicConfig(t *testing.T) {
 	assert.True(t, initContractRes.Valid)
 }
 
+func TestNotoDomainDeployMintPolicy(t *testing.T) {
+	n := &Noto{Callbacks: mockCallbacks}
+	ctx := context.Background()
+
+	deployTransaction := &prototk.DeployTransactionSpecification{
+		TransactionId: "tx1",
+		ConstructorParamsJson: `{
+			"notary": "notary@node1",
+			"notaryMode": "basic",
+			"options": {
+				"basic": {
+					"mintPolicy": {
+						"maxSupply": "1000",
+						"minters": ["minter1", "minter2@node2"],
+						"minterQuotas": [{"minter": "minter1", "quota": "100"}]
+					}
+				}
+			}
+		}`,
+	}
+
+	_, err := n.InitDeploy(ctx, &prototk.InitDeployRequest{
+		Transaction: deployTransaction,
+	})
+	require.NoError(t, err)
+
+	prepareDeployRes, err := n.PrepareDeploy(ctx, &prototk.PrepareDeployRequest{
+		Transaction: deployTransaction,
+		ResolvedVerifiers: []*prototk.ResolvedVerifier{
+			{
+				Lookup:       "notary@node1",
+				Algorithm:    algorithms.ECDSA_SECP256K1,
+				VerifierType: verifiers.ETH_ADDRESS,
+				Verifier:     "0x6e2430d15301a7ee28ceaaee0dff9781f8f82f71",
+			},
+		},
+	})
+	require.NoError(t, err)
+	var deployParams map[string]any
+	err = json.Unmarshal([]byte(prepareDeployRes.Transaction.ParamsJson), &deployParams)
+	require.NoError(t, err)
+	deployData := pldtypes.MustParseHexBytes(deployParams["data"].(string))
+	assert.JSONEq(t, `{
+		"notaryLookup": "notary@node1",
+		"notaryMode": "0x0",
+		"privateAddress": null,
+		"privateGroup": null,
+		"restrictMint": true,
+		"allowBurn": true,
+		"allowLock": true,
+		"mintPolicy": {
+			"maxSupply": "0x03e8",
+			"minters": ["minter1@node1", "minter2@node2"],
+			"minterQuotas": [{"minter": "minter1@node1", "quota": "0x64"}]
+		}
+	}`, string(deployData))
+
+	var configData types.NotoConfigData_V0
+	err = json.Unmarshal(deployData, &configData)
+	require.NoError(t, err)
+	initContractRes, err := n.InitContract(ctx, &prototk.InitContractRequest{
+		ContractAddress: "0xf6a75f065db3cef95de7aa786eee1d0cb1aeafc3",
+		ContractConfig:  encodedConfig(&configData),
+	})
+	require.NoError(t, err)
+	require.True(t, initContractRes.Valid)
+	var parsedConfig types.NotoParsedConfig
+	err = json.Unmarshal([]byte(initContractRes.ContractConfig.ContractConfigJson), &parsedConfig)
+	require.NoError(t, err)
+	assert.Equal(t, configData.MintPolicy, parsedConfig.Options.Basic.MintPolicy)
+}
+
+func TestInitDeployBadMintPolicy(t *testing.T) {
+	n := &Noto{Callbacks: mockCallbacks}
+	_, err := n.InitDeploy(context.Background(), &prototk.InitDeployRequest{
+		Transaction: &prototk.DeployTransactionSpecification{
+			ConstructorParamsJson: `{
+				"notary": "notary@node1",
+				"notaryMode": "basic",
+				"options": {"basic": {"mintPolicy": {"minterQuotas": [{"minter": "", "quota": "1"}]}}}
+			}`,
+		},
+	})
+	assert.Regexp(t, "PD200007.*minterQuotas\\[0\\].minter", err)
+
+	_, err = n.InitDeploy(context.Background(), &prototk.InitDeployRequest{
+		Transaction: &prototk.DeployTransactionSpecification{
+			ConstructorParamsJson: `{
+				"notary": "notary@node1",
+				"notaryMode": "basic",
+				"options": {"basic": {"mintPolicy": {"minterQuotas": [{"minter": "minter1", "quota": "0"}]}}}
+			}`,
+		},
+	})
+	assert.Regexp(t, "PD200008.*minterQuotas\\[0\\].quota", err)
+}
+
+func TestNotoDomainDeployNonFungible(t *testing.T) {
+	n := &Noto{Callbacks: mockCallbacks}
+	ctx := context.Background()
+
+	deployTransaction := &prototk.DeployTransactionSpecification{
+		TransactionId: "tx1",
+		ConstructorParamsJson: `{
+			"notary": "notary@node1",
+			"notaryMode": "basic",
+			"options": {
+				"basic": {
+					"nonFungible": true,
+					"mintPolicy": {"minters": ["minter1"]}
+				}
+			}
+		}`,
+	}
+
+	_, err := n.InitDeploy(ctx, &prototk.InitDeployRequest{
+		Transaction: deployTransaction,
+	})
+	require.NoError(t, err)
+
+	prepareDeployRes, err := n.PrepareDeploy(ctx, &prototk.PrepareDeployRequest{
+		Transaction: deployTransaction,
+		ResolvedVerifiers: []*prototk.ResolvedVerifier{
+			{
+				Lookup:       "notary@node1",
+				Algorithm:    algorithms.ECDSA_SECP256K1,
+				VerifierType: verifiers.ETH_ADDRESS,
+				Verifier:     "0x6e2430d15301a7ee28ceaaee0dff9781f8f82f71",
+			},
+		},
+	})
+	require.NoError(t, err)
+	var deployParams map[string]any
+	err = json.Unmarshal([]byte(prepareDeployRes.Transaction.ParamsJson), &deployParams)
+	require.NoError(t, err)
+	deployData := pldtypes.MustParseHexBytes(deployParams["data"].(string))
+	assert.JSONEq(t, `{
+		"notaryLookup": "notary@node1",
+		"notaryMode": "0x0",
+		"privateAddress": null,
+		"privateGroup": null,
+		"restrictMint": true,
+		"allowBurn": true,
+		"allowLock": true,
+		"mintPolicy": {"minters": ["minter1@node1"]},
+		"nonFungible": true
+	}`, string(deployData))
+
+	var configData types.NotoConfigData_V0
+	err = json.Unmarshal(deployData, &configData)
+	require.NoError(t, err)
+	initContractRes, err := n.InitContract(ctx, &prototk.InitContractRequest{
+		ContractAddress: "0xf6a75f065db3cef95de7aa786eee1d0cb1aeafc3",
+		ContractConfig:  encodedConfig(&configData),
+	})
+	require.NoError(t, err)
+	require.True(t, initContractRes.Valid)
+	var parsedConfig types.NotoParsedConfig
+	err = json.Unmarshal([]byte(initContractRes.ContractConfig.ContractConfigJson), &parsedConfig)
+	require.NoError(t, err)
+	assert.True(t, parsedConfig.IsNonFungible())
+}
+
+func TestInitDeployNonFungibleMintPolicy(t *testing.T) {
+	n := &Noto{Callbacks: mockCallbacks}
+	_, err := n.InitDeploy(context.Background(), &prototk.InitDeployRequest{
+		Transaction: &prototk.DeployTransactionSpecification{
+			ConstructorParamsJson: `{
+				"notary": "notary@node1",
+				"notaryMode": "basic",
+				"options": {"basic": {"nonFungible": true, "mintPolicy": {"maxSupply": "10"}}}
+			}`,
+		},
+	})
+	assert.Regexp(t, "PD200042", err)
+}
+
+func TestNotoDomainDeployTransferFee(t *testing.T) {
+	n := &Noto{Callbacks: mockCallbacks}
+	ctx := context.Background()
+
+	deployTransaction := &prototk.DeployTransactionSpecification{
+		TransactionId: "tx1",
+		ConstructorParamsJson: `{
+			"notary": "notary@node1",
+			"notaryMode": "basic",
+			"options": {
+				"basic": {
+					"transferFee": {"recipient": "operator", "basisPoints": 25, "flatFee": "0"}
+				}
+			}
+		}`,
+	}
+
+	_, err := n.InitDeploy(ctx, &prototk.InitDeployRequest{
+		Transaction: deployTransaction,
+	})
+	require.NoError(t, err)
+
+	prepareDeployRes, err := n.PrepareDeploy(ctx, &prototk.PrepareDeployRequest{
+		Transaction: deployTransaction,
+		ResolvedVerifiers: []*prototk.ResolvedVerifier{
+			{
+				Lookup:       "notary@node1",
+				Algorithm:    algorithms.ECDSA_SECP256K1,
+				VerifierType: verifiers.ETH_ADDRESS,
+				Verifier:     "0x6e2430d15301a7ee28ceaaee0dff9781f8f82f71",
+			},
+		},
+	})
+	require.NoError(t, err)
+	var deployParams map[string]any
+	err = json.Unmarshal([]byte(prepareDeployRes.Transaction.ParamsJson), &deployParams)
+	require.NoError(t, err)
+	deployData := pldtypes.MustParseHexBytes(deployParams["data"].(string))
+	assert.JSONEq(t, `{
+		"notaryLookup": "notary@node1",
+		"notaryMode": "0x0",
+		"privateAddress": null,
+		"privateGroup": null,
+		"restrictMint": true,
+		"allowBurn": true,
+		"allowLock": true,
+		"transferFee": {"recipient": "operator@node1", "basisPoints": "0x19"}
+	}`, string(deployData))
+
+	var configData types.NotoConfigData_V0
+	err = json.Unmarshal(deployData, &configData)
+	require.NoError(t, err)
+	initContractRes, err := n.InitContract(ctx, &prototk.InitContractRequest{
+		ContractAddress: "0xf6a75f065db3cef95de7aa786eee1d0cb1aeafc3",
+		ContractConfig:  encodedConfig(&configData),
+	})
+	require.NoError(t, err)
+	require.True(t, initContractRes.Valid)
+	var parsedConfig types.NotoParsedConfig
+	err = json.Unmarshal([]byte(initContractRes.ContractConfig.ContractConfigJson), &parsedConfig)
+	require.NoError(t, err)
+	assert.Equal(t, configData.TransferFee, parsedConfig.Options.Basic.TransferFee)
+}
+
+func TestInitDeployBadTransferFee(t *testing.T) {
+	n := &Noto{Callbacks: mockCallbacks}
+	initDeploy := func(basic string) error {
+		_, err := n.InitDeploy(context.Background(), &prototk.InitDeployRequest{
+			Transaction: &prototk.DeployTransactionSpecification{
+				ConstructorParamsJson: `{
+					"notary": "notary@node1",
+					"notaryMode": "basic",
+					"options": {"basic": ` + basic + `}
+				}`,
+			},
+		})
+		return err
+	}
+
+	err := initDeploy(`{"transferFee": {"recipient": "", "basisPoints": 0, "flatFee": "0"}}`)
+	assert.NoError(t, err)
+
+	err = initDeploy(`{"transferFee": {"basisPoints": 10}}`)
+	assert.Regexp(t, "PD200007.*transferFee.recipient", err)
+
+	err = initDeploy(`{"transferFee": {"recipient": "operator", "basisPoints": 10001}}`)
+	assert.Regexp(t, "PD200045", err)
+
+	err = initDeploy(`{"nonFungible": true, "transferFee": {"recipient": "operator", "basisPoints": 10}}`)
+	assert.Regexp(t, "PD200044", err)
+}
+
+func TestQualifyMintPolicyNoCap(t *testing.T) {
+	n := &Noto{Callbacks: mockCallbacks}
+	policy, err := n.qualifyMintPolicy(context.Background(), &types.NotoMintPolicy{
+		MaxSupply: pldtypes.Uint64ToUint256(0),
+		Minters:   []string{},
+	}, "node1")
+	require.NoError(t, err)
+	assert.Equal(t, &types.NotoMintPolicy{}, policy)
+}
+
+func TestPrepareDeployBadMinter(t *testing.T) {
+	n := &Noto{Callbacks: mockCallbacks}
+	_, err := n.PrepareDeploy(context.Background(), &prototk.PrepareDeployRequest{
+		Transaction: &prototk.DeployTransactionSpecification{
+			ConstructorParamsJson: `{
+				"notary": "notary@node1",
+				"notaryMode": "basic",
+				"options": {"basic": {"mintPolicy": {"minters": ["bad@@node"]}}}
+			}`,
+		},
+		ResolvedVerifiers: []*prototk.ResolvedVerifier{
+			{
+				Lookup:       "notary@node1",
+				Algorithm:    algorithms.ECDSA_SECP256K1,
+				VerifierType: verifiers.ETH_ADDRESS,
+				Verifier:     "0x6e2430d15301a7ee28ceaaee0dff9781f8f82f71",
+			},
+		},
+	})
+	assert.Error(t, err)
+}
+
 func TestNotoDomainDeployHooksConfig(t *testing.T) {
 	n := &Noto{Callbacks: mockCallbacks}
 	ctx := context.Background()
@@ -20,6 +20,7 @@ import (
 	"encoding/json"
 	"math/big"
 	"slices"
+	"strings"
 
 	"github.com/hyperledger/firefly-signer/pkg/eip712"
 	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
@@ -55,6 +56,13 @@ var NotoLockedCoinType = eip712.Type{
 	{Name: "amount", Type: "uint256"},
 }
 
+var NotoNFTType = eip712.Type{
+	{Name: "salt", Type: "bytes32"},
+	{Name: "tokenId", Type: "uint256"},
+	{Name: "owner", Type: "address"},
+	{Name: "uri", Type: "string"},
+}
+
 var NotoTransferUnmaskedTypeSet = eip712.TypeSet{
 	"Transfer": {
 		{Name: "inputs", Type: "Coin[]"},
@@ -64,6 +72,15 @@ var NotoTransferUnmaskedTypeSet = eip712.TypeSet{
 	eip712.EIP712Domain: EIP712DomainType,
 }
 
+var NotoNFTTransferTypeSet = eip712.TypeSet{
+	"NFTTransfer": {
+		{Name: "inputs", Type: "NFT[]"},
+		{Name: "outputs", Type: "NFT[]"},
+	},
+	"NFT":               NotoNFTType,
+	eip712.EIP712Domain: EIP712DomainType,
+}
+
 var NotoTransferMaskedTypeSet = eip712.TypeSet{
 	"Transfer": {
 		{Name: "inputs", Type: "bytes32[]"},
@@ -105,6 +122,20 @@ var NotoUnlockMaskedTypeSet = eip712.TypeSet{
 	eip712.EIP712Domain: EIP712DomainType,
 }
 
+var NotoLockExpiryTypeSet = eip712.TypeSet{
+	"LockExpiry": {
+		{Name: "lockId", Type: "bytes32"},
+		{Name: "expiry", Type: "uint256"},
+		{Name: "beneficiary", Type: "address"},
+		{Name: "lockedInputs", Type: "LockedCoin[]"},
+		{Name: "outputs", Type: "Coin[]"},
+		{Name: "data", Type: "bytes"},
+	},
+	"LockedCoin":        NotoLockedCoinType,
+	"Coin":              NotoCoinType,
+	eip712.EIP712Domain: EIP712DomainType,
+}
+
 var NotoDelegateLockTypeSet = eip712.TypeSet{
 	"DelegateLock": {
 		{Name: "lockId", Type: "bytes32"},
@@ -126,12 +157,24 @@ func (n *Noto) unmarshalLockedCoin(stateData string) (*types.NotoLockedCoin, err
 	return &coin, err
 }
 
+func (n *Noto) unmarshalNFT(stateData string) (*types.NotoNFT, error) {
+	var nft types.NotoNFT
+	err := json.Unmarshal([]byte(stateData), &nft)
+	return &nft, err
+}
+
 func (n *Noto) unmarshalInfo(stateData string) (*types.TransactionData, error) {
 	var info types.TransactionData
 	err := json.Unmarshal([]byte(stateData), &info)
 	return &info, err
 }
 
+func (n *Noto) unmarshalMintRecord(stateData string) (*types.NotoMintRecord, error) {
+	var record types.NotoMintRecord
+	err := json.Unmarshal([]byte(stateData), &record)
+	return &record, err
+}
+
 func (n *Noto) unmarshalLock(stateData string) (*types.NotoLockInfo, error) {
 	var lock types.NotoLockInfo
 	err := json.Unmarshal([]byte(stateData), &lock)
@@ -337,6 +380,106 @@ func (n *Noto) prepareLockedOutputs(id pldtypes.Bytes32, ownerAddress *pldtypes.
 	}, err
 }
 
+func (n *Noto) prepareNFTOutput(tokenID *pldtypes.HexUint256, ownerAddress *pldtypes.EthAddress, uri string, distributionList []string) (*types.NotoNFT, *prototk.NewState, error) {
+	nft := &types.NotoNFT{
+		Salt:    pldtypes.RandBytes32(),
+		TokenID: tokenID,
+		Owner:   ownerAddress,
+		URI:     uri,
+	}
+	nftJSON, err := json.Marshal(nft)
+	if err != nil {
+		return nil, nil, err
+	}
+	return nft, &prototk.NewState{
+		SchemaId:         n.nftSchema.Id,
+		StateDataJson:    string(nftJSON),
+		DistributionList: distributionList,
+	}, nil
+}
+
+// Find the unspent state for a token ID, optionally only if held by the given owner.
+// There is at most one, as every transfer spends the previous state.
+func (n *Noto) findNFT(ctx context.Context, stateQueryContext string, tokenID *pldtypes.HexUint256, owner *pldtypes.EthAddress, excludeIDs ...string) (*prototk.StoredState, *types.NotoNFT, error) {
+	queryBuilder := query.NewQueryBuilder().
+		Limit(10).
+		Sort(".created").
+		Equal("tokenId", tokenID)
+	if owner != nil {
+		queryBuilder.Equal("owner", owner.String())
+	}
+
+	log.L(ctx).Debugf("State query: %s", queryBuilder.Query())
+	states, err := n.findAvailableStates(ctx, stateQueryContext, n.nftSchema.Id, queryBuilder.Query().String())
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, state := range states {
+		if slices.ContainsFunc(excludeIDs, func(id string) bool { return strings.EqualFold(id, state.Id) }) {
+			continue
+		}
+		nft, err := n.unmarshalNFT(state.DataJson)
+		if err != nil {
+			return nil, nil, i18n.NewError(ctx, msgs.MsgInvalidStateData, state.Id, err)
+		}
+		return state, nft, nil
+	}
+	return nil, nil, nil
+}
+
+func (n *Noto) prepareMintRecord(minter *pldtypes.EthAddress, amount *pldtypes.HexUint256, distributionList []string) (*prototk.NewState, error) {
+	recordJSON, err := json.Marshal(&types.NotoMintRecord{
+		Salt:   pldtypes.RandBytes32(),
+		Minter: minter,
+		Amount: amount,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &prototk.NewState{
+		SchemaId:         n.mintRecordSchema.Id,
+		StateDataJson:    string(recordJSON),
+		DistributionList: distributionList,
+	}, nil
+}
+
+// Total the amount minted across all mint records (optionally for a single minter), excluding the given record.
+// Only the notary is guaranteed to receive every record.
+func (n *Noto) sumMintRecords(ctx context.Context, stateQueryContext string, minter *pldtypes.EthAddress, excludeID string) (*big.Int, error) {
+	var lastStateTimestamp int64
+	total := big.NewInt(0)
+	for {
+		queryBuilder := query.NewQueryBuilder().
+			Limit(100).
+			Sort(".created")
+		if minter != nil {
+			queryBuilder.Equal("minter", minter.String())
+		}
+		if lastStateTimestamp > 0 {
+			queryBuilder.GreaterThan(".created", lastStateTimestamp)
+		}
+
+		states, err := n.findAvailableStates(ctx, stateQueryContext, n.mintRecordSchema.Id, queryBuilder.Query().String())
+		if err != nil {
+			return nil, err
+		}
+		if len(states) == 0 {
+			return total, nil
+		}
+		for _, state := range states {
+			lastStateTimestamp = state.CreatedAt
+			if strings.EqualFold(state.Id, excludeID) {
+				continue
+			}
+			record, err := n.unmarshalMintRecord(state.DataJson)
+			if err != nil {
+				return nil, i18n.NewError(ctx, msgs.MsgInvalidStateData, state.Id, err)
+			}
+			total = total.Add(total, record.Amount.Int())
+		}
+	}
+}
+
 func (n *Noto) prepareInfo(data pldtypes.HexBytes, distributionList []string) ([]*prototk.NewState, error) {
 	newData := &types.TransactionData{
 		Salt: pldtypes.RandHex(32),
@@ -433,6 +576,19 @@ func (n *Noto) encodeNotoLockedCoins(coins []*types.NotoLockedCoin) []any {
 	return encodedCoins
 }
 
+func (n *Noto) encodeNotoNFTs(nfts []*types.NotoNFT) []any {
+	encodedNFTs := make([]any, len(nfts))
+	for i, nft := range nfts {
+		encodedNFTs[i] = map[string]any{
+			"salt":    nft.Salt,
+			"tokenId": nft.TokenID.String(),
+			"owner":   nft.Owner,
+			"uri":     nft.URI,
+		}
+	}
+	return encodedNFTs
+}
+
 func encodedStateIDs(states []*pldapi.StateEncoded) []string {
 	inputs := make([]string, len(states))
 	for i, state := range states {
@@ -469,6 +625,18 @@ func (n *Noto) encodeTransferUnmasked(ctx context.Context, contract *ethtypes.Ad
 	})
 }
 
+func (n *Noto) encodeNFTTransfer(ctx context.Context, contract *ethtypes.Address0xHex, inputs, outputs []*types.NotoNFT) (ethtypes.HexBytes0xPrefix, error) {
+	return eip712.EncodeTypedDataV4(ctx, &eip712.TypedData{
+		Types:       NotoNFTTransferTypeSet,
+		PrimaryType: "NFTTransfer",
+		Domain:      n.eip712Domain(contract),
+		Message: map[string]any{
+			"inputs":  n.encodeNotoNFTs(inputs),
+			"outputs": n.encodeNotoNFTs(outputs),
+		},
+	})
+}
+
 func (n *Noto) encodeTransferMasked(ctx context.Context, contract *ethtypes.Address0xHex, inputs, outputs []*pldapi.StateEncoded, data pldtypes.HexBytes) (ethtypes.HexBytes0xPrefix, error) {
 	return eip712.EncodeTypedDataV4(ctx, &eip712.TypedData{
 		Types:       NotoTransferMaskedTypeSet,
@@ -538,3 +706,19 @@ func (n *Noto) encodeDelegateLock(ctx context.Context, contract *ethtypes.Addres
 		},
 	})
 }
+
+func (n *Noto) encodeLockExpiry(ctx context.Context, contract *ethtypes.Address0xHex, lockID pldtypes.Bytes32, expiry pldtypes.HexUint64, beneficiary *pldtypes.EthAddress, lockedInputs []*types.NotoLockedCoin, outputs []*types.NotoCoin, data pldtypes.HexBytes) (ethtypes.HexBytes0xPrefix, error) {
+	return eip712.EncodeTypedDataV4(ctx, &eip712.TypedData{
+		Types:       NotoLockExpiryTypeSet,
+		PrimaryType: "LockExpiry",
+		Domain:      n.eip712Domain(contract),
+		Message: map[string]any{
+			"lockId":       lockID,
+			"expiry":       expiry.String(),
+			"beneficiary":  beneficiary,
+			"lockedInputs": n.encodeNotoLockedCoins(lockedInputs),
+			"outputs":      n.encodeNotoCoins(outputs),
+			"data":         data,
+		},
+	})
+}
@@ -28,6 +28,7 @@ type Noto interface {
 	CoinSchemaID() string
 	LockedCoinSchemaID() string
 	LockInfoSchemaID() string
+	NFTSchemaID() string
 }
 
 func New(callbacks plugintk.DomainCallbacks) Noto {
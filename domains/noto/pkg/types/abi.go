@@ -66,6 +66,19 @@ type TransferParams struct {
 	Data   pldtypes.HexBytes    `json:"data"`
 }
 
+type MintNFTParams struct {
+	To      string               `json:"to"`
+	TokenID *pldtypes.HexUint256 `json:"tokenId"`
+	URI     string               `json:"uri"`
+	Data    pldtypes.HexBytes    `json:"data"`
+}
+
+type TransferNFTParams struct {
+	To      string               `json:"to"`
+	TokenID *pldtypes.HexUint256 `json:"tokenId"`
+	Data    pldtypes.HexBytes    `json:"data"`
+}
+
 type BurnParams struct {
 	Amount *pldtypes.HexUint256 `json:"amount"`
 	Data   pldtypes.HexBytes    `json:"data"`
@@ -97,6 +110,14 @@ type DelegateLockParams struct {
 	Data     pldtypes.HexBytes    `json:"data"`
 }
 
+type SetLockExpiryParams struct {
+	LockID      pldtypes.Bytes32    `json:"lockId"`
+	Unlock      *UnlockPublicParams `json:"unlock"`
+	Expiry      pldtypes.HexUint64  `json:"expiry"`
+	Beneficiary string              `json:"beneficiary"`
+	Data        pldtypes.HexBytes   `json:"data"`
+}
+
 type UnlockRecipient struct {
 	To     string               `json:"to"`
 	Amount *pldtypes.HexUint256 `json:"amount"`
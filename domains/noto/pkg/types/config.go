@@ -16,6 +16,8 @@
 package types
 
 import (
+	"math/big"
+
 	"github.com/hyperledger/firefly-signer/pkg/abi"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
 	"github.com/kaleido-io/paladin/toolkit/pkg/domain"
@@ -43,6 +45,9 @@ type NotoConfigData_V0 struct {
 	RestrictMint   bool                 `json:"restrictMint"`
 	AllowBurn      bool                 `json:"allowBurn"`
 	AllowLock      bool                 `json:"allowLock"`
+	MintPolicy     *NotoMintPolicy      `json:"mintPolicy,omitempty"`
+	NonFungible    bool                 `json:"nonFungible,omitempty"`
+	TransferFee    *NotoTransferFee     `json:"transferFee,omitempty"`
 }
 
 // This is the structure we parse the config into in InitConfig and gets passed back to us on every call
@@ -60,9 +65,48 @@ type NotoOptions struct {
 }
 
 type NotoBasicOptions struct {
-	RestrictMint *bool `json:"restrictMint"` // Only allow notary to mint (default: true)
-	AllowBurn    *bool `json:"allowBurn"`    // Allow token holders to burn their tokens (default: true)
-	AllowLock    *bool `json:"allowLock"`    // Allow token holders to lock their tokens (default: true)
+	RestrictMint *bool            `json:"restrictMint"`          // Only allow notary to mint (default: true)
+	AllowBurn    *bool            `json:"allowBurn"`             // Allow token holders to burn their tokens (default: true)
+	AllowLock    *bool            `json:"allowLock"`             // Allow token holders to lock their tokens (default: true)
+	MintPolicy   *NotoMintPolicy  `json:"mintPolicy,omitempty"`  // Constrain who can mint, and how much (default: unconstrained)
+	NonFungible  *bool            `json:"nonFungible,omitempty"` // Issue unique tokens with a metadata URI, using mintNFT/transferNFT (default: false)
+	TransferFee  *NotoTransferFee `json:"transferFee,omitempty"` // Charge a fee on each transfer, paid to a fee recipient (default: no fee)
+}
+
+// Non-fungible mode is only available to basic notaries
+func (c *NotoParsedConfig) IsNonFungible() bool {
+	return c.Options.Basic != nil && c.Options.Basic.NonFungible != nil && *c.Options.Basic.NonFungible
+}
+
+// Mint policies are enforced by the notary. Amounts are private, so the base ledger only sees
+// an extra opaque record state on each mint under a policy with a supply cap or quotas.
+type NotoMintPolicy struct {
+	MaxSupply    *pldtypes.HexUint256 `json:"maxSupply,omitempty"`    // Total amount that can ever be minted (burns do not free up supply, zero means no cap)
+	Minters      []string             `json:"minters,omitempty"`      // Lookups allowed to mint in addition to the notary (when set, overrides restrictMint)
+	MinterQuotas []*NotoMinterQuota   `json:"minterQuotas,omitempty"` // Total amount that individual minters can ever mint
+}
+
+// The fee on a transfer is flatFee + (amount * basisPoints / 10000), rounded down, and is paid by the sender
+// in addition to the amount transferred. The notary checks the fee recipient receives it as an extra output.
+type NotoTransferFee struct {
+	Recipient   string               `json:"recipient"`             // Lookup of the identity that receives the fees
+	BasisPoints pldtypes.HexUint64   `json:"basisPoints,omitempty"` // Fee proportional to the amount, in hundredths of a percent
+	FlatFee     *pldtypes.HexUint256 `json:"flatFee,omitempty"`     // Fixed fee added to every transfer
+}
+
+// Calculate the fee due on a transfer of the given amount
+func (f *NotoTransferFee) Calculate(amount *big.Int) *big.Int {
+	fee := new(big.Int).Mul(amount, new(big.Int).SetUint64(f.BasisPoints.Uint64()))
+	fee.Div(fee, big.NewInt(10000))
+	if f.FlatFee != nil {
+		fee.Add(fee, f.FlatFee.Int())
+	}
+	return fee
+}
+
+type NotoMinterQuota struct {
+	Minter string               `json:"minter"`
+	Quota  *pldtypes.HexUint256 `json:"quota"`
 }
 
 type NotoHooksOptions struct {
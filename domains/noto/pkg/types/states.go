@@ -125,6 +125,53 @@ var NotoLockInfoABI = &abi.Parameter{
 	},
 }
 
+type NotoNFTState struct {
+	ID              pldtypes.Bytes32    `json:"id"`
+	Created         pldtypes.Timestamp  `json:"created"`
+	ContractAddress pldtypes.EthAddress `json:"contractAddress"`
+	Data            NotoNFT             `json:"data"`
+}
+
+// A unique token on a non-fungible Noto contract. Each transfer spends the current state
+// and creates a new one for the recipient, with the same token ID and URI.
+type NotoNFT struct {
+	Salt    pldtypes.Bytes32     `json:"salt"`
+	TokenID *pldtypes.HexUint256 `json:"tokenId"`
+	Owner   *pldtypes.EthAddress `json:"owner"`
+	URI     string               `json:"uri"`
+}
+
+var NotoNFTABI = &abi.Parameter{
+	Name:         "NotoNFT",
+	Type:         "tuple",
+	InternalType: "struct NotoNFT",
+	Components: abi.ParameterArray{
+		{Name: "salt", Type: "bytes32"},
+		{Name: "tokenId", Type: "uint256", Indexed: true},
+		{Name: "owner", Type: "string", Indexed: true},
+		{Name: "uri", Type: "string"},
+	},
+}
+
+// Recorded by mints under a mint policy, so the notary can total the amounts minted.
+// Unlike the coin states, these are never spent.
+type NotoMintRecord struct {
+	Salt   pldtypes.Bytes32     `json:"salt"`
+	Minter *pldtypes.EthAddress `json:"minter"`
+	Amount *pldtypes.HexUint256 `json:"amount"`
+}
+
+var NotoMintRecordABI = &abi.Parameter{
+	Name:         "NotoMintRecord",
+	Type:         "tuple",
+	InternalType: "struct NotoMintRecord",
+	Components: abi.ParameterArray{
+		{Name: "salt", Type: "bytes32"},
+		{Name: "minter", Type: "address", Indexed: true},
+		{Name: "amount", Type: "uint256"},
+	},
+}
+
 type TransactionData struct {
 	Salt string            `json:"salt"`
 	Data pldtypes.HexBytes `json:"data"`
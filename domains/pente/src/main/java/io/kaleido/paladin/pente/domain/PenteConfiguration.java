@@ -19,6 +19,7 @@
  import com.fasterxml.jackson.annotation.JsonInclude;
  import com.fasterxml.jackson.annotation.JsonProperty;
  import com.fasterxml.jackson.databind.JsonNode;
+ import com.fasterxml.jackson.databind.ObjectMapper;
  import io.kaleido.paladin.logging.PaladinLogging;
  import io.kaleido.paladin.toolkit.*;
  import io.kaleido.paladin.toolkit.JsonHex.Address;
@@ -34,6 +35,7 @@
  import org.web3j.abi.datatypes.generated.Uint256;
  import org.web3j.abi.datatypes.reflection.Parameterized;
  
+ import java.io.IOException;
  import java.nio.ByteBuffer;
  import java.util.ArrayList;
  import java.util.List;
@@ -69,6 +71,10 @@
      private String schemaId_AccountState_v24_10_0;
  
      private String schemaId_TransactionInfoState_v24_10_0;
+
+     private String schemaId_ExternalReadSet_v25_1_0;
+
+     private JsonRpcClient baseLedgerRPC;
  
      PenteConfiguration() {
          try {
@@ -96,6 +102,21 @@
          }
      }
  
+     @JsonIgnoreProperties(ignoreUnknown = true)
+     record BaseLedgerConfigJSON(
+             @JsonProperty
+             String url
+     ) {
+     }
+
+     /** The configuration supplied for the domain by the Paladin administrator */
+     @JsonIgnoreProperties(ignoreUnknown = true)
+     record DomainConfigJSON(
+             @JsonProperty
+             BaseLedgerConfigJSON baseLedger
+     ) {
+     }
+
      @JsonIgnoreProperties(ignoreUnknown = true)
     public record GroupTupleJSON(
              @JsonProperty
@@ -190,6 +211,31 @@
          ));
      }
  
+     JsonABI.Parameter abiTuple_ExternalReadSet_v25_1_0() {
+         var storageRead = JsonABI.newParameters(
+                 JsonABI.newParameter("key", "bytes32"),
+                 JsonABI.newParameter("value", "uint256"),
+                 JsonABI.newParameter("proof", "bytes[]")
+         );
+         var accountRead = JsonABI.newParameters(
+                 JsonABI.newParameter("address", "address"),
+                 JsonABI.newParameter("nonce", "uint256"),
+                 JsonABI.newParameter("balance", "uint256"),
+                 JsonABI.newParameter("codeHash", "bytes32"),
+                 JsonABI.newParameter("storageHash", "bytes32"),
+                 JsonABI.newParameter("code", "bytes"),
+                 JsonABI.newParameter("accountProof", "bytes[]"),
+                 JsonABI.newTupleArray("storage", "StorageRead", storageRead)
+         );
+         return JsonABI.newTuple("ExternalReadSet_v25_1_0", "ExternalReadSet_v25_1_0", JsonABI.newParameters(
+                 JsonABI.newParameter("salt", "bytes32"),
+                 JsonABI.newParameter("blockNumber", "uint64"),
+                 JsonABI.newParameter("blockHash", "bytes32"),
+                 JsonABI.newParameter("stateRoot", "bytes32"),
+                 JsonABI.newTupleArray("accounts", "AccountRead", accountRead)
+         ));
+     }
+
      record NewPrivacyGroupFactoryParams(
              @JsonProperty()
              Bytes32 transactionId,
@@ -313,15 +359,27 @@
          return domainName;
      }
  
-     synchronized void initFromConfig(ConfigureDomainRequest configReq) {
+     synchronized JsonRpcClient getBaseLedgerRPC() {
+         return baseLedgerRPC;
+     }
+
+     synchronized void initFromConfig(ConfigureDomainRequest configReq) throws IOException {
          this.domainName = configReq.getName();
          this.chainId = configReq.getChainId();
+         if (!configReq.getConfigJson().isBlank()) {
+             var domainConfig = new ObjectMapper().readValue(configReq.getConfigJson(), DomainConfigJSON.class);
+             if (domainConfig.baseLedger() != null && domainConfig.baseLedger().url() != null) {
+                 // Reads of base ledger state from private transactions are only enabled when configured
+                 this.baseLedgerRPC = new JsonRpcClient(domainConfig.baseLedger().url());
+             }
+         }
      }
  
      List<String> allPenteSchemas() {
          return List.of(
                  abiTuple_AccountState_v24_10_0().toString(),
-                 abiTuple_TransactionInfoState_v24_10_0().toString()
+                 abiTuple_TransactionInfoState_v24_10_0().toString(),
+                 abiTuple_ExternalReadSet_v25_1_0().toString()
          );
      }
  
@@ -334,6 +392,8 @@
          schemaId_AccountState_v24_10_0 = schema.getId();
          schema = schemas.get(1);
          schemaId_TransactionInfoState_v24_10_0 = schema.getId();
+         schema = schemas.get(2);
+         schemaId_ExternalReadSet_v25_1_0 = schema.getId();
      }
  
      synchronized String schemaId_AccountStateLatest() {
@@ -343,6 +403,10 @@
      synchronized String schemaId_TransactionInputStateLatest() {
          return schemaId_TransactionInfoState_v24_10_0;
      }
+
+     synchronized String schemaId_ExternalReadSetLatest() {
+         return schemaId_ExternalReadSet_v25_1_0;
+     }
  
  }
  
\ No newline at end of file
@@ -24,9 +24,7 @@
  import com.google.protobuf.ByteString;
  import io.kaleido.paladin.logging.PaladinLogging;
  import io.kaleido.paladin.pente.evmrunner.EVMRunner;
- import io.kaleido.paladin.pente.evmstate.AccountLoader;
- import io.kaleido.paladin.pente.evmstate.DynamicLoadWorldState;
- import io.kaleido.paladin.pente.evmstate.PersistedAccount;
+ import io.kaleido.paladin.pente.evmstate.*;
  import io.kaleido.paladin.toolkit.*;
  import io.kaleido.paladin.toolkit.JsonHex.Address;
  import io.kaleido.paladin.toolkit.JsonHex.Bytes;
@@ -53,18 +51,22 @@
 
      @Override
      protected CompletableFuture<ConfigureDomainResponse> configureDomain(ConfigureDomainRequest request) {
-         // The in-memory config is late initialized here (and does so in its lock so access from any thread
-         // we get called on for this and subsequent gRPC calls is safe).
-         config.initFromConfig(request);
-
-         var domainConfig = DomainConfig.newBuilder()
-                 .addAllAbiStateSchemasJson(config.allPenteSchemas())
-                 .setAbiEventsJson(config.getEventsABI().toString())
-                 .build();
-         return CompletableFuture.completedFuture(ConfigureDomainResponse.newBuilder()
-                 .setDomainConfig(domainConfig)
-                 .build()
-         );
+         try {
+             // The in-memory config is late initialized here (and does so in its lock so access from any thread
+             // we get called on for this and subsequent gRPC calls is safe).
+             config.initFromConfig(request);
+
+             var domainConfig = DomainConfig.newBuilder()
+                     .addAllAbiStateSchemasJson(config.allPenteSchemas())
+                     .setAbiEventsJson(config.getEventsABI().toString())
+                     .build();
+             return CompletableFuture.completedFuture(ConfigureDomainResponse.newBuilder()
+                     .setDomainConfig(domainConfig)
+                     .build()
+             );
+         } catch (Exception e) {
+             return CompletableFuture.failedFuture(e);
+         }
      }
 
      @Override
@@ -242,8 +244,9 @@
 
              // Execution throws an EVMExecutionException if fails
              var accountLoader = new AssemblyAccountLoader(request.getStateQueryContext());
+             var externalReader = newExternalStateReader(tx.getBaseBlock());
              var ethTxn = new PenteEVMTransaction(this, tx, tx.getFromVerifier(request.getResolvedVerifiersList()));
-             var execResult = ethTxn.invokeEVM(accountLoader);
+             var execResult = ethTxn.invokeEVM(withExternalState(accountLoader, externalReader));
              var result = AssembleTransactionResponse.newBuilder();
 
              // Just like a base Eth transaction, we need a signed and encoded transaction for endorser verification.
@@ -252,7 +255,8 @@
              // available for selection as an input to a transaction. It exists only to be emitted as part of the
              // event from the transaction where it is used.
              var encodedTxn = tx.getSignedRawTransaction(ethTxn);
-             var assembledTransaction = tx.buildAssembledTransaction(execResult.evm(), accountLoader, ethTxn, encodedTxn, buildDomainData(execResult));
+             var readSet = externalReader == null ? null : externalReader.getReadSet();
+             var assembledTransaction = tx.buildAssembledTransaction(execResult.evm(), accountLoader, ethTxn, encodedTxn, buildDomainData(execResult), readSet);
 
              // We now have the assembly result
              result.setAssemblyResult(AssembleTransactionResponse.Result.OK);
@@ -287,6 +291,29 @@
          }
      }
 
+     record InfoStates(
+             EndorsableState txInput,
+             ExternalReadSet.ReadSetJson readSet
+     ) {}
+
+     /** The transaction input is always supplied, and the base ledger read set only if state was read */
+     private InfoStates parseInfoStates(List<EndorsableState> infoList) throws IOException {
+         EndorsableState txInput = null;
+         ExternalReadSet.ReadSetJson readSet = null;
+         for (var info : infoList) {
+             if (txInput == null && info.getSchemaId().equals(config.schemaId_TransactionInputStateLatest())) {
+                 txInput = info;
+             } else if (readSet == null && info.getSchemaId().equals(config.schemaId_ExternalReadSetLatest())) {
+                 readSet = new ObjectMapper().readValue(info.getStateDataJson(), ExternalReadSet.ReadSetJson.class);
+             } else {
+                 throw new IllegalArgumentException("Unexpected info state %s (schema=%s)".formatted(info.getId(), info.getSchemaId()));
+             }
+         }
+         if (txInput == null)
+             throw new IllegalArgumentException("Expected exactly one info state containing the transaction input");
+         return new InfoStates(txInput, readSet);
+     }
+
      @Override
      protected CompletableFuture<EndorseTransactionResponse> endorseTransaction(EndorseTransactionRequest request) {
          try {
@@ -299,16 +326,24 @@
              for (var read : request.getReadsList()) {
                  readAccounts.add(PersistedAccount.deserialize(read.getStateDataJson().getBytes(StandardCharsets.UTF_8)));
              }
-             if (request.getInfoCount() != 1)
-                 throw new IllegalArgumentException("Expected exactly one info state containing the transaction input");
+             var infoStates = parseInfoStates(request.getInfoList());
 
              // Recover the input from the signed rawTransaction that is in the "info" state recorded alongside the transaction
              var tx = new PenteTransaction(this, request.getTransaction());
-             var evmTxn = PenteEVMTransaction.buildFromInput(this, request.getInfo(0).getStateDataJson().getBytes(StandardCharsets.UTF_8));
+             var evmTxn = PenteEVMTransaction.buildFromInput(this, infoStates.txInput().getStateDataJson().getBytes(StandardCharsets.UTF_8));
+
+             // Base ledger reads are only served from the read set, once we have checked it against our own view of the block
+             ReadSetExternalStateReader externalReader = null;
+             if (infoStates.readSet() != null) {
+                 if (config.getBaseLedgerRPC() == null) {
+                     throw new IllegalStateException("Transaction read base ledger state, but no base ledger is configured to verify it");
+                 }
+                 externalReader = ReadSetExternalStateReader.verify(config.getBaseLedgerRPC(), infoStates.readSet(), evmTxn.getBaseBlock());
+             }
 
              // Do the execution of the transaction again ourselves
              var endorsementLoader = new EndorsementAccountLoader(inputAccounts, readAccounts);
-             var execResult = evmTxn.invokeEVM(endorsementLoader);
+             var execResult = evmTxn.invokeEVM(withExternalState(endorsementLoader, externalReader));
 
              // For the inputs, the endorsementLoader checks we loaded everything from the right set
              var inputsMatch = endorsementLoader.checkEmpty();
@@ -545,7 +580,7 @@
              var tx = new PenteTransaction(this, request.getTransaction());
              var accountLoader = new AssemblyAccountLoader(request.getStateQueryContext());
              var ethTxn = new PenteEVMTransaction(this, tx, tx.getFromVerifier(request.getResolvedVerifiersList()));
-             var result = ethTxn.invokeEVM(accountLoader);
+             var result = ethTxn.invokeEVM(withExternalState(accountLoader, newExternalStateReader(tx.getBaseBlock())));
 
              var response = ExecCallResponse.newBuilder();
              response.setResultJson(tx.decodeOutput(result.outputData()));
@@ -573,15 +608,20 @@
              for (var read : request.getReadStatesList()) {
                  readAccounts.add(PersistedAccount.deserialize(read.getStateDataJson().getBytes(StandardCharsets.UTF_8)));
              }
-             if (request.getInfoStatesCount() != 1)
-                 throw new IllegalArgumentException("Expected exactly one info state containing the transaction input");
+             var infoStates = parseInfoStates(request.getInfoStatesList());
 
              // Recover the input from the signed rawTransaction that is in the "info" state recorded alongside the transaction
-             var evmTxn = PenteEVMTransaction.buildFromInput(this, request.getInfoStates(0).getStateDataJson().getBytes(StandardCharsets.UTF_8));
+             var evmTxn = PenteEVMTransaction.buildFromInput(this, infoStates.txInput().getStateDataJson().getBytes(StandardCharsets.UTF_8));
+
+             // The read set was verified by every endorser before the transaction was confirmed, so we do not re-check it
+             ReadSetExternalStateReader externalReader = null;
+             if (infoStates.readSet() != null) {
+                 externalReader = ReadSetExternalStateReader.confirmed(infoStates.readSet());
+             }
 
              // Do the execution of the transaction again ourselves
              var endorsementLoader = new EndorsementAccountLoader(inputAccounts, readAccounts);
-             var execResult = evmTxn.invokeEVM(endorsementLoader);
+             var execResult = evmTxn.invokeEVM(withExternalState(endorsementLoader, externalReader));
 
              // Build the full receipt from the result
              var jsonReceipt = evmTxn.buildJSONReceipt(execResult);
@@ -909,6 +949,22 @@
      PenteConfiguration getConfig() {
          return config;
      }
+
+     /** When a base ledger is configured, private transactions can read contract state from it at their base block */
+     RPCExternalStateReader newExternalStateReader(long baseBlock) {
+         var rpc = config.getBaseLedgerRPC();
+         if (rpc == null) {
+             return null;
+         }
+         return new RPCExternalStateReader(rpc, baseBlock);
+     }
+
+     static AccountLoader withExternalState(AccountLoader accountLoader, ExternalStateReader externalReader) {
+         if (externalReader == null) {
+             return accountLoader;
+         }
+         return new ExternalStateAccountLoader(accountLoader, externalReader);
+     }
  
      @FunctionalInterface
      public interface SupplierEx<T> {
@@ -262,6 +262,13 @@
          // Note we only increment the nonce after successful executions
          sender.setNonce(senderNonce+1);
          evm.getWorld().getUpdater().commit();
+
+         // Base ledger accounts can be read, but any attempt to write to them cannot be honored
+         for (var address : evm.getWorld().getCommittedAccountUpdates().keySet()) {
+             if (accountLoader.isReadOnly(address)) {
+                 throw new EVMExecutionException("transaction attempted to modify base ledger account %s".formatted(address));
+             }
+         }
          return new EVMExecutionResult(
                  evm,
                  senderAddress,
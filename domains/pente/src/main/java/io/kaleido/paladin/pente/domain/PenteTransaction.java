@@ -23,6 +23,7 @@
  import io.kaleido.paladin.logging.PaladinLogging;
  import io.kaleido.paladin.pente.evmrunner.EVMRunner;
  import io.kaleido.paladin.pente.evmstate.DynamicLoadWorldState;
+ import io.kaleido.paladin.pente.evmstate.ExternalReadSet;
  import io.kaleido.paladin.toolkit.*;
  import io.kaleido.paladin.toolkit.JsonHex.Address;
  import org.apache.logging.log4j.Logger;
@@ -312,7 +313,8 @@
              PenteDomain.AssemblyAccountLoader accountLoader,
              PenteEVMTransaction evmTxn,
              byte[] encodedTxn,
-             String domainData) throws IOException, ExecutionException, InterruptedException {
+             String domainData,
+             ExternalReadSet.ReadSetJson readSet) throws IOException, ExecutionException, InterruptedException {
  
          var latestAccountSchemaId = domain.getConfig().schemaId_AccountStateLatest();
          var latestTransactionInputSchemaId = domain.getConfig().schemaId_TransactionInputStateLatest();
@@ -346,7 +348,7 @@
                  } else {
                      LOGGER.info("Deleting account {} (existing={})", loadedAccount, inputState);
                  }
-             } else if (loadedAccount != null) {
+             } else if (inputState != null) {
                  // Note a read of an account with no state at this block is not tracked on-chain
                  LOGGER.info("Read of state for account {} (existing={})", loadedAccount, inputState);
                  readStates.add(StateRef.newBuilder().
@@ -371,6 +373,14 @@
          result.addAllReadStates(readStates);
          result.addAllOutputStates(outputStates);
          result.addInfoStates(txInputState);
+         if (readSet != null) {
+             // The base ledger state read by the transaction, with the proofs endorsers check it against
+             result.addInfoStates(NewState.newBuilder().
+                     setSchemaId(domain.getConfig().schemaId_ExternalReadSetLatest()).
+                     setStateDataJsonBytes(ByteString.copyFrom(new ObjectMapper().writeValueAsBytes(readSet))).
+                     addAllDistributionList(lookups).
+                     build());
+         }
          if (domainData != null) {
              result.setDomainData(domainData);
          }
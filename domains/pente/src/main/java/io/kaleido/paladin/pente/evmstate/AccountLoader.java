@@ -24,4 +24,9 @@ public interface AccountLoader {
 
     public Optional<PersistedAccount> load(Address address) throws IOException;
 
+    /** accounts that are visible to the private EVM, but must not be modified by it */
+    default boolean isReadOnly(Address address) {
+        return false;
+    }
+
 }
@@ -0,0 +1,88 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package io.kaleido.paladin.pente.evmstate;
+
+import org.apache.tuweni.bytes.Bytes;
+import org.apache.tuweni.units.bigints.UInt256;
+import org.hyperledger.besu.datatypes.Address;
+import org.hyperledger.besu.datatypes.Hash;
+import org.hyperledger.besu.datatypes.Wei;
+
+import java.io.IOException;
+import java.util.HashMap;
+import java.util.Map;
+
+/**
+ * A contract account on the base ledger, loaded into the private EVM world for reading.
+ * Storage slots are loaded on demand through the reader, so only the slots actually
+ * accessed by the execution end up in the read set.
+ */
+public class ExternalAccount extends PersistedAccount {
+
+    private final ExternalStateReader reader;
+
+    private final Hash storageHash;
+
+    private final Map<UInt256, UInt256> storage = new HashMap<>();
+
+    public ExternalAccount(ExternalStateReader reader, Address address, long nonce, Wei balance, Bytes code, Hash storageHash) {
+        super(address, nonce, balance, code);
+        this.reader = reader;
+        this.storageHash = storageHash;
+    }
+
+    @Override
+    public boolean isStorageEmpty() {
+        return storageHash.equals(Hash.EMPTY_TRIE_HASH);
+    }
+
+    @Override
+    public UInt256 getStorageValue(UInt256 key) {
+        var value = storage.get(key);
+        if (value == null) {
+            try {
+                value = reader.loadStorage(getAddress(), key);
+            } catch (IOException e) {
+                throw new RuntimeException(e);
+            }
+            storage.put(key, value);
+        }
+        return value;
+    }
+
+    @Override
+    public UInt256 getOriginalStorageValue(UInt256 key) {
+        return this.getStorageValue(key);
+    }
+
+    public Hash getStorageHash() {
+        return storageHash;
+    }
+
+    public String toString() {
+        return this.getAddress().toString() +
+                "[external" +
+                " nonce=" +
+                this.getNonce() +
+                " balance=" +
+                this.getBalance() +
+                " codehash=" +
+                this.getCodeHashOrZero().toString() +
+                " storagehash=" +
+                this.storageHash.toString() +
+                ']';
+    }
+}
@@ -0,0 +1,199 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package io.kaleido.paladin.pente.evmstate;
+
+import com.fasterxml.jackson.annotation.JsonIgnoreProperties;
+import com.fasterxml.jackson.annotation.JsonProperty;
+import com.fasterxml.jackson.databind.ObjectMapper;
+import io.kaleido.paladin.toolkit.JsonHex;
+import io.kaleido.paladin.toolkit.JsonHexNum;
+import io.kaleido.paladin.toolkit.JsonRpcClient;
+import org.apache.tuweni.bytes.Bytes;
+import org.apache.tuweni.bytes.Bytes32;
+import org.hyperledger.besu.datatypes.Address;
+import org.hyperledger.besu.datatypes.Hash;
+import org.hyperledger.besu.datatypes.Wei;
+import org.hyperledger.besu.ethereum.trie.MerkleTrieException;
+import org.hyperledger.besu.ethereum.trie.patricia.StoredMerklePatriciaTrie;
+import org.web3j.rlp.RlpEncoder;
+import org.web3j.rlp.RlpList;
+import org.web3j.rlp.RlpString;
+
+import java.io.IOException;
+import java.util.HashMap;
+import java.util.List;
+import java.util.Optional;
+
+/**
+ * The set of base ledger state read by a private transaction, anchored to the block the transaction
+ * was assembled against. Every account and storage slot carries the Merkle Patricia proof returned
+ * by eth_getProof, so an endorser only needs the header of that block from its own node to check
+ * the whole set - it does not need to trust the assembling node, or re-query each value.
+ */
+public class ExternalReadSet {
+
+    @JsonIgnoreProperties(ignoreUnknown = true)
+    public record StorageRead(
+            @JsonProperty
+            JsonHex.Bytes32 key,
+            @JsonProperty
+            JsonHexNum.Uint256 value,
+            @JsonProperty
+            List<JsonHex.Bytes> proof
+    ) {}
+
+    @JsonIgnoreProperties(ignoreUnknown = true)
+    public record AccountRead(
+            @JsonProperty
+            JsonHex.Address address,
+            @JsonProperty
+            JsonHexNum.Uint256 nonce,
+            @JsonProperty
+            JsonHexNum.Uint256 balance,
+            @JsonProperty
+            JsonHex.Bytes32 codeHash,
+            @JsonProperty
+            JsonHex.Bytes32 storageHash,
+            @JsonProperty
+            JsonHex.Bytes code,
+            @JsonProperty
+            List<JsonHex.Bytes> accountProof,
+            @JsonProperty
+            List<StorageRead> storage
+    ) {
+        boolean hasCode() {
+            return !Bytes32.wrap(codeHash.getBytes()).equals(Hash.EMPTY);
+        }
+
+        ExternalAccount toAccount(ExternalStateReader reader) {
+            return new ExternalAccount(
+                    reader,
+                    Address.wrap(Bytes.wrap(address.getBytes())),
+                    nonce.longValue(),
+                    Wei.of(balance.bigInt()),
+                    Bytes.wrap(code.getBytes()),
+                    Hash.wrap(Bytes32.wrap(storageHash.getBytes()))
+            );
+        }
+    }
+
+    @JsonIgnoreProperties(ignoreUnknown = true)
+    public record ReadSetJson(
+            @JsonProperty
+            JsonHex.Bytes32 salt,
+            @JsonProperty
+            JsonHexNum.Uint256 blockNumber,
+            @JsonProperty
+            JsonHex.Bytes32 blockHash,
+            @JsonProperty
+            JsonHex.Bytes32 stateRoot,
+            @JsonProperty
+            List<AccountRead> accounts
+    ) {}
+
+    @JsonIgnoreProperties(ignoreUnknown = true)
+    record BlockHeaderJson(
+            @JsonProperty
+            JsonHex.Bytes32 hash,
+            @JsonProperty
+            JsonHex.Bytes32 stateRoot
+    ) {}
+
+    public record BlockAnchor(long blockNumber, Bytes32 blockHash, Bytes32 stateRoot) {}
+
+    static String blockTag(long blockNumber) {
+        return "0x" + Long.toHexString(blockNumber);
+    }
+
+    /** fetches the hash and state root of a block from the base ledger node */
+    public static BlockAnchor fetchBlock(JsonRpcClient rpc, long blockNumber) throws IOException {
+        Object result = rpc.request("eth_getBlockByNumber", blockTag(blockNumber), false);
+        if (result == null) {
+            throw new IOException("block %d is not available on the base ledger".formatted(blockNumber));
+        }
+        var header = new ObjectMapper().convertValue(result, BlockHeaderJson.class);
+        return new BlockAnchor(
+                blockNumber,
+                Bytes32.wrap(header.hash().getBytes()),
+                Bytes32.wrap(header.stateRoot().getBytes())
+        );
+    }
+
+    /** checks the account fields, code, and every storage slot against the state root */
+    public static void verifyAccount(Bytes32 stateRoot, AccountRead account) {
+        var address = Address.wrap(Bytes.wrap(account.address().getBytes()));
+        var leaf = proofValue(stateRoot, Hash.hash(address), account.accountProof());
+        var matches = leaf.isPresent() ? leaf.get().equals(encodeAccount(account)) : isEmptyAccount(account);
+        if (!matches) {
+            throw new IllegalArgumentException("account proof for %s does not match state root %s".formatted(address, stateRoot));
+        }
+        if (!Hash.hash(Bytes.wrap(account.code().getBytes())).equals(Bytes32.wrap(account.codeHash().getBytes()))) {
+            throw new IllegalArgumentException("code for %s does not match code hash %s".formatted(address, account.codeHash()));
+        }
+        for (var slot : account.storage()) {
+            verifyStorage(account, slot);
+        }
+    }
+
+    /** checks a single storage slot against the storage root of the account */
+    public static void verifyStorage(AccountRead account, StorageRead slot) {
+        var storageHash = Bytes32.wrap(account.storageHash().getBytes());
+        var leaf = proofValue(storageHash, Hash.hash(Bytes.wrap(slot.key().getBytes())), slot.proof());
+        var value = slot.value().bigInt();
+        var matches = leaf.isPresent() ? leaf.get().equals(Bytes.wrap(RlpEncoder.encode(RlpString.create(value)))) : value.signum() == 0;
+        if (!matches) {
+            throw new IllegalArgumentException("storage proof for %s slot %s does not match storage root %s".formatted(
+                    account.address(), slot.key(), storageHash));
+        }
+    }
+
+    private static boolean isEmptyAccount(AccountRead account) {
+        return account.nonce().bigInt().signum() == 0 &&
+                account.balance().bigInt().signum() == 0 &&
+                !account.hasCode() &&
+                Bytes32.wrap(account.storageHash().getBytes()).equals(Hash.EMPTY_TRIE_HASH);
+    }
+
+    private static Bytes encodeAccount(AccountRead account) {
+        return Bytes.wrap(RlpEncoder.encode(new RlpList(
+                RlpString.create(account.nonce().bigInt()),
+                RlpString.create(account.balance().bigInt()),
+                RlpString.create(account.storageHash().getBytes()),
+                RlpString.create(account.codeHash().getBytes())
+        )));
+    }
+
+    private static Optional<Bytes> proofValue(Bytes32 rootHash, Bytes32 key, List<JsonHex.Bytes> proof) {
+        // The proof is the list of trie nodes on the path to the key, which we load by hash
+        // into a trie that has no other storage - so any missing node fails the lookup.
+        var nodes = new HashMap<Bytes32, Bytes>();
+        for (var node : proof) {
+            var nodeBytes = Bytes.wrap(node.getBytes());
+            nodes.put(Bytes32.wrap(Hash.hash(nodeBytes).toArray()), nodeBytes);
+        }
+        var trie = new StoredMerklePatriciaTrie<Bytes32, Bytes>(
+                (location, hash) -> Optional.ofNullable(nodes.get(Bytes32.wrap(hash.toArray()))),
+                rootHash,
+                b -> b,
+                b -> b
+        );
+        try {
+            return trie.get(key);
+        } catch (MerkleTrieException e) {
+            throw new IllegalArgumentException("incomplete proof for key %s".formatted(key), e);
+        }
+    }
+}
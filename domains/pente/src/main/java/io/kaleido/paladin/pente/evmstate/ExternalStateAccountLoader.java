@@ -0,0 +1,61 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package io.kaleido.paladin.pente.evmstate;
+
+import org.hyperledger.besu.datatypes.Address;
+
+import java.io.IOException;
+import java.util.HashSet;
+import java.util.Optional;
+import java.util.Set;
+
+/**
+ * Layers base ledger contracts underneath the private state of the privacy group.
+ * Private accounts always take precedence, and any base ledger account that is loaded
+ * is read-only to the private EVM.
+ */
+public class ExternalStateAccountLoader implements AccountLoader {
+
+    private final AccountLoader privateLoader;
+
+    private final ExternalStateReader externalReader;
+
+    private final Set<Address> externalAccounts = new HashSet<>();
+
+    public ExternalStateAccountLoader(AccountLoader privateLoader, ExternalStateReader externalReader) {
+        this.privateLoader = privateLoader;
+        this.externalReader = externalReader;
+    }
+
+    @Override
+    public Optional<PersistedAccount> load(Address address) throws IOException {
+        var account = privateLoader.load(address);
+        if (account.isPresent()) {
+            return account;
+        }
+        var externalAccount = externalReader.loadAccount(address);
+        if (externalAccount.isEmpty()) {
+            return Optional.empty();
+        }
+        externalAccounts.add(address);
+        return Optional.of(externalAccount.get());
+    }
+
+    @Override
+    public boolean isReadOnly(Address address) {
+        return externalAccounts.contains(address);
+    }
+}
@@ -0,0 +1,35 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package io.kaleido.paladin.pente.evmstate;
+
+import org.apache.tuweni.units.bigints.UInt256;
+import org.hyperledger.besu.datatypes.Address;
+
+import java.io.IOException;
+import java.util.Optional;
+
+/**
+ * Provides read-only access to the state of contracts on the base ledger, pinned to a single block,
+ * so that private EVM execution can read public contract state.
+ */
+public interface ExternalStateReader {
+
+    /** returns the account if it is a contract on the base ledger, or empty for EOAs and un-used addresses */
+    Optional<ExternalAccount> loadAccount(Address address) throws IOException;
+
+    UInt256 loadStorage(Address address, UInt256 key) throws IOException;
+
+}
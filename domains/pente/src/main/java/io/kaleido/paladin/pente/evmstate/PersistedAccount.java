@@ -55,6 +55,12 @@ public class PersistedAccount implements Account {
         this.address = address;
     }
 
+    protected PersistedAccount(Address address, long nonce, Wei balance, Bytes code) {
+        this.address = address;
+        this.nonce = nonce;
+        this.balance = balance;
+        this.code = code;
+    }
 
     @Override
     public Address getAddress() {
@@ -0,0 +1,167 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package io.kaleido.paladin.pente.evmstate;
+
+import com.fasterxml.jackson.annotation.JsonIgnoreProperties;
+import com.fasterxml.jackson.annotation.JsonProperty;
+import com.fasterxml.jackson.databind.ObjectMapper;
+import io.kaleido.paladin.logging.PaladinLogging;
+import io.kaleido.paladin.toolkit.JsonHex;
+import io.kaleido.paladin.toolkit.JsonHexNum;
+import io.kaleido.paladin.toolkit.JsonRpcClient;
+import org.apache.logging.log4j.Logger;
+import org.apache.tuweni.bytes.Bytes;
+import org.apache.tuweni.bytes.Bytes32;
+import org.apache.tuweni.units.bigints.UInt256;
+import org.hyperledger.besu.datatypes.Address;
+import org.hyperledger.besu.datatypes.Hash;
+
+import java.io.IOException;
+import java.util.*;
+
+/**
+ * Used during assembly to read base ledger state with eth_getProof at the base block of the
+ * transaction, recording everything that is read (with its proofs) into a read set.
+ */
+public class RPCExternalStateReader implements ExternalStateReader {
+
+    private static final Logger LOGGER = PaladinLogging.getLogger(RPCExternalStateReader.class);
+
+    private final JsonRpcClient rpc;
+
+    private final long blockNumber;
+
+    private ExternalReadSet.BlockAnchor block;
+
+    private final Map<Address, ExternalReadSet.AccountRead> accounts = new LinkedHashMap<>();
+
+    @JsonIgnoreProperties(ignoreUnknown = true)
+    record EthStorageProofJson(
+            @JsonProperty
+            JsonHexNum.Uint256 value,
+            @JsonProperty
+            List<JsonHex.Bytes> proof
+    ) {}
+
+    @JsonIgnoreProperties(ignoreUnknown = true)
+    record EthProofJson(
+            @JsonProperty
+            List<JsonHex.Bytes> accountProof,
+            @JsonProperty
+            JsonHexNum.Uint256 balance,
+            @JsonProperty
+            JsonHex.Bytes32 codeHash,
+            @JsonProperty
+            JsonHexNum.Uint256 nonce,
+            @JsonProperty
+            JsonHex.Bytes32 storageHash,
+            @JsonProperty
+            List<EthStorageProofJson> storageProof
+    ) {}
+
+    public RPCExternalStateReader(JsonRpcClient rpc, long blockNumber) {
+        this.rpc = rpc;
+        this.blockNumber = blockNumber;
+    }
+
+    private ExternalReadSet.BlockAnchor getBlock() throws IOException {
+        if (block == null) {
+            block = ExternalReadSet.fetchBlock(rpc, blockNumber);
+        }
+        return block;
+    }
+
+    private EthProofJson getProof(Address address, List<String> keys) throws IOException {
+        Object result = rpc.request("eth_getProof", address.toHexString(), keys, ExternalReadSet.blockTag(blockNumber));
+        if (result == null) {
+            throw new IOException("no proof returned for %s at block %d".formatted(address, blockNumber));
+        }
+        return new ObjectMapper().convertValue(result, EthProofJson.class);
+    }
+
+    // Some nodes return zero rather than the empty hashes for accounts that do not exist
+    private static JsonHex.Bytes32 orEmptyHash(JsonHex.Bytes32 hash, Hash empty) {
+        if (hash == null || hash.equals(JsonHex.Bytes32.ZERO)) {
+            return new JsonHex.Bytes32(empty.toArray());
+        }
+        return hash;
+    }
+
+    @Override
+    public Optional<ExternalAccount> loadAccount(Address address) throws IOException {
+        var block = getBlock();
+        var proof = getProof(address, Collections.emptyList());
+        var codeHash = orEmptyHash(proof.codeHash(), Hash.EMPTY);
+        var code = Bytes.EMPTY;
+        if (!Bytes32.wrap(codeHash.getBytes()).equals(Hash.EMPTY)) {
+            String codeHex = rpc.request("eth_getCode", address.toHexString(), ExternalReadSet.blockTag(blockNumber));
+            code = Bytes.fromHexString(codeHex);
+        }
+        var read = new ExternalReadSet.AccountRead(
+                new JsonHex.Address(address.toArray()),
+                proof.nonce(),
+                proof.balance(),
+                codeHash,
+                orEmptyHash(proof.storageHash(), Hash.EMPTY_TRIE_HASH),
+                new JsonHex.Bytes(code.toArray()),
+                proof.accountProof(),
+                new ArrayList<>()
+        );
+        // We check the node gave us a consistent answer, as every endorser will perform the same check
+        ExternalReadSet.verifyAccount(block.stateRoot(), read);
+        accounts.put(address, read);
+        LOGGER.debug("Loaded base ledger account {} at block {} (contract={})", address, blockNumber, read.hasCode());
+        if (!read.hasCode()) {
+            return Optional.empty();
+        }
+        return Optional.of(read.toAccount(this));
+    }
+
+    @Override
+    public UInt256 loadStorage(Address address, UInt256 key) throws IOException {
+        var account = accounts.get(address);
+        if (account == null) {
+            throw new IOException("storage read for base ledger account %s that has not been loaded".formatted(address));
+        }
+        var proof = getProof(address, List.of(key.toHexString()));
+        if (proof.storageProof() == null || proof.storageProof().size() != 1) {
+            throw new IOException("expected one storage proof for %s slot %s".formatted(address, key));
+        }
+        var storageProof = proof.storageProof().getFirst();
+        var slot = new ExternalReadSet.StorageRead(
+                new JsonHex.Bytes32(key.toArray()),
+                storageProof.value(),
+                storageProof.proof()
+        );
+        ExternalReadSet.verifyStorage(account, slot);
+        account.storage().add(slot);
+        return UInt256.valueOf(slot.value().bigInt());
+    }
+
+    /** returns the recorded read set, or null if nothing was read from the base ledger */
+    public ExternalReadSet.ReadSetJson getReadSet() {
+        if (accounts.isEmpty()) {
+            return null;
+        }
+        return new ExternalReadSet.ReadSetJson(
+                JsonHex.randomBytes32(),
+                new JsonHexNum.Uint256(block.blockNumber()),
+                new JsonHex.Bytes32(block.blockHash().toArray()),
+                new JsonHex.Bytes32(block.stateRoot().toArray()),
+                new ArrayList<>(accounts.values())
+        );
+    }
+}
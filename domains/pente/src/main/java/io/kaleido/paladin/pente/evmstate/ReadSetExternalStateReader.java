@@ -0,0 +1,90 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package io.kaleido.paladin.pente.evmstate;
+
+import io.kaleido.paladin.toolkit.JsonRpcClient;
+import org.apache.tuweni.bytes.Bytes;
+import org.apache.tuweni.bytes.Bytes32;
+import org.apache.tuweni.units.bigints.UInt256;
+import org.hyperledger.besu.datatypes.Address;
+
+import java.io.IOException;
+import java.util.HashMap;
+import java.util.Map;
+import java.util.Optional;
+
+/**
+ * Used during endorsement to serve base ledger reads only from a read set supplied by the assembler,
+ * once it has been verified against the block header held by our own base ledger node.
+ * Any read outside of the set fails, as we cannot know the assembler saw the same value.
+ */
+public class ReadSetExternalStateReader implements ExternalStateReader {
+
+    private final Map<Address, ExternalReadSet.AccountRead> accounts = new HashMap<>();
+
+    private ReadSetExternalStateReader(ExternalReadSet.ReadSetJson readSet) {
+        for (var account : readSet.accounts()) {
+            accounts.put(Address.wrap(Bytes.wrap(account.address().getBytes())), account);
+        }
+    }
+
+    /** for a read set that has already been verified, such as one from a confirmed transaction */
+    public static ReadSetExternalStateReader confirmed(ExternalReadSet.ReadSetJson readSet) {
+        return new ReadSetExternalStateReader(readSet);
+    }
+
+    public static ReadSetExternalStateReader verify(JsonRpcClient rpc, ExternalReadSet.ReadSetJson readSet, long baseBlock) throws IOException {
+        if (readSet.blockNumber().longValue() != baseBlock) {
+            throw new IllegalArgumentException("read set block %d does not match transaction base block %d".formatted(
+                    readSet.blockNumber().longValue(), baseBlock));
+        }
+        var block = ExternalReadSet.fetchBlock(rpc, baseBlock);
+        if (!block.blockHash().equals(Bytes32.wrap(readSet.blockHash().getBytes())) ||
+                !block.stateRoot().equals(Bytes32.wrap(readSet.stateRoot().getBytes()))) {
+            throw new IllegalArgumentException("read set is anchored to block %s, but the base ledger has %s at block %d".formatted(
+                    readSet.blockHash(), block.blockHash(), baseBlock));
+        }
+        for (var account : readSet.accounts()) {
+            ExternalReadSet.verifyAccount(block.stateRoot(), account);
+        }
+        return new ReadSetExternalStateReader(readSet);
+    }
+
+    @Override
+    public Optional<ExternalAccount> loadAccount(Address address) throws IOException {
+        var account = accounts.get(address);
+        if (account == null) {
+            throw new IOException("base ledger account %s is not in the read set".formatted(address));
+        }
+        if (!account.hasCode()) {
+            return Optional.empty();
+        }
+        return Optional.of(account.toAccount(this));
+    }
+
+    @Override
+    public UInt256 loadStorage(Address address, UInt256 key) throws IOException {
+        var account = accounts.get(address);
+        if (account != null) {
+            for (var slot : account.storage()) {
+                if (Bytes32.wrap(slot.key().getBytes()).equals(Bytes32.wrap(key.toArray()))) {
+                    return UInt256.valueOf(slot.value().bigInt());
+                }
+            }
+        }
+        throw new IOException("base ledger account %s slot %s is not in the read set".formatted(address, key));
+    }
+}
@@ -0,0 +1,98 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package io.kaleido.paladin.pente.evmstate;
+
+import io.kaleido.paladin.pente.evmrunner.EVMRunner;
+import org.apache.tuweni.bytes.Bytes;
+import org.apache.tuweni.units.bigints.UInt256;
+import org.hyperledger.besu.datatypes.Address;
+import org.hyperledger.besu.datatypes.Hash;
+import org.hyperledger.besu.datatypes.Wei;
+import org.junit.jupiter.api.Test;
+
+import java.io.IOException;
+import java.util.ArrayList;
+import java.util.List;
+import java.util.Optional;
+
+import static org.junit.jupiter.api.Assertions.*;
+
+public class ExternalAccountTest {
+
+    private static class TestReader implements ExternalStateReader {
+
+        final List<UInt256> loaded = new ArrayList<>();
+
+        @Override
+        public Optional<ExternalAccount> loadAccount(Address address) {
+            return Optional.empty();
+        }
+
+        @Override
+        public UInt256 loadStorage(Address address, UInt256 key) throws IOException {
+            loaded.add(key);
+            if (key.equals(UInt256.MAX_VALUE)) {
+                throw new IOException("not in the read set");
+            }
+            return key.add(1);
+        }
+    }
+
+    private final Address address = EVMRunner.randomAddress();
+
+    @Test
+    void storageLoadedOnDemandAndCached() {
+        var reader = new TestReader();
+        var account = new ExternalAccount(reader, address, 5, Wei.of(10), Bytes.fromHexString("0xfeedbeef"), Hash.hash(Bytes.of(1)));
+        assertFalse(account.isStorageEmpty());
+        assertEquals(Hash.hash(Bytes.of(1)), account.getStorageHash());
+        assertTrue(reader.loaded.isEmpty());
+
+        assertEquals(UInt256.valueOf(2), account.getStorageValue(UInt256.valueOf(1)));
+        assertEquals(UInt256.valueOf(2), account.getStorageValue(UInt256.valueOf(1)));
+        assertEquals(UInt256.valueOf(2), account.getOriginalStorageValue(UInt256.valueOf(1)));
+        assertEquals(UInt256.valueOf(3), account.getOriginalStorageValue(UInt256.valueOf(2)));
+        // each slot is only read once
+        assertEquals(List.of(UInt256.valueOf(1), UInt256.valueOf(2)), reader.loaded);
+    }
+
+    @Test
+    void emptyStorage() {
+        var account = new ExternalAccount(new TestReader(), address, 0, Wei.ZERO, Bytes.fromHexString("0xfeedbeef"), Hash.EMPTY_TRIE_HASH);
+        assertTrue(account.isStorageEmpty());
+    }
+
+    @Test
+    void readFailure() {
+        var reader = new TestReader();
+        var account = new ExternalAccount(reader, address, 0, Wei.ZERO, Bytes.fromHexString("0xfeedbeef"), Hash.EMPTY_TRIE_HASH);
+        var e = assertThrows(RuntimeException.class, () -> account.getStorageValue(UInt256.MAX_VALUE));
+        assertInstanceOf(IOException.class, e.getCause());
+        // failures are not cached
+        assertThrows(RuntimeException.class, () -> account.getStorageValue(UInt256.MAX_VALUE));
+        assertEquals(2, reader.loaded.size());
+    }
+
+    @Test
+    void accountToString() {
+        var account = new ExternalAccount(new TestReader(), address, 5, Wei.of(10), Bytes.fromHexString("0xfeedbeef"), Hash.EMPTY_TRIE_HASH);
+        var str = account.toString();
+        assertTrue(str.startsWith(address.toString() + "[external"));
+        assertTrue(str.contains("nonce=5"));
+        assertTrue(str.contains("storagehash=" + Hash.EMPTY_TRIE_HASH));
+    }
+
+}
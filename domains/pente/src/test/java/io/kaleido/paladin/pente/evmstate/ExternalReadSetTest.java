@@ -0,0 +1,208 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package io.kaleido.paladin.pente.evmstate;
+
+import io.kaleido.paladin.pente.evmrunner.EVMRunner;
+import io.kaleido.paladin.toolkit.JsonHex;
+import io.kaleido.paladin.toolkit.JsonHexNum;
+import org.apache.tuweni.bytes.Bytes;
+import org.apache.tuweni.bytes.Bytes32;
+import org.apache.tuweni.units.bigints.UInt256;
+import org.hyperledger.besu.datatypes.Address;
+import org.hyperledger.besu.datatypes.Hash;
+import org.junit.jupiter.api.BeforeEach;
+import org.junit.jupiter.api.Test;
+
+import java.io.IOException;
+import java.math.BigInteger;
+import java.util.ArrayList;
+import java.util.List;
+import java.util.Map;
+
+import static org.junit.jupiter.api.Assertions.*;
+
+public class ExternalReadSetTest {
+
+    private final Address contract = EVMRunner.randomAddress();
+
+    private final Bytes code = Bytes.fromHexString("0xfeedbeef");
+
+    private TestBaseLedger ledger;
+
+    private ExternalReadSet.AccountRead contractRead;
+
+    @BeforeEach
+    void setup() throws IOException {
+        ledger = new TestBaseLedger();
+        ledger.putAccount(contract, 1, BigInteger.valueOf(1000), code, Map.of(
+                UInt256.valueOf(1), UInt256.valueOf(12345),
+                UInt256.valueOf(2), UInt256.valueOf(67890)
+        ));
+        for (int i = 0; i < 10; i++) {
+            ledger.putAccount(EVMRunner.randomAddress(), i, BigInteger.valueOf(i), Bytes.EMPTY, Map.of());
+        }
+        ledger.mine();
+
+        var reader = ledger.newReader();
+        var account = reader.loadAccount(contract).orElseThrow();
+        account.getStorageValue(UInt256.valueOf(1));
+        account.getStorageValue(UInt256.valueOf(3));
+        contractRead = reader.getReadSet().accounts().getFirst();
+    }
+
+    private static ExternalReadSet.AccountRead withFields(ExternalReadSet.AccountRead a,
+                                                          JsonHexNum.Uint256 balance,
+                                                          JsonHex.Bytes code,
+                                                          List<JsonHex.Bytes> accountProof,
+                                                          List<ExternalReadSet.StorageRead> storage) {
+        return new ExternalReadSet.AccountRead(
+                a.address(), a.nonce(), balance, a.codeHash(), a.storageHash(), code, accountProof, storage);
+    }
+
+    private static List<JsonHex.Bytes> tamperFirstNode(List<JsonHex.Bytes> proof) {
+        var tampered = new ArrayList<>(proof);
+        var node = proof.getFirst().getBytes().clone();
+        node[node.length - 1] ^= 0x01;
+        tampered.set(0, new JsonHex.Bytes(node));
+        return tampered;
+    }
+
+    @Test
+    void validAccountAndStorageProofs() {
+        assertEquals(2, contractRead.storage().size());
+        assertEquals(BigInteger.valueOf(12345), contractRead.storage().get(0).value().bigInt());
+        // the slot that was never written is proved to be zero
+        assertEquals(BigInteger.ZERO, contractRead.storage().get(1).value().bigInt());
+        assertTrue(contractRead.hasCode());
+        assertDoesNotThrow(() -> ExternalReadSet.verifyAccount(ledger.stateRoot(), contractRead));
+    }
+
+    @Test
+    void tamperedAccountFields() {
+        var tampered = withFields(contractRead, new JsonHexNum.Uint256(1001), contractRead.code(),
+                contractRead.accountProof(), contractRead.storage());
+        var e = assertThrows(IllegalArgumentException.class, () -> ExternalReadSet.verifyAccount(ledger.stateRoot(), tampered));
+        assertTrue(e.getMessage().contains("account proof"));
+    }
+
+    @Test
+    void tamperedAccountProof() {
+        var tampered = withFields(contractRead, contractRead.balance(), contractRead.code(),
+                tamperFirstNode(contractRead.accountProof()), contractRead.storage());
+        var e = assertThrows(IllegalArgumentException.class, () -> ExternalReadSet.verifyAccount(ledger.stateRoot(), tampered));
+        assertTrue(e.getMessage().contains("incomplete proof"));
+    }
+
+    @Test
+    void missingAccountProof() {
+        var tampered = withFields(contractRead, contractRead.balance(), contractRead.code(),
+                List.of(), contractRead.storage());
+        assertThrows(IllegalArgumentException.class, () -> ExternalReadSet.verifyAccount(ledger.stateRoot(), tampered));
+    }
+
+    @Test
+    void tamperedCode() {
+        var tampered = withFields(contractRead, contractRead.balance(), new JsonHex.Bytes("0xfeedbee0"),
+                contractRead.accountProof(), contractRead.storage());
+        var e = assertThrows(IllegalArgumentException.class, () -> ExternalReadSet.verifyAccount(ledger.stateRoot(), tampered));
+        assertTrue(e.getMessage().contains("code hash"));
+    }
+
+    @Test
+    void tamperedStorageValue() {
+        var slot = contractRead.storage().getFirst();
+        var tamperedSlot = new ExternalReadSet.StorageRead(slot.key(), new JsonHexNum.Uint256(12346), slot.proof());
+        var e = assertThrows(IllegalArgumentException.class, () -> ExternalReadSet.verifyStorage(contractRead, tamperedSlot));
+        assertTrue(e.getMessage().contains("storage proof"));
+
+        // the same check is made for every slot when verifying the account
+        var tampered = withFields(contractRead, contractRead.balance(), contractRead.code(),
+                contractRead.accountProof(), List.of(tamperedSlot));
+        assertThrows(IllegalArgumentException.class, () -> ExternalReadSet.verifyAccount(ledger.stateRoot(), tampered));
+    }
+
+    @Test
+    void tamperedStorageZeroValue() {
+        // claiming a value for a slot that is proved to be empty
+        var slot = contractRead.storage().get(1);
+        var tamperedSlot = new ExternalReadSet.StorageRead(slot.key(), new JsonHexNum.Uint256(1), slot.proof());
+        assertThrows(IllegalArgumentException.class, () -> ExternalReadSet.verifyStorage(contractRead, tamperedSlot));
+    }
+
+    @Test
+    void tamperedStorageProof() {
+        var slot = contractRead.storage().getFirst();
+        var tamperedSlot = new ExternalReadSet.StorageRead(slot.key(), slot.value(), tamperFirstNode(slot.proof()));
+        var e = assertThrows(IllegalArgumentException.class, () -> ExternalReadSet.verifyStorage(contractRead, tamperedSlot));
+        assertTrue(e.getMessage().contains("incomplete proof"));
+    }
+
+    @Test
+    void storageProofForDifferentSlot() {
+        var slot = contractRead.storage().getFirst();
+        var tamperedSlot = new ExternalReadSet.StorageRead(
+                new JsonHex.Bytes32(UInt256.valueOf(2).toArray()), slot.value(), slot.proof());
+        assertThrows(IllegalArgumentException.class, () -> ExternalReadSet.verifyStorage(contractRead, tamperedSlot));
+    }
+
+    @Test
+    void wrongStateRoot() {
+        assertThrows(IllegalArgumentException.class, () -> ExternalReadSet.verifyAccount(Bytes32.random(), contractRead));
+    }
+
+    @Test
+    void nonExistentAccount() throws IOException {
+        var reader = ledger.newReader();
+        var missing = EVMRunner.randomAddress();
+        assertTrue(reader.loadAccount(missing).isEmpty());
+        var read = reader.getReadSet().accounts().getFirst();
+        assertFalse(read.hasCode());
+        assertDoesNotThrow(() -> ExternalReadSet.verifyAccount(ledger.stateRoot(), read));
+
+        // an account that does not exist cannot be claimed to have a balance
+        var tampered = withFields(read, new JsonHexNum.Uint256(1), read.code(), read.accountProof(), read.storage());
+        assertThrows(IllegalArgumentException.class, () -> ExternalReadSet.verifyAccount(ledger.stateRoot(), tampered));
+    }
+
+    @Test
+    void existingAccountClaimedEmpty() {
+        // an account that exists cannot be claimed to be empty, by dropping it from the proof
+        var empty = new ExternalReadSet.AccountRead(
+                contractRead.address(),
+                JsonHexNum.Uint256.ZERO,
+                JsonHexNum.Uint256.ZERO,
+                new JsonHex.Bytes32(Hash.EMPTY.toArray()),
+                new JsonHex.Bytes32(Hash.EMPTY_TRIE_HASH.toArray()),
+                new JsonHex.Bytes(new byte[0]),
+                contractRead.accountProof(),
+                List.of()
+        );
+        assertThrows(IllegalArgumentException.class, () -> ExternalReadSet.verifyAccount(ledger.stateRoot(), empty));
+    }
+
+    @Test
+    void fetchBlock() throws IOException {
+        var block = ExternalReadSet.fetchBlock(ledger, TestBaseLedger.BLOCK_NUMBER);
+        assertEquals(TestBaseLedger.BLOCK_NUMBER, block.blockNumber());
+        assertEquals(ledger.blockHash(), block.blockHash());
+        assertEquals(ledger.stateRoot(), block.stateRoot());
+        assertEquals("0x64", ExternalReadSet.blockTag(TestBaseLedger.BLOCK_NUMBER));
+
+        var e = assertThrows(IOException.class, () -> ExternalReadSet.fetchBlock(ledger, TestBaseLedger.BLOCK_NUMBER + 1));
+        assertTrue(e.getMessage().contains("not available"));
+    }
+
+}
@@ -0,0 +1,148 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package io.kaleido.paladin.pente.evmstate;
+
+import io.kaleido.paladin.pente.evmrunner.EVMRunner;
+import org.apache.tuweni.bytes.Bytes;
+import org.apache.tuweni.bytes.Bytes32;
+import org.apache.tuweni.units.bigints.UInt256;
+import org.hyperledger.besu.datatypes.Address;
+import org.hyperledger.besu.datatypes.Hash;
+import org.hyperledger.besu.datatypes.Wei;
+import org.junit.jupiter.api.BeforeEach;
+import org.junit.jupiter.api.Test;
+
+import java.io.IOException;
+import java.math.BigInteger;
+import java.util.Map;
+
+import static org.junit.jupiter.api.Assertions.*;
+
+public class RPCExternalStateReaderTest {
+
+    private final Address contract = EVMRunner.randomAddress();
+
+    private final Bytes code = Bytes.fromHexString("0xfeedbeef");
+
+    private TestBaseLedger ledger;
+
+    @BeforeEach
+    void setup() {
+        ledger = new TestBaseLedger();
+        ledger.putAccount(contract, 3, BigInteger.valueOf(1000), code, Map.of(
+                UInt256.valueOf(1), UInt256.valueOf(12345),
+                UInt256.MAX_VALUE, UInt256.MAX_VALUE
+        ));
+        ledger.mine();
+    }
+
+    @Test
+    void recordsVerifiedReads() throws IOException {
+        var reader = ledger.newReader();
+        assertNull(reader.getReadSet());
+
+        var account = reader.loadAccount(contract).orElseThrow();
+        assertEquals(contract, account.getAddress());
+        assertEquals(3, account.getNonce());
+        assertEquals(Wei.of(1000), account.getBalance());
+        assertEquals(code, account.getCode());
+        assertFalse(account.isStorageEmpty());
+
+        assertEquals(UInt256.valueOf(12345), account.getStorageValue(UInt256.valueOf(1)));
+        assertEquals(UInt256.MAX_VALUE, account.getStorageValue(UInt256.MAX_VALUE));
+        assertEquals(UInt256.ZERO, account.getStorageValue(UInt256.valueOf(2)));
+
+        var readSet = reader.getReadSet();
+        assertEquals(TestBaseLedger.BLOCK_NUMBER, readSet.blockNumber().longValue());
+        assertEquals(ledger.blockHash(), Bytes32.wrap(readSet.blockHash().getBytes()));
+        assertEquals(ledger.stateRoot(), Bytes32.wrap(readSet.stateRoot().getBytes()));
+        assertEquals(1, readSet.accounts().size());
+        var read = readSet.accounts().getFirst();
+        assertEquals(3, read.storage().size());
+        assertEquals(Hash.hash(code), Bytes32.wrap(read.codeHash().getBytes()));
+
+        // The block header is only fetched once per reader
+        assertEquals(1, ledger.requests.stream().filter("eth_getBlockByNumber"::equals).count());
+
+        // Each read set has its own salt, so identical reads give distinct states
+        var reader2 = ledger.newReader();
+        reader2.loadAccount(contract);
+        assertNotEquals(readSet.salt(), reader2.getReadSet().salt());
+
+        // The read set still verifies after being stored
+        var stored = TestBaseLedger.copy(readSet);
+        assertDoesNotThrow(() -> ReadSetExternalStateReader.verify(ledger, stored, TestBaseLedger.BLOCK_NUMBER));
+    }
+
+    @Test
+    void emptyAndNonExistentAccounts() throws IOException {
+        var eoa = EVMRunner.randomAddress();
+        ledger.putAccount(eoa, 1, BigInteger.TEN, Bytes.EMPTY, Map.of());
+        ledger.mine();
+        var missing = EVMRunner.randomAddress();
+
+        var reader = ledger.newReader();
+        assertTrue(reader.loadAccount(eoa).isEmpty());
+        assertTrue(reader.loadAccount(missing).isEmpty());
+        // no code is fetched for accounts without code
+        assertFalse(ledger.requests.contains("eth_getCode"));
+
+        var readSet = reader.getReadSet();
+        assertEquals(2, readSet.accounts().size());
+        var missingRead = readSet.accounts().get(1);
+        // the zero hashes returned for a missing account are normalized to the empty hashes
+        assertEquals(Hash.EMPTY, Bytes32.wrap(missingRead.codeHash().getBytes()));
+        assertEquals(Hash.EMPTY_TRIE_HASH, Bytes32.wrap(missingRead.storageHash().getBytes()));
+        assertEquals(0, missingRead.code().getBytes().length);
+    }
+
+    @Test
+    void storageBeforeAccount() {
+        var reader = ledger.newReader();
+        var e = assertThrows(IOException.class, () -> reader.loadStorage(contract, UInt256.valueOf(1)));
+        assertTrue(e.getMessage().contains("has not been loaded"));
+    }
+
+    @Test
+    void blockNotAvailable() {
+        var reader = new RPCExternalStateReader(ledger, TestBaseLedger.BLOCK_NUMBER + 1);
+        var e = assertThrows(IOException.class, () -> reader.loadAccount(contract));
+        assertTrue(e.getMessage().contains("not available"));
+    }
+
+    @Test
+    void nodeReturnsInconsistentAccount() {
+        // The node has moved on from the block it gave us the header for
+        ledger.putAccount(contract, 4, BigInteger.valueOf(1000), code, Map.of());
+        var reader = ledger.newReader();
+        assertThrows(IllegalArgumentException.class, () -> reader.loadAccount(contract));
+        assertNull(reader.getReadSet());
+    }
+
+    @Test
+    void nodeReturnsInconsistentStorage() throws IOException {
+        var reader = ledger.newReader();
+        var account = reader.loadAccount(contract).orElseThrow();
+
+        // Changing the storage changes the storage root the node proves against
+        ledger.putAccount(contract, 3, BigInteger.valueOf(1000), code, Map.of(
+                UInt256.valueOf(1), UInt256.valueOf(54321)
+        ));
+        assertThrows(IllegalArgumentException.class, () -> account.getStorageValue(UInt256.valueOf(1)));
+        assertTrue(reader.getReadSet().accounts().getFirst().storage().isEmpty());
+    }
+
+}
@@ -0,0 +1,185 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package io.kaleido.paladin.pente.evmstate;
+
+import io.kaleido.paladin.pente.evmrunner.EVMRunner;
+import io.kaleido.paladin.toolkit.JsonHex;
+import io.kaleido.paladin.toolkit.JsonHexNum;
+import org.apache.tuweni.bytes.Bytes;
+import org.apache.tuweni.bytes.Bytes32;
+import org.apache.tuweni.units.bigints.UInt256;
+import org.hyperledger.besu.datatypes.Address;
+import org.junit.jupiter.api.BeforeEach;
+import org.junit.jupiter.api.Test;
+
+import java.io.IOException;
+import java.math.BigInteger;
+import java.util.List;
+import java.util.Map;
+
+import static org.junit.jupiter.api.Assertions.*;
+
+public class ReadSetExternalStateReaderTest {
+
+    private final Address contract = EVMRunner.randomAddress();
+
+    private final Address eoa = EVMRunner.randomAddress();
+
+    private final Address missing = EVMRunner.randomAddress();
+
+    private TestBaseLedger ledger;
+
+    private ExternalReadSet.ReadSetJson readSet;
+
+    @BeforeEach
+    void setup() throws IOException {
+        ledger = new TestBaseLedger();
+        ledger.putAccount(contract, 1, BigInteger.ZERO, Bytes.fromHexString("0xfeedbeef"), Map.of(
+                UInt256.valueOf(1), UInt256.valueOf(12345)
+        ));
+        ledger.putAccount(eoa, 5, BigInteger.valueOf(1000), Bytes.EMPTY, Map.of());
+        ledger.mine();
+
+        var reader = ledger.newReader();
+        reader.loadAccount(contract).orElseThrow().getStorageValue(UInt256.valueOf(1));
+        assertTrue(reader.loadAccount(eoa).isEmpty());
+        assertTrue(reader.loadAccount(missing).isEmpty());
+        readSet = TestBaseLedger.copy(reader.getReadSet());
+    }
+
+    private ExternalReadSet.ReadSetJson withAnchor(long blockNumber, Bytes32 blockHash, Bytes32 stateRoot) {
+        return new ExternalReadSet.ReadSetJson(
+                readSet.salt(),
+                new JsonHexNum.Uint256(blockNumber),
+                new JsonHex.Bytes32(blockHash.toArray()),
+                new JsonHex.Bytes32(stateRoot.toArray()),
+                readSet.accounts()
+        );
+    }
+
+    @Test
+    void verifiedReads() throws IOException {
+        assertEquals(TestBaseLedger.BLOCK_NUMBER, readSet.blockNumber().longValue());
+        assertEquals(3, readSet.accounts().size());
+
+        ledger.requests.clear();
+        var reader = ReadSetExternalStateReader.verify(ledger, readSet, TestBaseLedger.BLOCK_NUMBER);
+        var account = reader.loadAccount(contract).orElseThrow();
+        assertEquals(1, account.getNonce());
+        assertEquals(Bytes.fromHexString("0xfeedbeef"), account.getCode());
+        assertEquals(UInt256.valueOf(12345), account.getStorageValue(UInt256.valueOf(1)));
+        assertEquals(UInt256.valueOf(12345), reader.loadStorage(contract, UInt256.valueOf(1)));
+
+        // EOAs and accounts that do not exist are not contracts
+        assertTrue(reader.loadAccount(eoa).isEmpty());
+        assertTrue(reader.loadAccount(missing).isEmpty());
+
+        // Only the block header is fetched from our own node - nothing is re-queried
+        assertEquals(List.of("eth_getBlockByNumber"), ledger.requests);
+    }
+
+    @Test
+    void readsOutsideTheReadSet() throws IOException {
+        var reader = ReadSetExternalStateReader.verify(ledger, readSet, TestBaseLedger.BLOCK_NUMBER);
+
+        var e = assertThrows(IOException.class, () -> reader.loadAccount(EVMRunner.randomAddress()));
+        assertTrue(e.getMessage().contains("not in the read set"));
+
+        e = assertThrows(IOException.class, () -> reader.loadStorage(contract, UInt256.valueOf(2)));
+        assertTrue(e.getMessage().contains("not in the read set"));
+
+        e = assertThrows(IOException.class, () -> reader.loadStorage(EVMRunner.randomAddress(), UInt256.valueOf(1)));
+        assertTrue(e.getMessage().contains("not in the read set"));
+
+        // The same applies to slots read through the account during execution
+        var account = reader.loadAccount(contract).orElseThrow();
+        var re = assertThrows(RuntimeException.class, () -> account.getStorageValue(UInt256.valueOf(2)));
+        assertInstanceOf(IOException.class, re.getCause());
+    }
+
+    @Test
+    void blockNumberMismatch() {
+        var e = assertThrows(IllegalArgumentException.class,
+                () -> ReadSetExternalStateReader.verify(ledger, readSet, TestBaseLedger.BLOCK_NUMBER + 1));
+        assertTrue(e.getMessage().contains("does not match transaction base block"));
+    }
+
+    @Test
+    void blockNotAvailable() {
+        var anchored = withAnchor(TestBaseLedger.BLOCK_NUMBER + 1, ledger.blockHash(), ledger.stateRoot());
+        assertThrows(IOException.class,
+                () -> ReadSetExternalStateReader.verify(ledger, anchored, TestBaseLedger.BLOCK_NUMBER + 1));
+    }
+
+    @Test
+    void blockHashMismatch() {
+        var anchored = withAnchor(TestBaseLedger.BLOCK_NUMBER, Bytes32.random(), ledger.stateRoot());
+        var e = assertThrows(IllegalArgumentException.class,
+                () -> ReadSetExternalStateReader.verify(ledger, anchored, TestBaseLedger.BLOCK_NUMBER));
+        assertTrue(e.getMessage().contains("anchored to block"));
+    }
+
+    @Test
+    void stateRootMismatch() {
+        var anchored = withAnchor(TestBaseLedger.BLOCK_NUMBER, ledger.blockHash(), Bytes32.random());
+        var e = assertThrows(IllegalArgumentException.class,
+                () -> ReadSetExternalStateReader.verify(ledger, anchored, TestBaseLedger.BLOCK_NUMBER));
+        assertTrue(e.getMessage().contains("anchored to block"));
+    }
+
+    @Test
+    void readSetFromDifferentState() throws IOException {
+        // The assembler read from a node with a different state at the same block - the
+        // anchor claims our block, but the proofs do not match our state root
+        var other = new TestBaseLedger();
+        other.putAccount(contract, 1, BigInteger.ZERO, Bytes.fromHexString("0xfeedbeef"), Map.of(
+                UInt256.valueOf(1), UInt256.valueOf(99999)
+        ));
+        other.mine();
+        var otherReader = other.newReader();
+        otherReader.loadAccount(contract).orElseThrow().getStorageValue(UInt256.valueOf(1));
+        var otherReadSet = otherReader.getReadSet();
+
+        var anchored = new ExternalReadSet.ReadSetJson(
+                otherReadSet.salt(),
+                otherReadSet.blockNumber(),
+                new JsonHex.Bytes32(ledger.blockHash().toArray()),
+                new JsonHex.Bytes32(ledger.stateRoot().toArray()),
+                otherReadSet.accounts()
+        );
+        assertThrows(IllegalArgumentException.class,
+                () -> ReadSetExternalStateReader.verify(ledger, anchored, TestBaseLedger.BLOCK_NUMBER));
+    }
+
+    @Test
+    void tamperedStorageValue() {
+        var account = readSet.accounts().getFirst();
+        var slot = account.storage().getFirst();
+        account.storage().set(0, new ExternalReadSet.StorageRead(slot.key(), new JsonHexNum.Uint256(99999), slot.proof()));
+        assertThrows(IllegalArgumentException.class,
+                () -> ReadSetExternalStateReader.verify(ledger, readSet, TestBaseLedger.BLOCK_NUMBER));
+    }
+
+    @Test
+    void confirmedReadSetIsNotVerified() throws IOException {
+        // Read sets from confirmed transactions have already been checked, so we do not need the node
+        var requests = ledger.requests.size();
+        var reader = ReadSetExternalStateReader.confirmed(readSet);
+        assertEquals(UInt256.valueOf(12345), reader.loadStorage(contract, UInt256.valueOf(1)));
+        assertEquals(requests, ledger.requests.size());
+    }
+
+}
@@ -0,0 +1,184 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package io.kaleido.paladin.pente.evmstate;
+
+import com.fasterxml.jackson.databind.ObjectMapper;
+import io.kaleido.paladin.toolkit.JsonRpcClient;
+import org.apache.tuweni.bytes.Bytes;
+import org.apache.tuweni.bytes.Bytes32;
+import org.apache.tuweni.units.bigints.UInt256;
+import org.hyperledger.besu.datatypes.Address;
+import org.hyperledger.besu.datatypes.Hash;
+import org.hyperledger.besu.ethereum.trie.patricia.SimpleMerklePatriciaTrie;
+import org.web3j.rlp.RlpEncoder;
+import org.web3j.rlp.RlpList;
+import org.web3j.rlp.RlpString;
+
+import java.io.IOException;
+import java.math.BigInteger;
+import java.util.*;
+
+/**
+ * An in-memory base ledger for testing, that serves eth_getProof from real Merkle Patricia tries.
+ * The block header is only updated by mine(), so changing accounts after mining gives proofs
+ * that do not match the header - like a node that is lying, or has moved on.
+ */
+class TestBaseLedger extends JsonRpcClient {
+
+    static final long BLOCK_NUMBER = 100;
+
+    private record AccountState(long nonce, BigInteger balance, Bytes code, SimpleMerklePatriciaTrie<Bytes32, Bytes> storage) {}
+
+    private final SimpleMerklePatriciaTrie<Bytes32, Bytes> worldTrie = new SimpleMerklePatriciaTrie<>(b -> b);
+
+    private final Map<Address, AccountState> accounts = new HashMap<>();
+
+    private final Bytes32 blockHash = Bytes32.random();
+
+    private Bytes32 stateRoot;
+
+    final List<String> requests = new ArrayList<>();
+
+    TestBaseLedger() {
+        super("http://localhost:0");
+    }
+
+    static Bytes rlpUint(BigInteger value) {
+        return Bytes.wrap(RlpEncoder.encode(RlpString.create(value)));
+    }
+
+    void putAccount(Address address, long nonce, BigInteger balance, Bytes code, Map<UInt256, UInt256> storage) {
+        var storageTrie = new SimpleMerklePatriciaTrie<Bytes32, Bytes>(b -> b);
+        storage.forEach((k, v) -> {
+            if (!v.isZero()) {
+                storageTrie.put(Hash.hash(Bytes.wrap(k.toArray())), rlpUint(v.toBigInteger()));
+            }
+        });
+        var account = new AccountState(nonce, balance, code, storageTrie);
+        accounts.put(address, account);
+        worldTrie.put(Hash.hash(address), Bytes.wrap(RlpEncoder.encode(new RlpList(
+                RlpString.create(BigInteger.valueOf(nonce)),
+                RlpString.create(balance),
+                RlpString.create(storageTrie.getRootHash().toArray()),
+                RlpString.create(Hash.hash(code).toArray())
+        ))));
+    }
+
+    void mine() {
+        stateRoot = worldTrie.getRootHash();
+    }
+
+    Bytes32 blockHash() {
+        return blockHash;
+    }
+
+    Bytes32 stateRoot() {
+        return stateRoot;
+    }
+
+    RPCExternalStateReader newReader() {
+        return new RPCExternalStateReader(this, BLOCK_NUMBER);
+    }
+
+    /** round-trips the read set through JSON, as it would be when stored as a state */
+    static ExternalReadSet.ReadSetJson copy(ExternalReadSet.ReadSetJson readSet) throws IOException {
+        var mapper = new ObjectMapper();
+        return mapper.readValue(mapper.writeValueAsString(readSet), ExternalReadSet.ReadSetJson.class);
+    }
+
+    private static String hex(Bytes b) {
+        return b.toHexString();
+    }
+
+    private static String hexQuantity(BigInteger value) {
+        return "0x" + value.toString(16);
+    }
+
+    private static List<String> hexList(List<Bytes> nodes) {
+        return nodes.stream().map(Bytes::toHexString).toList();
+    }
+
+    private Map<String, Object> getBlock(String blockTag) {
+        if (Long.parseLong(blockTag.substring(2), 16) != BLOCK_NUMBER) {
+            return null;
+        }
+        return Map.of(
+                "number", blockTag,
+                "hash", hex(blockHash),
+                "stateRoot", hex(stateRoot)
+        );
+    }
+
+    private Map<String, Object> getProof(Address address, List<String> keys) {
+        var account = accounts.get(address);
+        var result = new HashMap<String, Object>();
+        result.put("accountProof", hexList(worldTrie.getValueWithProof(Hash.hash(address)).getProofRelatedNodes()));
+        var storageProof = new ArrayList<Map<String, Object>>();
+        if (account == null) {
+            // Like geth, we return zero hashes for accounts that do not exist
+            result.put("nonce", "0x0");
+            result.put("balance", "0x0");
+            result.put("codeHash", hex(Bytes32.ZERO));
+            result.put("storageHash", hex(Bytes32.ZERO));
+            for (var key : keys) {
+                storageProof.add(Map.of("key", key, "value", "0x0", "proof", List.of()));
+            }
+        } else {
+            result.put("nonce", hexQuantity(BigInteger.valueOf(account.nonce())));
+            result.put("balance", hexQuantity(account.balance()));
+            result.put("codeHash", hex(Hash.hash(account.code())));
+            result.put("storageHash", hex(account.storage().getRootHash()));
+            for (var key : keys) {
+                var proof = account.storage().getValueWithProof(Hash.hash(Bytes32.fromHexString(key)));
+                var value = proof.getValue()
+                        .map(v -> UInt256.valueOf(new BigInteger(1, rlpValue(v))))
+                        .orElse(UInt256.ZERO);
+                storageProof.add(Map.of(
+                        "key", key,
+                        "value", hexQuantity(value.toBigInteger()),
+                        "proof", hexList(proof.getProofRelatedNodes())
+                ));
+            }
+        }
+        result.put("storageProof", storageProof);
+        return result;
+    }
+
+    // strips the RLP string header from an encoded storage value
+    private static byte[] rlpValue(Bytes encoded) {
+        var first = encoded.get(0) & 0xff;
+        if (first < 0x80) {
+            return encoded.toArray();
+        }
+        return encoded.slice(1).toArray();
+    }
+
+    @Override
+    @SuppressWarnings("unchecked")
+    public <ResultType> ResultType request(String method, Object... params) throws IOException {
+        requests.add(method);
+        Object result = switch (method) {
+            case "eth_getBlockByNumber" -> getBlock((String) params[0]);
+            case "eth_getProof" -> getProof(Address.fromHexString((String) params[0]), (List<String>) params[1]);
+            case "eth_getCode" -> {
+                var account = accounts.get(Address.fromHexString((String) params[0]));
+                yield account == null ? "0x" : account.code().toHexString();
+            }
+            default -> throw new IOException("unexpected method %s".formatted(method));
+        };
+        return (ResultType) result;
+    }
+}
@@ -173,4 +173,14 @@ var (
 	MsgErrorDecodeDelegateExtras             = pde("PD210132", "Failed to decode delegate in extras. %s")
 	MsgErrorMissingLockDelegate              = pde("PD210133", "lock delegate is required")
 	MsgFailedToQueryStatesById               = pde("PD210134", "Failed to query states by IDs. Wanted: %d, Found: %d")
+	MsgErrorIdentityNotRegistered            = pde("PD210135", "Identity %s is not registered with the KYC token. %s")
+	MsgErrorHashIdentity                     = pde("PD210136", "Failed to hash the identity public key. %s")
+	MsgErrorDecodeRegisterCall               = pde("PD210137", "Failed to decode the register call. %s")
+	MsgNoParamIdentity                       = pde("PD210138", "Parameter 'identity' is required")
+	MsgErrorRegisterNotKycToken              = pde("PD210139", "Identities can only be registered with a KYC token. Token: %s")
+	MsgInvalidConfigRemoteProverEndpoint     = pde("PD210140", "Remote prover endpoint must be set via the configuration file")
+	MsgRemoteProverTLSRequired               = pde("PD210141", "TLS must be enabled for the remote prover, as the witness contains private inputs")
+	MsgErrorRemoteProverConnect              = pde("PD210142", "Failed to connect to remote prover at %s. %s")
+	MsgErrorRemoteProverNoProof              = pde("PD210143", "Remote prover returned no proof for circuit %s")
+	MsgErrorLoadProvingKey                   = pde("PD210144", "Failed to load proving key for circuit %s. %s")
 )
@@ -27,6 +27,7 @@ import (
 	"github.com/kaleido-io/paladin/domains/zeto/pkg/types"
 
 	"github.com/iden3/go-iden3-crypto/babyjub"
+	"github.com/iden3/go-iden3-crypto/poseidon"
 	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
 	"github.com/kaleido-io/paladin/domains/zeto/internal/msgs"
 	"github.com/kaleido-io/paladin/domains/zeto/pkg/constants"
@@ -49,7 +50,11 @@ func IsBatchCircuit(sizeOfEndorsableStates int) bool {
 }
 
 func IsNullifiersToken(tokenName string) bool {
-	return tokenName == constants.TOKEN_ANON_NULLIFIER || tokenName == constants.TOKEN_NF_ANON_NULLIFIER
+	return tokenName == constants.TOKEN_ANON_NULLIFIER || tokenName == constants.TOKEN_ANON_NULLIFIER_KYC || tokenName == constants.TOKEN_NF_ANON_NULLIFIER
+}
+
+func IsKycToken(tokenName string) bool {
+	return tokenName == constants.TOKEN_ANON_NULLIFIER_KYC
 }
 
 func IsNonFungibleToken(tokenName string) bool {
@@ -92,6 +97,12 @@ func LoadBabyJubKey(payload []byte) (*babyjub.PublicKey, error) {
 	return keyCompressed.Decompress()
 }
 
+// IdentityHash returns the index of a public key in the identities
+// Merkle tree of a KYC token, matching the hashing in the on-chain registry
+func IdentityHash(publicKey *babyjub.PublicKey) (*big.Int, error) {
+	return poseidon.Hash([]*big.Int{publicKey.X, publicKey.Y})
+}
+
 func EncodeTransactionData(ctx context.Context, transaction *prototk.TransactionSpecification, infoStates []*prototk.EndorsableState) (pldtypes.HexBytes, error) {
 	var err error
 	stateIDs := make([]pldtypes.Bytes32, len(infoStates))
@@ -20,9 +20,15 @@ import (
 func TestIsNullifiersToken(t *testing.T) {
 	assert.True(t, IsNullifiersToken(constants.TOKEN_ANON_NULLIFIER))
 	assert.True(t, IsNullifiersToken(constants.TOKEN_NF_ANON_NULLIFIER))
+	assert.True(t, IsNullifiersToken(constants.TOKEN_ANON_NULLIFIER_KYC))
 	assert.False(t, IsNullifiersToken("other"))
 }
 
+func TestIsKycToken(t *testing.T) {
+	assert.True(t, IsKycToken(constants.TOKEN_ANON_NULLIFIER_KYC))
+	assert.False(t, IsKycToken(constants.TOKEN_ANON_NULLIFIER))
+}
+
 // HexUint256To32ByteHexString
 func TestHexUint256To32ByteHexString(t *testing.T) {
 	// Create a big.Int and cast it to *pldtypes.HexUint256.
@@ -28,12 +28,23 @@ var zetoABIBytes []byte
 //go:embed abis/IZetoLockable.json
 var zetoLockableABIBytes []byte
 
+// emitted by the identity registry of the KYC tokens
+var identityRegisteredEventABI = &abi.Entry{
+	Type: abi.Event,
+	Name: "IdentityRegistered",
+	Inputs: abi.ParameterArray{
+		{Name: "publicKey", Type: "uint256[2]"},
+		{Name: "data", Type: "bytes"},
+	},
+}
+
 func getAllZetoEventAbis() abi.ABI {
 	var events abi.ABI
 	contract := solutils.MustLoadBuild(zetoABIBytes)
 	events = buildEvents(events, contract)
 	contract = solutils.MustLoadBuild(zetoLockableABIBytes)
 	events = buildEvents(events, contract)
+	events = append(events, identityRegisteredEventABI)
 	return events
 }
 
@@ -20,11 +20,15 @@ import (
 
 	"github.com/kaleido-io/paladin/sdk/go/pkg/solutils"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestGetAllZetoEventAbis(t *testing.T) {
 	events := getAllZetoEventAbis()
-	assert.Equal(t, 6, len(events))
+	assert.Equal(t, 7, len(events))
+	registered := events.Events()["IdentityRegistered"]
+	require.NotNil(t, registered)
+	assert.Equal(t, "event IdentityRegistered(uint256[2] publicKey, bytes data)", registered.SolString())
 }
 
 func TestBuildEvents(t *testing.T) {
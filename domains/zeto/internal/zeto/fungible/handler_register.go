@@ -0,0 +1,149 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package fungible
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/hyperledger/firefly-signer/pkg/abi"
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/domains/zeto/internal/msgs"
+	"github.com/kaleido-io/paladin/domains/zeto/internal/zeto/common"
+	"github.com/kaleido-io/paladin/domains/zeto/pkg/types"
+	"github.com/kaleido-io/paladin/domains/zeto/pkg/zetosigner"
+	"github.com/kaleido-io/paladin/domains/zeto/pkg/zetosigner/zetosignerapi"
+	"github.com/kaleido-io/paladin/toolkit/pkg/domain"
+	pb "github.com/kaleido-io/paladin/toolkit/pkg/prototk"
+)
+
+// registers the Baby Jubjub public key of an identity in the on-chain
+// registry of a KYC token, which is a pre-requisite for being the
+// sender or a receiver of a transfer
+var registerABI = &abi.Entry{
+	Type: abi.Function,
+	Name: types.METHOD_REGISTER,
+	Inputs: abi.ParameterArray{
+		{Name: "publicKey", Type: "uint256[2]"},
+		{Name: "data", Type: "bytes"},
+	},
+}
+
+var _ types.DomainHandler = &registerHandler{}
+
+type registerHandler struct {
+	baseHandler
+}
+
+func NewRegisterHandler(name string, dataSchema *pb.StateSchema) *registerHandler {
+	return &registerHandler{
+		baseHandler: baseHandler{
+			name: name,
+			stateSchemas: &common.StateSchemas{
+				DataSchema: dataSchema,
+			},
+		},
+	}
+}
+
+func (h *registerHandler) ValidateParams(ctx context.Context, config *types.DomainInstanceConfig, params string) (interface{}, error) {
+	if !common.IsKycToken(config.TokenName) {
+		return nil, i18n.NewError(ctx, msgs.MsgErrorRegisterNotKycToken, config.TokenName)
+	}
+	var registerParams types.RegisterParams
+	if err := json.Unmarshal([]byte(params), &registerParams); err != nil {
+		return nil, i18n.NewError(ctx, msgs.MsgErrorDecodeRegisterCall, err)
+	}
+	if registerParams.Identity == "" {
+		return nil, i18n.NewError(ctx, msgs.MsgNoParamIdentity)
+	}
+	return &registerParams, nil
+}
+
+func (h *registerHandler) Init(ctx context.Context, tx *types.ParsedTransaction, req *pb.InitTransactionRequest) (*pb.InitTransactionResponse, error) {
+	params := tx.Params.(*types.RegisterParams)
+	return &pb.InitTransactionResponse{
+		RequiredVerifiers: []*pb.ResolveVerifierRequest{
+			{
+				Lookup:       params.Identity,
+				Algorithm:    h.getAlgoZetoSnarkBJJ(),
+				VerifierType: zetosignerapi.IDEN3_PUBKEY_BABYJUBJUB_COMPRESSED_0X,
+			},
+		},
+	}, nil
+}
+
+func (h *registerHandler) Assemble(ctx context.Context, tx *types.ParsedTransaction, req *pb.AssembleTransactionRequest) (*pb.AssembleTransactionResponse, error) {
+	params := tx.Params.(*types.RegisterParams)
+
+	resolvedIdentity := domain.FindVerifier(params.Identity, h.getAlgoZetoSnarkBJJ(), zetosignerapi.IDEN3_PUBKEY_BABYJUBJUB_COMPRESSED_0X, req.ResolvedVerifiers)
+	if resolvedIdentity == nil {
+		return nil, i18n.NewError(ctx, msgs.MsgErrorResolveVerifier, params.Identity)
+	}
+	if _, err := zetosigner.DecodeBabyJubJubPublicKey(resolvedIdentity.Verifier); err != nil {
+		return nil, i18n.NewError(ctx, msgs.MsgErrorLoadOwnerPubKey, err)
+	}
+
+	infoStates, err := prepareTransactionInfoStates(ctx, params.Data, []string{tx.Transaction.From, params.Identity}, h.stateSchemas.DataSchema)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.AssembleTransactionResponse{
+		AssemblyResult: pb.AssembleTransactionResponse_OK,
+		AssembledTransaction: &pb.AssembledTransaction{
+			InfoStates: infoStates,
+			DomainData: &resolvedIdentity.Verifier,
+		},
+		AttestationPlan: []*pb.AttestationRequest{},
+	}, nil
+}
+
+func (h *registerHandler) Endorse(ctx context.Context, tx *types.ParsedTransaction, req *pb.EndorseTransactionRequest) (*pb.EndorseTransactionResponse, error) {
+	return nil, nil
+}
+
+func (h *registerHandler) Prepare(ctx context.Context, tx *types.ParsedTransaction, req *pb.PrepareTransactionRequest) (*pb.PrepareTransactionResponse, error) {
+	publicKey, err := zetosigner.DecodeBabyJubJubPublicKey(*req.DomainData)
+	if err != nil {
+		return nil, i18n.NewError(ctx, msgs.MsgErrorLoadOwnerPubKey, err)
+	}
+
+	data, err := common.EncodeTransactionData(ctx, req.Transaction, req.InfoStates)
+	if err != nil {
+		return nil, i18n.NewError(ctx, msgs.MsgErrorEncodeTxData, err)
+	}
+	params := map[string]interface{}{
+		"publicKey": []string{publicKey.X.Text(10), publicKey.Y.Text(10)},
+		"data":      data,
+	}
+	paramsJSON, err := json.Marshal(params)
+	if err != nil {
+		return nil, err
+	}
+	functionJSON, err := json.Marshal(registerABI)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.PrepareTransactionResponse{
+		Transaction: &pb.PreparedTransaction{
+			FunctionAbiJson: string(functionJSON),
+			ParamsJson:      string(paramsJSON),
+			RequiredSigner:  &req.Transaction.From, // must be signed by the registry owner on-chain
+		},
+	}, nil
+}
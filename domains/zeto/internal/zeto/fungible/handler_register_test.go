@@ -0,0 +1,167 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package fungible
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/iden3/go-iden3-crypto/babyjub"
+	"github.com/kaleido-io/paladin/domains/zeto/internal/zeto/common"
+	"github.com/kaleido-io/paladin/domains/zeto/pkg/constants"
+	"github.com/kaleido-io/paladin/domains/zeto/pkg/types"
+	"github.com/kaleido-io/paladin/domains/zeto/pkg/zetosigner/zetosignerapi"
+	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegisterValidateParams(t *testing.T) {
+	h := registerHandler{}
+	ctx := context.Background()
+	config := &types.DomainInstanceConfig{TokenName: constants.TOKEN_ANON_NULLIFIER}
+	_, err := h.ValidateParams(ctx, config, "{}")
+	assert.EqualError(t, err, "PD210139: Identities can only be registered with a KYC token. Token: Zeto_AnonNullifier")
+
+	config.TokenName = constants.TOKEN_ANON_NULLIFIER_KYC
+	_, err = h.ValidateParams(ctx, config, "bad json")
+	assert.ErrorContains(t, err, "PD210137: Failed to decode the register call.")
+
+	_, err = h.ValidateParams(ctx, config, "{}")
+	assert.EqualError(t, err, "PD210138: Parameter 'identity' is required")
+
+	params, err := h.ValidateParams(ctx, config, "{\"identity\":\"Alice\",\"data\":\"0x1234\"}")
+	require.NoError(t, err)
+	assert.Equal(t, "Alice", params.(*types.RegisterParams).Identity)
+	assert.Equal(t, "0x1234", params.(*types.RegisterParams).Data.String())
+}
+
+func TestRegisterInit(t *testing.T) {
+	h := registerHandler{
+		baseHandler: baseHandler{
+			name: "test1",
+		},
+	}
+	ctx := context.Background()
+	tx := &types.ParsedTransaction{
+		Params: &types.RegisterParams{Identity: "Alice"},
+		Transaction: &prototk.TransactionSpecification{
+			From: "Bob",
+		},
+	}
+	res, err := h.Init(ctx, tx, &prototk.InitTransactionRequest{})
+	require.NoError(t, err)
+	assert.Len(t, res.RequiredVerifiers, 1)
+	assert.Equal(t, "Alice", res.RequiredVerifiers[0].Lookup)
+	assert.Equal(t, zetosignerapi.IDEN3_PUBKEY_BABYJUBJUB_COMPRESSED_0X, res.RequiredVerifiers[0].VerifierType)
+	assert.Equal(t, zetosignerapi.AlgoDomainZetoSnarkBJJ("test1"), res.RequiredVerifiers[0].Algorithm)
+}
+
+func TestRegisterAssemble(t *testing.T) {
+	h := registerHandler{
+		baseHandler: baseHandler{
+			name: "test1",
+			stateSchemas: &common.StateSchemas{
+				DataSchema: &prototk.StateSchema{
+					Id: "data",
+				},
+			},
+		},
+	}
+	ctx := context.Background()
+	tx := &types.ParsedTransaction{
+		Params: &types.RegisterParams{Identity: "Alice"},
+		Transaction: &prototk.TransactionSpecification{
+			From: "Bob",
+		},
+		DomainConfig: &types.DomainInstanceConfig{
+			TokenName: constants.TOKEN_ANON_NULLIFIER_KYC,
+		},
+	}
+	req := &prototk.AssembleTransactionRequest{}
+	_, err := h.Assemble(ctx, tx, req)
+	assert.EqualError(t, err, "PD210036: Failed to resolve verifier: Alice")
+
+	req.ResolvedVerifiers = []*prototk.ResolvedVerifier{
+		{
+			Lookup:       "Alice",
+			Algorithm:    zetosignerapi.AlgoDomainZetoSnarkBJJ("test1"),
+			VerifierType: zetosignerapi.IDEN3_PUBKEY_BABYJUBJUB_COMPRESSED_0X,
+			Verifier:     "0x1234567890123456789012345678901234567890",
+		},
+	}
+	_, err = h.Assemble(ctx, tx, req)
+	assert.EqualError(t, err, "PD210037: Failed load owner public key. PD210072: Invalid compressed public key length: 20")
+
+	privKey := babyjub.NewRandPrivKey()
+	compressedKey := privKey.Public().Compress()
+	req.ResolvedVerifiers[0].Verifier = compressedKey.String()
+	res, err := h.Assemble(ctx, tx, req)
+	require.NoError(t, err)
+	assert.Equal(t, prototk.AssembleTransactionResponse_OK, res.AssemblyResult)
+	assert.Empty(t, res.AssembledTransaction.OutputStates)
+	assert.Len(t, res.AssembledTransaction.InfoStates, 1)
+	assert.Equal(t, "data", res.AssembledTransaction.InfoStates[0].SchemaId)
+	assert.Equal(t, compressedKey.String(), *res.AssembledTransaction.DomainData)
+}
+
+func TestRegisterEndorse(t *testing.T) {
+	h := registerHandler{}
+	res, err := h.Endorse(context.Background(), &types.ParsedTransaction{}, &prototk.EndorseTransactionRequest{})
+	assert.NoError(t, err)
+	assert.Nil(t, res)
+}
+
+func TestRegisterPrepare(t *testing.T) {
+	h := registerHandler{
+		baseHandler: baseHandler{
+			name: "test1",
+		},
+	}
+	txSpec := &prototk.TransactionSpecification{
+		TransactionId: "bad hex",
+		From:          "Bob",
+	}
+	tx := &types.ParsedTransaction{
+		Params:      &types.RegisterParams{Identity: "Alice"},
+		Transaction: txSpec,
+	}
+	badKey := "0x1234"
+	req := &prototk.PrepareTransactionRequest{
+		Transaction: txSpec,
+		DomainData:  &badKey,
+	}
+	ctx := context.Background()
+	_, err := h.Prepare(ctx, tx, req)
+	assert.ErrorContains(t, err, "PD210037: Failed load owner public key.")
+
+	privKey := babyjub.NewRandPrivKey()
+	pubKey := privKey.Public()
+	compressedKey := pubKey.Compress().String()
+	req.DomainData = &compressedKey
+	_, err = h.Prepare(ctx, tx, req)
+	assert.ErrorContains(t, err, "PD210049: Failed to encode transaction data. PD210028: Failed to parse transaction id. PD020007: Invalid hex")
+
+	txSpec.TransactionId = "0x87229d205a0f48bcf0da37542fc140a9bdfc3b4a55c0beffcb62efe25a770a7f"
+	res, err := h.Prepare(ctx, tx, req)
+	require.NoError(t, err)
+	assert.Equal(t, "Bob", *res.Transaction.RequiredSigner)
+	var params map[string]any
+	require.NoError(t, json.Unmarshal([]byte(res.Transaction.ParamsJson), &params))
+	assert.Equal(t, []any{pubKey.X.Text(10), pubKey.Y.Text(10)}, params["publicKey"])
+	assert.Contains(t, res.Transaction.FunctionAbiJson, "\"register\"")
+}
@@ -21,7 +21,10 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/hyperledger-labs/zeto/go-sdk/pkg/sparse-merkle-tree/node"
+	"github.com/iden3/go-iden3-crypto/babyjub"
 	"github.com/kaleido-io/paladin/domains/zeto/internal/zeto/common"
+	"github.com/kaleido-io/paladin/domains/zeto/internal/zeto/smt"
 	"github.com/kaleido-io/paladin/domains/zeto/pkg/constants"
 	corepb "github.com/kaleido-io/paladin/domains/zeto/pkg/proto"
 	"github.com/kaleido-io/paladin/domains/zeto/pkg/types"
@@ -32,6 +35,7 @@ import (
 	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
 	pb "github.com/kaleido-io/paladin/toolkit/pkg/prototk"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"google.golang.org/protobuf/proto"
 )
 
@@ -546,6 +550,64 @@ func TestGenerateMerkleProofs(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestGenerateIdentityProofs(t *testing.T) {
+	testCallbacks := &domain.MockDomainCallbacks{
+		MockFindAvailableStates: func() (*pb.FindAvailableStatesResponse, error) {
+			return nil, errors.New("test error")
+		},
+	}
+	rootSchema := &pb.StateSchema{Id: "merkle_tree_root"}
+	nodeSchema := &pb.StateSchema{Id: "merkle_tree_node"}
+	addr := pldtypes.MustEthAddress("0x1234567890123456789012345678901234567890")
+	ctx := context.Background()
+
+	privKey := babyjub.NewRandPrivKey()
+	sender := privKey.Public().Compress().String()
+	_, _, err := generateIdentityProofs(ctx, testCallbacks, rootSchema, nodeSchema, constants.TOKEN_ANON_NULLIFIER_KYC, "queryContext", addr, sender, []string{sender, ""})
+	assert.EqualError(t, err, "PD210019: Failed to create Merkle tree for smtKyc_Zeto_AnonNullifierKyc_0x1234567890123456789012345678901234567890: PD210065: Failed to find available states for the merkle tree. test error")
+
+	// an empty registry
+	testCallbacks.MockFindAvailableStates = func() (*pb.FindAvailableStatesResponse, error) {
+		return &pb.FindAvailableStatesResponse{}, nil
+	}
+	_, _, err = generateIdentityProofs(ctx, testCallbacks, rootSchema, nodeSchema, constants.TOKEN_ANON_NULLIFIER_KYC, "queryContext", addr, "0x1234", nil)
+	assert.EqualError(t, err, "PD210037: Failed load owner public key. PD210072: Invalid compressed public key length: 2")
+
+	_, _, err = generateIdentityProofs(ctx, testCallbacks, rootSchema, nodeSchema, constants.TOKEN_ANON_NULLIFIER_KYC, "queryContext", addr, sender, nil)
+	assert.ErrorContains(t, err, "PD210135: Identity "+sender+" is not registered with the KYC token.")
+
+	// a registry with the sender as its only member
+	hash, err := common.IdentityHash(privKey.Public())
+	require.NoError(t, err)
+	idx, err := node.NewNodeIndexFromBigInt(hash)
+	require.NoError(t, err)
+	leaf, err := node.NewLeafNode(node.NewIndexOnly(idx))
+	require.NoError(t, err)
+	calls := 0
+	testCallbacks.MockFindAvailableStates = func() (*pb.FindAvailableStatesResponse, error) {
+		defer func() { calls++ }()
+		if calls == 0 {
+			return &pb.FindAvailableStatesResponse{
+				States: []*pb.StoredState{
+					{DataJson: "{\"rootIndex\":\"0x" + leaf.Ref().Hex() + "\"}"},
+				},
+			}, nil
+		}
+		return &pb.FindAvailableStatesResponse{
+			States: []*pb.StoredState{
+				{DataJson: "{\"index\":\"0x" + idx.Hex() + "\",\"leftChild\":\"0x0000000000000000000000000000000000000000000000000000000000000000\",\"refKey\":\"0x" + leaf.Ref().Hex() + "\",\"rightChild\":\"0x0000000000000000000000000000000000000000000000000000000000000000\",\"type\":\"0x02\"}"},
+			},
+		}, nil
+	}
+	root, proofs, err := generateIdentityProofs(ctx, testCallbacks, rootSchema, nodeSchema, constants.TOKEN_ANON_NULLIFIER_KYC, "queryContext", addr, sender, []string{sender, ""})
+	require.NoError(t, err)
+	assert.Equal(t, leaf.Ref().BigInt().Text(16), root)
+	require.Len(t, proofs, 3)
+	assert.Len(t, proofs[0].Nodes, smt.SMT_HEIGHT_IDENTITIES)
+	assert.Equal(t, proofs[0].Nodes, proofs[1].Nodes)
+	assert.Equal(t, &smt.Empty_Proof_Identities, proofs[2])
+}
+
 func TestFungibleTransferEndorse(t *testing.T) {
 	h := transferHandler{}
 	ctx := context.Background()
@@ -590,3 +652,7 @@ func (dc *testDomainCallbacks) LocalNodeName(context.Context, *pb.LocalNodeNameR
 func (dc *testDomainCallbacks) SendTransaction(ctx context.Context, tx *pb.SendTransactionRequest) (*pb.SendTransactionResponse, error) {
 	return nil, nil
 }
+
+func (dc *testDomainCallbacks) CheckCredential(ctx context.Context, req *pb.CheckCredentialRequest) (*pb.CheckCredentialResponse, error) {
+	return nil, nil
+}
@@ -187,6 +187,59 @@ func generateMerkleProofs(ctx context.Context, callbacks plugintk.DomainCallback
 	return proofs, &extrasObj, nil
 }
 
+// generateIdentityProofs generates the proofs of membership in the identities registry of
+// a KYC token, for the sender followed by each of the output owners. Filler outputs, which
+// have no owner, are given an empty proof
+func generateIdentityProofs(ctx context.Context, callbacks plugintk.DomainCallbacks, merkleTreeRootSchema *prototk.StateSchema, merkleTreeNodeSchema *prototk.StateSchema, tokenName string, stateQueryContext string, contractAddress *pldtypes.EthAddress, sender string, outputOwners []string) (string, []*corepb.MerkleProof, error) {
+	smtName := smt.MerkleTreeNameForIdentities(tokenName, contractAddress)
+	storage := smt.NewStatesStorage(callbacks, smtName, stateQueryContext, merkleTreeRootSchema.Id, merkleTreeNodeSchema.Id)
+	mt, err := smt.NewSmtForIdentities(storage)
+	if err != nil {
+		return "", nil, i18n.NewError(ctx, msgs.MsgErrorNewSmt, smtName, err)
+	}
+	mtRoot := mt.Root()
+	mps := make([]*corepb.MerkleProof, 0, len(outputOwners)+1)
+	for _, owner := range append([]string{sender}, outputOwners...) {
+		if owner == "" {
+			mps = append(mps, &smt.Empty_Proof_Identities)
+			continue
+		}
+		pubKey, err := zetosigner.DecodeBabyJubJubPublicKey(owner)
+		if err != nil {
+			return "", nil, i18n.NewError(ctx, msgs.MsgErrorLoadOwnerPubKey, err)
+		}
+		hash, err := common.IdentityHash(pubKey)
+		if err != nil {
+			return "", nil, i18n.NewError(ctx, msgs.MsgErrorHashIdentity, err)
+		}
+		idx, err := node.NewNodeIndexFromBigInt(hash)
+		if err != nil {
+			return "", nil, i18n.NewError(ctx, msgs.MsgErrorNewNodeIndex, err)
+		}
+		leaf, err := node.NewLeafNode(node.NewIndexOnly(idx))
+		if err != nil {
+			return "", nil, i18n.NewError(ctx, msgs.MsgErrorNewLeafNode, err)
+		}
+		if _, err := mt.GetNode(leaf.Ref()); err != nil {
+			return "", nil, i18n.NewError(ctx, msgs.MsgErrorIdentityNotRegistered, owner, err)
+		}
+		proofs, _, err := mt.GenerateProofs([]*big.Int{hash}, mtRoot)
+		if err != nil {
+			return "", nil, i18n.NewError(ctx, msgs.MsgErrorGenerateMTP, err)
+		}
+		cp, err := proofs[0].ToCircomVerifierProof(hash, hash, mtRoot, smt.SMT_HEIGHT_IDENTITIES)
+		if err != nil {
+			return "", nil, i18n.NewError(ctx, msgs.MsgErrorConvertToCircomProof, err)
+		}
+		proofSiblings := make([]string, len(cp.Siblings)-1)
+		for i, s := range cp.Siblings[0 : len(cp.Siblings)-1] {
+			proofSiblings[i] = s.BigInt().Text(16)
+		}
+		mps = append(mps, &corepb.MerkleProof{Nodes: proofSiblings})
+	}
+	return mtRoot.BigInt().Text(16), mps, nil
+}
+
 // formatTransferProvingRequest formats the proving request for a transfer transaction.
 // the same function is used for both the transfer and lock transactions because they
 // both require the same proof from the transfer circuit
@@ -240,6 +293,12 @@ func formatTransferProvingRequest(ctx context.Context, callbacks plugintk.Domain
 		if len(delegate) > 0 {
 			extrasObj.Delegate = delegate[0]
 		}
+		if common.IsKycToken(tokenName) {
+			extrasObj.IdentitiesRoot, extrasObj.IdentitiesMerkleProofs, err = generateIdentityProofs(ctx, callbacks, merkleTreeRootSchema, merkleTreeNodeSchema, tokenName, stateQueryContext, contractAddress, inputOwner, outputOwners)
+			if err != nil {
+				return nil, err
+			}
+		}
 		protoExtras, err := proto.Marshal(extrasObj)
 		if err != nil {
 			return nil, i18n.NewError(ctx, msgs.MsgErrorMarshalExtraObj, err)
@@ -7,6 +7,7 @@ import (
 
 	"github.com/hyperledger-labs/zeto/go-sdk/pkg/sparse-merkle-tree/core"
 	"github.com/hyperledger-labs/zeto/go-sdk/pkg/sparse-merkle-tree/node"
+	"github.com/iden3/go-iden3-crypto/babyjub"
 	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
 	"github.com/kaleido-io/paladin/common/go/pkg/log"
 	"github.com/kaleido-io/paladin/domains/zeto/internal/msgs"
@@ -149,6 +150,33 @@ func (z *Zeto) handleLockedEvent(ctx context.Context, smtTree *merkleTreeSpec, s
 	return nil
 }
 
+func (z *Zeto) handleIdentityRegisteredEvent(ctx context.Context, smtTree *merkleTreeSpec, ev *prototk.OnChainEvent, tokenName string, res *prototk.HandleEventBatchResponse) error {
+	var registered IdentityRegisteredEvent
+	if err := json.Unmarshal([]byte(ev.DataJson), &registered); err == nil {
+		txData, err := decodeTransactionData(ctx, registered.Data)
+		if err != nil || txData == nil {
+			log.L(ctx).Errorf("Failed to decode transaction data for identity registered event: %s. Skip to the next event", registered.Data)
+			return nil
+		}
+		z.recordTransactionInfo(ev, txData, res)
+		if common.IsKycToken(tokenName) && len(registered.PublicKey) == 2 {
+			// the registry indexes each identity by the hash of its public key
+			publicKey := &babyjub.PublicKey{X: registered.PublicKey[0].Int(), Y: registered.PublicKey[1].Int()}
+			hash, err := common.IdentityHash(publicKey)
+			if err != nil {
+				return i18n.NewError(ctx, msgs.MsgErrorHashIdentity, err)
+			}
+			err = z.updateMerkleTree(ctx, smtTree.tree, smtTree.storage, txData.TransactionID, []pldtypes.HexUint256{*(*pldtypes.HexUint256)(hash)})
+			if err != nil {
+				return i18n.NewError(ctx, msgs.MsgErrorUpdateSMT, "IdentityRegistered", err)
+			}
+		}
+	} else {
+		log.L(ctx).Errorf("Failed to unmarshal identity registered event: %s", err)
+	}
+	return nil
+}
+
 func (z *Zeto) updateMerkleTree(ctx context.Context, tree core.SparseMerkleTree, storage smt.StatesStorage, txID pldtypes.Bytes32, outputs []pldtypes.HexUint256) error {
 	storage.SetTransactionId(txID.HexString0xPrefix())
 	for _, out := range outputs {
@@ -18,10 +18,13 @@ package zeto
 import (
 	"context"
 	"encoding/json"
+	"math/big"
 	"testing"
 
+	"github.com/iden3/go-iden3-crypto/babyjub"
 	"github.com/kaleido-io/paladin/domains/zeto/internal/zeto/common"
 	"github.com/kaleido-io/paladin/domains/zeto/internal/zeto/smt"
+	"github.com/kaleido-io/paladin/domains/zeto/pkg/constants"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
 	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
 	"github.com/stretchr/testify/assert"
@@ -115,6 +118,55 @@ func TestHandleMintEvent(t *testing.T) {
 	assert.Equal(t, "merkle_tree_node", newStates[1].SchemaId)
 }
 
+func TestHandleIdentityRegisteredEvent(t *testing.T) {
+	z, testCallbacks := newTestZeto()
+	storage := smt.NewStatesStorage(testCallbacks, "testToken1", "context1", "merkle_tree_root", "merkle_tree_node")
+	merkleTree, err := smt.NewSmtForIdentities(storage)
+	require.NoError(t, err)
+	smtSpec := &merkleTreeSpec{tree: merkleTree, storage: storage}
+	ctx := context.Background()
+
+	ev := &prototk.OnChainEvent{
+		DataJson:          "bad json",
+		SoliditySignature: "event IdentityRegistered(uint256[2] publicKey, bytes data)",
+	}
+	res := &prototk.HandleEventBatchResponse{}
+	err = z.handleIdentityRegisteredEvent(ctx, smtSpec, ev, constants.TOKEN_ANON_NULLIFIER_KYC, res)
+	assert.NoError(t, err)
+
+	privKey := babyjub.NewRandPrivKey()
+	publicKey := privKey.Public()
+	ev.DataJson = pldtypes.JSONString(map[string]any{
+		"publicKey": []string{publicKey.X.Text(10), publicKey.Y.Text(10)},
+		"data":      "0x0001",
+	}).String()
+	err = z.handleIdentityRegisteredEvent(ctx, smtSpec, ev, constants.TOKEN_ANON_NULLIFIER_KYC, res)
+	assert.NoError(t, err)
+	assert.Empty(t, res.TransactionsComplete)
+
+	encodedData, err := common.EncodeTransactionData(ctx, &prototk.TransactionSpecification{
+		TransactionId: "0x30e43028afbb41d6887444f4c2b4ed6d00000000000000000000000000000000",
+	}, nil)
+	require.NoError(t, err)
+	ev.DataJson = pldtypes.JSONString(map[string]any{
+		"publicKey": []string{publicKey.X.Text(10), publicKey.Y.Text(10)},
+		"data":      encodedData,
+	}).String()
+	err = z.handleIdentityRegisteredEvent(ctx, smtSpec, ev, constants.TOKEN_ANON_NULLIFIER_KYC, res)
+	assert.NoError(t, err)
+	assert.Len(t, res.TransactionsComplete, 1)
+	assert.Equal(t, "0x30e43028afbb41d6887444f4c2b4ed6d00000000000000000000000000000000", res.TransactionsComplete[0].TransactionId)
+	newStates, err := storage.GetNewStates()
+	require.NoError(t, err)
+	assert.Len(t, newStates, 2)
+
+	hash, err := common.IdentityHash(publicKey)
+	require.NoError(t, err)
+	proofs, _, err := merkleTree.GenerateProofs([]*big.Int{hash}, merkleTree.Root())
+	require.NoError(t, err)
+	assert.True(t, proofs[0].IsExistenceProof())
+}
+
 func TestHandleTransferEvent(t *testing.T) {
 	z, testCallbacks := newTestZeto()
 	storage := smt.NewStatesStorage(testCallbacks, "testToken1", "context1", "merkle_tree_root", "merkle_tree_node")
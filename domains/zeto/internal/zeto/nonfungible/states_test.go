@@ -59,6 +59,10 @@ func (dc *testDomainCallbacks) SendTransaction(ctx context.Context, tx *pb.SendT
 	return nil, nil
 }
 
+func (dc *testDomainCallbacks) CheckCredential(ctx context.Context, req *pb.CheckCredentialRequest) (*pb.CheckCredentialResponse, error) {
+	return nil, nil
+}
+
 func TestProcessTokens(t *testing.T) {
 	ctx := context.Background()
 
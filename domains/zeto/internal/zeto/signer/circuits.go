@@ -33,7 +33,9 @@ func loadCircuit(ctx context.Context, circuitName string, config *zetosignerapi.
 	if config.CircuitsDir == "" {
 		return nil, []byte{}, i18n.NewError(ctx, msgs.MsgInvalidConfigCircuitRoot)
 	}
-	if config.ProvingKeysDir == "" {
+	// proving keys are held by the remote prover when proof generation is delegated
+	remoteProver := config.RemoteProver != nil
+	if config.ProvingKeysDir == "" && !remoteProver {
 		return nil, []byte{}, i18n.NewError(ctx, msgs.MsgInvalidConfigProvingKeysRoot)
 	}
 
@@ -44,9 +46,12 @@ func loadCircuit(ctx context.Context, circuitName string, config *zetosignerapi.
 	}
 
 	// create the prover
-	zkeyBytes, err := os.ReadFile(path.Join(config.ProvingKeysDir, fmt.Sprintf("%s.zkey", circuitName)))
-	if err != nil {
-		return nil, []byte{}, err
+	zkeyBytes := []byte{}
+	if !remoteProver {
+		zkeyBytes, err = os.ReadFile(path.Join(config.ProvingKeysDir, fmt.Sprintf("%s.zkey", circuitName)))
+		if err != nil {
+			return nil, []byte{}, err
+		}
 	}
 
 	// create the calculator
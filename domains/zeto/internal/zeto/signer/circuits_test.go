@@ -125,3 +125,21 @@ func TestLoadCircuitFailReadZKey(t *testing.T) {
 	_, _, err = loadCircuit(ctx, "test", config)
 	assert.ErrorContains(t, err, "test.zkey: no such file or directory")
 }
+
+func TestLoadCircuitRemoteProver(t *testing.T) {
+	tmpDir := t.TempDir()
+	err := os.Mkdir(path.Join(tmpDir, "test_js"), 0755)
+	require.NoError(t, err)
+	err = os.WriteFile(path.Join(tmpDir, "test_js", "test.wasm"), testWasm, 0644)
+	require.NoError(t, err)
+
+	// no proving keys are needed locally when proofs are generated remotely
+	config := &zetosignerapi.SnarkProverConfig{
+		CircuitsDir:  tmpDir,
+		RemoteProver: &zetosignerapi.RemoteProverConfig{},
+	}
+	circuit, provingKey, err := loadCircuit(context.Background(), "test", config)
+	require.NoError(t, err)
+	assert.NotNil(t, circuit)
+	assert.Empty(t, provingKey)
+}
@@ -0,0 +1,199 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package signer
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path"
+	"regexp"
+	"time"
+
+	"github.com/iden3/go-rapidsnark/types"
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/domains/zeto/internal/msgs"
+	pb "github.com/kaleido-io/paladin/domains/zeto/pkg/proto"
+	"github.com/kaleido-io/paladin/domains/zeto/pkg/zetosigner/zetosignerapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/tlsconf"
+	"github.com/kaleido-io/paladin/toolkit/pkg/cache"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+var defaultRemoteProverConfig = zetosignerapi.RemoteProverConfig{
+	MaxConcurrency: confutil.P(10),
+	RequestTimeout: confutil.P("5m"),
+}
+
+// circuit IDs are used to locate proving keys on the remote prover, so are restricted to simple names
+var circuitIDRegexp = regexp.MustCompile(`^[a-zA-Z0-9_]+$`)
+
+// remoteProver sends witnesses calculated locally to a remote prover service,
+// which holds the proving keys and runs the (expensive) Groth16 proof generation
+type remoteProver struct {
+	endpoint       string
+	conn           *grpc.ClientConn
+	client         pb.ZetoProverClient
+	slots          chan struct{}
+	requestTimeout time.Duration
+}
+
+func newRemoteProver(ctx context.Context, conf *zetosignerapi.RemoteProverConfig) (*remoteProver, error) {
+	if conf.Endpoint == "" {
+		return nil, i18n.NewError(ctx, msgs.MsgInvalidConfigRemoteProverEndpoint)
+	}
+	// The witness includes the private inputs to the circuit (including the private key of the
+	// owner of the input states), so we never send it over an unencrypted connection
+	if !conf.TLS.Enabled {
+		return nil, i18n.NewError(ctx, msgs.MsgRemoteProverTLSRequired)
+	}
+	tlsConfig, err := tlsconf.BuildTLSConfig(ctx, &conf.TLS, tlsconf.ClientType)
+	if err != nil {
+		return nil, err
+	}
+
+	// The connection is not established until the first request
+	conn, err := grpc.NewClient(conf.Endpoint, grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)))
+	if err != nil {
+		return nil, i18n.NewError(ctx, msgs.MsgErrorRemoteProverConnect, conf.Endpoint, err)
+	}
+
+	return &remoteProver{
+		endpoint:       conf.Endpoint,
+		conn:           conn,
+		client:         pb.NewZetoProverClient(conn),
+		slots:          make(chan struct{}, confutil.IntMin(conf.MaxConcurrency, 1, *defaultRemoteProverConfig.MaxConcurrency)),
+		requestTimeout: confutil.DurationMin(conf.RequestTimeout, 0, *defaultRemoteProverConfig.RequestTimeout),
+	}, nil
+}
+
+func (rp *remoteProver) generateProof(ctx context.Context, circuitID string, wtns, _ []byte) (*types.ZKProof, error) {
+	// limit the number of requests in flight to the prover service
+	select {
+	case rp.slots <- struct{}{}:
+		defer func() { <-rp.slots }()
+	case <-ctx.Done():
+		return nil, errors.New("context cancelled")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, rp.requestTimeout)
+	defer cancel()
+
+	log.L(ctx).Debugf("Requesting proof for circuit %s from remote prover %s", circuitID, rp.endpoint)
+	res, err := rp.client.GenerateProof(ctx, &pb.RemoteProofRequest{
+		CircuitId: circuitID,
+		Witness:   wtns,
+	})
+	if err != nil {
+		return nil, i18n.NewError(ctx, msgs.MsgErrorGenerateProof, err)
+	}
+	if res.Proof == nil {
+		return nil, i18n.NewError(ctx, msgs.MsgErrorRemoteProverNoProof, circuitID)
+	}
+	return &types.ZKProof{
+		Proof:      fromSnarkProof(res.Proof),
+		PubSignals: res.PublicSignals,
+	}, nil
+}
+
+func (rp *remoteProver) close() {
+	_ = rp.conn.Close()
+}
+
+// proverService is the server side of the remote prover, which can be hosted in a
+// standalone process alongside the proving keys (and any hardware acceleration)
+type proverService struct {
+	pb.UnimplementedZetoProverServer
+	provingKeysDir   string
+	provingKeysCache cache.Cache[string, []byte]
+	proofGenerator   func(ctx context.Context, circuitID string, witness []byte, provingKey []byte) (*types.ZKProof, error)
+}
+
+func NewProverService(provingKeysDir string) pb.ZetoProverServer {
+	return newProverService(provingKeysDir)
+}
+
+func newProverService(provingKeysDir string) *proverService {
+	cacheConfig := pldconf.CacheConfig{
+		Capacity: confutil.P(50),
+	}
+	return &proverService{
+		provingKeysDir:   provingKeysDir,
+		provingKeysCache: cache.NewCache[string, []byte](&cacheConfig, &cacheConfig),
+		proofGenerator:   generateProof,
+	}
+}
+
+func (ps *proverService) GenerateProof(ctx context.Context, req *pb.RemoteProofRequest) (*pb.RemoteProofResponse, error) {
+	if !circuitIDRegexp.MatchString(req.CircuitId) {
+		return nil, i18n.NewError(ctx, msgs.MsgErrorMissingCircuitID)
+	}
+
+	provingKey, ok := ps.provingKeysCache.Get(req.CircuitId)
+	if !ok {
+		if ps.provingKeysDir == "" {
+			return nil, i18n.NewError(ctx, msgs.MsgInvalidConfigProvingKeysRoot)
+		}
+		zkeyBytes, err := os.ReadFile(path.Join(ps.provingKeysDir, req.CircuitId+".zkey"))
+		if err != nil {
+			return nil, i18n.NewError(ctx, msgs.MsgErrorLoadProvingKey, req.CircuitId, err)
+		}
+		ps.provingKeysCache.Set(req.CircuitId, zkeyBytes)
+		provingKey = zkeyBytes
+	}
+
+	proof, err := ps.proofGenerator(ctx, req.CircuitId, req.Witness, provingKey)
+	if err != nil {
+		return nil, err
+	}
+	snark := toSnarkProof(proof.Proof)
+	snark.Protocol = proof.Proof.Protocol
+	return &pb.RemoteProofResponse{
+		Proof:         snark,
+		PublicSignals: proof.PubSignals,
+	}, nil
+}
+
+func toSnarkProof(proof *types.ProofData) *pb.SnarkProof {
+	snark := &pb.SnarkProof{
+		A: proof.A,
+		B: make([]*pb.B_Item, 0, len(proof.B)),
+		C: proof.C,
+	}
+	for _, p := range proof.B {
+		bItems := pb.B_Item{}
+		bItems.Items = append(bItems.Items, p...)
+		snark.B = append(snark.B, &bItems)
+	}
+	return snark
+}
+
+func fromSnarkProof(snark *pb.SnarkProof) *types.ProofData {
+	proof := &types.ProofData{
+		A:        snark.A,
+		B:        make([][]string, 0, len(snark.B)),
+		C:        snark.C,
+		Protocol: snark.Protocol,
+	}
+	for _, b := range snark.B {
+		proof.B = append(proof.B, b.Items)
+	}
+	return proof
+}
@@ -0,0 +1,226 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package signer
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net"
+	"os"
+	"path"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/iden3/go-rapidsnark/types"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	pb "github.com/kaleido-io/paladin/domains/zeto/pkg/proto"
+	"github.com/kaleido-io/paladin/domains/zeto/pkg/zetosigner/zetosignerapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+func buildTestTLSKeyPair(t *testing.T) (string, string) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	privateKeyPEM := &strings.Builder{}
+	err = pem.Encode(privateKeyPEM, &pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(privateKey)})
+	require.NoError(t, err)
+	x509Template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "prover"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(100 * time.Second),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		IPAddresses:           []net.IP{net.IPv4(127, 0, 0, 1)},
+	}
+	derBytes, err := x509.CreateCertificate(rand.Reader, x509Template, x509Template, &privateKey.PublicKey, privateKey)
+	require.NoError(t, err)
+	certPEM := &strings.Builder{}
+	err = pem.Encode(certPEM, &pem.Block{Type: "CERTIFICATE", Bytes: derBytes})
+	require.NoError(t, err)
+	return certPEM.String(), privateKeyPEM.String()
+}
+
+func newTestRemoteProver(t *testing.T, conf *zetosignerapi.RemoteProverConfig) (*remoteProver, *proverService) {
+	certPEM, keyPEM := buildTestTLSKeyPair(t)
+	cert, err := tls.X509KeyPair([]byte(certPEM), []byte(keyPEM))
+	require.NoError(t, err)
+
+	provingKeysDir := t.TempDir()
+	err = os.WriteFile(path.Join(provingKeysDir, "anon.zkey"), []byte("proving key"), 0644)
+	require.NoError(t, err)
+	ps := newProverService(provingKeysDir)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := grpc.NewServer(grpc.Creds(credentials.NewTLS(&tls.Config{Certificates: []tls.Certificate{cert}})))
+	pb.RegisterZetoProverServer(server, ps)
+	go func() { _ = server.Serve(listener) }()
+
+	conf.Endpoint = listener.Addr().String()
+	conf.TLS = pldconf.TLSConfig{Enabled: true, CA: certPEM}
+	rp, err := newRemoteProver(context.Background(), conf)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		rp.close()
+		server.Stop()
+	})
+	return rp, ps
+}
+
+func TestRemoteProverGenerateProof(t *testing.T) {
+	rp, ps := newTestRemoteProver(t, &zetosignerapi.RemoteProverConfig{})
+
+	ps.proofGenerator = func(ctx context.Context, circuitID string, witness []byte, provingKey []byte) (*types.ZKProof, error) {
+		assert.Equal(t, "anon", circuitID)
+		assert.Equal(t, []byte("witness"), witness)
+		assert.Equal(t, []byte("proving key"), provingKey)
+		return &types.ZKProof{
+			Proof: &types.ProofData{
+				A:        []string{"a"},
+				B:        [][]string{{"b1.1", "b1.2"}, {"b2.1", "b2.2"}},
+				C:        []string{"c"},
+				Protocol: "groth16",
+			},
+			PubSignals: []string{"1", "2"},
+		}, nil
+	}
+
+	proof, err := rp.generateProof(context.Background(), "anon", []byte("witness"), nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a"}, proof.Proof.A)
+	assert.Equal(t, [][]string{{"b1.1", "b1.2"}, {"b2.1", "b2.2"}}, proof.Proof.B)
+	assert.Equal(t, []string{"c"}, proof.Proof.C)
+	assert.Equal(t, "groth16", proof.Proof.Protocol)
+	assert.Equal(t, []string{"1", "2"}, proof.PubSignals)
+
+	// second request is served from the proving keys cache
+	_, err = rp.generateProof(context.Background(), "anon", []byte("witness"), nil)
+	require.NoError(t, err)
+}
+
+func TestRemoteProverGenerateProofErrors(t *testing.T) {
+	rp, ps := newTestRemoteProver(t, &zetosignerapi.RemoteProverConfig{})
+	ctx := context.Background()
+
+	_, err := rp.generateProof(ctx, "../anon", []byte("witness"), nil)
+	assert.ErrorContains(t, err, "PD210091")
+
+	_, err = rp.generateProof(ctx, "unknown", []byte("witness"), nil)
+	assert.ErrorContains(t, err, "PD210144")
+
+	ps.proofGenerator = func(ctx context.Context, circuitID string, witness []byte, provingKey []byte) (*types.ZKProof, error) {
+		return nil, errors.New("pop")
+	}
+	_, err = rp.generateProof(ctx, "anon", []byte("witness"), nil)
+	assert.Regexp(t, "PD210101.*pop", err)
+
+	ps.provingKeysDir = ""
+	_, err = rp.generateProof(ctx, "anon_nullifier", []byte("witness"), nil)
+	assert.ErrorContains(t, err, "PD210075")
+}
+
+func TestRemoteProverNoProof(t *testing.T) {
+	rp := &remoteProver{
+		slots:          make(chan struct{}, 1),
+		requestTimeout: time.Second,
+	}
+	rp.client = &testProverClient{res: &pb.RemoteProofResponse{}}
+	_, err := rp.generateProof(context.Background(), "anon", []byte("witness"), nil)
+	assert.ErrorContains(t, err, "PD210143")
+}
+
+func TestRemoteProverConcurrencyLimit(t *testing.T) {
+	rp, _ := newTestRemoteProver(t, &zetosignerapi.RemoteProverConfig{
+		MaxConcurrency: confutil.P(1),
+	})
+	assert.Equal(t, 1, cap(rp.slots))
+
+	// occupy the only slot, so the request waits until the context is cancelled
+	rp.slots <- struct{}{}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := rp.generateProof(ctx, "anon", []byte("witness"), nil)
+	assert.EqualError(t, err, "context cancelled")
+}
+
+func TestNewRemoteProverBadConfig(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := newRemoteProver(ctx, &zetosignerapi.RemoteProverConfig{})
+	assert.ErrorContains(t, err, "PD210140")
+
+	_, err = newRemoteProver(ctx, &zetosignerapi.RemoteProverConfig{
+		Endpoint: "localhost:8443",
+	})
+	assert.ErrorContains(t, err, "PD210141")
+
+	_, err = newRemoteProver(ctx, &zetosignerapi.RemoteProverConfig{
+		Endpoint: "localhost:8443",
+		TLS: pldconf.TLSConfig{
+			Enabled: true,
+			CAFile:  "!!!not a file",
+		},
+	})
+	assert.Error(t, err)
+
+	_, err = newSnarkProver(&zetosignerapi.SnarkProverConfig{
+		RemoteProver: &zetosignerapi.RemoteProverConfig{},
+	})
+	assert.ErrorContains(t, err, "PD210140")
+}
+
+func TestSnarkProverUsesRemoteProver(t *testing.T) {
+	remoteConf := &zetosignerapi.RemoteProverConfig{}
+	_, ps := newTestRemoteProver(t, remoteConf)
+	prover, err := newSnarkProver(&zetosignerapi.SnarkProverConfig{
+		CircuitsDir:  "test",
+		RemoteProver: remoteConf,
+	})
+	require.NoError(t, err)
+
+	called := false
+	ps.proofGenerator = func(ctx context.Context, circuitID string, witness []byte, provingKey []byte) (*types.ZKProof, error) {
+		called = true
+		return &types.ZKProof{Proof: &types.ProofData{}}, nil
+	}
+	_, err = prover.proofGenerator(context.Background(), "anon", []byte("witness"), nil)
+	require.NoError(t, err)
+	assert.True(t, called)
+}
+
+type testProverClient struct {
+	res *pb.RemoteProofResponse
+}
+
+func (c *testProverClient) GenerateProof(ctx context.Context, in *pb.RemoteProofRequest, opts ...grpc.CallOption) (*pb.RemoteProofResponse, error) {
+	return c.res, nil
+}
@@ -55,7 +55,7 @@ type snarkProver struct {
 	circuitsWorkerIndexChanRWLock sync.RWMutex
 	circuitsWorkerIndexChan       map[string]chan *int
 	circuitLoader                 func(ctx context.Context, circuitID string, config *zetosignerapi.SnarkProverConfig) (witness.Calculator, []byte, error)
-	proofGenerator                func(ctx context.Context, witness []byte, provingKey []byte) (*types.ZKProof, error)
+	proofGenerator                func(ctx context.Context, circuitID string, witness []byte, provingKey []byte) (*types.ZKProof, error)
 }
 
 func NewSnarkProver(conf *zetosignerapi.SnarkProverConfig) (signerapi.InMemorySigner, error) {
@@ -66,7 +66,7 @@ func newSnarkProver(conf *zetosignerapi.SnarkProverConfig) (*snarkProver, error)
 	cacheConfig := pldconf.CacheConfig{
 		Capacity: confutil.P(50),
 	}
-	return &snarkProver{
+	sp := &snarkProver{
 		zkpProverConfig:         conf,
 		circuitsCache:           cache.NewCache[string, witness.Calculator](&cacheConfig, &cacheConfig),
 		provingKeysCache:        cache.NewCache[string, []byte](&cacheConfig, &cacheConfig),
@@ -74,7 +74,16 @@ func newSnarkProver(conf *zetosignerapi.SnarkProverConfig) (*snarkProver, error)
 		proofGenerator:          generateProof,
 		workerPerCircuit:        confutil.Int(conf.MaxProverPerCircuit, *defaultSnarkProverConfig.MaxProverPerCircuit),
 		circuitsWorkerIndexChan: make(map[string]chan *int),
-	}, nil
+	}
+	if conf.RemoteProver != nil {
+		// witness calculation stays local, but proof generation is delegated to the remote prover
+		rp, err := newRemoteProver(context.Background(), conf.RemoteProver)
+		if err != nil {
+			return nil, err
+		}
+		sp.proofGenerator = rp.generateProof
+	}
+	return sp, nil
 }
 
 func (sp *snarkProver) GetVerifier(ctx context.Context, algorithm, verifierType string, privateKey []byte) (string, error) {
@@ -184,7 +193,7 @@ func (sp *snarkProver) Sign(ctx context.Context, algorithm, payloadType string,
 		return nil, err
 	}
 
-	proof, err := sp.proofGenerator(ctx, wtns, provingKey)
+	proof, err := sp.proofGenerator(ctx, circuitId, wtns, provingKey)
 	if err != nil {
 		return nil, err
 	}
@@ -208,15 +217,6 @@ func getCircuit(inputs *pb.ProvingRequest) *zetosignerapi.Circuit {
 }
 
 func serializeProofResponse(circuit *zetosignerapi.Circuit, proof *types.ZKProof) ([]byte, error) {
-	snark := pb.SnarkProof{}
-	snark.A = proof.Proof.A
-	snark.B = make([]*pb.B_Item, 0, len(proof.Proof.B))
-	for _, p := range proof.Proof.B {
-		bItems := pb.B_Item{}
-		bItems.Items = append(bItems.Items, p...)
-		snark.B = append(snark.B, &bItems)
-	}
-	snark.C = proof.Proof.C
 
 	publicInputs := make(map[string]string)
 	if circuit.Type == zetosignerapi.Transfer {
@@ -252,7 +252,7 @@ func serializeProofResponse(circuit *zetosignerapi.Circuit, proof *types.ZKProof
 	}
 
 	res := pb.ProvingResponse{
-		Proof:        &snark,
+		Proof:        toSnarkProof(proof.Proof),
 		PublicInputs: publicInputs,
 	}
 
@@ -336,7 +336,7 @@ func newWitnessInputs(tokenType pb.TokenType, circuit *zetosignerapi.Circuit, ex
 	return nil, fmt.Errorf("unsupported circuit type %s", circuit.Type)
 }
 
-func generateProof(ctx context.Context, wtns, provingKey []byte) (*types.ZKProof, error) {
+func generateProof(ctx context.Context, _ string, wtns, provingKey []byte) (*types.ZKProof, error) {
 	proof, err := prover.Groth16Prover(provingKey, wtns)
 	if err != nil {
 		return nil, i18n.NewError(ctx, msgs.MsgErrorGenerateProof, err)
@@ -127,7 +127,7 @@ func TestConcurrentSnarkProofGeneration(t *testing.T) {
 	}
 	prover.circuitLoader = testCircuitLoader
 
-	testProofGenerator := func(ctx context.Context, witness []byte, provingKey []byte) (*types.ZKProof, error) {
+	testProofGenerator := func(ctx context.Context, circuitID string, witness []byte, provingKey []byte) (*types.ZKProof, error) {
 		peakProverCountMutex.Lock()
 		peakProverCount++
 		assert.LessOrEqual(t, peakProverCount, 50) // ensure the peak prover count is smaller than the default max
@@ -639,7 +639,7 @@ func TestGenerateProof(t *testing.T) {
 		wtns := []byte("invalid witness")
 		provingKey := []byte("invalid proving key")
 
-		_, err := generateProof(ctx, wtns, provingKey)
+		_, err := generateProof(ctx, "anon", wtns, provingKey)
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "PD210101")
 	})
@@ -53,7 +53,7 @@ func NewTestProver(t *testing.T) signerapi.InMemorySigner {
 	}
 	prover.circuitLoader = testCircuitLoader
 
-	testProofGenerator := func(ctx context.Context, witness []byte, provingKey []byte) (*types.ZKProof, error) {
+	testProofGenerator := func(ctx context.Context, circuitID string, witness []byte, provingKey []byte) (*types.ZKProof, error) {
 		return &types.ZKProof{
 			Proof: &types.ProofData{
 				A:        []string{"a"},
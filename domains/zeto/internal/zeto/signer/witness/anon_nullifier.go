@@ -34,9 +34,47 @@ func (inputs *FungibleNullifierWitnessInputs) Assemble(ctx context.Context, keyE
 	if delegate != nil {
 		m["lockDelegate"] = delegate
 	}
+	if inputs.Extras.IdentitiesRoot != "" {
+		identitiesRoot, identitiesProofs, err := prepareInputsForIdentities(ctx, inputs.Extras)
+		if err != nil {
+			return nil, err
+		}
+		m["identitiesRoot"] = identitiesRoot
+		m["identitiesMerkleProof"] = identitiesProofs
+	}
 	return m, nil
 }
 
+// the KYC circuits take a proof of membership in the identities tree
+// for the sender, followed by one for each of the output owners
+func prepareInputsForIdentities(ctx context.Context, extras *pb.ProvingRequestExtras_Nullifiers) (*big.Int, [][]*big.Int, error) {
+	root, ok := new(big.Int).SetString(extras.IdentitiesRoot, 16)
+	if !ok {
+		return nil, nil, i18n.NewError(ctx, msgs.MsgErrorDecodeRootExtras)
+	}
+	proofs, err := decodeMerkleProofs(ctx, extras.IdentitiesMerkleProofs)
+	if err != nil {
+		return nil, nil, err
+	}
+	return root, proofs, nil
+}
+
+func decodeMerkleProofs(ctx context.Context, merkleProofs []*pb.MerkleProof) ([][]*big.Int, error) {
+	var proofs [][]*big.Int
+	for _, proof := range merkleProofs {
+		var mp []*big.Int
+		for _, node := range proof.Nodes {
+			n, ok := new(big.Int).SetString(node, 16)
+			if !ok {
+				return nil, i18n.NewError(ctx, msgs.MsgErrorDecodeMTPNodeExtras)
+			}
+			mp = append(mp, n)
+		}
+		proofs = append(proofs, mp)
+	}
+	return proofs, nil
+}
+
 func (inputs *FungibleNullifierWitnessInputs) prepareInputsForNullifiers(ctx context.Context, extras *pb.ProvingRequestExtras_Nullifiers, keyEntry *core.KeyEntry) ([]*big.Int, *big.Int, [][]*big.Int, []*big.Int, *big.Int, error) {
 	// calculate the nullifiers for the input UTXOs
 	nullifiers := make([]*big.Int, len(inputs.inputCommitments))
@@ -56,17 +94,9 @@ func (inputs *FungibleNullifierWitnessInputs) prepareInputsForNullifiers(ctx con
 	if !ok {
 		return nil, nil, nil, nil, nil, i18n.NewError(ctx, msgs.MsgErrorDecodeRootExtras)
 	}
-	var proofs [][]*big.Int
-	for _, proof := range extras.MerkleProofs {
-		var mp []*big.Int
-		for _, node := range proof.Nodes {
-			n, ok := new(big.Int).SetString(node, 16)
-			if !ok {
-				return nil, nil, nil, nil, nil, i18n.NewError(ctx, msgs.MsgErrorDecodeMTPNodeExtras)
-			}
-			mp = append(mp, n)
-		}
-		proofs = append(proofs, mp)
+	proofs, err := decodeMerkleProofs(ctx, extras.MerkleProofs)
+	if err != nil {
+		return nil, nil, nil, nil, nil, err
 	}
 	enabled := make([]*big.Int, len(extras.Enabled))
 	for i, e := range extras.Enabled {
@@ -552,7 +552,55 @@ func TestAssembleFungibleNullifierWitnessInputs(t *testing.T) {
 	assert.Contains(t, result, "root")
 	assert.Contains(t, result, "merkleProof")
 	assert.Contains(t, result, "enabled")
+	assert.NotContains(t, result, "identitiesRoot")
 }
+
+func TestAssembleFungibleNullifierKycWitnessInputs(t *testing.T) {
+	ras := &pb.ProvingRequestExtras_Nullifiers{
+		Root: "123456",
+		MerkleProofs: []*pb.MerkleProof{
+			{Nodes: []string{"1", "2", "3"}},
+			{Nodes: []string{"0", "0", "0"}},
+		},
+		Enabled:        []bool{true, false},
+		IdentitiesRoot: "abcdef",
+		IdentitiesMerkleProofs: []*pb.MerkleProof{
+			{Nodes: []string{"4", "5"}},
+			{Nodes: []string{"6", "7"}},
+		},
+	}
+	inputs := FungibleNullifierWitnessInputs{
+		Extras: ras,
+		FungibleWitnessInputs: FungibleWitnessInputs{
+			CommonWitnessInputs: CommonWitnessInputs{
+				inputCommitments: []*big.Int{big.NewInt(1), big.NewInt(2)},
+				inputSalts:       []*big.Int{big.NewInt(3), big.NewInt(4)},
+			},
+			inputValues:  []*big.Int{big.NewInt(5), big.NewInt(6)},
+			outputValues: []*big.Int{big.NewInt(7), big.NewInt(8)},
+		},
+	}
+	key := core.KeyEntry{
+		PrivateKeyForZkp: big.NewInt(123456789),
+	}
+	ctx := context.Background()
+	result, err := inputs.Assemble(ctx, &key)
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(0xabcdef), result["identitiesRoot"])
+	assert.Equal(t, [][]*big.Int{
+		{big.NewInt(4), big.NewInt(5)},
+		{big.NewInt(6), big.NewInt(7)},
+	}, result["identitiesMerkleProof"])
+
+	ras.IdentitiesMerkleProofs[0].Nodes[0] = "xyz"
+	_, err = inputs.Assemble(ctx, &key)
+	assert.ErrorContains(t, err, "PD210081")
+
+	ras.IdentitiesRoot = "xyz"
+	_, err = inputs.Assemble(ctx, &key)
+	assert.ErrorContains(t, err, "PD210080")
+}
+
 func TestPrepareInputsForNullifiers(t *testing.T) {
 	ras := &pb.ProvingRequestExtras_Nullifiers{
 		Root: "123456",
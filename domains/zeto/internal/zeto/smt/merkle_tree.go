@@ -24,14 +24,23 @@ import (
 
 const SMT_HEIGHT_UTXO = 64
 
+// the identities registry of KYC tokens uses a smaller tree than the UTXOs
+const SMT_HEIGHT_IDENTITIES = 10
+
 var Empty_Proof proto.MerkleProof
+var Empty_Proof_Identities proto.MerkleProof
 
 func init() {
+	Empty_Proof = emptyProof(SMT_HEIGHT_UTXO)
+	Empty_Proof_Identities = emptyProof(SMT_HEIGHT_IDENTITIES)
+}
+
+func emptyProof(height int) proto.MerkleProof {
 	var nodes []string
-	for i := 0; i < SMT_HEIGHT_UTXO; i++ {
+	for i := 0; i < height; i++ {
 		nodes = append(nodes, "0")
 	}
-	Empty_Proof = proto.MerkleProof{
+	return proto.MerkleProof{
 		Nodes: nodes,
 	}
 }
@@ -41,6 +50,11 @@ func NewSmt(storage StatesStorage) (core.SparseMerkleTree, error) {
 	return mt, err
 }
 
+func NewSmtForIdentities(storage StatesStorage) (core.SparseMerkleTree, error) {
+	mt, err := smt.NewMerkleTree(storage, SMT_HEIGHT_IDENTITIES)
+	return mt, err
+}
+
 func MerkleTreeName(tokenName string, domainInstanceContract *pldtypes.EthAddress) string {
 	return "smt_" + tokenName + "_" + domainInstanceContract.String()
 }
@@ -48,3 +62,7 @@ func MerkleTreeName(tokenName string, domainInstanceContract *pldtypes.EthAddres
 func MerkleTreeNameForLockedStates(tokenName string, domainInstanceContract *pldtypes.EthAddress) string {
 	return "smtLocked_" + tokenName + "_" + domainInstanceContract.String()
 }
+
+func MerkleTreeNameForIdentities(tokenName string, domainInstanceContract *pldtypes.EthAddress) string {
+	return "smtKyc_" + tokenName + "_" + domainInstanceContract.String()
+}
@@ -26,9 +26,12 @@ func TestPackageInit(t *testing.T) {
 	assert.Equal(t, SMT_HEIGHT_UTXO, len(Empty_Proof.Nodes))
 	assert.Equal(t, "0", Empty_Proof.Nodes[0])
 	assert.Equal(t, "0", Empty_Proof.Nodes[SMT_HEIGHT_UTXO-1])
+	assert.Equal(t, SMT_HEIGHT_IDENTITIES, len(Empty_Proof_Identities.Nodes))
 }
 
 func TestMerkleTreeName(t *testing.T) {
 	address, _ := pldtypes.ParseEthAddress("0xe12c416382988005ace9b2e2f9a8a904d8be961c")
 	assert.Equal(t, "smt_test1_0xe12c416382988005ace9b2e2f9a8a904d8be961c", MerkleTreeName("test1", address))
+	assert.Equal(t, "smtLocked_test1_0xe12c416382988005ace9b2e2f9a8a904d8be961c", MerkleTreeNameForLockedStates("test1", address))
+	assert.Equal(t, "smtKyc_test1_0xe12c416382988005ace9b2e2f9a8a904d8be961c", MerkleTreeNameForIdentities("test1", address))
 }
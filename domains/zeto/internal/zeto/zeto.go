@@ -49,20 +49,21 @@ var _ plugintk.DomainAPI = &Zeto{}
 type Zeto struct {
 	Callbacks plugintk.DomainCallbacks
 
-	name                     string
-	config                   *types.DomainFactoryConfig
-	chainID                  int64
-	coinSchema               *prototk.StateSchema
-	nftSchema                *prototk.StateSchema
-	merkleTreeRootSchema     *prototk.StateSchema
-	merkleTreeNodeSchema     *prototk.StateSchema
-	dataSchema               *prototk.StateSchema
-	mintSignature            string
-	transferSignature        string
-	transferWithEncSignature string
-	withdrawSignature        string
-	lockSignature            string
-	snarkProver              signerapi.InMemorySigner
+	name                        string
+	config                      *types.DomainFactoryConfig
+	chainID                     int64
+	coinSchema                  *prototk.StateSchema
+	nftSchema                   *prototk.StateSchema
+	merkleTreeRootSchema        *prototk.StateSchema
+	merkleTreeNodeSchema        *prototk.StateSchema
+	dataSchema                  *prototk.StateSchema
+	mintSignature               string
+	transferSignature           string
+	transferWithEncSignature    string
+	withdrawSignature           string
+	lockSignature               string
+	identityRegisteredSignature string
+	snarkProver                 signerapi.InMemorySigner
 }
 
 type MintEvent struct {
@@ -100,6 +101,11 @@ type LockedEvent struct {
 	Data          pldtypes.HexBytes     `json:"data"`
 }
 
+type IdentityRegisteredEvent struct {
+	PublicKey []pldtypes.HexUint256 `json:"publicKey"`
+	Data      pldtypes.HexBytes     `json:"data"`
+}
+
 type merkleTreeSpec struct {
 	name    string
 	storage smt.StatesStorage
@@ -344,6 +350,8 @@ func (z *Zeto) GetHandler(method, tokenName string) types.DomainHandler {
 		return fungible.NewDepositHandler(z.name, z.coinSchema)
 	case types.METHOD_WITHDRAW:
 		return fungible.NewWithdrawHandler(z.name, z.Callbacks, z.coinSchema, z.merkleTreeRootSchema, z.merkleTreeNodeSchema)
+	case types.METHOD_REGISTER:
+		return fungible.NewRegisterHandler(z.name, z.dataSchema)
 	default:
 		return nil
 	}
@@ -431,6 +439,8 @@ func (z *Zeto) registerEventSignatures(eventAbis abi.ABI) {
 			z.withdrawSignature = event.SolString()
 		case "UTXOsLocked":
 			z.lockSignature = event.SolString()
+		case "IdentityRegistered":
+			z.identityRegisteredSignature = event.SolString()
 		}
 	}
 }
@@ -451,14 +461,22 @@ func (z *Zeto) HandleEventBatch(ctx context.Context, req *prototk.HandleEventBat
 	var errors []string
 	var smtForStates *merkleTreeSpec
 	var smtForLockedStates *merkleTreeSpec
+	var smtForIdentities *merkleTreeSpec
 	if common.IsNullifiersToken(domainConfig.TokenName) {
 		smtName := smt.MerkleTreeName(domainConfig.TokenName, contractAddress)
-		smtForStates, err = z.newSmtTreeSpec(ctx, smtName, req.StateQueryContext)
+		smtForStates, err = z.newSmtTreeSpec(ctx, smtName, req.StateQueryContext, smt.NewSmt)
 		if err != nil {
 			return nil, err
 		}
 		smtName = smt.MerkleTreeNameForLockedStates(domainConfig.TokenName, contractAddress)
-		smtForLockedStates, err = z.newSmtTreeSpec(ctx, smtName, req.StateQueryContext)
+		smtForLockedStates, err = z.newSmtTreeSpec(ctx, smtName, req.StateQueryContext, smt.NewSmt)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if common.IsKycToken(domainConfig.TokenName) {
+		smtName := smt.MerkleTreeNameForIdentities(domainConfig.TokenName, contractAddress)
+		smtForIdentities, err = z.newSmtTreeSpec(ctx, smtName, req.StateQueryContext, smt.NewSmtForIdentities)
 		if err != nil {
 			return nil, err
 		}
@@ -476,6 +494,8 @@ func (z *Zeto) HandleEventBatch(ctx context.Context, req *prototk.HandleEventBat
 			err = z.handleWithdrawEvent(ctx, smtForStates, ev, domainConfig.TokenName, &res)
 		case z.lockSignature:
 			err = z.handleLockedEvent(ctx, smtForStates, smtForLockedStates, ev, domainConfig.TokenName, &res)
+		case z.identityRegisteredSignature:
+			err = z.handleIdentityRegisteredEvent(ctx, smtForIdentities, ev, domainConfig.TokenName, &res)
 		}
 		if err != nil {
 			errors = append(errors, err.Error())
@@ -500,6 +520,15 @@ func (z *Zeto) HandleEventBatch(ctx context.Context, req *prototk.HandleEventBat
 			res.NewStates = append(res.NewStates, newStatesForSMTForLocked...)
 		}
 	}
+	if common.IsKycToken(domainConfig.TokenName) {
+		newStatesForIdentities, err := smtForIdentities.storage.GetNewStates()
+		if err != nil {
+			return nil, i18n.NewError(ctx, msgs.MsgErrorGetNewSmtStates, smtForIdentities.name, err)
+		}
+		if len(newStatesForIdentities) > 0 {
+			res.NewStates = append(res.NewStates, newStatesForIdentities...)
+		}
+	}
 	return &res, nil
 }
 
@@ -636,12 +665,16 @@ func (z *Zeto) WrapPrivacyGroupEVMTX(ctx context.Context, req *prototk.WrapPriva
 	return nil, i18n.NewError(ctx, msgs.MsgNotImplemented)
 }
 
-func (z *Zeto) newSmtTreeSpec(ctx context.Context, smtName string, stateQueryContext string) (*merkleTreeSpec, error) {
+func (z *Zeto) HandleRPC(ctx context.Context, req *prototk.HandleRPCRequest) (*prototk.HandleRPCResponse, error) {
+	return nil, i18n.NewError(ctx, msgs.MsgNotImplemented)
+}
+
+func (z *Zeto) newSmtTreeSpec(ctx context.Context, smtName string, stateQueryContext string, newTree func(smt.StatesStorage) (core.SparseMerkleTree, error)) (*merkleTreeSpec, error) {
 	smtForStates := &merkleTreeSpec{
 		name:    smtName,
 		storage: smt.NewStatesStorage(z.Callbacks, smtName, stateQueryContext, z.merkleTreeRootSchema.Id, z.merkleTreeNodeSchema.Id),
 	}
-	tree, err := smt.NewSmt(smtForStates.storage)
+	tree, err := newTree(smtForStates.storage)
 	if err != nil {
 		return nil, i18n.NewError(ctx, msgs.MsgErrorNewSmt, smtName, err)
 	}
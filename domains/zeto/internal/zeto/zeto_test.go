@@ -72,6 +72,10 @@ func (dc *testDomainCallbacks) SendTransaction(ctx context.Context, tx *pb.SendT
 	return nil, nil
 }
 
+func (dc *testDomainCallbacks) CheckCredential(ctx context.Context, req *pb.CheckCredentialRequest) (*pb.CheckCredentialResponse, error) {
+	return nil, nil
+}
+
 func TestNew(t *testing.T) {
 	testCallbacks := &domain.MockDomainCallbacks{}
 	z := New(testCallbacks)
@@ -524,6 +528,7 @@ func newTestZeto() (*Zeto, *domain.MockDomainCallbacks) {
 	z.mintSignature = "event UTXOMint(uint256[] outputs, address indexed submitter, bytes data)"
 	z.transferSignature = "event UTXOTransfer(uint256[] inputs, uint256[] outputs, address indexed submitter, bytes data)"
 	z.transferWithEncSignature = "event UTXOTransferWithEncryptedValues(uint256[] inputs, uint256[] outputs, uint256 encryptionNonce, uint256[2] ecdhPublicKey, uint256[] encryptedValues, address indexed submitter, bytes data)"
+	z.identityRegisteredSignature = "event IdentityRegistered(uint256[2] publicKey, bytes data)"
 	return z, testCallbacks
 }
 
@@ -611,6 +616,21 @@ func TestHandleEventBatch(t *testing.T) {
 	req.Events[0].SoliditySignature = "event UTXOWithdraw(uint256 amount, uint256[] inputs, uint256 output, address indexed submitter, bytes data)"
 	_, err = z.HandleEventBatch(ctx, req)
 	assert.NoError(t, err)
+
+	// KYC tokens also keep the identities registry in sync
+	req.ContractInfo.ContractConfigJson = pldtypes.JSONString(map[string]interface{}{
+		"tokenName": "Zeto_AnonNullifierKyc",
+	}).Pretty()
+	data, _ = json.Marshal(map[string]any{
+		"data":      encodedData,
+		"publicKey": []string{"7980718117603030807695495350922077879582656644717071592146865497574198464253", "1"},
+	})
+	req.Events[0].DataJson = string(data)
+	req.Events[0].SoliditySignature = "event IdentityRegistered(uint256[2] publicKey, bytes data)"
+	res5, err := z.HandleEventBatch(ctx, req)
+	assert.NoError(t, err)
+	assert.Len(t, res5.TransactionsComplete, 1)
+	assert.Len(t, res5.NewStates, 2)
 }
 
 func TestGetVerifier(t *testing.T) {
@@ -824,6 +844,10 @@ func TestGetHandler(t *testing.T) {
 		{"Valid withdraw handler for TOKEN_ANON", "withdraw", constants.TOKEN_ANON, false},
 		{"Invalid handler for TOKEN_ANON", "bad", constants.TOKEN_ANON, true},
 
+		// Tests for TOKEN_ANON_NULLIFIER_KYC
+		{"Valid register handler for TOKEN_ANON_NULLIFIER_KYC", "register", constants.TOKEN_ANON_NULLIFIER_KYC, false},
+		{"Valid transfer handler for TOKEN_ANON_NULLIFIER_KYC", "transfer", constants.TOKEN_ANON_NULLIFIER_KYC, false},
+
 		// Tests for TOKEN_NF_ANON
 		{"Valid mint handler for TOKEN_NF_ANON", "mint", constants.TOKEN_NF_ANON, false},
 		{"Valid transfer handler for TOKEN_NF_ANON", "transfer", constants.TOKEN_NF_ANON, false},
@@ -26,6 +26,8 @@ const (
 	TOKEN_ANON           = "Zeto_Anon"
 	TOKEN_ANON_ENC       = "Zeto_AnonEnc"
 	TOKEN_ANON_NULLIFIER = "Zeto_AnonNullifier"
+	// the KYC variant also proves that the sender and receivers are in the token's identity registry
+	TOKEN_ANON_NULLIFIER_KYC = "Zeto_AnonNullifierKyc"
 
 	TOKEN_NF_ANON           = "Zeto_NfAnon"
 	TOKEN_NF_ANON_NULLIFIER = "Zeto_NfAnonNullifier"
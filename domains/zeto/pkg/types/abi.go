@@ -39,6 +39,7 @@ const (
 	METHOD_LOCK            = "lock"
 	METHOD_DEPOSIT         = "deposit"
 	METHOD_WITHDRAW        = "withdraw"
+	METHOD_REGISTER        = "register"
 )
 
 type InitializerParams struct {
@@ -100,3 +101,8 @@ type DepositParams struct {
 type WithdrawParams struct {
 	Amount *pldtypes.HexUint256 `json:"amount"`
 }
+
+type RegisterParams struct {
+	Identity string            `json:"identity"`
+	Data     pldtypes.HexBytes `json:"data"`
+}
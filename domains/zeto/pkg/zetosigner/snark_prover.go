@@ -2,6 +2,7 @@ package zetosigner
 
 import (
 	"github.com/kaleido-io/paladin/domains/zeto/internal/zeto/signer"
+	"github.com/kaleido-io/paladin/domains/zeto/pkg/proto"
 	"github.com/kaleido-io/paladin/domains/zeto/pkg/zetosigner/zetosignerapi"
 	"github.com/kaleido-io/paladin/toolkit/pkg/signerapi"
 )
@@ -9,3 +10,10 @@ import (
 func NewSnarkProver(conf *zetosignerapi.SnarkProverConfig) (signerapi.InMemorySigner, error) {
 	return signer.NewSnarkProver(conf)
 }
+
+// NewProverService returns the gRPC service for a remote prover, which generates proofs for the
+// witnesses sent by Paladin nodes configured with a remoteProver. The caller is responsible for
+// hosting it on a gRPC server with TLS enabled.
+func NewProverService(provingKeysDir string) proto.ZetoProverServer {
+	return signer.NewProverService(provingKeysDir)
+}
@@ -28,6 +28,18 @@ type SnarkProverConfig struct {
 	CircuitsDir         string `json:"circuitsDir"`         // directory for the circuits runtime (WASM currently supported)
 	ProvingKeysDir      string `json:"provingKeysDir"`      // public parameters for the prover, specific to each circuit
 	MaxProverPerCircuit *int   `json:"maxProverPerCircuit"` // maximum number of proving runtime per circuit, each prover owns a standalone WASM instance
+	// when set, proof generation is delegated to a remote prover service, and proving keys are not needed locally
+	RemoteProver *RemoteProverConfig `json:"remoteProver,omitempty"`
+}
+
+// RemoteProverConfig is the configuration for delegating proof generation to a
+// remote prover service over gRPC. The witness is still calculated locally, but it
+// contains the private inputs to the circuit, so TLS must be enabled.
+type RemoteProverConfig struct {
+	Endpoint       string            `json:"endpoint"`       // gRPC endpoint of the prover service, such as "dns:///prover.example.com:8443"
+	MaxConcurrency *int              `json:"maxConcurrency"` // maximum number of proof requests in flight to the prover service across all circuits
+	RequestTimeout *string           `json:"requestTimeout"` // maximum time to wait for an individual proof to be generated
+	TLS            pldconf.TLSConfig `json:"tls"`            // TLS configuration for the connection, which must be enabled
 }
 
 type CircuitType string
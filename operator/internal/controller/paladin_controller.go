@@ -415,13 +415,14 @@ func (r *PaladinReconciler) generateStatefulSetTemplate(node *corev1alpha1.Palad
 							},
 							ReadinessProbe: &corev1.Probe{
 								ProbeHandler: corev1.ProbeHandler{
-									TCPSocket: &corev1.TCPSocketAction{
+									HTTPGet: &corev1.HTTPGetAction{
+										Path: "/readyz",
 										Port: intstr.FromInt(8548),
 									},
 								},
 								InitialDelaySeconds: 5,
-								TimeoutSeconds:      2,
-								PeriodSeconds:       5,
+								TimeoutSeconds:      6, // the node reports a check as down after 5s by default
+								PeriodSeconds:       10,
 							},
 							SecurityContext: r.config.Paladin.SecurityContext,
 						},
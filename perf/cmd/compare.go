@@ -0,0 +1,87 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/kaleido-io/paladin/perf/internal/util"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var tolerance float64
+
+// compareCmd represents the compare command
+var compareCmd = &cobra.Command{
+	Use:   "compare <baseline report> <report>",
+	Short: "Compares the JSON reports of two runs of the same test instance, failing if performance has regressed",
+	Long: `Compares the JSON reports of two runs of the same test instance, failing if performance has regressed.
+A regression is a drop in throughput, or an increase in the 95th percentile latency, of more than the tolerance.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		baseline, err := util.LoadJSONReport(args[0])
+		if err != nil {
+			return err
+		}
+		current, err := util.LoadJSONReport(args[1])
+		if err != nil {
+			return err
+		}
+		regressions := compareReports(baseline, current, tolerance)
+		for _, r := range regressions {
+			log.Error(r)
+		}
+		if len(regressions) > 0 {
+			return errors.Errorf("%d performance regressions found against the baseline", len(regressions))
+		}
+		log.Infof("No performance regressions found against the baseline (tolerance %.1f%%)", tolerance)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(compareCmd)
+
+	compareCmd.Flags().Float64VarP(&tolerance, "tolerance", "t", 10, "Percentage change from the baseline that is allowed before a result is reported as a regression")
+}
+
+func compareReports(baseline, current *util.JSONReport, tolerance float64) []string {
+	regressions := []string{}
+	for _, b := range baseline.Results {
+		var c *util.TestRunResult
+		for i := range current.Results {
+			if current.Results[i].Name == b.Name {
+				c = &current.Results[i]
+				break
+			}
+		}
+		if c == nil {
+			regressions = append(regressions, fmt.Sprintf("%s: no result to compare with the baseline", b.Name))
+			continue
+		}
+		log.Infof("%s: throughput %f -> %f, p95 latency %s -> %s", b.Name, b.Throughput, c.Throughput, b.P95Latency, c.P95Latency)
+		if c.Throughput < b.Throughput*(1-tolerance/100) {
+			regressions = append(regressions, fmt.Sprintf("%s: throughput dropped from %f to %f", b.Name, b.Throughput, c.Throughput))
+		}
+		if float64(c.P95Latency) > float64(b.P95Latency)*(1+tolerance/100) {
+			regressions = append(regressions, fmt.Sprintf("%s: p95 latency increased from %s to %s", b.Name, b.P95Latency, c.P95Latency))
+		}
+	}
+	return regressions
+}
@@ -0,0 +1,97 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kaleido-io/paladin/perf/internal/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompareReports(t *testing.T) {
+	baseline := &util.JSONReport{
+		Results: []util.TestRunResult{
+			{Name: "public_contract", Throughput: 100, P95Latency: 200 * time.Millisecond},
+		},
+	}
+	tests := []struct {
+		name        string
+		current     []util.TestRunResult
+		tolerance   float64
+		regressions []string
+	}{
+		{
+			name:        "unchanged",
+			current:     []util.TestRunResult{{Name: "public_contract", Throughput: 100, P95Latency: 200 * time.Millisecond}},
+			tolerance:   10,
+			regressions: []string{},
+		},
+		{
+			name:        "improved",
+			current:     []util.TestRunResult{{Name: "public_contract", Throughput: 150, P95Latency: 100 * time.Millisecond}},
+			tolerance:   10,
+			regressions: []string{},
+		},
+		{
+			name:        "within tolerance",
+			current:     []util.TestRunResult{{Name: "public_contract", Throughput: 91, P95Latency: 219 * time.Millisecond}},
+			tolerance:   10,
+			regressions: []string{},
+		},
+		{
+			name:      "throughput dropped",
+			current:   []util.TestRunResult{{Name: "public_contract", Throughput: 89, P95Latency: 200 * time.Millisecond}},
+			tolerance: 10,
+			regressions: []string{
+				"public_contract: throughput dropped from 100.000000 to 89.000000",
+			},
+		},
+		{
+			name:      "latency increased",
+			current:   []util.TestRunResult{{Name: "public_contract", Throughput: 100, P95Latency: 221 * time.Millisecond}},
+			tolerance: 10,
+			regressions: []string{
+				"public_contract: p95 latency increased from 200ms to 221ms",
+			},
+		},
+		{
+			name:      "zero tolerance",
+			current:   []util.TestRunResult{{Name: "public_contract", Throughput: 99, P95Latency: 201 * time.Millisecond}},
+			tolerance: 0,
+			regressions: []string{
+				"public_contract: throughput dropped from 100.000000 to 99.000000",
+				"public_contract: p95 latency increased from 200ms to 201ms",
+			},
+		},
+		{
+			name:      "missing result",
+			current:   []util.TestRunResult{{Name: "private_contract", Throughput: 100, P95Latency: 200 * time.Millisecond}},
+			tolerance: 10,
+			regressions: []string{
+				"public_contract: no result to compare with the baseline",
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			regressions := compareReports(baseline, &util.JSONReport{Results: tc.current}, tc.tolerance)
+			assert.Equal(t, tc.regressions, regressions)
+		})
+	}
+}
@@ -38,6 +38,7 @@ var instanceName string
 var instanceIndex int
 var daemonOverride bool
 var deliquentAction string
+var reportFile string
 
 var httpServer *server.HttpServer
 var perfRunner perf.PerfRunner
@@ -100,6 +101,7 @@ func init() {
 	runCmd.Flags().IntVarP(&instanceIndex, "instance-idx", "i", -1, "Index of the instance within performance config to run")
 	runCmd.Flags().BoolVarP(&daemonOverride, "daemon", "d", false, "Run in long-lived, daemon mode. Any provided test length is ignored.")
 	runCmd.Flags().StringVarP(&deliquentAction, "delinquent", "", "exit", "Action to take when delinquent messages are detected. Valid options: [exit log]")
+	runCmd.Flags().StringVarP(&reportFile, "report-json", "r", "", "Path to write a JSON report of the results to, for comparison against other runs")
 
 	runCmd.MarkFlagRequired("config")
 }
@@ -166,6 +168,8 @@ func generateRunnerConfigFromInstance(instance *conf.InstanceConfig, perfConfig
 	runnerConfig.MaxTimePerAction = instance.MaxTimePerAction
 	runnerConfig.MaxActions = instance.MaxActions
 	runnerConfig.RampLength = instance.RampLength
+	runnerConfig.LoadProfile = instance.LoadProfile
+	runnerConfig.ReportFile = reportFile
 
 	// If delinquent action has been set on the test run instance this overrides the command line
 	if instance.DelinquentAction != "" {
@@ -198,5 +202,18 @@ func validateConfig(cfg *conf.RunnerConfig, instance *conf.InstanceConfig, globa
 	if len(globalConfig.Nodes) > 0 && ((instance.NodeIndex + 1) > len(globalConfig.Nodes)) {
 		return fmt.Errorf("NodeIndex %d not valid - only %d nodes have been configured", instance.NodeIndex, len(globalConfig.Nodes))
 	}
+	for i, stage := range cfg.LoadProfile {
+		if stage.Duration <= 0 || stage.TargetRate < 0 {
+			return fmt.Errorf("load profile stage %d not valid - a positive duration and a target rate of at least 0 are required", i)
+		}
+	}
+	for _, test := range cfg.Tests {
+		if test.Signers < 0 || test.PayloadSize < 0 {
+			return fmt.Errorf("test %s not valid - signers and payloadSize cannot be negative", test.Name)
+		}
+		if test.Name == conf.PerfTestPrivateContract && (cfg.ContractOptions.Domain == "" || cfg.ContractOptions.Function == "") {
+			return fmt.Errorf("test %s requires a domain and function to be set in the contract options", test.Name)
+		}
+	}
 	return nil
 }
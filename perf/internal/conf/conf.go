@@ -39,6 +39,8 @@ type RunnerConfig struct {
 	RampLength              time.Duration
 	NoWaitSubmission        bool
 	MaxSubmissionsPerSecond int
+	LoadProfile             []LoadStage
+	ReportFile              string
 }
 
 type PerformanceTestConfig struct {
@@ -64,12 +66,22 @@ type InstanceConfig struct {
 	NoWaitSubmission        bool             `json:"noWaitSubmission"`
 	MaxSubmissionsPerSecond int              `json:"maxSubmissionsPerSecond"`
 	DelinquentAction        string           `json:"delinquentAction,omitempty"`
+	LoadProfile             []LoadStage      `json:"loadProfile,omitempty"`
 }
 
 type TestCaseConfig struct {
 	Name           fftypes.FFEnum `json:"name"`
 	Workers        int            `json:"workers"`
 	ActionsPerLoop int            `json:"actionsPerLoop"`
+	Signers        int            `json:"signers,omitempty"`
+	PayloadSize    int            `json:"payloadSize,omitempty"`
+}
+
+// LoadStage is one step of a load profile. The submission rate ramps linearly from the
+// rate at the end of the previous stage (or zero) to TargetRate over Duration.
+type LoadStage struct {
+	Duration   time.Duration `json:"duration"`
+	TargetRate int           `json:"targetRate"`
 }
 
 type NodeConfig struct {
@@ -79,12 +91,18 @@ type NodeConfig struct {
 }
 
 type ContractOptions struct {
-	Address string `json:"address"`
+	Address      string `json:"address"`
+	Domain       string `json:"domain,omitempty"`
+	ABIReference string `json:"abiReference,omitempty"`
+	Function     string `json:"function,omitempty"`
+	Data         any    `json:"data,omitempty"`
 }
 
 var (
 	// PerfTestPublicContract invokes a public smart contract and checks for transaction receipts
 	PerfTestPublicContract fftypes.FFEnum = "public_contract"
+	// PerfTestPrivateContract invokes a function on a private smart contract in a domain and checks for transaction receipts
+	PerfTestPrivateContract fftypes.FFEnum = "private_contract"
 )
 
 var (
@@ -95,5 +113,6 @@ var (
 )
 
 var ValidPerfTests = map[string]fftypes.FFEnum{
-	PerfTestPublicContract.String(): PerfTestPublicContract,
+	PerfTestPublicContract.String():  PerfTestPublicContract,
+	PerfTestPrivateContract.String(): PerfTestPrivateContract,
 }
@@ -0,0 +1,48 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package perf
+
+import (
+	"time"
+
+	"github.com/kaleido-io/paladin/perf/internal/conf"
+)
+
+// rateAt returns the submission rate a load profile calls for after it has been running for the
+// elapsed time. Each stage ramps linearly from the rate the previous stage ended at, and once
+// all the stages are complete the rate of the final stage is held until the test ends.
+func rateAt(profile []conf.LoadStage, elapsed time.Duration) float64 {
+	startRate := 0.0
+	for _, stage := range profile {
+		targetRate := float64(stage.TargetRate)
+		if elapsed < stage.Duration {
+			return startRate + (targetRate-startRate)*(float64(elapsed)/float64(stage.Duration))
+		}
+		elapsed -= stage.Duration
+		startRate = targetRate
+	}
+	return startRate
+}
+
+// loadProfileLength is the total time taken to step through every stage of a load profile
+func loadProfileLength(profile []conf.LoadStage) time.Duration {
+	var length time.Duration
+	for _, stage := range profile {
+		length += stage.Duration
+	}
+	return length
+}
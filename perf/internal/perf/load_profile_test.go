@@ -0,0 +1,74 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package perf
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kaleido-io/paladin/perf/internal/conf"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateAt(t *testing.T) {
+	profile := []conf.LoadStage{
+		{Duration: 10 * time.Second, TargetRate: 100},
+		{Duration: 20 * time.Second, TargetRate: 100},
+		{Duration: 10 * time.Second, TargetRate: 50},
+	}
+	tests := []struct {
+		name    string
+		profile []conf.LoadStage
+		elapsed time.Duration
+		rate    float64
+	}{
+		{name: "start", profile: profile, elapsed: 0, rate: 0},
+		{name: "ramp up", profile: profile, elapsed: 5 * time.Second, rate: 50},
+		{name: "end of ramp up", profile: profile, elapsed: 10 * time.Second, rate: 100},
+		{name: "hold", profile: profile, elapsed: 20 * time.Second, rate: 100},
+		{name: "ramp down", profile: profile, elapsed: 35 * time.Second, rate: 75},
+		{name: "final rate held", profile: profile, elapsed: time.Minute, rate: 50},
+		{name: "empty profile", profile: nil, elapsed: time.Second, rate: 0},
+		{name: "zero length stage", profile: []conf.LoadStage{{TargetRate: 10}, {Duration: time.Second, TargetRate: 20}}, elapsed: 500 * time.Millisecond, rate: 15},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.InDelta(t, tc.rate, rateAt(tc.profile, tc.elapsed), 0.001)
+		})
+	}
+}
+
+func TestLoadProfileLength(t *testing.T) {
+	tests := []struct {
+		name    string
+		profile []conf.LoadStage
+		length  time.Duration
+	}{
+		{name: "empty", profile: nil, length: 0},
+		{name: "single stage", profile: []conf.LoadStage{{Duration: time.Minute, TargetRate: 10}}, length: time.Minute},
+		{name: "multiple stages", profile: []conf.LoadStage{
+			{Duration: 10 * time.Second, TargetRate: 100},
+			{Duration: 20 * time.Second, TargetRate: 100},
+			{Duration: 10 * time.Second, TargetRate: 50},
+		}, length: 40 * time.Second},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.length, loadProfileLength(tc.profile))
+		})
+	}
+}
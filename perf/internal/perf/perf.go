@@ -175,6 +175,11 @@ func New(config *conf.RunnerConfig, reportBuilder *util.Report) PerfRunner {
 
 	ctx, cancel := context.WithCancel(context.Background())
 
+	if config.Length == 0 && len(config.LoadProfile) > 0 {
+		// A load profile defines the length of the test, unless a longer length is set to hold the final rate
+		config.Length = loadProfileLength(config.LoadProfile)
+	}
+
 	startRampTime := time.Now().Unix()
 	endRampTime := time.Now().Unix() + int64(config.RampLength.Seconds())
 	startTime := endRampTime
@@ -226,6 +231,12 @@ func (pr *perfRunner) Start() (err error) {
 		}
 	}
 
+	if containsTargetTest(pr.cfg.Tests, conf.PerfTestPrivateContract) {
+		if err = pr.subscribeToPrivateContractListener(); err != nil {
+			return err
+		}
+	}
+
 	id := 0
 	for _, test := range pr.cfg.Tests {
 		log.Infof("Starting %d workers for case \"%s\"", test.Workers, test.Name)
@@ -234,7 +245,9 @@ func (pr *perfRunner) Start() (err error) {
 
 			switch test.Name {
 			case conf.PerfTestPublicContract:
-				tc = newPublicContractTestWorker(pr, id, test.ActionsPerLoop)
+				tc = newPublicContractTestWorker(pr, id, test)
+			case conf.PerfTestPrivateContract:
+				tc = newPrivateContractTestWorker(pr, id, test)
 			default:
 				return fmt.Errorf("unknown test case '%s'", test.Name)
 			}
@@ -268,7 +281,11 @@ func (pr *perfRunner) Start() (err error) {
 
 	rateLimiter := rate.NewLimiter(rate.Limit(math.MaxFloat64), math.MaxInt)
 
-	if pr.cfg.MaxSubmissionsPerSecond > 0 {
+	if len(pr.cfg.LoadProfile) > 0 {
+		// The load profile takes over from any fixed maximum rate, and we do not allow bursts
+		// so the submission rate tracks the profile closely
+		rateLimiter = rate.NewLimiter(rate.Limit(pr.profileRate()), 1)
+	} else if pr.cfg.MaxSubmissionsPerSecond > 0 {
 		rateLimiter = rate.NewLimiter(rate.Limit(pr.cfg.MaxSubmissionsPerSecond), pr.cfg.MaxSubmissionsPerSecond)
 	}
 	log.Infof("Sending rate: %f per second with %d burst", rateLimiter.Limit(), rateLimiter.Burst())
@@ -284,6 +301,9 @@ perfLoop:
 		case <-signalCh:
 			break perfLoop
 		case pr.bfr <- i:
+			if len(pr.cfg.LoadProfile) > 0 {
+				rateLimiter.SetLimit(rate.Limit(pr.profileRate()))
+			}
 			err = rateLimiter.Wait(pr.ctx)
 			if err != nil {
 				log.Panic(fmt.Errorf("rate limiter failed"))
@@ -360,6 +380,12 @@ perfLoop:
 		log.Errorf("failed to generate performance report: %+v", err)
 	}
 
+	if pr.cfg.ReportFile != "" {
+		if err = pr.reportBuilder.GenerateJSON(pr.cfg.ReportFile); err != nil {
+			log.Errorf("failed to write performance report to %s: %+v", pr.cfg.ReportFile, err)
+		}
+	}
+
 	// we sleep on shutdown / completion to allow for Prometheus metrics to be scraped one final time
 	// After 30 seconds workers should be completed, so we check for delinquent messages
 	// one last time so metrics are up-to-date
@@ -695,6 +721,27 @@ func (pr *perfRunner) subscribeToPublicContractListener() error {
 	return nil
 }
 
+func (pr *perfRunner) subscribeToPrivateContractListener() error {
+	// As with public transactions, the listener is created once outside of the test
+	listenerName := "privatelistener"
+	sub, err := pr.wsClient.PTX().SubscribeReceipts(pr.ctx, listenerName)
+	if err != nil {
+		return err
+	}
+
+	pr.subscriptions = append(pr.subscriptions, sub)
+
+	go pr.batchEventLoop(sub)
+
+	return nil
+}
+
+// profileRate is the current submission rate of the load profile, measured from the start of
+// the test. The rate is never allowed to drop to zero, as that would stop the submission loop.
+func (pr *perfRunner) profileRate() float64 {
+	return math.Max(rateAt(pr.cfg.LoadProfile, time.Since(time.Unix(pr.startRampTime, 0))), 1)
+}
+
 func (pr *perfRunner) IsDaemon() bool {
 	return pr.cfg.Daemon
 }
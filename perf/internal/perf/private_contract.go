@@ -0,0 +1,112 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package perf
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/kaleido-io/paladin/perf/internal/conf"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+)
+
+// privateContract invokes a function on a private smart contract in the configured domain,
+// such as a mint on a Noto token. The input data is templated per action, with these
+// placeholders replaced in any string value:
+//
+//   - ${signer}    the signing key of the action
+//   - ${payload}   hex bytes of the configured payload size
+//   - ${worker}    the ID of the worker
+//   - ${iteration} the iteration within the worker loop
+type privateContract struct {
+	testBase
+}
+
+func newPrivateContractTestWorker(pr *perfRunner, workerID int, test conf.TestCaseConfig) TestCase {
+	return &privateContract{
+		testBase: newTestBase(pr, workerID, test),
+	}
+}
+
+func (tc *privateContract) Name() string {
+	return conf.PerfTestPrivateContract.String()
+}
+
+func (tc *privateContract) RunOnce(iterationCount int) (string, error) {
+	opts := &tc.pr.cfg.ContractOptions
+	abiRef, err := pldtypes.ParseBytes32(opts.ABIReference)
+	if err != nil {
+		return "", fmt.Errorf("invalid abiReference for %s test: %s", tc.Name(), err)
+	}
+	to, err := pldtypes.ParseEthAddress(opts.Address)
+	if err != nil {
+		return "", fmt.Errorf("invalid address for %s test: %s", tc.Name(), err)
+	}
+	signer := tc.signer(iterationCount)
+	data, err := json.Marshal(tc.templateData(opts.Data, map[string]string{
+		"${signer}":    signer,
+		"${payload}":   tc.payload(iterationCount),
+		"${worker}":    strconv.Itoa(tc.workerID),
+		"${iteration}": strconv.Itoa(iterationCount),
+	}))
+	if err != nil {
+		return "", err
+	}
+	result, err := tc.pr.httpClient.PTX().SendTransaction(tc.pr.ctx, &pldapi.TransactionInput{
+		TransactionBase: pldapi.TransactionBase{
+			Type:           pldapi.TransactionTypePrivate.Enum(),
+			Domain:         opts.Domain,
+			ABIReference:   &abiRef,
+			Function:       opts.Function,
+			To:             to,
+			From:           signer,
+			Data:           data,
+			IdempotencyKey: tc.pr.getIdempotencyKey(tc.workerID, iterationCount),
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprint(result), nil
+}
+
+func (tc *privateContract) templateData(v any, replacements map[string]string) any {
+	switch v := v.(type) {
+	case string:
+		for placeholder, value := range replacements {
+			v = strings.ReplaceAll(v, placeholder, value)
+		}
+		return v
+	case map[string]any:
+		m := make(map[string]any, len(v))
+		for k, e := range v {
+			m[k] = tc.templateData(e, replacements)
+		}
+		return m
+	case []any:
+		a := make([]any, len(v))
+		for i, e := range v {
+			a[i] = tc.templateData(e, replacements)
+		}
+		return a
+	default:
+		return v
+	}
+}
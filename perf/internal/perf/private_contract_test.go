@@ -0,0 +1,68 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package perf
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTemplateData(t *testing.T) {
+	replacements := map[string]string{
+		"${worker}": "3",
+		"${nonce}":  "42",
+	}
+	tests := []struct {
+		name     string
+		data     any
+		expected any
+	}{
+		{name: "string", data: "recipient${worker}@node1", expected: "recipient3@node1"},
+		{name: "multiple placeholders", data: "${worker}-${nonce}-${worker}", expected: "3-42-3"},
+		{name: "no placeholders", data: "static", expected: "static"},
+		{name: "unknown placeholder", data: "${other}", expected: "${other}"},
+		{name: "number", data: float64(100), expected: float64(100)},
+		{name: "bool", data: true, expected: true},
+		{name: "nil", data: nil, expected: nil},
+		{
+			name: "nested",
+			data: map[string]any{
+				"to":     "recipient${worker}@node1",
+				"amount": float64(1),
+				"data":   []any{"${nonce}", map[string]any{"id": "w${worker}"}},
+			},
+			expected: map[string]any{
+				"to":     "recipient3@node1",
+				"amount": float64(1),
+				"data":   []any{"42", map[string]any{"id": "w3"}},
+			},
+		},
+	}
+	tc := &privateContract{}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, tc.templateData(test.data, replacements))
+		})
+	}
+}
+
+func TestTemplateDataDoesNotModifyInput(t *testing.T) {
+	data := map[string]any{"to": "recipient${worker}@node1"}
+	_ = (&privateContract{}).templateData(data, map[string]string{"${worker}": "1"})
+	assert.Equal(t, "recipient${worker}@node1", data["to"])
+}
@@ -28,13 +28,9 @@ type publicContract struct {
 	testBase
 }
 
-func newPublicContractTestWorker(pr *perfRunner, workerID int, actionsPerLoop int) TestCase {
+func newPublicContractTestWorker(pr *perfRunner, workerID int, test conf.TestCaseConfig) TestCase {
 	return &publicContract{
-		testBase: testBase{
-			pr:             pr,
-			workerID:       workerID,
-			actionsPerLoop: actionsPerLoop,
-		},
+		testBase: newTestBase(pr, workerID, test),
 	}
 }
 
@@ -48,14 +44,11 @@ func (tc *publicContract) RunOnce(iterationCount int) (string, error) {
 	to := pldtypes.MustEthAddress(tc.pr.cfg.ContractOptions.Address)
 	result, err := tc.pr.httpClient.PTX().SendTransaction(tc.pr.ctx, &pldapi.TransactionInput{
 		TransactionBase: pldapi.TransactionBase{
-			Type:         pldapi.TransactionTypePublic.Enum(),
-			ABIReference: &abiRef,
-			Function:     "set",
-			To:           to,
-			// This test is more valuable if it uses different signing keys, otherwise it only exercises
-			// a single transaction orchestrator. This approach works when using the default paladin
-			// wallet, but may require additional configuration if testing with an external wallet
-			From:           fmt.Sprintf("test%d", tc.workerID),
+			Type:           pldapi.TransactionTypePublic.Enum(),
+			ABIReference:   &abiRef,
+			Function:       "set",
+			To:             to,
+			From:           tc.signer(iterationCount),
 			Data:           pldtypes.RawJSON(fmt.Sprintf("[%d]", tc.workerID)),
 			IdempotencyKey: tc.pr.getIdempotencyKey(tc.workerID, iterationCount),
 		},
@@ -1,13 +1,29 @@
 package perf
 
 import (
+	"fmt"
+	"math/rand"
+
 	"github.com/go-resty/resty/v2"
+	"github.com/kaleido-io/paladin/perf/internal/conf"
 )
 
 type testBase struct {
 	pr             *perfRunner
 	workerID       int
 	actionsPerLoop int
+	signers        int
+	payloadSize    int
+}
+
+func newTestBase(pr *perfRunner, workerID int, test conf.TestCaseConfig) testBase {
+	return testBase{
+		pr:             pr,
+		workerID:       workerID,
+		actionsPerLoop: test.ActionsPerLoop,
+		signers:        test.Signers,
+		payloadSize:    test.PayloadSize,
+	}
 }
 
 func (t *testBase) WorkerID() int {
@@ -18,6 +34,26 @@ func (t *testBase) ActionsPerLoop() int {
 	return t.actionsPerLoop
 }
 
+// signer returns the key to submit an action with. Using different signing keys is more valuable
+// than a single one, as each key exercises its own transaction orchestrator. Without a configured
+// signer count each worker has its own key, otherwise the actions of all workers are spread across
+// exactly that many keys. This approach works when using the default paladin wallet, but may require
+// additional configuration if testing with an external wallet.
+func (t *testBase) signer(iterationCount int) string {
+	if t.signers <= 0 {
+		return fmt.Sprintf("test%d", t.workerID)
+	}
+	return fmt.Sprintf("test%d", (t.workerID*t.actionsPerLoop+iterationCount)%t.signers)
+}
+
+// payload returns a hex string of the configured payload size. The bytes are pseudo-random, but
+// seeded from the worker and iteration so that repeated runs of a profile submit the same data.
+func (t *testBase) payload(iterationCount int) string {
+	b := make([]byte, t.payloadSize)
+	rand.New(rand.NewSource(int64(t.workerID)<<32 | int64(iterationCount))).Read(b)
+	return fmt.Sprintf("0x%x", b)
+}
+
 func resStatus(res *resty.Response) int {
 	if res == nil {
 		return -1
@@ -1,9 +1,12 @@
 package util
 
 import (
+	"encoding/json"
 	"fmt"
 	"html/template"
+	"math"
 	"os"
+	"sort"
 	"sync"
 	"time"
 
@@ -18,12 +21,38 @@ type TestRunMetrics struct {
 	MinLatency   string
 	MaxLatency   string
 	AvgLatency   string
+	P95Latency   string
 	Throughput   string
 }
+
+// TestRunResult holds the same metrics as TestRunMetrics in machine readable form, so
+// the results of runs of the same profile can be compared to track regressions
+type TestRunResult struct {
+	Name         string        `json:"name"`
+	TotalActions int64         `json:"totalActions"`
+	Duration     time.Duration `json:"duration"`
+	SendRate     float64       `json:"sendRate"`
+	Throughput   float64       `json:"throughput"`
+	MinLatency   time.Duration `json:"minLatency"`
+	MaxLatency   time.Duration `json:"maxLatency"`
+	AvgLatency   time.Duration `json:"avgLatency"`
+	P50Latency   time.Duration `json:"p50Latency"`
+	P95Latency   time.Duration `json:"p95Latency"`
+	P99Latency   time.Duration `json:"p99Latency"`
+}
+
+type JSONReport struct {
+	TestInstanceName string          `json:"testInstanceName"`
+	RunnerConfig     string          `json:"runnerConfig"`
+	Created          time.Time       `json:"created"`
+	Results          []TestRunResult `json:"results"`
+}
+
 type Report struct {
 	RunnerConfig     string
 	TestInstanceName string
 	TestRuns         []TestRunMetrics
+	Results          []TestRunResult
 }
 
 func (r *Report) GenerateHTML() error {
@@ -192,6 +221,7 @@ func (r *Report) GenerateHTML() error {
                                 <th>Min Latency</th>
                                 <th>Max Latency</th>
                                 <th>Avg Latency</th>
+                                <th>P95 Latency</th>
                                 <th>Throughput</th>
                             </tr>
                             {{range .TestRuns}}
@@ -203,6 +233,7 @@ func (r *Report) GenerateHTML() error {
                                 <td>{{.MinLatency}}</td>
                                 <td>{{.MaxLatency}}</td>
                                 <td>{{.AvgLatency}}</td>
+                                <td>{{.P95Latency}}</td>
                                 <td>{{.Throughput}}</td>
                             </tr>
                             {{end}}
@@ -246,9 +277,50 @@ func (r *Report) AddTestRunMetrics(name string, totalActions int64, duration tim
 		MinLatency:   lt.Min().String(),
 		MaxLatency:   lt.Max().String(),
 		AvgLatency:   lt.Avg().String(),
+		P95Latency:   lt.Percentile(95).String(),
+	})
+	r.Results = append(r.Results, TestRunResult{
+		Name:         name,
+		TotalActions: totalActions,
+		Duration:     duration,
+		SendRate:     tps.SendRate,
+		Throughput:   tps.Throughput,
+		MinLatency:   lt.Min(),
+		MaxLatency:   lt.Max(),
+		AvgLatency:   lt.Avg(),
+		P50Latency:   lt.Percentile(50),
+		P95Latency:   lt.Percentile(95),
+		P99Latency:   lt.Percentile(99),
 	})
 }
 
+// GenerateJSON writes the results of the test runs to a JSON file
+func (r *Report) GenerateJSON(filename string) error {
+	b, err := json.MarshalIndent(&JSONReport{
+		TestInstanceName: r.TestInstanceName,
+		RunnerConfig:     r.RunnerConfig,
+		Created:          time.Now(),
+		Results:          r.Results,
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filename, b, 0644)
+}
+
+// LoadJSONReport reads a report previously written by GenerateJSON
+func LoadJSONReport(filename string) (*JSONReport, error) {
+	b, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	var report JSONReport
+	if err := json.Unmarshal(b, &report); err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
 func NewReportForTestInstance(runnerConfig string, instanceName string) *Report {
 	return &Report{
 		RunnerConfig:     runnerConfig,
@@ -277,11 +349,12 @@ func GenerateTPS(totalActions int64, startTime int64, endSendTime int64) *TPS {
 }
 
 type Latency struct {
-	mux   sync.Mutex
-	min   time.Duration
-	max   time.Duration
-	total int64
-	count int64
+	mux     sync.Mutex
+	min     time.Duration
+	max     time.Duration
+	total   int64
+	count   int64
+	samples []time.Duration
 }
 
 func (lt *Latency) Record(latency time.Duration) {
@@ -295,6 +368,24 @@ func (lt *Latency) Record(latency time.Duration) {
 	}
 	lt.total += latency.Milliseconds()
 	lt.count++
+	lt.samples = append(lt.samples, latency)
+}
+
+// Percentile returns the latency that the given percentage of the recorded samples are at, or below
+func (lt *Latency) Percentile(p float64) time.Duration {
+	lt.mux.Lock()
+	defer lt.mux.Unlock()
+	if len(lt.samples) == 0 {
+		return 0
+	}
+	sorted := make([]time.Duration, len(lt.samples))
+	copy(sorted, lt.samples)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	idx := int(math.Ceil(p/100*float64(len(sorted)))) - 1
+	if idx < 0 {
+		idx = 0
+	}
+	return sorted[idx]
 }
 
 func (lt *Latency) Avg() time.Duration {
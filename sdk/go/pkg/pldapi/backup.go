@@ -0,0 +1,64 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pldapi
+
+import (
+	"github.com/google/uuid"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+)
+
+type QuiesceStatus struct {
+	Quiesced       bool                `docstruct:"QuiesceStatus" json:"quiesced"`
+	ConfirmedBlock *pldtypes.HexUint64 `docstruct:"QuiesceStatus" json:"confirmedBlock,omitempty"`
+}
+
+type DrainStatus struct {
+	Draining            bool                `docstruct:"DrainStatus" json:"draining"`
+	SafeToStop          bool                `docstruct:"DrainStatus" json:"safeToStop"`
+	Started             *pldtypes.Timestamp `docstruct:"DrainStatus" json:"started,omitempty"`
+	PrivateTransactions int                 `docstruct:"DrainStatus" json:"privateTransactions"`
+	PublicTransactions  int                 `docstruct:"DrainStatus" json:"publicTransactions"`
+	PendingEvents       int64               `docstruct:"DrainStatus" json:"pendingEvents"`
+	ConfirmedBlock      *pldtypes.HexUint64 `docstruct:"DrainStatus" json:"confirmedBlock,omitempty"`
+}
+
+type SignerNonce struct {
+	Signer    pldtypes.EthAddress `docstruct:"SignerNonce" json:"signer"`
+	NextNonce pldtypes.HexUint64  `docstruct:"SignerNonce" json:"nextNonce"`
+}
+
+type BackupDatabaseInfo struct {
+	Type     string `docstruct:"BackupDatabaseInfo" json:"type"`
+	Position string `docstruct:"BackupDatabaseInfo" json:"position,omitempty"`
+}
+
+type BackupKeyInfo struct {
+	Wallets      []string `docstruct:"BackupKeyInfo" json:"wallets"`
+	KeyMappings  int64    `docstruct:"BackupKeyInfo" json:"keyMappings"`
+	KeyVerifiers int64    `docstruct:"BackupKeyInfo" json:"keyVerifiers"`
+}
+
+type BackupManifest struct {
+	ID             uuid.UUID           `docstruct:"BackupManifest" json:"id"`
+	Created        pldtypes.Timestamp  `docstruct:"BackupManifest" json:"created"`
+	Node           string              `docstruct:"BackupManifest" json:"node"`
+	ConfigHash     pldtypes.Bytes32    `docstruct:"BackupManifest" json:"configHash"`
+	ConfirmedBlock pldtypes.HexUint64  `docstruct:"BackupManifest" json:"confirmedBlock"`
+	Database       *BackupDatabaseInfo `docstruct:"BackupManifest" json:"database"`
+	Keys           *BackupKeyInfo      `docstruct:"BackupManifest" json:"keys"`
+	Signers        []*SignerNonce      `docstruct:"BackupManifest" json:"signers"`
+}
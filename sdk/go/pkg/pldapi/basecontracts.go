@@ -0,0 +1,62 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package pldapi
+
+import (
+	"github.com/google/uuid"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+)
+
+type BaseContractOrigin string
+
+const (
+	BaseContractOriginBound    BaseContractOrigin = "bound"    // configured with the address of an existing deployment
+	BaseContractOriginDeployed BaseContractOrigin = "deployed" // deployed by this node from its embedded build
+)
+
+func (o BaseContractOrigin) Enum() pldtypes.Enum[BaseContractOrigin] {
+	return pldtypes.Enum[BaseContractOrigin](o)
+}
+
+func (o BaseContractOrigin) Options() []string {
+	return []string{
+		string(BaseContractOriginBound),
+		string(BaseContractOriginDeployed),
+	}
+}
+
+type BaseContract struct {
+	Name             string                            `docstruct:"BaseContract" json:"name"`
+	Created          pldtypes.Timestamp                `docstruct:"BaseContract" json:"created"`
+	Updated          pldtypes.Timestamp                `docstruct:"BaseContract" json:"updated"`
+	Version          string                            `docstruct:"BaseContract" json:"version"`
+	Origin           pldtypes.Enum[BaseContractOrigin] `docstruct:"BaseContract" json:"origin"`
+	Address          *pldtypes.EthAddress              `docstruct:"BaseContract" json:"address,omitempty"`
+	Transaction      *uuid.UUID                        `docstruct:"BaseContract" json:"transaction,omitempty"`
+	Failure          *string                           `docstruct:"BaseContract" json:"failure,omitempty"`
+	EmbeddedVersion  string                            `docstruct:"BaseContract" json:"embeddedVersion,omitempty"`
+	UpgradeAvailable bool                              `docstruct:"BaseContract" json:"upgradeAvailable"`
+}
+
+type BaseContractMismatch struct {
+	Node         string               `docstruct:"BaseContractMismatch" json:"node"`
+	Name         string               `docstruct:"BaseContractMismatch" json:"name"`
+	LocalVersion string               `docstruct:"BaseContractMismatch" json:"localVersion"`
+	PeerVersion  string               `docstruct:"BaseContractMismatch" json:"peerVersion"`
+	LocalAddress *pldtypes.EthAddress `docstruct:"BaseContractMismatch" json:"localAddress,omitempty"`
+	PeerAddress  *pldtypes.EthAddress `docstruct:"BaseContractMismatch" json:"peerAddress,omitempty"`
+	Detected     pldtypes.Timestamp   `docstruct:"BaseContractMismatch" json:"detected"`
+}
@@ -32,9 +32,10 @@ type BlockchainEventListener struct {
 }
 
 type BlockchainEventListenerOptions struct {
-	BatchSize    *int            `docstruct:"BlockchainEventListenerOptions" json:"batchSize,omitempty"`
-	BatchTimeout *string         `docstruct:"BlockchainEventListenerOptions" json:"batchTimeout,omitempty"`
-	FromBlock    json.RawMessage `docstruct:"BlockchainEventListenerOptions" json:"fromBlock,omitempty"`
+	BatchSize     *int            `docstruct:"BlockchainEventListenerOptions" json:"batchSize,omitempty"`
+	BatchTimeout  *string         `docstruct:"BlockchainEventListenerOptions" json:"batchTimeout,omitempty"`
+	FromBlock     json.RawMessage `docstruct:"BlockchainEventListenerOptions" json:"fromBlock,omitempty"`
+	Confirmations *int            `docstruct:"BlockchainEventListenerOptions" json:"confirmations,omitempty"`
 }
 
 type BlockchainEventListenerSource struct {
@@ -50,3 +51,15 @@ type BlockchainEventListenerStatus struct {
 type BlockchainEventListenerCheckpoint struct {
 	BlockNumber int64 `docstruct:"BlockchainEventListenerCheckpoint" json:"blockNumber"`
 }
+
+// DomainEventSubscription is the input to ptx_subscribeDomainEvents, which delivers the
+// events a domain declares in its ABI (rather than raw logs) for a set of its contracts.
+// The name identifies a durable blockchain event listener that holds the checkpoint, so
+// re-subscribing with the same name resumes from where the previous subscription left off.
+type DomainEventSubscription struct {
+	Name      string                         `docstruct:"DomainEventSubscription" json:"name"`
+	Domain    string                         `docstruct:"DomainEventSubscription" json:"domain"`
+	Contracts []*pldtypes.EthAddress         `docstruct:"DomainEventSubscription" json:"contracts"`
+	Events    []string                       `docstruct:"DomainEventSubscription" json:"events,omitempty"`
+	Options   BlockchainEventListenerOptions `docstruct:"DomainEventSubscription" json:"options"`
+}
@@ -83,3 +83,67 @@ type EventWithData struct {
 	Address pldtypes.EthAddress `docstruct:"EventWithData" json:"address"`
 	Data    pldtypes.RawJSON    `docstruct:"EventWithData" json:"data"`
 }
+
+// DecodedEvent is an event that was decoded and stored by the block indexer as the block was indexed,
+// for an event stream configured to store decoded events
+type DecodedEvent struct {
+	*EventWithData
+	Topics  []pldtypes.Bytes32 `docstruct:"DecodedEvent" json:"topics"`
+	RawData pldtypes.HexBytes  `docstruct:"DecodedEvent" json:"rawData"`
+}
+
+type IndexerBackfillRequest struct {
+	FromBlock   pldtypes.HexUint64 `docstruct:"IndexerBackfillRequest" json:"fromBlock"`
+	ToBlock     pldtypes.HexUint64 `docstruct:"IndexerBackfillRequest" json:"toBlock"`
+	Concurrency *int               `docstruct:"IndexerBackfillRequest" json:"concurrency,omitempty"`
+	BatchSize   *int               `docstruct:"IndexerBackfillRequest" json:"batchSize,omitempty"`
+}
+
+type IndexerBackfillStatus struct {
+	FromBlock     pldtypes.HexUint64  `docstruct:"IndexerBackfillStatus" json:"fromBlock"`
+	ToBlock       pldtypes.HexUint64  `docstruct:"IndexerBackfillStatus" json:"toBlock"`
+	Concurrency   int                 `docstruct:"IndexerBackfillStatus" json:"concurrency"`
+	BatchSize     int                 `docstruct:"IndexerBackfillStatus" json:"batchSize"`
+	BlocksIndexed uint64              `docstruct:"IndexerBackfillStatus" json:"blocksIndexed"`
+	Running       bool                `docstruct:"IndexerBackfillStatus" json:"running"`
+	Started       pldtypes.Timestamp  `docstruct:"IndexerBackfillStatus" json:"started"`
+	Completed     *pldtypes.Timestamp `docstruct:"IndexerBackfillStatus" json:"completed,omitempty"`
+	Error         string              `docstruct:"IndexerBackfillStatus" json:"error,omitempty"`
+}
+
+type ChainTime struct {
+	BlockNumber    pldtypes.HexUint64 `docstruct:"ChainTime" json:"blockNumber"`
+	BlockTimestamp pldtypes.Timestamp `docstruct:"ChainTime" json:"blockTimestamp"`
+	ObservedAt     pldtypes.Timestamp `docstruct:"ChainTime" json:"observedAt"`
+	EstimatedTime  pldtypes.Timestamp `docstruct:"ChainTime" json:"estimatedTime"`
+	DriftMS        int64              `docstruct:"ChainTime" json:"driftMs"`
+	DriftExceeded  bool               `docstruct:"ChainTime" json:"driftExceeded"`
+}
+
+type HeaderVerificationStatus struct {
+	Enabled              bool                `docstruct:"HeaderVerificationStatus" json:"enabled"`
+	Consensus            string              `docstruct:"HeaderVerificationStatus" json:"consensus,omitempty"`
+	BlocksVerified       uint64              `docstruct:"HeaderVerificationStatus" json:"blocksVerified"`
+	HighestBlockVerified *pldtypes.HexUint64 `docstruct:"HeaderVerificationStatus" json:"highestBlockVerified,omitempty"`
+	Discrepancies        uint64              `docstruct:"HeaderVerificationStatus" json:"discrepancies"`
+	LastDiscrepancy      *HeaderDiscrepancy  `docstruct:"HeaderVerificationStatus" json:"lastDiscrepancy,omitempty"`
+}
+
+type HeaderDiscrepancy struct {
+	BlockNumber pldtypes.HexUint64 `docstruct:"HeaderDiscrepancy" json:"blockNumber"`
+	BlockHash   pldtypes.HexBytes  `docstruct:"HeaderDiscrepancy" json:"blockHash"`
+	Detected    pldtypes.Timestamp `docstruct:"HeaderDiscrepancy" json:"detected"`
+	Error       string             `docstruct:"HeaderDiscrepancy" json:"error"`
+}
+
+type IndexerEventReplayRequest struct {
+	StreamType      string           `docstruct:"IndexerEventReplayRequest" json:"streamType,omitempty"`
+	StreamName      string           `docstruct:"IndexerEventReplayRequest" json:"streamName"`
+	TransactionHash pldtypes.Bytes32 `docstruct:"IndexerEventReplayRequest" json:"transactionHash"`
+}
+
+type IndexerEventReplayResult struct {
+	StreamName      string           `docstruct:"IndexerEventReplayResult" json:"streamName"`
+	TransactionHash pldtypes.Bytes32 `docstruct:"IndexerEventReplayResult" json:"transactionHash"`
+	Events          int              `docstruct:"IndexerEventReplayResult" json:"events"`
+}
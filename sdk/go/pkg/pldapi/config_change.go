@@ -0,0 +1,31 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pldapi
+
+import (
+	"github.com/google/uuid"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+)
+
+type ConfigChange struct {
+	ID         uuid.UUID          `docstruct:"ConfigChange" json:"id"`
+	Created    pldtypes.Timestamp `docstruct:"ConfigChange" json:"created"`
+	Section    string             `docstruct:"ConfigChange" json:"section"`
+	Previous   pldtypes.RawJSON   `docstruct:"ConfigChange" json:"previous"`
+	Value      pldtypes.RawJSON   `docstruct:"ConfigChange" json:"value"`
+	ConfigHash pldtypes.Bytes32   `docstruct:"ConfigChange" json:"configHash"`
+}
@@ -0,0 +1,91 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pldapi
+
+import (
+	"github.com/google/uuid"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+)
+
+type DataPurgeStatus string
+
+const (
+	DataPurgeStatusPending  DataPurgeStatus = "pending"  // waiting for approval by someone other than the requester
+	DataPurgeStatusExecuted DataPurgeStatus = "executed" // approved, and the data has been purged
+)
+
+func (s DataPurgeStatus) Enum() pldtypes.Enum[DataPurgeStatus] {
+	return pldtypes.Enum[DataPurgeStatus](s)
+}
+
+func (s DataPurgeStatus) Options() []string {
+	return []string{
+		string(DataPurgeStatusPending),
+		string(DataPurgeStatusExecuted),
+	}
+}
+
+type DataPurgeTargetType string
+
+const (
+	DataPurgeTargetState       DataPurgeTargetType = "state"
+	DataPurgeTargetTransaction DataPurgeTargetType = "transaction"
+)
+
+func (t DataPurgeTargetType) Enum() pldtypes.Enum[DataPurgeTargetType] {
+	return pldtypes.Enum[DataPurgeTargetType](t)
+}
+
+func (t DataPurgeTargetType) Options() []string {
+	return []string{
+		string(DataPurgeTargetState),
+		string(DataPurgeTargetTransaction),
+	}
+}
+
+type DataPurgeRequestInput struct {
+	RequestedBy  string              `docstruct:"DataPurgeRequestInput" json:"requestedBy"`
+	Reason       string              `docstruct:"DataPurgeRequestInput" json:"reason"`
+	Domain       string              `docstruct:"DataPurgeRequestInput" json:"domain,omitempty"` // required when purging states
+	States       []pldtypes.HexBytes `docstruct:"DataPurgeRequestInput" json:"states,omitempty"`
+	Transactions []uuid.UUID         `docstruct:"DataPurgeRequestInput" json:"transactions,omitempty"`
+}
+
+type DataPurgeRequest struct {
+	ID           uuid.UUID                      `docstruct:"DataPurgeRequest" json:"id"`
+	Created      pldtypes.Timestamp             `docstruct:"DataPurgeRequest" json:"created"`
+	RequestedBy  string                         `docstruct:"DataPurgeRequest" json:"requestedBy"`
+	Reason       string                         `docstruct:"DataPurgeRequest" json:"reason"`
+	Domain       string                         `docstruct:"DataPurgeRequest" json:"domain,omitempty"`
+	States       []pldtypes.HexBytes            `docstruct:"DataPurgeRequest" json:"states"`
+	Transactions []uuid.UUID                    `docstruct:"DataPurgeRequest" json:"transactions"`
+	Status       pldtypes.Enum[DataPurgeStatus] `docstruct:"DataPurgeRequest" json:"status"`
+	ApprovedBy   *string                        `docstruct:"DataPurgeRequest" json:"approvedBy,omitempty"`
+	Executed     *pldtypes.Timestamp            `docstruct:"DataPurgeRequest" json:"executed,omitempty"`
+}
+
+// An immutable record that the private data of a state or transaction was purged. The hash of the
+// data that was removed is kept, so a copy of the data held elsewhere can still be verified.
+type DataPurgeLogEntry struct {
+	ID           uuid.UUID                          `docstruct:"DataPurgeLogEntry" json:"id"`
+	PurgeRequest uuid.UUID                          `docstruct:"DataPurgeLogEntry" json:"purgeRequest"`
+	Created      pldtypes.Timestamp                 `docstruct:"DataPurgeLogEntry" json:"created"`
+	TargetType   pldtypes.Enum[DataPurgeTargetType] `docstruct:"DataPurgeLogEntry" json:"targetType"`
+	TargetID     string                             `docstruct:"DataPurgeLogEntry" json:"targetId"`
+	Domain       string                             `docstruct:"DataPurgeLogEntry" json:"domain,omitempty"`
+	DataHash     *pldtypes.Bytes32                  `docstruct:"DataPurgeLogEntry" json:"dataHash,omitempty"` // nil if there was no data to purge
+}
@@ -23,6 +23,7 @@ import (
 type Domain struct {
 	Name            string               `docstruct:"Domain" json:"name"`
 	RegistryAddress *pldtypes.EthAddress `docstruct:"Domain" json:"registryAddress"`
+	Paused          bool                 `docstruct:"Domain" json:"paused"`
 }
 
 type DomainSmartContract struct {
@@ -37,6 +37,7 @@ func TestEnums(t *testing.T) {
 	assert.NotEmpty(t, PTXEventType("").Enum().Options())
 	assert.NotEmpty(t, PGroupEventType("").Enum().Options())
 	assert.NotEmpty(t, ReliableMessageType("").Enum().Options())
+	assert.NotEmpty(t, SwapStatus("").Enum().Options())
 
 	// TODO: separate out from pldapi
 	assert.NotEmpty(t, (StateBase{}).TableName())
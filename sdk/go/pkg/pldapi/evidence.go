@@ -0,0 +1,96 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pldapi
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/google/uuid"
+	"github.com/hyperledger/firefly-signer/pkg/secp256k1"
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/common/go/pkg/pldmsgs"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+)
+
+// The only envelope format currently produced. The payload is the JSON serialization of TransactionEvidence,
+// and the signature is a compact secp256k1 R,S,V signature over the keccak256 hash of the payload bytes.
+const TransactionEvidenceFormatV1 = "paladin.evidence.v1"
+
+type TransactionEndorsement struct {
+	TransactionID   uuid.UUID          `docstruct:"TransactionEndorsement" json:"transactionId"`
+	Created         pldtypes.Timestamp `docstruct:"TransactionEndorsement" json:"created"`
+	Name            string             `docstruct:"TransactionEndorsement" json:"name"`
+	AttestationType string             `docstruct:"TransactionEndorsement" json:"attestationType"`
+	Lookup          string             `docstruct:"TransactionEndorsement" json:"lookup"`
+	Verifier        *KeyVerifier       `docstruct:"TransactionEndorsement" json:"verifier"`
+	PayloadType     string             `docstruct:"TransactionEndorsement" json:"payloadType,omitempty"`
+	Payload         pldtypes.HexBytes  `docstruct:"TransactionEndorsement" json:"payload,omitempty"`
+	Constraints     []string           `docstruct:"TransactionEndorsement" json:"constraints,omitempty"`
+}
+
+type TransactionEvidence struct {
+	Node          string                    `docstruct:"TransactionEvidence" json:"node"`
+	TransactionID uuid.UUID                 `docstruct:"TransactionEvidence" json:"transactionId"`
+	Exported      pldtypes.Timestamp        `docstruct:"TransactionEvidence" json:"exported"`
+	Receipt       *TransactionReceiptFull   `docstruct:"TransactionEvidence" json:"receipt"`
+	Events        []*IndexedEvent           `docstruct:"TransactionEvidence" json:"events"`
+	Endorsements  []*TransactionEndorsement `docstruct:"TransactionEvidence" json:"endorsements"`
+}
+
+type SignedTransactionEvidence struct {
+	Format      string            `docstruct:"SignedTransactionEvidence" json:"format"`
+	Payload     pldtypes.HexBytes `docstruct:"SignedTransactionEvidence" json:"payload"`
+	PayloadHash pldtypes.Bytes32  `docstruct:"SignedTransactionEvidence" json:"payloadHash"`
+	Signer      *KeyVerifier      `docstruct:"SignedTransactionEvidence" json:"signer"`
+	Signature   pldtypes.HexBytes `docstruct:"SignedTransactionEvidence" json:"signature"`
+}
+
+// Verify checks the envelope was signed by the signer it names, without needing access to a Paladin node,
+// and returns the evidence it contains. It is the responsibility of the caller to decide whether they trust
+// the signer's address as belonging to the node that exported the evidence.
+func (se *SignedTransactionEvidence) Verify(ctx context.Context) (*TransactionEvidence, error) {
+	if se.Format != TransactionEvidenceFormatV1 {
+		return nil, i18n.NewError(ctx, pldmsgs.MsgTypesEvidenceFormatUnsupported, se.Format)
+	}
+	hash := pldtypes.Bytes32Keccak(se.Payload)
+	if hash != se.PayloadHash {
+		return nil, i18n.NewError(ctx, pldmsgs.MsgTypesEvidenceHashMismatch, se.PayloadHash)
+	}
+	sig, err := secp256k1.DecodeCompactRSV(ctx, se.Signature)
+	if err != nil {
+		return nil, err
+	}
+	recovered, err := sig.RecoverDirect(hash[:], -1 /* V is always 27/28 */)
+	if err != nil {
+		return nil, err
+	}
+	var signerAddr string
+	if se.Signer != nil {
+		signerAddr = se.Signer.Verifier
+	}
+	signer, err := pldtypes.ParseEthAddress(signerAddr)
+	if err != nil {
+		return nil, err
+	}
+	if !signer.Equals((*pldtypes.EthAddress)(recovered)) {
+		return nil, i18n.NewError(ctx, pldmsgs.MsgTypesEvidenceSignerMismatch, recovered, signer)
+	}
+	var evidence *TransactionEvidence
+	err = json.Unmarshal(se.Payload, &evidence)
+	return evidence, err
+}
@@ -0,0 +1,97 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pldapi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hyperledger/firefly-signer/pkg/secp256k1"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newSignedEvidenceForTesting(t *testing.T) (*SignedTransactionEvidence, *TransactionEvidence) {
+	kp, err := secp256k1.GenerateSecp256k1KeyPair()
+	require.NoError(t, err)
+
+	evidence := &TransactionEvidence{
+		Node:          "node1",
+		TransactionID: uuid.New(),
+		Exported:      pldtypes.TimestampNow(),
+		Endorsements: []*TransactionEndorsement{
+			{Name: "notary", AttestationType: "ENDORSE", Lookup: "notary@node2"},
+		},
+	}
+	payload := pldtypes.JSONString(evidence)
+	hash := pldtypes.Bytes32Keccak(payload)
+	sig, err := kp.SignDirect(hash[:])
+	require.NoError(t, err)
+
+	return &SignedTransactionEvidence{
+		Format:      TransactionEvidenceFormatV1,
+		Payload:     pldtypes.HexBytes(payload),
+		PayloadHash: hash,
+		Signer:      &KeyVerifier{Verifier: kp.Address.String()},
+		Signature:   sig.CompactRSV(),
+	}, evidence
+}
+
+func TestSignedTransactionEvidenceVerify(t *testing.T) {
+	signed, evidence := newSignedEvidenceForTesting(t)
+
+	verified, err := signed.Verify(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, evidence.TransactionID, verified.TransactionID)
+	assert.Equal(t, "notary@node2", verified.Endorsements[0].Lookup)
+}
+
+func TestSignedTransactionEvidenceVerifyFail(t *testing.T) {
+	ctx := context.Background()
+
+	signed, _ := newSignedEvidenceForTesting(t)
+	signed.Format = "other"
+	_, err := signed.Verify(ctx)
+	assert.Regexp(t, "PD020027", err)
+
+	signed, _ = newSignedEvidenceForTesting(t)
+	signed.Payload = append(signed.Payload, ' ')
+	_, err = signed.Verify(ctx)
+	assert.Regexp(t, "PD020028", err)
+
+	signed, _ = newSignedEvidenceForTesting(t)
+	signed.Signature = signed.Signature[0:64]
+	_, err = signed.Verify(ctx)
+	assert.Regexp(t, "FF22087", err)
+
+	signed, _ = newSignedEvidenceForTesting(t)
+	signed.Signature[64] = 30
+	_, err = signed.Verify(ctx)
+	assert.Regexp(t, "invalid V value", err)
+
+	signed, _ = newSignedEvidenceForTesting(t)
+	signed.Signer = nil
+	_, err = signed.Verify(ctx)
+	assert.Error(t, err)
+
+	signed, _ = newSignedEvidenceForTesting(t)
+	signed.Signer.Verifier = pldtypes.RandAddress().String()
+	_, err = signed.Verify(ctx)
+	assert.Regexp(t, "PD020029", err)
+}
@@ -0,0 +1,35 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pldapi
+
+import (
+	"github.com/google/uuid"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+)
+
+// ExternalTransactionWatch registers interest in a transaction that was not submitted by Paladin,
+// identified either by its hash, or by the sender and nonce. When the transaction is mined
+// a receipt is written under the ID of the watch, and delivered through the same receipt
+// APIs and listeners as transactions submitted through Paladin.
+type ExternalTransactionWatch struct {
+	ID              uuid.UUID            `docstruct:"ExternalTransactionWatch" json:"id"`
+	Created         pldtypes.Timestamp   `docstruct:"ExternalTransactionWatch" json:"created"`
+	TransactionHash *pldtypes.Bytes32    `docstruct:"ExternalTransactionWatch" json:"transactionHash,omitempty"`
+	From            *pldtypes.EthAddress `docstruct:"ExternalTransactionWatch" json:"from,omitempty"`
+	Nonce           *pldtypes.HexUint64  `docstruct:"ExternalTransactionWatch" json:"nonce,omitempty"`
+	Completed       *pldtypes.Timestamp  `docstruct:"ExternalTransactionWatch" json:"completed,omitempty"`
+}
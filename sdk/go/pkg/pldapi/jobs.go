@@ -0,0 +1,37 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package pldapi
+
+import (
+	"github.com/google/uuid"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+)
+
+type ScheduledJob struct {
+	ID          uuid.UUID           `docstruct:"ScheduledJob" json:"id"`
+	Name        *string             `docstruct:"ScheduledJob" json:"name,omitempty"`
+	Created     pldtypes.Timestamp  `docstruct:"ScheduledJob" json:"created"`
+	JobType     string              `docstruct:"ScheduledJob" json:"jobType"`
+	Schedule    *string             `docstruct:"ScheduledJob" json:"schedule,omitempty"`
+	Payload     pldtypes.RawJSON    `docstruct:"ScheduledJob" json:"payload,omitempty"`
+	NextRun     pldtypes.Timestamp  `docstruct:"ScheduledJob" json:"nextRun"`
+	Attempt     int                 `docstruct:"ScheduledJob" json:"attempt"`
+	LeaseOwner  *string             `docstruct:"ScheduledJob" json:"leaseOwner,omitempty"`
+	LeaseExpiry *pldtypes.Timestamp `docstruct:"ScheduledJob" json:"leaseExpiry,omitempty"`
+	LastRun     *pldtypes.Timestamp `docstruct:"ScheduledJob" json:"lastRun,omitempty"`
+	LastError   *string             `docstruct:"ScheduledJob" json:"lastError,omitempty"`
+	Completed   *pldtypes.Timestamp `docstruct:"ScheduledJob" json:"completed,omitempty"`
+}
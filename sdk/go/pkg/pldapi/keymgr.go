@@ -16,6 +16,8 @@
 
 package pldapi
 
+import "github.com/hyperledger/firefly-signer/pkg/eip712"
+
 type WalletInfo struct {
 	Name        string `docstruct:"WalletInfo" json:"name"`
 	KeySelector string `docstruct:"WalletInfo" json:"keySelector"`
@@ -48,6 +50,17 @@ type KeyVerifier struct {
 	Algorithm string `docstruct:"KeyVerifier" json:"algorithm"`
 }
 
+type VerifierLookup struct {
+	Lookup       string `docstruct:"VerifierLookup" json:"lookup"`
+	Algorithm    string `docstruct:"VerifierLookup" json:"algorithm"`
+	VerifierType string `docstruct:"VerifierLookup" json:"verifierType"`
+}
+
+type ResolvedVerifier struct {
+	*VerifierLookup `json:",inline"`
+	Verifier        string `docstruct:"ResolvedVerifier" json:"verifier"`
+}
+
 type KeyPathSegment struct {
 	Name  string `docstruct:"KeyPathSegment" json:"name"`
 	Index int64  `docstruct:"KeyPathSegment" json:"index"`
@@ -64,3 +77,12 @@ type KeyQueryEntry struct {
 	KeyHandle   string         `docstruct:"KeyListEntry" json:"keyHandle"`
 	Verifiers   []*KeyVerifier `docstruct:"KeyListEntry" json:"verifiers" gorm:"-"`
 }
+
+// EIP712TypedData is the structured data signed by keymgr_signTypedData, in the eth_signTypedData_v4 JSON format.
+// It can be converted directly to an eip712.TypedData for encoding.
+type EIP712TypedData struct {
+	Types       eip712.TypeSet `docstruct:"EIP712TypedData" json:"types"`
+	PrimaryType string         `docstruct:"EIP712TypedData" json:"primaryType"`
+	Domain      map[string]any `docstruct:"EIP712TypedData" json:"domain"`
+	Message     map[string]any `docstruct:"EIP712TypedData" json:"message"`
+}
@@ -36,4 +36,16 @@ type PeerStats struct {
 	LastReceive         *pldtypes.Timestamp `docstruct:"PeerStats" json:"lastReceive"`
 	ReliableHighestSent uint64              `docstruct:"PeerStats" json:"reliableHighestSent"`
 	ReliableAckBase     uint64              `docstruct:"PeerStats" json:"reliableAckBase"`
+	RejectedMsgs        uint64              `docstruct:"PeerStats" json:"rejectedMsgs"`
+	RateLimitedMsgs     uint64              `docstruct:"PeerStats" json:"rateLimitedMsgs"`
+	OversizedMsgs       uint64              `docstruct:"PeerStats" json:"oversizedMsgs"`
+}
+
+type PeerBan struct {
+	Node       string             `docstruct:"PeerBan" json:"node"`
+	Banned     pldtypes.Timestamp `docstruct:"PeerBan" json:"banned"`
+	Expires    pldtypes.Timestamp `docstruct:"PeerBan" json:"expires"`
+	Violations int                `docstruct:"PeerBan" json:"violations"`
+	Reason     string             `docstruct:"PeerBan" json:"reason"`
+	Rejected   uint64             `docstruct:"PeerBan" json:"rejected"`
 }
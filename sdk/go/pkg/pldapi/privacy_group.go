@@ -66,9 +66,54 @@ type PrivacyGroupInput struct {
 	Name               string                 `docstruct:"PrivacyGroup" json:"name"`
 	Properties         map[string]string      `docstruct:"PrivacyGroup" json:"properties,omitempty"`
 	Configuration      map[string]string      `docstruct:"PrivacyGroup" json:"configuration,omitempty"`
+	Template           string                 `docstruct:"PrivacyGroupInput" json:"template,omitempty"`
+	Roles              map[string][]string    `docstruct:"PrivacyGroupInput" json:"roles,omitempty"`
 	TransactionOptions *PrivacyGroupTXOptions `docstruct:"PrivacyGroupInput" json:"transactionOptions,omitempty"`
 }
 
+// Property names set on groups created from a template, so all members of the group can see
+// the template and role assignments it was created with
+const (
+	PrivacyGroupTemplateProperty   = "template"
+	PrivacyGroupRolePropertyPrefix = "role."
+)
+
+type PrivacyGroupTemplateSource string
+
+const (
+	PrivacyGroupTemplateSourceConfig PrivacyGroupTemplateSource = "config"
+	PrivacyGroupTemplateSourceAPI    PrivacyGroupTemplateSource = "api"
+)
+
+func (s PrivacyGroupTemplateSource) Enum() pldtypes.Enum[PrivacyGroupTemplateSource] {
+	return pldtypes.Enum[PrivacyGroupTemplateSource](s)
+}
+
+func (s PrivacyGroupTemplateSource) Options() []string {
+	return []string{
+		string(PrivacyGroupTemplateSourceConfig),
+		string(PrivacyGroupTemplateSourceAPI),
+	}
+}
+
+// A named template that standardizes the domain, properties, configuration and member roles of privacy groups
+type PrivacyGroupTemplate struct {
+	Name               string                                    `docstruct:"PrivacyGroupTemplate" json:"name"`
+	Source             pldtypes.Enum[PrivacyGroupTemplateSource] `docstruct:"PrivacyGroupTemplate" json:"source,omitempty"`
+	Created            pldtypes.Timestamp                        `docstruct:"PrivacyGroupTemplate" json:"created,omitempty"`
+	Domain             string                                    `docstruct:"PrivacyGroupTemplate" json:"domain"`
+	Properties         map[string]string                         `docstruct:"PrivacyGroupTemplate" json:"properties,omitempty"`
+	RequiredProperties []string                                  `docstruct:"PrivacyGroupTemplate" json:"requiredProperties,omitempty"`
+	Configuration      map[string]string                         `docstruct:"PrivacyGroupTemplate" json:"configuration,omitempty"`
+	Roles              []*PrivacyGroupTemplateRole               `docstruct:"PrivacyGroupTemplate" json:"roles,omitempty"`
+}
+
+type PrivacyGroupTemplateRole struct {
+	Name       string `docstruct:"PrivacyGroupTemplateRole" json:"name"`
+	MinMembers int    `docstruct:"PrivacyGroupTemplateRole" json:"minMembers,omitempty"`
+	MaxMembers *int   `docstruct:"PrivacyGroupTemplateRole" json:"maxMembers,omitempty"`
+}
+
 type PrivacyGroupEVMTX struct {
 	From     string               `docstruct:"PrivacyGroupEVMTX" json:"from,omitempty"` // signing key reference
 	To       *pldtypes.EthAddress `docstruct:"PrivacyGroupEVMTX" json:"to,omitempty"`
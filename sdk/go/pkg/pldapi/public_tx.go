@@ -19,6 +19,7 @@ package pldapi
 import (
 	"github.com/google/uuid"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/query"
 )
 
 // These are user-supplied directly on the external interface (vs. calculated)
@@ -28,6 +29,9 @@ type PublicTxOptions struct {
 	Gas                *pldtypes.HexUint64  `docstruct:"PublicTxOptions" json:"gas,omitempty"`
 	Value              *pldtypes.HexUint256 `docstruct:"PublicTxOptions" json:"value,omitempty"`
 	PublicTxGasPricing                      // fixed when any of these are supplied - disabling the gas pricing engine for this TX
+	Blobs              []pldtypes.HexBytes  `docstruct:"PublicTxOptions" json:"blobs,omitempty"`    // makes this an EIP-4844 blob transaction - only supplied on input
+	Expiry             *pldtypes.Timestamp  `docstruct:"PublicTxOptions" json:"expiry,omitempty"`   // if not mined by this time, the nonce is replaced with a no-op transaction and the transaction is marked expired
+	Metadata           pldtypes.RawJSON     `docstruct:"PublicTxOptions" json:"metadata,omitempty"` // a JSON object stored with the public transaction, and queryable with jsonEq - such as an order ID from the submitting system
 }
 
 type PublicCallOptions struct {
@@ -38,6 +42,7 @@ type PublicTxGasPricing struct {
 	MaxPriorityFeePerGas *pldtypes.HexUint256 `docstruct:"PublicTxGasPricing" json:"maxPriorityFeePerGas,omitempty"`
 	MaxFeePerGas         *pldtypes.HexUint256 `docstruct:"PublicTxGasPricing" json:"maxFeePerGas,omitempty"`
 	GasPrice             *pldtypes.HexUint256 `docstruct:"PublicTxGasPricing" json:"gasPrice,omitempty"`
+	MaxFeePerBlobGas     *pldtypes.HexUint256 `docstruct:"PublicTxGasPricing" json:"maxFeePerBlobGas,omitempty"` // blob transactions only
 }
 
 type PublicTxInput struct {
@@ -53,9 +58,17 @@ type PublicTxSubmission struct {
 	PublicTxSubmissionData
 }
 
+// The signed transaction of a submission, exactly as it was sent to the chain, so that it can be re-broadcast
+// through another channel or handed to the operator of the chain as evidence
+type PublicTxRawSubmission struct {
+	PublicTxSubmission
+	RawTransaction pldtypes.HexBytes `docstruct:"PublicTxRawSubmission" json:"rawTransaction,omitempty"` // empty if the submission was signed by the blockchain node, or made before raw transactions were stored
+}
+
 type PublicTxSubmissionData struct {
 	Time            pldtypes.Timestamp `docstruct:"PublicTxSubmissionData" json:"time"`
 	TransactionHash pldtypes.Bytes32   `docstruct:"PublicTxSubmissionData" json:"transactionHash"`
+	Cancel          bool               `docstruct:"PublicTxSubmissionData" json:"cancel,omitempty"` // a no-op replacement, submitted because the transaction expired
 	PublicTxGasPricing
 }
 
@@ -70,11 +83,99 @@ type PublicTx struct {
 	TransactionHash *pldtypes.Bytes32           `docstruct:"PublicTx" json:"transactionHash"`       // only once confirmed
 	Success         *bool                       `docstruct:"PublicTx" json:"success,omitempty"`     // only once confirmed
 	RevertData      pldtypes.HexBytes           `docstruct:"PublicTx" json:"revertData,omitempty"`  // only once confirmed, if available
+	Expired         bool                        `docstruct:"PublicTx" json:"expired,omitempty"`     // only once confirmed, if the no-op replacement was mined rather than the transaction itself
 	Submissions     []*PublicTxSubmissionData   `docstruct:"PublicTx" json:"submissions,omitempty"`
 	Activity        []TransactionActivityRecord `docstruct:"PublicTx" json:"activity,omitempty"`
+	BlobHashes      []pldtypes.Bytes32          `docstruct:"PublicTx" json:"blobHashes,omitempty"` // the versioned hashes of the blobs, for blob transactions
 	PublicTxOptions
 }
 
+// The result of running a public transaction through gas estimation, nonce assignment, gas pricing
+// and signing, without persisting or submitting it
+type PublicTxDryRun struct {
+	From            pldtypes.EthAddress  `docstruct:"PublicTxDryRun" json:"from"`
+	To              *pldtypes.EthAddress `docstruct:"PublicTxDryRun" json:"to,omitempty"`
+	Data            pldtypes.HexBytes    `docstruct:"PublicTxDryRun" json:"data,omitempty"`
+	Nonce           pldtypes.HexUint64   `docstruct:"PublicTxDryRun" json:"nonce"` // provisional - it is not reserved, so could be used by the next transaction submitted
+	TransactionHash pldtypes.Bytes32     `docstruct:"PublicTxDryRun" json:"transactionHash"`
+	RawTransaction  pldtypes.HexBytes    `docstruct:"PublicTxDryRun" json:"rawTransaction"` // the signed transaction, in the form for eth_sendRawTransaction
+	BlobHashes      []pldtypes.Bytes32   `docstruct:"PublicTxDryRun" json:"blobHashes,omitempty"`
+	PublicTxOptions                      // the gas limit and gas pricing used to build the transaction
+}
+
+// The result of executing a public transaction against the current state of the chain, without submitting it.
+// When the node supports debug_traceCall the full call tree, logs and storage changes are included.
+type PublicTxSimulation struct {
+	Traced         bool                     `docstruct:"PublicTxSimulation" json:"traced"` // false if the node could not trace the call, so only the result of eth_call is available
+	Reverted       bool                     `docstruct:"PublicTxSimulation" json:"reverted"`
+	RevertData     pldtypes.HexBytes        `docstruct:"PublicTxSimulation" json:"revertData,omitempty"`
+	RevertReason   string                   `docstruct:"PublicTxSimulation" json:"revertReason,omitempty"`
+	Output         pldtypes.HexBytes        `docstruct:"PublicTxSimulation" json:"output,omitempty"`
+	GasUsed        *pldtypes.HexUint64      `docstruct:"PublicTxSimulation" json:"gasUsed,omitempty"`
+	Call           *PublicTxSimulatedCall   `docstruct:"PublicTxSimulation" json:"call,omitempty"`
+	Logs           []*PublicTxSimulatedLog  `docstruct:"PublicTxSimulation" json:"logs,omitempty"`
+	StorageChanges []*PublicTxStorageChange `docstruct:"PublicTxSimulation" json:"storageChanges,omitempty"`
+}
+
+// A frame in the call tree of a simulated transaction
+type PublicTxSimulatedCall struct {
+	Type         string                   `docstruct:"PublicTxSimulatedCall" json:"type"` // CALL, STATICCALL, DELEGATECALL, CREATE etc.
+	From         pldtypes.EthAddress      `docstruct:"PublicTxSimulatedCall" json:"from"`
+	To           *pldtypes.EthAddress     `docstruct:"PublicTxSimulatedCall" json:"to,omitempty"`
+	Value        *pldtypes.HexUint256     `docstruct:"PublicTxSimulatedCall" json:"value,omitempty"`
+	Gas          pldtypes.HexUint64       `docstruct:"PublicTxSimulatedCall" json:"gas"`
+	GasUsed      pldtypes.HexUint64       `docstruct:"PublicTxSimulatedCall" json:"gasUsed"`
+	Input        pldtypes.HexBytes        `docstruct:"PublicTxSimulatedCall" json:"input,omitempty"`
+	Output       pldtypes.HexBytes        `docstruct:"PublicTxSimulatedCall" json:"output,omitempty"`
+	Error        string                   `docstruct:"PublicTxSimulatedCall" json:"error,omitempty"`
+	RevertReason string                   `docstruct:"PublicTxSimulatedCall" json:"revertReason,omitempty"`
+	Calls        []*PublicTxSimulatedCall `docstruct:"PublicTxSimulatedCall" json:"calls,omitempty"`
+}
+
+// An event that would be emitted by a simulated transaction
+type PublicTxSimulatedLog struct {
+	Address pldtypes.EthAddress `docstruct:"PublicTxSimulatedLog" json:"address"`
+	Topics  []pldtypes.Bytes32  `docstruct:"PublicTxSimulatedLog" json:"topics"`
+	Data    pldtypes.HexBytes   `docstruct:"PublicTxSimulatedLog" json:"data,omitempty"`
+}
+
+// A storage slot that would be modified by a simulated transaction
+type PublicTxStorageChange struct {
+	Address pldtypes.EthAddress `docstruct:"PublicTxStorageChange" json:"address"`
+	Slot    pldtypes.Bytes32    `docstruct:"PublicTxStorageChange" json:"slot"`
+	Before  pldtypes.Bytes32    `docstruct:"PublicTxStorageChange" json:"before"`
+	After   pldtypes.Bytes32    `docstruct:"PublicTxStorageChange" json:"after"`
+}
+
+// The predicted impact of submitting a batch of public transactions, calculated without persisting, reserving
+// or submitting anything. Nonces and costs are provisional, as other transactions might be submitted first.
+type PublicTxBatchImpact struct {
+	Transactions []*PublicTxImpact       `docstruct:"PublicTxBatchImpact" json:"transactions"` // in the order of the input transactions
+	Signers      []*PublicTxSignerImpact `docstruct:"PublicTxBatchImpact" json:"signers"`      // in the order each signer first appears in the batch
+}
+
+type PublicTxImpact struct {
+	From    pldtypes.EthAddress  `docstruct:"PublicTxImpact" json:"from"`
+	To      *pldtypes.EthAddress `docstruct:"PublicTxImpact" json:"to,omitempty"`
+	Nonce   pldtypes.HexUint64   `docstruct:"PublicTxImpact" json:"nonce"`
+	GasCost *pldtypes.HexUint256 `docstruct:"PublicTxImpact" json:"gasCost"` // the most the transaction could cost in gas, at the gas limit and pricing below
+	PublicTxOptions
+}
+
+type PublicTxSignerImpact struct {
+	Signer         pldtypes.EthAddress  `docstruct:"PublicTxSignerImpact" json:"signer"`
+	Transactions   int                  `docstruct:"PublicTxSignerImpact" json:"transactions"`
+	FirstNonce     pldtypes.HexUint64   `docstruct:"PublicTxSignerImpact" json:"firstNonce"`
+	NextNonce      pldtypes.HexUint64   `docstruct:"PublicTxSignerImpact" json:"nextNonce"`
+	GasCost        *pldtypes.HexUint256 `docstruct:"PublicTxSignerImpact" json:"gasCost"`
+	Value          *pldtypes.HexUint256 `docstruct:"PublicTxSignerImpact" json:"value"`
+	Balance        *pldtypes.HexUint256 `docstruct:"PublicTxSignerImpact" json:"balance"`
+	Reserved       *pldtypes.HexUint256 `docstruct:"PublicTxSignerImpact" json:"reserved"` // already committed to the signer's transactions in flight
+	Shortfall      *pldtypes.HexUint256 `docstruct:"PublicTxSignerImpact" json:"shortfall,omitempty"`
+	AutoFuel       bool                 `docstruct:"PublicTxSignerImpact" json:"autoFuel"`
+	AutoFuelAmount *pldtypes.HexUint256 `docstruct:"PublicTxSignerImpact" json:"autoFuelAmount,omitempty"`
+}
+
 type PublicTxBinding struct {
 	Transaction     uuid.UUID                      `docstruct:"PublicTxBinding" json:"transaction"`
 	TransactionType pldtypes.Enum[TransactionType] `docstruct:"PublicTxBinding" json:"transactionType"`
@@ -83,3 +184,93 @@ type PublicTxWithBinding struct {
 	*PublicTx
 	PublicTxBinding
 }
+
+// The values of the "status" field that can be used in queries of public transactions
+type PublicTxStatus string
+
+const (
+	PublicTxStatusPending PublicTxStatus = "pending" // not yet confirmed on the blockchain
+	PublicTxStatusSuccess PublicTxStatus = "success" // confirmed, and executed successfully
+	PublicTxStatusFailed  PublicTxStatus = "failed"  // confirmed, and reverted
+	PublicTxStatusExpired PublicTxStatus = "expired" // cancelled by a no-op replacement, as it was not mined before its expiry
+)
+
+func (s PublicTxStatus) Enum() pldtypes.Enum[PublicTxStatus] {
+	return pldtypes.Enum[PublicTxStatus](s)
+}
+
+func (s PublicTxStatus) Options() []string {
+	return []string{
+		string(PublicTxStatusPending),
+		string(PublicTxStatusSuccess),
+		string(PublicTxStatusFailed),
+		string(PublicTxStatusExpired),
+	}
+}
+
+// A query for a page of public transactions. Pages are ordered by "localId" or "created" (ascending or descending),
+// and the next page is requested by passing the "next" cursor from the previous page as "after"
+type PublicTxQuery struct {
+	query.QueryJSON
+	PendingLongerThan *string `docstruct:"PublicTxQuery" json:"pendingLongerThan,omitempty"` // a duration such as "5m" - only transactions that have been pending for longer are returned
+	After             string  `docstruct:"PublicTxQuery" json:"after,omitempty"`
+}
+
+type PublicTxPage struct {
+	Items []*PublicTxWithBinding `docstruct:"PublicTxPage" json:"items"`
+	Next  string                 `docstruct:"PublicTxPage" json:"next,omitempty"` // omitted on the last page
+}
+
+// The stages recorded for latency tracing of a public transaction, in the order they occur
+type PublicTxStage string
+
+const (
+	PublicTxStageReceived      PublicTxStage = "received"       // persisted by the public transaction manager
+	PublicTxStageNonceAssigned PublicTxStage = "nonce_assigned" // nonce allocated by the orchestrator for the signing address
+	PublicTxStageSigned        PublicTxStage = "signed"         // first successful signature
+	PublicTxStageSubmitted     PublicTxStage = "submitted"      // first successful submission to the blockchain node
+	PublicTxStageIndexed       PublicTxStage = "indexed"        // matched by the block indexer in a confirmed block
+	PublicTxStageConfirmed     PublicTxStage = "confirmed"      // completion committed to the database
+)
+
+func (s PublicTxStage) Enum() pldtypes.Enum[PublicTxStage] {
+	return pldtypes.Enum[PublicTxStage](s)
+}
+
+func (s PublicTxStage) Options() []string {
+	return []string{
+		string(PublicTxStageReceived),
+		string(PublicTxStageNonceAssigned),
+		string(PublicTxStageSigned),
+		string(PublicTxStageSubmitted),
+		string(PublicTxStageIndexed),
+		string(PublicTxStageConfirmed),
+	}
+}
+
+type PublicTxStageTime struct {
+	Stage        pldtypes.Enum[PublicTxStage] `docstruct:"PublicTxStageTime" json:"stage"`
+	Time         pldtypes.Timestamp           `docstruct:"PublicTxStageTime" json:"time"`
+	SincePrevMS  int64                        `docstruct:"PublicTxStageTime" json:"sincePrevMs"`
+	SinceStartMS int64                        `docstruct:"PublicTxStageTime" json:"sinceStartMs"`
+}
+
+type PublicTxTimeline struct {
+	LocalID uint64               `docstruct:"PublicTxTimeline" json:"localId"`
+	Stages  []*PublicTxStageTime `docstruct:"PublicTxTimeline" json:"stages"`
+	Binding *PublicTxBinding     `docstruct:"PublicTxTimeline" json:"binding,omitempty"`
+}
+
+type PublicTxLatencyPercentiles struct {
+	Count int   `docstruct:"PublicTxLatencyPercentiles" json:"count"`
+	P50MS int64 `docstruct:"PublicTxLatencyPercentiles" json:"p50Ms"`
+	P90MS int64 `docstruct:"PublicTxLatencyPercentiles" json:"p90Ms"`
+	P99MS int64 `docstruct:"PublicTxLatencyPercentiles" json:"p99Ms"`
+	MaxMS int64 `docstruct:"PublicTxLatencyPercentiles" json:"maxMs"`
+}
+
+type PublicTxLatencyStats struct {
+	Stage      pldtypes.Enum[PublicTxStage] `docstruct:"PublicTxLatencyStats" json:"stage"`
+	SincePrev  PublicTxLatencyPercentiles   `docstruct:"PublicTxLatencyStats" json:"sincePrev"`
+	SinceStart PublicTxLatencyPercentiles   `docstruct:"PublicTxLatencyStats" json:"sinceStart"`
+}
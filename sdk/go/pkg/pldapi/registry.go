@@ -16,7 +16,14 @@
 
 package pldapi
 
-import "github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+import (
+	"context"
+
+	"github.com/hyperledger/firefly-signer/pkg/secp256k1"
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/common/go/pkg/pldmsgs"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+)
 
 // An entity within a registry with its current properties
 type RegistryEntry struct {
@@ -74,3 +81,78 @@ func (af ActiveFilter) Options() []string {
 		string(ActiveFilterAny),
 	}
 }
+
+// A signed attestation (verifiable credential) about a registry entry, issued by a secp256k1 key.
+// The proof is a compact R,S,V signature over the hash returned by SigningHash().
+type RegistryCredential struct {
+	ID             string               `docstruct:"RegistryCredential" json:"id"`
+	Registry       string               `docstruct:"RegistryCredential" json:"registry,omitempty"` // the local registry the credential is attached in - not signed
+	EntryID        pldtypes.HexBytes    `docstruct:"RegistryCredential" json:"entryId"`
+	Type           string               `docstruct:"RegistryCredential" json:"type"`
+	Issuer         *pldtypes.EthAddress `docstruct:"RegistryCredential" json:"issuer"`
+	IssuanceDate   pldtypes.Timestamp   `docstruct:"RegistryCredential" json:"issuanceDate"`
+	ExpirationDate *pldtypes.Timestamp  `docstruct:"RegistryCredential" json:"expirationDate,omitempty"`
+	Claims         map[string]string    `docstruct:"RegistryCredential" json:"claims,omitempty"`
+	Proof          pldtypes.HexBytes    `docstruct:"RegistryCredential" json:"proof"`
+	Attached       *pldtypes.Timestamp  `docstruct:"RegistryCredential" json:"attached,omitempty"` // set by the node when stored
+	Revoked        *pldtypes.Timestamp  `docstruct:"RegistryCredential" json:"revoked,omitempty"`  // set by the node when revoked
+}
+
+// The fields covered by the issuer's signature. The registry name is excluded, as it is local
+// configuration, whereas the entry ID is consistent on every node that indexes the registry.
+type registryCredentialSigningPayload struct {
+	ID             string               `json:"id"`
+	EntryID        pldtypes.HexBytes    `json:"entryId"`
+	Type           string               `json:"type"`
+	Issuer         *pldtypes.EthAddress `json:"issuer"`
+	IssuanceDate   pldtypes.Timestamp   `json:"issuanceDate"`
+	ExpirationDate *pldtypes.Timestamp  `json:"expirationDate,omitempty"`
+	Claims         map[string]string    `json:"claims,omitempty"`
+}
+
+// SigningHash is the keccak256 hash of the canonical JSON serialization of the signed fields,
+// which the issuer signs directly (the "opaque:rsv" payload type in the Paladin key manager)
+func (rc *RegistryCredential) SigningHash() pldtypes.Bytes32 {
+	return pldtypes.Bytes32Keccak(pldtypes.JSONString(&registryCredentialSigningPayload{
+		ID:             rc.ID,
+		EntryID:        rc.EntryID,
+		Type:           rc.Type,
+		Issuer:         rc.Issuer,
+		IssuanceDate:   rc.IssuanceDate,
+		ExpirationDate: rc.ExpirationDate,
+		Claims:         rc.Claims,
+	}))
+}
+
+// VerifyProof checks the credential was signed by the issuer it names. It is the responsibility
+// of the caller to decide whether they trust the issuer.
+func (rc *RegistryCredential) VerifyProof(ctx context.Context) error {
+	sig, err := secp256k1.DecodeCompactRSV(ctx, rc.Proof)
+	if err != nil {
+		return err
+	}
+	hash := rc.SigningHash()
+	recovered, err := sig.RecoverDirect(hash[:], -1 /* V is always 27/28 */)
+	if err != nil {
+		return err
+	}
+	if !rc.Issuer.Equals((*pldtypes.EthAddress)(recovered)) {
+		return i18n.NewError(ctx, pldmsgs.MsgTypesCredentialIssuerMismatch, rc.ID, recovered, rc.Issuer)
+	}
+	return nil
+}
+
+// A request to check that a registry entry holds a valid credential of a given type
+type RegistryCredentialCheck struct {
+	Registry  string                 `docstruct:"RegistryCredentialCheck" json:"registry"`
+	EntryID   pldtypes.HexBytes      `docstruct:"RegistryCredentialCheck" json:"entryId,omitempty"`
+	EntryPath []string               `docstruct:"RegistryCredentialCheck" json:"entryPath,omitempty"`
+	Type      string                 `docstruct:"RegistryCredentialCheck" json:"type"`
+	Issuers   []*pldtypes.EthAddress `docstruct:"RegistryCredentialCheck" json:"issuers,omitempty"`
+}
+
+type RegistryCredentialCheckResult struct {
+	Valid      bool                `docstruct:"RegistryCredentialCheckResult" json:"valid"`
+	Reason     string              `docstruct:"RegistryCredentialCheckResult" json:"reason,omitempty"`
+	Credential *RegistryCredential `docstruct:"RegistryCredentialCheckResult" json:"credential,omitempty"`
+}
@@ -0,0 +1,64 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pldapi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hyperledger/firefly-signer/pkg/secp256k1"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistryCredentialVerifyProof(t *testing.T) {
+	ctx := context.Background()
+	kp, err := secp256k1.GenerateSecp256k1KeyPair()
+	require.NoError(t, err)
+
+	cred := &RegistryCredential{
+		ID:           "kyc-0001",
+		Registry:     "registry1",
+		EntryID:      pldtypes.RandBytes(32),
+		Type:         "KYCPassed",
+		Issuer:       (*pldtypes.EthAddress)(&kp.Address),
+		IssuanceDate: pldtypes.TimestampNow(),
+		Claims:       map[string]string{"level": "enhanced"},
+	}
+	hash := cred.SigningHash()
+	sig, err := kp.SignDirect(hash[:])
+	require.NoError(t, err)
+	cred.Proof = sig.CompactRSV()
+	require.NoError(t, cred.VerifyProof(ctx))
+
+	// Fields set by the node are not covered by the signature
+	now := pldtypes.TimestampNow()
+	cred.Registry = "registry2"
+	cred.Attached = &now
+	require.NoError(t, cred.VerifyProof(ctx))
+
+	// Signed fields are
+	cred.Claims["level"] = "basic"
+	assert.Regexp(t, "PD020030", cred.VerifyProof(ctx))
+
+	cred.Proof = []byte{0x01}
+	assert.Regexp(t, "FF22087", cred.VerifyProof(ctx))
+
+	cred.Proof = make([]byte, 65)
+	assert.Error(t, cred.VerifyProof(ctx))
+}
@@ -125,17 +125,19 @@ func (StateBase) TableName() string {
 }
 
 type StateLabel struct {
-	DomainName string            `gorm:"primaryKey"`
-	State      pldtypes.HexBytes `gorm:"primaryKey"`
-	Label      string
-	Value      string
+	DomainName      string            `gorm:"primaryKey"`
+	State           pldtypes.HexBytes `gorm:"primaryKey"`
+	ContractAddress *pldtypes.EthAddress
+	Label           string
+	Value           string
 }
 
 type StateInt64Label struct {
-	DomainName string            `gorm:"primaryKey"`
-	State      pldtypes.HexBytes `gorm:"primaryKey"`
-	Label      string
-	Value      int64
+	DomainName      string            `gorm:"primaryKey"`
+	State           pldtypes.HexBytes `gorm:"primaryKey"`
+	ContractAddress *pldtypes.EthAddress
+	Label           string
+	Value           int64
 }
 
 type TransactionStates struct {
@@ -171,9 +173,55 @@ type UnavailableStates struct {
 	Info      []pldtypes.HexBytes `docstruct:"UnavailableStates" json:"info"`
 }
 
+// A portable export of the private state data of a domain (optionally scoped to a single contract),
+// that can be imported into another node to migrate or recover private data that is not on-chain.
+type StateSnapshot struct {
+	Domain          string                `docstruct:"StateSnapshot" json:"domain"`
+	ContractAddress *pldtypes.EthAddress  `docstruct:"StateSnapshot" json:"contractAddress,omitempty"`
+	Created         pldtypes.Timestamp    `docstruct:"StateSnapshot" json:"created"`
+	Schemas         []*Schema             `docstruct:"StateSnapshot" json:"schemas"`
+	States          []*StateSnapshotEntry `docstruct:"StateSnapshot" json:"states"`
+	Hash            pldtypes.Bytes32      `docstruct:"StateSnapshot" json:"hash"`
+}
+
+type StateSnapshotEntry struct {
+	StateBase
+	Confirmed *uuid.UUID        `docstruct:"StateSnapshotEntry" json:"confirmed,omitempty"`
+	Spent     *uuid.UUID        `docstruct:"StateSnapshotEntry" json:"spent,omitempty"`
+	Nullifier pldtypes.HexBytes `docstruct:"StateSnapshotEntry" json:"nullifier,omitempty"`
+}
+
+type StateSnapshotImportResult struct {
+	Domain  string           `docstruct:"StateSnapshotImportResult" json:"domain"`
+	Hash    pldtypes.Bytes32 `docstruct:"StateSnapshotImportResult" json:"hash"`
+	Schemas int              `docstruct:"StateSnapshotImportResult" json:"schemas"`
+	States  int              `docstruct:"StateSnapshotImportResult" json:"states"`
+}
+
+// A merkle root over the IDs of a batch of confirmed states in a domain, written to the base ledger
+// by the state anchoring service to provide proof-of-existence for those states
+type StateAnchor struct {
+	ID              uuid.UUID           `docstruct:"StateAnchor" json:"id"`
+	Created         pldtypes.Timestamp  `docstruct:"StateAnchor" json:"created"`
+	Domain          string              `docstruct:"StateAnchor" json:"domain"`
+	Root            pldtypes.Bytes32    `docstruct:"StateAnchor" json:"root"`
+	LeafCount       int                 `docstruct:"StateAnchor" json:"leafCount"`
+	ContractAddress pldtypes.EthAddress `docstruct:"StateAnchor" json:"contractAddress"`
+	Transaction     uuid.UUID           `docstruct:"StateAnchor" json:"transaction"`
+}
+
+type StateAnchorProof struct {
+	Anchor    *StateAnchor       `docstruct:"StateAnchorProof" json:"anchor"`
+	State     pldtypes.HexBytes  `docstruct:"StateAnchorProof" json:"state"`
+	LeafIndex int                `docstruct:"StateAnchorProof" json:"leafIndex"`
+	Leaf      pldtypes.Bytes32   `docstruct:"StateAnchorProof" json:"leaf"`
+	Proof     []pldtypes.Bytes32 `docstruct:"StateAnchorProof" json:"proof"`
+}
+
 // A confirm record is written when indexing the blockchain, and can be written regardless
 // of whether we currently have access to the private data of the state.
-// It is simply a join record between the Paladin transaction ID and the state.
+// It is a join record between the Paladin transaction ID and the state, which also records
+// the base ledger transaction and block of the confirmation where the domain reported it.
 //
 // A state is "available" if we:
 // - have the confirm record
@@ -185,9 +233,11 @@ type UnavailableStates struct {
 // states all in a single block. In that case the state will only be "available" within
 // the in-memory domain context being managed by the sequencer for that smart contract.
 type StateConfirmRecord struct {
-	DomainName  string            `json:"-"                 gorm:"primaryKey"`
-	State       pldtypes.HexBytes `json:"-"                 gorm:"primaryKey"`
-	Transaction uuid.UUID         `docstruct:"StateConfirm" json:"transaction"`
+	DomainName      string            `json:"-"                                                  gorm:"primaryKey"`
+	State           pldtypes.HexBytes `json:"-"                                                  gorm:"primaryKey"`
+	Transaction     uuid.UUID         `docstruct:"StateConfirm" json:"transaction"`
+	TransactionHash *pldtypes.Bytes32 `docstruct:"StateConfirm" json:"transactionHash,omitempty" gorm:"column:tx_hash"`
+	BlockNumber     *int64            `docstruct:"StateConfirm" json:"blockNumber,omitempty"`
 }
 
 // A spend record is written when indexing the blockchain, and can be written regardless
@@ -267,3 +317,18 @@ type StateNullifier struct {
 	ID         pldtypes.HexBytes `json:"id"              gorm:"primaryKey"`
 	Spent      *StateSpendRecord `json:"spent,omitempty" gorm:"foreignKey:state;references:id;"`
 }
+
+// Statistics about the values of a labelled field of a schema, used to plan queries
+type StateLabelStats struct {
+	Label          string `docstruct:"StateLabelStats" json:"label"`
+	Type           string `docstruct:"StateLabelStats" json:"type"`
+	DistinctValues int64  `docstruct:"StateLabelStats" json:"distinctValues"`
+	States         int64  `docstruct:"StateLabelStats" json:"states"`
+}
+
+type StateQueryExplanation struct {
+	SQL        string             `docstruct:"StateQueryExplanation" json:"sql"`
+	Indexed    bool               `docstruct:"StateQueryExplanation" json:"indexed"`
+	LabelStats []*StateLabelStats `docstruct:"StateQueryExplanation" json:"labelStats"`
+	StatsTime  pldtypes.Timestamp `docstruct:"StateQueryExplanation" json:"statsTime"`
+}
@@ -33,3 +33,43 @@ type StoredABI struct {
 	Hash pldtypes.Bytes32 `docstruct:"StoredABI" json:"hash,omitempty"`
 	ABI  abi.ABI          `docstruct:"StoredABI" json:"abi,omitempty"`
 }
+
+// Registers an ABI against a deployed contract, so that transactions and events involving that
+// contract can be decoded without the caller supplying the ABI.
+// Exactly one of address or codeHash must be supplied. A registration by codeHash applies to
+// every deployment of the same runtime bytecode, while a registration by address takes precedence
+// for that one contract.
+type ContractABIInput struct {
+	Address  *pldtypes.EthAddress `docstruct:"ContractABIInput" json:"address,omitempty"`
+	CodeHash *pldtypes.Bytes32    `docstruct:"ContractABIInput" json:"codeHash,omitempty"`
+	ABI      abi.ABI              `docstruct:"ContractABIInput" json:"abi"`
+}
+
+type ContractABISource string
+
+const (
+	ContractABISourceAddress  ContractABISource = "address"  // registered against the contract address
+	ContractABISourceCodeHash ContractABISource = "codeHash" // registered against the hash of the deployed runtime code
+	ContractABISourceDomain   ContractABISource = "domain"   // harvested from the domain that manages a private smart contract
+)
+
+// The ABI resolved for a contract, and how it was resolved
+type ContractABI struct {
+	Address  *pldtypes.EthAddress `docstruct:"ContractABI" json:"address,omitempty"`
+	CodeHash *pldtypes.Bytes32    `docstruct:"ContractABI" json:"codeHash,omitempty"`
+	Source   ContractABISource    `docstruct:"ContractABI" json:"source"`
+	Domain   string               `docstruct:"ContractABI" json:"domain,omitempty"`
+	ABIHash  *pldtypes.Bytes32    `docstruct:"ContractABI" json:"abiHash,omitempty"`
+	ABI      abi.ABI              `docstruct:"ContractABI" json:"abi,omitempty"`
+	Created  *pldtypes.Timestamp  `docstruct:"ContractABI" json:"created,omitempty"`
+}
+
+// A human readable view of an indexed base ledger transaction, with the calldata and
+// events decoded using the contract ABI registry (falling back to all stored ABIs)
+type DecodedTransaction struct {
+	Transaction *IndexedTransaction `docstruct:"DecodedTransaction" json:"transaction"`
+	ContractABI *ContractABI        `docstruct:"DecodedTransaction" json:"contractABI,omitempty"`
+	Call        *ABIDecodedData     `docstruct:"DecodedTransaction" json:"call,omitempty"`
+	CallError   string              `docstruct:"DecodedTransaction" json:"callError,omitempty"`
+	Events      []*EventWithData    `docstruct:"DecodedTransaction" json:"events"`
+}
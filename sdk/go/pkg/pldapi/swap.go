@@ -0,0 +1,92 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package pldapi
+
+import (
+	"github.com/google/uuid"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+)
+
+type SwapStatus string
+
+const (
+	SwapStatusPreparing   SwapStatus = "preparing"   // the legs have been submitted, and are being prepared by their domains
+	SwapStatusPrepared    SwapStatus = "prepared"    // every leg is prepared, and the settlement operations have been derived
+	SwapStatusApproving   SwapStatus = "approving"   // the settlement contract is being created on the base ledger
+	SwapStatusApproved    SwapStatus = "approved"    // the settlement contract exists, and can be authorized in each domain before execution
+	SwapStatusExecuting   SwapStatus = "executing"   // the settlement contract is being executed
+	SwapStatusExecuted    SwapStatus = "executed"    // every leg settled atomically on the base ledger
+	SwapStatusRollingBack SwapStatus = "rollingBack" // the settlement contract is being cancelled
+	SwapStatusRolledBack  SwapStatus = "rolledBack"  // the swap was abandoned, and can no longer be executed
+	SwapStatusFailed      SwapStatus = "failed"      // a leg could not be prepared for settlement
+)
+
+func (s SwapStatus) Enum() pldtypes.Enum[SwapStatus] {
+	return pldtypes.Enum[SwapStatus](s)
+}
+
+func (s SwapStatus) Options() []string {
+	return []string{
+		string(SwapStatusPreparing),
+		string(SwapStatusPrepared),
+		string(SwapStatusApproving),
+		string(SwapStatusApproved),
+		string(SwapStatusExecuting),
+		string(SwapStatusExecuted),
+		string(SwapStatusRollingBack),
+		string(SwapStatusRolledBack),
+		string(SwapStatusFailed),
+	}
+}
+
+type SwapInput struct {
+	Name        string               `docstruct:"SwapInput" json:"name,omitempty"`
+	From        string               `docstruct:"SwapInput" json:"from"`
+	AtomFactory *pldtypes.EthAddress `docstruct:"SwapInput" json:"atomFactory"`
+	Legs        []*TransactionInput  `docstruct:"SwapInput" json:"legs"`
+}
+
+// A swap coordinates two or more private transactions, possibly in different domains, so that
+// they settle in a single base ledger transaction or not at all. Each leg is prepared rather than
+// submitted, and the prepared base ledger calls become the operations of an Atom contract.
+type Swap struct {
+	ID                  uuid.UUID                 `docstruct:"Swap" json:"id"`
+	Name                string                    `docstruct:"Swap" json:"name,omitempty"`
+	Created             pldtypes.Timestamp        `docstruct:"Swap" json:"created"`
+	Updated             pldtypes.Timestamp        `docstruct:"Swap" json:"updated"`
+	From                string                    `docstruct:"Swap" json:"from"`
+	AtomFactory         pldtypes.EthAddress       `docstruct:"Swap" json:"atomFactory"`
+	Status              pldtypes.Enum[SwapStatus] `docstruct:"Swap" json:"status"`
+	Legs                []*SwapLeg                `docstruct:"Swap" json:"legs"`
+	Operations          []*SwapOperation          `docstruct:"Swap" json:"operations,omitempty"`
+	Atom                *pldtypes.EthAddress      `docstruct:"Swap" json:"atom,omitempty"`
+	ApproveTransaction  *uuid.UUID                `docstruct:"Swap" json:"approveTransaction,omitempty"`
+	ExecuteTransaction  *uuid.UUID                `docstruct:"Swap" json:"executeTransaction,omitempty"`
+	RollbackTransaction *uuid.UUID                `docstruct:"Swap" json:"rollbackTransaction,omitempty"`
+	FailureMessage      string                    `docstruct:"Swap" json:"failureMessage,omitempty"`
+}
+
+type SwapLeg struct {
+	TransactionID uuid.UUID            `docstruct:"SwapLeg" json:"transactionId"`
+	Domain        string               `docstruct:"SwapLeg" json:"domain"`
+	To            *pldtypes.EthAddress `docstruct:"SwapLeg" json:"to,omitempty"`
+}
+
+// The field names match the Atom.Operation struct, so these can be passed directly to the factory
+type SwapOperation struct {
+	ContractAddress pldtypes.EthAddress `docstruct:"SwapOperation" json:"contractAddress"`
+	CallData        pldtypes.HexBytes   `docstruct:"SwapOperation" json:"callData"`
+}
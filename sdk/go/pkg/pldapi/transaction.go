@@ -103,6 +103,7 @@ type Transaction struct {
 	ID         *uuid.UUID                `docstruct:"Transaction" json:"id,omitempty"`         // server generated UUID for this transaction (query only)
 	Created    pldtypes.Timestamp        `docstruct:"Transaction" json:"created,omitempty"`    // server generated creation timestamp for this transaction (query only)
 	SubmitMode pldtypes.Enum[SubmitMode] `docstruct:"Transaction" json:"submitMode,omitempty"` // empty unless submitted via PrepareTransaction route
+	Tenant     string                    `docstruct:"Transaction" json:"tenant,omitempty"`     // the tenant that submitted the transaction, when the RPC server has tenant authentication configured (query only)
 	TransactionBase
 }
 
@@ -112,6 +113,19 @@ type TransactionInput struct {
 	DependsOn []uuid.UUID       `docstruct:"TransactionInput" json:"dependsOn,omitempty"` // these transactions must be mined on the blockchain successfully (or deleted) before this transaction submits. Failure of pre-reqs results in failure of this TX
 	ABI       abi.ABI           `docstruct:"TransactionInput" json:"abi,omitempty"`       // required if abiReference not supplied
 	Bytecode  pldtypes.HexBytes `docstruct:"TransactionInput" json:"bytecode,omitempty"`  // for deploy this is prepended to the encoded data inputs
+	TransactionScheduleInput
+}
+
+// The optional conditions for holding a transaction before it is submitted. If both are set, both must be met
+type TransactionScheduleInput struct {
+	NotBefore      *pldtypes.Timestamp `docstruct:"TransactionScheduleInput" json:"notBefore,omitempty"`      // the transaction is persisted, but not submitted for processing until this time
+	NotBeforeBlock *pldtypes.HexUint64 `docstruct:"TransactionScheduleInput" json:"notBeforeBlock,omitempty"` // the transaction is persisted, but not submitted for processing until the confirmed block height reaches this block
+}
+
+// The schedule of a transaction submitted with a notBefore condition
+type TransactionSchedule struct {
+	TransactionScheduleInput
+	Released *pldtypes.Timestamp `docstruct:"TransactionSchedule" json:"released,omitempty"` // the time the transaction was submitted for processing - not set while the transaction is still scheduled
 }
 
 // Call also provides some options on how to execute the call
@@ -130,6 +144,7 @@ type TransactionHistory struct {
 type TransactionFull struct {
 	*Transaction
 	DependsOn []uuid.UUID             `docstruct:"TransactionFull" json:"dependsOn,omitempty"` // transactions registered as dependencies when the transaction was created
+	Scheduled *TransactionSchedule    `docstruct:"TransactionFull" json:"scheduled,omitempty"` // set if the transaction was submitted with a schedule
 	Receipt   *TransactionReceiptData `docstruct:"TransactionFull" json:"receipt"`             // available if the transaction has reached a final state
 	Public    []*PublicTx             `docstruct:"TransactionFull" json:"public"`              // list of public transactions associated
 	History   []*TransactionHistory   `docstruct:"TransactionFull" json:"history,omitempty"`   // list of values previously provided for this transaction
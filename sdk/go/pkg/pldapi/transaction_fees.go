@@ -0,0 +1,86 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pldapi
+
+import (
+	"github.com/google/uuid"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+)
+
+// The fee paid for a base ledger transaction submitted by this node, attributed to the
+// identity that submitted the Paladin transaction it was for
+type TransactionFee struct {
+	TransactionHash   pldtypes.Bytes32     `docstruct:"TransactionFee" json:"transactionHash"`
+	Transaction       uuid.UUID            `docstruct:"TransactionFee" json:"transaction"`
+	Time              pldtypes.Timestamp   `docstruct:"TransactionFee" json:"time"`
+	BlockNumber       int64                `docstruct:"TransactionFee" json:"blockNumber"`
+	Signer            pldtypes.EthAddress  `docstruct:"TransactionFee" json:"signer"`
+	Identity          string               `docstruct:"TransactionFee" json:"identity"`
+	Tenant            string               `docstruct:"TransactionFee" json:"tenant"`
+	Domain            string               `docstruct:"TransactionFee" json:"domain,omitempty"`
+	GasUsed           pldtypes.HexUint64   `docstruct:"TransactionFee" json:"gasUsed"`
+	EffectiveGasPrice *pldtypes.HexUint256 `docstruct:"TransactionFee" json:"effectiveGasPrice"`
+	Fee               *pldtypes.HexUint256 `docstruct:"TransactionFee" json:"fee"`
+}
+
+type TransactionFeeGroupBy string
+
+const (
+	TransactionFeeGroupByDay      TransactionFeeGroupBy = "day" // UTC day of the block the transaction was mined in
+	TransactionFeeGroupBySigner   TransactionFeeGroupBy = "signer"
+	TransactionFeeGroupByIdentity TransactionFeeGroupBy = "identity"
+	TransactionFeeGroupByTenant   TransactionFeeGroupBy = "tenant"
+	TransactionFeeGroupByDomain   TransactionFeeGroupBy = "domain"
+)
+
+func (g TransactionFeeGroupBy) Enum() pldtypes.Enum[TransactionFeeGroupBy] {
+	return pldtypes.Enum[TransactionFeeGroupBy](g)
+}
+
+func (g TransactionFeeGroupBy) Options() []string {
+	return []string{
+		string(TransactionFeeGroupByDay),
+		string(TransactionFeeGroupBySigner),
+		string(TransactionFeeGroupByIdentity),
+		string(TransactionFeeGroupByTenant),
+		string(TransactionFeeGroupByDomain),
+	}
+}
+
+type TransactionFeeReportInput struct {
+	GroupBy pldtypes.Enum[TransactionFeeGroupBy] `docstruct:"TransactionFeeReportInput" json:"groupBy,omitempty"`
+	Since   *pldtypes.Timestamp                  `docstruct:"TransactionFeeReportInput" json:"since,omitempty"`
+	Until   *pldtypes.Timestamp                  `docstruct:"TransactionFeeReportInput" json:"until,omitempty"`
+	Signer  *pldtypes.EthAddress                 `docstruct:"TransactionFeeReportInput" json:"signer,omitempty"`
+	Tenant  string                               `docstruct:"TransactionFeeReportInput" json:"tenant,omitempty"`
+	Domain  string                               `docstruct:"TransactionFeeReportInput" json:"domain,omitempty"`
+}
+
+type TransactionFeeReport struct {
+	GroupBy pldtypes.Enum[TransactionFeeGroupBy] `docstruct:"TransactionFeeReport" json:"groupBy"`
+	Since   *pldtypes.Timestamp                  `docstruct:"TransactionFeeReport" json:"since,omitempty"`
+	Until   *pldtypes.Timestamp                  `docstruct:"TransactionFeeReport" json:"until,omitempty"`
+	Entries []*TransactionFeeReportEntry         `docstruct:"TransactionFeeReport" json:"entries"`
+	Total   *TransactionFeeReportEntry           `docstruct:"TransactionFeeReport" json:"total"`
+}
+
+type TransactionFeeReportEntry struct {
+	Key          string               `docstruct:"TransactionFeeReportEntry" json:"key"`
+	Transactions int                  `docstruct:"TransactionFeeReportEntry" json:"transactions"`
+	GasUsed      pldtypes.HexUint64   `docstruct:"TransactionFeeReportEntry" json:"gasUsed"`
+	Fee          *pldtypes.HexUint256 `docstruct:"TransactionFeeReportEntry" json:"fee"`
+}
@@ -0,0 +1,97 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package pldapi
+
+import (
+	"github.com/google/uuid"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+)
+
+type WebhookEventType string
+
+const (
+	WebhookEventTypeTransactionSuccess WebhookEventType = "transaction_success"
+	WebhookEventTypeTransactionFailed  WebhookEventType = "transaction_failed"
+)
+
+func (t WebhookEventType) Enum() pldtypes.Enum[WebhookEventType] {
+	return pldtypes.Enum[WebhookEventType](t)
+}
+
+func (t WebhookEventType) Options() []string {
+	return []string{
+		string(WebhookEventTypeTransactionSuccess),
+		string(WebhookEventTypeTransactionFailed),
+	}
+}
+
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryStatusPending   WebhookDeliveryStatus = "pending"   // waiting for the first or a further attempt
+	WebhookDeliveryStatusDelivered WebhookDeliveryStatus = "delivered" // the endpoint responded with a 2xx status
+	WebhookDeliveryStatusFailed    WebhookDeliveryStatus = "failed"    // the attempts were exhausted, or the webhook is no longer configured
+)
+
+func (s WebhookDeliveryStatus) Enum() pldtypes.Enum[WebhookDeliveryStatus] {
+	return pldtypes.Enum[WebhookDeliveryStatus](s)
+}
+
+func (s WebhookDeliveryStatus) Options() []string {
+	return []string{
+		string(WebhookDeliveryStatusPending),
+		string(WebhookDeliveryStatusDelivered),
+		string(WebhookDeliveryStatusFailed),
+	}
+}
+
+type Webhook struct {
+	Name    string         `docstruct:"Webhook" json:"name"`
+	URL     string         `docstruct:"Webhook" json:"url"`
+	Signed  bool           `docstruct:"Webhook" json:"signed"`
+	Filters WebhookFilters `docstruct:"Webhook" json:"filters"`
+}
+
+type WebhookFilters struct {
+	EventTypes []WebhookEventType `docstruct:"WebhookFilters" json:"eventTypes,omitempty"`
+	Domain     string             `docstruct:"WebhookFilters" json:"domain,omitempty"`
+	Signer     string             `docstruct:"WebhookFilters" json:"signer,omitempty"`
+}
+
+// The body POSTed to the webhook endpoint. The same body is sent on every attempt of a delivery.
+type WebhookEvent struct {
+	ID        uuid.UUID                       `docstruct:"WebhookEvent" json:"id"` // the delivery ID, so endpoints can discard duplicates
+	Webhook   string                          `docstruct:"WebhookEvent" json:"webhook"`
+	EventType pldtypes.Enum[WebhookEventType] `docstruct:"WebhookEvent" json:"eventType"`
+	Created   pldtypes.Timestamp              `docstruct:"WebhookEvent" json:"created"`
+	Receipt   *TransactionReceiptFull         `docstruct:"WebhookEvent" json:"receipt"`
+}
+
+type WebhookDelivery struct {
+	ID             uuid.UUID                            `docstruct:"WebhookDelivery" json:"id"`
+	Webhook        string                               `docstruct:"WebhookDelivery" json:"webhook"`
+	Created        pldtypes.Timestamp                   `docstruct:"WebhookDelivery" json:"created"`
+	EventType      pldtypes.Enum[WebhookEventType]      `docstruct:"WebhookDelivery" json:"eventType"`
+	Transaction    uuid.UUID                            `docstruct:"WebhookDelivery" json:"transaction"`
+	Sequence       uint64                               `docstruct:"WebhookDelivery" json:"sequence"`
+	Status         pldtypes.Enum[WebhookDeliveryStatus] `docstruct:"WebhookDelivery" json:"status"`
+	Attempts       int                                  `docstruct:"WebhookDelivery" json:"attempts"`
+	LastAttempt    *pldtypes.Timestamp                  `docstruct:"WebhookDelivery" json:"lastAttempt,omitempty"`
+	LastStatusCode *int                                 `docstruct:"WebhookDelivery" json:"lastStatusCode,omitempty"`
+	LastError      *string                              `docstruct:"WebhookDelivery" json:"lastError,omitempty"`
+	Delivered      *pldtypes.Timestamp                  `docstruct:"WebhookDelivery" json:"delivered,omitempty"`
+	Payload        pldtypes.RawJSON                     `docstruct:"WebhookDelivery" json:"payload,omitempty"`
+}
@@ -64,6 +64,10 @@ var blockIndexInfo = &rpcModuleInfo{
 			Inputs: []string{"query"},
 			Output: "events",
 		},
+		"bidx_queryDecodedEvents": {
+			Inputs: []string{"query"},
+			Output: "events",
+		},
 		"bidx_getConfirmedBlockHeight": {
 			Inputs: []string{},
 			Output: "blockHeight",
@@ -72,6 +76,30 @@ var blockIndexInfo = &rpcModuleInfo{
 			Inputs: []string{"transactionHash", "abi", "resultFormat"},
 			Output: "events",
 		},
+		"bidx_startBackfill": {
+			Inputs: []string{"request"},
+			Output: "status",
+		},
+		"bidx_getBackfillStatus": {
+			Inputs: []string{},
+			Output: "status",
+		},
+		"bidx_stopBackfill": {
+			Inputs: []string{},
+			Output: "status",
+		},
+		"bidx_replayTransactionEvents": {
+			Inputs: []string{"request"},
+			Output: "result",
+		},
+		"bidx_getChainTime": {
+			Inputs: []string{},
+			Output: "chainTime",
+		},
+		"bidx_getHeaderVerificationStatus": {
+			Inputs: []string{},
+			Output: "status",
+		},
 	},
 }
 
@@ -124,6 +152,11 @@ func (r *blockIndex) QueryIndexedEvents(ctx context.Context, query *query.QueryJ
 	return
 }
 
+func (r *blockIndex) QueryDecodedEvents(ctx context.Context, query *query.QueryJSON) (events []*pldapi.DecodedEvent, err error) {
+	err = r.c.CallRPC(ctx, &events, "bidx_queryDecodedEvents", query)
+	return
+}
+
 func (r *blockIndex) GetConfirmedBlockHeight(ctx context.Context) (blockHeight pldtypes.HexUint64, err error) {
 	err = r.c.CallRPC(ctx, &blockHeight, "bidx_getConfirmedBlockHeight")
 	return
@@ -133,3 +166,33 @@ func (r *blockIndex) DecodeTransactionEvents(ctx context.Context, transactionHas
 	err = r.c.CallRPC(ctx, &events, "bidx_decodeTransactionEvents", transactionHash, abi, resultFormat)
 	return
 }
+
+func (r *blockIndex) StartBackfill(ctx context.Context, request *pldapi.IndexerBackfillRequest) (status *pldapi.IndexerBackfillStatus, err error) {
+	err = r.c.CallRPC(ctx, &status, "bidx_startBackfill", request)
+	return
+}
+
+func (r *blockIndex) GetBackfillStatus(ctx context.Context) (status *pldapi.IndexerBackfillStatus, err error) {
+	err = r.c.CallRPC(ctx, &status, "bidx_getBackfillStatus")
+	return
+}
+
+func (r *blockIndex) StopBackfill(ctx context.Context) (status *pldapi.IndexerBackfillStatus, err error) {
+	err = r.c.CallRPC(ctx, &status, "bidx_stopBackfill")
+	return
+}
+
+func (r *blockIndex) ReplayTransactionEvents(ctx context.Context, request *pldapi.IndexerEventReplayRequest) (result *pldapi.IndexerEventReplayResult, err error) {
+	err = r.c.CallRPC(ctx, &result, "bidx_replayTransactionEvents", request)
+	return
+}
+
+func (r *blockIndex) GetChainTime(ctx context.Context) (chainTime *pldapi.ChainTime, err error) {
+	err = r.c.CallRPC(ctx, &chainTime, "bidx_getChainTime")
+	return
+}
+
+func (r *blockIndex) GetHeaderVerificationStatus(ctx context.Context) (status *pldapi.HeaderVerificationStatus, err error) {
+	err = r.c.CallRPC(ctx, &status, "bidx_getHeaderVerificationStatus")
+	return
+}
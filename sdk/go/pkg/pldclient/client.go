@@ -54,6 +54,9 @@ type PaladinClient interface {
 	// Paladin Registry RPC interface
 	Registry() Registry
 
+	// Paladin Domain RPC interface
+	Domain() Domain
+
 	// Paladin state store RPC interface
 	StateStore() StateStore
 
@@ -62,6 +65,15 @@ type PaladinClient interface {
 
 	// Paladin pgroup RPC interface
 	PrivacyGroups() PrivacyGroups
+
+	// Paladin job scheduler RPC interface
+	Jobs() Jobs
+
+	// Paladin base ledger contract manager RPC interface
+	Contracts() Contracts
+
+	// Paladin webhooks RPC interface
+	Webhooks() Webhooks
 }
 
 type RPCModule interface {
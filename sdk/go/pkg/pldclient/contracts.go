@@ -0,0 +1,94 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+package pldclient
+
+import (
+	"context"
+
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+)
+
+type Contracts interface {
+	RPCModule
+
+	ListBaseContracts(ctx context.Context) (contracts []*pldapi.BaseContract, err error)
+	GetBaseContract(ctx context.Context, name string) (contract *pldapi.BaseContract, err error)
+	DeployBaseContract(ctx context.Context, name string) (contract *pldapi.BaseContract, err error)
+	CheckPeerVersions(ctx context.Context, node string) (requested bool, err error)
+	ListVersionMismatches(ctx context.Context) (mismatches []*pldapi.BaseContractMismatch, err error)
+}
+
+// This is necessary because there's no way to introspect function parameter names via reflection
+var contractsInfo = &rpcModuleInfo{
+	group: "contracts",
+	methodInfo: map[string]RPCMethodInfo{
+		"contracts_listBaseContracts": {
+			Inputs: []string{},
+			Output: "contracts",
+		},
+		"contracts_getBaseContract": {
+			Inputs: []string{"name"},
+			Output: "contract",
+		},
+		"contracts_deployBaseContract": {
+			Inputs: []string{"name"},
+			Output: "contract",
+		},
+		"contracts_checkPeerVersions": {
+			Inputs: []string{"node"},
+			Output: "requested",
+		},
+		"contracts_listVersionMismatches": {
+			Inputs: []string{},
+			Output: "mismatches",
+		},
+	},
+}
+
+var _ Contracts = &contracts{}
+
+type contracts struct {
+	*rpcModuleInfo
+	c *paladinClient
+}
+
+func (c *paladinClient) Contracts() Contracts {
+	return &contracts{rpcModuleInfo: contractsInfo, c: c}
+}
+
+func (cm *contracts) ListBaseContracts(ctx context.Context) (contracts []*pldapi.BaseContract, err error) {
+	err = cm.c.CallRPC(ctx, &contracts, "contracts_listBaseContracts")
+	return
+}
+
+func (cm *contracts) GetBaseContract(ctx context.Context, name string) (contract *pldapi.BaseContract, err error) {
+	err = cm.c.CallRPC(ctx, &contract, "contracts_getBaseContract", name)
+	return
+}
+
+func (cm *contracts) DeployBaseContract(ctx context.Context, name string) (contract *pldapi.BaseContract, err error) {
+	err = cm.c.CallRPC(ctx, &contract, "contracts_deployBaseContract", name)
+	return
+}
+
+func (cm *contracts) CheckPeerVersions(ctx context.Context, node string) (requested bool, err error) {
+	err = cm.c.CallRPC(ctx, &requested, "contracts_checkPeerVersions", node)
+	return
+}
+
+func (cm *contracts) ListVersionMismatches(ctx context.Context) (mismatches []*pldapi.BaseContractMismatch, err error) {
+	err = cm.c.CallRPC(ctx, &mismatches, "contracts_listVersionMismatches")
+	return
+}
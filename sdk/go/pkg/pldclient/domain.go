@@ -0,0 +1,117 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package pldclient
+
+import (
+	"context"
+
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/query"
+)
+
+type Domain interface {
+	RPCModule
+
+	ListDomains(ctx context.Context) (domainNames []string, err error)
+	GetDomain(ctx context.Context, name string) (domain *pldapi.Domain, err error)
+	GetDomainByAddress(ctx context.Context, address pldtypes.EthAddress) (domain *pldapi.Domain, err error)
+	QuerySmartContracts(ctx context.Context, query *query.QueryJSON) (smartContracts []*pldapi.DomainSmartContract, err error)
+	GetSmartContractByAddress(ctx context.Context, address pldtypes.EthAddress) (smartContract *pldapi.DomainSmartContract, err error)
+	PauseDomain(ctx context.Context, name string) (domain *pldapi.Domain, err error)
+	ResumeDomain(ctx context.Context, name string) (domain *pldapi.Domain, err error)
+}
+
+// This is necessary because there's no way to introspect function parameter names via reflection
+var domainInfo = &rpcModuleInfo{
+	group: "domain",
+	methodInfo: map[string]RPCMethodInfo{
+		"domain_listDomains": {
+			Inputs: []string{},
+			Output: "domainNames",
+		},
+		"domain_getDomain": {
+			Inputs: []string{"name"},
+			Output: "domain",
+		},
+		"domain_getDomainByAddress": {
+			Inputs: []string{"address"},
+			Output: "domain",
+		},
+		"domain_querySmartContracts": {
+			Inputs: []string{"query"},
+			Output: "smartContracts",
+		},
+		"domain_getSmartContractByAddress": {
+			Inputs: []string{"address"},
+			Output: "smartContract",
+		},
+		"domain_pauseDomain": {
+			Inputs: []string{"name"},
+			Output: "domain",
+		},
+		"domain_resumeDomain": {
+			Inputs: []string{"name"},
+			Output: "domain",
+		},
+	},
+}
+
+var _ Domain = &domain{}
+
+type domain struct {
+	*rpcModuleInfo
+	c *paladinClient
+}
+
+func (c *paladinClient) Domain() Domain {
+	return &domain{rpcModuleInfo: domainInfo, c: c}
+}
+
+func (d *domain) ListDomains(ctx context.Context) (domainNames []string, err error) {
+	err = d.c.CallRPC(ctx, &domainNames, "domain_listDomains")
+	return
+}
+
+func (d *domain) GetDomain(ctx context.Context, name string) (domain *pldapi.Domain, err error) {
+	err = d.c.CallRPC(ctx, &domain, "domain_getDomain", name)
+	return
+}
+
+func (d *domain) GetDomainByAddress(ctx context.Context, address pldtypes.EthAddress) (domain *pldapi.Domain, err error) {
+	err = d.c.CallRPC(ctx, &domain, "domain_getDomainByAddress", address)
+	return
+}
+
+func (d *domain) QuerySmartContracts(ctx context.Context, query *query.QueryJSON) (smartContracts []*pldapi.DomainSmartContract, err error) {
+	err = d.c.CallRPC(ctx, &smartContracts, "domain_querySmartContracts", query)
+	return
+}
+
+func (d *domain) GetSmartContractByAddress(ctx context.Context, address pldtypes.EthAddress) (smartContract *pldapi.DomainSmartContract, err error) {
+	err = d.c.CallRPC(ctx, &smartContract, "domain_getSmartContractByAddress", address)
+	return
+}
+
+func (d *domain) PauseDomain(ctx context.Context, name string) (domain *pldapi.Domain, err error) {
+	err = d.c.CallRPC(ctx, &domain, "domain_pauseDomain", name)
+	return
+}
+
+func (d *domain) ResumeDomain(ctx context.Context, name string) (domain *pldapi.Domain, err error) {
+	err = d.c.CallRPC(ctx, &domain, "domain_resumeDomain", name)
+	return
+}
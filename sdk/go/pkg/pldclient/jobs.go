@@ -0,0 +1,56 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package pldclient
+
+import (
+	"context"
+
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/query"
+)
+
+type Jobs interface {
+	RPCModule
+
+	QueryJobs(ctx context.Context, jq *query.QueryJSON) (jobs []*pldapi.ScheduledJob, err error)
+}
+
+// This is necessary because there's no way to introspect function parameter names via reflection
+var jobsInfo = &rpcModuleInfo{
+	group: "jobs",
+	methodInfo: map[string]RPCMethodInfo{
+		"jobs_queryJobs": {
+			Inputs: []string{"query"},
+			Output: "jobs",
+		},
+	},
+}
+
+var _ Jobs = &jobs{}
+
+type jobs struct {
+	*rpcModuleInfo
+	c *paladinClient
+}
+
+func (c *paladinClient) Jobs() Jobs {
+	return &jobs{rpcModuleInfo: jobsInfo, c: c}
+}
+
+func (j *jobs) QueryJobs(ctx context.Context, jq *query.QueryJSON) (jobs []*pldapi.ScheduledJob, err error) {
+	err = j.c.CallRPC(ctx, &jobs, "jobs_queryJobs", jq)
+	return
+}
@@ -29,6 +29,7 @@ type KeyManager interface {
 	ResolveKey(ctx context.Context, keyIdentifier, algorithm, verifierType string) (mapping *pldapi.KeyMappingAndVerifier, err error)
 	ResolveEthAddress(ctx context.Context, keyIdentifier string) (ethAddress *pldtypes.EthAddress, err error)
 	ReverseKeyLookup(ctx context.Context, algorithm, verifierType, verifier string) (mapping *pldapi.KeyMappingAndVerifier, err error)
+	SignTypedData(ctx context.Context, keyIdentifier string, typedData *pldapi.EIP712TypedData) (signature pldtypes.HexBytes, err error)
 }
 
 // This is necessary because there's no way to introspect function parameter names via reflection
@@ -51,6 +52,10 @@ var keymgrInfo = &rpcModuleInfo{
 			Inputs: []string{"algorithm", "verifierType", "verifier"},
 			Output: "mapping",
 		},
+		"keymgr_signTypedData": {
+			Inputs: []string{"keyIdentifier", "typedData"},
+			Output: "signature",
+		},
 	},
 }
 
@@ -82,3 +87,8 @@ func (k *keymgr) ReverseKeyLookup(ctx context.Context, algorithm, verifierType,
 	err = k.c.CallRPC(ctx, &mapping, "keymgr_reverseKeyLookup", algorithm, verifierType, verifier)
 	return
 }
+
+func (k *keymgr) SignTypedData(ctx context.Context, keyIdentifier string, typedData *pldapi.EIP712TypedData) (signature pldtypes.HexBytes, err error) {
+	err = k.c.CallRPC(ctx, &signature, "keymgr_signTypedData", keyIdentifier, typedData)
+	return
+}
@@ -0,0 +1,257 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package pldclient
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/rpcclient"
+)
+
+// ListenerCheckpoint records the last receipt or event successfully handled by a listener.
+// Receipts are ordered by their sequence on the node, and events by their position on the chain.
+type ListenerCheckpoint struct {
+	Sequence         uint64 `json:"sequence,omitempty"`
+	BlockNumber      int64  `json:"blockNumber,omitempty"`
+	TransactionIndex int64  `json:"transactionIndex,omitempty"`
+	LogIndex         int64  `json:"logIndex,omitempty"`
+}
+
+// CheckpointStore persists listener checkpoints on behalf of the application.
+//
+// The WebSocket client re-establishes subscriptions automatically after a reconnect, and the node redelivers
+// everything that was not acknowledged. The checkpoint ensures receipts and events that were handled,
+// but where the ack was lost with the connection (or the application restarted), are not handled again.
+type CheckpointStore interface {
+	// GetCheckpoint returns nil if the listener has not yet handled anything
+	GetCheckpoint(ctx context.Context, listenerName string) (*ListenerCheckpoint, error)
+	SetCheckpoint(ctx context.Context, listenerName string, checkpoint *ListenerCheckpoint) error
+}
+
+type ReceiptBatchHandler func(ctx context.Context, batch *pldapi.TransactionReceiptBatch) error
+
+type EventBatchHandler func(ctx context.Context, batch *pldapi.TransactionEventBatch) error
+
+// Listener is a running subscription, delivering to a handler and checkpointing after each batch.
+// A handler error results in a nack, so the batch is redelivered by the node.
+type Listener interface {
+	Done() <-chan struct{}
+	Close(ctx context.Context) error
+}
+
+type memoryCheckpointStore struct {
+	mux         sync.Mutex
+	checkpoints map[string]ListenerCheckpoint
+}
+
+// NewMemoryCheckpointStore is suitable for applications that only need to avoid duplicates across reconnects,
+// and not across restarts
+func NewMemoryCheckpointStore() CheckpointStore {
+	return &memoryCheckpointStore{
+		checkpoints: make(map[string]ListenerCheckpoint),
+	}
+}
+
+func (ms *memoryCheckpointStore) GetCheckpoint(ctx context.Context, listenerName string) (*ListenerCheckpoint, error) {
+	ms.mux.Lock()
+	defer ms.mux.Unlock()
+	cp, ok := ms.checkpoints[listenerName]
+	if !ok {
+		return nil, nil
+	}
+	return &cp, nil
+}
+
+func (ms *memoryCheckpointStore) SetCheckpoint(ctx context.Context, listenerName string, checkpoint *ListenerCheckpoint) error {
+	ms.mux.Lock()
+	defer ms.mux.Unlock()
+	ms.checkpoints[listenerName] = *checkpoint
+	return nil
+}
+
+// processes a notification, returning the new checkpoint - which is unchanged if everything was already handled
+type notificationProcessor func(ctx context.Context, checkpoint *ListenerCheckpoint, result pldtypes.RawJSON) (*ListenerCheckpoint, error)
+
+type listener struct {
+	ctx         context.Context
+	cancelCtx   context.CancelFunc
+	name        string
+	sub         rpcclient.Subscription
+	checkpoints CheckpointStore
+	checkpoint  *ListenerCheckpoint
+	process     notificationProcessor
+	done        chan struct{}
+}
+
+func (p *ptx) ListenReceipts(ctx context.Context, listenerName string, checkpoints CheckpointStore, handler ReceiptBatchHandler) (Listener, error) {
+	return startListener(ctx, listenerName, checkpoints, receiptProcessor(handler), func() (rpcclient.Subscription, error) {
+		return p.SubscribeReceipts(ctx, listenerName)
+	})
+}
+
+func (p *ptx) ListenBlockchainEvents(ctx context.Context, listenerName string, checkpoints CheckpointStore, handler EventBatchHandler) (Listener, error) {
+	return startListener(ctx, listenerName, checkpoints, eventProcessor(handler), func() (rpcclient.Subscription, error) {
+		return p.SubscribeBlockchainEvents(ctx, listenerName)
+	})
+}
+
+func (p *ptx) ListenDomainEvents(ctx context.Context, subscription *pldapi.DomainEventSubscription, checkpoints CheckpointStore, handler EventBatchHandler) (Listener, error) {
+	return startListener(ctx, subscription.Name, checkpoints, eventProcessor(handler), func() (rpcclient.Subscription, error) {
+		return p.SubscribeDomainEvents(ctx, subscription)
+	})
+}
+
+func startListener(ctx context.Context, name string, checkpoints CheckpointStore, process notificationProcessor, subscribe func() (rpcclient.Subscription, error)) (Listener, error) {
+	checkpoint, err := checkpoints.GetCheckpoint(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	sub, err := subscribe()
+	if err != nil {
+		return nil, err
+	}
+	l := &listener{
+		name:        name,
+		sub:         sub,
+		checkpoints: checkpoints,
+		checkpoint:  checkpoint,
+		process:     process,
+		done:        make(chan struct{}),
+	}
+	l.ctx, l.cancelCtx = context.WithCancel(log.WithLogField(ctx, "listener", name))
+	go l.run()
+	return l, nil
+}
+
+func (l *listener) Done() <-chan struct{} {
+	return l.done
+}
+
+func (l *listener) Close(ctx context.Context) error {
+	l.cancelCtx()
+	err := l.sub.Unsubscribe(ctx)
+	<-l.done
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (l *listener) run() {
+	defer close(l.done)
+	for {
+		select {
+		case n, ok := <-l.sub.Notifications():
+			if !ok {
+				log.L(l.ctx).Infof("Listener stopped after unsubscribe")
+				return
+			}
+			l.handleNotification(n)
+		case <-l.ctx.Done():
+			log.L(l.ctx).Infof("Listener stopped")
+			return
+		}
+	}
+}
+
+func (l *listener) handleNotification(n rpcclient.RPCSubscriptionNotification) {
+	checkpoint, err := l.process(l.ctx, l.checkpoint, n.GetResult())
+	if err == nil && checkpoint != l.checkpoint {
+		err = l.checkpoints.SetCheckpoint(l.ctx, l.name, checkpoint)
+	}
+	if err != nil {
+		log.L(l.ctx).Errorf("Failed to handle notification on subscription %s (nack will trigger redelivery): %s", n.GetCurrentSubID(), err)
+		if err := n.Nack(l.ctx); err != nil {
+			log.L(l.ctx).Errorf("Nack failed: %s", err)
+		}
+		return
+	}
+	l.checkpoint = checkpoint
+	if err := n.Ack(l.ctx); err != nil {
+		log.L(l.ctx).Errorf("Ack failed: %s", err)
+	}
+}
+
+func receiptProcessor(handler ReceiptBatchHandler) notificationProcessor {
+	return func(ctx context.Context, checkpoint *ListenerCheckpoint, result pldtypes.RawJSON) (*ListenerCheckpoint, error) {
+		var batch pldapi.TransactionReceiptBatch
+		if err := json.Unmarshal(result, &batch); err != nil {
+			return nil, err
+		}
+		var newCheckpoint *ListenerCheckpoint
+		receipts := make([]*pldapi.TransactionReceiptFull, 0, len(batch.Receipts))
+		for _, r := range batch.Receipts {
+			if checkpoint == nil || r.Sequence > checkpoint.Sequence {
+				receipts = append(receipts, r)
+				newCheckpoint = &ListenerCheckpoint{Sequence: r.Sequence}
+			}
+		}
+		if newCheckpoint == nil {
+			log.L(ctx).Debugf("Skipping batch %d of %d receipts already handled", batch.BatchID, len(batch.Receipts))
+			return checkpoint, nil
+		}
+		batch.Receipts = receipts
+		if err := handler(ctx, &batch); err != nil {
+			return nil, err
+		}
+		return newCheckpoint, nil
+	}
+}
+
+func eventProcessor(handler EventBatchHandler) notificationProcessor {
+	return func(ctx context.Context, checkpoint *ListenerCheckpoint, result pldtypes.RawJSON) (*ListenerCheckpoint, error) {
+		var batch pldapi.TransactionEventBatch
+		if err := json.Unmarshal(result, &batch); err != nil {
+			return nil, err
+		}
+		var newCheckpoint *ListenerCheckpoint
+		events := make([]*pldapi.EventWithData, 0, len(batch.Events))
+		for _, e := range batch.Events {
+			if checkpoint == nil || eventAfterCheckpoint(e.IndexedEvent, checkpoint) {
+				events = append(events, e)
+				newCheckpoint = &ListenerCheckpoint{
+					BlockNumber:      e.BlockNumber,
+					TransactionIndex: e.TransactionIndex,
+					LogIndex:         e.LogIndex,
+				}
+			}
+		}
+		if newCheckpoint == nil {
+			log.L(ctx).Debugf("Skipping batch %s of %d events already handled", batch.BatchID, len(batch.Events))
+			return checkpoint, nil
+		}
+		batch.Events = events
+		if err := handler(ctx, &batch); err != nil {
+			return nil, err
+		}
+		return newCheckpoint, nil
+	}
+}
+
+func eventAfterCheckpoint(e *pldapi.IndexedEvent, checkpoint *ListenerCheckpoint) bool {
+	if e.BlockNumber != checkpoint.BlockNumber {
+		return e.BlockNumber > checkpoint.BlockNumber
+	}
+	if e.TransactionIndex != checkpoint.TransactionIndex {
+		return e.TransactionIndex > checkpoint.TransactionIndex
+	}
+	return e.LogIndex > checkpoint.LogIndex
+}
@@ -0,0 +1,260 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package pldclient
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/rpcclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testSubscription struct {
+	notifications  chan rpcclient.RPCSubscriptionNotification
+	unsubscribeErr rpcclient.ErrorRPC
+}
+
+func (ts *testSubscription) LocalID() uuid.UUID {
+	return uuid.UUID{}
+}
+
+func (ts *testSubscription) Notifications() chan rpcclient.RPCSubscriptionNotification {
+	return ts.notifications
+}
+
+func (ts *testSubscription) Unsubscribe(ctx context.Context) rpcclient.ErrorRPC {
+	if ts.unsubscribeErr != nil {
+		return ts.unsubscribeErr
+	}
+	close(ts.notifications)
+	return nil
+}
+
+type testNotification struct {
+	result pldtypes.RawJSON
+	acked  chan bool
+	ackErr rpcclient.ErrorRPC
+}
+
+func (tn *testNotification) Ack(ctx context.Context) rpcclient.ErrorRPC {
+	tn.acked <- true
+	return tn.ackErr
+}
+
+func (tn *testNotification) Nack(ctx context.Context) rpcclient.ErrorRPC {
+	tn.acked <- false
+	return tn.ackErr
+}
+
+func (tn *testNotification) GetCurrentSubID() string {
+	return "sub1"
+}
+
+func (tn *testNotification) GetResult() pldtypes.RawJSON {
+	return tn.result
+}
+
+type testCheckpointStore struct {
+	CheckpointStore
+	getErr error
+	setErr error
+}
+
+func (tcs *testCheckpointStore) GetCheckpoint(ctx context.Context, listenerName string) (*ListenerCheckpoint, error) {
+	if tcs.getErr != nil {
+		return nil, tcs.getErr
+	}
+	return tcs.CheckpointStore.GetCheckpoint(ctx, listenerName)
+}
+
+func (tcs *testCheckpointStore) SetCheckpoint(ctx context.Context, listenerName string, checkpoint *ListenerCheckpoint) error {
+	if tcs.setErr != nil {
+		return tcs.setErr
+	}
+	return tcs.CheckpointStore.SetCheckpoint(ctx, listenerName, checkpoint)
+}
+
+func newTestListener(t *testing.T, checkpoints CheckpointStore, process notificationProcessor) (*testSubscription, Listener) {
+	ts := &testSubscription{notifications: make(chan rpcclient.RPCSubscriptionNotification)}
+	l, err := startListener(context.Background(), "listener1", checkpoints, process, func() (rpcclient.Subscription, error) {
+		return ts, nil
+	})
+	require.NoError(t, err)
+	return ts, l
+}
+
+func deliver(ts *testSubscription, result any) bool {
+	n := &testNotification{result: pldtypes.JSONString(result), acked: make(chan bool, 1)}
+	ts.notifications <- n
+	return <-n.acked
+}
+
+func receiptBatch(sequences ...uint64) *pldapi.TransactionReceiptBatch {
+	batch := &pldapi.TransactionReceiptBatch{BatchID: 12345}
+	for _, seq := range sequences {
+		batch.Receipts = append(batch.Receipts, &pldapi.TransactionReceiptFull{
+			TransactionReceipt: &pldapi.TransactionReceipt{
+				TransactionReceiptData: pldapi.TransactionReceiptData{Sequence: seq},
+			},
+		})
+	}
+	return batch
+}
+
+func TestListenReceiptsSkipsHandled(t *testing.T) {
+	ctx := context.Background()
+	checkpoints := NewMemoryCheckpointStore()
+
+	var handled []uint64
+	var handlerErr error
+	ts, l := newTestListener(t, checkpoints, receiptProcessor(func(ctx context.Context, batch *pldapi.TransactionReceiptBatch) error {
+		if handlerErr != nil {
+			return handlerErr
+		}
+		for _, r := range batch.Receipts {
+			handled = append(handled, r.Sequence)
+		}
+		return nil
+	}))
+
+	assert.True(t, deliver(ts, receiptBatch(1, 2)))
+	// redelivered after a reconnect, where only part was handled
+	assert.True(t, deliver(ts, receiptBatch(2, 3)))
+	// redelivered after a reconnect, where all was handled
+	assert.True(t, deliver(ts, receiptBatch(3)))
+	assert.Equal(t, []uint64{1, 2, 3}, handled)
+
+	handlerErr = fmt.Errorf("pop")
+	assert.False(t, deliver(ts, receiptBatch(4)))
+	assert.False(t, deliver(ts, "not a batch"))
+
+	require.NoError(t, l.Close(ctx))
+	<-l.Done()
+
+	cp, err := checkpoints.GetCheckpoint(ctx, "listener1")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), cp.Sequence)
+}
+
+func TestListenEventsSkipsHandled(t *testing.T) {
+	ctx := context.Background()
+	checkpoints := NewMemoryCheckpointStore()
+	err := checkpoints.SetCheckpoint(ctx, "listener1", &ListenerCheckpoint{BlockNumber: 10, TransactionIndex: 5, LogIndex: 2})
+	require.NoError(t, err)
+
+	var handled []string
+	ts, l := newTestListener(t, checkpoints, eventProcessor(func(ctx context.Context, batch *pldapi.TransactionEventBatch) error {
+		for _, e := range batch.Events {
+			handled = append(handled, fmt.Sprintf("%d/%d/%d", e.BlockNumber, e.TransactionIndex, e.LogIndex))
+		}
+		return nil
+	}))
+	defer func() {
+		require.NoError(t, l.Close(ctx))
+	}()
+
+	event := func(block, txIndex, logIndex int64) *pldapi.EventWithData {
+		return &pldapi.EventWithData{IndexedEvent: &pldapi.IndexedEvent{
+			BlockNumber: block, TransactionIndex: txIndex, LogIndex: logIndex,
+		}}
+	}
+	assert.True(t, deliver(ts, &pldapi.TransactionEventBatch{
+		BatchID: uuid.New(),
+		Events: []*pldapi.EventWithData{
+			event(9, 10, 10),
+			event(10, 4, 10),
+			event(10, 5, 2),
+			event(10, 5, 3),
+			event(10, 6, 0),
+			event(11, 0, 0),
+		},
+	}))
+	assert.True(t, deliver(ts, &pldapi.TransactionEventBatch{
+		BatchID: uuid.New(),
+		Events:  []*pldapi.EventWithData{event(11, 0, 0)},
+	}))
+	assert.False(t, deliver(ts, "not a batch"))
+	assert.Equal(t, []string{"10/5/3", "10/6/0", "11/0/0"}, handled)
+
+	cp, err := checkpoints.GetCheckpoint(ctx, "listener1")
+	require.NoError(t, err)
+	assert.Equal(t, &ListenerCheckpoint{BlockNumber: 11}, cp)
+}
+
+func TestListenerCheckpointErrors(t *testing.T) {
+	ctx := context.Background()
+	checkpoints := &testCheckpointStore{CheckpointStore: NewMemoryCheckpointStore()}
+
+	handled := 0
+	ts, l := newTestListener(t, checkpoints, receiptProcessor(func(ctx context.Context, batch *pldapi.TransactionReceiptBatch) error {
+		handled++
+		return nil
+	}))
+
+	// failing to checkpoint results in a nack, and we handle it again on redelivery
+	checkpoints.setErr = fmt.Errorf("pop")
+	assert.False(t, deliver(ts, receiptBatch(1)))
+	checkpoints.setErr = nil
+	assert.True(t, deliver(ts, receiptBatch(1)))
+	assert.Equal(t, 2, handled)
+
+	// ack/nack errors are logged only
+	n := &testNotification{result: pldtypes.JSONString(receiptBatch(2)), acked: make(chan bool, 1), ackErr: rpcclient.NewRPCError(ctx, rpcclient.RPCCodeInternalError, "PD020702")}
+	ts.notifications <- n
+	assert.True(t, <-n.acked)
+	n = &testNotification{result: pldtypes.RawJSON(`!!!`), acked: make(chan bool, 1), ackErr: n.ackErr}
+	ts.notifications <- n
+	assert.False(t, <-n.acked)
+
+	ts.unsubscribeErr = n.ackErr
+	err := l.Close(ctx)
+	assert.Regexp(t, "PD020702", err)
+	<-l.Done()
+
+	checkpoints.getErr = fmt.Errorf("pop")
+	_, err = startListener(ctx, "listener1", checkpoints, nil, nil)
+	assert.Regexp(t, "pop", err)
+}
+
+func TestListenerStopsOnContextCancel(t *testing.T) {
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	ts := &testSubscription{notifications: make(chan rpcclient.RPCSubscriptionNotification)}
+	l, err := startListener(ctx, "listener1", NewMemoryCheckpointStore(), nil, func() (rpcclient.Subscription, error) {
+		return ts, nil
+	})
+	require.NoError(t, err)
+	cancelCtx()
+	<-l.Done()
+}
+
+func TestPTXListenSubscribeFail(t *testing.T) {
+	ctx, c, done := newTestClientAndServerWebSockets(t)
+	defer done()
+
+	checkpoints := NewMemoryCheckpointStore()
+	_, err := c.PTX().ListenReceipts(ctx, "listener1", checkpoints, nil)
+	require.Regexp(t, "PD020702", err)
+	_, err = c.PTX().ListenBlockchainEvents(ctx, "listener1", checkpoints, nil)
+	require.Regexp(t, "PD020702", err)
+	_, err = c.PTX().ListenDomainEvents(ctx, &pldapi.DomainEventSubscription{Name: "sub1", Domain: "noto"}, checkpoints, nil)
+	require.Regexp(t, "PD020702", err)
+}
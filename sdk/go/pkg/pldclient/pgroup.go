@@ -33,6 +33,10 @@ type PrivacyGroups interface {
 	GetGroupByAddress(ctx context.Context, addr pldtypes.EthAddress) (group *pldapi.PrivacyGroup, err error)
 	QueryGroups(ctx context.Context, jq *query.QueryJSON) (groups []*pldapi.PrivacyGroup, err error)
 	QueryGroupsWithMember(ctx context.Context, member string, jq *query.QueryJSON) (groups []*pldapi.PrivacyGroup, err error)
+	RegisterTemplate(ctx context.Context, template *pldapi.PrivacyGroupTemplate) (registered *pldapi.PrivacyGroupTemplate, err error)
+	GetTemplate(ctx context.Context, name string) (template *pldapi.PrivacyGroupTemplate, err error)
+	ListTemplates(ctx context.Context) (templates []*pldapi.PrivacyGroupTemplate, err error)
+	DeleteTemplate(ctx context.Context, name string) (success bool, err error)
 	SendTransaction(ctx context.Context, tx *pldapi.PrivacyGroupEVMTXInput) (txID uuid.UUID, err error)
 	Call(ctx context.Context, call *pldapi.PrivacyGroupEVMCall) (data pldtypes.RawJSON, err error)
 
@@ -82,6 +86,22 @@ var privacyGroupsInfo = &rpcModuleInfo{
 			Inputs: []string{"member", "query"},
 			Output: "pgroups",
 		},
+		"pgroup_registerTemplate": {
+			Inputs: []string{"template"},
+			Output: "template",
+		},
+		"pgroup_getTemplate": {
+			Inputs: []string{"name"},
+			Output: "template",
+		},
+		"pgroup_listTemplates": {
+			Inputs: []string{},
+			Output: "templates",
+		},
+		"pgroup_deleteTemplate": {
+			Inputs: []string{"name"},
+			Output: "success",
+		},
 		"pgroup_sendTransaction": {
 			Inputs: []string{"tx"},
 			Output: "transactionId",
@@ -170,6 +190,26 @@ func (r *pgroup) QueryGroupsWithMember(ctx context.Context, member string, jq *q
 	return
 }
 
+func (r *pgroup) RegisterTemplate(ctx context.Context, template *pldapi.PrivacyGroupTemplate) (registered *pldapi.PrivacyGroupTemplate, err error) {
+	err = r.c.CallRPC(ctx, &registered, "pgroup_registerTemplate", template)
+	return
+}
+
+func (r *pgroup) GetTemplate(ctx context.Context, name string) (template *pldapi.PrivacyGroupTemplate, err error) {
+	err = r.c.CallRPC(ctx, &template, "pgroup_getTemplate", name)
+	return
+}
+
+func (r *pgroup) ListTemplates(ctx context.Context) (templates []*pldapi.PrivacyGroupTemplate, err error) {
+	err = r.c.CallRPC(ctx, &templates, "pgroup_listTemplates")
+	return
+}
+
+func (r *pgroup) DeleteTemplate(ctx context.Context, name string) (success bool, err error) {
+	err = r.c.CallRPC(ctx, &success, "pgroup_deleteTemplate", name)
+	return
+}
+
 func (r *pgroup) SendTransaction(ctx context.Context, tx *pldapi.PrivacyGroupEVMTXInput) (txID uuid.UUID, err error) {
 	err = r.c.CallRPC(ctx, &txID, "pgroup_sendTransaction", tx)
 	return
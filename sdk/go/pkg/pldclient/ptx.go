@@ -35,6 +35,9 @@ type PTX interface {
 	PrepareTransactions(ctx context.Context, txs []*pldapi.TransactionInput) (txIDs []uuid.UUID, err error)
 	UpdateTransaction(ctx context.Context, id uuid.UUID, tx *pldapi.TransactionInput) (txID *uuid.UUID, err error)
 	Call(ctx context.Context, tx *pldapi.TransactionCall) (data pldtypes.RawJSON, err error)
+	DryRunTransaction(ctx context.Context, tx *pldapi.TransactionInput) (dryRun *pldapi.PublicTxDryRun, err error)
+	SimulateTransaction(ctx context.Context, tx *pldapi.TransactionInput) (simulation *pldapi.PublicTxSimulation, err error)
+	EstimateBatchImpact(ctx context.Context, txs []*pldapi.TransactionInput) (impact *pldapi.PublicTxBatchImpact, err error)
 
 	GetTransaction(ctx context.Context, txID uuid.UUID) (receipt *pldapi.Transaction, err error)
 	GetTransactionFull(ctx context.Context, txID uuid.UUID) (receipt *pldapi.TransactionFull, err error)
@@ -46,8 +49,18 @@ type PTX interface {
 	GetTransactionReceiptFull(ctx context.Context, txID uuid.UUID) (receipt *pldapi.TransactionReceiptFull, err error)
 	GetDomainReceipt(ctx context.Context, domain string, txID uuid.UUID) (domainReceipt pldtypes.RawJSON, err error)
 	GetStateReceipt(ctx context.Context, txID uuid.UUID) (stateReceipt *pldapi.TransactionStates, err error)
+	ExportTransactionEvidence(ctx context.Context, txID uuid.UUID) (evidence *pldapi.SignedTransactionEvidence, err error)
 	QueryTransactionReceipts(ctx context.Context, jq *query.QueryJSON) (receipts []*pldapi.TransactionReceipt, err error)
 	GetPreparedTransaction(ctx context.Context, txID uuid.UUID) (preparedTransaction *pldapi.PreparedTransaction, err error)
+	QueryPublicTransactions(ctx context.Context, jq *query.QueryJSON) (publicTransactions []*pldapi.PublicTxWithBinding, err error)
+	QueryPendingPublicTransactions(ctx context.Context, jq *query.QueryJSON) (publicTransactions []*pldapi.PublicTxWithBinding, err error)
+	QueryPublicTransactionsPage(ctx context.Context, ptq *pldapi.PublicTxQuery) (page *pldapi.PublicTxPage, err error)
+	GetPublicTransactionByNonce(ctx context.Context, from pldtypes.EthAddress, nonce pldtypes.HexUint64) (publicTransaction *pldapi.PublicTxWithBinding, err error)
+	GetPublicTransactionByHash(ctx context.Context, hash pldtypes.Bytes32) (publicTransaction *pldapi.PublicTxWithBinding, err error)
+	GetPublicTransactionRawSubmission(ctx context.Context, hash pldtypes.Bytes32) (rawSubmission *pldapi.PublicTxRawSubmission, err error)
+	SuspendPublicTransaction(ctx context.Context, from pldtypes.EthAddress, nonce pldtypes.HexUint64) (success bool, err error)
+	ResumePublicTransaction(ctx context.Context, from pldtypes.EthAddress, nonce pldtypes.HexUint64) (success bool, err error)
+	CancelPublicTransaction(ctx context.Context, from pldtypes.EthAddress, nonce pldtypes.HexUint64) (success bool, err error)
 	QueryPreparedTransactions(ctx context.Context, jq *query.QueryJSON) (preparedTransactions []*pldapi.PreparedTransaction, err error)
 	DecodeError(ctx context.Context, revertData pldtypes.HexBytes, dataFormat pldtypes.JSONFormatOptions) (decodedError *pldapi.ABIDecodedData, err error)
 	DecodeCall(ctx context.Context, callData pldtypes.HexBytes, dataFormat pldtypes.JSONFormatOptions) (decodedCall *pldapi.ABIDecodedData, err error)
@@ -57,7 +70,13 @@ type PTX interface {
 	GetStoredABI(ctx context.Context, hashRef pldtypes.Bytes32) (storedABI *pldapi.StoredABI, err error)
 	QueryStoredABIs(ctx context.Context, jq *query.QueryJSON) (storedABIs []*pldapi.StoredABI, err error)
 
+	RegisterContractABI(ctx context.Context, contractABI *pldapi.ContractABIInput) (registered *pldapi.ContractABI, err error)
+	GetContractABI(ctx context.Context, address pldtypes.EthAddress) (contractABI *pldapi.ContractABI, err error)
+	QueryContractABIs(ctx context.Context, jq *query.QueryJSON) (contractABIs []*pldapi.ContractABI, err error)
+	DecodeIndexedTransaction(ctx context.Context, txHash pldtypes.Bytes32, dataFormat pldtypes.JSONFormatOptions) (decoded *pldapi.DecodedTransaction, err error)
+
 	ResolveVerifier(ctx context.Context, keyIdentifier string, algorithm string, verifierType string) (verifier string, err error)
+	ResolveVerifiers(ctx context.Context, lookups []*pldapi.VerifierLookup) (verifiers []*pldapi.ResolvedVerifier, err error)
 
 	CreateReceiptListener(ctx context.Context, listener *pldapi.TransactionReceiptListener) (success bool, err error)
 	QueryReceiptListeners(ctx context.Context, jq *query.QueryJSON) (listeners []*pldapi.TransactionReceiptListener, err error)
@@ -66,6 +85,14 @@ type PTX interface {
 	StopReceiptListener(ctx context.Context, listenerName string) (success bool, err error)
 	DeleteReceiptListener(ctx context.Context, listenerName string) (success bool, err error)
 
+	WatchExternalTransaction(ctx context.Context, watch *pldapi.ExternalTransactionWatch) (externalWatch *pldapi.ExternalTransactionWatch, err error)
+	GetExternalTransactionWatch(ctx context.Context, watchID uuid.UUID) (externalWatch *pldapi.ExternalTransactionWatch, err error)
+	QueryExternalTransactionWatches(ctx context.Context, jq *query.QueryJSON) (externalWatches []*pldapi.ExternalTransactionWatch, err error)
+	DeleteExternalTransactionWatch(ctx context.Context, watchID uuid.UUID) (success bool, err error)
+
+	QueryTransactionFees(ctx context.Context, jq *query.QueryJSON) (fees []*pldapi.TransactionFee, err error)
+	GetTransactionFeeReport(ctx context.Context, input *pldapi.TransactionFeeReportInput) (report *pldapi.TransactionFeeReport, err error)
+
 	CreateBlockchainEventListener(ctx context.Context, listener *pldapi.BlockchainEventListener) (success bool, err error)
 	QueryBlockchainEventListeners(ctx context.Context, jq *query.QueryJSON) (listeners []*pldapi.BlockchainEventListener, err error)
 	GetBlockchainEventListener(ctx context.Context, listenerName string) (listener *pldapi.BlockchainEventListener, err error)
@@ -73,9 +100,22 @@ type PTX interface {
 	StopBlockchainEventListener(ctx context.Context, listenerName string) (success bool, err error)
 	DeleteBlockchainEventListener(ctx context.Context, listenerName string) (success bool, err error)
 	GetBlockchainEventListenerStatus(ctx context.Context, name string) (*pldapi.BlockchainEventListenerStatus, error)
+	ReplayBlockchainEvents(ctx context.Context, listenerName string, txID uuid.UUID) (*pldapi.IndexerEventReplayResult, error)
+
+	PrepareSwap(ctx context.Context, swap *pldapi.SwapInput) (*pldapi.Swap, error)
+	GetSwap(ctx context.Context, swapID uuid.UUID) (*pldapi.Swap, error)
+	QuerySwaps(ctx context.Context, jq *query.QueryJSON) ([]*pldapi.Swap, error)
+	ApproveSwap(ctx context.Context, swapID uuid.UUID) (*pldapi.Swap, error)
+	ExecuteSwap(ctx context.Context, swapID uuid.UUID) (*pldapi.Swap, error)
+	RollbackSwap(ctx context.Context, swapID uuid.UUID) (*pldapi.Swap, error)
 
 	SubscribeReceipts(ctx context.Context, listenerName string) (sub rpcclient.Subscription, err error)
 	SubscribeBlockchainEvents(ctx context.Context, listenerName string) (sub rpcclient.Subscription, err error)
+	SubscribeDomainEvents(ctx context.Context, subscription *pldapi.DomainEventSubscription) (sub rpcclient.Subscription, err error)
+
+	ListenReceipts(ctx context.Context, listenerName string, checkpoints CheckpointStore, handler ReceiptBatchHandler) (Listener, error)
+	ListenBlockchainEvents(ctx context.Context, listenerName string, checkpoints CheckpointStore, handler EventBatchHandler) (Listener, error)
+	ListenDomainEvents(ctx context.Context, subscription *pldapi.DomainEventSubscription, checkpoints CheckpointStore, handler EventBatchHandler) (Listener, error)
 }
 
 var ptxSubscriptionConfig = rpcclient.SubscriptionConfig{
@@ -86,6 +126,14 @@ var ptxSubscriptionConfig = rpcclient.SubscriptionConfig{
 	NackMethod:         "ptx_nack",
 }
 
+var ptxDomainEventsSubscriptionConfig = rpcclient.SubscriptionConfig{
+	SubscribeMethod:    "ptx_subscribeDomainEvents",
+	UnsubscribeMethod:  "ptx_unsubscribe",
+	NotificationMethod: "ptx_subscription",
+	AckMethod:          "ptx_ack",
+	NackMethod:         "ptx_nack",
+}
+
 // This is necessary because there's no way to introspect function parameter names via reflection
 var ptxInfo = &rpcModuleInfo{
 	group: "ptx",
@@ -114,6 +162,18 @@ var ptxInfo = &rpcModuleInfo{
 			Inputs: []string{"transaction"},
 			Output: "result",
 		},
+		"ptx_dryRunTransaction": {
+			Inputs: []string{"transaction"},
+			Output: "dryRun",
+		},
+		"ptx_simulateTransaction": {
+			Inputs: []string{"transaction"},
+			Output: "simulation",
+		},
+		"ptx_estimateBatchImpact": {
+			Inputs: []string{"transactions"},
+			Output: "impact",
+		},
 		"ptx_getTransaction": {
 			Inputs: []string{"transactionId"},
 			Output: "transaction",
@@ -142,6 +202,10 @@ var ptxInfo = &rpcModuleInfo{
 			Inputs: []string{"transactionId"},
 			Output: "receipt",
 		},
+		"ptx_exportTransactionEvidence": {
+			Inputs: []string{"transactionId"},
+			Output: "evidence",
+		},
 		"ptx_getPreparedTransaction": {
 			Inputs: []string{"transactionId"},
 			Output: "preparedTransaction",
@@ -158,6 +222,42 @@ var ptxInfo = &rpcModuleInfo{
 			Inputs: []string{"query"},
 			Output: "receipts",
 		},
+		"ptx_queryPublicTransactions": {
+			Inputs: []string{"query"},
+			Output: "publicTransactions",
+		},
+		"ptx_queryPendingPublicTransactions": {
+			Inputs: []string{"query"},
+			Output: "publicTransactions",
+		},
+		"ptx_queryPublicTransactionsPage": {
+			Inputs: []string{"query"},
+			Output: "page",
+		},
+		"ptx_getPublicTransactionByNonce": {
+			Inputs: []string{"from", "nonce"},
+			Output: "publicTransaction",
+		},
+		"ptx_getPublicTransactionByHash": {
+			Inputs: []string{"hash"},
+			Output: "publicTransaction",
+		},
+		"ptx_getPublicTransactionRawSubmission": {
+			Inputs: []string{"hash"},
+			Output: "rawSubmission",
+		},
+		"ptx_suspendPublicTransaction": {
+			Inputs: []string{"from", "nonce"},
+			Output: "success",
+		},
+		"ptx_resumePublicTransaction": {
+			Inputs: []string{"from", "nonce"},
+			Output: "success",
+		},
+		"ptx_cancelPublicTransaction": {
+			Inputs: []string{"from", "nonce"},
+			Output: "success",
+		},
 		"ptx_queryPreparedTransactions": {
 			Inputs: []string{"query"},
 			Output: "preparedTransactions",
@@ -174,6 +274,22 @@ var ptxInfo = &rpcModuleInfo{
 			Inputs: []string{"query"},
 			Output: "storedABIs",
 		},
+		"ptx_registerContractABI": {
+			Inputs: []string{"contractABI"},
+			Output: "registered",
+		},
+		"ptx_getContractABI": {
+			Inputs: []string{"address"},
+			Output: "contractABI",
+		},
+		"ptx_queryContractABIs": {
+			Inputs: []string{"query"},
+			Output: "contractABIs",
+		},
+		"ptx_decodeIndexedTransaction": {
+			Inputs: []string{"txHash", "dataFormat"},
+			Output: "decoded",
+		},
 		"ptx_decodeError": {
 			Inputs: []string{"revertData", "dataFormat"},
 			Output: "decodedError",
@@ -190,6 +306,10 @@ var ptxInfo = &rpcModuleInfo{
 			Inputs: []string{"keyIdentifier", "algorithm", "verifierType"},
 			Output: "verifier",
 		},
+		"ptx_resolveVerifiers": {
+			Inputs: []string{"lookups"},
+			Output: "verifiers",
+		},
 		"ptx_createReceiptListener": {
 			Inputs: []string{"listener"},
 			Output: "success",
@@ -214,6 +334,30 @@ var ptxInfo = &rpcModuleInfo{
 			Inputs: []string{"listenerName"},
 			Output: "success",
 		},
+		"ptx_watchExternalTransaction": {
+			Inputs: []string{"watch"},
+			Output: "externalWatch",
+		},
+		"ptx_getExternalTransactionWatch": {
+			Inputs: []string{"watchId"},
+			Output: "externalWatch",
+		},
+		"ptx_queryExternalTransactionWatches": {
+			Inputs: []string{"query"},
+			Output: "externalWatches",
+		},
+		"ptx_deleteExternalTransactionWatch": {
+			Inputs: []string{"watchId"},
+			Output: "success",
+		},
+		"ptx_queryTransactionFees": {
+			Inputs: []string{"query"},
+			Output: "fees",
+		},
+		"ptx_getTransactionFeeReport": {
+			Inputs: []string{"input"},
+			Output: "report",
+		},
 		"ptx_createBlockchainEventListener": {
 			Inputs: []string{"listener"},
 			Output: "success",
@@ -242,6 +386,34 @@ var ptxInfo = &rpcModuleInfo{
 			Inputs: []string{"listenerName"},
 			Output: "listenerStatus",
 		},
+		"ptx_replayBlockchainEvents": {
+			Inputs: []string{"listenerName", "transactionId"},
+			Output: "result",
+		},
+		"ptx_prepareSwap": {
+			Inputs: []string{"swap"},
+			Output: "swap",
+		},
+		"ptx_getSwap": {
+			Inputs: []string{"swapId"},
+			Output: "swap",
+		},
+		"ptx_querySwaps": {
+			Inputs: []string{"query"},
+			Output: "swaps",
+		},
+		"ptx_approveSwap": {
+			Inputs: []string{"swapId"},
+			Output: "swap",
+		},
+		"ptx_executeSwap": {
+			Inputs: []string{"swapId"},
+			Output: "swap",
+		},
+		"ptx_rollbackSwap": {
+			Inputs: []string{"swapId"},
+			Output: "swap",
+		},
 	},
 	subscriptions: []RPCSubscriptionInfo{
 		{
@@ -296,6 +468,21 @@ func (p *ptx) Call(ctx context.Context, tx *pldapi.TransactionCall) (data pldtyp
 	return
 }
 
+func (p *ptx) DryRunTransaction(ctx context.Context, tx *pldapi.TransactionInput) (dryRun *pldapi.PublicTxDryRun, err error) {
+	err = p.c.CallRPC(ctx, &dryRun, "ptx_dryRunTransaction", tx)
+	return
+}
+
+func (p *ptx) SimulateTransaction(ctx context.Context, tx *pldapi.TransactionInput) (simulation *pldapi.PublicTxSimulation, err error) {
+	err = p.c.CallRPC(ctx, &simulation, "ptx_simulateTransaction", tx)
+	return
+}
+
+func (p *ptx) EstimateBatchImpact(ctx context.Context, txs []*pldapi.TransactionInput) (impact *pldapi.PublicTxBatchImpact, err error) {
+	err = p.c.CallRPC(ctx, &impact, "ptx_estimateBatchImpact", txs)
+	return
+}
+
 func (p *ptx) GetTransaction(ctx context.Context, txID uuid.UUID) (tx *pldapi.Transaction, err error) {
 	err = p.c.CallRPC(ctx, &tx, "ptx_getTransaction", txID)
 	return
@@ -331,6 +518,11 @@ func (p *ptx) GetTransactionReceiptFull(ctx context.Context, txID uuid.UUID) (re
 	return
 }
 
+func (p *ptx) ExportTransactionEvidence(ctx context.Context, txID uuid.UUID) (evidence *pldapi.SignedTransactionEvidence, err error) {
+	err = p.c.CallRPC(ctx, &evidence, "ptx_exportTransactionEvidence", txID)
+	return
+}
+
 func (p *ptx) GetPreparedTransaction(ctx context.Context, txID uuid.UUID) (preparedTransaction *pldapi.PreparedTransaction, err error) {
 	err = p.c.CallRPC(ctx, &preparedTransaction, "ptx_getPreparedTransaction", txID)
 	return
@@ -351,6 +543,51 @@ func (p *ptx) QueryTransactionReceipts(ctx context.Context, jq *query.QueryJSON)
 	return
 }
 
+func (p *ptx) QueryPublicTransactions(ctx context.Context, jq *query.QueryJSON) (publicTransactions []*pldapi.PublicTxWithBinding, err error) {
+	err = p.c.CallRPC(ctx, &publicTransactions, "ptx_queryPublicTransactions", jq)
+	return
+}
+
+func (p *ptx) QueryPendingPublicTransactions(ctx context.Context, jq *query.QueryJSON) (publicTransactions []*pldapi.PublicTxWithBinding, err error) {
+	err = p.c.CallRPC(ctx, &publicTransactions, "ptx_queryPendingPublicTransactions", jq)
+	return
+}
+
+func (p *ptx) QueryPublicTransactionsPage(ctx context.Context, ptq *pldapi.PublicTxQuery) (page *pldapi.PublicTxPage, err error) {
+	err = p.c.CallRPC(ctx, &page, "ptx_queryPublicTransactionsPage", ptq)
+	return
+}
+
+func (p *ptx) GetPublicTransactionByNonce(ctx context.Context, from pldtypes.EthAddress, nonce pldtypes.HexUint64) (publicTransaction *pldapi.PublicTxWithBinding, err error) {
+	err = p.c.CallRPC(ctx, &publicTransaction, "ptx_getPublicTransactionByNonce", from, nonce)
+	return
+}
+
+func (p *ptx) GetPublicTransactionByHash(ctx context.Context, hash pldtypes.Bytes32) (publicTransaction *pldapi.PublicTxWithBinding, err error) {
+	err = p.c.CallRPC(ctx, &publicTransaction, "ptx_getPublicTransactionByHash", hash)
+	return
+}
+
+func (p *ptx) GetPublicTransactionRawSubmission(ctx context.Context, hash pldtypes.Bytes32) (rawSubmission *pldapi.PublicTxRawSubmission, err error) {
+	err = p.c.CallRPC(ctx, &rawSubmission, "ptx_getPublicTransactionRawSubmission", hash)
+	return
+}
+
+func (p *ptx) SuspendPublicTransaction(ctx context.Context, from pldtypes.EthAddress, nonce pldtypes.HexUint64) (success bool, err error) {
+	err = p.c.CallRPC(ctx, &success, "ptx_suspendPublicTransaction", from, nonce)
+	return
+}
+
+func (p *ptx) ResumePublicTransaction(ctx context.Context, from pldtypes.EthAddress, nonce pldtypes.HexUint64) (success bool, err error) {
+	err = p.c.CallRPC(ctx, &success, "ptx_resumePublicTransaction", from, nonce)
+	return
+}
+
+func (p *ptx) CancelPublicTransaction(ctx context.Context, from pldtypes.EthAddress, nonce pldtypes.HexUint64) (success bool, err error) {
+	err = p.c.CallRPC(ctx, &success, "ptx_cancelPublicTransaction", from, nonce)
+	return
+}
+
 func (p *ptx) QueryPreparedTransactions(ctx context.Context, jq *query.QueryJSON) (preparedTransactions []*pldapi.PreparedTransaction, err error) {
 	err = p.c.CallRPC(ctx, &preparedTransactions, "ptx_queryPreparedTransactions", jq)
 	return
@@ -371,6 +608,26 @@ func (p *ptx) QueryStoredABIs(ctx context.Context, jq *query.QueryJSON) (storedA
 	return
 }
 
+func (p *ptx) RegisterContractABI(ctx context.Context, contractABI *pldapi.ContractABIInput) (registered *pldapi.ContractABI, err error) {
+	err = p.c.CallRPC(ctx, &registered, "ptx_registerContractABI", contractABI)
+	return
+}
+
+func (p *ptx) GetContractABI(ctx context.Context, address pldtypes.EthAddress) (contractABI *pldapi.ContractABI, err error) {
+	err = p.c.CallRPC(ctx, &contractABI, "ptx_getContractABI", address)
+	return
+}
+
+func (p *ptx) QueryContractABIs(ctx context.Context, jq *query.QueryJSON) (contractABIs []*pldapi.ContractABI, err error) {
+	err = p.c.CallRPC(ctx, &contractABIs, "ptx_queryContractABIs", jq)
+	return
+}
+
+func (p *ptx) DecodeIndexedTransaction(ctx context.Context, txHash pldtypes.Bytes32, dataFormat pldtypes.JSONFormatOptions) (decoded *pldapi.DecodedTransaction, err error) {
+	err = p.c.CallRPC(ctx, &decoded, "ptx_decodeIndexedTransaction", txHash, dataFormat)
+	return
+}
+
 func (p *ptx) DecodeError(ctx context.Context, revertData pldtypes.HexBytes, dataFormat pldtypes.JSONFormatOptions) (decodedError *pldapi.ABIDecodedData, err error) {
 	err = p.c.CallRPC(ctx, &decodedError, "ptx_decodeError", revertData, dataFormat)
 	return
@@ -391,6 +648,11 @@ func (p *ptx) ResolveVerifier(ctx context.Context, keyIdentifier string, algorit
 	return
 }
 
+func (p *ptx) ResolveVerifiers(ctx context.Context, lookups []*pldapi.VerifierLookup) (verifiers []*pldapi.ResolvedVerifier, err error) {
+	err = p.c.CallRPC(ctx, &verifiers, "ptx_resolveVerifiers", lookups)
+	return
+}
+
 func (p *ptx) CreateReceiptListener(ctx context.Context, listener *pldapi.TransactionReceiptListener) (success bool, err error) {
 	err = p.c.CallRPC(ctx, &success, "ptx_createReceiptListener", listener)
 	return
@@ -421,6 +683,36 @@ func (p *ptx) DeleteReceiptListener(ctx context.Context, listenerName string) (s
 	return
 }
 
+func (p *ptx) WatchExternalTransaction(ctx context.Context, watch *pldapi.ExternalTransactionWatch) (externalWatch *pldapi.ExternalTransactionWatch, err error) {
+	err = p.c.CallRPC(ctx, &externalWatch, "ptx_watchExternalTransaction", watch)
+	return
+}
+
+func (p *ptx) GetExternalTransactionWatch(ctx context.Context, watchID uuid.UUID) (externalWatch *pldapi.ExternalTransactionWatch, err error) {
+	err = p.c.CallRPC(ctx, &externalWatch, "ptx_getExternalTransactionWatch", watchID)
+	return
+}
+
+func (p *ptx) QueryExternalTransactionWatches(ctx context.Context, jq *query.QueryJSON) (externalWatches []*pldapi.ExternalTransactionWatch, err error) {
+	err = p.c.CallRPC(ctx, &externalWatches, "ptx_queryExternalTransactionWatches", jq)
+	return
+}
+
+func (p *ptx) DeleteExternalTransactionWatch(ctx context.Context, watchID uuid.UUID) (success bool, err error) {
+	err = p.c.CallRPC(ctx, &success, "ptx_deleteExternalTransactionWatch", watchID)
+	return
+}
+
+func (p *ptx) QueryTransactionFees(ctx context.Context, jq *query.QueryJSON) (fees []*pldapi.TransactionFee, err error) {
+	err = p.c.CallRPC(ctx, &fees, "ptx_queryTransactionFees", jq)
+	return
+}
+
+func (p *ptx) GetTransactionFeeReport(ctx context.Context, input *pldapi.TransactionFeeReportInput) (report *pldapi.TransactionFeeReport, err error) {
+	err = p.c.CallRPC(ctx, &report, "ptx_getTransactionFeeReport", input)
+	return
+}
+
 func (p *ptx) CreateBlockchainEventListener(ctx context.Context, listener *pldapi.BlockchainEventListener) (success bool, err error) {
 	err = p.c.CallRPC(ctx, &success, "ptx_createBlockchainEventListener", listener)
 	return
@@ -456,6 +748,41 @@ func (p *ptx) GetBlockchainEventListenerStatus(ctx context.Context, listenerName
 	return
 }
 
+func (p *ptx) ReplayBlockchainEvents(ctx context.Context, listenerName string, txID uuid.UUID) (result *pldapi.IndexerEventReplayResult, err error) {
+	err = p.c.CallRPC(ctx, &result, "ptx_replayBlockchainEvents", listenerName, txID)
+	return
+}
+
+func (p *ptx) PrepareSwap(ctx context.Context, swapInput *pldapi.SwapInput) (swap *pldapi.Swap, err error) {
+	err = p.c.CallRPC(ctx, &swap, "ptx_prepareSwap", swapInput)
+	return
+}
+
+func (p *ptx) GetSwap(ctx context.Context, swapID uuid.UUID) (swap *pldapi.Swap, err error) {
+	err = p.c.CallRPC(ctx, &swap, "ptx_getSwap", swapID)
+	return
+}
+
+func (p *ptx) QuerySwaps(ctx context.Context, jq *query.QueryJSON) (swaps []*pldapi.Swap, err error) {
+	err = p.c.CallRPC(ctx, &swaps, "ptx_querySwaps", jq)
+	return
+}
+
+func (p *ptx) ApproveSwap(ctx context.Context, swapID uuid.UUID) (swap *pldapi.Swap, err error) {
+	err = p.c.CallRPC(ctx, &swap, "ptx_approveSwap", swapID)
+	return
+}
+
+func (p *ptx) ExecuteSwap(ctx context.Context, swapID uuid.UUID) (swap *pldapi.Swap, err error) {
+	err = p.c.CallRPC(ctx, &swap, "ptx_executeSwap", swapID)
+	return
+}
+
+func (p *ptx) RollbackSwap(ctx context.Context, swapID uuid.UUID) (swap *pldapi.Swap, err error) {
+	err = p.c.CallRPC(ctx, &swap, "ptx_rollbackSwap", swapID)
+	return
+}
+
 func (p *ptx) SubscribeReceipts(ctx context.Context, listenerName string) (sub rpcclient.Subscription, err error) {
 	ws, err := p.c.WSClient(ctx)
 	if err != nil {
@@ -471,3 +798,11 @@ func (p *ptx) SubscribeBlockchainEvents(ctx context.Context, listenerName string
 	}
 	return ws.Subscribe(ctx, ptxSubscriptionConfig, "blockchainevents", listenerName)
 }
+
+func (p *ptx) SubscribeDomainEvents(ctx context.Context, subscription *pldapi.DomainEventSubscription) (sub rpcclient.Subscription, err error) {
+	ws, err := p.c.WSClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return ws.Subscribe(ctx, ptxDomainEventsSubscriptionConfig, subscription)
+}
@@ -18,6 +18,7 @@ package pldclient
 import (
 	"testing"
 
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
 	"github.com/stretchr/testify/require"
 )
 
@@ -49,6 +50,22 @@ func TestPTXSubscribeBlockchainEvents(t *testing.T) {
 	require.Regexp(t, "PD020702", err)
 }
 
+func TestPTXSubscribeDomainEvents(t *testing.T) {
+	ctx, c, done := newTestClientAndServerWebSockets(t)
+	defer done()
+
+	_, err := c.PTX().SubscribeDomainEvents(ctx, &pldapi.DomainEventSubscription{Name: "sub1", Domain: "noto"})
+	require.Regexp(t, "PD020702", err)
+}
+
+func TestPTXSubscribeDomainEventsNotWS(t *testing.T) {
+	ctx, c, done := newTestClientAndServerHTTP(t)
+	defer done()
+
+	_, err := c.PTX().SubscribeDomainEvents(ctx, &pldapi.DomainEventSubscription{Name: "sub1", Domain: "noto"})
+	require.Regexp(t, "PD020217", err)
+}
+
 func TestPTXSubscribeBlockchainEventsNotWS(t *testing.T) {
 	ctx, c, done := newTestClientAndServerHTTP(t)
 	defer done()
@@ -30,6 +30,11 @@ type Registry interface {
 	QueryEntries(ctx context.Context, registryName string, jq query.QueryJSON, activeFilter pldtypes.Enum[pldapi.ActiveFilter]) (entries []*pldapi.RegistryEntry, err error)
 	QueryEntriesWithProps(ctx context.Context, registryName string, jq query.QueryJSON, activeFilter pldtypes.Enum[pldapi.ActiveFilter]) (entries []*pldapi.RegistryEntryWithProperties, err error)
 	GetEntryProperties(ctx context.Context, registryName string, entryID pldtypes.HexBytes, activeFilter pldtypes.Enum[pldapi.ActiveFilter]) (entries []*pldapi.RegistryProperty, err error)
+
+	AttachCredential(ctx context.Context, registryName string, credential *pldapi.RegistryCredential) (stored *pldapi.RegistryCredential, err error)
+	GetCredentials(ctx context.Context, registryName string, entryID pldtypes.HexBytes, includeRevoked bool) (credentials []*pldapi.RegistryCredential, err error)
+	RevokeCredential(ctx context.Context, registryName string, credentialID string) (success bool, err error)
+	CheckCredential(ctx context.Context, check *pldapi.RegistryCredentialCheck) (result *pldapi.RegistryCredentialCheckResult, err error)
 }
 
 // This is necessary because there's no way to introspect function parameter names via reflection
@@ -52,6 +57,22 @@ var registryInfo = &rpcModuleInfo{
 			Inputs: []string{"registryName", "entryId", "activeFilter"},
 			Output: "properties",
 		},
+		"reg_attachCredential": {
+			Inputs: []string{"registryName", "credential"},
+			Output: "stored",
+		},
+		"reg_getCredentials": {
+			Inputs: []string{"registryName", "entryId", "includeRevoked"},
+			Output: "credentials",
+		},
+		"reg_revokeCredential": {
+			Inputs: []string{"registryName", "credentialId"},
+			Output: "success",
+		},
+		"reg_checkCredential": {
+			Inputs: []string{"check"},
+			Output: "result",
+		},
 	},
 }
 
@@ -83,3 +104,23 @@ func (r *registry) GetEntryProperties(ctx context.Context, registryName string,
 	err = r.c.CallRPC(ctx, &properties, "reg_getEntryProperties", registryName, entryID, activeFilter)
 	return
 }
+
+func (r *registry) AttachCredential(ctx context.Context, registryName string, credential *pldapi.RegistryCredential) (stored *pldapi.RegistryCredential, err error) {
+	err = r.c.CallRPC(ctx, &stored, "reg_attachCredential", registryName, credential)
+	return
+}
+
+func (r *registry) GetCredentials(ctx context.Context, registryName string, entryID pldtypes.HexBytes, includeRevoked bool) (credentials []*pldapi.RegistryCredential, err error) {
+	err = r.c.CallRPC(ctx, &credentials, "reg_getCredentials", registryName, entryID, includeRevoked)
+	return
+}
+
+func (r *registry) RevokeCredential(ctx context.Context, registryName string, credentialID string) (success bool, err error) {
+	err = r.c.CallRPC(ctx, &success, "reg_revokeCredential", registryName, credentialID)
+	return
+}
+
+func (r *registry) CheckCredential(ctx context.Context, check *pldapi.RegistryCredentialCheck) (result *pldapi.RegistryCredentialCheckResult, err error) {
+	err = r.c.CallRPC(ctx, &result, "reg_checkCredential", check)
+	return
+}
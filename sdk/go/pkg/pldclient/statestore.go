@@ -32,6 +32,10 @@ type StateStore interface {
 	QueryContractStates(ctx context.Context, domain string, contractAddress pldtypes.EthAddress, schemaRef pldtypes.Bytes32, query *query.QueryJSON, qualifier pldapi.StateStatusQualifier) (states []*pldapi.State, err error)
 	QueryNullifiers(ctx context.Context, domain string, schemaRef pldtypes.Bytes32, query *query.QueryJSON, status pldapi.StateStatusQualifier) (states []*pldapi.State, err error)
 	QueryContractNullifiers(ctx context.Context, domain string, contractAddress pldtypes.EthAddress, schemaRef pldtypes.Bytes32, query *query.QueryJSON, status pldapi.StateStatusQualifier) (states []*pldapi.State, err error)
+	ExplainQuery(ctx context.Context, domain string, contractAddress *pldtypes.EthAddress, schemaRef pldtypes.Bytes32, query *query.QueryJSON, qualifier pldapi.StateStatusQualifier) (explanation *pldapi.StateQueryExplanation, err error)
+	ExportSnapshot(ctx context.Context, domain string, contractAddress *pldtypes.EthAddress) (snapshot *pldapi.StateSnapshot, err error)
+	ImportSnapshot(ctx context.Context, snapshot *pldapi.StateSnapshot) (result *pldapi.StateSnapshotImportResult, err error)
+	GetStateAnchorProof(ctx context.Context, domain string, stateID pldtypes.HexBytes) (proof *pldapi.StateAnchorProof, err error)
 }
 
 // This is necessary because there's no way to introspect function parameter names via reflection
@@ -62,6 +66,22 @@ var stateStoreInfo = &rpcModuleInfo{
 			Inputs: []string{"domain", "contractAddress", "schemaRef", "query", "qualifier"},
 			Output: "states",
 		},
+		"pstate_explainQuery": {
+			Inputs: []string{"domain", "contractAddress", "schemaRef", "query", "qualifier"},
+			Output: "explanation",
+		},
+		"pstate_exportSnapshot": {
+			Inputs: []string{"domain", "contractAddress"},
+			Output: "snapshot",
+		},
+		"pstate_importSnapshot": {
+			Inputs: []string{"snapshot"},
+			Output: "result",
+		},
+		"pstate_getStateAnchorProof": {
+			Inputs: []string{"domain", "stateId"},
+			Output: "proof",
+		},
 	},
 }
 
@@ -103,3 +123,23 @@ func (r *stateStore) QueryContractNullifiers(ctx context.Context, domain string,
 	err = r.c.CallRPC(ctx, &states, "pstate_queryContractNullifiers", domain, contractAddress, schemaRef, query)
 	return
 }
+
+func (r *stateStore) ExplainQuery(ctx context.Context, domain string, contractAddress *pldtypes.EthAddress, schemaRef pldtypes.Bytes32, query *query.QueryJSON, qualifier pldapi.StateStatusQualifier) (explanation *pldapi.StateQueryExplanation, err error) {
+	err = r.c.CallRPC(ctx, &explanation, "pstate_explainQuery", domain, contractAddress, schemaRef, query, qualifier)
+	return
+}
+
+func (r *stateStore) ExportSnapshot(ctx context.Context, domain string, contractAddress *pldtypes.EthAddress) (snapshot *pldapi.StateSnapshot, err error) {
+	err = r.c.CallRPC(ctx, &snapshot, "pstate_exportSnapshot", domain, contractAddress)
+	return
+}
+
+func (r *stateStore) ImportSnapshot(ctx context.Context, snapshot *pldapi.StateSnapshot) (result *pldapi.StateSnapshotImportResult, err error) {
+	err = r.c.CallRPC(ctx, &result, "pstate_importSnapshot", snapshot)
+	return
+}
+
+func (r *stateStore) GetStateAnchorProof(ctx context.Context, domain string, stateID pldtypes.HexBytes) (proof *pldapi.StateAnchorProof, err error) {
+	err = r.c.CallRPC(ctx, &proof, "pstate_getStateAnchorProof", domain, stateID)
+	return
+}
@@ -19,6 +19,7 @@ import (
 	"context"
 
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/query"
 )
 
@@ -32,6 +33,9 @@ type Transport interface {
 	PeerInfo(ctx context.Context, nodeName string) (peer *pldapi.PeerInfo, err error)
 	QueryReliableMessages(ctx context.Context, query *query.QueryJSON) (reliableMessages []*pldapi.ReliableMessage, err error)
 	QueryReliableMessageAcks(ctx context.Context, query *query.QueryJSON) (reliableMessageAcks []*pldapi.ReliableMessageAck, err error)
+	RequestStates(ctx context.Context, nodeName string, domain string, stateIDs []pldtypes.HexBytes) (reliableMessage *pldapi.ReliableMessage, err error)
+	ListPeerBans(ctx context.Context) (bans []*pldapi.PeerBan, err error)
+	ClearPeerBan(ctx context.Context, nodeName string) (cleared bool, err error)
 }
 
 // This is necessary because there's no way to introspect function parameter names via reflection
@@ -66,6 +70,18 @@ var transportInfo = &rpcModuleInfo{
 			Inputs: []string{"query"},
 			Output: "reliableMessageAcks",
 		},
+		"transport_requestStates": {
+			Inputs: []string{"nodeName", "domain", "stateIds"},
+			Output: "reliableMessage",
+		},
+		"transport_listPeerBans": {
+			Inputs: []string{},
+			Output: "bans",
+		},
+		"transport_clearPeerBan": {
+			Inputs: []string{"nodeName"},
+			Output: "cleared",
+		},
 	},
 }
 
@@ -114,3 +130,18 @@ func (t *transport) QueryReliableMessageAcks(ctx context.Context, query *query.Q
 	err = t.c.CallRPC(ctx, &reliableMessageAcks, "transport_queryReliableMessageAcks", query)
 	return
 }
+
+func (t *transport) RequestStates(ctx context.Context, nodeName string, domain string, stateIDs []pldtypes.HexBytes) (reliableMessage *pldapi.ReliableMessage, err error) {
+	err = t.c.CallRPC(ctx, &reliableMessage, "transport_requestStates", nodeName, domain, stateIDs)
+	return
+}
+
+func (t *transport) ListPeerBans(ctx context.Context) (bans []*pldapi.PeerBan, err error) {
+	err = t.c.CallRPC(ctx, &bans, "transport_listPeerBans")
+	return
+}
+
+func (t *transport) ClearPeerBan(ctx context.Context, nodeName string) (cleared bool, err error) {
+	err = t.c.CallRPC(ctx, &cleared, "transport_clearPeerBan", nodeName)
+	return
+}
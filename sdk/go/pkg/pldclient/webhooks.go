@@ -0,0 +1,86 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+package pldclient
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/query"
+)
+
+type Webhooks interface {
+	RPCModule
+
+	ListWebhooks(ctx context.Context) (webhooks []*pldapi.Webhook, err error)
+	QueryDeliveries(ctx context.Context, jq *query.QueryJSON) (deliveries []*pldapi.WebhookDelivery, err error)
+	GetDelivery(ctx context.Context, id uuid.UUID) (delivery *pldapi.WebhookDelivery, err error)
+	RetryDelivery(ctx context.Context, id uuid.UUID) (delivery *pldapi.WebhookDelivery, err error)
+}
+
+// This is necessary because there's no way to introspect function parameter names via reflection
+var webhooksInfo = &rpcModuleInfo{
+	group: "webhooks",
+	methodInfo: map[string]RPCMethodInfo{
+		"webhooks_listWebhooks": {
+			Inputs: []string{},
+			Output: "webhooks",
+		},
+		"webhooks_queryDeliveries": {
+			Inputs: []string{"query"},
+			Output: "deliveries",
+		},
+		"webhooks_getDelivery": {
+			Inputs: []string{"id"},
+			Output: "delivery",
+		},
+		"webhooks_retryDelivery": {
+			Inputs: []string{"id"},
+			Output: "delivery",
+		},
+	},
+}
+
+var _ Webhooks = &webhooks{}
+
+type webhooks struct {
+	*rpcModuleInfo
+	c *paladinClient
+}
+
+func (c *paladinClient) Webhooks() Webhooks {
+	return &webhooks{rpcModuleInfo: webhooksInfo, c: c}
+}
+
+func (w *webhooks) ListWebhooks(ctx context.Context) (webhooks []*pldapi.Webhook, err error) {
+	err = w.c.CallRPC(ctx, &webhooks, "webhooks_listWebhooks")
+	return
+}
+
+func (w *webhooks) QueryDeliveries(ctx context.Context, jq *query.QueryJSON) (deliveries []*pldapi.WebhookDelivery, err error) {
+	err = w.c.CallRPC(ctx, &deliveries, "webhooks_queryDeliveries", jq)
+	return
+}
+
+func (w *webhooks) GetDelivery(ctx context.Context, id uuid.UUID) (delivery *pldapi.WebhookDelivery, err error) {
+	err = w.c.CallRPC(ctx, &delivery, "webhooks_getDelivery", id)
+	return
+}
+
+func (w *webhooks) RetryDelivery(ctx context.Context, id uuid.UUID) (delivery *pldapi.WebhookDelivery, err error) {
+	err = w.c.CallRPC(ctx, &delivery, "webhooks_retryDelivery", id)
+	return
+}
@@ -0,0 +1,256 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pldtypes
+
+import (
+	"bytes"
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"math/big"
+	"regexp"
+	"strings"
+
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/common/go/pkg/pldmsgs"
+)
+
+// MaxDecimalScale is large enough for any common token (18 decimals is typical for ERC-20)
+const MaxDecimalScale = 77
+
+var decimalRegexp = regexp.MustCompile(`^([+-]?)([0-9]+)(?:\.([0-9]+))?$`)
+
+// Decimal is a fixed-point number, stored as an arbitrary precision integer plus a scale
+// (the number of decimal places). So 1.5 with 18 decimals is stored as 1500000000000000000 with scale 18.
+//
+// The JSON and DB representation is a decimal string, such as "1.500000000000000000",
+// which always includes all the decimal places of the scale.
+type Decimal struct {
+	unscaled *big.Int
+	scale    int
+}
+
+func NewDecimal(unscaled *big.Int, scale int) *Decimal {
+	if scale < 0 || scale > MaxDecimalScale {
+		panic(i18n.NewError(context.Background(), pldmsgs.MsgTypesDecimalScaleInvalid, scale, MaxDecimalScale))
+	}
+	return &Decimal{unscaled: new(big.Int).Set(unscaled), scale: scale}
+}
+
+func Int64ToDecimal(v int64, scale int) *Decimal {
+	return NewDecimal(new(big.Int).Mul(big.NewInt(v), decimalMultiplier(scale)), scale)
+}
+
+// Parse a decimal string such as "-12.345", where the scale is inferred from
+// the number of decimal places supplied.
+func ParseDecimal(ctx context.Context, s string) (*Decimal, error) {
+	match := decimalRegexp.FindStringSubmatch(s)
+	if match == nil {
+		return nil, i18n.NewError(ctx, pldmsgs.MsgTypesInvalidDecimal, s)
+	}
+	scale := len(match[3])
+	if scale > MaxDecimalScale {
+		return nil, i18n.NewError(ctx, pldmsgs.MsgTypesDecimalScaleInvalid, scale, MaxDecimalScale)
+	}
+	unscaled, _ := new(big.Int).SetString(match[1]+match[2]+match[3], 10)
+	return &Decimal{unscaled: unscaled, scale: scale}, nil
+}
+
+// Parse a decimal string, and set it to the required scale - failing if there
+// are more significant decimal places in the input than the scale supports.
+func ParseDecimalWithScale(ctx context.Context, s string, scale int) (*Decimal, error) {
+	d, err := ParseDecimal(ctx, s)
+	if err != nil {
+		return nil, err
+	}
+	return d.Rescale(ctx, scale)
+}
+
+func MustParseDecimal(s string) *Decimal {
+	d, err := ParseDecimal(context.Background(), s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+func decimalMultiplier(scale int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(scale)), nil)
+}
+
+func (d *Decimal) int() *big.Int {
+	if d.unscaled == nil {
+		return new(big.Int)
+	}
+	return d.unscaled
+}
+
+// Unscaled returns a copy of the underlying integer, which is the value to use on-chain
+func (d *Decimal) Unscaled() *big.Int {
+	return new(big.Int).Set(d.int())
+}
+
+func (d *Decimal) Scale() int {
+	return d.scale
+}
+
+func (d *Decimal) Sign() int {
+	return d.int().Sign()
+}
+
+func (d *Decimal) NilOrZero() bool {
+	return d == nil || d.Sign() == 0
+}
+
+// Rescale returns a new decimal with the specified scale, failing if the
+// reduction in scale would lose precision.
+func (d *Decimal) Rescale(ctx context.Context, scale int) (*Decimal, error) {
+	if scale < 0 || scale > MaxDecimalScale {
+		return nil, i18n.NewError(ctx, pldmsgs.MsgTypesDecimalScaleInvalid, scale, MaxDecimalScale)
+	}
+	if scale >= d.scale {
+		return &Decimal{
+			unscaled: new(big.Int).Mul(d.int(), decimalMultiplier(scale-d.scale)),
+			scale:    scale,
+		}, nil
+	}
+	q, r := new(big.Int).QuoRem(d.int(), decimalMultiplier(d.scale-scale), new(big.Int))
+	if r.Sign() != 0 {
+		return nil, i18n.NewError(ctx, pldmsgs.MsgTypesDecimalPrecisionLoss, d, scale)
+	}
+	return &Decimal{unscaled: q, scale: scale}, nil
+}
+
+// IntAtScale is a convenience for getting the integer representation of the
+// decimal for a token with the supplied number of decimals.
+func (d *Decimal) IntAtScale(ctx context.Context, scale int) (*big.Int, error) {
+	r, err := d.Rescale(ctx, scale)
+	if err != nil {
+		return nil, err
+	}
+	return r.unscaled, nil
+}
+
+// Aligns two decimals to the larger of the two scales, which never loses precision
+func alignDecimals(d1, d2 *Decimal) (*big.Int, *big.Int, int) {
+	scale := d1.scale
+	if d2.scale > scale {
+		scale = d2.scale
+	}
+	i1 := new(big.Int).Mul(d1.int(), decimalMultiplier(scale-d1.scale))
+	i2 := new(big.Int).Mul(d2.int(), decimalMultiplier(scale-d2.scale))
+	return i1, i2, scale
+}
+
+// Add returns a new decimal with the larger scale of the two inputs
+func (d *Decimal) Add(d2 *Decimal) *Decimal {
+	i1, i2, scale := alignDecimals(d, d2)
+	return &Decimal{unscaled: i1.Add(i1, i2), scale: scale}
+}
+
+// Sub returns a new decimal with the larger scale of the two inputs
+func (d *Decimal) Sub(d2 *Decimal) *Decimal {
+	i1, i2, scale := alignDecimals(d, d2)
+	return &Decimal{unscaled: i1.Sub(i1, i2), scale: scale}
+}
+
+// Mul returns a new decimal with the scale of this decimal, rounding towards zero
+func (d *Decimal) Mul(d2 *Decimal) *Decimal {
+	product := new(big.Int).Mul(d.int(), d2.int())
+	return &Decimal{unscaled: product.Quo(product, decimalMultiplier(d2.scale)), scale: d.scale}
+}
+
+func (d *Decimal) Cmp(d2 *Decimal) int {
+	i1, i2, _ := alignDecimals(d, d2)
+	return i1.Cmp(i2)
+}
+
+// String always includes all decimal places in the scale
+func (d *Decimal) String() string {
+	if d == nil {
+		return ""
+	}
+	abs := new(big.Int).Abs(d.int()).String()
+	sign := ""
+	if d.Sign() < 0 {
+		sign = "-"
+	}
+	if d.scale == 0 {
+		return sign + abs
+	}
+	if len(abs) <= d.scale {
+		abs = strings.Repeat("0", d.scale-len(abs)+1) + abs
+	}
+	return sign + abs[0:len(abs)-d.scale] + "." + abs[len(abs)-d.scale:]
+}
+
+// JSON representation is always a string, to avoid loss of precision in JSON parsers
+func (d *Decimal) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+// Parses from a JSON string or number, inferring the scale from the decimal places supplied
+func (d *Decimal) UnmarshalJSON(b []byte) error {
+	var iVal interface{}
+	decoder := json.NewDecoder(bytes.NewReader(b))
+	decoder.UseNumber() // It's not safe to use a JSON number decoder as it uses float64, so can (and does) lose precision
+	err := decoder.Decode(&iVal)
+	if err == nil {
+		var s string
+		switch v := iVal.(type) {
+		case string:
+			s = v
+		case json.Number:
+			s = v.String()
+		default:
+			return i18n.NewError(context.Background(), pldmsgs.MsgTypesScanFail, iVal, d)
+		}
+		var pd *Decimal
+		if pd, err = ParseDecimal(context.Background(), s); err == nil {
+			*d = *pd
+		}
+	}
+	return err
+}
+
+func (d *Decimal) Value() (driver.Value, error) {
+	if d == nil {
+		return nil, nil
+	}
+	return d.String(), nil
+}
+
+func (d *Decimal) Scan(src interface{}) error {
+	var s string
+	switch v := src.(type) {
+	case string:
+		s = v
+	case []byte:
+		s = string(v)
+	case int64:
+		*d = Decimal{unscaled: big.NewInt(v)}
+		return nil
+	default:
+		return i18n.NewError(context.Background(), pldmsgs.MsgTypesScanFail, src, d)
+	}
+	pd, err := ParseDecimal(context.Background(), s)
+	if err != nil {
+		return err
+	}
+	*d = *pd
+	return nil
+}
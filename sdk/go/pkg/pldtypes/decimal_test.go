@@ -0,0 +1,154 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pldtypes
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecimalParseAndString(t *testing.T) {
+	ctx := context.Background()
+
+	d := MustParseDecimal("1.5")
+	assert.Equal(t, 1, d.Scale())
+	assert.Equal(t, int64(15), d.Unscaled().Int64())
+	assert.Equal(t, "1.5", d.String())
+
+	assert.Equal(t, "-0.000123", MustParseDecimal("-0.000123").String())
+	assert.Equal(t, "42", MustParseDecimal("+42").String())
+	assert.Equal(t, "0.000000000000000001", NewDecimal(big.NewInt(1), 18).String())
+	assert.Equal(t, "-1.000000000000000000", Int64ToDecimal(-1, 18).String())
+	assert.Equal(t, "", (*Decimal)(nil).String())
+	assert.Equal(t, "0", (&Decimal{}).String())
+
+	d, err := ParseDecimalWithScale(ctx, "1.5", 18)
+	require.NoError(t, err)
+	assert.Equal(t, "1500000000000000000", d.Unscaled().String())
+	assert.Equal(t, "1.500000000000000000", d.String())
+
+	_, err = ParseDecimal(ctx, "1.2.3")
+	assert.Regexp(t, "PD020024", err)
+	_, err = ParseDecimal(ctx, ".5")
+	assert.Regexp(t, "PD020024", err)
+	_, err = ParseDecimalWithScale(ctx, "wrong", 18)
+	assert.Regexp(t, "PD020024", err)
+	_, err = ParseDecimalWithScale(ctx, "1.23", 1)
+	assert.Regexp(t, "PD020025", err)
+	_, err = ParseDecimal(ctx, "0.00000000000000000000000000000000000000000000000000000000000000000000000000000001")
+	assert.Regexp(t, "PD020026", err)
+
+	assert.Panics(t, func() { MustParseDecimal("bad") })
+	assert.Panics(t, func() { NewDecimal(big.NewInt(1), -1) })
+}
+
+func TestDecimalRescale(t *testing.T) {
+	ctx := context.Background()
+
+	d := MustParseDecimal("1.2300")
+	r, err := d.Rescale(ctx, 2)
+	require.NoError(t, err)
+	assert.Equal(t, "1.23", r.String())
+	assert.Equal(t, "1.2300", d.String())
+
+	i, err := d.IntAtScale(ctx, 6)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1230000), i.Int64())
+
+	_, err = d.Rescale(ctx, 1)
+	assert.Regexp(t, "PD020025", err)
+	_, err = d.IntAtScale(ctx, 1)
+	assert.Regexp(t, "PD020025", err)
+	_, err = d.Rescale(ctx, MaxDecimalScale+1)
+	assert.Regexp(t, "PD020026", err)
+}
+
+func TestDecimalArithmetic(t *testing.T) {
+	a := MustParseDecimal("10.25")
+	b := MustParseDecimal("0.005")
+
+	assert.Equal(t, "10.255", a.Add(b).String())
+	assert.Equal(t, "10.245", a.Sub(b).String())
+	assert.Equal(t, "-10.245", b.Sub(a).String())
+	assert.Equal(t, "20.50", a.Mul(MustParseDecimal("2")).String())
+	assert.Equal(t, "0.05", a.Mul(b).String()) // rounds towards zero at the scale of a
+	assert.Equal(t, 1, a.Cmp(b))
+	assert.Equal(t, -1, b.Cmp(a))
+	assert.Equal(t, 0, MustParseDecimal("1.0").Cmp(MustParseDecimal("1.000")))
+	assert.Equal(t, 1, a.Sign())
+	assert.True(t, (*Decimal)(nil).NilOrZero())
+	assert.True(t, MustParseDecimal("0.00").NilOrZero())
+	assert.False(t, a.NilOrZero())
+}
+
+func TestDecimalJSON(t *testing.T) {
+	type testStruct struct {
+		Amount *Decimal `json:"amount"`
+	}
+
+	var v testStruct
+	err := json.Unmarshal([]byte(`{"amount":"1.000000000000000001"}`), &v)
+	require.NoError(t, err)
+	assert.Equal(t, 18, v.Amount.Scale())
+	b, err := json.Marshal(&v)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"amount":"1.000000000000000001"}`, string(b))
+
+	// Numbers are parsed without passing through float64
+	err = json.Unmarshal([]byte(`{"amount":12345678901234567890.123}`), &v)
+	require.NoError(t, err)
+	assert.Equal(t, "12345678901234567890.123", v.Amount.String())
+
+	err = json.Unmarshal([]byte(`{"amount":"1e18"}`), &v)
+	assert.Regexp(t, "PD020024", err)
+
+	err = json.Unmarshal([]byte(`{"amount":false}`), &v)
+	assert.Regexp(t, "PD020002", err)
+
+	var d Decimal
+	err = d.UnmarshalJSON([]byte(`{!!!`))
+	assert.Error(t, err)
+}
+
+func TestDecimalDB(t *testing.T) {
+	d := MustParseDecimal("-99.99")
+	dbv, err := d.Value()
+	require.NoError(t, err)
+	assert.Equal(t, "-99.99", dbv)
+
+	var d2 Decimal
+	require.NoError(t, d2.Scan(dbv))
+	assert.Equal(t, 0, d.Cmp(&d2))
+	require.NoError(t, d2.Scan([]byte("0.1")))
+	assert.Equal(t, "0.1", d2.String())
+	require.NoError(t, d2.Scan(int64(12345)))
+	assert.Equal(t, "12345", d2.String())
+
+	err = d2.Scan("wrong")
+	assert.Regexp(t, "PD020024", err)
+	err = d2.Scan(false)
+	assert.Regexp(t, "PD020002", err)
+
+	dbv, err = (*Decimal)(nil).Value()
+	require.NoError(t, err)
+	assert.Nil(t, dbv)
+}
@@ -73,6 +73,15 @@ type QueryBuilder interface {
 	// NotLike adds a not like filter to the query
 	NotLike(field string, value any) QueryBuilder
 
+	// Contains adds a substring filter to the query
+	Contains(field string, value any, adds ...addOns) QueryBuilder
+
+	// NotContains adds a negated substring filter to the query
+	NotContains(field string, value any, adds ...addOns) QueryBuilder
+
+	// JSONEqual adds an equality filter on a dot separated path within a JSON field
+	JSONEqual(field string, path string, value any, adds ...addOns) QueryBuilder
+
 	// Or creates an OR condition between multiple queries
 	Or(...QueryBuilder) QueryBuilder
 
@@ -204,6 +213,27 @@ func (qb *queryBuilderImpl) NotLike(field string, value any) QueryBuilder {
 	return qb
 }
 
+// Contains adds a substring filter to the query
+func (qb *queryBuilderImpl) Contains(field string, value any, adds ...addOns) QueryBuilder {
+	qb.statements.Contains = append(qb.statements.Contains, buildSingleValueOp(field, value, adds...))
+	return qb
+}
+
+// NotContains adds a negated substring filter to the query
+func (qb *queryBuilderImpl) NotContains(field string, value any, adds ...addOns) QueryBuilder {
+	qb.statements.Contains = append(qb.statements.Contains, buildSingleValueOp(field, value, append(adds, Not)...))
+	return qb
+}
+
+// JSONEqual adds an equality filter on a dot separated path within a JSON field
+func (qb *queryBuilderImpl) JSONEqual(field string, path string, value any, adds ...addOns) QueryBuilder {
+	qb.statements.JSONEq = append(qb.statements.JSONEq, &OpJSONPath{
+		OpSingleVal: *buildSingleValueOp(field, value, adds...),
+		Path:        path,
+	})
+	return qb
+}
+
 // Or creates an OR condition between multiple queries
 func (qb *queryBuilderImpl) Or(q ...QueryBuilder) QueryBuilder {
 	for _, child := range q {
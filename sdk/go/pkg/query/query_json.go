@@ -57,6 +57,11 @@ type OpMultiVal struct {
 	Values []pldtypes.RawJSON `docstruct:"OpMultiVal" json:"values,omitempty"`
 }
 
+type OpJSONPath struct {
+	OpSingleVal
+	Path string `docstruct:"OpJSONPath" json:"path,omitempty"`
+}
+
 type Statements struct {
 	Or []*Statements `docstruct:"Statements" json:"or,omitempty"`
 	Ops
@@ -67,6 +72,7 @@ type Ops struct {
 	Eq                 []*OpSingleVal `docstruct:"Ops" json:"eq,omitempty"`  // short name
 	NEq                []*OpSingleVal `docstruct:"Ops" json:"neq,omitempty"` // negated short name
 	Like               []*OpSingleVal `docstruct:"Ops" json:"like,omitempty"`
+	Contains           []*OpSingleVal `docstruct:"Ops" json:"contains,omitempty"`
 	LessThan           []*OpSingleVal `docstruct:"Ops" json:"lessThan,omitempty"`
 	LT                 []*OpSingleVal `docstruct:"Ops" json:"lt,omitempty"` // short name
 	LessThanOrEqual    []*OpSingleVal `docstruct:"Ops" json:"lessThanOrEqual,omitempty"`
@@ -78,6 +84,7 @@ type Ops struct {
 	In                 []*OpMultiVal  `docstruct:"Ops" json:"in,omitempty"`
 	NIn                []*OpMultiVal  `docstruct:"Ops" json:"nin,omitempty"` // negated short name
 	Null               []*Op          `docstruct:"Ops" json:"null,omitempty"`
+	JSONEq             []*OpJSONPath  `docstruct:"Ops" json:"jsonEq,omitempty"`
 }
 
 func (jq *QueryJSON) String() string {
@@ -47,6 +47,10 @@ const (
 	isNullKey = "null"
 	// LikeKey is the key for the like field in the query
 	likeKey = "like"
+	// ContainsKey is the key for the contains field in the query
+	containsKey = "contains"
+	// JSONEqKey is the key for the JSON path equality field in the query
+	jsonEqKey = "jsonEq"
 	// CaseInsensitiveKey is the key for the case insensitive flag in the query
 	// caseInsensitiveKey = "caseInsensitive"
 )
@@ -426,6 +430,28 @@ func TestQueryBuilderImpl_NotLike(t *testing.T) {
 	}
 }
 
+func TestQueryBuilderImpl_Contains(t *testing.T) {
+	qb := NewQueryBuilder()
+	qb.Contains("name", "oh", CaseInsensitive).NotContains("name", "Jane")
+	assertQueryEqual(t, map[string]interface{}{
+		containsKey: []map[string]interface{}{
+			{"field": "name", "value": "oh", "caseInsensitive": true},
+			{"field": "name", "value": "Jane", "not": true},
+		},
+	}, qb.Query())
+}
+
+func TestQueryBuilderImpl_JSONEqual(t *testing.T) {
+	qb := NewQueryBuilder()
+	qb.JSONEqual("properties", "owner.name", "John").JSONEqual("properties", "count", 5, Not)
+	assertQueryEqual(t, map[string]interface{}{
+		jsonEqKey: []map[string]interface{}{
+			{"field": "properties", "path": "owner.name", "value": "John"},
+			{"field": "properties", "path": "count", "value": 5, "not": true},
+		},
+	}, qb.Query())
+}
+
 func TestQueryBuilderImpl_setField(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -76,16 +76,28 @@ func (r *Retry) Do(ctx context.Context, do func(attempt int) (retryable bool, er
 	}
 }
 
+// Delay returns the backoff delay after the given number of failures, for callers that
+// cannot block in WaitDelay and instead need to schedule the next attempt themselves.
+func (r *Retry) Delay(failureCount int) time.Duration {
+	retryDelay := r.initialDelay
+	for i := 0; i < (failureCount - 1); i++ {
+		retryDelay = time.Duration(float64(retryDelay) * r.factor)
+		if retryDelay > r.maxDelay {
+			retryDelay = r.maxDelay
+			break
+		}
+	}
+	return retryDelay
+}
+
+// MaxAttempts returns the configured limit on attempts, or zero if the retry is indefinite
+func (r *Retry) MaxAttempts() int {
+	return r.maxAttempts
+}
+
 func (r *Retry) WaitDelay(ctx context.Context, failureCount int) error {
 	if failureCount > 0 {
-		retryDelay := r.initialDelay
-		for i := 0; i < (failureCount - 1); i++ {
-			retryDelay = time.Duration(float64(retryDelay) * r.factor)
-			if retryDelay > r.maxDelay {
-				retryDelay = r.maxDelay
-				break
-			}
-		}
+		retryDelay := r.Delay(failureCount)
 		log.L(ctx).Debugf("Retrying after %.2f (failures=%d)", retryDelay.Seconds(), failureCount)
 		select {
 		case <-time.After(retryDelay):
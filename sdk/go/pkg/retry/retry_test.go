@@ -115,3 +115,20 @@ func TestDefaultsOverride(t *testing.T) {
 	assert.Equal(t, 42, r.maxAttempts)
 
 }
+
+func TestRetryDelay(t *testing.T) {
+	r := NewRetryLimited(&pldconf.RetryConfigWithMax{
+		RetryConfig: pldconf.RetryConfig{
+			InitialDelay: confutil.P("10ms"),
+			MaxDelay:     confutil.P("50ms"),
+			Factor:       confutil.P(2.0),
+		},
+		MaxAttempts: confutil.P(4),
+	})
+	assert.Equal(t, 10*time.Millisecond, r.Delay(1))
+	assert.Equal(t, 20*time.Millisecond, r.Delay(2))
+	assert.Equal(t, 40*time.Millisecond, r.Delay(3))
+	assert.Equal(t, 50*time.Millisecond, r.Delay(4))
+	assert.Equal(t, 4, r.MaxAttempts())
+	assert.Zero(t, NewRetryIndefinite(&pldconf.RetryConfig{}).MaxAttempts())
+}
@@ -73,12 +73,21 @@ type rpcSubscriptionNotification struct {
 }
 
 func (n *rpcSubscriptionNotification) Ack(ctx context.Context) ErrorRPC {
-	id, req := n.wsc.newAsyncReq(n.sub.AckMethod, pldtypes.JSONString(n.CurrentSubID))
-	return n.wsc.sendRPC(ctx, id, req)
+	return n.sendAckNack(ctx, n.sub.AckMethod)
 }
 
 func (n *rpcSubscriptionNotification) Nack(ctx context.Context) ErrorRPC {
-	id, req := n.wsc.newAsyncReq(n.sub.NackMethod, pldtypes.JSONString(n.CurrentSubID))
+	return n.sendAckNack(ctx, n.sub.NackMethod)
+}
+
+func (n *rpcSubscriptionNotification) sendAckNack(ctx context.Context, method string) ErrorRPC {
+	if n.wsc.getCurrentSubID(n.sub) != n.CurrentSubID {
+		// The server discards the in-flight batch when the connection it was delivered on is lost,
+		// and redelivers it to the new subscription. So there is nothing to ack/nack here.
+		log.L(ctx).Infof("Ignoring %s for subscription %s as it was resubscribed since the notification (serverId=%s)", method, n.sub.localID, n.CurrentSubID)
+		return nil
+	}
+	id, req := n.wsc.newAsyncReq(method, pldtypes.JSONString(n.CurrentSubID))
 	return n.wsc.sendRPC(ctx, id, req)
 }
 
@@ -230,6 +239,12 @@ func (rc *wsRPCClient) addActiveSub(s *sub, subscriptionID string) {
 	rc.activeSubsBySubID[s.currentSubID] = s
 }
 
+func (rc *wsRPCClient) getCurrentSubID(s *sub) string {
+	rc.mux.Lock()
+	defer rc.mux.Unlock()
+	return s.currentSubID
+}
+
 func (rc *wsRPCClient) getActiveSub(subID string) *sub {
 	rc.mux.Lock()
 	defer rc.mux.Unlock()
@@ -493,3 +493,19 @@ func TestHandleAckNack(t *testing.T) {
 
 	<-subDone
 }
+
+func TestAckNackAfterResubscribe(t *testing.T) {
+	ctx, rc, _, _, done := newTestWSRPC(t)
+	defer done()
+
+	s, _ := rc.addConfiguredSub(ctx, SubscriptionConfig{
+		AckMethod:  "ptx_ack",
+		NackMethod: "ptx_nack",
+	}, nil)
+	rc.addActiveSub(s, "sub2")
+
+	// Nothing is sent for a notification from the old subscription, as the server will redeliver
+	n := &rpcSubscriptionNotification{wsc: rc, sub: s, CurrentSubID: "sub1"}
+	assert.Nil(t, n.Ack(ctx))
+	assert.Nil(t, n.Nack(ctx))
+}
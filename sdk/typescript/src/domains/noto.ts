@@ -47,6 +47,32 @@ export const notoConstructorABI = (
                   { name: "restrictMint", type: "bool" },
                   { name: "allowBurn", type: "bool" },
                   { name: "allowLock", type: "bool" },
+                  {
+                    name: "mintPolicy",
+                    type: "tuple",
+                    components: [
+                      { name: "maxSupply", type: "uint256" },
+                      { name: "minters", type: "string[]" },
+                      {
+                        name: "minterQuotas",
+                        type: "tuple[]",
+                        components: [
+                          { name: "minter", type: "string" },
+                          { name: "quota", type: "uint256" },
+                        ],
+                      },
+                    ],
+                  },
+                  { name: "nonFungible", type: "bool" },
+                  {
+                    name: "transferFee",
+                    type: "tuple",
+                    components: [
+                      { name: "recipient", type: "string" },
+                      { name: "basisPoints", type: "uint64" },
+                      { name: "flatFee", type: "uint256" },
+                    ],
+                  },
                 ],
               },
             ]),
@@ -63,6 +89,9 @@ export interface NotoConstructorParams {
       restrictMint: boolean;
       allowBurn: boolean;
       allowLock: boolean;
+      mintPolicy?: NotoMintPolicy;
+      nonFungible?: boolean;
+      transferFee?: NotoTransferFee;
     };
     hooks?: {
       publicAddress: string;
@@ -72,6 +101,18 @@ export interface NotoConstructorParams {
   };
 }
 
+export interface NotoMintPolicy {
+  maxSupply: string | number; // 0 for no cap
+  minters: string[];
+  minterQuotas: { minter: string; quota: string | number }[];
+}
+
+export interface NotoTransferFee {
+  recipient: string; // empty for no fee
+  basisPoints: number;
+  flatFee: string | number;
+}
+
 export interface NotoMintParams {
   to: PaladinVerifier;
   amount: string | number;
@@ -84,6 +125,19 @@ export interface NotoTransferParams {
   data: string;
 }
 
+export interface NotoMintNFTParams {
+  to: PaladinVerifier;
+  tokenId: string | number;
+  uri: string;
+  data: string;
+}
+
+export interface NotoTransferNFTParams {
+  to: PaladinVerifier;
+  tokenId: string | number;
+  data: string;
+}
+
 export interface NotoBurnParams {
   amount: string | number;
   data: string;
@@ -120,6 +174,14 @@ export interface NotoDelegateLockParams {
   data: string;
 }
 
+export interface NotoSetLockExpiryParams {
+  lockId: string;
+  unlock: NotoUnlockPublicParams;
+  expiry: number;
+  beneficiary: PaladinVerifier;
+  data: string;
+}
+
 export interface NotoUnlockPublicParams {
   lockedInputs: string[];
   lockedOutputs: string[];
@@ -157,13 +219,16 @@ export class NotoFactory {
         ...data,
         notary: data.notary.lookup,
         options: {
+          ...data.options,
           basic: {
             restrictMint: true,
             allowBurn: true,
             allowLock: true,
+            mintPolicy: { maxSupply: 0, minters: [], minterQuotas: [] },
+            nonFungible: false,
+            transferFee: { recipient: "", basisPoints: 0, flatFee: 0 },
             ...data.options?.basic,
           },
-          ...data.options,
         },
       },
     });
@@ -225,6 +290,36 @@ export class NotoInstance {
     return this.paladin.pollForReceipt(txID, this.options.pollTimeout);
   }
 
+  async mintNFT(from: PaladinVerifier, data: NotoMintNFTParams) {
+    const txID = await this.paladin.sendTransaction({
+      type: TransactionType.PRIVATE,
+      abi: notoPrivateJSON.abi,
+      function: "mintNFT",
+      to: this.address,
+      from: from.lookup,
+      data: {
+        ...data,
+        to: data.to.lookup,
+      },
+    });
+    return this.paladin.pollForReceipt(txID, this.options.pollTimeout);
+  }
+
+  async transferNFT(from: PaladinVerifier, data: NotoTransferNFTParams) {
+    const txID = await this.paladin.sendTransaction({
+      type: TransactionType.PRIVATE,
+      abi: notoPrivateJSON.abi,
+      function: "transferNFT",
+      to: this.address,
+      from: from.lookup,
+      data: {
+        ...data,
+        to: data.to.lookup,
+      },
+    });
+    return this.paladin.pollForReceipt(txID, this.options.pollTimeout);
+  }
+
   prepareTransfer(from: PaladinVerifier, data: NotoTransferParams) {
     return this.paladin.prepareTransaction({
       type: TransactionType.PRIVATE,
@@ -340,6 +435,21 @@ export class NotoInstance {
     return this.paladin.pollForReceipt(txID, this.options.pollTimeout);
   }
 
+  async setLockExpiry(from: PaladinVerifier, data: NotoSetLockExpiryParams) {
+    const txID = await this.paladin.sendTransaction({
+      type: TransactionType.PRIVATE,
+      abi: notoPrivateJSON.abi,
+      function: "setLockExpiry",
+      to: this.address,
+      from: from.lookup,
+      data: {
+        ...data,
+        beneficiary: data.beneficiary.lookup,
+      },
+    });
+    return this.paladin.pollForReceipt(txID, this.options.pollTimeout);
+  }
+
   encodeUnlock(data: NotoUnlockPublicParams) {
     return new ethers.Interface(notoJSON.abi).encodeFunctionData("unlock", [
       data.lockedInputs,
@@ -64,6 +64,11 @@ export interface ZetoWithdrawParams {
   amount: string | number;
 }
 
+export interface ZetoRegisterParams {
+  identity: PaladinVerifier;
+  data: string;
+}
+
 export class ZetoFactory {
   private options: Required<ZetoOptions>;
 
@@ -230,4 +235,19 @@ export class ZetoInstance {
     });
     return this.paladin.pollForReceipt(receipt, POLL_TIMEOUT_MS);
   }
+
+  async register(from: PaladinVerifier, data: ZetoRegisterParams) {
+    const receipt = await this.paladin.sendTransaction({
+      type: TransactionType.PRIVATE,
+      abi: zetoAbi,
+      function: "register",
+      to: this.address,
+      from: from.lookup,
+      data: {
+        ...data,
+        identity: data.identity.lookup,
+      },
+    });
+    return this.paladin.pollForReceipt(receipt, POLL_TIMEOUT_MS);
+  }
 }
@@ -12,12 +12,17 @@ export interface IQueryOpMultiVal extends IQueryOp {
   values: any[];
 }
 
+export interface IQueryOpJSONPath extends IQueryOpSingleVal {
+  path: string;
+}
+
 export interface IQueryStatements {
   or?: IQueryStatements[];
   equal?: IQueryOpSingleVal[];
   eq?: IQueryOpSingleVal[];
   neq?: IQueryOpSingleVal[];
   like?: IQueryOpSingleVal[];
+  contains?: IQueryOpSingleVal[];
   lessThan?: IQueryOpSingleVal[];
   lt?: IQueryOpSingleVal[];
   lessThanOrEqual?: IQueryOpSingleVal[];
@@ -29,6 +34,7 @@ export interface IQueryStatements {
   in?: IQueryOpMultiVal[];
   nin?: IQueryOpMultiVal[];
   null?: IQueryOp;
+  jsonEq?: IQueryOpJSONPath[];
 }
 
 export interface IQuery extends IQueryStatements {
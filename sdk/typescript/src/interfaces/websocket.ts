@@ -37,3 +37,16 @@ export interface WebSocketEvent {
     };
   };
 }
+
+export interface IDomainEventSubscription {
+  name: string;
+  domain: string;
+  contracts: string[];
+  events?: string[];
+  options?: {
+    batchSize?: number;
+    batchTimeout?: string;
+    fromBlock?: number | "latest";
+    confirmations?: number;
+  };
+}
@@ -3,6 +3,7 @@ import { Transform } from "stream";
 import WebSocket from "ws";
 import { Logger } from "./interfaces/logger";
 import {
+  IDomainEventSubscription,
   WebSocketClientOptions,
   WebSocketEvent,
   WebSocketEventCallback,
@@ -161,6 +162,10 @@ export class PaladinWebSocketClient {
     this.sendRpc("ptx_subscribe", [type, name]);
   }
 
+  subscribeDomainEvents(subscription: IDomainEventSubscription) {
+    this.sendRpc("ptx_subscribeDomainEvents", [subscription]);
+  }
+
   ack(subscription: string) {
     this.sendRpc("ptx_ack", [subscription]);
   }
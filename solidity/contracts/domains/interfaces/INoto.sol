@@ -51,6 +51,15 @@ interface INoto {
         bytes data
     );
 
+    event NotoLockExpirySet(
+        bytes32 unlockHash,
+        uint256 expiry,
+        address beneficiary,
+        bytes32 releaseHash,
+        bytes signature,
+        bytes data
+    );
+
     function initialize(
         address notaryAddress,
         bytes calldata data
@@ -112,4 +121,13 @@ interface INoto {
         bytes calldata signature,
         bytes calldata data
     ) external;
+
+    function setLockExpiry(
+        bytes32 unlockHash,
+        uint256 expiry,
+        address beneficiary,
+        bytes32 releaseHash,
+        bytes calldata signature,
+        bytes calldata data
+    ) external;
 }
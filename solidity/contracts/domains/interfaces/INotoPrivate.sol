@@ -21,6 +21,19 @@ interface INotoPrivate {
 
     function burn(uint256 amount, bytes calldata data) external;
 
+    function mintNFT(
+        string calldata to,
+        uint256 tokenId,
+        string calldata uri,
+        bytes calldata data
+    ) external;
+
+    function transferNFT(
+        string calldata to,
+        uint256 tokenId,
+        bytes calldata data
+    ) external;
+
     function approveTransfer(
         StateEncoded[] calldata inputs,
         StateEncoded[] calldata outputs,
@@ -51,6 +64,14 @@ interface INotoPrivate {
         bytes calldata data
     ) external;
 
+    function setLockExpiry(
+        bytes32 lockId,
+        UnlockPublicParams calldata unlock,
+        uint256 expiry,
+        string calldata beneficiary,
+        bytes calldata data
+    ) external;
+
     struct StateEncoded {
         bytes id;
         string domain;
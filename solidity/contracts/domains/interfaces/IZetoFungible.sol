@@ -24,4 +24,5 @@ interface IZetoFungible {
     function deposit(uint256 amount) external;
     function withdraw(uint256 amount) external;
     function setERC20(address erc20) external;
+    function register(string memory identity, bytes memory data) external;
 }
@@ -32,6 +32,13 @@ contract Noto is EIP712Upgradeable, UUPSUpgradeable, INoto, INotoErrors {
     mapping(bytes32 => bool) private _locked;
     mapping(bytes32 => bytes32) private _unlockHashes; // state ID => unlock hash
     mapping(bytes32 => address) private _unlockDelegates; // unlock hash => delegate
+    mapping(bytes32 => LockExpiry) private _lockExpiries; // unlock hash => expiry terms
+
+    struct LockExpiry {
+        uint256 expiry;
+        address beneficiary;
+        bytes32 releaseHash;
+    }
 
     // Config follows the convention of a 4 byte type selector, followed by ABI encoded bytes
     bytes4 public constant NotoConfigID_V0 = 0x00010000;
@@ -128,6 +135,28 @@ contract Noto is EIP712Upgradeable, UUPSUpgradeable, INoto, INotoErrors {
         return _locked[id];
     }
 
+    /**
+     * @dev query the expiry terms of a prepared unlock
+     * @param unlockHash the EIP-712 hash of the prepared unlock
+     * @return expiry the block timestamp after which the release may be triggered, or zero if no expiry is set
+     * @return beneficiary the address that may trigger the release
+     * @return releaseHash the EIP-712 hash of the release operation
+     */
+    function getLockExpiry(
+        bytes32 unlockHash
+    )
+        public
+        view
+        returns (uint256 expiry, address beneficiary, bytes32 releaseHash)
+    {
+        LockExpiry storage lockExpiry = _lockExpiries[unlockHash];
+        return (
+            lockExpiry.expiry,
+            lockExpiry.beneficiary,
+            lockExpiry.releaseHash
+        );
+    }
+
     /**
      * @dev query whether an approval exists for the given transaction
      * @param txhash the transaction hash
@@ -325,6 +354,8 @@ contract Noto is EIP712Upgradeable, UUPSUpgradeable, INoto, INotoErrors {
      * @dev Unlock some value from a set of locked states.
      *      May be triggered by the notary (if lock is undelegated) or by the current lock delegate.
      *      If triggered by the lock delegate, only a prepared unlock operation may be triggered.
+     *      If the prepared unlock has passed its expiry, only the beneficiary may trigger the
+     *      release operation that was fixed when the expiry was set.
      *
      * @param lockedInputs array of zero or more locked outputs of a previous function call
      * @param lockedOutputs array of zero or more locked outputs to generate, which will be tied to the lock ID
@@ -353,8 +384,35 @@ contract Noto is EIP712Upgradeable, UUPSUpgradeable, INoto, INotoErrors {
             delete _unlockHashes[lockedInputs[i]];
         }
 
+        LockExpiry memory lockExpiry = _lockExpiries[expectedHash];
+        if (lockExpiry.expiry != 0) {
+            delete _lockExpiries[expectedHash];
+        }
+
         address delegate = _unlockDelegates[expectedHash];
-        if (delegate == address(0)) {
+        if (lockExpiry.expiry != 0 && block.timestamp >= lockExpiry.expiry) {
+            if (msg.sender != lockExpiry.beneficiary) {
+                revert NotoInvalidDelegate(
+                    expectedHash,
+                    lockExpiry.beneficiary,
+                    msg.sender
+                );
+            }
+            delete _unlockDelegates[expectedHash];
+
+            bytes32 actualHash = _buildUnlockHash(
+                lockedInputs,
+                lockedOutputs,
+                outputs,
+                data
+            );
+            if (actualHash != lockExpiry.releaseHash) {
+                revert NotoInvalidUnlockHash(
+                    lockExpiry.releaseHash,
+                    actualHash
+                );
+            }
+        } else if (delegate == address(0)) {
             requireNotary(msg.sender);
         } else {
             requireLockDelegate(expectedHash, msg.sender);
@@ -441,6 +499,50 @@ contract Noto is EIP712Upgradeable, UUPSUpgradeable, INoto, INotoErrors {
         emit NotoLockDelegated(unlockHash, delegate, signature, data);
     }
 
+    /**
+     * @dev Set an expiry on a prepared unlock, after which the locked value may only be
+     *      released by the beneficiary, using the release operation given here.
+     *      May only be triggered by the notary, and only before the lock is delegated, so
+     *      the terms cannot change once a delegate is relying on them.
+     *
+     * @param unlockHash the EIP-712 hash of the prepared unlock
+     * @param expiry the block timestamp from which the release may be triggered
+     * @param beneficiary the address that is authorized to trigger the release
+     * @param releaseHash pre-calculated EIP-712 hash of the release (unlock) transaction
+     * @param signature a signature over the original request to the notary (opaque to the blockchain)
+     * @param data any additional transaction data (opaque to the blockchain)
+     *
+     * Emits a {NotoLockExpirySet} event.
+     */
+    function setLockExpiry(
+        bytes32 unlockHash,
+        uint256 expiry,
+        address beneficiary,
+        bytes32 releaseHash,
+        bytes calldata signature,
+        bytes calldata data
+    ) external virtual override onlyNotary {
+        if (_unlockDelegates[unlockHash] != address(0)) {
+            revert NotoAlreadyPrepared(unlockHash);
+        }
+        if (expiry <= block.timestamp) {
+            revert NotoInvalidExpiry(expiry);
+        }
+        _lockExpiries[unlockHash] = LockExpiry({
+            expiry: expiry,
+            beneficiary: beneficiary,
+            releaseHash: releaseHash
+        });
+        emit NotoLockExpirySet(
+            unlockHash,
+            expiry,
+            beneficiary,
+            releaseHash,
+            signature,
+            data
+        );
+    }
+
     /**
      * @dev Check the inputs are all locked
      */
@@ -192,6 +192,16 @@ contract NotoTrackerERC20 is INotoHooks, ERC20 {
         emit PenteExternalCall(prepared.contractAddress, prepared.encodedCall);
     }
 
+    function onSetLockExpiry(
+        address sender,
+        bytes32 lockId,
+        uint256 expiry,
+        address beneficiary,
+        PreparedTransaction calldata prepared
+    ) external virtual override {
+        emit PenteExternalCall(prepared.contractAddress, prepared.encodedCall);
+    }
+
     function handleDelegateUnlock(
         address sender,
         bytes32 lockId,
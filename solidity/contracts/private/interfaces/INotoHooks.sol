@@ -87,6 +87,14 @@ interface INotoHooks is IPenteExternalCall {
         PreparedTransaction calldata prepared
     ) external;
 
+    function onSetLockExpiry(
+        address sender,
+        bytes32 lockId,
+        uint256 expiry,
+        address beneficiary,
+        PreparedTransaction calldata prepared
+    ) external;
+
     /**
      * @dev This method is called after a prepared unlock is executed by the lock delegate.
      *      Unlike other hooks, this method is called after the unlock has been confirmed.
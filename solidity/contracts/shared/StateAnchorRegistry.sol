@@ -0,0 +1,43 @@
+// SPDX-License-Identifier: Apache-2.0
+pragma solidity ^0.8.20;
+
+import {MerkleProof} from "@openzeppelin/contracts/utils/cryptography/MerkleProof.sol";
+
+/**
+ * @title StateAnchorRegistry
+ * @dev Records merkle roots of the IDs of confirmed Paladin states, so that the existence
+ *      of a private state at a point in time can be proven without revealing its data.
+ *
+ *      Leaves are keccak256(stateId), and pairs are hashed in sorted order, which is
+ *      compatible with the OpenZeppelin MerkleProof library.
+ */
+contract StateAnchorRegistry {
+    event StateAnchored(
+        address indexed submitter,
+        string domain,
+        bytes32 indexed root,
+        uint256 leafCount
+    );
+
+    // submitter => root => the block number the root was first anchored in
+    mapping(address => mapping(bytes32 => uint256)) public anchoredAt;
+
+    function anchor(
+        string calldata domain,
+        bytes32 root,
+        uint256 leafCount
+    ) external {
+        if (anchoredAt[msg.sender][root] == 0) {
+            anchoredAt[msg.sender][root] = block.number;
+        }
+        emit StateAnchored(msg.sender, domain, root, leafCount);
+    }
+
+    function verify(
+        bytes32 root,
+        bytes32 leaf,
+        bytes32[] calldata proof
+    ) external pure returns (bool) {
+        return MerkleProof.verifyCalldata(proof, root, leaf);
+    }
+}
@@ -97,6 +97,16 @@ contract NotoTrackerPublicERC20 is INotoHooks, ERC20 {
         // do nothing
     }
 
+    function onSetLockExpiry(
+        address sender,
+        bytes32 lockId,
+        uint256 expiry,
+        address beneficiary,
+        PreparedTransaction calldata prepared
+    ) external override {
+        // do nothing
+    }
+
     function handleDelegateUnlock(
         address sender,
         bytes32 lockId,
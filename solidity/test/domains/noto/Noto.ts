@@ -1,4 +1,7 @@
-import { loadFixture } from "@nomicfoundation/hardhat-toolbox/network-helpers";
+import {
+  loadFixture,
+  time,
+} from "@nomicfoundation/hardhat-toolbox/network-helpers";
 import { expect } from "chai";
 import { ethers } from "hardhat";
 import { Noto } from "../../../typechain-types";
@@ -7,6 +10,7 @@ import {
   doDelegateLock,
   doLock,
   doPrepareUnlock,
+  doSetLockExpiry,
   doTransfer,
   doUnlock,
   fakeTXO,
@@ -142,4 +146,105 @@ describe("Noto", function () {
     // Perform the prepared unlock
     await doUnlock(delegate, noto, [locked2], [], [txo5], unlockData);
   });
+
+  it("lock with expiry and release", async function () {
+    const { noto, notary } = await loadFixture(deployNotoFixture);
+    const [_, delegate, beneficiary] = await ethers.getSigners();
+
+    const txo1 = fakeTXO();
+    const txo2 = fakeTXO();
+    const txo3 = fakeTXO();
+    const locked1 = fakeTXO();
+
+    // Lock a UTXO and prepare an unlock to a counterparty
+    await doTransfer(notary, noto, [], [txo1], randomBytes32());
+    await doLock(notary, noto, [txo1], [], [locked1], randomBytes32());
+    const unlockData = randomBytes32();
+    const unlockHash = await newUnlockHash(
+      noto,
+      [locked1],
+      [],
+      [txo2],
+      unlockData
+    );
+    await doPrepareUnlock(notary, noto, [locked1], unlockHash, unlockData);
+
+    // Expiry must be in the future
+    const releaseData = randomBytes32();
+    const releaseHash = await newUnlockHash(
+      noto,
+      [locked1],
+      [],
+      [txo3],
+      releaseData
+    );
+    const now = await time.latest();
+    await expect(
+      doSetLockExpiry(
+        notary,
+        noto,
+        unlockHash,
+        now,
+        beneficiary.address,
+        releaseHash,
+        randomBytes32()
+      )
+    ).to.be.rejectedWith("NotoInvalidExpiry");
+
+    // Set the expiry, then delegate the lock
+    const expiry = now + 3600;
+    await doSetLockExpiry(
+      notary,
+      noto,
+      unlockHash,
+      expiry,
+      beneficiary.address,
+      releaseHash,
+      randomBytes32()
+    );
+    const lockExpiry = await noto.getLockExpiry(unlockHash);
+    expect(lockExpiry.expiry).to.equal(expiry);
+    expect(lockExpiry.beneficiary).to.equal(beneficiary.address);
+    expect(lockExpiry.releaseHash).to.equal(releaseHash);
+    await doDelegateLock(
+      notary,
+      noto,
+      unlockHash,
+      delegate.address,
+      randomBytes32()
+    );
+
+    // Terms cannot be changed after delegation
+    await expect(
+      doSetLockExpiry(
+        notary,
+        noto,
+        unlockHash,
+        expiry + 3600,
+        notary.address,
+        releaseHash,
+        randomBytes32()
+      )
+    ).to.be.rejectedWith("NotoAlreadyPrepared");
+
+    // Release cannot be triggered before the expiry
+    await expect(
+      doUnlock(beneficiary, noto, [locked1], [], [txo3], releaseData)
+    ).to.be.rejectedWith("NotoInvalidDelegate");
+
+    await time.increaseTo(expiry);
+
+    // After the expiry, the delegate can no longer perform the prepared unlock
+    await expect(
+      doUnlock(delegate, noto, [locked1], [], [txo2], unlockData)
+    ).to.be.rejectedWith("NotoInvalidDelegate");
+    await expect(
+      doUnlock(beneficiary, noto, [locked1], [], [txo2], unlockData)
+    ).to.be.rejectedWith("NotoInvalidUnlockHash");
+
+    // Beneficiary performs the release
+    await doUnlock(beneficiary, noto, [locked1], [], [txo3], releaseData);
+    expect(await noto.isUnspent(txo3)).to.equal(true);
+    expect((await noto.getLockExpiry(unlockHash)).expiry).to.equal(0);
+  });
 });
@@ -220,3 +220,28 @@ export async function doDelegateLock(
     expect(event?.args.data).to.deep.equal(data);
   }
 }
+
+export async function doSetLockExpiry(
+  notary: Signer,
+  noto: Noto,
+  unlockHash: string,
+  expiry: number,
+  beneficiary: string,
+  releaseHash: string,
+  data: string
+) {
+  const tx = await noto
+    .connect(notary)
+    .setLockExpiry(unlockHash, expiry, beneficiary, releaseHash, "0x", data);
+  const results = await tx.wait();
+  expect(results).to.exist;
+
+  for (const log of results?.logs || []) {
+    const event = noto.interface.parseLog(log);
+    expect(event).to.exist;
+    expect(event?.name).to.equal("NotoLockExpirySet");
+    expect(event?.args.expiry).to.equal(expiry);
+    expect(event?.args.beneficiary).to.deep.equal(beneficiary);
+    expect(event?.args.data).to.deep.equal(data);
+  }
+}
@@ -0,0 +1,53 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/kaleido-io/paladin/testinfra/pkg/fakeevm"
+)
+
+// Runs the fake EVM chain on the same ports as the Besu node in the test docker-compose,
+// so it can be used in its place when running the testbed and domain tests locally
+func main() {
+	chainID := flag.Int64("chainid", fakeevm.DefaultChainID, "chain ID")
+	host := flag.String("host", "127.0.0.1", "listen address")
+	httpPort := flag.Int("http", 8545, "HTTP JSON/RPC port")
+	wsPort := flag.Int("ws", 8546, "WebSocket JSON/RPC port")
+	flag.Parse()
+
+	chain, err := fakeevm.Start(&fakeevm.Config{
+		ChainID:  *chainID,
+		Host:     *host,
+		HTTPPort: *httpPort,
+		WSPort:   *wsPort,
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to start: %s\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("Fake EVM chain %d listening on %s and %s\n", *chainID, chain.HTTPURL(), chain.WSURL())
+
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
+	<-sigs
+	_ = chain.Close()
+}
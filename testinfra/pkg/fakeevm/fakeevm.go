@@ -0,0 +1,250 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+// Package fakeevm runs an embedded, in-memory EVM chain with a JSON/RPC endpoint, that
+// can be used as the base ledger for the testbed and component tests instead of Besu.
+//
+// Blocks are mined instantly when a transaction arrives. Gas is free, so signing keys
+// do not need to be funded. In addition to the standard eth_ methods, the chain supports:
+//   - evm_snapshot - returns an ID that can be passed to evm_revert
+//   - evm_revert - rewinds the chain to the head block at the time of the snapshot
+//   - evm_increaseTime - mines an empty block, with a timestamp the given number of seconds ahead
+//   - evm_mine - mines a block with any pending transactions
+package fakeevm
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/eth"
+	"github.com/ethereum/go-ethereum/eth/catalyst"
+	"github.com/ethereum/go-ethereum/eth/downloader"
+	"github.com/ethereum/go-ethereum/eth/ethconfig"
+	"github.com/ethereum/go-ethereum/eth/filters"
+	"github.com/ethereum/go-ethereum/node"
+	"github.com/ethereum/go-ethereum/p2p"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+const DefaultChainID = 1337
+
+type Config struct {
+	ChainID  int64                       // defaults to DefaultChainID
+	Alloc    map[common.Address]*big.Int // initial balances
+	GasLimit uint64                      // block gas limit, defaults to the geth default
+	Host     string                      // defaults to 127.0.0.1
+	HTTPPort int                         // defaults to a free port
+	WSPort   int                         // defaults to a free port
+}
+
+type Chain struct {
+	mux       sync.Mutex
+	stack     *node.Node
+	eth       *eth.Ethereum
+	beacon    *catalyst.SimulatedBeacon
+	snapshots []*types.Header
+}
+
+// Start creates the chain at its genesis block, and starts the HTTP and WebSocket JSON/RPC
+// endpoints. Close must be called to stop it.
+func Start(conf *Config) (*Chain, error) {
+	if conf == nil {
+		conf = &Config{}
+	}
+	chainConfig := *params.AllDevChainProtocolChanges
+	chainConfig.ChainID = big.NewInt(DefaultChainID)
+	if conf.ChainID != 0 {
+		chainConfig.ChainID = big.NewInt(conf.ChainID)
+	}
+	alloc := types.GenesisAlloc{}
+	for addr, balance := range conf.Alloc {
+		alloc[addr] = types.Account{Balance: balance}
+	}
+
+	host := conf.Host
+	if host == "" {
+		host = "127.0.0.1"
+	}
+	modules := []string{"eth", "net", "web3", "evm", "dev"}
+	nodeConf := node.DefaultConfig
+	nodeConf.DataDir = ""
+	nodeConf.P2P = p2p.Config{NoDiscovery: true, MaxPeers: 0}
+	nodeConf.HTTPHost = host
+	nodeConf.HTTPPort = conf.HTTPPort
+	nodeConf.HTTPModules = modules
+	nodeConf.HTTPVirtualHosts = []string{"*"}
+	nodeConf.WSHost = host
+	nodeConf.WSPort = conf.WSPort
+	nodeConf.WSModules = modules
+	nodeConf.WSOrigins = []string{"*"}
+
+	ethConf := ethconfig.Defaults
+	ethConf.NetworkId = chainConfig.ChainID.Uint64()
+	ethConf.SyncMode = downloader.FullSync
+	ethConf.Genesis = &core.Genesis{
+		Config:   &chainConfig,
+		GasLimit: ethconfig.Defaults.Miner.GasCeil,
+		BaseFee:  new(big.Int), // gas is free, as on a Paladin Besu network with zeroBaseFee
+		Alloc:    alloc,
+	}
+	if conf.GasLimit != 0 {
+		ethConf.Genesis.GasLimit = conf.GasLimit
+		ethConf.Miner.GasCeil = conf.GasLimit
+	}
+	ethConf.RPCGasCap = 0 // no cap on eth_call / eth_estimateGas
+	ethConf.RPCTxFeeCap = 0
+
+	stack, err := node.New(&nodeConf)
+	if err != nil {
+		return nil, err
+	}
+	c := &Chain{stack: stack}
+	if err := c.init(&ethConf); err != nil {
+		_ = stack.Close()
+		return nil, err
+	}
+	return c, nil
+}
+
+func (c *Chain) init(ethConf *ethconfig.Config) (err error) {
+	if c.eth, err = eth.New(c.stack, ethConf); err != nil {
+		return err
+	}
+	filterSystem := filters.NewFilterSystem(c.eth.APIBackend, filters.Config{})
+	if c.beacon, err = catalyst.NewSimulatedBeacon(0, c.eth); err != nil {
+		return err
+	}
+	// With a zero period, registering the beacon API mines a block as soon as a transaction arrives
+	catalyst.RegisterSimulatedBeaconAPIs(c.stack, c.beacon)
+	c.stack.RegisterLifecycle(c.beacon)
+	c.stack.RegisterAPIs([]rpc.API{
+		{Namespace: "eth", Service: filters.NewFilterAPI(filterSystem)},
+		{Namespace: "eth", Service: &gasPriceAPI{chain: c}}, // registered after the eth service, so overrides its methods
+		{Namespace: "evm", Service: &evmAPI{chain: c}},
+	})
+	return c.stack.Start()
+}
+
+func (c *Chain) HTTPURL() string {
+	return c.stack.HTTPEndpoint()
+}
+
+func (c *Chain) WSURL() string {
+	return c.stack.WSEndpoint()
+}
+
+func (c *Chain) Close() error {
+	return c.stack.Close()
+}
+
+// BlockNumber returns the number of the current head block
+func (c *Chain) BlockNumber() uint64 {
+	return c.eth.BlockChain().CurrentBlock().Number.Uint64()
+}
+
+// Snapshot records the current head of the chain, and returns an ID that can be passed to Revert
+func (c *Chain) Snapshot() int {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	c.snapshots = append(c.snapshots, c.eth.BlockChain().CurrentBlock())
+	return len(c.snapshots) - 1
+}
+
+// Revert rewinds the chain to the snapshot. The snapshot, and any taken after it,
+// can no longer be used.
+func (c *Chain) Revert(id int) error {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	if id < 0 || id >= len(c.snapshots) {
+		return fmt.Errorf("snapshot %d not found", id)
+	}
+	// Rewinding the head (rather than forking) discards the transactions in the later blocks,
+	// instead of them being returned to the pool and mined again
+	snapshot := c.snapshots[id]
+	if err := c.eth.BlockChain().SetHead(snapshot.Number.Uint64()); err != nil {
+		return err
+	}
+	if err := c.eth.TxPool().Sync(); err != nil {
+		return err
+	}
+	c.snapshots = c.snapshots[:id]
+	return nil
+}
+
+// IncreaseTime mines an empty block with a timestamp d after the current head,
+// and all blocks after that will have a timestamp later than it
+func (c *Chain) IncreaseTime(d time.Duration) error {
+	if d < time.Second {
+		return errors.New("time must be increased by at least one second")
+	}
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	return c.beacon.AdjustTime(d)
+}
+
+// Mine mines a block immediately, including any pending transactions
+func (c *Chain) Mine() common.Hash {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	return c.beacon.Commit()
+}
+
+// evmAPI exposes the chain controls over JSON/RPC, using the same names as other development chains
+type evmAPI struct {
+	chain *Chain
+}
+
+func (api *evmAPI) Snapshot() hexutil.Uint64 {
+	return hexutil.Uint64(api.chain.Snapshot())
+}
+
+func (api *evmAPI) Revert(id hexutil.Uint64) (bool, error) {
+	if err := api.chain.Revert(int(id)); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (api *evmAPI) IncreaseTime(seconds hexutil.Uint64) (hexutil.Uint64, error) {
+	if err := api.chain.IncreaseTime(time.Duration(seconds) * time.Second); err != nil {
+		return 0, err
+	}
+	return hexutil.Uint64(api.chain.eth.BlockChain().CurrentBlock().Time), nil
+}
+
+func (api *evmAPI) Mine() common.Hash {
+	return api.chain.Mine()
+}
+
+// gasPriceAPI reports a zero gas price, as all gas is free on the chain
+type gasPriceAPI struct {
+	chain *Chain
+}
+
+func (api *gasPriceAPI) GasPrice() *hexutil.Big {
+	return (*hexutil.Big)(api.chain.eth.BlockChain().CurrentBlock().BaseFee)
+}
+
+func (api *gasPriceAPI) MaxPriorityFeePerGas() *hexutil.Big {
+	return (*hexutil.Big)(new(big.Int))
+}
@@ -0,0 +1,165 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package fakeevm
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Init code that deploys a contract with a runtime that emits LOG0 of the calldata
+// PUSH1 0x0a PUSH1 0x0c PUSH1 0x00 CODECOPY PUSH1 0x0a PUSH1 0x00 RETURN
+// runtime: CALLDATASIZE PUSH1 0x00 PUSH1 0x00 CALLDATACOPY CALLDATASIZE PUSH1 0x00 LOG0 STOP
+var logContractInitCode = common.FromHex("600a600c600039600a6000f3" + "3660006000373660" + "00a000")
+
+func newTestChain(t *testing.T) (*Chain, *ethclient.Client, *rpc.Client) {
+	chain, err := Start(&Config{ChainID: 12345})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = chain.Close() })
+
+	rpcClient, err := rpc.Dial(chain.WSURL())
+	require.NoError(t, err)
+	t.Cleanup(rpcClient.Close)
+	return chain, ethclient.NewClient(rpcClient), rpcClient
+}
+
+func sendTX(t *testing.T, client *ethclient.Client, nonce uint64, to *common.Address, data []byte) *types.Receipt {
+	ctx := context.Background()
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	chainID, err := client.ChainID(ctx)
+	require.NoError(t, err)
+
+	// unfunded key with zero gas price
+	tx, err := types.SignNewTx(key, types.LatestSignerForChainID(chainID), &types.LegacyTx{
+		Nonce:    nonce,
+		To:       to,
+		Gas:      1000000,
+		GasPrice: new(big.Int),
+		Data:     data,
+	})
+	require.NoError(t, err)
+	require.NoError(t, client.SendTransaction(ctx, tx))
+
+	var receipt *types.Receipt
+	require.Eventually(t, func() bool {
+		receipt, _ = client.TransactionReceipt(ctx, tx.Hash())
+		return receipt != nil
+	}, 5*time.Second, 10*time.Millisecond)
+	return receipt
+}
+
+func TestInstantMiningFreeGas(t *testing.T) {
+	ctx := context.Background()
+	chain, client, _ := newTestChain(t)
+
+	chainID, err := client.ChainID(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(12345), chainID.Int64())
+
+	gasPrice, err := client.SuggestGasPrice(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, gasPrice.Sign())
+
+	receipt := sendTX(t, client, 0, nil, logContractInitCode)
+	assert.Equal(t, types.ReceiptStatusSuccessful, receipt.Status)
+	assert.Equal(t, uint64(1), chain.BlockNumber())
+
+	receipt = sendTX(t, client, 0, &receipt.ContractAddress, []byte("hello"))
+	assert.Equal(t, types.ReceiptStatusSuccessful, receipt.Status)
+	require.Len(t, receipt.Logs, 1)
+	assert.Equal(t, []byte("hello"), receipt.Logs[0].Data)
+	assert.Equal(t, uint64(2), chain.BlockNumber())
+}
+
+func TestSnapshotRevert(t *testing.T) {
+	ctx := context.Background()
+	chain, client, _ := newTestChain(t)
+
+	snap := chain.Snapshot()
+	receipt := sendTX(t, client, 0, nil, logContractInitCode)
+	code, err := client.CodeAt(ctx, receipt.ContractAddress, nil)
+	require.NoError(t, err)
+	assert.NotEmpty(t, code)
+
+	require.NoError(t, chain.Revert(snap))
+	assert.Equal(t, uint64(0), chain.BlockNumber())
+	code, err = client.CodeAt(ctx, receipt.ContractAddress, nil)
+	require.NoError(t, err)
+	assert.Empty(t, code)
+
+	// snapshot is consumed
+	assert.Regexp(t, "snapshot 0 not found", chain.Revert(snap))
+	assert.Regexp(t, "not found", chain.Revert(-1))
+
+	// new blocks build on the reverted head
+	sendTX(t, client, 0, nil, logContractInitCode)
+	assert.Equal(t, uint64(1), chain.BlockNumber())
+}
+
+func TestEVMRPCMethods(t *testing.T) {
+	ctx := context.Background()
+	chain, client, rpcClient := newTestChain(t)
+
+	var snap hexutil.Uint64
+	require.NoError(t, rpcClient.CallContext(ctx, &snap, "evm_snapshot"))
+
+	var blockHash common.Hash
+	require.NoError(t, rpcClient.CallContext(ctx, &blockHash, "evm_mine"))
+	header, err := client.HeaderByNumber(ctx, nil)
+	require.NoError(t, err)
+	assert.Equal(t, header.Hash(), blockHash)
+
+	var newTime hexutil.Uint64
+	require.NoError(t, rpcClient.CallContext(ctx, &newTime, "evm_increaseTime", hexutil.Uint64(3600)))
+	assert.Equal(t, header.Time+3600, uint64(newTime))
+	assert.Equal(t, uint64(2), chain.BlockNumber())
+
+	err = rpcClient.CallContext(ctx, &newTime, "evm_increaseTime", hexutil.Uint64(0))
+	assert.Regexp(t, "at least one second", err)
+
+	var reverted bool
+	require.NoError(t, rpcClient.CallContext(ctx, &reverted, "evm_revert", snap))
+	assert.True(t, reverted)
+	assert.Equal(t, uint64(0), chain.BlockNumber())
+
+	err = rpcClient.CallContext(ctx, &reverted, "evm_revert", snap)
+	assert.Regexp(t, "not found", err)
+}
+
+func TestStartDefaults(t *testing.T) {
+	chain, err := Start(nil)
+	require.NoError(t, err)
+	defer chain.Close()
+	assert.Regexp(t, "^http://127.0.0.1:", chain.HTTPURL())
+	assert.Regexp(t, "^ws://127.0.0.1:", chain.WSURL())
+}
+
+func TestStartFail(t *testing.T) {
+	_, err := Start(&Config{GasLimit: 10000000, Host: "not a host"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,84 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// pldbindgen generates a typed Go binding for the private functions of a Paladin domain.
+// It is intended to be used from a go:generate directive, such as:
+//
+//	//go:generate go run github.com/kaleido-io/paladin/toolkit/cmd/pldbindgen -abi noto.json -descriptor noto.yaml -out noto_binding.go
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/kaleido-io/paladin/toolkit/pkg/bindgen"
+)
+
+func main() {
+	if err := run(context.Background(), os.Args[1:], os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "pldbindgen: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("pldbindgen", flag.ContinueOnError)
+	abiFile := flags.String("abi", "", "JSON ABI array, or compiler build artifact, with the private functions of the domain")
+	descriptorFile := flags.String("descriptor", "", "YAML or JSON domain descriptor, with the domain name and state schemas")
+	pkg := flags.String("package", os.Getenv("GOPACKAGE"), "Go package of the generated file (defaults to $GOPACKAGE under go generate)")
+	out := flags.String("out", "", "File to write the binding to (defaults to stdout)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *abiFile == "" || *descriptorFile == "" {
+		flags.Usage()
+		return fmt.Errorf("-abi and -descriptor are required")
+	}
+
+	abiData, err := os.ReadFile(*abiFile)
+	if err != nil {
+		return err
+	}
+	a, err := bindgen.ParseABI(ctx, abiData)
+	if err != nil {
+		return err
+	}
+	descriptorData, err := os.ReadFile(*descriptorFile)
+	if err != nil {
+		return err
+	}
+	descriptor, err := bindgen.ParseDomainDescriptor(ctx, descriptorData)
+	if err != nil {
+		return err
+	}
+
+	src, err := bindgen.Generate(ctx, &bindgen.Options{
+		Package:    *pkg,
+		ABI:        a,
+		Descriptor: descriptor,
+	})
+	if err != nil {
+		return err
+	}
+	if *out == "" {
+		_, err = stdout.Write(src)
+		return err
+	}
+	return os.WriteFile(*out, src, 0644)
+}
@@ -0,0 +1,88 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const exampleDir = "../../pkg/bindgen/example"
+
+func TestRunToFile(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "noto_binding.go")
+	err := run(context.Background(), []string{
+		"-abi", filepath.Join(exampleDir, "noto.json"),
+		"-descriptor", filepath.Join(exampleDir, "noto.yaml"),
+		"-package", "example",
+		"-out", out,
+	}, nil)
+	require.NoError(t, err)
+
+	generated, err := os.ReadFile(out)
+	require.NoError(t, err)
+	expected, err := os.ReadFile(filepath.Join(exampleDir, "noto_binding.go"))
+	require.NoError(t, err)
+	assert.Equal(t, string(expected), string(generated))
+}
+
+func TestRunToStdout(t *testing.T) {
+	stdout := new(bytes.Buffer)
+	err := run(context.Background(), []string{
+		"-abi", filepath.Join(exampleDir, "noto.json"),
+		"-descriptor", filepath.Join(exampleDir, "noto.yaml"),
+		"-package", "mypkg",
+	}, stdout)
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), "package mypkg")
+}
+
+func TestRunMissingFlags(t *testing.T) {
+	err := run(context.Background(), []string{"-abi", "noto.json"}, nil)
+	assert.Regexp(t, "-abi and -descriptor are required", err)
+}
+
+func TestRunBadFlag(t *testing.T) {
+	err := run(context.Background(), []string{"-wrong"}, nil)
+	assert.Error(t, err)
+}
+
+func TestRunBadFiles(t *testing.T) {
+	dir := t.TempDir()
+	notABI := filepath.Join(dir, "bad.json")
+	require.NoError(t, os.WriteFile(notABI, []byte(`{}`), 0644))
+	badDescriptor := filepath.Join(dir, "bad.yaml")
+	require.NoError(t, os.WriteFile(badDescriptor, []byte(`domain: [`), 0644))
+	exampleABI := filepath.Join(exampleDir, "noto.json")
+
+	err := run(context.Background(), []string{"-abi", filepath.Join(dir, "missing.json"), "-descriptor", badDescriptor}, nil)
+	assert.Error(t, err)
+	err = run(context.Background(), []string{"-abi", notABI, "-descriptor", badDescriptor}, nil)
+	assert.Regexp(t, "PD021205", err)
+	err = run(context.Background(), []string{"-abi", exampleABI, "-descriptor", filepath.Join(dir, "missing.yaml")}, nil)
+	assert.Error(t, err)
+	err = run(context.Background(), []string{"-abi", exampleABI, "-descriptor", badDescriptor}, nil)
+	assert.Regexp(t, "PD021206", err)
+	err = run(context.Background(), []string{"-abi", exampleABI, "-descriptor", filepath.Join(exampleDir, "noto.yaml"), "-package", ""}, nil)
+	assert.Regexp(t, "PD021200", err)
+}
@@ -0,0 +1,63 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bindgen
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/hyperledger/firefly-signer/pkg/abi"
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/common/go/pkg/pldmsgs"
+	"sigs.k8s.io/yaml"
+)
+
+// DomainDescriptor describes the parts of a domain that are not in the ABI of its private functions,
+// and are needed to generate a binding.
+type DomainDescriptor struct {
+	// Domain is the name the domain is registered with on the Paladin node
+	Domain string `json:"domain"`
+	// Contract is the name of the generated binding type - defaults to the domain name
+	Contract string `json:"contract,omitempty"`
+	// States are the schemas the domain registers for its states. Each must be a tuple with the same
+	// name as the schema registered by the domain, such as "NotoCoin"
+	States []*abi.Parameter `json:"states,omitempty"`
+}
+
+// ParseABI accepts either a JSON ABI array, or a compiler build artifact with an "abi" field
+func ParseABI(ctx context.Context, data []byte) (abi.ABI, error) {
+	var a abi.ABI
+	if err := json.Unmarshal(data, &a); err == nil {
+		return a, nil
+	}
+	var artifact struct {
+		ABI abi.ABI `json:"abi"`
+	}
+	if err := json.Unmarshal(data, &artifact); err != nil || len(artifact.ABI) == 0 {
+		return nil, i18n.NewError(ctx, pldmsgs.MsgBindgenInvalidABI)
+	}
+	return artifact.ABI, nil
+}
+
+// ParseDomainDescriptor accepts a domain descriptor in YAML or JSON
+func ParseDomainDescriptor(ctx context.Context, data []byte) (*DomainDescriptor, error) {
+	var dd DomainDescriptor
+	if err := yaml.Unmarshal(data, &dd); err != nil {
+		return nil, i18n.WrapError(ctx, err, pldmsgs.MsgBindgenInvalidDescriptor)
+	}
+	return &dd, nil
+}
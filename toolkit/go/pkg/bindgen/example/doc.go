@@ -0,0 +1,21 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package example contains a binding generated for a subset of the Noto private ABI,
+// to show how an application generates and uses a typed client for a domain.
+package example
+
+//go:generate go run github.com/kaleido-io/paladin/toolkit/cmd/pldbindgen -abi noto.json -descriptor noto.yaml -out noto_binding.go
@@ -0,0 +1,431 @@
+// Code generated by pldbindgen. DO NOT EDIT.
+
+package example
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/hyperledger/firefly-signer/pkg/abi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldclient"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/query"
+)
+
+// NotoDomain is the name of the domain Noto contracts are deployed with
+const NotoDomain = "noto"
+
+const notoABIJSON = `[
+  {
+    "type": "function",
+    "name": "mint",
+    "stateMutability": "nonpayable",
+    "inputs": [
+      {
+        "name": "to",
+        "type": "string",
+        "internalType": "string"
+      },
+      {
+        "name": "amount",
+        "type": "uint256",
+        "internalType": "uint256"
+      },
+      {
+        "name": "data",
+        "type": "bytes",
+        "internalType": "bytes"
+      }
+    ],
+    "outputs": []
+  },
+  {
+    "type": "function",
+    "name": "transfer",
+    "stateMutability": "nonpayable",
+    "inputs": [
+      {
+        "name": "to",
+        "type": "string",
+        "internalType": "string"
+      },
+      {
+        "name": "amount",
+        "type": "uint256",
+        "internalType": "uint256"
+      },
+      {
+        "name": "data",
+        "type": "bytes",
+        "internalType": "bytes"
+      }
+    ],
+    "outputs": []
+  },
+  {
+    "type": "function",
+    "name": "lock",
+    "stateMutability": "nonpayable",
+    "inputs": [
+      {
+        "name": "amount",
+        "type": "uint256",
+        "internalType": "uint256"
+      },
+      {
+        "name": "data",
+        "type": "bytes",
+        "internalType": "bytes"
+      }
+    ],
+    "outputs": []
+  },
+  {
+    "type": "function",
+    "name": "unlock",
+    "stateMutability": "nonpayable",
+    "inputs": [
+      {
+        "name": "lockId",
+        "type": "bytes32",
+        "internalType": "bytes32"
+      },
+      {
+        "name": "from",
+        "type": "string",
+        "internalType": "string"
+      },
+      {
+        "name": "recipients",
+        "type": "tuple[]",
+        "internalType": "struct UnlockRecipient[]",
+        "components": [
+          {
+            "name": "to",
+            "type": "address",
+            "internalType": "address"
+          },
+          {
+            "name": "amount",
+            "type": "uint256",
+            "internalType": "uint256"
+          }
+        ]
+      },
+      {
+        "name": "data",
+        "type": "bytes",
+        "internalType": "bytes"
+      }
+    ],
+    "outputs": []
+  },
+  {
+    "type": "function",
+    "name": "delegateLock",
+    "stateMutability": "nonpayable",
+    "inputs": [
+      {
+        "name": "lockId",
+        "type": "bytes32",
+        "internalType": "bytes32"
+      },
+      {
+        "name": "unlock",
+        "type": "tuple",
+        "internalType": "struct UnlockPublicParams",
+        "components": [
+          {
+            "name": "lockedInputs",
+            "type": "bytes32[]",
+            "internalType": "bytes32[]"
+          },
+          {
+            "name": "lockedOutputs",
+            "type": "bytes32[]",
+            "internalType": "bytes32[]"
+          },
+          {
+            "name": "outputs",
+            "type": "bytes32[]",
+            "internalType": "bytes32[]"
+          },
+          {
+            "name": "signature",
+            "type": "bytes",
+            "internalType": "bytes"
+          },
+          {
+            "name": "data",
+            "type": "bytes",
+            "internalType": "bytes"
+          }
+        ]
+      },
+      {
+        "name": "delegate",
+        "type": "address",
+        "internalType": "address"
+      },
+      {
+        "name": "data",
+        "type": "bytes",
+        "internalType": "bytes"
+      }
+    ],
+    "outputs": []
+  }
+]`
+
+// NotoABI is the ABI of the private functions of Noto contracts
+var NotoABI = func() abi.ABI {
+	var a abi.ABI
+	if err := json.Unmarshal([]byte(notoABIJSON), &a); err != nil {
+		panic(err)
+	}
+	return a
+}()
+
+// NotoMintParams are the inputs of mint(string,uint256,bytes)
+type NotoMintParams struct {
+	To     string               `json:"to"`
+	Amount *pldtypes.HexUint256 `json:"amount"`
+	Data   pldtypes.HexBytes    `json:"data"`
+}
+
+// NotoTransferParams are the inputs of transfer(string,uint256,bytes)
+type NotoTransferParams struct {
+	To     string               `json:"to"`
+	Amount *pldtypes.HexUint256 `json:"amount"`
+	Data   pldtypes.HexBytes    `json:"data"`
+}
+
+// NotoLockParams are the inputs of lock(uint256,bytes)
+type NotoLockParams struct {
+	Amount *pldtypes.HexUint256 `json:"amount"`
+	Data   pldtypes.HexBytes    `json:"data"`
+}
+
+// UnlockRecipient is the ABI tuple (address,uint256)
+type UnlockRecipient struct {
+	To     *pldtypes.EthAddress `json:"to"`
+	Amount *pldtypes.HexUint256 `json:"amount"`
+}
+
+// NotoUnlockParams are the inputs of unlock(bytes32,string,(address,uint256)[],bytes)
+type NotoUnlockParams struct {
+	LockId     pldtypes.Bytes32  `json:"lockId"`
+	From       string            `json:"from"`
+	Recipients []UnlockRecipient `json:"recipients"`
+	Data       pldtypes.HexBytes `json:"data"`
+}
+
+// UnlockPublicParams is the ABI tuple (bytes32[],bytes32[],bytes32[],bytes,bytes)
+type UnlockPublicParams struct {
+	LockedInputs  []pldtypes.Bytes32 `json:"lockedInputs"`
+	LockedOutputs []pldtypes.Bytes32 `json:"lockedOutputs"`
+	Outputs       []pldtypes.Bytes32 `json:"outputs"`
+	Signature     pldtypes.HexBytes  `json:"signature"`
+	Data          pldtypes.HexBytes  `json:"data"`
+}
+
+// NotoDelegateLockParams are the inputs of delegateLock(bytes32,(bytes32[],bytes32[],bytes32[],bytes,bytes),address,bytes)
+type NotoDelegateLockParams struct {
+	LockId   pldtypes.Bytes32     `json:"lockId"`
+	Unlock   UnlockPublicParams   `json:"unlock"`
+	Delegate *pldtypes.EthAddress `json:"delegate"`
+	Data     pldtypes.HexBytes    `json:"data"`
+}
+
+// NotoCoin is the ABI tuple (bytes32,string,uint256)
+type NotoCoin struct {
+	Salt   pldtypes.Bytes32     `json:"salt"`
+	Owner  string               `json:"owner"`
+	Amount *pldtypes.HexUint256 `json:"amount"`
+}
+
+// NotoLockedCoin is the ABI tuple (bytes32,bytes32,string,uint256)
+type NotoLockedCoin struct {
+	Salt   pldtypes.Bytes32     `json:"salt"`
+	LockId pldtypes.Bytes32     `json:"lockId"`
+	Owner  string               `json:"owner"`
+	Amount *pldtypes.HexUint256 `json:"amount"`
+}
+
+// NotoCoinState is a NotoCoin state of a Noto contract, with its data decoded
+type NotoCoinState struct {
+	State *pldapi.State
+	Data  NotoCoin
+}
+
+// NotoLockedCoinState is a NotoLockedCoin state of a Noto contract, with its data decoded
+type NotoLockedCoinState struct {
+	State *pldapi.State
+	Data  NotoLockedCoin
+}
+
+// Noto is a typed client for a Noto private smart contract
+type Noto struct {
+	c          pldclient.PaladinClient
+	address    pldtypes.EthAddress
+	schemaLock sync.Mutex
+	schemaIDs  map[string]pldtypes.Bytes32
+}
+
+// NewNoto returns a client for the Noto contract deployed at the address
+func NewNoto(c pldclient.PaladinClient, address pldtypes.EthAddress) *Noto {
+	return &Noto{
+		c:         c,
+		address:   address,
+		schemaIDs: map[string]pldtypes.Bytes32{},
+	}
+}
+
+// ContractAddress returns the address of the contract
+func (b *Noto) ContractAddress() pldtypes.EthAddress {
+	return b.address
+}
+
+// BuildMint returns a builder for mint(string,uint256,bytes), that can be customized before it is submitted
+func (b *Noto) BuildMint(ctx context.Context, from string, params *NotoMintParams) pldclient.TxBuilder {
+	return b.c.ForABI(ctx, NotoABI).
+		Private().
+		Domain(NotoDomain).
+		To(&b.address).
+		Function("mint(string,uint256,bytes)").
+		From(from).
+		Inputs(params)
+}
+
+// Mint submits mint(string,uint256,bytes). Use Wait() on the result to await the receipt.
+func (b *Noto) Mint(ctx context.Context, from string, params *NotoMintParams) pldclient.SentTransaction {
+	return b.BuildMint(ctx, from, params).Send()
+}
+
+// BuildTransfer returns a builder for transfer(string,uint256,bytes), that can be customized before it is submitted
+func (b *Noto) BuildTransfer(ctx context.Context, from string, params *NotoTransferParams) pldclient.TxBuilder {
+	return b.c.ForABI(ctx, NotoABI).
+		Private().
+		Domain(NotoDomain).
+		To(&b.address).
+		Function("transfer(string,uint256,bytes)").
+		From(from).
+		Inputs(params)
+}
+
+// Transfer submits transfer(string,uint256,bytes). Use Wait() on the result to await the receipt.
+func (b *Noto) Transfer(ctx context.Context, from string, params *NotoTransferParams) pldclient.SentTransaction {
+	return b.BuildTransfer(ctx, from, params).Send()
+}
+
+// BuildLock returns a builder for lock(uint256,bytes), that can be customized before it is submitted
+func (b *Noto) BuildLock(ctx context.Context, from string, params *NotoLockParams) pldclient.TxBuilder {
+	return b.c.ForABI(ctx, NotoABI).
+		Private().
+		Domain(NotoDomain).
+		To(&b.address).
+		Function("lock(uint256,bytes)").
+		From(from).
+		Inputs(params)
+}
+
+// Lock submits lock(uint256,bytes). Use Wait() on the result to await the receipt.
+func (b *Noto) Lock(ctx context.Context, from string, params *NotoLockParams) pldclient.SentTransaction {
+	return b.BuildLock(ctx, from, params).Send()
+}
+
+// BuildUnlock returns a builder for unlock(bytes32,string,(address,uint256)[],bytes), that can be customized before it is submitted
+func (b *Noto) BuildUnlock(ctx context.Context, from string, params *NotoUnlockParams) pldclient.TxBuilder {
+	return b.c.ForABI(ctx, NotoABI).
+		Private().
+		Domain(NotoDomain).
+		To(&b.address).
+		Function("unlock(bytes32,string,(address,uint256)[],bytes)").
+		From(from).
+		Inputs(params)
+}
+
+// Unlock submits unlock(bytes32,string,(address,uint256)[],bytes). Use Wait() on the result to await the receipt.
+func (b *Noto) Unlock(ctx context.Context, from string, params *NotoUnlockParams) pldclient.SentTransaction {
+	return b.BuildUnlock(ctx, from, params).Send()
+}
+
+// BuildDelegateLock returns a builder for delegateLock(bytes32,(bytes32[],bytes32[],bytes32[],bytes,bytes),address,bytes), that can be customized before it is submitted
+func (b *Noto) BuildDelegateLock(ctx context.Context, from string, params *NotoDelegateLockParams) pldclient.TxBuilder {
+	return b.c.ForABI(ctx, NotoABI).
+		Private().
+		Domain(NotoDomain).
+		To(&b.address).
+		Function("delegateLock(bytes32,(bytes32[],bytes32[],bytes32[],bytes,bytes),address,bytes)").
+		From(from).
+		Inputs(params)
+}
+
+// DelegateLock submits delegateLock(bytes32,(bytes32[],bytes32[],bytes32[],bytes,bytes),address,bytes). Use Wait() on the result to await the receipt.
+func (b *Noto) DelegateLock(ctx context.Context, from string, params *NotoDelegateLockParams) pldclient.SentTransaction {
+	return b.BuildDelegateLock(ctx, from, params).Send()
+}
+
+// QueryNotoCoinStates queries the NotoCoin states of the contract
+func (b *Noto) QueryNotoCoinStates(ctx context.Context, jq *query.QueryJSON, status pldapi.StateStatusQualifier) ([]*NotoCoinState, error) {
+	schemaID, err := b.schemaID(ctx, "NotoCoin")
+	if err != nil {
+		return nil, err
+	}
+	states, err := b.c.StateStore().QueryContractStates(ctx, NotoDomain, b.address, schemaID, jq, status)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]*NotoCoinState, len(states))
+	for i, s := range states {
+		results[i] = &NotoCoinState{State: s}
+		if err := json.Unmarshal(s.Data, &results[i].Data); err != nil {
+			return nil, err
+		}
+	}
+	return results, nil
+}
+
+// QueryNotoLockedCoinStates queries the NotoLockedCoin states of the contract
+func (b *Noto) QueryNotoLockedCoinStates(ctx context.Context, jq *query.QueryJSON, status pldapi.StateStatusQualifier) ([]*NotoLockedCoinState, error) {
+	schemaID, err := b.schemaID(ctx, "NotoLockedCoin")
+	if err != nil {
+		return nil, err
+	}
+	states, err := b.c.StateStore().QueryContractStates(ctx, NotoDomain, b.address, schemaID, jq, status)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]*NotoLockedCoinState, len(states))
+	for i, s := range states {
+		results[i] = &NotoLockedCoinState{State: s}
+		if err := json.Unmarshal(s.Data, &results[i].Data); err != nil {
+			return nil, err
+		}
+	}
+	return results, nil
+}
+
+// schemaID finds the ID of a schema registered by the domain, which is the same for every contract
+func (b *Noto) schemaID(ctx context.Context, name string) (pldtypes.Bytes32, error) {
+	b.schemaLock.Lock()
+	defer b.schemaLock.Unlock()
+	if id, ok := b.schemaIDs[name]; ok {
+		return id, nil
+	}
+	schemas, err := b.c.StateStore().ListSchemas(ctx, NotoDomain)
+	if err != nil {
+		return pldtypes.Bytes32{}, err
+	}
+	for _, s := range schemas {
+		var def abi.Parameter
+		if err := json.Unmarshal(s.Definition, &def); err == nil && def.Name == name {
+			b.schemaIDs[name] = s.ID
+			return s.ID, nil
+		}
+	}
+	return pldtypes.Bytes32{}, fmt.Errorf("schema %s is not registered by domain %s", name, NotoDomain)
+}
@@ -0,0 +1,159 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package example
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldclient"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/query"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/rpcclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testRPC answers JSON-RPC calls from canned handlers, counting the calls to each method
+type testRPC struct {
+	t        *testing.T
+	handlers map[string]func(params ...any) (any, error)
+	calls    map[string]int
+}
+
+func (r *testRPC) CallRPC(ctx context.Context, result any, method string, params ...any) rpcclient.ErrorRPC {
+	r.calls[method]++
+	handler := r.handlers[method]
+	require.NotNil(r.t, handler, "unexpected call to %s", method)
+	res, err := handler(params...)
+	if err != nil {
+		return rpcclient.WrapRPCError(rpcclient.RPCCodeInternalError, err)
+	}
+	b, err := json.Marshal(res)
+	require.NoError(r.t, err)
+	require.NoError(r.t, json.Unmarshal(b, result))
+	return nil
+}
+
+func newTestNoto(t *testing.T, handlers map[string]func(params ...any) (any, error)) (*Noto, *testRPC) {
+	rpc := &testRPC{t: t, handlers: handlers, calls: map[string]int{}}
+	return NewNoto(pldclient.Wrap(rpc), *pldtypes.RandAddress()), rpc
+}
+
+func TestUnlockSendsTypedInputs(t *testing.T) {
+	txID := uuid.New()
+	recipient := pldtypes.RandAddress()
+	lockID := pldtypes.RandBytes32()
+	noto, _ := newTestNoto(t, map[string]func(params ...any) (any, error){
+		"ptx_sendTransaction": func(params ...any) (any, error) {
+			tx := params[0].(*pldapi.TransactionInput)
+			assert.Equal(t, pldapi.TransactionTypePrivate.Enum(), tx.Type)
+			assert.Equal(t, NotoDomain, tx.Domain)
+			assert.Equal(t, "alice@node1", tx.From)
+			assert.Equal(t, "unlock(bytes32,string,(address,uint256)[],bytes)", tx.Function)
+			assert.JSONEq(t, fmt.Sprintf(`{
+				"lockId": "%s",
+				"from": "alice@node1",
+				"recipients": [{"to": "%s", "amount": "100"}],
+				"data": "0x"
+			}`, lockID, recipient), tx.Data.String())
+			return txID, nil
+		},
+	})
+
+	sent := noto.Unlock(context.Background(), "alice@node1", &NotoUnlockParams{
+		LockId: lockID,
+		From:   "alice@node1",
+		Recipients: []UnlockRecipient{
+			{To: recipient, Amount: pldtypes.Uint64ToUint256(100)},
+		},
+		Data: pldtypes.HexBytes{},
+	})
+	require.NoError(t, sent.Error())
+	assert.Equal(t, txID, *sent.ID())
+}
+
+func TestQueryNotoCoinStates(t *testing.T) {
+	schemaID := pldtypes.RandBytes32()
+	var contractAddr pldtypes.EthAddress
+	noto, rpc := newTestNoto(t, map[string]func(params ...any) (any, error){
+		"pstate_listSchemas": func(params ...any) (any, error) {
+			assert.Equal(t, NotoDomain, params[0])
+			return []*pldapi.Schema{
+				{ID: pldtypes.RandBytes32(), Definition: pldtypes.RawJSON(`{"name":"NotoLockedCoin"}`)},
+				{ID: schemaID, Definition: pldtypes.RawJSON(`{"name":"NotoCoin"}`)},
+			}, nil
+		},
+		"pstate_queryContractStates": func(params ...any) (any, error) {
+			assert.Equal(t, NotoDomain, params[0])
+			assert.Equal(t, contractAddr, params[1])
+			assert.Equal(t, schemaID, params[2])
+			return []*pldapi.State{
+				{StateBase: pldapi.StateBase{Data: pldtypes.RawJSON(`{"owner":"bob@node2","amount":"0x0a"}`)}},
+			}, nil
+		},
+	})
+	contractAddr = noto.ContractAddress()
+
+	for i := 0; i < 2; i++ {
+		coins, err := noto.QueryNotoCoinStates(context.Background(), query.NewQueryBuilder().Limit(10).Query(), pldapi.StateStatusAvailable)
+		require.NoError(t, err)
+		require.Len(t, coins, 1)
+		assert.Equal(t, "bob@node2", coins[0].Data.Owner)
+		assert.Equal(t, int64(10), coins[0].Data.Amount.Int().Int64())
+	}
+	assert.Equal(t, 1, rpc.calls["pstate_listSchemas"], "schema ID should be cached")
+}
+
+func TestQueryNotoCoinStatesSchemaNotRegistered(t *testing.T) {
+	noto, _ := newTestNoto(t, map[string]func(params ...any) (any, error){
+		"pstate_listSchemas": func(params ...any) (any, error) {
+			return []*pldapi.Schema{}, nil
+		},
+	})
+	_, err := noto.QueryNotoLockedCoinStates(context.Background(), query.NewQueryBuilder().Limit(10).Query(), pldapi.StateStatusAvailable)
+	assert.Regexp(t, "schema NotoLockedCoin is not registered by domain noto", err)
+}
+
+func TestQueryNotoCoinStatesBadData(t *testing.T) {
+	noto, _ := newTestNoto(t, map[string]func(params ...any) (any, error){
+		"pstate_listSchemas": func(params ...any) (any, error) {
+			return []*pldapi.Schema{{Definition: pldtypes.RawJSON(`{"name":"NotoCoin"}`)}}, nil
+		},
+		"pstate_queryContractStates": func(params ...any) (any, error) {
+			return []*pldapi.State{
+				{StateBase: pldapi.StateBase{Data: pldtypes.RawJSON(`{"amount":false}`)}},
+			}, nil
+		},
+	})
+	_, err := noto.QueryNotoCoinStates(context.Background(), query.NewQueryBuilder().Limit(10).Query(), pldapi.StateStatusAvailable)
+	assert.Error(t, err)
+}
+
+func TestQueryNotoCoinStatesFail(t *testing.T) {
+	noto, _ := newTestNoto(t, map[string]func(params ...any) (any, error){
+		"pstate_listSchemas": func(params ...any) (any, error) {
+			return nil, fmt.Errorf("pop")
+		},
+	})
+	_, err := noto.QueryNotoCoinStates(context.Background(), query.NewQueryBuilder().Limit(10).Query(), pldapi.StateStatusAvailable)
+	assert.Regexp(t, "pop", err)
+}
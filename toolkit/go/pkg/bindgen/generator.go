@@ -0,0 +1,328 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package bindgen generates typed Go bindings for the private functions of a Paladin domain,
+// so applications can build, submit and await transactions, and query the resulting states,
+// without hand-writing JSON against the ABI.
+package bindgen
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/hyperledger/firefly-signer/pkg/abi"
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/common/go/pkg/pldmsgs"
+)
+
+type Options struct {
+	Package    string            // the Go package of the generated file
+	ABI        abi.ABI           // the ABI of the private functions of the domain
+	Descriptor *DomainDescriptor // the domain name, and the state schemas
+}
+
+type goField struct {
+	Name     string
+	Type     string
+	JSONName string
+}
+
+type goStruct struct {
+	Name   string
+	Doc    string
+	Fields []*goField
+}
+
+type goMethod struct {
+	Name      string
+	Signature string
+	Params    string // empty if the function has no inputs
+	Result    string // empty if the function has no outputs
+	Call      bool   // view and pure functions are called, rather than sent as transactions
+}
+
+type goState struct {
+	Name       string
+	SchemaName string
+}
+
+type binding struct {
+	Package     string
+	Contract    string
+	Domain      string
+	ABIConst    string
+	ABIJSON     string
+	Structs     []*goStruct
+	Constructor *goMethod
+	Methods     []*goMethod
+	States      []*goState
+}
+
+type generator struct {
+	ctx         context.Context
+	b           *binding
+	structs     map[string]*goStruct
+	methodNames map[string]bool
+}
+
+// Generate returns the gofmt'd source of a binding for the domain
+func Generate(ctx context.Context, opts *Options) ([]byte, error) {
+	if opts.Package == "" {
+		return nil, i18n.NewError(ctx, pldmsgs.MsgBindgenPackageRequired)
+	}
+	if opts.Descriptor == nil || opts.Descriptor.Domain == "" {
+		return nil, i18n.NewError(ctx, pldmsgs.MsgBindgenDomainRequired)
+	}
+	abiJSON, err := json.MarshalIndent(opts.ABI, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	// A raw string keeps the ABI readable in the generated file, where it is safe to use one
+	abiLiteral := strconv.Quote(string(abiJSON))
+	if !strings.Contains(string(abiJSON), "`") {
+		abiLiteral = "`" + string(abiJSON) + "`"
+	}
+	contract := opts.Descriptor.Contract
+	if contract == "" {
+		contract = goName(opts.Descriptor.Domain)
+	}
+	g := &generator{
+		ctx: ctx,
+		b: &binding{
+			Package:  opts.Package,
+			Contract: contract,
+			Domain:   opts.Descriptor.Domain,
+			ABIConst: string(unicode.ToLower(rune(contract[0]))) + contract[1:] + "ABIJSON",
+			ABIJSON:  abiLiteral,
+		},
+		structs: map[string]*goStruct{},
+		// Reserved for the methods every binding has
+		methodNames: map[string]bool{"ContractAddress": true},
+	}
+	if err := g.buildFunctions(opts.ABI); err != nil {
+		return nil, err
+	}
+	if err := g.buildStates(opts.Descriptor.States); err != nil {
+		return nil, err
+	}
+
+	buf := new(bytes.Buffer)
+	if err := bindingTemplate.Execute(buf, g.b); err != nil {
+		return nil, err
+	}
+	return format.Source(buf.Bytes())
+}
+
+func (g *generator) buildFunctions(a abi.ABI) error {
+	for _, e := range a {
+		switch {
+		case e.Type == abi.Constructor:
+			m := &goMethod{Signature: e.String()}
+			params, err := g.buildParamsStruct(g.b.Contract+"ConstructorParams", "inputs", e, e.Inputs, true)
+			if err != nil {
+				return err
+			}
+			m.Params = params
+			g.b.Constructor = m
+		case e.Type == abi.Function && e.Name != "":
+			m := &goMethod{
+				Name:      g.uniqueMethodName(goName(e.Name)),
+				Signature: e.String(),
+				Call:      e.StateMutability == abi.View || e.StateMutability == abi.Pure || e.Constant,
+			}
+			var err error
+			if m.Params, err = g.buildParamsStruct(g.b.Contract+m.Name+"Params", "inputs", e, e.Inputs, true); err != nil {
+				return err
+			}
+			if m.Call {
+				if m.Result, err = g.buildParamsStruct(g.b.Contract+m.Name+"Result", "outputs", e, e.Outputs, false); err != nil {
+					return err
+				}
+			}
+			g.b.Methods = append(g.b.Methods, m)
+		}
+	}
+	return nil
+}
+
+func (g *generator) buildStates(states []*abi.Parameter) error {
+	for _, p := range states {
+		tc, err := p.TypeComponentTreeCtx(g.ctx)
+		if err != nil {
+			return err
+		}
+		if tc.ComponentType() != abi.TupleComponent {
+			return i18n.NewError(g.ctx, pldmsgs.MsgBindgenStateNotTuple, p.Name)
+		}
+		name, err := g.goType(tc, p, goName(p.Name))
+		if err != nil {
+			return err
+		}
+		g.b.States = append(g.b.States, &goState{
+			Name:       name,
+			SchemaName: p.Name,
+		})
+		g.methodNames["Query"+name+"States"] = true
+	}
+	return nil
+}
+
+// uniqueMethodName adds a numeric suffix to overloaded functions, and anything else that
+// would clash with a method already on the binding
+func (g *generator) uniqueMethodName(base string) string {
+	name := base
+	for i := 1; g.methodNames[name] || g.methodNames["Build"+name]; i++ {
+		name = base + strconv.Itoa(i)
+	}
+	g.methodNames[name] = true
+	g.methodNames["Build"+name] = true
+	return name
+}
+
+// buildParamsStruct generates a struct for a list of inputs or outputs, returning
+// an empty name if there are none. Unnamed outputs are keyed by their index, in the
+// same way the Paladin node serializes them.
+func (g *generator) buildParamsStruct(name, kind string, e *abi.Entry, params abi.ParameterArray, requireNames bool) (string, error) {
+	if len(params) == 0 {
+		return "", nil
+	}
+	s := &goStruct{
+		Name: name,
+		Doc:  fmt.Sprintf("%s are the %s of %s", name, kind, e.String()),
+	}
+	for i, p := range params {
+		jsonName := p.Name
+		fieldName := goName(p.Name)
+		if fieldName == "" {
+			if requireNames {
+				return "", i18n.NewError(g.ctx, pldmsgs.MsgBindgenUnnamedParameter, i, e.String())
+			}
+			jsonName = strconv.Itoa(i)
+			fieldName = "Result" + jsonName
+		}
+		tc, err := p.TypeComponentTreeCtx(g.ctx)
+		if err != nil {
+			return "", err
+		}
+		goType, err := g.goType(tc, p, name+fieldName)
+		if err != nil {
+			return "", err
+		}
+		s.Fields = append(s.Fields, &goField{Name: fieldName, Type: goType, JSONName: jsonName})
+	}
+	g.addStruct(s)
+	return name, nil
+}
+
+func (g *generator) addStruct(s *goStruct) {
+	if _, exists := g.structs[s.Name]; !exists {
+		g.structs[s.Name] = s
+		g.b.Structs = append(g.b.Structs, s)
+	}
+}
+
+// goType maps an ABI type to the Go type used in the binding. The types are the same ones
+// used throughout the Paladin SDK, so that they serialize to JSON the node will accept.
+func (g *generator) goType(tc abi.TypeComponent, p *abi.Parameter, structName string) (string, error) {
+	switch tc.ComponentType() {
+	case abi.ElementaryComponent:
+		switch tc.ElementaryType().BaseType() {
+		case abi.BaseTypeUInt:
+			return "*pldtypes.HexUint256", nil
+		case abi.BaseTypeInt:
+			return "*pldtypes.HexInt256", nil
+		case abi.BaseTypeAddress:
+			return "*pldtypes.EthAddress", nil
+		case abi.BaseTypeBool:
+			return "bool", nil
+		case abi.BaseTypeString:
+			return "string", nil
+		case abi.BaseTypeBytes:
+			if tc.ElementaryFixed() && tc.ElementaryM() == 32 {
+				return "pldtypes.Bytes32", nil
+			}
+			return "pldtypes.HexBytes", nil
+		}
+	case abi.FixedArrayComponent, abi.DynamicArrayComponent:
+		childType, err := g.goType(tc.ArrayChild(), p, structName)
+		if err != nil {
+			return "", err
+		}
+		return "[]" + childType, nil
+	case abi.TupleComponent:
+		s := &goStruct{Name: tupleStructName(p, structName)}
+		s.Doc = fmt.Sprintf("%s is the ABI tuple %s", s.Name, tc.String())
+		for i, child := range tc.TupleChildren() {
+			cp := child.Parameter()
+			fieldName := goName(cp.Name)
+			if fieldName == "" {
+				return "", i18n.NewError(g.ctx, pldmsgs.MsgBindgenUnnamedParameter, i, p.Name)
+			}
+			childType, err := g.goType(child, cp, s.Name+fieldName)
+			if err != nil {
+				return "", err
+			}
+			s.Fields = append(s.Fields, &goField{Name: fieldName, Type: childType, JSONName: cp.Name})
+		}
+		g.addStruct(s)
+		return s.Name, nil
+	}
+	return "", i18n.NewError(g.ctx, pldmsgs.MsgBindgenUnsupportedType, tc.String(), p.Name)
+}
+
+// tupleStructName uses the Solidity struct name where the compiler has provided it
+// (so "struct Noto.UnlockRecipient[]" becomes "UnlockRecipient"), falling back to a
+// name derived from where the tuple is used
+func tupleStructName(p *abi.Parameter, fallback string) string {
+	if solName, ok := strings.CutPrefix(p.InternalType, "struct "); ok {
+		if i := strings.Index(solName, "["); i >= 0 {
+			solName = solName[:i]
+		}
+		if i := strings.LastIndex(solName, "."); i >= 0 {
+			solName = solName[i+1:]
+		}
+		if name := goName(solName); name != "" {
+			return name
+		}
+	}
+	return fallback
+}
+
+// goName converts an ABI name such as "_lock_id" to an exported Go name such as "LockId"
+func goName(abiName string) string {
+	var b strings.Builder
+	for _, part := range strings.FieldsFunc(abiName, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		runes := []rune(part)
+		runes[0] = unicode.ToUpper(runes[0])
+		b.WriteString(string(runes))
+	}
+	name := b.String()
+	if name != "" && unicode.IsDigit([]rune(name)[0]) {
+		name = "X" + name
+	}
+	return name
+}
+
+var bindingTemplate = template.Must(template.New("binding").Parse(bindingTemplateText))
@@ -0,0 +1,285 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bindgen
+
+import (
+	"context"
+	"go/parser"
+	"go/token"
+	"os"
+	"testing"
+
+	"github.com/hyperledger/firefly-signer/pkg/abi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testABI = `[
+	{
+		"type": "constructor",
+		"inputs": [
+			{"name": "name", "type": "string"},
+			{"name": "decimals", "type": "uint8"}
+		]
+	},
+	{
+		"type": "function",
+		"name": "set_value",
+		"stateMutability": "nonpayable",
+		"inputs": [
+			{"name": "_key", "type": "bytes4"},
+			{"name": "delta", "type": "int64"},
+			{"name": "enabled", "type": "bool"},
+			{"name": "owners", "type": "address[2]"},
+			{
+				"name": "config",
+				"type": "tuple",
+				"components": [
+					{"name": "label", "type": "string"},
+					{"name": "nested", "type": "tuple", "components": [{"name": "id", "type": "bytes32"}]}
+				]
+			}
+		]
+	},
+	{
+		"type": "function",
+		"name": "set_value",
+		"stateMutability": "nonpayable",
+		"inputs": [{"name": "value", "type": "uint256"}]
+	},
+	{
+		"type": "function",
+		"name": "getValue",
+		"stateMutability": "view",
+		"inputs": [],
+		"outputs": [
+			{"name": "", "type": "uint256"},
+			{"name": "updated", "type": "bytes"}
+		]
+	},
+	{
+		"type": "function",
+		"name": "check",
+		"stateMutability": "pure",
+		"inputs": []
+	},
+	{
+		"type": "function",
+		"name": "contractAddress",
+		"inputs": []
+	},
+	{
+		"type": "event",
+		"name": "Changed",
+		"inputs": []
+	}
+]`
+
+func parseTestABI(t *testing.T, abiJSON string) abi.ABI {
+	a, err := ParseABI(context.Background(), []byte(abiJSON))
+	require.NoError(t, err)
+	return a
+}
+
+func TestGenerateExampleUpToDate(t *testing.T) {
+	ctx := context.Background()
+
+	abiData, err := os.ReadFile("example/noto.json")
+	require.NoError(t, err)
+	a, err := ParseABI(ctx, abiData)
+	require.NoError(t, err)
+	descriptorData, err := os.ReadFile("example/noto.yaml")
+	require.NoError(t, err)
+	descriptor, err := ParseDomainDescriptor(ctx, descriptorData)
+	require.NoError(t, err)
+
+	src, err := Generate(ctx, &Options{
+		Package:    "example",
+		ABI:        a,
+		Descriptor: descriptor,
+	})
+	require.NoError(t, err)
+
+	expected, err := os.ReadFile("example/noto_binding.go")
+	require.NoError(t, err)
+	assert.Equal(t, string(expected), string(src), "run go generate in the example directory")
+}
+
+func TestGenerateTypesAndMethods(t *testing.T) {
+	src, err := Generate(context.Background(), &Options{
+		Package:    "widgets",
+		ABI:        parseTestABI(t, testABI),
+		Descriptor: &DomainDescriptor{Domain: "widget-domain"},
+	})
+	require.NoError(t, err)
+	_, err = parser.ParseFile(token.NewFileSet(), "", src, parser.AllErrors)
+	require.NoError(t, err)
+	code := string(src)
+
+	// No states, so no state query support or imports
+	assert.NotContains(t, code, `"sync"`)
+	assert.NotContains(t, code, "schemaID")
+
+	assert.Contains(t, code, "type WidgetDomain struct")
+	assert.Contains(t, code, `const WidgetDomainDomain = "widget-domain"`)
+	assert.Contains(t, code, "func DeployWidgetDomain(ctx context.Context, c pldclient.PaladinClient, from string, params *WidgetDomainConstructorParams) pldclient.SentTransaction")
+	assert.Regexp(t, "Decimals +\\*pldtypes.HexUint256 +`json:\"decimals\"`", code)
+
+	// Type mapping, including nested tuples without a Solidity struct name
+	assert.Regexp(t, "Key +pldtypes.HexBytes +`json:\"_key\"`", code)
+	assert.Regexp(t, "Delta +\\*pldtypes.HexInt256 +`json:\"delta\"`", code)
+	assert.Regexp(t, "Enabled +bool +`json:\"enabled\"`", code)
+	assert.Regexp(t, "Owners +\\[\\]\\*pldtypes.EthAddress +`json:\"owners\"`", code)
+	assert.Regexp(t, "Config +WidgetDomainSetValueParamsConfig +`json:\"config\"`", code)
+	assert.Regexp(t, "Nested +WidgetDomainSetValueParamsConfigNested +`json:\"nested\"`", code)
+	assert.Regexp(t, "Id +pldtypes.Bytes32 +`json:\"id\"`", code)
+
+	// Overloads are numbered, and we avoid the accessor every binding has
+	assert.Contains(t, code, `func (b *WidgetDomain) SetValue(ctx context.Context, from string, params *WidgetDomainSetValueParams) pldclient.SentTransaction`)
+	assert.Contains(t, code, `func (b *WidgetDomain) SetValue1(ctx context.Context, from string, params *WidgetDomainSetValue1Params) pldclient.SentTransaction`)
+	assert.Contains(t, code, `Function("set_value(uint256)")`)
+	assert.Contains(t, code, `func (b *WidgetDomain) ContractAddress1(ctx context.Context, from string) pldclient.SentTransaction`)
+
+	// Calls, with unnamed outputs keyed by index
+	assert.Contains(t, code, `func (b *WidgetDomain) GetValue(ctx context.Context, from string) (*WidgetDomainGetValueResult, error)`)
+	assert.Regexp(t, "Result0 +\\*pldtypes.HexUint256 +`json:\"0\"`", code)
+	assert.Regexp(t, "Updated +pldtypes.HexBytes +`json:\"updated\"`", code)
+	assert.Contains(t, code, `func (b *WidgetDomain) Check(ctx context.Context, from string) error`)
+}
+
+func TestGenerateMissingPackage(t *testing.T) {
+	_, err := Generate(context.Background(), &Options{
+		Descriptor: &DomainDescriptor{Domain: "noto"},
+	})
+	assert.Regexp(t, "PD021200", err)
+}
+
+func TestGenerateMissingDomain(t *testing.T) {
+	_, err := Generate(context.Background(), &Options{
+		Package: "example",
+	})
+	assert.Regexp(t, "PD021201", err)
+}
+
+func TestGenerateUnsupportedType(t *testing.T) {
+	_, err := Generate(context.Background(), &Options{
+		Package: "example",
+		ABI: parseTestABI(t, `[{"type":"function","name":"f","inputs":[
+			{"name":"rate","type":"fixed128x18"}
+		]}]`),
+		Descriptor: &DomainDescriptor{Domain: "noto"},
+	})
+	assert.Regexp(t, "PD021202.*fixed128x18.*rate", err)
+}
+
+func TestGenerateBadType(t *testing.T) {
+	_, err := Generate(context.Background(), &Options{
+		Package: "example",
+		ABI: parseTestABI(t, `[{"type":"function","name":"f","inputs":[
+			{"name":"bad","type":"wrong"}
+		]}]`),
+		Descriptor: &DomainDescriptor{Domain: "noto"},
+	})
+	assert.Regexp(t, "FF22025", err)
+}
+
+func TestGenerateUnnamedInput(t *testing.T) {
+	_, err := Generate(context.Background(), &Options{
+		Package: "example",
+		ABI: parseTestABI(t, `[{"type":"function","name":"f","inputs":[
+			{"name":"","type":"uint256"}
+		]}]`),
+		Descriptor: &DomainDescriptor{Domain: "noto"},
+	})
+	assert.Regexp(t, "PD021204.*f\\(uint256\\)", err)
+}
+
+func TestGenerateUnnamedTupleComponent(t *testing.T) {
+	_, err := Generate(context.Background(), &Options{
+		Package: "example",
+		ABI: parseTestABI(t, `[{"type":"function","name":"f","inputs":[
+			{"name":"t","type":"tuple","components":[{"name":"","type":"uint256"}]}
+		]}]`),
+		Descriptor: &DomainDescriptor{Domain: "noto"},
+	})
+	assert.Regexp(t, "PD021204", err)
+}
+
+func TestGenerateStateNotTuple(t *testing.T) {
+	_, err := Generate(context.Background(), &Options{
+		Package: "example",
+		Descriptor: &DomainDescriptor{
+			Domain: "noto",
+			States: []*abi.Parameter{{Name: "NotoCoin", Type: "uint256"}},
+		},
+	})
+	assert.Regexp(t, "PD021203.*NotoCoin", err)
+}
+
+func TestGenerateStateBadType(t *testing.T) {
+	_, err := Generate(context.Background(), &Options{
+		Package: "example",
+		Descriptor: &DomainDescriptor{
+			Domain: "noto",
+			States: []*abi.Parameter{{Name: "NotoCoin", Type: "wrong"}},
+		},
+	})
+	assert.Regexp(t, "FF22025", err)
+}
+
+func TestGenerateStateBadComponent(t *testing.T) {
+	_, err := Generate(context.Background(), &Options{
+		Package: "example",
+		Descriptor: &DomainDescriptor{
+			Domain: "noto",
+			States: []*abi.Parameter{{Name: "NotoCoin", Type: "tuple", Components: abi.ParameterArray{
+				{Name: "rate", Type: "ufixed"},
+			}}},
+		},
+	})
+	assert.Regexp(t, "PD021202", err)
+}
+
+func TestParseABIArtifact(t *testing.T) {
+	a, err := ParseABI(context.Background(), []byte(`{"abi":[{"type":"function","name":"f"}]}`))
+	require.NoError(t, err)
+	assert.Equal(t, "f", a[0].Name)
+}
+
+func TestParseABIInvalid(t *testing.T) {
+	_, err := ParseABI(context.Background(), []byte(`{"bytecode":"0x"}`))
+	assert.Regexp(t, "PD021205", err)
+}
+
+func TestParseDomainDescriptorInvalid(t *testing.T) {
+	_, err := ParseDomainDescriptor(context.Background(), []byte(`domain: [`))
+	assert.Regexp(t, "PD021206", err)
+}
+
+func TestGoName(t *testing.T) {
+	assert.Equal(t, "LockId", goName("_lock_id"))
+	assert.Equal(t, "LockId", goName("lockId"))
+	assert.Equal(t, "X0", goName("0"))
+	assert.Equal(t, "", goName("_"))
+}
+
+func TestTupleStructName(t *testing.T) {
+	assert.Equal(t, "UnlockPublicParams", tupleStructName(&abi.Parameter{InternalType: "struct INotoPrivate.UnlockPublicParams"}, "Fallback"))
+	assert.Equal(t, "UnlockRecipient", tupleStructName(&abi.Parameter{InternalType: "struct UnlockRecipient[][2]"}, "Fallback"))
+	assert.Equal(t, "Fallback", tupleStructName(&abi.Parameter{InternalType: "struct _"}, "Fallback"))
+	assert.Equal(t, "Fallback", tupleStructName(&abi.Parameter{}, "Fallback"))
+}
@@ -0,0 +1,193 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bindgen
+
+const bindingTemplateText = `// Code generated by pldbindgen. DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+	"context"
+	"encoding/json"
+{{- if .States}}
+	"fmt"
+	"sync"
+{{- end}}
+
+	"github.com/hyperledger/firefly-signer/pkg/abi"
+{{- if .States}}
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+{{- end}}
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldclient"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+{{- if .States}}
+	"github.com/kaleido-io/paladin/sdk/go/pkg/query"
+{{- end}}
+)
+
+{{- $c := .Contract}}
+
+// {{$c}}Domain is the name of the domain {{$c}} contracts are deployed with
+const {{$c}}Domain = {{printf "%q" .Domain}}
+
+const {{.ABIConst}} = {{.ABIJSON}}
+
+// {{$c}}ABI is the ABI of the private functions of {{$c}} contracts
+var {{$c}}ABI = func() abi.ABI {
+	var a abi.ABI
+	if err := json.Unmarshal([]byte({{.ABIConst}}), &a); err != nil {
+		panic(err)
+	}
+	return a
+}()
+{{range .Structs}}
+{{- if .Doc}}
+// {{.Doc}}
+{{- end}}
+type {{.Name}} struct {
+{{- range .Fields}}
+	{{.Name}} {{.Type}} ` + "`" + `json:"{{.JSONName}}"` + "`" + `
+{{- end}}
+}
+{{end}}
+{{- range .States}}
+// {{.Name}}State is a {{.SchemaName}} state of a {{$c}} contract, with its data decoded
+type {{.Name}}State struct {
+	State *pldapi.State
+	Data  {{.Name}}
+}
+{{end}}
+// {{$c}} is a typed client for a {{$c}} private smart contract
+type {{$c}} struct {
+	c       pldclient.PaladinClient
+	address pldtypes.EthAddress
+{{- if .States}}
+	schemaLock sync.Mutex
+	schemaIDs  map[string]pldtypes.Bytes32
+{{- end}}
+}
+
+// New{{$c}} returns a client for the {{$c}} contract deployed at the address
+func New{{$c}}(c pldclient.PaladinClient, address pldtypes.EthAddress) *{{$c}} {
+	return &{{$c}}{
+		c:       c,
+		address: address,
+{{- if .States}}
+		schemaIDs: map[string]pldtypes.Bytes32{},
+{{- end}}
+	}
+}
+
+// ContractAddress returns the address of the contract
+func (b *{{$c}}) ContractAddress() pldtypes.EthAddress {
+	return b.address
+}
+{{with .Constructor}}
+// Deploy{{$c}} deploys a new {{$c}} contract. The address of the contract is in the
+// receipt, once the returned transaction completes.
+func Deploy{{$c}}(ctx context.Context, c pldclient.PaladinClient, from string{{if .Params}}, params *{{.Params}}{{end}}) pldclient.SentTransaction {
+	return c.ForABI(ctx, {{$c}}ABI).
+		Private().
+		Domain({{$c}}Domain).
+		Constructor().
+		From(from).
+{{- if .Params}}
+		Inputs(params).
+{{- end}}
+		Send()
+}
+{{end}}
+{{- range .Methods}}
+// Build{{.Name}} returns a builder for {{.Signature}}, that can be customized before it is submitted
+func (b *{{$c}}) Build{{.Name}}(ctx context.Context, from string{{if .Params}}, params *{{.Params}}{{end}}) pldclient.TxBuilder {
+	return b.c.ForABI(ctx, {{$c}}ABI).
+		Private().
+		Domain({{$c}}Domain).
+		To(&b.address).
+		Function({{printf "%q" .Signature}}).
+{{- if .Params}}
+		From(from).
+		Inputs(params)
+{{- else}}
+		From(from)
+{{- end}}
+}
+{{if not .Call}}
+// {{.Name}} submits {{.Signature}}. Use Wait() on the result to await the receipt.
+func (b *{{$c}}) {{.Name}}(ctx context.Context, from string{{if .Params}}, params *{{.Params}}{{end}}) pldclient.SentTransaction {
+	return b.Build{{.Name}}(ctx, from{{if .Params}}, params{{end}}).Send()
+}
+{{else if .Result}}
+// {{.Name}} calls {{.Signature}}, and returns the decoded outputs
+func (b *{{$c}}) {{.Name}}(ctx context.Context, from string{{if .Params}}, params *{{.Params}}{{end}}) (*{{.Result}}, error) {
+	result := &{{.Result}}{}
+	if err := b.Build{{.Name}}(ctx, from{{if .Params}}, params{{end}}).Outputs(result).Call(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+{{else}}
+// {{.Name}} calls {{.Signature}}
+func (b *{{$c}}) {{.Name}}(ctx context.Context, from string{{if .Params}}, params *{{.Params}}{{end}}) error {
+	return b.Build{{.Name}}(ctx, from{{if .Params}}, params{{end}}).Call()
+}
+{{end}}
+{{- end}}
+{{- range .States}}
+// Query{{.Name}}States queries the {{.SchemaName}} states of the contract
+func (b *{{$c}}) Query{{.Name}}States(ctx context.Context, jq *query.QueryJSON, status pldapi.StateStatusQualifier) ([]*{{.Name}}State, error) {
+	schemaID, err := b.schemaID(ctx, {{printf "%q" .SchemaName}})
+	if err != nil {
+		return nil, err
+	}
+	states, err := b.c.StateStore().QueryContractStates(ctx, {{$c}}Domain, b.address, schemaID, jq, status)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]*{{.Name}}State, len(states))
+	for i, s := range states {
+		results[i] = &{{.Name}}State{State: s}
+		if err := json.Unmarshal(s.Data, &results[i].Data); err != nil {
+			return nil, err
+		}
+	}
+	return results, nil
+}
+{{end}}
+{{- if .States}}
+// schemaID finds the ID of a schema registered by the domain, which is the same for every contract
+func (b *{{$c}}) schemaID(ctx context.Context, name string) (pldtypes.Bytes32, error) {
+	b.schemaLock.Lock()
+	defer b.schemaLock.Unlock()
+	if id, ok := b.schemaIDs[name]; ok {
+		return id, nil
+	}
+	schemas, err := b.c.StateStore().ListSchemas(ctx, {{$c}}Domain)
+	if err != nil {
+		return pldtypes.Bytes32{}, err
+	}
+	for _, s := range schemas {
+		var def abi.Parameter
+		if err := json.Unmarshal(s.Definition, &def); err == nil && def.Name == name {
+			b.schemaIDs[name] = s.ID
+			return s.ID, nil
+		}
+	}
+	return pldtypes.Bytes32{}, fmt.Errorf("schema %s is not registered by domain %s", name, {{$c}}Domain)
+}
+{{- end}}
+`
@@ -17,6 +17,8 @@
 package cache
 
 import (
+	"sort"
+	"sync"
 	"sync/atomic"
 
 	cacheimpl "github.com/Code-Hex/go-generics-cache"
@@ -31,13 +33,33 @@ type Cache[K comparable, V any] interface {
 	Delete(key K)
 	Capacity() int
 	Clear()
+	Stats() *Stats
+}
+
+// Stats are point-in-time statistics for a cache, with hits and misses counted since it was created
+type Stats struct {
+	Name     string `json:"name,omitempty"`
+	Capacity int    `json:"capacity"`
+	Size     int    `json:"size"`
+	Hits     uint64 `json:"hits"`
+	Misses   uint64 `json:"misses"`
 }
 
 type cache[K comparable, V any] struct {
+	name     string
 	cache    atomic.Pointer[cacheimpl.Cache[K, V]]
 	capacity int
+	hits     atomic.Uint64
+	misses   atomic.Uint64
+}
+
+type statsProvider interface {
+	Stats() *Stats
 }
 
+var namedCachesLock sync.Mutex
+var namedCaches = map[string]statsProvider{}
+
 func NewCache[K comparable, V any](conf *pldconf.CacheConfig, defs *pldconf.CacheConfig) Cache[K, V] {
 	capacity := confutil.Int(conf.Capacity, *defs.Capacity)
 	c := &cache[K, V]{
@@ -50,8 +72,37 @@ func NewCache[K comparable, V any](conf *pldconf.CacheConfig, defs *pldconf.Cach
 	return c
 }
 
+// NewNamedCache creates a cache that is included in AllStats() under the given name.
+// A subsequent cache created with the same name replaces the previous one.
+func NewNamedCache[K comparable, V any](name string, conf *pldconf.CacheConfig, defs *pldconf.CacheConfig) Cache[K, V] {
+	c := NewCache[K, V](conf, defs).(*cache[K, V])
+	c.name = name
+	namedCachesLock.Lock()
+	defer namedCachesLock.Unlock()
+	namedCaches[name] = c
+	return c
+}
+
+// AllStats returns the statistics for every named cache, sorted by name
+func AllStats() []*Stats {
+	namedCachesLock.Lock()
+	defer namedCachesLock.Unlock()
+	stats := make([]*Stats, 0, len(namedCaches))
+	for _, c := range namedCaches {
+		stats = append(stats, c.Stats())
+	}
+	sort.Slice(stats, func(i, j int) bool { return stats[i].Name < stats[j].Name })
+	return stats
+}
+
 func (c *cache[K, V]) Get(key K) (V, bool) {
-	return c.cache.Load().Get(key)
+	v, ok := c.cache.Load().Get(key)
+	if ok {
+		c.hits.Add(1)
+	} else {
+		c.misses.Add(1)
+	}
+	return v, ok
 }
 
 func (c *cache[K, V]) Set(key K, val V) {
@@ -72,3 +123,13 @@ func (c *cache[K, V]) Clear() {
 func (c *cache[K, V]) Capacity() int {
 	return c.capacity
 }
+
+func (c *cache[K, V]) Stats() *Stats {
+	return &Stats{
+		Name:     c.name,
+		Capacity: c.capacity,
+		Size:     c.cache.Load().Len(),
+		Hits:     c.hits.Load(),
+		Misses:   c.misses.Load(),
+	}
+}
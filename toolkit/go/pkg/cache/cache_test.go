@@ -47,3 +47,34 @@ func TestCache(t *testing.T) {
 
 	assert.Equal(t, 1, c.Capacity())
 }
+
+func TestCacheStats(t *testing.T) {
+
+	c := NewNamedCache[string, string]("test.stats", &pldconf.CacheConfig{}, &pldconf.CacheConfig{Capacity: confutil.P(10)})
+	c.Set("key1", "val1")
+	c.Set("key2", "val2")
+	_, _ = c.Get("key1")
+	_, _ = c.Get("key1")
+	_, _ = c.Get("key3")
+
+	assert.Equal(t, &Stats{
+		Name:     "test.stats",
+		Capacity: 10,
+		Size:     2,
+		Hits:     2,
+		Misses:   1,
+	}, c.Stats())
+
+	// Replaced by a new cache of the same name
+	_ = NewNamedCache[string, string]("test.stats", &pldconf.CacheConfig{}, &pldconf.CacheConfig{Capacity: confutil.P(5)})
+	_ = NewNamedCache[string, string]("test.another", &pldconf.CacheConfig{}, &pldconf.CacheConfig{Capacity: confutil.P(5)})
+	var names []string
+	for _, s := range AllStats() {
+		names = append(names, s.Name)
+		if s.Name == "test.stats" {
+			assert.Equal(t, 5, s.Capacity)
+			assert.Zero(t, s.Size)
+		}
+	}
+	assert.Equal(t, []string{"test.another", "test.stats"}, names)
+}
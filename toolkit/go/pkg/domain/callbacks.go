@@ -24,6 +24,8 @@ import (
 type MockDomainCallbacks struct {
 	MockFindAvailableStates func() (*prototk.FindAvailableStatesResponse, error)
 	MockLocalNodeName       func() (*prototk.LocalNodeNameResponse, error)
+	MockGetStatesByID       func(*prototk.GetStatesByIDRequest) (*prototk.GetStatesByIDResponse, error)
+	MockCheckCredential     func(*prototk.CheckCredentialRequest) (*prototk.CheckCredentialResponse, error)
 }
 
 func (dc *MockDomainCallbacks) FindAvailableStates(ctx context.Context, req *prototk.FindAvailableStatesRequest) (*prototk.FindAvailableStatesResponse, error) {
@@ -49,6 +51,16 @@ func (dc *MockDomainCallbacks) LocalNodeName(context.Context, *prototk.LocalNode
 	return dc.MockLocalNodeName()
 }
 
-func (dc *MockDomainCallbacks) GetStatesByID(context.Context, *prototk.GetStatesByIDRequest) (*prototk.GetStatesByIDResponse, error) {
-	return nil, nil
+func (dc *MockDomainCallbacks) GetStatesByID(ctx context.Context, req *prototk.GetStatesByIDRequest) (*prototk.GetStatesByIDResponse, error) {
+	if dc.MockGetStatesByID == nil {
+		return nil, nil
+	}
+	return dc.MockGetStatesByID(req)
+}
+
+func (dc *MockDomainCallbacks) CheckCredential(ctx context.Context, req *prototk.CheckCredentialRequest) (*prototk.CheckCredentialResponse, error) {
+	if dc.MockCheckCredential == nil {
+		return nil, nil
+	}
+	return dc.MockCheckCredential(req)
 }
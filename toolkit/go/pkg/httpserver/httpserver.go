@@ -32,7 +32,6 @@ import (
 	"github.com/kaleido-io/paladin/config/pkg/confutil"
 	"github.com/kaleido-io/paladin/config/pkg/pldconf"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
-	"github.com/kaleido-io/paladin/sdk/go/pkg/tlsconf"
 )
 
 type Server interface {
@@ -72,7 +71,7 @@ func NewServer(ctx context.Context, description string, conf *pldconf.HTTPServer
 	}
 	log.L(ctx).Infof("%s server listening on %s", description, s.listener.Addr())
 
-	tlsConfig, err := tlsconf.BuildTLSConfig(ctx, &conf.TLS, tlsconf.ServerType)
+	tlsConfig, err := buildServerTLSConfig(ctx, description, conf)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,166 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package httpserver
+
+import (
+	"context"
+	"crypto/tls"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/common/go/pkg/pldmsgs"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/tlsconf"
+)
+
+type sniConfig struct {
+	serverNames []string
+	tlsConfig   *tls.Config
+}
+
+// reloadingCert serves the certificate from the configured files, re-reading them when their modification
+// times change, so certificates can be rotated without restarting the server.
+type reloadingCert struct {
+	ctx           context.Context
+	certFile      string
+	keyFile       string
+	checkInterval time.Duration
+	mux           sync.Mutex
+	lastCheck     time.Time
+	modTimes      [2]time.Time
+	cert          *tls.Certificate
+}
+
+func buildServerTLSConfig(ctx context.Context, description string, conf *pldconf.HTTPServerConfig) (*tls.Config, error) {
+	if !conf.TLS.Enabled {
+		if len(conf.SNI) > 0 {
+			return nil, i18n.NewError(ctx, pldmsgs.MsgHTTPServerSNIWithoutTLS, description)
+		}
+		return nil, nil
+	}
+
+	reloadInterval := confutil.DurationMin(conf.CertReloadInterval, 0, *pldconf.HTTPDefaults.CertReloadInterval)
+	tlsConfig, err := buildReloadingTLSConfig(ctx, &conf.TLS, reloadInterval)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(conf.SNI) > 0 {
+		sniConfigs := make([]*sniConfig, len(conf.SNI))
+		for i, sc := range conf.SNI {
+			if len(sc.ServerNames) == 0 {
+				return nil, i18n.NewError(ctx, pldmsgs.MsgHTTPServerSNINoServerNames, description, i)
+			}
+			sniTLS := sc.TLS
+			sniTLS.Enabled = true
+			sniConfigs[i] = &sniConfig{serverNames: make([]string, len(sc.ServerNames))}
+			for j, name := range sc.ServerNames {
+				sniConfigs[i].serverNames[j] = strings.ToLower(name)
+			}
+			if sniConfigs[i].tlsConfig, err = buildReloadingTLSConfig(ctx, &sniTLS, reloadInterval); err != nil {
+				return nil, err
+			}
+		}
+		tlsConfig.GetConfigForClient = func(hello *tls.ClientHelloInfo) (*tls.Config, error) {
+			// nil means the default configuration is used
+			return matchSNI(sniConfigs, hello.ServerName), nil
+		}
+	}
+
+	return tlsConfig, nil
+}
+
+func buildReloadingTLSConfig(ctx context.Context, conf *pldconf.TLSConfig, reloadInterval time.Duration) (*tls.Config, error) {
+	detail, err := tlsconf.BuildTLSConfigExt(ctx, conf, tlsconf.ServerType)
+	if err != nil {
+		return nil, err
+	}
+	if conf.CertFile != "" && conf.KeyFile != "" && reloadInterval > 0 {
+		rc := &reloadingCert{
+			ctx:           ctx,
+			certFile:      conf.CertFile,
+			keyFile:       conf.KeyFile,
+			checkInterval: reloadInterval,
+			lastCheck:     time.Now(),
+			cert:          detail.Certificate,
+		}
+		rc.modTimes, _ = rc.fileModTimes() // we have just loaded the files successfully
+		detail.TLSConfig.GetCertificate = rc.getCertificate
+	}
+	return detail.TLSConfig, nil
+}
+
+// matchSNI returns the first entry with an exact match, or a wildcard match on everything after the first label
+func matchSNI(sniConfigs []*sniConfig, serverName string) *tls.Config {
+	serverName = strings.ToLower(strings.TrimSuffix(serverName, "."))
+	_, parentDomain, _ := strings.Cut(serverName, ".")
+	for _, sc := range sniConfigs {
+		for _, name := range sc.serverNames {
+			wildcardDomain, isWildcard := strings.CutPrefix(name, "*.")
+			if name == serverName || (isWildcard && parentDomain != "" && wildcardDomain == parentDomain) {
+				return sc.tlsConfig
+			}
+		}
+	}
+	return nil
+}
+
+func (rc *reloadingCert) fileModTimes() (modTimes [2]time.Time, err error) {
+	for i, f := range []string{rc.certFile, rc.keyFile} {
+		fi, err := os.Stat(f)
+		if err != nil {
+			return modTimes, err
+		}
+		modTimes[i] = fi.ModTime()
+	}
+	return modTimes, nil
+}
+
+func (rc *reloadingCert) getCertificate(_ *tls.ClientHelloInfo) (*tls.Certificate, error) {
+	rc.mux.Lock()
+	defer rc.mux.Unlock()
+	if time.Since(rc.lastCheck) >= rc.checkInterval {
+		rc.lastCheck = time.Now()
+		rc.reloadIfChanged()
+	}
+	return rc.cert, nil
+}
+
+func (rc *reloadingCert) reloadIfChanged() {
+	modTimes, err := rc.fileModTimes()
+	if err != nil {
+		log.L(rc.ctx).Errorf("Unable to check certificate files for changes (continuing with current certificate): %s", err)
+		return
+	}
+	if modTimes == rc.modTimes {
+		return
+	}
+	// The files might be part way through being replaced, in which case we keep the old certificate and try again next time
+	cert, err := tls.LoadX509KeyPair(rc.certFile, rc.keyFile)
+	if err != nil {
+		log.L(rc.ctx).Errorf("Failed to reload changed certificate files (continuing with current certificate): %s", err)
+		return
+	}
+	rc.modTimes = modTimes
+	rc.cert = &cert
+	log.L(rc.ctx).Infof("Reloaded server certificate from %s", rc.certFile)
+}
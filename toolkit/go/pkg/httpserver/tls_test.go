@@ -0,0 +1,202 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package httpserver
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeTestCertFiles(t *testing.T, dir, commonName string) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-1 * time.Minute),
+		NotAfter:     time.Now().Add(1 * time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		DNSNames:     []string{commonName},
+	}
+	derBytes, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyBytes, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certFile := filepath.Join(dir, commonName+".crt")
+	keyFile := filepath.Join(dir, commonName+".key")
+	err = os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: derBytes}), 0600)
+	require.NoError(t, err)
+	err = os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyBytes}), 0600)
+	require.NoError(t, err)
+	return certFile, keyFile
+}
+
+func serverCertCN(t *testing.T, url, serverName string) string {
+	conn, err := tls.Dial("tcp", strings.TrimPrefix(url, "http://"), &tls.Config{
+		ServerName:         serverName,
+		InsecureSkipVerify: true,
+	})
+	require.NoError(t, err)
+	defer conn.Close()
+	return conn.ConnectionState().PeerCertificates[0].Subject.CommonName
+}
+
+func TestSNISelectsCertificate(t *testing.T) {
+	dir := t.TempDir()
+	defaultCert, defaultKey := writeTestCertFiles(t, dir, "default.example.com")
+	apiCert, apiKey := writeTestCertFiles(t, dir, "api.example.com")
+	internalCert, internalKey := writeTestCertFiles(t, dir, "node1.internal.example.com")
+
+	url, _, done := newTestServer(t, &pldconf.HTTPServerConfig{
+		TLS: pldconf.TLSConfig{Enabled: true, CertFile: defaultCert, KeyFile: defaultKey},
+		SNI: []pldconf.HTTPServerSNIConfig{
+			{ServerNames: []string{"API.example.com"}, TLS: pldconf.TLSConfig{CertFile: apiCert, KeyFile: apiKey}},
+			{ServerNames: []string{"*.internal.example.com"}, TLS: pldconf.TLSConfig{CertFile: internalCert, KeyFile: internalKey}},
+		},
+	}, func(w http.ResponseWriter, r *http.Request) {})
+	defer done()
+
+	assert.Equal(t, "api.example.com", serverCertCN(t, url, "api.example.com."))
+	assert.Equal(t, "node1.internal.example.com", serverCertCN(t, url, "node1.internal.example.com"))
+	assert.Equal(t, "default.example.com", serverCertCN(t, url, "a.b.internal.example.com"))
+	assert.Equal(t, "default.example.com", serverCertCN(t, url, "other.example.com"))
+	assert.Equal(t, "default.example.com", serverCertCN(t, url, ""))
+}
+
+func TestSNIClientAuth(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := writeTestCertFiles(t, dir, "localhost")
+	caPEM, err := os.ReadFile(certFile)
+	require.NoError(t, err)
+
+	url, _, done := newTestServer(t, &pldconf.HTTPServerConfig{
+		TLS: pldconf.TLSConfig{Enabled: true, CertFile: certFile, KeyFile: keyFile},
+		SNI: []pldconf.HTTPServerSNIConfig{
+			{ServerNames: []string{"mtls.example.com"}, TLS: pldconf.TLSConfig{CertFile: certFile, KeyFile: keyFile, CA: string(caPEM), ClientAuth: true}},
+		},
+	}, func(w http.ResponseWriter, r *http.Request) {})
+	defer done()
+
+	// Client auth is not required without the server name
+	assert.Equal(t, "localhost", serverCertCN(t, url, "localhost"))
+
+	// The server rejects the handshake with the server name, as we do not supply a client certificate
+	conn, err := tls.Dial("tcp", strings.TrimPrefix(url, "http://"), &tls.Config{
+		ServerName:         "mtls.example.com",
+		InsecureSkipVerify: true,
+	})
+	if err == nil {
+		// With TLS 1.3 the rejection is seen on the first read
+		_, err = conn.Read(make([]byte, 1))
+		conn.Close()
+	}
+	assert.Error(t, err)
+}
+
+func TestCertReload(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := writeTestCertFiles(t, dir, "localhost")
+
+	url, _, done := newTestServer(t, &pldconf.HTTPServerConfig{
+		TLS:                pldconf.TLSConfig{Enabled: true, CertFile: certFile, KeyFile: keyFile},
+		CertReloadInterval: confutil.P("1ns"),
+	}, func(w http.ResponseWriter, r *http.Request) {})
+	defer done()
+	assert.Equal(t, "localhost", serverCertCN(t, url, ""))
+
+	replaceFiles := func(commonName string) {
+		newCert, newKey := writeTestCertFiles(t, t.TempDir(), commonName)
+		for src, dst := range map[string]string{newCert: certFile, newKey: keyFile} {
+			data, err := os.ReadFile(src)
+			require.NoError(t, err)
+			err = os.WriteFile(dst, data, 0600)
+			require.NoError(t, err)
+			future := time.Now().Add(1 * time.Minute)
+			require.NoError(t, os.Chtimes(dst, future, future))
+		}
+	}
+
+	// Rotated certificate is picked up on the next connection
+	replaceFiles("rotated")
+	assert.Equal(t, "rotated", serverCertCN(t, url, ""))
+
+	// Half-written files do not stop us serving the previous certificate
+	err := os.WriteFile(keyFile, []byte("partial"), 0600)
+	require.NoError(t, err)
+	assert.Equal(t, "rotated", serverCertCN(t, url, ""))
+
+	// Nor do missing files
+	require.NoError(t, os.Remove(keyFile))
+	assert.Equal(t, "rotated", serverCertCN(t, url, ""))
+}
+
+func TestCertReloadDisabled(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := writeTestCertFiles(t, dir, "localhost")
+
+	tlsConfig, err := buildServerTLSConfig(context.Background(), "unittest", &pldconf.HTTPServerConfig{
+		TLS:                pldconf.TLSConfig{Enabled: true, CertFile: certFile, KeyFile: keyFile},
+		CertReloadInterval: confutil.P("0"),
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, os.Remove(certFile))
+	cert, err := tlsConfig.GetCertificate(&tls.ClientHelloInfo{})
+	require.NoError(t, err)
+	assert.NotNil(t, cert)
+}
+
+func TestSNIConfigErrors(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := buildServerTLSConfig(ctx, "unittest", &pldconf.HTTPServerConfig{
+		SNI: []pldconf.HTTPServerSNIConfig{{ServerNames: []string{"example.com"}}},
+	})
+	assert.Regexp(t, "PD020604", err)
+
+	_, err = buildServerTLSConfig(ctx, "unittest", &pldconf.HTTPServerConfig{
+		TLS: pldconf.TLSConfig{Enabled: true},
+		SNI: []pldconf.HTTPServerSNIConfig{{}},
+	})
+	assert.Regexp(t, "PD020605", err)
+
+	_, err = buildServerTLSConfig(ctx, "unittest", &pldconf.HTTPServerConfig{
+		TLS: pldconf.TLSConfig{Enabled: true},
+		SNI: []pldconf.HTTPServerSNIConfig{{ServerNames: []string{"example.com"}, TLS: pldconf.TLSConfig{CAFile: "!!!!!badness"}}},
+	})
+	assert.Regexp(t, "PD020401", err)
+}
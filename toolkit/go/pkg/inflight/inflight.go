@@ -16,21 +16,45 @@ package inflight
 
 import (
 	"context"
+	"sort"
 	"sync"
 	"time"
 
 	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
 	"github.com/kaleido-io/paladin/common/go/pkg/log"
 	"github.com/kaleido-io/paladin/common/go/pkg/pldmsgs"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
 )
 
 type InflightManager[K comparable, T any] struct {
-	lock     sync.Mutex
-	parseStr func(string) (K, error)
-	requests map[K]*InflightRequest[K, T]
-	closed   bool
+	lock           sync.Mutex
+	name           string
+	parseStr       func(string) (K, error)
+	requests       map[K]*InflightRequest[K, T]
+	closed         bool
+	maxInflight    int           // zero means unbounded
+	defaultTimeout time.Duration // zero means no default timeout
+	peak           int
+	overloaded     uint64
 }
 
+// Point-in-time gauges for the depth of an inflight manager
+type InflightStats struct {
+	Name       string `json:"name,omitempty"`
+	Current    int    `json:"current"`
+	Peak       int    `json:"peak"`
+	Capacity   int    `json:"capacity"`
+	Overloaded uint64 `json:"overloaded"`
+}
+
+type namedInflightManager interface {
+	Stats() InflightStats
+}
+
+var namedManagersLock sync.Mutex
+var namedManagers = map[string]namedInflightManager{}
+
 type InflightRequest[K comparable, T any] struct {
 	ctx       context.Context
 	cancelCtx context.CancelFunc
@@ -47,24 +71,87 @@ func NewInflightManager[K comparable, T any](parseStr func(string) (K, error)) *
 	}
 }
 
+// A bounded inflight manager rejects new requests with an overloaded error (via TryAddInflight)
+// once the max is reached, and applies a default timeout to any request whose context
+// does not already have a deadline.
+func NewBoundedInflightManager[K comparable, T any](conf *pldconf.InflightConfig, parseStr func(string) (K, error)) *InflightManager[K, T] {
+	ifm := NewInflightManager[K, T](parseStr)
+	ifm.maxInflight = confutil.IntMin(conf.MaxInflight, 0, *pldconf.InflightDefaults.MaxInflight)
+	ifm.defaultTimeout = confutil.DurationMin(conf.DefaultTimeout, 0, *pldconf.InflightDefaults.DefaultTimeout)
+	return ifm
+}
+
+// NewNamedBoundedInflightManager creates a bounded inflight manager that is included in AllStats()
+// under the given name. A subsequent manager created with the same name replaces the previous one.
+func NewNamedBoundedInflightManager[K comparable, T any](name string, conf *pldconf.InflightConfig, parseStr func(string) (K, error)) *InflightManager[K, T] {
+	ifm := NewBoundedInflightManager[K, T](conf, parseStr)
+	ifm.name = name
+	namedManagersLock.Lock()
+	defer namedManagersLock.Unlock()
+	namedManagers[name] = ifm
+	return ifm
+}
+
+// AllStats returns the statistics for every named inflight manager, sorted by name
+func AllStats() []*InflightStats {
+	namedManagersLock.Lock()
+	defer namedManagersLock.Unlock()
+	stats := make([]*InflightStats, 0, len(namedManagers))
+	for _, ifm := range namedManagers {
+		s := ifm.Stats()
+		stats = append(stats, &s)
+	}
+	sort.Slice(stats, func(i, j int) bool { return stats[i].Name < stats[j].Name })
+	return stats
+}
+
 // Inflight requests are scoped to a context, and Wait() will cancel on either;
 // - The supplied context closing
 // - The inflight manager closing
+//
+// AddInflight never rejects a request, regardless of the configured limit.
+// Callers that should be subject to backpressure must use TryAddInflight.
 func (ifm *InflightManager[K, T]) AddInflight(ctx context.Context, id K) *InflightRequest[K, T] {
+	req, _ := ifm.addInflight(ctx, id, false)
+	return req
+}
+
+// TryAddInflight returns a PD020101 error if the manager is bounded, and already
+// has the maximum number of requests in-flight.
+func (ifm *InflightManager[K, T]) TryAddInflight(ctx context.Context, id K) (*InflightRequest[K, T], error) {
+	return ifm.addInflight(ctx, id, true)
+}
+
+func (ifm *InflightManager[K, T]) addInflight(ctx context.Context, id K, enforceLimit bool) (*InflightRequest[K, T], error) {
+	ifm.lock.Lock()
+	defer ifm.lock.Unlock()
+
+	if enforceLimit && ifm.maxInflight > 0 && len(ifm.requests) >= ifm.maxInflight {
+		if _, replacing := ifm.requests[id]; !replacing {
+			ifm.overloaded++
+			return nil, i18n.NewError(ctx, pldmsgs.MsgInflightOverloaded, ifm.maxInflight)
+		}
+	}
+
 	req := &InflightRequest[K, T]{
 		ifm:    ifm,
 		id:     id,
 		queued: time.Now(),
 		done:   make(chan T, 1),
 	}
-	req.ctx, req.cancelCtx = context.WithCancel(ctx)
-	ifm.lock.Lock()
-	defer ifm.lock.Unlock()
+	if _, hasDeadline := ctx.Deadline(); !hasDeadline && ifm.defaultTimeout > 0 {
+		req.ctx, req.cancelCtx = context.WithTimeout(ctx, ifm.defaultTimeout)
+	} else {
+		req.ctx, req.cancelCtx = context.WithCancel(ctx)
+	}
 	ifm.requests[id] = req
+	if len(ifm.requests) > ifm.peak {
+		ifm.peak = len(ifm.requests)
+	}
 	if ifm.closed {
 		req.cancelCtx()
 	}
-	return req
+	return req, nil
 }
 
 func (ifm *InflightManager[K, T]) GetInflightStr(strID string) *InflightRequest[K, T] {
@@ -97,11 +184,26 @@ func (ifm *InflightManager[K, T]) InFlightCount() int {
 	return len(ifm.requests)
 }
 
+func (ifm *InflightManager[K, T]) Stats() InflightStats {
+	ifm.lock.Lock()
+	defer ifm.lock.Unlock()
+	return InflightStats{
+		Name:       ifm.name,
+		Current:    len(ifm.requests),
+		Peak:       ifm.peak,
+		Capacity:   ifm.maxInflight,
+		Overloaded: ifm.overloaded,
+	}
+}
+
 func (ifm *InflightManager[K, T]) cancelInFlight(req *InflightRequest[K, T]) {
 	ifm.lock.Lock()
 	defer ifm.lock.Unlock()
 	req.cancelCtx()
-	delete(ifm.requests, req.id)
+	// A request might have been replaced by a newer one with the same ID
+	if ifm.requests[req.id] == req {
+		delete(ifm.requests, req.id)
+	}
 }
 
 func (ifm *InflightManager[K, T]) Close() {
@@ -17,8 +17,11 @@ package inflight
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -79,3 +82,102 @@ func TestInFlightCancel(t *testing.T) {
 	_, err = req.Wait()
 	assert.Regexp(t, "PD020100", err)
 }
+
+func TestInFlightBoundedOverloaded(t *testing.T) {
+
+	ifm := NewBoundedInflightManager[uuid.UUID, string](&pldconf.InflightConfig{
+		MaxInflight: confutil.P(2),
+	}, uuid.Parse)
+
+	req1, err := ifm.TryAddInflight(context.Background(), uuid.New())
+	require.NoError(t, err)
+	req2, err := ifm.TryAddInflight(context.Background(), uuid.New())
+	require.NoError(t, err)
+
+	_, err = ifm.TryAddInflight(context.Background(), uuid.New())
+	assert.Regexp(t, "PD020101", err)
+
+	// Replacing an existing ID is not rejected
+	req2b, err := ifm.TryAddInflight(context.Background(), req2.ID())
+	require.NoError(t, err)
+
+	// Cancelling the replaced request does not remove the new one
+	req2.Cancel()
+	assert.Equal(t, req2b, ifm.GetInflight(req2.ID()))
+
+	// AddInflight is never rejected
+	req3 := ifm.AddInflight(context.Background(), uuid.New())
+	assert.Equal(t, InflightStats{
+		Current:    3,
+		Peak:       3,
+		Capacity:   2,
+		Overloaded: 1,
+	}, ifm.Stats())
+
+	req1.Cancel()
+	req3.Cancel()
+	req4, err := ifm.TryAddInflight(context.Background(), uuid.New())
+	require.NoError(t, err)
+	req4.Cancel()
+	req2b.Cancel()
+
+	stats := ifm.Stats()
+	assert.Zero(t, stats.Current)
+	assert.Equal(t, 3, stats.Peak)
+}
+
+func TestInFlightNamedStats(t *testing.T) {
+
+	ifm := NewNamedBoundedInflightManager[uuid.UUID, string]("test.stats", &pldconf.InflightConfig{
+		MaxInflight: confutil.P(5),
+	}, uuid.Parse)
+	req := ifm.AddInflight(context.Background(), uuid.New())
+	defer req.Cancel()
+
+	assert.Equal(t, InflightStats{
+		Name:     "test.stats",
+		Current:  1,
+		Peak:     1,
+		Capacity: 5,
+	}, ifm.Stats())
+
+	// Replaced by a new manager of the same name
+	_ = NewNamedBoundedInflightManager[uuid.UUID, string]("test.stats", &pldconf.InflightConfig{}, uuid.Parse)
+	_ = NewNamedBoundedInflightManager[uuid.UUID, string]("test.another", &pldconf.InflightConfig{}, uuid.Parse)
+	var names []string
+	for _, s := range AllStats() {
+		names = append(names, s.Name)
+		if s.Name == "test.stats" {
+			assert.Zero(t, s.Current)
+		}
+	}
+	assert.Equal(t, []string{"test.another", "test.stats"}, names)
+}
+
+func TestInFlightDefaultTimeout(t *testing.T) {
+
+	ifm := NewBoundedInflightManager[uuid.UUID, string](&pldconf.InflightConfig{
+		DefaultTimeout: confutil.P("1ms"),
+	}, uuid.Parse)
+	assert.Equal(t, *pldconf.InflightDefaults.MaxInflight, ifm.Stats().Capacity)
+
+	req, err := ifm.TryAddInflight(context.Background(), uuid.New())
+	require.NoError(t, err)
+	defer req.Cancel()
+	_, err = req.Wait()
+	assert.Regexp(t, "PD020100", err)
+
+	// An existing deadline on the context is honored over the default
+	ctx, cancelCtx := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancelCtx()
+	req2, err := ifm.TryAddInflight(ctx, uuid.New())
+	require.NoError(t, err)
+	defer req2.Cancel()
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		req2.Complete("hello")
+	}()
+	v, err := req2.Wait()
+	require.NoError(t, err)
+	assert.Equal(t, "hello", v)
+}
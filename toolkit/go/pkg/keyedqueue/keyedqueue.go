@@ -0,0 +1,378 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+package keyedqueue
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/common/go/pkg/pldmsgs"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+)
+
+// A Worker performs all the processing for a single key, in order, until it is stopped or
+// it decides to exit by itself. It must close the done channel returned by Start once it has
+// exited, and Stop must not block.
+type Worker interface {
+	Start(ctx context.Context) (done <-chan struct{}, err error)
+	Stop()
+}
+
+// A Worker can optionally implement BusyWorker to report that it holds in-flight work, which it
+// cannot hand over to the next worker for its key. It is not swapped out while busy.
+// IsBusy is called under the lock of the pool, so must not call back into the pool.
+type BusyWorker interface {
+	IsBusy() bool
+}
+
+// Pool runs a single worker for each key being processed, such as a signing address or a
+// contract, with a bounded number of keys processed concurrently.
+//
+// When all slots are full, keys wait for a slot in the order they were first requested. To be
+// fair to the waiting keys, workers that have run for longer than the swap timeout are stopped
+// to free up their slot - they are started again at the back of the queue if there is more work.
+type Pool[K comparable, W Worker] struct {
+	ctx         context.Context
+	mux         sync.Mutex
+	maxActive   int           // zero means unbounded
+	swapTimeout time.Duration // zero means workers are never swapped out
+	active      map[K]*activeWorker[W]
+	reserved    map[K]bool // slots handed to waiting keys, that have not yet been started
+	starting    map[K]chan struct{}
+	waiting     []*waitingKey[K]
+	draining    bool
+	peak        int
+	swapped     uint64
+}
+
+// Point-in-time gauges for a pool
+type PoolStats struct {
+	Active   int    `json:"active"`
+	Waiting  int    `json:"waiting"`
+	Peak     int    `json:"peak"`
+	Capacity int    `json:"capacity"`
+	Swapped  uint64 `json:"swapped"`
+}
+
+type activeWorker[W Worker] struct {
+	worker   W
+	started  time.Time
+	done     <-chan struct{}
+	removed  chan struct{}
+	swapping bool
+}
+
+// how often a busy worker that is due to be swapped out is checked again, if it is shorter than the swap timeout
+const busyRecheckInterval = 1 * time.Second
+
+type waitingKey[K comparable] struct {
+	key      K
+	queued   time.Time
+	callers  int
+	admitted chan struct{}
+}
+
+func NewPool[K comparable, W Worker](ctx context.Context, conf *pldconf.KeyedQueueConfig) *Pool[K, W] {
+	return &Pool[K, W]{
+		ctx:         ctx,
+		maxActive:   confutil.IntMin(conf.MaxActive, 0, *pldconf.KeyedQueueDefaults.MaxActive),
+		swapTimeout: confutil.DurationMin(conf.SwapTimeout, 0, *pldconf.KeyedQueueDefaults.SwapTimeout),
+		active:      make(map[K]*activeWorker[W]),
+		reserved:    make(map[K]bool),
+		starting:    make(map[K]chan struct{}),
+	}
+}
+
+// Get returns the worker for a key, if there is one running
+func (p *Pool[K, W]) Get(key K) (w W, ok bool) {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	if aw := p.active[key]; aw != nil {
+		return aw.worker, true
+	}
+	return w, false
+}
+
+// Workers returns a copy of the set of running workers
+func (p *Pool[K, W]) Workers() map[K]W {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	workers := make(map[K]W, len(p.active))
+	for key, aw := range p.active {
+		workers[key] = aw.worker
+	}
+	return workers
+}
+
+// GetOrStart returns the running worker for the key, or builds and starts one with newWorker.
+// If all the slots are full, it blocks until the key is given a slot, or the context is cancelled.
+//
+// The worker is built and started outside of the lock of the pool, holding the slot. Concurrent
+// callers for the same key wait for it to start.
+func (p *Pool[K, W]) GetOrStart(ctx context.Context, key K, newWorker func() (W, error)) (W, error) {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+
+	var wk *waitingKey[K]
+	for {
+		if p.draining {
+			p.leaveQueue(wk)
+			return *new(W), i18n.NewError(ctx, pldmsgs.MsgKeyedQueueDraining, key)
+		}
+		if aw := p.active[key]; aw != nil {
+			p.leaveQueue(wk)
+			return aw.worker, nil
+		}
+		started := p.starting[key]
+		if started == nil && (p.reserved[key] || (wk == nil && p.hasFreeSlot())) {
+			delete(p.reserved, key)
+			p.leaveQueue(wk)
+			return p.start(ctx, key, newWorker)
+		}
+
+		var admitted <-chan struct{}
+		var swapCheck <-chan time.Time
+		if started == nil {
+			if wk == nil {
+				wk = p.joinQueue(key)
+			}
+			admitted = wk.admitted
+			p.swapOut(ctx)
+			swapCheck = p.nextSwapCheck()
+		}
+
+		p.mux.Unlock()
+		select {
+		case <-started:
+		case <-admitted:
+		case <-swapCheck:
+		case <-ctx.Done():
+			p.mux.Lock()
+			p.leaveQueue(wk)
+			return *new(W), i18n.NewError(ctx, pldmsgs.MsgKeyedQueueWaitCancelled, time.Since(wk.queued), key)
+		}
+		p.mux.Lock()
+	}
+}
+
+// Drain stops accepting new keys, and stops all the workers - waiting until they have all exited,
+// or the context is cancelled.
+func (p *Pool[K, W]) Drain(ctx context.Context) {
+	p.mux.Lock()
+	p.draining = true
+	for _, wk := range p.waiting {
+		close(wk.admitted)
+	}
+	p.waiting = nil
+	removed := make([]chan struct{}, 0, len(p.active))
+	for _, aw := range p.active {
+		aw.worker.Stop()
+		removed = append(removed, aw.removed)
+	}
+	p.mux.Unlock()
+
+	for _, r := range removed {
+		select {
+		case <-r:
+		case <-ctx.Done():
+			log.L(ctx).Warnf("Context cancelled while draining %d workers", len(removed))
+			return
+		}
+	}
+}
+
+func (p *Pool[K, W]) Stats() PoolStats {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	return PoolStats{
+		Active:   len(p.active),
+		Waiting:  len(p.waiting),
+		Peak:     p.peak,
+		Capacity: p.maxActive,
+		Swapped:  p.swapped,
+	}
+}
+
+// a new key only gets a free slot if there are no keys ahead of it in the queue
+func (p *Pool[K, W]) hasFreeSlot() bool {
+	return p.maxActive == 0 || (len(p.waiting) == 0 && p.used() < p.maxActive)
+}
+
+func (p *Pool[K, W]) used() int {
+	return len(p.active) + len(p.reserved) + len(p.starting)
+}
+
+// start is called with the lock held, and releases it while the worker is built and started,
+// so that slow work such as DB queries in newWorker does not block the whole pool
+func (p *Pool[K, W]) start(ctx context.Context, key K, newWorker func() (W, error)) (W, error) {
+	started := make(chan struct{})
+	p.starting[key] = started
+	p.mux.Unlock()
+
+	w, err := newWorker()
+	var done <-chan struct{}
+	if err == nil {
+		done, err = w.Start(p.ctx)
+	}
+
+	p.mux.Lock()
+	delete(p.starting, key)
+	close(started)
+	if err != nil {
+		log.L(ctx).Errorf("Failed to start worker for key %v: %s", key, err)
+		p.admitNext()
+		return *new(W), err
+	}
+	aw := &activeWorker[W]{
+		worker:  w,
+		started: time.Now(),
+		done:    done,
+		removed: make(chan struct{}),
+	}
+	p.active[key] = aw
+	if len(p.active) > p.peak {
+		p.peak = len(p.active)
+	}
+	go p.removeOnExit(key, aw)
+	if p.draining {
+		// Drain did not see this worker, so it is stopped here instead
+		w.Stop()
+		return *new(W), i18n.NewError(ctx, pldmsgs.MsgKeyedQueueDraining, key)
+	}
+	return w, nil
+}
+
+func (p *Pool[K, W]) removeOnExit(key K, aw *activeWorker[W]) {
+	<-aw.done
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	if p.active[key] == aw {
+		delete(p.active, key)
+	}
+	close(aw.removed)
+	p.admitNext()
+}
+
+func (p *Pool[K, W]) joinQueue(key K) *waitingKey[K] {
+	for _, wk := range p.waiting {
+		if wk.key == key {
+			wk.callers++
+			return wk
+		}
+	}
+	wk := &waitingKey[K]{
+		key:      key,
+		queued:   time.Now(),
+		callers:  1,
+		admitted: make(chan struct{}),
+	}
+	p.waiting = append(p.waiting, wk)
+	return wk
+}
+
+// leaveQueue is called by each caller that joined the queue for a key when it returns. Once there
+// are no callers left, the key must not hold a place in the queue, or a slot it will never use.
+func (p *Pool[K, W]) leaveQueue(wk *waitingKey[K]) {
+	if wk == nil {
+		return
+	}
+	if wk.callers--; wk.callers > 0 {
+		return
+	}
+	for i, queued := range p.waiting {
+		if queued == wk {
+			p.waiting = append(p.waiting[:i], p.waiting[i+1:]...)
+			return
+		}
+	}
+	if p.reserved[wk.key] {
+		delete(p.reserved, wk.key)
+		p.admitNext()
+	}
+}
+
+// admitNext hands any free slots to the keys at the front of the queue
+func (p *Pool[K, W]) admitNext() {
+	for len(p.waiting) > 0 && (p.maxActive == 0 || p.used() < p.maxActive) {
+		wk := p.waiting[0]
+		p.waiting = p.waiting[1:]
+		p.reserved[wk.key] = true
+		close(wk.admitted)
+	}
+}
+
+// swapOut stops the longest running workers that have exceeded the swap timeout, to free
+// up a slot for each of the waiting keys. Workers that are busy with in-flight work are skipped.
+func (p *Pool[K, W]) swapOut(ctx context.Context) {
+	if p.swapTimeout == 0 {
+		return
+	}
+	needed := len(p.waiting)
+	candidates := make([]K, 0, len(p.active))
+	for key, aw := range p.active {
+		if aw.swapping {
+			needed--
+		} else if time.Since(aw.started) > p.swapTimeout && !isBusy(aw.worker) {
+			candidates = append(candidates, key)
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		return p.active[candidates[i]].started.Before(p.active[candidates[j]].started)
+	})
+	for i := 0; i < len(candidates) && i < needed; i++ {
+		aw := p.active[candidates[i]]
+		log.L(ctx).Infof("Swapping out worker for key %v after %s, for waiting keys", candidates[i], time.Since(aw.started))
+		aw.swapping = true
+		p.swapped++
+		aw.worker.Stop()
+	}
+}
+
+// nextSwapCheck returns a channel that fires when the next worker becomes eligible to be swapped
+// out, or nil if there are none that will. Busy workers past the swap timeout are checked again
+// after the busy recheck interval.
+func (p *Pool[K, W]) nextSwapCheck() <-chan time.Time {
+	if p.swapTimeout == 0 {
+		return nil
+	}
+	var next time.Time
+	for _, aw := range p.active {
+		if aw.swapping {
+			continue
+		}
+		due := aw.started.Add(p.swapTimeout)
+		if time.Now().After(due) {
+			// only workers that were busy in the last swapOut can be past the timeout
+			due = time.Now().Add(min(p.swapTimeout, busyRecheckInterval))
+		}
+		if next.IsZero() || due.Before(next) {
+			next = due
+		}
+	}
+	if next.IsZero() {
+		return nil
+	}
+	return time.After(time.Until(next) + time.Millisecond)
+}
+
+func isBusy(w any) bool {
+	bw, ok := w.(BusyWorker)
+	return ok && bw.IsBusy()
+}
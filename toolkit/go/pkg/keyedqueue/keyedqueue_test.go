@@ -0,0 +1,336 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+package keyedqueue
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testWorker struct {
+	key      string
+	done     chan struct{}
+	stopOnce sync.Once
+	ignore   bool // does not exit when stopped
+	startErr error
+	busy     atomic.Bool
+}
+
+func newTestWorker(key string) *testWorker {
+	return &testWorker{key: key, done: make(chan struct{})}
+}
+
+func (tw *testWorker) Start(ctx context.Context) (<-chan struct{}, error) {
+	return tw.done, tw.startErr
+}
+
+func (tw *testWorker) Stop() {
+	if !tw.ignore {
+		tw.exit()
+	}
+}
+
+func (tw *testWorker) IsBusy() bool {
+	return tw.busy.Load()
+}
+
+func (tw *testWorker) exit() {
+	tw.stopOnce.Do(func() { close(tw.done) })
+}
+
+func startWorker(p *Pool[string, *testWorker], key string) (*testWorker, error) {
+	return p.GetOrStart(context.Background(), key, func() (*testWorker, error) {
+		return newTestWorker(key), nil
+	})
+}
+
+// startInBackground returns a channel that receives the worker once the key is given a slot, and
+// waits for the key to join the queue
+func startInBackground(t *testing.T, p *Pool[string, *testWorker], key string, waitingAfter int) chan *testWorker {
+	started := make(chan *testWorker, 1)
+	go func() {
+		w, err := startWorker(p, key)
+		assert.NoError(t, err)
+		started <- w
+	}()
+	require.Eventually(t, func() bool { return p.Stats().Waiting == waitingAfter }, 5*time.Second, time.Millisecond)
+	return started
+}
+
+func TestPoolUnbounded(t *testing.T) {
+	p := NewPool[string, *testWorker](context.Background(), &pldconf.KeyedQueueConfig{})
+
+	w1, err := startWorker(p, "key1")
+	require.NoError(t, err)
+	w1b, err := startWorker(p, "key1")
+	require.NoError(t, err)
+	assert.Same(t, w1, w1b)
+	w2, err := startWorker(p, "key2")
+	require.NoError(t, err)
+
+	w, ok := p.Get("key1")
+	assert.True(t, ok)
+	assert.Same(t, w1, w)
+	assert.Equal(t, map[string]*testWorker{"key1": w1, "key2": w2}, p.Workers())
+
+	// exiting workers are removed
+	w1.exit()
+	require.Eventually(t, func() bool { _, ok := p.Get("key1"); return !ok }, 5*time.Second, time.Millisecond)
+	assert.Equal(t, PoolStats{Active: 1, Peak: 2}, p.Stats())
+
+	// and started again if needed
+	w1c, err := startWorker(p, "key1")
+	require.NoError(t, err)
+	assert.NotSame(t, w1, w1c)
+
+	p.Drain(context.Background())
+	assert.Empty(t, p.Workers())
+	_, err = startWorker(p, "key3")
+	assert.Regexp(t, "PD021100", err)
+}
+
+func TestPoolBoundedFIFO(t *testing.T) {
+	p := NewPool[string, *testWorker](context.Background(), &pldconf.KeyedQueueConfig{
+		MaxActive:   confutil.P(1),
+		SwapTimeout: confutil.P("0"),
+	})
+
+	w1, err := startWorker(p, "key1")
+	require.NoError(t, err)
+
+	// key2 then key3 queue for the slot, and a second request for key2 shares its place
+	key2Started := startInBackground(t, p, "key2", 1)
+	key3Started := startInBackground(t, p, "key3", 2)
+	key2StartedAgain := make(chan *testWorker, 1)
+	go func() {
+		w, err := startWorker(p, "key2")
+		assert.NoError(t, err)
+		key2StartedAgain <- w
+	}()
+
+	w1.exit()
+	w2 := <-key2Started
+	assert.Equal(t, "key2", w2.key)
+	assert.Same(t, w2, <-key2StartedAgain)
+	assert.Equal(t, 1, p.Stats().Waiting)
+
+	w2.exit()
+	w3 := <-key3Started
+	assert.Equal(t, "key3", w3.key)
+	assert.Equal(t, PoolStats{Active: 1, Peak: 1, Capacity: 1}, p.Stats())
+}
+
+func TestPoolSwapOut(t *testing.T) {
+	p := NewPool[string, *testWorker](context.Background(), &pldconf.KeyedQueueConfig{
+		MaxActive:   confutil.P(1),
+		SwapTimeout: confutil.P("10ms"),
+	})
+
+	w1, err := startWorker(p, "key1")
+	require.NoError(t, err)
+
+	// key1 is swapped out once it has run for the swap timeout
+	w2, err := startWorker(p, "key2")
+	require.NoError(t, err)
+	assert.Equal(t, "key2", w2.key)
+	<-w1.done
+	assert.Equal(t, uint64(1), p.Stats().Swapped)
+}
+
+func TestPoolSwapOutSkipsBusy(t *testing.T) {
+	p := NewPool[string, *testWorker](context.Background(), &pldconf.KeyedQueueConfig{
+		MaxActive:   confutil.P(1),
+		SwapTimeout: confutil.P("10ms"),
+	})
+
+	w1, err := startWorker(p, "key1")
+	require.NoError(t, err)
+	w1.busy.Store(true)
+
+	// key1 holds its slot while it is busy, past the swap timeout
+	key2Started := startInBackground(t, p, "key2", 1)
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, uint64(0), p.Stats().Swapped)
+	_, ok := p.Get("key1")
+	assert.True(t, ok)
+
+	// and is swapped out on the next check once it is idle
+	w1.busy.Store(false)
+	w2 := <-key2Started
+	assert.Equal(t, "key2", w2.key)
+	assert.Equal(t, uint64(1), p.Stats().Swapped)
+}
+
+func TestPoolStartOutsideLock(t *testing.T) {
+	p := NewPool[string, *testWorker](context.Background(), &pldconf.KeyedQueueConfig{
+		MaxActive: confutil.P(2),
+	})
+
+	building := make(chan struct{})
+	release := make(chan struct{})
+	key1Started := make(chan *testWorker, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			w, err := p.GetOrStart(context.Background(), "key1", func() (*testWorker, error) {
+				close(building)
+				<-release
+				return newTestWorker("key1"), nil
+			})
+			assert.NoError(t, err)
+			key1Started <- w
+		}()
+	}
+	<-building
+
+	// the pool can be used while key1 is being built, and its slot is held
+	w2, err := startWorker(p, "key2")
+	require.NoError(t, err)
+	assert.Len(t, p.Workers(), 1)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = p.GetOrStart(ctx, "key3", func() (*testWorker, error) {
+		panic("not started")
+	})
+	assert.Regexp(t, "PD021101", err)
+
+	// the second caller for key1 gets the same worker, built once
+	close(release)
+	w1 := <-key1Started
+	assert.Same(t, w1, <-key1Started)
+	assert.Equal(t, map[string]*testWorker{"key1": w1, "key2": w2}, p.Workers())
+}
+
+func TestPoolDrainWhileStarting(t *testing.T) {
+	p := NewPool[string, *testWorker](context.Background(), &pldconf.KeyedQueueConfig{})
+
+	building := make(chan struct{})
+	release := make(chan struct{})
+	w1 := newTestWorker("key1")
+	startErr := make(chan error, 1)
+	go func() {
+		_, err := p.GetOrStart(context.Background(), "key1", func() (*testWorker, error) {
+			close(building)
+			<-release
+			return w1, nil
+		})
+		startErr <- err
+	}()
+	<-building
+
+	p.Drain(context.Background())
+	close(release)
+	assert.Regexp(t, "PD021100", <-startErr)
+	<-w1.done
+	require.Eventually(t, func() bool { return p.Stats().Active == 0 }, 5*time.Second, time.Millisecond)
+}
+
+func TestPoolWaitCancelled(t *testing.T) {
+	p := NewPool[string, *testWorker](context.Background(), &pldconf.KeyedQueueConfig{
+		MaxActive:   confutil.P(1),
+		SwapTimeout: confutil.P("1h"),
+	})
+
+	w1, err := startWorker(p, "key1")
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = p.GetOrStart(ctx, "key2", func() (*testWorker, error) {
+		panic("not started")
+	})
+	assert.Regexp(t, "PD021101", err)
+	assert.Equal(t, 0, p.Stats().Waiting)
+
+	// the slot is still available once freed
+	w1.exit()
+	require.Eventually(t, func() bool { return p.Stats().Active == 0 }, 5*time.Second, time.Millisecond)
+	_, err = startWorker(p, "key2")
+	require.NoError(t, err)
+}
+
+func TestPoolAdmittedThenCancelled(t *testing.T) {
+	p := NewPool[string, *testWorker](context.Background(), &pldconf.KeyedQueueConfig{
+		MaxActive:   confutil.P(1),
+		SwapTimeout: confutil.P("0"),
+	})
+
+	// Simulate key1 having been given the slot, but its caller giving up before it starts
+	wk := p.joinQueue("key1")
+	p.admitNext()
+	assert.True(t, p.reserved["key1"])
+	p.leaveQueue(wk)
+	assert.Empty(t, p.reserved)
+
+	_, err := startWorker(p, "key2")
+	require.NoError(t, err)
+}
+
+func TestPoolStartFail(t *testing.T) {
+	p := NewPool[string, *testWorker](context.Background(), &pldconf.KeyedQueueConfig{
+		MaxActive: confutil.P(1),
+	})
+
+	_, err := p.GetOrStart(context.Background(), "key1", func() (*testWorker, error) {
+		return nil, fmt.Errorf("pop")
+	})
+	assert.Regexp(t, "pop", err)
+
+	_, err = p.GetOrStart(context.Background(), "key1", func() (*testWorker, error) {
+		w := newTestWorker("key1")
+		w.startErr = fmt.Errorf("snap")
+		return w, nil
+	})
+	assert.Regexp(t, "snap", err)
+
+	// the failures do not use up the slot
+	_, err = startWorker(p, "key1")
+	require.NoError(t, err)
+}
+
+func TestPoolDrain(t *testing.T) {
+	p := NewPool[string, *testWorker](context.Background(), &pldconf.KeyedQueueConfig{
+		MaxActive:   confutil.P(1),
+		SwapTimeout: confutil.P("0"),
+	})
+
+	w1, err := startWorker(p, "key1")
+	require.NoError(t, err)
+	w1.ignore = true
+
+	waitErr := make(chan error, 1)
+	go func() {
+		_, err := startWorker(p, "key2")
+		waitErr <- err
+	}()
+	require.Eventually(t, func() bool { return p.Stats().Waiting == 1 }, 5*time.Second, time.Millisecond)
+
+	// key1 does not exit, so we give up on it
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	p.Drain(ctx)
+	assert.Regexp(t, "PD021100", <-waitErr)
+
+	w1.exit()
+	require.Eventually(t, func() bool { return p.Stats().Active == 0 }, 5*time.Second, time.Millisecond)
+}
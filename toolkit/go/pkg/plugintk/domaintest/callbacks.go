@@ -0,0 +1,98 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package domaintest
+
+import (
+	"context"
+	"sync"
+
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/common/go/pkg/pldmsgs"
+	"github.com/kaleido-io/paladin/toolkit/pkg/plugintk"
+	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
+)
+
+// recordingCallbacks keeps a copy of every state the domain reads, so the suite can supply
+// the data of input and read states to the later steps, as Paladin does
+type recordingCallbacks struct {
+	plugintk.DomainCallbacks
+	mux    sync.Mutex
+	states map[string]*prototk.StoredState
+}
+
+func (rc *recordingCallbacks) record(states []*prototk.StoredState) {
+	rc.mux.Lock()
+	defer rc.mux.Unlock()
+	for _, s := range states {
+		rc.states[s.Id] = s
+	}
+}
+
+func (rc *recordingCallbacks) getState(id string) *prototk.StoredState {
+	rc.mux.Lock()
+	defer rc.mux.Unlock()
+	return rc.states[id]
+}
+
+func (rc *recordingCallbacks) FindAvailableStates(ctx context.Context, req *prototk.FindAvailableStatesRequest) (*prototk.FindAvailableStatesResponse, error) {
+	res, err := rc.DomainCallbacks.FindAvailableStates(ctx, req)
+	if err == nil {
+		rc.record(res.States)
+	}
+	return res, err
+}
+
+func (rc *recordingCallbacks) GetStatesByID(ctx context.Context, req *prototk.GetStatesByIDRequest) (*prototk.GetStatesByIDResponse, error) {
+	res, err := rc.DomainCallbacks.GetStatesByID(ctx, req)
+	if err == nil {
+		rc.record(res.States)
+	}
+	return res, err
+}
+
+type unimplementedCallbacks struct{}
+
+func (*unimplementedCallbacks) FindAvailableStates(ctx context.Context, req *prototk.FindAvailableStatesRequest) (*prototk.FindAvailableStatesResponse, error) {
+	return nil, i18n.NewError(ctx, pldmsgs.MsgPluginUnimplementedRequest, req)
+}
+
+func (*unimplementedCallbacks) EncodeData(ctx context.Context, req *prototk.EncodeDataRequest) (*prototk.EncodeDataResponse, error) {
+	return nil, i18n.NewError(ctx, pldmsgs.MsgPluginUnimplementedRequest, req)
+}
+
+func (*unimplementedCallbacks) DecodeData(ctx context.Context, req *prototk.DecodeDataRequest) (*prototk.DecodeDataResponse, error) {
+	return nil, i18n.NewError(ctx, pldmsgs.MsgPluginUnimplementedRequest, req)
+}
+
+func (*unimplementedCallbacks) RecoverSigner(ctx context.Context, req *prototk.RecoverSignerRequest) (*prototk.RecoverSignerResponse, error) {
+	return nil, i18n.NewError(ctx, pldmsgs.MsgPluginUnimplementedRequest, req)
+}
+
+func (*unimplementedCallbacks) SendTransaction(ctx context.Context, req *prototk.SendTransactionRequest) (*prototk.SendTransactionResponse, error) {
+	return nil, i18n.NewError(ctx, pldmsgs.MsgPluginUnimplementedRequest, req)
+}
+
+func (*unimplementedCallbacks) LocalNodeName(ctx context.Context, req *prototk.LocalNodeNameRequest) (*prototk.LocalNodeNameResponse, error) {
+	return nil, i18n.NewError(ctx, pldmsgs.MsgPluginUnimplementedRequest, req)
+}
+
+func (*unimplementedCallbacks) GetStatesByID(ctx context.Context, req *prototk.GetStatesByIDRequest) (*prototk.GetStatesByIDResponse, error) {
+	return nil, i18n.NewError(ctx, pldmsgs.MsgPluginUnimplementedRequest, req)
+}
+
+func (*unimplementedCallbacks) CheckCredential(ctx context.Context, req *prototk.CheckCredentialRequest) (*prototk.CheckCredentialResponse, error) {
+	return nil, i18n.NewError(ctx, pldmsgs.MsgPluginUnimplementedRequest, req)
+}
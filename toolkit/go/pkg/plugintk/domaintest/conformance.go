@@ -0,0 +1,539 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+// Package domaintest provides a conformance suite that domain authors can run from their own
+// unit tests, against their plugintk.DomainAPI implementation, to check it follows the contract
+// Paladin relies on for each of the requests it sends to a domain.
+//
+// The suite calls the domain directly (without gRPC), in the same order Paladin would.
+package domaintest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sync"
+	"testing"
+
+	"github.com/hyperledger/firefly-signer/pkg/abi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/toolkit/pkg/plugintk"
+	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+// Errors returned by Paladin components are prefixed with a registered message code, such as "PD011600: "
+const DefaultErrorCodePattern = `^[A-Z]{2}\d{2}\d*: `
+
+// Suite describes the domain under test, and the inputs needed to drive it through a full
+// deploy and transaction flow. Only Factory and ConfigJSON are required - the tests that
+// need the optional sections are skipped if they are not supplied.
+type Suite struct {
+	Factory         plugintk.DomainFactory
+	Callbacks       plugintk.DomainCallbacks // defaults to callbacks that return an error for every request
+	Name            string                   // defaults to "conformance"
+	ConfigJSON      string
+	ChainID         int64  // defaults to 1337
+	RegistryAddress string // defaults to a random address
+
+	// Resolves any verifier the domain requires, including those of endorsers in the attestation plan
+	ResolveVerifier func(req *prototk.ResolveVerifierRequest) *prototk.ResolvedVerifier
+
+	// A deploy the domain should accept
+	Deploy *prototk.DeployTransactionSpecification
+
+	// A transaction the domain should be able to assemble, endorse and prepare
+	Transaction *TransactionCase
+
+	// How many transactions to initialize and assemble in parallel against one domain instance (default 10)
+	Concurrency int
+
+	// Every error returned by the domain must match this pattern (defaults to DefaultErrorCodePattern)
+	ErrorCodePattern string
+}
+
+type TransactionCase struct {
+	ContractAddress string
+	ContractConfig  []byte                            // the config emitted by the constructor event, as passed to InitContract
+	Transaction     *prototk.TransactionSpecification // ContractInfo is filled in from InitContract if not set
+
+	// Produces the result for SIGN and GENERATE_PROOF attestations in the plan, and signs the payload
+	// of any endorsement with a SIGN result. ENDORSE attestations are performed by the domain itself.
+	Attest func(req *prototk.AttestationRequest, verifier *prototk.ResolvedVerifier) *prototk.AttestationResult
+}
+
+type conformance struct {
+	*Suite
+	callbacks *recordingCallbacks
+	errorCode *regexp.Regexp
+	schemas   []*prototk.StateSchema
+}
+
+// Run executes the conformance suite as a set of sub-tests of t
+func Run(t *testing.T, s *Suite) {
+	require.NotNil(t, s.Factory, "Factory is required")
+	c := &conformance{Suite: s}
+	if c.Name == "" {
+		c.Name = "conformance"
+	}
+	if c.ChainID == 0 {
+		c.ChainID = 1337
+	}
+	if c.RegistryAddress == "" {
+		c.RegistryAddress = pldtypes.RandAddress().String()
+	}
+	if c.Concurrency <= 0 {
+		c.Concurrency = 10
+	}
+	pattern := c.ErrorCodePattern
+	if pattern == "" {
+		pattern = DefaultErrorCodePattern
+	}
+	c.errorCode = regexp.MustCompile(pattern)
+	var callbacks plugintk.DomainCallbacks = &unimplementedCallbacks{}
+	if c.Callbacks != nil {
+		callbacks = c.Callbacks
+	}
+	c.callbacks = &recordingCallbacks{DomainCallbacks: callbacks, states: map[string]*prototk.StoredState{}}
+
+	t.Run("ConfigureDomain", c.testConfigureDomain)
+	t.Run("ConfigureDomainInvalidConfig", c.testConfigureDomainInvalidConfig)
+	t.Run("UninitializedRequests", c.testUninitializedRequests)
+	t.Run("Deploy", c.testDeploy)
+	t.Run("DeployInvalidParams", c.testDeployInvalidParams)
+	t.Run("InitContract", c.testInitContract)
+	t.Run("InitContractInvalidConfig", c.testInitContractInvalidConfig)
+	t.Run("Transaction", c.testTransaction)
+	t.Run("TransactionInvalidParams", c.testTransactionInvalidParams)
+	t.Run("Concurrency", c.testConcurrency)
+}
+
+// call invokes the domain, converting a panic into a test failure (and an error)
+func call[Req, Res any](t *testing.T, fn func(context.Context, *Req) (*Res, error), req *Req) (res *Res, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			t.Errorf("domain panicked handling %T: %v", req, r)
+			res, err = nil, fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return fn(context.Background(), req)
+}
+
+func (c *conformance) assertErrorCode(t *testing.T, err error, msg string) {
+	if assert.Error(t, err, msg) {
+		assert.Regexp(t, c.errorCode, err.Error(), "%s: errors must be prefixed with a message code", msg)
+	}
+}
+
+// newDomain creates a fresh instance of the domain, configured and initialized
+func (c *conformance) newDomain(t *testing.T) plugintk.DomainAPI {
+	d := c.Factory(c.callbacks)
+	confRes, err := call(t, d.ConfigureDomain, &prototk.ConfigureDomainRequest{
+		Name:                    c.Name,
+		ConfigJson:              c.ConfigJSON,
+		RegistryContractAddress: c.RegistryAddress,
+		ChainId:                 c.ChainID,
+	})
+	require.NoError(t, err, "ConfigureDomain")
+	require.NotNil(t, confRes.DomainConfig, "ConfigureDomain must return a DomainConfig")
+
+	// Paladin generates the schema IDs - here we use a simple hash of the definition
+	schemas := make([]*prototk.StateSchema, len(confRes.DomainConfig.AbiStateSchemasJson))
+	for i, schemaJSON := range confRes.DomainConfig.AbiStateSchemasJson {
+		var param abi.Parameter
+		err := json.Unmarshal([]byte(schemaJSON), &param)
+		require.NoError(t, err, "state schema %d must be a valid ABI parameter", i)
+		schemas[i] = &prototk.StateSchema{
+			Id:        pldtypes.Bytes32Keccak([]byte(schemaJSON)).String(),
+			Signature: param.String(),
+		}
+	}
+	_, err = call(t, d.InitDomain, &prototk.InitDomainRequest{AbiStateSchemas: schemas})
+	require.NoError(t, err, "InitDomain")
+	c.schemas = schemas
+	return d
+}
+
+func (c *conformance) testConfigureDomain(t *testing.T) {
+	d := c.Factory(c.callbacks)
+	confRes, err := call(t, d.ConfigureDomain, &prototk.ConfigureDomainRequest{
+		Name:                    c.Name,
+		ConfigJson:              c.ConfigJSON,
+		RegistryContractAddress: c.RegistryAddress,
+		ChainId:                 c.ChainID,
+	})
+	require.NoError(t, err, "ConfigureDomain")
+	dc := confRes.DomainConfig
+	require.NotNil(t, dc, "ConfigureDomain must return a DomainConfig")
+
+	for i, schemaJSON := range dc.AbiStateSchemasJson {
+		var param abi.Parameter
+		err := json.Unmarshal([]byte(schemaJSON), &param)
+		require.NoError(t, err, "state schema %d must be a valid ABI parameter", i)
+		assert.Equal(t, "tuple", param.Type, "state schema %d must be a tuple", i)
+		assert.NotEmpty(t, param.InternalType, "state schema %d must have an internalType naming the struct", i)
+	}
+	if dc.AbiEventsJson != "" {
+		var events abi.ABI
+		err := json.Unmarshal([]byte(dc.AbiEventsJson), &events)
+		require.NoError(t, err, "events must be a valid ABI")
+		for _, e := range events {
+			assert.Equal(t, abi.Event, e.Type, "events ABI must only contain events (found %s %s)", e.Type, e.Name)
+		}
+	}
+	for algo, keyLen := range dc.SigningAlgorithms {
+		assert.Positive(t, keyLen, "signing algorithm %s must have a key length", algo)
+	}
+	for _, method := range dc.RpcMethods {
+		assert.NotContains(t, method, "_", "RPC method %s must not contain '_'", method)
+	}
+
+}
+
+func (c *conformance) testConfigureDomainInvalidConfig(t *testing.T) {
+	d := c.Factory(c.callbacks)
+	_, err := call(t, d.ConfigureDomain, &prototk.ConfigureDomainRequest{
+		Name:                    c.Name,
+		ConfigJson:              `{!!! not valid JSON`,
+		RegistryContractAddress: c.RegistryAddress,
+		ChainId:                 c.ChainID,
+	})
+	c.assertErrorCode(t, err, "ConfigureDomain with invalid config JSON")
+}
+
+// Requests can arrive with empty payloads (for example from a misbehaving node), and before the
+// domain is initialized. The domain can return errors for these, but must not panic.
+func (c *conformance) testUninitializedRequests(t *testing.T) {
+	d := c.Factory(c.callbacks)
+	_, _ = call(t, d.InitDeploy, &prototk.InitDeployRequest{})
+	_, _ = call(t, d.PrepareDeploy, &prototk.PrepareDeployRequest{})
+	_, _ = call(t, d.InitContract, &prototk.InitContractRequest{})
+	_, _ = call(t, d.InitTransaction, &prototk.InitTransactionRequest{})
+	_, _ = call(t, d.AssembleTransaction, &prototk.AssembleTransactionRequest{})
+	_, _ = call(t, d.EndorseTransaction, &prototk.EndorseTransactionRequest{})
+	_, _ = call(t, d.PrepareTransaction, &prototk.PrepareTransactionRequest{})
+	_, _ = call(t, d.HandleEventBatch, &prototk.HandleEventBatchRequest{})
+	_, _ = call(t, d.InitCall, &prototk.InitCallRequest{})
+	_, _ = call(t, d.ExecCall, &prototk.ExecCallRequest{})
+	_, _ = call(t, d.BuildReceipt, &prototk.BuildReceiptRequest{})
+	_, _ = call(t, d.ValidateStateHashes, &prototk.ValidateStateHashesRequest{})
+}
+
+func (c *conformance) resolveVerifiers(t *testing.T, required []*prototk.ResolveVerifierRequest) []*prototk.ResolvedVerifier {
+	resolved := make([]*prototk.ResolvedVerifier, len(required))
+	for i, req := range required {
+		assert.NotEmpty(t, req.Lookup, "required verifier %d must have a lookup", i)
+		assert.NotEmpty(t, req.Algorithm, "required verifier %d must have an algorithm", i)
+		assert.NotEmpty(t, req.VerifierType, "required verifier %d must have a verifier type", i)
+		require.NotNil(t, c.ResolveVerifier, "ResolveVerifier is required to resolve %s", req.Lookup)
+		resolved[i] = c.ResolveVerifier(req)
+		require.NotNil(t, resolved[i], "ResolveVerifier returned nil for %s", req.Lookup)
+	}
+	return resolved
+}
+
+func checkPreparedTransaction(t *testing.T, tx *prototk.PreparedTransaction, step string) {
+	var fn abi.Entry
+	err := json.Unmarshal([]byte(tx.FunctionAbiJson), &fn)
+	require.NoError(t, err, "%s must return a valid function ABI", step)
+	assert.True(t, json.Valid([]byte(tx.ParamsJson)), "%s must return valid params JSON", step)
+	if tx.ContractAddress != nil {
+		_, err := pldtypes.ParseEthAddress(*tx.ContractAddress)
+		assert.NoError(t, err, "%s must return a valid contract address", step)
+	}
+}
+
+func (c *conformance) testDeploy(t *testing.T) {
+	if c.Deploy == nil {
+		t.Skip("no Deploy supplied")
+	}
+	d := c.newDomain(t)
+
+	deploy := proto.Clone(c.Deploy).(*prototk.DeployTransactionSpecification)
+	initRes, err := call(t, d.InitDeploy, &prototk.InitDeployRequest{Transaction: deploy})
+	require.NoError(t, err, "InitDeploy")
+	resolved := c.resolveVerifiers(t, initRes.RequiredVerifiers)
+
+	prepRes, err := call(t, d.PrepareDeploy, &prototk.PrepareDeployRequest{
+		Transaction:       deploy,
+		ResolvedVerifiers: resolved,
+	})
+	require.NoError(t, err, "PrepareDeploy")
+	require.True(t, (prepRes.Transaction == nil) != (prepRes.Deploy == nil),
+		"PrepareDeploy must return exactly one of a transaction or a deploy")
+	if prepRes.Transaction != nil {
+		checkPreparedTransaction(t, prepRes.Transaction, "PrepareDeploy")
+	} else {
+		var constructor abi.Entry
+		err := json.Unmarshal([]byte(prepRes.Deploy.ConstructorAbiJson), &constructor)
+		require.NoError(t, err, "PrepareDeploy must return a valid constructor ABI")
+		assert.NotEmpty(t, prepRes.Deploy.Bytecode, "PrepareDeploy must return bytecode")
+		assert.True(t, json.Valid([]byte(prepRes.Deploy.ParamsJson)), "PrepareDeploy must return valid params JSON")
+	}
+}
+
+func (c *conformance) testDeployInvalidParams(t *testing.T) {
+	if c.Deploy == nil {
+		t.Skip("no Deploy supplied")
+	}
+	d := c.newDomain(t)
+
+	deploy := proto.Clone(c.Deploy).(*prototk.DeployTransactionSpecification)
+	deploy.ConstructorParamsJson = `{!!! not valid JSON`
+	_, err := call(t, d.InitDeploy, &prototk.InitDeployRequest{Transaction: deploy})
+	c.assertErrorCode(t, err, "InitDeploy with invalid params")
+}
+
+func (c *conformance) initContract(t *testing.T, d plugintk.DomainAPI) *prototk.ContractConfig {
+	res, err := call(t, d.InitContract, &prototk.InitContractRequest{
+		ContractAddress: c.Transaction.ContractAddress,
+		ContractConfig:  c.Transaction.ContractConfig,
+	})
+	require.NoError(t, err, "InitContract")
+	require.True(t, res.Valid, "InitContract must accept the supplied contract config")
+	require.NotNil(t, res.ContractConfig, "InitContract must return the parsed contract config")
+	assert.True(t, json.Valid([]byte(res.ContractConfig.ContractConfigJson)), "InitContract must return valid config JSON")
+	return res.ContractConfig
+}
+
+func (c *conformance) testInitContract(t *testing.T) {
+	if c.Transaction == nil {
+		t.Skip("no Transaction supplied")
+	}
+	cc := c.initContract(t, c.newDomain(t))
+	if cc.CoordinatorSelection == prototk.ContractConfig_COORDINATOR_STATIC {
+		assert.NotEmpty(t, cc.GetStaticCoordinator(), "a static coordinator must be specified with COORDINATOR_STATIC")
+	}
+}
+
+// An invalid contract must be rejected with valid=false, not an error, as an error blocks
+// the event processing for the whole domain.
+func (c *conformance) testInitContractInvalidConfig(t *testing.T) {
+	if c.Transaction == nil {
+		t.Skip("no Transaction supplied")
+	}
+	d := c.newDomain(t)
+	res, err := call(t, d.InitContract, &prototk.InitContractRequest{
+		ContractAddress: c.Transaction.ContractAddress,
+		ContractConfig:  []byte{0xfe, 0xed, 0xbe, 0xef},
+	})
+	require.NoError(t, err, "InitContract must not return an error for an invalid contract")
+	assert.False(t, res.Valid, "InitContract must reject an invalid contract config")
+}
+
+func (c *conformance) transactionSpec(t *testing.T, d plugintk.DomainAPI) *prototk.TransactionSpecification {
+	cc := c.initContract(t, d)
+	tx := proto.Clone(c.Transaction.Transaction).(*prototk.TransactionSpecification)
+	if tx.ContractInfo == nil {
+		tx.ContractInfo = &prototk.ContractInfo{
+			ContractAddress:    c.Transaction.ContractAddress,
+			ContractConfigJson: cc.ContractConfigJson,
+		}
+	}
+	if tx.TransactionId == "" {
+		tx.TransactionId = pldtypes.RandBytes32().String()
+	}
+	return tx
+}
+
+func (c *conformance) assemble(t *testing.T, d plugintk.DomainAPI, tx *prototk.TransactionSpecification) ([]*prototk.ResolvedVerifier, *prototk.AssembleTransactionResponse) {
+	initRes, err := call(t, d.InitTransaction, &prototk.InitTransactionRequest{Transaction: tx})
+	require.NoError(t, err, "InitTransaction")
+	resolved := c.resolveVerifiers(t, initRes.RequiredVerifiers)
+
+	res, err := call(t, d.AssembleTransaction, &prototk.AssembleTransactionRequest{
+		StateQueryContext: pldtypes.RandHex(16),
+		Transaction:       tx,
+		ResolvedVerifiers: resolved,
+	})
+	require.NoError(t, err, "AssembleTransaction")
+	return resolved, res
+}
+
+func (c *conformance) knownSchema(schemaID string) bool {
+	for _, s := range c.schemas {
+		if s.Id == schemaID {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *conformance) endorsableStates(t *testing.T, refs []*prototk.StateRef) []*prototk.EndorsableState {
+	states := make([]*prototk.EndorsableState, len(refs))
+	for i, ref := range refs {
+		assert.True(t, c.knownSchema(ref.SchemaId), "state %s must use one of the domain's schemas", ref.Id)
+		stored := c.callbacks.getState(ref.Id)
+		require.NotNil(t, stored, "state %s must have been obtained via FindAvailableStates or GetStatesByID", ref.Id)
+		states[i] = &prototk.EndorsableState{Id: ref.Id, SchemaId: ref.SchemaId, StateDataJson: stored.DataJson}
+	}
+	return states
+}
+
+func (c *conformance) newEndorsableStates(t *testing.T, newStates []*prototk.NewState) []*prototk.EndorsableState {
+	states := make([]*prototk.EndorsableState, len(newStates))
+	for i, ns := range newStates {
+		assert.True(t, c.knownSchema(ns.SchemaId), "new state %d must use one of the domain's schemas", i)
+		assert.True(t, json.Valid([]byte(ns.StateDataJson)), "new state %d must have valid JSON data", i)
+		id := ns.GetId()
+		if id == "" {
+			id = pldtypes.RandBytes32().String()
+		}
+		states[i] = &prototk.EndorsableState{Id: id, SchemaId: ns.SchemaId, StateDataJson: ns.StateDataJson}
+	}
+	return states
+}
+
+func (c *conformance) testTransaction(t *testing.T) {
+	if c.Transaction == nil {
+		t.Skip("no Transaction supplied")
+	}
+	d := c.newDomain(t)
+	tx := c.transactionSpec(t, d)
+	resolved, assembleRes := c.assemble(t, d, tx)
+	require.Equal(t, prototk.AssembleTransactionResponse_OK, assembleRes.AssemblyResult,
+		"AssembleTransaction must succeed for the supplied transaction (revert reason: %s)", assembleRes.GetRevertReason())
+	assembled := assembleRes.AssembledTransaction
+	require.NotNil(t, assembled, "AssembleTransaction must return the assembled transaction")
+
+	inputs := c.endorsableStates(t, assembled.InputStates)
+	reads := c.endorsableStates(t, assembled.ReadStates)
+	outputs := c.newEndorsableStates(t, assembled.OutputStates)
+	info := c.newEndorsableStates(t, assembled.InfoStates)
+
+	var signatures, attestations []*prototk.AttestationResult
+	lastType := prototk.AttestationType_SIGN
+	for _, ar := range assembleRes.AttestationPlan {
+		assert.GreaterOrEqual(t, ar.AttestationType, lastType, "attestation %s is out of order in the plan", ar.Name)
+		lastType = ar.AttestationType
+		assert.NotEmpty(t, ar.Parties, "attestation %s must have at least one party", ar.Name)
+		for _, party := range ar.Parties {
+			verifier := c.resolveVerifiers(t, []*prototk.ResolveVerifierRequest{
+				{Lookup: party, Algorithm: ar.Algorithm, VerifierType: ar.VerifierType},
+			})[0]
+			switch ar.AttestationType {
+			case prototk.AttestationType_ENDORSE:
+				endorseRes, err := call(t, d.EndorseTransaction, &prototk.EndorseTransactionRequest{
+					StateQueryContext:   pldtypes.RandHex(16),
+					EndorsementRequest:  ar,
+					EndorsementVerifier: verifier,
+					Transaction:         tx,
+					ResolvedVerifiers:   resolved,
+					Inputs:              inputs,
+					Reads:               reads,
+					Outputs:             outputs,
+					Info:                info,
+					Signatures:          signatures,
+				})
+				require.NoError(t, err, fmt.Sprintf("EndorseTransaction by %s", party))
+				switch endorseRes.EndorsementResult {
+				case prototk.EndorseTransactionResponse_SIGN:
+					require.NotEmpty(t, endorseRes.Payload, "an endorsement with a SIGN result must return a payload")
+					require.NotNil(t, c.Transaction.Attest, "Attest is required to sign endorsement %s", ar.Name)
+					signReq := proto.Clone(ar).(*prototk.AttestationRequest)
+					signReq.Payload = endorseRes.Payload
+					attestations = append(attestations, c.Transaction.Attest(signReq, verifier))
+				case prototk.EndorseTransactionResponse_ENDORSER_SUBMIT:
+					attestations = append(attestations, &prototk.AttestationResult{
+						Name:            ar.Name,
+						AttestationType: ar.AttestationType,
+						Verifier:        verifier,
+						Constraints:     []prototk.AttestationResult_AttestationConstraint{prototk.AttestationResult_ENDORSER_MUST_SUBMIT},
+					})
+				default:
+					require.Fail(t, "endorsement rejected", "EndorseTransaction by %s returned %s: %s", party, endorseRes.EndorsementResult, endorseRes.GetRevertReason())
+				}
+			default:
+				require.NotNil(t, c.Transaction.Attest, "Attest is required for %s attestation %s", ar.AttestationType, ar.Name)
+				result := c.Transaction.Attest(ar, verifier)
+				require.NotNil(t, result, "Attest returned nil for %s", ar.Name)
+				if ar.AttestationType == prototk.AttestationType_SIGN {
+					signatures = append(signatures, result)
+				}
+				attestations = append(attestations, result)
+			}
+		}
+	}
+
+	prepRes, err := call(t, d.PrepareTransaction, &prototk.PrepareTransactionRequest{
+		StateQueryContext: pldtypes.RandHex(16),
+		Transaction:       tx,
+		InputStates:       inputs,
+		ReadStates:        reads,
+		OutputStates:      outputs,
+		InfoStates:        info,
+		AttestationResult: attestations,
+		ResolvedVerifiers: resolved,
+		DomainData:        assembled.DomainData,
+	})
+	require.NoError(t, err, "PrepareTransaction")
+	require.NotNil(t, prepRes.Transaction, "PrepareTransaction must return a transaction")
+	checkPreparedTransaction(t, prepRes.Transaction, "PrepareTransaction")
+}
+
+func (c *conformance) testTransactionInvalidParams(t *testing.T) {
+	if c.Transaction == nil {
+		t.Skip("no Transaction supplied")
+	}
+	d := c.newDomain(t)
+	tx := c.transactionSpec(t, d)
+	tx.FunctionParamsJson = `{!!! not valid JSON`
+	_, err := call(t, d.InitTransaction, &prototk.InitTransactionRequest{Transaction: tx})
+	c.assertErrorCode(t, err, "InitTransaction with invalid params")
+}
+
+// Paladin calls into a domain from many goroutines at once, for different transactions
+// and contracts, so the domain must be safe for concurrent use (run with -race to check).
+func (c *conformance) testConcurrency(t *testing.T) {
+	if c.Transaction == nil {
+		t.Skip("no Transaction supplied")
+	}
+	d := c.newDomain(t)
+	baseTX := c.transactionSpec(t, d)
+
+	// The verifiers are the same for every copy of the transaction, so we resolve them up front
+	initRes, err := call(t, d.InitTransaction, &prototk.InitTransactionRequest{Transaction: baseTX})
+	require.NoError(t, err, "InitTransaction")
+	resolved := c.resolveVerifiers(t, initRes.RequiredVerifiers)
+
+	// Only assert (not require) is safe to use in the goroutines
+	var wg sync.WaitGroup
+	for i := 0; i < c.Concurrency; i++ {
+		wg.Add(1)
+		tx := proto.Clone(baseTX).(*prototk.TransactionSpecification)
+		tx.TransactionId = pldtypes.RandBytes32().String()
+		go func() {
+			defer wg.Done()
+			_, err := call(t, d.InitTransaction, &prototk.InitTransactionRequest{Transaction: tx})
+			if !assert.NoError(t, err, "InitTransaction %s", tx.TransactionId) {
+				return
+			}
+			res, err := call(t, d.AssembleTransaction, &prototk.AssembleTransactionRequest{
+				StateQueryContext: pldtypes.RandHex(16),
+				Transaction:       tx,
+				ResolvedVerifiers: resolved,
+			})
+			if assert.NoError(t, err, "AssembleTransaction %s", tx.TransactionId) {
+				// There might not be enough states for every transaction, but each must be handled cleanly
+				assert.NotEqual(t, prototk.AssembleTransactionResponse_REVERT, res.AssemblyResult,
+					"AssembleTransaction %s reverted: %s", tx.TransactionId, res.GetRevertReason())
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,321 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package domaintest
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/toolkit/pkg/algorithms"
+	"github.com/kaleido-io/paladin/toolkit/pkg/plugintk"
+	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
+	"github.com/kaleido-io/paladin/toolkit/pkg/verifiers"
+	"golang.org/x/text/language"
+)
+
+var registered sync.Once
+var pde = func(key, translation string) i18n.ErrorMessageKey {
+	registered.Do(func() {
+		i18n.RegisterPrefix("DT01", "Domain conformance test")
+	})
+	return i18n.PDE(language.AmericanEnglish, key, translation)
+}
+
+var (
+	msgInvalidConfig = pde("DT010001", "Invalid config: %s")
+	msgInvalidParams = pde("DT010002", "Invalid params: %s")
+)
+
+const coinSchema = `{
+	"type": "tuple",
+	"internalType": "struct Coin",
+	"components": [
+		{"name": "owner", "type": "string", "indexed": true},
+		{"name": "amount", "type": "uint256"}
+	]
+}`
+
+type notaryConfig struct {
+	Notary string `json:"notary"`
+}
+
+type transferParams struct {
+	To     string               `json:"to"`
+	Amount *pldtypes.HexUint256 `json:"amount"`
+}
+
+// coinDomain is a minimal notarized token, sufficient to drive the whole suite
+type coinDomain struct {
+	callbacks plugintk.DomainCallbacks
+	mux       sync.Mutex
+	schemaID  string
+}
+
+func newCoinDomain(callbacks plugintk.DomainCallbacks) plugintk.DomainAPI {
+	d := &coinDomain{callbacks: callbacks}
+	return &plugintk.DomainAPIBase{Functions: &plugintk.DomainAPIFunctions{
+		ConfigureDomain:     d.configureDomain,
+		InitDomain:          d.initDomain,
+		InitDeploy:          d.initDeploy,
+		PrepareDeploy:       d.prepareDeploy,
+		InitContract:        d.initContract,
+		InitTransaction:     d.initTransaction,
+		AssembleTransaction: d.assembleTransaction,
+		EndorseTransaction:  d.endorseTransaction,
+		PrepareTransaction:  d.prepareTransaction,
+	}}
+}
+
+func (d *coinDomain) configureDomain(ctx context.Context, req *prototk.ConfigureDomainRequest) (*prototk.ConfigureDomainResponse, error) {
+	var conf map[string]any
+	if err := json.Unmarshal([]byte(req.ConfigJson), &conf); err != nil {
+		return nil, i18n.WrapError(ctx, err, msgInvalidConfig, err)
+	}
+	return &prototk.ConfigureDomainResponse{
+		DomainConfig: &prototk.DomainConfig{
+			AbiStateSchemasJson: []string{coinSchema},
+			AbiEventsJson:       `[{"type":"event","name":"Transfer","inputs":[]}]`,
+		},
+	}, nil
+}
+
+func (d *coinDomain) initDomain(ctx context.Context, req *prototk.InitDomainRequest) (*prototk.InitDomainResponse, error) {
+	d.mux.Lock()
+	defer d.mux.Unlock()
+	d.schemaID = req.AbiStateSchemas[0].Id
+	return &prototk.InitDomainResponse{}, nil
+}
+
+func (d *coinDomain) getSchemaID() string {
+	d.mux.Lock()
+	defer d.mux.Unlock()
+	return d.schemaID
+}
+
+func requireVerifier(lookup string) *prototk.ResolveVerifierRequest {
+	return &prototk.ResolveVerifierRequest{
+		Lookup:       lookup,
+		Algorithm:    algorithms.ECDSA_SECP256K1,
+		VerifierType: verifiers.ETH_ADDRESS,
+	}
+}
+
+func (d *coinDomain) initDeploy(ctx context.Context, req *prototk.InitDeployRequest) (*prototk.InitDeployResponse, error) {
+	if req.Transaction == nil {
+		return nil, i18n.NewError(ctx, msgInvalidParams, "missing transaction")
+	}
+	var params notaryConfig
+	if err := json.Unmarshal([]byte(req.Transaction.ConstructorParamsJson), &params); err != nil {
+		return nil, i18n.WrapError(ctx, err, msgInvalidParams, err)
+	}
+	return &prototk.InitDeployResponse{
+		RequiredVerifiers: []*prototk.ResolveVerifierRequest{requireVerifier(params.Notary)},
+	}, nil
+}
+
+func (d *coinDomain) prepareDeploy(ctx context.Context, req *prototk.PrepareDeployRequest) (*prototk.PrepareDeployResponse, error) {
+	if len(req.ResolvedVerifiers) != 1 {
+		return nil, i18n.NewError(ctx, msgInvalidParams, "missing notary verifier")
+	}
+	return &prototk.PrepareDeployResponse{
+		Deploy: &prototk.BaseLedgerDeployTransaction{
+			ConstructorAbiJson: `{"type":"constructor","inputs":[{"name":"notary","type":"address"}]}`,
+			Bytecode:           []byte{0x01},
+			ParamsJson:         pldtypes.JSONString(map[string]string{"notary": req.ResolvedVerifiers[0].Verifier}).String(),
+		},
+	}, nil
+}
+
+func (d *coinDomain) initContract(ctx context.Context, req *prototk.InitContractRequest) (*prototk.InitContractResponse, error) {
+	var conf notaryConfig
+	if err := json.Unmarshal(req.ContractConfig, &conf); err != nil || conf.Notary == "" {
+		return &prototk.InitContractResponse{Valid: false}, nil
+	}
+	return &prototk.InitContractResponse{
+		Valid: true,
+		ContractConfig: &prototk.ContractConfig{
+			ContractConfigJson:   string(req.ContractConfig),
+			CoordinatorSelection: prototk.ContractConfig_COORDINATOR_STATIC,
+			StaticCoordinator:    &conf.Notary,
+		},
+	}, nil
+}
+
+func (d *coinDomain) parseTransfer(ctx context.Context, tx *prototk.TransactionSpecification) (*notaryConfig, *transferParams, error) {
+	if tx == nil || tx.ContractInfo == nil {
+		return nil, nil, i18n.NewError(ctx, msgInvalidParams, "missing transaction")
+	}
+	var conf notaryConfig
+	var params transferParams
+	err := json.Unmarshal([]byte(tx.ContractInfo.ContractConfigJson), &conf)
+	if err == nil {
+		err = json.Unmarshal([]byte(tx.FunctionParamsJson), &params)
+	}
+	if err != nil {
+		return nil, nil, i18n.WrapError(ctx, err, msgInvalidParams, err)
+	}
+	return &conf, &params, nil
+}
+
+func (d *coinDomain) initTransaction(ctx context.Context, req *prototk.InitTransactionRequest) (*prototk.InitTransactionResponse, error) {
+	if _, _, err := d.parseTransfer(ctx, req.Transaction); err != nil {
+		return nil, err
+	}
+	return &prototk.InitTransactionResponse{
+		RequiredVerifiers: []*prototk.ResolveVerifierRequest{requireVerifier(req.Transaction.From)},
+	}, nil
+}
+
+func (d *coinDomain) assembleTransaction(ctx context.Context, req *prototk.AssembleTransactionRequest) (*prototk.AssembleTransactionResponse, error) {
+	conf, params, err := d.parseTransfer(ctx, req.Transaction)
+	if err != nil {
+		return nil, err
+	}
+	schemaID := d.getSchemaID()
+	coins, err := d.callbacks.FindAvailableStates(ctx, &prototk.FindAvailableStatesRequest{
+		StateQueryContext: req.StateQueryContext,
+		SchemaId:          schemaID,
+		QueryJson:         `{"eq":[{"field":"owner","value":"` + req.Transaction.From + `"}]}`,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(coins.States) == 0 {
+		return &prototk.AssembleTransactionResponse{AssemblyResult: prototk.AssembleTransactionResponse_PARK}, nil
+	}
+	return &prototk.AssembleTransactionResponse{
+		AssemblyResult: prototk.AssembleTransactionResponse_OK,
+		AssembledTransaction: &prototk.AssembledTransaction{
+			InputStates: []*prototk.StateRef{{Id: coins.States[0].Id, SchemaId: schemaID}},
+			OutputStates: []*prototk.NewState{{
+				SchemaId:         schemaID,
+				StateDataJson:    pldtypes.JSONString(map[string]any{"owner": params.To, "amount": params.Amount}).String(),
+				DistributionList: []string{params.To},
+			}},
+		},
+		AttestationPlan: []*prototk.AttestationRequest{
+			{
+				Name:            "sender",
+				AttestationType: prototk.AttestationType_SIGN,
+				Algorithm:       algorithms.ECDSA_SECP256K1,
+				VerifierType:    verifiers.ETH_ADDRESS,
+				Payload:         []byte(req.Transaction.TransactionId),
+				Parties:         []string{req.Transaction.From},
+			},
+			{
+				Name:            "notary",
+				AttestationType: prototk.AttestationType_ENDORSE,
+				Algorithm:       algorithms.ECDSA_SECP256K1,
+				VerifierType:    verifiers.ETH_ADDRESS,
+				Parties:         []string{conf.Notary},
+			},
+		},
+	}, nil
+}
+
+func (d *coinDomain) endorseTransaction(ctx context.Context, req *prototk.EndorseTransactionRequest) (*prototk.EndorseTransactionResponse, error) {
+	if len(req.Signatures) != 1 || len(req.Inputs) != 1 || len(req.Outputs) != 1 {
+		return &prototk.EndorseTransactionResponse{
+			EndorsementResult: prototk.EndorseTransactionResponse_REVERT,
+			RevertReason:      confutil.P("unexpected endorsement request"),
+		}, nil
+	}
+	return &prototk.EndorseTransactionResponse{EndorsementResult: prototk.EndorseTransactionResponse_ENDORSER_SUBMIT}, nil
+}
+
+func (d *coinDomain) prepareTransaction(ctx context.Context, req *prototk.PrepareTransactionRequest) (*prototk.PrepareTransactionResponse, error) {
+	if len(req.InputStates) != 1 || len(req.OutputStates) != 1 {
+		return nil, i18n.NewError(ctx, msgInvalidParams, "expected one input and one output")
+	}
+	return &prototk.PrepareTransactionResponse{
+		Transaction: &prototk.PreparedTransaction{
+			FunctionAbiJson: `{"type":"function","name":"transfer","inputs":[{"name":"inputs","type":"bytes32[]"},{"name":"outputs","type":"bytes32[]"}]}`,
+			ParamsJson: pldtypes.JSONString(map[string]any{
+				"inputs":  []string{req.InputStates[0].Id},
+				"outputs": []string{req.OutputStates[0].Id},
+			}).String(),
+		},
+	}, nil
+}
+
+// coinCallbacks has a single coin for every owner
+type coinCallbacks struct {
+	unimplementedCallbacks
+}
+
+func (*coinCallbacks) FindAvailableStates(ctx context.Context, req *prototk.FindAvailableStatesRequest) (*prototk.FindAvailableStatesResponse, error) {
+	return &prototk.FindAvailableStatesResponse{
+		States: []*prototk.StoredState{{
+			Id:       pldtypes.RandBytes32().String(),
+			SchemaId: req.SchemaId,
+			DataJson: `{"owner":"alice","amount":"100"}`,
+		}},
+	}, nil
+}
+
+func resolveVerifier(req *prototk.ResolveVerifierRequest) *prototk.ResolvedVerifier {
+	return &prototk.ResolvedVerifier{
+		Lookup:       req.Lookup,
+		Algorithm:    req.Algorithm,
+		VerifierType: req.VerifierType,
+		Verifier:     pldtypes.RandAddress().String(),
+	}
+}
+
+func TestConformanceSuite(t *testing.T) {
+	contractConfig := pldtypes.JSONString(&notaryConfig{Notary: "notary@node1"})
+	Run(t, &Suite{
+		Factory:         newCoinDomain,
+		Callbacks:       &coinCallbacks{},
+		ConfigJSON:      `{}`,
+		ResolveVerifier: resolveVerifier,
+		Deploy: &prototk.DeployTransactionSpecification{
+			TransactionId:         pldtypes.RandBytes32().String(),
+			From:                  "deployer@node1",
+			ConstructorParamsJson: contractConfig.String(),
+		},
+		Transaction: &TransactionCase{
+			ContractAddress: pldtypes.RandAddress().String(),
+			ContractConfig:  contractConfig,
+			Transaction: &prototk.TransactionSpecification{
+				From:               "alice@node1",
+				FunctionSignature:  "transfer(string,uint256)",
+				FunctionParamsJson: `{"to":"bob@node2","amount":"10"}`,
+			},
+			Attest: func(req *prototk.AttestationRequest, verifier *prototk.ResolvedVerifier) *prototk.AttestationResult {
+				return &prototk.AttestationResult{
+					Name:            req.Name,
+					AttestationType: req.AttestationType,
+					Verifier:        verifier,
+					Payload:         []byte("signature"),
+				}
+			},
+		},
+	})
+}
+
+func TestConformanceSuiteConfigOnly(t *testing.T) {
+	// Without callbacks, a deploy or a transaction, only the configuration tests run
+	Run(t, &Suite{
+		Factory:    newCoinDomain,
+		ConfigJSON: `{}`,
+	})
+}
@@ -177,6 +177,11 @@ func (pr *pluginRun[M]) serve() error {
 		// Handling based on the type
 		switch header.MessageType {
 		case prototk.Header_REQUEST_TO_PLUGIN:
+			if msg.RequestToPlugin() == nil {
+				// A ping from Paladin to check we are still processing requests
+				go pr.handlePing(msg)
+				continue
+			}
 			// Dispatch to another go routine so we can continue to serve requests
 			go pr.handleRequestToPlugin(msg)
 		case prototk.Header_RESPONSE_TO_PLUGIN, prototk.Header_ERROR_RESPONSE:
@@ -201,6 +206,18 @@ func (pr *pluginRun[M]) serve() error {
 
 }
 
+func (pr *pluginRun[M]) handlePing(msg PluginMessage[M]) {
+	header := msg.Header()
+	log.L(pr.ctx).Debugf("[%s] --> PING", header.MessageId)
+	reply := pr.pi.impl.Wrap(new(M))
+	replyHeader := reply.Header()
+	replyHeader.MessageType = prototk.Header_RESPONSE_FROM_PLUGIN
+	replyHeader.PluginId = pr.pi.id
+	replyHeader.CorrelationId = &header.MessageId
+	replyHeader.MessageId = uuid.NewString()
+	pr.send(reply.Message())
+}
+
 func (pr *pluginRun[M]) handleRequestToPlugin(msg PluginMessage[M]) {
 
 	// Log the request and generate a reply identifier
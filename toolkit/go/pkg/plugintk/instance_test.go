@@ -233,3 +233,45 @@ func TestEmptyErrorFromServer(t *testing.T) {
 	_, err := callbacks.FindAvailableStates(ctx, &prototk.FindAvailableStatesRequest{})
 	assert.Regexp(t, "PD020303", err)
 }
+
+func TestPluginRunPing(t *testing.T) {
+	_, tc, done := newTestController(t)
+	defer done()
+
+	pr := newTestPluginRunner("unix:" + tc.socketFile)
+
+	stop := make(chan struct{})
+	waitConnected := make(chan grpc.BidiStreamingServer[prototk.DomainMessage, prototk.DomainMessage])
+	tc.fakeDomainController = func(stream grpc.BidiStreamingServer[prototk.DomainMessage, prototk.DomainMessage]) error {
+		waitConnected <- stream
+		<-stop
+		return nil
+	}
+	defer close(stop)
+
+	go func() {
+		_ = pr.run()
+	}()
+	stream := <-waitConnected
+
+	// The register comes first
+	reg, err := stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, prototk.Header_REGISTER, reg.Header.MessageType)
+
+	// A request with no body is answered without calling the plugin
+	pingID := uuid.NewString()
+	err = stream.Send(&prototk.DomainMessage{
+		Header: &prototk.Header{
+			PluginId:    pr.pi.id,
+			MessageId:   pingID,
+			MessageType: prototk.Header_REQUEST_TO_PLUGIN,
+		},
+	})
+	require.NoError(t, err)
+	res, err := stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, prototk.Header_RESPONSE_FROM_PLUGIN, res.Header.MessageType)
+	assert.Equal(t, pingID, *res.Header.CorrelationId)
+	assert.Nil(t, res.ResponseFromDomain)
+}
@@ -44,6 +44,7 @@ type DomainAPI interface {
 	ConfigurePrivacyGroup(context.Context, *prototk.ConfigurePrivacyGroupRequest) (*prototk.ConfigurePrivacyGroupResponse, error)
 	InitPrivacyGroup(context.Context, *prototk.InitPrivacyGroupRequest) (*prototk.InitPrivacyGroupResponse, error)
 	WrapPrivacyGroupEVMTX(context.Context, *prototk.WrapPrivacyGroupEVMTXRequest) (*prototk.WrapPrivacyGroupEVMTXResponse, error)
+	HandleRPC(context.Context, *prototk.HandleRPCRequest) (*prototk.HandleRPCResponse, error)
 }
 
 type DomainCallbacks interface {
@@ -54,6 +55,7 @@ type DomainCallbacks interface {
 	SendTransaction(ctx context.Context, tx *prototk.SendTransactionRequest) (*prototk.SendTransactionResponse, error)
 	LocalNodeName(context.Context, *prototk.LocalNodeNameRequest) (*prototk.LocalNodeNameResponse, error)
 	GetStatesByID(ctx context.Context, req *prototk.GetStatesByIDRequest) (*prototk.GetStatesByIDResponse, error)
+	CheckCredential(ctx context.Context, req *prototk.CheckCredentialRequest) (*prototk.CheckCredentialResponse, error)
 }
 
 type DomainFactory func(callbacks DomainCallbacks) DomainAPI
@@ -213,6 +215,10 @@ func (dp *domainHandler) RequestToPlugin(ctx context.Context, iReq PluginMessage
 		resMsg := &prototk.DomainMessage_WrapPrivacyGroupEvmtxRes{}
 		resMsg.WrapPrivacyGroupEvmtxRes, err = dp.api.WrapPrivacyGroupEVMTX(ctx, input.WrapPrivacyGroupEvmtx)
 		res.ResponseFromDomain = resMsg
+	case *prototk.DomainMessage_HandleRpc:
+		resMsg := &prototk.DomainMessage_HandleRpcRes{}
+		resMsg.HandleRpcRes, err = dp.api.HandleRPC(ctx, input.HandleRpc)
+		res.ResponseFromDomain = resMsg
 	default:
 		err = i18n.NewError(ctx, pldmsgs.MsgPluginUnsupportedRequest, input)
 	}
@@ -296,6 +302,17 @@ func (dp *domainHandler) GetStatesByID(ctx context.Context, req *prototk.GetStat
 	})
 }
 
+func (dp *domainHandler) CheckCredential(ctx context.Context, req *prototk.CheckCredentialRequest) (*prototk.CheckCredentialResponse, error) {
+	res, err := dp.proxy.RequestFromPlugin(ctx, dp.Wrap(&prototk.DomainMessage{
+		RequestFromDomain: &prototk.DomainMessage_CheckCredential{
+			CheckCredential: req,
+		},
+	}))
+	return responseToPluginAs(ctx, res, err, func(msg *prototk.DomainMessage_CheckCredentialRes) *prototk.CheckCredentialResponse {
+		return msg.CheckCredentialRes
+	})
+}
+
 type DomainAPIFunctions struct {
 	ConfigureDomain       func(context.Context, *prototk.ConfigureDomainRequest) (*prototk.ConfigureDomainResponse, error)
 	InitDomain            func(context.Context, *prototk.InitDomainRequest) (*prototk.InitDomainResponse, error)
@@ -316,6 +333,7 @@ type DomainAPIFunctions struct {
 	ConfigurePrivacyGroup func(context.Context, *prototk.ConfigurePrivacyGroupRequest) (*prototk.ConfigurePrivacyGroupResponse, error)
 	InitPrivacyGroup      func(context.Context, *prototk.InitPrivacyGroupRequest) (*prototk.InitPrivacyGroupResponse, error)
 	WrapPrivacyGroupEVMTX func(context.Context, *prototk.WrapPrivacyGroupEVMTXRequest) (*prototk.WrapPrivacyGroupEVMTXResponse, error)
+	HandleRPC             func(context.Context, *prototk.HandleRPCRequest) (*prototk.HandleRPCResponse, error)
 }
 
 type DomainAPIBase struct {
@@ -397,3 +415,7 @@ func (db *DomainAPIBase) InitPrivacyGroup(ctx context.Context, req *prototk.Init
 func (db *DomainAPIBase) WrapPrivacyGroupEVMTX(ctx context.Context, req *prototk.WrapPrivacyGroupEVMTXRequest) (*prototk.WrapPrivacyGroupEVMTXResponse, error) {
 	return callPluginImpl(ctx, req, db.Functions.WrapPrivacyGroupEVMTX)
 }
+
+func (db *DomainAPIBase) HandleRPC(ctx context.Context, req *prototk.HandleRPCRequest) (*prototk.HandleRPCResponse, error) {
+	return callPluginImpl(ctx, req, db.Functions.HandleRPC)
+}
@@ -153,6 +153,20 @@ func TestDomainCallback_GetStates(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestDomainCallback_CheckCredential(t *testing.T) {
+	ctx, _, _, callbacks, inOutMap, done := setupDomainTests(t)
+	defer done()
+
+	inOutMap[fmt.Sprintf("%T", &prototk.DomainMessage_CheckCredential{})] = func(dm *prototk.DomainMessage) {
+		dm.ResponseToDomain = &prototk.DomainMessage_CheckCredentialRes{
+			CheckCredentialRes: &prototk.CheckCredentialResponse{Valid: true},
+		}
+	}
+	res, err := callbacks.CheckCredential(ctx, &prototk.CheckCredentialRequest{})
+	require.NoError(t, err)
+	assert.True(t, res.Valid)
+}
+
 func TestDomainFunction_ConfigureDomain(t *testing.T) {
 	_, exerciser, funcs, _, _, done := setupDomainTests(t)
 	defer done()
@@ -476,12 +490,31 @@ func TestDomainFunction_WrapPrivacyGroupEVMTX(t *testing.T) {
 	})
 }
 
+func TestDomainFunction_HandleRPC(t *testing.T) {
+	_, exerciser, funcs, _, _, done := setupDomainTests(t)
+	defer done()
+
+	// HandleRPC - paladin to domain
+	funcs.HandleRPC = func(ctx context.Context, hrr *prototk.HandleRPCRequest) (*prototk.HandleRPCResponse, error) {
+		assert.Equal(t, "domain1_custom", hrr.Method)
+		return &prototk.HandleRPCResponse{ResultJson: `{"some":"result"}`}, nil
+	}
+	exerciser.doExchangeToPlugin(func(req *prototk.DomainMessage) {
+		req.RequestToDomain = &prototk.DomainMessage_HandleRpc{
+			HandleRpc: &prototk.HandleRPCRequest{Method: "domain1_custom", ParamsJson: `[]`},
+		}
+	}, func(res *prototk.DomainMessage) {
+		assert.Equal(t, `{"some":"result"}`, res.GetHandleRpcRes().ResultJson)
+	})
+}
+
 func TestDomainRequestError(t *testing.T) {
 	_, exerciser, _, _, _, done := setupDomainTests(t)
 	defer done()
 
-	// Check responseToPluginAs handles nil
+	// An empty request is a ping, which is answered without calling the plugin
 	exerciser.doExchangeToPlugin(func(req *prototk.DomainMessage) {}, func(res *prototk.DomainMessage) {
-		assert.Regexp(t, "PD020300", *res.Header.ErrorMessage)
+		assert.Equal(t, prototk.Header_RESPONSE_FROM_PLUGIN, res.Header.MessageType)
+		assert.Nil(t, res.Header.ErrorMessage)
 	})
 }
@@ -113,9 +113,10 @@ func TestRegistryRequestError(t *testing.T) {
 	_, exerciser, _, _, _, done := setupRegistryTests(t)
 	defer done()
 
-	// Check responseToPluginAs handles nil
+	// An empty request is a ping, which is answered without calling the plugin
 	exerciser.doExchangeToPlugin(func(req *prototk.RegistryMessage) {}, func(res *prototk.RegistryMessage) {
-		assert.Regexp(t, "PD020300", *res.Header.ErrorMessage)
+		assert.Equal(t, prototk.Header_RESPONSE_FROM_PLUGIN, res.Header.MessageType)
+		assert.Nil(t, res.Header.ErrorMessage)
 	})
 }
 
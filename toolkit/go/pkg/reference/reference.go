@@ -27,6 +27,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/hyperledger/firefly-signer/pkg/abi"
+	"github.com/hyperledger/firefly-signer/pkg/eip712"
 	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
 	"github.com/kaleido-io/paladin/common/go/pkg/pldmsgs"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
@@ -78,16 +79,51 @@ var allTypes = []interface{}{
 	pldapi.IndexedEvent{},
 	pldapi.TransactionReceipt{},
 	pldapi.TransactionReceiptFull{},
+	pldapi.SignedTransactionEvidence{},
+	pldapi.BackupManifest{},
+	pldapi.QuiesceStatus{},
+	pldapi.DrainStatus{},
+	pldapi.ConfigChange{},
+	pldapi.DataPurgeRequestInput{},
+	pldapi.DataPurgeRequest{},
+	pldapi.DataPurgeLogEntry{},
+	pldapi.TransactionEvidence{},
+	pldapi.TransactionEndorsement{},
 	pldapi.TransactionReceiptListener{},
 	pldapi.TransactionReceiptFilters{},
 	pldapi.TransactionReceiptListenerOptions{},
+	pldapi.ExternalTransactionWatch{},
+	pldapi.TransactionFee{},
+	pldapi.TransactionFeeReportInput{},
+	pldapi.TransactionFeeReport{},
+	pldapi.TransactionFeeReportEntry{},
+	pldapi.SwapInput{},
+	pldapi.Swap{},
+	pldapi.SwapLeg{},
+	pldapi.SwapOperation{},
 	pldapi.TransactionStates{},
+	pldapi.StateSnapshot{},
+	pldapi.StateSnapshotImportResult{},
+	pldapi.StateAnchor{},
+	pldapi.StateAnchorProof{Anchor: &pldapi.StateAnchor{}},
+	pldapi.StateQueryExplanation{},
+	pldapi.ScheduledJob{},
+	pldapi.BaseContract{},
+	pldapi.BaseContractMismatch{},
+	pldapi.Webhook{},
+	pldapi.WebhookEvent{},
+	pldapi.WebhookDelivery{},
 	pldapi.TransactionInput{},
 	pldapi.TransactionFull{},
 	pldapi.TransactionCall{},
 	pldapi.Transaction{},
 	pldapi.PreparedTransaction{},
 	pldapi.PublicTx{},
+	pldapi.PublicTxWithBinding{PublicTx: &pldapi.PublicTx{}},
+	pldapi.PublicTxRawSubmission{},
+	pldapi.PublicTxDryRun{},
+	pldapi.PublicTxSimulation{},
+	pldapi.PublicTxBatchImpact{},
 	pldapi.StoredABI{
 		ABI: abi.ABI{
 			&abi.Entry{
@@ -98,6 +134,9 @@ var allTypes = []interface{}{
 		},
 		Hash: pldtypes.Bytes32{},
 	},
+	pldapi.ContractABIInput{},
+	pldapi.ContractABI{},
+	pldapi.DecodedTransaction{},
 	pldapi.State{},
 	pldapi.StateConfirmRecord{},
 	pldapi.StateSpendRecord{},
@@ -110,14 +149,49 @@ var allTypes = []interface{}{
 		},
 	},
 	pldapi.RegistryProperty{},
+	pldapi.RegistryCredential{},
+	pldapi.RegistryCredentialCheck{},
+	pldapi.RegistryCredentialCheckResult{},
 	pldapi.OnChainLocation{},
 	pldapi.IndexedBlock{},
 	pldapi.IndexedTransaction{},
 	pldapi.IndexedEvent{},
 	pldapi.EventWithData{},
+	pldapi.DecodedEvent{
+		EventWithData: &pldapi.EventWithData{
+			IndexedEvent: &pldapi.IndexedEvent{},
+		},
+	},
+	pldapi.IndexerBackfillRequest{},
+	pldapi.IndexerBackfillStatus{},
+	pldapi.IndexerEventReplayRequest{},
+	pldapi.IndexerEventReplayResult{},
+	pldapi.ChainTime{},
+	pldapi.HeaderVerificationStatus{},
+	pldapi.HeaderDiscrepancy{},
 	pldapi.ABIDecodedData{},
 	pldapi.PeerInfo{},
+	pldapi.PeerBan{},
+	pldapi.Domain{},
+	pldapi.DomainSmartContract{},
 	pldapi.KeyMappingAndVerifier{},
+	pldapi.EIP712TypedData{
+		Types: eip712.TypeSet{
+			eip712.EIP712Domain: eip712.Type{
+				{Name: "name", Type: "string"},
+				{Name: "chainId", Type: "uint256"},
+			},
+			"Permit": eip712.Type{
+				{Name: "spender", Type: "address"},
+				{Name: "value", Type: "uint256"},
+			},
+		},
+		PrimaryType: "Permit",
+		Domain:      map[string]any{"name": "Token", "chainId": 1337},
+		Message:     map[string]any{"spender": "0x2a5e2f3b1e6e6e4b3f6a7d2a4cbe2d4d7c4b2f1a", "value": "1000"},
+	},
+	pldapi.VerifierLookup{},
+	pldapi.ResolvedVerifier{},
 	pldapi.ReliableMessageAck{},
 	pldapi.ReliableMessage{},
 	pldapi.PrivacyGroup{},
@@ -127,6 +201,7 @@ var allTypes = []interface{}{
 	pldapi.PrivacyGroupMessageListener{},
 	pldapi.PrivacyGroupMessage{},
 	pldapi.PrivacyGroupMessageInput{},
+	pldapi.PrivacyGroupTemplate{},
 	pldtypes.JSONFormatOptions(""),
 	pldapi.StateStatusQualifier(""),
 	query.QueryJSON{
@@ -166,6 +241,15 @@ var allTypes = []interface{}{
 						Value: pldtypes.RawJSON(`"abcde"`),
 					},
 				},
+				Contains: []*query.OpSingleVal{
+					{
+						Op: query.Op{
+							Field:           "field13",
+							CaseInsensitive: true,
+						},
+						Value: pldtypes.RawJSON(`"bcd"`),
+					},
+				},
 				LT: []*query.OpSingleVal{
 					{
 						Op: query.Op{
@@ -223,6 +307,17 @@ var allTypes = []interface{}{
 						Field: "field2",
 					},
 				},
+				JSONEq: []*query.OpJSONPath{
+					{
+						OpSingleVal: query.OpSingleVal{
+							Op: query.Op{
+								Field: "field14",
+							},
+							Value: pldtypes.RawJSON(`"abcde"`),
+						},
+						Path: "path.to.value",
+					},
+				},
 			},
 		},
 	},
@@ -231,15 +326,22 @@ var allTypes = []interface{}{
 	pldapi.BlockchainEventListenerSource{},
 	pldapi.BlockchainEventListenerStatus{},
 	pldapi.BlockchainEventListenerCheckpoint{},
+	pldapi.DomainEventSubscription{},
+	pldapi.PublicTxQuery{},
+	pldapi.PublicTxPage{},
 }
 var allAPITypes = []pldclient.RPCModule{
 	pldclient.New().PTX(),
 	pldclient.New().KeyManager(),
 	pldclient.New().Registry(),
+	pldclient.New().Domain(),
 	pldclient.New().Transport(),
 	pldclient.New().StateStore(),
 	pldclient.New().BlockIndex(),
 	pldclient.New().PrivacyGroups(),
+	pldclient.New().Jobs(),
+	pldclient.New().Contracts(),
+	pldclient.New().Webhooks(),
 }
 
 var allSimpleTypes = []interface{}{
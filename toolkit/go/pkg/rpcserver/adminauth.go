@@ -0,0 +1,136 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rpcserver
+
+import (
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/common/go/pkg/pldmsgs"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+)
+
+type adminAuthContextKey struct{}
+
+type adminIdentityContextKey struct{}
+
+type adminToken struct {
+	token []byte
+	name  string
+}
+
+type adminAuth struct {
+	token  []byte // the shared admin token, which does not identify the admin
+	admins []*adminToken
+	groups map[string]bool
+}
+
+// WithAdminIdentity records on the context the name of the admin that authenticated the request
+func WithAdminIdentity(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, adminIdentityContextKey{}, name)
+}
+
+// AdminIdentity returns the name of the admin that authenticated the request with their own token,
+// or the empty string if the caller used the shared admin token (or is not an admin)
+func AdminIdentity(ctx context.Context) string {
+	name, _ := ctx.Value(adminIdentityContextKey{}).(string)
+	return name
+}
+
+func newAdminAuth(ctx context.Context, conf *pldconf.RPCServerAdminAuthConfig) (*adminAuth, error) {
+	if !conf.Enabled {
+		log.L(ctx).Warnf("Admin authorization is disabled - all RPC methods are available to any caller")
+		return nil, nil
+	}
+	aa := &adminAuth{
+		admins: make([]*adminToken, len(conf.Admins)),
+		groups: make(map[string]bool),
+	}
+	// The shared token is optional only if there are named admins
+	if conf.TokenFile != "" || len(conf.Admins) == 0 {
+		token, err := os.ReadFile(conf.TokenFile)
+		if err != nil {
+			return nil, i18n.WrapError(ctx, err, pldmsgs.MsgJSONRPCAdminTokenFile, conf.TokenFile)
+		}
+		aa.token = bytes.TrimSpace(token)
+		if len(aa.token) == 0 {
+			return nil, i18n.NewError(ctx, pldmsgs.MsgJSONRPCAdminTokenEmpty, conf.TokenFile)
+		}
+	}
+	names := make(map[string]bool, len(conf.Admins))
+	for i, ac := range conf.Admins {
+		if ac.Name == "" || ac.TokenFile == "" || names[ac.Name] {
+			return nil, i18n.NewError(ctx, pldmsgs.MsgJSONRPCAdminInvalid, i)
+		}
+		names[ac.Name] = true
+		token, err := os.ReadFile(ac.TokenFile)
+		if err != nil {
+			return nil, i18n.WrapError(ctx, err, pldmsgs.MsgJSONRPCAdminTokenFile, ac.TokenFile)
+		}
+		token = bytes.TrimSpace(token)
+		if len(token) == 0 || bytes.Equal(token, aa.token) ||
+			slices.ContainsFunc(aa.admins[:i], func(at *adminToken) bool { return bytes.Equal(at.token, token) }) {
+			return nil, i18n.NewError(ctx, pldmsgs.MsgJSONRPCAdminInvalid, i)
+		}
+		aa.admins[i] = &adminToken{token: token, name: ac.Name}
+	}
+	groups := conf.Groups
+	if groups == nil {
+		groups = pldconf.RPCServerAdminAuthDefaults.Groups
+	}
+	for _, g := range groups {
+		aa.groups[g] = true
+	}
+	log.L(ctx).Infof("Admin authorization enabled for RPC groups: %v (named admins=%d)", groups, len(aa.admins))
+	return aa, nil
+}
+
+// authorize records on the context whether the HTTP request (or WebSocket upgrade) carried an admin token,
+// and the identity of the admin if it was their own token
+func (aa *adminAuth) authorize(ctx context.Context, req *http.Request) context.Context {
+	if aa == nil {
+		return ctx
+	}
+	token, isBearer := strings.CutPrefix(req.Header.Get("Authorization"), "Bearer ")
+	if !isBearer {
+		return context.WithValue(ctx, adminAuthContextKey{}, false)
+	}
+	for _, at := range aa.admins {
+		if subtle.ConstantTimeCompare([]byte(token), at.token) == 1 {
+			return WithAdminIdentity(context.WithValue(ctx, adminAuthContextKey{}, true), at.name)
+		}
+	}
+	isAdmin := len(aa.token) > 0 && subtle.ConstantTimeCompare([]byte(token), aa.token) == 1
+	return context.WithValue(ctx, adminAuthContextKey{}, isAdmin)
+}
+
+func (aa *adminAuth) checkMethod(ctx context.Context, group, method string) error {
+	if aa == nil || !aa.groups[group] {
+		return nil
+	}
+	if isAdmin, _ := ctx.Value(adminAuthContextKey{}).(bool); !isAdmin {
+		return i18n.NewError(ctx, pldmsgs.MsgJSONRPCAdminAuthRequired, method)
+	}
+	return nil
+}
@@ -0,0 +1,236 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rpcserver
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/wsclient"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/rpcclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeTestTokenFile(t *testing.T, token string) string {
+	tokenFile := filepath.Join(t.TempDir(), "admin.token")
+	err := os.WriteFile(tokenFile, []byte(token), 0600)
+	require.NoError(t, err)
+	return tokenFile
+}
+
+func regAdminAuthTestRPCs(s *rpcServer) {
+	regTestRPC(s, "debug_ping", RPCMethod0(func(ctx context.Context) (string, error) {
+		return "pong", nil
+	}))
+	regTestRPC(s, "ptx_ping", RPCMethod0(func(ctx context.Context) (string, error) {
+		return "pong", nil
+	}))
+}
+
+func TestAdminAuthHTTP(t *testing.T) {
+	url, s, done := newTestServerHTTP(t, &pldconf.RPCServerConfig{
+		AdminAuth: pldconf.RPCServerAdminAuthConfig{
+			Enabled:   true,
+			TokenFile: writeTestTokenFile(t, "secret\n"),
+		},
+	})
+	defer done()
+	regAdminAuthTestRPCs(s)
+
+	call := func(method, authHeader string) *rpcclient.RPCResponse {
+		var rpcRes rpcclient.RPCResponse
+		req := resty.New().R().
+			SetBody(&rpcclient.RPCRequest{JSONRpc: "2.0", ID: pldtypes.RawJSON(`1`), Method: method}).
+			SetResult(&rpcRes).
+			SetError(&rpcRes)
+		if authHeader != "" {
+			req.SetHeader("Authorization", authHeader)
+		}
+		_, err := req.Post(url)
+		require.NoError(t, err)
+		return &rpcRes
+	}
+
+	assert.Nil(t, call("ptx_ping", "").Error)
+	assert.Regexp(t, "PD020707.*debug_ping", call("debug_ping", "").Error.Message)
+	assert.Regexp(t, "PD020707", call("debug_ping", "Bearer wrong").Error.Message)
+	assert.Regexp(t, "PD020707", call("debug_ping", "secret").Error.Message)
+	assert.Nil(t, call("debug_ping", "Bearer secret").Error)
+}
+
+func TestAdminAuthWebSockets(t *testing.T) {
+	ctx, cancelCtx := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancelCtx()
+	url, s, done := newTestServerWebSockets(t, &pldconf.RPCServerConfig{
+		AdminAuth: pldconf.RPCServerAdminAuthConfig{
+			Enabled:   true,
+			TokenFile: writeTestTokenFile(t, "secret"),
+			Groups:    []string{"debug"},
+		},
+	})
+	defer done()
+	regAdminAuthTestRPCs(s)
+
+	connect := func(headers fftypes.JSONObject) rpcclient.WSClient {
+		client := rpcclient.WrapWSConfig(&wsclient.WSConfig{WebSocketURL: url, DisableReconnect: true, HTTPHeaders: headers})
+		err := client.Connect(ctx)
+		require.NoError(t, err)
+		return client
+	}
+
+	var result string
+	client := connect(nil)
+	defer client.Close()
+	rpcErr := client.CallRPC(ctx, &result, "ptx_ping")
+	assert.Nil(t, rpcErr)
+	rpcErr = client.CallRPC(ctx, &result, "debug_ping")
+	assert.Regexp(t, "PD020707", rpcErr)
+
+	adminClient := connect(fftypes.JSONObject{"Authorization": "Bearer secret"})
+	defer adminClient.Close()
+	rpcErr = adminClient.CallRPC(ctx, &result, "debug_ping")
+	assert.Nil(t, rpcErr)
+	assert.Equal(t, "pong", result)
+}
+
+func TestAdminAuthDisabled(t *testing.T) {
+	url, s, done := newTestServerWebSockets(t, &pldconf.RPCServerConfig{})
+	defer done()
+	regAdminAuthTestRPCs(s)
+
+	client := rpcclient.WrapWSConfig(&wsclient.WSConfig{WebSocketURL: url, DisableReconnect: true})
+	defer client.Close()
+	err := client.Connect(context.Background())
+	require.NoError(t, err)
+
+	var result string
+	rpcErr := client.CallRPC(context.Background(), &result, "debug_ping")
+	assert.Nil(t, rpcErr)
+}
+
+func TestAdminAuthTokenFileErrors(t *testing.T) {
+	_, err := NewRPCServer(context.Background(), &pldconf.RPCServerConfig{
+		AdminAuth: pldconf.RPCServerAdminAuthConfig{
+			Enabled:   true,
+			TokenFile: filepath.Join(t.TempDir(), "missing"),
+		},
+	})
+	assert.Regexp(t, "PD020708", err)
+
+	_, err = NewRPCServer(context.Background(), &pldconf.RPCServerConfig{
+		AdminAuth: pldconf.RPCServerAdminAuthConfig{
+			Enabled:   true,
+			TokenFile: writeTestTokenFile(t, " \n"),
+		},
+	})
+	assert.Regexp(t, "PD020709", err)
+}
+
+func TestAdminAuthNamedAdmins(t *testing.T) {
+	url, s, done := newTestServerHTTP(t, &pldconf.RPCServerConfig{
+		AdminAuth: pldconf.RPCServerAdminAuthConfig{
+			Enabled:   true,
+			TokenFile: writeTestTokenFile(t, "secret"),
+			Admins: []*pldconf.RPCServerAdminConfig{
+				{Name: "alice", TokenFile: writeTestTokenFile(t, "alice-secret")},
+				{Name: "bob", TokenFile: writeTestTokenFile(t, "bob-secret")},
+			},
+		},
+	})
+	defer done()
+	regTestRPC(s, "debug_whoami", RPCMethod0(func(ctx context.Context) (string, error) {
+		return AdminIdentity(ctx), nil
+	}))
+
+	call := func(authHeader string) *rpcclient.RPCResponse {
+		var rpcRes rpcclient.RPCResponse
+		_, err := resty.New().R().
+			SetBody(&rpcclient.RPCRequest{JSONRpc: "2.0", ID: pldtypes.RawJSON(`1`), Method: "debug_whoami"}).
+			SetHeader("Authorization", authHeader).
+			SetResult(&rpcRes).
+			SetError(&rpcRes).
+			Post(url)
+		require.NoError(t, err)
+		return &rpcRes
+	}
+
+	assert.JSONEq(t, `"alice"`, call("Bearer alice-secret").Result.String())
+	assert.JSONEq(t, `"bob"`, call("Bearer bob-secret").Result.String())
+	assert.JSONEq(t, `""`, call("Bearer secret").Result.String())
+	assert.Regexp(t, "PD020707", call("Bearer carol-secret").Error.Message)
+}
+
+func TestAdminAuthNamedAdminsOnly(t *testing.T) {
+	url, s, done := newTestServerHTTP(t, &pldconf.RPCServerConfig{
+		AdminAuth: pldconf.RPCServerAdminAuthConfig{
+			Enabled: true,
+			Admins: []*pldconf.RPCServerAdminConfig{
+				{Name: "alice", TokenFile: writeTestTokenFile(t, "alice-secret")},
+			},
+		},
+	})
+	defer done()
+	regAdminAuthTestRPCs(s)
+
+	var rpcRes rpcclient.RPCResponse
+	_, err := resty.New().R().
+		SetBody(&rpcclient.RPCRequest{JSONRpc: "2.0", ID: pldtypes.RawJSON(`1`), Method: "debug_ping"}).
+		SetHeader("Authorization", "Bearer ").
+		SetResult(&rpcRes).
+		SetError(&rpcRes).
+		Post(url)
+	require.NoError(t, err)
+	assert.Regexp(t, "PD020707", rpcRes.Error.Message)
+}
+
+func TestAdminAuthNamedAdminErrors(t *testing.T) {
+	for _, admins := range [][]*pldconf.RPCServerAdminConfig{
+		{{TokenFile: writeTestTokenFile(t, "a")}},
+		{{Name: "alice"}},
+		{{Name: "alice", TokenFile: writeTestTokenFile(t, "a")}, {Name: "alice", TokenFile: writeTestTokenFile(t, "b")}},
+		{{Name: "alice", TokenFile: writeTestTokenFile(t, "a")}, {Name: "bob", TokenFile: writeTestTokenFile(t, "a")}},
+		{{Name: "alice", TokenFile: writeTestTokenFile(t, "secret")}},
+		{{Name: "alice", TokenFile: writeTestTokenFile(t, " ")}},
+	} {
+		_, err := NewRPCServer(context.Background(), &pldconf.RPCServerConfig{
+			AdminAuth: pldconf.RPCServerAdminAuthConfig{
+				Enabled:   true,
+				TokenFile: writeTestTokenFile(t, "secret"),
+				Admins:    admins,
+			},
+		})
+		assert.Regexp(t, "PD020714", err)
+	}
+
+	_, err := NewRPCServer(context.Background(), &pldconf.RPCServerConfig{
+		AdminAuth: pldconf.RPCServerAdminAuthConfig{
+			Enabled: true,
+			Admins: []*pldconf.RPCServerAdminConfig{
+				{Name: "alice", TokenFile: filepath.Join(t.TempDir(), "missing")},
+			},
+		},
+	})
+	assert.Regexp(t, "PD020708", err)
+}
@@ -19,6 +19,8 @@ package rpcserver
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"net/http"
 
 	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
 	"github.com/kaleido-io/paladin/common/go/pkg/pldmsgs"
@@ -169,7 +171,13 @@ func mapResponse(ctx context.Context, req *rpcclient.RPCRequest, result interfac
 		}
 	}
 	if code == 0 {
-		code = rpcclient.RPCCodeInternalError
+		// Errors that are flagged as a problem with the input, are reported as an invalid request
+		var pdErr i18n.PDError
+		if errors.As(err, &pdErr) && pdErr.HTTPStatus() == http.StatusBadRequest {
+			code = rpcclient.RPCCodeInvalidRequest
+		} else {
+			code = rpcclient.RPCCodeInternalError
+		}
 	}
 	return rpcclient.NewRPCErrorResponse(err, req.ID, code)
 }
@@ -18,15 +18,18 @@ package rpcserver
 
 import (
 	"context"
+	"net/http"
 	"testing"
 
 	"github.com/go-resty/resty/v2"
 	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
 	"github.com/kaleido-io/paladin/config/pkg/pldconf"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/rpcclient"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
 )
 
 func TestRCPMethod0(t *testing.T) {
@@ -393,3 +396,34 @@ func TestRCPMethodBadResult(t *testing.T) {
 	assert.Regexp(t, "PD020705", errResponse.Error.Message)
 
 }
+
+var msgTestBadRequest = func() i18n.ErrorMessageKey {
+	i18n.RegisterPrefix("RS01", "RPC server test")
+	return i18n.PDE(language.AmericanEnglish, "RS010001", "Bad input '%s'", http.StatusBadRequest)
+}()
+
+func TestRCPMethodBadRequestError(t *testing.T) {
+
+	url, s, done := newTestServerHTTP(t, &pldconf.RPCServerConfig{})
+	defer done()
+
+	regTestRPC(s, "stringy_method", RPCMethod1(func(ctx context.Context, input string) (string, error) {
+		return "", i18n.NewError(ctx, msgTestBadRequest, input)
+	}))
+
+	var errResponse rpcclient.RPCResponse
+	res, err := resty.New().R().
+		SetBody(`{
+		  "jsonrpc": "2.0",
+		  "id": "1",
+		  "method": "stringy_method",
+		  "params": [ "wrong" ]
+		}`).
+		SetError(&errResponse).
+		Post(url)
+	require.NoError(t, err)
+	assert.False(t, res.IsSuccess())
+	assert.Equal(t, int64(rpcclient.RPCCodeInvalidRequest), errResponse.Error.Code)
+	assert.Regexp(t, "RS010001.*wrong", errResponse.Error.Message)
+
+}
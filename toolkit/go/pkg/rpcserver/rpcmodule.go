@@ -23,8 +23,9 @@ import (
 )
 
 type RPCModule struct {
-	group   string
-	methods map[string]*rpcMethodEntry
+	group    string
+	methods  map[string]*rpcMethodEntry
+	fallback *rpcMethodEntry
 }
 
 type rpcMethodType int
@@ -70,6 +71,16 @@ func (m *RPCModule) Add(method string, handler RPCHandler) *RPCModule {
 	return m
 }
 
+// A fallback handler is called for any method in the group that has not been added explicitly.
+// This is for groups where the set of methods is only known at runtime, such as those implemented by plugins.
+func (m *RPCModule) AddFallback(handler RPCHandler) *RPCModule {
+	if m.fallback != nil {
+		panic(fmt.Sprintf("duplicate fallback: %s", m.group))
+	}
+	m.fallback = &rpcMethodEntry{methodType: rpcMethodTypeMethod, handler: handler}
+	return m
+}
+
 func (m *RPCModule) AddAsync(handler RPCAsyncHandler) *RPCModule {
 	startMethod := handler.StartMethod()
 	m.validateMethod(startMethod)
@@ -18,11 +18,13 @@ package rpcserver
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
 	"github.com/go-resty/resty/v2"
 	"github.com/kaleido-io/paladin/config/pkg/pldconf"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/rpcclient"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -81,3 +83,66 @@ func TestRCPModulePanicDup(t *testing.T) {
 	})
 
 }
+
+func TestRCPModuleRegisterCombinesGroup(t *testing.T) {
+	_, s, done := newTestServerHTTP(t, &pldconf.RPCServerConfig{})
+	defer done()
+
+	handler := RPCMethod0(func(ctx context.Context) (string, error) {
+		return "result0", nil
+	})
+	m1 := NewRPCModule("example").Add("example_test1", handler)
+	s.Register(m1)
+	s.Register(m1) // re-registering the same module is a no-op
+	s.Register(NewRPCModule("example").Add("example_test2", handler))
+	assert.Len(t, s.rpcModules["example"].methods, 2)
+
+	assert.Panics(t, func() {
+		s.Register(NewRPCModule("example").Add("example_test1", handler))
+	})
+}
+
+func TestRCPModuleFallback(t *testing.T) {
+
+	url, s, done := newTestServerHTTP(t, &pldconf.RPCServerConfig{})
+	defer done()
+
+	s.Register(NewRPCModule("example").
+		Add("example_test1", RPCMethod0(func(ctx context.Context) (string, error) {
+			return "result0", nil
+		})),
+	)
+	s.Register(NewRPCModule("example").
+		AddFallback(HandlerFunc(func(ctx context.Context, req *rpcclient.RPCRequest) *rpcclient.RPCResponse {
+			return &rpcclient.RPCResponse{JSONRpc: "2.0", ID: req.ID, Result: pldtypes.JSONString(req.Method)}
+		})),
+	)
+
+	for method, expected := range map[string]string{
+		"example_test1": "result0",
+		"example_other": "example_other",
+	} {
+		var jsonResponse pldtypes.RawJSON
+		res, err := resty.New().R().
+			SetBody(fmt.Sprintf(`{
+			  "jsonrpc": "2.0",
+			  "id": "1",
+			  "method": "%s",
+			  "params": []
+			}`, method)).
+			SetResult(&jsonResponse).
+			SetError(&jsonResponse).
+			Post(url)
+		require.NoError(t, err)
+		assert.True(t, res.IsSuccess())
+		assert.JSONEq(t, fmt.Sprintf(`{
+			"jsonrpc": "2.0",
+			"id": "1",
+			"result": "%s"
+		}`, expected), (string)(jsonResponse))
+	}
+
+	assert.Panics(t, func() {
+		s.Register(NewRPCModule("example").AddFallback(nil))
+	})
+}
@@ -39,11 +39,20 @@ func (s *rpcServer) processRPC(ctx context.Context, rpcReq *rpcclient.RPCRequest
 	module := s.rpcModules[group]
 	if module != nil {
 		mh = module.methods[rpcReq.Method]
+		if mh == nil {
+			mh = module.fallback
+		}
 	}
 	if mh == nil {
 		err := i18n.NewError(ctx, pldmsgs.MsgJSONRPCUnsupportedMethod, rpcReq.Method)
 		return rpcclient.NewRPCErrorResponse(err, rpcReq.ID, rpcclient.RPCCodeInvalidRequest), false
 	}
+	if err := s.adminAuth.checkMethod(ctx, group, rpcReq.Method); err != nil {
+		return rpcclient.NewRPCErrorResponse(err, rpcReq.ID, rpcclient.RPCCodeInvalidRequest), false
+	}
+	if err := s.tenantAuth.checkMethod(ctx, rpcReq.Method); err != nil {
+		return rpcclient.NewRPCErrorResponse(err, rpcReq.ID, rpcclient.RPCCodeInvalidRequest), false
+	}
 
 	var rpcRes *rpcclient.RPCResponse
 	if mh.methodType == rpcMethodTypeMethod {
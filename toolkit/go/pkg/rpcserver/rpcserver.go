@@ -39,18 +39,27 @@ type RPCServer interface {
 	WSAddr() net.Addr
 
 	Register(module *RPCModule)
+	HandleHTTP(path string, handler http.HandlerFunc) // Adds a plain HTTP handler alongside JSON/RPC on the HTTP server (no-op if HTTP is disabled)
 
 	WSHandler(w http.ResponseWriter, r *http.Request)   // Provides access to the WebSocket handler directly to be able to install it into another server
 	HTTPHandler(w http.ResponseWriter, r *http.Request) // Provides access to the http handler directly to be able to install it into another server
 }
 
 func NewRPCServer(ctx context.Context, conf *pldconf.RPCServerConfig) (_ *rpcServer, err error) {
+	ctx = log.WithSubsystem(ctx, "rpcserver")
 	s := &rpcServer{
 		bgCtx:         ctx,
 		wsConnections: make(map[string]*webSocketConnection),
 		rpcModules:    make(map[string]*RPCModule),
 	}
 
+	if s.adminAuth, err = newAdminAuth(ctx, &conf.AdminAuth); err != nil {
+		return nil, err
+	}
+	if s.tenantAuth, err = newTenantAuth(ctx, &conf.TenantAuth); err != nil {
+		return nil, err
+	}
+
 	// Add the HTTP server
 	if !conf.HTTP.Disabled {
 		r, err := router.NewRouter(s.bgCtx, "JSON/RPC (HTTP)", &conf.HTTP.HTTPServerConfig)
@@ -93,19 +102,41 @@ var _ RPCServer = &rpcServer{}
 
 type rpcServer struct {
 	bgCtx         context.Context
-	httpServer    httpserver.Server
+	httpServer    router.Router
 	wsServer      httpserver.Server
 	wsMux         sync.Mutex
 	wsUpgrader    *websocket.Upgrader
 	wsConnections map[string]*webSocketConnection
 	rpcModules    map[string]*RPCModule
+	adminAuth     *adminAuth
+	tenantAuth    *tenantAuth
 }
 
 func (s *rpcServer) Register(module *RPCModule) {
+	if existing := s.rpcModules[module.group]; existing != nil && existing != module {
+		// Different components can contribute methods to the same group, so we combine them
+		combined := NewRPCModule(module.group)
+		for _, m := range []*RPCModule{existing, module} {
+			for method, entry := range m.methods {
+				combined.validateMethod(method)
+				combined.methods[method] = entry
+			}
+			if m.fallback != nil {
+				combined.AddFallback(m.fallback.handler)
+			}
+		}
+		module = combined
+	}
 	log.L(s.bgCtx).Debugf("RPC module %s registered: %v", module.group, module.MethodNames())
 	s.rpcModules[module.group] = module
 }
 
+func (s *rpcServer) HandleHTTP(path string, handler http.HandlerFunc) {
+	if s.httpServer != nil {
+		s.httpServer.HandleFunc(path, handler)
+	}
+}
+
 func (s *rpcServer) HTTPAddr() (a net.Addr) {
 	if s.httpServer != nil {
 		a = s.httpServer.Addr()
@@ -128,12 +159,16 @@ func (s *rpcServer) HTTPHandler(w http.ResponseWriter, r *http.Request) {
 	s.httpHandler(w, r)
 }
 
+func (s *rpcServer) authorize(ctx context.Context, req *http.Request) context.Context {
+	return s.tenantAuth.authorize(s.adminAuth.authorize(ctx, req), req)
+}
+
 func (s *rpcServer) httpHandler(res http.ResponseWriter, req *http.Request) {
 	if req.Method != http.MethodPost {
 		res.WriteHeader(http.StatusMethodNotAllowed)
 	}
 
-	r := s.rpcHandler(req.Context(), req.Body, nil /* not websockets */)
+	r := s.rpcHandler(s.authorize(req.Context(), req), req.Body, nil /* not websockets */)
 
 	res.Header().Set("Content-Type", "application/json; charset=utf-8")
 	status := http.StatusOK
@@ -150,7 +185,7 @@ func (s *rpcServer) wsHandler(res http.ResponseWriter, req *http.Request) {
 		log.L(req.Context()).Errorf("WebSocket upgrade failed: %s", err)
 		return
 	}
-	s.newWSConnection(conn)
+	s.newWSConnection(conn, req)
 }
 
 func (s *rpcServer) Start() (err error) {
@@ -266,3 +266,36 @@ func TestNewRPCServerWithStaticServerDisabled(t *testing.T) {
 	// Verify the status code
 	require.Equal(t, http.StatusNotFound, res.StatusCode)
 }
+
+func TestHandleHTTP(t *testing.T) {
+	conf := &pldconf.RPCServerConfig{
+		HTTP: pldconf.RPCServerConfigHTTP{
+			HTTPServerConfig: pldconf.HTTPServerConfig{
+				Address: confutil.P("127.0.0.1"),
+				Port:    confutil.P(0),
+			},
+		},
+		WS: pldconf.RPCServerConfigWS{Disabled: true},
+	}
+
+	rpcServer, err := NewRPCServer(context.Background(), conf)
+	require.NoError(t, err)
+	rpcServer.HandleHTTP("/livez", func(res http.ResponseWriter, req *http.Request) {
+		res.WriteHeader(http.StatusNoContent)
+	})
+
+	err = rpcServer.Start()
+	require.NoError(t, err)
+	defer rpcServer.Stop()
+
+	res, err := http.Get(fmt.Sprintf("http://%s/livez", rpcServer.HTTPAddr()))
+	require.NoError(t, err)
+	defer res.Body.Close()
+	assert.Equal(t, http.StatusNoContent, res.StatusCode)
+
+	// No-op without an HTTP server
+	conf.HTTP.Disabled = true
+	rpcServer, err = NewRPCServer(context.Background(), conf)
+	require.NoError(t, err)
+	rpcServer.HandleHTTP("/livez", func(res http.ResponseWriter, req *http.Request) {})
+}
@@ -0,0 +1,168 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rpcserver
+
+import (
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/common/go/pkg/pldmsgs"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+)
+
+type tenantContextKey struct{}
+
+// Tenant is the application a caller is acting for, as established from the tenant token
+// supplied on the request. Managers use it to scope what the caller can see and do.
+type Tenant struct {
+	Name                   string
+	Domains                []string               // empty allows all domains
+	Identities             []string               // key identifier prefixes - empty allows all identities
+	Contracts              []*pldtypes.EthAddress // private contracts the tenant can access in addition to those it deployed
+	MaxPendingTransactions int                    // zero is unlimited
+}
+
+type tenantToken struct {
+	token  []byte
+	tenant *Tenant
+}
+
+type tenantAuth struct {
+	required bool
+	tokens   []*tenantToken
+}
+
+func WithTenant(ctx context.Context, tenant *Tenant) context.Context {
+	return context.WithValue(ctx, tenantContextKey{}, tenant)
+}
+
+// TenantFromContext returns nil for callers that are not scoped to a tenant, which includes
+// admin callers and all processing that is internal to the node
+func TenantFromContext(ctx context.Context) *Tenant {
+	tenant, _ := ctx.Value(tenantContextKey{}).(*Tenant)
+	return tenant
+}
+
+// TenantName returns the empty string for callers that are not scoped to a tenant
+func TenantName(ctx context.Context) string {
+	if tenant := TenantFromContext(ctx); tenant != nil {
+		return tenant.Name
+	}
+	return ""
+}
+
+// CheckTenantDomain returns an error if the caller is scoped to a tenant that cannot use the domain
+func CheckTenantDomain(ctx context.Context, domain string) error {
+	tenant := TenantFromContext(ctx)
+	if tenant == nil || len(tenant.Domains) == 0 || slices.Contains(tenant.Domains, domain) {
+		return nil
+	}
+	return i18n.NewError(ctx, pldmsgs.MsgJSONRPCTenantDomainDenied, tenant.Name, domain)
+}
+
+// CheckTenantIdentity returns an error if the caller is scoped to a tenant that cannot use the identity,
+// which can be a key identifier or a locator with a node name
+func CheckTenantIdentity(ctx context.Context, identity string) error {
+	tenant := TenantFromContext(ctx)
+	if tenant == nil || len(tenant.Identities) == 0 {
+		return nil
+	}
+	identifier, _, _ := strings.Cut(identity, "@")
+	for _, prefix := range tenant.Identities {
+		if strings.HasPrefix(identifier, prefix) {
+			return nil
+		}
+	}
+	return i18n.NewError(ctx, pldmsgs.MsgJSONRPCTenantIdentityDenied, tenant.Name, identity)
+}
+
+func newTenantAuth(ctx context.Context, conf *pldconf.RPCServerTenantAuthConfig) (*tenantAuth, error) {
+	if len(conf.Tenants) == 0 && !conf.Required {
+		return nil, nil
+	}
+	ta := &tenantAuth{
+		required: conf.Required,
+		tokens:   make([]*tenantToken, len(conf.Tenants)),
+	}
+	names := make(map[string]bool, len(conf.Tenants))
+	for i, tc := range conf.Tenants {
+		if tc.Name == "" || tc.TokenFile == "" || names[tc.Name] {
+			return nil, i18n.NewError(ctx, pldmsgs.MsgJSONRPCTenantInvalid, i)
+		}
+		names[tc.Name] = true
+		token, err := os.ReadFile(tc.TokenFile)
+		if err != nil {
+			return nil, i18n.WrapError(ctx, err, pldmsgs.MsgJSONRPCTenantTokenFile, tc.TokenFile, tc.Name)
+		}
+		token = bytes.TrimSpace(token)
+		if len(token) == 0 || slices.ContainsFunc(ta.tokens[:i], func(tt *tenantToken) bool { return bytes.Equal(tt.token, token) }) {
+			return nil, i18n.NewError(ctx, pldmsgs.MsgJSONRPCTenantInvalid, i)
+		}
+		tenant := &Tenant{
+			Name:       tc.Name,
+			Domains:    tc.Domains,
+			Identities: tc.Identities,
+			Contracts:  make([]*pldtypes.EthAddress, len(tc.Contracts)),
+		}
+		for j, c := range tc.Contracts {
+			if tenant.Contracts[j], err = pldtypes.ParseEthAddress(c); err != nil {
+				return nil, i18n.WrapError(ctx, err, pldmsgs.MsgJSONRPCTenantInvalid, i)
+			}
+		}
+		if tc.MaxPendingTransactions != nil {
+			tenant.MaxPendingTransactions = *tc.MaxPendingTransactions
+		}
+		ta.tokens[i] = &tenantToken{token: token, tenant: tenant}
+	}
+	log.L(ctx).Infof("Tenant authorization enabled for %d tenants (required=%t)", len(ta.tokens), ta.required)
+	return ta, nil
+}
+
+// authorize records the tenant on the context, if the HTTP request (or WebSocket upgrade) carried a tenant token
+func (ta *tenantAuth) authorize(ctx context.Context, req *http.Request) context.Context {
+	if ta == nil {
+		return ctx
+	}
+	token, isBearer := strings.CutPrefix(req.Header.Get("Authorization"), "Bearer ")
+	if !isBearer {
+		return ctx
+	}
+	for _, tt := range ta.tokens {
+		if subtle.ConstantTimeCompare([]byte(token), tt.token) == 1 {
+			return WithTenant(ctx, tt.tenant)
+		}
+	}
+	return ctx
+}
+
+func (ta *tenantAuth) checkMethod(ctx context.Context, method string) error {
+	if ta == nil || !ta.required || TenantFromContext(ctx) != nil {
+		return nil
+	}
+	if isAdmin, _ := ctx.Value(adminAuthContextKey{}).(bool); !isAdmin {
+		return i18n.NewError(ctx, pldmsgs.MsgJSONRPCTenantAuthRequired, method)
+	}
+	return nil
+}
@@ -0,0 +1,158 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rpcserver
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/rpcclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTenantAuthHTTP(t *testing.T) {
+	url, s, done := newTestServerHTTP(t, &pldconf.RPCServerConfig{
+		AdminAuth: pldconf.RPCServerAdminAuthConfig{
+			Enabled:   true,
+			TokenFile: writeTestTokenFile(t, "admin"),
+		},
+		TenantAuth: pldconf.RPCServerTenantAuthConfig{
+			Required: true,
+			Tenants: []*pldconf.RPCServerTenantConfig{
+				{Name: "app1", TokenFile: writeTestTokenFile(t, "token1\n"), Domains: []string{"noto"}, MaxPendingTransactions: confutil.P(10)},
+				{Name: "app2", TokenFile: writeTestTokenFile(t, "token2")},
+			},
+		},
+	})
+	defer done()
+	regTestRPC(s, "ptx_whoami", RPCMethod0(func(ctx context.Context) (string, error) {
+		if err := CheckTenantDomain(ctx, "noto"); err != nil {
+			return "", err
+		}
+		if err := CheckTenantDomain(ctx, "zeto"); err != nil {
+			return TenantName(ctx) + ":noto", nil
+		}
+		if tenant := TenantFromContext(ctx); tenant != nil {
+			assert.Zero(t, tenant.MaxPendingTransactions)
+		}
+		return TenantName(ctx) + ":all", nil
+	}))
+
+	call := func(authHeader string) *rpcclient.RPCResponse {
+		var rpcRes rpcclient.RPCResponse
+		req := resty.New().R().
+			SetBody(&rpcclient.RPCRequest{JSONRpc: "2.0", ID: pldtypes.RawJSON(`1`), Method: "ptx_whoami"}).
+			SetResult(&rpcRes).
+			SetError(&rpcRes)
+		if authHeader != "" {
+			req.SetHeader("Authorization", authHeader)
+		}
+		_, err := req.Post(url)
+		require.NoError(t, err)
+		return &rpcRes
+	}
+
+	assert.Regexp(t, "PD020710.*ptx_whoami", call("").Error.Message)
+	assert.Regexp(t, "PD020710", call("Bearer wrong").Error.Message)
+	assert.Regexp(t, "PD020710", call("token1").Error.Message)
+	assert.JSONEq(t, `"app1:noto"`, call("Bearer token1").Result.String())
+	assert.JSONEq(t, `"app2:all"`, call("Bearer token2").Result.String())
+	assert.JSONEq(t, `":all"`, call("Bearer admin").Result.String())
+}
+
+func TestTenantAuthOptional(t *testing.T) {
+	url, s, done := newTestServerHTTP(t, &pldconf.RPCServerConfig{
+		TenantAuth: pldconf.RPCServerTenantAuthConfig{
+			Tenants: []*pldconf.RPCServerTenantConfig{
+				{Name: "app1", TokenFile: writeTestTokenFile(t, "token1"), Domains: []string{"noto"}},
+			},
+		},
+	})
+	defer done()
+	regTestRPC(s, "ptx_whoami", RPCMethod0(func(ctx context.Context) (string, error) {
+		return TenantName(ctx), CheckTenantDomain(ctx, "zeto")
+	}))
+
+	var result string
+	c := rpcclient.WrapRestyClient(resty.New().SetBaseURL(url))
+	err := c.CallRPC(context.Background(), &result, "ptx_whoami")
+	require.NoError(t, err)
+	assert.Empty(t, result)
+
+	c = rpcclient.WrapRestyClient(resty.New().SetBaseURL(url).SetAuthToken("token1"))
+	err = c.CallRPC(context.Background(), &result, "ptx_whoami")
+	assert.Regexp(t, "PD020712.*app1.*zeto", err)
+}
+
+func TestTenantAuthIdentities(t *testing.T) {
+	url, s, done := newTestServerHTTP(t, &pldconf.RPCServerConfig{
+		TenantAuth: pldconf.RPCServerTenantAuthConfig{
+			Tenants: []*pldconf.RPCServerTenantConfig{
+				{Name: "app1", TokenFile: writeTestTokenFile(t, "token1"), Identities: []string{"app1.", "shared.app1"}, Contracts: []string{"0x2E3A0aB4c6a1b0e0c5b4D0A0C5E0fB1dA7c3e9F2"}},
+				{Name: "app2", TokenFile: writeTestTokenFile(t, "token2")},
+			},
+		},
+	})
+	defer done()
+	regTestRPC(s, "ptx_sign", RPCMethod1(func(ctx context.Context, identity string) (string, error) {
+		if tenant := TenantFromContext(ctx); tenant != nil && tenant.Name == "app1" {
+			assert.Equal(t, "0x2e3a0ab4c6a1b0e0c5b4d0a0c5e0fb1da7c3e9f2", tenant.Contracts[0].String())
+		}
+		return identity, CheckTenantIdentity(ctx, identity)
+	}))
+
+	call := func(token, identity string) error {
+		var result string
+		c := rpcclient.WrapRestyClient(resty.New().SetBaseURL(url).SetAuthToken(token))
+		return c.CallRPC(context.Background(), &result, "ptx_sign", identity)
+	}
+
+	require.NoError(t, call("token1", "app1.key1"))
+	require.NoError(t, call("token1", "app1.key1@node1"))
+	require.NoError(t, call("token1", "shared.app1.key1"))
+	assert.Regexp(t, "PD020715.*app1.*app2.key1", call("token1", "app2.key1"))
+	assert.Regexp(t, "PD020715", call("token1", "key@app1.node1"))
+	require.NoError(t, call("token2", "app1.key1"))
+	require.NoError(t, call("", "app2.key1"))
+}
+
+func TestTenantAuthConfigErrors(t *testing.T) {
+	token := writeTestTokenFile(t, "token")
+	for _, tc := range []struct {
+		tenants []*pldconf.RPCServerTenantConfig
+		err     string
+	}{
+		{tenants: []*pldconf.RPCServerTenantConfig{{TokenFile: token}}, err: "PD020711"},
+		{tenants: []*pldconf.RPCServerTenantConfig{{Name: "app1"}}, err: "PD020711"},
+		{tenants: []*pldconf.RPCServerTenantConfig{{Name: "app1", TokenFile: token}, {Name: "app1", TokenFile: writeTestTokenFile(t, "other")}}, err: "PD020711"},
+		{tenants: []*pldconf.RPCServerTenantConfig{{Name: "app1", TokenFile: token}, {Name: "app2", TokenFile: token}}, err: "PD020711"},
+		{tenants: []*pldconf.RPCServerTenantConfig{{Name: "app1", TokenFile: writeTestTokenFile(t, " ")}}, err: "PD020711"},
+		{tenants: []*pldconf.RPCServerTenantConfig{{Name: "app1", TokenFile: filepath.Join(t.TempDir(), "missing")}}, err: "PD020713.*app1"},
+		{tenants: []*pldconf.RPCServerTenantConfig{{Name: "app1", TokenFile: token, Contracts: []string{"wrong"}}}, err: "PD020711"},
+	} {
+		_, err := NewRPCServer(context.Background(), &pldconf.RPCServerConfig{
+			TenantAuth: pldconf.RPCServerTenantAuthConfig{Tenants: tc.tenants},
+		})
+		assert.Regexp(t, tc.err, err)
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"net/http"
 	"sync"
 
 	"github.com/google/uuid"
@@ -30,7 +31,7 @@ import (
 	"github.com/kaleido-io/paladin/sdk/go/pkg/rpcclient"
 )
 
-func (s *rpcServer) newWSConnection(conn *websocket.Conn) {
+func (s *rpcServer) newWSConnection(conn *websocket.Conn, upgradeReq *http.Request) {
 	s.wsMux.Lock()
 	defer s.wsMux.Unlock()
 
@@ -42,7 +43,7 @@ func (s *rpcServer) newWSConnection(conn *websocket.Conn) {
 		send:           make(chan []byte),
 		closing:        make(chan struct{}),
 	}
-	c.ctx, c.cancelCtx = context.WithCancel(log.WithLogField(s.bgCtx, "wsconn", c.id))
+	c.ctx, c.cancelCtx = context.WithCancel(s.authorize(log.WithLogField(s.bgCtx, "wsconn", c.id), upgradeReq))
 
 	s.wsConnections[c.id] = c
 	go c.listen()
@@ -0,0 +1,504 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package keystores
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/common/go/pkg/pldmsgs"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	encryptedFormatVersion = 1
+	encryptedCipher        = "aes-256-gcm"
+	// Key files and directories always have a "-" or "_" prefix, so the manifest cannot clash
+	manifestFileName = "keystore.json"
+	// Files are written with this suffix during a master password rotation, then renamed once the rotation commits
+	rotationSuffix = ".next"
+)
+
+// The master password is never used directly. Each file (including the manifest) records its own
+// random salt and KDF parameters, so parameters can be strengthened over time without invalidating
+// files written with the old parameters.
+type kdfParams struct {
+	Type      pldconf.FileSystemKDFType `json:"type"`
+	Salt      pldtypes.HexBytes         `json:"salt"`
+	N         int                       `json:"n,omitempty"`
+	R         int                       `json:"r,omitempty"`
+	P         int                       `json:"p,omitempty"`
+	Time      uint32                    `json:"time,omitempty"`
+	MemoryKiB uint32                    `json:"memoryKiB,omitempty"`
+	Threads   uint8                     `json:"threads,omitempty"`
+}
+
+type encryptedKeyFile struct {
+	Version    int               `json:"version"`
+	Generation uint64            `json:"generation"`
+	KDF        kdfParams         `json:"kdf"`
+	Cipher     string            `json:"cipher"`
+	Nonce      pldtypes.HexBytes `json:"nonce"`
+	Ciphertext pldtypes.HexBytes `json:"ciphertext"`
+}
+
+// The manifest records the generation of the keystore, which is incremented on every master password rotation.
+// Key files from any other generation are rejected, so an old key file cannot be restored into the keystore.
+// The manifest itself is authenticated with the master password, so it cannot be rolled back without
+// also knowing the master password of that generation.
+type keystoreManifest struct {
+	Version    int               `json:"version"`
+	Generation uint64            `json:"generation"`
+	KDF        kdfParams         `json:"kdf"`
+	MAC        pldtypes.HexBytes `json:"mac"`
+}
+
+type masterKeyEncryption struct {
+	password   []byte
+	kdfConf    *pldconf.FileSystemKDFConfig
+	fileMode   os.FileMode
+	generation uint64
+}
+
+func loadMasterPassword(ctx context.Context, conf *pldconf.FileSystemMasterPasswordConfig) ([]byte, error) {
+	var password string
+	switch {
+	case conf.File != "":
+		b, err := os.ReadFile(conf.File)
+		if err != nil {
+			return nil, i18n.WrapError(ctx, err, pldmsgs.MsgSigningModuleMasterPasswordMissing)
+		}
+		password = strings.TrimRight(string(b), " \t\r\n")
+	case conf.Env != "":
+		password = os.Getenv(conf.Env)
+	default:
+		return nil, nil // not configured
+	}
+	if password == "" {
+		return nil, i18n.NewError(ctx, pldmsgs.MsgSigningModuleMasterPasswordMissing)
+	}
+	return []byte(password), nil
+}
+
+// Returns nil if no master password is configured, after checking the keystore has not previously been
+// encrypted with a master password (so we never silently fall back to unencrypted password files).
+func newMasterKeyEncryption(ctx context.Context, path string, conf *pldconf.FileSystemKeyStoreConfig, fileMode os.FileMode) (*masterKeyEncryption, error) {
+	password, err := loadMasterPassword(ctx, &conf.MasterPassword)
+	if err != nil {
+		return nil, err
+	}
+	manifest, err := readManifest(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	if password == nil {
+		if manifest != nil {
+			return nil, i18n.NewError(ctx, pldmsgs.MsgSigningModuleNoMasterPassword)
+		}
+		return nil, nil
+	}
+
+	mke := &masterKeyEncryption{
+		password: password,
+		kdfConf:  &conf.KDF,
+		fileMode: fileMode,
+	}
+	if manifest == nil {
+		// A new keystore - any existing files must be migrated by a rotation before we can use them
+		keyFiles, err := listKeyFiles(ctx, path, ".key")
+		if err != nil {
+			return nil, err
+		}
+		if len(keyFiles) > 0 {
+			return nil, i18n.NewError(ctx, pldmsgs.MsgSigningModuleUnencryptedKeyFiles, path)
+		}
+		mke.generation = 1
+		if err := mke.writeManifest(ctx, filepath.Join(path, manifestFileName)); err != nil {
+			return nil, err
+		}
+		log.L(ctx).Infof("Initialized keystore '%s' with master password encryption", path)
+		return mke, nil
+	}
+
+	if err := mke.verifyManifest(ctx, path, manifest); err != nil {
+		return nil, err
+	}
+	// Complete any rotation that was interrupted after it was committed, or clean up one that was not
+	if err := mke.recoverRotation(ctx, path); err != nil {
+		return nil, err
+	}
+	return mke, nil
+}
+
+func newKDFParams(ctx context.Context, conf *pldconf.FileSystemKDFConfig) (*kdfParams, error) {
+	defs := &pldconf.FileSystemDefaults.KDF
+	kp := &kdfParams{
+		Type: conf.Type,
+		Salt: pldtypes.RandBytes(32),
+	}
+	if kp.Type == "" {
+		kp.Type = defs.Type
+	}
+	switch kp.Type {
+	case pldconf.FileSystemKDFTypeScrypt:
+		kp.N = confutil.Int(conf.Scrypt.N, *defs.Scrypt.N)
+		kp.R = confutil.Int(conf.Scrypt.R, *defs.Scrypt.R)
+		kp.P = confutil.Int(conf.Scrypt.P, *defs.Scrypt.P)
+	case pldconf.FileSystemKDFTypeArgon2id:
+		kp.Time = uint32(confutil.Int(conf.Argon2id.Time, *defs.Argon2id.Time))
+		kp.MemoryKiB = uint32(confutil.Int(conf.Argon2id.MemoryKiB, *defs.Argon2id.MemoryKiB))
+		kp.Threads = uint8(confutil.Int(conf.Argon2id.Threads, *defs.Argon2id.Threads))
+	default:
+		return nil, i18n.NewError(ctx, pldmsgs.MsgSigningModuleKDFInvalid, kp.Type)
+	}
+	return kp, nil
+}
+
+func (kp *kdfParams) deriveKey(ctx context.Context, password []byte) ([]byte, error) {
+	switch kp.Type {
+	case pldconf.FileSystemKDFTypeScrypt:
+		key, err := scrypt.Key(password, kp.Salt, kp.N, kp.R, kp.P, 32)
+		if err != nil {
+			return nil, i18n.WrapError(ctx, err, pldmsgs.MsgSigningModuleKDFInvalid, kp.Type)
+		}
+		return key, nil
+	case pldconf.FileSystemKDFTypeArgon2id:
+		if kp.Time == 0 || kp.MemoryKiB == 0 || kp.Threads == 0 {
+			return nil, i18n.NewError(ctx, pldmsgs.MsgSigningModuleKDFInvalid, kp.Type)
+		}
+		return argon2.IDKey(password, kp.Salt, kp.Time, kp.MemoryKiB, kp.Threads, 32), nil
+	default:
+		return nil, i18n.NewError(ctx, pldmsgs.MsgSigningModuleKDFInvalid, kp.Type)
+	}
+}
+
+func (m *keystoreManifest) calculateMAC(key []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	fmt.Fprintf(mac, "paladin-keystore:%d:%d", m.Version, m.Generation)
+	return mac.Sum(nil)
+}
+
+func readManifest(ctx context.Context, path string) (*keystoreManifest, error) {
+	manifestPath := filepath.Join(path, manifestFileName)
+	b, err := os.ReadFile(manifestPath)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, i18n.WrapError(ctx, err, pldmsgs.MsgSigningModuleFSError)
+	}
+	var manifest keystoreManifest
+	if err := json.Unmarshal(b, &manifest); err != nil {
+		return nil, i18n.WrapError(ctx, err, pldmsgs.MsgSigningModuleManifestInvalid, manifestPath)
+	}
+	return &manifest, nil
+}
+
+func (mke *masterKeyEncryption) verifyManifest(ctx context.Context, path string, manifest *keystoreManifest) error {
+	manifestPath := filepath.Join(path, manifestFileName)
+	if manifest.Version != encryptedFormatVersion {
+		return i18n.NewError(ctx, pldmsgs.MsgSigningModuleManifestInvalid, manifestPath)
+	}
+	key, err := manifest.KDF.deriveKey(ctx, mke.password)
+	if err != nil {
+		return err
+	}
+	if !hmac.Equal(manifest.calculateMAC(key), manifest.MAC) {
+		return i18n.NewError(ctx, pldmsgs.MsgSigningModuleManifestInvalid, manifestPath)
+	}
+	mke.generation = manifest.Generation
+	return nil
+}
+
+func (mke *masterKeyEncryption) writeManifest(ctx context.Context, manifestPath string) error {
+	kp, err := newKDFParams(ctx, mke.kdfConf)
+	if err != nil {
+		return err
+	}
+	key, err := kp.deriveKey(ctx, mke.password)
+	if err != nil {
+		return err
+	}
+	manifest := &keystoreManifest{
+		Version:    encryptedFormatVersion,
+		Generation: mke.generation,
+		KDF:        *kp,
+	}
+	manifest.MAC = manifest.calculateMAC(key)
+	b, _ := json.MarshalIndent(manifest, "", "  ")
+	if err := os.WriteFile(manifestPath, b, mke.fileMode); err != nil {
+		return i18n.WrapError(ctx, err, pldmsgs.MsgSigningModuleFSError)
+	}
+	return nil
+}
+
+// The key handle and generation are authenticated along with the key, so a file cannot be moved
+// to a different key handle, or have its generation edited.
+func additionalData(keyHandle string, generation uint64) []byte {
+	return []byte(fmt.Sprintf("%s:%d", keyHandle, generation))
+}
+
+func (kp *kdfParams) newGCM(ctx context.Context, password []byte) (cipher.AEAD, error) {
+	key, err := kp.deriveKey(ctx, password)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := aes.NewCipher(key) // 32 byte key is always valid
+	return cipher.NewGCM(block)
+}
+
+func (mke *masterKeyEncryption) encrypt(ctx context.Context, keyHandle string, keyMaterial []byte) ([]byte, error) {
+	kp, err := newKDFParams(ctx, mke.kdfConf)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := kp.newGCM(ctx, mke.password)
+	if err != nil {
+		return nil, err
+	}
+	ekf := &encryptedKeyFile{
+		Version:    encryptedFormatVersion,
+		Generation: mke.generation,
+		KDF:        *kp,
+		Cipher:     encryptedCipher,
+		Nonce:      pldtypes.RandBytes(gcm.NonceSize()),
+	}
+	ekf.Ciphertext = gcm.Seal(nil, ekf.Nonce, keyMaterial, additionalData(keyHandle, ekf.Generation))
+	b, _ := json.Marshal(ekf)
+	return b, nil
+}
+
+func parseEncryptedKeyFile(ctx context.Context, keyFilePath string, data []byte) (*encryptedKeyFile, error) {
+	var ekf encryptedKeyFile
+	err := json.Unmarshal(data, &ekf)
+	if err != nil || ekf.Version != encryptedFormatVersion || ekf.Cipher != encryptedCipher {
+		return nil, i18n.WrapError(ctx, err, pldmsgs.MsgSigningModuleKeyFileFormat, keyFilePath)
+	}
+	return &ekf, nil
+}
+
+func (mke *masterKeyEncryption) decrypt(ctx context.Context, keyFilePath, keyHandle string, data []byte) ([]byte, error) {
+	ekf, err := parseEncryptedKeyFile(ctx, keyFilePath, data)
+	if err != nil {
+		return nil, err
+	}
+	if ekf.Generation != mke.generation {
+		return nil, i18n.NewError(ctx, pldmsgs.MsgSigningModuleKeyFileRollback, keyFilePath, ekf.Generation, mke.generation)
+	}
+	gcm, err := ekf.KDF.newGCM(ctx, mke.password)
+	if err == nil && len(ekf.Nonce) != gcm.NonceSize() {
+		err = i18n.NewError(ctx, pldmsgs.MsgSigningModuleKeyFileFormat, keyFilePath)
+	}
+	if err != nil {
+		return nil, err
+	}
+	keyMaterial, err := gcm.Open(nil, ekf.Nonce, ekf.Ciphertext, additionalData(keyHandle, ekf.Generation))
+	if err != nil {
+		return nil, i18n.WrapError(ctx, err, pldmsgs.MsgSigningModuleKeyFileDecrypt, keyFilePath)
+	}
+	return keyMaterial, nil
+}
+
+func (mke *masterKeyEncryption) createKeyFile(ctx context.Context, keyFilePath, keyHandle string, newKeyMaterial func() ([]byte, error)) ([]byte, error) {
+	keyMaterial, err := newKeyMaterial()
+	if err != nil {
+		return nil, err
+	}
+	data, err := mke.encrypt(ctx, keyHandle, keyMaterial)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(keyFilePath, data, mke.fileMode); err != nil {
+		return nil, i18n.WrapError(ctx, err, pldmsgs.MsgSigningModuleFSError)
+	}
+	return keyMaterial, nil
+}
+
+func (mke *masterKeyEncryption) readKeyFile(ctx context.Context, keyFilePath, keyHandle string) ([]byte, error) {
+	data, err := os.ReadFile(keyFilePath)
+	if err != nil {
+		return nil, i18n.WrapError(ctx, err, pldmsgs.MsgSigningModuleBadKeyFile, keyFilePath)
+	}
+	return mke.decrypt(ctx, keyFilePath, keyHandle, data)
+}
+
+// Lists all files with the given suffix in the keystore, returning the path of each relative to the root
+func listKeyFiles(ctx context.Context, path, suffix string) ([]string, error) {
+	var keyFiles []string
+	err := filepath.WalkDir(path, func(filePath string, d fs.DirEntry, err error) error {
+		if err == nil && !d.IsDir() && strings.HasSuffix(filePath, suffix) {
+			var relPath string
+			relPath, err = filepath.Rel(path, filePath)
+			keyFiles = append(keyFiles, relPath)
+		}
+		return err
+	})
+	if err != nil {
+		return nil, i18n.WrapError(ctx, err, pldmsgs.MsgSigningModuleFSError)
+	}
+	return keyFiles, nil
+}
+
+// Reverses the mapping of validateFilePathKeyHandle, from "_a/_b/-c.key" to "a/b/c"
+func keyHandleFromFile(relPath, suffix string) string {
+	segments := strings.Split(strings.TrimSuffix(filepath.ToSlash(relPath), suffix), "/")
+	for i, segment := range segments {
+		if len(segment) > 0 {
+			segments[i] = segment[1:]
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+func (mke *masterKeyEncryption) recoverRotation(ctx context.Context, path string) error {
+	pendingFiles, err := listKeyFiles(ctx, path, ".key"+rotationSuffix)
+	if err != nil {
+		return err
+	}
+	for _, relPath := range pendingFiles {
+		pendingPath := filepath.Join(path, relPath)
+		data, err := os.ReadFile(pendingPath)
+		if err != nil {
+			return i18n.WrapError(ctx, err, pldmsgs.MsgSigningModuleBadKeyFile, pendingPath)
+		}
+		ekf, err := parseEncryptedKeyFile(ctx, pendingPath, data)
+		if err != nil {
+			return err
+		}
+		if ekf.Generation == mke.generation {
+			// the rotation committed, so this file replaces the previous generation
+			log.L(ctx).Infof("Completing master password rotation for key file %s", pendingPath)
+			err = os.Rename(pendingPath, strings.TrimSuffix(pendingPath, rotationSuffix))
+			if err == nil {
+				err = removeLegacyPasswordFile(pendingPath)
+			}
+		} else {
+			log.L(ctx).Warnf("Discarding key file %s from uncommitted master password rotation", pendingPath)
+			err = os.Remove(pendingPath)
+		}
+		if err != nil {
+			return i18n.WrapError(ctx, err, pldmsgs.MsgSigningModuleFSError)
+		}
+	}
+	return nil
+}
+
+func removeLegacyPasswordFile(pendingPath string) error {
+	passwordFilePath := strings.TrimSuffix(pendingPath, ".key"+rotationSuffix) + ".pwd"
+	err := os.Remove(passwordFilePath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+// RotateFilesystemMasterPassword re-encrypts every key in a filesystem keystore under a new master password,
+// with the KDF parameters in the configuration. The keystore moves to a new generation, so key files and
+// manifests from before the rotation cannot be restored into it.
+//
+// The current master password is loaded from the configuration. If the keystore does not yet use a master
+// password, then any existing keystorev3 key files are migrated, and their password files are deleted.
+//
+// The keystore must not be in use by a running node during the rotation. If the rotation is interrupted,
+// it is completed (or rolled back) the next time the keystore is loaded.
+func RotateFilesystemMasterPassword(ctx context.Context, conf *pldconf.FileSystemKeyStoreConfig, newPassword []byte) error {
+	path, err := filepath.Abs(confutil.StringNotEmpty(conf.Path, *pldconf.FileSystemDefaults.Path))
+	if err != nil {
+		return i18n.WrapError(ctx, err, pldmsgs.MsgSigningModuleBadPathError, *pldconf.FileSystemDefaults.Path)
+	}
+	fileMode := confutil.UnixFileMode(conf.FileMode, *pldconf.FileSystemDefaults.FileMode)
+	if len(newPassword) == 0 {
+		return i18n.NewError(ctx, pldmsgs.MsgSigningModuleMasterPasswordMissing)
+	}
+
+	// Load the current state, which for a keystore without a master password is generation 0
+	var current *masterKeyEncryption
+	if manifest, err := readManifest(ctx, path); err != nil || manifest != nil {
+		if err == nil {
+			current, err = newMasterKeyEncryption(ctx, path, conf, fileMode)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	next := &masterKeyEncryption{
+		password:   newPassword,
+		kdfConf:    &conf.KDF,
+		fileMode:   fileMode,
+		generation: 1,
+	}
+	if current != nil {
+		next.generation = current.generation + 1
+	}
+
+	// Stage all the re-encrypted files
+	keyFiles, err := listKeyFiles(ctx, path, ".key")
+	if err != nil {
+		return err
+	}
+	for _, relPath := range keyFiles {
+		keyFilePath := filepath.Join(path, relPath)
+		keyHandle := keyHandleFromFile(relPath, ".key")
+		var keyMaterial []byte
+		if current != nil {
+			keyMaterial, err = current.readKeyFile(ctx, keyFilePath, keyHandle)
+		} else {
+			legacy := &filesystemStore{}
+			wf, readErr := legacy.readWalletFile(ctx, keyFilePath, strings.TrimSuffix(keyFilePath, ".key")+".pwd")
+			if err = readErr; err == nil {
+				keyMaterial = wf.PrivateKey()
+			}
+		}
+		if err != nil {
+			return err
+		}
+		data, err := next.encrypt(ctx, keyHandle, keyMaterial)
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(keyFilePath+rotationSuffix, data, fileMode); err != nil {
+			return i18n.WrapError(ctx, err, pldmsgs.MsgSigningModuleFSError)
+		}
+	}
+
+	// Writing the manifest commits the rotation
+	manifestPath := filepath.Join(path, manifestFileName)
+	if err := next.writeManifest(ctx, manifestPath+rotationSuffix); err != nil {
+		return err
+	}
+	if err := os.Rename(manifestPath+rotationSuffix, manifestPath); err != nil {
+		return i18n.WrapError(ctx, err, pldmsgs.MsgSigningModuleFSError)
+	}
+	log.L(ctx).Infof("Rotated master password of keystore '%s' to generation %d (%d keys)", path, next.generation, len(keyFiles))
+
+	// Then we swap the files over
+	return next.recoverRotation(ctx, path)
+}
@@ -0,0 +1,303 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package keystores
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/toolkit/pkg/signerapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Cheap parameters for unit tests
+var testScrypt = pldconf.ScryptKDFConfig{N: confutil.P(1024), R: confutil.P(8), P: confutil.P(1)}
+var testArgon2id = pldconf.Argon2idKDFConfig{Time: confutil.P(1), MemoryKiB: confutil.P(1024), Threads: confutil.P(1)}
+
+func newTestEncryptedConf(t *testing.T, dir, password string) *pldconf.FileSystemKeyStoreConfig {
+	conf := &pldconf.FileSystemKeyStoreConfig{
+		Path: confutil.P(dir),
+		KDF: pldconf.FileSystemKDFConfig{
+			Scrypt:   testScrypt,
+			Argon2id: testArgon2id,
+		},
+	}
+	if password != "" {
+		passwordFile := path.Join(t.TempDir(), "master.pwd")
+		require.NoError(t, os.WriteFile(passwordFile, []byte(password+"\n"), 0600))
+		conf.MasterPassword.File = passwordFile
+	}
+	return conf
+}
+
+func newTestEncryptedStore(t *testing.T, conf *pldconf.FileSystemKeyStoreConfig) (*filesystemStore, error) {
+	store, err := NewFilesystemStoreFactory[*signerapi.ConfigNoExt]().NewKeyStore(context.Background(), &signerapi.ConfigNoExt{
+		KeyStore: pldconf.KeyStoreConfig{
+			Type:       pldconf.KeyStoreTypeFilesystem,
+			FileSystem: *conf,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return store.(*filesystemStore), nil
+}
+
+func createTestKey(t *testing.T, fs *filesystemStore, name string) []byte {
+	keyMaterial, _, err := fs.FindOrCreateLoadableKey(context.Background(), &signerapi.ResolveKeyRequest{
+		Name: name,
+		Path: []*signerapi.ResolveKeyPathSegment{{Name: "folder"}},
+	}, func() ([]byte, error) { return []byte("key material for " + name), nil })
+	require.NoError(t, err)
+	return keyMaterial
+}
+
+func TestEncryptedStoreCreateReload(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	for _, kdfType := range []pldconf.FileSystemKDFType{pldconf.FileSystemKDFTypeArgon2id, pldconf.FileSystemKDFTypeScrypt} {
+		conf := newTestEncryptedConf(t, dir, "pass1")
+		conf.KDF.Type = kdfType
+		fs, err := newTestEncryptedStore(t, conf)
+		require.NoError(t, err)
+		createTestKey(t, fs, string(kdfType))
+	}
+
+	// No password files, and the key material is not in the file
+	keyFile := path.Join(dir, "_folder", "-scrypt.key")
+	data, err := os.ReadFile(keyFile)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "key material")
+	assert.NoFileExists(t, path.Join(dir, "_folder", "-scrypt.pwd"))
+	assert.FileExists(t, path.Join(dir, manifestFileName))
+
+	// Each file records its own parameters, so both can be loaded with the default config
+	conf := newTestEncryptedConf(t, dir, "pass1")
+	fs, err := newTestEncryptedStore(t, conf)
+	require.NoError(t, err)
+	for _, name := range []string{"scrypt", "argon2id"} {
+		keyMaterial, err := fs.LoadKeyMaterial(ctx, "folder/"+name)
+		require.NoError(t, err)
+		assert.Equal(t, "key material for "+name, string(keyMaterial))
+	}
+
+	// A file cannot be moved to another key handle
+	require.NoError(t, os.WriteFile(path.Join(dir, "_folder", "-moved.key"), data, 0600))
+	_, err = fs.LoadKeyMaterial(ctx, "folder/moved")
+	assert.Regexp(t, "PD020831", err)
+
+	// Wrong password
+	_, err = newTestEncryptedStore(t, newTestEncryptedConf(t, dir, "wrong"))
+	assert.Regexp(t, "PD020830", err)
+
+	// No password, on a keystore that has one
+	_, err = newTestEncryptedStore(t, newTestEncryptedConf(t, dir, ""))
+	assert.Regexp(t, "PD020835", err)
+}
+
+func TestRotateMasterPasswordWithMigration(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	// Start with an unencrypted keystore
+	fs, err := newTestEncryptedStore(t, newTestEncryptedConf(t, dir, ""))
+	require.NoError(t, err)
+	createTestKey(t, fs, "key1")
+	createTestKey(t, fs, "key2")
+	legacyKey1, err := os.ReadFile(path.Join(dir, "_folder", "-key1.key"))
+	require.NoError(t, err)
+
+	// Cannot just switch on a master password
+	_, err = newTestEncryptedStore(t, newTestEncryptedConf(t, dir, "pass1"))
+	assert.Regexp(t, "PD020833", err)
+
+	// Migrate
+	err = RotateFilesystemMasterPassword(ctx, newTestEncryptedConf(t, dir, ""), []byte("pass1"))
+	require.NoError(t, err)
+	assert.NoFileExists(t, path.Join(dir, "_folder", "-key1.pwd"))
+	gen1Key1, err := os.ReadFile(path.Join(dir, "_folder", "-key1.key"))
+	require.NoError(t, err)
+
+	fs, err = newTestEncryptedStore(t, newTestEncryptedConf(t, dir, "pass1"))
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), fs.encryption.generation)
+	keyMaterial, err := fs.LoadKeyMaterial(ctx, "folder/key1")
+	require.NoError(t, err)
+	assert.Equal(t, "key material for key1", string(keyMaterial))
+
+	// Rotate to a new password, and argon2id
+	conf := newTestEncryptedConf(t, dir, "pass1")
+	conf.KDF.Type = pldconf.FileSystemKDFTypeArgon2id
+	err = RotateFilesystemMasterPassword(ctx, conf, []byte("pass2"))
+	require.NoError(t, err)
+
+	_, err = newTestEncryptedStore(t, newTestEncryptedConf(t, dir, "pass1"))
+	assert.Regexp(t, "PD020830", err)
+	fs, err = newTestEncryptedStore(t, newTestEncryptedConf(t, dir, "pass2"))
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), fs.encryption.generation)
+	keyMaterial, err = fs.LoadKeyMaterial(ctx, "folder/key2")
+	require.NoError(t, err)
+	assert.Equal(t, "key material for key2", string(keyMaterial))
+
+	// Restoring the file from the previous generation is detected
+	require.NoError(t, os.WriteFile(path.Join(dir, "_folder", "-key1.key"), gen1Key1, 0600))
+	_, err = fs.LoadKeyMaterial(ctx, "folder/key1")
+	assert.Regexp(t, "PD020832", err)
+
+	// As is restoring a legacy file
+	require.NoError(t, os.WriteFile(path.Join(dir, "_folder", "-key1.key"), legacyKey1, 0600))
+	_, err = fs.LoadKeyMaterial(ctx, "folder/key1")
+	assert.Regexp(t, "PD020834", err)
+}
+
+func TestRotateMasterPasswordRecovery(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	keyFile := path.Join(dir, "_folder", "-key1.key")
+
+	fs, err := newTestEncryptedStore(t, newTestEncryptedConf(t, dir, "pass1"))
+	require.NoError(t, err)
+	createTestKey(t, fs, "key1")
+	gen1Key1, err := os.ReadFile(keyFile)
+	require.NoError(t, err)
+
+	err = RotateFilesystemMasterPassword(ctx, newTestEncryptedConf(t, dir, "pass1"), []byte("pass2"))
+	require.NoError(t, err)
+
+	// Simulate a crash after the commit, but before the files were swapped
+	require.NoError(t, os.Rename(keyFile, keyFile+rotationSuffix))
+	require.NoError(t, os.WriteFile(keyFile, gen1Key1, 0600))
+	fs, err = newTestEncryptedStore(t, newTestEncryptedConf(t, dir, "pass2"))
+	require.NoError(t, err)
+	keyMaterial, err := fs.LoadKeyMaterial(ctx, "folder/key1")
+	require.NoError(t, err)
+	assert.Equal(t, "key material for key1", string(keyMaterial))
+	assert.NoFileExists(t, keyFile+rotationSuffix)
+
+	// Simulate a crash before the commit
+	require.NoError(t, os.WriteFile(keyFile+rotationSuffix, gen1Key1, 0600))
+	_, err = newTestEncryptedStore(t, newTestEncryptedConf(t, dir, "pass2"))
+	require.NoError(t, err)
+	assert.NoFileExists(t, keyFile+rotationSuffix)
+
+	// Corrupt staged file
+	require.NoError(t, os.WriteFile(keyFile+rotationSuffix, []byte("{}"), 0600))
+	_, err = newTestEncryptedStore(t, newTestEncryptedConf(t, dir, "pass2"))
+	assert.Regexp(t, "PD020834", err)
+}
+
+func TestEncryptedStoreBadConfig(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	conf := newTestEncryptedConf(t, dir, "")
+	conf.MasterPassword.File = path.Join(dir, "missing")
+	_, err := newTestEncryptedStore(t, conf)
+	assert.Regexp(t, "PD020828", err)
+
+	conf = newTestEncryptedConf(t, dir, "")
+	conf.MasterPassword.Env = "TEST_PALADIN_KEYSTORE_PASSWORD"
+	t.Setenv(conf.MasterPassword.Env, "")
+	_, err = newTestEncryptedStore(t, conf)
+	assert.Regexp(t, "PD020828", err)
+
+	err = RotateFilesystemMasterPassword(ctx, conf, []byte{})
+	assert.Regexp(t, "PD020828", err)
+
+	t.Setenv(conf.MasterPassword.Env, "pass1")
+	conf.KDF.Type = "wrong"
+	_, err = newTestEncryptedStore(t, conf)
+	assert.Regexp(t, "PD020829", err)
+
+	conf.KDF.Type = pldconf.FileSystemKDFTypeScrypt
+	conf.KDF.Scrypt.N = confutil.P(3) // not a power of 2
+	_, err = newTestEncryptedStore(t, conf)
+	assert.Regexp(t, "PD020829", err)
+
+	require.NoError(t, os.WriteFile(path.Join(dir, manifestFileName), []byte("!json"), 0600))
+	_, err = newTestEncryptedStore(t, conf)
+	assert.Regexp(t, "PD020830", err)
+
+	require.NoError(t, os.WriteFile(path.Join(dir, manifestFileName), []byte(`{"version":99}`), 0600))
+	_, err = newTestEncryptedStore(t, conf)
+	assert.Regexp(t, "PD020830", err)
+
+	require.NoError(t, os.WriteFile(path.Join(dir, manifestFileName), []byte(`{"version":1,"kdf":{"type":"argon2id"}}`), 0600))
+	_, err = newTestEncryptedStore(t, conf)
+	assert.Regexp(t, "PD020829", err)
+}
+
+func TestEncryptedStoreCorruptKeyFile(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	fs, err := newTestEncryptedStore(t, newTestEncryptedConf(t, dir, "pass1"))
+	require.NoError(t, err)
+	createTestKey(t, fs, "key1")
+	fs.cache.Delete("folder/key1")
+
+	keyFile := path.Join(dir, "_folder", "-key1.key")
+	ekf, err := parseEncryptedKeyFile(ctx, keyFile, mustReadFile(t, keyFile))
+	require.NoError(t, err)
+
+	ekf.Nonce = ekf.Nonce[1:]
+	writeEncryptedKeyFile(t, keyFile, ekf)
+	_, err = fs.LoadKeyMaterial(ctx, "folder/key1")
+	assert.Regexp(t, "PD020834", err)
+
+	ekf.KDF.Type = "wrong"
+	writeEncryptedKeyFile(t, keyFile, ekf)
+	_, err = fs.LoadKeyMaterial(ctx, "folder/key1")
+	assert.Regexp(t, "PD020829", err)
+
+	require.NoError(t, os.Remove(keyFile))
+	require.NoError(t, os.Mkdir(keyFile, 0700))
+	_, err = fs.encryption.readKeyFile(ctx, keyFile, "folder/key1")
+	assert.Regexp(t, "PD020801", err)
+	_, err = fs.encryption.createKeyFile(ctx, keyFile, "folder/key1", func() ([]byte, error) { return []byte{}, nil })
+	assert.Regexp(t, "PD020804", err)
+
+	// Rotation fails on a corrupt file
+	require.NoError(t, os.Remove(keyFile))
+	require.NoError(t, os.WriteFile(keyFile, []byte("{}"), 0600))
+	err = RotateFilesystemMasterPassword(ctx, newTestEncryptedConf(t, dir, "pass1"), []byte("pass2"))
+	assert.Regexp(t, "PD020834", err)
+}
+
+func TestKeyHandleFromFile(t *testing.T) {
+	assert.Equal(t, "a/b/c", keyHandleFromFile("_a/_b/-c.key", ".key"))
+	assert.Equal(t, "c", keyHandleFromFile("-c.key.next", ".key.next"))
+}
+
+func mustReadFile(t *testing.T, filePath string) []byte {
+	data, err := os.ReadFile(filePath)
+	require.NoError(t, err)
+	return data
+}
+
+func writeEncryptedKeyFile(t *testing.T, filePath string, ekf *encryptedKeyFile) {
+	data, err := json.Marshal(ekf)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filePath, data, 0600))
+}
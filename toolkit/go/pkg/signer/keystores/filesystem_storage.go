@@ -38,10 +38,11 @@ import (
 type filesystemStoreFactory[C signerapi.ExtensibleConfig] struct{}
 
 type filesystemStore struct {
-	cache    cache.Cache[string, keystorev3.WalletFile]
-	path     string
-	fileMode os.FileMode
-	dirMode  os.FileMode
+	cache      cache.Cache[string, []byte]
+	path       string
+	fileMode   os.FileMode
+	dirMode    os.FileMode
+	encryption *masterKeyEncryption // nil unless a master password is configured
 }
 
 func NewFilesystemStoreFactory[C signerapi.ExtensibleConfig]() signerapi.KeyStoreFactory[C] {
@@ -60,12 +61,17 @@ func (fsf *filesystemStoreFactory[C]) NewKeyStore(ctx context.Context, eConf C)
 	if err != nil || !pathInfo.IsDir() {
 		return nil, i18n.WrapError(ctx, err, pldmsgs.MsgSigningModuleBadPathError, *pldconf.FileSystemDefaults.Path)
 	}
-	return &filesystemStore{
-		cache:    cache.NewCache[string, keystorev3.WalletFile](&conf.Cache, &pldconf.FileSystemDefaults.Cache),
+	store := &filesystemStore{
+		cache:    cache.NewCache[string, []byte](&conf.Cache, &pldconf.FileSystemDefaults.Cache),
 		fileMode: confutil.UnixFileMode(conf.FileMode, *pldconf.FileSystemDefaults.FileMode),
 		dirMode:  confutil.UnixFileMode(conf.DirMode, *pldconf.FileSystemDefaults.DirMode),
 		path:     path,
-	}, nil
+	}
+	store.encryption, err = newMasterKeyEncryption(ctx, path, conf, store.fileMode)
+	if err != nil {
+		return nil, err
+	}
+	return store, nil
 }
 
 func (fss *filesystemStore) validateFilePathKeyHandle(ctx context.Context, keyHandle string, forCreate bool) (absPath string, err error) {
@@ -135,7 +141,7 @@ func (fss *filesystemStore) createWalletFile(ctx context.Context, keyFilePath, p
 	return wf, nil
 }
 
-func (fss *filesystemStore) getOrCreateWalletFile(ctx context.Context, keyHandle string, newKeyMaterialFactory func() ([]byte, error)) (keystorev3.WalletFile, error) {
+func (fss *filesystemStore) getOrCreateKey(ctx context.Context, keyHandle string, newKeyMaterialFactory func() ([]byte, error)) ([]byte, error) {
 
 	absPathPrefix, err := fss.validateFilePathKeyHandle(ctx, keyHandle, newKeyMaterialFactory != nil)
 	if err != nil {
@@ -151,23 +157,38 @@ func (fss *filesystemStore) getOrCreateWalletFile(ctx context.Context, keyHandle
 
 	_, checkNotExist := os.Stat(keyFilePath)
 	if os.IsNotExist(checkNotExist) {
-		if newKeyMaterialFactory != nil {
-			// We need to create it
-			wf, err := fss.createWalletFile(ctx, keyFilePath, passwordFilePath, newKeyMaterialFactory)
-			if err == nil {
-				fss.cache.Set(keyHandle, wf)
-			}
-			return wf, err
-		} else {
+		if newKeyMaterialFactory == nil {
 			return nil, i18n.NewError(ctx, pldmsgs.MsgSigningModuleKeyNotExist, keyHandle)
 		}
+		// We need to create it
+		var keyMaterial []byte
+		if fss.encryption != nil {
+			keyMaterial, err = fss.encryption.createKeyFile(ctx, keyFilePath, keyHandle, newKeyMaterialFactory)
+		} else {
+			var wf keystorev3.WalletFile
+			if wf, err = fss.createWalletFile(ctx, keyFilePath, passwordFilePath, newKeyMaterialFactory); err == nil {
+				keyMaterial = wf.PrivateKey()
+			}
+		}
+		if err == nil {
+			fss.cache.Set(keyHandle, keyMaterial)
+		}
+		return keyMaterial, err
 	}
 	// we need to read it
-	wf, err := fss.readWalletFile(ctx, keyFilePath, passwordFilePath)
+	var keyMaterial []byte
+	if fss.encryption != nil {
+		keyMaterial, err = fss.encryption.readKeyFile(ctx, keyFilePath, keyHandle)
+	} else {
+		var wf keystorev3.WalletFile
+		if wf, err = fss.readWalletFile(ctx, keyFilePath, passwordFilePath); err == nil {
+			keyMaterial = wf.PrivateKey()
+		}
+	}
 	if err == nil {
-		fss.cache.Set(keyHandle, wf)
+		fss.cache.Set(keyHandle, keyMaterial)
 	}
-	return wf, err
+	return keyMaterial, err
 }
 
 func (fss *filesystemStore) readWalletFile(ctx context.Context, keyFilePath, passwordFilePath string) (keystorev3.WalletFile, error) {
@@ -197,19 +218,15 @@ func (fss *filesystemStore) FindOrCreateLoadableKey(ctx context.Context, req *si
 		return nil, "", i18n.NewError(ctx, pldmsgs.MsgSigningModuleBadKeyHandle)
 	}
 	keyHandle += url.PathEscape(req.Name)
-	wf, err := fss.getOrCreateWalletFile(ctx, keyHandle, newKeyMaterial)
+	keyMaterial, err = fss.getOrCreateKey(ctx, keyHandle, newKeyMaterial)
 	if err != nil {
 		return nil, "", err
 	}
-	return wf.PrivateKey(), keyHandle, nil
+	return keyMaterial, keyHandle, nil
 }
 
 func (fss *filesystemStore) LoadKeyMaterial(ctx context.Context, keyHandle string) ([]byte, error) {
-	wf, err := fss.getOrCreateWalletFile(ctx, keyHandle, nil)
-	if err != nil {
-		return nil, err
-	}
-	return wf.PrivateKey(), nil
+	return fss.getOrCreateKey(ctx, keyHandle, nil)
 }
 
 func (fss *filesystemStore) Close() {
@@ -18,8 +18,10 @@ package signers
 import (
 	"context"
 	"encoding/hex"
+	"encoding/json"
 	"strings"
 
+	"github.com/hyperledger/firefly-signer/pkg/eip712"
 	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
 	"github.com/hyperledger/firefly-signer/pkg/secp256k1"
 	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
@@ -68,6 +70,20 @@ func (s *ecdsaSigner) Sign_secp256k1(ctx context.Context, algorithm, payloadType
 			return nil, err
 		}
 		return sig.CompactRSV(), nil
+	case signpayloads.EIP712_TO_RSV:
+		var typedData eip712.TypedData
+		if err := json.Unmarshal(payload, &typedData); err != nil {
+			return nil, i18n.WrapError(ctx, err, pldmsgs.MsgSigningInvalidTypedData)
+		}
+		hash, err := eip712.EncodeTypedDataV4(ctx, &typedData)
+		if err != nil {
+			return nil, i18n.WrapError(ctx, err, pldmsgs.MsgSigningInvalidTypedData)
+		}
+		sig, err := kp.SignDirect(hash)
+		if err != nil {
+			return nil, err
+		}
+		return sig.CompactRSV(), nil
 	default:
 		return nil, i18n.NewError(ctx, pldmsgs.MsgSigningUnsupportedPayloadCombination, payloadType, algorithm)
 	}
@@ -118,3 +118,56 @@ func TestECDSAVerifiers_secp256k1(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, pubKey, verifier)
 }
+
+func TestECDSASigningEIP712_secp256k1(t *testing.T) {
+	// The example from the EIP-712 specification
+	privKey := ethtypes.MustNewHexBytes0xPrefix(
+		"c85ef7d79691fe79573b1a7064c19c1a9819ebdbd1faaab1a8ec92344438aaf4")
+	ctx, signer, kp := newTestSigner(t, privKey)
+	require.Equal(t, "0xcd2a3d9f938e13cd947ec05abc7fe734df8dd826", kp.Address.String())
+
+	typedData := `{
+		"types": {
+			"EIP712Domain": [
+				{"name": "name", "type": "string"},
+				{"name": "version", "type": "string"},
+				{"name": "chainId", "type": "uint256"},
+				{"name": "verifyingContract", "type": "address"}
+			],
+			"Person": [
+				{"name": "name", "type": "string"},
+				{"name": "wallet", "type": "address"}
+			],
+			"Mail": [
+				{"name": "from", "type": "Person"},
+				{"name": "to", "type": "Person"},
+				{"name": "contents", "type": "string"}
+			]
+		},
+		"primaryType": "Mail",
+		"domain": {
+			"name": "Ether Mail",
+			"version": "1",
+			"chainId": 1,
+			"verifyingContract": "0xCcCCccccCCCCcCCCCCCcCcCccCcCCCcCcccccccC"
+		},
+		"message": {
+			"from": {"name": "Cow", "wallet": "0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826"},
+			"to": {"name": "Bob", "wallet": "0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB"},
+			"contents": "Hello, Bob!"
+		}
+	}`
+
+	signatureRSV, err := signer.Sign(ctx, algorithms.ECDSA_SECP256K1, signpayloads.EIP712_TO_RSV, kp.PrivateKeyBytes(), []byte(typedData))
+	require.NoError(t, err)
+	assert.Equal(t, "0x"+
+		"4355c47d63924e8a72e509b65029052eb6c299d53a04e167c5775fd466751c9d"+
+		"07299936d304c153f6443dfa05f40ff007d72911b6f72307f996231605b91562"+
+		"1c", pldtypes.HexBytes(signatureRSV).String())
+
+	_, err = signer.Sign(ctx, algorithms.ECDSA_SECP256K1, signpayloads.EIP712_TO_RSV, kp.PrivateKeyBytes(), []byte(`{!!!`))
+	assert.Regexp(t, "PD020836", err)
+
+	_, err = signer.Sign(ctx, algorithms.ECDSA_SECP256K1, signpayloads.EIP712_TO_RSV, kp.PrivateKeyBytes(), []byte(`{}`))
+	assert.Regexp(t, "PD020836", err)
+}
@@ -19,10 +19,12 @@ import (
 	"context"
 	"crypto/rand"
 	"strings"
+	"time"
 
 	"github.com/btcsuite/btcd/btcutil/hdkeychain"
 	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
 	"github.com/kaleido-io/paladin/common/go/pkg/pldmsgs"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
 	"github.com/kaleido-io/paladin/config/pkg/pldconf"
 	"github.com/kaleido-io/paladin/toolkit/pkg/algorithms"
 	"github.com/kaleido-io/paladin/toolkit/pkg/signer/keystores"
@@ -53,6 +55,9 @@ type hdDerivation[C signerapi.ExtensibleConfig] struct {
 type signingModule[C signerapi.ExtensibleConfig] struct {
 	keyStore               signerapi.KeyStore
 	keyStoreSigner         signerapi.KeyStoreSigner
+	keyStoreAsyncSigner    signerapi.KeyStoreAsyncSigner
+	sessionPollInterval    time.Duration
+	sessionTimeout         time.Duration
 	disableKeyListing      bool
 	hd                     *hdDerivation[C]
 	signingImplementations map[string]signerapi.InMemorySigner
@@ -123,11 +128,16 @@ func NewSigningModule[C signerapi.ExtensibleConfig](ctx context.Context, conf C,
 	// Check if we'be been asked to delegate signing directly to the key storage system
 	// (disabling ALL in memory signing modules)
 	if ksConf.KeyStoreSigning {
-		var supportsSigning bool
+		var supportsSigning, supportsAsyncSigning bool
 		sm.keyStoreSigner, supportsSigning = sm.keyStore.(signerapi.KeyStoreSigner)
 		if !supportsSigning {
+			sm.keyStoreAsyncSigner, supportsAsyncSigning = sm.keyStore.(signerapi.KeyStoreAsyncSigner)
+		}
+		if !supportsSigning && !supportsAsyncSigning {
 			return nil, i18n.NewError(ctx, pldmsgs.MsgSigningKeyStoreNoInStoreSingingSupport, ksConf.Type)
 		}
+		sm.sessionPollInterval = confutil.DurationMin(ksConf.SigningSession.PollInterval, 1*time.Millisecond, *pldconf.SigningSessionDefaults.PollInterval)
+		sm.sessionTimeout = confutil.DurationMin(ksConf.SigningSession.Timeout, 0, *pldconf.SigningSessionDefaults.Timeout)
 	}
 
 	kdConf := conf.KeyDerivationConfig()
@@ -214,6 +224,9 @@ func (sm *signingModule[C]) Resolve(ctx context.Context, req *signerapi.ResolveK
 	if sm.keyStoreSigner != nil {
 		return sm.keyStoreSigner.FindOrCreateInStoreSigningKey(ctx, req)
 	}
+	if sm.keyStoreAsyncSigner != nil {
+		return sm.keyStoreAsyncSigner.FindOrCreateInStoreSigningKey(ctx, req)
+	}
 	// If we have HD wallet derivation, then that is where we do the resolution
 	if sm.hd != nil {
 		return sm.hd.resolveHDWalletKey(ctx, req)
@@ -256,6 +269,9 @@ func (sm *signingModule[C]) Sign(ctx context.Context, req *signerapi.SignRequest
 	if sm.keyStoreSigner != nil {
 		return sm.keyStoreSigner.SignWithinKeystore(ctx, req)
 	}
+	if sm.keyStoreAsyncSigner != nil {
+		return sm.signWithSession(ctx, req)
+	}
 	// If we have HD wallet derivation, then that is where we do the signing
 	if sm.hd != nil {
 		return sm.hd.signHDWalletKey(ctx, req)
@@ -0,0 +1,71 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package signer
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/common/go/pkg/pldmsgs"
+	"github.com/kaleido-io/paladin/toolkit/pkg/signerapi"
+)
+
+// The session ID is a hash of everything in the request, so that a retry of the same request (including one
+// made after a restart of the runtime) attaches to the session that is already in progress with the provider.
+func signingSessionID(req *signerapi.SignRequest) string {
+	h := sha256.New()
+	for _, s := range []string{req.KeyHandle, req.Algorithm, req.PayloadType} {
+		h.Write([]byte(s))
+		h.Write([]byte{0})
+	}
+	h.Write(req.Payload)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func (sm *signingModule[C]) signWithSession(ctx context.Context, req *signerapi.SignRequest) (*signerapi.SignResponse, error) {
+	sessionID := signingSessionID(req)
+	startTime := time.Now()
+	session, err := sm.keyStoreAsyncSigner.StartSigningSession(ctx, &signerapi.StartSigningSessionRequest{
+		SessionID:   sessionID,
+		SignRequest: req,
+	})
+	for err == nil {
+		switch session.Status {
+		case signerapi.SigningSessionComplete:
+			log.L(ctx).Debugf("Signing session %s complete after %s", sessionID, time.Since(startTime))
+			return &signerapi.SignResponse{Payload: session.Payload}, nil
+		case signerapi.SigningSessionFailed:
+			return nil, i18n.NewError(ctx, pldmsgs.MsgSigningSessionFailed, sessionID, session.Error)
+		case signerapi.SigningSessionPending:
+		default:
+			return nil, i18n.NewError(ctx, pldmsgs.MsgSigningSessionInvalidStatus, sessionID, session.Status)
+		}
+		if time.Since(startTime) > sm.sessionTimeout {
+			return nil, i18n.NewError(ctx, pldmsgs.MsgSigningSessionTimeout, sessionID, sm.sessionTimeout)
+		}
+		select {
+		case <-time.After(sm.sessionPollInterval):
+		case <-ctx.Done():
+			return nil, i18n.NewError(ctx, pldmsgs.MsgContextCanceled)
+		}
+		session, err = sm.keyStoreAsyncSigner.GetSigningSession(ctx, sessionID)
+	}
+	return nil, err
+}
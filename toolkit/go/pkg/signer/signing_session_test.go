@@ -0,0 +1,181 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package signer
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/toolkit/pkg/algorithms"
+	"github.com/kaleido-io/paladin/toolkit/pkg/signerapi"
+	"github.com/kaleido-io/paladin/toolkit/pkg/signpayloads"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testKeyStoreAsync struct {
+	testKeyStoreBase
+	findOrCreateInStoreSigningKey func(ctx context.Context, req *signerapi.ResolveKeyRequest) (res *signerapi.ResolveKeyResponse, err error)
+	startSigningSession           func(ctx context.Context, req *signerapi.StartSigningSessionRequest) (*signerapi.SigningSession, error)
+	getSigningSession             func(ctx context.Context, sessionID string) (*signerapi.SigningSession, error)
+}
+
+func (tk *testKeyStoreAsync) FindOrCreateInStoreSigningKey(ctx context.Context, req *signerapi.ResolveKeyRequest) (res *signerapi.ResolveKeyResponse, err error) {
+	return tk.findOrCreateInStoreSigningKey(ctx, req)
+}
+
+func (tk *testKeyStoreAsync) StartSigningSession(ctx context.Context, req *signerapi.StartSigningSessionRequest) (*signerapi.SigningSession, error) {
+	return tk.startSigningSession(ctx, req)
+}
+
+func (tk *testKeyStoreAsync) GetSigningSession(ctx context.Context, sessionID string) (*signerapi.SigningSession, error) {
+	return tk.getSigningSession(ctx, sessionID)
+}
+
+type testKeyStoreAsyncFactory struct {
+	keyStore *testKeyStoreAsync
+}
+
+func (tf *testKeyStoreAsyncFactory) NewKeyStore(ctx context.Context, conf *signerapi.ConfigNoExt) (signerapi.KeyStore, error) {
+	return tf.keyStore, nil
+}
+
+func newTestSessionSigningModule(t *testing.T, ks *testKeyStoreAsync, sessionConf pldconf.SigningSessionConfig) SigningModule {
+	sm, err := NewSigningModule(context.Background(), &signerapi.ConfigNoExt{
+		KeyStore: pldconf.KeyStoreConfig{
+			Type:            "mpc",
+			KeyStoreSigning: true,
+			SigningSession:  sessionConf,
+		},
+	}, &signerapi.Extensions[*signerapi.ConfigNoExt]{
+		KeyStoreFactories: map[string]signerapi.KeyStoreFactory[*signerapi.ConfigNoExt]{
+			"mpc": &testKeyStoreAsyncFactory{keyStore: ks},
+		},
+	})
+	require.NoError(t, err)
+	return sm
+}
+
+func testSignRequest() *signerapi.SignRequest {
+	return &signerapi.SignRequest{
+		KeyHandle:   "key1",
+		Algorithm:   algorithms.ECDSA_SECP256K1,
+		PayloadType: signpayloads.OPAQUE_TO_RSV,
+		Payload:     []byte("some data"),
+	}
+}
+
+func TestSigningSessionResumedAfterRestart(t *testing.T) {
+	ctx := context.Background()
+
+	// A provider that takes a few polls to complete each session, and remembers its sessions
+	sessions := map[string]*signerapi.SigningSession{}
+	polls := map[string]int{}
+	starts := 0
+	ks := &testKeyStoreAsync{
+		findOrCreateInStoreSigningKey: func(ctx context.Context, req *signerapi.ResolveKeyRequest) (*signerapi.ResolveKeyResponse, error) {
+			return &signerapi.ResolveKeyResponse{KeyHandle: "key1"}, nil
+		},
+		startSigningSession: func(ctx context.Context, req *signerapi.StartSigningSessionRequest) (*signerapi.SigningSession, error) {
+			if s := sessions[req.SessionID]; s != nil {
+				return s, nil
+			}
+			starts++
+			sessions[req.SessionID] = &signerapi.SigningSession{SessionID: req.SessionID, Status: signerapi.SigningSessionPending}
+			return sessions[req.SessionID], nil
+		},
+		getSigningSession: func(ctx context.Context, sessionID string) (*signerapi.SigningSession, error) {
+			polls[sessionID]++
+			if polls[sessionID] == 3 {
+				sessions[sessionID].Status = signerapi.SigningSessionComplete
+				sessions[sessionID].Payload = pldtypes.HexBytes("signature")
+			}
+			return sessions[sessionID], nil
+		},
+	}
+
+	// First module gives up waiting before the session completes, like a runtime that is stopped mid-session
+	sm := newTestSessionSigningModule(t, ks, pldconf.SigningSessionConfig{
+		PollInterval: confutil.P("1ms"),
+		Timeout:      confutil.P("0"),
+	})
+	res, err := sm.Resolve(ctx, &signerapi.ResolveKeyRequest{Name: "key1"})
+	require.NoError(t, err)
+	assert.Equal(t, "key1", res.KeyHandle)
+	_, err = sm.Sign(ctx, testSignRequest())
+	assert.Regexp(t, "PD020838", err)
+
+	// A new module attaches to the same session, rather than starting a new one
+	sm = newTestSessionSigningModule(t, ks, pldconf.SigningSessionConfig{
+		PollInterval: confutil.P("1ms"),
+	})
+	sig, err := sm.Sign(ctx, testSignRequest())
+	require.NoError(t, err)
+	assert.Equal(t, "signature", string(sig.Payload))
+	assert.Equal(t, 1, starts)
+
+	// A different payload is a different session
+	req := testSignRequest()
+	req.Payload = []byte("other data")
+	_, err = sm.Sign(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, 2, starts)
+	assert.Len(t, sessions, 2)
+}
+
+func TestSigningSessionErrors(t *testing.T) {
+	ctx := context.Background()
+
+	ks := &testKeyStoreAsync{
+		startSigningSession: func(ctx context.Context, req *signerapi.StartSigningSessionRequest) (*signerapi.SigningSession, error) {
+			return nil, fmt.Errorf("pop")
+		},
+	}
+	sm := newTestSessionSigningModule(t, ks, pldconf.SigningSessionConfig{PollInterval: confutil.P("1ms")})
+	_, err := sm.Sign(ctx, testSignRequest())
+	assert.Regexp(t, "pop", err)
+
+	ks.startSigningSession = func(ctx context.Context, req *signerapi.StartSigningSessionRequest) (*signerapi.SigningSession, error) {
+		return &signerapi.SigningSession{SessionID: req.SessionID, Status: signerapi.SigningSessionFailed, Error: "rejected by policy"}, nil
+	}
+	_, err = sm.Sign(ctx, testSignRequest())
+	assert.Regexp(t, "PD020837.*rejected by policy", err)
+
+	ks.startSigningSession = func(ctx context.Context, req *signerapi.StartSigningSessionRequest) (*signerapi.SigningSession, error) {
+		return &signerapi.SigningSession{SessionID: req.SessionID, Status: "unknown"}, nil
+	}
+	_, err = sm.Sign(ctx, testSignRequest())
+	assert.Regexp(t, "PD020839", err)
+
+	ks.startSigningSession = func(ctx context.Context, req *signerapi.StartSigningSessionRequest) (*signerapi.SigningSession, error) {
+		return &signerapi.SigningSession{SessionID: req.SessionID, Status: signerapi.SigningSessionPending}, nil
+	}
+	ks.getSigningSession = func(ctx context.Context, sessionID string) (*signerapi.SigningSession, error) {
+		return nil, fmt.Errorf("pop")
+	}
+	_, err = sm.Sign(ctx, testSignRequest())
+	assert.Regexp(t, "pop", err)
+
+	cancelled, cancelCtx := context.WithCancel(ctx)
+	cancelCtx()
+	sm = newTestSessionSigningModule(t, ks, pldconf.SigningSessionConfig{PollInterval: confutil.P("1h")})
+	_, err = sm.Sign(cancelled, testSignRequest())
+	assert.Regexp(t, "PD020000", err)
+}
@@ -71,3 +71,22 @@ type KeyStoreSigner interface {
 	FindOrCreateInStoreSigningKey(ctx context.Context, req *ResolveKeyRequest) (res *ResolveKeyResponse, err error)
 	SignWithinKeystore(ctx context.Context, req *SignRequest) (res *SignResponse, err error)
 }
+
+// Multi-party computation (MPC) and threshold signature scheme (TSS) providers do not hold a whole key
+// in any one place. A signature is instead the result of a protocol run between the parties holding the
+// key shares, which can take seconds to complete (or longer if an approval policy is involved).
+//
+// Key stores backed by such a provider implement this interface in place of KeyStoreSigner, and are
+// enabled in the same way by configuring keyStoreSigning. The signing module starts a session for each
+// sign request, and then polls it until it is complete.
+//
+// The session ID is derived deterministically from the sign request. If the Paladin runtime restarts
+// while a session is in progress, the retried request for the same payload arrives with the same session
+// ID, and must attach to that session rather than starting another. So StartSigningSession must return
+// the existing session for a session ID that is pending or complete, and only start a new attempt if the
+// previous attempt for that session ID failed.
+type KeyStoreAsyncSigner interface {
+	FindOrCreateInStoreSigningKey(ctx context.Context, req *ResolveKeyRequest) (res *ResolveKeyResponse, err error)
+	StartSigningSession(ctx context.Context, req *StartSigningSessionRequest) (session *SigningSession, err error)
+	GetSigningSession(ctx context.Context, sessionID string) (session *SigningSession, err error)
+}
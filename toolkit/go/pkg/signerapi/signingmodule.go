@@ -63,6 +63,36 @@ type SignResponse struct {
 	Payload pldtypes.HexBytes `json:"payload,omitempty"`
 }
 
+type SigningSessionStatus string
+
+const (
+	SigningSessionPending  SigningSessionStatus = "pending"
+	SigningSessionComplete SigningSessionStatus = "complete"
+	SigningSessionFailed   SigningSessionStatus = "failed"
+)
+
+type StartSigningSessionRequest struct {
+	// deterministically derived by the signing module from the sign request, so the same request always maps to the same session
+	SessionID string `json:"sessionId,omitempty"`
+
+	// the sign request that is being fulfilled by the session
+	*SignRequest `json:",inline"`
+}
+
+type SigningSession struct {
+	// the ID supplied when the session was started
+	SessionID string `json:"sessionId,omitempty"`
+
+	// progress of the session - the signing module continues to poll until the session is complete or failed
+	Status SigningSessionStatus `json:"status,omitempty"`
+
+	// the signature, in the same format as the payload of a SignResponse, once the session is complete
+	Payload pldtypes.HexBytes `json:"payload,omitempty"`
+
+	// a description of the failure, if the session failed
+	Error string `json:"error,omitempty"`
+}
+
 type ListKeysRequest struct {
 	// the maximum number of records to return
 	Limit int `json:"limit,omitempty"`
@@ -23,3 +23,12 @@ package signpayloads
 // according to the Bitcoin/Eth standard of 27+recid (27 or 28)
 // denoting an uncompressed public key.
 const OPAQUE_TO_RSV = "opaque:rsv"
+
+// Input:
+// A JSON encoded EIP-712 typed data payload (types, primaryType, domain and message),
+// per the eth_signTypedData_v4 specification. The signing module performs the EIP-712
+// encoding and hashing, so that signers that are able to display or police structured
+// data can inspect what they are being asked to sign.
+// Output:
+// A compact 65 byte encoded R,S,V byte string, as per OPAQUE_TO_RSV.
+const EIP712_TO_RSV = "eip712:rsv"
@@ -46,6 +46,11 @@
      protected abstract CompletableFuture<InitPrivacyGroupResponse> initPrivacyGroup(InitPrivacyGroupRequest request);
      protected abstract CompletableFuture<WrapPrivacyGroupEVMTXResponse> wrapPrivacyGroupTransaction(WrapPrivacyGroupEVMTXRequest request);
 
+     // Only called for methods the domain declares in rpc_methods on its DomainConfig
+     protected CompletableFuture<HandleRPCResponse> handleRPC(HandleRPCRequest request) {
+         return CompletableFuture.failedFuture(new UnsupportedOperationException("unsupported RPC method: %s".formatted(request.getMethod())));
+     }
+
      protected DomainInstance(String grpcTarget, String instanceId) {
          super(grpcTarget, instanceId);
      }
@@ -81,6 +86,14 @@
                  build();
          return requestReply(message).thenApply(DomainMessage::getRecoverSignerRes);
      }
+
+     public CompletableFuture<CheckCredentialResponse> checkCredential(CheckCredentialRequest request) {
+         DomainMessage message = DomainMessage.newBuilder().
+                 setHeader(newRequestHeader()).
+                 setCheckCredential(request).
+                 build();
+         return requestReply(message).thenApply(DomainMessage::getCheckCredentialRes);
+     }
  
      @Override
      final StreamObserver<DomainMessage> connect(StreamObserver<DomainMessage> observer) {
@@ -123,6 +136,9 @@
                  case CONFIGURE_PRIVACY_GROUP -> configurePrivacyGroup(request.getConfigurePrivacyGroup()).thenApply(response::setConfigurePrivacyGroupRes);
                  case INIT_PRIVACY_GROUP -> initPrivacyGroup(request.getInitPrivacyGroup()).thenApply(response::setInitPrivacyGroupRes);
                  case WRAP_PRIVACY_GROUP_EVMTX -> wrapPrivacyGroupTransaction(request.getWrapPrivacyGroupEvmtx()).thenApply(response::setWrapPrivacyGroupEvmtxRes);
+                 case HANDLE_RPC -> handleRPC(request.getHandleRpc()).thenApply(response::setHandleRpcRes);
+                 // A request with no body is a ping from the manager, to check we are still responsive
+                 case REQUESTTODOMAIN_NOT_SET -> CompletableFuture.completedFuture(null);
                  default -> throw new IllegalArgumentException("unknown request: %s".formatted(request.getRequestToDomainCase()));
              };
              return resultApplied.thenApply((ra) -> {
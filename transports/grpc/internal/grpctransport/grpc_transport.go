@@ -189,16 +189,25 @@ func (t *grpcTransport) ConnectSendStream(stream grpc.ClientStreamingServer[prot
 			msg.MessageId, msg.CorrelationId, msg.Component, msg.MessageType, ai.verifiedNodeName)
 
 		// Deliver it to Paladin
-		_, err = t.callbacks.ReceiveMessage(ctx, &prototk.ReceiveMessageRequest{
+		req := &prototk.ReceiveMessageRequest{
 			FromNode: ai.verifiedNodeName,
 			Message: &prototk.PaladinMsg{
-				MessageId:     msg.MessageId,
-				CorrelationId: msg.CorrelationId,
-				Component:     prototk.PaladinMsg_Component(msg.Component),
-				MessageType:   msg.MessageType,
-				Payload:       msg.Payload,
+				MessageId:       msg.MessageId,
+				CorrelationId:   msg.CorrelationId,
+				Component:       prototk.PaladinMsg_Component(msg.Component),
+				MessageType:     msg.MessageType,
+				Payload:         msg.Payload,
+				PayloadEncoding: prototk.PaladinMsg_PayloadEncoding(msg.PayloadEncoding),
 			},
-		})
+		}
+		if msg.Chunk != nil {
+			req.Message.Chunk = &prototk.PaladinMsg_Chunk{
+				Index:     msg.Chunk.Index,
+				Count:     msg.Chunk.Count,
+				TotalSize: msg.Chunk.TotalSize,
+			}
+		}
+		_, err = t.callbacks.ReceiveMessage(ctx, req)
 		if err != nil {
 			msgBytes, _ := protojson.Marshal(msg)
 			log.L(ctx).Errorf("Receive failed (err=%s): %s", err, msgBytes)
@@ -277,13 +286,22 @@ func (t *grpcTransport) SendMessage(ctx context.Context, req *prototk.SendMessag
 	}
 	log.L(ctx).Infof("GRPC sending message id=%s cid=%v component=%s messageType=%s to peer %s",
 		msg.MessageId, msg.CorrelationId, msg.Component, msg.MessageType, req.Node)
-	err := oc.send(&proto.Message{
-		MessageId:     msg.MessageId,
-		CorrelationId: msg.CorrelationId,
-		Component:     int32(msg.Component),
-		MessageType:   msg.MessageType,
-		Payload:       msg.Payload,
-	})
+	sendMsg := &proto.Message{
+		MessageId:       msg.MessageId,
+		CorrelationId:   msg.CorrelationId,
+		Component:       int32(msg.Component),
+		MessageType:     msg.MessageType,
+		Payload:         msg.Payload,
+		PayloadEncoding: int32(msg.PayloadEncoding),
+	}
+	if msg.Chunk != nil {
+		sendMsg.Chunk = &proto.Chunk{
+			Index:     msg.Chunk.Index,
+			Count:     msg.Chunk.Count,
+			TotalSize: msg.Chunk.TotalSize,
+		}
+	}
+	err := oc.send(sendMsg)
 	if err != nil {
 		return nil, err
 	}
@@ -327,17 +327,25 @@ func TestGRPCTransport_CACertVerificationWithSubjectRegex_OK(t *testing.T) {
 	// Connect and send from plugin1 to plugin2
 	deactivate := testActivatePeer(t, plugin1, "node2", transportDetails2)
 	defer deactivate()
+	chunk := &prototk.PaladinMsg_Chunk{Index: 1, Count: 3, TotalSize: 12345}
 	sendRes, err := plugin1.SendMessage(ctx, &prototk.SendMessageRequest{
 		Node: "node2",
 		Message: &prototk.PaladinMsg{
-			Component: prototk.PaladinMsg_TRANSACTION_ENGINE,
+			Component:       prototk.PaladinMsg_TRANSACTION_ENGINE,
+			PayloadEncoding: prototk.PaladinMsg_GZIP,
+			Chunk:           chunk,
 		},
 	})
 	assert.NoError(t, err)
 	assert.NotNil(t, sendRes)
 
 	if err == nil {
-		<-received
+		// Chunking and compression are applied by Paladin, and passed through unchanged by the transport
+		msg := <-received
+		assert.Equal(t, prototk.PaladinMsg_GZIP, msg.PayloadEncoding)
+		assert.Equal(t, chunk.Index, msg.Chunk.Index)
+		assert.Equal(t, chunk.Count, msg.Chunk.Count)
+		assert.Equal(t, chunk.TotalSize, msg.Chunk.TotalSize)
 	}
 
 }